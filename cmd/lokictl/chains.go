@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// newChainsCommand creates the commands listing and executing execution chains
+func newChainsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "chains",
+		Aliases: []string{"chain"},
+		Short:   "List and execute execution chains",
+	}
+	cmd.AddCommand(
+		newChainsListCommand(opts),
+		newChainsExecuteCommand(opts),
+		newChainsRunCommand(opts),
+	)
+	return cmd
+}
+
+// newChainsListCommand lists the tenant's chains with GET /api/v1/execution-chains
+func newChainsListCommand(opts *options) *cobra.Command {
+	var page, limit int
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the tenant's execution chains",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			query := url.Values{
+				"tenant_id": {tenantID},
+				"page":      {strconv.Itoa(page)},
+				"limit":     {strconv.Itoa(limit)},
+			}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodGet, "/api/v1/execution-chains", query, nil)
+			return printResponse(cmd, body, err)
+		},
+	}
+	cmd.Flags().IntVar(&page, "page", 1, "Page to list, from 1")
+	cmd.Flags().IntVar(&limit, "limit", 10, "Chains per page")
+	return cmd
+}
+
+// newChainsExecuteCommand starts a run with POST /api/v1/execution-chains/:id/execute
+func newChainsExecuteCommand(opts *options) *cobra.Command {
+	var (
+		req      models.ExecuteChainBody
+		data     string
+		dryRun   bool
+		validate bool
+	)
+	cmd := &cobra.Command{
+		Use:   "execute <chain-id>",
+		Short: "Execute a chain, or simulate it with --dry-run",
+		Example: "  lokictl chains execute 6f1c2d3e-... --data '{\"order_id\": \"A-1001\"}'\n" +
+			"  lokictl chains execute 6f1c2d3e-... --data @order.json --dry-run",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if data != "" {
+				if err := readJSONArg(data, &req.TriggerData); err != nil {
+					return err
+				}
+			}
+			if dryRun || validate {
+				req.Options = &models.ChainExecutionOptions{DryRun: dryRun, ValidationOnly: validate}
+			}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodPost, "/api/v1/execution-chains/"+url.PathEscape(args[0])+"/execute", nil, &req)
+			return printResponse(cmd, body, err)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&data, "data", "", "Trigger data as JSON, @file or - for standard input")
+	flags.StringVar(&req.CallbackURL, "callback", "", "URL receiving the run's summary once it finishes")
+	flags.StringVar((*string)(&req.Priority), "priority", "", "Priority of the run: high, normal or low")
+	flags.BoolVar(&dryRun, "dry-run", false, "Simulate the run: render templates and evaluate conditions without calling webhooks")
+	flags.BoolVar(&validate, "validate", false, "Only check the chain's webhooks and template syntax")
+	return cmd
+}
+
+// newChainsRunCommand shows a run with GET /api/v1/execution-chains/runs/:runId
+func newChainsRunCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "run <run-id>",
+		Short: "Show the status and step results of a run",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := opts.client(true).call(cmd.Context(), http.MethodGet, "/api/v1/execution-chains/runs/"+url.PathEscape(args[0]), nil, nil)
+			return printResponse(cmd, body, err)
+		},
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// Content types of request bodies
+const (
+	contentTypeJSON = "application/json"
+	contentTypeYAML = "application/yaml"
+)
+
+// apiClient sends requests to the management API with the configured credentials
+type apiClient struct {
+	server string
+	apiKey string
+	token  string
+	http   *http.Client
+}
+
+// client creates an API client; streaming requests pass a zero timeout
+func (o *options) client(timeout bool) *apiClient {
+	httpClient := &http.Client{}
+	if timeout {
+		httpClient.Timeout = o.timeout
+	}
+	return &apiClient{
+		server: strings.TrimSuffix(o.server, "/"),
+		apiKey: o.apiKey,
+		token:  o.token,
+		http:   httpClient,
+	}
+}
+
+// requireTenant returns the tenant commands act on, failing when none is configured
+func (o *options) requireTenant() (string, error) {
+	if o.tenant == "" {
+		return "", errors.New("no tenant: set --tenant or LOKI_TENANT")
+	}
+	return o.tenant, nil
+}
+
+// apiError is an unsuccessful response of the API
+type apiError struct {
+	Status int
+
+	// Code and Message are the code and detail of problem details; both are empty for other bodies,
+	// such as the plan of an import or manifest with invalid entries
+	Code    string
+	Message string
+
+	// Body is the response body
+	Body []byte
+}
+
+func (e *apiError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("API returned status %d", e.Status)
+	}
+	return fmt.Sprintf("API returned status %d: %s: %s", e.Status, e.Code, e.Message)
+}
+
+// newAPIError reads an unsuccessful response, whose body is problem details for errors the API answered
+func newAPIError(resp *http.Response) *apiError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	apiErr := &apiError{Status: resp.StatusCode, Body: body}
+	var problem models.Problem
+	if json.Unmarshal(body, &problem) == nil && problem.Code != "" {
+		apiErr.Code = problem.Code
+		apiErr.Message = problem.Detail
+		if apiErr.Message == "" {
+			apiErr.Message = problem.Title
+		}
+	}
+	return apiErr
+}
+
+// open sends a request and returns the response when it is successful; unsuccessful ones are returned as
+// *apiError. The caller closes the body
+func (c *apiClient) open(ctx context.Context, method, path string, query url.Values, body []byte, contentType string) (*http.Response, error) {
+	target := c.server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", c.server, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+	return resp, nil
+}
+
+// do sends a request and returns the body of a successful response
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, body []byte, contentType string) ([]byte, error) {
+	resp, err := c.open(ctx, method, path, query, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return data, nil
+}
+
+// call sends v as a JSON body, or no body when v is nil, and returns the body of a successful response
+func (c *apiClient) call(ctx context.Context, method, path string, query url.Values, v interface{}) ([]byte, error) {
+	if v == nil {
+		return c.do(ctx, method, path, query, nil, "")
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	return c.do(ctx, method, path, query, body, contentTypeJSON)
+}
+
+// printResponse prints the outcome of a request: the body of a successful response, indented when it is
+// JSON, or the body of an unsuccessful one that is not an error response, such as a rejected plan
+func printResponse(cmd *cobra.Command, body []byte, err error) error {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Code == "" && len(apiErr.Body) > 0 {
+		writeBody(cmd.OutOrStdout(), apiErr.Body)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	writeBody(cmd.OutOrStdout(), body)
+	return nil
+}
+
+// writeBody writes a response body, indenting JSON
+func writeBody(w io.Writer, body []byte) {
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		body = indented.Bytes()
+	}
+	w.Write(body)
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		fmt.Fprintln(w)
+	}
+}
+
+// readJSONArg parses a JSON flag value: inline JSON, @path to read it from a file, or - for standard input
+func readJSONArg(value string, v interface{}) error {
+	data := []byte(value)
+	switch {
+	case value == "-":
+		read, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read standard input: %w", err)
+		}
+		data = read
+	case strings.HasPrefix(value, "@"):
+		read, err := os.ReadFile(value[1:])
+		if err != nil {
+			return err
+		}
+		data = read
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// readDocument reads a JSON or YAML document from a file, or standard input for -, and returns it with
+// its content type: YAML for .yaml and .yml files, or when it does not start like JSON
+func readDocument(path string) ([]byte, string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		return data, contentTypeYAML, nil
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return data, contentTypeJSON, nil
+	}
+	return data, contentTypeYAML, nil
+}
+
+// boolQuery sets a boolean query parameter when it is true
+func boolQuery(query url.Values, name string, value bool) {
+	if value {
+		query.Set(name, "true")
+	}
+}
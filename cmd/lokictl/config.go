@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand creates the commands moving webhook configuration between environments
+func newConfigCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Export, import and declaratively apply webhook configuration",
+	}
+	cmd.AddCommand(
+		newConfigExportCommand(opts),
+		newConfigImportCommand(opts),
+		newConfigApplyCommand(opts),
+	)
+	return cmd
+}
+
+// newConfigExportCommand exports the tenant's subscriptions with GET /api/v1/webhooks/export
+func newConfigExportCommand(opts *options) *cobra.Command {
+	var (
+		format         string
+		output         string
+		includeSecrets bool
+	)
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export the tenant's webhook subscriptions",
+		Example: "  lokictl config export --tenant acme-staging -o acme.yaml",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			if format != "json" && format != "yaml" {
+				return fmt.Errorf("--format must be json or yaml, got %q", format)
+			}
+			query := url.Values{"tenant_id": {tenantID}, "format": {format}}
+			boolQuery(query, "include_secrets", includeSecrets)
+			body, err := opts.client(true).call(cmd.Context(), http.MethodGet, "/api/v1/webhooks/export", query, nil)
+			if err != nil || output == "" {
+				return printResponse(cmd, body, err)
+			}
+			return os.WriteFile(output, body, 0o600)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&format, "format", "yaml", "Format of the export: yaml or json")
+	flags.StringVarP(&output, "output", "o", "", "File to write the export to (default standard output)")
+	flags.BoolVar(&includeSecrets, "include-secrets", false, "Include secret tokens and routing keys")
+	return cmd
+}
+
+// newConfigImportCommand imports the subscriptions of an export with POST /api/v1/webhooks/import
+func newConfigImportCommand(opts *options) *cobra.Command {
+	var (
+		file              string
+		dryRun            bool
+		regenerateSecrets bool
+		onConflict        string
+	)
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create the subscriptions of an export for the tenant",
+		Long: "Create the subscriptions of an export for the tenant, or the export's tenant when none is set.\n" +
+			"Nothing is created when any subscription is invalid; the results are printed either way.",
+		Example: "  lokictl config import -f acme.yaml --tenant acme-prod --dry-run",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			document, contentType, err := readDocument(file)
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			if opts.tenant != "" {
+				query.Set("tenant_id", opts.tenant)
+			}
+			if onConflict != "" {
+				query.Set("on_conflict", onConflict)
+			}
+			boolQuery(query, "dry_run", dryRun)
+			boolQuery(query, "regenerate_secrets", regenerateSecrets)
+			body, err := opts.client(true).do(cmd.Context(), http.MethodPost, "/api/v1/webhooks/import", query, document, contentType)
+			return printResponse(cmd, body, err)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&file, "file", "f", "", "Export to import, JSON or YAML; - reads standard input")
+	flags.BoolVar(&dryRun, "dry-run", false, "Validate and report conflicts without creating anything")
+	flags.BoolVar(&regenerateSecrets, "regenerate-secrets", false, "Generate new secrets instead of keeping exported ones")
+	flags.StringVar(&onConflict, "on-conflict", "", "What to do with subscriptions the tenant already has: skip or fail")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// newConfigApplyCommand reconciles the tenant with a manifest with POST /api/v1/config/apply
+func newConfigApplyCommand(opts *options) *cobra.Command {
+	var (
+		file   string
+		dryRun bool
+	)
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Reconcile the tenant's subscriptions and chains with a declarative manifest",
+		Long: "Reconcile the tenant's subscriptions and chains with a declarative manifest: missing ones are created,\n" +
+			"differing ones updated and those the manifest does not list deleted. --dry-run prints the plan.",
+		Example: "  lokictl config apply -f acme.yaml --dry-run\n" +
+			"  lokictl config apply -f acme.yaml",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			document, contentType, err := readDocument(file)
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			if opts.tenant != "" {
+				query.Set("tenant_id", opts.tenant)
+			}
+			boolQuery(query, "dry_run", dryRun)
+			body, err := opts.client(true).do(cmd.Context(), http.MethodPost, "/api/v1/config/apply", query, document, contentType)
+			return printResponse(cmd, body, err)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&file, "file", "f", "", "Manifest to apply, JSON or YAML; - reads standard input")
+	flags.BoolVar(&dryRun, "dry-run", false, "Print the plan without applying it")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// newEventsCommand creates the commands publishing events
+func newEventsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "events",
+		Aliases: []string{"event"},
+		Short:   "Publish events",
+	}
+	cmd.AddCommand(newEventsPublishCommand(opts))
+	return cmd
+}
+
+// newEventsPublishCommand sends an event to its subscribers with POST /api/v1/webhooks/event
+func newEventsPublishCommand(opts *options) *cobra.Command {
+	var (
+		req     models.SendEventRequest
+		payload string
+	)
+	cmd := &cobra.Command{
+		Use:   "publish <event>",
+		Short: "Publish an event to every active subscription of the tenant",
+		Example: "  lokictl events publish order.created --payload '{\"order_id\": \"A-1001\"}'\n" +
+			"  lokictl events publish order.created --payload @order.json --delay 60",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			if req.DelaySeconds < 0 {
+				return errors.New("--delay must not be negative")
+			}
+			req.TenantID = tenantID
+			req.Event = args[0]
+			if err := readJSONArg(payload, &req.Payload); err != nil {
+				return err
+			}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodPost, "/api/v1/webhooks/event", nil, &req)
+			return printResponse(cmd, body, err)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&payload, "payload", "{}", "Payload as JSON, @file or - for standard input")
+	flags.StringVar(&req.Source, "source", "lokictl", "Source the event is attributed to")
+	flags.IntVar(&req.DelaySeconds, "delay", 0, "Seconds to delay the deliveries by")
+	flags.StringVar((*string)(&req.Priority), "priority", "", "Priority of the event: high, normal or low")
+	return cmd
+}
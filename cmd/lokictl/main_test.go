@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// run executes lokictl against a test server and returns what it printed
+func run(server *httptest.Server, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := newRootCommand(&out, io.Discard)
+	cmd.SetArgs(append([]string{"--server", server.URL, "--api-key", "test-key", "--tenant", "acme"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+// TestWebhooksList tests that requests carry the credentials and tenant and that responses are printed indented
+func TestWebhooksList(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/webhooks", r.URL.Path)
+		assert.Equal(t, "acme", r.URL.Query().Get("tenant_id"))
+		assert.Equal(t, "test-key", r.Header.Get("X-API-Key"))
+		w.Write([]byte(`{"webhooks":[],"total_count":0}`))
+	}))
+	defer server.Close()
+
+	// Act
+	out, err := run(server, "webhooks", "list")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"webhooks\": [],\n  \"total_count\": 0\n}\n", out)
+}
+
+// TestEventsPublish tests that events are sent with the tenant and the parsed payload
+func TestEventsPublish(t *testing.T) {
+	// Arrange
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/webhooks/event", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"message":"Event sent"}`))
+	}))
+	defer server.Close()
+
+	// Act
+	_, err := run(server, "events", "publish", "order.created", "--payload", `{"order_id": "A-1001"}`)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", body["tenant_id"])
+	assert.Equal(t, "order.created", body["event"])
+	assert.Equal(t, map[string]interface{}{"order_id": "A-1001"}, body["payload"])
+}
+
+// TestErrorResponses tests that error responses fail the command with the code and detail of their problem details
+func TestErrorResponses(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"type":"about:blank","title":"Forbidden","status":403,"detail":"insufficient role","code":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	// Act
+	out, err := run(server, "chains", "run", "run-1")
+
+	// Assert
+	assert.EqualError(t, err, "API returned status 403: forbidden: insufficient role")
+	assert.Empty(t, out)
+}
+
+// TestConfigApply_InvalidPlan tests that YAML manifests are sent as YAML and that the plan of a rejected
+// manifest is printed along with the error
+func TestConfigApply_InvalidPlan(t *testing.T) {
+	// Arrange
+	manifest := filepath.Join(t.TempDir(), "acme.yaml")
+	assert.NoError(t, os.WriteFile(manifest, []byte("version: 1\ntenant_id: acme\n"), 0o600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/config/apply", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+		assert.Equal(t, contentTypeYAML, r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"invalid":1}`))
+	}))
+	defer server.Close()
+
+	// Act
+	out, err := run(server, "config", "apply", "-f", manifest, "--dry-run")
+
+	// Assert
+	assert.EqualError(t, err, "API returned status 422")
+	assert.Equal(t, "{\n  \"invalid\": 1\n}\n", out)
+}
+
+// TestTailEvents tests that the data of each streamed event is printed on its own line, skipping keepalives
+func TestTailEvents(t *testing.T) {
+	// Arrange
+	stream := strings.Join([]string{
+		": keepalive",
+		"",
+		"event: delivery.completed",
+		`data: {"type": "delivery.completed"}`,
+		"",
+		"event: step.completed",
+		`data: {"type": "step.completed"}`,
+		"",
+		"",
+	}, "\n")
+	var out bytes.Buffer
+
+	// Act
+	err := tailEvents(strings.NewReader(stream), &out)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"type\": \"delivery.completed\"}\n{\"type\": \"step.completed\"}\n", out.String())
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// newSecretsCommand creates the commands rotating signing keys
+func newSecretsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "secrets",
+		Aliases: []string{"secret"},
+		Short:   "Rotate the keys deliveries and tokens are signed with",
+	}
+	cmd.AddCommand(
+		newSecretsRotateSigningKeyCommand(opts),
+		newSecretsRotateJWTKeyCommand(opts),
+	)
+	return cmd
+}
+
+// newSecretsRotateSigningKeyCommand rotates the tenant's Ed25519 key with PUT /api/v1/tenants/:id/signing
+func newSecretsRotateSigningKeyCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-signing-key",
+		Short: "Generate a new Ed25519 key for the tenant's deliveries",
+		Long: "Generate a new Ed25519 key for the tenant's deliveries, switching the tenant to Ed25519 signing if it\n" +
+			"signs with HMAC secrets. Rotated keys stay in the published key set so deliveries in flight still verify.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			req := models.TenantSigningRequest{Algorithm: models.SigningAlgorithmEd25519, Rotate: true}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodPut, "/api/v1/tenants/"+url.PathEscape(tenantID)+"/signing", nil, &req)
+			return printResponse(cmd, body, err)
+		},
+	}
+}
+
+// newSecretsRotateJWTKeyCommand adds a primary key to the JWT keyring with POST /api/v1/admin/keys
+func newSecretsRotateJWTKeyCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-jwt-key",
+		Short: "Add a key to the JWT keyring and sign new tokens with it",
+		Long: "Add a key to the JWT keyring and sign new tokens with it. Tokens signed with earlier keys keep\n" +
+			"verifying until their key is retired. Needs an admin credential not bound to a tenant.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			req := models.AddJWTKeyRequest{Primary: true}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodPost, "/api/v1/admin/keys", nil, &req)
+			return printResponse(cmd, body, err)
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// newTailCommand follows delivery results and chain runs with GET /api/v1/streams/events
+func newTailCommand(opts *options) *cobra.Command {
+	var types, chainID, runID string
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Follow delivery results, run status changes and step completions as they happen",
+		Long: "Follow delivery results, run status changes and step completions as they happen, printing each event\n" +
+			"as one line of JSON until interrupted. Events are streamed by the instance that processed them, so\n" +
+			"behind a load balancer tail every instance.",
+		Example: "  lokictl tail --types delivery.completed\n" +
+			"  lokictl tail --run 6f1c2d3e-... | jq .step.status",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			query := url.Values{"tenant_id": {tenantID}}
+			for name, value := range map[string]string{"types": types, "chain_id": chainID, "run_id": runID} {
+				if value != "" {
+					query.Set(name, value)
+				}
+			}
+
+			resp, err := opts.client(false).open(cmd.Context(), http.MethodGet, "/api/v1/streams/events", query, nil, "")
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			err = tailEvents(resp.Body, cmd.OutOrStdout())
+			if errors.Is(cmd.Context().Err(), context.Canceled) {
+				return nil
+			}
+			return err
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&types, "types", "", "Comma separated event types, e.g. delivery.completed,run.status_changed")
+	flags.StringVar(&chainID, "chain", "", "Only follow the runs of a chain")
+	flags.StringVar(&runID, "run", "", "Only follow one run")
+	return cmd
+}
+
+// tailEvents copies the data of each Server-Sent Event to w, one per line, until the stream ends
+// Keepalive comments and event names are skipped; the data of every event carries its type
+func tailEvents(stream io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				fmt.Fprintln(w, strings.Join(data, "\n"))
+				data = data[:0]
+			}
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("event stream failed: %w", err)
+	}
+	return nil
+}
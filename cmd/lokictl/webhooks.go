@@ -0,0 +1,130 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// newWebhooksCommand creates the commands managing webhook subscriptions
+func newWebhooksCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "webhooks",
+		Aliases: []string{"webhook", "wh"},
+		Short:   "Manage webhook subscriptions",
+	}
+	cmd.AddCommand(
+		newWebhooksListCommand(opts),
+		newWebhooksCreateCommand(opts),
+		newWebhooksDeleteCommand(opts),
+		newWebhooksTestCommand(opts),
+	)
+	return cmd
+}
+
+// newWebhooksListCommand lists the tenant's subscriptions with GET /api/v1/webhooks
+func newWebhooksListCommand(opts *options) *cobra.Command {
+	var page, limit int
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the tenant's webhook subscriptions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			query := url.Values{
+				"tenant_id": {tenantID},
+				"page":      {strconv.Itoa(page)},
+				"limit":     {strconv.Itoa(limit)},
+			}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodGet, "/api/v1/webhooks", query, nil)
+			return printResponse(cmd, body, err)
+		},
+	}
+	cmd.Flags().IntVar(&page, "page", 1, "Page to list, from 1")
+	cmd.Flags().IntVar(&limit, "limit", 10, "Subscriptions per page")
+	return cmd
+}
+
+// newWebhooksCreateCommand subscribes a target to an event with POST /api/v1/webhooks/subscribe
+func newWebhooksCreateCommand(opts *options) *cobra.Command {
+	var (
+		req     models.SubscribeWebhookRequest
+		private bool
+		headers map[string]string
+	)
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Subscribe a target to an event",
+		Long: "Subscribe a target to an event. The response carries the subscription's secret token, and the JWT of\n" +
+			"private subscriptions; they are only returned once.",
+		Example: "  lokictl webhooks create --app inventory-service --event order.created --url https://inventory.example.com/hooks",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tenantID, err := opts.requireTenant()
+			if err != nil {
+				return err
+			}
+			req.TenantID = tenantID
+			req.Type = models.WebhookTypePublic
+			if private {
+				req.Type = models.WebhookTypePrivate
+			}
+			req.IsPublic = !private
+			req.Headers = headers
+			body, err := opts.client(true).call(cmd.Context(), http.MethodPost, "/api/v1/webhooks/subscribe", nil, &req)
+			return printResponse(cmd, body, err)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&req.AppName, "app", "", "Name of the receiving application")
+	flags.StringVar(&req.SubscribedEvent, "event", "", "Event to subscribe to")
+	flags.StringVar(&req.TargetURL, "url", "", "URL deliveries are sent to")
+	flags.StringVar((*string)(&req.TargetType), "target-type", "", "Target type, e.g. http, slack or amqp (default http)")
+	flags.BoolVar(&private, "private", false, "Authenticate deliveries with a JWT as well as the signature")
+	flags.StringToStringVar(&headers, "header", nil, "Header sent with every delivery, as name=value; repeatable")
+	_ = cmd.MarkFlagRequired("app")
+	_ = cmd.MarkFlagRequired("event")
+	return cmd
+}
+
+// newWebhooksDeleteCommand deletes a subscription with DELETE /api/v1/webhooks/:id
+func newWebhooksDeleteCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <webhook-id>",
+		Short: "Delete a webhook subscription; it can be restored until it is purged",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := opts.client(true).call(cmd.Context(), http.MethodDelete, "/api/v1/webhooks/"+url.PathEscape(args[0]), nil, nil)
+			return printResponse(cmd, body, err)
+		},
+	}
+}
+
+// newWebhooksTestCommand sends a test delivery with POST /api/v1/webhooks/:id/test
+func newWebhooksTestCommand(opts *options) *cobra.Command {
+	var req models.TestWebhookRequest
+	var payload string
+	cmd := &cobra.Command{
+		Use:   "test <webhook-id>",
+		Short: "Send a signed test delivery to a subscription and show the receiver's answer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if payload != "" {
+				if err := readJSONArg(payload, &req.Payload); err != nil {
+					return err
+				}
+			}
+			body, err := opts.client(true).call(cmd.Context(), http.MethodPost, "/api/v1/webhooks/"+url.PathEscape(args[0])+"/test", nil, &req)
+			return printResponse(cmd, body, err)
+		},
+	}
+	cmd.Flags().StringVar(&req.Event, "event", "", "Event of the test delivery (default the subscribed event)")
+	cmd.Flags().StringVar(&payload, "payload", "", "Payload as JSON, @file or - for standard input (default a sample payload)")
+	return cmd
+}
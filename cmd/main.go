@@ -2,20 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/sakibcoolz/loki-suite/internal/amqp"
 	"github.com/sakibcoolz/loki-suite/internal/controller"
+	"github.com/sakibcoolz/loki-suite/internal/email"
+	"github.com/sakibcoolz/loki-suite/internal/grpcapi"
 	"github.com/sakibcoolz/loki-suite/internal/handler"
-	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/migrations"
+	"github.com/sakibcoolz/loki-suite/internal/objectstore"
 	"github.com/sakibcoolz/loki-suite/internal/repository"
 	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/internal/sms"
 	"github.com/sakibcoolz/zcornor/pkg/config"
 	"github.com/sakibcoolz/zcornor/pkg/db/postgres"
 	"github.com/sakibcoolz/zcornor/pkg/security"
 	"github.com/sakibcoolz/zcornor/pkg/zlog"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -31,26 +49,194 @@ func main() {
 	}
 	logger := log
 
+	// Every component is constructed with the shared logger, whose entries carry request_id and tenant_id
+	// when logged while handling a request, and the component's name
+	baseLogger, err := logging.New(config.Name, config.Env, config.Debug)
+	if err != nil {
+		panic("Failed to initialize logger: " + err.Error())
+	}
+	defer func() { _ = baseLogger.Sync() }()
+	appLogger := logging.NewLogger(baseLogger)
+	componentLogger := func(name string) logging.Logger {
+		return appLogger.With(zap.String("component", name))
+	}
+	repositoryLogger := componentLogger("repository")
+	serviceLogger := componentLogger("service")
+	controllerLogger := componentLogger("controller")
+
 	ctx := context.Background()
 
-	db := postgres.Connect(ctx, log, config.Postgres)
-	if db == nil {
-		log.FatalSimple("Failed to connect to database")
+	// LOKI_DB_DRIVER selects the database: postgres (default) connects to the configured Postgres, sqlite keeps
+	// the whole database in the file at LOKI_SQLITE_PATH (default loki.db), for a single instance, and memory
+	// keeps it in process memory. LOKI_STORAGE=memory defaults to memory, so it runs without a database server
+	database := repository.DefaultDatabaseConfig()
+	storage := os.Getenv("LOKI_STORAGE")
+	if storage == "memory" {
+		database.Driver = repository.DriverMemory
+	}
+	if value := os.Getenv("LOKI_DB_DRIVER"); value != "" {
+		database.Driver = value
+	}
+	if value := os.Getenv("LOKI_SQLITE_PATH"); value != "" {
+		database.Path = value
+	}
+	var db *gorm.DB
+	switch database.Driver {
+	case repository.DriverPostgres:
+		db = postgres.Connect(ctx, log, config.Postgres)
+		if db == nil {
+			log.FatalSimple("Failed to connect to database")
+		}
+	case repository.DriverSQLite:
+		db, err = repository.OpenSQLite(database.Path)
+		if err != nil {
+			log.Fatal(ctx, "Failed to open SQLite database", zap.Error(err))
+		}
+		log.Info(ctx, "Using the SQLite database", zap.String("path", database.Path))
+	case repository.DriverMemory:
+		db, err = repository.OpenMemorySQLite()
+		if err != nil {
+			log.Fatal(ctx, "Failed to open in-memory database", zap.Error(err))
+		}
+		log.Warn(ctx, "Using an in-memory database; data is lost on restart and not shared between instances")
+	default:
+		log.Fatal(ctx, "Invalid LOKI_DB_DRIVER, expected postgres, sqlite or memory", zap.String("value", database.Driver))
 	}
 
-	// Migrate database schema
-	log.Info(ctx, "Starting database migration...")
-	if err := db.AutoMigrate(
-		&models.WebhookSubscription{},
-		&models.WebhookEvent{},
-		&models.ExecutionChain{},
-		&models.ExecutionChainStep{},
-		&models.ExecutionChainRun{},
-		&models.ExecutionChainStepRun{},
-	); err != nil {
-		log.Fatal(ctx, "Failed to migrate database schema", zap.Error(err))
+	// LOKI_DB_MAX_OPEN_CONNS and LOKI_DB_MAX_IDLE_CONNS bound the connections of this instance (0 means
+	// unlimited open connections, or no idle ones); LOKI_DB_CONN_MAX_LIFETIME and LOKI_DB_CONN_MAX_IDLE_TIME
+	// recycle connections (e.g. 30m, 0 keeps them forever). Pool usage is exposed on /metrics
+	pool := repository.DefaultPoolConfig()
+	poolSize := func(name string, fallback int) int {
+		value := os.Getenv(name)
+		if value == "" {
+			return fallback
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			logger.Error(ctx, "Invalid "+name+", using default", zap.String("value", value))
+			return fallback
+		}
+		return parsed
+	}
+	poolDuration := func(name string, fallback time.Duration) time.Duration {
+		value := os.Getenv(name)
+		if value == "" {
+			return fallback
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			logger.Error(ctx, "Invalid "+name+", using default", zap.String("value", value))
+			return fallback
+		}
+		return parsed
+	}
+	pool.MaxOpenConns = poolSize("LOKI_DB_MAX_OPEN_CONNS", pool.MaxOpenConns)
+	pool.MaxIdleConns = poolSize("LOKI_DB_MAX_IDLE_CONNS", pool.MaxIdleConns)
+	pool.ConnMaxLifetime = poolDuration("LOKI_DB_CONN_MAX_LIFETIME", pool.ConnMaxLifetime)
+	pool.ConnMaxIdleTime = poolDuration("LOKI_DB_CONN_MAX_IDLE_TIME", pool.ConnMaxIdleTime)
+	if err := repository.ConfigurePool(db, "loki", pool, prometheus.DefaultRegisterer); err != nil {
+		log.Fatal(ctx, "Failed to configure database connection pool", zap.Error(err))
+	}
+
+	// LOKI_DB_READ_REPLICAS lists comma separated DSNs of read replicas serving list and analytics queries,
+	// each with a pool configured like the primary's (db_name="loki_replica_<n>" on /metrics)
+	var replicaDBs []*gorm.DB
+	for _, dsn := range strings.Split(os.Getenv("LOKI_DB_READ_REPLICAS"), ",") {
+		if dsn = strings.TrimSpace(dsn); dsn == "" {
+			continue
+		}
+		if database.Driver != repository.DriverPostgres {
+			log.Fatal(ctx, "LOKI_DB_READ_REPLICAS requires LOKI_DB_DRIVER=postgres")
+		}
+		replicaDB, err := repository.OpenReadReplica(dsn)
+		if err != nil {
+			log.Fatal(ctx, "Failed to connect to read replica", zap.Int("replica", len(replicaDBs)+1), zap.Error(err))
+		}
+		name := "loki_replica_" + strconv.Itoa(len(replicaDBs)+1)
+		if err := repository.ConfigurePool(replicaDB, name, pool, prometheus.DefaultRegisterer); err != nil {
+			log.Fatal(ctx, "Failed to configure read replica connection pool", zap.Error(err))
+		}
+		replicaDBs = append(replicaDBs, replicaDB)
+	}
+	replicas := repository.NewReadReplicas(replicaDBs...)
+
+	// LOKI_ENCRYPTION_KEY is the base64 encoded 32 byte AES key secrets are encrypted at rest with;
+	// LOKI_ENCRYPTION_PREVIOUS_KEYS lists comma separated keys still accepted for reading during a rotation
+	var secretCipher *repository.SecretCipher
+	if value := os.Getenv("LOKI_ENCRYPTION_KEY"); value != "" {
+		secretCipher, err = repository.NewSecretCipher(value, strings.Split(os.Getenv("LOKI_ENCRYPTION_PREVIOUS_KEYS"), ",")...)
+		if err != nil {
+			log.Fatal(ctx, "Invalid LOKI_ENCRYPTION_KEY", zap.Error(err))
+		}
+		repository.ConfigureEncryption(secretCipher)
+	} else {
+		log.Warn(ctx, "LOKI_ENCRYPTION_KEY is not set, secrets are stored in plaintext")
+	}
+
+	// The database schema is versioned by the SQL migrations in internal/migrations
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatal(ctx, "Failed to load database migrations", zap.Error(err))
+	}
+
+	// "migrate" applies pending migrations and exits; "migrate status" lists them without applying them
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if len(os.Args) > 2 && os.Args[2] == "status" {
+			status, err := migrator.Status(ctx)
+			if err != nil {
+				log.Fatal(ctx, "Failed to read migration status", zap.Error(err))
+			}
+			log.Info(ctx, "Migration status",
+				zap.Int("current_version", status.Current),
+				zap.Int("latest_version", status.Latest),
+				zap.Int("pending", len(status.Pending)))
+			for _, migration := range status.Pending {
+				log.Info(ctx, "Pending migration", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+			}
+			return
+		}
+
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal(ctx, "Failed to migrate database schema", zap.Error(err))
+		}
+		for _, migration := range applied {
+			log.Info(ctx, "Applied migration", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+		}
+		log.Info(ctx, "Database schema is up to date", zap.Int("applied", len(applied)))
+		return
+	}
+
+	// LOKI_AUTO_MIGRATE=true applies pending migrations on startup, for development; otherwise the server
+	// refuses to start until the migrate command has brought the schema up to date. In-memory databases start
+	// empty, so they are always migrated
+	if os.Getenv("LOKI_AUTO_MIGRATE") == "true" || database.Driver == repository.DriverMemory {
+		if _, err := migrator.Up(ctx); err != nil {
+			log.Fatal(ctx, "Failed to migrate database schema", zap.Error(err))
+		}
+	}
+	if err := migrator.Verify(ctx); err != nil {
+		log.Fatal(ctx, "Refusing to start against an out-of-date database schema", zap.Error(err))
+	}
+
+	// "rotate-secrets" encrypts plaintext secrets and re-encrypts those written with a previous key, then exits
+	if len(os.Args) > 1 && os.Args[1] == "rotate-secrets" {
+		if secretCipher == nil {
+			log.FatalSimple("rotate-secrets requires LOKI_ENCRYPTION_KEY")
+		}
+		results, err := repository.RotateSecrets(ctx, db, secretCipher)
+		for _, result := range results {
+			log.Info(ctx, "Secrets rotated",
+				zap.String("column", result.Table+"."+result.Column),
+				zap.Int("rotated", result.Rotated),
+				zap.Int("unchanged", result.Unchanged))
+		}
+		if err != nil {
+			log.Fatal(ctx, "Failed to rotate secrets", zap.Error(err))
+		}
+		return
 	}
-	log.Info(ctx, "Database migration completed successfully")
 
 	// Initialize security service
 	securitySvc := security.NewSecurityService(
@@ -59,37 +245,728 @@ func main() {
 		int(config.JWT.Exp),
 	)
 
+	// LOKI_SLOW_QUERY_THRESHOLD sets the repository call duration above which calls are logged, 0 disables logging
+	slowQueryThreshold := repository.DefaultSlowQueryThreshold
+	if value := os.Getenv("LOKI_SLOW_QUERY_THRESHOLD"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			slowQueryThreshold = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_SLOW_QUERY_THRESHOLD, using default", zap.Error(err))
+		}
+	}
+
+	// Repository metrics are exposed on /metrics
+	queryMetrics, err := repository.NewQueryMetrics(prometheus.DefaultRegisterer, slowQueryThreshold, repositoryLogger)
+	if err != nil {
+		log.Fatal(ctx, "Failed to register repository metrics", zap.Error(err))
+	}
+	for _, tracedDB := range append([]*gorm.DB{db}, replicas.Replicas()...) {
+		if err := queryMetrics.TraceStatements(tracedDB); err != nil {
+			log.Fatal(ctx, "Failed to register query tracing callbacks", zap.Error(err))
+		}
+	}
+
+	// Run status changes and step completions written through the chain repository are streamed to
+	// GET /api/streams/events, like the delivery results the webhook service publishes to it
+	statusStream := service.NewStatusStream()
+
+	// LOKI_STORAGE=memory keeps subscriptions, events, deliveries, chains and runs in process memory for
+	// load tests and demos; tenants, credentials and the other stores use the database, in memory as well
+	// unless LOKI_DB_DRIVER selects another
+	var webhookStore repository.WebhookRepository
+	var chainStore repository.ExecutionChainRepository
+	switch storage {
+	case "", "postgres":
+		webhookStore = repository.NewWebhookRepository(db, replicas)
+		chainStore = repository.NewExecutionChainRepository(db, replicas)
+	case "memory":
+		memoryStore := repository.NewMemoryStore()
+		webhookStore = repository.NewMemoryWebhookRepository(memoryStore)
+		chainStore = repository.NewMemoryExecutionChainRepository(memoryStore)
+		logger.Warn(ctx, "Using in-memory webhook and chain storage; data is lost on restart and not shared between instances")
+	default:
+		logger.Fatal(ctx, "Invalid LOKI_STORAGE, expected postgres or memory", zap.String("value", storage))
+	}
+
+	// Redis at LOKI_REDIS_URL is connected to once, by the first of the subscription cache and the work queue using it
+	var redisClient *redis.Client
+	connectRedis := func(setting string) *redis.Client {
+		if redisClient != nil {
+			return redisClient
+		}
+		redisURL := os.Getenv("LOKI_REDIS_URL")
+		if redisURL == "" {
+			logger.Fatal(ctx, setting+" requires LOKI_REDIS_URL")
+		}
+		options, err := redis.ParseURL(redisURL)
+		if err != nil {
+			logger.Fatal(ctx, "Invalid LOKI_REDIS_URL", zap.Error(err))
+		}
+		client := redis.NewClient(options)
+		if err := client.Ping(ctx).Err(); err != nil {
+			logger.Fatal(ctx, "Failed to connect to Redis", zap.Error(err))
+		}
+		redisClient = client
+		return redisClient
+	}
+
 	// Initialize repositories
-	webhookRepo := repository.NewWebhookRepository(db)
-	chainRepo := repository.NewExecutionChainRepository(db)
+	webhookRepo := repository.NewInstrumentedWebhookRepository(webhookStore, queryMetrics)
+	chainStoreRepo := repository.NewInstrumentedExecutionChainRepository(chainStore, queryMetrics)
+
+	// LOKI_WRITE_BATCHING=true buffers delivery attempts and step run writes and flushes them in batches every
+	// LOKI_WRITE_BATCH_INTERVAL, or once LOKI_WRITE_BATCH_MAX_ROWS rows are buffered, to cut the inserts of
+	// high fan-out events; other instances see the writes up to an interval later
+	var writeBatcher *repository.WriteBatcher
+	if os.Getenv("LOKI_WRITE_BATCHING") == "true" {
+		writeBatchConfig := repository.DefaultWriteBatchConfig()
+		if value := os.Getenv("LOKI_WRITE_BATCH_INTERVAL"); value != "" {
+			if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+				writeBatchConfig.Interval = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_WRITE_BATCH_INTERVAL, using default", zap.String("value", value))
+			}
+		}
+		if value := os.Getenv("LOKI_WRITE_BATCH_MAX_ROWS"); value != "" {
+			if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+				writeBatchConfig.MaxRows = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_WRITE_BATCH_MAX_ROWS, using default", zap.String("value", value))
+			}
+		}
+		writeBatcher = repository.NewWriteBatcher(webhookRepo, chainStoreRepo, writeBatchConfig, repositoryLogger)
+		webhookRepo = writeBatcher.Webhooks()
+		chainStoreRepo = writeBatcher.Chains()
+	}
+	writeBatcherCtx, stopWriteBatcher := context.WithCancel(ctx)
+	defer stopWriteBatcher()
+	if writeBatcher != nil {
+		go writeBatcher.Run(writeBatcherCtx)
+	}
+
+	// LOKI_SUBSCRIPTION_CACHE caches the active subscriptions looked up for every published event: memory keeps
+	// up to LOKI_SUBSCRIPTION_CACHE_SIZE lookups per instance, redis shares them through the Redis server at
+	// LOKI_REDIS_URL; lookups expire after LOKI_SUBSCRIPTION_CACHE_TTL, which bounds how long an instance misses
+	// the subscription changes of another with the memory cache
+	subscriptionCacheConfig := repository.DefaultSubscriptionCacheConfig()
+	if value := os.Getenv("LOKI_SUBSCRIPTION_CACHE_TTL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			subscriptionCacheConfig.TTL = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_SUBSCRIPTION_CACHE_TTL, using default", zap.String("value", value))
+		}
+	}
+	if value := os.Getenv("LOKI_SUBSCRIPTION_CACHE_SIZE"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			subscriptionCacheConfig.Size = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_SUBSCRIPTION_CACHE_SIZE, using default", zap.String("value", value))
+		}
+	}
+	var subscriptionCache repository.SubscriptionCache
+	switch backend := os.Getenv("LOKI_SUBSCRIPTION_CACHE"); backend {
+	case "":
+	case "memory":
+		subscriptionCache = repository.NewLRUSubscriptionCache(subscriptionCacheConfig)
+	case "redis":
+		subscriptionCache = repository.NewRedisSubscriptionCache(connectRedis("LOKI_SUBSCRIPTION_CACHE=redis"),
+			os.Getenv("LOKI_REDIS_CACHE_PREFIX"), subscriptionCacheConfig.TTL)
+	default:
+		logger.Fatal(ctx, "Invalid LOKI_SUBSCRIPTION_CACHE, expected memory or redis", zap.String("value", backend))
+	}
+	if subscriptionCache != nil {
+		webhookRepo, err = repository.NewCachingWebhookRepository(webhookRepo, subscriptionCache, prometheus.DefaultRegisterer, repositoryLogger)
+		if err != nil {
+			log.Fatal(ctx, "Failed to register subscription cache metrics", zap.Error(err))
+		}
+	}
+	chainRepo := service.NewStreamingExecutionChainRepository(chainStoreRepo, statusStream, serviceLogger)
+	tenantRepo := repository.NewTenantRepository(db)
+	historyRepo := repository.NewConfigHistoryRepository(db)
+	credentialRepo := repository.NewCredentialRepository(db)
+	adminRepo := repository.NewAdminRepository(db, replicas)
+	keyringRepo := repository.NewKeyringRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
+	complianceRepo := repository.NewComplianceRepository(db)
+	alertRepo := repository.NewAlertRepository(db)
+	lockRepo := repository.NewLockRepository(db)
+
+	// Management API tokens and private webhook JWTs are signed with the keyring; JWT_SECRET only
+	// verifies tokens issued before it, so unset it once they expired
+	keyring := service.NewKeyring(keyringRepo, config.JWT.JWTSecret, serviceLogger)
+	if err := keyring.Load(ctx); err != nil {
+		log.Fatal(ctx, "Failed to load JWT keyring", zap.Error(err))
+	}
 
 	// Initialize services
-	webhookSvc := service.NewWebhookService(webhookRepo, securitySvc, config)
-	chainSvc := service.NewExecutionChainService(chainRepo, webhookRepo, securitySvc, config)
+	webhookSvc := service.NewWebhookService(webhookRepo, tenantRepo, historyRepo, securitySvc, config, serviceLogger)
+	webhookSvc.SetKeyring(keyring)
+	webhookSvc.SetEventPublisher(statusStream)
+
+	// End-to-end delivery latencies are exposed on /metrics for latency SLO alerting
+	latencyMetrics, err := service.NewLatencyMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatal(ctx, "Failed to register delivery latency metrics", zap.Error(err))
+	}
+	webhookSvc.SetLatencyMetrics(latencyMetrics)
+
+	// How events and chain runs of each priority are served is exposed on /metrics
+	priorityMetrics, err := service.NewPriorityMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatal(ctx, "Failed to register priority metrics", zap.Error(err))
+	}
+	webhookSvc.SetPriorityMetrics(priorityMetrics)
+
+	// LOKI_DELIVERY_WORKERS bounds how many events are delivered at once, highest priority first; unset means
+	// no bound. LOKI_HIGH_PRIORITY_DELIVERY_WORKERS of them only deliver high priority events
+	if value := os.Getenv("LOKI_DELIVERY_WORKERS"); value != "" {
+		if deliveryWorkers, err := strconv.Atoi(value); err == nil && deliveryWorkers >= 0 {
+			reservedHigh := 0
+			if value := os.Getenv("LOKI_HIGH_PRIORITY_DELIVERY_WORKERS"); value != "" {
+				if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+					reservedHigh = parsed
+				} else {
+					logger.Error(ctx, "Invalid LOKI_HIGH_PRIORITY_DELIVERY_WORKERS, reserving none", zap.String("value", value))
+				}
+			}
+			webhookSvc.ConfigureDeliveryConcurrency(deliveryWorkers, reservedHigh)
+		} else {
+			logger.Error(ctx, "Invalid LOKI_DELIVERY_WORKERS, delivering without a bound", zap.String("value", value))
+		}
+	}
+
+	// LOKI_EGRESS_IPS lists the comma separated IPs or CIDR ranges deliveries leave from, published to receivers;
+	// LOKI_RECEIVE_ALLOWED_IPS restricts which sources may post to receive endpoints, unset allows every source
+	if err := webhookSvc.ConfigureNetwork(
+		strings.Split(os.Getenv("LOKI_EGRESS_IPS"), ","),
+		strings.Split(os.Getenv("LOKI_RECEIVE_ALLOWED_IPS"), ","),
+	); err != nil {
+		log.Fatal(ctx, "Invalid network configuration", zap.Error(err))
+	}
+	chainSvc := service.NewExecutionChainService(chainRepo, webhookRepo, tenantRepo, historyRepo, securitySvc, config, serviceLogger)
+	chainSvc.SetPriorityMetrics(priorityMetrics)
+
+	// LOKI_CHAIN_WORKERS bounds how many chain runs execute at once; LOKI_INSTANCE_ID identifies
+	// this instance on the runs it executes and defaults to the host name
+	chainWorkers := 0
+	if value := os.Getenv("LOKI_CHAIN_WORKERS"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			chainWorkers = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_CHAIN_WORKERS, using default", zap.String("value", value))
+		}
+	}
+	chainSvc.ConfigureWorkers(os.Getenv("LOKI_INSTANCE_ID"), chainWorkers)
+
+	// LOKI_GLOBAL_RUN_LIMIT caps the chain runs of all tenants running at once; unset means no limit
+	if value := os.Getenv("LOKI_GLOBAL_RUN_LIMIT"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			chainSvc.ConfigureRunLimit(parsed)
+		} else {
+			logger.Error(ctx, "Invalid LOKI_GLOBAL_RUN_LIMIT, running without a global limit", zap.String("value", value))
+		}
+	}
+
+	// LOKI_BOOTSTRAP_API_KEY is an admin key not bound to any tenant, used to create the first credentials
+	authSvc := service.NewAuthService(credentialRepo, keyring, os.Getenv("LOKI_BOOTSTRAP_API_KEY"), serviceLogger)
+	adminSvc := service.NewAdminService(adminRepo, keyring, serviceLogger)
+	topologySvc := service.NewTopologyService(tenantRepo, webhookRepo, chainRepo)
+
+	// LOKI_RETENTION_DAYS is how many days finished events and chain runs are kept before they are archived,
+	// for tenants without their own retention; LOKI_ARCHIVE_RETENTION_DAYS is how many days archived rows are
+	// kept before they are pruned. Unset or 0 keeps rows forever
+	retentionDays := func(name string) int {
+		value := os.Getenv(name)
+		if value == "" {
+			return 0
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			logger.Error(ctx, "Invalid "+name+", keeping rows forever", zap.String("value", value))
+			return 0
+		}
+		return parsed
+	}
+	retentionSvc := service.NewRetentionService(retentionRepo, tenantRepo, service.RetentionConfig{
+		DefaultDays: retentionDays("LOKI_RETENTION_DAYS"),
+		ArchiveDays: retentionDays("LOKI_ARCHIVE_RETENTION_DAYS"),
+	}, serviceLogger)
+
+	tenantSvc := service.NewTenantService(tenantRepo, webhookSvc, serviceLogger)
+	complianceSvc := service.NewComplianceService(complianceRepo, tenantRepo, serviceLogger)
+	alertSvc := service.NewAlertService(alertRepo, webhookRepo, chainRepo, webhookSvc, serviceLogger)
 
 	// Set chain service in webhook service (to avoid circular dependencies)
 	webhookSvc.SetChainService(chainSvc)
 
+	// Replicas sharing the database admit queued runs, retry failed runs and release paused deliveries
+	// under advisory locks, so each is done once however many instances run
+	webhookSvc.SetLocks(lockRepo)
+	chainSvc.SetLocks(lockRepo)
+	alertSvc.SetLocks(lockRepo)
+
+	// LOKI_QUEUE_BACKEND selects the queue of scheduled events and run admissions: postgres (default) polls the
+	// database, redis hands the due work out from a Redis server at LOKI_REDIS_URL, polled every
+	// LOKI_QUEUE_POLL_INTERVAL, while a database sweep every LOKI_QUEUE_SWEEP_INTERVAL delivers what the queue lost
+	// Delivery retries are made in memory by the instance delivering under either backend
+	redisQueue := false
+	queuePollInterval := time.Second
+	queueSweepInterval := 5 * time.Minute
+	switch backend := os.Getenv("LOKI_QUEUE_BACKEND"); backend {
+	case "", "postgres":
+	case "redis":
+		redisQueue = true
+		if value := os.Getenv("LOKI_QUEUE_POLL_INTERVAL"); value != "" {
+			if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+				queuePollInterval = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_QUEUE_POLL_INTERVAL, using default", zap.String("value", value))
+			}
+		}
+		if value := os.Getenv("LOKI_QUEUE_SWEEP_INTERVAL"); value != "" {
+			if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+				queueSweepInterval = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_QUEUE_SWEEP_INTERVAL, using default", zap.String("value", value))
+			}
+		}
+		workQueue := service.NewRedisWorkQueue(connectRedis("LOKI_QUEUE_BACKEND=redis"), os.Getenv("LOKI_REDIS_QUEUE_PREFIX"))
+		webhookSvc.SetWorkQueue(workQueue)
+		chainSvc.SetWorkQueue(workQueue)
+		logger.Info(ctx, "Using the Redis work queue", zap.Duration("poll_interval", queuePollInterval))
+	default:
+		logger.Fatal(ctx, "Invalid LOKI_QUEUE_BACKEND, expected postgres or redis", zap.String("value", backend))
+	}
+
+	// Chain steps are delivered through the webhook service's transports, so they reach every target type
+	chainSvc.SetTransports(webhookSvc.Transports())
+
+	// LOKI_RESPONSE_CAPTURE_BYTES (default 64 KiB) and LOKI_RESPONSE_CAPTURE_TYPES limit what is stored of the
+	// answers of step webhooks; LOKI_RESPONSE_REDACT_PATTERNS (regular expressions, one per line) and
+	// LOKI_RESPONSE_REDACT_PATHS (comma-separated JSONPath expressions) select what is redacted before storing
+	responseCapture := service.ResponseCapture{}
+	if value := os.Getenv("LOKI_RESPONSE_CAPTURE_BYTES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			responseCapture.MaxBytes = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_RESPONSE_CAPTURE_BYTES, using default", zap.String("value", value))
+		}
+	}
+	if value := os.Getenv("LOKI_RESPONSE_CAPTURE_TYPES"); value != "" {
+		for _, contentType := range strings.Split(value, ",") {
+			if contentType = strings.TrimSpace(contentType); contentType != "" {
+				responseCapture.ContentTypes = append(responseCapture.ContentTypes, contentType)
+			}
+		}
+	}
+	responseRedactor, err := service.NewResponseRedactor(
+		strings.Split(os.Getenv("LOKI_RESPONSE_REDACT_PATTERNS"), "\n"),
+		strings.Split(os.Getenv("LOKI_RESPONSE_REDACT_PATHS"), ","),
+	)
+	if err != nil {
+		logger.Fatal(ctx, "Invalid response redaction configuration", zap.Error(err))
+	}
+	responseCapture.Redact = responseRedactor
+	chainSvc.SetResponseCapture(responseCapture)
+
+	// LOKI_PAUSE_RELEASE_INTERVAL sets how often deliveries queued by receiver-requested pauses are checked for release
+	pauseReleaseInterval := 30 * time.Second
+	if value := os.Getenv("LOKI_PAUSE_RELEASE_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			pauseReleaseInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_PAUSE_RELEASE_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	releaserCtx, stopReleaser := context.WithCancel(ctx)
+	defer stopReleaser()
+	go webhookSvc.RunPauseReleaser(releaserCtx, pauseReleaseInterval)
+
+	// LOKI_BATCH_FLUSH_INTERVAL sets how often the delivery batches of batching subscriptions are checked for being due
+	batchFlushInterval := time.Second
+	if value := os.Getenv("LOKI_BATCH_FLUSH_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			batchFlushInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_BATCH_FLUSH_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	go webhookSvc.RunBatchFlusher(releaserCtx, batchFlushInterval)
+
+	// LOKI_ORDERED_RELEASE_INTERVAL sets how often queues of deliveries with an ordering key that nobody is sending are picked up
+	orderedReleaseInterval := 30 * time.Second
+	if value := os.Getenv("LOKI_ORDERED_RELEASE_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			orderedReleaseInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_ORDERED_RELEASE_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	go webhookSvc.RunOrderedDeliveryReleaser(releaserCtx, orderedReleaseInterval)
+
+	// LOKI_DRAIN_INTERVAL sets how often events held by resumed delivery pauses are drained; each pass delivers
+	// a pause's drain rate times the interval of them
+	drainInterval := time.Second
+	if value := os.Getenv("LOKI_DRAIN_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			drainInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_DRAIN_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	go webhookSvc.RunDeliveryDrain(releaserCtx, drainInterval)
+
+	// LOKI_AUTO_DISABLE_INTERVAL sets how often subscriptions failing persistently under their tenant's
+	// auto-disable policy are disabled
+	autoDisableInterval := service.DefaultAutoDisableInterval
+	if value := os.Getenv("LOKI_AUTO_DISABLE_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			autoDisableInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_AUTO_DISABLE_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	go webhookSvc.RunAutoDisabler(releaserCtx, autoDisableInterval)
+
+	// LOKI_SCHEDULER_INTERVAL sets how often scheduled chain runs and scheduled events are checked for being due
+	schedulerInterval := 15 * time.Second
+	if value := os.Getenv("LOKI_SCHEDULER_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			schedulerInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_SCHEDULER_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	defer stopScheduler()
+	go chainSvc.RunScheduler(schedulerCtx, schedulerInterval)
+	if redisQueue {
+		go webhookSvc.RunEventScheduler(schedulerCtx, queueSweepInterval)
+		go webhookSvc.RunEventQueue(schedulerCtx, queuePollInterval)
+	} else {
+		go webhookSvc.RunEventScheduler(schedulerCtx, schedulerInterval)
+	}
+
+	// LOKI_RECOVERY_INTERVAL sets how often in-flight runs record a heartbeat and incomplete runs are recovered
+	recoveryInterval := 30 * time.Second
+	if value := os.Getenv("LOKI_RECOVERY_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			recoveryInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_RECOVERY_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	recoveryCtx, stopRecovery := context.WithCancel(ctx)
+	defer stopRecovery()
+	go chainSvc.RunRecovery(recoveryCtx, recoveryInterval)
+	go chainSvc.RunAdmissionQueue(recoveryCtx, queuePollInterval)
+
+	// LOKI_ARCHIVAL_INTERVAL sets how often expired events and chain runs are archived
+	archivalInterval := time.Hour
+	if value := os.Getenv("LOKI_ARCHIVAL_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			archivalInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_ARCHIVAL_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	archiverCtx, stopArchiver := context.WithCancel(ctx)
+	defer stopArchiver()
+	go retentionSvc.RunArchiver(archiverCtx, archivalInterval)
+
+	// LOKI_ALERT_EVALUATION_INTERVAL sets how often alert rules are evaluated
+	alertInterval := service.DefaultAlertEvaluationInterval
+	if value := os.Getenv("LOKI_ALERT_EVALUATION_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			alertInterval = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_ALERT_EVALUATION_INTERVAL, using default", zap.String("value", value))
+		}
+	}
+	alertCtx, stopAlerts := context.WithCancel(ctx)
+	defer stopAlerts()
+	go alertSvc.RunEvaluator(alertCtx, alertInterval)
+
+	// LOKI_NATS_URL lists comma separated NATS servers to ingest events from and publish to, unset disables NATS;
+	// LOKI_NATS_SUBJECTS lists the comma separated subjects events are ingested from, LOKI_NATS_QUEUE the queue
+	// group instances share (the deliver group of JetStream push consumers), LOKI_NATS_WORKERS how many messages
+	// of each subject are processed at once and LOKI_NATS_PUBLISH_PREFIX the prefix delivery results and finished
+	// chain runs are published under, unset disables publishing
+	var natsConn *nats.Conn
+	var eventBus *service.EventBus
+	if servers := os.Getenv("LOKI_NATS_URL"); servers != "" {
+		natsLogger := componentLogger("nats")
+		natsConn, err = nats.Connect(servers,
+			nats.Name("loki-suite"),
+			nats.MaxReconnects(-1),
+			nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+				if err != nil {
+					natsLogger.Warn(ctx, "NATS connection lost, reconnecting", zap.Error(err))
+				}
+			}),
+			nats.ReconnectHandler(func(*nats.Conn) {
+				natsLogger.Info(ctx, "NATS connection re-established")
+			}),
+			nats.ErrorHandler(func(_ *nats.Conn, subscription *nats.Subscription, err error) {
+				if subscription != nil {
+					natsLogger.Warn(ctx, "NATS subscription error", zap.String("subject", subscription.Subject), zap.Error(err))
+					return
+				}
+				natsLogger.Warn(ctx, "NATS server reported an error", zap.Error(err))
+			}),
+		)
+		if err != nil {
+			logger.Fatal(ctx, "Failed to connect to NATS", zap.Error(err))
+		}
+
+		busConfig := service.EventBusConfig{
+			Queue:         service.DefaultEventBusQueue,
+			Workers:       service.DefaultEventBusWorkers,
+			PublishPrefix: os.Getenv("LOKI_NATS_PUBLISH_PREFIX"),
+		}
+		for _, subject := range strings.Split(os.Getenv("LOKI_NATS_SUBJECTS"), ",") {
+			if subject = strings.TrimSpace(subject); subject != "" {
+				busConfig.Subjects = append(busConfig.Subjects, subject)
+			}
+		}
+		if value, ok := os.LookupEnv("LOKI_NATS_QUEUE"); ok {
+			busConfig.Queue = value
+		}
+		if value := os.Getenv("LOKI_NATS_WORKERS"); value != "" {
+			if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+				busConfig.Workers = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_NATS_WORKERS, using default", zap.String("value", value))
+			}
+		}
+
+		eventBus = service.NewEventBus(natsConn, webhookSvc, busConfig, serviceLogger)
+		if busConfig.PublishPrefix != "" {
+			webhookSvc.SetEventPublisher(service.MultiPublisher(eventBus, statusStream))
+			chainSvc.SetEventPublisher(eventBus)
+		}
+		if err := eventBus.Start(ctx); err != nil {
+			logger.Fatal(ctx, "Failed to ingest events from NATS", zap.Error(err))
+		}
+	}
+
+	// LOKI_AMQP_URL is the amqp:// or amqps:// URL of the broker subscriptions with AMQP targets are delivered
+	// to, unset makes their deliveries fail
+	var amqpConn *amqp.Conn
+	if brokerURL := os.Getenv("LOKI_AMQP_URL"); brokerURL != "" {
+		amqpConn, err = amqp.Dial(brokerURL, amqp.Options{Name: "loki-suite"})
+		if err != nil {
+			logger.Fatal(ctx, "Failed to connect to the AMQP broker", zap.Error(err))
+		}
+		webhookSvc.SetAMQPPublisher(amqpConn)
+	}
+
+	// LOKI_SMTP_HOST is the SMTP server subscriptions with email targets are sent through, unset makes
+	// their deliveries fail; LOKI_SMTP_FROM is the sender address
+	if host := os.Getenv("LOKI_SMTP_HOST"); host != "" {
+		sender, err := email.NewSender(email.Config{
+			Host:     host,
+			Port:     os.Getenv("LOKI_SMTP_PORT"),
+			Username: os.Getenv("LOKI_SMTP_USERNAME"),
+			Password: os.Getenv("LOKI_SMTP_PASSWORD"),
+			From:     os.Getenv("LOKI_SMTP_FROM"),
+		})
+		if err != nil {
+			logger.Fatal(ctx, "Invalid SMTP configuration", zap.Error(err))
+		}
+		webhookSvc.SetEmailSender(sender)
+	}
+
+	// LOKI_TWILIO_ACCOUNT_SID and LOKI_TWILIO_AUTH_TOKEN are the account subscriptions with SMS targets are
+	// sent through, unset makes their deliveries fail; LOKI_TWILIO_API_URL points to a compatible provider
+	if accountSID := os.Getenv("LOKI_TWILIO_ACCOUNT_SID"); accountSID != "" {
+		sender, err := sms.NewTwilio(sms.TwilioConfig{
+			AccountSID: accountSID,
+			AuthToken:  os.Getenv("LOKI_TWILIO_AUTH_TOKEN"),
+			From:       os.Getenv("LOKI_SMS_FROM"),
+			BaseURL:    os.Getenv("LOKI_TWILIO_API_URL"),
+		}, nil)
+		if err != nil {
+			logger.Fatal(ctx, "Invalid SMS configuration", zap.Error(err))
+		}
+		webhookSvc.SetSMSSender(sender)
+	}
+
+	// LOKI_S3_BUCKET is the bucket the bodies of HTTP deliveries larger than LOKI_PAYLOAD_OFFLOAD_BYTES (default
+	// 1 MiB) are stored in, delivered as presigned URLs valid for LOKI_PAYLOAD_URL_TTL (default 24h); unset
+	// delivers every body inline. LOKI_S3_ENDPOINT points to S3-compatible services, addressed by path when
+	// LOKI_S3_PATH_STYLE is true
+	if bucket := os.Getenv("LOKI_S3_BUCKET"); bucket != "" {
+		accessKeyID, secretAccessKey := os.Getenv("LOKI_S3_ACCESS_KEY_ID"), os.Getenv("LOKI_S3_SECRET_ACCESS_KEY")
+		if accessKeyID == "" {
+			accessKeyID, secretAccessKey = os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+		store, err := objectstore.NewS3(objectstore.S3Config{
+			Endpoint:        os.Getenv("LOKI_S3_ENDPOINT"),
+			Region:          os.Getenv("LOKI_S3_REGION"),
+			Bucket:          bucket,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			PathStyle:       os.Getenv("LOKI_S3_PATH_STYLE") == "true",
+		}, nil)
+		if err != nil {
+			logger.Fatal(ctx, "Invalid object storage configuration", zap.Error(err))
+		}
+
+		threshold := service.DefaultPayloadOffloadThreshold
+		if value := os.Getenv("LOKI_PAYLOAD_OFFLOAD_BYTES"); value != "" {
+			if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+				threshold = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_PAYLOAD_OFFLOAD_BYTES, using default", zap.String("value", value))
+			}
+		}
+		urlTTL := service.DefaultPayloadURLTTL
+		if value := os.Getenv("LOKI_PAYLOAD_URL_TTL"); value != "" {
+			if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 && parsed <= objectstore.MaxPresignExpiry {
+				urlTTL = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_PAYLOAD_URL_TTL, using default", zap.String("value", value))
+			}
+		}
+		webhookSvc.SetPayloadOffload(store, threshold, urlTTL)
+	}
+
 	// Initialize controllers
-	webhookController := controller.NewWebhookController(webhookSvc)
-	chainController := controller.NewExecutionChainController(chainSvc)
+	webhookController := controller.NewWebhookController(webhookSvc, topologySvc, controllerLogger)
+	chainController := controller.NewExecutionChainController(chainSvc, controllerLogger)
+	tenantController := controller.NewTenantController(chainSvc, webhookSvc, topologySvc, retentionSvc, tenantSvc, controllerLogger)
+	credentialController := controller.NewCredentialController(authSvc, controllerLogger)
+	adminController := controller.NewAdminController(adminSvc, retentionSvc, controllerLogger)
+	complianceController := controller.NewComplianceController(complianceSvc, controllerLogger)
+	alertController := controller.NewAlertController(alertSvc, controllerLogger)
+	streamController := controller.NewStreamController(statusStream, controllerLogger)
 
 	// Initialize router
-	router := handler.NewRouter(webhookController, chainController)
+	router := handler.NewRouter(webhookController, chainController, tenantController, credentialController, adminController, complianceController, alertController, streamController, authSvc, componentLogger("middleware"))
+
+	// LOKI_MAX_BODY_BYTES limits request bodies; LOKI_MAX_PUBLISH_BODY_BYTES and LOKI_MAX_RECEIVE_BODY_BYTES
+	// override it for event publishing and the webhook receive endpoint, 0 disables a limit
+	bodyLimit := func(name string, fallback int64) int64 {
+		value := os.Getenv(name)
+		if value == "" {
+			return fallback
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			logger.Error(ctx, "Invalid "+name+", using default", zap.String("value", value))
+			return fallback
+		}
+		return parsed
+	}
+	router.SetBodyLimits(
+		bodyLimit("LOKI_MAX_BODY_BYTES", middleware.DefaultMaxBodyBytes),
+		bodyLimit("LOKI_MAX_PUBLISH_BODY_BYTES", middleware.DefaultMaxPublishBodyBytes),
+		bodyLimit("LOKI_MAX_RECEIVE_BODY_BYTES", middleware.DefaultMaxReceiveBodyBytes),
+	)
+
+	// LOKI_RATE_LIMIT sets the requests per second each tenant may make to the API, 0 disables rate limiting;
+	// LOKI_RATE_LIMIT_BURST sets how many requests a tenant may make at once
+	rateLimit, rateLimitBurst := middleware.DefaultRateLimit, middleware.DefaultRateLimitBurst
+	if value := os.Getenv("LOKI_RATE_LIMIT"); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed >= 0 {
+			rateLimit = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_RATE_LIMIT, using default", zap.String("value", value))
+		}
+	}
+	if value := os.Getenv("LOKI_RATE_LIMIT_BURST"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			rateLimitBurst = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_RATE_LIMIT_BURST, using default", zap.String("value", value))
+		}
+	}
+	rateLimiter := middleware.NewRateLimiter(rateLimit, rateLimitBurst)
+	router.SetRateLimiter(rateLimiter)
+
+	// LOKI_LEGACY_API_SUNSET sets when the deprecated unversioned /api prefix stops being served, as an RFC 3339
+	// time or a date such as 2027-04-16; it is announced in the Sunset header of its responses
+	if value := os.Getenv("LOKI_LEGACY_API_SUNSET"); value != "" {
+		sunset, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			sunset, err = time.Parse(time.DateOnly, value)
+		}
+		if err == nil {
+			router.SetLegacyAPISunset(sunset)
+		} else {
+			logger.Error(ctx, "Invalid LOKI_LEGACY_API_SUNSET, using default", zap.String("value", value))
+		}
+	}
+
+	// LOKI_SANDBOX_ENABLED=true registers the unauthenticated receivers under /sandbox for testing
+	// subscriptions; LOKI_SANDBOX_MAX_DELAY caps the delay /sandbox/slow accepts (e.g. 30s)
+	if os.Getenv("LOKI_SANDBOX_ENABLED") == "true" {
+		sandboxMaxDelay := controller.DefaultSandboxMaxDelay
+		if value := os.Getenv("LOKI_SANDBOX_MAX_DELAY"); value != "" {
+			if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+				sandboxMaxDelay = parsed
+			} else {
+				logger.Error(ctx, "Invalid LOKI_SANDBOX_MAX_DELAY, using default", zap.String("value", value))
+			}
+		}
+		router.SetSandbox(controller.NewSandboxController(sandboxMaxDelay))
+	}
 	router.Setup()
 
+	// Tenants with their own rate limit use it instead; limits changed on other instances apply within a minute
+	rateLimitSyncCtx, stopRateLimitSync := context.WithCancel(ctx)
+	defer stopRateLimitSync()
+	if rateLimiter != nil {
+		tenantSvc.SetRateLimiter(rateLimiter)
+		go tenantSvc.RunRateLimitSync(rateLimitSyncCtx, service.DefaultRateLimitSyncInterval)
+	}
+
+	// LOKI_TRUSTED_PROXIES lists the comma separated proxies whose X-Forwarded-For header is trusted for the
+	// client IP that source allowlists are checked against; unset trusts every proxy
+	if value := os.Getenv("LOKI_TRUSTED_PROXIES"); value != "" {
+		if err := router.GetEngine().SetTrustedProxies(strings.Split(value, ",")); err != nil {
+			log.Fatal(ctx, "Invalid LOKI_TRUSTED_PROXIES", zap.Error(err))
+		}
+	}
+
 	// Start server
 	logger.Info(ctx, "Server starting",
 		zap.String("host", config.Host),
 		zap.String("port", config.Port))
 
+	server := &http.Server{
+		Addr:    config.Host + ":" + config.Port,
+		Handler: router.GetEngine(),
+	}
+
 	// Graceful shutdown
 	go func() {
-		if err := router.GetEngine().Run(config.Host + ":" + config.Port); err != nil {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal(ctx, "Failed to start server", zap.Error(err))
 		}
 	}()
 
+	// LOKI_GRPC_PORT is the port the gRPC API listens on next to the REST API, unset disables it
+	var grpcServer *grpc.Server
+	if grpcPort := os.Getenv("LOKI_GRPC_PORT"); grpcPort != "" {
+		listener, err := net.Listen("tcp", config.Host+":"+grpcPort)
+		if err != nil {
+			logger.Fatal(ctx, "Failed to listen for gRPC", zap.Error(err))
+		}
+		grpcServer = grpcapi.NewServer(webhookSvc, chainSvc, authSvc, grpcapi.Config{RateLimiter: rateLimiter}, componentLogger("grpc"))
+
+		logger.Info(ctx, "gRPC server starting",
+			zap.String("host", config.Host),
+			zap.String("port", grpcPort))
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Fatal(ctx, "Failed to start gRPC server", zap.Error(err))
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -97,11 +974,71 @@ func main() {
 
 	logger.InfoSimple("Shutting down server...")
 
-	// Close database connection
-	sqlDB, err := db.DB()
-	if err == nil {
-		if err := sqlDB.Close(); err != nil {
-			logger.Error(ctx, "Error closing database connection", zap.Error(err))
+	// LOKI_SHUTDOWN_TIMEOUT bounds how long in-flight requests and chain runs may take to finish
+	shutdownTimeout := 30 * time.Second
+	if value := os.Getenv("LOKI_SHUTDOWN_TIMEOUT"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			shutdownTimeout = parsed
+		} else {
+			logger.Error(ctx, "Invalid LOKI_SHUTDOWN_TIMEOUT, using default", zap.Error(err))
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
+
+	// Stop accepting requests first so no new chain runs are started, then drain the running ones
+	stopReleaser()
+	stopScheduler()
+	stopRecovery()
+	stopArchiver()
+	stopAlerts()
+	stopRateLimitSync()
+	if eventBus != nil {
+		eventBus.Stop()
+	}
+	// Open event streams never finish on their own, so end them before waiting for requests
+	statusStream.Close()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error(ctx, "Error shutting down HTTP server", zap.Error(err))
+	}
+	if grpcServer != nil {
+		// A graceful stop waits for open streams to end, so calls still running at the deadline are cut off
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
+		}
+	}
+	if err := chainSvc.Shutdown(shutdownCtx); err != nil {
+		logger.Error(ctx, "Error draining chain runs", zap.Error(err))
+	}
+	if writeBatcher != nil {
+		stopWriteBatcher()
+		writeBatcher.Close(shutdownCtx)
+	}
+	if natsConn != nil {
+		natsConn.Close()
+	}
+	if amqpConn != nil {
+		amqpConn.Close()
+	}
+	if redisClient != nil {
+		redisClient.Close()
+	}
+
+	// Close database connections
+	for _, openDB := range append([]*gorm.DB{db}, replicas.Replicas()...) {
+		sqlDB, err := openDB.DB()
+		if err == nil {
+			if err := sqlDB.Close(); err != nil {
+				logger.Error(ctx, "Error closing database connection", zap.Error(err))
+			}
 		}
 	}
 
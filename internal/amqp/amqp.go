@@ -0,0 +1,224 @@
+// Package amqp publishes messages to RabbitMQ exchanges over github.com/rabbitmq/amqp091-go
+// It publishes on a single channel in confirm mode, so Publish returns once the broker has taken
+// responsibility for the message, and reports messages no queue is bound for as unroutable
+// The connection is established again by the next Publish after it fails
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// DefaultConfirmTimeout is how long Publish waits for the broker to confirm a message
+	DefaultConfirmTimeout = 10 * time.Second
+
+	// ioTimeout bounds connecting to the broker
+	ioTimeout = 5 * time.Second
+)
+
+var (
+	// ErrClosed is returned when publishing on a closed connection
+	ErrClosed = errors.New("amqp: connection closed")
+
+	// ErrUnroutable is returned when the exchange has no queue bound for the message's routing key
+	ErrUnroutable = errors.New("amqp: message unroutable")
+
+	// ErrNacked is returned when the broker could not take responsibility for the message
+	ErrNacked = errors.New("amqp: message rejected by the broker")
+)
+
+// Options configures a connection
+type Options struct {
+	// Name identifies the connection in the broker's management UI and is the app ID of messages
+	Name string
+
+	// ConfirmTimeout is how long Publish waits for the broker's confirmation, DefaultConfirmTimeout if 0
+	ConfirmTimeout time.Duration
+
+	// TLSConfig is used for amqps:// URLs; the server name defaults to the host
+	TLSConfig *tls.Config
+}
+
+// Publishing is a message to publish
+type Publishing struct {
+	// ContentType is the MIME type of the body
+	ContentType string
+
+	// MessageID identifies the message, so consumers can detect redeliveries
+	MessageID string
+
+	// Timestamp is when the message was created
+	Timestamp time.Time
+
+	// Headers are the application headers of the message
+	Headers map[string]string
+
+	// Body is the message payload
+	Body []byte
+}
+
+// Conn is a connection to an AMQP broker publishing messages one at a time
+type Conn struct {
+	url  string
+	opts Options
+
+	mu      sync.Mutex
+	conn    *amqp091.Connection
+	channel *amqp091.Channel
+	returns chan amqp091.Return
+	closes  chan *amqp091.Error
+	closed  bool
+}
+
+// Dial connects to the broker at an amqp:// or amqps:// URL; credentials default to guest:guest and the
+// virtual host, the URL's path, to /
+func Dial(rawURL string, opts Options) (*Conn, error) {
+	if _, err := amqp091.ParseURI(rawURL); err != nil {
+		return nil, fmt.Errorf("amqp: invalid URL: %w", err)
+	}
+	if opts.ConfirmTimeout <= 0 {
+		opts.ConfirmTimeout = DefaultConfirmTimeout
+	}
+
+	c := &Conn{url: rawURL, opts: opts}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Publish publishes a persistent message to an exchange and waits for the broker to confirm it
+// The empty exchange is the default exchange, routing to the queue named by the routing key
+func (c *Conn) Publish(ctx context.Context, exchange, routingKey string, msg Publishing) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+	if c.channel == nil || c.channel.IsClosed() {
+		c.drop()
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+
+	err := c.publish(ctx, exchange, routingKey, msg)
+	if err != nil && !errors.Is(err, ErrUnroutable) && !errors.Is(err, ErrNacked) {
+		// A confirmation or return may still arrive for the message, so the next message gets a new connection
+		c.drop()
+	}
+	return err
+}
+
+// Close closes the connection; publishing afterwards fails with ErrClosed
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.conn == nil {
+		return nil
+	}
+	defer func() { c.conn, c.channel = nil, nil }()
+	return c.conn.Close()
+}
+
+// connect establishes the connection and opens the publishing channel in confirm mode
+func (c *Conn) connect() error {
+	properties := amqp091.NewConnectionProperties()
+	properties.SetClientConnectionName(c.opts.Name)
+	conn, err := amqp091.DialConfig(c.url, amqp091.Config{
+		TLSClientConfig: c.opts.TLSConfig,
+		Properties:      properties,
+		Dial:            amqp091.DefaultDial(ioTimeout),
+	})
+	if err != nil {
+		return fmt.Errorf("amqp: failed to connect: %w", err)
+	}
+	channel, err := conn.Channel()
+	if err == nil {
+		err = channel.Confirm(false)
+	}
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("amqp: failed to open the publishing channel: %w", err)
+	}
+
+	// The library delivers returns and closes synchronously, so each has room for the one of the message in flight
+	c.conn, c.channel = conn, channel
+	c.returns = channel.NotifyReturn(make(chan amqp091.Return, 1))
+	c.closes = channel.NotifyClose(make(chan *amqp091.Error, 1))
+	return nil
+}
+
+// drop closes the connection without waiting for the broker, after emptying the returns the library may be
+// blocked on
+func (c *Conn) drop() {
+	if c.conn == nil {
+		return
+	}
+	select {
+	case <-c.returns:
+	default:
+	}
+	c.conn.CloseDeadline(time.Now().Add(ioTimeout))
+	c.conn, c.channel = nil, nil
+}
+
+// publish sends a message and waits for its confirmation
+// A message returned as unroutable is returned before it is confirmed, so it is waiting once the confirmation is in
+func (c *Conn) publish(ctx context.Context, exchange, routingKey string, msg Publishing) error {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.ConfirmTimeout)
+	defer cancel()
+
+	headers := make(amqp091.Table, len(msg.Headers))
+	for name, value := range msg.Headers {
+		headers[name] = value
+	}
+	confirmation, err := c.channel.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, true, false, amqp091.Publishing{
+		ContentType:  msg.ContentType,
+		Headers:      headers,
+		DeliveryMode: amqp091.Persistent,
+		MessageId:    msg.MessageID,
+		Timestamp:    msg.Timestamp,
+		AppId:        c.opts.Name,
+		Body:         msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("amqp: failed to publish: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("amqp: failed to await confirmation: %w", err)
+	}
+	if !acked {
+		// Confirmations pending when the channel closes are reported as nacks
+		select {
+		case reason, ok := <-c.closes:
+			if ok && reason != nil {
+				return fmt.Errorf("amqp: channel closed by the broker: %d %s", reason.Code, reason.Reason)
+			}
+			return fmt.Errorf("amqp: connection lost before the confirmation")
+		default:
+			return ErrNacked
+		}
+	}
+
+	select {
+	case returned, ok := <-c.returns:
+		if ok {
+			return fmt.Errorf("%w: %d %s", ErrUnroutable, returned.ReplyCode, returned.ReplyText)
+		}
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,436 @@
+package amqp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Frame types and the methods the fake broker speaks, as class<<16 | method
+const (
+	frameMethod    = 1
+	frameHeader    = 2
+	frameBody      = 3
+	frameEnd       = 0xCE
+	publishChannel = 1
+
+	connectionStart   = 10<<16 | 10
+	connectionStartOk = 10<<16 | 11
+	connectionTune    = 10<<16 | 30
+	connectionTuneOk  = 10<<16 | 31
+	connectionOpen    = 10<<16 | 40
+	connectionOpenOk  = 10<<16 | 41
+	connectionClose   = 10<<16 | 50
+	connectionCloseOk = 10<<16 | 51
+	channelOpen       = 20<<16 | 10
+	channelOpenOk     = 20<<16 | 11
+	channelClose      = 20<<16 | 40
+	channelCloseOk    = 20<<16 | 41
+	basicPublish      = 60<<16 | 40
+	basicReturn       = 60<<16 | 50
+	basicAck          = 60<<16 | 80
+	basicNack         = 60<<16 | 120
+	confirmSelect     = 85<<16 | 10
+	confirmSelectOk   = 85<<16 | 11
+)
+
+// fakeBroker accepts connections speaking the broker side of AMQP 0-9-1, enough for a publisher
+type fakeBroker struct {
+	t         *testing.T
+	ln        net.Listener
+	published chan published
+}
+
+// brokerConn is a client connection accepted by the fake broker
+type brokerConn struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// published is a message received by the fake broker, with the connection to answer it on
+type published struct {
+	conn       *brokerConn
+	tag        uint64
+	exchange   string
+	routingKey string
+	mandatory  bool
+	properties map[string]interface{}
+	body       []byte
+}
+
+// newFakeBroker starts a broker expecting the credentials response and virtual host on every connection
+func newFakeBroker(t *testing.T, response, vhost string) *fakeBroker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	b := &fakeBroker{t: t, ln: ln, published: make(chan published, 4)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			bc := &brokerConn{t: t, conn: conn, r: bufio.NewReader(conn)}
+			go b.serve(bc, response, vhost)
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return b
+}
+
+func (b *fakeBroker) url(userinfo, vhost string) string {
+	return "amqp://" + userinfo + b.ln.Addr().String() + vhost
+}
+
+// next returns the next message published to the broker
+func (b *fakeBroker) next(t *testing.T) published {
+	select {
+	case msg := <-b.published:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Error("no message published")
+		return published{conn: &brokerConn{t: t, conn: nopConn{}}}
+	}
+}
+
+// serve completes the handshake of a connection, checking its credentials and virtual host, and hands out
+// the messages published on it until it is closed
+func (b *fakeBroker) serve(bc *brokerConn, response, vhost string) {
+	defer bc.conn.Close()
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(bc.r, header); err != nil {
+		return
+	}
+	assert.Equal(bc.t, "AMQP\x00\x00\x09\x01", string(header))
+
+	var start encoder
+	start.octet(0)
+	start.octet(9)
+	start.long(0)
+	start.longstr("PLAIN AMQPLAIN")
+	start.longstr("en_US")
+	bc.send(0, connectionStart, start.Bytes())
+	args := bc.expect(connectionStartOk)
+	args.take(int(args.long()))
+	assert.Equal(bc.t, "PLAIN", args.shortstr())
+	assert.Equal(bc.t, response, args.longstr())
+
+	var tune encoder
+	tune.short(2047)
+	tune.long(4096)
+	tune.short(0)
+	bc.send(0, connectionTune, tune.Bytes())
+	bc.expect(connectionTuneOk)
+	args = bc.expect(connectionOpen)
+	if requested := args.shortstr(); requested != vhost {
+		var closing encoder
+		closing.short(530)
+		closing.shortstr("NOT_ALLOWED - vhost not found")
+		closing.short(10)
+		closing.short(40)
+		bc.send(0, connectionClose, closing.Bytes())
+		bc.expect(connectionCloseOk)
+		return
+	}
+	bc.send(0, connectionOpenOk, []byte{0})
+	bc.expect(channelOpen)
+	bc.send(publishChannel, channelOpenOk, []byte{0, 0, 0, 0})
+	bc.expect(confirmSelect)
+	bc.send(publishChannel, confirmSelectOk, nil)
+
+	var tag uint64
+	for {
+		typ, channel, payload, err := bc.readFrame()
+		if err != nil || typ != frameMethod {
+			if err != nil {
+				return
+			}
+			continue
+		}
+		args := &decoder{data: payload}
+		switch args.long() {
+		case basicPublish:
+			tag++
+			args.short()
+			msg := published{conn: bc, tag: tag, exchange: args.shortstr(), routingKey: args.shortstr(), mandatory: args.octet()&1 == 1}
+			msg.properties, msg.body = bc.content()
+			b.published <- msg
+		case channelClose:
+			bc.send(channel, channelCloseOk, nil)
+		case connectionClose:
+			bc.send(0, connectionCloseOk, nil)
+			return
+		}
+	}
+}
+
+// content reads the header and body frames of a message, decoding the properties the publisher sets
+func (bc *brokerConn) content() (map[string]interface{}, []byte) {
+	_, _, payload, err := bc.readFrame()
+	if !assert.NoError(bc.t, err) {
+		return nil, nil
+	}
+	header := &decoder{data: payload}
+	header.long()
+	size := header.longlong()
+	flags := header.short()
+	properties := map[string]interface{}{}
+	for bit, name := range []string{"content_type", "content_encoding", "headers", "delivery_mode", "priority",
+		"correlation_id", "reply_to", "expiration", "message_id", "timestamp", "type", "user_id", "app_id"} {
+		if flags&(1<<(15-bit)) == 0 {
+			continue
+		}
+		switch name {
+		case "headers":
+			properties[name] = header.take(int(header.long()))
+		case "delivery_mode", "priority":
+			properties[name] = header.octet()
+		case "timestamp":
+			properties[name] = header.longlong()
+		default:
+			properties[name] = header.shortstr()
+		}
+	}
+
+	var body []byte
+	for uint64(len(body)) < size {
+		typ, _, payload, err := bc.readFrame()
+		if !assert.NoError(bc.t, err) {
+			return properties, body
+		}
+		assert.Equal(bc.t, byte(frameBody), typ)
+		assert.LessOrEqual(bc.t, len(payload), 4096-8)
+		body = append(body, payload...)
+	}
+	return properties, body
+}
+
+// ack confirms the message
+func (msg published) ack() {
+	var args encoder
+	args.longlong(msg.tag)
+	args.octet(0)
+	msg.conn.send(publishChannel, basicAck, args.Bytes())
+}
+
+// nack rejects the message
+func (msg published) nack() {
+	var args encoder
+	args.longlong(msg.tag)
+	args.octet(0)
+	msg.conn.send(publishChannel, basicNack, args.Bytes())
+}
+
+// unroutable returns the message as unroutable, then confirms it as brokers do
+func (msg published) unroutable() {
+	var returned encoder
+	returned.short(312)
+	returned.shortstr("NO_ROUTE")
+	returned.shortstr(msg.exchange)
+	returned.shortstr(msg.routingKey)
+	msg.conn.send(publishChannel, basicReturn, returned.Bytes())
+	var header encoder
+	header.short(60)
+	header.short(0)
+	header.longlong(uint64(len(msg.body)))
+	header.short(0)
+	msg.conn.write(frameHeader, publishChannel, header.Bytes())
+	msg.conn.write(frameBody, publishChannel, msg.body)
+	msg.ack()
+}
+
+func (bc *brokerConn) write(typ byte, channel uint16, payload []byte) {
+	frame := make([]byte, 7, 8+len(payload))
+	frame[0] = typ
+	binary.BigEndian.PutUint16(frame[1:], channel)
+	binary.BigEndian.PutUint32(frame[3:], uint32(len(payload)))
+	frame = append(append(frame, payload...), frameEnd)
+	if _, err := bc.conn.Write(frame); err != nil {
+		bc.t.Error(err)
+	}
+}
+
+func (bc *brokerConn) send(channel uint16, id uint32, args []byte) {
+	bc.write(frameMethod, channel, append(binary.BigEndian.AppendUint32(nil, id), args...))
+}
+
+// expect reads the next method, failing the test unless it is the expected one, and returns its arguments
+func (bc *brokerConn) expect(id uint32) *decoder {
+	for {
+		typ, _, payload, err := bc.readFrame()
+		if err != nil {
+			bc.t.Error(err)
+			return &decoder{}
+		}
+		if typ != frameMethod {
+			continue
+		}
+		args := &decoder{data: payload}
+		assert.Equal(bc.t, id, args.long())
+		return args
+	}
+}
+
+// readFrame reads a frame, returning its type, channel and payload
+func (bc *brokerConn) readFrame() (byte, uint16, []byte, error) {
+	var header [7]byte
+	if _, err := io.ReadFull(bc.r, header[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(bc.r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	return header[0], binary.BigEndian.Uint16(header[1:3]), payload[:len(payload)-1], nil
+}
+
+// nopConn stands in for the connection of a message that was never published, discarding the answers to it
+type nopConn struct{ net.Conn }
+
+func (nopConn) Write(p []byte) (int, error) { return len(p), nil }
+
+// encoder writes AMQP fields
+type encoder struct{ bytes.Buffer }
+
+func (e *encoder) octet(v byte)      { e.WriteByte(v) }
+func (e *encoder) short(v uint16)    { e.Write(binary.BigEndian.AppendUint16(nil, v)) }
+func (e *encoder) long(v uint32)     { e.Write(binary.BigEndian.AppendUint32(nil, v)) }
+func (e *encoder) longlong(v uint64) { e.Write(binary.BigEndian.AppendUint64(nil, v)) }
+func (e *encoder) shortstr(s string) { e.octet(byte(len(s))); e.WriteString(s) }
+func (e *encoder) longstr(s string)  { e.long(uint32(len(s))); e.WriteString(s) }
+
+// decoder reads AMQP fields
+type decoder struct{ data []byte }
+
+func (d *decoder) take(n int) []byte {
+	n = min(n, len(d.data))
+	v := d.data[:n:n]
+	d.data = d.data[n:]
+	return v
+}
+
+// fixed takes n bytes, zero padded when the data runs out
+func (d *decoder) fixed(n int) []byte {
+	return append(d.take(n), make([]byte, n)...)[:n]
+}
+
+func (d *decoder) octet() byte      { return d.fixed(1)[0] }
+func (d *decoder) short() uint16    { return binary.BigEndian.Uint16(d.fixed(2)) }
+func (d *decoder) long() uint32     { return binary.BigEndian.Uint32(d.fixed(4)) }
+func (d *decoder) longlong() uint64 { return binary.BigEndian.Uint64(d.fixed(8)) }
+func (d *decoder) shortstr() string { return string(d.take(int(d.octet()))) }
+func (d *decoder) longstr() string  { return string(d.take(int(d.long()))) }
+
+// TestPublishConfirmed tests that messages are published persistent and mandatory with their properties and
+// headers, split into body frames of the negotiated size, and return once confirmed
+func TestPublishConfirmed(t *testing.T) {
+	broker := newFakeBroker(t, "\x00loki\x00secret", "orders")
+	conn, err := Dial(broker.url("loki:secret@", "/orders"), Options{Name: "loki-suite"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	body := make([]byte, 10000)
+	for i := range body {
+		body[i] = byte('a' + i%26)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- conn.Publish(context.Background(), "events", "order.created", Publishing{
+			ContentType: "application/json",
+			MessageID:   "42",
+			Timestamp:   time.Unix(1700000000, 0),
+			Headers:     map[string]string{"X-Shavix-Signature": "sha256=abc"},
+			Body:        body,
+		})
+	}()
+
+	msg := broker.next(t)
+	assert.Equal(t, "events", msg.exchange)
+	assert.Equal(t, "order.created", msg.routingKey)
+	assert.True(t, msg.mandatory)
+	assert.Equal(t, body, msg.body)
+	assert.Equal(t, "application/json", msg.properties["content_type"])
+	assert.Contains(t, string(msg.properties["headers"].([]byte)), "\x12X-Shavix-SignatureS\x00\x00\x00\x0asha256=abc")
+	assert.Equal(t, byte(2), msg.properties["delivery_mode"])
+	assert.Equal(t, "42", msg.properties["message_id"])
+	assert.Equal(t, uint64(1700000000), msg.properties["timestamp"])
+	assert.Equal(t, "loki-suite", msg.properties["app_id"])
+
+	msg.ack()
+	assert.NoError(t, <-done)
+}
+
+// TestPublishRejected tests that a message returned by the broker fails with ErrUnroutable and a nacked one with
+// ErrNacked, while the connection keeps being used
+func TestPublishRejected(t *testing.T) {
+	broker := newFakeBroker(t, "\x00guest\x00guest", "/")
+	conn, err := Dial(broker.url("", ""), Options{})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	go func() {
+		broker.next(t).unroutable()
+		broker.next(t).nack()
+		broker.next(t).ack()
+	}()
+
+	err = conn.Publish(context.Background(), "events", "nowhere", Publishing{Body: []byte("{}")})
+	assert.ErrorIs(t, err, ErrUnroutable)
+	assert.ErrorContains(t, err, "312 NO_ROUTE")
+	assert.ErrorIs(t, conn.Publish(context.Background(), "events", "orders", Publishing{Body: []byte("{}")}), ErrNacked)
+	assert.NoError(t, conn.Publish(context.Background(), "events", "orders", Publishing{Body: []byte("{}")}))
+}
+
+// TestPublishReconnects tests that a connection lost while awaiting the confirmation fails the message, and
+// the next one is published on a new connection
+func TestPublishReconnects(t *testing.T) {
+	broker := newFakeBroker(t, "\x00guest\x00guest", "/")
+	conn, err := Dial(broker.url("", ""), Options{})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	go func() {
+		first := broker.next(t)
+		first.conn.conn.Close()
+		second := broker.next(t)
+		assert.NotSame(t, first.conn, second.conn)
+		assert.Equal(t, "orders", second.routingKey)
+		second.ack()
+	}()
+
+	assert.Error(t, conn.Publish(context.Background(), "", "orders", Publishing{Body: []byte("{}")}))
+	assert.NoError(t, conn.Publish(context.Background(), "", "orders", Publishing{Body: []byte("{}")}))
+}
+
+// TestPublishCancelled tests that publishing stops waiting for the confirmation once the context is done
+func TestPublishCancelled(t *testing.T) {
+	broker := newFakeBroker(t, "\x00guest\x00guest", "/")
+	conn, err := Dial(broker.url("", ""), Options{})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = conn.Publish(ctx, "events", "orders", Publishing{Body: []byte("{}")})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestDialRefused tests that a connection the broker refuses and a URL that is not AMQP fail to dial
+func TestDialRefused(t *testing.T) {
+	broker := newFakeBroker(t, "\x00guest\x00guest", "/")
+
+	_, err := Dial(broker.url("", "/missing"), Options{})
+	assert.ErrorIs(t, err, amqp091.ErrVhost)
+
+	_, err = Dial("http://"+broker.ln.Addr().String(), Options{})
+	assert.ErrorContains(t, err, "amqp: invalid URL")
+}
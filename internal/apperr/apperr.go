@@ -0,0 +1,124 @@
+// Package apperr provides the typed errors the service returns to its callers
+// Every error has a kind, which decides the HTTP status and gRPC code it is answered with, a stable
+// machine-readable code clients can branch on, and a message safe to show them; the error it wraps, such
+// as a database error, is only logged
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors; errors.Is reports whether an error is of a kind, however deeply it is wrapped
+var (
+	// ErrNotFound is the kind of errors for resources that do not exist, or that the caller may not see
+	ErrNotFound = errors.New("not found")
+
+	// ErrValidation is the kind of errors for requests that are malformed or break a rule of the resource
+	ErrValidation = errors.New("validation failed")
+
+	// ErrConflict is the kind of errors for requests conflicting with the current state of a resource, such as
+	// an update based on a stale version or a transition the resource's status does not allow
+	ErrConflict = errors.New("conflict")
+
+	// ErrUnauthorized is the kind of errors for callers whose credentials are missing, invalid or expired
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden is the kind of errors for authenticated callers that may not make a request, such as the
+	// events of a suspended tenant
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrLimitExceeded is the kind of errors for requests beyond a quota or rate limit
+	ErrLimitExceeded = errors.New("limit exceeded")
+)
+
+// Error is an error of a kind with a machine-readable code and a message for clients
+type Error struct {
+	// Kind is one of ErrNotFound, ErrValidation, ErrConflict, ErrUnauthorized, ErrForbidden and ErrLimitExceeded
+	Kind error
+
+	// Code identifies the error, such as chain_not_found; codes are stable and never reused for another error
+	Code string
+
+	// Message describes the error to clients; it never includes the wrapped error
+	Message string
+
+	// err is the error that caused this one, if any
+	err error
+}
+
+// NotFound returns an error of the ErrNotFound kind
+func NotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+// Validation returns an error of the ErrValidation kind
+func Validation(code, message string) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message}
+}
+
+// Conflict returns an error of the ErrConflict kind
+func Conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+// Unauthorized returns an error of the ErrUnauthorized kind
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+// Forbidden returns an error of the ErrForbidden kind
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+// LimitExceeded returns an error of the ErrLimitExceeded kind
+func LimitExceeded(code, message string) *Error {
+	return &Error{Kind: ErrLimitExceeded, Code: code, Message: message}
+}
+
+// Wrap returns a copy of the error caused by err
+// Copies are still the error they were made from for errors.Is, so sentinel errors can be wrapped and reworded
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.err = err
+	return &wrapped
+}
+
+// Withf returns a copy of the error with a message describing this occurrence of it
+func (e *Error) Withf(format string, args ...interface{}) *Error {
+	reworded := *e
+	reworded.Message = fmt.Sprintf(format, args...)
+	return &reworded
+}
+
+// Error returns the message followed by the wrapped error, for logs
+func (e *Error) Error() string {
+	if e.err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is the error's kind, or the error it was copied from by Wrap or Withf
+func (e *Error) Is(target error) bool {
+	if target == e.Kind {
+		return true
+	}
+	other, ok := target.(*Error)
+	return ok && other.Kind == e.Kind && other.Code == e.Code
+}
+
+// As returns the outermost Error err wraps, or nil when it wraps none
+func As(err error) *Error {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package apperr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestError tests that wrapped and reworded copies of an error are still the error and of its kind, however
+// deeply they are wrapped, that errors of the same kind with other codes are not, and that messages never
+// include the wrapped error
+func TestError(t *testing.T) {
+	// Arrange
+	notFound := apperr.NotFound("chain_not_found", "execution chain not found")
+	other := apperr.NotFound("run_not_found", "chain run not found")
+	cause := errors.New("record not found")
+
+	// Act
+	wrapped := fmt.Errorf("failed to load chain: %w", notFound.Withf("chain 42 not found").Wrap(cause))
+	typed := apperr.As(wrapped)
+
+	// Assert
+	assert.ErrorIs(t, wrapped, notFound)
+	assert.ErrorIs(t, wrapped, apperr.ErrNotFound)
+	assert.ErrorIs(t, wrapped, cause)
+	assert.NotErrorIs(t, wrapped, other)
+	assert.NotErrorIs(t, wrapped, apperr.ErrValidation)
+	if assert.NotNil(t, typed) {
+		assert.Equal(t, "chain_not_found", typed.Code)
+		assert.Equal(t, "chain 42 not found", typed.Message)
+		assert.Equal(t, "chain 42 not found: record not found", typed.Error())
+	}
+	assert.Equal(t, "execution chain not found", notFound.Message)
+	assert.Nil(t, apperr.As(cause))
+}
@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// AdminController handles HTTP requests for cross-tenant operator views
+type AdminController struct {
+	service          service.AdminService
+	retentionService service.RetentionService
+	logger           logging.Logger
+}
+
+// NewAdminController creates a new admin controller
+func NewAdminController(service service.AdminService, retentionService service.RetentionService, logger logging.Logger) *AdminController {
+	return &AdminController{
+		service:          service,
+		retentionService: retentionService,
+		logger:           logger,
+	}
+}
+
+// ListSubscriptions handles GET /api/admin/subscriptions
+func (c *AdminController) ListSubscriptions(ctx *gin.Context) {
+	filter, page, ok := parseAdminListQuery(ctx)
+	if !ok {
+		return
+	}
+
+	requested, ok := parseResponseFields(ctx, webhookListShape)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.ListSubscriptions(ctx.Request.Context(), filter, page)
+	if invalidListPage(ctx, err) {
+		return
+	}
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list subscriptions across tenants", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "subscriptions_listing_failed", "Failed to retrieve subscriptions")
+		return
+	}
+
+	writeShaped(ctx, c.logger, http.StatusOK, response, requested)
+}
+
+// ListEvents handles GET /api/admin/events
+func (c *AdminController) ListEvents(ctx *gin.Context) {
+	filter, page, ok := parseAdminListQuery(ctx)
+	if !ok {
+		return
+	}
+
+	requested, ok := parseResponseFields(ctx, adminEventListShape)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.ListEvents(ctx.Request.Context(), filter, page)
+	if invalidListPage(ctx, err) {
+		return
+	}
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list events across tenants", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "events_listing_failed", "Failed to retrieve events")
+		return
+	}
+
+	writeShaped(ctx, c.logger, http.StatusOK, response, requested)
+}
+
+// ListChainRuns handles GET /api/admin/chain-runs
+func (c *AdminController) ListChainRuns(ctx *gin.Context) {
+	filter, page, ok := parseAdminListQuery(ctx)
+	if !ok {
+		return
+	}
+
+	requested, ok := parseResponseFields(ctx, chainRunListShape)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.ListChainRuns(ctx.Request.Context(), filter, page)
+	if invalidListPage(ctx, err) {
+		return
+	}
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list chain runs across tenants", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "runs_listing_failed", "Failed to retrieve chain runs")
+		return
+	}
+
+	writeShaped(ctx, c.logger, http.StatusOK, response, requested)
+}
+
+// GetTenantStats handles GET /api/admin/tenants/stats
+func (c *AdminController) GetTenantStats(ctx *gin.Context) {
+	response, err := c.service.GetTenantStats(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to aggregate tenant stats", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "tenant_stats_failed", "Failed to aggregate tenant statistics")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ListJWTKeys handles GET /api/admin/keys
+func (c *AdminController) ListJWTKeys(ctx *gin.Context) {
+	response, err := c.service.ListJWTKeys(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list JWT keys", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "keys_listing_failed", "Failed to retrieve JWT keys")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AddJWTKey handles POST /api/admin/keys
+func (c *AdminController) AddJWTKey(ctx *gin.Context) {
+	var req models.AddJWTKeyRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	key, err := c.service.AddJWTKey(ctx.Request.Context(), &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to add JWT key", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "key_creation_failed", "Failed to add JWT key")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, key)
+}
+
+// PromoteJWTKey handles POST /api/admin/keys/:kid/promote
+func (c *AdminController) PromoteJWTKey(ctx *gin.Context) {
+	kid := ctx.Param("kid")
+	if err := c.service.PromoteJWTKey(ctx.Request.Context(), kid); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to promote JWT key", zap.String("kid", kid), zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusBadRequest, "key_promotion_failed", "Failed to promote JWT key")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "JWT key promoted successfully",
+		Data:    gin.H{"kid": kid},
+	})
+}
+
+// RetireJWTKey handles POST /api/admin/keys/:kid/retire
+func (c *AdminController) RetireJWTKey(ctx *gin.Context) {
+	kid := ctx.Param("kid")
+	if err := c.service.RetireJWTKey(ctx.Request.Context(), kid); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to retire JWT key", zap.String("kid", kid), zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusBadRequest, "key_retirement_failed", "Failed to retire JWT key")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "JWT key retired successfully",
+		Data:    gin.H{"kid": kid},
+	})
+}
+
+// PurgeDeleted handles POST /api/admin/purge
+func (c *AdminController) PurgeDeleted(ctx *gin.Context) {
+	var req models.PurgeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	result, err := c.service.PurgeDeleted(ctx.Request.Context(), &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to purge deleted records", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "purge_failed", "Failed to purge deleted subscriptions and chains")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// TriggerArchival handles POST /api/admin/archival
+func (c *AdminController) TriggerArchival(ctx *gin.Context) {
+	run, err := c.retentionService.TriggerArchival(ctx.Request.Context())
+	if errors.Is(err, service.ErrArchivalRunning) {
+		middleware.WriteError(ctx, err, http.StatusConflict, "archival_running", service.ErrArchivalRunning.Error())
+		return
+	}
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to trigger archival", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "archival_failed", "Failed to start archival run")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, run)
+}
+
+// ListArchivalRuns handles GET /api/admin/archival
+func (c *AdminController) ListArchivalRuns(ctx *gin.Context) {
+	page, limit := parsePagination(ctx)
+
+	response, err := c.retentionService.ListArchivalRuns(ctx.Request.Context(), page, limit)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list archival runs", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "archival_runs_listing_failed", "Failed to retrieve archival runs")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parseAdminListQuery reads the optional filters, pagination, cursor and sort parameters of admin listings
+func parseAdminListQuery(ctx *gin.Context) (models.AdminListFilter, models.ListPage, bool) {
+	var filter models.AdminListFilter
+	page, ok := parseListQuery(ctx, &filter)
+	return filter, page, ok
+}
+
+// parsePagination reads the page and limit parameters of listings, defaulting invalid values
+func parsePagination(ctx *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return page, limit
+}
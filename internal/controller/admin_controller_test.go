@@ -0,0 +1,131 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/controller"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// operatorKeys authenticates the API key "operator" as an admin bound to no tenant, and any other key as an
+// admin of the tenant it names
+type operatorKeys struct{}
+
+func (operatorKeys) AuthenticateAPIKey(_ context.Context, apiKey string) (*models.Principal, error) {
+	if apiKey == "operator" {
+		return &models.Principal{Role: models.RoleAdmin}, nil
+	}
+	return &models.Principal{TenantID: apiKey, Role: models.RoleAdmin}, nil
+}
+
+func (operatorKeys) AuthenticateToken(context.Context, string) (*models.Principal, error) {
+	return nil, nil
+}
+
+// newAdminEngine routes the cross-tenant admin endpoints to a controller over the admin service
+func newAdminEngine(adminService *mocks.MockAdminService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	admin := controller.NewAdminController(adminService, nil, logging.Nop())
+	engine := gin.New()
+	api := engine.Group("/api/admin", middleware.RequireRole(operatorKeys{}, models.RoleAdmin, nil, logging.Nop()), middleware.RequireGlobalPrincipal())
+	api.GET("/subscriptions", admin.ListSubscriptions)
+	api.GET("/events", admin.ListEvents)
+	api.GET("/chain-runs", admin.ListChainRuns)
+	api.GET("/tenants/stats", admin.GetTenantStats)
+	return engine
+}
+
+// callAdmin sends a GET request to the admin endpoints with the API key
+func callAdmin(engine *gin.Engine, apiKey, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/admin"+path, nil)
+	req.Header.Set("X-API-Key", apiKey)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestAdminController_TenantBoundCaller tests that an admin bound to a tenant gets 403 on every cross-tenant
+// endpoint without reaching the admin service
+func TestAdminController_TenantBoundCaller(t *testing.T) {
+	// Arrange
+	engine := newAdminEngine(mocks.NewMockAdminService(t))
+
+	for _, path := range []string{"/subscriptions", "/events", "/chain-runs", "/tenants/stats"} {
+		t.Run(path, func(t *testing.T) {
+			// Act
+			recorder := callAdmin(engine, "tenant-a", path)
+
+			// Assert
+			assert.Equal(t, http.StatusForbidden, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+// TestAdminController_ListChainRuns tests that an operator lists the chain runs of every tenant, narrowed by
+// the tenant and status given in the query
+func TestAdminController_ListChainRuns(t *testing.T) {
+	// Arrange
+	adminService := mocks.NewMockAdminService(t)
+	engine := newAdminEngine(adminService)
+	adminService.EXPECT().
+		ListChainRuns(mock.Anything, mock.MatchedBy(func(filter models.AdminListFilter) bool {
+			return filter.TenantID == "tenant-b" && filter.Status == "failed"
+		}), mock.Anything).
+		Return(&models.ExecutionChainRunsResponse{
+			Runs:  []models.ExecutionChainRun{{TenantID: "tenant-b", Status: models.ExecutionChainStatusFailed}},
+			Total: 1, Page: 1, Limit: 10,
+		}, nil).
+		Once()
+
+	// Act
+	recorder := callAdmin(engine, "operator", "/chain-runs?tenant_id=tenant-b&status=failed")
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response models.ExecutionChainRunsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, int64(1), response.Total)
+	require.Len(t, response.Runs, 1)
+	assert.Equal(t, "tenant-b", response.Runs[0].TenantID)
+}
+
+// TestAdminController_GetTenantStats tests that an operator gets the resource counts of every tenant
+func TestAdminController_GetTenantStats(t *testing.T) {
+	// Arrange
+	adminService := mocks.NewMockAdminService(t)
+	engine := newAdminEngine(adminService)
+	adminService.EXPECT().
+		GetTenantStats(mock.Anything).
+		Return(&models.TenantStatsResponse{
+			Tenants: []models.TenantStats{
+				{TenantID: "tenant-a", Subscriptions: 3, FailedEvents: 1},
+				{TenantID: "tenant-b", Chains: 2, ChainRuns: 40},
+			},
+			TotalTenants: 2,
+		}, nil).
+		Once()
+
+	// Act
+	recorder := callAdmin(engine, "operator", "/tenants/stats")
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response models.TenantStatsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.TotalTenants)
+	require.Len(t, response.Tenants, 2)
+	assert.Equal(t, int64(3), response.Tenants[0].Subscriptions)
+	assert.Equal(t, int64(40), response.Tenants[1].ChainRuns)
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// AlertController handles HTTP requests for alert rules and the alert history
+type AlertController struct {
+	service service.AlertService
+	logger  logging.Logger
+}
+
+// NewAlertController creates a new alert controller
+func NewAlertController(service service.AlertService, logger logging.Logger) *AlertController {
+	return &AlertController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateRule handles POST /api/alerts/rules
+func (c *AlertController) CreateRule(ctx *gin.Context) {
+	var req models.CreateAlertRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	rule, err := c.service.CreateRule(ctx.Request.Context(), &req)
+	if err != nil {
+		writeAlertError(ctx, c.logger, err, "Failed to create alert rule", "alert_rule_creation_failed")
+		return
+	}
+
+	c.logger.Info(ctx.Request.Context(), "Alert rule created successfully",
+		zap.String("alert_rule_id", rule.ID.String()),
+		zap.String("tenant_id", rule.TenantID))
+
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+// ListRules handles GET /api/alerts/rules
+func (c *AlertController) ListRules(ctx *gin.Context) {
+	tenantID := ctx.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	response, err := c.service.ListRules(ctx.Request.Context(), tenantID)
+	if err != nil {
+		writeAlertError(ctx, c.logger, err, "Failed to list alert rules", "alert_rule_list_failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetRule handles GET /api/alerts/rules/:id
+func (c *AlertController) GetRule(ctx *gin.Context) {
+	ruleID, ok := parseAlertRuleID(ctx)
+	if !ok {
+		return
+	}
+
+	rule, err := c.service.GetRule(ctx.Request.Context(), ruleID)
+	if err != nil {
+		writeAlertError(ctx, c.logger, err, "Failed to get alert rule", "alert_rule_retrieval_failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// UpdateRule handles PUT /api/alerts/rules/:id
+func (c *AlertController) UpdateRule(ctx *gin.Context) {
+	ruleID, ok := parseAlertRuleID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.AlertRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	rule, err := c.service.UpdateRule(ctx.Request.Context(), ruleID, &req)
+	if err != nil {
+		writeAlertError(ctx, c.logger, err, "Failed to update alert rule", "alert_rule_update_failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule handles DELETE /api/alerts/rules/:id
+func (c *AlertController) DeleteRule(ctx *gin.Context) {
+	ruleID, ok := parseAlertRuleID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteRule(ctx.Request.Context(), ruleID); err != nil {
+		writeAlertError(ctx, c.logger, err, "Failed to delete alert rule", "alert_rule_deletion_failed")
+		return
+	}
+
+	c.logger.Info(ctx.Request.Context(), "Alert rule deleted successfully",
+		zap.String("alert_rule_id", ruleID.String()))
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Alert rule deleted successfully",
+	})
+}
+
+// ListHistory handles GET /api/alerts/history
+func (c *AlertController) ListHistory(ctx *gin.Context) {
+	tenantID := ctx.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	var ruleID *uuid.UUID
+	if value := ctx.Query("rule_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_rule_id", "Invalid alert rule ID format")
+			return
+		}
+		ruleID = &parsed
+	}
+	page, limit := parsePagination(ctx)
+
+	response, err := c.service.ListHistory(ctx.Request.Context(), tenantID, ruleID, page, limit)
+	if err != nil {
+		writeAlertError(ctx, c.logger, err, "Failed to list alert history", "alert_history_list_failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parseAlertRuleID reads the alert rule ID path parameter, answering 400 when it is not a UUID
+func parseAlertRuleID(ctx *gin.Context) (uuid.UUID, bool) {
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_rule_id", "Invalid alert rule ID format")
+		return uuid.Nil, false
+	}
+	return ruleID, true
+}
+
+// writeAlertError answers an alert service error: 404 for unknown rules, 400 for invalid rules and
+// 500 with the given error code and message otherwise
+func writeAlertError(ctx *gin.Context, logger logging.Logger, err error, message, code string) {
+	if !errors.Is(err, service.ErrAlertRuleNotFound) && !errors.Is(err, service.ErrInvalidAlertRule) {
+		logger.Error(ctx.Request.Context(), message, zap.Error(err))
+	}
+
+	middleware.WriteError(ctx, err, http.StatusInternalServerError, code, message)
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// ComplianceController handles HTTP requests for data protection obligations such as erasure requests
+type ComplianceController struct {
+	service service.ComplianceService
+	logger  logging.Logger
+}
+
+// NewComplianceController creates a new compliance controller
+func NewComplianceController(service service.ComplianceService, logger logging.Logger) *ComplianceController {
+	return &ComplianceController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// EraseSubject handles DELETE /api/compliance/tenants/:tenantID/subjects/:subjectKey
+func (c *ComplianceController) EraseSubject(ctx *gin.Context) {
+	tenantID := ctx.Param("tenantID")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.service.EraseSubject(ctx.Request.Context(), tenantID, ctx.Param("subjectKey"))
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		if errors.Is(err, service.ErrInvalidSubjectKey) {
+			middleware.WriteError(ctx, err, http.StatusBadRequest, "invalid_subject_key", service.ErrInvalidSubjectKey.Error())
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to erase data subject",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "erasure_failed", "Failed to erase data subject")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Data subject erased",
+		Data:    response,
+	})
+}
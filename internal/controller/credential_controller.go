@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// CredentialController handles HTTP requests for API credentials
+type CredentialController struct {
+	service service.AuthService
+	logger  logging.Logger
+}
+
+// NewCredentialController creates a new credential controller
+func NewCredentialController(service service.AuthService, logger logging.Logger) *CredentialController {
+	return &CredentialController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateCredential handles POST /api/credentials
+func (c *CredentialController) CreateCredential(ctx *gin.Context) {
+	var req models.CreateCredentialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for credential creation", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	if !req.Role.IsValid() {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_role", "role must be one of admin, publisher, viewer")
+		return
+	}
+
+	if !canManageTenant(ctx, req.TenantID) {
+		return
+	}
+
+	response, err := c.service.CreateCredential(ctx.Request.Context(), &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to create credential", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "credential_creation_failed", "Failed to create credential")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListCredentials handles GET /api/credentials
+func (c *CredentialController) ListCredentials(ctx *gin.Context) {
+	tenantID := ctx.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	response, err := c.service.ListCredentials(ctx.Request.Context(), tenantID, page, limit)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list credentials", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "credentials_listing_failed", "Failed to retrieve credentials")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RevokeCredential handles DELETE /api/credentials/:id
+func (c *CredentialController) RevokeCredential(ctx *gin.Context) {
+	credential, ok := c.loadCredential(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.RevokeCredential(ctx.Request.Context(), credential.ID); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to revoke credential", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "credential_revocation_failed", "Failed to revoke credential")
+		return
+	}
+
+	c.logger.Info(ctx.Request.Context(), "Credential revoked",
+		zap.String("credential_id", credential.ID.String()))
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Credential revoked successfully",
+	})
+}
+
+// IssueToken handles POST /api/credentials/:id/token
+func (c *CredentialController) IssueToken(ctx *gin.Context) {
+	credential, ok := c.loadCredential(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.IssueTokenRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	response, err := c.service.IssueToken(ctx.Request.Context(), credential.ID, req.TTLSeconds)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to issue token", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusBadRequest, "token_issue_failed", "Failed to issue token")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// loadCredential resolves the :id path parameter and checks the caller may manage the credential
+func (c *CredentialController) loadCredential(ctx *gin.Context) (*models.APICredential, bool) {
+	credentialID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_credential_id", "Invalid credential ID format")
+		return nil, false
+	}
+
+	credential, err := c.service.GetCredential(ctx.Request.Context(), credentialID)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusNotFound, "credential_not_found", "Credential not found")
+		return nil, false
+	}
+
+	if !canManageTenant(ctx, credential.TenantID) {
+		return nil, false
+	}
+
+	return credential, true
+}
+
+// canManageTenant rejects callers bound to one tenant from reading or managing another tenant's credentials,
+// settings, controls, data subjects and event streams
+// The bootstrap admin key is not bound to a tenant and may manage all of them
+func canManageTenant(ctx *gin.Context, tenantID string) bool {
+	principal := middleware.GetPrincipal(ctx)
+	if principal == nil || principal.TenantID == "" || principal.TenantID == tenantID {
+		return true
+	}
+
+	middleware.WriteProblem(ctx, http.StatusForbidden, "forbidden", "credential cannot access this tenant")
+	return false
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// writeTagged responds with the JSON of a response and its ETag, or with 304 and no body when the request's
+// If-None-Match lists the ETag, as it does when a client revalidates the copy it read before
+// The ETag hashes the JSON, so it changes with the resource's updated_at and version and with anything else
+// the response shows, such as the progress of a run's steps
+func writeTagged(c *gin.Context, logger logging.Logger, status int, response interface{}) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		logger.Error(c.Request.Context(), "Failed to encode response", zap.Error(err))
+		middleware.WriteProblem(c, http.StatusInternalServerError, "encoding_failed", "Failed to encode response")
+		return
+	}
+	writeBody(c, status, body, entityTag(body))
+}
+
+// writeBody responds with a JSON body and its ETag, or with 304 and no body when the request's If-None-Match
+// lists the ETag
+func writeBody(c *gin.Context, status int, body []byte, tag string) {
+	c.Header("ETag", tag)
+	if status == http.StatusOK && etagListed(c.GetHeader("If-None-Match"), tag, false) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// ifMatch checks the If-Match header of a request changing a resource against the ETag of the resource's
+// canonical response, the one writeShaped tags whatever fields were requested, and reports whether the change
+// may proceed: when the header is absent or lists the ETag
+// Responds with 412 when the resource changed since the client read it
+func ifMatch(c *gin.Context, logger logging.Logger, current interface{}) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return true
+	}
+
+	body, err := json.Marshal(current)
+	if err != nil {
+		logger.Error(c.Request.Context(), "Failed to encode response", zap.Error(err))
+		middleware.WriteProblem(c, http.StatusInternalServerError, "encoding_failed", "Failed to encode response")
+		return false
+	}
+
+	if !etagListed(header, entityTag(body), true) {
+		middleware.WriteProblem(c, http.StatusPreconditionFailed, "precondition_failed", "The resource changed since it was read; read it again and reapply the change")
+		return false
+	}
+	return true
+}
+
+// versionConflict responds with 409 when an update failed because the resource was changed since the lock
+// version it was based on, and reports whether it did
+func versionConflict(c *gin.Context, err error) bool {
+	if !errors.Is(err, service.ErrVersionConflict) {
+		return false
+	}
+	middleware.WriteError(c, err, http.StatusConflict, "version_conflict", service.ErrVersionConflict.Error())
+	return true
+}
+
+// entityTag returns the strong ETag of a response body
+func entityTag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagListed reports whether an If-None-Match or If-Match header is * or lists an ETag
+// If-Match compares strongly, so a weak ETag, prefixed W/, never matches; If-None-Match ignores the prefix
+func etagListed(header, tag string, strong bool) bool {
+	for _, listed := range strings.Split(header, ",") {
+		listed = strings.TrimSpace(listed)
+		if !strong {
+			listed = strings.TrimPrefix(listed, "W/")
+		}
+		if listed == "*" || listed == tag {
+			return true
+		}
+	}
+	return false
+}
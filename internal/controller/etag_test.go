@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type taggedChain struct {
+	ID    string            `json:"id"`
+	Name  string            `json:"name"`
+	Steps []taggedChainStep `json:"steps"`
+}
+
+type taggedChainStep struct {
+	Name string `json:"name"`
+}
+
+// TestShapedETagMatches tests that the ETag of a response read with ?fields= is the ETag of the whole
+// resource, so sending it back as If-Match lets the update through until the resource changes
+func TestShapedETagMatches(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chain := taggedChain{ID: "c1", Name: "orders", Steps: []taggedChainStep{{Name: "charge"}}}
+
+	engine := gin.New()
+	engine.GET("/chain", func(c *gin.Context) {
+		requested, ok := parseResponseFields(c, chainShape)
+		if !ok {
+			return
+		}
+		writeShaped(c, logging.Nop(), http.StatusOK, chain, requested)
+	})
+	engine.PUT("/chain", func(c *gin.Context) {
+		if ifMatch(c, logging.Nop(), chain) {
+			c.Status(http.StatusNoContent)
+		}
+	})
+
+	read := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	update := func(tag string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/chain", strings.NewReader("{}"))
+		req.Header.Set("If-Match", tag)
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	full := read("/chain")
+	shaped := read("/chain?fields=id")
+	assert.Equal(t, http.StatusOK, shaped.Code)
+	assert.NotContains(t, shaped.Body.String(), "orders")
+	assert.Equal(t, full.Header().Get("ETag"), shaped.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNoContent, update(shaped.Header().Get("ETag")))
+
+	chain.Name = "orders v2"
+	assert.Equal(t, http.StatusPreconditionFailed, update(shaped.Header().Get("ETag")))
+	assert.Equal(t, http.StatusNoContent, update(read("/chain?fields=name").Header().Get("ETag")))
+}
@@ -1,35 +1,30 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
 	"github.com/sakibcoolz/loki-suite/internal/models"
 	"github.com/sakibcoolz/loki-suite/internal/service"
 	"go.uber.org/zap"
 )
 
-var logger *zap.Logger
-
-func init() {
-	var err error
-	logger, err = zap.NewProduction()
-	if err != nil {
-		panic("Failed to initialize logger: " + err.Error())
-	}
-}
-
 // ExecutionChainController handles HTTP requests for execution chains
 type ExecutionChainController struct {
 	service service.ExecutionChainService
+	logger  logging.Logger
 }
 
 // NewExecutionChainController creates a new execution chain controller
-func NewExecutionChainController(service service.ExecutionChainService) *ExecutionChainController {
+func NewExecutionChainController(service service.ExecutionChainService, logger logging.Logger) *ExecutionChainController {
 	return &ExecutionChainController{
 		service: service,
+		logger:  logger,
 	}
 }
 
@@ -37,37 +32,28 @@ func NewExecutionChainController(service service.ExecutionChainService) *Executi
 func (c *ExecutionChainController) CreateChain(ctx *gin.Context) {
 	var req models.CreateExecutionChainRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		logger.Error("Invalid request for chain creation", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		c.logger.Error(ctx.Request.Context(), "Invalid request for chain creation", zap.Error(err))
+		invalidRequest(ctx, err)
 		return
 	}
 
 	// Validate steps
 	if len(req.Steps) == 0 {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "at least one step is required",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_request", "at least one step is required")
 		return
 	}
 
 	response, err := c.service.CreateChain(ctx.Request.Context(), &req)
 	if err != nil {
-		logger.Error("Failed to create execution chain", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "chain_creation_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to create execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_creation_failed", "Failed to create execution chain")
 		return
 	}
 
-	logger.Info("Execution chain created successfully",
+	c.logger.Info(ctx.Request.Context(), "Execution chain created successfully",
 		zap.String("chain_id", response.ChainID.String()),
 		zap.String("tenant_id", req.TenantID))
 
@@ -79,63 +65,56 @@ func (c *ExecutionChainController) GetChain(ctx *gin.Context) {
 	chainIDStr := ctx.Param("id")
 	chainID, err := uuid.Parse(chainIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_chain_id",
-			Message: "Invalid chain ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
 		return
 	}
 
-	chain, err := c.service.GetChain(ctx.Request.Context(), chainID)
+	requested, ok := parseResponseFields(ctx, chainShape)
+	if !ok {
+		return
+	}
+
+	chain, err := c.service.GetChain(ctx.Request.Context(), callerTenant(ctx), chainID)
 	if err != nil {
-		logger.Error("Failed to get execution chain", zap.Error(err))
-		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "chain_not_found",
-			Message: "Execution chain not found",
-			Code:    http.StatusNotFound,
-		})
+		c.logger.Error(ctx.Request.Context(), "Failed to get execution chain", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusNotFound, "chain_not_found", "Execution chain not found")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, chain)
+	writeShaped(ctx, c.logger, http.StatusOK, chain, requested)
 }
 
 // ListChains handles GET /api/execution-chains
 func (c *ExecutionChainController) ListChains(ctx *gin.Context) {
 	tenantID := ctx.Query("tenant_id")
 	if tenantID == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_tenant_id",
-			Message: "tenant_id query parameter is required",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-
-	if page < 1 {
-		page = 1
+	// Parse filters and pagination parameters
+	var filter models.ListFilter
+	page, ok := parseListQuery(ctx, &filter)
+	if !ok {
+		return
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	requested, ok := parseResponseFields(ctx, chainListShape)
+	if !ok {
+		return
 	}
 
-	response, err := c.service.ListChains(ctx.Request.Context(), tenantID, page, limit)
+	response, err := c.service.ListChains(ctx.Request.Context(), tenantID, filter, page)
+	if invalidListPage(ctx, err) {
+		return
+	}
 	if err != nil {
-		logger.Error("Failed to list execution chains", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "chains_listing_failed",
-			Message: "Failed to retrieve execution chains",
-			Code:    http.StatusInternalServerError,
-		})
+		c.logger.Error(ctx.Request.Context(), "Failed to list execution chains", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "chains_listing_failed", "Failed to retrieve execution chains")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	writeShaped(ctx, c.logger, http.StatusOK, response, requested)
 }
 
 // UpdateChain handles PUT /api/execution-chains/:id
@@ -143,36 +122,35 @@ func (c *ExecutionChainController) UpdateChain(ctx *gin.Context) {
 	chainIDStr := ctx.Param("id")
 	chainID, err := uuid.Parse(chainIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_chain_id",
-			Message: "Invalid chain ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
 		return
 	}
 
 	var req models.UpdateExecutionChainRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		logger.Error("Invalid request for chain update", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		c.logger.Error(ctx.Request.Context(), "Invalid request for chain update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	if !c.chainUnchanged(ctx, chainID) {
 		return
 	}
 
-	if err := c.service.UpdateChain(ctx.Request.Context(), chainID, &req); err != nil {
-		logger.Error("Failed to update execution chain", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "chain_update_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+	if err := c.service.UpdateChain(ctx.Request.Context(), callerTenant(ctx), chainID, &req); err != nil {
+		if versionConflict(ctx, err) {
+			return
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			middleware.WriteProblem(ctx, http.StatusNotFound, "chain_not_found", "Execution chain not found")
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to update execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_update_failed", "Failed to update execution chain")
 		return
 	}
 
-	logger.Info("Execution chain updated successfully",
+	c.logger.Info(ctx.Request.Context(), "Execution chain updated successfully",
 		zap.String("chain_id", chainID.String()))
 
 	ctx.JSON(http.StatusOK, models.SuccessResponse{
@@ -180,30 +158,59 @@ func (c *ExecutionChainController) UpdateChain(ctx *gin.Context) {
 	})
 }
 
+// UpdateChainSteps handles PUT /api/execution-chains/:id/steps
+func (c *ExecutionChainController) UpdateChainSteps(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	var req models.UpdateChainStepsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for chain steps update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	if !c.chainUnchanged(ctx, chainID) {
+		return
+	}
+
+	response, err := c.service.UpdateChainSteps(ctx.Request.Context(), callerTenant(ctx), chainID, &req)
+	if err != nil {
+		if versionConflict(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to update execution chain steps", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_steps_update_failed", "Failed to update execution chain steps")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // DeleteChain handles DELETE /api/execution-chains/:id
 func (c *ExecutionChainController) DeleteChain(ctx *gin.Context) {
 	chainIDStr := ctx.Param("id")
 	chainID, err := uuid.Parse(chainIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_chain_id",
-			Message: "Invalid chain ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
 		return
 	}
 
-	if err := c.service.DeleteChain(ctx.Request.Context(), chainID); err != nil {
-		logger.Error("Failed to delete execution chain", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "chain_deletion_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+	if err := c.service.DeleteChain(ctx.Request.Context(), callerTenant(ctx), chainID); err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			middleware.WriteProblem(ctx, http.StatusNotFound, "chain_not_found", "Execution chain not found")
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to delete execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_deletion_failed", "Failed to delete execution chain")
 		return
 	}
 
-	logger.Info("Execution chain deleted successfully",
+	c.logger.Info(ctx.Request.Context(), "Execution chain deleted successfully",
 		zap.String("chain_id", chainID.String()))
 
 	ctx.JSON(http.StatusOK, models.SuccessResponse{
@@ -211,50 +218,237 @@ func (c *ExecutionChainController) DeleteChain(ctx *gin.Context) {
 	})
 }
 
-// ExecuteChain handles POST /api/execution-chains/:id/execute
-func (c *ExecutionChainController) ExecuteChain(ctx *gin.Context) {
+// RestoreChain handles POST /api/execution-chains/:id/restore
+func (c *ExecutionChainController) RestoreChain(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	chain, err := c.service.RestoreChain(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to restore execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusNotFound, "chain_restore_failed", "Failed to restore execution chain")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Execution chain restored successfully",
+		Data:    chain,
+	})
+}
+
+// GetChainHistory handles GET /api/execution-chains/:id/history
+func (c *ExecutionChainController) GetChainHistory(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	response, err := c.service.GetChainHistory(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get execution chain history", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_history_failed", "Failed to get execution chain history")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// PauseChain handles POST /api/execution-chains/:id/pause
+func (c *ExecutionChainController) PauseChain(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	var req models.PauseChainRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	response, err := c.service.PauseChain(ctx.Request.Context(), callerTenant(ctx), chainID, &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to pause execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusConflict, "chain_pause_failed", "Failed to pause execution chain")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ActivateChain handles POST /api/execution-chains/:id/activate
+func (c *ExecutionChainController) ActivateChain(ctx *gin.Context) {
 	chainIDStr := ctx.Param("id")
 	chainID, err := uuid.Parse(chainIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_chain_id",
-			Message: "Invalid chain ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
 		return
 	}
 
-	var requestBody struct {
-		TriggerData map[string]interface{} `json:"trigger_data,omitempty"`
+	response, err := c.service.ActivateChain(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to activate execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusConflict, "chain_activate_failed", "Failed to activate execution chain")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetChainSchedule handles GET /api/execution-chains/:id/schedule
+func (c *ExecutionChainController) GetChainSchedule(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	response, err := c.service.GetChainSchedule(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get execution chain schedule", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_schedule_failed", "Failed to get execution chain schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// SetChainSchedule handles PUT /api/execution-chains/:id/schedule
+func (c *ExecutionChainController) SetChainSchedule(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	var req models.ChainScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	if !c.chainUnchanged(ctx, chainID) {
+		return
+	}
+
+	response, err := c.service.SetChainSchedule(ctx.Request.Context(), callerTenant(ctx), chainID, &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to set execution chain schedule", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_schedule_update_failed", "Failed to set execution chain schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetChainVersions handles GET /api/execution-chains/:id/versions
+func (c *ExecutionChainController) GetChainVersions(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
 	}
 
+	response, err := c.service.GetChainVersions(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get execution chain versions", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_versions_failed", "Failed to get execution chain versions")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RollbackChain handles POST /api/execution-chains/:id/versions/:version/rollback
+func (c *ExecutionChainController) RollbackChain(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_version", "Version must be a positive integer")
+		return
+	}
+
+	response, err := c.service.RollbackChain(ctx.Request.Context(), callerTenant(ctx), chainID, version)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to roll back execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_rollback_failed", "Failed to roll back execution chain")
+		return
+	}
+
+	c.logger.Info(ctx.Request.Context(), "Execution chain rolled back successfully",
+		zap.String("chain_id", chainID.String()),
+		zap.Int("restored_version", version),
+		zap.Int("version", response.Version))
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ExecuteChain handles POST /api/execution-chains/:id/execute
+func (c *ExecutionChainController) ExecuteChain(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	var requestBody models.ExecuteChainBody
 	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		logger.Error("Invalid request for chain execution", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		c.logger.Error(ctx.Request.Context(), "Invalid request for chain execution", zap.Error(err))
+		invalidRequest(ctx, err)
 		return
 	}
 
 	req := &models.ExecuteChainRequest{
 		ChainID:     chainID,
 		TriggerData: requestBody.TriggerData,
+		CallbackURL: requestBody.CallbackURL,
+		Priority:    requestBody.Priority,
+		Options:     requestBody.Options,
 	}
 
-	response, err := c.service.ExecuteChain(ctx.Request.Context(), req)
+	// A dry run or validation checks the chain and returns its plan instead of starting a run
+	if req.Options != nil && (req.Options.DryRun || req.Options.ValidationOnly) {
+		plan, err := c.service.DryRunChain(ctx.Request.Context(), callerTenant(ctx), req)
+		if err != nil {
+			c.logger.Error(ctx.Request.Context(), "Failed to dry run chain", zap.Error(err))
+			middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_dry_run_failed", "Failed to dry run chain")
+			return
+		}
+		ctx.JSON(http.StatusOK, plan)
+		return
+	}
+
+	response, err := c.service.ExecuteChain(ctx.Request.Context(), callerTenant(ctx), req)
 	if err != nil {
-		logger.Error("Failed to execute chain", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "chain_execution_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to execute chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_execution_failed", "Failed to execute chain")
 		return
 	}
 
-	logger.Info("Execution chain started successfully",
+	c.logger.Info(ctx.Request.Context(), "Execution chain started successfully",
 		zap.String("chain_id", chainID.String()),
 		zap.String("run_id", response.RunID.String()))
 
@@ -266,26 +460,246 @@ func (c *ExecutionChainController) GetChainRun(ctx *gin.Context) {
 	runIDStr := ctx.Param("runId")
 	runID, err := uuid.Parse(runIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_run_id",
-			Message: "Invalid run ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_run_id", "Invalid run ID format")
+		return
+	}
+
+	requested, ok := parseResponseFields(ctx, chainRunShape)
+	if !ok {
+		return
+	}
+
+	run, err := c.service.GetChainRun(ctx.Request.Context(), callerTenant(ctx), runID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get chain run", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusNotFound, "run_not_found", "Chain run not found")
+		return
+	}
+
+	writeShaped(ctx, c.logger, http.StatusOK, run, requested)
+}
+
+// GetChainRunOutputs handles GET /api/execution-chains/runs/:runId/outputs
+func (c *ExecutionChainController) GetChainRunOutputs(ctx *gin.Context) {
+	runIDStr := ctx.Param("runId")
+	runID, err := uuid.Parse(runIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_run_id", "Invalid run ID format")
+		return
+	}
+
+	outputs, err := c.service.GetChainRunOutputs(ctx.Request.Context(), callerTenant(ctx), runID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get chain run outputs", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusNotFound, "run_not_found", "Chain run not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, outputs)
+}
+
+// ResumeChainRun handles POST /api/execution-chains/runs/:runId/resume
+func (c *ExecutionChainController) ResumeChainRun(ctx *gin.Context) {
+	runID, ok := c.loadChainRunID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.ResumeChainRun(ctx.Request.Context(), runID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to resume chain run", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusConflict, "run_resume_failed", "Failed to resume chain run")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+// GetChainStats handles GET /api/execution-chains/:id/stats
+func (c *ExecutionChainController) GetChainStats(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	window, ok := statsWindow(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.GetChainStats(ctx.Request.Context(), callerTenant(ctx), chainID, window)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get chain stats", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusNotFound, "chain_stats_failed", "Failed to get chain stats")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetChainUsage handles GET /api/execution-chains/usage
+func (c *ExecutionChainController) GetChainUsage(ctx *gin.Context) {
+	tenantID := ctx.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	window, ok := statsWindow(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.GetChainUsage(ctx.Request.Context(), tenantID, window)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get chain usage",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_usage_failed", "Failed to get chain usage")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CancelChainRun handles POST /api/execution-chains/runs/:runId/cancel
+func (c *ExecutionChainController) CancelChainRun(ctx *gin.Context) {
+	runID, ok := c.loadChainRunID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CancelChainRunRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	response, err := c.service.CancelChainRun(ctx.Request.Context(), runID, req.Reason)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to cancel chain run", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusConflict, "run_cancel_failed", "Failed to cancel chain run")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RetryChainRun handles POST /api/execution-chains/runs/:runId/retry
+func (c *ExecutionChainController) RetryChainRun(ctx *gin.Context) {
+	runID, ok := c.loadChainRunID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.RetryChainRun(ctx.Request.Context(), runID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to retry chain run", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusConflict, "run_retry_failed", "Failed to retry chain run")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+// ApproveChainRun handles POST /api/execution-chains/runs/:runId/approve
+func (c *ExecutionChainController) ApproveChainRun(ctx *gin.Context) {
+	c.decideChainRun(ctx, models.ApprovalDecisionApproved)
+}
+
+// RejectChainRun handles POST /api/execution-chains/runs/:runId/reject
+func (c *ExecutionChainController) RejectChainRun(ctx *gin.Context) {
+	c.decideChainRun(ctx, models.ApprovalDecisionRejected)
+}
+
+// decideChainRun records an approval decision on the approval step a run is waiting on
+// The optional body carries the approver metadata; the credential used is recorded alongside
+func (c *ExecutionChainController) decideChainRun(ctx *gin.Context, decision models.ApprovalDecision) {
+	runID, ok := c.loadChainRunID(ctx)
+	if !ok {
 		return
 	}
 
-	run, err := c.service.GetChainRun(ctx.Request.Context(), runID)
+	var req models.ApprovalDecisionRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	approval := models.StepApproval{
+		Approver: req.Approver,
+		Comment:  req.Comment,
+		Metadata: req.Metadata,
+	}
+	if principal := middleware.GetPrincipal(ctx); principal != nil && principal.CredentialID != uuid.Nil {
+		credentialID := principal.CredentialID
+		approval.CredentialID = &credentialID
+	}
+
+	decide, errCode := c.service.ApproveChainRun, "run_approve_failed"
+	if decision == models.ApprovalDecisionRejected {
+		decide, errCode = c.service.RejectChainRun, "run_reject_failed"
+	}
+
+	response, err := decide(ctx.Request.Context(), runID, approval)
 	if err != nil {
-		logger.Error("Failed to get chain run", zap.Error(err))
-		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "run_not_found",
-			Message: "Chain run not found",
-			Code:    http.StatusNotFound,
-		})
+		c.logger.Error(ctx.Request.Context(), "Failed to record approval decision",
+			zap.String("run_id", runID.String()),
+			zap.String("decision", string(decision)),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusConflict, errCode, "Failed to record approval decision")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, run)
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+// loadChainRunID parses the :runId path parameter and checks the run exists and belongs to the caller's tenant
+func (c *ExecutionChainController) loadChainRunID(ctx *gin.Context) (uuid.UUID, bool) {
+	runID, err := uuid.Parse(ctx.Param("runId"))
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_run_id", "Invalid run ID format")
+		return uuid.Nil, false
+	}
+
+	if _, err := c.service.GetChainRun(ctx.Request.Context(), callerTenant(ctx), runID); err != nil {
+		middleware.WriteProblem(ctx, http.StatusNotFound, "run_not_found", "Chain run not found")
+		return uuid.Nil, false
+	}
+
+	return runID, true
+}
+
+// chainUnchanged checks the If-Match header of a chain update against the ETag of GET /api/execution-chains/:id,
+// so concurrent edits of a chain do not silently overwrite each other, and reports whether the update may
+// proceed; responds with 404 or 412 otherwise
+func (c *ExecutionChainController) chainUnchanged(ctx *gin.Context, chainID uuid.UUID) bool {
+	if ctx.GetHeader("If-Match") == "" {
+		return true
+	}
+
+	chain, err := c.service.GetChain(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusNotFound, "chain_not_found", "Execution chain not found")
+		return false
+	}
+	return ifMatch(ctx, c.logger, chain)
+}
+
+// callerTenant returns the tenant of the caller's credential, which its reads and changes of chains, runs and
+// webhooks are scoped to; empty for global credentials and deployments without authentication, which reach the
+// chains, runs and webhooks of every tenant
+func callerTenant(ctx *gin.Context) string {
+	if principal := middleware.GetPrincipal(ctx); principal != nil {
+		return principal.TenantID
+	}
+	return ""
 }
 
 // ListChainRuns handles GET /api/execution-chains/:id/runs
@@ -293,35 +707,126 @@ func (c *ExecutionChainController) ListChainRuns(ctx *gin.Context) {
 	chainIDStr := ctx.Param("id")
 	chainID, err := uuid.Parse(chainIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_chain_id",
-			Message: "Invalid chain ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
+		return
+	}
+
+	// Parse filters and pagination parameters
+	var filter models.ListFilter
+	page, ok := parseListQuery(ctx, &filter)
+	if !ok {
+		return
+	}
+
+	requested, ok := parseResponseFields(ctx, chainRunListShape)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.ListChainRuns(ctx.Request.Context(), callerTenant(ctx), chainID, filter, page)
+	if invalidListPage(ctx, err) {
+		return
+	}
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list chain runs", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "runs_listing_failed", "Failed to retrieve chain runs")
+		return
+	}
+
+	writeShaped(ctx, c.logger, http.StatusOK, response, requested)
+}
+
+// ExportChain handles GET /api/execution-chains/:id/export
+func (c *ExecutionChainController) ExportChain(ctx *gin.Context) {
+	chainIDStr := ctx.Param("id")
+	chainID, err := uuid.Parse(chainIDStr)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_chain_id", "Invalid chain ID format")
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	template, err := c.service.ExportChain(ctx.Request.Context(), callerTenant(ctx), chainID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to export execution chain", zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusNotFound, "chain_export_failed", "Failed to export execution chain")
+		return
+	}
+
+	writeDocument(ctx, c.logger, http.StatusOK, template)
+}
+
+// ImportChain handles POST /api/execution-chains/import
+func (c *ExecutionChainController) ImportChain(ctx *gin.Context) {
+	var template models.ChainTemplate
+	if err := bindDocument(ctx, &template); err != nil {
+		c.logger.Warn(ctx.Request.Context(), "Invalid chain import request", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
 
-	if page < 1 {
-		page = 1
+	req := models.InstantiateChainTemplateRequest{
+		TenantID:     ctx.Query("tenant_id"),
+		Name:         ctx.Query("name"),
+		TriggerEvent: ctx.Query("trigger_event"),
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if req.TenantID == "" {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
 	}
 
-	response, err := c.service.ListChainRuns(ctx.Request.Context(), chainID, page, limit)
+	c.createFromTemplate(ctx, "", func() (*models.CreateExecutionChainResponse, error) {
+		return c.service.ImportChain(ctx.Request.Context(), &template, &req)
+	})
+}
+
+// ListChainTemplates handles GET /api/chain-templates
+func (c *ExecutionChainController) ListChainTemplates(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.service.ListChainTemplates())
+}
+
+// GetChainTemplate handles GET /api/chain-templates/:id
+func (c *ExecutionChainController) GetChainTemplate(ctx *gin.Context) {
+	template, err := c.service.GetChainTemplate(ctx.Param("id"))
 	if err != nil {
-		logger.Error("Failed to list chain runs", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "runs_listing_failed",
-			Message: "Failed to retrieve chain runs",
-			Code:    http.StatusInternalServerError,
-		})
+		middleware.WriteError(ctx, err, http.StatusNotFound, "template_not_found", service.ErrChainTemplateNotFound.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	writeDocument(ctx, c.logger, http.StatusOK, template)
+}
+
+// InstantiateChainTemplate handles POST /api/chain-templates/:id/instantiate
+func (c *ExecutionChainController) InstantiateChainTemplate(ctx *gin.Context) {
+	var req models.InstantiateChainTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	templateID := ctx.Param("id")
+	c.createFromTemplate(ctx, templateID, func() (*models.CreateExecutionChainResponse, error) {
+		return c.service.InstantiateChainTemplate(ctx.Request.Context(), templateID, &req)
+	})
+}
+
+// createFromTemplate responds with the chain created from a template, or the error creating it: 404 for
+// unknown catalog templates, tenant errors as for CreateChain and 400 for templates that do not fit the tenant
+func (c *ExecutionChainController) createFromTemplate(ctx *gin.Context, templateID string, create func() (*models.CreateExecutionChainResponse, error)) {
+	response, err := create()
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Warn(ctx.Request.Context(), "Failed to create execution chain from template",
+			zap.Error(err),
+			zap.String("template_id", templateID))
+		middleware.WriteError(ctx, err, http.StatusBadRequest, "chain_import_failed", "Failed to create execution chain from template")
+		return
+	}
+
+	c.logger.Info(ctx.Request.Context(), "Execution chain created from template",
+		zap.String("chain_id", response.ChainID.String()),
+		zap.String("template_id", templateID))
+
+	ctx.JSON(http.StatusCreated, response)
 }
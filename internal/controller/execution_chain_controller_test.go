@@ -0,0 +1,205 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/controller"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+	"github.com/sakibcoolz/zcornor/pkg/config"
+)
+
+// tenantAdmins authenticates the API key as an admin of the tenant it names
+type tenantAdmins struct{}
+
+func (tenantAdmins) AuthenticateAPIKey(_ context.Context, apiKey string) (*models.Principal, error) {
+	return &models.Principal{TenantID: apiKey, Role: models.RoleAdmin}, nil
+}
+
+func (tenantAdmins) AuthenticateToken(context.Context, string) (*models.Principal, error) {
+	return nil, nil
+}
+
+// TestExecutionChainController_CrossTenant tests that a caller of one tenant gets 404 for the chains and runs
+// of another tenant on every chain endpoint, as if they did not exist, and sees none of their runs
+func TestExecutionChainController_CrossTenant(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	chainRepo := repository.NewMemoryExecutionChainRepository(store)
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	svc := service.NewExecutionChainService(chainRepo, repository.NewMemoryWebhookRepository(store), mocks.NewMockTenantRepository(t),
+		historyRepo, nil, &config.Config{}, logging.Nop())
+
+	chain := &models.ExecutionChain{TenantID: "tenant-a", Name: "Orders", TriggerEvent: "order.created", IsActive: true, Version: 1}
+	require.NoError(t, chainRepo.CreateChain(ctx, chain))
+	run := &models.ExecutionChainRun{ChainID: chain.ID, TenantID: "tenant-a", Status: models.ExecutionChainStatusCompleted}
+	require.NoError(t, chainRepo.CreateChainRun(ctx, run))
+	deleted := &models.ExecutionChain{TenantID: "tenant-a", Name: "Legacy", TriggerEvent: "order.created"}
+	require.NoError(t, chainRepo.CreateChain(ctx, deleted))
+	require.NoError(t, chainRepo.DeleteChain(ctx, deleted.ID))
+	historyRepo.On("GetSnapshots", mock.Anything, models.ConfigResourceChain, chain.ID).
+		Return([]models.ConfigSnapshot{{TenantID: "tenant-a", ResourceType: models.ConfigResourceChain, ResourceID: chain.ID, Version: 1, Config: "{}"}}, nil).Maybe()
+
+	chains := controller.NewExecutionChainController(svc, logging.Nop())
+	engine := gin.New()
+	api := engine.Group("/api/execution-chains", middleware.RequireRole(tenantAdmins{}, models.RoleViewer, nil, logging.Nop()))
+	api.PUT("/:id/steps", chains.UpdateChainSteps)
+	api.POST("/:id/restore", chains.RestoreChain)
+	api.GET("/:id/history", chains.GetChainHistory)
+	api.POST("/:id/pause", chains.PauseChain)
+	api.POST("/:id/activate", chains.ActivateChain)
+	api.GET("/:id/schedule", chains.GetChainSchedule)
+	api.PUT("/:id/schedule", chains.SetChainSchedule)
+	api.GET("/:id/versions", chains.GetChainVersions)
+	api.POST("/:id/versions/:version/rollback", chains.RollbackChain)
+	api.POST("/:id/execute", chains.ExecuteChain)
+	api.GET("/:id/stats", chains.GetChainStats)
+	api.GET("/:id/runs", chains.ListChainRuns)
+	api.GET("/:id/export", chains.ExportChain)
+	api.GET("/runs/:runId/outputs", chains.GetChainRunOutputs)
+
+	call := func(tenantID, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/execution-chains"+path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", tenantID)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	chainPath := "/" + chain.ID.String()
+	steps := `{"steps": [{"name": "Charge", "webhook_id": "` + uuid.NewString() + `"}], "lock_version": 1}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "update steps", method: http.MethodPut, path: chainPath + "/steps", body: steps},
+		{name: "restore", method: http.MethodPost, path: "/" + deleted.ID.String() + "/restore"},
+		{name: "history", method: http.MethodGet, path: chainPath + "/history"},
+		{name: "pause", method: http.MethodPost, path: chainPath + "/pause"},
+		{name: "activate", method: http.MethodPost, path: chainPath + "/activate"},
+		{name: "get schedule", method: http.MethodGet, path: chainPath + "/schedule"},
+		{name: "set schedule", method: http.MethodPut, path: chainPath + "/schedule", body: `{"schedule": "@daily"}`},
+		{name: "versions", method: http.MethodGet, path: chainPath + "/versions"},
+		{name: "rollback", method: http.MethodPost, path: chainPath + "/versions/2/rollback"},
+		{name: "execute", method: http.MethodPost, path: chainPath + "/execute", body: `{}`},
+		{name: "dry run", method: http.MethodPost, path: chainPath + "/execute", body: `{"execution_options": {"dry_run": true}}`},
+		{name: "stats", method: http.MethodGet, path: chainPath + "/stats"},
+		{name: "export", method: http.MethodGet, path: chainPath + "/export"},
+		{name: "run outputs", method: http.MethodGet, path: "/runs/" + run.ID.String() + "/outputs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			recorder := call("tenant-b", tt.method, tt.path, tt.body)
+
+			// Assert
+			assert.Equal(t, http.StatusNotFound, recorder.Code, recorder.Body.String())
+		})
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		for _, path := range []string{"/history", "/schedule", "/versions", "/stats", "/export"} {
+			assert.Equal(t, http.StatusOK, call("tenant-a", http.MethodGet, chainPath+path, "").Code, path)
+		}
+		assert.Equal(t, http.StatusOK, call("tenant-a", http.MethodGet, "/runs/"+run.ID.String()+"/outputs", "").Code)
+	})
+
+	t.Run("list runs", func(t *testing.T) {
+		var owned, foreign models.ExecutionChainRunsResponse
+		require.NoError(t, json.Unmarshal(call("tenant-a", http.MethodGet, chainPath+"/runs", "").Body.Bytes(), &owned))
+		require.NoError(t, json.Unmarshal(call("tenant-b", http.MethodGet, chainPath+"/runs", "").Body.Bytes(), &foreign))
+		assert.Equal(t, int64(1), owned.Total)
+		assert.Equal(t, int64(0), foreign.Total)
+		assert.Empty(t, foreign.Runs)
+	})
+
+	restored, err := chainRepo.GetChainByID(ctx, deleted.ID)
+	assert.Error(t, err, "a cross-tenant restore must leave the chain deleted")
+	assert.Nil(t, restored)
+}
+
+// TestExecutionChainController_DryRun tests that an execution with dry_run or validation_only returns the plan of
+// the chain without starting a run, and that a plan reporting problems or a rejected request comes back as such
+func TestExecutionChainController_DryRun(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	chainID := uuid.New()
+	chainSvc := mocks.NewMockExecutionChainService(t)
+	chainSvc.EXPECT().DryRunChain(mock.Anything, "tenant-a", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, req *models.ExecuteChainRequest) (*models.ChainDryRunResponse, error) {
+			if _, ok := req.Options.SimulatedResponses["charge"]; ok {
+				return nil, service.ErrInvalidRun.Withf(`simulated_responses: key "charge" must name a step as step_N`)
+			}
+			mode := "dry_run"
+			if req.Options.ValidationOnly {
+				mode = "validation_only"
+			}
+			return &models.ChainDryRunResponse{ChainID: req.ChainID, Mode: mode, Steps: []models.DryRunStep{
+				{StepOrder: 1, Name: "Reserve", Action: "call", Errors: []string{"webhook is not active"}},
+			}}, nil
+		})
+	chains := controller.NewExecutionChainController(chainSvc, logging.Nop())
+	engine := gin.New()
+	engine.POST("/api/execution-chains/:id/execute", middleware.RequireRole(tenantAdmins{}, models.RoleAdmin, nil, logging.Nop()), chains.ExecuteChain)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+		mode string
+	}{
+		{name: "dry run", body: `{"execution_options": {"dry_run": true}}`, want: http.StatusOK, mode: "dry_run"},
+		{name: "validation only", body: `{"execution_options": {"validation_only": true}}`, want: http.StatusOK, mode: "validation_only"},
+		{name: "invalid options", body: `{"execution_options": {"dry_run": true, "simulated_responses": {"charge": {}}}}`,
+			want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/execution-chains/"+chainID.String()+"/execute", strings.NewReader(tt.body))
+			req.Header.Set("X-API-Key", "tenant-a")
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+
+			// Act
+			engine.ServeHTTP(recorder, req)
+
+			// Assert
+			require.Equal(t, tt.want, recorder.Code, recorder.Body.String())
+			if tt.mode == "" {
+				assert.Contains(t, recorder.Body.String(), `simulated_responses: key \"charge\" must name a step as step_N`)
+				return
+			}
+			var plan models.ChainDryRunResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &plan))
+			assert.Equal(t, tt.mode, plan.Mode)
+			assert.Equal(t, chainID, plan.ChainID)
+			require.Len(t, plan.Steps, 1)
+			assert.Equal(t, []string{"webhook is not active"}, plan.Steps[0].Errors)
+		})
+	}
+	chainSvc.AssertNotCalled(t, "ExecuteChain", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"go.uber.org/zap"
+)
+
+// resourceShape describes how responses of a resource are shaped
+type resourceShape struct {
+	// collection is the key of the resources in list responses; empty for responses of a single resource
+	collection string
+
+	// relations are the dotted paths of nested objects left out unless named by the include parameter, such as
+	// the webhook subscriptions of chain steps, whose target URLs, headers and query parameters often carry
+	// credentials
+	relations []string
+}
+
+// Shapes of the shaped resources
+var (
+	chainShape          = resourceShape{relations: []string{"steps.webhook", "steps.compensation_webhook"}}
+	chainRunShape       = resourceShape{relations: []string{"step_runs.step.webhook", "step_runs.step.compensation_webhook"}}
+	chainListShape      = resourceShape{collection: "chains", relations: chainShape.relations}
+	chainRunListShape   = resourceShape{collection: "runs", relations: chainRunShape.relations}
+	webhookShape        = resourceShape{}
+	webhookListShape    = resourceShape{collection: "webhooks"}
+	adminEventListShape = resourceShape{collection: "events"}
+)
+
+// responseFields are the fields and optional relations a request asked for
+type responseFields struct {
+	shape resourceShape
+
+	// fields are the dotted paths of the fields to keep; nil keeps every field
+	fields []string
+
+	// include are the optional relations to keep
+	include []string
+}
+
+// parseResponseFields reads the fields and include parameters of a request for a resource
+// fields lists the dotted paths of the resource's fields to return, such as fields=id,status,step_runs.status;
+// include lists optional relations to return. Responds with 400 and returns false as ok for an unknown relation
+func parseResponseFields(c *gin.Context, shape resourceShape) (responseFields, bool) {
+	requested := responseFields{shape: shape, fields: queryList(c, "fields"), include: queryList(c, "include")}
+	for _, relation := range requested.include {
+		if !slices.Contains(shape.relations, relation) {
+			message := "the resource has no optional relations"
+			if len(shape.relations) > 0 {
+				message = "include must name one of: " + strings.Join(shape.relations, ", ")
+			}
+			middleware.WriteProblem(c, http.StatusBadRequest, "invalid_include", message)
+			return requested, false
+		}
+	}
+	return requested, true
+}
+
+// queryList reads a comma separated query parameter, nil when absent
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// writeShaped responds with the JSON of a response keeping only the requested fields and relations of its
+// resources, tagged with the ETag of the whole response, so a client reading a few fields can still send the
+// ETag back as the If-Match of an update
+func writeShaped(c *gin.Context, logger logging.Logger, status int, response interface{}, requested responseFields) {
+	canonical, err := json.Marshal(response)
+	var body []byte
+	if err == nil {
+		var shaped interface{}
+		if shaped, err = shapeResponse(response, requested); err == nil {
+			body, err = json.Marshal(shaped)
+		}
+	}
+	if err != nil {
+		logger.Error(c.Request.Context(), "Failed to encode response", zap.Error(err))
+		middleware.WriteProblem(c, http.StatusInternalServerError, "encoding_failed", "Failed to encode response")
+		return
+	}
+	writeBody(c, status, body, entityTag(canonical))
+}
+
+// shapeResponse returns the generic JSON form of a response keeping only the requested fields and relations of
+// its resources; the other fields of list responses, such as total and next_cursor, are always kept
+func shapeResponse(response interface{}, requested responseFields) (interface{}, error) {
+	tree, err := jsonTree(response)
+	if err != nil {
+		return nil, err
+	}
+
+	shape := func(resource interface{}) interface{} {
+		for _, relation := range requested.shape.relations {
+			if !slices.Contains(requested.include, relation) {
+				removePath(resource, strings.Split(relation, "."))
+			}
+		}
+		if requested.fields == nil {
+			return resource
+		}
+		return selectFields(resource, requested.fields)
+	}
+
+	if requested.shape.collection == "" {
+		return shape(tree), nil
+	}
+	if envelope, ok := tree.(map[string]interface{}); ok {
+		if resources, ok := envelope[requested.shape.collection].([]interface{}); ok {
+			for i, resource := range resources {
+				resources[i] = shape(resource)
+			}
+		}
+	}
+	return tree, nil
+}
+
+// jsonTree converts a value to its generic JSON form, keeping numbers exact
+func jsonTree(value interface{}) (interface{}, error) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// removePath removes the field at a path from an object, and from every object of the arrays along the path
+func removePath(node interface{}, path []string) {
+	switch value := node.(type) {
+	case []interface{}:
+		for _, item := range value {
+			removePath(item, path)
+		}
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(value, path[0])
+			return
+		}
+		removePath(value[path[0]], path[1:])
+	}
+}
+
+// selectFields returns a copy of an object, or of every object of an array, with only the fields at paths
+// A path naming an object keeps all of it; paths of fields the object lacks are ignored
+func selectFields(node interface{}, paths []string) interface{} {
+	switch value := node.(type) {
+	case []interface{}:
+		selected := make([]interface{}, len(value))
+		for i, item := range value {
+			selected[i] = selectFields(item, paths)
+		}
+		return selected
+	case map[string]interface{}:
+		nested := make(map[string][]string)
+		selected := make(map[string]interface{})
+		for _, path := range paths {
+			name, rest, deeper := strings.Cut(path, ".")
+			field, ok := value[name]
+			if !ok {
+				continue
+			}
+			if !deeper {
+				selected[name] = field
+				nested[name] = nil
+				continue
+			}
+			// A field kept whole, recorded with nil subpaths, stays whole
+			if subpaths, present := nested[name]; !present || subpaths != nil {
+				nested[name] = append(subpaths, rest)
+			}
+		}
+		for name, subpaths := range nested {
+			if subpaths != nil {
+				selected[name] = selectFields(value[name], subpaths)
+			}
+		}
+		return selected
+	}
+	return node
+}
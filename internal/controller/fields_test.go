@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteShaped tests that list responses keep only the requested fields of their resources and the rest of
+// the envelope, and that optional relations such as step webhooks are left out unless included
+func TestWriteShaped(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainID := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	list := models.ExecutionChainListResponse{
+		Chains: []models.ExecutionChain{{
+			ID:   chainID,
+			Name: "orders",
+			Steps: []models.ExecutionChainStep{{
+				Name:    "charge",
+				Webhook: &models.WebhookSubscription{AppName: "billing", TargetURL: "https://billing.example.com/hooks?token=secret"},
+			}},
+		}},
+		Total: 1,
+	}
+
+	engine := gin.New()
+	engine.GET("/chains", func(c *gin.Context) {
+		requested, ok := parseResponseFields(c, chainListShape)
+		if !ok {
+			return
+		}
+		writeShaped(c, logging.Nop(), http.StatusOK, list, requested)
+	})
+	engine.GET("/webhooks", func(c *gin.Context) {
+		if _, ok := parseResponseFields(c, webhookListShape); ok {
+			c.Status(http.StatusNoContent)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		want   string
+	}{
+		{name: "relations left out", path: "/chains?fields=steps.name,steps.webhook", status: http.StatusOK,
+			want: `{"chains":[{"steps":[{"name":"charge"}]}],"limit":0,"page":0,"total":1}`},
+		{name: "nested fields", path: "/chains?fields=id,steps.name", status: http.StatusOK,
+			want: `{"chains":[{"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7","steps":[{"name":"charge"}]}],"limit":0,"page":0,"total":1}`},
+		{name: "included relation", path: "/chains?fields=name,steps.webhook.app_name&include=steps.webhook", status: http.StatusOK,
+			want: `{"chains":[{"name":"orders","steps":[{"webhook":{"app_name":"billing"}}]}],"limit":0,"page":0,"total":1}`},
+		{name: "unknown fields ignored", path: "/chains?fields=id,nope", status: http.StatusOK,
+			want: `{"chains":[{"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7"}],"limit":0,"page":0,"total":1}`},
+		{name: "unknown relation", path: "/chains?include=steps.secret", status: http.StatusBadRequest,
+			want: "include must name one of: steps.webhook, steps.compensation_webhook"},
+		{name: "resource without relations", path: "/webhooks?include=subscription", status: http.StatusBadRequest,
+			want: "the resource has no optional relations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status != http.StatusOK {
+				var problem models.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				assert.Equal(t, "invalid_include", problem.Code)
+				assert.Equal(t, tt.want, problem.Detail)
+				return
+			}
+			assert.JSONEq(t, tt.want, w.Body.String())
+			assert.NotContains(t, w.Body.String(), "token=secret")
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlContentType is the content type of YAML request and response bodies
+const yamlContentType = "application/yaml"
+
+// errInvalidYAML is returned by bindDocument for YAML bodies that do not parse
+var errInvalidYAML = apperr.Validation("invalid_request", "Request body is not valid YAML")
+
+// wantsYAML reports whether a request asks for a YAML response, with format=yaml or an Accept header
+// naming YAML; format=json wins over the Accept header
+func wantsYAML(c *gin.Context) bool {
+	switch c.Query("format") {
+	case "yaml", "yml":
+		return true
+	case "json":
+		return false
+	}
+	return strings.Contains(c.GetHeader("Accept"), "yaml")
+}
+
+// writeDocument responds with a document as JSON, or as YAML when the request asks for it
+// YAML documents have the same field names as JSON ones
+func writeDocument(c *gin.Context, logger logging.Logger, status int, document interface{}) {
+	if !wantsYAML(c) {
+		c.JSON(status, document)
+		return
+	}
+
+	body, err := toYAML(document)
+	if err != nil {
+		logger.Error(c.Request.Context(), "Failed to encode response", zap.Error(err))
+		middleware.WriteProblem(c, http.StatusInternalServerError, "encoding_failed", "Failed to encode response")
+		return
+	}
+	c.Data(status, yamlContentType, body)
+}
+
+// toYAML encodes a document as YAML through its JSON form, so the JSON field names apply
+func toYAML(document interface{}) ([]byte, error) {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	var tree interface{}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	return yaml.Marshal(tree)
+}
+
+// bindDocument decodes a request body sent as JSON, or as YAML when its Content-Type names YAML
+func bindDocument(c *gin.Context, document interface{}) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	if strings.Contains(c.ContentType(), "yaml") {
+		var tree interface{}
+		if err := yaml.Unmarshal(body, &tree); err != nil {
+			return errInvalidYAML.Wrap(err)
+		}
+		if body, err = json.Marshal(tree); err != nil {
+			return errInvalidYAML.Wrap(err)
+		}
+	}
+	if err := json.Unmarshal(body, document); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+	return nil
+}
+
+// queryBool reads a boolean query parameter, false when absent
+// Responds with 400 and returns false as ok when the value is not a boolean
+func queryBool(c *gin.Context, name string) (value bool, ok bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return false, true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_"+name, name+" must be true or false")
+		return false, false
+	}
+	return value, true
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+)
+
+// parseListQuery binds the filters of a listing into filter and reads its page, cursor and sort parameters
+// Responds with 400 and returns false as ok when a filter or the sort is invalid
+func parseListQuery(ctx *gin.Context, filter interface{}) (models.ListPage, bool) {
+	var page models.ListPage
+	err := ctx.ShouldBindQuery(filter)
+	if err == nil {
+		err = ctx.ShouldBindQuery(&page)
+	}
+	if err != nil {
+		invalidRequest(ctx, err)
+		return page, false
+	}
+
+	page.Page, page.Limit = parsePagination(ctx)
+	return page, true
+}
+
+// invalidListPage responds with 400 when a listing failed for a cursor or sort the list does not accept, and
+// reports whether it did
+func invalidListPage(ctx *gin.Context, err error) bool {
+	if !errors.Is(err, service.ErrInvalidListPage) {
+		return false
+	}
+	middleware.WriteError(ctx, err, http.StatusBadRequest, "invalid_list_page", service.ErrInvalidListPage.Error())
+	return true
+}
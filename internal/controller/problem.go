@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+)
+
+// invalidRequest responds with 400 to a request whose body or query could not be bound, describing what is
+// wrong with it without echoing the decoder's error; typed errors are answered with their own code and message
+func invalidRequest(ctx *gin.Context, err error) {
+	middleware.WriteError(ctx, err, http.StatusBadRequest, "invalid_request", bindingDetail(err))
+}
+
+// bindingDetail returns the detail of a binding error safe to show clients
+func bindingDetail(err error) string {
+	var validationErrs validator.ValidationErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var numErr *strconv.NumError
+	switch {
+	case errors.As(err, &validationErrs) && len(validationErrs) > 0:
+		return fmt.Sprintf("Field %s failed the '%s' rule", validationErrs[0].Field(), validationErrs[0].Tag())
+	case errors.As(err, &typeErr):
+		return fmt.Sprintf("Field %s must be of type %s", typeErr.Field, typeErr.Type)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return "Request body is not valid JSON"
+	case errors.Is(err, io.EOF):
+		return "Request body is empty"
+	case errors.As(err, &numErr):
+		return fmt.Sprintf("%q is not a valid number", numErr.Num)
+	default:
+		return "Request is malformed"
+	}
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// Sandbox receiver defaults
+const (
+	DefaultSandboxMaxDelay    = 60 * time.Second
+	defaultSandboxDelay       = time.Second
+	defaultSandboxFailureRate = 0.5
+	defaultSandboxFailureCode = http.StatusServiceUnavailable
+)
+
+// SandboxController serves the built-in receivers subscriptions can point at for load and failure-mode
+// testing without standing up a mock server
+type SandboxController struct {
+	maxDelay time.Duration
+}
+
+// NewSandboxController creates a new sandbox controller; maxDelay caps the delay /sandbox/slow accepts,
+// DefaultSandboxMaxDelay when zero
+func NewSandboxController(maxDelay time.Duration) *SandboxController {
+	if maxDelay <= 0 {
+		maxDelay = DefaultSandboxMaxDelay
+	}
+	return &SandboxController{
+		maxDelay: maxDelay,
+	}
+}
+
+// Success handles POST /sandbox/success
+func (c *SandboxController) Success(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, models.SandboxResponse{Status: "ok", Receiver: "success"})
+}
+
+// Flaky handles POST /sandbox/flaky
+// ?rate= is the share of requests failed (0 to 1), ?status= the status code they fail with
+func (c *SandboxController) Flaky(ctx *gin.Context) {
+	rate := defaultSandboxFailureRate
+	if value := ctx.Query("rate"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			writeSandboxError(ctx, "invalid_rate", "rate must be a number between 0 and 1")
+			return
+		}
+		rate = parsed
+	}
+
+	status := defaultSandboxFailureCode
+	if value := ctx.Query("status"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 400 || parsed > 599 {
+			writeSandboxError(ctx, "invalid_status", "status must be an HTTP error code between 400 and 599")
+			return
+		}
+		status = parsed
+	}
+
+	if rand.Float64() < rate {
+		ctx.JSON(status, models.SandboxResponse{Status: "failed", Receiver: "flaky"})
+		return
+	}
+	ctx.JSON(http.StatusOK, models.SandboxResponse{Status: "ok", Receiver: "flaky"})
+}
+
+// Slow handles POST /sandbox/slow
+// ?delay= is a Go duration such as 500ms or 5s, at most the controller's maximum delay
+func (c *SandboxController) Slow(ctx *gin.Context) {
+	delay := defaultSandboxDelay
+	if value := ctx.Query("delay"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			writeSandboxError(ctx, "invalid_delay", "delay must be a duration such as 500ms or 5s")
+			return
+		}
+		if parsed > c.maxDelay {
+			writeSandboxError(ctx, "invalid_delay", "delay must not exceed "+c.maxDelay.String())
+			return
+		}
+		delay = parsed
+	}
+
+	// The sender giving up ends the wait early instead of holding the connection
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Request.Context().Done():
+		return
+	case <-timer.C:
+	}
+
+	delayedMs := delay.Milliseconds()
+	ctx.JSON(http.StatusOK, models.SandboxResponse{Status: "ok", Receiver: "slow", DelayedMs: &delayedMs})
+}
+
+// Echo handles POST /sandbox/echo
+func (c *SandboxController) Echo(ctx *gin.Context) {
+	raw, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		writeSandboxError(ctx, "invalid_body", "Failed to read request body")
+		return
+	}
+
+	var body interface{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &body); err != nil {
+			body = string(raw)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.SandboxEchoResponse{
+		Method:  ctx.Request.Method,
+		Path:    ctx.Request.URL.Path,
+		Query:   ctx.Request.URL.Query(),
+		Headers: ctx.Request.Header,
+		Body:    body,
+	})
+}
+
+// writeSandboxError rejects invalid sandbox parameters with 400
+func writeSandboxError(ctx *gin.Context, code, message string) {
+	middleware.WriteProblem(ctx, http.StatusBadRequest, code, message)
+}
@@ -0,0 +1,138 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/controller"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// newSandboxEngine routes the sandbox receivers to a controller with the maximum delay
+func newSandboxEngine(maxDelay time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	sandbox := controller.NewSandboxController(maxDelay)
+	engine := gin.New()
+	engine.POST("/sandbox/success", sandbox.Success)
+	engine.POST("/sandbox/flaky", sandbox.Flaky)
+	engine.POST("/sandbox/slow", sandbox.Slow)
+	engine.POST("/sandbox/echo", sandbox.Echo)
+	return engine
+}
+
+// postSandbox sends a POST request with the body to a sandbox receiver
+func postSandbox(engine *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestSandboxController_Flaky tests that the flaky receiver always fails with the requested status at a rate
+// of 1, never fails at a rate of 0, and rejects rates and statuses out of range
+func TestSandboxController_Flaky(t *testing.T) {
+	// Arrange
+	engine := newSandboxEngine(0)
+
+	tests := []struct {
+		query  string
+		status int
+		want   string
+	}{
+		{query: "?rate=1&status=429", status: http.StatusTooManyRequests, want: "failed"},
+		{query: "?rate=1", status: http.StatusServiceUnavailable, want: "failed"},
+		{query: "?rate=0", status: http.StatusOK, want: "ok"},
+		{query: "?rate=1.5", status: http.StatusBadRequest, want: "invalid_rate"},
+		{query: "?rate=1&status=200", status: http.StatusBadRequest, want: "invalid_status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// Act
+			recorder := postSandbox(engine, "/sandbox/flaky"+tt.query, "{}")
+
+			// Assert
+			require.Equal(t, tt.status, recorder.Code, recorder.Body.String())
+			if tt.status == http.StatusBadRequest {
+				var problem models.Problem
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+				assert.Equal(t, tt.want, problem.Code)
+				return
+			}
+			var response models.SandboxResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, models.SandboxResponse{Status: tt.want, Receiver: "flaky"}, response)
+		})
+	}
+}
+
+// TestSandboxController_Slow tests that the slow receiver waits for the requested delay, rejects delays above
+// the maximum, and stops waiting once the sender gives up
+func TestSandboxController_Slow(t *testing.T) {
+	// Arrange
+	engine := newSandboxEngine(time.Second)
+
+	// Act
+	started := time.Now()
+	delayed := postSandbox(engine, "/sandbox/slow?delay=50ms", "{}")
+	elapsed := time.Since(started)
+	tooLong := postSandbox(engine, "/sandbox/slow?delay=2s", "{}")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodPost, "/sandbox/slow?delay=1s", nil).WithContext(ctx)
+	abandoned := httptest.NewRecorder()
+	started = time.Now()
+	engine.ServeHTTP(abandoned, req)
+	abandonedAfter := time.Since(started)
+
+	// Assert
+	require.Equal(t, http.StatusOK, delayed.Code)
+	assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+	var response models.SandboxResponse
+	require.NoError(t, json.Unmarshal(delayed.Body.Bytes(), &response))
+	require.NotNil(t, response.DelayedMs)
+	assert.Equal(t, int64(50), *response.DelayedMs)
+
+	assert.Equal(t, http.StatusBadRequest, tooLong.Code)
+	assert.Contains(t, tooLong.Body.String(), "delay must not exceed 1s")
+
+	assert.Less(t, abandonedAfter, 500*time.Millisecond)
+	assert.Empty(t, abandoned.Body.String())
+}
+
+// TestSandboxController_Echo tests that the echo receiver mirrors the method, path, query, headers and JSON body
+// of the request, and returns a body that is not JSON as a string
+func TestSandboxController_Echo(t *testing.T) {
+	// Arrange
+	engine := newSandboxEngine(0)
+
+	// Act
+	recorder := postSandbox(engine, "/sandbox/echo?attempt=2", `{"order_id": "ord-1"}`)
+	plain := postSandbox(engine, "/sandbox/echo", "not json")
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response models.SandboxEchoResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, http.MethodPost, response.Method)
+	assert.Equal(t, "/sandbox/echo", response.Path)
+	assert.Equal(t, []string{"2"}, response.Query["attempt"])
+	assert.Equal(t, []string{"application/json"}, response.Headers["Content-Type"])
+	assert.Equal(t, map[string]interface{}{"order_id": "ord-1"}, response.Body)
+
+	require.Equal(t, http.StatusOK, plain.Code)
+	var plainResponse models.SandboxEchoResponse
+	require.NoError(t, json.Unmarshal(plain.Body.Bytes(), &plainResponse))
+	assert.Equal(t, "not json", plainResponse.Body)
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// DefaultStreamKeepAlive is how often an idle event stream sends a comment, so proxies keep the connection open
+const DefaultStreamKeepAlive = 15 * time.Second
+
+// StreamController handles HTTP requests streaming status changes to clients
+type StreamController struct {
+	stream    *service.StatusStream
+	keepAlive time.Duration
+	logger    logging.Logger
+}
+
+// NewStreamController creates a new stream controller over a status stream
+func NewStreamController(stream *service.StatusStream, logger logging.Logger) *StreamController {
+	return &StreamController{
+		stream:    stream,
+		keepAlive: DefaultStreamKeepAlive,
+		logger:    logger,
+	}
+}
+
+// SetKeepAlive overrides how often idle streams send a keepalive comment
+func (c *StreamController) SetKeepAlive(interval time.Duration) {
+	c.keepAlive = interval
+}
+
+// StreamEvents handles GET /api/streams/events
+// Status changes of the tenant are sent as Server-Sent Events named after their type until the client
+// disconnects or the server shuts down
+func (c *StreamController) StreamEvents(ctx *gin.Context) {
+	filter, ok := c.parseFilter(ctx)
+	if !ok {
+		return
+	}
+
+	subscription := c.stream.Subscribe(filter)
+	defer subscription.Close()
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	// The retry field tells EventSource clients how long to wait before reconnecting
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", c.keepAlive.Milliseconds())
+	ctx.Writer.Flush()
+
+	c.logger.Info(ctx.Request.Context(), "Status stream opened",
+		zap.String("tenant_id", filter.TenantID))
+
+	keepAlive := time.NewTicker(c.keepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event, open := <-subscription.Events():
+			if !open {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				c.logger.Error(ctx.Request.Context(), "Failed to encode stream event", zap.Error(err))
+				continue
+			}
+			fmt.Fprintf(ctx.Writer, "event: %s\ndata: %s\n\n", event.Type, data)
+			ctx.Writer.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(ctx.Writer, ": keepalive\n\n")
+			ctx.Writer.Flush()
+		case <-ctx.Request.Context().Done():
+			c.logger.Info(ctx.Request.Context(), "Status stream closed",
+				zap.String("tenant_id", filter.TenantID),
+				zap.Int64("dropped", subscription.Dropped()))
+			return
+		}
+	}
+}
+
+// parseFilter reads the stream filter from the query, answering invalid requests
+func (c *StreamController) parseFilter(ctx *gin.Context) (filter service.StreamFilter, ok bool) {
+	filter = service.StreamFilter{TenantID: ctx.Query("tenant_id")}
+	if filter.TenantID == "" {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return filter, false
+	}
+
+	if !canManageTenant(ctx, filter.TenantID) {
+		return filter, false
+	}
+
+	if types := ctx.Query("types"); types != "" {
+		for _, name := range strings.Split(types, ",") {
+			eventType := models.StreamEventType(strings.TrimSpace(name))
+			if !eventType.IsValid() {
+				middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_type", fmt.Sprintf("unknown event type %q, must be one of delivery.completed, run.status_changed, step.completed", eventType))
+				return filter, false
+			}
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+
+	if filter.ChainID, ok = optionalUUIDQuery(ctx, "chain_id"); !ok {
+		return filter, false
+	}
+	if filter.RunID, ok = optionalUUIDQuery(ctx, "run_id"); !ok {
+		return filter, false
+	}
+	return filter, true
+}
+
+// optionalUUIDQuery parses a UUID query parameter, nil when it is absent; invalid values are answered with 400
+func optionalUUIDQuery(ctx *gin.Context, name string) (*uuid.UUID, bool) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_"+name, name+" must be a UUID")
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,712 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"go.uber.org/zap"
+)
+
+// TenantController handles HTTP requests for tenant lifecycle and tenant-wide operations
+type TenantController struct {
+	chainService     service.ExecutionChainService
+	webhookService   service.WebhookService
+	topologyService  service.TopologyService
+	retentionService service.RetentionService
+	tenantService    service.TenantService
+	logger           logging.Logger
+}
+
+// NewTenantController creates a new tenant controller
+func NewTenantController(chainService service.ExecutionChainService, webhookService service.WebhookService, topologyService service.TopologyService, retentionService service.RetentionService, tenantService service.TenantService, logger logging.Logger) *TenantController {
+	return &TenantController{
+		chainService:     chainService,
+		webhookService:   webhookService,
+		topologyService:  topologyService,
+		retentionService: retentionService,
+		tenantService:    tenantService,
+		logger:           logger,
+	}
+}
+
+// CreateTenant handles POST /api/admin/tenants
+func (c *TenantController) CreateTenant(ctx *gin.Context) {
+	var req models.CreateTenantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for tenant creation", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	tenant, err := c.tenantService.CreateTenant(ctx.Request.Context(), &req)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to create tenant",
+			zap.String("tenant_id", req.ID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusBadRequest, "tenant_creation_failed", "Failed to create tenant")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, tenant)
+}
+
+// ListTenants handles GET /api/admin/tenants
+func (c *TenantController) ListTenants(ctx *gin.Context) {
+	page, limit := parsePagination(ctx)
+
+	response, err := c.tenantService.ListTenants(ctx.Request.Context(), page, limit)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to list tenants", zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "list_failed", "Failed to list tenants")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetTenant handles GET /api/tenants/:id
+func (c *TenantController) GetTenant(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	tenant, err := c.tenantService.GetTenant(ctx.Request.Context(), tenantID)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "tenant_failed", "Failed to get tenant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tenant)
+}
+
+// SuspendTenant handles POST /api/admin/tenants/:id/suspend
+func (c *TenantController) SuspendTenant(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+
+	var req models.SuspendTenantRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	tenant, err := c.tenantService.SuspendTenant(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to suspend tenant",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "tenant_suspend_failed", "Failed to suspend tenant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Tenant suspended",
+		Data:    tenant,
+	})
+}
+
+// ActivateTenant handles POST /api/admin/tenants/:id/activate
+func (c *TenantController) ActivateTenant(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+
+	tenant, err := c.tenantService.ActivateTenant(ctx.Request.Context(), tenantID)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to activate tenant",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "tenant_activate_failed", "Failed to activate tenant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Tenant activated",
+		Data:    tenant,
+	})
+}
+
+// DeleteTenant handles DELETE /api/admin/tenants/:id
+func (c *TenantController) DeleteTenant(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+
+	response, err := c.tenantService.DeleteTenant(ctx.Request.Context(), tenantID)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to delete tenant",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "tenant_delete_failed", "Failed to delete tenant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Tenant deleted",
+		Data:    response,
+	})
+}
+
+// GetSettings handles GET /api/tenants/:id/settings
+func (c *TenantController) GetSettings(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	settings, err := c.tenantService.GetTenantSettings(ctx.Request.Context(), tenantID)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant settings",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "settings_failed", "Failed to get tenant settings")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /api/tenants/:id/settings
+func (c *TenantController) UpdateSettings(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	var req models.TenantSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for tenant settings update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	settings, err := c.tenantService.UpdateTenantSettings(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to update tenant settings",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusBadRequest, "settings_update_failed", "Failed to update tenant settings")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Settings updated for tenant",
+		Data:    settings,
+	})
+}
+
+// maxUsageDays is the longest range of days a usage report covers
+const maxUsageDays = 366
+
+// GetUsage handles GET /api/tenants/:id/usage
+// from and to are UTC days as YYYY-MM-DD and default to the current month up to today
+func (c *TenantController) GetUsage(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from, fromErr := parseUsageDay(ctx.Query("from"), today.AddDate(0, 0, 1-today.Day()))
+	to, toErr := parseUsageDay(ctx.Query("to"), today)
+	if err := errors.Join(fromErr, toErr); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	if to.Before(from) || to.Sub(from) >= maxUsageDays*24*time.Hour {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_request", "to must not be before from, and the range must not exceed 366 days")
+		return
+	}
+
+	response, err := c.tenantService.GetUsage(ctx.Request.Context(), tenantID, from, to)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant usage",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "usage_retrieval_failed", "Failed to retrieve tenant usage")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parseUsageDay parses a YYYY-MM-DD day, returning def for an empty value
+func parseUsageDay(value string, def time.Time) (time.Time, error) {
+	if value == "" {
+		return def, nil
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, apperr.Validation("invalid_request", "Days must be formatted as YYYY-MM-DD").Wrap(err)
+	}
+	return day, nil
+}
+
+// writeTenantError responds to errors about a missing, suspended or existing tenant, or an exceeded quota
+// Returns whether err was one of them
+func writeTenantError(ctx *gin.Context, err error) bool {
+	for _, tenantErr := range []*apperr.Error{service.ErrTenantNotFound, service.ErrTenantSuspended, service.ErrTenantExists, service.ErrQuotaExceeded} {
+		if errors.Is(err, tenantErr) {
+			middleware.WriteError(ctx, err, http.StatusInternalServerError, tenantErr.Code, tenantErr.Message)
+			return true
+		}
+	}
+	return false
+}
+
+// PauseAllChains handles POST /api/tenants/:id/chains/pause-all
+func (c *TenantController) PauseAllChains(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.chainService.PauseTenantChains(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to pause tenant chains",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_pause_failed", "Failed to pause tenant chains")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Chain executions paused for tenant",
+		Data:    response,
+	})
+}
+
+// ResumeAllChains handles POST /api/tenants/:id/chains/resume-all
+func (c *TenantController) ResumeAllChains(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.chainService.ResumeTenantChains(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to resume tenant chains",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "chain_resume_failed", "Failed to resume tenant chains")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Chain executions resumed for tenant",
+		Data:    response,
+	})
+}
+
+// PauseDeliveries handles POST /api/tenants/:id/deliveries/pause
+func (c *TenantController) PauseDeliveries(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	c.pauseDeliveries(ctx, tenantID)
+}
+
+// ResumeDeliveries handles POST /api/tenants/:id/deliveries/resume
+func (c *TenantController) ResumeDeliveries(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	c.resumeDeliveries(ctx, tenantID)
+}
+
+// GetDeliveryPause handles GET /api/tenants/:id/deliveries/pause
+func (c *TenantController) GetDeliveryPause(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	c.getDeliveryPause(ctx, tenantID)
+}
+
+// PauseAllDeliveries handles POST /api/admin/deliveries/pause
+func (c *TenantController) PauseAllDeliveries(ctx *gin.Context) {
+	c.pauseDeliveries(ctx, "")
+}
+
+// ResumeAllDeliveries handles POST /api/admin/deliveries/resume
+func (c *TenantController) ResumeAllDeliveries(ctx *gin.Context) {
+	c.resumeDeliveries(ctx, "")
+}
+
+// GetGlobalDeliveryPause handles GET /api/admin/deliveries/pause
+func (c *TenantController) GetGlobalDeliveryPause(ctx *gin.Context) {
+	c.getDeliveryPause(ctx, "")
+}
+
+// pauseDeliveries pauses the deliveries of a tenant, or of every tenant for an empty tenantID
+func (c *TenantController) pauseDeliveries(ctx *gin.Context, tenantID string) {
+	var req models.PauseDeliveriesRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	response, err := c.webhookService.PauseDeliveries(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to pause deliveries",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "delivery_pause_failed", "Failed to pause deliveries")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Deliveries paused",
+		Data:    response,
+	})
+}
+
+// resumeDeliveries resumes the deliveries of a tenant, or the global pause for an empty tenantID
+func (c *TenantController) resumeDeliveries(ctx *gin.Context, tenantID string) {
+	var req models.ResumeDeliveriesRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+	}
+
+	response, err := c.webhookService.ResumeDeliveries(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to resume deliveries",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "delivery_resume_failed", "Failed to resume deliveries")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Deliveries resumed",
+		Data:    response,
+	})
+}
+
+// getDeliveryPause reports the delivery pause of a tenant, or the global one for an empty tenantID
+func (c *TenantController) getDeliveryPause(ctx *gin.Context, tenantID string) {
+	response, err := c.webhookService.GetDeliveryPause(ctx.Request.Context(), tenantID)
+	if err != nil {
+		if writeTenantError(ctx, err) {
+			return
+		}
+		c.logger.Error(ctx.Request.Context(), "Failed to get delivery pause",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "delivery_pause_failed", "Failed to get delivery pause")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetRunLimit handles GET /api/tenants/:id/run-limit
+func (c *TenantController) GetRunLimit(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.chainService.GetTenantRunLimit(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant run limit",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "run_limit_failed", "Failed to get tenant run limit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateRunLimit handles PUT /api/tenants/:id/run-limit
+func (c *TenantController) UpdateRunLimit(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	var req models.TenantRunLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for run limit update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.chainService.SetTenantRunLimit(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to update tenant run limit",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "run_limit_update_failed", "Failed to update tenant run limit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Run limit updated for tenant",
+		Data:    response,
+	})
+}
+
+// GetRetention handles GET /api/tenants/:id/retention
+func (c *TenantController) GetRetention(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.retentionService.GetTenantRetention(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant retention",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "retention_failed", "Failed to get tenant retention")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateRetention handles PUT /api/tenants/:id/retention
+func (c *TenantController) UpdateRetention(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	var req models.TenantRetentionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for retention update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.retentionService.SetTenantRetention(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to update tenant retention",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "retention_update_failed", "Failed to update tenant retention")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Retention updated for tenant",
+		Data:    response,
+	})
+}
+
+// GetTopology handles GET /api/tenants/:id/topology
+func (c *TenantController) GetTopology(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.topologyService.GetTenantTopology(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to build tenant topology",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteProblem(ctx, http.StatusInternalServerError, "topology_failed", "Failed to build tenant topology")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetSigningHeaders handles GET /api/tenants/:id/signing-headers
+func (c *TenantController) GetSigningHeaders(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.webhookService.GetTenantSigningHeaders(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant signing headers",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "signing_headers_failed", "Failed to get tenant signing headers")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateSigningHeaders handles PUT /api/tenants/:id/signing-headers
+func (c *TenantController) UpdateSigningHeaders(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	var req models.SigningHeaders
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for signing headers update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.webhookService.UpdateTenantSigningHeaders(ctx.Request.Context(), tenantID, req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to update tenant signing headers",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "signing_headers_update_failed", "Failed to update tenant signing headers")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Signing headers updated for tenant",
+		Data:    response,
+	})
+}
+
+// GetPayloadValidation handles GET /api/tenants/:id/payload-validation
+func (c *TenantController) GetPayloadValidation(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.webhookService.GetTenantPayloadValidation(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant payload validation",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "payload_validation_failed", "Failed to get tenant payload validation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetSigning handles GET /api/tenants/:id/signing
+func (c *TenantController) GetSigning(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	response, err := c.webhookService.GetTenantSigning(ctx.Request.Context(), tenantID)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to get tenant signing",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "signing_failed", "Failed to get tenant signing")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateSigning handles PUT /api/tenants/:id/signing
+func (c *TenantController) UpdateSigning(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	var req models.TenantSigningRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for signing update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+	if !req.Algorithm.IsValid() {
+		middleware.WriteProblem(ctx, http.StatusBadRequest, "invalid_request", "algorithm must be hmac-sha256 or ed25519")
+		return
+	}
+
+	response, err := c.webhookService.UpdateTenantSigning(ctx.Request.Context(), tenantID, &req)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to update tenant signing",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "signing_update_failed", "Failed to update tenant signing")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Signing algorithm updated for tenant",
+		Data:    response,
+	})
+}
+
+// UpdatePayloadValidation handles PUT /api/tenants/:id/payload-validation
+func (c *TenantController) UpdatePayloadValidation(ctx *gin.Context) {
+	tenantID := ctx.Param("id")
+	if !canManageTenant(ctx, tenantID) {
+		return
+	}
+
+	var req models.TenantPayloadValidationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error(ctx.Request.Context(), "Invalid request for payload validation update", zap.Error(err))
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.webhookService.UpdateTenantPayloadValidation(ctx.Request.Context(), tenantID, req.Strict)
+	if err != nil {
+		c.logger.Error(ctx.Request.Context(), "Failed to update tenant payload validation",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		middleware.WriteError(ctx, err, http.StatusInternalServerError, "payload_validation_update_failed", "Failed to update tenant payload validation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Payload validation updated for tenant",
+		Data:    response,
+	})
+}
@@ -0,0 +1,176 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/controller"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestTenantController_OtherTenant tests that a caller bound to one tenant gets 403 for the settings, usage,
+// controls and topology of another tenant without reaching the services, while reaching its own tenant's
+func TestTenantController_OtherTenant(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	tenantService := mocks.NewMockTenantService(t)
+	tenants := controller.NewTenantController(mocks.NewMockExecutionChainService(t), mocks.NewMockWebhookService(t),
+		mocks.NewMockTopologyService(t), mocks.NewMockRetentionService(t), tenantService, logging.Nop())
+
+	engine := gin.New()
+	api := engine.Group("/api/tenants", middleware.RequireRole(tenantAdmins{}, models.RoleViewer, nil, logging.Nop()))
+	api.GET("/:id", tenants.GetTenant)
+	api.GET("/:id/settings", tenants.GetSettings)
+	api.PUT("/:id/settings", tenants.UpdateSettings)
+	api.GET("/:id/usage", tenants.GetUsage)
+	api.POST("/:id/chains/pause-all", tenants.PauseAllChains)
+	api.POST("/:id/chains/resume-all", tenants.ResumeAllChains)
+	api.POST("/:id/deliveries/pause", tenants.PauseDeliveries)
+	api.POST("/:id/deliveries/resume", tenants.ResumeDeliveries)
+	api.GET("/:id/deliveries/pause", tenants.GetDeliveryPause)
+	api.GET("/:id/run-limit", tenants.GetRunLimit)
+	api.PUT("/:id/run-limit", tenants.UpdateRunLimit)
+	api.GET("/:id/retention", tenants.GetRetention)
+	api.PUT("/:id/retention", tenants.UpdateRetention)
+	api.GET("/:id/topology", tenants.GetTopology)
+	api.GET("/:id/signing-headers", tenants.GetSigningHeaders)
+	api.PUT("/:id/signing-headers", tenants.UpdateSigningHeaders)
+	api.GET("/:id/payload-validation", tenants.GetPayloadValidation)
+	api.PUT("/:id/payload-validation", tenants.UpdatePayloadValidation)
+	api.GET("/:id/signing", tenants.GetSigning)
+	api.PUT("/:id/signing", tenants.UpdateSigning)
+
+	call := func(tenantID, method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/tenants"+path, strings.NewReader(`{}`))
+		req.Header.Set("X-API-Key", tenantID)
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, ""},
+		{http.MethodGet, "/settings"},
+		{http.MethodPut, "/settings"},
+		{http.MethodGet, "/usage"},
+		{http.MethodPost, "/chains/pause-all"},
+		{http.MethodPost, "/chains/resume-all"},
+		{http.MethodPost, "/deliveries/pause"},
+		{http.MethodPost, "/deliveries/resume"},
+		{http.MethodGet, "/deliveries/pause"},
+		{http.MethodGet, "/run-limit"},
+		{http.MethodPut, "/run-limit"},
+		{http.MethodGet, "/retention"},
+		{http.MethodPut, "/retention"},
+		{http.MethodGet, "/topology"},
+		{http.MethodGet, "/signing-headers"},
+		{http.MethodPut, "/signing-headers"},
+		{http.MethodGet, "/payload-validation"},
+		{http.MethodPut, "/payload-validation"},
+		{http.MethodGet, "/signing"},
+		{http.MethodPut, "/signing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Act
+			recorder := call("tenant-b", tt.method, "/tenant-a"+tt.path)
+
+			// Assert
+			assert.Equal(t, http.StatusForbidden, recorder.Code, recorder.Body.String())
+			assert.Contains(t, recorder.Body.String(), "credential cannot access this tenant")
+		})
+	}
+
+	t.Run("own tenant", func(t *testing.T) {
+		tenantService.On("GetTenantSettings", mock.Anything, "tenant-a").Return(&models.TenantSettings{TenantID: "tenant-a"}, nil).Once()
+
+		assert.Equal(t, http.StatusOK, call("tenant-a", http.MethodGet, "/tenant-a/settings").Code)
+	})
+}
+
+// TestComplianceController_OtherTenant tests that a caller bound to one tenant cannot erase a data subject of
+// another tenant
+func TestComplianceController_OtherTenant(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	compliance := controller.NewComplianceController(mocks.NewMockComplianceService(t), logging.Nop())
+	engine := gin.New()
+	engine.DELETE("/api/compliance/tenants/:tenantID/subjects/:subjectKey",
+		middleware.RequireRole(tenantAdmins{}, models.RoleAdmin, nil, logging.Nop()), compliance.EraseSubject)
+	req := httptest.NewRequest(http.MethodDelete, "/api/compliance/tenants/tenant-a/subjects/customer-1", nil)
+	req.Header.Set("X-API-Key", "tenant-b")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	engine.ServeHTTP(recorder, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, recorder.Code, recorder.Body.String())
+	assert.Contains(t, recorder.Body.String(), "credential cannot access this tenant")
+}
+
+// TestTenantController_PauseResumeChains tests that pausing and resuming all chains acts on the tenant of the path
+// only and returns the service's counts, and that a failure of the service is reported as such
+func TestTenantController_PauseResumeChains(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	chainSvc := mocks.NewMockExecutionChainService(t)
+	tenants := controller.NewTenantController(chainSvc, mocks.NewMockWebhookService(t),
+		mocks.NewMockTopologyService(t), mocks.NewMockRetentionService(t), mocks.NewMockTenantService(t), logging.Nop())
+	engine := gin.New()
+	api := engine.Group("/api/tenants", middleware.RequireRole(tenantAdmins{}, models.RoleAdmin, nil, logging.Nop()))
+	api.POST("/:id/chains/pause-all", tenants.PauseAllChains)
+	api.POST("/:id/chains/resume-all", tenants.ResumeAllChains)
+
+	chainSvc.EXPECT().PauseTenantChains(mock.Anything, "tenant-a").
+		Return(&models.TenantChainControlResponse{TenantID: "tenant-a", ChainsPaused: true, QueuedRuns: 2}, nil).Once()
+	chainSvc.EXPECT().ResumeTenantChains(mock.Anything, "tenant-a").
+		Return(&models.TenantChainControlResponse{TenantID: "tenant-a", ResumedRuns: 2}, nil).Once()
+	chainSvc.EXPECT().PauseTenantChains(mock.Anything, "tenant-c").
+		Return(nil, errors.New("settings store unavailable")).Once()
+
+	call := func(tenantID, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/tenants/"+tenantID+path, nil)
+		req.Header.Set("X-API-Key", tenantID)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+	decode := func(recorder *httptest.ResponseRecorder) models.TenantChainControlResponse {
+		var body struct {
+			Data models.TenantChainControlResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	// Act
+	paused := call("tenant-a", "/chains/pause-all")
+	resumed := call("tenant-a", "/chains/resume-all")
+	failed := call("tenant-c", "/chains/pause-all")
+
+	// Assert
+	require.Equal(t, http.StatusOK, paused.Code, paused.Body.String())
+	assert.Equal(t, models.TenantChainControlResponse{TenantID: "tenant-a", ChainsPaused: true, QueuedRuns: 2}, decode(paused))
+	require.Equal(t, http.StatusOK, resumed.Code, resumed.Body.String())
+	assert.Equal(t, models.TenantChainControlResponse{TenantID: "tenant-a", ResumedRuns: 2}, decode(resumed))
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Contains(t, failed.Body.String(), "chain_pause_failed")
+}
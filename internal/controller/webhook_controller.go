@@ -1,12 +1,14 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
 	"github.com/sakibcoolz/loki-suite/internal/models"
 	"github.com/sakibcoolz/loki-suite/internal/service"
 	"go.uber.org/zap"
@@ -14,13 +16,17 @@ import (
 
 // WebhookController handles webhook HTTP requests
 type WebhookController struct {
-	webhookSvc service.WebhookService
+	webhookSvc  service.WebhookService
+	topologySvc service.TopologyService
+	logger      logging.Logger
 }
 
 // NewWebhookController creates a new webhook controller
-func NewWebhookController(webhookSvc service.WebhookService) *WebhookController {
+func NewWebhookController(webhookSvc service.WebhookService, topologySvc service.TopologyService, logger logging.Logger) *WebhookController {
 	return &WebhookController{
-		webhookSvc: webhookSvc,
+		webhookSvc:  webhookSvc,
+		topologySvc: topologySvc,
+		logger:      logger,
 	}
 }
 
@@ -28,34 +34,29 @@ func NewWebhookController(webhookSvc service.WebhookService) *WebhookController
 func (wc *WebhookController) GenerateWebhook(c *gin.Context) {
 	var req models.GenerateWebhookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Invalid generate webhook request",
+		wc.logger.Warn(c.Request.Context(), "Invalid generate webhook request",
 			zap.Error(err),
 			zap.String("remote_addr", c.ClientIP()))
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		invalidRequest(c, err)
 		return
 	}
 
-	response, err := wc.webhookSvc.GenerateWebhook(&req)
+	response, err := wc.webhookSvc.GenerateWebhook(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("Failed to generate webhook",
+		if writeTenantError(c, err) {
+			return
+		}
+		wc.logger.Error(c.Request.Context(), "Failed to generate webhook",
 			zap.Error(err),
 			zap.String("tenant_id", req.TenantID),
 			zap.String("app_name", req.AppName))
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "webhook_generation_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		middleware.WriteError(c, err, http.StatusInternalServerError, "webhook_generation_failed", "Failed to generate webhook")
 		return
 	}
 
-	logger.Info("Webhook generated successfully",
+	wc.logger.Info(c.Request.Context(), "Webhook generated successfully",
 		zap.String("webhook_id", response.WebhookID.String()),
 		zap.String("tenant_id", req.TenantID),
 		zap.String("type", string(req.Type)))
@@ -67,34 +68,29 @@ func (wc *WebhookController) GenerateWebhook(c *gin.Context) {
 func (wc *WebhookController) SubscribeWebhook(c *gin.Context) {
 	var req models.SubscribeWebhookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Invalid subscribe webhook request",
+		wc.logger.Warn(c.Request.Context(), "Invalid subscribe webhook request",
 			zap.Error(err),
 			zap.String("remote_addr", c.ClientIP()))
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		invalidRequest(c, err)
 		return
 	}
 
-	response, err := wc.webhookSvc.SubscribeWebhook(&req)
+	response, err := wc.webhookSvc.SubscribeWebhook(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("Failed to subscribe webhook",
+		if writeTenantError(c, err) {
+			return
+		}
+		wc.logger.Error(c.Request.Context(), "Failed to subscribe webhook",
 			zap.Error(err),
 			zap.String("tenant_id", req.TenantID),
 			zap.String("target_url", req.TargetURL))
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "webhook_subscription_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		middleware.WriteError(c, err, http.StatusInternalServerError, "webhook_subscription_failed", "Failed to subscribe webhook")
 		return
 	}
 
-	logger.Info("Webhook subscription created successfully",
+	wc.logger.Info(c.Request.Context(), "Webhook subscription created successfully",
 		zap.String("webhook_id", response.WebhookID.String()),
 		zap.String("tenant_id", req.TenantID),
 		zap.String("target_url", req.TargetURL))
@@ -106,34 +102,44 @@ func (wc *WebhookController) SubscribeWebhook(c *gin.Context) {
 func (wc *WebhookController) SendEvent(c *gin.Context) {
 	var req models.SendEventRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Invalid send event request",
+		wc.logger.Warn(c.Request.Context(), "Invalid send event request",
 			zap.Error(err),
 			zap.String("remote_addr", c.ClientIP()))
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		invalidRequest(c, err)
 		return
 	}
 
-	result, err := wc.webhookSvc.SendEvent(&req)
+	result, err := wc.webhookSvc.SendEvent(c.Request.Context(), &req)
+	var validationErr *service.PayloadValidationError
+	if errors.As(err, &validationErr) {
+		wc.logger.Warn(c.Request.Context(), "Event payload rejected by schema",
+			zap.String("tenant_id", req.TenantID),
+			zap.String("event", req.Event),
+			zap.Int("violations", len(validationErr.Violations)))
+
+		c.Header("Content-Type", middleware.ProblemContentType)
+		c.JSON(http.StatusUnprocessableEntity, models.PayloadValidationErrorResponse{
+			Problem:    middleware.NewProblem(c, http.StatusUnprocessableEntity, "payload_validation_failed", validationErr.Error()),
+			EventType:  validationErr.EventType,
+			Violations: validationErr.Violations,
+		})
+		return
+	}
+	if writeTenantError(c, err) {
+		return
+	}
 	if err != nil {
-		logger.Error("Failed to send webhook event",
+		wc.logger.Error(c.Request.Context(), "Failed to send webhook event",
 			zap.Error(err),
 			zap.String("tenant_id", req.TenantID),
 			zap.String("event", req.Event))
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "event_processing_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		middleware.WriteError(c, err, http.StatusInternalServerError, "event_processing_failed", "Failed to send webhook event")
 		return
 	}
 
-	logger.Info("Webhook event processed successfully",
+	wc.logger.Info(c.Request.Context(), "Webhook event processed successfully",
 		zap.String("event_id", result.EventID.String()),
 		zap.String("tenant_id", req.TenantID),
 		zap.String("event", req.Event),
@@ -141,7 +147,10 @@ func (wc *WebhookController) SendEvent(c *gin.Context) {
 		zap.Int("total_failed", result.TotalFailed))
 
 	message := "Webhook event processed successfully"
-	if result.TotalSent == 0 {
+	switch {
+	case result.Scheduled:
+		message = "Webhook event scheduled for delivery"
+	case result.TotalSent == 0:
 		message = "No active webhook subscriptions found for this event"
 	}
 
@@ -158,30 +167,22 @@ func (wc *WebhookController) ReceiveWebhook(c *gin.Context) {
 	// Parse webhook ID
 	webhookID, err := uuid.Parse(webhookIDStr)
 	if err != nil {
-		logger.Warn("Invalid webhook ID in receive request",
+		wc.logger.Warn(c.Request.Context(), "Invalid webhook ID in receive request",
 			zap.String("webhook_id", webhookIDStr),
 			zap.String("remote_addr", c.ClientIP()))
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_webhook_id",
-			Message: "Invalid webhook ID format",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
 		return
 	}
 
 	// Read request body
 	payload, err := c.GetRawData()
 	if err != nil {
-		logger.Warn("Failed to read request body",
+		wc.logger.Warn(c.Request.Context(), "Failed to read request body",
 			zap.String("webhook_id", webhookIDStr),
 			zap.Error(err))
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_payload",
-			Message: "Failed to read request body",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_payload", "Failed to read request body")
 		return
 	}
 
@@ -191,22 +192,24 @@ func (wc *WebhookController) ReceiveWebhook(c *gin.Context) {
 	authHeader := c.GetHeader("Authorization")
 
 	// Verify webhook
-	err = wc.webhookSvc.VerifyWebhook(webhookID, payload, signature, timestamp, authHeader)
+	err = wc.webhookSvc.VerifyWebhook(c.Request.Context(), webhookID, payload, signature, timestamp, authHeader, c.ClientIP())
 	if err != nil {
-		logger.Warn("Webhook verification failed",
+		wc.logger.Warn(c.Request.Context(), "Webhook verification failed",
 			zap.String("webhook_id", webhookIDStr),
 			zap.Error(err),
 			zap.String("remote_addr", c.ClientIP()))
 
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-			Error:   "webhook_verification_failed",
-			Message: err.Error(),
-			Code:    http.StatusUnauthorized,
-		})
+		if errors.Is(err, service.ErrSourceIPNotAllowed) {
+			middleware.WriteError(c, err, http.StatusForbidden, "source_ip_not_allowed", service.ErrSourceIPNotAllowed.Error())
+			return
+		}
+
+		// Other failures are not detailed so the response does not tell which check a forged request failed
+		middleware.WriteProblem(c, http.StatusUnauthorized, "webhook_verification_failed", "Webhook verification failed")
 		return
 	}
 
-	logger.Info("Webhook received and verified successfully",
+	wc.logger.Info(c.Request.Context(), "Webhook received and verified successfully",
 		zap.String("webhook_id", webhookIDStr),
 		zap.String("remote_addr", c.ClientIP()))
 
@@ -223,44 +226,663 @@ func (wc *WebhookController) ReceiveWebhook(c *gin.Context) {
 func (wc *WebhookController) ListWebhooks(c *gin.Context) {
 	tenantID := c.Query("tenant_id")
 	if tenantID == "" {
-		logger.Warn("Missing tenant_id in list webhooks request",
+		wc.logger.Warn(c.Request.Context(), "Missing tenant_id in list webhooks request",
 			zap.String("remote_addr", c.ClientIP()))
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_tenant_id",
-			Message: "tenant_id query parameter is required",
-			Code:    http.StatusBadRequest,
-		})
+		middleware.WriteProblem(c, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	// Parse filters and pagination parameters
+	var filter models.ListFilter
+	page, ok := parseListQuery(c, &filter)
+	if !ok {
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	requested, ok := parseResponseFields(c, webhookListShape)
+	if !ok {
+		return
+	}
 
-	response, err := wc.webhookSvc.ListWebhooks(tenantID, page, limit)
+	response, err := wc.webhookSvc.ListWebhooks(c.Request.Context(), tenantID, filter, page)
+	if invalidListPage(c, err) {
+		return
+	}
 	if err != nil {
-		logger.Error("Failed to list webhooks",
+		wc.logger.Error(c.Request.Context(), "Failed to list webhooks",
 			zap.Error(err),
 			zap.String("tenant_id", tenantID))
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "list_webhooks_failed",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		middleware.WriteError(c, err, http.StatusInternalServerError, "list_webhooks_failed", "Failed to list webhooks")
 		return
 	}
 
-	logger.Debug("Webhooks listed successfully",
+	wc.logger.Debug(c.Request.Context(), "Webhooks listed successfully",
 		zap.String("tenant_id", tenantID),
 		zap.Int64("total", response.Total),
-		zap.Int("page", page),
-		zap.Int("limit", limit))
+		zap.Int("page", response.Page),
+		zap.Int("limit", response.Limit))
+
+	writeShaped(c, wc.logger, http.StatusOK, response, requested)
+}
+
+// ExportWebhooks handles GET /api/webhooks/export
+func (wc *WebhookController) ExportWebhooks(c *gin.Context) {
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(c, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+	includeSecrets, ok := queryBool(c, "include_secrets")
+	if !ok {
+		return
+	}
+
+	export, err := wc.webhookSvc.ExportWebhooks(c.Request.Context(), tenantID, includeSecrets)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to export webhooks",
+			zap.Error(err),
+			zap.String("tenant_id", tenantID))
+
+		middleware.WriteError(c, err, http.StatusInternalServerError, "export_webhooks_failed", "Failed to export webhooks")
+		return
+	}
+
+	writeDocument(c, wc.logger, http.StatusOK, export)
+}
+
+// ImportWebhooks handles POST /api/webhooks/import
+// Responds with 201 when the subscriptions were created, 200 for dry runs and 422 when the import was
+// rejected; the body reports the outcome per subscription either way
+func (wc *WebhookController) ImportWebhooks(c *gin.Context) {
+	var export models.WebhookExport
+	if err := bindDocument(c, &export); err != nil {
+		wc.logger.Warn(c.Request.Context(), "Invalid webhook import request",
+			zap.Error(err),
+			zap.String("remote_addr", c.ClientIP()))
+
+		invalidRequest(c, err)
+		return
+	}
+	if !namesCallerTenant(c, export.TenantID) {
+		return
+	}
+
+	opts := models.WebhookImportOptions{
+		TenantID:   targetTenant(c),
+		OnConflict: models.ImportConflictPolicy(c.Query("on_conflict")),
+	}
+	var ok bool
+	if opts.DryRun, ok = queryBool(c, "dry_run"); !ok {
+		return
+	}
+	if opts.RegenerateSecrets, ok = queryBool(c, "regenerate_secrets"); !ok {
+		return
+	}
+	if !opts.OnConflict.IsValid() {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_on_conflict", "on_conflict must be skip or fail")
+		return
+	}
+
+	response, err := wc.webhookSvc.ImportWebhooks(c.Request.Context(), &export, opts)
+	if err != nil {
+		if writeTenantError(c, err) {
+			return
+		}
+		wc.logger.Warn(c.Request.Context(), "Failed to import webhooks",
+			zap.Error(err),
+			zap.String("tenant_id", opts.TenantID))
+
+		middleware.WriteError(c, err, http.StatusBadRequest, "import_webhooks_failed", "Failed to import webhooks")
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case response.DryRun:
+		status = http.StatusOK
+	case !response.Applied:
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, response)
+}
+
+// ApplyConfig handles POST /api/config/apply
+// Responds with 200 when the manifest was applied or planned on a dry run, and 422 when an entry is invalid;
+// the body lists the change of every subscription and chain either way
+func (wc *WebhookController) ApplyConfig(c *gin.Context) {
+	var manifest models.ConfigManifest
+	if err := bindDocument(c, &manifest); err != nil {
+		wc.logger.Warn(c.Request.Context(), "Invalid configuration manifest",
+			zap.Error(err),
+			zap.String("remote_addr", c.ClientIP()))
+
+		invalidRequest(c, err)
+		return
+	}
+	if !namesCallerTenant(c, manifest.TenantID) {
+		return
+	}
+
+	opts := models.ConfigApplyOptions{TenantID: targetTenant(c)}
+	var ok bool
+	if opts.DryRun, ok = queryBool(c, "dry_run"); !ok {
+		return
+	}
+
+	response, err := wc.webhookSvc.ApplyConfig(c.Request.Context(), &manifest, opts)
+	if err != nil {
+		if writeTenantError(c, err) {
+			return
+		}
+		if versionConflict(c, err) {
+			return
+		}
+		wc.logger.Error(c.Request.Context(), "Failed to apply configuration manifest",
+			zap.Error(err),
+			zap.String("tenant_id", opts.TenantID))
+
+		middleware.WriteError(c, err, http.StatusBadRequest, "apply_config_failed", "Failed to apply configuration manifest")
+		return
+	}
+
+	status := http.StatusOK
+	if response.Invalid > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	writeDocument(c, wc.logger, status, response)
+}
+
+// namesCallerTenant reports whether a document names no tenant or the tenant of the caller's credential, and
+// otherwise responds with 403. RequireRole checks the tenant_id of JSON bodies only, so this makes YAML
+// documents subject to the same check
+func namesCallerTenant(c *gin.Context, documentTenant string) bool {
+	if tenantID := callerTenant(c); tenantID != "" && documentTenant != "" && documentTenant != tenantID {
+		middleware.WriteProblem(c, http.StatusForbidden, "forbidden", "tenant_id names a different tenant than the credential's")
+		return false
+	}
+	return true
+}
+
+// targetTenant returns the tenant an import or manifest is written to: the tenant_id query parameter, else the
+// tenant of the caller's credential. Empty only for callers not bound to a tenant, whose documents name their own;
+// the tenant_id of a document sent by a tenant-bound caller is never used, whatever its content type
+func targetTenant(c *gin.Context) string {
+	if tenantID := c.Query("tenant_id"); tenantID != "" {
+		return tenantID
+	}
+	return callerTenant(c)
+}
+
+// GetWebhook handles GET /api/webhooks/:id
+func (wc *WebhookController) GetWebhook(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	requested, ok := parseResponseFields(c, webhookShape)
+	if !ok {
+		return
+	}
+
+	subscription, err := wc.webhookSvc.GetWebhook(c.Request.Context(), callerTenant(c), webhookID)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get webhook",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteProblem(c, http.StatusNotFound, "webhook_not_found", "Webhook subscription not found")
+		return
+	}
+
+	writeShaped(c, wc.logger, http.StatusOK, subscription, requested)
+}
+
+// GetWebhookImpact handles GET /api/webhooks/:id/impact
+func (wc *WebhookController) GetWebhookImpact(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	response, err := wc.topologySvc.GetWebhookImpact(c.Request.Context(), callerTenant(c), webhookID)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to analyse webhook impact",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "impact_analysis_failed", "Failed to analyse webhook impact")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// TestWebhook handles POST /api/webhooks/:id/test
+func (wc *WebhookController) TestWebhook(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	// The body is optional; without one the subscribed event is sent with a sample payload
+	var req models.TestWebhookRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			invalidRequest(c, err)
+			return
+		}
+	}
+
+	response, err := wc.webhookSvc.TestWebhook(c.Request.Context(), callerTenant(c), webhookID, &req)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to send test delivery",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_test_failed", "Failed to send test delivery")
+		return
+	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// VerifySignature handles POST /api/webhooks/verify-signature
+func (wc *WebhookController) VerifySignature(c *gin.Context) {
+	var req models.VerifySignatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := wc.webhookSvc.VerifySignature(c.Request.Context(), callerTenant(c), &req)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to verify signature",
+			zap.String("webhook_id", req.WebhookID.String()),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_not_found", "Failed to verify signature")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhookStats handles GET /api/webhooks/:id/stats
+func (wc *WebhookController) GetWebhookStats(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	window, ok := statsWindow(c)
+	if !ok {
+		return
+	}
+
+	response, err := wc.webhookSvc.GetWebhookDeliveryStats(c.Request.Context(), callerTenant(c), webhookID, window)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get webhook delivery stats",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_stats_failed", "Failed to get webhook delivery stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTenantWebhookStats handles GET /api/webhooks/stats
+func (wc *WebhookController) GetTenantWebhookStats(c *gin.Context) {
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(c, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	window, ok := statsWindow(c)
+	if !ok {
+		return
+	}
+
+	response, err := wc.webhookSvc.GetTenantDeliveryStats(c.Request.Context(), tenantID, window)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get tenant delivery stats",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusInternalServerError, "webhook_stats_failed", "Failed to get tenant delivery stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhookSLO handles GET /api/webhooks/:id/slo
+func (wc *WebhookController) GetWebhookSLO(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	window, ok := statsWindow(c)
+	if !ok {
+		return
+	}
+
+	response, err := wc.webhookSvc.GetWebhookLatencySLO(c.Request.Context(), callerTenant(c), webhookID, window)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get webhook latency SLO report",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_slo_failed", "Failed to get webhook latency SLO report")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTenantWebhookSLO handles GET /api/webhooks/slo
+func (wc *WebhookController) GetTenantWebhookSLO(c *gin.Context) {
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(c, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	window, ok := statsWindow(c)
+	if !ok {
+		return
+	}
+
+	response, err := wc.webhookSvc.GetTenantLatencySLO(c.Request.Context(), tenantID, window)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get tenant latency SLO report",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusInternalServerError, "webhook_slo_failed", "Failed to get tenant latency SLO report")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// statsWindow reads the window query parameter of a statistics request, 24h by default
+// Responds with 400 and returns false when the window is unknown
+func statsWindow(c *gin.Context) (models.StatsWindow, bool) {
+	window := models.StatsWindow(c.DefaultQuery("window", string(models.StatsWindowDay)))
+	if _, ok := window.Duration(); !ok {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_window", "window must be one of 1h, 24h, 7d, 30d or all")
+		return "", false
+	}
+	return window, true
+}
+
+// GetWebhookHistory handles GET /api/webhooks/:id/history
+func (wc *WebhookController) GetWebhookHistory(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	response, err := wc.webhookSvc.GetWebhookHistory(c.Request.Context(), callerTenant(c), webhookID)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get webhook history",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusInternalServerError, "webhook_history_failed", "Failed to get webhook history")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	if err := wc.webhookSvc.DeleteWebhook(c.Request.Context(), callerTenant(c), webhookID); err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to delete webhook",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_deletion_failed", "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Webhook deleted successfully",
+		Data:    gin.H{"webhook_id": webhookID},
+	})
+}
+
+// RestoreWebhook handles POST /api/webhooks/:id/restore
+func (wc *WebhookController) RestoreWebhook(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	subscription, err := wc.webhookSvc.RestoreWebhook(c.Request.Context(), callerTenant(c), webhookID)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to restore webhook",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_restore_failed", "Failed to restore webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Webhook restored successfully",
+		Data:    subscription,
+	})
+}
+
+// EnableWebhook handles POST /api/webhooks/:id/enable
+func (wc *WebhookController) EnableWebhook(c *gin.Context) {
+	webhookIDStr := c.Param("id")
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_webhook_id", "Invalid webhook ID format")
+		return
+	}
+
+	subscription, err := wc.webhookSvc.EnableWebhook(c.Request.Context(), callerTenant(c), webhookID)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to enable webhook",
+			zap.String("webhook_id", webhookIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "webhook_enable_failed", "Failed to enable webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Webhook enabled successfully",
+		Data:    subscription,
+	})
+}
+
+// ExplainRoute handles POST /api/webhooks/route-explain
+func (wc *WebhookController) ExplainRoute(c *gin.Context) {
+	var req models.RouteExplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := wc.topologySvc.ExplainRoute(c.Request.Context(), &req)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to explain event routing",
+			zap.String("tenant_id", req.TenantID),
+			zap.String("event", req.Event),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusInternalServerError, "route_explain_failed", "Failed to explain event routing")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RegisterEventType handles POST /api/event-types
+func (wc *WebhookController) RegisterEventType(c *gin.Context) {
+	var req models.RegisterEventTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	eventType, err := wc.webhookSvc.RegisterEventType(c.Request.Context(), &req)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to register event type",
+			zap.String("tenant_id", req.TenantID),
+			zap.String("name", req.Name),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusBadRequest, "event_type_registration_failed", "Failed to register event type")
+		return
+	}
+
+	wc.logger.Info(c.Request.Context(), "Event type registered successfully",
+		zap.String("event_type_id", eventType.ID.String()),
+		zap.String("tenant_id", eventType.TenantID),
+		zap.String("name", eventType.Name))
+
+	c.JSON(http.StatusCreated, eventType)
+}
+
+// ListEventTypes handles GET /api/event-types
+func (wc *WebhookController) ListEventTypes(c *gin.Context) {
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		middleware.WriteProblem(c, http.StatusBadRequest, "missing_tenant_id", "tenant_id query parameter is required")
+		return
+	}
+
+	response, err := wc.webhookSvc.ListEventTypes(c.Request.Context(), tenantID)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to list event types",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusInternalServerError, "event_type_list_failed", "Failed to list event types")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateEventType handles PUT /api/event-types/:id
+func (wc *WebhookController) UpdateEventType(c *gin.Context) {
+	eventTypeIDStr := c.Param("id")
+	eventTypeID, err := uuid.Parse(eventTypeIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_event_type_id", "Invalid event type ID format")
+		return
+	}
+
+	var req models.UpdateEventTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	eventType, err := wc.webhookSvc.UpdateEventType(c.Request.Context(), eventTypeID, &req)
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to update event type",
+			zap.String("event_type_id", eventTypeIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusBadRequest, "event_type_update_failed", "Failed to update event type")
+		return
+	}
+
+	c.JSON(http.StatusOK, eventType)
+}
+
+// DeleteEventType handles DELETE /api/event-types/:id
+func (wc *WebhookController) DeleteEventType(c *gin.Context) {
+	eventTypeIDStr := c.Param("id")
+	eventTypeID, err := uuid.Parse(eventTypeIDStr)
+	if err != nil {
+		middleware.WriteProblem(c, http.StatusBadRequest, "invalid_event_type_id", "Invalid event type ID format")
+		return
+	}
+
+	if err := wc.webhookSvc.DeleteEventType(c.Request.Context(), eventTypeID); err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to delete event type",
+			zap.String("event_type_id", eventTypeIDStr),
+			zap.Error(err))
+
+		middleware.WriteError(c, err, http.StatusNotFound, "event_type_deletion_failed", "Failed to delete event type")
+		return
+	}
+
+	wc.logger.Info(c.Request.Context(), "Event type deleted successfully",
+		zap.String("event_type_id", eventTypeIDStr))
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Event type deleted successfully",
+	})
+}
+
+// GetSigningKeys handles GET /.well-known/loki-suite/keys.json
+func (wc *WebhookController) GetSigningKeys(c *gin.Context) {
+	keySet, err := wc.webhookSvc.GetSigningKeySet(c.Request.Context(), c.Query("tenant_id"))
+	if err != nil {
+		wc.logger.Error(c.Request.Context(), "Failed to get signing keys", zap.Error(err))
+
+		middleware.WriteProblem(c, http.StatusInternalServerError, "signing_keys_failed", "Failed to retrieve signing keys")
+		return
+	}
+
+	// Receivers fetch the key set for every unknown key ID; let them cache it briefly
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keySet)
+}
+
+// GetEgressIPs handles GET /api/webhooks/egress-ips
+func (wc *WebhookController) GetEgressIPs(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, wc.webhookSvc.GetEgressIPs())
+}
+
 // HealthCheck handles GET /health
 func (wc *WebhookController) HealthCheck(c *gin.Context) {
 	response := models.HealthResponse{
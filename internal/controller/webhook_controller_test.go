@@ -0,0 +1,177 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/controller"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+	"github.com/sakibcoolz/zcornor/pkg/config"
+)
+
+// TestWebhookController_CrossTenant tests that a caller of one tenant gets 404 for the webhooks of another
+// tenant on every endpoint naming a webhook by ID, as if they did not exist, and changes none of them
+func TestWebhookController_CrossTenant(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	webhookRepo := repository.NewMemoryWebhookRepository(store)
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	webhookSvc := service.NewWebhookService(webhookRepo, mocks.NewMockTenantRepository(t), historyRepo, nil, &config.Config{}, logging.Nop())
+	topologySvc := service.NewTopologyService(nil, webhookRepo, repository.NewMemoryExecutionChainRepository(store))
+
+	subscription := &models.WebhookSubscription{TenantID: "tenant-a", AppName: "billing", SubscribedEvent: "invoice.paid",
+		TargetURL: "https://billing.example.com/hooks", Type: models.WebhookTypePublic, IsActive: true}
+	require.NoError(t, webhookRepo.CreateSubscription(ctx, subscription))
+	deleted := &models.WebhookSubscription{TenantID: "tenant-a", AppName: "legacy", SubscribedEvent: "invoice.paid",
+		TargetURL: "https://legacy.example.com/hooks", Type: models.WebhookTypePublic}
+	require.NoError(t, webhookRepo.CreateSubscription(ctx, deleted))
+	require.NoError(t, webhookRepo.DeleteSubscription(ctx, deleted.ID))
+	historyRepo.EXPECT().GetSnapshots(mock.Anything, models.ConfigResourceSubscription, subscription.ID).
+		Return([]models.ConfigSnapshot{{TenantID: "tenant-a", ResourceType: models.ConfigResourceSubscription, ResourceID: subscription.ID, Version: 1, Config: "{}"}}, nil).Maybe()
+
+	webhooks := controller.NewWebhookController(webhookSvc, topologySvc, logging.Nop())
+	engine := gin.New()
+	api := engine.Group("/api/webhooks", middleware.RequireRole(tenantAdmins{}, models.RoleViewer, nil, logging.Nop()))
+	api.GET("/:id", webhooks.GetWebhook)
+	api.GET("/:id/impact", webhooks.GetWebhookImpact)
+	api.GET("/:id/history", webhooks.GetWebhookHistory)
+	api.GET("/:id/stats", webhooks.GetWebhookStats)
+	api.GET("/:id/slo", webhooks.GetWebhookSLO)
+	api.DELETE("/:id", webhooks.DeleteWebhook)
+	api.POST("/:id/restore", webhooks.RestoreWebhook)
+	api.POST("/:id/enable", webhooks.EnableWebhook)
+	api.POST("/:id/test", webhooks.TestWebhook)
+	api.POST("/verify-signature", webhooks.VerifySignature)
+
+	call := func(tenantID, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/webhooks"+path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", tenantID)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	webhookPath := "/" + subscription.ID.String()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "get", method: http.MethodGet, path: webhookPath},
+		{name: "impact", method: http.MethodGet, path: webhookPath + "/impact"},
+		{name: "history", method: http.MethodGet, path: webhookPath + "/history"},
+		{name: "stats", method: http.MethodGet, path: webhookPath + "/stats"},
+		{name: "slo", method: http.MethodGet, path: webhookPath + "/slo"},
+		{name: "delete", method: http.MethodDelete, path: webhookPath},
+		{name: "restore", method: http.MethodPost, path: "/" + deleted.ID.String() + "/restore"},
+		{name: "enable", method: http.MethodPost, path: webhookPath + "/enable"},
+		{name: "test", method: http.MethodPost, path: webhookPath + "/test"},
+		{name: "verify signature", method: http.MethodPost, path: "/verify-signature",
+			body: `{"webhook_id": "` + subscription.ID.String() + `", "body": "{}", "signature": "sha256=00"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			recorder := call("tenant-b", tt.method, tt.path, tt.body)
+
+			// Assert
+			assert.Equal(t, http.StatusNotFound, recorder.Code, recorder.Body.String())
+		})
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		for _, path := range []string{"", "/history"} {
+			assert.Equal(t, http.StatusOK, call("tenant-a", http.MethodGet, webhookPath+path, "").Code, path)
+		}
+	})
+
+	_, err := webhookRepo.GetSubscriptionByID(ctx, subscription.ID)
+	assert.NoError(t, err, "a cross-tenant deletion must leave the webhook in place")
+	_, err = webhookRepo.GetSubscriptionByID(ctx, deleted.ID)
+	assert.Error(t, err, "a cross-tenant restore must leave the webhook deleted")
+}
+
+// TestWebhookController_ImportToCallerTenant tests that a tenant-bound caller's imports and manifests are
+// written to its own tenant, and that documents naming another tenant are rejected in YAML as in JSON
+func TestWebhookController_ImportToCallerTenant(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	webhookSvc := mocks.NewMockWebhookService(t)
+	webhooks := controller.NewWebhookController(webhookSvc, nil, logging.Nop())
+	engine := gin.New()
+	api := engine.Group("/api", middleware.RequireRole(tenantAdmins{}, models.RoleAdmin, nil, logging.Nop()))
+	api.POST("/webhooks/import", webhooks.ImportWebhooks)
+	api.POST("/config/apply", webhooks.ApplyConfig)
+
+	var imported, applied []string
+	webhookSvc.EXPECT().ImportWebhooks(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.WebhookExport, opts models.WebhookImportOptions) (*models.WebhookImportResponse, error) {
+			imported = append(imported, opts.TenantID)
+			return &models.WebhookImportResponse{TenantID: opts.TenantID, Applied: true}, nil
+		})
+	webhookSvc.EXPECT().ApplyConfig(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.ConfigManifest, opts models.ConfigApplyOptions) (*models.ConfigApplyResponse, error) {
+			applied = append(applied, opts.TenantID)
+			return &models.ConfigApplyResponse{}, nil
+		})
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{name: "import json of another tenant", path: "/webhooks/import", contentType: "application/json",
+			body: `{"version": 1, "tenant_id": "tenant-a"}`, want: http.StatusForbidden},
+		{name: "import yaml of another tenant", path: "/webhooks/import", contentType: "application/yaml",
+			body: "version: 1\ntenant_id: tenant-a\n", want: http.StatusForbidden},
+		{name: "import yaml of own tenant", path: "/webhooks/import", contentType: "application/yaml",
+			body: "version: 1\ntenant_id: tenant-b\n", want: http.StatusCreated},
+		{name: "import yaml without tenant", path: "/webhooks/import", contentType: "application/yaml",
+			body: "version: 1\n", want: http.StatusCreated},
+		{name: "apply json of another tenant", path: "/config/apply", contentType: "application/json",
+			body: `{"version": 1, "tenant_id": "tenant-a"}`, want: http.StatusForbidden},
+		{name: "apply yaml of another tenant", path: "/config/apply", contentType: "application/yaml",
+			body: "version: 1\ntenant_id: tenant-a\n", want: http.StatusForbidden},
+		{name: "apply yaml without tenant", path: "/config/apply", contentType: "application/yaml",
+			body: "version: 1\n", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("X-API-Key", "tenant-b")
+			req.Header.Set("Content-Type", tt.contentType)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			engine.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, tt.want, recorder.Code, recorder.Body.String())
+		})
+	}
+	assert.Equal(t, []string{"tenant-b", "tenant-b"}, imported)
+	assert.Equal(t, []string{"tenant-b"}, applied)
+}
@@ -0,0 +1,231 @@
+// Package email sends plain text email over SMTP
+// Connections use STARTTLS when the server offers it, or implicit TLS on port 465, and authenticate
+// with PLAIN when credentials are configured; a connection is opened per message
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPort is the submission port used when Config.Port is empty
+	DefaultPort = "587"
+
+	// ImplicitTLSPort is the port connected to with TLS from the start instead of STARTTLS
+	ImplicitTLSPort = "465"
+
+	// DefaultTimeout bounds sending a message when the context has no earlier deadline
+	DefaultTimeout = 30 * time.Second
+)
+
+// ErrRejected is returned when the server permanently refuses a message or one of its recipients
+var ErrRejected = errors.New("email: message rejected")
+
+// Config configures the SMTP server messages are sent through
+type Config struct {
+	// Host is the SMTP server's host name
+	Host string
+
+	// Port is the SMTP server's port, DefaultPort if empty
+	Port string
+
+	// Username and Password authenticate with PLAIN; no authentication when Username is empty
+	Username string
+	Password string
+
+	// From is the sender address of every message, e.g. "Loki Suite <alerts@example.com>"
+	From string
+
+	// TLSConfig is used for STARTTLS and implicit TLS; the server name defaults to Host
+	TLSConfig *tls.Config
+}
+
+// Message is a plain text email
+type Message struct {
+	// To are the recipient addresses
+	To []string
+
+	// Subject is the subject line; line breaks are replaced with spaces
+	Subject string
+
+	// Text is the plain text body
+	Text string
+
+	// MessageID identifies the message, so clients thread or drop resent copies; generated by the server if empty
+	MessageID string
+
+	// Date is when the message was created, the current time if zero
+	Date time.Time
+
+	// Headers are additional headers of the message, such as X-Priority
+	Headers map[string]string
+}
+
+// Sender sends messages through an SMTP server
+type Sender struct {
+	cfg  Config
+	from *mail.Address
+}
+
+// NewSender creates a sender for an SMTP server
+// Returns:
+//   - error: If the host is missing or the sender address does not parse
+func NewSender(cfg Config) (*Sender, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("email: SMTP host is required")
+	}
+	if cfg.Port == "" {
+		cfg.Port = DefaultPort
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("email: invalid sender address: %w", err)
+	}
+	if cfg.TLSConfig == nil {
+		cfg.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.TLSConfig.ServerName == "" {
+		cfg.TLSConfig = cfg.TLSConfig.Clone()
+		cfg.TLSConfig.ServerName = cfg.Host
+	}
+	return &Sender{cfg: cfg, from: from}, nil
+}
+
+// Send delivers a message to its recipients
+// The message is sent to all recipients or none; a recipient the server refuses fails the message with ErrRejected
+func (s *Sender) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("%w: no recipients", ErrRejected)
+	}
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("email: failed to connect: %w", err)
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("email: failed to greet server: %w", err)
+	}
+	defer client.Close()
+
+	if s.cfg.Port != ImplicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(s.cfg.TLSConfig); err != nil {
+				return fmt.Errorf("email: STARTTLS failed: %w", err)
+			}
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("email: authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return classify("sender refused", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return classify("recipient "+to+" refused", err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return classify("data refused", err)
+	}
+	if _, err := w.Write(s.encode(msg)); err != nil {
+		return fmt.Errorf("email: failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return classify("message refused", err)
+	}
+	return client.Quit()
+}
+
+// dial connects to the server, with TLS from the start on ImplicitTLSPort
+func (s *Sender) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	if s.cfg.Port == ImplicitTLSPort {
+		dialer := &tls.Dialer{Config: s.cfg.TLSConfig}
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", addr)
+}
+
+// classify wraps an error of the SMTP conversation, marking permanent (5xx) replies as ErrRejected
+func classify(what string, err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return fmt.Errorf("%w: %s: %v", ErrRejected, what, err)
+	}
+	return fmt.Errorf("email: %s: %w", what, err)
+}
+
+// encode renders a message with its headers and a quoted-printable UTF-8 body
+func (s *Sender) encode(msg Message) []byte {
+	date := msg.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, headerValue(value))
+	}
+	header("From", s.from.String())
+	header("To", strings.Join(msg.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", headerValue(msg.Subject)))
+	header("Date", date.Format(time.RFC1123Z))
+	if msg.MessageID != "" {
+		header("Message-ID", "<"+msg.MessageID+">")
+	}
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+
+	names := make([]string, 0, len(msg.Headers))
+	for name := range msg.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header(textproto.CanonicalMIMEHeaderKey(headerValue(name)), msg.Headers[name])
+	}
+	buf.WriteString("\r\n")
+
+	// The writer turns line breaks into CRLF
+	body := quotedprintable.NewWriter(&buf)
+	body.Write([]byte(strings.ReplaceAll(msg.Text, "\r\n", "\n")))
+	body.Close()
+	return buf.Bytes()
+}
+
+// headerValue keeps rendered values from breaking out of their header line
+func headerValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
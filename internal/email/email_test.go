@@ -0,0 +1,160 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer accepts one SMTP session, refusing recipients at refused.example and recording the message
+type fakeServer struct {
+	ln         net.Listener
+	from       string
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{ln: ln, done: make(chan struct{})}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) serve() {
+	defer close(s.done)
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); verb {
+		case "EHLO", "HELO":
+			reply("250 fake")
+		case "MAIL":
+			s.from = command
+			reply("250 OK")
+		case "RCPT":
+			if strings.Contains(command, "refused.example") {
+				reply("550 5.1.1 No such user")
+				continue
+			}
+			s.recipients = append(s.recipients, command)
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.data = data.String()
+			reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Not implemented")
+		}
+	}
+}
+
+func (s *fakeServer) sender(t *testing.T) *Sender {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	sender, err := NewSender(Config{Host: host, Port: port, From: "Loki Suite <alerts@example.com>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+// TestSend tests that messages reach every recipient with their headers and a quoted-printable body
+func TestSend(t *testing.T) {
+	// Arrange
+	server := newFakeServer(t)
+	sender := server.sender(t)
+	msg := Message{
+		To:        []string{"ops@example.com", "oncall@example.com"},
+		Subject:   "Payment failed\r\nBcc: attacker@example.com",
+		Text:      "Customer: café\nAmount: 42",
+		MessageID: "event-1@loki-suite",
+		Date:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Headers:   map[string]string{"x-priority": "1"},
+	}
+
+	// Act
+	err := sender.Send(context.Background(), msg)
+	<-server.done
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "MAIL FROM:<alerts@example.com>", server.from)
+	assert.Equal(t, []string{"RCPT TO:<ops@example.com>", "RCPT TO:<oncall@example.com>"}, server.recipients)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(server.data))
+	if assert.NoError(t, err) {
+		assert.Equal(t, `"Loki Suite" <alerts@example.com>`, parsed.Header.Get("From"))
+		assert.Equal(t, "ops@example.com, oncall@example.com", parsed.Header.Get("To"))
+		assert.Equal(t, "Payment failed Bcc: attacker@example.com", parsed.Header.Get("Subject"))
+		assert.Empty(t, parsed.Header.Get("Bcc"))
+		assert.Equal(t, "<event-1@loki-suite>", parsed.Header.Get("Message-ID"))
+		assert.Equal(t, "1", parsed.Header.Get("X-Priority"))
+		body, _ := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+		// The body ends with the line break the terminating dot is sent after
+		assert.Equal(t, "Customer: café\r\nAmount: 42\r\n", string(body))
+	}
+}
+
+// TestSend_RecipientRefused tests that a recipient the server refuses for good fails the message with ErrRejected
+func TestSend_RecipientRefused(t *testing.T) {
+	// Arrange
+	server := newFakeServer(t)
+	sender := server.sender(t)
+
+	// Act
+	err := sender.Send(context.Background(), Message{To: []string{"ops@example.com", "gone@refused.example"}, Subject: "Alert"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRejected)
+	assert.ErrorContains(t, err, "gone@refused.example")
+}
+
+// TestNewSender tests that senders need a host and a valid sender address
+func TestNewSender(t *testing.T) {
+	_, err := NewSender(Config{From: "alerts@example.com"})
+	assert.ErrorContains(t, err, "host is required")
+
+	_, err = NewSender(Config{Host: "smtp.example.com", From: "not an address"})
+	assert.ErrorContains(t, err, "invalid sender address")
+
+	sender, err := NewSender(Config{Host: "smtp.example.com", From: "alerts@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPort, sender.cfg.Port)
+	assert.Equal(t, "smtp.example.com", sender.cfg.TLSConfig.ServerName)
+}
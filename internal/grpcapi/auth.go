@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/pkg/lokiv1"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles is the role each RPC requires, the one of its REST counterpart
+// RPCs missing here require the admin role
+var methodRoles = map[string]models.Role{
+	lokiv1.EventService_PublishEvent_FullMethodName:       models.RolePublisher,
+	lokiv1.EventService_PublishEventStream_FullMethodName: models.RolePublisher,
+
+	lokiv1.ChainService_CreateChain_FullMethodName:    models.RoleAdmin,
+	lokiv1.ChainService_GetChain_FullMethodName:       models.RoleViewer,
+	lokiv1.ChainService_ListChains_FullMethodName:     models.RoleViewer,
+	lokiv1.ChainService_UpdateChain_FullMethodName:    models.RoleAdmin,
+	lokiv1.ChainService_DeleteChain_FullMethodName:    models.RoleAdmin,
+	lokiv1.ChainService_ExecuteChain_FullMethodName:   models.RolePublisher,
+	lokiv1.ChainService_GetChainRun_FullMethodName:    models.RoleViewer,
+	lokiv1.ChainService_ListChainRuns_FullMethodName:  models.RoleViewer,
+	lokiv1.ChainService_CancelChainRun_FullMethodName: models.RolePublisher,
+}
+
+// principalKey is the context key under which the authenticated caller is stored
+type principalKey struct{}
+
+// principalFromContext returns the caller authenticated by the interceptors
+func principalFromContext(ctx context.Context) *models.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*models.Principal)
+	return principal
+}
+
+// callerTenant returns the tenant of the caller's credential, which its reads and changes of chains and runs are
+// scoped to; empty for global credentials, which reach every tenant's
+func callerTenant(ctx context.Context) string {
+	if principal := principalFromContext(ctx); principal != nil {
+		return principal.TenantID
+	}
+	return ""
+}
+
+// authorizer authenticates calls and checks the caller's role, like middleware.RequireRole for REST
+type authorizer struct {
+	auth    middleware.Authenticator
+	limiter *middleware.RateLimiter
+	logger  logging.Logger
+}
+
+// unary authorizes and rate limits unary calls
+func (a *authorizer) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := allow(a.limiter, principalFromContext(ctx)); err != nil {
+		a.logger.Warn(ctx, "Rate limit exceeded", zap.String("method", info.FullMethod))
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// stream authorizes streams; the events of a stream are rate limited one by one by the stream's handler
+func (a *authorizer) stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authorize authenticates the caller of a method and checks its role
+// Returns a context carrying the caller
+func (a *authorizer) authorize(ctx context.Context, method string) (context.Context, error) {
+	principal, err := a.authenticate(ctx)
+	if err != nil {
+		a.logger.Warn(ctx, "Authentication failed",
+			zap.String("method", method),
+			zap.Error(err))
+		message := "missing or invalid credentials"
+		if typed := apperr.As(err); typed != nil {
+			message = typed.Message
+		}
+		return nil, status.Error(codes.Unauthenticated, message)
+	}
+
+	if principal.TenantID != "" {
+		ctx = logging.WithFields(ctx, zap.String("tenant_id", principal.TenantID))
+	}
+
+	required, ok := methodRoles[method]
+	if !ok {
+		required = models.RoleAdmin
+	}
+	if !principal.Role.Allows(required) {
+		a.logger.Warn(ctx, "Insufficient role for call",
+			zap.String("method", method),
+			zap.String("role", string(principal.Role)),
+			zap.String("required_role", string(required)))
+		return nil, status.Error(codes.PermissionDenied,
+			"role "+string(principal.Role)+" cannot call this method, "+string(required)+" required")
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// authenticate verifies the credentials sent as x-api-key or "authorization: Bearer <jwt>" metadata
+func (a *authorizer) authenticate(ctx context.Context) (*models.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if apiKey := firstValue(md, "x-api-key"); apiKey != "" {
+		return a.auth.AuthenticateAPIKey(ctx, apiKey)
+	}
+
+	if token, found := strings.CutPrefix(firstValue(md, "authorization"), "Bearer "); found && token != "" {
+		return a.auth.AuthenticateToken(ctx, token)
+	}
+
+	return nil, errors.New("missing API key or bearer token")
+}
+
+// allow takes a call of the caller from its rate limit bucket, returning ResourceExhausted when it is empty
+func allow(limiter *middleware.RateLimiter, principal *models.Principal) error {
+	if limiter == nil || principal == nil {
+		return nil
+	}
+	allowed, wait := limiter.Allow(principal)
+	if allowed {
+		return nil
+	}
+	seconds := max(1, int(math.Ceil(wait.Seconds())))
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded, retry after "+strconv.Itoa(seconds)+"s")
+}
+
+// firstValue returns the first value of a metadata key, empty if it is missing
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// authorizedStream is a server stream whose context carries the authenticated caller
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,259 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/pkg/lokiv1"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// chainServer implements ChainService over the execution chain service
+type chainServer struct {
+	lokiv1.UnimplementedChainServiceServer
+
+	chainService service.ExecutionChainService
+	logger       logging.Logger
+}
+
+// CreateChain creates a chain from the body of POST /api/execution-chains
+func (s *chainServer) CreateChain(ctx context.Context, req *lokiv1.CreateChainRequest) (*lokiv1.CreateChainResponse, error) {
+	var createReq models.CreateExecutionChainRequest
+	if err := decodeStruct(req.GetChain(), &createReq); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := binding.Validator.ValidateStruct(&createReq); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	response, err := s.chainService.CreateChain(ctx, &createReq)
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to create execution chain", err, codes.Internal)
+	}
+
+	s.logger.Info(ctx, "Execution chain created successfully",
+		zap.String("chain_id", response.ChainID.String()),
+		zap.String("tenant_id", createReq.TenantID))
+
+	return &lokiv1.CreateChainResponse{
+		ChainId:      response.ChainID.String(),
+		Name:         response.Name,
+		TriggerEvent: response.TriggerEvent,
+		StepsCount:   int32(response.StepsCount),
+		Status:       response.Status,
+		CreatedAt:    timestamppb.New(response.CreatedAt),
+	}, nil
+}
+
+// GetChain returns a chain with its steps
+func (s *chainServer) GetChain(ctx context.Context, req *lokiv1.GetChainRequest) (*lokiv1.Chain, error) {
+	chainID, err := parseID("chain ID", req.GetChainId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	chain, err := s.chainService.GetChain(ctx, callerTenant(ctx), chainID)
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to get execution chain", err, codes.NotFound)
+	}
+	return chainMessage(chain)
+}
+
+// ListChains lists the chains of a tenant
+func (s *chainServer) ListChains(ctx context.Context, req *lokiv1.ListChainsRequest) (*lokiv1.ListChainsResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArgument(errors.New("tenant_id is required"))
+	}
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+
+	response, err := s.chainService.ListChains(ctx, req.GetTenantId(), models.ListFilter{}, models.ListPage{Page: page, Limit: limit})
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to list execution chains", err, codes.Internal)
+	}
+
+	resp := &lokiv1.ListChainsResponse{Total: response.Total, Page: int32(response.Page), Limit: int32(response.Limit)}
+	for i := range response.Chains {
+		chain, err := chainMessage(&response.Chains[i])
+		if err != nil {
+			return nil, serviceError(ctx, s.logger, "Failed to encode execution chain", err, codes.Internal)
+		}
+		resp.Chains = append(resp.Chains, chain)
+	}
+	return resp, nil
+}
+
+// UpdateChain changes the name, description, activation or completion webhook of a chain
+func (s *chainServer) UpdateChain(ctx context.Context, req *lokiv1.UpdateChainRequest) (*lokiv1.UpdateChainResponse, error) {
+	chainID, err := parseID("chain ID", req.GetChainId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	updateReq := models.UpdateExecutionChainRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		IsActive:    req.IsActive,
+	}
+	if req.CompletionWebhookId != nil {
+		// An empty ID removes the completion webhook, like the nil UUID in the REST API
+		webhookID := uuid.Nil
+		if *req.CompletionWebhookId != "" {
+			if webhookID, err = parseID("completion webhook ID", *req.CompletionWebhookId); err != nil {
+				return nil, invalidArgument(err)
+			}
+		}
+		updateReq.CompletionWebhookID = &webhookID
+	}
+
+	if err := s.chainService.UpdateChain(ctx, callerTenant(ctx), chainID, &updateReq); err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to update execution chain", err, codes.Internal)
+	}
+
+	s.logger.Info(ctx, "Execution chain updated successfully",
+		zap.String("chain_id", chainID.String()))
+	return &lokiv1.UpdateChainResponse{}, nil
+}
+
+// DeleteChain soft deletes a chain
+func (s *chainServer) DeleteChain(ctx context.Context, req *lokiv1.DeleteChainRequest) (*lokiv1.DeleteChainResponse, error) {
+	chainID, err := parseID("chain ID", req.GetChainId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.chainService.DeleteChain(ctx, callerTenant(ctx), chainID); err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to delete execution chain", err, codes.Internal)
+	}
+
+	s.logger.Info(ctx, "Execution chain deleted successfully",
+		zap.String("chain_id", chainID.String()))
+	return &lokiv1.DeleteChainResponse{}, nil
+}
+
+// ExecuteChain starts a run of a chain, or returns its plan for dry runs and validations
+func (s *chainServer) ExecuteChain(ctx context.Context, req *lokiv1.ExecuteChainRequest) (*lokiv1.ExecuteChainResponse, error) {
+	chainID, err := parseID("chain ID", req.GetChainId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	body := models.ExecuteChainBody{CallbackURL: req.GetCallbackUrl()}
+	if req.GetTriggerData() != nil {
+		body.TriggerData = req.GetTriggerData().AsMap()
+	}
+	if req.GetExecutionOptions() != nil {
+		body.Options = &models.ChainExecutionOptions{}
+		if err := decodeStruct(req.GetExecutionOptions(), body.Options); err != nil {
+			return nil, invalidArgument(err)
+		}
+	}
+	if err := binding.Validator.ValidateStruct(&body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	executeReq := &models.ExecuteChainRequest{
+		ChainID:     chainID,
+		TriggerData: body.TriggerData,
+		CallbackURL: body.CallbackURL,
+		Options:     body.Options,
+	}
+
+	if executeReq.Options != nil && (executeReq.Options.DryRun || executeReq.Options.ValidationOnly) {
+		plan, err := s.chainService.DryRunChain(ctx, callerTenant(ctx), executeReq)
+		if err != nil {
+			return nil, serviceError(ctx, s.logger, "Failed to dry run chain", err, codes.Internal)
+		}
+		planStruct, err := encodeStruct(plan)
+		if err != nil {
+			return nil, serviceError(ctx, s.logger, "Failed to encode dry run plan", err, codes.Internal)
+		}
+		return &lokiv1.ExecuteChainResponse{ChainId: chainID.String(), Plan: planStruct}, nil
+	}
+
+	response, err := s.chainService.ExecuteChain(ctx, callerTenant(ctx), executeReq)
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to execute chain", err, codes.Internal)
+	}
+
+	s.logger.Info(ctx, "Execution chain started successfully",
+		zap.String("chain_id", chainID.String()),
+		zap.String("run_id", response.RunID.String()))
+
+	return &lokiv1.ExecuteChainResponse{
+		RunId:          response.RunID.String(),
+		ChainId:        response.ChainID.String(),
+		Status:         response.Status,
+		TotalSteps:     int32(response.TotalSteps),
+		StartedAt:      timestamp(response.StartedAt),
+		QueuePosition:  int32(response.QueuePosition),
+		CallbackSecret: response.CallbackSecret,
+		RetryOfRunId:   optionalID(response.RetryOfRunID),
+	}, nil
+}
+
+// GetChainRun returns a run with its step results
+func (s *chainServer) GetChainRun(ctx context.Context, req *lokiv1.GetChainRunRequest) (*lokiv1.ChainRun, error) {
+	runID, err := parseID("run ID", req.GetRunId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	run, err := s.chainService.GetChainRun(ctx, callerTenant(ctx), runID)
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to get chain run", err, codes.NotFound)
+	}
+	return chainRunMessage(run)
+}
+
+// ListChainRuns lists the runs of a chain
+func (s *chainServer) ListChainRuns(ctx context.Context, req *lokiv1.ListChainRunsRequest) (*lokiv1.ListChainRunsResponse, error) {
+	chainID, err := parseID("chain ID", req.GetChainId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+
+	response, err := s.chainService.ListChainRuns(ctx, callerTenant(ctx), chainID, models.ListFilter{}, models.ListPage{Page: page, Limit: limit})
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to list chain runs", err, codes.Internal)
+	}
+
+	resp := &lokiv1.ListChainRunsResponse{Total: response.Total, Page: int32(response.Page), Limit: int32(response.Limit)}
+	for i := range response.Runs {
+		run, err := chainRunMessage(&response.Runs[i])
+		if err != nil {
+			return nil, serviceError(ctx, s.logger, "Failed to encode chain run", err, codes.Internal)
+		}
+		resp.Runs = append(resp.Runs, run)
+	}
+	return resp, nil
+}
+
+// CancelChainRun cancels a queued, running or paused run
+func (s *chainServer) CancelChainRun(ctx context.Context, req *lokiv1.CancelChainRunRequest) (*lokiv1.ChainRunControlResponse, error) {
+	runID, err := parseID("run ID", req.GetRunId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	response, err := s.chainService.CancelChainRun(ctx, runID, req.GetReason())
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to cancel chain run", err, codes.FailedPrecondition)
+	}
+
+	return &lokiv1.ChainRunControlResponse{
+		RunId:       response.RunID.String(),
+		ChainId:     response.ChainID.String(),
+		Status:      response.Status,
+		CurrentStep: int32(response.CurrentStep),
+		TotalSteps:  int32(response.TotalSteps),
+	}, nil
+}
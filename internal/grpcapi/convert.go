@@ -0,0 +1,152 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/pkg/lokiv1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// decodeStruct decodes a Struct holding a REST request body into the request's model
+// The Struct is converted through JSON, so fields are named and typed like in the REST API
+func decodeStruct(s *structpb.Struct, v interface{}) error {
+	if s == nil {
+		return nil
+	}
+	data, err := json.Marshal(s.AsMap())
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return nil
+}
+
+// encodeStruct encodes a model as a Struct holding its REST representation
+func encodeStruct(v interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(fields)
+}
+
+// parseID parses the ID of a chain or run named by a request
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s format", field)
+	}
+	return id, nil
+}
+
+// optionalID formats an optional ID, empty for nil
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// timestamp converts an optional time, nil for nil
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// pagination applies the REST API's defaults to a page and limit
+func pagination(page, limit int32) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return int(page), int(limit)
+}
+
+// eventResponse converts the result of sending an event
+func eventResponse(requestID string, result *models.EventProcessingResult) *lokiv1.PublishEventResponse {
+	resp := &lokiv1.PublishEventResponse{
+		RequestId:   requestID,
+		EventId:     result.EventID.String(),
+		TotalSent:   int32(result.TotalSent),
+		TotalFailed: int32(result.TotalFailed),
+		TotalQueued: int32(result.TotalQueued),
+		Scheduled:   result.Scheduled,
+		DeliverAt:   timestamp(result.DeliverAt),
+	}
+	for _, webhook := range result.Webhooks {
+		delivery := &lokiv1.WebhookDeliveryResult{
+			WebhookId:    webhook.WebhookID.String(),
+			TargetUrl:    webhook.TargetURL,
+			Success:      webhook.Success,
+			AttemptCount: int32(webhook.AttemptCount),
+			Queued:       webhook.Queued,
+			PausedUntil:  timestamp(webhook.PausedUntil),
+		}
+		if webhook.ResponseCode != nil {
+			delivery.ResponseCode = int32(*webhook.ResponseCode)
+		}
+		if webhook.Error != nil {
+			delivery.Error = *webhook.Error
+		}
+		resp.Webhooks = append(resp.Webhooks, delivery)
+	}
+	return resp
+}
+
+// chainMessage converts a chain, keeping its full REST representation as details
+func chainMessage(chain *models.ExecutionChain) (*lokiv1.Chain, error) {
+	details, err := encodeStruct(chain)
+	if err != nil {
+		return nil, err
+	}
+	return &lokiv1.Chain{
+		Id:           chain.ID.String(),
+		TenantId:     chain.TenantID,
+		Name:         chain.Name,
+		Description:  chain.Description,
+		Status:       string(chain.Status),
+		TriggerEvent: chain.TriggerEvent,
+		IsActive:     chain.IsActive,
+		Version:      int32(chain.Version),
+		CreatedAt:    timestamppb.New(chain.CreatedAt),
+		UpdatedAt:    timestamppb.New(chain.UpdatedAt),
+		Details:      details,
+	}, nil
+}
+
+// chainRunMessage converts a chain run, keeping its full REST representation as details
+func chainRunMessage(run *models.ExecutionChainRun) (*lokiv1.ChainRun, error) {
+	details, err := encodeStruct(run)
+	if err != nil {
+		return nil, err
+	}
+	return &lokiv1.ChainRun{
+		Id:           run.ID.String(),
+		ChainId:      run.ChainID.String(),
+		TenantId:     run.TenantID,
+		Status:       string(run.Status),
+		TriggerEvent: run.TriggerEvent,
+		ChainVersion: int32(run.ChainVersion),
+		CurrentStep:  int32(run.CurrentStep),
+		TotalSteps:   int32(run.TotalSteps),
+		StartedAt:    timestamp(run.StartedAt),
+		CompletedAt:  timestamp(run.CompletedAt),
+		Details:      details,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// errorCode returns the code a failed call is answered with, the equivalent of the REST API's status
+// fallback is used for errors without a specific mapping, like the status a controller falls back to
+func errorCode(err error, fallback codes.Code) codes.Code {
+	var validationErr *service.PayloadValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrTenantExists):
+		return codes.AlreadyExists
+	case errors.Is(err, apperr.ErrNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		return codes.NotFound
+	case errors.Is(err, apperr.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, apperr.ErrConflict):
+		return codes.FailedPrecondition
+	case errors.Is(err, apperr.ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, apperr.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, apperr.ErrLimitExceeded):
+		return codes.ResourceExhausted
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return fallback
+	}
+}
+
+// serviceError converts an error of the service layer into a status error, logging it
+// Rejections are logged as warnings, failures of the server as errors
+// Like the REST API, the status carries the message of typed errors and msg for others, never their text
+func serviceError(ctx context.Context, logger logging.Logger, msg string, err error, fallback codes.Code) error {
+	code := errorCode(err, fallback)
+	if code == codes.Internal || code == codes.Unavailable {
+		logger.Error(ctx, msg, zap.Error(err))
+	} else {
+		logger.Warn(ctx, msg, zap.String("code", code.String()), zap.Error(err))
+	}
+
+	var validationErr *service.PayloadValidationError
+	switch typed := apperr.As(err); {
+	case typed != nil:
+		msg = typed.Message
+	case errors.As(err, &validationErr):
+		msg = validationErr.Error()
+	}
+	return status.Error(code, msg)
+}
+
+// invalidArgument returns an InvalidArgument status error for a malformed request
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/pkg/lokiv1"
+
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventServer implements EventService over the webhook service
+type eventServer struct {
+	lokiv1.UnimplementedEventServiceServer
+
+	webhookService service.WebhookService
+	limiter        *middleware.RateLimiter
+	concurrency    int
+	logger         logging.Logger
+}
+
+// PublishEvent publishes an event, like POST /api/webhooks/event
+func (s *eventServer) PublishEvent(ctx context.Context, req *lokiv1.PublishEventRequest) (*lokiv1.PublishEventResponse, error) {
+	return s.publish(ctx, req)
+}
+
+// PublishEventStream publishes the events of a stream, processing up to the configured number at once
+// Every event is answered on the stream; failed events are answered with their error and the stream goes on
+func (s *eventServer) PublishEventStream(stream lokiv1.EventService_PublishEventStreamServer) error {
+	ctx := stream.Context()
+	principal := principalFromContext(ctx)
+
+	var (
+		inFlight sync.WaitGroup
+		sendMu   sync.Mutex
+		sendErr  error
+	)
+	slots := make(chan struct{}, s.concurrency)
+	send := func(resp *lokiv1.PublishEventResponse) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if sendErr == nil {
+			sendErr = stream.Send(resp)
+		}
+	}
+	finish := func(err error) error {
+		inFlight.Wait()
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if err == nil {
+			err = sendErr
+		}
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return finish(nil)
+		}
+		if err != nil {
+			return finish(err)
+		}
+
+		if err := allow(s.limiter, principal); err != nil {
+			send(streamError(req.GetRequestId(), err))
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return finish(status.FromContextError(ctx.Err()).Err())
+		}
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			defer func() { <-slots }()
+
+			resp, err := s.publish(ctx, req)
+			if err != nil {
+				resp = streamError(req.GetRequestId(), err)
+			}
+			send(resp)
+		}()
+	}
+}
+
+// publish validates an event like the REST API and sends it through the webhook service
+func (s *eventServer) publish(ctx context.Context, req *lokiv1.PublishEventRequest) (*lokiv1.PublishEventResponse, error) {
+	sendReq := models.SendEventRequest{
+		TenantID:     req.GetTenantId(),
+		Event:        req.GetEvent(),
+		Source:       req.GetSource(),
+		DelaySeconds: int(req.GetDelaySeconds()),
+	}
+	if req.GetPayload() != nil {
+		sendReq.Payload = req.GetPayload().AsInterface()
+	}
+	if req.GetDeliverAt() != nil {
+		deliverAt := req.GetDeliverAt().AsTime()
+		sendReq.DeliverAt = &deliverAt
+	}
+	if err := binding.Validator.ValidateStruct(&sendReq); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	result, err := s.webhookService.SendEvent(ctx, &sendReq)
+	if err != nil {
+		return nil, serviceError(ctx, s.logger, "Failed to send webhook event", err, codes.Internal)
+	}
+
+	s.logger.Debug(ctx, "Webhook event processed successfully",
+		zap.String("event_id", result.EventID.String()),
+		zap.String("tenant_id", sendReq.TenantID),
+		zap.String("event", sendReq.Event),
+		zap.Int("total_sent", result.TotalSent),
+		zap.Int("total_failed", result.TotalFailed))
+	return eventResponse(req.GetRequestId(), result), nil
+}
+
+// streamError answers an event of a stream that was not published
+func streamError(requestID string, err error) *lokiv1.PublishEventResponse {
+	st := status.Convert(err)
+	return &lokiv1.PublishEventResponse{
+		RequestId: requestID,
+		Error: &lokiv1.Error{
+			Code:    int32(st.Code()),
+			Message: st.Message(),
+		},
+	}
+}
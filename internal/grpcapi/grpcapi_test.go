@@ -0,0 +1,237 @@
+package grpcapi_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/grpcapi"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+	"github.com/sakibcoolz/loki-suite/pkg/lokiv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// roleAuthenticator authenticates every API key as the role named by the key, bound to tenant acme
+type roleAuthenticator struct{}
+
+func (roleAuthenticator) AuthenticateAPIKey(_ context.Context, apiKey string) (*models.Principal, error) {
+	role := models.Role(apiKey)
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid API key")
+	}
+	return &models.Principal{TenantID: "acme", Role: role}, nil
+}
+
+func (roleAuthenticator) AuthenticateToken(context.Context, string) (*models.Principal, error) {
+	return nil, fmt.Errorf("invalid token")
+}
+
+// startServer serves the gRPC API over an in-memory listener and returns a connection to it
+func startServer(t *testing.T, webhookService service.WebhookService, chainService service.ExecutionChainService, config grpcapi.Config) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := grpcapi.NewServer(webhookService, chainService, roleAuthenticator{}, config, logging.Nop())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// withKey returns a context sending an API key
+func withKey(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", apiKey)
+}
+
+// TestPublishEvent tests that events are validated and sent through the webhook service and that the
+// caller's role is checked
+func TestPublishEvent(t *testing.T) {
+	// Arrange
+	webhookService := mocks.NewMockWebhookService(t)
+	conn := startServer(t, webhookService, mocks.NewMockExecutionChainService(t), grpcapi.Config{})
+	client := lokiv1.NewEventServiceClient(conn)
+
+	eventID := uuid.New()
+	webhookID := uuid.New()
+	webhookService.On("SendEvent", mock.Anything, mock.MatchedBy(func(req *models.SendEventRequest) bool {
+		payload, _ := req.Payload.(map[string]interface{})
+		return req.TenantID == "acme" && req.Event == "order.created" && payload["order_id"] == "ORD-1"
+	})).Return(&models.EventProcessingResult{
+		EventID:   eventID,
+		TotalSent: 1,
+		Webhooks:  []models.WebhookDeliveryResult{{WebhookID: webhookID, TargetURL: "https://example.com", Success: true, AttemptCount: 1}},
+	}, nil).Once()
+
+	payload, _ := structpb.NewValue(map[string]interface{}{"order_id": "ORD-1"})
+	req := &lokiv1.PublishEventRequest{RequestId: "r1", TenantId: "acme", Event: "order.created", Source: "checkout", Payload: payload}
+
+	// Act
+	resp, err := client.PublishEvent(withKey("publisher"), req)
+	_, viewerErr := client.PublishEvent(withKey("viewer"), req)
+	_, anonymousErr := client.PublishEvent(context.Background(), req)
+	_, invalidErr := client.PublishEvent(withKey("publisher"), &lokiv1.PublishEventRequest{TenantId: "acme", Event: "order.created"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "r1", resp.GetRequestId())
+	assert.Equal(t, eventID.String(), resp.GetEventId())
+	assert.Equal(t, int32(1), resp.GetTotalSent())
+	assert.Equal(t, webhookID.String(), resp.GetWebhooks()[0].GetWebhookId())
+	assert.Equal(t, codes.PermissionDenied, status.Code(viewerErr))
+	assert.Equal(t, codes.Unauthenticated, status.Code(anonymousErr))
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+}
+
+// TestPublishEvent_ServiceErrors tests that errors of the service layer map to the codes matching the REST statuses
+func TestPublishEvent_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{service.ErrTenantNotFound, codes.NotFound},
+		{service.ErrTenantSuspended, codes.PermissionDenied},
+		{fmt.Errorf("%w: 100 events per day", service.ErrQuotaExceeded), codes.ResourceExhausted},
+		{&service.PayloadValidationError{EventType: "order.created", Violations: []models.SchemaViolation{{Path: "$.total", Message: "must be a number"}}}, codes.InvalidArgument},
+		{fmt.Errorf("database unavailable"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			// Arrange
+			webhookService := mocks.NewMockWebhookService(t)
+			conn := startServer(t, webhookService, mocks.NewMockExecutionChainService(t), grpcapi.Config{})
+			webhookService.On("SendEvent", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+			payload, _ := structpb.NewValue("data")
+
+			// Act
+			_, err := lokiv1.NewEventServiceClient(conn).PublishEvent(withKey("publisher"),
+				&lokiv1.PublishEventRequest{TenantId: "acme", Event: "order.created", Source: "checkout", Payload: payload})
+
+			// Assert
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+// TestPublishEventStream tests that every event of a stream is answered with its request ID, that failed
+// events are answered with an error without ending the stream, and that events are rate limited one by one
+func TestPublishEventStream(t *testing.T) {
+	// Arrange
+	webhookService := mocks.NewMockWebhookService(t)
+	limiter := middleware.NewRateLimiter(0.001, 3)
+	conn := startServer(t, webhookService, mocks.NewMockExecutionChainService(t), grpcapi.Config{StreamConcurrency: 2, RateLimiter: limiter})
+
+	webhookService.On("SendEvent", mock.Anything, mock.MatchedBy(func(req *models.SendEventRequest) bool {
+		return req.Event == "order.bad"
+	})).Return(nil, service.ErrTenantSuspended).Once()
+	webhookService.On("SendEvent", mock.Anything, mock.MatchedBy(func(req *models.SendEventRequest) bool {
+		return req.Event == "order.created"
+	})).Return(&models.EventProcessingResult{EventID: uuid.New(), TotalSent: 1}, nil).Twice()
+
+	ctx, cancel := context.WithTimeout(withKey("publisher"), 5*time.Second)
+	defer cancel()
+	stream, err := lokiv1.NewEventServiceClient(conn).PublishEventStream(ctx)
+	assert.NoError(t, err)
+	payload, _ := structpb.NewValue(map[string]interface{}{"id": 1})
+
+	// Act
+	for i, event := range []string{"order.created", "order.bad", "order.created", "order.created"} {
+		err := stream.Send(&lokiv1.PublishEventRequest{RequestId: fmt.Sprintf("r%d", i), TenantId: "acme", Event: event, Source: "checkout", Payload: payload})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, stream.CloseSend())
+
+	responses := map[string]*lokiv1.PublishEventResponse{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		responses[resp.GetRequestId()] = resp
+	}
+
+	// Assert
+	ids := make([]string, 0, len(responses))
+	for id := range responses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	assert.Equal(t, []string{"r0", "r1", "r2", "r3"}, ids)
+	assert.Nil(t, responses["r0"].GetError())
+	assert.Equal(t, int32(1), responses["r0"].GetTotalSent())
+	assert.Equal(t, int32(codes.PermissionDenied), responses["r1"].GetError().GetCode())
+	assert.Nil(t, responses["r2"].GetError())
+	assert.Equal(t, int32(codes.ResourceExhausted), responses["r3"].GetError().GetCode())
+}
+
+// TestChainService tests that chains are created from the REST body and returned with their REST representation
+func TestChainService(t *testing.T) {
+	// Arrange
+	chainService := mocks.NewMockExecutionChainService(t)
+	conn := startServer(t, mocks.NewMockWebhookService(t), chainService, grpcapi.Config{})
+	client := lokiv1.NewChainServiceClient(conn)
+
+	chainID := uuid.New()
+	webhookID := uuid.New()
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	chainService.On("CreateChain", mock.Anything, mock.MatchedBy(func(req *models.CreateExecutionChainRequest) bool {
+		return req.Name == "Order Processing" && len(req.Steps) == 1 && *req.Steps[0].WebhookID == webhookID
+	})).Return(&models.CreateExecutionChainResponse{ChainID: chainID, Name: "Order Processing", StepsCount: 1, Status: "pending", CreatedAt: created}, nil).Once()
+	chainService.On("GetChain", mock.Anything, "acme", chainID).Return(&models.ExecutionChain{
+		ID: chainID, TenantID: "acme", Name: "Order Processing", TriggerEvent: "order.placed", IsActive: true,
+		Steps: []models.ExecutionChainStep{{ChainID: chainID, StepOrder: 1, Name: "Charge"}},
+	}, nil).Once()
+	chainService.On("GetChainRun", mock.Anything, "acme", mock.Anything).Return(nil, fmt.Errorf("chain run not found: %w", service.ErrTenantNotFound)).Once()
+
+	body, _ := structpb.NewStruct(map[string]interface{}{
+		"tenant_id": "acme", "name": "Order Processing", "trigger_event": "order.placed",
+		"steps": []interface{}{map[string]interface{}{"name": "Charge", "webhook_id": webhookID.String()}},
+	})
+	noSteps, _ := structpb.NewStruct(map[string]interface{}{"tenant_id": "acme", "name": "Empty", "trigger_event": "order.placed"})
+
+	// Act
+	createResp, createErr := client.CreateChain(withKey("admin"), &lokiv1.CreateChainRequest{Chain: body})
+	_, publisherErr := client.CreateChain(withKey("publisher"), &lokiv1.CreateChainRequest{Chain: body})
+	_, invalidErr := client.CreateChain(withKey("admin"), &lokiv1.CreateChainRequest{Chain: noSteps})
+	chain, getErr := client.GetChain(withKey("viewer"), &lokiv1.GetChainRequest{ChainId: chainID.String()})
+	_, badIDErr := client.GetChain(withKey("viewer"), &lokiv1.GetChainRequest{ChainId: "not-a-uuid"})
+	_, runErr := client.GetChainRun(withKey("viewer"), &lokiv1.GetChainRunRequest{RunId: uuid.New().String()})
+
+	// Assert
+	assert.NoError(t, createErr)
+	assert.Equal(t, chainID.String(), createResp.GetChainId())
+	assert.Equal(t, created, createResp.GetCreatedAt().AsTime())
+	assert.Equal(t, codes.PermissionDenied, status.Code(publisherErr))
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	assert.NoError(t, getErr)
+	assert.Equal(t, "order.placed", chain.GetTriggerEvent())
+	assert.True(t, chain.GetIsActive())
+	assert.Len(t, chain.GetDetails().GetFields()["steps"].GetListValue().GetValues(), 1)
+	assert.Equal(t, codes.InvalidArgument, status.Code(badIDErr))
+	assert.Equal(t, codes.NotFound, status.Code(runErr))
+}
@@ -0,0 +1,48 @@
+// Package grpcapi serves the gRPC API defined in proto/loki/v1 next to the REST API
+// The RPCs call the same services as the Gin controllers and authenticate with the same credentials
+package grpcapi
+
+import (
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/pkg/lokiv1"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultStreamConcurrency is how many events of one PublishEventStream are processed at once
+const DefaultStreamConcurrency = 16
+
+// Config configures the gRPC API
+type Config struct {
+	// StreamConcurrency bounds the events of one stream processed at once, DefaultStreamConcurrency if 0
+	StreamConcurrency int
+
+	// RateLimiter applies the REST API's limits to calls and to every event of a stream, nil to not limit
+	RateLimiter *middleware.RateLimiter
+}
+
+// NewServer creates a gRPC server exposing the event and chain services
+// Calls are authenticated by auth and need the role of their REST counterpart; rejected and failed calls
+// are logged to logger
+func NewServer(webhookService service.WebhookService, chainService service.ExecutionChainService, auth middleware.Authenticator, config Config, logger logging.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	if config.StreamConcurrency <= 0 {
+		config.StreamConcurrency = DefaultStreamConcurrency
+	}
+
+	authz := &authorizer{auth: auth, limiter: config.RateLimiter, logger: logger}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(authz.unary),
+		grpc.ChainStreamInterceptor(authz.stream))
+	server := grpc.NewServer(opts...)
+
+	lokiv1.RegisterEventServiceServer(server, &eventServer{
+		webhookService: webhookService,
+		limiter:        config.RateLimiter,
+		concurrency:    config.StreamConcurrency,
+		logger:         logger,
+	})
+	lokiv1.RegisterChainServiceServer(server, &chainServer{chainService: chainService, logger: logger})
+	return server
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dashboardAssets are the files of the admin dashboard, embedded so the binary serves it without a build step
+//
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// dashboardCSP restricts the dashboard to its own assets and the API on the same origin, so tenant data it
+// renders cannot load or run anything else
+const dashboardCSP = "default-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+// dashboardFiles serves the dashboard's files under /admin/
+var dashboardFiles = func() http.Handler {
+	files, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		panic("dashboard assets are not embedded: " + err.Error())
+	}
+	return http.StripPrefix("/admin", http.FileServer(http.FS(files)))
+}()
+
+// serveDashboard handles GET /admin/*filepath
+// The page is public; the data it shows is loaded from the admin API with the API key the operator enters
+func (r *Router) serveDashboard(c *gin.Context) {
+	c.Header("Content-Security-Policy", dashboardCSP)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "no-cache")
+	dashboardFiles.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,299 @@
+// Loki Suite admin dashboard
+// Every view is read from the admin API with the operator's API key, which is kept for the browser session only.
+// Replaying a failed event publishes it again; retrying a failed run resumes it from its failed step.
+"use strict";
+
+const PAGE_SIZE = 25;
+
+const state = {
+  view: "subscriptions",
+  page: 1,
+  total: 0,
+  filters: { events: { status: "failed" }, runs: { status: "failed" } },
+};
+
+const $ = (selector) => document.querySelector(selector);
+
+// el creates an element; text is always set as text so tenant data is never interpreted as markup
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    if (name === "onclick") {
+      node.addEventListener("click", value);
+    } else if (value !== undefined && value !== null && value !== false) {
+      node.setAttribute(name, value === true ? "" : value);
+    }
+  }
+  for (const child of children) {
+    if (child !== undefined && child !== null) {
+      node.append(child instanceof Node ? child : String(child));
+    }
+  }
+  return node;
+}
+
+function statusBadge(status) {
+  return el("span", { class: "status status-" + status }, status);
+}
+
+function formatTime(value) {
+  return value ? new Date(value).toLocaleString() : "";
+}
+
+function formatDuration(start, end) {
+  if (!start) {
+    return "";
+  }
+  const ms = (end ? new Date(end) : new Date()) - new Date(start);
+  return ms < 1000 ? ms + " ms" : (ms / 1000).toFixed(1) + " s";
+}
+
+function showMessage(text, isError) {
+  const message = $("#message");
+  message.textContent = text;
+  message.classList.toggle("error", Boolean(isError));
+  message.hidden = !text;
+}
+
+// api calls the management API and returns the decoded response, throwing the API's message on errors
+async function api(method, path, body) {
+  const headers = { "X-API-Key": sessionStorage.getItem("loki-api-key") || "" };
+  const init = { method, headers };
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+    init.body = JSON.stringify(body);
+  }
+  const response = await fetch(path, init);
+  const data = await response.json().catch(() => ({}));
+  if (!response.ok) {
+    throw new Error(data.message || data.error || "Request failed with status " + response.status);
+  }
+  return data;
+}
+
+function query(params) {
+  const search = new URLSearchParams({ page: state.page, limit: PAGE_SIZE });
+  const tenant = $("#tenant").value.trim();
+  if (tenant) {
+    search.set("tenant_id", tenant);
+  }
+  for (const [name, value] of Object.entries(params || {})) {
+    if (value) {
+      search.set(name, value);
+    }
+  }
+  return search.toString();
+}
+
+function fillTable(view, rows) {
+  const body = document.querySelector("#" + view + " tbody");
+  body.replaceChildren(...rows);
+  if (rows.length === 0) {
+    const columns = document.querySelectorAll("#" + view + " thead th").length;
+    body.append(el("tr", {}, el("td", { colspan: columns }, "Nothing to show")));
+  }
+}
+
+async function loadSubscriptions() {
+  const data = await api("GET", "/api/v1/admin/subscriptions?" + query());
+  fillTable("subscriptions", (data.webhooks || []).map((sub) => el("tr", {},
+    el("td", {}, sub.tenant_id),
+    el("td", {}, sub.app_name),
+    el("td", {}, sub.subscribed_event),
+    el("td", { class: "wrap" }, sub.target_url || sub.target_type),
+    el("td", {}, sub.type),
+    el("td", {}, sub.is_active ? "yes" : "no"),
+    el("td", {}, formatTime(sub.created_at)),
+  )));
+  return data.total;
+}
+
+async function loadEvents() {
+  const data = await api("GET", "/api/v1/admin/events?" + query(state.filters.events));
+  fillTable("events", (data.events || []).map((event) => el("tr", {},
+    el("td", {}, event.tenant_id),
+    el("td", {}, event.event_name),
+    el("td", {}, event.source),
+    el("td", {}, statusBadge(event.status)),
+    el("td", {}, event.response_code),
+    el("td", {}, event.attempts),
+    el("td", { class: "wrap" }, event.last_error),
+    el("td", {}, formatTime(event.created_at)),
+    el("td", {}, event.status === "failed"
+      ? el("button", { type: "button", onclick: (e) => replayEvent(event, e.target) }, "Replay")
+      : null),
+  )));
+  return data.total;
+}
+
+async function loadRuns() {
+  const data = await api("GET", "/api/v1/admin/chain-runs?" + query(state.filters.runs));
+  fillTable("runs", (data.runs || []).map((run) => {
+    const row = el("tr", {},
+      el("td", {}, run.tenant_id),
+      el("td", {}, (run.chain && run.chain.name) || run.chain_id),
+      el("td", {}, statusBadge(run.status)),
+      el("td", {}, run.current_step + " / " + run.total_steps),
+      el("td", {}, formatTime(run.started_at)),
+      el("td", {}, formatDuration(run.started_at, run.completed_at)),
+      el("td", {},
+        el("button", { type: "button", onclick: () => showTimeline(run.id, row) }, "Timeline"),
+        run.status === "failed"
+          ? el("button", { type: "button", onclick: (e) => retryRun(run, e.target) }, "Retry")
+          : null),
+    );
+    return row;
+  }));
+  return data.total;
+}
+
+const loaders = { subscriptions: loadSubscriptions, events: loadEvents, runs: loadRuns };
+
+async function load() {
+  if (!sessionStorage.getItem("loki-api-key")) {
+    showMessage("Enter an admin API key that is not bound to a tenant.");
+    return;
+  }
+  try {
+    state.total = (await loaders[state.view]()) || 0;
+    showMessage("");
+  } catch (err) {
+    state.total = 0;
+    showMessage(err.message, true);
+  }
+  const pages = Math.max(1, Math.ceil(state.total / PAGE_SIZE));
+  $("#page").textContent = "Page " + state.page + " of " + pages + " (" + state.total + ")";
+  $("#previous").disabled = state.page <= 1;
+  $("#next").disabled = state.page >= pages;
+}
+
+async function replayEvent(event, button) {
+  let payload;
+  try {
+    payload = typeof event.payload === "string" ? JSON.parse(event.payload) : event.payload;
+  } catch (err) {
+    showMessage("The event's payload is not JSON and cannot be replayed.", true);
+    return;
+  }
+  if (!confirm("Publish " + event.event_name + " again to every active subscriber of " + event.tenant_id + "?")) {
+    return;
+  }
+  button.disabled = true;
+  try {
+    const result = await api("POST", "/api/v1/webhooks/event", {
+      tenant_id: event.tenant_id,
+      event: event.event_name,
+      source: event.source,
+      payload,
+    });
+    showMessage(result.message || "Event published again.");
+  } catch (err) {
+    button.disabled = false;
+    showMessage(err.message, true);
+  }
+}
+
+async function retryRun(run, button) {
+  button.disabled = true;
+  try {
+    const result = await api("POST", "/api/v1/execution-chains/runs/" + encodeURIComponent(run.id) + "/retry");
+    showMessage("Run retried as " + (result.run_id || "a new run") + ".");
+  } catch (err) {
+    button.disabled = false;
+    showMessage(err.message, true);
+  }
+}
+
+// showTimeline draws the steps of a run as bars on the run's time axis
+async function showTimeline(runID, row) {
+  const timeline = $("#timeline");
+  document.querySelectorAll("#runs tr.selected").forEach((selected) => selected.classList.remove("selected"));
+  row.classList.add("selected");
+  let run;
+  try {
+    run = await api("GET", "/api/v1/execution-chains/runs/" + encodeURIComponent(runID));
+  } catch (err) {
+    showMessage(err.message, true);
+    return;
+  }
+
+  const steps = (run.step_runs || []).slice().sort((a, b) => a.step_order - b.step_order);
+  const times = steps.flatMap((step) => [step.started_at, step.completed_at]).concat(run.started_at, run.completed_at)
+    .filter(Boolean).map((value) => new Date(value).getTime());
+  const start = times.length ? Math.min(...times) : Date.now();
+  let end = times.length ? Math.max(...times) : start;
+  if (!run.completed_at) {
+    end = Math.max(end, Date.now());
+  }
+  const span = Math.max(1, end - start);
+
+  const rows = steps.map((step) => {
+    const bar = el("div", { class: "bar status-" + step.status });
+    if (step.started_at) {
+      const from = new Date(step.started_at).getTime() - start;
+      const to = (step.completed_at ? new Date(step.completed_at).getTime() : end) - start;
+      bar.style.left = (100 * from / span).toFixed(2) + "%";
+      bar.style.width = (100 * Math.max(0, to - from) / span).toFixed(2) + "%";
+    } else {
+      bar.hidden = true;
+    }
+    const detail = [
+      formatDuration(step.started_at, step.completed_at),
+      step.response_code ? "HTTP " + step.response_code : "",
+      step.attempt_count > 1 ? step.attempt_count + " attempts" : "",
+      step.last_error || "",
+    ].filter(Boolean).join(" · ");
+    return el("div", { class: "step" },
+      el("div", {}, step.step_order + ". " + (step.name || (step.step && step.step.name) || "Step"), " ", statusBadge(step.status)),
+      el("div", { class: "track" }, bar),
+      el("div", { class: "detail" }, detail));
+  });
+
+  timeline.replaceChildren(
+    el("h2", {}, ((run.chain && run.chain.name) || run.chain_id) + " ", statusBadge(run.status)),
+    el("p", {}, "Run " + run.id + " · started " + formatTime(run.started_at) + " · "
+      + formatDuration(run.started_at, run.completed_at) + (run.last_error ? " · " + run.last_error : "")),
+    ...(rows.length ? rows : [el("p", {}, "No steps have run yet.")]),
+  );
+  timeline.hidden = false;
+}
+
+function switchView(view) {
+  state.view = view;
+  state.page = 1;
+  document.querySelectorAll(".tab").forEach((tab) => tab.classList.toggle("active", tab.dataset.view === view));
+  document.querySelectorAll(".view").forEach((section) => { section.hidden = section.id !== view; });
+  $("#timeline").hidden = true;
+  load();
+}
+
+document.querySelectorAll(".tab").forEach((tab) => tab.addEventListener("click", () => switchView(tab.dataset.view)));
+
+document.querySelectorAll("[data-filter]").forEach((select) => {
+  const view = select.closest(".view").id;
+  select.value = state.filters[view][select.dataset.filter];
+  select.addEventListener("change", () => {
+    state.filters[view][select.dataset.filter] = select.value;
+    state.page = 1;
+    load();
+  });
+});
+
+$("#credentials").addEventListener("submit", (e) => {
+  e.preventDefault();
+  sessionStorage.setItem("loki-api-key", $("#api-key").value);
+  state.page = 1;
+  load();
+});
+
+$("#sign-out").addEventListener("click", () => {
+  sessionStorage.removeItem("loki-api-key");
+  $("#api-key").value = "";
+  load();
+});
+
+$("#previous").addEventListener("click", () => { state.page--; load(); });
+$("#next").addEventListener("click", () => { state.page++; load(); });
+
+$("#api-key").value = sessionStorage.getItem("loki-api-key") || "";
+load();
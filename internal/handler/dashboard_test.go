@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestServeDashboard tests that the embedded dashboard page and the assets it loads are served without
+// authentication under the restrictive content security policy, and that unknown files are not found
+func TestServeDashboard(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/admin/*filepath", (&Router{}).serveDashboard)
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		body        string
+	}{
+		{path: "/admin/", status: http.StatusOK, contentType: "text/html; charset=utf-8", body: "<title>Loki Suite Admin</title>"},
+		{path: "/admin/app.js", status: http.StatusOK, contentType: "text/javascript; charset=utf-8", body: "fetch(path, init)"},
+		{path: "/admin/style.css", status: http.StatusOK, contentType: "text/css; charset=utf-8"},
+		{path: "/admin/secrets.json", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// Act
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			// Assert
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, dashboardCSP, recorder.Header().Get("Content-Security-Policy"))
+			assert.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+			if tt.status != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.contentType, recorder.Header().Get("Content-Type"))
+			assert.Contains(t, recorder.Body.String(), tt.body)
+		})
+	}
+}
+
+// TestServeDashboard_Redirect tests that /admin redirects to the dashboard page
+func TestServeDashboard_Redirect(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/admin/*filepath", (&Router{}).serveDashboard)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusMovedPermanently, recorder.Code)
+	assert.Equal(t, "/admin/", recorder.Header().Get("Location"))
+}
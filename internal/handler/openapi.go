@@ -0,0 +1,835 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tags of the generated OpenAPI document
+const (
+	tagWebhooks    = "Webhooks"
+	tagEventTypes  = "Event Types"
+	tagChains      = "Execution Chains"
+	tagChainRuns   = "Chain Runs"
+	tagTemplates   = "Chain Templates"
+	tagConfig      = "Configuration"
+	tagTenants     = "Tenants"
+	tagCredentials = "Credentials"
+	tagStreams     = "Streams"
+	tagAlerts      = "Alerts"
+	tagCompliance  = "Compliance"
+	tagAdmin       = "Admin"
+	tagSystem      = "System"
+	tagSandbox     = "Sandbox"
+)
+
+// apiTags lists the document's tags in display order
+var apiTags = []openapi.Tag{
+	{Name: tagWebhooks, Description: "Webhook subscriptions, event delivery and delivery statistics"},
+	{Name: tagEventTypes, Description: "The tenant's event catalog and payload schemas"},
+	{Name: tagChains, Description: "Sequential webhook workflows"},
+	{Name: tagChainRuns, Description: "Individual executions of a chain"},
+	{Name: tagTemplates, Description: "Built-in catalog of reusable execution chains"},
+	{Name: tagConfig, Description: "Declarative management of a tenant's subscriptions and chains"},
+	{Name: tagTenants, Description: "Tenant-wide operational settings"},
+	{Name: tagCredentials, Description: "API keys and tokens"},
+	{Name: tagStreams, Description: "Real-time delivery results and chain run status changes"},
+	{Name: tagAlerts, Description: "Alert rules on delivery failures and run durations, and their history"},
+	{Name: tagCompliance, Description: "Data protection obligations such as erasing data subjects"},
+	{Name: tagAdmin, Description: "Cross-tenant operator views; require a global admin credential"},
+	{Name: tagSystem, Description: "Health, metrics and documentation"},
+	{Name: tagSandbox, Description: "Built-in receivers to point subscriptions at in tests; only when enabled"},
+}
+
+// Parameters shared by several operations
+var (
+	webhookIDParam   = openapi.PathUUID("id", "Webhook subscription ID")
+	chainIDParam     = openapi.PathUUID("id", "Execution chain ID")
+	runIDParam       = openapi.PathUUID("runId", "Chain run ID")
+	tenantIDParam    = openapi.PathParam("id", "Tenant ID")
+	jwtKeyIDParam    = openapi.PathParam("kid", "JWT key ID")
+	tenantIDQuery    = openapi.Query("tenant_id", "Tenant ID", true)
+	pageQuery        = openapi.QueryInt("page", "Page number, 1 by default")
+	limitQuery       = openapi.QueryInt("limit", "Page size between 1 and 100, 10 by default")
+	statsWindowQuery = openapi.QueryEnum("window", "Aggregation window, 24h by default",
+		string(models.StatsWindowHour), string(models.StatsWindowDay), string(models.StatsWindowWeek),
+		string(models.StatsWindowMonth), string(models.StatsWindowAll))
+	adminTenantQuery  = openapi.Query("tenant_id", "Only list resources of this tenant", false)
+	isActiveQuery     = openapi.QueryEnum("is_active", "Only list active or inactive resources", "true", "false")
+	statusQuery       = openapi.Query("status", "Only list resources with this status", false)
+	fieldsQuery       = openapi.Query("fields", "Comma separated dotted paths of the fields to return of each resource, such as id,status,step_runs.status; every field by default", false)
+	chainIncludeQuery = openapi.Query("include", "Comma separated relations to return besides the fields: steps.webhook, steps.compensation_webhook; "+
+		"the webhook subscriptions of steps are left out by default", false)
+	ifNoneMatchHeader    = openapi.Header("If-None-Match", "ETag of the copy the client holds; answers 304 with no body while it is current", false)
+	ifMatchHeader        = openapi.Header("If-Match", "ETag of GET /api/execution-chains/:id the change was based on; answers 412 when the chain changed since", false)
+	chainRunIncludeQuery = openapi.Query("include", "Comma separated relations to return besides the fields: step_runs.step.webhook, "+
+		"step_runs.step.compensation_webhook; the webhook subscriptions of steps are left out by default", false)
+)
+
+// listQueries returns the parameters of a list sortable by the given fields besides created_at and filtered by
+// filters: its pagination, cursor, sort and creation time range parameters
+func listQueries(sorts []models.ListSort, filters ...openapi.Parameter) []openapi.Parameter {
+	sortValues := []string{string(models.ListSortCreatedAt)}
+	for _, sort := range sorts {
+		sortValues = append(sortValues, string(sort))
+	}
+	return append(append([]openapi.Parameter{
+		pageQuery,
+		limitQuery,
+		openapi.Query("cursor", "The next_cursor of the previous page, continuing the list in its sort and order; page is ignored", false),
+		openapi.QueryEnum("sort", "Field to sort by, created_at by default; ties are sorted by creation time", sortValues...),
+		openapi.QueryEnum("order", "Sort direction, desc by default", string(models.ListOrderAsc), string(models.ListOrderDesc)),
+	}, filters...),
+		openapi.Query("created_from", "Only list resources created at or after this RFC 3339 time", false),
+		openapi.Query("created_to", "Only list resources created before this RFC 3339 time", false),
+	)
+}
+
+// Parameters of the lists served by several operations
+var (
+	subscriptionListQueries = listQueries([]models.ListSort{models.ListSortAppName},
+		isActiveQuery,
+		openapi.QueryEnum("type", "Only list subscriptions of this type", string(models.WebhookTypePublic), string(models.WebhookTypePrivate)),
+		openapi.Query("event", "Only list subscriptions to this event", false),
+		fieldsQuery)
+	chainRunListQueries = listQueries([]models.ListSort{models.ListSortStatus},
+		statusQuery, openapi.Query("event", "Only list runs triggered by this event", false), fieldsQuery, chainRunIncludeQuery)
+)
+
+// apiOperations documents the registered routes, keyed by method and gin path
+// Routes missing here still appear in the document with their path parameters only
+var apiOperations = map[string]openapi.Operation{
+	// Webhooks
+	"POST /api/v1/webhooks/generate": {
+		Tag: tagWebhooks, Summary: "Generate a webhook subscription URL with credentials", Role: string(models.RoleAdmin),
+		Request: models.GenerateWebhookRequest{}, Response: models.GenerateWebhookResponse{}, Status: http.StatusCreated,
+	},
+	"POST /api/v1/webhooks/subscribe": {
+		Tag: tagWebhooks, Summary: "Subscribe an external endpoint to an event", Role: string(models.RoleAdmin),
+		Request: models.SubscribeWebhookRequest{}, Response: models.GenerateWebhookResponse{}, Status: http.StatusCreated,
+	},
+	"POST /api/v1/webhooks/event": {
+		Tag: tagWebhooks, Summary: "Send an event to all matching subscriptions and chains", Role: string(models.RolePublisher),
+		Description: "data holds the EventProcessingResult. Payloads violating the schema of a validating event type " +
+			"are rejected with 422.",
+		Request: models.SendEventRequest{}, Response: models.SuccessResponse{},
+		Responses: map[int]interface{}{
+			http.StatusUnprocessableEntity: models.PayloadValidationErrorResponse{},
+		},
+	},
+	"POST /api/v1/webhooks/receive/:id": {
+		Tag: tagWebhooks, Summary: "Receive a payload on a generated webhook endpoint",
+		Description: "Authenticated by the webhook's HMAC signature or JWT instead of a credential; the body is any JSON. " +
+			"Requests from addresses outside the receive allowlist or the webhook's allowed_source_ips get 403.",
+		Parameters: []openapi.Parameter{
+			webhookIDParam,
+			openapi.Header("X-Shavix-Signature", "HMAC-SHA256 signature of the timestamp and body", false),
+			openapi.Header("X-Shavix-Timestamp", "Unix timestamp the signature was computed with", false),
+		},
+		Request: map[string]interface{}{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/webhooks/egress-ips": {
+		Tag: tagWebhooks, Summary: "Addresses webhook deliveries are sent from",
+		Description: "Unauthenticated so receivers can keep their firewall allowlists current.",
+		Response:    models.EgressIPsResponse{},
+	},
+	"GET /api/v1/webhooks": {
+		Tag: tagWebhooks, Summary: "List the webhook subscriptions of a tenant", Role: string(models.RoleViewer),
+		Parameters: append([]openapi.Parameter{tenantIDQuery}, subscriptionListQueries...),
+		Response:   models.WebhookListResponse{},
+	},
+	"GET /api/v1/webhooks/:id": {
+		Tag: tagWebhooks, Summary: "Get a webhook subscription", Role: string(models.RoleViewer),
+		Description: "The secret is never returned. The ETag header hashes the response.",
+		Parameters:  []openapi.Parameter{webhookIDParam, fieldsQuery, ifNoneMatchHeader}, Response: models.WebhookSubscription{},
+	},
+	"GET /api/v1/webhooks/:id/impact": {
+		Tag: tagWebhooks, Summary: "Analyze the impact of disabling or deleting a webhook", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{webhookIDParam}, Response: models.WebhookImpactResponse{},
+	},
+	"GET /api/v1/webhooks/:id/history": {
+		Tag: tagWebhooks, Summary: "Configuration versions of a subscription with diffs", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{webhookIDParam}, Response: models.ConfigHistoryResponse{},
+	},
+	"DELETE /api/v1/webhooks/:id": {
+		Tag: tagWebhooks, Summary: "Delete a webhook subscription", Role: string(models.RoleAdmin),
+		Description: "Soft delete; the subscription can be restored until it is purged.",
+		Parameters:  []openapi.Parameter{webhookIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/webhooks/:id/restore": {
+		Tag: tagWebhooks, Summary: "Restore a deleted webhook subscription", Role: string(models.RoleAdmin),
+		Description: "data holds the restored WebhookSubscription.",
+		Parameters:  []openapi.Parameter{webhookIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/webhooks/:id/enable": {
+		Tag: tagWebhooks, Summary: "Enable a deactivated webhook subscription again", Role: string(models.RoleAdmin),
+		Description: "Clears why the subscription was deactivated, such as by the tenant's auto-disable policy. data holds the WebhookSubscription.",
+		Parameters:  []openapi.Parameter{webhookIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/webhooks/:id/test": {
+		Tag: tagWebhooks, Summary: "Send a test delivery to a webhook subscription", Role: string(models.RolePublisher),
+		Description: "The body is optional. A failed delivery is reported in the response with 200.",
+		Parameters:  []openapi.Parameter{webhookIDParam},
+		Request:     models.TestWebhookRequest{}, OptionalRequest: true, Response: models.TestWebhookResponse{},
+	},
+	"POST /api/v1/webhooks/verify-signature": {
+		Tag: tagWebhooks, Summary: "Check a received signature against a subscription's secret", Role: string(models.RolePublisher),
+		Description: "Reports whether the signature and timestamp verify, with hints on common mistakes. The expected signature is never returned.",
+		Request:     models.VerifySignatureRequest{}, Response: models.VerifySignatureResponse{},
+	},
+	"GET /api/v1/webhooks/:id/stats": {
+		Tag: tagWebhooks, Summary: "Delivery statistics of a webhook subscription", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{webhookIDParam, statsWindowQuery}, Response: models.DeliveryStatsResponse{},
+	},
+	"GET /api/v1/webhooks/stats": {
+		Tag: tagWebhooks, Summary: "Delivery statistics of all webhook subscriptions of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDQuery, statsWindowQuery}, Response: models.DeliveryStatsResponse{},
+	},
+	"GET /api/v1/webhooks/:id/slo": {
+		Tag: tagWebhooks, Summary: "Latency SLO report of a webhook subscription", Role: string(models.RoleViewer),
+		Description: "Measures the end-to-end latency of successful deliveries, from the event being accepted until the receiver accepted it, against the tenant's latency_slo setting.",
+		Parameters:  []openapi.Parameter{webhookIDParam, statsWindowQuery}, Response: models.LatencySLOReport{},
+	},
+	"GET /api/v1/webhooks/slo": {
+		Tag: tagWebhooks, Summary: "Latency SLO report of all webhook subscriptions of a tenant", Role: string(models.RoleViewer),
+		Description: "Subscriptions are listed worst compliance first.",
+		Parameters:  []openapi.Parameter{tenantIDQuery, statsWindowQuery}, Response: models.LatencySLOReport{},
+	},
+	"POST /api/v1/webhooks/route-explain": {
+		Tag: tagWebhooks, Summary: "Explain how a hypothetical event would be routed", Role: string(models.RoleViewer),
+		Request: models.RouteExplainRequest{}, Response: models.RouteExplainResponse{},
+	},
+	"GET /api/v1/webhooks/export": {
+		Tag: tagWebhooks, Summary: "Export the webhook subscriptions of a tenant", Role: string(models.RoleAdmin),
+		Description: "Generated receive endpoints are not exported. YAML is returned with format=yaml or an Accept " +
+			"header naming YAML.",
+		Parameters: []openapi.Parameter{
+			tenantIDQuery,
+			openapi.QueryEnum("include_secrets", "Export signing secrets and PagerDuty routing keys", "true", "false"),
+			openapi.QueryEnum("format", "Response format, json by default", "json", "yaml"),
+		},
+		Response: models.WebhookExport{},
+	},
+	"POST /api/v1/webhooks/import": {
+		Tag: tagWebhooks, Summary: "Import exported webhook subscriptions", Role: string(models.RoleAdmin),
+		Description: "The body is an export as JSON, or as YAML with Content-Type: application/yaml. Nothing is " +
+			"created when any subscription is invalid, or conflicts with on_conflict=fail; such imports are " +
+			"answered with 422. Dry runs are answered with 200.",
+		Parameters: []openapi.Parameter{
+			openapi.Query("tenant_id", "Tenant to import to, the export's tenant by default", false),
+			openapi.QueryEnum("dry_run", "Validate and report without creating anything", "true", "false"),
+			openapi.QueryEnum("regenerate_secrets", "Give every subscription a new signing secret", "true", "false"),
+			openapi.QueryEnum("on_conflict", "What to do with subscriptions the tenant already has, skip by default", "skip", "fail"),
+		},
+		Request: models.WebhookExport{}, Response: models.WebhookImportResponse{}, Status: http.StatusCreated,
+		Responses: map[int]interface{}{
+			http.StatusOK:                  models.WebhookImportResponse{},
+			http.StatusUnprocessableEntity: models.WebhookImportResponse{},
+		},
+	},
+
+	// Event types
+	"POST /api/v1/event-types": {
+		Tag: tagEventTypes, Summary: "Register an event type", Role: string(models.RoleAdmin),
+		Request: models.RegisterEventTypeRequest{}, Response: models.EventType{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/event-types": {
+		Tag: tagEventTypes, Summary: "List the event catalog of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDQuery}, Response: models.EventTypeListResponse{},
+	},
+	"PUT /api/v1/event-types/:id": {
+		Tag: tagEventTypes, Summary: "Update an event type", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Event type ID")},
+		Request:    models.UpdateEventTypeRequest{}, Response: models.EventType{},
+	},
+	"DELETE /api/v1/event-types/:id": {
+		Tag: tagEventTypes, Summary: "Delete an event type", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Event type ID")}, Response: models.SuccessResponse{},
+	},
+
+	// Execution chains
+	"POST /api/v1/execution-chains": {
+		Tag: tagChains, Summary: "Create an execution chain", Role: string(models.RoleAdmin),
+		Request: models.CreateExecutionChainRequest{}, Response: models.CreateExecutionChainResponse{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/execution-chains": {
+		Tag: tagChains, Summary: "List the execution chains of a tenant", Role: string(models.RoleViewer),
+		Parameters: append([]openapi.Parameter{tenantIDQuery}, listQueries([]models.ListSort{models.ListSortStatus},
+			isActiveQuery, statusQuery, openapi.Query("event", "Only list chains triggered by this event", false), fieldsQuery, chainIncludeQuery)...),
+		Response: models.ExecutionChainListResponse{},
+	},
+	"GET /api/v1/execution-chains/:id": {
+		Tag: tagChains, Summary: "Get an execution chain with its steps", Role: string(models.RoleViewer),
+		Description: "Chains of other tenants than the credential's answer 404.",
+		Parameters:  []openapi.Parameter{chainIDParam, fieldsQuery, chainIncludeQuery, ifNoneMatchHeader}, Response: models.ExecutionChain{},
+		Responses: map[int]interface{}{http.StatusNotFound: models.Problem{}},
+	},
+	"PUT /api/v1/execution-chains/:id": {
+		Tag: tagChains, Summary: "Update an execution chain", Role: string(models.RoleAdmin),
+		Description: "lock_version is the lock_version of the chain the change was based on; answers 409 when the chain was changed since. Chains of other tenants than the credential's answer 404.",
+		Parameters:  []openapi.Parameter{chainIDParam, ifMatchHeader},
+		Request:     models.UpdateExecutionChainRequest{}, Response: models.SuccessResponse{},
+		Responses: map[int]interface{}{http.StatusNotFound: models.Problem{}, http.StatusConflict: models.Problem{}},
+	},
+	"PUT /api/v1/execution-chains/:id/steps": {
+		Tag: tagChains, Summary: "Replace the steps of a chain as a new version", Role: string(models.RoleAdmin),
+		Description: "lock_version is the lock_version of the chain the steps were edited from; answers 409 when the chain was changed since.",
+		Parameters:  []openapi.Parameter{chainIDParam, ifMatchHeader},
+		Request:     models.UpdateChainStepsRequest{}, Response: models.UpdateChainStepsResponse{},
+		Responses: map[int]interface{}{http.StatusConflict: models.Problem{}},
+	},
+	"DELETE /api/v1/execution-chains/:id": {
+		Tag: tagChains, Summary: "Delete an execution chain", Role: string(models.RoleAdmin),
+		Description: "Soft delete; the chain can be restored until it is purged. Chains of other tenants than the credential's answer 404.",
+		Parameters:  []openapi.Parameter{chainIDParam}, Response: models.SuccessResponse{},
+		Responses: map[int]interface{}{http.StatusNotFound: models.Problem{}},
+	},
+	"POST /api/v1/execution-chains/:id/restore": {
+		Tag: tagChains, Summary: "Restore a deleted execution chain", Role: string(models.RoleAdmin),
+		Description: "data holds the restored ExecutionChain.",
+		Parameters:  []openapi.Parameter{chainIDParam}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/execution-chains/:id/history": {
+		Tag: tagChains, Summary: "Configuration history of a chain", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{chainIDParam}, Response: models.ConfigHistoryResponse{},
+	},
+	"GET /api/v1/execution-chains/:id/export": {
+		Tag: tagChains, Summary: "Export a chain as a portable template", Role: string(models.RoleViewer),
+		Description: "Steps name their webhooks by app name instead of subscription ID. YAML is returned with " +
+			"format=yaml or an Accept header naming YAML.",
+		Parameters: []openapi.Parameter{
+			chainIDParam,
+			openapi.QueryEnum("format", "Response format, json by default", "json", "yaml"),
+		},
+		Response: models.ChainTemplate{},
+	},
+	"POST /api/v1/execution-chains/import": {
+		Tag: tagChains, Summary: "Create a chain from a template", Role: string(models.RoleAdmin),
+		Description: "The body is a template as JSON, or as YAML with Content-Type: application/yaml. Each webhook " +
+			"must match exactly one subscription of the tenant by app name and subscribed event.",
+		Parameters: []openapi.Parameter{
+			tenantIDQuery,
+			openapi.Query("name", "Name of the chain, the template's by default", false),
+			openapi.Query("trigger_event", "Trigger event of the chain, the template's by default", false),
+		},
+		Request: models.ChainTemplate{}, Response: models.CreateExecutionChainResponse{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/execution-chains/:id/versions": {
+		Tag: tagChains, Summary: "List the step versions of a chain", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{chainIDParam}, Response: models.ChainVersionsResponse{},
+	},
+	"POST /api/v1/execution-chains/:id/versions/:version/rollback": {
+		Tag: tagChains, Summary: "Restore the steps of an earlier version", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{chainIDParam, openapi.PathInt("version", "Version to restore")},
+		Response:   models.UpdateChainStepsResponse{},
+	},
+	"POST /api/v1/execution-chains/:id/pause": {
+		Tag: tagChains, Summary: "Pause a chain", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{chainIDParam},
+		Request:    models.PauseChainRequest{}, Response: models.ChainStateResponse{},
+	},
+	"POST /api/v1/execution-chains/:id/activate": {
+		Tag: tagChains, Summary: "Activate a paused or inactive chain", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{chainIDParam}, Response: models.ChainStateResponse{},
+	},
+	"GET /api/v1/execution-chains/:id/schedule": {
+		Tag: tagChains, Summary: "Show a chain's cron schedule and its next runs", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{chainIDParam}, Response: models.ChainScheduleResponse{},
+	},
+	"PUT /api/v1/execution-chains/:id/schedule": {
+		Tag: tagChains, Summary: "Set or remove a chain's cron schedule", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{chainIDParam, ifMatchHeader},
+		Request:    models.ChainScheduleRequest{}, Response: models.ChainScheduleResponse{},
+	},
+	"POST /api/v1/execution-chains/:id/execute": {
+		Tag: tagChains, Summary: "Trigger an execution chain", Role: string(models.RolePublisher),
+		Description: "Starts a run and answers 202. With execution_options.dry_run or validation_only " +
+			"the chain is checked instead and its plan is returned with 200.",
+		Parameters: []openapi.Parameter{chainIDParam},
+		Request:    models.ExecuteChainBody{}, Response: models.ExecuteChainResponse{}, Status: http.StatusAccepted,
+		Responses: map[int]interface{}{
+			http.StatusOK: models.ChainDryRunResponse{},
+		},
+	},
+	"GET /api/v1/execution-chains/:id/runs": {
+		Tag: tagChains, Summary: "List the runs of a chain", Role: string(models.RoleViewer),
+		Parameters: append([]openapi.Parameter{chainIDParam}, chainRunListQueries...),
+		Response:   models.ExecutionChainRunsResponse{},
+	},
+	"GET /api/v1/execution-chains/:id/stats": {
+		Tag: tagChains, Summary: "Aggregated statistics of a chain's runs", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{chainIDParam, statsWindowQuery}, Response: models.ChainStatsResponse{},
+	},
+	"GET /api/v1/execution-chains/usage": {
+		Tag: tagChains, Summary: "Resources used by the chain runs of a tenant", Role: string(models.RoleViewer),
+		Description: "Sums step wall time, attempts, retries and payload sizes of the runs created within the window, overall and per chain.",
+		Parameters:  []openapi.Parameter{tenantIDQuery, statsWindowQuery}, Response: models.ChainUsageResponse{},
+	},
+
+	// Chain runs
+	"GET /api/v1/execution-chains/runs/:runId": {
+		Tag: tagChainRuns, Summary: "Get a chain run with its step executions", Role: string(models.RoleViewer),
+		Description: "resources reports the wall time, attempts, retries and payload sizes of the run and each of its steps. Runs of other tenants than the credential's answer 404.",
+		Parameters:  []openapi.Parameter{runIDParam, fieldsQuery, chainRunIncludeQuery, ifNoneMatchHeader}, Response: models.ExecutionChainRun{},
+		Responses: map[int]interface{}{http.StatusNotFound: models.Problem{}},
+	},
+	"GET /api/v1/execution-chains/runs/:runId/outputs": {
+		Tag: tagChainRuns, Summary: "Get the aggregated outputs of a run", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{runIDParam}, Response: models.ChainRunOutputsResponse{},
+	},
+	"POST /api/v1/execution-chains/runs/:runId/resume": {
+		Tag: tagChainRuns, Summary: "Resume a paused or interrupted run", Role: string(models.RolePublisher),
+		Parameters: []openapi.Parameter{runIDParam}, Response: models.ChainRunControlResponse{}, Status: http.StatusAccepted,
+	},
+	"POST /api/v1/execution-chains/runs/:runId/cancel": {
+		Tag: tagChainRuns, Summary: "Cancel a run", Role: string(models.RolePublisher),
+		Parameters: []openapi.Parameter{runIDParam},
+		Request:    models.CancelChainRunRequest{}, Response: models.ChainRunControlResponse{},
+	},
+	"POST /api/v1/execution-chains/runs/:runId/retry": {
+		Tag: tagChainRuns, Summary: "Retry a failed run from its failed step", Role: string(models.RolePublisher),
+		Parameters: []openapi.Parameter{runIDParam}, Response: models.ExecuteChainResponse{}, Status: http.StatusAccepted,
+	},
+	"POST /api/v1/execution-chains/runs/:runId/approve": {
+		Tag: tagChainRuns, Summary: "Approve the approval step a run is waiting on", Role: string(models.RolePublisher),
+		Parameters: []openapi.Parameter{runIDParam},
+		Request:    models.ApprovalDecisionRequest{}, OptionalRequest: true,
+		Response: models.ChainRunControlResponse{}, Status: http.StatusAccepted,
+	},
+	"POST /api/v1/execution-chains/runs/:runId/reject": {
+		Tag: tagChainRuns, Summary: "Reject the approval step a run is waiting on", Role: string(models.RolePublisher),
+		Parameters: []openapi.Parameter{runIDParam},
+		Request:    models.ApprovalDecisionRequest{}, OptionalRequest: true,
+		Response: models.ChainRunControlResponse{}, Status: http.StatusAccepted,
+	},
+
+	// Chain templates
+	"GET /api/v1/chain-templates": {
+		Tag: tagTemplates, Summary: "List the template catalog", Role: string(models.RoleViewer),
+		Response: models.ChainTemplateListResponse{},
+	},
+	"GET /api/v1/chain-templates/:id": {
+		Tag: tagTemplates, Summary: "Get a template of the catalog", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("id", "Template ID"),
+			openapi.QueryEnum("format", "Response format, json by default", "json", "yaml"),
+		},
+		Response: models.ChainTemplate{},
+	},
+	"POST /api/v1/chain-templates/:id/instantiate": {
+		Tag: tagTemplates, Summary: "Create a chain from a template of the catalog", Role: string(models.RoleAdmin),
+		Description: "webhooks maps the template's webhook refs to subscriptions of the tenant; refs that are not " +
+			"mapped must match exactly one subscription by app name and subscribed event.",
+		Parameters: []openapi.Parameter{openapi.PathParam("id", "Template ID")},
+		Request:    models.InstantiateChainTemplateRequest{}, Response: models.CreateExecutionChainResponse{}, Status: http.StatusCreated,
+	},
+
+	// Configuration
+	"POST /api/v1/config/apply": {
+		Tag: tagConfig, Summary: "Reconcile a tenant with a declarative manifest", Role: string(models.RoleAdmin),
+		Description: "The body is a manifest as JSON, or as YAML with Content-Type: application/yaml. Subscriptions " +
+			"and chains are created, updated and deleted to match it; generated receive endpoints are left as " +
+			"they are. Nothing is applied when any entry is invalid; such manifests are answered with 422. A " +
+			"subscription changed by someone else while the manifest is applied is answered with 409.",
+		Parameters: []openapi.Parameter{
+			openapi.Query("tenant_id", "Tenant to apply the manifest to, the manifest's tenant by default", false),
+			openapi.QueryEnum("dry_run", "Plan the changes without applying them", "true", "false"),
+		},
+		Request: models.ConfigManifest{}, Response: models.ConfigApplyResponse{},
+		Responses: map[int]interface{}{
+			http.StatusUnprocessableEntity: models.ConfigApplyResponse{},
+			http.StatusConflict:            models.Problem{},
+		},
+	},
+
+	// Tenants
+	"POST /api/v1/tenants/:id/chains/pause-all": {
+		Tag: tagTenants, Summary: "Pause chain executions for a tenant", Role: string(models.RoleAdmin),
+		Description: "data holds the TenantChainControlResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/tenants/:id/chains/resume-all": {
+		Tag: tagTenants, Summary: "Resume chain executions for a tenant", Role: string(models.RoleAdmin),
+		Description: "data holds the TenantChainControlResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/tenants/:id/deliveries/pause": {
+		Tag: tagTenants, Summary: "Pause outbound deliveries of a tenant", Role: string(models.RoleAdmin),
+		Description: "Events sent while paused are held as pending until the pause is resumed. data holds the DeliveryPauseResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.PauseDeliveriesRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id/deliveries/pause": {
+		Tag: tagTenants, Summary: "Delivery pause of a tenant with its held events", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.DeliveryPauseResponse{},
+	},
+	"POST /api/v1/tenants/:id/deliveries/resume": {
+		Tag: tagTenants, Summary: "Resume outbound deliveries of a tenant", Role: string(models.RoleAdmin),
+		Description: "Held events are drained at drain_rate events per second, 10 by default. data holds the DeliveryPauseResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.ResumeDeliveriesRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id/run-limit": {
+		Tag: tagTenants, Summary: "Chain run concurrency limit of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantRunLimitResponse{},
+	},
+	"PUT /api/v1/tenants/:id/run-limit": {
+		Tag: tagTenants, Summary: "Set the chain run concurrency limit of a tenant", Role: string(models.RoleAdmin),
+		Description: "data holds the TenantRunLimitResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.TenantRunLimitRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id/topology": {
+		Tag: tagTenants, Summary: "Dependency graph of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantTopologyResponse{},
+	},
+	"GET /api/v1/tenants/:id/signing-headers": {
+		Tag: tagTenants, Summary: "Signing header names of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantSigningHeadersResponse{},
+	},
+	"PUT /api/v1/tenants/:id/signing-headers": {
+		Tag: tagTenants, Summary: "Override the signing header names of a tenant", Role: string(models.RoleAdmin),
+		Description: "data holds the TenantSigningHeadersResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.SigningHeaders{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id": {
+		Tag: tagTenants, Summary: "Tenant with its status and settings", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.Tenant{},
+	},
+	"GET /api/v1/tenants/:id/settings": {
+		Tag: tagTenants, Summary: "Operational settings of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantSettings{},
+	},
+	"PUT /api/v1/tenants/:id/settings": {
+		Tag: tagTenants, Summary: "Change the default retry policy, rate limit, quota, retention or signing algorithm of a tenant", Role: string(models.RoleAdmin),
+		Description: "Omitted settings are left unchanged. data holds the TenantSettings.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.TenantSettingsRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id/retention": {
+		Tag: tagTenants, Summary: "Retention period of a tenant's events and chain runs", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantRetentionResponse{},
+	},
+	"PUT /api/v1/tenants/:id/retention": {
+		Tag: tagTenants, Summary: "Set how long a tenant's finished events and chain runs are kept", Role: string(models.RoleAdmin),
+		Description: "0 uses the global default. data holds the TenantRetentionResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.TenantRetentionRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id/usage": {
+		Tag: tagTenants, Summary: "Events and deliveries of a tenant per day with its quota", Role: string(models.RoleViewer),
+		Description: "Days are UTC days; the range defaults to the current month.",
+		Parameters: []openapi.Parameter{
+			tenantIDParam,
+			openapi.Query("from", "First day as YYYY-MM-DD, inclusive", false),
+			openapi.Query("to", "Last day as YYYY-MM-DD, inclusive", false),
+		},
+		Response: models.TenantUsageResponse{},
+	},
+	"GET /api/v1/tenants/:id/payload-validation": {
+		Tag: tagTenants, Summary: "Payload validation mode of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantPayloadValidationResponse{},
+	},
+	"PUT /api/v1/tenants/:id/payload-validation": {
+		Tag: tagTenants, Summary: "Switch strict payload validation for a tenant", Role: string(models.RoleAdmin),
+		Description: "data holds the TenantPayloadValidationResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.TenantPayloadValidationRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/tenants/:id/signing": {
+		Tag: tagTenants, Summary: "Delivery signing algorithm of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDParam}, Response: models.TenantSigningResponse{},
+	},
+	"PUT /api/v1/tenants/:id/signing": {
+		Tag: tagTenants, Summary: "Switch a tenant between HMAC and Ed25519 signing", Role: string(models.RoleAdmin),
+		Description: "Switching to ed25519 generates a key pair unless the tenant has one. data holds the TenantSigningResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.TenantSigningRequest{}, Response: models.SuccessResponse{},
+	},
+
+	// Credentials
+	"POST /api/v1/credentials": {
+		Tag: tagCredentials, Summary: "Create an API key with a role", Role: string(models.RoleAdmin),
+		Request: models.CreateCredentialRequest{}, Response: models.CreateCredentialResponse{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/credentials": {
+		Tag: tagCredentials, Summary: "List the credentials of a tenant", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.Query("tenant_id", "Tenant ID", false), pageQuery, limitQuery},
+		Response:   models.CredentialListResponse{},
+	},
+	"DELETE /api/v1/credentials/:id": {
+		Tag: tagCredentials, Summary: "Revoke a credential", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Credential ID")}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/credentials/:id/token": {
+		Tag: tagCredentials, Summary: "Issue a JWT with the credential's role claims", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Credential ID")},
+		Request:    models.IssueTokenRequest{}, Response: models.IssueTokenResponse{}, Status: http.StatusCreated,
+	},
+
+	// Streams
+	"GET /api/v1/streams/events": {
+		Tag: tagStreams, Summary: "Stream delivery results, run status changes and step completions", Role: string(models.RoleViewer),
+		Description: "Server-Sent Events named after their type, each holding a StreamEvent as data. Only changes " +
+			"processed by the instance serving the stream are sent.",
+		Parameters: []openapi.Parameter{
+			tenantIDQuery,
+			openapi.Query("types", "Comma separated event types to receive: delivery.completed, run.status_changed, step.completed", false),
+			openapi.Query("chain_id", "Only receive run and step events of this chain", false),
+			openapi.Query("run_id", "Only receive run and step events of this run", false),
+		},
+		Response: models.StreamEvent{}, ResponseType: "text/event-stream",
+	},
+
+	// Alerts
+	"POST /api/v1/alerts/rules": {
+		Tag: tagAlerts, Summary: "Create an alert rule", Role: string(models.RoleAdmin),
+		Description: "Watches the deliveries of webhook_id or the runs of chain_id and notifies notify_webhook_id " +
+			"with loki.alert.firing and loki.alert.resolved events.",
+		Request: models.CreateAlertRuleRequest{}, Response: models.AlertRule{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/alerts/rules": {
+		Tag: tagAlerts, Summary: "List the alert rules of a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{tenantIDQuery}, Response: models.AlertRuleListResponse{},
+	},
+	"GET /api/v1/alerts/rules/:id": {
+		Tag: tagAlerts, Summary: "Get an alert rule with its state", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Alert rule ID")}, Response: models.AlertRule{},
+	},
+	"PUT /api/v1/alerts/rules/:id": {
+		Tag: tagAlerts, Summary: "Replace the definition of an alert rule", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Alert rule ID")},
+		Request:    models.AlertRuleRequest{}, Response: models.AlertRule{},
+	},
+	"DELETE /api/v1/alerts/rules/:id": {
+		Tag: tagAlerts, Summary: "Delete an alert rule", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{openapi.PathUUID("id", "Alert rule ID")}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/alerts/history": {
+		Tag: tagAlerts, Summary: "List the alerts fired, repeated and resolved for a tenant", Role: string(models.RoleViewer),
+		Parameters: []openapi.Parameter{
+			tenantIDQuery,
+			openapi.Query("rule_id", "Only list the history of this alert rule", false),
+			pageQuery, limitQuery,
+		},
+		Response: models.AlertEventListResponse{},
+	},
+
+	// Compliance
+	"DELETE /api/v1/compliance/tenants/:tenantID/subjects/:subjectKey": {
+		Tag: tagCompliance, Summary: "Erase a data subject from a tenant's stored data", Role: string(models.RoleAdmin),
+		Description: "Shreds the subject's key and replaces every stored value mentioning the identifier with [ERASED], " +
+			"in the live tables and the archives. data holds the ErasureResponse, whose signature is a JWS of the " +
+			"report signed with the tenant's Ed25519 key.",
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("tenantID", "Tenant ID"),
+			openapi.PathParam("subjectKey", "Identifier of the data subject as it appears in payloads, at least 3 characters"),
+		},
+		Response: models.SuccessResponse{},
+	},
+
+	// Admin
+	"GET /api/v1/admin/subscriptions": {
+		Tag: tagAdmin, Summary: "List the webhook subscriptions of all tenants", Role: string(models.RoleAdmin),
+		Parameters: append([]openapi.Parameter{adminTenantQuery}, subscriptionListQueries...), Response: models.WebhookListResponse{},
+	},
+	"GET /api/v1/admin/events": {
+		Tag: tagAdmin, Summary: "List the webhook events of all tenants", Role: string(models.RoleAdmin),
+		Parameters: append([]openapi.Parameter{adminTenantQuery}, listQueries([]models.ListSort{models.ListSortStatus},
+			statusQuery, openapi.Query("event", "Only list events with this name", false), fieldsQuery)...),
+		Response: models.AdminEventListResponse{},
+	},
+	"GET /api/v1/admin/chain-runs": {
+		Tag: tagAdmin, Summary: "List the chain runs of all tenants", Role: string(models.RoleAdmin),
+		Parameters: append([]openapi.Parameter{adminTenantQuery}, chainRunListQueries...), Response: models.ExecutionChainRunsResponse{},
+	},
+	"GET /api/v1/admin/tenants/stats": {
+		Tag: tagAdmin, Summary: "Aggregate resource counts per tenant", Role: string(models.RoleAdmin),
+		Response: models.TenantStatsResponse{},
+	},
+	"GET /api/v1/admin/keys": {
+		Tag: tagAdmin, Summary: "List the keys of the JWT keyring", Role: string(models.RoleAdmin),
+		Response: models.JWTKeyListResponse{},
+	},
+	"POST /api/v1/admin/keys": {
+		Tag: tagAdmin, Summary: "Add a key to the JWT keyring", Role: string(models.RoleAdmin),
+		Description: "With primary set, tokens issued from now on are signed with the new key; tokens signed with earlier keys keep verifying.",
+		Request:     models.AddJWTKeyRequest{}, Response: models.JWTKey{}, Status: http.StatusCreated,
+	},
+	"POST /api/v1/admin/keys/:kid/promote": {
+		Tag: tagAdmin, Summary: "Make a JWT key the one new tokens are signed with", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{jwtKeyIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/admin/keys/:kid/retire": {
+		Tag: tagAdmin, Summary: "Retire a JWT key so tokens signed with it stop verifying", Role: string(models.RoleAdmin),
+		Description: "The primary key cannot be retired; promote another key first.",
+		Parameters:  []openapi.Parameter{jwtKeyIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/admin/purge": {
+		Tag: tagAdmin, Summary: "Permanently delete soft deleted subscriptions and chains", Role: string(models.RoleAdmin),
+		Description: "Chains are purged with their steps, versions and runs; subscriptions still called by steps of remaining chains are kept.",
+		Request:     models.PurgeRequest{}, Response: models.PurgeResult{},
+	},
+	"POST /api/v1/admin/tenants": {
+		Tag: tagAdmin, Summary: "Create a tenant", Role: string(models.RoleAdmin),
+		Description: "409 when the ID is taken, also by a deleted tenant.",
+		Request:     models.CreateTenantRequest{}, Response: models.Tenant{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/admin/tenants": {
+		Tag: tagAdmin, Summary: "List tenants, oldest first", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{pageQuery, limitQuery}, Response: models.TenantListResponse{},
+	},
+	"POST /api/v1/admin/tenants/:id/suspend": {
+		Tag: tagAdmin, Summary: "Suspend a tenant", Role: string(models.RoleAdmin),
+		Description: "The tenant's events, chain runs and new subscriptions and chains are refused with 403 until it is activated. data holds the Tenant.",
+		Parameters:  []openapi.Parameter{tenantIDParam},
+		Request:     models.SuspendTenantRequest{}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/admin/tenants/:id/activate": {
+		Tag: tagAdmin, Summary: "Activate a suspended tenant", Role: string(models.RoleAdmin),
+		Description: "data holds the Tenant.",
+		Parameters:  []openapi.Parameter{tenantIDParam}, Response: models.SuccessResponse{},
+	},
+	"DELETE /api/v1/admin/tenants/:id": {
+		Tag: tagAdmin, Summary: "Delete a tenant with its subscriptions and chains", Role: string(models.RoleAdmin),
+		Description: "Subscriptions and chains are soft deleted. data holds the TenantDeleteResponse.",
+		Parameters:  []openapi.Parameter{tenantIDParam}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/admin/deliveries/pause": {
+		Tag: tagAdmin, Summary: "Pause outbound deliveries of every tenant", Role: string(models.RoleAdmin),
+		Description: "Events sent while paused are held as pending until the pause is resumed. data holds the DeliveryPauseResponse.",
+		Request:     models.PauseDeliveriesRequest{}, Response: models.SuccessResponse{},
+	},
+	"GET /api/v1/admin/deliveries/pause": {
+		Tag: tagAdmin, Summary: "Global delivery pause with the events held across tenants", Role: string(models.RoleAdmin),
+		Response: models.DeliveryPauseResponse{},
+	},
+	"POST /api/v1/admin/deliveries/resume": {
+		Tag: tagAdmin, Summary: "Resume outbound deliveries of every tenant", Role: string(models.RoleAdmin),
+		Description: "Held events are drained at drain_rate events per second, 10 by default; tenants paused on their own stay paused. data holds the DeliveryPauseResponse.",
+		Request:     models.ResumeDeliveriesRequest{}, Response: models.SuccessResponse{},
+	},
+	"POST /api/v1/admin/archival": {
+		Tag: tagAdmin, Summary: "Start an archival run", Role: string(models.RoleAdmin),
+		Description: "Expired events and chain runs are archived in the background; 409 while this instance is already archiving.",
+		Response:    models.ArchivalRun{}, Status: http.StatusAccepted,
+	},
+	"GET /api/v1/admin/archival": {
+		Tag: tagAdmin, Summary: "List archival runs, newest first", Role: string(models.RoleAdmin),
+		Parameters: []openapi.Parameter{pageQuery, limitQuery}, Response: models.ArchivalRunListResponse{},
+	},
+
+	// System
+	"GET /health": {
+		Tag: tagSystem, Summary: "Health check", Response: models.HealthResponse{},
+	},
+	"GET /metrics": {
+		Tag: tagSystem, Summary: "Prometheus metrics", Response: "", ResponseType: "text/plain",
+	},
+	"GET /.well-known/loki-suite/keys.json": {
+		Tag: tagSystem, Summary: "Public keys of tenants signing deliveries with Ed25519",
+		Parameters: []openapi.Parameter{openapi.Query("tenant_id", "Only keys of this tenant", false)},
+		Response:   models.SigningKeySet{},
+	},
+	"GET /api/openapi.json": {
+		Tag: tagSystem, Summary: "This OpenAPI document", Response: map[string]interface{}{},
+	},
+	"GET /docs": {
+		Tag: tagSystem, Summary: "Swagger UI for this document", Response: "", ResponseType: "text/html",
+	},
+	"GET /admin/*filepath": {
+		Tag: tagSystem, Summary: "Admin dashboard for browsing subscriptions, events and chain runs",
+		Parameters: []openapi.Parameter{openapi.PathParam("filepath", "Asset of the dashboard, / for its page")},
+		Response:   "", ResponseType: "text/html",
+	},
+
+	// Sandbox
+	"POST /sandbox/success": {
+		Tag: tagSandbox, Summary: "Receiver that always succeeds", Response: models.SandboxResponse{},
+	},
+	"POST /sandbox/flaky": {
+		Tag: tagSandbox, Summary: "Receiver that fails a share of requests", Response: models.SandboxResponse{},
+		Parameters: []openapi.Parameter{
+			openapi.Query("rate", "Share of requests failed, 0 to 1 (default 0.5)", false),
+			openapi.Query("status", "Status code of failed requests, 400 to 599 (default 503)", false),
+		},
+	},
+	"POST /sandbox/slow": {
+		Tag: tagSandbox, Summary: "Receiver that responds after a delay", Response: models.SandboxResponse{},
+		Parameters: []openapi.Parameter{
+			openapi.Query("delay", "Go duration to wait such as 500ms or 5s (default 1s)", false),
+		},
+	},
+	"POST /sandbox/echo": {
+		Tag: tagSandbox, Summary: "Receiver that echoes the request it received", Response: models.SandboxEchoResponse{},
+	},
+}
+
+// swaggerUIPage renders the Swagger UI for the generated document; the UI assets load from a CDN
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Loki Suite API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// serveOpenAPI handles GET /api/openapi.json
+// The document is generated from the registered routes on first request and cached; the deprecated /api aliases
+// of versioned routes are left out
+func (r *Router) serveOpenAPI(c *gin.Context) {
+	r.openapiOnce.Do(func() {
+		doc := openapi.Build(openapi.Info{
+			Title:       "Loki Suite API",
+			Description: "Webhook management and execution chain orchestration",
+			Version:     "2.0.0",
+		}, apiTags, documentedRoutes(r.engine.Routes()), apiOperations, models.Problem{})
+		r.openapiDoc, r.openapiErr = json.Marshal(doc)
+	})
+
+	if r.openapiErr != nil {
+		middleware.WriteProblem(c, http.StatusInternalServerError, "openapi_generation_failed", "Failed to generate the OpenAPI document")
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", r.openapiDoc)
+}
+
+// documentedRoutes returns the routes without the /api aliases of v1 routes
+func documentedRoutes(routes gin.RoutesInfo) gin.RoutesInfo {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	documented := make(gin.RoutesInfo, 0, len(routes))
+	for _, route := range routes {
+		path, ok := strings.CutPrefix(route.Path, legacyAPIPrefix)
+		if ok && registered[route.Method+" "+apiV1.prefix+path] {
+			continue
+		}
+		documented = append(documented, route)
+	}
+	return documented
+}
+
+// serveDocs handles GET /docs
+func (r *Router) serveDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+)
+
+// openapiMethods are the operation keys of an OpenAPI 3.0 path item
+var openapiMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// ginParamPattern matches the parameters of a gin route pattern such as :id or *filepath
+var ginParamPattern = regexp.MustCompile(`[:*](\w+)`)
+
+// templateParamPattern matches the parameters of an OpenAPI path template such as {id}
+var templateParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// setupOpenAPI sets up a router with every route and returns it with the OpenAPI document it serves, decoded
+func setupOpenAPI(t *testing.T) (*Router, map[string]interface{}) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, logging.Nop())
+	router.Setup()
+
+	recorder := httptest.NewRecorder()
+	router.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+	return router, doc
+}
+
+// object returns the JSON object under key, failing the test when it is missing or not an object
+func object(t *testing.T, value map[string]interface{}, key string) map[string]interface{} {
+	t.Helper()
+	child, ok := value[key].(map[string]interface{})
+	require.True(t, ok, "%q must be an object", key)
+	return child
+}
+
+// collectRefs appends every $ref found in value, at any depth, to refs
+func collectRefs(value interface{}, refs *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				*refs = append(*refs, ref)
+				continue
+			}
+			collectRefs(child, refs)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectRefs(child, refs)
+		}
+	}
+}
+
+// TestServeOpenAPI_Valid tests that the served document is a structurally valid OpenAPI 3.0 document: operations
+// have unique IDs, declared tags, responses with descriptions and their path parameters, security requirements
+// name defined schemes and every schema reference resolves
+func TestServeOpenAPI_Valid(t *testing.T) {
+	// Arrange
+	_, doc := setupOpenAPI(t)
+
+	// Assert
+	assert.Regexp(t, `^3\.0\.\d+$`, doc["openapi"])
+	info := object(t, doc, "info")
+	assert.NotEmpty(t, info["title"])
+	assert.NotEmpty(t, info["version"])
+
+	components := object(t, doc, "components")
+	schemas := object(t, components, "schemas")
+	schemes := object(t, components, "securitySchemes")
+	tags := map[string]bool{}
+	for _, tag := range doc["tags"].([]interface{}) {
+		tags[tag.(map[string]interface{})["name"].(string)] = true
+	}
+
+	operationIDs := map[string]string{}
+	paths := object(t, doc, "paths")
+	require.NotEmpty(t, paths)
+	for path, item := range paths {
+		assert.True(t, strings.HasPrefix(path, "/"), path)
+		assert.NotRegexp(t, `[:*]\w`, path, "gin parameters must be converted to templates")
+		for method, value := range item.(map[string]interface{}) {
+			name := strings.ToUpper(method) + " " + path
+			require.True(t, openapiMethods[method], name)
+			operation := value.(map[string]interface{})
+
+			if id, ok := operation["operationId"].(string); ok {
+				assert.NotContains(t, operationIDs, id, "%s repeats the operation ID of %s", name, operationIDs[id])
+				operationIDs[id] = name
+			}
+			for _, tag := range operation["tags"].([]interface{}) {
+				assert.True(t, tags[tag.(string)], "%s: undeclared tag %q", name, tag)
+			}
+
+			responses := object(t, operation, "responses")
+			assert.Contains(t, responses, "default", name)
+			for code, response := range responses {
+				assert.Regexp(t, `^(default|[1-5]\d\d)$`, code, name)
+				assert.NotEmpty(t, response.(map[string]interface{})["description"], "%s %s", name, code)
+			}
+
+			declared := map[string]bool{}
+			parameters, _ := operation["parameters"].([]interface{})
+			for _, value := range parameters {
+				parameter := value.(map[string]interface{})
+				assert.Contains(t, []string{"path", "query", "header", "cookie"}, parameter["in"], name)
+				assert.NotNil(t, parameter["schema"], "%s: parameter %v", name, parameter["name"])
+				if parameter["in"] == "path" {
+					assert.Equal(t, true, parameter["required"], "%s: path parameter %v", name, parameter["name"])
+					declared[parameter["name"].(string)] = true
+				}
+			}
+			for _, match := range templateParamPattern.FindAllStringSubmatch(path, -1) {
+				assert.True(t, declared[match[1]], "%s: path parameter %s is not declared", name, match[1])
+			}
+
+			security, _ := operation["security"].([]interface{})
+			for _, requirement := range security {
+				for scheme := range requirement.(map[string]interface{}) {
+					assert.Contains(t, schemes, scheme, name)
+				}
+			}
+		}
+	}
+
+	var refs []string
+	collectRefs(doc, &refs)
+	require.NotEmpty(t, refs)
+	for _, ref := range refs {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if assert.True(t, ok, ref) {
+			assert.Contains(t, schemas, name, "unresolved reference %s", ref)
+		}
+	}
+}
+
+// TestServeOpenAPI_CoversRoutes tests that every route registered under /api/v1 is documented with its method and
+// a summary, and that the deprecated /api aliases of those routes are left out
+func TestServeOpenAPI_CoversRoutes(t *testing.T) {
+	// Arrange
+	router, doc := setupOpenAPI(t)
+	paths := object(t, doc, "paths")
+
+	routes := router.engine.Routes()
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	// Assert
+	covered := 0
+	for _, route := range routes {
+		if route.Method == http.MethodHead || route.Method == http.MethodOptions {
+			continue
+		}
+		templated := ginParamPattern.ReplaceAllString(route.Path, "{$1}")
+		if !strings.HasPrefix(route.Path, apiV1.prefix+"/") {
+			path, ok := strings.CutPrefix(route.Path, legacyAPIPrefix)
+			if ok && registered[route.Method+" "+apiV1.prefix+path] {
+				item, _ := paths[templated].(map[string]interface{})
+				assert.NotContains(t, item, strings.ToLower(route.Method), "alias %s %s must not be documented", route.Method, route.Path)
+			}
+			continue
+		}
+
+		name := route.Method + " " + route.Path
+		covered++
+		item, ok := paths[templated].(map[string]interface{})
+		if !assert.True(t, ok, "%s is not in the document", name) {
+			continue
+		}
+		assert.Contains(t, item, strings.ToLower(route.Method), name)
+		assert.NotEmpty(t, apiOperations[name].Summary, "%s has no entry in apiOperations", name)
+	}
+	assert.NotZero(t, covered)
+}
@@ -0,0 +1,121 @@
+// Package logging provides the structured logger the service's components are constructed with
+// Loggers take the context of the call so fields scoped to a request, such as request_id and tenant_id,
+// are attached to every entry logged while handling it, from controllers down to repositories
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger logs structured entries carrying the fields of the context they are logged with
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields ...zap.Field)
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Warn(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+
+	// With returns a logger adding the fields to every entry
+	With(fields ...zap.Field) Logger
+}
+
+// New builds the zap logger of the service; every entry carries its source and environment, as the
+// entries of the zlog logger do
+func New(source, environment string, debug bool) (*zap.Logger, error) {
+	config := zap.NewProductionConfig()
+	if debug {
+		config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	}
+	config.InitialFields = map[string]interface{}{
+		"source":      source,
+		"environment": environment,
+	}
+	return config.Build(zap.AddCallerSkip(2))
+}
+
+// NewLogger returns a Logger writing to the zap logger
+// Loggers report the caller of their methods, so the zap logger must skip two frames, as New does
+func NewLogger(base *zap.Logger) Logger {
+	return &logger{base: base}
+}
+
+// Nop returns a Logger discarding every entry, for tests and callers that do not log
+func Nop() Logger {
+	return NewLogger(zap.NewNop())
+}
+
+// logger implements Logger on top of a zap logger
+type logger struct {
+	base   *zap.Logger
+	fields []zap.Field
+}
+
+func (l *logger) Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	l.write(ctx, zapcore.DebugLevel, msg, fields)
+}
+
+func (l *logger) Info(ctx context.Context, msg string, fields ...zap.Field) {
+	l.write(ctx, zapcore.InfoLevel, msg, fields)
+}
+
+func (l *logger) Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	l.write(ctx, zapcore.WarnLevel, msg, fields)
+}
+
+func (l *logger) Error(ctx context.Context, msg string, fields ...zap.Field) {
+	l.write(ctx, zapcore.ErrorLevel, msg, fields)
+}
+
+func (l *logger) With(fields ...zap.Field) Logger {
+	return &logger{base: l.base, fields: append(append([]zap.Field{}, l.fields...), fields...)}
+}
+
+// write logs an entry with the logger's fields, the context's fields and the entry's own fields
+func (l *logger) write(ctx context.Context, level zapcore.Level, msg string, fields []zap.Field) {
+	entry := l.base.Check(level, msg)
+	if entry == nil {
+		return
+	}
+	scoped := Fields(ctx)
+	all := make([]zap.Field, 0, len(l.fields)+len(scoped)+len(fields))
+	all = append(all, l.fields...)
+	all = append(all, scoped...)
+	entry.Write(append(all, fields...)...)
+}
+
+// fieldsKey is the context key of the request-scoped fields
+type fieldsKey struct{}
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the request it handles, logged as request_id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return WithFields(context.WithValue(ctx, requestIDKey{}, requestID), zap.String("request_id", requestID))
+}
+
+// RequestID returns the ID of the request a context handles, empty outside requests
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithFields returns a context whose log entries carry the fields in addition to those already scoped to it
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	scoped := Fields(ctx)
+	return context.WithValue(ctx, fieldsKey{}, append(append(make([]zap.Field, 0, len(scoped)+len(fields)), scoped...), fields...))
+}
+
+// Fields returns the fields scoped to a context
+func Fields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	return fields
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiVersionKey is the gin context key of the API version a request was made to
+const apiVersionKey = "api_version"
+
+// APIVersion records the version of the API a route group serves, such as v1, for GetAPIVersion
+// Handlers shared by several versions branch on it where a version changed a request or response
+func APIVersion(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Next()
+	}
+}
+
+// GetAPIVersion returns the API version a request was made to, or "" for routes outside the versioned API
+func GetAPIVersion(c *gin.Context) string {
+	return c.GetString(apiVersionKey)
+}
+
+// Deprecation describes a deprecated route prefix, such as the unversioned /api
+type Deprecation struct {
+	// Prefix is the deprecated prefix and Successor the one replacing it, such as /api and /api/v1
+	Prefix    string
+	Successor string
+
+	// Since is when the prefix was deprecated
+	Since time.Time
+
+	// Sunset is when the prefix stops being served, zero while undecided
+	Sunset time.Time
+}
+
+// Deprecated marks the responses of a deprecated route prefix with the Deprecation (RFC 9745) and Sunset
+// (RFC 8594) headers and a Link to the same route under the successor prefix, so clients notice they need to
+// move before the sunset
+func Deprecated(deprecation Deprecation) gin.HandlerFunc {
+	since := "@" + strconv.FormatInt(deprecation.Since.Unix(), 10)
+	var sunset string
+	if !deprecation.Sunset.IsZero() {
+		sunset = deprecation.Sunset.UTC().Format(http.TimeFormat)
+	}
+	return func(c *gin.Context) {
+		c.Header("Deprecation", since)
+		if sunset != "" {
+			c.Header("Sunset", sunset)
+		}
+		if path, ok := strings.CutPrefix(c.Request.URL.Path, deprecation.Prefix); ok {
+			c.Header("Link", "<"+deprecation.Successor+path+`>; rel="successor-version"`)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeprecated tests that routes of a deprecated prefix answer with Deprecation, Sunset and a Link to their
+// successor and know the version they alias, while the successor's routes carry no deprecation headers
+func TestDeprecated(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	handler := func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.GetAPIVersion(c))
+	}
+	engine.Group("/api/v1", middleware.APIVersion("v1")).GET("/webhooks/:id", handler)
+	engine.Group("/api", middleware.Deprecated(middleware.Deprecation{
+		Prefix:    "/api",
+		Successor: "/api/v1",
+		Since:     time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+		Sunset:    time.Date(2027, time.April, 16, 0, 0, 0, 0, time.UTC),
+	}), middleware.APIVersion("v1")).GET("/webhooks/:id", handler)
+
+	// Act
+	legacy := httptest.NewRecorder()
+	engine.ServeHTTP(legacy, httptest.NewRequest(http.MethodGet, "/api/webhooks/42?fields=id", nil))
+	current := httptest.NewRecorder()
+	engine.ServeHTTP(current, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/42", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, legacy.Code)
+	assert.Equal(t, "v1", legacy.Body.String())
+	assert.Equal(t, "@1792108800", legacy.Header().Get("Deprecation"))
+	assert.Equal(t, "Fri, 16 Apr 2027 00:00:00 GMT", legacy.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/webhooks/42>; rel="successor-version"`, legacy.Header().Get("Link"))
+
+	assert.Equal(t, http.StatusOK, current.Code)
+	assert.Equal(t, "v1", current.Body.String())
+	assert.Empty(t, current.Header().Get("Deprecation"))
+	assert.Empty(t, current.Header().Get("Sunset"))
+	assert.Empty(t, current.Header().Get("Link"))
+}
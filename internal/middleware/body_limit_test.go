@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBodyLimits tests that bodies over the route limit get 413, including chunked bodies, and that
+// bodies of other media types than the route's get 415
+func TestBodyLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.MaxBodySize(middleware.BodyLimits{
+		Default: 16,
+		Routes:  map[string]int64{"POST /receive": 64},
+	}, logging.Nop()))
+	api := engine.Group("", middleware.RequireContentTypes(middleware.ContentTypes{
+		Default: []string{"application/json"},
+		Routes:  map[string][]string{"POST /receive": {"application/json", "application/yaml"}},
+	}, logging.Nop()))
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "%d", len(body))
+	}
+	api.POST("/event", echo)
+	api.POST("/receive", echo)
+
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		contentType string
+		chunked     bool
+		want        int
+	}{
+		{"within default limit", "/event", `{"a":1}`, "application/json", false, http.StatusOK},
+		{"over default limit", "/event", `{"a":"0123456789"}`, "application/json", false, http.StatusRequestEntityTooLarge},
+		{"chunked over default limit", "/event", `{"a":"0123456789"}`, "application/json", true, http.StatusRequestEntityTooLarge},
+		{"route limit", "/receive", `{"a":"0123456789"}`, "application/json; charset=utf-8", false, http.StatusOK},
+		{"structured suffix", "/receive", `{"a":1}`, "application/cloudevents+json", false, http.StatusOK},
+		{"form body", "/receive", `a=1`, "application/x-www-form-urlencoded", false, http.StatusUnsupportedMediaType},
+		{"route media type", "/receive", `a: 1`, "application/yaml", false, http.StatusOK},
+		{"media type of other route", "/event", `a: 1`, "application/yaml", false, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			recorder := httptest.NewRecorder()
+
+			engine.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.want, recorder.Code, recorder.Body.String())
+		})
+	}
+}
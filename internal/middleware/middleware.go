@@ -1,24 +1,45 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
 	"go.uber.org/zap"
 )
 
-var logger *zap.Logger
+// RequestIDHeader carries the ID of a request, sent by the caller or generated
+const RequestIDHeader = "X-Request-ID"
 
-func init() {
-	var err error
-	logger, err = zap.NewProduction()
-	if err != nil {
-		panic("Failed to initialize logger: " + err.Error())
+// maxRequestIDLength bounds request IDs sent by callers, longer ones are replaced
+const maxRequestIDLength = 128
+
+// RequestID assigns every request an ID, echoed in the X-Request-ID response header
+// The caller's X-Request-ID is kept so requests can be traced across services; the ID is added
+// as request_id to every entry logged with the request's context
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
 	}
 }
 
-// RequestLogger logs HTTP requests using Zap logger
-func RequestLogger() gin.HandlerFunc {
+// RequestLogger logs HTTP requests to logger
+func RequestLogger(logger logging.Logger) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		// Log using Zap instead of default Gin logger
 		fields := []zap.Field{
@@ -35,13 +56,14 @@ func RequestLogger() gin.HandlerFunc {
 		}
 
 		// Determine log level based on status code
+		ctx := param.Request.Context()
 		switch {
 		case param.StatusCode >= 400 && param.StatusCode < 500:
-			logger.Warn("HTTP Request", fields...)
+			logger.Warn(ctx, "HTTP Request", fields...)
 		case param.StatusCode >= 500:
-			logger.Error("HTTP Request", fields...)
+			logger.Error(ctx, "HTTP Request", fields...)
 		default:
-			logger.Info("HTTP Request", fields...)
+			logger.Info(ctx, "HTTP Request", fields...)
 		}
 
 		return "" // Return empty string since we're using Zap for logging
@@ -53,8 +75,9 @@ func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Shavix-Signature, X-Shavix-Timestamp")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Shavix-Signature, X-Shavix-Timestamp, X-API-Key, X-Request-ID, If-Match, If-None-Match")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Header("Access-Control-Expose-Headers", "X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After, X-Request-ID, ETag, Deprecation, Sunset, Link")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -65,11 +88,6 @@ func CORS() gin.HandlerFunc {
 	}
 }
 
-// RateLimiter could be added here for API rate limiting
-// func RateLimiter() gin.HandlerFunc {
-//     // Implementation would go here
-// }
-
 // Security adds security headers
 func Security() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -88,3 +106,121 @@ func Timeout(timeout time.Duration) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// principalKey is the gin context key under which the authenticated caller is stored
+const principalKey = "principal"
+
+// Authenticator resolves the caller of a management API request from its credentials
+type Authenticator interface {
+	AuthenticateAPIKey(ctx context.Context, apiKey string) (*models.Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (*models.Principal, error)
+}
+
+// RequireRole authenticates the request and rejects callers whose role is below the required one
+// Credentials are read from the X-API-Key header or from an "Authorization: Bearer <jwt>" header
+// Callers bound to a tenant are rejected when the tenant_id of the query or the JSON body names another tenant
+// Authenticated callers are then rate limited by limiter, nil to not limit; rejected requests are logged to logger
+func RequireRole(auth Authenticator, role models.Role, limiter *RateLimiter, logger logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := authenticate(c, auth)
+		if err != nil {
+			logger.Warn(c.Request.Context(), "Authentication failed",
+				zap.String("path", c.FullPath()),
+				zap.String("client_ip", c.ClientIP()),
+				zap.Error(err))
+			AbortWithError(c, err, http.StatusUnauthorized, "unauthorized", "missing or invalid credentials")
+			return
+		}
+
+		if principal.TenantID != "" {
+			c.Request = c.Request.WithContext(logging.WithFields(c.Request.Context(), zap.String("tenant_id", principal.TenantID)))
+		}
+
+		if !principal.Role.Allows(role) {
+			logger.Warn(c.Request.Context(), "Insufficient role for request",
+				zap.String("path", c.FullPath()),
+				zap.String("role", string(principal.Role)),
+				zap.String("required_role", string(role)))
+			AbortWithProblem(c, http.StatusForbidden, "forbidden", "role "+string(principal.Role)+" cannot access this endpoint, "+string(role)+" required")
+			return
+		}
+
+		if principal.TenantID != "" && !namesOnlyTenant(c, principal.TenantID) {
+			logger.Warn(c.Request.Context(), "Request names another tenant than the credential's",
+				zap.String("path", c.FullPath()))
+			AbortWithProblem(c, http.StatusForbidden, "forbidden", "tenant_id names a different tenant than the credential's")
+			return
+		}
+
+		if limiter != nil && !limiter.allow(c, principal, logger) {
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// RequireGlobalPrincipal rejects callers bound to a tenant
+// Must run after RequireRole; used for operator endpoints that expose data of every tenant
+func RequireGlobalPrincipal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := GetPrincipal(c)
+		if principal == nil || principal.TenantID != "" {
+			AbortWithProblem(c, http.StatusForbidden, "forbidden", "endpoint is restricted to credentials not bound to a tenant")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// namesOnlyTenant reports whether every tenant_id of a request's query and JSON body, if it has any, is tenantID
+// The body is read and put back for the handler; bodies that are not a JSON object are left to the handler to reject
+func namesOnlyTenant(c *gin.Context, tenantID string) bool {
+	for _, named := range c.QueryArray("tenant_id") {
+		if named != tenantID {
+			return false
+		}
+	}
+
+	if c.Request.Body == nil || c.Request.Body == http.NoBody || !strings.HasSuffix(c.ContentType(), "json") {
+		return true
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return true
+	}
+	var named struct {
+		TenantID *string `json:"tenant_id"`
+	}
+	if json.Unmarshal(body, &named) != nil || named.TenantID == nil {
+		return true
+	}
+	return *named.TenantID == tenantID
+}
+
+// GetPrincipal returns the caller authenticated by RequireRole, or nil for unauthenticated routes
+func GetPrincipal(c *gin.Context) *models.Principal {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil
+	}
+	principal, _ := value.(*models.Principal)
+	return principal
+}
+
+// authenticate extracts and verifies the credentials presented with a request
+func authenticate(c *gin.Context, auth Authenticator) (*models.Principal, error) {
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		return auth.AuthenticateAPIKey(c.Request.Context(), apiKey)
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if token, found := strings.CutPrefix(authHeader, "Bearer "); found && token != "" {
+		return auth.AuthenticateToken(c.Request.Context(), token)
+	}
+
+	return nil, apperr.Unauthorized("unauthorized", "missing API key or bearer token")
+}
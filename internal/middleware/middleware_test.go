@@ -0,0 +1,177 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequestID tests that the caller's request ID is kept, a missing one is generated, and that entries
+// logged with the request's context carry request_id and tenant_id
+func TestRequestID(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := logging.NewLogger(zap.New(core))
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.RequestID())
+	engine.GET("/webhooks", middleware.RequireRole(tenantAuthenticator{}, models.RoleViewer, nil, logger), func(c *gin.Context) {
+		logger.Info(c.Request.Context(), "Listing webhooks")
+		c.Status(http.StatusOK)
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	req.Header.Set("X-API-Key", "tenant-a")
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+
+	generatedReq := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	generatedReq.Header.Set("X-API-Key", "tenant-b")
+	generated := httptest.NewRecorder()
+	engine.ServeHTTP(generated, generatedReq)
+
+	// Assert
+	assert.Equal(t, "req-123", recorder.Header().Get(middleware.RequestIDHeader))
+	assert.NotEmpty(t, generated.Header().Get(middleware.RequestIDHeader))
+
+	entries := logs.FilterMessage("Listing webhooks").AllUntimed()
+	if assert.Len(t, entries, 2) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "req-123", fields["request_id"])
+		assert.Equal(t, "tenant-a", fields["tenant_id"])
+
+		fields = entries[1].ContextMap()
+		assert.Equal(t, generated.Header().Get(middleware.RequestIDHeader), fields["request_id"])
+		assert.Equal(t, "tenant-b", fields["tenant_id"])
+	}
+}
+
+// credentials authenticates API keys of the form role@tenant, or role for credentials not bound to a tenant
+type credentials struct{}
+
+func (credentials) AuthenticateAPIKey(_ context.Context, apiKey string) (*models.Principal, error) {
+	role, tenantID, _ := strings.Cut(apiKey, "@")
+	switch models.Role(role) {
+	case models.RoleViewer, models.RolePublisher, models.RoleAdmin:
+		return &models.Principal{TenantID: tenantID, Role: models.Role(role)}, nil
+	}
+	return nil, apperr.Unauthorized("unauthorized", "unknown API key")
+}
+
+func (credentials) AuthenticateToken(context.Context, string) (*models.Principal, error) {
+	return nil, apperr.Unauthorized("unauthorized", "invalid token")
+}
+
+// TestRequireRole tests that callers without valid credentials get 401, callers whose role is below the route's
+// get 403, and that operator routes reject credentials bound to a tenant
+func TestRequireRole(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.GET("/webhooks", middleware.RequireRole(credentials{}, models.RoleViewer, nil, logging.Nop()), ok)
+	engine.POST("/event", middleware.RequireRole(credentials{}, models.RolePublisher, nil, logging.Nop()), ok)
+	engine.POST("/subscribe", middleware.RequireRole(credentials{}, models.RoleAdmin, nil, logging.Nop()), ok)
+	engine.GET("/admin", middleware.RequireRole(credentials{}, models.RoleAdmin, nil, logging.Nop()), middleware.RequireGlobalPrincipal(), ok)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		apiKey string
+		status int
+	}{
+		{name: "missing credentials", method: http.MethodGet, path: "/webhooks", status: http.StatusUnauthorized},
+		{name: "unknown key", method: http.MethodGet, path: "/webhooks", apiKey: "owner@tenant-a", status: http.StatusUnauthorized},
+		{name: "viewer reads", method: http.MethodGet, path: "/webhooks", apiKey: "viewer@tenant-a", status: http.StatusOK},
+		{name: "viewer publishes", method: http.MethodPost, path: "/event", apiKey: "viewer@tenant-a", status: http.StatusForbidden},
+		{name: "publisher publishes", method: http.MethodPost, path: "/event", apiKey: "publisher@tenant-a", status: http.StatusOK},
+		{name: "publisher subscribes", method: http.MethodPost, path: "/subscribe", apiKey: "publisher@tenant-a", status: http.StatusForbidden},
+		{name: "admin reads", method: http.MethodGet, path: "/webhooks", apiKey: "admin@tenant-a", status: http.StatusOK},
+		{name: "admin subscribes", method: http.MethodPost, path: "/subscribe", apiKey: "admin@tenant-a", status: http.StatusOK},
+		{name: "tenant admin on operator route", method: http.MethodGet, path: "/admin", apiKey: "admin@tenant-a", status: http.StatusForbidden},
+		{name: "global admin on operator route", method: http.MethodGet, path: "/admin", apiKey: "admin", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, tt.status, recorder.Code)
+		})
+	}
+}
+
+// TestRequireRole_TenantID tests that callers bound to a tenant are rejected with 403 when the tenant_id of the
+// query or JSON body names another tenant, and that handlers still read the body of accepted requests
+func TestRequireRole_TenantID(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "%s", body)
+	}
+	engine.GET("/webhooks", middleware.RequireRole(credentials{}, models.RoleViewer, nil, logging.Nop()), echo)
+	engine.POST("/subscribe", middleware.RequireRole(credentials{}, models.RoleAdmin, nil, logging.Nop()), echo)
+	engine.POST("/event", middleware.RequireRole(credentials{}, models.RolePublisher, nil, logging.Nop()), echo)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		apiKey string
+		body   string
+		status int
+	}{
+		{name: "own tenant in query", method: http.MethodGet, path: "/webhooks?tenant_id=tenant-a", apiKey: "viewer@tenant-a", status: http.StatusOK},
+		{name: "other tenant in query", method: http.MethodGet, path: "/webhooks?tenant_id=tenant-b", apiKey: "viewer@tenant-a", status: http.StatusForbidden},
+		{name: "repeated query names another", method: http.MethodGet, path: "/webhooks?tenant_id=tenant-a&tenant_id=tenant-b", apiKey: "viewer@tenant-a", status: http.StatusForbidden},
+		{name: "own tenant in body", method: http.MethodPost, path: "/subscribe", apiKey: "admin@tenant-a", body: `{"tenant_id": "tenant-a", "app_name": "shop"}`, status: http.StatusOK},
+		{name: "other tenant in body", method: http.MethodPost, path: "/subscribe", apiKey: "admin@tenant-a", body: `{"tenant_id": "tenant-b", "app_name": "shop"}`, status: http.StatusForbidden},
+		{name: "other tenant in event", method: http.MethodPost, path: "/event", apiKey: "publisher@tenant-a", body: `{"tenant_id": "tenant-b", "event": "order.created"}`, status: http.StatusForbidden},
+		{name: "body without tenant", method: http.MethodPost, path: "/event", apiKey: "publisher@tenant-a", body: `{"event": "order.created"}`, status: http.StatusOK},
+		{name: "global credential names any tenant", method: http.MethodPost, path: "/event", apiKey: "publisher", body: `{"tenant_id": "tenant-b"}`, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("X-API-Key", tt.apiKey)
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.body, recorder.Body.String())
+			}
+		})
+	}
+}
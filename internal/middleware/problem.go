@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// ProblemContentType is the media type of error responses, RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// NewProblem returns the problem details of a failed request
+func NewProblem(c *gin.Context, status int, code, detail string) models.Problem {
+	problem := models.Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Code:      code,
+		RequestID: logging.RequestID(c.Request.Context()),
+	}
+	if c.Request.URL != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+	return problem
+}
+
+// WriteProblem responds with the problem details of a failed request
+// code is the stable machine-readable code of the error and detail its explanation for clients, which must
+// not include internal errors
+func WriteProblem(c *gin.Context, status int, code, detail string) {
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(status, NewProblem(c, status, code, detail))
+}
+
+// AbortWithProblem responds with the problem details of a failed request like WriteProblem and stops the
+// handlers after the calling one from running
+func AbortWithProblem(c *gin.Context, status int, code, detail string) {
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(status, NewProblem(c, status, code, detail))
+}
+
+// WriteError responds with the problem details of an error
+// Typed errors, see apperr, are answered with the status of their kind, their code and their message; other
+// errors with the given status, code and detail, so their text never reaches clients
+func WriteError(c *gin.Context, err error, status int, code, detail string) {
+	if typed := apperr.As(err); typed != nil {
+		WriteProblem(c, ErrorStatus(typed, status), typed.Code, typed.Message)
+		return
+	}
+	WriteProblem(c, status, code, detail)
+}
+
+// AbortWithError responds with the problem details of an error like WriteError and stops the handlers after the
+// calling one from running
+func AbortWithError(c *gin.Context, err error, status int, code, detail string) {
+	if typed := apperr.As(err); typed != nil {
+		status, code, detail = ErrorStatus(typed, status), typed.Code, typed.Message
+	}
+	AbortWithProblem(c, status, code, detail)
+}
+
+// ErrorStatus returns the HTTP status of an error's kind, fallback for errors of no kind
+func ErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperr.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, apperr.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperr.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperr.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperr.ErrLimitExceeded):
+		return http.StatusTooManyRequests
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteError tests that typed errors are answered with the status of their kind, their code and their
+// message, and that other errors are answered with the given fallback without leaking their text
+func TestWriteError(t *testing.T) {
+	notFound := apperr.NotFound("chain_not_found", "execution chain not found")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"typed", notFound.Wrap(errors.New("record not found")), http.StatusNotFound, "chain_not_found", "execution chain not found"},
+		{"reworded", apperr.Validation("invalid_chain", "invalid execution chain").Withf("step 2 has no webhook"), http.StatusBadRequest, "invalid_chain", "step 2 has no webhook"},
+		{"limit", apperr.LimitExceeded("quota_exceeded", "daily event quota exceeded"), http.StatusTooManyRequests, "quota_exceeded", "daily event quota exceeded"},
+		{"untyped", errors.New("pq: connection refused"), http.StatusInternalServerError, "chain_lookup_failed", "Failed to retrieve execution chain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.Use(middleware.RequestID())
+			engine.GET("/chains/:id", func(c *gin.Context) {
+				middleware.WriteError(c, tt.err, http.StatusInternalServerError, "chain_lookup_failed", "Failed to retrieve execution chain")
+			})
+
+			// Act
+			req := httptest.NewRequest(http.MethodGet, "/chains/42", nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-123")
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, middleware.ProblemContentType, recorder.Header().Get("Content-Type"))
+			var problem models.Problem
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, tt.wantStatus, problem.Status)
+			assert.Equal(t, http.StatusText(tt.wantStatus), problem.Title)
+			assert.Equal(t, tt.wantCode, problem.Code)
+			assert.Equal(t, tt.wantDetail, problem.Detail)
+			assert.Equal(t, "/chains/42", problem.Instance)
+			assert.Equal(t, "req-123", problem.RequestID)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"go.uber.org/zap"
+)
+
+// Default API rate limit, see NewRateLimiter
+const (
+	DefaultRateLimit      = 50.0 // requests per second
+	DefaultRateLimitBurst = 100
+)
+
+// rateLimitSweepInterval is how often buckets that refilled completely are dropped
+const rateLimitSweepInterval = time.Minute
+
+// tenantKeyPrefix prefixes the bucket keys of callers bound to a tenant
+const tenantKeyPrefix = "tenant:"
+
+// RateLimiter limits management API requests with a token bucket per caller
+// Callers bound to a tenant share the tenant's bucket, so a tenant cannot multiply its limit by creating
+// credentials; callers not bound to a tenant get a bucket per credential
+// Tenants can have their own rate and burst, see SetTenantLimits
+type RateLimiter struct {
+	limit bucketLimit
+	now   func() time.Time
+
+	mu           sync.Mutex
+	buckets      map[string]*tokenBucket
+	tenantLimits map[string]bucketLimit
+	lastSweep    time.Time
+}
+
+// bucketLimit is the refill rate, in tokens per second, and size of a bucket
+type bucketLimit struct {
+	rate  float64
+	burst int
+}
+
+// newBucketLimit creates a limit, rounding the rate up for the burst when burst is not positive
+func newBucketLimit(rate float64, burst int) bucketLimit {
+	if burst < 1 {
+		burst = int(math.Ceil(rate))
+	}
+	return bucketLimit{rate: rate, burst: burst}
+}
+
+// tokenBucket holds the tokens left for one caller as of the last update
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a limiter refilling rate tokens per second into buckets of burst tokens
+// Returns nil, which disables limiting, when rate is not positive
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &RateLimiter{
+		limit:   newBucketLimit(rate, burst),
+		now:     time.Now,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// SetTenantLimits replaces the rate limits of tenants that do not use the limiter's own
+// Limits without a positive rate are ignored; does nothing on a nil limiter, which limits no one
+func (l *RateLimiter) SetTenantLimits(limits map[string]models.RateLimit) {
+	if l == nil {
+		return
+	}
+	tenantLimits := make(map[string]bucketLimit, len(limits))
+	for tenantID, limit := range limits {
+		if limit.RequestsPerSecond > 0 {
+			tenantLimits[tenantID] = newBucketLimit(limit.RequestsPerSecond, limit.Burst)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tenantLimits = tenantLimits
+}
+
+// SetClock replaces the time source, for tests
+func (l *RateLimiter) SetClock(now func() time.Time) {
+	l.now = now
+}
+
+// take removes a token from the key's bucket
+// Returns the limit of the bucket, whether the request is allowed, the whole tokens left, and how long
+// until the next token (when rejected) or until the bucket is full again (when allowed)
+func (l *RateLimiter) take(key, tenantID string) (bucketLimit, bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	limit := l.bucketLimit(tenantID)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.burst), updated: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(float64(limit.burst), bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.rate)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / limit.rate * float64(time.Second))
+		return limit, false, 0, wait
+	}
+
+	bucket.tokens--
+	untilFull := time.Duration((float64(limit.burst) - bucket.tokens) / limit.rate * float64(time.Second))
+	return limit, true, int(bucket.tokens), untilFull
+}
+
+// bucketLimit returns the limit of a tenant's bucket, the limiter's own for other callers
+func (l *RateLimiter) bucketLimit(tenantID string) bucketLimit {
+	if limit, ok := l.tenantLimits[tenantID]; ok && tenantID != "" {
+		return limit
+	}
+	return l.limit
+}
+
+// sweep drops buckets that have refilled completely, which behave like new ones
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		limit := l.limit
+		if tenantID, ok := strings.CutPrefix(key, tenantKeyPrefix); ok {
+			limit = l.bucketLimit(tenantID)
+		}
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.rate >= float64(limit.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// allow applies the caller's limit to a request, setting the X-RateLimit headers on every response
+// and rejecting the request with 429 and Retry-After when the caller's bucket is empty, logged to logger
+func (l *RateLimiter) allow(c *gin.Context, principal *models.Principal, logger logging.Logger) bool {
+	key := rateLimitKey(principal)
+	limit, allowed, remaining, wait := l.take(key, principal.TenantID)
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit.burst))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(wait)))
+	if allowed {
+		return true
+	}
+
+	logger.Warn(c.Request.Context(), "Rate limit exceeded",
+		zap.String("path", c.FullPath()),
+		zap.String("key", key))
+	c.Header("Retry-After", strconv.Itoa(ceilSeconds(wait)))
+	AbortWithProblem(c, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded, retry after "+strconv.Itoa(ceilSeconds(wait))+"s")
+	return false
+}
+
+// Allow takes a request of the caller from its bucket, for APIs served outside of gin such as gRPC
+// Returns whether the request is allowed and, when it is not, how long until it would be
+func (l *RateLimiter) Allow(principal *models.Principal) (bool, time.Duration) {
+	_, allowed, _, wait := l.take(rateLimitKey(principal), principal.TenantID)
+	return allowed, wait
+}
+
+// rateLimitKey identifies the bucket of a caller
+func rateLimitKey(principal *models.Principal) string {
+	switch {
+	case principal.TenantID != "":
+		return tenantKeyPrefix + principal.TenantID
+	case principal.CredentialID != uuid.Nil:
+		return "credential:" + principal.CredentialID.String()
+	default:
+		return "bootstrap"
+	}
+}
+
+// ceilSeconds rounds a duration up to whole seconds, at least 1
+func ceilSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
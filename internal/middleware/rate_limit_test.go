@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/middleware"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tenantAuthenticator authenticates every API key as a viewer of the tenant named by the key
+type tenantAuthenticator struct{}
+
+func (tenantAuthenticator) AuthenticateAPIKey(_ context.Context, apiKey string) (*models.Principal, error) {
+	return &models.Principal{TenantID: apiKey, Role: models.RoleViewer}, nil
+}
+
+func (tenantAuthenticator) AuthenticateToken(context.Context, string) (*models.Principal, error) {
+	return nil, nil
+}
+
+// TestRateLimiter tests that each tenant gets its own bucket and that exhausted buckets get 429 until they refill
+func TestRateLimiter(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	limiter := middleware.NewRateLimiter(1, 2)
+	limiter.SetClock(func() time.Time { return now })
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/webhooks", middleware.RequireRole(tenantAuthenticator{}, models.RoleViewer, limiter, logging.Nop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	call := func(tenantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		req.Header.Set("X-API-Key", tenantID)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act & Assert
+	first := call("acme")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, http.StatusOK, call("acme").Code)
+
+	limited := call("acme")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get("X-RateLimit-Remaining"))
+
+	assert.Equal(t, http.StatusOK, call("globex").Code)
+
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, call("acme").Code)
+	assert.Equal(t, http.StatusTooManyRequests, call("acme").Code)
+}
+
+// TestRateLimiter_TenantLimits tests that tenants with their own limit use it and the others the limiter's
+func TestRateLimiter_TenantLimits(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	limiter := middleware.NewRateLimiter(1, 1)
+	limiter.SetClock(func() time.Time { return now })
+	limiter.SetTenantLimits(map[string]models.RateLimit{
+		"acme":    {RequestsPerSecond: 10, Burst: 3},
+		"initech": {RequestsPerSecond: 0, Burst: 50},
+	})
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/webhooks", middleware.RequireRole(tenantAuthenticator{}, models.RoleViewer, limiter, logging.Nop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	call := func(tenantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		req.Header.Set("X-API-Key", tenantID)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act & Assert
+	first := call("acme")
+	assert.Equal(t, "3", first.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, http.StatusOK, call("acme").Code)
+	assert.Equal(t, http.StatusOK, call("acme").Code)
+	assert.Equal(t, http.StatusTooManyRequests, call("acme").Code)
+
+	// A limit without a rate is ignored
+	assert.Equal(t, http.StatusOK, call("initech").Code)
+	assert.Equal(t, http.StatusTooManyRequests, call("initech").Code)
+}
@@ -0,0 +1,225 @@
+// Package migrations applies the versioned SQL migrations of the database schema
+// Migrations are the files in sql/, named <version>_<description>.sql, e.g. 0002_add_run_index.sql; they
+// are applied in version order, each in the transaction recording it in the schema_migrations table
+// SQLite databases are migrated with the files in sqlite/, which mirror those in sql/ version for version
+// Migrations are forward only and must stay compatible with the previous release, which keeps running
+// against the migrated schema during a rolling deployment
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql sqlite/*.sql
+var files embed.FS
+
+// dialectDirs are the directories holding the migrations of each database dialect
+var dialectDirs = map[string]string{
+	"postgres": "sql",
+	"sqlite":   "sqlite",
+}
+
+// ErrSchemaOutdated is returned by Verify when migrations are pending
+var ErrSchemaOutdated = errors.New("database schema is out of date")
+
+// lockID is the key of the advisory lock serializing migrations of concurrently starting instances
+const lockID = 7_402_118_350
+
+// fileName matches migration file names and captures their version and description
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// AppliedMigration records a migration applied to the database
+type AppliedMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for AppliedMigration
+func (AppliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Load returns the embedded migrations of a database dialect, postgres or sqlite, in version order
+func Load(dialect string) ([]Migration, error) {
+	dir, ok := dialectDirs[dialect]
+	if !ok {
+		return nil, fmt.Errorf("no migrations for database dialect %q", dialect)
+	}
+	return load(files, dir)
+}
+
+// load reads the migrations in a directory, rejecting malformed names and duplicate versions
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	versions := map[int]string{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q, expected <version>_<description>.sql", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations %q and %q have the same version", other, entry.Name())
+		}
+		versions[version] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: match[2], SQL: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies migrations to a database
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	now        func() time.Time
+}
+
+// New creates a migrator applying the embedded migrations of the database's dialect
+func New(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, now: time.Now}, nil
+}
+
+// Status describes the schema version of the database
+type Status struct {
+	// Current is the highest applied version, 0 for an empty database
+	Current int
+	// Latest is the highest version known to this build
+	Latest int
+	// Pending are the migrations not applied yet, in version order
+	Pending []Migration
+}
+
+// Status returns the applied and pending migrations
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return m.status(applied), nil
+}
+
+// Up applies the pending migrations and returns them
+// Instances starting at once wait for each other on an advisory lock, so every migration is applied once;
+// SQLite has a single writer, which already serializes the migration transactions
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var pending []Migration
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockID).Error; err != nil {
+				return fmt.Errorf("failed to lock migrations: %w", err)
+			}
+		}
+		applied, err := m.applied(tx)
+		if err != nil {
+			return err
+		}
+
+		pending = m.status(applied).Pending
+		for _, migration := range pending {
+			if err := tx.Exec(migration.SQL).Error; err != nil {
+				return fmt.Errorf("failed to apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			record := &AppliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: m.now()}
+			if err := tx.Create(record).Error; err != nil {
+				return fmt.Errorf("failed to record migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// Verify returns ErrSchemaOutdated when migrations are pending
+// A schema newer than this build is accepted, as migrations stay compatible with the previous release
+func (m *Migrator) Verify(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("%w: at version %d, %d migrations pending up to version %d, run the migrate command",
+			ErrSchemaOutdated, status.Current, len(status.Pending), status.Latest)
+	}
+	return nil
+}
+
+// applied returns the versions applied to the database, creating the schema_migrations table if needed
+func (m *Migrator) applied(db *gorm.DB) (map[int]bool, error) {
+	timestamp := "timestamptz"
+	if db.Dialector.Name() == "sqlite" {
+		timestamp = "datetime"
+	}
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (
+    "version" bigint PRIMARY KEY,
+    "name" text NOT NULL,
+    "applied_at" ` + timestamp + ` NOT NULL
+)`).Error; err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var records []AppliedMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list applied migrations: %w", err)
+	}
+	applied := make(map[int]bool, len(records))
+	for _, record := range records {
+		applied[record.Version] = true
+	}
+	return applied, nil
+}
+
+// status compares the applied versions with the known migrations
+func (m *Migrator) status(applied map[int]bool) *Status {
+	status := &Status{}
+	for version := range applied {
+		status.Current = max(status.Current, version)
+	}
+	for _, migration := range m.migrations {
+		status.Latest = max(status.Latest, migration.Version)
+		if !applied[migration.Version] {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status
+}
@@ -0,0 +1,199 @@
+package migrations
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	_ "github.com/sakibcoolz/loki-suite/internal/repository" // registers the encrypted serializer
+	"gorm.io/gorm/schema"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// schemaModels are the models stored in the database; their tables must be created by the migrations
+var schemaModels = []interface{}{
+	&models.WebhookSubscription{},
+	&models.WebhookEvent{},
+	&models.ExecutionChain{},
+	&models.ExecutionChainStep{},
+	&models.ExecutionChainRun{},
+	&models.ExecutionChainStepRun{},
+	&models.ExecutionChainCompensationRun{},
+	&models.ExecutionChainVersion{},
+	&models.Tenant{},
+	&models.TenantSettings{},
+	&models.TenantUsage{},
+	&models.SigningKey{},
+	&models.JWTKey{},
+	&models.APICredential{},
+	&models.ConfigSnapshot{},
+	&models.QueuedDelivery{},
+	&models.BatchedDelivery{},
+	&models.OrderedDelivery{},
+	&models.WebhookDeliveryAttempt{},
+	&models.EventType{},
+	&models.ArchivalRun{},
+	&models.SubjectKey{},
+	&models.AlertRule{},
+	&models.AlertEvent{},
+	&models.DeliveryPause{},
+}
+
+// TestLoad tests that migrations are ordered by version and that malformed names and duplicate versions are rejected
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int
+		wantErr string
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"sql/0010_add_index.sql":  {Data: []byte("CREATE INDEX x ON y (z);")},
+				"sql/0002_add_column.sql": {Data: []byte("ALTER TABLE y ADD COLUMN z text;")},
+				"sql/0001_baseline.sql":   {Data: []byte("CREATE TABLE y ();")},
+			},
+			want: []int{1, 2, 10},
+		},
+		{
+			name:    "malformed name",
+			files:   fstest.MapFS{"sql/add_column.sql": {Data: []byte("")}},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"sql/0002_add_column.sql": {Data: []byte("")},
+				"sql/002_add_index.sql":   {Data: []byte("")},
+			},
+			wantErr: "same version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.files, "sql")
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			var versions []int
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
+
+// TestStatus tests that pending migrations are those not applied, whatever the highest applied version
+func TestStatus(t *testing.T) {
+	m := &Migrator{migrations: []Migration{{Version: 1}, {Version: 2}, {Version: 3}}}
+
+	status := m.status(map[int]bool{1: true, 3: true})
+
+	assert.Equal(t, 3, status.Current)
+	assert.Equal(t, 3, status.Latest)
+	if assert.Len(t, status.Pending, 1) {
+		assert.Equal(t, 2, status.Pending[0].Version)
+	}
+	assert.Empty(t, m.status(map[int]bool{1: true, 2: true, 3: true, 4: true}).Pending)
+}
+
+// TestMigrationsCoverModels tests that the migrations create every table and column of the stored models,
+// so a model change without a migration fails here instead of at runtime
+func TestMigrationsCoverModels(t *testing.T) {
+	for dialect := range dialectDirs {
+		t.Run(dialect, func(t *testing.T) {
+			migrations, err := Load(dialect)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var sql strings.Builder
+			for _, migration := range migrations {
+				sql.WriteString(migration.SQL)
+				sql.WriteString("\n")
+			}
+			columns := tableColumns(sql.String())
+
+			for _, model := range schemaModels {
+				parsed, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+				if !assert.NoError(t, err) {
+					continue
+				}
+
+				tableColumns, ok := columns[parsed.Table]
+				if !assert.True(t, ok, "no migration creates table %s", parsed.Table) {
+					continue
+				}
+				for _, field := range parsed.Fields {
+					if field.DBName == "" || field.IgnoreMigration {
+						continue
+					}
+					assert.True(t, tableColumns[field.DBName], "no migration creates column %s.%s", parsed.Table, field.DBName)
+				}
+			}
+		})
+	}
+}
+
+// TestDialectsMirrorMigrations tests that every Postgres migration has its SQLite counterpart, so both databases
+// reach the same schema at the same version
+func TestDialectsMirrorMigrations(t *testing.T) {
+	postgres, err := Load("postgres")
+	if !assert.NoError(t, err) {
+		return
+	}
+	sqlite, err := Load("sqlite")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	names := func(migrations []Migration) []string {
+		var names []string
+		for _, migration := range migrations {
+			names = append(names, fmt.Sprintf("%04d_%s", migration.Version, migration.Name))
+		}
+		return names
+	}
+	assert.Equal(t, names(postgres), names(sqlite))
+
+	_, err = Load("mysql")
+	assert.ErrorContains(t, err, "no migrations")
+}
+
+var (
+	createTable = regexp.MustCompile(`(?s)CREATE TABLE (?:IF NOT EXISTS )?"(\w+)" \((.*?)\r?\n\);`)
+	columnDef   = regexp.MustCompile(`(?m)^\s+"(\w+)" `)
+	addColumn   = regexp.MustCompile(`ALTER TABLE "(\w+)" ADD COLUMN (?:IF NOT EXISTS )?"(\w+)"`)
+)
+
+// tableColumns returns the columns the migrations create per table
+func tableColumns(sql string) map[string]map[string]bool {
+	tables := map[string]map[string]bool{}
+	for _, match := range createTable.FindAllStringSubmatch(sql, -1) {
+		columns := map[string]bool{}
+		for _, column := range columnDef.FindAllStringSubmatch(match[2], -1) {
+			columns[column[1]] = true
+		}
+		tables[match[1]] = columns
+	}
+	for _, match := range addColumn.FindAllStringSubmatch(sql, -1) {
+		if tables[match[1]] == nil {
+			tables[match[1]] = map[string]bool{}
+		}
+		tables[match[1]][match[2]] = true
+	}
+	return tables
+}
@@ -0,0 +1,309 @@
+-- Baseline schema: the tables created by GORM AutoMigrate before versioned migrations were introduced
+-- IF NOT EXISTS lets databases created by AutoMigrate adopt this migration without changes
+
+CREATE TABLE IF NOT EXISTS "webhook_subscriptions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "app_name" text NOT NULL,
+    "description" text,
+    "target_url" text NOT NULL,
+    "subscribed_event" text NOT NULL,
+    "type" text NOT NULL,
+    "secret_token" text NOT NULL,
+    "jwt_token" text,
+    "retry_count" bigint DEFAULT 0,
+    "max_retries" bigint DEFAULT 3,
+    "retry_delay_seconds" bigint DEFAULT 5,
+    "query_params" jsonb,
+    "headers" jsonb,
+    "payload" jsonb,
+    "response_schema" jsonb,
+    "signing_signature" text,
+    "signing_timestamp" text,
+    "signing_attempt" text,
+    "signature_scheme" varchar(32),
+    "allowed_source_ips" jsonb,
+    "is_active" boolean DEFAULT true,
+    "paused_until" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_paused_until" ON "webhook_subscriptions" ("paused_until");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_tenant_id" ON "webhook_subscriptions" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "event_name" text NOT NULL,
+    "source" text NOT NULL,
+    "payload" jsonb,
+    "status" text DEFAULT 'pending',
+    "response_code" bigint,
+    "attempts" bigint DEFAULT 0,
+    "last_error" text,
+    "sent_at" timestamptz,
+    "deliver_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_deliver_at" ON "webhook_events" ("deliver_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_tenant_id" ON "webhook_events" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "execution_chains" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "status" text DEFAULT 'pending',
+    "trigger_event" text NOT NULL,
+    "is_active" boolean DEFAULT true,
+    "paused_at" timestamptz,
+    "pause_reason" text,
+    "version" bigint NOT NULL DEFAULT 0,
+    "schedule" text,
+    "schedule_timezone" text,
+    "overlap_policy" text,
+    "next_run_at" timestamptz,
+    "last_scheduled_at" timestamptz,
+    "completion_webhook_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chains_next_run_at" ON "execution_chains" ("next_run_at");
+CREATE INDEX IF NOT EXISTS "idx_execution_chains_tenant_id" ON "execution_chains" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_steps" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "chain_id" uuid NOT NULL,
+    "step_order" bigint NOT NULL,
+    "webhook_id" uuid,
+    "name" text NOT NULL,
+    "description" text,
+    "type" text DEFAULT 'webhook',
+    "request_params" jsonb,
+    "response_schema" jsonb,
+    "condition" text,
+    "parallel_group" text,
+    "key" text,
+    "depends_on" jsonb,
+    "branches" jsonb,
+    "branch_path" text,
+    "extract" jsonb,
+    "compensation_webhook_id" uuid,
+    "compensation_params" jsonb,
+    "on_success_action" text DEFAULT 'continue',
+    "on_failure_action" text DEFAULT 'stop',
+    "retry_count" bigint DEFAULT 0,
+    "max_retries" bigint DEFAULT 3,
+    "delay_seconds" bigint DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "retired_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_execution_chains_steps" FOREIGN KEY ("chain_id") REFERENCES "execution_chains"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_execution_chain_steps_webhook" FOREIGN KEY ("webhook_id") REFERENCES "webhook_subscriptions"("id"),
+    CONSTRAINT "fk_execution_chain_steps_compensation_webhook" FOREIGN KEY ("compensation_webhook_id") REFERENCES "webhook_subscriptions"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_steps_retired_at" ON "execution_chain_steps" ("retired_at");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_runs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "chain_id" uuid NOT NULL,
+    "tenant_id" text NOT NULL,
+    "status" text DEFAULT 'pending',
+    "trigger_event" text,
+    "chain_version" bigint NOT NULL DEFAULT 0,
+    "trigger_data" jsonb,
+    "current_step" bigint DEFAULT 0,
+    "total_steps" bigint,
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "last_error" text,
+    "cancel_reason" text,
+    "cancelled_at" timestamptz,
+    "variables" jsonb,
+    "compensation_status" text,
+    "callback_url" text,
+    "callback_secret" text,
+    "callback_status" text,
+    "callback_error" text,
+    "worker_id" text,
+    "heartbeat_at" timestamptz,
+    "priority" bigint NOT NULL DEFAULT 0,
+    "options" jsonb,
+    "retry_of_run_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_execution_chain_runs_chain" FOREIGN KEY ("chain_id") REFERENCES "execution_chains"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_retry_of_run_id" ON "execution_chain_runs" ("retry_of_run_id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_heartbeat_at" ON "execution_chain_runs" ("heartbeat_at");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_tenant_id" ON "execution_chain_runs" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_step_runs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "run_id" uuid NOT NULL,
+    "step_id" uuid NOT NULL,
+    "step_order" bigint,
+    "status" text DEFAULT 'pending',
+    "request_payload" jsonb,
+    "response_code" bigint,
+    "response_body" text,
+    "attempt_count" bigint DEFAULT 0,
+    "last_error" text,
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "approval" jsonb,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_execution_chain_step_runs_step" FOREIGN KEY ("step_id") REFERENCES "execution_chain_steps"("id"),
+    CONSTRAINT "fk_execution_chain_runs_step_runs" FOREIGN KEY ("run_id") REFERENCES "execution_chain_runs"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "execution_chain_compensation_runs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "run_id" uuid NOT NULL,
+    "step_id" uuid NOT NULL,
+    "step_order" bigint,
+    "webhook_id" uuid NOT NULL,
+    "status" text DEFAULT 'pending',
+    "request_payload" jsonb,
+    "response_code" bigint,
+    "response_body" text,
+    "attempt_count" bigint DEFAULT 0,
+    "last_error" text,
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_execution_chain_runs_compensations" FOREIGN KEY ("run_id") REFERENCES "execution_chain_runs"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_compensation_runs_run_id" ON "execution_chain_compensation_runs" ("run_id");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_versions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "chain_id" uuid NOT NULL,
+    "version" bigint NOT NULL,
+    "steps" jsonb NOT NULL,
+    "rolled_back_from" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_chain_version" ON "execution_chain_versions" ("chain_id","version");
+
+CREATE TABLE IF NOT EXISTS "tenant_settings" (
+    "tenant_id" text,
+    "chains_paused" boolean DEFAULT false,
+    "chains_paused_at" timestamptz,
+    "signing_signature" text,
+    "signing_timestamp" text,
+    "signing_attempt" text,
+    "max_concurrent_runs" bigint DEFAULT 0,
+    "strict_payload_validation" boolean DEFAULT false,
+    "signing_algorithm" text,
+    "signing_key_id" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("tenant_id")
+);
+
+CREATE TABLE IF NOT EXISTS "signing_keys" (
+    "id" text,
+    "tenant_id" text NOT NULL,
+    "algorithm" text NOT NULL,
+    "public_key" bytea NOT NULL,
+    "private_key" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_signing_keys_tenant_id" ON "signing_keys" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "jwt_keys" (
+    "id" text,
+    "secret" text NOT NULL,
+    "is_primary" boolean NOT NULL DEFAULT false,
+    "retired_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "api_credentials" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "name" text NOT NULL,
+    "key_prefix" text NOT NULL,
+    "key_hash" text NOT NULL,
+    "role" text NOT NULL,
+    "is_active" boolean DEFAULT true,
+    "last_used_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_credentials_key_hash" ON "api_credentials" ("key_hash");
+CREATE INDEX IF NOT EXISTS "idx_api_credentials_tenant_id" ON "api_credentials" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "config_snapshots" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "resource_type" text NOT NULL,
+    "resource_id" uuid NOT NULL,
+    "version" bigint NOT NULL,
+    "change" text NOT NULL,
+    "config" jsonb NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_config_snapshot_version" ON "config_snapshots" ("resource_type","resource_id","version");
+CREATE INDEX IF NOT EXISTS "idx_config_snapshots_tenant_id" ON "config_snapshots" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "queued_deliveries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "subscription_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "tenant_id" text NOT NULL,
+    "payload" jsonb NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_queued_deliveries_tenant_id" ON "queued_deliveries" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_queued_deliveries_event_id" ON "queued_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_queued_deliveries_subscription_id" ON "queued_deliveries" ("subscription_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery_attempts" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "webhook_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "attempt" bigint,
+    "final" boolean,
+    "success" boolean,
+    "response_code" bigint,
+    "error_class" text,
+    "error" text,
+    "latency_ms" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_event_id" ON "webhook_delivery_attempts" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_delivery_attempts_webhook_created" ON "webhook_delivery_attempts" ("webhook_id","created_at");
+CREATE INDEX IF NOT EXISTS "idx_delivery_attempts_tenant_created" ON "webhook_delivery_attempts" ("tenant_id","created_at");
+
+CREATE TABLE IF NOT EXISTS "event_types" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "schema" jsonb,
+    "validate_payloads" boolean,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_types_tenant_name" ON "event_types" ("tenant_id","name");
@@ -0,0 +1,82 @@
+-- Retention: per-tenant retention periods, archive tables for expired events and chain runs, and archival runs
+-- Archived rows are stored as jsonb so columns added to the live tables later need no archive migration
+
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "retention_days" bigint DEFAULT 0;
+
+-- The archiver scans finished rows by age
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_created_at" ON "webhook_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_completed_at" ON "execution_chain_runs" ("completed_at");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_step_runs_run_id" ON "execution_chain_step_runs" ("run_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_events_archive" (
+    "id" uuid,
+    "tenant_id" text NOT NULL,
+    "created_at" timestamptz,
+    "archived_at" timestamptz NOT NULL,
+    "data" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_archive_tenant_id" ON "webhook_events_archive" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_archive_archived_at" ON "webhook_events_archive" ("archived_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery_attempts_archive" (
+    "id" uuid,
+    "event_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "archived_at" timestamptz NOT NULL,
+    "data" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_archive_event_id" ON "webhook_delivery_attempts_archive" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_archive_archived_at" ON "webhook_delivery_attempts_archive" ("archived_at");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_runs_archive" (
+    "id" uuid,
+    "tenant_id" text NOT NULL,
+    "chain_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "archived_at" timestamptz NOT NULL,
+    "data" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_archive_tenant_id" ON "execution_chain_runs_archive" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_archive_archived_at" ON "execution_chain_runs_archive" ("archived_at");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_step_runs_archive" (
+    "id" uuid,
+    "run_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "archived_at" timestamptz NOT NULL,
+    "data" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_step_runs_archive_run_id" ON "execution_chain_step_runs_archive" ("run_id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_step_runs_archive_archived_at" ON "execution_chain_step_runs_archive" ("archived_at");
+
+CREATE TABLE IF NOT EXISTS "execution_chain_compensation_runs_archive" (
+    "id" uuid,
+    "run_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "archived_at" timestamptz NOT NULL,
+    "data" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_compensation_runs_archive_run_id" ON "execution_chain_compensation_runs_archive" ("run_id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_compensation_runs_archive_archived_at" ON "execution_chain_compensation_runs_archive" ("archived_at");
+
+CREATE TABLE IF NOT EXISTS "archival_runs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "trigger" text NOT NULL,
+    "status" text NOT NULL,
+    "events_archived" bigint,
+    "attempts_archived" bigint,
+    "runs_archived" bigint,
+    "step_runs_archived" bigint,
+    "archives_pruned" bigint,
+    "error" text,
+    "started_at" timestamptz NOT NULL,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_archival_runs_status" ON "archival_runs" ("status");
+CREATE INDEX IF NOT EXISTS "idx_archival_runs_started_at" ON "archival_runs" ("started_at");
@@ -0,0 +1,7 @@
+-- Soft deletes: deleted subscriptions and chains keep their rows until purged, so they can be restored
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_deleted_at" ON "webhook_subscriptions" ("deleted_at");
+
+ALTER TABLE "execution_chains" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_execution_chains_deleted_at" ON "execution_chains" ("deleted_at");
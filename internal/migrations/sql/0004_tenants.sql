@@ -0,0 +1,35 @@
+-- Tenants: tenant IDs used so far become tenants, and subscriptions, events and chains reference their tenant
+
+CREATE TABLE IF NOT EXISTS "tenants" (
+    "id" text,
+    "name" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'active',
+    "suspended_at" timestamptz,
+    "suspended_reason" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_tenants_status" ON "tenants" ("status");
+CREATE INDEX IF NOT EXISTS "idx_tenants_deleted_at" ON "tenants" ("deleted_at");
+
+INSERT INTO "tenants" ("id", "name", "status", "created_at", "updated_at")
+SELECT "tenant_id", "tenant_id", 'active', MIN("created_at"), now() FROM (
+    SELECT "tenant_id", "created_at" FROM "tenant_settings"
+    UNION ALL SELECT "tenant_id", "created_at" FROM "webhook_subscriptions"
+    UNION ALL SELECT "tenant_id", "created_at" FROM "webhook_events"
+    UNION ALL SELECT "tenant_id", "created_at" FROM "execution_chains"
+    UNION ALL SELECT "tenant_id", "created_at" FROM "api_credentials" WHERE "tenant_id" <> ''
+) AS "used"
+GROUP BY "tenant_id"
+ON CONFLICT ("id") DO NOTHING;
+
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "default_max_retries" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "default_retry_delay_seconds" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "rate_limit_requests_per_second" numeric;
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "rate_limit_burst" bigint;
+
+ALTER TABLE "webhook_subscriptions" ADD CONSTRAINT "fk_webhook_subscriptions_tenant" FOREIGN KEY ("tenant_id") REFERENCES "tenants"("id");
+ALTER TABLE "webhook_events" ADD CONSTRAINT "fk_webhook_events_tenant" FOREIGN KEY ("tenant_id") REFERENCES "tenants"("id");
+ALTER TABLE "execution_chains" ADD CONSTRAINT "fk_execution_chains_tenant" FOREIGN KEY ("tenant_id") REFERENCES "tenants"("id");
@@ -0,0 +1,5 @@
+-- AMQP targets: subscriptions delivered to an exchange of the AMQP broker instead of an HTTP endpoint
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "target_type" varchar(16);
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "amqp_exchange" text;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "amqp_routing_key" text;
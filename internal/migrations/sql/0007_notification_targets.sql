@@ -0,0 +1,4 @@
+-- Notification targets: subscriptions delivering events to Slack, Teams and PagerDuty as rendered messages
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "notification" jsonb;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "pagerduty_routing_key" text;
@@ -0,0 +1,3 @@
+-- Message targets: subscriptions sending events as email or text messages to their recipients
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "recipients" jsonb;
@@ -0,0 +1,17 @@
+-- Batch delivery: subscriptions buffering events and delivering them together as a JSON array
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "batch_max_events" bigint DEFAULT 0;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "batch_window_seconds" bigint DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "batched_deliveries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "subscription_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "tenant_id" text NOT NULL,
+    "payload" jsonb NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_batched_deliveries_subscription_created" ON "batched_deliveries" ("subscription_id","created_at");
+CREATE INDEX IF NOT EXISTS "idx_batched_deliveries_event_id" ON "batched_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_batched_deliveries_tenant_id" ON "batched_deliveries" ("tenant_id");
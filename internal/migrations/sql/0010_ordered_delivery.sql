@@ -0,0 +1,18 @@
+-- Ordered delivery: events sent with an ordering key are delivered to each subscription one at a time, in order
+
+ALTER TABLE "webhook_events" ADD COLUMN IF NOT EXISTS "ordering_key" text;
+
+CREATE TABLE IF NOT EXISTS "ordered_deliveries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "subscription_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "tenant_id" text NOT NULL,
+    "ordering_key" text NOT NULL,
+    "payload" jsonb NOT NULL,
+    "claimed_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_ordered_deliveries_subscription_key_created" ON "ordered_deliveries" ("subscription_id","ordering_key","created_at");
+CREATE INDEX IF NOT EXISTS "idx_ordered_deliveries_event_id" ON "ordered_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_ordered_deliveries_tenant_id" ON "ordered_deliveries" ("tenant_id");
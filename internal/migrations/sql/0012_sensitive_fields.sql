@@ -0,0 +1,3 @@
+-- Sensitive payload fields: JSONPaths redacted or encrypted in a tenant's stored payloads
+
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "sensitive_fields" jsonb;
@@ -0,0 +1,47 @@
+-- Alerting: rules watching the deliveries of a subscription or the runs of a chain, and the history of their
+-- notifications, with the index the chain run measurements read
+
+CREATE TABLE IF NOT EXISTS "alert_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "tenant_id" text NOT NULL,
+    "name" text NOT NULL,
+    "webhook_id" uuid,
+    "chain_id" uuid,
+    "condition" varchar(32) NOT NULL,
+    "threshold" double precision NOT NULL,
+    "window_minutes" bigint,
+    "min_samples" bigint,
+    "notify_webhook_id" uuid NOT NULL,
+    "suppress_minutes" bigint,
+    "silenced_until" timestamptz,
+    "is_active" boolean DEFAULT true,
+    "state" varchar(16) NOT NULL DEFAULT 'ok',
+    "value" double precision,
+    "firing_since" timestamptz,
+    "notified_at" timestamptz,
+    "evaluated_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_alert_rules_tenant_id" ON "alert_rules" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_alert_rules_webhook_id" ON "alert_rules" ("webhook_id");
+CREATE INDEX IF NOT EXISTS "idx_alert_rules_chain_id" ON "alert_rules" ("chain_id");
+
+CREATE TABLE IF NOT EXISTS "alert_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "rule_id" uuid NOT NULL,
+    "tenant_id" text NOT NULL,
+    "rule_name" text,
+    "kind" varchar(16) NOT NULL,
+    "value" double precision,
+    "message" text,
+    "notification" varchar(16) NOT NULL,
+    "error" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_alert_events_rule_id" ON "alert_events" ("rule_id");
+CREATE INDEX IF NOT EXISTS "idx_alert_events_tenant_created" ON "alert_events" ("tenant_id","created_at");
+
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_chain_completed" ON "execution_chain_runs" ("chain_id","completed_at");
@@ -0,0 +1,10 @@
+-- Auto-disable: tenant policy deactivating subscriptions whose deliveries keep failing, and why a subscription was
+-- deactivated
+
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "auto_disable_failures" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "auto_disable_days" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN IF NOT EXISTS "auto_disable_notify_webhook_id" uuid;
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "disabled_at" timestamptz;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "disabled_reason" text;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN IF NOT EXISTS "reenabled_at" timestamptz;
@@ -0,0 +1,15 @@
+-- Delivery pauses: outbound deliveries held back globally or per tenant during maintenance windows, and the
+-- events held while a pause lasts
+
+CREATE TABLE IF NOT EXISTS "delivery_pauses" (
+    "scope" text,
+    "reason" text,
+    "paused_at" timestamptz,
+    "resumed_at" timestamptz,
+    "drain_rate" bigint,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("scope")
+);
+
+ALTER TABLE "webhook_events" ADD COLUMN IF NOT EXISTS "held_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_held_at" ON "webhook_events" ("held_at");
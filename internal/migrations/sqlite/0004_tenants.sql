@@ -0,0 +1,22 @@
+-- Tenants: the tenants subscriptions, events and chains belong to
+-- SQLite databases start out at this schema version, so there are no tenant IDs to backfill, and SQLite cannot add
+-- foreign keys to existing tables, so the tenant references of subscriptions, events and chains are not enforced
+
+CREATE TABLE IF NOT EXISTS "tenants" (
+    "id" text,
+    "name" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'active',
+    "suspended_at" datetime,
+    "suspended_reason" text,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_tenants_status" ON "tenants" ("status");
+CREATE INDEX IF NOT EXISTS "idx_tenants_deleted_at" ON "tenants" ("deleted_at");
+
+ALTER TABLE "tenant_settings" ADD COLUMN "default_max_retries" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "default_retry_delay_seconds" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "rate_limit_requests_per_second" numeric;
+ALTER TABLE "tenant_settings" ADD COLUMN "rate_limit_burst" bigint;
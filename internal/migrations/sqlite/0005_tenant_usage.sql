@@ -0,0 +1,15 @@
+-- Tenant quotas: daily usage counters kept independently of archived events, and quota limits in the tenant settings
+
+CREATE TABLE IF NOT EXISTS "tenant_usage" (
+    "tenant_id" text REFERENCES "tenants"("id"),
+    "day" date,
+    "events" bigint NOT NULL DEFAULT 0,
+    "deliveries" bigint NOT NULL DEFAULT 0,
+    "updated_at" datetime,
+    PRIMARY KEY ("tenant_id", "day")
+);
+
+ALTER TABLE "tenant_settings" ADD COLUMN "quota_events_per_day" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "quota_events_per_month" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "quota_deliveries_per_day" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "quota_deliveries_per_month" bigint;
@@ -0,0 +1,4 @@
+-- Notification targets: subscriptions delivering events to Slack, Teams and PagerDuty as rendered messages
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "notification" text;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "pagerduty_routing_key" text;
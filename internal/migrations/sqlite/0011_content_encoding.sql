@@ -0,0 +1,3 @@
+-- Payload compression: subscriptions receiving their deliveries gzip-compressed
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "content_encoding" varchar(16);
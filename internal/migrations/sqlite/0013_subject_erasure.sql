@@ -0,0 +1,14 @@
+-- Data subject erasure: the path identifying the data subject of a tenant's payloads, and the per subject keys
+-- their sensitive fields are encrypted with, deleted to crypto-shred them when the subject is erased
+
+ALTER TABLE "tenant_settings" ADD COLUMN "subject_path" text;
+
+CREATE TABLE IF NOT EXISTS "subject_keys" (
+    "id" text,
+    "tenant_id" text NOT NULL,
+    "subject_hash" text NOT NULL,
+    "key" text NOT NULL,
+    "created_at" datetime,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_subject_keys_tenant_subject" ON "subject_keys" ("tenant_id","subject_hash");
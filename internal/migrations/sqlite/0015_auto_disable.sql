@@ -0,0 +1,10 @@
+-- Auto-disable: tenant policy deactivating subscriptions whose deliveries keep failing, and why a subscription was
+-- deactivated
+
+ALTER TABLE "tenant_settings" ADD COLUMN "auto_disable_failures" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "auto_disable_days" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "auto_disable_notify_webhook_id" text;
+
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "disabled_at" datetime;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "disabled_reason" text;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "reenabled_at" datetime;
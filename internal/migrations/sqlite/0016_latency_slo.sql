@@ -0,0 +1,6 @@
+-- Latency SLO: end-to-end latency of successful deliveries and the tenant objective reports measure it against
+
+ALTER TABLE "webhook_delivery_attempts" ADD COLUMN "end_to_end_ms" bigint;
+
+ALTER TABLE "tenant_settings" ADD COLUMN "latency_slo_target_ms" bigint;
+ALTER TABLE "tenant_settings" ADD COLUMN "latency_slo_objective" numeric;
@@ -0,0 +1,4 @@
+-- Resource accounting: payload sizes of step runs, summed over their attempts
+
+ALTER TABLE "execution_chain_step_runs" ADD COLUMN "request_bytes" bigint DEFAULT 0;
+ALTER TABLE "execution_chain_step_runs" ADD COLUMN "response_bytes" bigint DEFAULT 0;
@@ -0,0 +1,13 @@
+-- List indexes: composite indexes for the active subscription lookup of every published event and for the
+-- subscription, chain and run lists, ordered newest first and paged by (created_at, id)
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_tenant_event_active" ON "webhook_subscriptions" ("tenant_id","subscribed_event","is_active");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_tenant_created" ON "webhook_subscriptions" ("tenant_id","created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chains_tenant_created" ON "execution_chains" ("tenant_id","created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_chain_created" ON "execution_chain_runs" ("chain_id","created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_step_runs_run_order" ON "execution_chain_step_runs" ("run_id","step_order");
+
+-- The composite indexes start with the columns of these, which serve no query they don't
+DROP INDEX IF EXISTS "idx_webhook_subscriptions_tenant_id";
+DROP INDEX IF EXISTS "idx_execution_chains_tenant_id";
+DROP INDEX IF EXISTS "idx_execution_chain_step_runs_run_id";
@@ -0,0 +1,9 @@
+-- Event and run list indexes: the cross-tenant event and chain run listings are filtered by tenant, ordered by
+-- creation time and paged by (created_at, id), as the subscription, chain and run lists are since 0018
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_tenant_created" ON "webhook_events" ("tenant_id","created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_execution_chain_runs_tenant_created" ON "execution_chain_runs" ("tenant_id","created_at","id");
+
+-- The composite indexes start with the columns of these, which serve no query they don't
+DROP INDEX IF EXISTS "idx_webhook_events_tenant_id";
+DROP INDEX IF EXISTS "idx_execution_chain_runs_tenant_id";
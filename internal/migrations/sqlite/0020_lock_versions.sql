@@ -0,0 +1,5 @@
+-- Optimistic locking: chains and subscriptions count their changes, so updates based on a copy read before
+-- another change fail instead of overwriting it
+
+ALTER TABLE "execution_chains" ADD COLUMN "lock_version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "webhook_subscriptions" ADD COLUMN "lock_version" bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,7 @@
+-- Event expiry: events and their held back deliveries carry the event's expiry, after which deliveries are dropped
+-- rather than sent
+
+ALTER TABLE "webhook_events" ADD COLUMN "expires_at" datetime;
+ALTER TABLE "queued_deliveries" ADD COLUMN "expires_at" datetime;
+ALTER TABLE "ordered_deliveries" ADD COLUMN "expires_at" datetime;
+ALTER TABLE "batched_deliveries" ADD COLUMN "expires_at" datetime;
@@ -0,0 +1,3 @@
+-- Event priorities: due scheduled events are dispatched highest priority first
+
+ALTER TABLE "webhook_events" ADD COLUMN "priority" bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertCondition selects what an alert rule measures and how its threshold is read
+type AlertCondition string
+
+const (
+	// AlertConditionFailureRate fires when more than Threshold percent of the deliveries or runs finished
+	// within the window failed
+	AlertConditionFailureRate AlertCondition = "failure_rate"
+
+	// AlertConditionConsecutiveFailures fires when the last Threshold deliveries or runs all failed
+	AlertConditionConsecutiveFailures AlertCondition = "consecutive_failures"
+
+	// AlertConditionRunDuration fires when a chain run finished within the window, or still running, took
+	// longer than Threshold seconds
+	AlertConditionRunDuration AlertCondition = "run_duration"
+)
+
+// IsValid reports whether the condition is known
+func (c AlertCondition) IsValid() bool {
+	switch c {
+	case AlertConditionFailureRate, AlertConditionConsecutiveFailures, AlertConditionRunDuration:
+		return true
+	}
+	return false
+}
+
+// AlertState is whether an alert rule's condition held when it was last evaluated
+type AlertState string
+
+const (
+	// AlertStateOK indicates the condition did not hold
+	AlertStateOK AlertState = "ok"
+
+	// AlertStateFiring indicates the condition held
+	AlertStateFiring AlertState = "firing"
+)
+
+// AlertRule watches the deliveries of one subscription or the runs of one chain, and notifies a
+// subscription of the tenant, such as a Slack or email target, when its condition starts or stops holding
+type AlertRule struct {
+	// ID is the unique identifier for this rule
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// TenantID identifies the tenant that owns the rule
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// Name describes the rule in notifications, e.g. "Payment webhook failing"
+	Name string `json:"name" gorm:"not null"`
+
+	// WebhookID is the subscription whose deliveries are watched; either it or ChainID is set
+	WebhookID *uuid.UUID `json:"webhook_id,omitempty" gorm:"type:uuid;index"`
+
+	// ChainID is the execution chain whose runs are watched; either it or WebhookID is set
+	ChainID *uuid.UUID `json:"chain_id,omitempty" gorm:"type:uuid;index"`
+
+	// Condition selects what is measured
+	Condition AlertCondition `json:"condition" gorm:"type:varchar(32);not null"`
+
+	// Threshold is a percentage for failure_rate, a count for consecutive_failures and seconds for run_duration
+	Threshold float64 `json:"threshold" gorm:"not null"`
+
+	// WindowMinutes is how far back failure_rate and run_duration look
+	WindowMinutes int `json:"window_minutes"`
+
+	// MinSamples is how many deliveries or runs must have finished within the window before failure_rate fires
+	MinSamples int `json:"min_samples"`
+
+	// NotifyWebhookID is the subscription notifications are delivered to
+	NotifyWebhookID uuid.UUID `json:"notify_webhook_id" gorm:"type:uuid;not null"`
+
+	// SuppressMinutes is how long notifications are not repeated while the rule keeps firing
+	SuppressMinutes int `json:"suppress_minutes"`
+
+	// SilencedUntil suppresses every notification of the rule until then, e.g. during maintenance;
+	// the rule is still evaluated and its history recorded
+	SilencedUntil *time.Time `json:"silenced_until,omitempty"`
+
+	// IsActive controls whether the rule is evaluated
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// State is whether the condition held at the last evaluation
+	State AlertState `json:"state" gorm:"type:varchar(16);not null;default:'ok'"`
+
+	// Value is what the condition measured at the last evaluation
+	Value float64 `json:"value"`
+
+	// FiringSince timestamp when the rule started firing, nil while it is not
+	FiringSince *time.Time `json:"firing_since,omitempty"`
+
+	// NotifiedAt timestamp when the last notification was delivered or attempted
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+
+	// EvaluatedAt timestamp of the last evaluation
+	EvaluatedAt *time.Time `json:"evaluated_at,omitempty"`
+
+	// CreatedAt timestamp when the rule was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt timestamp when the rule was last changed through the API
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName sets the table name for AlertRule
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
+// AlertEventKind describes what an alert history entry records
+type AlertEventKind string
+
+const (
+	// AlertEventFired records a rule starting to fire
+	AlertEventFired AlertEventKind = "fired"
+
+	// AlertEventRepeated records a notification repeated for a rule still firing after its suppression window
+	AlertEventRepeated AlertEventKind = "repeated"
+
+	// AlertEventResolved records a rule no longer firing
+	AlertEventResolved AlertEventKind = "resolved"
+)
+
+// AlertNotificationStatus is what became of the notification of an alert history entry
+type AlertNotificationStatus string
+
+const (
+	// AlertNotificationSent indicates the notification subscription accepted the notification
+	AlertNotificationSent AlertNotificationStatus = "sent"
+
+	// AlertNotificationFailed indicates delivering the notification failed; it is not retried
+	AlertNotificationFailed AlertNotificationStatus = "failed"
+
+	// AlertNotificationSuppressed indicates the rule was silenced, so no notification was sent
+	AlertNotificationSuppressed AlertNotificationStatus = "suppressed"
+)
+
+// AlertEvent is an entry of the alert history: a rule firing, repeating its notification or resolving
+type AlertEvent struct {
+	// ID is the unique identifier for this entry
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// RuleID references the rule; entries are kept when the rule is deleted
+	RuleID uuid.UUID `json:"rule_id" gorm:"type:uuid;not null;index"`
+
+	// TenantID identifies the tenant that owns the rule
+	TenantID string `json:"tenant_id" gorm:"not null;index:idx_alert_events_tenant_created,priority:1"`
+
+	// RuleName is the rule's name when the entry was recorded
+	RuleName string `json:"rule_name"`
+
+	// Kind describes what the entry records
+	Kind AlertEventKind `json:"kind" gorm:"type:varchar(16);not null"`
+
+	// Value is what the rule's condition measured
+	Value float64 `json:"value"`
+
+	// Message describes the measurement, e.g. "12.5% of 40 deliveries failed in the last 15m"
+	Message string `json:"message"`
+
+	// Notification is what became of the entry's notification
+	Notification AlertNotificationStatus `json:"notification" gorm:"type:varchar(16);not null"`
+
+	// Error is why delivering the notification failed
+	Error *string `json:"error,omitempty"`
+
+	// CreatedAt timestamp when the entry was recorded
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_alert_events_tenant_created,priority:2"`
+}
+
+// TableName sets the table name for AlertEvent
+func (AlertEvent) TableName() string {
+	return "alert_events"
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBatchEvents caps the events delivered in one batch, and is the batch size of subscriptions without one
+const MaxBatchEvents = 1000
+
+// MaxBatchWindow caps how long buffered events wait for their batch to fill up
+const MaxBatchWindow = time.Hour
+
+// BatchedDelivery represents an event delivery buffered for its subscription's next batch
+// Buffered deliveries are sent in creation order, as the items of the batch's JSON array
+type BatchedDelivery struct {
+	// ID is the unique identifier for this buffered delivery
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// SubscriptionID references the batching subscription the delivery is for
+	SubscriptionID uuid.UUID `json:"subscription_id" gorm:"type:uuid;not null;index:idx_batched_deliveries_subscription_created,priority:1"`
+
+	// EventID references the event being delivered
+	EventID uuid.UUID `json:"event_id" gorm:"type:uuid;not null;index"`
+
+	// TenantID identifies the tenant that sent the event
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// ExpiresAt is the expiry of the event; the delivery is dropped instead of sent once it passes
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Payload is the subscription-specific payload, delivered as an item of the batch
+	Payload string `json:"payload" gorm:"type:jsonb;not null"`
+
+	// CreatedAt timestamp when the delivery was buffered
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_batched_deliveries_subscription_created,priority:2"`
+}
+
+// TableName sets the table name for BatchedDelivery
+func (BatchedDelivery) TableName() string {
+	return "batched_deliveries"
+}
+
+// BatchDeliveryReport is what a receiver may answer a batch with to report the events it did not accept
+// The other events of the batch count as delivered; a non-2xx answer fails the whole batch
+type BatchDeliveryReport struct {
+	// Failed lists the events of the batch the receiver rejected
+	Failed []BatchItemFailure `json:"failed"`
+}
+
+// BatchItemFailure is an event a receiver rejected in a batch
+type BatchItemFailure struct {
+	// EventID is the event_id of the rejected item
+	EventID uuid.UUID `json:"event_id"`
+
+	// Error is the receiver's reason, optional
+	Error string `json:"error,omitempty"`
+}
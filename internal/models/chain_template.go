@@ -0,0 +1,110 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ChainTemplateVersion is the version of the chain template format
+const ChainTemplateVersion = 1
+
+// ChainTemplate is a portable chain definition: steps name the webhooks they call by app name instead of
+// by the subscription IDs of one tenant, so the chain can be recreated for any tenant that has such webhooks
+// Returned by GET /api/execution-chains/:id/export and the template catalog, and accepted by
+// POST /api/execution-chains/import, as JSON or YAML
+type ChainTemplate struct {
+	// Version is the version of the template format, ChainTemplateVersion
+	Version int `json:"version"`
+
+	// ID identifies catalog templates; empty for exported chains
+	ID string `json:"id,omitempty"`
+
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	TriggerEvent string `json:"trigger_event"`
+	ChainScheduleRequest
+
+	// CompletionWebhook receives a summary of every finished run
+	CompletionWebhook *TemplateWebhook `json:"completion_webhook,omitempty"`
+
+	Steps []ChainTemplateStep `json:"steps"`
+}
+
+// TemplateWebhook names the webhook subscription a template calls by its app name, and by its subscribed
+// event when the tenant has several subscriptions of the app
+type TemplateWebhook struct {
+	AppName         string `json:"app_name"`
+	SubscribedEvent string `json:"subscribed_event,omitempty"`
+}
+
+// Ref returns how the webhook is named in InstantiateChainTemplateRequest.Webhooks: the app name, followed
+// by "/" and the subscribed event when one is set
+func (w TemplateWebhook) Ref() string {
+	if w.SubscribedEvent == "" {
+		return w.AppName
+	}
+	return w.AppName + "/" + w.SubscribedEvent
+}
+
+// ChainTemplateStep is a step of a chain template; see CreateExecutionChainStep for the fields
+type ChainTemplateStep struct {
+	// Webhook is the subscription webhook and approval steps call
+	Webhook *TemplateWebhook `json:"webhook,omitempty"`
+
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description,omitempty"`
+	Type            StepType               `json:"type,omitempty"`
+	RequestParams   map[string]interface{} `json:"request_params,omitempty"`
+	ResponseSchema  map[string]interface{} `json:"response_schema,omitempty"`
+	Condition       string                 `json:"condition,omitempty"`
+	ParallelGroup   string                 `json:"parallel_group,omitempty"`
+	Key             string                 `json:"key,omitempty"`
+	DependsOn       []string               `json:"depends_on,omitempty"`
+	Branches        []StepBranch           `json:"branches,omitempty"`
+	BranchPath      string                 `json:"branch_path,omitempty"`
+	Extract         map[string]string      `json:"extract,omitempty"`
+	OnSuccessAction string                 `json:"on_success_action,omitempty"`
+	OnFailureAction string                 `json:"on_failure_action,omitempty"`
+	MaxRetries      int                    `json:"max_retries,omitempty"`
+	DelaySeconds    int                    `json:"delay_seconds,omitempty"`
+
+	// Compensation is the webhook that undoes the step when a later step fails the run
+	Compensation *TemplateCompensation `json:"compensation,omitempty"`
+}
+
+// TemplateCompensation is the compensation of a chain template step
+type TemplateCompensation struct {
+	Webhook       TemplateWebhook        `json:"webhook"`
+	RequestParams map[string]interface{} `json:"request_params,omitempty"`
+}
+
+// InstantiateChainTemplateRequest creates a chain from a catalog template
+type InstantiateChainTemplateRequest struct {
+	// TenantID is the tenant the chain is created for
+	TenantID string `json:"tenant_id" binding:"required"`
+
+	// Name and TriggerEvent replace the template's when set
+	Name         string `json:"name,omitempty"`
+	TriggerEvent string `json:"trigger_event,omitempty"`
+
+	// Webhooks maps the template's webhook refs (see TemplateWebhook.Ref) to subscriptions of the tenant,
+	// for webhooks whose app names differ from the template's
+	Webhooks map[string]uuid.UUID `json:"webhooks,omitempty"`
+}
+
+// ChainTemplateSummary describes a catalog template
+type ChainTemplateSummary struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	TriggerEvent string `json:"trigger_event"`
+	Schedule     string `json:"schedule,omitempty"`
+	StepsCount   int    `json:"steps_count"`
+
+	// Webhooks are the refs of the webhooks the template calls, which the tenant needs or maps on instantiation
+	Webhooks []string `json:"webhooks"`
+}
+
+// ChainTemplateListResponse lists the template catalog
+type ChainTemplateListResponse struct {
+	Templates []ChainTemplateSummary `json:"templates"`
+}
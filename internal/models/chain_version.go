@@ -0,0 +1,65 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChainVersionStep pins a step definition to its position within a chain version
+// Step definitions are never modified or deleted, only retired, so the step ID identifies the
+// exact definition; order and dependencies are kept here because they change between versions
+type ChainVersionStep struct {
+	// StepID references the step definition
+	StepID uuid.UUID `json:"step_id"`
+
+	// StepOrder is the position of the step in this version, starting from 1
+	StepOrder int `json:"step_order"`
+
+	// DependsOn lists the IDs of the steps of this version that must finish first
+	DependsOn []uuid.UUID `json:"depends_on,omitempty"`
+}
+
+// PinVersionSteps pins the given steps, ordered by step order, as the steps of a chain version
+func PinVersionSteps(steps []ExecutionChainStep) []ChainVersionStep {
+	pinned := make([]ChainVersionStep, len(steps))
+	for i, step := range steps {
+		pinned[i] = ChainVersionStep{
+			StepID:    step.ID,
+			StepOrder: step.StepOrder,
+			DependsOn: step.DependsOn,
+		}
+	}
+	sort.SliceStable(pinned, func(i, j int) bool { return pinned[i].StepOrder < pinned[j].StepOrder })
+	return pinned
+}
+
+// ExecutionChainVersion represents an immutable snapshot of the steps of an execution chain in the database
+// A version is recorded whenever the steps of a chain change; runs are pinned to the version they
+// started on, so in-flight and historical runs keep executing and referencing the steps they began with
+type ExecutionChainVersion struct {
+	// ID is the unique identifier for this version
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ChainID links this version to its execution chain
+	ChainID uuid.UUID `json:"chain_id" gorm:"type:uuid;not null;uniqueIndex:idx_chain_version"`
+
+	// Version numbers the versions of a chain, starting from 1
+	Version int `json:"version" gorm:"not null;uniqueIndex:idx_chain_version"`
+
+	// Steps lists the steps of this version in execution order
+	Steps []ChainVersionStep `json:"steps" gorm:"type:jsonb;serializer:json;not null"`
+
+	// RolledBackFrom is the earlier version whose steps were restored to create this version
+	// Only set for versions created by a rollback
+	RolledBackFrom *int `json:"rolled_back_from,omitempty"`
+
+	// CreatedAt timestamp when the version was recorded
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName sets the table name for ExecutionChainVersion
+func (ExecutionChainVersion) TableName() string {
+	return "execution_chain_versions"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErasedValue replaces what an erasure removes from stored payloads, and values encrypted with the key of an
+// erased data subject when they are read
+const ErasedValue = "[ERASED]"
+
+// MinSubjectKeyLength is the shortest subject identifier an erasure accepts, so a typo such as "1" does not
+// erase every payload mentioning it
+const MinSubjectKeyLength = 3
+
+// SubjectKey is the encryption key of one data subject of a tenant, the person a payload is about
+// Sensitive fields to encrypt in payloads identifying a subject at the tenant's subject path are encrypted
+// with the subject's key; erasing the subject deletes the key, crypto-shredding every copy of those fields
+type SubjectKey struct {
+	// ID is the unique identifier of the key, stored with every value encrypted with it
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// TenantID identifies the tenant the subject belongs to
+	TenantID string `json:"tenant_id" gorm:"not null;uniqueIndex:idx_subject_keys_tenant_subject,priority:1"`
+
+	// SubjectHash is the hex encoded SHA-256 of the subject identifier, so keys are found without storing it
+	SubjectHash string `json:"subject_hash" gorm:"not null;uniqueIndex:idx_subject_keys_tenant_subject,priority:2"`
+
+	// Key is the base64 encoded 32 byte AES key; never serialized and encrypted at rest
+	Key string `json:"-" gorm:"not null;serializer:encrypted"`
+
+	// CreatedAt timestamp when the first payload of the subject was protected
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName sets the table name for SubjectKey
+func (SubjectKey) TableName() string {
+	return "subject_keys"
+}
+
+// ErasureReport records what an erasure removed of a data subject from a tenant's stored data
+type ErasureReport struct {
+	// ID is the unique identifier of the erasure
+	ID uuid.UUID `json:"id"`
+
+	// TenantID identifies the tenant the subject was erased from
+	TenantID string `json:"tenant_id"`
+
+	// SubjectHash is the hex encoded SHA-256 of the subject identifier, so the report does not repeat it
+	SubjectHash string `json:"subject_hash"`
+
+	// RowsScrubbed counts the rows of each table whose payloads, answers or errors mentioned the subject
+	// and were rewritten
+	RowsScrubbed map[string]int64 `json:"rows_scrubbed"`
+
+	// TotalRowsScrubbed is the sum of RowsScrubbed
+	TotalRowsScrubbed int64 `json:"total_rows_scrubbed"`
+
+	// SubjectKeyShredded reports whether the subject had a key that was deleted, so the sensitive fields
+	// encrypted with it can no longer be decrypted wherever they are stored
+	SubjectKeyShredded bool `json:"subject_key_shredded"`
+
+	// ErasedAt timestamp when the erasure completed
+	ErasedAt time.Time `json:"erased_at"`
+}
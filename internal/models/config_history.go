@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConfigResourceType identifies the kind of resource a configuration snapshot belongs to
+type ConfigResourceType string
+
+const (
+	// ConfigResourceSubscription marks snapshots of webhook subscriptions
+	ConfigResourceSubscription ConfigResourceType = "subscription"
+
+	// ConfigResourceChain marks snapshots of execution chains, including their steps
+	ConfigResourceChain ConfigResourceType = "chain"
+)
+
+// ConfigChange describes what happened to a resource when a snapshot was recorded
+type ConfigChange string
+
+const (
+	// ConfigChangeCreated marks the snapshot taken when the resource was created
+	ConfigChangeCreated ConfigChange = "created"
+
+	// ConfigChangeUpdated marks a snapshot taken after the resource was modified
+	ConfigChangeUpdated ConfigChange = "updated"
+
+	// ConfigChangeDeleted marks the last configuration of a deleted resource
+	ConfigChangeDeleted ConfigChange = "deleted"
+
+	// ConfigChangeRestored marks a deleted resource that was restored
+	ConfigChangeRestored ConfigChange = "restored"
+
+	// ConfigChangePaused marks a subscription paused at the receiver's request
+	ConfigChangePaused ConfigChange = "paused"
+
+	// ConfigChangeResumed marks a subscription whose receiver-requested pause ended
+	ConfigChangeResumed ConfigChange = "resumed"
+
+	// ConfigChangeDisabled marks a subscription deactivated by its tenant's auto-disable policy
+	ConfigChangeDisabled ConfigChange = "disabled"
+
+	// ConfigChangeEnabled marks a deactivated subscription that was enabled again
+	ConfigChangeEnabled ConfigChange = "enabled"
+)
+
+// ConfigSnapshot represents a versioned configuration snapshot of a subscription or chain in the database
+// A snapshot is recorded whenever the resource is created, modified or deleted, so changes in delivery
+// behavior can be tied to the configuration change that caused them
+type ConfigSnapshot struct {
+	// ID is the unique identifier for this snapshot
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// TenantID identifies the tenant that owns the resource
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// ResourceType is the kind of resource, "subscription" or "chain"
+	ResourceType ConfigResourceType `json:"resource_type" gorm:"not null;uniqueIndex:idx_config_snapshot_version"`
+
+	// ResourceID is the ID of the subscription or chain
+	ResourceID uuid.UUID `json:"resource_id" gorm:"type:uuid;not null;uniqueIndex:idx_config_snapshot_version"`
+
+	// Version numbers the snapshots of a resource, starting from 1
+	Version int `json:"version" gorm:"not null;uniqueIndex:idx_config_snapshot_version"`
+
+	// Change records whether the resource was created, updated or deleted
+	Change ConfigChange `json:"change" gorm:"not null"`
+
+	// Config is the resource configuration as JSON, without secrets and runtime state
+	Config string `json:"config" gorm:"type:jsonb;not null"`
+
+	// CreatedAt timestamp when the snapshot was recorded
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName sets the table name for ConfigSnapshot
+func (ConfigSnapshot) TableName() string {
+	return "config_snapshots"
+}
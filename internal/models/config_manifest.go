@@ -0,0 +1,116 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ConfigManifestVersion is the version of the declarative configuration manifest format
+const ConfigManifestVersion = 1
+
+// ConfigManifest declares every webhook subscription and execution chain a tenant should have
+// POST /api/config/apply reconciles the tenant with it: missing resources are created, differing ones
+// updated and resources the manifest does not list deleted, so the configuration can live in version control
+// Accepted as JSON or YAML
+type ConfigManifest struct {
+	// Version is the version of the manifest format, ConfigManifestVersion
+	Version int `json:"version"`
+
+	// TenantID is the tenant the manifest describes
+	TenantID string `json:"tenant_id"`
+
+	// Subscriptions are identified by their subscribed event, target type and target, as on import
+	// Generated receive endpoints are not managed by manifests and are left as they are
+	Subscriptions []ExportedSubscription `json:"subscriptions"`
+
+	// Chains are identified by name; their steps name webhooks as chain templates do
+	Chains []ChainTemplate `json:"chains"`
+}
+
+// ConfigApplyOptions controls how POST /api/config/apply applies a manifest
+type ConfigApplyOptions struct {
+	// TenantID is the tenant the manifest is applied to; empty uses the manifest's tenant
+	TenantID string
+
+	// DryRun plans the changes without applying them
+	DryRun bool
+}
+
+// ConfigAction is what applying a manifest does to one resource
+type ConfigAction string
+
+const (
+	// ConfigActionCreate creates a resource the tenant does not have
+	ConfigActionCreate ConfigAction = "create"
+
+	// ConfigActionUpdate changes a resource that differs from the manifest
+	ConfigActionUpdate ConfigAction = "update"
+
+	// ConfigActionDelete deletes a resource the manifest does not list
+	ConfigActionDelete ConfigAction = "delete"
+
+	// ConfigActionUnchanged leaves a resource that matches the manifest as it is
+	ConfigActionUnchanged ConfigAction = "unchanged"
+
+	// ConfigActionInvalid marks a manifest entry that failed validation; nothing is applied then
+	ConfigActionInvalid ConfigAction = "invalid"
+)
+
+// ConfigResourceChange is the planned or applied change of one subscription or chain
+type ConfigResourceChange struct {
+	ResourceType ConfigResourceType `json:"resource_type"`
+	Action       ConfigAction       `json:"action"`
+
+	// Index is the position of the resource in the manifest's subscriptions or chains, from 0; nil for deletions
+	Index *int `json:"index,omitempty"`
+
+	// ResourceID is the ID of the resource; for creations it is known once the manifest is applied
+	ResourceID *uuid.UUID `json:"resource_id,omitempty"`
+
+	// Name describes the resource: the chain name, or the app name and subscribed event of a subscription
+	Name string `json:"name"`
+
+	// Diff lists the fields an update changes; secrets are listed without their values
+	Diff []ConfigFieldDiff `json:"diff,omitempty"`
+
+	// SecretToken is the generated signing secret of a created subscription whose manifest entry has none,
+	// and JWTToken the new JWT of a private one; only returned when the manifest was applied
+	SecretToken *string `json:"secret_token,omitempty"`
+	JWTToken    *string `json:"jwt_token,omitempty"`
+
+	// Error is why the manifest entry is invalid
+	Error *string `json:"error,omitempty"`
+}
+
+// ConfigApplyResponse is the plan of a manifest, and its outcome when applied
+type ConfigApplyResponse struct {
+	TenantID string `json:"tenant_id"`
+	DryRun   bool   `json:"dry_run"`
+
+	// Applied reports whether the changes were made; false for dry runs and manifests with invalid entries
+	Applied bool `json:"applied"`
+
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+	Invalid   int `json:"invalid"`
+
+	// Changes lists the subscriptions, then the chains, each in manifest order followed by deletions
+	Changes []ConfigResourceChange `json:"changes"`
+}
+
+// Count adds a change to the totals of the response
+func (r *ConfigApplyResponse) Count(action ConfigAction) {
+	switch action {
+	case ConfigActionCreate:
+		r.Created++
+	case ConfigActionUpdate:
+		r.Updated++
+	case ConfigActionDelete:
+		r.Deleted++
+	case ConfigActionUnchanged:
+		r.Unchanged++
+	case ConfigActionInvalid:
+		r.Invalid++
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role defines the access level granted to an API credential
+// Roles are ordered: admin includes publisher rights, publisher includes viewer rights
+type Role string
+
+const (
+	// RoleViewer allows read-only access to the management APIs
+	// Suitable for dashboards and monitoring tools that only issue GET requests
+	RoleViewer Role = "viewer"
+
+	// RolePublisher allows sending events and triggering chains in addition to viewer access
+	// Suitable for producer services that publish events into loki-suite
+	RolePublisher Role = "publisher"
+
+	// RoleAdmin allows managing subscriptions, chains, credentials and tenant settings
+	// Grants every permission of the publisher and viewer roles
+	RoleAdmin Role = "admin"
+)
+
+// roleRank orders roles so that higher roles inherit the permissions of lower ones
+var roleRank = map[Role]int{
+	RoleViewer:    1,
+	RolePublisher: 2,
+	RoleAdmin:     3,
+}
+
+// IsValid reports whether the role is one of the known roles
+func (r Role) IsValid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// Allows reports whether the role grants at least the permissions of the required role
+func (r Role) Allows(required Role) bool {
+	return r.IsValid() && roleRank[r] >= roleRank[required]
+}
+
+// APICredential represents an API key used to authenticate against the management APIs
+// Only a hash of the key is stored; the plain key is returned once when the credential is created
+type APICredential struct {
+	// ID is the unique identifier for this credential
+	// Used as the subject of JWTs issued for the credential
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// TenantID identifies the tenant this credential belongs to
+	// Used for multi-tenancy isolation and access control
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// Name is a human-readable label for the credential
+	// Helps operators identify which service or person uses the key
+	Name string `json:"name" gorm:"not null"`
+
+	// KeyPrefix holds the first characters of the API key
+	// Allows keys to be recognised in listings without exposing the secret
+	KeyPrefix string `json:"key_prefix" gorm:"not null"`
+
+	// KeyHash is the SHA-256 hash of the API key
+	// Hidden from JSON responses, used to look up credentials on each request
+	KeyHash string `json:"-" gorm:"uniqueIndex;not null"`
+
+	// Role determines which management APIs the credential may call
+	// One of viewer, publisher or admin
+	Role Role `json:"role" gorm:"not null"`
+
+	// IsActive controls whether the credential can still authenticate
+	// Revoked credentials are kept for audit purposes with IsActive set to false
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// LastUsedAt timestamp when the credential last authenticated a request
+	// Helps identify stale keys that can be revoked
+	LastUsedAt *time.Time `json:"last_used_at"`
+
+	// CreatedAt timestamp when the credential was first created
+	// Automatically managed by GORM for audit trails
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt timestamp when the credential was last modified
+	// Automatically updated by GORM on any field changes
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Principal describes the authenticated caller of a management API request
+// Built from either an API key or a JWT carrying role claims
+type Principal struct {
+	// CredentialID identifies the credential used to authenticate
+	// Nil for the bootstrap admin key configured through the environment
+	CredentialID uuid.UUID `json:"credential_id"`
+
+	// TenantID is the tenant the caller belongs to
+	// Empty for the bootstrap admin key, which is not bound to a tenant
+	TenantID string `json:"tenant_id"`
+
+	// Role is the access level granted to the caller
+	Role Role `json:"role"`
+}
+
+// TableName sets the table name for APICredential
+func (APICredential) TableName() string {
+	return "api_credentials"
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryErrorClass classifies why a webhook delivery attempt failed
+type DeliveryErrorClass string
+
+const (
+	// DeliveryErrorTimeout means the receiver did not respond before the request timed out
+	DeliveryErrorTimeout DeliveryErrorClass = "timeout"
+
+	// DeliveryErrorConnection means the request could not be sent, e.g. DNS failure or connection refused
+	DeliveryErrorConnection DeliveryErrorClass = "connection"
+
+	// DeliveryErrorClient means the receiver answered with a 4xx status
+	DeliveryErrorClient DeliveryErrorClass = "client_error"
+
+	// DeliveryErrorServer means the receiver answered with a 5xx status
+	DeliveryErrorServer DeliveryErrorClass = "server_error"
+
+	// DeliveryErrorStatus means the receiver answered with a status that is neither 2xx, 4xx nor 5xx
+	DeliveryErrorStatus DeliveryErrorClass = "unexpected_status"
+
+	// DeliveryErrorSchema means the receiver answered 2xx with a body violating the response schema
+	DeliveryErrorSchema DeliveryErrorClass = "schema"
+
+	// DeliveryErrorRequest means the request could not be built or the delivery was cancelled
+	DeliveryErrorRequest DeliveryErrorClass = "request"
+
+	// DeliveryErrorRejected means the AMQP broker returned the message as unroutable or refused it
+	DeliveryErrorRejected DeliveryErrorClass = "rejected"
+)
+
+// WebhookDeliveryAttempt records a single HTTP attempt to deliver an event to a subscription
+// Attempts are kept for delivery statistics; a delivery retried three times records three attempts
+type WebhookDeliveryAttempt struct {
+	// ID is the unique identifier for this attempt
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// TenantID identifies the tenant that owns the subscription
+	TenantID string `json:"tenant_id" gorm:"not null;index:idx_delivery_attempts_tenant_created,priority:1"`
+
+	// WebhookID references the subscription the event was delivered to
+	WebhookID uuid.UUID `json:"webhook_id" gorm:"type:uuid;not null;index:idx_delivery_attempts_webhook_created,priority:1"`
+
+	// EventID references the event being delivered, or is the batch ID for batched deliveries
+	EventID uuid.UUID `json:"event_id" gorm:"type:uuid;not null;index"`
+
+	// Attempt is the 1-based number of the attempt within its delivery
+	Attempt int `json:"attempt"`
+
+	// Final is set on the last attempt of a delivery, whose outcome is the delivery's outcome
+	Final bool `json:"final"`
+
+	// Success is set when the receiver accepted the attempt
+	Success bool `json:"success"`
+
+	// ResponseCode is the HTTP status the receiver answered with, nil when no response was received
+	ResponseCode *int `json:"response_code,omitempty"`
+
+	// ErrorClass classifies the failure, empty for successful attempts
+	ErrorClass DeliveryErrorClass `json:"error_class,omitempty"`
+
+	// Error is the error message of a failed attempt
+	Error *string `json:"error,omitempty"`
+
+	// LatencyMs is the time from sending the request until the response headers arrived or the request failed
+	LatencyMs int64 `json:"latency_ms"`
+
+	// EndToEndMs is the time from the event being accepted until the receiver accepted this attempt, set on
+	// successful attempts of event deliveries only
+	EndToEndMs *int64 `json:"end_to_end_ms,omitempty"`
+
+	// CreatedAt timestamp when the attempt was made
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_delivery_attempts_tenant_created,priority:2;index:idx_delivery_attempts_webhook_created,priority:2"`
+}
+
+// TableName sets the table name for WebhookDeliveryAttempt
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// DeliveryStats aggregates delivery attempts
+// Success rate counts deliveries, i.e. final attempts; latencies cover every attempt that got a response
+type DeliveryStats struct {
+	Attempts     int64    `json:"attempts"`
+	Deliveries   int64    `json:"deliveries"`
+	Delivered    int64    `json:"delivered"`
+	SuccessRate  float64  `json:"success_rate"`
+	P50LatencyMs *float64 `json:"p50_latency_ms"`
+	P95LatencyMs *float64 `json:"p95_latency_ms"`
+}
+
+// DeliveryStatsBucket aggregates the delivery attempts made within one time bucket
+type DeliveryStatsBucket struct {
+	Start time.Time `json:"start"`
+	DeliveryStats
+
+	// FailuresByClass counts the failed attempts of the bucket per error class
+	FailuresByClass map[DeliveryErrorClass]int64 `json:"failures_by_class"`
+}
+
+// DeliveryStatsResponse represents delivery statistics of a webhook or of all webhooks of a tenant
+type DeliveryStatsResponse struct {
+	TenantID  string      `json:"tenant_id"`
+	WebhookID *uuid.UUID  `json:"webhook_id,omitempty"`
+	Window    StatsWindow `json:"window"`
+
+	// Since is the start of the window, nil for all history
+	Since *time.Time `json:"since,omitempty"`
+
+	// BucketSeconds is the length of each bucket
+	BucketSeconds int64 `json:"bucket_seconds"`
+
+	DeliveryStats
+
+	// FailuresByStatusCode counts failed attempts per HTTP status code; attempts without a response are not counted
+	FailuresByStatusCode map[int]int64 `json:"failures_by_status_code"`
+
+	// FailuresByClass counts failed attempts per error class
+	FailuresByClass map[DeliveryErrorClass]int64 `json:"failures_by_class"`
+
+	// Buckets break the window down over time, oldest first; buckets without attempts are omitted
+	Buckets []DeliveryStatsBucket `json:"buckets"`
+}
+
+// LatencySLOStats measures the end-to-end latency of successful deliveries against a latency SLO
+// Percentiles are in milliseconds and nil without deliveries
+type LatencySLOStats struct {
+	Deliveries   int64 `json:"deliveries"`
+	WithinTarget int64 `json:"within_target"`
+
+	// Compliance is the percentage of deliveries within the target, nil without deliveries
+	Compliance *float64 `json:"compliance"`
+
+	// MeetsObjective is set when compliance reaches the objective, or there were no deliveries
+	MeetsObjective bool `json:"meets_objective"`
+
+	P50Ms *float64 `json:"p50_ms"`
+	P95Ms *float64 `json:"p95_ms"`
+	P99Ms *float64 `json:"p99_ms"`
+}
+
+// SubscriptionLatencySLO measures the deliveries to one subscription against the latency SLO
+type SubscriptionLatencySLO struct {
+	WebhookID uuid.UUID `json:"webhook_id"`
+	LatencySLOStats
+}
+
+// LatencySLOReport reports how the deliveries of a tenant, or of one of its webhooks, met the tenant's latency SLO
+type LatencySLOReport struct {
+	TenantID  string      `json:"tenant_id"`
+	WebhookID *uuid.UUID  `json:"webhook_id,omitempty"`
+	Window    StatsWindow `json:"window"`
+
+	// Since is the start of the window, nil for all history
+	Since *time.Time `json:"since,omitempty"`
+
+	// TargetMs and Objective are the tenant's latency SLO, defaults applied
+	TargetMs  int64   `json:"target_ms"`
+	Objective float64 `json:"objective"`
+
+	LatencySLOStats
+
+	// Subscriptions break the report down per subscription, worst compliance first; subscriptions without
+	// deliveries are omitted
+	Subscriptions []SubscriptionLatencySLO `json:"subscriptions"`
+}
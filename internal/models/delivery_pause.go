@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// DeliveryPauseGlobal is the scope of the pause holding back the deliveries of every tenant
+const DeliveryPauseGlobal = "*"
+
+// DefaultDrainRate is the number of held events delivered per second after a pause is resumed without a rate
+const DefaultDrainRate = 10
+
+// MaxDrainRate is the highest drain rate a resume may ask for
+const MaxDrainRate = 1000
+
+// DeliveryPause holds back the outbound deliveries of a tenant, or of every tenant, during a planned
+// maintenance window of the receivers
+// Events sent while the pause lasts are stored as held, pending events; once it is resumed they are
+// delivered at the pause's drain rate and the pause is removed when none are left
+type DeliveryPause struct {
+	// Scope is the ID of the paused tenant, or DeliveryPauseGlobal for every tenant
+	Scope string `json:"scope" gorm:"primaryKey"`
+
+	// Reason describes the maintenance window, shown to whoever looks at the pause
+	Reason string `json:"reason,omitempty"`
+
+	// PausedAt timestamp when deliveries were paused
+	PausedAt time.Time `json:"paused_at"`
+
+	// ResumedAt timestamp when deliveries were resumed; nil while the pause lasts
+	// Held events are drained while it is set
+	ResumedAt *time.Time `json:"resumed_at,omitempty"`
+
+	// DrainRate is the number of held events delivered per second once resumed
+	DrainRate int `json:"drain_rate,omitempty"`
+
+	// UpdatedAt timestamp when the pause was last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Paused reports whether the pause still holds back deliveries
+func (p *DeliveryPause) Paused() bool {
+	return p.ResumedAt == nil
+}
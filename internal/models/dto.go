@@ -14,6 +14,13 @@ type RetryPolicy struct {
 	RetryDelaySeconds int `json:"retry_delay_seconds,omitempty"`
 }
 
+// BatchPolicy opts a subscription into batched delivery: events are buffered and delivered together as a
+// JSON array once the oldest has waited WindowSeconds or MaxEvents are buffered, whichever comes first
+type BatchPolicy struct {
+	MaxEvents     int `json:"max_events,omitempty"` // defaults to MaxBatchEvents
+	WindowSeconds int `json:"window_seconds"`
+}
+
 // GenerateWebhookRequest represents the request to generate a new webhook subscription
 // Used when creating a new webhook endpoint that will receive event notifications
 type GenerateWebhookRequest struct {
@@ -43,6 +50,10 @@ type GenerateWebhookRequest struct {
 
 	// Payload is an optional field for additional data to be sent with the webhook
 	Payload interface{} `json:"payload,omitempty"`
+
+	// AllowedSourceIPs optionally restricts which IPs or CIDR ranges may post to the webhook URL
+	// e.g. ["203.0.113.7", "198.51.100.0/24"]; empty allows every source
+	AllowedSourceIPs []string `json:"allowed_source_ips,omitempty"`
 }
 
 // SubscribeWebhookRequest represents the manual subscription request
@@ -57,8 +68,32 @@ type SubscribeWebhookRequest struct {
 	AppName string `json:"app_name" binding:"required"`
 
 	// TargetURL is the HTTP endpoint where webhook payloads will be delivered
-	// Must be a valid, accessible HTTP/HTTPS URL controlled by the subscriber
-	TargetURL string `json:"target_url" binding:"required"`
+	// Must be a valid, accessible HTTP/HTTPS URL controlled by the subscriber; not used by AMQP, email and
+	// SMS targets and optional for PagerDuty targets, which default to the PagerDuty Events API
+	TargetURL string `json:"target_url" binding:"required_unless=TargetType amqp TargetType pagerduty TargetType email TargetType sms"`
+
+	// TargetType selects how events are delivered: "http" (default), "amqp", "slack", "teams", "pagerduty",
+	// "email", "sms" or the type of another registered delivery transport
+	// AMQP targets are published to AMQPExchange with AMQPRoutingKey on the configured broker; Slack, Teams,
+	// PagerDuty, email and SMS targets receive the event rendered per Notification
+	TargetType TargetType `json:"target_type,omitempty"`
+
+	// Notification describes the messages of Slack, Teams, PagerDuty, email and SMS targets; defaults apply
+	// when omitted
+	Notification *NotificationTemplate `json:"notification,omitempty"`
+
+	// Recipients are the email addresses of email targets or the E.164 phone numbers (e.g. "+14155550123")
+	// of SMS targets; required for both
+	Recipients []string `json:"recipients,omitempty"`
+
+	// PagerDutyRoutingKey is the integration key of the PagerDuty service; required for PagerDuty targets
+	PagerDutyRoutingKey string `json:"pagerduty_routing_key,omitempty"`
+
+	// AMQPExchange is the exchange AMQP targets are published to; empty is the default exchange
+	AMQPExchange string `json:"amqp_exchange,omitempty"`
+
+	// AMQPRoutingKey is the routing key AMQP targets are published with; required for AMQP targets
+	AMQPRoutingKey string `json:"amqp_routing_key,omitempty"`
 
 	// SubscribedEvent specifies which event type should trigger webhook delivery
 	// Filters events to only those matching this subscription
@@ -99,6 +134,27 @@ type SubscribeWebhookRequest struct {
 	// IsPublic indicates whether this subscription is public or private
 	// Public subscriptions use HMAC for verification, private use HMAC+JWT
 	IsPublic bool `json:"is_public" binding:"required"`
+
+	// ResponseSchema is an optional JSON Schema that receiver responses must conform to
+	// Catches receivers that answer 2xx with an error page; violations count as delivery failures
+	ResponseSchema map[string]interface{} `json:"response_schema,omitempty"`
+
+	// SigningHeaders optionally overrides the tenant's signature and metadata header names
+	// Names left empty use the tenant's names or the defaults (X-Shavix-Signature, X-Shavix-Timestamp, X-Shavix-Attempt)
+	SigningHeaders *SigningHeaders `json:"signing_headers,omitempty"`
+
+	// SignatureScheme selects how deliveries are signed: "loki" (default) or "standard_webhooks"
+	// Standard Webhooks deliveries use fixed header names, so SigningHeaders only renames the attempt header
+	SignatureScheme SignatureScheme `json:"signature_scheme,omitempty"`
+
+	// AllowedSourceIPs optionally restricts which IPs or CIDR ranges may post to the subscription's receive endpoint
+	AllowedSourceIPs []string `json:"allowed_source_ips,omitempty"`
+
+	// Batching optionally delivers the subscription's events in batches; only for HTTP targets
+	Batching *BatchPolicy `json:"batching,omitempty"`
+
+	// ContentEncoding optionally compresses deliveries: "gzip"; only for HTTP targets
+	ContentEncoding ContentEncoding `json:"content_encoding,omitempty"`
 }
 
 // SendEventRequest represents the request to send a webhook event
@@ -119,6 +175,27 @@ type SendEventRequest struct {
 	// Payload contains the event data to be delivered to webhook endpoints
 	// Can be any JSON-serializable data structure
 	Payload interface{} `json:"payload" binding:"required"`
+
+	// DeliverAt schedules the event for delivery at a later time instead of delivering it now
+	// Times in the past deliver the event right away; mutually exclusive with DelaySeconds
+	DeliverAt *time.Time `json:"deliver_at,omitempty"`
+
+	// DelaySeconds schedules the event for delivery after the given number of seconds
+	DelaySeconds int `json:"delay_seconds,omitempty"`
+
+	// ExpiresAt is when the event stops being worth delivering, such as a one-time password's expiry
+	// Deliveries not made by then are dropped rather than attempted or retried, the event is marked expired and
+	// its chains are not triggered; must be in the future, and after DeliverAt for scheduled events
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// OrderingKey names the entity the event is about, e.g. "order-123"
+	// Events with the same key are delivered to each subscription one at a time, in the order they were sent
+	OrderingKey string `json:"ordering_key,omitempty" binding:"omitempty,max=255"`
+
+	// Priority is high, normal or low; empty means normal
+	// Higher priority events get delivery slots first, are dispatched first once scheduled and pass their
+	// priority to the chain runs they trigger
+	Priority Priority `json:"priority,omitempty"`
 }
 
 // Response DTOs - Data Transfer Objects for API responses
@@ -155,6 +232,26 @@ type GenerateWebhookResponse struct {
 	// RetryPolicy defines how failed deliveries should be retried
 	// Allows subscribers to specify retry behavior for failed webhook deliveries
 	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
+	// SignatureScheme is how deliveries to a subscribed endpoint are signed
+	SignatureScheme SignatureScheme `json:"signature_scheme,omitempty"`
+
+	// TargetType is how events are delivered to a subscribed endpoint
+	TargetType TargetType `json:"target_type,omitempty"`
+
+	// StandardSecret is SecretToken in the whsec_ form Standard Webhooks libraries expect
+	// Only present for subscriptions using the standard_webhooks signature scheme
+	StandardSecret string `json:"standard_secret,omitempty"`
+
+	// AllowedSourceIPs are the IPs and CIDR ranges allowed to post to the receive endpoint, empty for any
+	AllowedSourceIPs []string `json:"allowed_source_ips,omitempty"`
+}
+
+// EgressIPsResponse represents the addresses webhook deliveries are sent from
+// Receivers add them to their firewall allowlists
+type EgressIPsResponse struct {
+	// EgressIPs are the IPs and CIDR ranges deliveries originate from; empty when none are published
+	EgressIPs []string `json:"egress_ips"`
 }
 
 // WebhookListResponse represents the response for listing webhooks
@@ -175,6 +272,77 @@ type WebhookListResponse struct {
 	// Limit is the maximum number of results per page
 	// Indicates the page size used for this request
 	Limit int `json:"limit"`
+
+	// NextCursor continues the list after this page; empty on the last page
+	// Pass it as the cursor parameter to fetch the next page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListSort is a field lists can be sorted by
+type ListSort string
+
+const (
+	// ListSortCreatedAt sorts by creation time, the default of every list
+	ListSortCreatedAt ListSort = "created_at"
+
+	// ListSortStatus sorts by status, then by creation time
+	ListSortStatus ListSort = "status"
+
+	// ListSortAppName sorts subscriptions by app name, then by creation time
+	ListSortAppName ListSort = "app_name"
+)
+
+// ListOrder is the direction a list is sorted in
+type ListOrder string
+
+const (
+	// ListOrderDesc lists the highest values and newest records first, the default
+	ListOrderDesc ListOrder = "desc"
+
+	// ListOrderAsc lists the lowest values and oldest records first
+	ListOrderAsc ListOrder = "asc"
+)
+
+// ListPage selects a page of a list and its order
+// Page and Limit are parsed leniently by the controllers, so they are not bound from the query
+type ListPage struct {
+	// Page is the 1-based page number, ignored when Cursor is set
+	Page int `form:"-"`
+
+	// Limit is the maximum number of results per page (1-100)
+	Limit int `form:"-"`
+
+	// Cursor is the next_cursor of the previous page; it keeps its sort and order, so they may be omitted
+	// Unlike pages skipped by number, a cursor is found through an index however deep the page is
+	Cursor string `form:"cursor"`
+
+	// Sort is the field the list is sorted by, created_at when empty
+	Sort ListSort `form:"sort" binding:"omitempty,oneof=created_at status app_name"`
+
+	// Order is the direction of the sort, desc when empty
+	Order ListOrder `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// ListFilter narrows a list; empty fields, and fields a list has no column for, are ignored
+type ListFilter struct {
+	// IsActive keeps the active or the inactive subscriptions or chains
+	IsActive *bool `form:"is_active"`
+
+	// Type keeps the subscriptions of a webhook type
+	Type string `form:"type"`
+
+	// Event keeps the records of an event: the subscribed event of subscriptions, the trigger event of chains
+	// and runs, and the name of events
+	Event string `form:"event"`
+
+	// Status keeps the chains, runs or events of a status
+	Status string `form:"status"`
+
+	// CreatedFrom keeps the records created at or after it
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+
+	// CreatedTo keeps the records created before it
+	CreatedTo *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
 }
 
 // Webhook payload sent to external endpoints
@@ -201,24 +369,54 @@ type WebhookPayload struct {
 	// EventID is the unique identifier for this specific event
 	// Used for deduplication and event tracking across systems
 	EventID uuid.UUID `json:"event_id"`
+
+	// OrderingKey is the ordering key the event was sent with, if any
+	// Receivers can rely on events with the same key arriving in order
+	OrderingKey string `json:"ordering_key,omitempty"`
+}
+
+// PayloadReference stands in for the payload of a delivery too large to be sent inline
+// The full delivery is stored in object storage and can be downloaded from URL until ExpiresAt
+type PayloadReference struct {
+	// URL is a presigned URL of the stored delivery
+	URL string `json:"url"`
+
+	// Size is the size of the stored delivery in bytes
+	Size int `json:"size"`
+
+	// SHA256 is the hex SHA-256 digest of the stored delivery, covered by the delivery's signature
+	SHA256 string `json:"sha256"`
+
+	// ExpiresAt is when URL stops working
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // Common response types
 
-// ErrorResponse represents an error response
-// Standardized error format for consistent API error handling
-type ErrorResponse struct {
-	// Error is a machine-readable error code or identifier
-	// Used for programmatic error handling and categorization
-	Error string `json:"error"`
+// Problem represents an error response, an RFC 7807 problem details document
+// Served as application/problem+json by every endpoint of the API
+type Problem struct {
+	// Type identifies the kind of problem; about:blank, as the code identifies the problem
+	Type string `json:"type"`
+
+	// Title is the text of the HTTP status, such as Not Found
+	Title string `json:"title"`
+
+	// Status is the HTTP status code of the response
+	Status int `json:"status"`
 
-	// Message is a human-readable description of the error
-	// Provides additional context and details for debugging
-	Message string `json:"message,omitempty"`
+	// Detail is a human-readable explanation of this occurrence of the problem
+	// Never includes internal errors, which are only logged
+	Detail string `json:"detail,omitempty"`
 
-	// Code is the HTTP status code associated with this error
-	// Redundant with HTTP response code but useful for client-side handling
-	Code int `json:"code,omitempty"`
+	// Instance is the path of the request that failed
+	Instance string `json:"instance,omitempty"`
+
+	// Code is a stable machine-readable error code, such as chain_not_found, for programmatic error handling
+	Code string `json:"code"`
+
+	// RequestID is the ID of the failed request, also sent in X-Request-ID, to find it in the service's logs
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // SuccessResponse represents a success response
@@ -253,22 +451,105 @@ type HealthResponse struct {
 	Timestamp string `json:"timestamp"`
 }
 
+// SandboxResponse is returned by the sandbox receivers under /sandbox
+type SandboxResponse struct {
+	Status    string `json:"status"`               // "ok", or "failed" when /sandbox/flaky fails the request
+	Receiver  string `json:"receiver"`             // success, flaky or slow
+	DelayedMs *int64 `json:"delayed_ms,omitempty"` // how long /sandbox/slow waited before responding
+}
+
+// SandboxEchoResponse is returned by /sandbox/echo and mirrors the request it received
+type SandboxEchoResponse struct {
+	Method  string              `json:"method"`
+	Path    string              `json:"path"`
+	Query   map[string][]string `json:"query,omitempty"`
+	Headers map[string][]string `json:"headers"`
+	Body    interface{}         `json:"body,omitempty"` // the parsed JSON body, or the raw body as a string
+}
+
 // EventProcessingResult represents the result of event processing
 type EventProcessingResult struct {
-	EventID     uuid.UUID               `json:"event_id"`
-	TotalSent   int                     `json:"total_sent"`
-	TotalFailed int                     `json:"total_failed"`
-	Webhooks    []WebhookDeliveryResult `json:"webhooks"`
+	EventID      uuid.UUID               `json:"event_id"`
+	TotalSent    int                     `json:"total_sent"`
+	TotalFailed  int                     `json:"total_failed"`
+	TotalQueued  int                     `json:"total_queued"`
+	TotalExpired int                     `json:"total_expired,omitempty"` // deliveries dropped because the event expired
+	Webhooks     []WebhookDeliveryResult `json:"webhooks"`
+	Scheduled    bool                    `json:"scheduled,omitempty"`  // stored for later delivery, nothing was sent yet
+	DeliverAt    *time.Time              `json:"deliver_at,omitempty"` // when a scheduled event will be delivered
+	Held         bool                    `json:"held,omitempty"`       // stored while deliveries are paused, nothing was sent yet
 }
 
 // WebhookDeliveryResult represents the result of a single webhook delivery
 type WebhookDeliveryResult struct {
-	WebhookID    uuid.UUID `json:"webhook_id"`
-	TargetURL    string    `json:"target_url"`
-	Success      bool      `json:"success"`
-	ResponseCode *int      `json:"response_code,omitempty"`
-	Error        *string   `json:"error,omitempty"`
-	AttemptCount int       `json:"attempt_count"`
+	WebhookID    uuid.UUID  `json:"webhook_id"`
+	TargetURL    string     `json:"target_url"`
+	Success      bool       `json:"success"`
+	ResponseCode *int       `json:"response_code,omitempty"`
+	Error        *string    `json:"error,omitempty"`
+	AttemptCount int        `json:"attempt_count"`
+	Queued       bool       `json:"queued,omitempty"`       // held back while the receiver's pause lasts, or buffered for a batch
+	Batched      bool       `json:"batched,omitempty"`      // buffered for the subscription's next batch
+	PausedUntil  *time.Time `json:"paused_until,omitempty"` // set when deliveries to the receiver are paused
+	Expired      bool       `json:"expired,omitempty"`      // dropped because the event expired before it was delivered
+}
+
+// DeliveryResultMessage is published to the message bus once an event was delivered to its subscriptions
+type DeliveryResultMessage struct {
+	Event        string                  `json:"event"` // always "webhook.event_delivered"
+	EventID      uuid.UUID               `json:"event_id"`
+	TenantID     string                  `json:"tenant_id"`
+	EventName    string                  `json:"event_name"`
+	Source       string                  `json:"source"`
+	Status       WebhookStatus           `json:"status"`
+	TotalSent    int                     `json:"total_sent"`
+	TotalFailed  int                     `json:"total_failed"`
+	TotalQueued  int                     `json:"total_queued"`
+	TotalExpired int                     `json:"total_expired,omitempty"`
+	Webhooks     []WebhookDeliveryResult `json:"webhooks"`
+	Timestamp    string                  `json:"timestamp"`
+}
+
+// TestWebhookRequest represents the optional body of a test delivery to a subscription
+type TestWebhookRequest struct {
+	Event   string                 `json:"event,omitempty"`   // defaults to the subscribed event
+	Payload map[string]interface{} `json:"payload,omitempty"` // defaults to a sample payload
+}
+
+// TestWebhookResponse represents the outcome of a single test delivery to a subscription
+type TestWebhookResponse struct {
+	WebhookID    uuid.UUID         `json:"webhook_id"`
+	TargetURL    string            `json:"target_url"`
+	Success      bool              `json:"success"`
+	ResponseCode *int              `json:"response_code,omitempty"`
+	LatencyMs    int64             `json:"latency_ms"`
+	ResponseBody string            `json:"response_body,omitempty"` // excerpt of the receiver's answer
+	Truncated    bool              `json:"truncated,omitempty"`     // the response body was longer than the excerpt
+	Error        *string           `json:"error,omitempty"`
+	RequestBody  string            `json:"request_body"`    // the exact bytes that were signed and sent
+	Headers      map[string]string `json:"request_headers"` // signing headers sent, to compare with the receiver's computation
+}
+
+// VerifySignatureRequest represents a delivery a receiver wants to check against its subscription's secret
+type VerifySignatureRequest struct {
+	WebhookID uuid.UUID `json:"webhook_id" binding:"required"`
+	Body      string    `json:"body" binding:"required"`      // the raw request body exactly as received
+	Signature string    `json:"signature" binding:"required"` // value of the signature header
+	Timestamp string    `json:"timestamp,omitempty"`          // value of the timestamp header; checked when set
+	MessageID string    `json:"message_id,omitempty"`         // value of webhook-id, for Standard Webhooks subscriptions
+}
+
+// VerifySignatureResponse represents the outcome of a signature check
+type VerifySignatureResponse struct {
+	WebhookID            uuid.UUID `json:"webhook_id"`
+	Valid                bool      `json:"valid"`
+	SignatureValid       bool      `json:"signature_valid"`
+	TimestampValid       *bool     `json:"timestamp_valid,omitempty"`
+	TimestampSkewSeconds *int64    `json:"timestamp_skew_seconds,omitempty"` // positive when the timestamp is in the past
+	SignatureHeader      string    `json:"signature_header"`                 // header names the subscription's deliveries use
+	TimestampHeader      string    `json:"timestamp_header"`
+	Problems             []string  `json:"problems"`
+	Hints                []string  `json:"hints"`
 }
 
 // ===== Execution Chain DTOs =====
@@ -280,18 +561,38 @@ type CreateExecutionChainRequest struct {
 	Description  string                     `json:"description"`
 	TriggerEvent string                     `json:"trigger_event" binding:"required"`
 	Steps        []CreateExecutionChainStep `json:"steps" binding:"required,min=1"`
+	ChainScheduleRequest
+
+	// CompletionWebhookID references a webhook of the tenant that receives a summary of every finished run
+	CompletionWebhookID *uuid.UUID `json:"completion_webhook_id,omitempty"`
 }
 
 // CreateExecutionChainStep represents a step in the chain creation request
 type CreateExecutionChainStep struct {
-	WebhookID       uuid.UUID              `json:"webhook_id" binding:"required"`
+	WebhookID       *uuid.UUID             `json:"webhook_id,omitempty"` // required for webhook and approval steps
 	Name            string                 `json:"name" binding:"required"`
 	Description     string                 `json:"description"`
+	Type            StepType               `json:"type,omitempty"` // webhook (default), approval, delay, transform or branch
 	RequestParams   map[string]interface{} `json:"request_params"`
+	ResponseSchema  map[string]interface{} `json:"response_schema,omitempty"`   // overrides the webhook's schema
+	Condition       string                 `json:"condition,omitempty"`         // e.g. .trigger_data.total > 100
+	ParallelGroup   string                 `json:"parallel_group,omitempty"`    // consecutive steps sharing a group run concurrently
+	Key             string                 `json:"key,omitempty"`               // referenced by depends_on of other steps
+	DependsOn       []string               `json:"depends_on,omitempty"`        // keys of the steps that must finish first
+	Branches        []StepBranch           `json:"branches,omitempty"`          // paths of a branch step
+	BranchPath      string                 `json:"branch_path,omitempty"`       // <branch step key>/<branch name> the step belongs to
+	Extract         map[string]string      `json:"extract,omitempty"`           // run variable name -> path in the step result
 	OnSuccessAction string                 `json:"on_success_action,omitempty"` // continue, stop, pause
 	OnFailureAction string                 `json:"on_failure_action,omitempty"` // continue, stop, retry
 	MaxRetries      int                    `json:"max_retries,omitempty"`
 	DelaySeconds    int                    `json:"delay_seconds,omitempty"`
+	Compensation    *StepCompensation      `json:"compensation,omitempty"` // undoes the step when a later step fails the run
+}
+
+// StepCompensation represents the webhook that undoes a chain step
+type StepCompensation struct {
+	WebhookID     uuid.UUID              `json:"webhook_id" binding:"required"`
+	RequestParams map[string]interface{} `json:"request_params,omitempty"`
 }
 
 // CreateExecutionChainResponse represents the response for chain creation
@@ -308,31 +609,362 @@ type CreateExecutionChainResponse struct {
 type ExecuteChainRequest struct {
 	ChainID     uuid.UUID              `json:"chain_id" binding:"required"`
 	TriggerData map[string]interface{} `json:"trigger_data,omitempty"`
+	CallbackURL string                 `json:"callback_url,omitempty"` // receives the run's summary once it finishes
+	Priority    Priority               `json:"priority,omitempty"`     // high, normal or low; orders the admission queue
+	Options     *ChainExecutionOptions `json:"execution_options,omitempty"`
+}
+
+// ExecuteChainBody represents the body of a manual chain execution; the chain is taken from the path
+type ExecuteChainBody struct {
+	TriggerData map[string]interface{} `json:"trigger_data,omitempty"`
+	CallbackURL string                 `json:"callback_url,omitempty" binding:"omitempty,url"`
+	Priority    Priority               `json:"priority,omitempty"`
+	Options     *ChainExecutionOptions `json:"execution_options,omitempty"`
+}
+
+// ChainExecutionOptions controls how a manual execution runs the chain
+// With dry_run or validation_only set the chain is checked instead of run: no run is recorded and no webhook is called
+type ChainExecutionOptions struct {
+	DryRun             bool                   `json:"dry_run,omitempty"`             // simulate the run: render templates and evaluate conditions
+	ValidationOnly     bool                   `json:"validation_only,omitempty"`     // only check webhooks and template syntax
+	SimulatedResponses map[string]interface{} `json:"simulated_responses,omitempty"` // step_N -> response body assumed for the step in a dry run
+
+	StepsToSkip    []int                             `json:"steps_to_skip,omitempty"`   // orders of the steps not to execute
+	StartAtStep    int                               `json:"start_at_step,omitempty"`   // order of the first step to execute
+	OverrideParams map[string]map[string]interface{} `json:"override_params,omitempty"` // step_N -> params merged over the step's request_params
+	SourceRunID    *uuid.UUID                        `json:"source_run_id,omitempty"`   // earlier run whose step results and trigger data are reused
+}
+
+// ChainDryRunResponse represents the simulated plan of a chain returned by a dry run or validation
+type ChainDryRunResponse struct {
+	ChainID      uuid.UUID              `json:"chain_id"`
+	ChainVersion int                    `json:"chain_version"`
+	Mode         string                 `json:"mode"`  // dry_run or validation_only
+	Valid        bool                   `json:"valid"` // false when any step or target reported an error
+	Steps        []DryRunStep           `json:"steps"`
+	Variables    map[string]interface{} `json:"variables,omitempty"` // run variables at the end of a dry run
+	Completion   *DryRunTarget          `json:"completion_webhook,omitempty"`
+	Errors       []string               `json:"errors,omitempty"`
+}
+
+// DryRunStep represents what a step would do when the chain runs
+type DryRunStep struct {
+	StepOrder       int                    `json:"step_order"`
+	Name            string                 `json:"name"`
+	Type            StepType               `json:"type"`
+	Action          string                 `json:"action"` // call, await_approval, delay, transform, branch, skip, reuse or check
+	SkipReason      string                 `json:"skip_reason,omitempty"`
+	ConditionResult *bool                  `json:"condition_result,omitempty"`
+	Branch          string                 `json:"branch,omitempty"` // path chosen by a branch step
+	RequestParams   map[string]interface{} `json:"request_params,omitempty"`
+	Target          *DryRunTarget          `json:"target,omitempty"`
+	Compensation    *DryRunTarget          `json:"compensation,omitempty"`
+	Note            string                 `json:"note,omitempty"`
+	Errors          []string               `json:"errors,omitempty"`
+}
+
+// DryRunTarget represents the check of a webhook a step or chain would call
+type DryRunTarget struct {
+	WebhookID uuid.UUID `json:"webhook_id"`
+	TargetURL string    `json:"target_url"`
+	Active    bool      `json:"active"`
+	Reachable bool      `json:"reachable"`
+	Error     string    `json:"error,omitempty"`
 }
 
 // ExecuteChainResponse represents the response for chain execution
 type ExecuteChainResponse struct {
-	RunID      uuid.UUID `json:"run_id"`
-	ChainID    uuid.UUID `json:"chain_id"`
-	Status     string    `json:"status"`
-	TotalSteps int       `json:"total_steps"`
-	StartedAt  time.Time `json:"started_at"`
+	RunID         uuid.UUID  `json:"run_id"`
+	ChainID       uuid.UUID  `json:"chain_id"`
+	Status        string     `json:"status"`
+	TotalSteps    int        `json:"total_steps"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	QueuePosition int        `json:"queue_position,omitempty"`
+
+	// CallbackSecret is the HMAC secret the summary sent to the callback URL is signed with
+	// Only returned here; set when the request passed a callback URL
+	CallbackSecret string `json:"callback_secret,omitempty"`
+
+	// RetryOfRunID is the failed run a retry continues
+	RetryOfRunID *uuid.UUID `json:"retry_of_run_id,omitempty"`
 }
 
 // ExecutionChainListResponse represents the response for listing chains
 type ExecutionChainListResponse struct {
-	Chains []ExecutionChain `json:"chains"`
-	Total  int64            `json:"total"`
-	Page   int              `json:"page"`
-	Limit  int              `json:"limit"`
+	Chains     []ExecutionChain `json:"chains"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 }
 
 // ExecutionChainRunsResponse represents the response for listing chain runs
 type ExecutionChainRunsResponse struct {
-	Runs  []ExecutionChainRun `json:"runs"`
-	Total int64               `json:"total"`
-	Page  int                 `json:"page"`
-	Limit int                 `json:"limit"`
+	Runs       []ExecutionChainRun `json:"runs"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// StatsWindow selects how far back aggregated statistics of runs and deliveries reach
+type StatsWindow string
+
+const (
+	// StatsWindowHour covers the last hour
+	StatsWindowHour StatsWindow = "1h"
+
+	// StatsWindowDay covers the last 24 hours
+	StatsWindowDay StatsWindow = "24h"
+
+	// StatsWindowWeek covers the last 7 days
+	StatsWindowWeek StatsWindow = "7d"
+
+	// StatsWindowMonth covers the last 30 days
+	StatsWindowMonth StatsWindow = "30d"
+
+	// StatsWindowAll covers all recorded history
+	StatsWindowAll StatsWindow = "all"
+)
+
+// Duration returns how far back the window reaches, 0 for all runs, and false for an unknown window
+func (w StatsWindow) Duration() (time.Duration, bool) {
+	switch w {
+	case StatsWindowHour:
+		return time.Hour, true
+	case StatsWindowDay:
+		return 24 * time.Hour, true
+	case StatsWindowWeek:
+		return 7 * 24 * time.Hour, true
+	case StatsWindowMonth:
+		return 30 * 24 * time.Hour, true
+	case StatsWindowAll:
+		return 0, true
+	}
+	return 0, false
+}
+
+// BucketSize returns the length of the time buckets a window is broken down into
+func (w StatsWindow) BucketSize() time.Duration {
+	switch w {
+	case StatsWindowHour:
+		return 5 * time.Minute
+	case StatsWindowDay:
+		return time.Hour
+	case StatsWindowWeek:
+		return 6 * time.Hour
+	case StatsWindowMonth:
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// ChainRunStats holds the run counts and durations of a chain aggregated over a window
+// Durations are in milliseconds and measured over completed runs; they are nil when no run completed
+type ChainRunStats struct {
+	TotalRuns     int64    `json:"total_runs"`
+	CompletedRuns int64    `json:"completed_runs"`
+	FailedRuns    int64    `json:"failed_runs"`
+	CancelledRuns int64    `json:"cancelled_runs"`
+	ActiveRuns    int64    `json:"active_runs"`
+	AvgDurationMs *float64 `json:"avg_duration_ms"`
+	P50DurationMs *float64 `json:"p50_duration_ms"`
+	P95DurationMs *float64 `json:"p95_duration_ms"`
+	P99DurationMs *float64 `json:"p99_duration_ms"`
+}
+
+// ChainStepFailures counts the failed step runs of one step of a chain
+type ChainStepFailures struct {
+	StepOrder int    `json:"step_order"`
+	Name      string `json:"name,omitempty"`
+	Failures  int64  `json:"failures"`
+}
+
+// ChainStatsResponse represents the statistics of a chain's runs over a window
+type ChainStatsResponse struct {
+	ChainID uuid.UUID   `json:"chain_id"`
+	Window  StatsWindow `json:"window"`
+
+	// Since is the start of the window, nil for all runs
+	Since *time.Time `json:"since,omitempty"`
+
+	ChainRunStats
+
+	// SuccessRate is the share of completed runs among the runs that completed or failed, 0 when there are none
+	SuccessRate float64 `json:"success_rate"`
+
+	// MostFailingStep is the step with the most failed step runs, nil when no step failed
+	MostFailingStep *ChainStepFailures `json:"most_failing_step,omitempty"`
+}
+
+// ResourceUsage sums the resources used by step runs; skipped steps are not counted
+// Wall time is in milliseconds and covers finished steps, including the time approval steps waited for a decision
+type ResourceUsage struct {
+	Steps         int64 `json:"steps"`
+	Attempts      int64 `json:"attempts"`
+	Retries       int64 `json:"retries"`
+	StepTimeMs    int64 `json:"step_time_ms"`
+	RequestBytes  int64 `json:"request_bytes"`
+	ResponseBytes int64 `json:"response_bytes"`
+}
+
+// StepRunResources is the resources used by one step run
+type StepRunResources struct {
+	StepOrder int           `json:"step_order"`
+	StepName  string        `json:"step_name,omitempty"`
+	Status    WebhookStatus `json:"status"`
+
+	// DurationMs is the wall time from the step starting until it finished, nil while it has not finished
+	DurationMs *int64 `json:"duration_ms"`
+
+	Attempts      int   `json:"attempts"`
+	Retries       int   `json:"retries"`
+	RequestBytes  int64 `json:"request_bytes"`
+	ResponseBytes int64 `json:"response_bytes"`
+}
+
+// RunResources is the resources used by a chain run
+type RunResources struct {
+	// DurationMs is the wall time from the run starting until it finished, nil while it has not finished
+	DurationMs *int64 `json:"duration_ms"`
+
+	ResourceUsage
+
+	// PerformanceBreakdown lists the resources of every step run in step order, skipped steps included
+	PerformanceBreakdown []StepRunResources `json:"performance_breakdown"`
+}
+
+// ChainResourceUsage sums the resources used by the runs of one chain
+type ChainResourceUsage struct {
+	ChainID   uuid.UUID `json:"chain_id"`
+	ChainName string    `json:"chain_name"`
+	Runs      int64     `json:"runs"`
+	ResourceUsage
+}
+
+// ChainUsageResponse represents the resources used by a tenant's chain runs created within a window
+type ChainUsageResponse struct {
+	TenantID string      `json:"tenant_id"`
+	Window   StatsWindow `json:"window"`
+
+	// Since is the start of the window, nil for all runs
+	Since *time.Time `json:"since,omitempty"`
+
+	Runs int64 `json:"runs"`
+	ResourceUsage
+
+	// Chains break the usage down per chain, most step time first; chains without runs are omitted
+	Chains []ChainResourceUsage `json:"chains"`
+}
+
+// CancelChainRunRequest represents the optional body of a chain run cancellation
+type CancelChainRunRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ApprovalDecisionRequest represents the optional body of approving or rejecting a run's approval step
+type ApprovalDecisionRequest struct {
+	Approver string                 `json:"approver,omitempty"`
+	Comment  string                 `json:"comment,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PauseChainRequest represents the optional body of a chain pause
+type PauseChainRequest struct {
+	Reason            string `json:"reason,omitempty"`
+	SuspendQueuedRuns bool   `json:"suspend_queued_runs,omitempty"` // move queued runs to paused instead of keeping them queued
+}
+
+// ChainStateResponse represents the response for pausing or activating a chain
+type ChainStateResponse struct {
+	ChainID       uuid.UUID  `json:"chain_id"`
+	IsActive      bool       `json:"is_active"`
+	PausedAt      *time.Time `json:"paused_at,omitempty"`
+	PauseReason   *string    `json:"pause_reason,omitempty"`
+	QueuedRuns    int        `json:"queued_runs"`
+	SuspendedRuns int        `json:"suspended_runs"`
+	StartedRuns   int        `json:"started_runs"`
+}
+
+// ChainRunControlResponse represents the response for resuming, cancelling, approving or rejecting a chain run
+type ChainRunControlResponse struct {
+	RunID       uuid.UUID `json:"run_id"`
+	ChainID     uuid.UUID `json:"chain_id"`
+	Status      string    `json:"status"`
+	CurrentStep int       `json:"current_step"`
+	TotalSteps  int       `json:"total_steps"`
+}
+
+// ChainRunOutputsResponse represents the aggregated outputs of a chain run
+type ChainRunOutputsResponse struct {
+	RunID       uuid.UUID              `json:"run_id"`
+	ChainID     uuid.UUID              `json:"chain_id"`
+	Status      string                 `json:"status"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	Variables   map[string]interface{} `json:"variables"`
+	Steps       map[string]interface{} `json:"steps"` // step_N -> name, status_code and response of every successful step
+}
+
+// UpdateChainStepsRequest represents the request to replace the steps of a chain
+// Steps are listed in their new execution order
+type UpdateChainStepsRequest struct {
+	Steps []UpdateExecutionChainStep `json:"steps" binding:"required,min=1"`
+
+	// LockVersion is the lock_version of the chain the steps were edited from
+	LockVersion *int64 `json:"lock_version" binding:"required"`
+}
+
+// UpdateExecutionChainStep represents a step in a step update request
+// Steps carrying the ID of a current step whose definition is unchanged keep that step;
+// all other steps are created anew
+type UpdateExecutionChainStep struct {
+	ID *uuid.UUID `json:"id,omitempty"`
+	CreateExecutionChainStep
+}
+
+// UpdateChainStepsResponse represents the response for a chain step update
+type UpdateChainStepsResponse struct {
+	ChainID uuid.UUID            `json:"chain_id"`
+	Steps   []ExecutionChainStep `json:"steps"`
+	Kept    int                  `json:"kept"`
+	Added   int                  `json:"added"`
+	Retired int                  `json:"retired"`
+	Version int                  `json:"version"`
+}
+
+// ChainVersionsResponse represents the step versions of an execution chain
+type ChainVersionsResponse struct {
+	ChainID        uuid.UUID              `json:"chain_id"`
+	CurrentVersion int                    `json:"current_version"`
+	Versions       []ChainVersionResponse `json:"versions"`
+}
+
+// ChainVersionResponse represents one version of a chain with its resolved step definitions
+type ChainVersionResponse struct {
+	Version        int                  `json:"version"`
+	RolledBackFrom *int                 `json:"rolled_back_from,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	Steps          []ExecutionChainStep `json:"steps"`
+}
+
+// ChainScheduleRequest represents the cron schedule of a chain
+// An empty schedule removes the chain's schedule
+type ChainScheduleRequest struct {
+	Schedule         string `json:"schedule,omitempty"`          // cron expression, e.g. "*/15 9-17 * * MON-FRI" or "@daily"
+	ScheduleTimezone string `json:"schedule_timezone,omitempty"` // IANA time zone, defaults to UTC
+	OverlapPolicy    string `json:"overlap_policy,omitempty"`    // skip, queue, allow; defaults to skip
+}
+
+// ChainScheduleResponse represents the schedule of a chain with its upcoming runs
+type ChainScheduleResponse struct {
+	ChainID          uuid.UUID   `json:"chain_id"`
+	Schedule         string      `json:"schedule,omitempty"`
+	ScheduleTimezone string      `json:"schedule_timezone,omitempty"`
+	OverlapPolicy    string      `json:"overlap_policy,omitempty"`
+	IsActive         bool        `json:"is_active"`
+	NextRunAt        *time.Time  `json:"next_run_at,omitempty"`
+	LastScheduledAt  *time.Time  `json:"last_scheduled_at,omitempty"`
+	UpcomingRuns     []time.Time `json:"upcoming_runs"`
 }
 
 // UpdateExecutionChainRequest represents the request to update a chain
@@ -340,4 +972,576 @@ type UpdateExecutionChainRequest struct {
 	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
 	IsActive    *bool   `json:"is_active,omitempty"`
+
+	// CompletionWebhookID replaces the chain's completion webhook; the nil UUID removes it
+	CompletionWebhookID *uuid.UUID `json:"completion_webhook_id,omitempty"`
+
+	// LockVersion is the lock_version of the chain the change was based on; the update fails with a version
+	// conflict once the chain was changed since. Required over HTTP, optional for internal callers
+	LockVersion *int64 `json:"lock_version" binding:"required"`
+}
+
+// ===== Tenant DTOs =====
+
+// TenantChainControlResponse represents the response for pausing or resuming a tenant's chain executions
+type TenantChainControlResponse struct {
+	TenantID       string     `json:"tenant_id"`
+	ChainsPaused   bool       `json:"chains_paused"`
+	ChainsPausedAt *time.Time `json:"chains_paused_at,omitempty"`
+	QueuedRuns     int        `json:"queued_runs"`
+	ResumedRuns    int        `json:"resumed_runs"`
+}
+
+// PauseDeliveriesRequest represents the request for pausing the outbound deliveries of a tenant or of every tenant
+type PauseDeliveriesRequest struct {
+	Reason string `json:"reason,omitempty"` // Maintenance window the deliveries are paused for
+}
+
+// ResumeDeliveriesRequest represents the request for resuming paused deliveries
+type ResumeDeliveriesRequest struct {
+	DrainRate int `json:"drain_rate,omitempty" binding:"min=0"` // Held events delivered per second, DefaultDrainRate if 0
+}
+
+// DeliveryPauseResponse represents whether the deliveries of a tenant, or of every tenant, are paused
+// Draining is set while the events held during a resumed pause are still being delivered
+type DeliveryPauseResponse struct {
+	TenantID   string     `json:"tenant_id,omitempty"`
+	Paused     bool       `json:"paused"`
+	Draining   bool       `json:"draining"`
+	Reason     string     `json:"reason,omitempty"`
+	PausedAt   *time.Time `json:"paused_at,omitempty"`
+	ResumedAt  *time.Time `json:"resumed_at,omitempty"`
+	DrainRate  int        `json:"drain_rate,omitempty"`
+	HeldEvents int64      `json:"held_events"`
+}
+
+// TenantRunLimitRequest represents the request for setting a tenant's chain run concurrency limit
+type TenantRunLimitRequest struct {
+	MaxConcurrentRuns int `json:"max_concurrent_runs" binding:"min=0"`
+}
+
+// TenantRunLimitResponse represents a tenant's chain run concurrency limit and current usage
+type TenantRunLimitResponse struct {
+	TenantID                string `json:"tenant_id"`
+	MaxConcurrentRuns       int    `json:"max_concurrent_runs"`
+	GlobalMaxConcurrentRuns int    `json:"global_max_concurrent_runs"`
+	RunningRuns             int64  `json:"running_runs"`
+	QueuedRuns              int64  `json:"queued_runs"`
+}
+
+// TenantSigningHeadersResponse represents a tenant's signing header overrides and the names they resolve to
+type TenantSigningHeadersResponse struct {
+	TenantID  string         `json:"tenant_id"`
+	Overrides SigningHeaders `json:"overrides"`
+	Effective SigningHeaders `json:"effective"`
+}
+
+// TenantPayloadValidationRequest represents the request for switching a tenant's strict payload validation
+type TenantPayloadValidationRequest struct {
+	Strict bool `json:"strict"`
+}
+
+// TenantPayloadValidationResponse represents a tenant's payload validation mode
+type TenantPayloadValidationResponse struct {
+	TenantID string `json:"tenant_id"`
+	Strict   bool   `json:"strict"`
+}
+
+// TenantRetentionRequest represents the request for setting a tenant's retention period
+type TenantRetentionRequest struct {
+	RetentionDays int `json:"retention_days" binding:"min=0"` // 0 uses the global default
+}
+
+// TenantRetentionResponse represents a tenant's retention period and the period in effect
+type TenantRetentionResponse struct {
+	TenantID             string `json:"tenant_id"`
+	RetentionDays        int    `json:"retention_days"`
+	DefaultRetentionDays int    `json:"default_retention_days"`
+	EffectiveDays        int    `json:"effective_retention_days"` // 0 keeps the tenant's rows forever
+}
+
+// ArchivalRunListResponse represents a page of archival runs, newest first
+type ArchivalRunListResponse struct {
+	Runs  []ArchivalRun `json:"runs"`
+	Total int64         `json:"total"`
+	Page  int           `json:"page"`
+	Limit int           `json:"limit"`
+}
+
+// TenantSigningRequest represents the request for switching a tenant's delivery signing algorithm
+type TenantSigningRequest struct {
+	Algorithm SigningAlgorithm `json:"algorithm" binding:"required"`
+	Rotate    bool             `json:"rotate,omitempty"` // generate a new Ed25519 key even if the tenant has one
+}
+
+// TenantSigningResponse represents a tenant's delivery signing algorithm and current key
+type TenantSigningResponse struct {
+	TenantID  string           `json:"tenant_id"`
+	Algorithm SigningAlgorithm `json:"algorithm"`
+	KeyID     string           `json:"key_id,omitempty"`
+	PublicKey string           `json:"public_key,omitempty"` // base64url, as in the key set
+	KeysURL   string           `json:"keys_url"`
+}
+
+// ErasureResponse represents the signed report of erasing a data subject
+// signature is a JWS in compact serialization whose payload is the report, signed with EdDSA by the tenant's
+// Ed25519 key named in its kid header; verify it with the key set at keys_url
+type ErasureResponse struct {
+	Report    ErasureReport `json:"report"`
+	Signature string        `json:"signature"`
+	KeyID     string        `json:"key_id"`
+	KeysURL   string        `json:"keys_url"`
+}
+
+// SigningKeySet represents the public keys deliveries are signed with, as a JSON Web Key Set (RFC 7517)
+type SigningKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey represents an Ed25519 public key as a JSON Web Key (RFC 8037)
+type JSONWebKey struct {
+	Kty      string `json:"kty"` // always "OKP"
+	Crv      string `json:"crv"` // always "Ed25519"
+	X        string `json:"x"`   // base64url public key
+	Kid      string `json:"kid"`
+	Use      string `json:"use"` // always "sig"
+	Alg      string `json:"alg"` // always "EdDSA"
+	TenantID string `json:"tenant_id"`
+}
+
+// ===== Config History DTOs =====
+
+// ConfigHistoryResponse represents the configuration history of a subscription or chain
+type ConfigHistoryResponse struct {
+	ResourceType ConfigResourceType `json:"resource_type"`
+	ResourceID   uuid.UUID          `json:"resource_id"`
+	Versions     []ConfigVersion    `json:"versions"`
+}
+
+// ConfigVersion represents one configuration snapshot and what changed since the previous version
+type ConfigVersion struct {
+	Version   int                    `json:"version"`
+	Change    ConfigChange           `json:"change"`
+	CreatedAt time.Time              `json:"created_at"`
+	Config    map[string]interface{} `json:"config"`
+	Diff      []ConfigFieldDiff      `json:"diff"`
+}
+
+// ConfigFieldDiff represents a field that differs from the previous configuration version
+type ConfigFieldDiff struct {
+	Path string      `json:"path"` // e.g. steps[1].on_failure_action
+	Op   string      `json:"op"`   // added, removed, changed
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// ===== Event Type DTOs =====
+
+// RegisterEventTypeRequest represents the request to declare an event type
+type RegisterEventTypeRequest struct {
+	TenantID    string                 `json:"tenant_id" binding:"required"`
+	Name        string                 `json:"name" binding:"required"`
+	Description string                 `json:"description"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+
+	// ValidatePayloads makes SendEvent reject payloads violating the schema; requires a schema
+	ValidatePayloads bool `json:"validate_payloads"`
+}
+
+// UpdateEventTypeRequest represents the request to replace an event type's definition
+// The tenant and name cannot change; omitting the schema removes it
+type UpdateEventTypeRequest struct {
+	Description      string                 `json:"description"`
+	Schema           map[string]interface{} `json:"schema,omitempty"`
+	ValidatePayloads bool                   `json:"validate_payloads"`
+}
+
+// EventTypeSummary describes an event known to a tenant: registered, subscribed to or triggering a chain
+type EventTypeSummary struct {
+	Name string `json:"name"`
+
+	// Registered is false for events used by subscriptions or chains without a registered event type
+	Registered       bool                   `json:"registered"`
+	ID               *uuid.UUID             `json:"id,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Schema           map[string]interface{} `json:"schema,omitempty"`
+	ValidatePayloads bool                   `json:"validate_payloads"`
+
+	// Subscribers and Chains count the active subscriptions and active chains the event reaches
+	Subscribers int `json:"subscribers"`
+	Chains      int `json:"chains"`
+}
+
+// EventTypeListResponse represents the event catalog of a tenant, ordered by event name
+type EventTypeListResponse struct {
+	TenantID   string             `json:"tenant_id"`
+	EventTypes []EventTypeSummary `json:"event_types"`
+	Total      int                `json:"total"`
+}
+
+// PayloadValidationErrorResponse represents an event rejected because its payload violates its event type's schema
+type PayloadValidationErrorResponse struct {
+	Problem
+	EventType  string            `json:"event_type"`
+	Violations []SchemaViolation `json:"violations"`
+}
+
+// ===== Credential DTOs =====
+
+// CreateCredentialRequest represents the request to create an API credential
+type CreateCredentialRequest struct {
+	TenantID string `json:"tenant_id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Role     Role   `json:"role" binding:"required"`
+}
+
+// CreateCredentialResponse represents the response for credential creation
+// APIKey is only returned once and cannot be retrieved again
+type CreateCredentialResponse struct {
+	CredentialID uuid.UUID `json:"credential_id"`
+	TenantID     string    `json:"tenant_id"`
+	Name         string    `json:"name"`
+	Role         Role      `json:"role"`
+	APIKey       string    `json:"api_key"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CredentialListResponse represents the response for listing credentials
+type CredentialListResponse struct {
+	Credentials []APICredential `json:"credentials"`
+	Total       int64           `json:"total"`
+	Page        int             `json:"page"`
+	Limit       int             `json:"limit"`
+}
+
+// IssueTokenRequest represents the request to issue a JWT for a credential
+type IssueTokenRequest struct {
+	TTLSeconds int `json:"ttl_seconds,omitempty"`
+}
+
+// IssueTokenResponse represents a JWT carrying the role claims of a credential
+type IssueTokenResponse struct {
+	Token     string    `json:"token"`
+	TenantID  string    `json:"tenant_id"`
+	Role      Role      `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ===== Admin DTOs =====
+
+// AdminListFilter narrows cross-tenant listings for operators
+// Empty fields are ignored so the zero value lists everything
+type AdminListFilter struct {
+	TenantID string `form:"tenant_id"`
+	ListFilter
+}
+
+// AdminEventListResponse represents the response for listing webhook events across tenants
+type AdminEventListResponse struct {
+	Events     []WebhookEvent `json:"events"`
+	Total      int64          `json:"total"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// TenantStats aggregates the resources owned by a single tenant for capacity planning
+type TenantStats struct {
+	TenantID            string `json:"tenant_id"`
+	Subscriptions       int64  `json:"subscriptions"`
+	ActiveSubscriptions int64  `json:"active_subscriptions"`
+	Events              int64  `json:"events"`
+	FailedEvents        int64  `json:"failed_events"`
+	Chains              int64  `json:"chains"`
+	ActiveChains        int64  `json:"active_chains"`
+	ChainRuns           int64  `json:"chain_runs"`
+	FailedChainRuns     int64  `json:"failed_chain_runs"`
+}
+
+// TenantStatsResponse represents the response for per-tenant aggregate statistics
+type TenantStatsResponse struct {
+	Tenants      []TenantStats `json:"tenants"`
+	TotalTenants int           `json:"total_tenants"`
+}
+
+// PurgeRequest represents the request for permanently deleting soft deleted subscriptions and chains
+type PurgeRequest struct {
+	OlderThanDays int `json:"older_than_days" binding:"min=0"` // 0 purges everything deleted so far
+}
+
+// PurgeResult represents the outcome of purging soft deleted subscriptions and chains
+type PurgeResult struct {
+	DeletedBefore       time.Time `json:"deleted_before"`
+	ChainsPurged        int64     `json:"chains_purged"`
+	RunsPurged          int64     `json:"runs_purged"`
+	SubscriptionsPurged int64     `json:"subscriptions_purged"`
+	SubscriptionsKept   int64     `json:"subscriptions_kept"` // still called by steps of chains that were not purged
+}
+
+// CreateTenantRequest represents the request for creating a tenant, optionally with its settings
+type CreateTenantRequest struct {
+	ID       string                 `json:"id" binding:"required,max=64"`
+	Name     string                 `json:"name" binding:"required"`
+	Settings *TenantSettingsRequest `json:"settings,omitempty"`
+}
+
+// TenantSettingsRequest represents the request for changing a tenant's settings
+// Omitted settings are left unchanged
+type TenantSettingsRequest struct {
+	DefaultRetryPolicy *RetryPolicy       `json:"default_retry_policy,omitempty"`
+	RateLimit          *RateLimit         `json:"rate_limit,omitempty"`
+	RetentionDays      *int               `json:"retention_days,omitempty" binding:"omitempty,min=0"` // 0 uses the global default
+	SigningAlgorithm   *SigningAlgorithm  `json:"signing_algorithm,omitempty"`
+	Quota              *Quota             `json:"quota,omitempty"`
+	SensitiveFields    *[]SensitiveField  `json:"sensitive_fields,omitempty"` // replaces all sensitive fields, [] clears them
+	SubjectPath        *string            `json:"subject_path,omitempty"`     // empty clears it
+	AutoDisable        *AutoDisablePolicy `json:"auto_disable,omitempty"`     // failures 0 turns it off
+	LatencySLO         *LatencySLO        `json:"latency_slo,omitempty"`      // zero values use the defaults
+}
+
+// SuspendTenantRequest represents the request for suspending a tenant
+type SuspendTenantRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// TenantListResponse represents a page of tenants, oldest first
+type TenantListResponse struct {
+	Tenants []Tenant `json:"tenants"`
+	Total   int64    `json:"total"`
+	Page    int      `json:"page"`
+	Limit   int      `json:"limit"`
+}
+
+// TenantDeleteResponse represents the outcome of deleting a tenant
+type TenantDeleteResponse struct {
+	TenantID             string `json:"tenant_id"`
+	SubscriptionsDeleted int64  `json:"subscriptions_deleted"`
+	ChainsDeleted        int64  `json:"chains_deleted"`
+}
+
+// TenantUsageDay represents the usage of a tenant on one UTC day
+type TenantUsageDay struct {
+	Date       string `json:"date"` // YYYY-MM-DD
+	Events     int64  `json:"events"`
+	Deliveries int64  `json:"deliveries"`
+}
+
+// QuotaUsage represents how much of its quota a tenant used in the current UTC day and month
+type QuotaUsage struct {
+	EventsToday         int64 `json:"events_today"`
+	EventsThisMonth     int64 `json:"events_this_month"`
+	DeliveriesToday     int64 `json:"deliveries_today"`
+	DeliveriesThisMonth int64 `json:"deliveries_this_month"`
+}
+
+// TenantUsageResponse represents the events and deliveries of a tenant over a range of days
+// Days without usage are left out of Days
+type TenantUsageResponse struct {
+	TenantID   string           `json:"tenant_id"`
+	From       string           `json:"from"` // first day, inclusive
+	To         string           `json:"to"`   // last day, inclusive
+	Events     int64            `json:"events"`
+	Deliveries int64            `json:"deliveries"`
+	Days       []TenantUsageDay `json:"days"`
+	Quota      Quota            `json:"quota"`
+	Current    QuotaUsage       `json:"current"`
+}
+
+// AddJWTKeyRequest represents the request for adding a key to the JWT keyring
+// Leave Primary unset when several instances run and promote the key once all of them picked it up
+type AddJWTKeyRequest struct {
+	Primary bool `json:"primary"`
+}
+
+// JWTKeyListResponse represents the response for listing the keys of the JWT keyring
+type JWTKeyListResponse struct {
+	Keys         []JWTKey `json:"keys"`
+	PrimaryKeyID string   `json:"primary_kid"`
+}
+
+// ===== Topology DTOs =====
+
+// TopologyNodeType identifies the kind of resource a topology node represents
+type TopologyNodeType string
+
+const (
+	TopologyNodeApp     TopologyNodeType = "app"
+	TopologyNodeWebhook TopologyNodeType = "webhook"
+	TopologyNodeEvent   TopologyNodeType = "event"
+	TopologyNodeChain   TopologyNodeType = "chain"
+)
+
+// TopologyEdgeType describes how two topology nodes are related
+type TopologyEdgeType string
+
+const (
+	TopologyEdgeEmits      TopologyEdgeType = "emits"       // app → event it has sent
+	TopologyEdgeOwns       TopologyEdgeType = "owns"        // app → webhook it registered
+	TopologyEdgeDeliversTo TopologyEdgeType = "delivers_to" // event → webhook subscribed to it
+	TopologyEdgeTriggers   TopologyEdgeType = "triggers"    // event → chain started by it
+	TopologyEdgeCalls      TopologyEdgeType = "calls"       // chain → webhook called by a step
+)
+
+// EventSource is a distinct source and event name pair observed for a tenant
+type EventSource struct {
+	Source    string `json:"source"`
+	EventName string `json:"event_name"`
+}
+
+// TopologyNode represents an app, webhook, event or chain in a tenant's dependency graph
+type TopologyNode struct {
+	ID       string                 `json:"id"`
+	Type     TopologyNodeType       `json:"type"`
+	Label    string                 `json:"label"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TopologyEdge represents a directed relationship between two topology nodes
+type TopologyEdge struct {
+	Source string           `json:"source"`
+	Target string           `json:"target"`
+	Type   TopologyEdgeType `json:"type"`
+	Label  string           `json:"label,omitempty"`
+}
+
+// TenantTopologyResponse represents the dependency graph of a tenant
+type TenantTopologyResponse struct {
+	TenantID    string         `json:"tenant_id"`
+	Nodes       []TopologyNode `json:"nodes"`
+	Edges       []TopologyEdge `json:"edges"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+// ===== Impact Analysis DTOs =====
+
+// WebhookImpactResponse reports what would break if a webhook were disabled or deleted
+type WebhookImpactResponse struct {
+	WebhookID uuid.UUID            `json:"webhook_id"`
+	TenantID  string               `json:"tenant_id"`
+	AppName   string               `json:"app_name"`
+	IsActive  bool                 `json:"is_active"`
+	Event     ImpactedEvent        `json:"event"`
+	Chains    []ImpactedChain      `json:"chains"`
+	Traffic   WebhookImpactTraffic `json:"traffic"`
+	Breaking  bool                 `json:"breaking"`
+	Warnings  []string             `json:"warnings"`
+}
+
+// ImpactedEvent describes the subscribed event the webhook would stop receiving
+// Orphaned is true when no other active subscription receives the event
+type ImpactedEvent struct {
+	EventName              string `json:"event_name"`
+	OtherActiveSubscribers int    `json:"other_active_subscribers"`
+	Orphaned               bool   `json:"orphaned"`
+}
+
+// ImpactedChain describes a chain with steps that call the webhook
+type ImpactedChain struct {
+	ChainID      uuid.UUID      `json:"chain_id"`
+	Name         string         `json:"name"`
+	TriggerEvent string         `json:"trigger_event"`
+	IsActive     bool           `json:"is_active"`
+	Steps        []ImpactedStep `json:"steps"`
+}
+
+// ImpactedStep describes a chain step that calls the webhook
+type ImpactedStep struct {
+	StepID          uuid.UUID `json:"step_id"`
+	StepOrder       int       `json:"step_order"`
+	Name            string    `json:"name"`
+	OnFailureAction string    `json:"on_failure_action"`
+}
+
+// WebhookImpactTraffic summarises recent traffic that depends on the webhook
+type WebhookImpactTraffic struct {
+	EventsLast24h    int64 `json:"events_last_24h"`
+	EventsLast7d     int64 `json:"events_last_7d"`
+	StepCallsLast24h int64 `json:"step_calls_last_24h"`
+	StepCallsLast7d  int64 `json:"step_calls_last_7d"`
+}
+
+// ===== Route Explain DTOs =====
+
+// RouteExplainRequest represents a hypothetical event to evaluate against routing rules
+type RouteExplainRequest struct {
+	TenantID string      `json:"tenant_id" binding:"required"`
+	Event    string      `json:"event" binding:"required"`
+	Source   string      `json:"source"`
+	Payload  interface{} `json:"payload"`
+}
+
+// RouteExplainResponse explains how an event would be routed without delivering it
+type RouteExplainResponse struct {
+	TenantID             string                     `json:"tenant_id"`
+	Event                string                     `json:"event"`
+	Subscriptions        []RouteExplainSubscription `json:"subscriptions"`
+	Chains               []RouteExplainChain        `json:"chains"`
+	MatchedSubscriptions int                        `json:"matched_subscriptions"`
+	TriggeredChains      int                        `json:"triggered_chains"`
+	Warnings             []string                   `json:"warnings"`
+}
+
+// RouteExplainSubscription explains whether a subscription would receive the event
+type RouteExplainSubscription struct {
+	WebhookID       uuid.UUID    `json:"webhook_id"`
+	AppName         string       `json:"app_name"`
+	TargetURL       string       `json:"target_url"`
+	SubscribedEvent string       `json:"subscribed_event"`
+	Matched         bool         `json:"matched"`
+	Checks          []RouteCheck `json:"checks"`
+}
+
+// RouteExplainChain explains whether a chain would be triggered by the event
+// Queued is true when the chain would trigger but the tenant's chain executions are paused
+type RouteExplainChain struct {
+	ChainID      uuid.UUID    `json:"chain_id"`
+	Name         string       `json:"name"`
+	TriggerEvent string       `json:"trigger_event"`
+	WouldTrigger bool         `json:"would_trigger"`
+	Queued       bool         `json:"queued"`
+	Checks       []RouteCheck `json:"checks"`
+}
+
+// RouteCheck is the outcome of a single routing rule
+type RouteCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason"`
+}
+
+// AlertRuleRequest represents the definition of an alert rule, replacing the previous one on updates
+// Either WebhookID or ChainID selects what is watched; zero window and suppression use the defaults
+type AlertRuleRequest struct {
+	Name            string         `json:"name" binding:"required"`
+	WebhookID       *uuid.UUID     `json:"webhook_id,omitempty"`
+	ChainID         *uuid.UUID     `json:"chain_id,omitempty"`
+	Condition       AlertCondition `json:"condition" binding:"required"`
+	Threshold       float64        `json:"threshold"`
+	WindowMinutes   int            `json:"window_minutes,omitempty"`
+	MinSamples      int            `json:"min_samples,omitempty"`
+	NotifyWebhookID uuid.UUID      `json:"notify_webhook_id" binding:"required"`
+	SuppressMinutes int            `json:"suppress_minutes,omitempty"`
+	SilencedUntil   *time.Time     `json:"silenced_until,omitempty"`
+	IsActive        *bool          `json:"is_active,omitempty"` // true if omitted
+}
+
+// CreateAlertRuleRequest represents the request for creating an alert rule of a tenant
+type CreateAlertRuleRequest struct {
+	TenantID string `json:"tenant_id" binding:"required"`
+	AlertRuleRequest
+}
+
+// AlertRuleListResponse represents the alert rules of a tenant, ordered by name
+type AlertRuleListResponse struct {
+	TenantID string      `json:"tenant_id"`
+	Rules    []AlertRule `json:"rules"`
+	Total    int         `json:"total"`
+}
+
+// AlertEventListResponse represents a page of the alert history, newest first
+type AlertEventListResponse struct {
+	Events []AlertEvent `json:"events"`
+	Total  int64        `json:"total"`
+	Page   int          `json:"page"`
+	Limit  int          `json:"limit"`
 }
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType declares an event a tenant sends, documenting its payload with an optional JSON Schema
+// Registering an event type is optional; events without one are delivered as before
+type EventType struct {
+	// ID is the unique identifier for this event type
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// TenantID identifies the tenant that sends the event
+	TenantID string `json:"tenant_id" gorm:"not null;uniqueIndex:idx_event_types_tenant_name"`
+
+	// Name is the event name as sent in SendEvent and subscribed to by webhooks and chains
+	Name string `json:"name" gorm:"not null;uniqueIndex:idx_event_types_tenant_name"`
+
+	// Description explains when the event is sent
+	Description string `json:"description"`
+
+	// Schema is an optional JSON Schema describing the event payload
+	Schema *string `json:"schema,omitempty" gorm:"type:jsonb"`
+
+	// ValidatePayloads makes SendEvent reject events whose payload violates the schema
+	ValidatePayloads bool `json:"validate_payloads"`
+
+	// CreatedAt timestamp when the event type was registered
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt timestamp when the event type was last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName sets the table name for EventType
+func (EventType) TableName() string {
+	return "event_types"
+}
+
+// SchemaViolation describes one place where a payload does not conform to its event type's schema
+type SchemaViolation struct {
+	// Path locates the offending value, e.g. "$.items[2].sku"
+	Path string `json:"path"`
+
+	// Message explains the violation
+	Message string `json:"message"`
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookExportVersion is the version of the subscription export format
+const WebhookExportVersion = 1
+
+// WebhookExport describes the webhook subscriptions of a tenant portably, to recreate them in another
+// environment, e.g. to promote them from staging to production
+// Returned by GET /api/webhooks/export and accepted by POST /api/webhooks/import, as JSON or YAML
+type WebhookExport struct {
+	// Version is the version of the export format, WebhookExportVersion
+	Version int `json:"version"`
+
+	// TenantID is the tenant the subscriptions were exported from
+	TenantID string `json:"tenant_id"`
+
+	// ExportedAt is when the export was made
+	ExportedAt time.Time `json:"exported_at"`
+
+	// IncludesSecrets reports whether the subscriptions carry their signing secrets and PagerDuty routing keys
+	IncludesSecrets bool `json:"includes_secrets"`
+
+	// Subscriptions are the exported subscriptions
+	Subscriptions []ExportedSubscription `json:"subscriptions"`
+}
+
+// ExportedSubscription is a subscription as exported, holding what is needed to subscribe it again
+// Runtime state such as pauses, retry counts and delivery history is not exported
+type ExportedSubscription struct {
+	// SourceID is the ID of the subscription in the environment it was exported from, for reference
+	SourceID uuid.UUID `json:"source_id,omitempty"`
+
+	AppName         string      `json:"app_name"`
+	Description     *string     `json:"description,omitempty"`
+	SubscribedEvent string      `json:"subscribed_event"`
+	Type            WebhookType `json:"type"`
+	IsActive        bool        `json:"is_active"`
+
+	TargetType     TargetType            `json:"target_type,omitempty"`
+	TargetURL      string                `json:"target_url,omitempty"`
+	AMQPExchange   string                `json:"amqp_exchange,omitempty"`
+	AMQPRoutingKey string                `json:"amqp_routing_key,omitempty"`
+	Notification   *NotificationTemplate `json:"notification,omitempty"`
+	Recipients     []string              `json:"recipients,omitempty"`
+
+	Headers          map[string]string      `json:"headers,omitempty"`
+	QueryParams      map[string]string      `json:"query_params,omitempty"`
+	RetryPolicy      *RetryPolicy           `json:"retry_policy,omitempty"`
+	Batching         *BatchPolicy           `json:"batching,omitempty"`
+	ContentEncoding  ContentEncoding        `json:"content_encoding,omitempty"`
+	ResponseSchema   map[string]interface{} `json:"response_schema,omitempty"`
+	SigningHeaders   *SigningHeaders        `json:"signing_headers,omitempty"`
+	SignatureScheme  SignatureScheme        `json:"signature_scheme,omitempty"`
+	AllowedSourceIPs []string               `json:"allowed_source_ips,omitempty"`
+
+	// SecretToken is the HMAC secret deliveries are signed with; only exported with secrets
+	SecretToken *string `json:"secret_token,omitempty"`
+
+	// PagerDutyRoutingKey is the integration key of PagerDuty targets; only exported with secrets
+	PagerDutyRoutingKey *string `json:"pagerduty_routing_key,omitempty"`
+}
+
+// WebhookImportOptions controls how POST /api/webhooks/import applies an export
+type WebhookImportOptions struct {
+	// TenantID is the tenant the subscriptions are created for; empty uses the export's tenant
+	TenantID string
+
+	// DryRun validates the export and reports what would be created without creating anything
+	DryRun bool
+
+	// RegenerateSecrets gives every imported subscription a new signing secret instead of the exported one
+	// Subscriptions exported without secrets always get new ones
+	RegenerateSecrets bool
+
+	// OnConflict is what happens to subscriptions the tenant already has; ImportConflictSkip by default
+	OnConflict ImportConflictPolicy
+}
+
+// ImportConflictPolicy selects how an import treats subscriptions that already exist
+// A subscription exists when the tenant has one for the same event, target type and target
+type ImportConflictPolicy string
+
+const (
+	// ImportConflictSkip imports the other subscriptions and leaves the existing ones as they are
+	ImportConflictSkip ImportConflictPolicy = "skip"
+
+	// ImportConflictFail imports nothing when any subscription exists
+	ImportConflictFail ImportConflictPolicy = "fail"
+)
+
+// IsValid reports whether the policy is known; empty means ImportConflictSkip
+func (p ImportConflictPolicy) IsValid() bool {
+	return p == "" || p == ImportConflictSkip || p == ImportConflictFail
+}
+
+// ImportStatus is the outcome of importing one subscription
+type ImportStatus string
+
+const (
+	// ImportStatusCreated means the subscription was created
+	ImportStatusCreated ImportStatus = "created"
+
+	// ImportStatusValid means a dry run found the subscription valid and would create it
+	ImportStatusValid ImportStatus = "valid"
+
+	// ImportStatusConflict means the tenant already has the subscription, or the export lists it twice
+	ImportStatusConflict ImportStatus = "conflict"
+
+	// ImportStatusInvalid means the subscription failed validation
+	ImportStatusInvalid ImportStatus = "invalid"
+
+	// ImportStatusNotImported means the subscription is valid but was not created because the import was
+	// rejected or failed
+	ImportStatusNotImported ImportStatus = "not_imported"
+)
+
+// WebhookImportResponse reports the outcome of an import per subscription
+type WebhookImportResponse struct {
+	// TenantID is the tenant the subscriptions were imported to
+	TenantID string `json:"tenant_id"`
+
+	// DryRun reports whether nothing was created because the import was a dry run
+	DryRun bool `json:"dry_run"`
+
+	// Applied reports whether the subscriptions were created; false for dry runs and for imports rejected
+	// because a subscription is invalid or conflicts under ImportConflictFail
+	Applied bool `json:"applied"`
+
+	Created   int `json:"created"`
+	Conflicts int `json:"conflicts"`
+	Invalid   int `json:"invalid"`
+
+	// Results has the outcome of each subscription in the order of the export
+	Results []WebhookImportResult `json:"results"`
+}
+
+// WebhookImportResult is the outcome of importing one subscription
+type WebhookImportResult struct {
+	// Index is the position of the subscription in the export, from 0
+	Index int `json:"index"`
+
+	SourceID        uuid.UUID  `json:"source_id,omitempty"`
+	SubscribedEvent string     `json:"subscribed_event"`
+	TargetType      TargetType `json:"target_type"`
+	TargetURL       string     `json:"target_url"`
+
+	Status ImportStatus `json:"status"`
+
+	// WebhookID is the ID of the created subscription
+	WebhookID *uuid.UUID `json:"webhook_id,omitempty"`
+
+	// ConflictsWith is the ID of the tenant's subscription the subscription conflicts with; entries
+	// duplicating an earlier entry of the export are reported in Error instead
+	ConflictsWith *uuid.UUID `json:"conflicts_with,omitempty"`
+
+	// SecretToken is the new signing secret of a created subscription whose secret was generated, for
+	// configuring its receiver; JWTToken is the new JWT of a private one
+	SecretToken *string `json:"secret_token,omitempty"`
+	JWTToken    *string `json:"jwt_token,omitempty"`
+
+	// Error is why the subscription is invalid or could not be created
+	Error *string `json:"error,omitempty"`
+}
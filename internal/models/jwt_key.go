@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// JWTKey is an HMAC key of the keyring that JWTs are signed with
+// Tokens carry the ID of their key in the "kid" header, so adding a key does not invalidate tokens
+// signed with earlier ones; only retiring a key does
+type JWTKey struct {
+	// ID is the key identifier embedded in every token, e.g. "jk_3f9a0c1b2d4e5f60"
+	ID string `json:"kid" gorm:"primary_key"`
+
+	// Secret is the base64 encoded HMAC key; never serialized and encrypted at rest
+	Secret string `json:"-" gorm:"not null;serializer:encrypted"`
+
+	// IsPrimary marks the key new tokens are signed with; exactly one unretired key is primary
+	IsPrimary bool `json:"is_primary" gorm:"not null;default:false"`
+
+	// RetiredAt is when the key was retired; tokens signed with a retired key no longer verify
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+
+	// CreatedAt timestamp when the key was generated
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsRetired reports whether the key was retired
+func (k *JWTKey) IsRetired() bool {
+	return k.RetiredAt != nil
+}
+
+// SecretBytes decodes the HMAC key
+func (k *JWTKey) SecretBytes() ([]byte, error) {
+	secret, err := base64.StdEncoding.DecodeString(k.Secret)
+	if err != nil || len(secret) == 0 {
+		return nil, fmt.Errorf("jwt key %s has an invalid secret", k.ID)
+	}
+	return secret, nil
+}
+
+// TableName sets the table name for JWTKey
+func (JWTKey) TableName() string {
+	return "jwt_keys"
+}
@@ -0,0 +1,55 @@
+package models
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint PagerDuty targets are delivered to by default
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// NotificationSeverity is the severity of a notification, using PagerDuty's levels
+// Slack and Teams messages show it as an emoji and a color
+type NotificationSeverity string
+
+const (
+	NotificationSeverityCritical NotificationSeverity = "critical"
+	NotificationSeverityError    NotificationSeverity = "error"
+	NotificationSeverityWarning  NotificationSeverity = "warning"
+	NotificationSeverityInfo     NotificationSeverity = "info"
+)
+
+// IsValid reports whether the severity is known; empty means the target type's default
+func (s NotificationSeverity) IsValid() bool {
+	switch s {
+	case "", NotificationSeverityCritical, NotificationSeverityError, NotificationSeverityWarning, NotificationSeverityInfo:
+		return true
+	}
+	return false
+}
+
+// NotificationTemplate describes how events are turned into the messages of Slack, Teams, PagerDuty, email
+// and SMS targets
+//
+// Title, Text, Fields and DedupKey are Go templates rendered against the delivered event:
+// .event, .source, .timestamp, .event_id and .payload, e.g. "Order {{.payload.order_id}} failed"
+type NotificationTemplate struct {
+	// Title is the headline of the message, the summary of PagerDuty alerts and the subject of email,
+	// "{{.event}} from {{.source}}" if empty
+	Title string `json:"title,omitempty"`
+
+	// Text is the body of Slack, Teams, email and SMS messages, in Slack mrkdwn, Teams markdown or plain text
+	// Without Text and Fields, messages show the event payload as JSON
+	Text string `json:"text,omitempty"`
+
+	// Fields are shown as label and value pairs, ordered by label; PagerDuty alerts carry them as custom details
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// Severity is the severity of events SeverityField does not map; info by default, error for PagerDuty
+	Severity NotificationSeverity `json:"severity,omitempty"`
+
+	// SeverityField is the dotted path of the event value deciding the severity, e.g. "payload.level"
+	SeverityField string `json:"severity_field,omitempty"`
+
+	// SeverityMap maps values of SeverityField to severities, e.g. {"P1": "critical"}
+	// Values that are severity names themselves need no entry
+	SeverityMap map[string]NotificationSeverity `json:"severity_map,omitempty"`
+
+	// DedupKey groups PagerDuty alerts into one incident, the event ID if empty
+	DedupKey string `json:"dedup_key,omitempty"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderedDelivery represents a delivery of an event sent with an ordering key, waiting for its turn
+// A subscription's deliveries with the same key are sent one at a time in creation order: only the
+// oldest can be claimed, and the next one once it succeeded or failed for good
+type OrderedDelivery struct {
+	// ID is the unique identifier for this ordered delivery
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// SubscriptionID references the subscription the delivery is for
+	SubscriptionID uuid.UUID `json:"subscription_id" gorm:"type:uuid;not null;index:idx_ordered_deliveries_subscription_key_created,priority:1"`
+
+	// EventID references the event being delivered
+	EventID uuid.UUID `json:"event_id" gorm:"type:uuid;not null;index"`
+
+	// TenantID identifies the tenant that sent the event
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// OrderingKey is the ordering key of the event
+	OrderingKey string `json:"ordering_key" gorm:"not null;index:idx_ordered_deliveries_subscription_key_created,priority:2"`
+
+	// ExpiresAt is the expiry of the event; the delivery is dropped instead of sent once it passes
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Payload is the subscription-specific payload to deliver
+	Payload string `json:"payload" gorm:"type:jsonb;not null"`
+
+	// ClaimedAt timestamp when an instance started sending the delivery, nil while it waits
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+
+	// CreatedAt timestamp when the delivery was queued, which sets its place in the order
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_ordered_deliveries_subscription_key_created,priority:3"`
+}
+
+// TableName sets the table name for OrderedDelivery
+func (OrderedDelivery) TableName() string {
+	return "ordered_deliveries"
+}
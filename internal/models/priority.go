@@ -0,0 +1,52 @@
+package models
+
+// Priority ranks events and chain runs competing for delivery slots and run workers, so critical alerts are not
+// stuck behind bulk traffic
+type Priority string
+
+const (
+	// PriorityHigh is for critical traffic such as alerts; it is served first and may use slots reserved for it
+	PriorityHigh Priority = "high"
+
+	// PriorityNormal is the priority of events and runs sent without one
+	PriorityNormal Priority = "normal"
+
+	// PriorityLow is for bulk traffic such as batch imports; it is served after everything else
+	PriorityLow Priority = "low"
+)
+
+// Levels stored for the named priorities; queued work with a higher level is served first
+const (
+	PriorityLevelHigh   = 10
+	PriorityLevelNormal = 0
+	PriorityLevelLow    = -10
+)
+
+// IsValid reports whether the priority is known; empty means PriorityNormal
+func (p Priority) IsValid() bool {
+	return p == "" || p == PriorityHigh || p == PriorityNormal || p == PriorityLow
+}
+
+// Level returns the level stored for the priority
+func (p Priority) Level() int {
+	switch p {
+	case PriorityHigh:
+		return PriorityLevelHigh
+	case PriorityLow:
+		return PriorityLevelLow
+	default:
+		return PriorityLevelNormal
+	}
+}
+
+// PriorityOf returns the named priority of a stored level: positive levels are high and negative ones low
+func PriorityOf(level int) Priority {
+	switch {
+	case level > 0:
+		return PriorityHigh
+	case level < 0:
+		return PriorityLow
+	default:
+		return PriorityNormal
+	}
+}
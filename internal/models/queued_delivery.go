@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PauseHeader is the response header a receiver sets to pause deliveries to it
+// Its value is the pause duration in seconds, e.g. "X-Loki-Pause: 3600" during a deploy
+const PauseHeader = "X-Loki-Pause"
+
+// MaxReceiverPause caps the pause a receiver can request, so a misbehaving receiver
+// cannot hold deliveries back indefinitely
+const MaxReceiverPause = 24 * time.Hour
+
+// QueuedDelivery represents an event delivery held back while its subscription is paused
+// Queued deliveries are sent in creation order once the pause ends
+type QueuedDelivery struct {
+	// ID is the unique identifier for this queued delivery
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// SubscriptionID references the paused subscription the delivery is for
+	SubscriptionID uuid.UUID `json:"subscription_id" gorm:"type:uuid;not null;index"`
+
+	// EventID references the event being delivered
+	EventID uuid.UUID `json:"event_id" gorm:"type:uuid;not null;index"`
+
+	// TenantID identifies the tenant that sent the event
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// ExpiresAt is the expiry of the event; the delivery is dropped instead of sent once it passes
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Payload is the subscription-specific payload to deliver
+	Payload string `json:"payload" gorm:"type:jsonb;not null"`
+
+	// CreatedAt timestamp when the delivery was queued
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName sets the table name for QueuedDelivery
+func (QueuedDelivery) TableName() string {
+	return "queued_deliveries"
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivalTrigger describes what started an archival run
+type ArchivalTrigger string
+
+const (
+	// ArchivalTriggerScheduled marks runs of the background archiver
+	ArchivalTriggerScheduled ArchivalTrigger = "scheduled"
+
+	// ArchivalTriggerManual marks runs started through POST /api/admin/archival
+	ArchivalTriggerManual ArchivalTrigger = "manual"
+)
+
+// ArchivalStatus represents the state of an archival run
+type ArchivalStatus string
+
+const (
+	// ArchivalStatusRunning indicates the run is still moving rows
+	ArchivalStatusRunning ArchivalStatus = "running"
+
+	// ArchivalStatusCompleted indicates every expired row was archived and expired archives pruned
+	ArchivalStatusCompleted ArchivalStatus = "completed"
+
+	// ArchivalStatusFailed indicates the run stopped on an error; rows archived before it stay archived
+	ArchivalStatusFailed ArchivalStatus = "failed"
+)
+
+// ArchivalRun records one pass of the archiver over the event and run tables
+// Expired rows are moved to the *_archive tables and archives older than the archive retention are pruned
+type ArchivalRun struct {
+	// ID is the unique identifier for this archival run
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Trigger describes whether the run was scheduled or started by an operator
+	Trigger ArchivalTrigger `json:"trigger" gorm:"not null"`
+
+	// Status is the state of the run
+	Status ArchivalStatus `json:"status" gorm:"not null;index"`
+
+	// EventsArchived counts the webhook events moved to webhook_events_archive
+	EventsArchived int64 `json:"events_archived"`
+
+	// AttemptsArchived counts the delivery attempts of archived events moved to webhook_delivery_attempts_archive
+	AttemptsArchived int64 `json:"attempts_archived"`
+
+	// RunsArchived counts the chain runs moved to execution_chain_runs_archive
+	RunsArchived int64 `json:"runs_archived"`
+
+	// StepRunsArchived counts the step and compensation runs of archived chain runs
+	StepRunsArchived int64 `json:"step_runs_archived"`
+
+	// ArchivesPruned counts the archived rows deleted for being older than the archive retention
+	ArchivesPruned int64 `json:"archives_pruned"`
+
+	// Error describes why a failed run stopped
+	Error string `json:"error,omitempty"`
+
+	// StartedAt timestamp when the run started
+	StartedAt time.Time `json:"started_at" gorm:"not null;index"`
+
+	// CompletedAt timestamp when the run completed or failed
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName sets the table name for ArchivalRun
+func (ArchivalRun) TableName() string {
+	return "archival_runs"
+}
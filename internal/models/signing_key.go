@@ -0,0 +1,62 @@
+package models
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// SigningAlgorithm selects how a tenant's deliveries are signed
+type SigningAlgorithm string
+
+const (
+	// SigningAlgorithmHMAC signs deliveries with HMAC-SHA256 keyed with each subscription's secret token
+	// Receivers need the shared secret to verify
+	SigningAlgorithmHMAC SigningAlgorithm = "hmac-sha256"
+
+	// SigningAlgorithmEd25519 signs deliveries with the tenant's Ed25519 private key
+	// Receivers verify with the public key published at /.well-known/loki-suite/keys.json, no secret is shared
+	SigningAlgorithmEd25519 SigningAlgorithm = "ed25519"
+)
+
+// IsValid reports whether the algorithm is known; empty means SigningAlgorithmHMAC
+func (a SigningAlgorithm) IsValid() bool {
+	return a == "" || a == SigningAlgorithmHMAC || a == SigningAlgorithmEd25519
+}
+
+// SigningKey represents an asymmetric key pair a tenant's deliveries are signed with
+// Keys are never deleted when rotated, so deliveries signed with an earlier key still verify
+type SigningKey struct {
+	// ID is the key identifier sent with every signature, e.g. "lk_3f9a0c1b2d4e5f60"
+	ID string `json:"kid" gorm:"primary_key"`
+
+	// TenantID identifies the tenant whose deliveries are signed with this key
+	TenantID string `json:"tenant_id" gorm:"index;not null"`
+
+	// Algorithm of the key pair, currently always SigningAlgorithmEd25519
+	Algorithm SigningAlgorithm `json:"algorithm" gorm:"not null"`
+
+	// PublicKey is the raw public key, published in the key set
+	PublicKey []byte `json:"public_key" gorm:"not null"`
+
+	// PrivateKey is the base64 encoded private key; never serialized and encrypted at rest
+	PrivateKey string `json:"-" gorm:"not null;serializer:encrypted"`
+
+	// CreatedAt timestamp when the key was generated
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Ed25519PrivateKey decodes the private key
+func (k *SigningKey) Ed25519PrivateKey() (ed25519.PrivateKey, error) {
+	key, err := base64.StdEncoding.DecodeString(k.PrivateKey)
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("signing key %s has an invalid private key", k.ID)
+	}
+	return key, nil
+}
+
+// TableName sets the table name for SigningKey
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StreamEventType identifies the kind of status change pushed to GET /api/streams/events
+type StreamEventType string
+
+const (
+	// StreamEventDeliveryCompleted is pushed once an event was delivered to its subscriptions
+	StreamEventDeliveryCompleted StreamEventType = "delivery.completed"
+
+	// StreamEventRunStatusChanged is pushed when a chain run is created or changes its status
+	StreamEventRunStatusChanged StreamEventType = "run.status_changed"
+
+	// StreamEventStepCompleted is pushed when a step of a chain run succeeded, failed or was skipped
+	StreamEventStepCompleted StreamEventType = "step.completed"
+)
+
+// IsValid reports whether the type is a known stream event type
+func (t StreamEventType) IsValid() bool {
+	switch t {
+	case StreamEventDeliveryCompleted, StreamEventRunStatusChanged, StreamEventStepCompleted:
+		return true
+	}
+	return false
+}
+
+// StreamEvent is a status change pushed to the clients of GET /api/streams/events
+// Exactly one of Delivery, Run and Step is set, matching Type
+type StreamEvent struct {
+	Type      StreamEventType `json:"type"`
+	TenantID  string          `json:"tenant_id"`
+	Timestamp time.Time       `json:"timestamp"`
+
+	Delivery *DeliveryStreamData `json:"delivery,omitempty"`
+	Run      *RunStreamData      `json:"run,omitempty"`
+	Step     *StepStreamData     `json:"step,omitempty"`
+}
+
+// DeliveryStreamData is the outcome of delivering an event to its subscriptions
+type DeliveryStreamData struct {
+	EventID      uuid.UUID               `json:"event_id"`
+	Event        string                  `json:"event"`
+	Source       string                  `json:"source"`
+	Status       WebhookStatus           `json:"status"`
+	TotalSent    int                     `json:"total_sent"`
+	TotalFailed  int                     `json:"total_failed"`
+	TotalQueued  int                     `json:"total_queued"`
+	TotalExpired int                     `json:"total_expired,omitempty"`
+	Webhooks     []WebhookDeliveryResult `json:"webhooks"`
+}
+
+// RunStreamData is the new status of a chain run
+type RunStreamData struct {
+	RunID   uuid.UUID            `json:"run_id"`
+	ChainID uuid.UUID            `json:"chain_id"`
+	Status  ExecutionChainStatus `json:"status"`
+}
+
+// StepStreamData is the outcome of a step of a chain run
+type StepStreamData struct {
+	RunID        uuid.UUID     `json:"run_id"`
+	ChainID      uuid.UUID     `json:"chain_id"`
+	StepID       uuid.UUID     `json:"step_id"`
+	StepOrder    int           `json:"step_order"`
+	Status       WebhookStatus `json:"status"`
+	ResponseCode *int          `json:"response_code,omitempty"`
+	AttemptCount int           `json:"attempt_count,omitempty"`
+	Error        *string       `json:"error,omitempty"`
+}
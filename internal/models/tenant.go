@@ -0,0 +1,272 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TenantStatus represents the lifecycle state of a tenant
+type TenantStatus string
+
+const (
+	// TenantStatusActive indicates the tenant can send events and run chains
+	TenantStatusActive TenantStatus = "active"
+
+	// TenantStatusSuspended indicates the tenant's events are rejected and its chain runs refused
+	// Subscriptions and chains are kept and work again once the tenant is activated
+	TenantStatusSuspended TenantStatus = "suspended"
+)
+
+// Tenant represents an organization owning subscriptions, events and execution chains
+// Subscriptions, events and chains reference their tenant by ID, so a tenant must exist before them
+type Tenant struct {
+	// ID is the tenant identifier used as tenant_id throughout the API
+	ID string `json:"id" gorm:"primary_key"`
+
+	// Name is a human-readable name for the tenant
+	Name string `json:"name" gorm:"not null"`
+
+	// Status is the lifecycle state of the tenant
+	Status TenantStatus `json:"status" gorm:"not null;default:active;index"`
+
+	// SuspendedAt timestamp when the tenant was suspended
+	// Cleared when the tenant is activated
+	SuspendedAt *time.Time `json:"suspended_at,omitempty"`
+
+	// SuspendedReason records why the tenant was suspended
+	SuspendedReason string `json:"suspended_reason,omitempty"`
+
+	// Settings are the tenant's operational settings, loaded with the tenant
+	Settings *TenantSettings `json:"settings,omitempty" gorm:"foreignKey:TenantID"`
+
+	// CreatedAt timestamp when the tenant was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt timestamp when the tenant was last modified
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DeletedAt timestamp when the tenant was deleted
+	// Deleted tenants keep their row, so their ID is never given to another tenant
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
+}
+
+// TableName sets the table name for Tenant
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// IsActive reports whether the tenant can send events and run chains
+func (t *Tenant) IsActive() bool {
+	return t.Status == TenantStatusActive
+}
+
+// RateLimit overrides the management API rate limit of a tenant
+// A zero RequestsPerSecond uses the installation-wide limit
+type RateLimit struct {
+	// RequestsPerSecond is how many tokens are refilled per second
+	RequestsPerSecond float64 `json:"requests_per_second" binding:"min=0"`
+
+	// Burst is how many requests can be made at once, RequestsPerSecond rounded up if 0
+	Burst int `json:"burst" binding:"min=0"`
+}
+
+// Quota limits the events a tenant publishes and the deliveries made for its events
+// Days and months are UTC calendar days and months; a zero limit is unlimited
+type Quota struct {
+	// EventsPerDay is how many events the tenant can publish per day
+	EventsPerDay int64 `json:"events_per_day" binding:"min=0"`
+
+	// EventsPerMonth is how many events the tenant can publish per month
+	EventsPerMonth int64 `json:"events_per_month" binding:"min=0"`
+
+	// DeliveriesPerDay is how many deliveries to subscriptions can be made per day
+	DeliveriesPerDay int64 `json:"deliveries_per_day" binding:"min=0"`
+
+	// DeliveriesPerMonth is how many deliveries to subscriptions can be made per month
+	DeliveriesPerMonth int64 `json:"deliveries_per_month" binding:"min=0"`
+}
+
+// MaxAutoDisableDays caps the window of the auto-disable policy at a year
+const MaxAutoDisableDays = 365
+
+// AutoDisablePolicy deactivates a tenant's subscriptions that keep failing, so dead URLs are not retried forever
+// A subscription is disabled once at least Failures of its deliveries failed within the last Days days and
+// none succeeded; a zero Failures disables the policy
+type AutoDisablePolicy struct {
+	// Failures is how many deliveries must have failed within the window
+	Failures int `json:"failures" binding:"min=0"`
+
+	// Days is how far back failures are counted
+	Days int `json:"days" binding:"min=0"`
+
+	// NotifyWebhookID is the subscription, such as a Slack or email target, told about disabled subscriptions
+	NotifyWebhookID *uuid.UUID `json:"notify_webhook_id,omitempty" gorm:"type:uuid"`
+}
+
+// Enabled reports whether the policy disables subscriptions
+func (p AutoDisablePolicy) Enabled() bool {
+	return p.Failures > 0 && p.Days > 0
+}
+
+const (
+	// DefaultLatencySLOTargetMs is the latency target of tenants that did not set one
+	DefaultLatencySLOTargetMs = 5000
+
+	// DefaultLatencySLOObjective is the objective of tenants that did not set one, in percent
+	DefaultLatencySLOObjective = 99.0
+)
+
+// LatencySLO is a tenant's service level objective for how fast events reach their subscriptions
+// The objective is met when at least Objective percent of the successful deliveries within a window
+// arrived within TargetMs of the event being accepted; zero values use the defaults
+type LatencySLO struct {
+	// TargetMs is the end-to-end latency a delivery must not exceed, in milliseconds
+	TargetMs int64 `json:"target_ms" binding:"min=0"`
+
+	// Objective is the percentage of deliveries that must meet the target, e.g. 99.5
+	Objective float64 `json:"objective" binding:"min=0,max=100"`
+}
+
+// OrDefault returns the SLO with unset values replaced by the defaults
+func (s LatencySLO) OrDefault() LatencySLO {
+	if s.TargetMs == 0 {
+		s.TargetMs = DefaultLatencySLOTargetMs
+	}
+	if s.Objective == 0 {
+		s.Objective = DefaultLatencySLOObjective
+	}
+	return s
+}
+
+// SensitiveFieldAction is what happens to the values of a sensitive field before a payload is stored
+type SensitiveFieldAction string
+
+const (
+	// SensitiveFieldRedact replaces the values with [REDACTED]
+	SensitiveFieldRedact SensitiveFieldAction = "redact"
+
+	// SensitiveFieldEncrypt encrypts the values with the installation's encryption key, so they can still be
+	// delivered from the stored payload
+	SensitiveFieldEncrypt SensitiveFieldAction = "encrypt"
+)
+
+// IsValid reports whether the action is supported
+func (a SensitiveFieldAction) IsValid() bool {
+	return a == SensitiveFieldRedact || a == SensitiveFieldEncrypt
+}
+
+// MaxSensitiveFields is how many sensitive fields a tenant can mark
+const MaxSensitiveFields = 100
+
+// SensitiveField marks the values at a JSONPath of a tenant's event payloads, chain trigger data and step
+// requests as sensitive; they are redacted or encrypted in the stored copies, and delivered as sent
+type SensitiveField struct {
+	// Path selects the values from the root of the event payload or step request, e.g. $.card.number
+	// or $.customers[*].email
+	Path string `json:"path" binding:"required"`
+
+	// Action is what happens to the values before they are stored, redact if empty
+	Action SensitiveFieldAction `json:"action,omitempty"`
+}
+
+// TenantUsage counts the events a tenant published and the deliveries made for it on one UTC day
+// Usage is kept when events are archived, so it can be used for billing
+type TenantUsage struct {
+	// TenantID identifies the tenant the usage belongs to
+	TenantID string `json:"tenant_id" gorm:"primary_key"`
+
+	// Day is the UTC day the usage was counted on
+	Day time.Time `json:"day" gorm:"primary_key;type:date"`
+
+	// Events is how many events the tenant published
+	Events int64 `json:"events" gorm:"not null;default:0"`
+
+	// Deliveries is how many deliveries of the tenant's events to subscriptions were made
+	// A delivery counts once however many attempts it takes
+	Deliveries int64 `json:"deliveries" gorm:"not null;default:0"`
+
+	// UpdatedAt timestamp when the usage was last counted
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName sets the table name for TenantUsage
+func (TenantUsage) TableName() string {
+	return "tenant_usage"
+}
+
+// TenantSettings represents tenant-wide operational settings in the database
+// Stores switches that apply to every subscription and chain owned by a tenant
+type TenantSettings struct {
+	// TenantID identifies the tenant these settings belong to
+	// Acts as the primary key since there is exactly one settings row per tenant
+	TenantID string `json:"tenant_id" gorm:"primary_key"`
+
+	// ChainsPaused prevents new execution chain runs from starting for this tenant
+	// Triggered runs are queued instead and started when executions are resumed
+	ChainsPaused bool `json:"chains_paused" gorm:"default:false"`
+
+	// ChainsPausedAt timestamp when chain executions were last paused
+	// Cleared when executions are resumed
+	ChainsPausedAt *time.Time `json:"chains_paused_at"`
+
+	// SigningHeaders overrides the signature and metadata header names of every delivery to the
+	// tenant's subscriptions; subscriptions can override it again
+	SigningHeaders SigningHeaders `json:"signing_headers" gorm:"embedded;embeddedPrefix:signing_"`
+
+	// MaxConcurrentRuns limits how many of the tenant's chain runs execute at once, 0 for no limit
+	// Runs triggered beyond the limit are queued and started in trigger order as running ones finish
+	MaxConcurrentRuns int `json:"max_concurrent_runs" gorm:"default:0"`
+
+	// StrictPayloadValidation validates every event against the schema of its event type,
+	// including event types that do not validate payloads themselves
+	StrictPayloadValidation bool `json:"strict_payload_validation" gorm:"default:false"`
+
+	// SigningAlgorithm selects how the tenant's deliveries are signed; empty means HMAC-SHA256
+	SigningAlgorithm SigningAlgorithm `json:"signing_algorithm"`
+
+	// SigningKeyID references the SigningKey new Ed25519 signatures are made with
+	SigningKeyID string `json:"signing_key_id,omitempty"`
+
+	// RetentionDays is how many days the tenant's finished events and chain runs are kept before they are
+	// archived; 0 uses the global default of LOKI_RETENTION_DAYS
+	RetentionDays int `json:"retention_days" gorm:"default:0"`
+
+	// DefaultRetryPolicy is applied to subscriptions created without a retry policy
+	// Zero values use the installation defaults of 3 retries 5 seconds apart
+	DefaultRetryPolicy RetryPolicy `json:"default_retry_policy" gorm:"embedded;embeddedPrefix:default_"`
+
+	// RateLimit overrides the management API rate limit shared by the tenant's credentials
+	RateLimit RateLimit `json:"rate_limit" gorm:"embedded;embeddedPrefix:rate_limit_"`
+
+	// Quota limits the events the tenant publishes and the deliveries made for them
+	Quota Quota `json:"quota" gorm:"embedded;embeddedPrefix:quota_"`
+
+	// AutoDisable deactivates the tenant's subscriptions whose deliveries keep failing
+	AutoDisable AutoDisablePolicy `json:"auto_disable" gorm:"embedded;embeddedPrefix:auto_disable_"`
+
+	// LatencySLO is what delivery latency reports measure the tenant's subscriptions against
+	LatencySLO LatencySLO `json:"latency_slo" gorm:"embedded;embeddedPrefix:latency_slo_"`
+
+	// SensitiveFields are redacted or encrypted in the tenant's stored event payloads and step requests
+	SensitiveFields []SensitiveField `json:"sensitive_fields,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// SubjectPath selects the value identifying the data subject of an event payload or chain trigger data,
+	// e.g. $.customer.id; sensitive fields to encrypt are then encrypted with the subject's own key, which
+	// erasing the subject deletes
+	SubjectPath string `json:"subject_path,omitempty"`
+
+	// CreatedAt timestamp when the settings row was first created
+	// Automatically managed by GORM for audit trails
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt timestamp when the settings were last modified
+	// Automatically updated by GORM on any field changes
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName sets the table name for TenantSettings
+func (TenantSettings) TableName() string {
+	return "tenant_settings"
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // WebhookType defines the type of webhook subscription
@@ -36,8 +37,23 @@ const (
 	// WebhookStatusFailed indicates delivery failed to all subscribers
 	// All delivery attempts failed due to network, authentication, or target errors
 	WebhookStatusFailed WebhookStatus = "failed"
+
+	// WebhookStatusSkipped indicates a chain step was not executed, because its condition was false
+	// or its run was cancelled; only used for step runs
+	WebhookStatusSkipped WebhookStatus = "skipped"
+
+	// WebhookStatusScheduled indicates the event is stored for delivery at a later time
+	// The event scheduler moves it to pending and delivers it once its delivery time is reached
+	WebhookStatusScheduled WebhookStatus = "scheduled"
+
+	// WebhookStatusExpired indicates the event expired before it was delivered to every subscriber
+	// Deliveries not made by its expiry are dropped rather than attempted or retried, and its chains are not triggered
+	WebhookStatusExpired WebhookStatus = "expired"
 )
 
+// MaxEventDelay is the longest an event can be scheduled ahead of its delivery
+const MaxEventDelay = 30 * 24 * time.Hour
+
 // ExecutionChainStatus defines the execution state of workflow chains
 // Tracks the progress and outcome of multi-step webhook execution workflows
 type ExecutionChainStatus string
@@ -47,6 +63,10 @@ const (
 	// Waiting for trigger event or manual execution command
 	ExecutionChainStatusPending ExecutionChainStatus = "pending"
 
+	// ExecutionChainStatusQueued indicates the run was triggered while chain executions were paused
+	// for its tenant and will start once executions are resumed
+	ExecutionChainStatusQueued ExecutionChainStatus = "queued"
+
 	// ExecutionChainStatusRunning indicates the chain is currently executing
 	// One or more steps are in progress or awaiting execution
 	ExecutionChainStatusRunning ExecutionChainStatus = "running"
@@ -62,6 +82,205 @@ const (
 	// ExecutionChainStatusPaused indicates execution was paused by success/failure action
 	// Chain can be resumed manually or by external trigger
 	ExecutionChainStatusPaused ExecutionChainStatus = "paused"
+
+	// ExecutionChainStatusInterrupted indicates the run was stopped by a server shutdown before finishing
+	// CurrentStep records where it stopped so the run can be resumed
+	ExecutionChainStatusInterrupted ExecutionChainStatus = "interrupted"
+
+	// ExecutionChainStatusCancelled indicates the run was cancelled before finishing
+	// Cancelled runs cannot be resumed
+	ExecutionChainStatusCancelled ExecutionChainStatus = "cancelled"
+
+	// ExecutionChainStatusAwaitingApproval indicates the run reached an approval step and waits for
+	// the step to be approved or rejected through the API
+	ExecutionChainStatusAwaitingApproval ExecutionChainStatus = "awaiting_approval"
+)
+
+// StepType defines what a chain step does when it is executed
+type StepType string
+
+const (
+	// StepTypeWebhook calls the step's webhook and moves on according to its success or failure action
+	StepTypeWebhook StepType = "webhook"
+
+	// StepTypeApproval notifies approvers through the step's webhook and pauses the run until the
+	// step is approved, which resumes the run, or rejected, which fails it
+	StepTypeApproval StepType = "approval"
+
+	// StepTypeDelay waits for the step's delay without calling a webhook
+	StepTypeDelay StepType = "delay"
+
+	// StepTypeTransform renders the step's request params into run variables, available to later
+	// steps as .vars, without calling a webhook
+	StepTypeTransform StepType = "transform"
+
+	// StepTypeBranch selects the first of the step's branches whose condition holds; steps on the
+	// other branches' paths are skipped
+	StepTypeBranch StepType = "branch"
+)
+
+// CallsWebhook reports whether steps of this type call the step's webhook
+func (t StepType) CallsWebhook() bool {
+	return t == "" || t == StepTypeWebhook || t == StepTypeApproval
+}
+
+// ApprovalDecision defines the outcome of an approval step
+type ApprovalDecision string
+
+const (
+	// ApprovalDecisionApproved indicates the approval step was approved and the run continued
+	ApprovalDecisionApproved ApprovalDecision = "approved"
+
+	// ApprovalDecisionRejected indicates the approval step was rejected and the run failed
+	ApprovalDecisionRejected ApprovalDecision = "rejected"
+)
+
+// CompensationStatus defines the state of undoing the completed steps of a failed run
+type CompensationStatus string
+
+const (
+	// CompensationStatusRunning indicates the compensation webhooks of the run are being called
+	CompensationStatusRunning CompensationStatus = "compensating"
+
+	// CompensationStatusCompleted indicates every completed step with a compensation webhook was undone
+	CompensationStatusCompleted CompensationStatus = "compensated"
+
+	// CompensationStatusFailed indicates at least one compensation webhook failed after its retries
+	// The failed compensations need to be undone manually
+	CompensationStatusFailed CompensationStatus = "compensation_failed"
+)
+
+// ScheduleOverlapPolicy defines what a scheduled trigger does while an earlier run of the chain is unfinished
+type ScheduleOverlapPolicy string
+
+const (
+	// ScheduleOverlapSkip drops the scheduled run while another run is queued or running
+	ScheduleOverlapSkip ScheduleOverlapPolicy = "skip"
+
+	// ScheduleOverlapQueue queues the scheduled run and starts it once the earlier runs have finished
+	ScheduleOverlapQueue ScheduleOverlapPolicy = "queue"
+
+	// ScheduleOverlapAllow starts the scheduled run alongside the unfinished runs
+	ScheduleOverlapAllow ScheduleOverlapPolicy = "allow"
+)
+
+// ScheduleTriggerEvent is the trigger event of runs started by a chain's schedule
+const ScheduleTriggerEvent = "schedule"
+
+// Default names of the headers signed webhook deliveries carry their signature and metadata in
+const (
+	DefaultSignatureHeader = "X-Shavix-Signature"
+	DefaultTimestampHeader = "X-Shavix-Timestamp"
+	DefaultAttemptHeader   = "X-Shavix-Attempt"
+)
+
+// Headers outbound webhook and chain step requests are correlated with our logs by
+const (
+	// DeliveryIDHeader carries the ID of the delivered message, the same for every attempt
+	DeliveryIDHeader = "X-Shavix-Delivery-Id"
+
+	// RequestIDHeader carries the ID of the API request that caused the delivery, when there is one
+	RequestIDHeader = "X-Request-ID"
+)
+
+// SigningHeaders overrides the names of the signature and event metadata headers of webhook deliveries
+// Lets receivers keep existing verification code that expects their own header names, e.g. X-Acme-Signature
+// Empty names fall back to the tenant's overrides and then to the defaults
+type SigningHeaders struct {
+	// Signature names the header carrying the HMAC signature ("sha256=<hex>")
+	Signature string `json:"signature,omitempty"`
+
+	// Timestamp names the header carrying the delivery time in RFC 3339 format
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Attempt names the header carrying the delivery attempt number
+	Attempt string `json:"attempt,omitempty"`
+}
+
+// Or returns the header names with empty names taken from fallback
+func (h SigningHeaders) Or(fallback SigningHeaders) SigningHeaders {
+	if h.Signature == "" {
+		h.Signature = fallback.Signature
+	}
+	if h.Timestamp == "" {
+		h.Timestamp = fallback.Timestamp
+	}
+	if h.Attempt == "" {
+		h.Attempt = fallback.Attempt
+	}
+	return h
+}
+
+// DefaultSigningHeaders returns the header names used when neither the subscription nor its tenant overrides them
+func DefaultSigningHeaders() SigningHeaders {
+	return SigningHeaders{
+		Signature: DefaultSignatureHeader,
+		Timestamp: DefaultTimestampHeader,
+		Attempt:   DefaultAttemptHeader,
+	}
+}
+
+// SignatureScheme selects how deliveries to a subscription are signed
+type SignatureScheme string
+
+const (
+	// SignatureSchemeLoki signs the body with "sha256=<hex HMAC>" in the signature header and an
+	// RFC 3339 timestamp header, under the subscription's or tenant's signing header names
+	SignatureSchemeLoki SignatureScheme = "loki"
+
+	// SignatureSchemeStandardWebhooks signs per the Standard Webhooks specification: webhook-id,
+	// webhook-timestamp (Unix seconds) and webhook-signature ("v1,<base64 HMAC>") headers, verifiable with
+	// the specification's libraries using the subscription's secret in whsec_ form
+	SignatureSchemeStandardWebhooks SignatureScheme = "standard_webhooks"
+)
+
+// IsValid reports whether the scheme is known; empty means SignatureSchemeLoki
+func (s SignatureScheme) IsValid() bool {
+	return s == "" || s == SignatureSchemeLoki || s == SignatureSchemeStandardWebhooks
+}
+
+// ContentEncoding selects how the bodies of deliveries to a subscription are compressed
+type ContentEncoding string
+
+const (
+	// ContentEncodingGzip compresses bodies with gzip and sends them with Content-Encoding: gzip
+	// Signatures cover the uncompressed body
+	ContentEncodingGzip ContentEncoding = "gzip"
+)
+
+// IsValid reports whether the encoding is known; empty sends bodies uncompressed
+func (e ContentEncoding) IsValid() bool {
+	return e == "" || e == ContentEncodingGzip
+}
+
+// TargetType selects how events are delivered to a subscription: through the delivery transport
+// registered for it, the built-in ones being listed below
+type TargetType string
+
+const (
+	// TargetTypeHTTP posts deliveries to the subscription's target URL
+	TargetTypeHTTP TargetType = "http"
+
+	// TargetTypeAMQP publishes deliveries to an exchange of the configured AMQP broker, with the signature
+	// and metadata headers of HTTP deliveries as message headers
+	TargetTypeAMQP TargetType = "amqp"
+
+	// TargetTypeSlack posts events to a Slack incoming webhook as Block Kit messages
+	TargetTypeSlack TargetType = "slack"
+
+	// TargetTypeTeams posts events to a Microsoft Teams incoming webhook or workflow as Adaptive Cards
+	TargetTypeTeams TargetType = "teams"
+
+	// TargetTypePagerDuty triggers PagerDuty alerts through the Events API v2
+	TargetTypePagerDuty TargetType = "pagerduty"
+
+	// TargetTypeEmail sends events as plain text email to the subscription's recipients through the
+	// configured SMTP server
+	TargetTypeEmail TargetType = "email"
+
+	// TargetTypeSMS sends events as text messages to the subscription's recipients through the
+	// configured SMS provider
+	TargetTypeSMS TargetType = "sms"
 )
 
 // WebhookSubscription represents a webhook subscription in the database
@@ -69,11 +288,11 @@ const (
 type WebhookSubscription struct {
 	// ID is the unique identifier for this webhook subscription
 	// Generated automatically using PostgreSQL's gen_random_uuid() function
-	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid();index:idx_webhook_subscriptions_tenant_created,priority:3"`
 
 	// TenantID identifies the tenant/organization that owns this webhook
 	// Used for multi-tenancy isolation and access control
-	TenantID string `json:"tenant_id" gorm:"index;not null"`
+	TenantID string `json:"tenant_id" gorm:"not null;index:idx_webhook_subscriptions_tenant_event_active,priority:1;index:idx_webhook_subscriptions_tenant_created,priority:1"`
 
 	// AppName identifies the application or service that created this webhook
 	// Used for organizing and filtering webhooks by source application
@@ -87,21 +306,40 @@ type WebhookSubscription struct {
 	// Must be a valid HTTP/HTTPS URL accessible by the webhook service
 	TargetURL string `json:"target_url" gorm:"not null"`
 
+	// TargetType selects how events are delivered; empty for subscriptions created before target types
+	// existed, which are delivered over HTTP
+	TargetType TargetType `json:"target_type,omitempty" gorm:"type:varchar(16)"`
+
+	// AMQPExchange is the exchange deliveries to AMQP targets are published to; empty is the default exchange
+	AMQPExchange string `json:"amqp_exchange,omitempty"`
+
+	// AMQPRoutingKey is the routing key deliveries to AMQP targets are published with
+	AMQPRoutingKey string `json:"amqp_routing_key,omitempty"`
+
+	// Notification describes the messages Slack, Teams, PagerDuty, email and SMS targets receive; nil uses the defaults
+	Notification *NotificationTemplate `json:"notification,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// Recipients are the email addresses of email targets or the E.164 phone numbers of SMS targets
+	Recipients []string `json:"recipients,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// PagerDutyRoutingKey is the integration key PagerDuty targets trigger alerts with; encrypted at rest
+	PagerDutyRoutingKey *string `json:"-" gorm:"column:pagerduty_routing_key;type:text;serializer:encrypted"`
+
 	// SubscribedEvent specifies which event type this webhook should receive
 	// Acts as a filter to determine which events trigger this webhook
-	SubscribedEvent string `json:"subscribed_event" gorm:"not null"`
+	SubscribedEvent string `json:"subscribed_event" gorm:"not null;index:idx_webhook_subscriptions_tenant_event_active,priority:2"`
 
 	// Type determines the security model (public with HMAC or private with HMAC+JWT)
 	// Affects authentication requirements and security headers sent with webhooks
 	Type WebhookType `json:"type" gorm:"not null"`
 
 	// SecretToken is the HMAC secret used for signature verification
-	// Hidden from JSON responses for security, used to generate X-Shavix-Signature header
-	SecretToken string `json:"-" gorm:"not null"`
+	// Hidden from JSON responses for security, used to generate the signature header; encrypted at rest
+	SecretToken string `json:"-" gorm:"not null;serializer:encrypted"`
 
 	// JWTToken contains the JWT for private webhook authentication
-	// Only populated for private webhooks, sent in Authorization header
-	JWTToken *string `json:"-" gorm:"type:text"`
+	// Only populated for private webhooks, sent in Authorization header; encrypted at rest
+	JWTToken *string `json:"-" gorm:"type:text;serializer:encrypted"`
 
 	// RetryCount tracks the number of failed delivery attempts
 	// Used for implementing retry policies and delivery statistics
@@ -115,29 +353,78 @@ type WebhookSubscription struct {
 	// Allows subscribers to control the backoff strategy for retries
 	RetryDelaySeconds int `json:"retry_delay_seconds" gorm:"default:5"`
 
+	// BatchMaxEvents is the most events delivered in one batch, MaxBatchEvents when 0
+	BatchMaxEvents int `json:"batch_max_events,omitempty" gorm:"default:0"`
+
+	// BatchWindowSeconds is how long the oldest buffered event waits for its batch to fill up
+	// Deliveries are batched when it is set and sent one event at a time when it is 0
+	BatchWindowSeconds int `json:"batch_window_seconds,omitempty" gorm:"default:0"`
+
+	// ContentEncoding compresses the bodies of deliveries, sent uncompressed when empty
+	ContentEncoding ContentEncoding `json:"content_encoding,omitempty" gorm:"type:varchar(16)"`
+
 	// QueryParams is an optional map of query parameters included in webhook requests
 	// Allows subscribers to specify additional parameters for the webhook URL
-	QueryParams map[string]string `json:"query_params,omitempty" gorm:"type:jsonb"`
+	QueryParams map[string]string `json:"query_params,omitempty" gorm:"type:jsonb;serializer:json"`
 
 	// Headers is an optional map of custom headers to include in webhook requests
 	// Allows subscribers to specify additional metadata or authentication headers
-	Headers map[string]string `json:"headers,omitempty" gorm:"type:jsonb"`
+	Headers map[string]string `json:"headers,omitempty" gorm:"type:jsonb;serializer:json"`
 
 	// Payload contains additional static data to be included with each webhook delivery
 	// Stored as JSONB and merged with event payload when sending webhooks
 	Payload string `json:"payload,omitempty" gorm:"type:jsonb"`
 
+	// ResponseSchema is an optional JSON Schema that receiver responses must conform to
+	// A 2xx response whose body violates it is treated as a failed delivery
+	ResponseSchema *string `json:"response_schema,omitempty" gorm:"type:jsonb"`
+
+	// SigningHeaders overrides the tenant's signature and metadata header names for this subscription
+	// Stored as signing_signature, signing_timestamp and signing_attempt columns
+	SigningHeaders SigningHeaders `json:"signing_headers" gorm:"embedded;embeddedPrefix:signing_"`
+
+	// SignatureScheme selects how deliveries are signed; empty for subscriptions created before schemes
+	// existed, which are signed like SignatureSchemeLoki
+	SignatureScheme SignatureScheme `json:"signature_scheme,omitempty" gorm:"type:varchar(32)"`
+
+	// AllowedSourceIPs restricts which IPs or CIDR ranges may post to the receive endpoint of this webhook
+	// Empty allows every source; checked in addition to the installation-wide receive allowlist
+	AllowedSourceIPs []string `json:"allowed_source_ips,omitempty" gorm:"type:jsonb;serializer:json"`
+
 	// IsActive controls whether this webhook should receive events
 	// Allows temporary disabling without deleting the subscription
-	IsActive bool `json:"is_active" gorm:"default:true"`
+	IsActive bool `json:"is_active" gorm:"default:true;index:idx_webhook_subscriptions_tenant_event_active,priority:3"`
+
+	// DisabledAt timestamp when the tenant's auto-disable policy deactivated the subscription
+	// Cleared when the subscription is enabled again
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+
+	// DisabledReason records why the subscription was deactivated
+	DisabledReason string `json:"disabled_reason,omitempty"`
+
+	// ReenabledAt timestamp when the subscription was last enabled again
+	// Failures before it are not counted by the auto-disable policy again
+	ReenabledAt *time.Time `json:"reenabled_at,omitempty"`
+
+	// PausedUntil is set while the receiver asked for deliveries to be paused via the pause header
+	// Deliveries are queued while it is set and released once it has passed
+	PausedUntil *time.Time `json:"paused_until,omitempty" gorm:"index"`
+
+	// LockVersion is incremented by every change of the subscription's configuration or state; an update made
+	// at a lock version the subscription is no longer at fails, so concurrent edits don't overwrite each other
+	LockVersion int64 `json:"lock_version" gorm:"not null;default:1"`
 
 	// CreatedAt timestamp when the subscription was first created
 	// Automatically managed by GORM for audit trails
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_webhook_subscriptions_tenant_created,priority:2"`
 
 	// UpdatedAt timestamp when the subscription was last modified
 	// Automatically updated by GORM on any field changes
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// DeletedAt timestamp when the subscription was deleted; GORM excludes deleted subscriptions from queries
+	// Deleted subscriptions receive no deliveries and can be restored until they are purged
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
 }
 
 // WebhookEvent represents a webhook event in the database
@@ -145,11 +432,11 @@ type WebhookSubscription struct {
 type WebhookEvent struct {
 	// ID is the unique identifier for this webhook event
 	// Generated automatically for tracking individual event deliveries
-	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid();index:idx_webhook_events_tenant_created,priority:3"`
 
 	// TenantID identifies the tenant that generated this event
 	// Used for isolation and filtering events by tenant
-	TenantID string `json:"tenant_id" gorm:"index;not null"`
+	TenantID string `json:"tenant_id" gorm:"not null;index:idx_webhook_events_tenant_created,priority:1"`
 
 	// EventName specifies the type of event that occurred
 	// Used to match against webhook subscriptions and trigger appropriate handlers
@@ -183,9 +470,29 @@ type WebhookEvent struct {
 	// Only set when at least one webhook delivery succeeds
 	SentAt *time.Time `json:"sent_at"`
 
+	// DeliverAt timestamp when a scheduled event is due for delivery
+	// Only set for events sent with a delivery time or delay
+	DeliverAt *time.Time `json:"deliver_at,omitempty" gorm:"index"`
+
+	// ExpiresAt timestamp after which the event is no longer delivered
+	// Only set for events sent with an expiry
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// OrderingKey serializes the event's deliveries with those of earlier events with the same key
+	// Empty for events sent without one
+	OrderingKey string `json:"ordering_key,omitempty"`
+
+	// Priority level of the event, see Priority.Level: 10 for high, 0 for normal and -10 for low
+	// Due scheduled events are dispatched highest priority first
+	Priority int `json:"priority" gorm:"not null;default:0"`
+
+	// HeldAt timestamp when the event was held back by a delivery pause, see DeliveryPause
+	// Cleared when the event is released for delivery after the pause is resumed
+	HeldAt *time.Time `json:"held_at,omitempty" gorm:"index"`
+
 	// CreatedAt timestamp when the event was first created
 	// Automatically managed by GORM for audit trails
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_webhook_events_tenant_created,priority:2"`
 
 	// UpdatedAt timestamp when the event was last modified
 	// Updated when delivery status or attempts change
@@ -197,11 +504,11 @@ type WebhookEvent struct {
 type ExecutionChain struct {
 	// ID is the unique identifier for this execution chain
 	// Generated automatically for referencing and managing chains
-	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid();index:idx_execution_chains_tenant_created,priority:3"`
 
 	// TenantID identifies the tenant that owns this execution chain
 	// Used for multi-tenancy isolation and access control
-	TenantID string `json:"tenant_id" gorm:"index;not null"`
+	TenantID string `json:"tenant_id" gorm:"not null;index:idx_execution_chains_tenant_created,priority:1"`
 
 	// Name is a human-readable identifier for this chain
 	// Used in management interfaces and logging for easy identification
@@ -223,14 +530,54 @@ type ExecutionChain struct {
 	// Allows temporary disabling of workflows without deletion
 	IsActive bool `json:"is_active" gorm:"default:true"`
 
+	// PausedAt timestamp when the chain was paused through the pause endpoint
+	// Runs of a paused chain queued by a tenant-wide pause wait for the chain to be activated
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+
+	// PauseReason records why the chain was paused
+	PauseReason *string `json:"pause_reason,omitempty"`
+
+	// Version is the number of the chain's current step version
+	// Incremented whenever the steps change; 0 for chains created before versioning
+	Version int `json:"version" gorm:"not null;default:0"`
+
+	// LockVersion is incremented by every change of the chain's properties, schedule or steps; updates name
+	// the lock version they were based on and fail once the chain moved past it, instead of overwriting
+	// a concurrent edit
+	LockVersion int64 `json:"lock_version" gorm:"not null;default:1"`
+
+	// Schedule is an optional cron expression on which the chain is triggered in addition to its trigger event
+	// Five fields (minute hour day-of-month month day-of-week) or a macro such as @hourly
+	Schedule string `json:"schedule,omitempty"`
+
+	// ScheduleTimezone is the IANA time zone the schedule is evaluated in, UTC when empty
+	ScheduleTimezone string `json:"schedule_timezone,omitempty"`
+
+	// OverlapPolicy controls scheduled runs while an earlier run is unfinished, skip when empty
+	OverlapPolicy ScheduleOverlapPolicy `json:"overlap_policy,omitempty"`
+
+	// NextRunAt timestamp of the next scheduled run; nil for chains without a schedule
+	NextRunAt *time.Time `json:"next_run_at,omitempty" gorm:"index"`
+
+	// LastScheduledAt timestamp of the most recent scheduled trigger
+	LastScheduledAt *time.Time `json:"last_scheduled_at,omitempty"`
+
+	// CompletionWebhookID references the webhook that receives a signed summary of every run once it finishes
+	// Lets callers learn a run's outcome without polling the run
+	CompletionWebhookID *uuid.UUID `json:"completion_webhook_id,omitempty" gorm:"type:uuid"`
+
 	// CreatedAt timestamp when the chain was first created
 	// Automatically managed by GORM for audit trails
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_execution_chains_tenant_created,priority:2"`
 
 	// UpdatedAt timestamp when the chain configuration was last modified
 	// Updated when chain properties or steps are changed
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// DeletedAt timestamp when the chain was deleted; GORM excludes deleted chains from queries
+	// Deleted chains are not triggered and keep their steps, versions and runs until they are purged
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
+
 	// Steps contains the ordered list of webhook calls in this chain
 	// Foreign key relationship with cascading delete for data consistency
 	Steps []ExecutionChainStep `json:"steps" gorm:"foreignKey:ChainID;constraint:OnDelete:CASCADE"`
@@ -252,8 +599,8 @@ type ExecutionChainStep struct {
 	StepOrder int `json:"step_order" gorm:"not null"`
 
 	// WebhookID references the webhook subscription to call in this step
-	// Links to the actual webhook endpoint configuration and security settings
-	WebhookID uuid.UUID `json:"webhook_id" gorm:"type:uuid;not null"`
+	// Nil for delay, transform and branch steps, which do not call a webhook
+	WebhookID *uuid.UUID `json:"webhook_id,omitempty" gorm:"type:uuid"`
 
 	// Name is a human-readable identifier for this step
 	// Used for logging, debugging, and management interfaces
@@ -263,10 +610,52 @@ type ExecutionChainStep struct {
 	// Optional field for documenting the step's role in the workflow
 	Description string `json:"description"`
 
+	// Type defines what the step does: "webhook" calls the webhook, "approval" calls it to notify
+	// approvers and then waits for an approval decision
+	Type StepType `json:"type" gorm:"default:'webhook'"`
+
 	// RequestParams contains additional parameters to include in the webhook call
 	// Stored as JSONB for flexible parameter passing and merging with event data
 	RequestParams string `json:"request_params" gorm:"type:jsonb"`
 
+	// ResponseSchema is an optional JSON Schema the step's receiver response must conform to
+	// Overrides the webhook subscription's schema; a violation fails the step attempt
+	ResponseSchema *string `json:"response_schema,omitempty" gorm:"type:jsonb"`
+
+	// Condition is an optional boolean expression over trigger data and earlier step results
+	// When it evaluates to false the step is skipped and the chain moves on to the next step
+	Condition string `json:"condition,omitempty"`
+
+	// ParallelGroup names the parallel group of the step; consecutive steps in the same group run
+	// concurrently and the chain moves on once all of them have finished
+	ParallelGroup string `json:"parallel_group,omitempty"`
+
+	// Key is an optional identifier of the step, unique within its chain, that other steps reference in depends_on
+	Key string `json:"key,omitempty"`
+
+	// DependsOn lists the IDs of the steps that must finish before this step starts
+	// When any step of a chain declares dependencies the chain runs as a graph instead of in step order
+	DependsOn []uuid.UUID `json:"depends_on,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// Branches lists the paths a branch step chooses from, in evaluation order
+	Branches []StepBranch `json:"branches,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// BranchPath places the step on a path of an earlier branch step, as "<branch step key>/<branch name>"
+	// The step is skipped unless the branch step chose that path
+	BranchPath string `json:"branch_path,omitempty"`
+
+	// Extract maps run variable names to paths in the step's result, e.g. "response.payment_id" or "status_code"
+	// Applied when the step succeeds; later steps read the variables as .vars.<name>
+	Extract map[string]string `json:"extract,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// CompensationWebhookID references the webhook that undoes this step, e.g. refunding a captured payment
+	// When a later step fails the run, compensations of completed steps are called in reverse step order
+	CompensationWebhookID *uuid.UUID `json:"compensation_webhook_id,omitempty" gorm:"type:uuid"`
+
+	// CompensationParams contains the request parameters of the compensation webhook call
+	// Rendered like RequestParams, so they can reference this step's own response
+	CompensationParams *string `json:"compensation_params,omitempty" gorm:"type:jsonb"`
+
 	// OnSuccessAction defines what to do when this step succeeds
 	// Options: "continue" (next step), "stop" (end chain), "pause" (wait for manual resume)
 	OnSuccessAction string `json:"on_success_action" gorm:"default:'continue'"`
@@ -295,13 +684,20 @@ type ExecutionChainStep struct {
 	// Updated when step parameters or behavior settings change
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// RetiredAt timestamp when the step was removed or replaced by a step update
+	// Retired steps are no longer executed but kept so historical step runs still reference their definition
+	RetiredAt *time.Time `json:"retired_at,omitempty" gorm:"index"`
+
 	// Chain provides access to the parent execution chain
 	// Relationship for accessing chain-level configuration and metadata
 	Chain ExecutionChain `json:"-" gorm:"foreignKey:ChainID"`
 
 	// Webhook provides access to the webhook subscription details
 	// Includes target URL, security credentials, and delivery configuration
-	Webhook WebhookSubscription `json:"webhook" gorm:"foreignKey:WebhookID"`
+	Webhook *WebhookSubscription `json:"webhook,omitempty" gorm:"foreignKey:WebhookID"`
+
+	// CompensationWebhook provides access to the compensation webhook subscription, if any
+	CompensationWebhook *WebhookSubscription `json:"compensation_webhook,omitempty" gorm:"foreignKey:CompensationWebhookID"`
 }
 
 // ExecutionChainRun represents a single execution instance of an execution chain
@@ -309,15 +705,15 @@ type ExecutionChainStep struct {
 type ExecutionChainRun struct {
 	// ID is the unique identifier for this execution run
 	// Generated automatically for tracking individual workflow executions
-	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid();index:idx_execution_chain_runs_chain_created,priority:3;index:idx_execution_chain_runs_tenant_created,priority:3"`
 
 	// ChainID links this run to the execution chain being executed
 	// References the workflow definition and configuration
-	ChainID uuid.UUID `json:"chain_id" gorm:"type:uuid;not null"`
+	ChainID uuid.UUID `json:"chain_id" gorm:"type:uuid;not null;index:idx_execution_chain_runs_chain_completed,priority:1;index:idx_execution_chain_runs_chain_created,priority:1"`
 
 	// TenantID identifies the tenant that owns this execution
 	// Used for isolation and access control of execution results
-	TenantID string `json:"tenant_id" gorm:"index;not null"`
+	TenantID string `json:"tenant_id" gorm:"not null;index:idx_execution_chain_runs_tenant_created,priority:1"`
 
 	// Status tracks the current state of this execution run
 	// Indicates whether the workflow is pending, running, completed, failed, or paused
@@ -327,6 +723,10 @@ type ExecutionChainRun struct {
 	// Provides context about what caused the workflow to start
 	TriggerEvent string `json:"trigger_event"`
 
+	// ChainVersion pins the run to the chain version it started on
+	// Resumed runs keep executing the steps of this version even if the chain changed since
+	ChainVersion int `json:"chain_version" gorm:"not null;default:0"`
+
 	// TriggerData contains the original event payload that started this execution
 	// Stored as JSONB for passing context data through the workflow steps
 	TriggerData string `json:"trigger_data" gorm:"type:jsonb"`
@@ -345,15 +745,70 @@ type ExecutionChainRun struct {
 
 	// CompletedAt timestamp when the execution finished (success or failure)
 	// Set when the workflow reaches a terminal state
-	CompletedAt *time.Time `json:"completed_at"`
+	CompletedAt *time.Time `json:"completed_at" gorm:"index:idx_execution_chain_runs_chain_completed,priority:2"`
 
 	// LastError contains the error message from the most recent step failure
 	// Provides diagnostic information for troubleshooting workflow issues
 	LastError *string `json:"last_error"`
 
+	// CancelReason records why the run was cancelled
+	// Only set for runs in the cancelled status
+	CancelReason *string `json:"cancel_reason,omitempty"`
+
+	// CancelledAt timestamp when the run was cancelled
+	// Only set for runs in the cancelled status
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+
+	// Variables is the run-scoped variable store written by transform steps and step extraction rules
+	// Saved whenever a variable changes; returned with the run's step results by the outputs endpoint
+	Variables map[string]interface{} `json:"variables,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// CompensationStatus tracks undoing the completed steps after the run failed
+	// Empty when the run did not fail or none of its completed steps has a compensation webhook
+	CompensationStatus CompensationStatus `json:"compensation_status,omitempty"`
+
+	// CallbackURL is the URL passed with a manual execution that receives the run's summary once it finishes
+	CallbackURL *string `json:"callback_url,omitempty"`
+
+	// CallbackSecret signs the summary sent to CallbackURL; returned only by the execution request
+	// Encrypted at rest
+	CallbackSecret string `json:"-" gorm:"serializer:encrypted"`
+
+	// CallbackStatus tracks sending the run's summary to the chain's completion webhook and the callback URL
+	// Sent once every one of them accepted it; empty when the run has neither
+	CallbackStatus WebhookStatus `json:"callback_status,omitempty"`
+
+	// CallbackError contains the error of the last summary delivery that failed after its retries
+	CallbackError *string `json:"callback_error,omitempty"`
+
+	// WorkerID identifies the server instance executing the run
+	// Used by run recovery to tell runs of a crashed instance from runs still executing elsewhere
+	WorkerID string `json:"worker_id,omitempty"`
+
+	// HeartbeatAt timestamp of the last sign of life from the instance executing the run
+	// Running runs whose heartbeat is older than the recovery lease are re-queued by another instance
+	HeartbeatAt *time.Time `json:"heartbeat_at,omitempty" gorm:"index"`
+
+	// Priority orders queued runs: higher priorities are admitted first, equal ones oldest first
+	Priority int `json:"priority" gorm:"not null;default:0"`
+
+	// Options holds the execution options the run was started with, applied again when it is resumed
+	Options *RunOptions `json:"options,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// RetryOfRunID references the failed run this run continues from its failed step
+	RetryOfRunID *uuid.UUID `json:"retry_of_run_id,omitempty" gorm:"type:uuid;index"`
+
+	// QueuePosition is the 1-based position of a queued run in the admission queue
+	// Computed when the run is retrieved, not stored
+	QueuePosition int `json:"queue_position,omitempty" gorm:"-"`
+
+	// Resources is the wall time, attempts and payload sizes of the run and each of its steps
+	// Computed when the run is retrieved, not stored
+	Resources *RunResources `json:"resources,omitempty" gorm:"-"`
+
 	// CreatedAt timestamp when the run was first created
 	// Automatically managed by GORM for audit trails
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_execution_chain_runs_chain_created,priority:2;index:idx_execution_chain_runs_tenant_created,priority:2"`
 
 	// UpdatedAt timestamp when the run status was last modified
 	// Updated as steps execute and the workflow progresses
@@ -366,6 +821,10 @@ type ExecutionChainRun struct {
 	// StepRuns contains the execution results for each step in this run
 	// Foreign key relationship with cascading delete for data consistency
 	StepRuns []ExecutionChainStepRun `json:"step_runs" gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE"`
+
+	// Compensations contains the results of the compensation webhook calls made after the run failed
+	// Kept apart from StepRuns so step results always describe the forward execution
+	Compensations []ExecutionChainCompensationRun `json:"compensations,omitempty" gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE"`
 }
 
 // ExecutionChainStepRun represents the execution of a single step within a workflow run
@@ -377,7 +836,7 @@ type ExecutionChainStepRun struct {
 
 	// RunID links this step execution to its parent workflow run
 	// Foreign key relationship for maintaining execution hierarchy
-	RunID uuid.UUID `json:"run_id" gorm:"type:uuid;not null"`
+	RunID uuid.UUID `json:"run_id" gorm:"type:uuid;not null;index:idx_execution_chain_step_runs_run_order,priority:1"`
 
 	// StepID references the step definition that was executed
 	// Links to the configuration and parameters for this step
@@ -385,7 +844,7 @@ type ExecutionChainStepRun struct {
 
 	// StepOrder indicates the position of this step in the execution sequence
 	// Copied from the step definition for easier querying and sorting
-	StepOrder int `json:"step_order"`
+	StepOrder int `json:"step_order" gorm:"index:idx_execution_chain_step_runs_run_order,priority:2"`
 
 	// Status tracks the delivery status of this step's webhook call
 	// Indicates whether the step is pending, sent successfully, or failed
@@ -407,6 +866,13 @@ type ExecutionChainStepRun struct {
 	// Incremented on each retry until successful or max retries reached
 	AttemptCount int `json:"attempt_count" gorm:"default:0"`
 
+	// RequestBytes is the total size of the requests sent over all attempts
+	RequestBytes int64 `json:"request_bytes" gorm:"default:0"`
+
+	// ResponseBytes is the total size of the answers received over all attempts, each read up to 1 MiB
+	// For built-in steps it is the size of the step's output
+	ResponseBytes int64 `json:"response_bytes" gorm:"default:0"`
+
 	// LastError contains the error message from the most recent failed attempt
 	// Provides diagnostic information for troubleshooting step failures
 	LastError *string `json:"last_error"`
@@ -419,6 +885,10 @@ type ExecutionChainStepRun struct {
 	// Set when the step reaches a terminal state
 	CompletedAt *time.Time `json:"completed_at"`
 
+	// Approval records the decision on an approval step
+	// Nil for webhook steps and for approval steps still awaiting a decision
+	Approval *StepApproval `json:"approval,omitempty" gorm:"type:jsonb;serializer:json"`
+
 	// CreatedAt timestamp when the step run was first created
 	// Automatically managed by GORM for audit trails
 	CreatedAt time.Time `json:"created_at"`
@@ -436,6 +906,102 @@ type ExecutionChainStepRun struct {
 	Step ExecutionChainStep `json:"step" gorm:"foreignKey:StepID"`
 }
 
+// StepBranch is a path a branch step can choose
+type StepBranch struct {
+	// Name identifies the path; steps join it through their branch_path
+	Name string `json:"name"`
+
+	// Condition selects the path when it holds; a branch without a condition is always selected
+	// when reached, so it serves as the default when listed last
+	Condition string `json:"condition,omitempty"`
+}
+
+// RunOptions are the per-run execution options of a manual execution
+// Used to reprocess part of a chain without editing it
+type RunOptions struct {
+	// StepsToSkip lists the orders of the steps the run skips
+	StepsToSkip []int `json:"steps_to_skip,omitempty"`
+
+	// StartAtStep is the order of the step the run starts at; earlier steps are not executed
+	StartAtStep int `json:"start_at_step,omitempty"`
+
+	// OverrideParams maps "step_N" to request params merged over those of step N before rendering
+	OverrideParams map[string]map[string]interface{} `json:"override_params,omitempty"`
+
+	// SourceRunID references the earlier run whose results of the steps before StartAtStep the run reuses
+	SourceRunID *uuid.UUID `json:"source_run_id,omitempty"`
+}
+
+// StepApproval records who approved or rejected an approval step, and why
+type StepApproval struct {
+	// Decision is the outcome of the approval step
+	Decision ApprovalDecision `json:"decision"`
+
+	// Approver identifies the person or system that decided, as given in the decision request
+	Approver string `json:"approver,omitempty"`
+
+	// Comment explains the decision
+	Comment string `json:"comment,omitempty"`
+
+	// Metadata holds additional caller-defined details such as a ticket reference
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// CredentialID identifies the API credential the decision was submitted with
+	// Nil for the bootstrap admin key
+	CredentialID *uuid.UUID `json:"credential_id,omitempty"`
+
+	// DecidedAt timestamp when the decision was submitted
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// ExecutionChainCompensationRun represents the call of a step's compensation webhook after its run failed
+type ExecutionChainCompensationRun struct {
+	// ID is the unique identifier for this compensation call
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// RunID links this compensation to the failed run
+	RunID uuid.UUID `json:"run_id" gorm:"type:uuid;not null;index"`
+
+	// StepID references the completed step being undone
+	StepID uuid.UUID `json:"step_id" gorm:"type:uuid;not null"`
+
+	// StepOrder is the position of the compensated step; compensations run in descending step order
+	StepOrder int `json:"step_order"`
+
+	// WebhookID references the compensation webhook that was called
+	WebhookID uuid.UUID `json:"webhook_id" gorm:"type:uuid;not null"`
+
+	// Status tracks the delivery status of the compensation webhook call
+	Status WebhookStatus `json:"status" gorm:"default:'pending'"`
+
+	// RequestPayload contains the rendered compensation params sent to the webhook
+	RequestPayload string `json:"request_payload" gorm:"type:jsonb"`
+
+	// ResponseCode stores the HTTP status code returned by the compensation webhook
+	ResponseCode *int `json:"response_code"`
+
+	// ResponseBody contains the response body returned by the compensation webhook
+	ResponseBody *string `json:"response_body" gorm:"type:text"`
+
+	// AttemptCount tracks the number of delivery attempts made, bounded by the step's MaxRetries
+	AttemptCount int `json:"attempt_count" gorm:"default:0"`
+
+	// LastError contains the error message from the most recent failed attempt
+	LastError *string `json:"last_error"`
+
+	// StartedAt timestamp when the compensation call began
+	StartedAt *time.Time `json:"started_at"`
+
+	// CompletedAt timestamp when the compensation succeeded or finally failed
+	CompletedAt *time.Time `json:"completed_at"`
+
+	// CreatedAt timestamp when the compensation run was first created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt timestamp when the compensation run was last modified
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // TableName sets the table name for WebhookSubscription
 func (WebhookSubscription) TableName() string {
 	return "webhook_subscriptions"
@@ -465,3 +1031,8 @@ func (ExecutionChainRun) TableName() string {
 func (ExecutionChainStepRun) TableName() string {
 	return "execution_chain_step_runs"
 }
+
+// TableName sets the table name for ExecutionChainCompensationRun
+func (ExecutionChainCompensationRun) TableName() string {
+	return "execution_chain_compensation_runs"
+}
@@ -0,0 +1,143 @@
+// Package objectstore stores objects in S3-compatible object storage such as AWS S3, MinIO or Cloudflare R2
+// over github.com/aws/aws-sdk-go-v2, and shares them through presigned URLs
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// DefaultRegion is the region of configurations without one
+	DefaultRegion = "us-east-1"
+
+	// MaxPresignExpiry is the longest a presigned URL can stay valid
+	MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// S3Config configures the bucket objects are stored in
+type S3Config struct {
+	// Endpoint is the base URL of the service, the AWS endpoint of the region if empty
+	Endpoint string
+
+	// Region is the region of the bucket, DefaultRegion if empty
+	Region string
+
+	// Bucket is the bucket objects are stored in
+	Bucket string
+
+	// AccessKeyID and SecretAccessKey authenticate requests; SessionToken is set for temporary credentials
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// PathStyle addresses the bucket in the path (endpoint/bucket/key) instead of the host name
+	// (bucket.endpoint/key), as MinIO and most self-hosted services need
+	PathStyle bool
+}
+
+// S3 stores objects in a bucket of an S3-compatible service
+type S3 struct {
+	bucket string
+	client *s3.Client
+}
+
+// NewS3 creates a store for a bucket; a nil client uses one with a 30 second timeout
+// Returns:
+//   - error: If the bucket or the credentials are missing, or the endpoint is not an HTTP(S) URL
+func NewS3(cfg S3Config, client *http.Client) (*S3, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("objectstore: bucket is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("objectstore: access key ID and secret access key are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultRegion
+	}
+	var endpoint *string
+	if cfg.Endpoint != "" {
+		base, err := url.Parse(cfg.Endpoint)
+		if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+			return nil, fmt.Errorf("objectstore: invalid endpoint %q", cfg.Endpoint)
+		}
+		endpoint = aws.String(strings.TrimSuffix(cfg.Endpoint, "/"))
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &S3{
+		bucket: cfg.Bucket,
+		client: s3.New(s3.Options{
+			Region:       cfg.Region,
+			BaseEndpoint: endpoint,
+			UsePathStyle: cfg.PathStyle,
+			Credentials: aws.NewCredentialsCache(
+				credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken)),
+			HTTPClient: client,
+			// Checksums only where S3 requires them, as not every S3-compatible service accepts the SDK's defaults
+			RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+			ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+		}),
+	}, nil
+}
+
+// Put stores an object, replacing any object stored under the key
+func (s *S3) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	if _, err := s.client.PutObject(ctx, input); err != nil {
+		return fmt.Errorf("objectstore: failed to store %s: %w", key, err)
+	}
+	return nil
+}
+
+// PresignGet returns a URL anyone can download an object with until it expires, at most MaxPresignExpiry
+// after now; the URL is signed locally without contacting the service
+func (s *S3) PresignGet(key string, now time.Time, expires time.Duration) (string, error) {
+	if expires <= 0 || expires > MaxPresignExpiry {
+		return "", fmt.Errorf("objectstore: presigned URLs expire within %s", MaxPresignExpiry)
+	}
+
+	presigner := s3.NewPresignClient(s.client, s3.WithPresignExpires(expires), func(o *s3.PresignOptions) {
+		o.Presigner = signedAt{signer: v4.NewSigner(func(so *v4.SignerOptions) { so.DisableURIPathEscaping = true }), at: now}
+	})
+	request, err := presigner.PresignGetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("objectstore: failed to presign %s: %w", key, err)
+	}
+	return request.URL, nil
+}
+
+// signedAt presigns requests as of a fixed time rather than the time of signing, so URLs expire relative to
+// the caller's clock
+type signedAt struct {
+	signer *v4.Signer
+	at     time.Time
+}
+
+func (p signedAt) PresignHTTP(ctx context.Context, creds aws.Credentials, r *http.Request, payloadHash, service, region string,
+	_ time.Time, optFns ...func(*v4.SignerOptions)) (string, http.Header, error) {
+	return p.signer.PresignHTTP(ctx, creds, r, payloadHash, service, region, p.at, optFns...)
+}
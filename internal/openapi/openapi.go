@@ -0,0 +1,316 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Document is an OpenAPI 3.0 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag groups operations in the document
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of one path, keyed by lowercase HTTP method
+type PathItem map[string]*OperationObject
+
+// OperationObject describes a single API operation
+type OperationObject struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+
+	// RequiredRole is the minimum credential role the operation requires
+	RequiredRole string `json:"x-required-role,omitempty"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of an operation
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes one response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType pairs a content type with its schema
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas and security schemes of the document
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how clients authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Operation documents one route for Build
+// Request and Response are zero values of the DTOs the handler binds and returns; nil for none
+type Operation struct {
+	Tag         string
+	Summary     string
+	Description string
+
+	// Role is the minimum credential role, empty for unauthenticated routes
+	Role string
+
+	// Parameters lists query and header parameters; path parameters not listed are added as strings
+	Parameters []Parameter
+
+	Request  interface{}
+	Response interface{}
+
+	// OptionalRequest marks the request body as optional
+	OptionalRequest bool
+
+	// Status is the success status code, 200 when zero
+	Status int
+
+	// ResponseType is the content type of the success response, application/json when empty
+	ResponseType string
+
+	// Responses documents further responses by status code, such as an alternative success; those of error
+	// statuses are documented as problem details
+	Responses map[int]interface{}
+}
+
+// Security scheme names of the document
+const (
+	apiKeyScheme = "apiKey"
+	bearerScheme = "bearerAuth"
+)
+
+// problemContentType is the content type of error responses, RFC 7807 problem details
+const problemContentType = "application/problem+json"
+
+// pathParamPattern matches gin path parameters such as :id or *filepath
+var pathParamPattern = regexp.MustCompile(`[:*](\w+)`)
+
+// handlerPattern extracts controller and method names from gin handler names
+var handlerPattern = regexp.MustCompile(`\(\*(\w+?)(?:Controller)?\)\.(\w+)-fm$`)
+
+// Build generates the OpenAPI document of the registered routes
+// Parameters:
+//   - info: Title, description and version of the API
+//   - tags: Tags in display order
+//   - routes: Routes registered on the engine; every route is documented, HEAD and OPTIONS excepted
+//   - operations: Documentation per route, keyed by "METHOD /path" with gin-style parameters
+//   - errorResponse: Zero value of the problem details DTO returned by failing operations
+//
+// Returns:
+//   - *Document: The generated document
+func Build(info Info, tags []Tag, routes gin.RoutesInfo, operations map[string]Operation, errorResponse interface{}) *Document {
+	generator := NewGenerator()
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Tags:    tags,
+		Paths:   make(map[string]PathItem),
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	errorSchema := generator.SchemaOf(errorResponse)
+	for _, route := range routes {
+		if route.Method == http.MethodHead || route.Method == http.MethodOptions {
+			continue
+		}
+
+		op := operations[route.Method+" "+route.Path]
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = buildOperation(generator, route, op, errorSchema)
+	}
+
+	doc.Components = Components{
+		Schemas: generator.Schemas(),
+		SecuritySchemes: map[string]SecurityScheme{
+			apiKeyScheme: {
+				Type:        "apiKey",
+				Name:        "X-API-Key",
+				In:          "header",
+				Description: "API key of a credential",
+			},
+			bearerScheme: {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "Token issued by POST /api/credentials/{id}/token",
+			},
+		},
+	}
+	return doc
+}
+
+// buildOperation documents a single route
+func buildOperation(generator *Generator, route gin.RouteInfo, op Operation, errorSchema *Schema) *OperationObject {
+	operation := &OperationObject{
+		Summary:     op.Summary,
+		Description: op.Description,
+		Parameters:  append([]Parameter(nil), op.Parameters...),
+		Responses:   make(map[string]Response),
+	}
+	if op.Tag != "" {
+		operation.Tags = []string{op.Tag}
+	}
+	if match := handlerPattern.FindStringSubmatch(route.Handler); match != nil {
+		operation.OperationID = match[1] + "." + match[2]
+	}
+
+	// Path parameters the operation does not document are still declared, as required strings
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		if !hasParameter(operation.Parameters, match[1], "path") {
+			operation.Parameters = append(operation.Parameters, PathParam(match[1], ""))
+		}
+	}
+
+	if op.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: !op.OptionalRequest,
+			Content:  map[string]MediaType{"application/json": {Schema: generator.SchemaOf(op.Request)}},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	if op.Response != nil {
+		contentType := op.ResponseType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		success.Content = map[string]MediaType{contentType: {Schema: generator.SchemaOf(op.Response)}}
+	}
+	operation.Responses[strconv.Itoa(status)] = success
+	for code, response := range op.Responses {
+		contentType := "application/json"
+		if code >= http.StatusBadRequest {
+			contentType = problemContentType
+		}
+		operation.Responses[strconv.Itoa(code)] = Response{
+			Description: http.StatusText(code),
+			Content:     map[string]MediaType{contentType: {Schema: generator.SchemaOf(response)}},
+		}
+	}
+	operation.Responses["default"] = Response{
+		Description: "Error",
+		Content:     map[string]MediaType{problemContentType: {Schema: errorSchema}},
+	}
+
+	if op.Role != "" {
+		operation.RequiredRole = op.Role
+		operation.Security = []map[string][]string{
+			{apiKeyScheme: {}},
+			{bearerScheme: {}},
+		}
+	}
+	return operation
+}
+
+// hasParameter reports whether a parameter of the given name and location is declared
+func hasParameter(parameters []Parameter, name, in string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name && parameter.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+// PathParam declares a string path parameter
+func PathParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: "string"}}
+}
+
+// PathUUID declares a UUID path parameter
+func PathUUID(name, description string) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: "string", Format: "uuid"}}
+}
+
+// PathInt declares an integer path parameter
+func PathInt(name, description string) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: "integer"}}
+}
+
+// Query declares a string query parameter
+func Query(name, description string, required bool) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Required: required, Schema: &Schema{Type: "string"}}
+}
+
+// QueryInt declares an optional integer query parameter
+func QueryInt(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "integer"}}
+}
+
+// QueryEnum declares an optional query parameter restricted to the given values
+func QueryEnum(name, description string, values ...string) Parameter {
+	enum := make([]interface{}, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Enum: enum}}
+}
+
+// Header declares a string header parameter
+func Header(name, description string, required bool) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Required: required, Schema: &Schema{Type: "string"}}
+}
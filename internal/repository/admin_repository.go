@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AdminRepository defines the interface for operator data access across all tenants
+// Unlike the other repositories its queries are not scoped to a single tenant,
+// so it must only be used behind the global admin endpoints
+type AdminRepository interface {
+	// ListSubscriptions retrieves webhook subscriptions of every tenant with pagination
+	// An empty filter returns all subscriptions
+	ListSubscriptions(ctx context.Context, filter models.AdminListFilter, page Page) ([]models.WebhookSubscription, int64, error)
+
+	// ListEvents retrieves webhook events of every tenant with pagination
+	// Supports filtering by tenant, delivery status, event name and creation time
+	ListEvents(ctx context.Context, filter models.AdminListFilter, page Page) ([]models.WebhookEvent, int64, error)
+
+	// ListChainRuns retrieves execution chain runs of every tenant with pagination
+	// Supports filtering by tenant, run status, trigger event and creation time
+	ListChainRuns(ctx context.Context, filter models.AdminListFilter, page Page) ([]models.ExecutionChainRun, int64, error)
+
+	// GetTenantStats aggregates subscription, event, chain and run counts per tenant
+	// Used for capacity planning across the whole installation
+	GetTenantStats(ctx context.Context) ([]models.TenantStats, error)
+
+	// PurgeDeleted permanently deletes the subscriptions and chains soft deleted before a point in time
+	// Chains are purged with their steps, versions and runs; subscriptions still called by steps of
+	// chains that are not purged are kept, as the steps reference them
+	PurgeDeleted(ctx context.Context, before time.Time) (*models.PurgeResult, error)
+}
+
+// adminRepository implements AdminRepository interface
+// Provides concrete implementation of cross-tenant data access using GORM ORM
+type adminRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+
+	// replicas serve every query, as cross-tenant listings and statistics tolerate replication lag
+	replicas *ReadReplicas
+}
+
+// NewAdminRepository creates a new admin repository instance
+// Factory function that initializes the repository with a database connection and optional read replicas
+// Returns: AdminRepository interface implementation
+func NewAdminRepository(db *gorm.DB, replicas *ReadReplicas) AdminRepository {
+	return &adminRepository{db: db, replicas: replicas}
+}
+
+// ListSubscriptions retrieves webhook subscriptions of every tenant with pagination
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - filter: Optional tenant, activity, type, subscribed event and creation time filters; the status filter
+//     does not apply to subscriptions
+//   - page: Page of the subscriptions, sorted by creation time or app name
+//
+// Returns: Slice of WebhookSubscriptions, total count, error if query fails
+func (r *adminRepository) ListSubscriptions(ctx context.Context, filter models.AdminListFilter, page Page) ([]models.WebhookSubscription, int64, error) {
+	var subscriptions []models.WebhookSubscription
+	total, err := r.list(ctx, &models.WebhookSubscription{}, filter, subscriptionListColumns, page, &subscriptions)
+	return subscriptions, total, err
+}
+
+// ListEvents retrieves webhook events of every tenant with pagination
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - filter: Optional tenant, delivery status, event name and creation time filters
+//   - page: Page of the events, sorted by creation time or delivery status
+//
+// Returns: Slice of WebhookEvents, total count, error if query fails
+func (r *adminRepository) ListEvents(ctx context.Context, filter models.AdminListFilter, page Page) ([]models.WebhookEvent, int64, error) {
+	var events []models.WebhookEvent
+	total, err := r.list(ctx, &models.WebhookEvent{}, filter, eventListColumns, page, &events)
+	return events, total, err
+}
+
+// ListChainRuns retrieves execution chain runs of every tenant with pagination
+// Step runs are not preloaded to keep cross-tenant listings cheap
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - filter: Optional tenant, run status, trigger event and creation time filters
+//   - page: Page of the runs, sorted by creation time or run status
+//
+// Returns: Slice of ExecutionChainRuns, total count, error if query fails
+func (r *adminRepository) ListChainRuns(ctx context.Context, filter models.AdminListFilter, page Page) ([]models.ExecutionChainRun, int64, error) {
+	var runs []models.ExecutionChainRun
+	total, err := r.list(ctx, &models.ExecutionChainRun{}, filter, chainRunListColumns, page, &runs)
+	return runs, total, err
+}
+
+// list counts the records of a model matching a filter and loads a page of them into dest
+func (r *adminRepository) list(ctx context.Context, model interface{}, filter models.AdminListFilter, columns listColumns, page Page, dest interface{}) (int64, error) {
+	query := func() *gorm.DB {
+		query := r.replicas.DB(r.db).WithContext(ctx).Model(model)
+		if filter.TenantID != "" {
+			query = query.Where("tenant_id = ?", filter.TenantID)
+		}
+		return filterList(query, filter.ListFilter, columns)
+	}
+
+	// Get total count
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	// Get paginated results
+	return total, paginate(query(), page).Find(dest).Error
+}
+
+// tenantCount is a single row of a per-tenant grouped count query
+type tenantCount struct {
+	TenantID string
+	Total    int64
+	Matching int64
+}
+
+// GetTenantStats aggregates subscription, event, chain and run counts per tenant
+// Each table is grouped by tenant separately and the results are merged in memory
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//
+// Returns: Slice of TenantStats ordered by tenant ID, error if any query fails
+func (r *adminRepository) GetTenantStats(ctx context.Context) ([]models.TenantStats, error) {
+	statsByTenant := make(map[string]*models.TenantStats)
+	tenantStats := func(tenantID string) *models.TenantStats {
+		stats, ok := statsByTenant[tenantID]
+		if !ok {
+			stats = &models.TenantStats{TenantID: tenantID}
+			statsByTenant[tenantID] = stats
+		}
+		return stats
+	}
+
+	subscriptions, err := r.countByTenant(ctx, &models.WebhookSubscription{}, "is_active = ?", true)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range subscriptions {
+		stats := tenantStats(row.TenantID)
+		stats.Subscriptions = row.Total
+		stats.ActiveSubscriptions = row.Matching
+	}
+
+	events, err := r.countByTenant(ctx, &models.WebhookEvent{}, "status = ?", models.WebhookStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range events {
+		stats := tenantStats(row.TenantID)
+		stats.Events = row.Total
+		stats.FailedEvents = row.Matching
+	}
+
+	chains, err := r.countByTenant(ctx, &models.ExecutionChain{}, "is_active = ?", true)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range chains {
+		stats := tenantStats(row.TenantID)
+		stats.Chains = row.Total
+		stats.ActiveChains = row.Matching
+	}
+
+	runs, err := r.countByTenant(ctx, &models.ExecutionChainRun{}, "status = ?", models.ExecutionChainStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range runs {
+		stats := tenantStats(row.TenantID)
+		stats.ChainRuns = row.Total
+		stats.FailedChainRuns = row.Matching
+	}
+
+	result := make([]models.TenantStats, 0, len(statsByTenant))
+	for _, stats := range statsByTenant {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TenantID < result[j].TenantID
+	})
+
+	return result, nil
+}
+
+// countByTenant counts the rows of a table per tenant, along with the rows matching a condition
+func (r *adminRepository) countByTenant(ctx context.Context, model interface{}, condition string, args ...interface{}) ([]tenantCount, error) {
+	var rows []tenantCount
+	err := r.replicas.DB(r.db).WithContext(ctx).Model(model).
+		Select("tenant_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE "+condition+") AS matching", args...).
+		Group("tenant_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// PurgeDeleted permanently deletes the subscriptions and chains soft deleted before a point in time
+// Runs in one transaction; chains are purged first so subscriptions only called by purged chains go too
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - before: Only records deleted before this time are purged
+//
+// Returns: Counts of purged and kept records, error if any deletion fails
+func (r *adminRepository) PurgeDeleted(ctx context.Context, before time.Time) (*models.PurgeResult, error) {
+	result := &models.PurgeResult{DeletedBefore: before}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var chainIDs []uuid.UUID
+		if err := tx.Unscoped().Model(&models.ExecutionChain{}).
+			Where("deleted_at < ?", before).
+			Pluck("id", &chainIDs).Error; err != nil {
+			return err
+		}
+		if len(chainIDs) > 0 {
+			// Deleting runs cascades to their step and compensation runs, which reference the steps
+			runs := tx.Where("chain_id IN ?", chainIDs).Delete(&models.ExecutionChainRun{})
+			if runs.Error != nil {
+				return runs.Error
+			}
+			result.RunsPurged = runs.RowsAffected
+
+			if err := tx.Where("chain_id IN ?", chainIDs).Delete(&models.ExecutionChainVersion{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("chain_id IN ?", chainIDs).Delete(&models.ExecutionChainStep{}).Error; err != nil {
+				return err
+			}
+			chains := tx.Unscoped().Where("id IN ?", chainIDs).Delete(&models.ExecutionChain{})
+			if chains.Error != nil {
+				return chains.Error
+			}
+			result.ChainsPurged = chains.RowsAffected
+		}
+
+		var subscriptionIDs []uuid.UUID
+		if err := tx.Unscoped().Model(&models.WebhookSubscription{}).
+			Where("deleted_at < ?", before).
+			Pluck("id", &subscriptionIDs).Error; err != nil {
+			return err
+		}
+		if len(subscriptionIDs) == 0 {
+			return nil
+		}
+
+		subscriptions := tx.Unscoped().
+			Where("id IN ?", subscriptionIDs).
+			Where(`NOT EXISTS (SELECT 1 FROM "execution_chain_steps" s
+	WHERE s.webhook_id = "webhook_subscriptions".id OR s.compensation_webhook_id = "webhook_subscriptions".id)`).
+			Delete(&models.WebhookSubscription{})
+		if subscriptions.Error != nil {
+			return subscriptions.Error
+		}
+		result.SubscriptionsPurged = subscriptions.RowsAffected
+		result.SubscriptionsKept = int64(len(subscriptionIDs)) - subscriptions.RowsAffected
+
+		// Deliveries queued while a purged subscription was paused, buffered for its next batch or waiting
+		// for their turn can no longer be sent
+		if err := tx.Exec(`DELETE FROM "queued_deliveries" AS q WHERE q.subscription_id IN ?
+	AND NOT EXISTS (SELECT 1 FROM "webhook_subscriptions" s WHERE s.id = q.subscription_id)`, subscriptionIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM "ordered_deliveries" AS o WHERE o.subscription_id IN ?
+	AND NOT EXISTS (SELECT 1 FROM "webhook_subscriptions" s WHERE s.id = o.subscription_id)`, subscriptionIDs).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM "batched_deliveries" AS b WHERE b.subscription_id IN ?
+	AND NOT EXISTS (SELECT 1 FROM "webhook_subscriptions" s WHERE s.id = b.subscription_id)`, subscriptionIDs).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
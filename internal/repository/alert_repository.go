@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AlertRepository defines the interface for alert rules, their history and the measurements they are evaluated on
+type AlertRepository interface {
+	// CreateAlertRule stores a new alert rule
+	CreateAlertRule(ctx context.Context, rule *models.AlertRule) error
+
+	// GetAlertRuleByID retrieves an alert rule by its unique identifier
+	GetAlertRuleByID(ctx context.Context, id uuid.UUID) (*models.AlertRule, error)
+
+	// GetAlertRulesByTenant retrieves every alert rule of a tenant, ordered by name
+	GetAlertRulesByTenant(ctx context.Context, tenantID string) ([]models.AlertRule, error)
+
+	// GetActiveAlertRules retrieves the active alert rules of every tenant, for evaluation
+	GetActiveAlertRules(ctx context.Context) ([]models.AlertRule, error)
+
+	// UpdateAlertRule saves the definition of an alert rule, leaving the outcome of its last evaluation untouched
+	UpdateAlertRule(ctx context.Context, rule *models.AlertRule) error
+
+	// UpdateAlertRuleState saves the outcome of evaluating an alert rule, leaving its definition untouched
+	// so changes made through the API during the evaluation are kept
+	UpdateAlertRuleState(ctx context.Context, rule *models.AlertRule) error
+
+	// DeleteAlertRule removes an alert rule; its history is kept
+	DeleteAlertRule(ctx context.Context, id uuid.UUID) error
+
+	// CreateAlertEvent records an entry of the alert history
+	CreateAlertEvent(ctx context.Context, event *models.AlertEvent) error
+
+	// GetAlertEvents retrieves the alert history of a tenant, or of one of its rules, with pagination, newest first
+	GetAlertEvents(ctx context.Context, tenantID string, ruleID *uuid.UUID, offset, limit int) ([]models.AlertEvent, int64, error)
+
+	// CountDeliveryOutcomes counts the deliveries to a subscription that finished since the given time,
+	// and how many of them failed
+	CountDeliveryOutcomes(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, int64, error)
+
+	// RecentDeliveryOutcomes returns whether each of the last deliveries to a subscription succeeded, newest first
+	RecentDeliveryOutcomes(ctx context.Context, webhookID uuid.UUID, limit int) ([]bool, error)
+
+	// CountRunOutcomes counts the runs of a chain that completed or failed since the given time,
+	// and how many of them failed
+	CountRunOutcomes(ctx context.Context, chainID uuid.UUID, since time.Time) (int64, int64, error)
+
+	// RecentRunOutcomes returns whether each of the last completed or failed runs of a chain completed, newest first
+	RecentRunOutcomes(ctx context.Context, chainID uuid.UUID, limit int) ([]bool, error)
+
+	// LongestRunSeconds returns the longest duration of the runs of a chain that finished since the given time
+	// or are still running at now, 0 without any
+	LongestRunSeconds(ctx context.Context, chainID uuid.UUID, since, now time.Time) (float64, error)
+}
+
+// finishedRunStatuses are the run statuses alert rules measure; cancelled runs are neither
+var finishedRunStatuses = []models.ExecutionChainStatus{models.ExecutionChainStatusCompleted, models.ExecutionChainStatusFailed}
+
+// alertRepository implements AlertRepository interface
+// Provides concrete implementation of alert rule and history operations using GORM
+type alertRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewAlertRepository creates a new alert repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: AlertRepository interface implementation
+func NewAlertRepository(db *gorm.DB) AlertRepository {
+	return &alertRepository{db: db}
+}
+
+// CreateAlertRule stores a new alert rule
+func (r *alertRepository) CreateAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+// GetAlertRuleByID retrieves an alert rule by its unique identifier
+// Returns: gorm.ErrRecordNotFound if no rule has the ID
+func (r *alertRepository) GetAlertRuleByID(ctx context.Context, id uuid.UUID) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetAlertRulesByTenant retrieves every alert rule of a tenant, ordered by name
+func (r *alertRepository) GetAlertRulesByTenant(ctx context.Context, tenantID string) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetActiveAlertRules retrieves the active alert rules of every tenant, oldest first
+func (r *alertRepository) GetActiveAlertRules(ctx context.Context) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("created_at ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// UpdateAlertRule saves the definition of an alert rule, leaving the state evaluations save untouched
+func (r *alertRepository) UpdateAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	return r.db.WithContext(ctx).Model(&models.AlertRule{}).
+		Where("id = ?", rule.ID).
+		Select("name", "webhook_id", "chain_id", "condition", "threshold", "window_minutes", "min_samples",
+			"notify_webhook_id", "suppress_minutes", "silenced_until", "is_active", "updated_at").
+		Updates(rule).Error
+}
+
+// UpdateAlertRuleState saves the state, measurement and timestamps of an evaluated alert rule
+func (r *alertRepository) UpdateAlertRuleState(ctx context.Context, rule *models.AlertRule) error {
+	return r.db.WithContext(ctx).Model(&models.AlertRule{}).
+		Where("id = ?", rule.ID).
+		Select("state", "value", "firing_since", "notified_at", "evaluated_at").
+		Updates(rule).Error
+}
+
+// DeleteAlertRule removes an alert rule
+func (r *alertRepository) DeleteAlertRule(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.AlertRule{}, id).Error
+}
+
+// CreateAlertEvent records an entry of the alert history
+func (r *alertRepository) CreateAlertEvent(ctx context.Context, event *models.AlertEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// GetAlertEvents retrieves the alert history of a tenant, or of one of its rules, with pagination, newest first
+func (r *alertRepository) GetAlertEvents(ctx context.Context, tenantID string, ruleID *uuid.UUID, offset, limit int) ([]models.AlertEvent, int64, error) {
+	var events []models.AlertEvent
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.AlertEvent{}).Where("tenant_id = ?", tenantID)
+	if ruleID != nil {
+		query = query.Where("rule_id = ?", *ruleID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&events).Error
+
+	return events, total, err
+}
+
+// outcomeCounts scans the total and failed counts of a measurement
+type outcomeCounts struct {
+	Total  int64
+	Failed int64
+}
+
+// CountDeliveryOutcomes counts the final delivery attempts to a subscription made since the given time
+func (r *alertRepository) CountDeliveryOutcomes(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, int64, error) {
+	var counts outcomeCounts
+	err := r.db.WithContext(ctx).Model(&models.WebhookDeliveryAttempt{}).
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE NOT success) AS failed").
+		Where("webhook_id = ? AND final AND created_at >= ?", webhookID, since).
+		Scan(&counts).Error
+	return counts.Total, counts.Failed, err
+}
+
+// RecentDeliveryOutcomes returns whether each of the last final delivery attempts to a subscription succeeded
+func (r *alertRepository) RecentDeliveryOutcomes(ctx context.Context, webhookID uuid.UUID, limit int) ([]bool, error) {
+	var outcomes []bool
+	err := r.db.WithContext(ctx).Model(&models.WebhookDeliveryAttempt{}).
+		Where("webhook_id = ? AND final", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("success", &outcomes).Error
+	return outcomes, err
+}
+
+// CountRunOutcomes counts the runs of a chain that completed or failed since the given time
+func (r *alertRepository) CountRunOutcomes(ctx context.Context, chainID uuid.UUID, since time.Time) (int64, int64, error) {
+	var counts outcomeCounts
+	err := r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS failed", models.ExecutionChainStatusFailed).
+		Where("chain_id = ? AND completed_at >= ? AND status IN ?", chainID, since, finishedRunStatuses).
+		Scan(&counts).Error
+	return counts.Total, counts.Failed, err
+}
+
+// RecentRunOutcomes returns whether each of the last completed or failed runs of a chain completed
+func (r *alertRepository) RecentRunOutcomes(ctx context.Context, chainID uuid.UUID, limit int) ([]bool, error) {
+	var outcomes []bool
+	err := r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).
+		Where("chain_id = ? AND completed_at IS NOT NULL AND status IN ?", chainID, finishedRunStatuses).
+		Select("status = ?", models.ExecutionChainStatusCompleted).
+		Order("completed_at DESC").
+		Limit(limit).
+		Scan(&outcomes).Error
+	return outcomes, err
+}
+
+// LongestRunSeconds returns the longest duration of the runs of a chain finished since the given time, or running
+// Runs waiting for an approval or paused are not running, as they wait on purpose
+func (r *alertRepository) LongestRunSeconds(ctx context.Context, chainID uuid.UUID, since, now time.Time) (float64, error) {
+	var seconds float64
+	err := r.db.WithContext(ctx).Raw(`SELECT COALESCE(MAX(`+secondsBetween(r.db, `"started_at"`, `COALESCE("completed_at", @now)`)+`), 0)
+	FROM "execution_chain_runs"
+	WHERE "chain_id" = @chain_id AND "started_at" IS NOT NULL
+		AND (("completed_at" >= @since AND "status" IN @finished) OR ("completed_at" IS NULL AND "status" = @running))`,
+		map[string]interface{}{
+			"chain_id": chainID,
+			"since":    since,
+			"now":      now,
+			"finished": finishedRunStatuses,
+			"running":  models.ExecutionChainStatusRunning,
+		}).
+		Scan(&seconds).Error
+	return seconds, err
+}
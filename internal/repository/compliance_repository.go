@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+// SubjectScrubber rewrites a stored value mentioning a data subject
+// Returns the value to store and whether it differs from the stored one
+type SubjectScrubber func(value string) (string, bool)
+
+// ComplianceRepository defines the interface for erasing data subjects from a tenant's stored data
+type ComplianceRepository interface {
+	// ScrubSubject rewrites the stored payloads, answers and errors of a tenant that mention a data subject,
+	// in the live tables and their archives
+	// Rows are searched table by table for the subject's identifier and rewritten by scrub in transactions of
+	// batchSize rows, locked while they are rewritten; an error stops the scrub, keeping the rows already rewritten
+	// Returns the number of rows rewritten per table, also those rewritten before an error
+	ScrubSubject(ctx context.Context, tenantID, subject string, scrub SubjectScrubber, batchSize int) (map[string]int64, error)
+}
+
+// erasureTable is a table with columns that can hold a data subject's identifier
+type erasureTable struct {
+	// name is the table's name
+	name string
+
+	// columns are the text and jsonb columns holding payloads, answers, errors or whole archived rows
+	columns []string
+
+	// tenant is the condition on @tenant_id selecting the tenant's rows
+	tenant string
+}
+
+// Conditions selecting a tenant's rows of tables without a tenant_id column through their parent rows
+const (
+	tenantRows        = `"tenant_id" = @tenant_id`
+	runRows           = `"run_id" IN (SELECT "id" FROM "execution_chain_runs" WHERE "tenant_id" = @tenant_id)`
+	archivedRunRows   = `"run_id" IN (SELECT "id" FROM "execution_chain_runs_archive" WHERE "tenant_id" = @tenant_id)`
+	archivedEventRows = `"event_id" IN (SELECT "id" FROM "webhook_events_archive" WHERE "tenant_id" = @tenant_id)`
+)
+
+// erasureTables are the tables ScrubSubject searches, live tables before their archives so rows archived
+// during the scrub are still found
+var erasureTables = []erasureTable{
+	{name: "webhook_events", columns: []string{"payload", "last_error", "ordering_key"}, tenant: tenantRows},
+	{name: "webhook_delivery_attempts", columns: []string{"error"}, tenant: tenantRows},
+	{name: "queued_deliveries", columns: []string{"payload"}, tenant: tenantRows},
+	{name: "batched_deliveries", columns: []string{"payload"}, tenant: tenantRows},
+	{name: "ordered_deliveries", columns: []string{"payload", "ordering_key"}, tenant: tenantRows},
+	{name: "execution_chain_runs", columns: []string{"trigger_data", "variables", "last_error", "callback_error"}, tenant: tenantRows},
+	{name: "execution_chain_step_runs", columns: []string{"request_payload", "response_body", "last_error"}, tenant: runRows},
+	{name: "execution_chain_compensation_runs", columns: []string{"request_payload", "response_body", "last_error"}, tenant: runRows},
+	{name: "webhook_events_archive", columns: []string{"data"}, tenant: tenantRows},
+	{name: "webhook_delivery_attempts_archive", columns: []string{"data"}, tenant: archivedEventRows},
+	{name: "execution_chain_runs_archive", columns: []string{"data"}, tenant: tenantRows},
+	{name: "execution_chain_step_runs_archive", columns: []string{"data"}, tenant: archivedRunRows},
+	{name: "execution_chain_compensation_runs_archive", columns: []string{"data"}, tenant: archivedRunRows},
+}
+
+// complianceRepository implements ComplianceRepository interface
+// Provides concrete implementation of erasure using raw SQL through GORM, as it spans most tables
+type complianceRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewComplianceRepository creates a new compliance repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: ComplianceRepository interface implementation
+func NewComplianceRepository(db *gorm.DB) ComplianceRepository {
+	return &complianceRepository{db: db}
+}
+
+// ScrubSubject rewrites the stored payloads, answers and errors of a tenant that mention a data subject
+// Values are matched on their text, so jsonb values also match with the identifier JSON escaped
+func (r *complianceRepository) ScrubSubject(ctx context.Context, tenantID, subject string, scrub SubjectScrubber, batchSize int) (map[string]int64, error) {
+	escaped, err := json.Marshal(subject)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{
+		"tenant_id": tenantID,
+		"subject":   subject,
+		"escaped":   strings.Trim(string(escaped), `"`),
+		"limit":     batchSize,
+	}
+
+	scrubbed := make(map[string]int64, len(erasureTables))
+	for _, table := range erasureTables {
+		after := uuid.Nil
+		for {
+			params["after"] = after
+			rewritten, last, err := r.scrubBatch(ctx, table, params, scrub)
+			scrubbed[table.name] += rewritten
+			if err != nil {
+				return scrubbed, fmt.Errorf("failed to scrub %s: %w", table.name, err)
+			}
+			if last == uuid.Nil {
+				break
+			}
+			after = last
+		}
+	}
+	return scrubbed, nil
+}
+
+// scrubBatch rewrites the next batch of a table's rows mentioning the subject, after the row ID @after
+// Returns the number of rows rewritten and the ID of the last row of the batch, uuid.Nil once none are left
+func (r *complianceRepository) scrubBatch(ctx context.Context, table erasureTable, params map[string]interface{}, scrub SubjectScrubber) (int64, uuid.UUID, error) {
+	// SQLite has no row locks and spells strpos instr; its transactions already hold the database's write lock
+	position, lock := "strpos", "FOR UPDATE"
+	if isSQLite(r.db) {
+		position, lock = "instr", ""
+	}
+	selected := make([]string, len(table.columns))
+	matches := make([]string, len(table.columns))
+	for i, column := range table.columns {
+		selected[i] = fmt.Sprintf("CAST(%[1]q AS text) AS %[1]q", column)
+		matches[i] = fmt.Sprintf("%[2]s(CAST(%[1]q AS text), @subject) > 0 OR %[2]s(CAST(%[1]q AS text), @escaped) > 0", column, position)
+	}
+	query := fmt.Sprintf(`SELECT CAST("id" AS text) AS "id", %s FROM %q
+	WHERE %s AND "id" > @after AND (%s)
+	ORDER BY "id"
+	LIMIT @limit
+	%s`, strings.Join(selected, ", "), table.name, table.tenant, strings.Join(matches, " OR "), lock)
+
+	var rewritten int64
+	var last uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []map[string]interface{}
+		if err := tx.Raw(query, params).Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			id, err := uuid.Parse(fmt.Sprint(row["id"]))
+			if err != nil {
+				return fmt.Errorf("invalid row ID %v: %w", row["id"], err)
+			}
+			last = id
+
+			updates := map[string]interface{}{}
+			for _, column := range table.columns {
+				value, ok := row[column].(string)
+				if !ok {
+					continue
+				}
+				if scrubbed, changed := scrub(value); changed {
+					updates[column] = scrubbed
+				}
+			}
+			if len(updates) == 0 {
+				continue
+			}
+			if err := tx.Table(table.name).Where(`"id" = ?`, id).Updates(updates).Error; err != nil {
+				return err
+			}
+			rewritten++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	return rewritten, last, nil
+}
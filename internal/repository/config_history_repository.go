@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConfigHistoryRepository defines the interface for configuration snapshot data access
+// This interface provides methods for recording and listing the versioned configuration
+// snapshots of webhook subscriptions and execution chains
+type ConfigHistoryRepository interface {
+	// CreateSnapshot stores a configuration snapshot
+	// The snapshot's version must not already exist for its resource
+	CreateSnapshot(ctx context.Context, snapshot *models.ConfigSnapshot) error
+
+	// GetLatestVersion retrieves the highest snapshot version of a resource
+	// Returns 0 when the resource has no snapshots yet
+	GetLatestVersion(ctx context.Context, resourceType models.ConfigResourceType, resourceID uuid.UUID) (int, error)
+
+	// GetSnapshots retrieves every snapshot of a resource ordered by version
+	// Used to build the configuration history with diffs between versions
+	GetSnapshots(ctx context.Context, resourceType models.ConfigResourceType, resourceID uuid.UUID) ([]models.ConfigSnapshot, error)
+}
+
+// configHistoryRepository implements ConfigHistoryRepository interface
+// Provides concrete implementation of configuration snapshot data access using GORM ORM
+type configHistoryRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewConfigHistoryRepository creates a new configuration history repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: ConfigHistoryRepository interface implementation
+func NewConfigHistoryRepository(db *gorm.DB) ConfigHistoryRepository {
+	return &configHistoryRepository{db: db}
+}
+
+// CreateSnapshot stores a configuration snapshot in the database
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - snapshot: ConfigSnapshot model with resource, version and configuration
+//
+// Returns: error if creation fails, including when the version already exists, nil on success
+func (r *configHistoryRepository) CreateSnapshot(ctx context.Context, snapshot *models.ConfigSnapshot) error {
+	return r.db.WithContext(ctx).Create(snapshot).Error
+}
+
+// GetLatestVersion retrieves the highest snapshot version of a resource
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - resourceType: Kind of resource, subscription or chain
+//   - resourceID: ID of the resource
+//
+// Returns: Latest version, 0 if the resource has no snapshots, error if query fails
+func (r *configHistoryRepository) GetLatestVersion(ctx context.Context, resourceType models.ConfigResourceType, resourceID uuid.UUID) (int, error) {
+	var snapshot models.ConfigSnapshot
+	err := r.db.WithContext(ctx).
+		Select("version").
+		Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("version DESC").
+		First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return snapshot.Version, nil
+}
+
+// GetSnapshots retrieves every snapshot of a resource
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - resourceType: Kind of resource, subscription or chain
+//   - resourceID: ID of the resource
+//
+// Returns: Slice of ConfigSnapshots ordered by version, error if query fails
+func (r *configHistoryRepository) GetSnapshots(ctx context.Context, resourceType models.ConfigResourceType, resourceID uuid.UUID) ([]models.ConfigSnapshot, error) {
+	var snapshots []models.ConfigSnapshot
+	err := r.db.WithContext(ctx).
+		Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("version ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CredentialRepository defines the interface for API credential data access
+// This interface provides methods for storing, looking up and revoking the credentials
+// used to authenticate callers of the management APIs
+type CredentialRepository interface {
+	// CreateCredential stores a new API credential
+	// The credential must already carry the hash of its API key
+	CreateCredential(ctx context.Context, credential *models.APICredential) error
+
+	// GetCredentialByID retrieves a credential by its unique identifier
+	// Used when issuing tokens and revoking credentials
+	GetCredentialByID(ctx context.Context, id uuid.UUID) (*models.APICredential, error)
+
+	// GetCredentialByKeyHash retrieves a credential by the hash of its API key
+	// Used by the authentication middleware on every API key request
+	GetCredentialByKeyHash(ctx context.Context, keyHash string) (*models.APICredential, error)
+
+	// GetCredentialsByTenant retrieves all credentials for a tenant with pagination
+	// Provides credential management listings with total count
+	GetCredentialsByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*models.APICredential, int64, error)
+
+	// UpdateCredential modifies specific fields of a credential using a map of updates
+	// Used to revoke credentials and record last usage
+	UpdateCredential(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+}
+
+// credentialRepository implements CredentialRepository interface
+// Provides concrete implementation of credential data access using GORM ORM
+type credentialRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewCredentialRepository creates a new credential repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: CredentialRepository interface implementation
+func NewCredentialRepository(db *gorm.DB) CredentialRepository {
+	return &credentialRepository{db: db}
+}
+
+// CreateCredential stores a new API credential in the database
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - credential: APICredential model with key hash, role and tenant
+//
+// Returns: error if creation fails, nil on success
+func (r *credentialRepository) CreateCredential(ctx context.Context, credential *models.APICredential) error {
+	return r.db.WithContext(ctx).Create(credential).Error
+}
+
+// GetCredentialByID retrieves a credential by its unique identifier
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the credential to retrieve
+//
+// Returns: APICredential pointer if found, error if not found or query fails
+func (r *credentialRepository) GetCredentialByID(ctx context.Context, id uuid.UUID) (*models.APICredential, error) {
+	var credential models.APICredential
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&credential).Error
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// GetCredentialByKeyHash retrieves a credential by the hash of its API key
+// Only active credentials are returned so revoked keys fail authentication
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - keyHash: Hex-encoded SHA-256 hash of the API key
+//
+// Returns: APICredential pointer if found, error if not found or query fails
+func (r *credentialRepository) GetCredentialByKeyHash(ctx context.Context, keyHash string) (*models.APICredential, error) {
+	var credential models.APICredential
+	err := r.db.WithContext(ctx).
+		Where("key_hash = ? AND is_active = ?", keyHash, true).
+		First(&credential).Error
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// GetCredentialsByTenant retrieves all credentials for a tenant with pagination
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter credentials
+//   - offset: Number of records to skip for pagination
+//   - limit: Maximum number of records to return
+//
+// Returns: Slice of APICredential pointers, total count, error if query fails
+func (r *credentialRepository) GetCredentialsByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*models.APICredential, int64, error) {
+	var credentials []*models.APICredential
+	var total int64
+
+	// Get total count
+	if err := r.db.WithContext(ctx).Model(&models.APICredential{}).Where("tenant_id = ?", tenantID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&credentials).Error
+
+	return credentials, total, err
+}
+
+// UpdateCredential modifies specific fields of a credential
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the credential to update
+//   - updates: Map of field names to new values for selective updating
+//
+// Returns: error if update fails, nil on success
+func (r *credentialRepository) UpdateCredential(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.APICredential{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// encryptedPrefix marks column values encrypted by SecretCipher; the key ID and ciphertext follow
+// Values without it are plaintext written before encryption was configured
+const encryptedPrefix = "enc:v1:"
+
+// ErrNoEncryptionKey is returned when an encrypted value is read without an encryption key configured
+var ErrNoEncryptionKey = errors.New("secret is encrypted but no encryption key is configured")
+
+// SecretCipher encrypts secret columns with AES-256-GCM
+// Values are encrypted with the current key and decrypted with whichever known key they name,
+// so previous keys can be kept while rows are re-encrypted during a key rotation
+type SecretCipher struct {
+	currentID string
+	keys      map[string]cipher.AEAD
+}
+
+// NewSecretCipher creates a cipher from base64 encoded 32 byte keys
+// Parameters:
+//   - current: Key new values are encrypted with
+//   - previous: Keys still accepted for decryption, e.g. the key being rotated out
+//
+// Returns: SecretCipher, error if a key is not base64 or not 32 bytes long
+func NewSecretCipher(current string, previous ...string) (*SecretCipher, error) {
+	c := &SecretCipher{keys: make(map[string]cipher.AEAD)}
+
+	currentID, err := c.addKey(current)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	c.currentID = currentID
+
+	for _, key := range previous {
+		if strings.TrimSpace(key) == "" {
+			continue
+		}
+		if _, err := c.addKey(key); err != nil {
+			return nil, fmt.Errorf("invalid previous encryption key: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// addKey registers a key and returns its ID, the first 8 hex digits of its SHA-256
+func (c *SecretCipher) addKey(encoded string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", fmt.Errorf("key is not base64: %w", err)
+	}
+	if len(key) != 32 {
+		return "", fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(key)
+	id := hex.EncodeToString(sum[:4])
+	c.keys[id] = aead
+	return id, nil
+}
+
+// Encrypt encrypts a column value with the current key
+// column is authenticated along with the value, so ciphertext copied to another column does not decrypt
+func (c *SecretCipher) Encrypt(column, plaintext string) (string, error) {
+	aead := c.keys[c.currentID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(column))
+	return encryptedPrefix + c.currentID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a column value; plaintext values are returned unchanged
+func (c *SecretCipher) Decrypt(column, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value in %s", column)
+	}
+	aead, ok := c.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("%s is encrypted with unknown key %s", column, keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value in %s", column)
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether a stored value is plaintext or encrypted with a key other than the current one
+func (c *SecretCipher) NeedsRotation(value string) bool {
+	return !strings.HasPrefix(value, encryptedPrefix+c.currentID+":")
+}
+
+// IsEncrypted reports whether a stored value was encrypted by a SecretCipher
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// secretCipher is the cipher used by the "encrypted" GORM serializer, nil to store secrets in plaintext
+var secretCipher atomic.Pointer[SecretCipher]
+
+// ConfigureEncryption sets the cipher secret columns are encrypted with; nil stores new values in plaintext
+// Must be called before the database is used, as values written earlier are stored in plaintext
+func ConfigureEncryption(c *SecretCipher) {
+	secretCipher.Store(c)
+}
+
+// ConfiguredCipher returns the cipher set with ConfigureEncryption, nil when secrets are stored in plaintext
+func ConfiguredCipher() *SecretCipher {
+	return secretCipher.Load()
+}
+
+func init() {
+	schema.RegisterSerializer("encrypted", encryptedSerializer{})
+}
+
+// encryptedSerializer encrypts string and *string fields tagged `gorm:"serializer:encrypted"`
+// using the cipher set with ConfigureEncryption
+type encryptedSerializer struct{}
+
+// Scan decrypts a column value into the field
+func (encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	target := field.ReflectValueOf(ctx, dst)
+	if dbValue == nil {
+		target.Set(reflect.Zero(field.FieldType))
+		return nil
+	}
+
+	var stored string
+	switch v := dbValue.(type) {
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("unsupported value type %T for encrypted column %s", dbValue, field.DBName)
+	}
+
+	plaintext := stored
+	if IsEncrypted(stored) {
+		c := secretCipher.Load()
+		if c == nil {
+			return fmt.Errorf("%s: %w", field.DBName, ErrNoEncryptionKey)
+		}
+		var err error
+		if plaintext, err = c.Decrypt(field.DBName, stored); err != nil {
+			return err
+		}
+	}
+
+	if field.FieldType.Kind() == reflect.Pointer {
+		target.Set(reflect.ValueOf(&plaintext))
+	} else {
+		target.SetString(plaintext)
+	}
+	return nil
+}
+
+// Value encrypts a field value for storage
+func (encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var plaintext string
+	switch v := fieldValue.(type) {
+	case string:
+		plaintext = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		plaintext = *v
+	default:
+		return nil, fmt.Errorf("unsupported field type %T for encrypted column %s", fieldValue, field.DBName)
+	}
+
+	c := secretCipher.Load()
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	return c.Encrypt(field.DBName, plaintext)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+// newTestKey returns a random base64 encoded encryption key
+func newTestKey() string {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// TestSecretCipher_Rotation tests that values encrypted with a previous key decrypt and need rotation
+func TestSecretCipher_Rotation(t *testing.T) {
+	// Arrange
+	oldKey, newKey := newTestKey(), newTestKey()
+	oldCipher, err := NewSecretCipher(oldKey)
+	assert.NoError(t, err)
+	rotatingCipher, err := NewSecretCipher(newKey, oldKey)
+	assert.NoError(t, err)
+
+	// Act
+	stored, err := oldCipher.Encrypt("secret_token", "s3cret")
+	assert.NoError(t, err)
+	plaintext, err := rotatingCipher.Decrypt("secret_token", stored)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", plaintext)
+	assert.True(t, rotatingCipher.NeedsRotation(stored))
+	assert.True(t, rotatingCipher.NeedsRotation("plaintext-secret"))
+
+	reencrypted, err := rotatingCipher.Encrypt("secret_token", plaintext)
+	assert.NoError(t, err)
+	assert.False(t, rotatingCipher.NeedsRotation(reencrypted))
+
+	_, err = rotatingCipher.Decrypt("jwt_token", reencrypted)
+	assert.Error(t, err, "ciphertext must not decrypt for another column")
+}
+
+// TestEncryptedSerializer tests that tagged model fields are encrypted on write and decrypted on read
+func TestEncryptedSerializer(t *testing.T) {
+	// Arrange
+	c, err := NewSecretCipher(newTestKey())
+	assert.NoError(t, err)
+	ConfigureEncryption(c)
+	defer ConfigureEncryption(nil)
+
+	parsed, err := schema.Parse(&models.WebhookSubscription{}, &sync.Map{}, schema.NamingStrategy{})
+	assert.NoError(t, err)
+	ctx := context.Background()
+	jwt := "eyJhbGciOiJIUzI1NiJ9"
+
+	// Act
+	secretField := parsed.LookUpField("secret_token")
+	storedSecret, err := secretField.Serializer.Value(ctx, secretField, reflect.Value{}, "s3cret")
+	assert.NoError(t, err)
+	jwtField := parsed.LookUpField("jwt_token")
+	storedJWT, err := jwtField.Serializer.Value(ctx, jwtField, reflect.Value{}, &jwt)
+	assert.NoError(t, err)
+
+	var subscription models.WebhookSubscription
+	dst := reflect.ValueOf(&subscription).Elem()
+	assert.NoError(t, secretField.Serializer.Scan(ctx, secretField, dst, storedSecret))
+	assert.NoError(t, jwtField.Serializer.Scan(ctx, jwtField, dst, storedJWT))
+
+	// Assert
+	assert.True(t, IsEncrypted(storedSecret.(string)))
+	assert.NotContains(t, storedSecret, "s3cret")
+	assert.Equal(t, "s3cret", subscription.SecretToken)
+	assert.Equal(t, jwt, *subscription.JWTToken)
+
+	// Rows written before encryption was configured are read as plaintext
+	assert.NoError(t, secretField.Serializer.Scan(ctx, secretField, dst, "legacy-secret"))
+	assert.Equal(t, "legacy-secret", subscription.SecretToken)
+}
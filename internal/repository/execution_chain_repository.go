@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/sakibcoolz/loki-suite/internal/models"
 
@@ -17,29 +19,70 @@ type ExecutionChainRepository interface {
 	// Chain management methods for CRUD operations on execution chains
 
 	// CreateChain creates a new execution chain with its associated steps in a transaction
-	// Validates JSON format of request parameters and sets proper step ordering
+	// Validates JSON format of request parameters, sets proper step ordering and records
+	// the chain's first version when the chain is versioned
 	CreateChain(ctx context.Context, chain *models.ExecutionChain) error
 
 	// GetChainByID retrieves a single execution chain by its unique identifier
 	// Preloads associated steps and webhook relationships for complete chain data
+	// Chains of other tenants than the one ctx is scoped to, see WithTenantScope, are not found
 	GetChainByID(ctx context.Context, id uuid.UUID) (*models.ExecutionChain, error)
 
 	// GetChainsByTenant retrieves all execution chains for a specific tenant with pagination
 	// Returns chains with preloaded steps and total count for pagination metadata
-	GetChainsByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*models.ExecutionChain, int64, error)
+	GetChainsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]*models.ExecutionChain, int64, error)
 
 	// GetChainsByTriggerEvent finds active chains that respond to a specific event type
 	// Used by the webhook system to determine which chains to execute for incoming events
 	GetChainsByTriggerEvent(ctx context.Context, tenantID, event string) ([]*models.ExecutionChain, error)
 
+	// GetDueScheduledChains finds active chains with a schedule whose next run is due
+	// Used by the scheduler to determine which chains to trigger
+	GetDueScheduledChains(ctx context.Context, now time.Time) ([]*models.ExecutionChain, error)
+
+	// ClaimScheduledRun advances a chain's next scheduled run if it is still the given due time
+	// Ensures a scheduled run is triggered once when several instances run the scheduler
+	ClaimScheduledRun(ctx context.Context, chainID uuid.UUID, due time.Time, next *time.Time, triggeredAt time.Time) (bool, error)
+
 	// UpdateChain modifies specific fields of an execution chain using a map of updates
-	// Allows partial updates without affecting unchanged fields
+	// Allows partial updates without affecting unchanged fields; increments the chain's lock version
+	// Returns gorm.ErrRecordNotFound when ctx is scoped to a tenant that has no chain with the ID
 	UpdateChain(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 
-	// DeleteChain soft deletes an execution chain and all its associated steps
-	// Uses transaction to ensure data consistency during cascading deletion
+	// UpdateChainAtVersion modifies specific fields of an execution chain like UpdateChain, provided the chain is
+	// still at the lock version the change was based on
+	// Returns ErrVersionConflict when the chain was changed or deleted since, or is not of the tenant ctx is scoped to
+	UpdateChainAtVersion(ctx context.Context, id uuid.UUID, lockVersion int64, updates map[string]interface{}) error
+
+	// UpdateChainSteps replaces the active steps of a chain in a transaction and records the new version
+	// Kept steps get their new order and dependencies, created steps are inserted and retired steps are
+	// marked retired rather than deleted so step runs and earlier versions keep referencing them
+	// Fails when the chain's version changed since the new version was computed
+	UpdateChainSteps(ctx context.Context, chainID uuid.UUID, kept, created []models.ExecutionChainStep, retired []uuid.UUID, version *models.ExecutionChainVersion) error
+
+	// CreateChainVersion records a version of a chain's steps and makes it the chain's current version
+	// Used to record the baseline version of chains created before versioning
+	CreateChainVersion(ctx context.Context, version *models.ExecutionChainVersion) error
+
+	// GetChainVersion retrieves one version of a chain
+	GetChainVersion(ctx context.Context, chainID uuid.UUID, version int) (*models.ExecutionChainVersion, error)
+
+	// GetChainVersions retrieves every version of a chain ordered by version
+	GetChainVersions(ctx context.Context, chainID uuid.UUID) ([]models.ExecutionChainVersion, error)
+
+	// GetStepsByIDs retrieves step definitions by ID, including retired steps, with their webhooks
+	// Used to resolve the steps of a chain version
+	GetStepsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.ExecutionChainStep, error)
+
+	// DeleteChain soft deletes an execution chain
+	// Steps, versions and runs are kept so the chain can be restored until it is purged
+	// Returns gorm.ErrRecordNotFound when ctx is scoped to a tenant that has no chain with the ID
 	DeleteChain(ctx context.Context, id uuid.UUID) error
 
+	// RestoreChain restores a soft deleted execution chain
+	// Returns gorm.ErrRecordNotFound when no deleted chain has the ID, or none of the tenant ctx is scoped to
+	RestoreChain(ctx context.Context, id uuid.UUID) error
+
 	// Chain execution methods for managing runtime execution instances
 
 	// CreateChainRun initiates a new execution instance of a chain
@@ -48,11 +91,62 @@ type ExecutionChainRepository interface {
 
 	// GetChainRunByID retrieves a specific chain execution run with full relationship data
 	// Includes chain definition, step runs, and associated webhook information
+	// Runs of other tenants than the one ctx is scoped to are not found
 	GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error)
 
 	// GetChainRunsByChain retrieves all execution runs for a specific chain with pagination
 	// Provides execution history and audit trail for chain performance analysis
-	GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, offset, limit int) ([]*models.ExecutionChainRun, int64, error)
+	// Runs of other tenants than the one ctx is scoped to are left out
+	GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, filter models.ListFilter, page Page) ([]*models.ExecutionChainRun, int64, error)
+
+	// GetChainRunStats aggregates the run counts and durations of a chain's runs created since the given time
+	// Computed by the database, so statistics over long windows never load the runs themselves
+	GetChainRunStats(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainRunStats, error)
+
+	// GetMostFailingChainStep finds the step of a chain with the most failed step runs in runs created since the given time
+	// Returns nil when no step failed
+	GetMostFailingChainStep(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainStepFailures, error)
+
+	// GetChainUsage sums the resources used by the step runs of a tenant's runs created since the given time,
+	// overall and per chain; the response's metadata is left for the caller to fill
+	GetChainUsage(ctx context.Context, tenantID string, since *time.Time) (*models.ChainUsageResponse, error)
+
+	// GetChainRunsByTenantAndStatus retrieves all runs of a tenant that are in the given status
+	// Returns runs by descending priority, oldest first within a priority, so queued work is released in that order
+	GetChainRunsByTenantAndStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)
+
+	// GetChainRunsByStatus retrieves the runs of every tenant in the given status, by descending priority and then oldest first
+	// Used to admit queued runs in that order when a global concurrency limit applies
+	GetChainRunsByStatus(ctx context.Context, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)
+
+	// CountChainRunsByStatus counts the runs in the given status, of one tenant or of all tenants
+	// Used to enforce the per-tenant and global run concurrency limits
+	CountChainRunsByStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) (int64, error)
+
+	// CountQueuedChainRunsAhead counts the queued runs admitted before a run of the given priority and creation time,
+	// of one tenant or of all tenants
+	// Used to report a queued run's position in the admission queue
+	CountQueuedChainRunsAhead(ctx context.Context, tenantID string, priority int, createdAt time.Time) (int64, error)
+
+	// GetChainRunsByChainAndStatus retrieves all runs of a chain that are in one of the given statuses
+	// Returns runs oldest first; used to apply a schedule's overlap policy
+	GetChainRunsByChainAndStatus(ctx context.Context, chainID uuid.UUID, statuses []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)
+
+	// GetChainRunRetries retrieves the runs retrying a failed run, oldest first
+	// Used to retry a failed run once
+	GetChainRunRetries(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainRun, error)
+
+	// TouchChainRuns records a heartbeat for the running runs an instance is executing
+	// Keeps the runs from being recovered by other instances
+	TouchChainRuns(ctx context.Context, runIDs []uuid.UUID, workerID string, now time.Time) error
+
+	// GetRecoverableChainRuns retrieves the runs no live instance is executing, oldest first
+	// These are interrupted runs and running runs whose heartbeat expired or predates the instance's current process
+	GetRecoverableChainRuns(ctx context.Context, workerID string, ownBefore, staleBefore time.Time) ([]*models.ExecutionChainRun, error)
+
+	// ClaimRecoverableChainRun queues a recoverable run for the given instance if it is still recoverable
+	// Ensures a run is recovered once when several instances run recovery
+	ClaimRecoverableChainRun(ctx context.Context, runID uuid.UUID, workerID string, ownBefore, staleBefore, now time.Time) (bool, error)
 
 	// UpdateChainRunStatus updates the execution status of a chain run
 	// Automatically sets completion timestamp for terminal statuses
@@ -62,6 +156,14 @@ type ExecutionChainRepository interface {
 	// Tracks progress through the execution sequence
 	UpdateChainRunStep(ctx context.Context, runID uuid.UUID, currentStep int) error
 
+	// UpdateChainRun modifies specific fields of a chain run using a map of updates
+	// Allows partial updates such as moving a queued run to running with its start time
+	UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error
+
+	// UpdateChainRunIfStatus modifies a chain run only while it still has the given status
+	// Ensures a run awaiting approval is approved or rejected once when decisions race
+	UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{}) (bool, error)
+
 	// Step execution methods for managing individual step executions within a chain run
 
 	// CreateStepRun records the execution of a single step within a chain run
@@ -75,6 +177,26 @@ type ExecutionChainRepository interface {
 	// UpdateStepRun modifies specific fields of a step execution run
 	// Used to update status, results, or error information during step execution
 	UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error
+
+	// CreateStepRuns records step runs in batched inserts, for writes buffered by a WriteBatcher
+	CreateStepRuns(ctx context.Context, stepRuns []*models.ExecutionChainStepRun) error
+
+	// UpdateStepRuns modifies specific fields of several step runs in one transaction
+	UpdateStepRuns(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) error
+
+	// CreateCompensationRun records the call of a step's compensation webhook
+	CreateCompensationRun(ctx context.Context, compensation *models.ExecutionChainCompensationRun) error
+
+	// UpdateCompensationRun updates the status and results of a compensation webhook call
+	UpdateCompensationRun(ctx context.Context, compensationID uuid.UUID, updates map[string]interface{}) error
+
+	// GetStepsByWebhook retrieves every chain step that calls a webhook, with its chain
+	// Used for impact analysis before a webhook is disabled or deleted
+	GetStepsByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*models.ExecutionChainStep, error)
+
+	// CountStepRunsByWebhookSince counts the step executions that called a webhook since a point in time
+	// Measures how much chain traffic depends on the webhook
+	CountStepRunsByWebhookSince(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, error)
 }
 
 // executionChainRepository implements ExecutionChainRepository interface
@@ -84,13 +206,16 @@ type executionChainRepository struct {
 	// db is the GORM database instance for executing queries
 	// Provides transaction support and relationship management
 	db *gorm.DB
+
+	// replicas serve chain and run listings and run statistics
+	replicas *ReadReplicas
 }
 
 // NewExecutionChainRepository creates a new execution chain repository instance
-// Factory function that initializes the repository with a database connection
+// Factory function that initializes the repository with a database connection and optional read replicas
 // Returns: ExecutionChainRepository interface implementation
-func NewExecutionChainRepository(db *gorm.DB) ExecutionChainRepository {
-	return &executionChainRepository{db: db}
+func NewExecutionChainRepository(db *gorm.DB, replicas *ReadReplicas) ExecutionChainRepository {
+	return &executionChainRepository{db: db, replicas: replicas}
 }
 
 // CreateChain creates a new execution chain with its associated steps
@@ -125,9 +250,18 @@ func (r *executionChainRepository) CreateChain(ctx context.Context, chain *model
 			if err := tx.Create(&step).Error; err != nil {
 				return err
 			}
+			chain.Steps[i].StepOrder = step.StepOrder
 		}
 
-		return nil
+		if chain.Version == 0 {
+			return nil
+		}
+		return tx.Create(&models.ExecutionChainVersion{
+			ChainID:   chain.ID,
+			Version:   chain.Version,
+			Steps:     models.PinVersionSteps(chain.Steps),
+			CreatedAt: chain.CreatedAt,
+		}).Error
 	})
 }
 
@@ -137,10 +271,16 @@ func (r *executionChainRepository) CreateChain(ctx context.Context, chain *model
 //   - ctx: Context for request cancellation and timeout control
 //   - id: UUID of the execution chain to retrieve
 //
-// Returns: ExecutionChain pointer with preloaded relationships, error if not found
+// Returns: ExecutionChain pointer with preloaded relationships, error if not found or of another tenant than
+// the one ctx is scoped to
 func (r *executionChainRepository) GetChainByID(ctx context.Context, id uuid.UUID) (*models.ExecutionChain, error) {
 	var chain models.ExecutionChain
-	err := r.db.WithContext(ctx).Preload("Steps.Webhook").Where("id = ?", id).First(&chain).Error
+	err := scopeTenant(ctx, r.db.WithContext(ctx)).
+		Preload("Steps", "retired_at IS NULL").
+		Preload("Steps.Webhook").
+		Preload("Steps.CompensationWebhook").
+		Where("id = ?", id).
+		First(&chain).Error
 	if err != nil {
 		return nil, err
 	}
@@ -152,26 +292,28 @@ func (r *executionChainRepository) GetChainByID(ctx context.Context, id uuid.UUI
 // Parameters:
 //   - ctx: Context for request cancellation and timeout control
 //   - tenantID: Tenant identifier to filter chains
-//   - offset: Number of records to skip for pagination
-//   - limit: Maximum number of records to return
+//   - filter: Narrows the chains by activity, status, trigger event and creation time
+//   - page: Page of the chains, sorted by creation time or status
 //
-// Returns: Slice of ExecutionChain pointers, total count, error if query fails
-func (r *executionChainRepository) GetChainsByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*models.ExecutionChain, int64, error) {
+// Returns: Slice of ExecutionChain pointers, total count of the filtered chains, error if query fails
+func (r *executionChainRepository) GetChainsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]*models.ExecutionChain, int64, error) {
+	db := r.replicas.DB(r.db)
 	var chains []*models.ExecutionChain
 	var total int64
+	query := func() *gorm.DB {
+		return filterList(db.WithContext(ctx).Model(&models.ExecutionChain{}).Where("tenant_id = ?", tenantID), filter, chainListColumns)
+	}
 
 	// Count total
-	if err := r.db.WithContext(ctx).Model(&models.ExecutionChain{}).Where("tenant_id = ?", tenantID).Count(&total).Error; err != nil {
+	if err := query().Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get chains with steps
-	err := r.db.WithContext(ctx).
+	err := paginate(query().
+		Preload("Steps", "retired_at IS NULL").
 		Preload("Steps.Webhook").
-		Where("tenant_id = ?", tenantID).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
+		Preload("Steps.CompensationWebhook"), page).
 		Find(&chains).Error
 
 	return chains, total, err
@@ -188,13 +330,60 @@ func (r *executionChainRepository) GetChainsByTenant(ctx context.Context, tenant
 func (r *executionChainRepository) GetChainsByTriggerEvent(ctx context.Context, tenantID, event string) ([]*models.ExecutionChain, error) {
 	var chains []*models.ExecutionChain
 	err := r.db.WithContext(ctx).
-		Preload("Steps", "steps.step_order ASC").
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Where("retired_at IS NULL").Order("step_order ASC")
+		}).
 		Preload("Steps.Webhook").
+		Preload("Steps.CompensationWebhook").
 		Where("tenant_id = ? AND trigger_event = ? AND is_active = ?", tenantID, event, true).
 		Find(&chains).Error
 	return chains, err
 }
 
+// GetDueScheduledChains finds active execution chains whose next scheduled run is due
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - now: Current time, chains scheduled at or before it are due
+//
+// Returns: Slice of ExecutionChain with steps ordered by execution sequence, error if query fails
+func (r *executionChainRepository) GetDueScheduledChains(ctx context.Context, now time.Time) ([]*models.ExecutionChain, error) {
+	var chains []*models.ExecutionChain
+	err := r.db.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Where("retired_at IS NULL").Order("step_order ASC")
+		}).
+		Preload("Steps.Webhook").
+		Preload("Steps.CompensationWebhook").
+		Where("is_active = ? AND schedule <> '' AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC").
+		Find(&chains).Error
+	return chains, err
+}
+
+// ClaimScheduledRun moves a chain's next scheduled run forward from the due time it was read with
+// The conditional update lets only one scheduler instance trigger each scheduled run
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the scheduled execution chain
+//   - due: Next run time the chain was found due with
+//   - next: Following run time, nil when the schedule never fires again
+//   - triggeredAt: Time recorded as the chain's last scheduled trigger
+//
+// Returns: true if this call claimed the run, error if the update fails
+func (r *executionChainRepository) ClaimScheduledRun(ctx context.Context, chainID uuid.UUID, due time.Time, next *time.Time, triggeredAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ExecutionChain{}).
+		Where("id = ? AND next_run_at = ?", chainID, due).
+		Updates(map[string]interface{}{
+			"next_run_at":       next,
+			"last_scheduled_at": triggeredAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // UpdateChain modifies specific fields of an execution chain
 // Allows partial updates using a map of field names to new values
 // Parameters:
@@ -202,29 +391,184 @@ func (r *executionChainRepository) GetChainsByTriggerEvent(ctx context.Context,
 //   - id: UUID of the execution chain to update
 //   - updates: Map of field names to new values for selective updating
 //
-// Returns: error if update fails, nil on success
+// Returns: gorm.ErrRecordNotFound if ctx is scoped to a tenant without the chain, error if update fails, nil on success
 func (r *executionChainRepository) UpdateChain(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
-	return r.db.WithContext(ctx).Model(&models.ExecutionChain{}).Where("id = ?", id).Updates(updates).Error
+	return scopedWrite(ctx, scopeTenant(ctx, r.db.WithContext(ctx).Model(&models.ExecutionChain{})).
+		Where("id = ?", id).
+		Updates(withLockVersion(updates)))
 }
 
-// DeleteChain performs soft deletion of an execution chain and its associated steps
-// Uses database transaction to ensure data consistency during cascading deletion
+// UpdateChainAtVersion modifies specific fields of an execution chain at a lock version
+// The version is compared in the update itself, so of two concurrent updates based on the same version only
+// the first succeeds
 // Parameters:
 //   - ctx: Context for request cancellation and timeout control
-//   - id: UUID of the execution chain to delete
+//   - id: UUID of the execution chain to update
+//   - lockVersion: Lock version of the chain the change was based on
+//   - updates: Map of field names to new values for selective updating
 //
-// Returns: error if deletion fails, nil on success
-func (r *executionChainRepository) DeleteChain(ctx context.Context, id uuid.UUID) error {
+// Returns: ErrVersionConflict if the chain is no longer at the lock version or of the tenant ctx is scoped to,
+// nil on success
+func (r *executionChainRepository) UpdateChainAtVersion(ctx context.Context, id uuid.UUID, lockVersion int64, updates map[string]interface{}) error {
+	return lockedUpdate(scopeTenant(ctx, r.db.WithContext(ctx).Model(&models.ExecutionChain{})).
+		Where("id = ? AND lock_version = ?", id, lockVersion).
+		Updates(withLockVersion(updates)))
+}
+
+// UpdateChainSteps replaces the active steps of a chain
+// Uses database transaction so a chain never runs with a partially updated step list
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the execution chain whose steps are updated
+//   - kept: Existing steps that stay active, with their new step order and dependencies
+//   - created: New steps with their IDs and step order already assigned
+//   - retired: IDs of the steps that are no longer active
+//   - version: Version recorded for the new steps, retired steps are retired at its creation time
+//
+// Returns: error if any update fails or the chain is no longer at the previous version, nil on success
+func (r *executionChainRepository) UpdateChainSteps(ctx context.Context, chainID uuid.UUID, kept, created []models.ExecutionChainStep, retired []uuid.UUID, version *models.ExecutionChainVersion) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Delete steps first
-		if err := tx.Where("chain_id = ?", id).Delete(&models.ExecutionChainStep{}).Error; err != nil {
+		// Claim the new version first so concurrent updates of the same chain cannot both succeed
+		if err := advanceChainVersion(tx, version); err != nil {
 			return err
 		}
-		// Delete chain
-		return tx.Where("id = ?", id).Delete(&models.ExecutionChain{}).Error
+
+		// Retire removed and replaced steps
+		if len(retired) > 0 {
+			if err := tx.Model(&models.ExecutionChainStep{}).
+				Where("chain_id = ? AND id IN ?", chainID, retired).
+				Update("retired_at", version.CreatedAt).Error; err != nil {
+				return err
+			}
+		}
+
+		// Move kept steps to their new position
+		for i := range kept {
+			if err := tx.Model(&kept[i]).
+				Select("step_order", "depends_on", "updated_at").
+				Updates(&kept[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		// Create the new steps
+		for i := range created {
+			created[i].ChainID = chainID
+			if err := tx.Create(&created[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(version).Error
 	})
 }
 
+// CreateChainVersion records a version of a chain's steps and makes it the chain's current version
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - version: Version with its chain, number and pinned steps
+//
+// Returns: error if the chain is no longer at the previous version or creation fails, nil on success
+func (r *executionChainRepository) CreateChainVersion(ctx context.Context, version *models.ExecutionChainVersion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := advanceChainVersion(tx, version); err != nil {
+			return err
+		}
+		return tx.Create(version).Error
+	})
+}
+
+// advanceChainVersion moves a chain from the version preceding the given one to the given version
+// Returns an error when the chain is at a different version, i.e. it was changed concurrently
+func advanceChainVersion(tx *gorm.DB, version *models.ExecutionChainVersion) error {
+	result := tx.Model(&models.ExecutionChain{}).
+		Where("id = ? AND version = ?", version.ChainID, version.Version-1).
+		Updates(withLockVersion(map[string]interface{}{
+			"version":    version.Version,
+			"updated_at": version.CreatedAt,
+		}))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict.Withf("chain %s is no longer at version %d", version.ChainID, version.Version-1)
+	}
+	return nil
+}
+
+// GetChainVersion retrieves one version of a chain
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the execution chain
+//   - version: Version number to retrieve
+//
+// Returns: ExecutionChainVersion pointer, error if not found
+func (r *executionChainRepository) GetChainVersion(ctx context.Context, chainID uuid.UUID, version int) (*models.ExecutionChainVersion, error) {
+	var chainVersion models.ExecutionChainVersion
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND version = ?", chainID, version).
+		First(&chainVersion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &chainVersion, nil
+}
+
+// GetChainVersions retrieves every version of a chain
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the execution chain
+//
+// Returns: Versions ordered by version number, error if query fails
+func (r *executionChainRepository) GetChainVersions(ctx context.Context, chainID uuid.UUID) ([]models.ExecutionChainVersion, error) {
+	var versions []models.ExecutionChainVersion
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Order("version ASC").
+		Find(&versions).Error
+	return versions, err
+}
+
+// GetStepsByIDs retrieves step definitions by ID, including retired steps
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - ids: UUIDs of the steps to retrieve
+//
+// Returns: Steps with their webhooks and compensation webhooks preloaded, in no particular order, error if query fails
+func (r *executionChainRepository) GetStepsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.ExecutionChainStep, error) {
+	var steps []models.ExecutionChainStep
+	if len(ids) == 0 {
+		return steps, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("Webhook").
+		Preload("CompensationWebhook").
+		Where("id IN ?", ids).
+		Find(&steps).Error
+	return steps, err
+}
+
+// DeleteChain performs soft deletion of an execution chain by setting its deleted_at
+// Steps and versions stay untouched, so restoring the chain brings it back as it was
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the execution chain to delete
+//
+// Returns: gorm.ErrRecordNotFound if ctx is scoped to a tenant without the chain, error if deletion fails, nil on success
+func (r *executionChainRepository) DeleteChain(ctx context.Context, id uuid.UUID) error {
+	return scopedWrite(ctx, scopeTenant(ctx, r.db.WithContext(ctx)).Where("id = ?", id).Delete(&models.ExecutionChain{}))
+}
+
+// RestoreChain restores a soft deleted execution chain by clearing its deleted_at
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the deleted execution chain
+//
+// Returns: gorm.ErrRecordNotFound if no deleted chain has the ID, or none of the tenant ctx is scoped to, nil on success
+func (r *executionChainRepository) RestoreChain(ctx context.Context, id uuid.UUID) error {
+	return restoreDeleted(scopeTenant(ctx, r.db.WithContext(ctx)), &models.ExecutionChain{}, id)
+}
+
 // CreateChainRun initiates a new execution instance of a chain
 // Records the start of chain execution with initial status and trigger context
 // Parameters:
@@ -242,12 +586,18 @@ func (r *executionChainRepository) CreateChainRun(ctx context.Context, run *mode
 //   - ctx: Context for request cancellation and timeout control
 //   - runID: UUID of the chain execution run to retrieve
 //
-// Returns: ExecutionChainRun pointer with preloaded relationships, error if not found
+// Returns: ExecutionChainRun pointer with preloaded relationships, error if not found or of another tenant than
+// the one ctx is scoped to
 func (r *executionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
 	var run models.ExecutionChainRun
-	err := r.db.WithContext(ctx).
-		Preload("Chain").
-		Preload("StepRuns.Step.Webhook").
+	// Runs of deleted chains, and steps of deleted webhooks, keep showing what they executed
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	err := scopeTenant(ctx, r.db.WithContext(ctx)).
+		Preload("Chain", unscoped).
+		Preload("StepRuns.Step.Webhook", unscoped).
+		Preload("Compensations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("step_order DESC")
+		}).
 		Where("id = ?", runID).
 		First(&run).Error
 	if err != nil {
@@ -261,31 +611,338 @@ func (r *executionChainRepository) GetChainRunByID(ctx context.Context, runID uu
 // Parameters:
 //   - ctx: Context for request cancellation and timeout control
 //   - chainID: UUID of the execution chain to get runs for
-//   - offset: Number of records to skip for pagination
-//   - limit: Maximum number of records to return
+//   - filter: Narrows the runs by status, trigger event and creation time
+//   - page: Page of the runs, sorted by creation time or status
 //
-// Returns: Slice of ExecutionChainRun pointers, total count, error if query fails
-func (r *executionChainRepository) GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, offset, limit int) ([]*models.ExecutionChainRun, int64, error) {
+// Returns: Slice of ExecutionChainRun pointers, total count of the filtered runs, error if query fails
+func (r *executionChainRepository) GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, filter models.ListFilter, page Page) ([]*models.ExecutionChainRun, int64, error) {
+	db := r.replicas.DB(r.db)
 	var runs []*models.ExecutionChainRun
 	var total int64
+	query := func() *gorm.DB {
+		return filterList(scopeTenant(ctx, db.WithContext(ctx)).Model(&models.ExecutionChainRun{}).Where("chain_id = ?", chainID), filter, chainRunListColumns)
+	}
 
 	// Count total
-	if err := r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).Where("chain_id = ?", chainID).Count(&total).Error; err != nil {
+	if err := query().Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get runs
-	err := r.db.WithContext(ctx).
-		Preload("StepRuns.Step").
-		Where("chain_id = ?", chainID).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
+	err := paginate(query().
+		Preload("StepRuns.Step"), page).
 		Find(&runs).Error
 
 	return runs, total, err
 }
 
+// chainRunDurationMs returns the SQL expression for the duration of a run in milliseconds
+func chainRunDurationMs(db *gorm.DB) string {
+	return secondsBetween(db, "started_at", "completed_at") + " * 1000"
+}
+
+// GetChainRunStats aggregates the runs of a chain with a single query
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the execution chain to aggregate runs for
+//   - since: Earliest creation time of the runs to include, nil to include every run
+//
+// Returns: Run counts and the average and percentile durations of completed runs, error if query fails
+func (r *executionChainRepository) GetChainRunStats(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainRunStats, error) {
+	db := r.replicas.DB(r.db)
+	completed := fmt.Sprintf("FILTER (WHERE status = '%s' AND started_at IS NOT NULL AND completed_at IS NOT NULL)", models.ExecutionChainStatusCompleted)
+	duration := chainRunDurationMs(db)
+	percentile := func(fraction, column string) string {
+		return percentileCont(db, fraction, duration, completed) + " AS " + column
+	}
+
+	query := db.WithContext(ctx).Model(&models.ExecutionChainRun{}).
+		Select("COUNT(*) AS total_runs, "+
+			"COUNT(*) FILTER (WHERE status = ?) AS completed_runs, "+
+			"COUNT(*) FILTER (WHERE status = ?) AS failed_runs, "+
+			"COUNT(*) FILTER (WHERE status = ?) AS cancelled_runs, "+
+			"AVG("+duration+") "+completed+" AS avg_duration_ms, "+
+			percentile("0.5", "p50_duration_ms")+", "+
+			percentile("0.95", "p95_duration_ms")+", "+
+			percentile("0.99", "p99_duration_ms"),
+			models.ExecutionChainStatusCompleted, models.ExecutionChainStatusFailed, models.ExecutionChainStatusCancelled).
+		Where("chain_id = ?", chainID)
+	if since != nil {
+		query = query.Where("created_at >= ?", *since)
+	}
+
+	var stats models.ChainRunStats
+	if err := query.Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	stats.ActiveRuns = stats.TotalRuns - stats.CompletedRuns - stats.FailedRuns - stats.CancelledRuns
+	return &stats, nil
+}
+
+// GetMostFailingChainStep counts failed step runs per step order and returns the step with the most
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the execution chain whose step runs to count
+//   - since: Earliest creation time of the runs to include, nil to include every run
+//
+// Returns: Step order and failure count, nil when no step failed, error if query fails
+func (r *executionChainRepository) GetMostFailingChainStep(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainStepFailures, error) {
+	db := r.replicas.DB(r.db)
+	query := db.WithContext(ctx).Model(&models.ExecutionChainStepRun{}).
+		Select("execution_chain_step_runs.step_order AS step_order, COUNT(*) AS failures").
+		Joins("JOIN execution_chain_runs ON execution_chain_runs.id = execution_chain_step_runs.run_id").
+		Where("execution_chain_runs.chain_id = ? AND execution_chain_step_runs.status = ?", chainID, models.WebhookStatusFailed)
+	if since != nil {
+		query = query.Where("execution_chain_runs.created_at >= ?", *since)
+	}
+
+	var failures []models.ChainStepFailures
+	err := query.
+		Group("execution_chain_step_runs.step_order").
+		Order("failures DESC, step_order ASC").
+		Limit(1).
+		Scan(&failures).Error
+	if err != nil || len(failures) == 0 {
+		return nil, err
+	}
+	return &failures[0], nil
+}
+
+// chainUsageColumns selects the run count and the summed step run resources of a group of runs joined to their
+// step runs; skipped steps made no attempt and took no time, so only their count needs a filter
+func chainUsageColumns(db *gorm.DB) string {
+	stepTime := secondsBetween(db, "execution_chain_step_runs.started_at", "execution_chain_step_runs.completed_at")
+	return "COUNT(DISTINCT execution_chain_runs.id) AS runs, " +
+		fmt.Sprintf("COUNT(execution_chain_step_runs.id) FILTER (WHERE execution_chain_step_runs.status <> '%s') AS steps, ", models.WebhookStatusSkipped) +
+		"COALESCE(SUM(execution_chain_step_runs.attempt_count), 0) AS attempts, " +
+		"COALESCE(SUM(" + greatest(db, "execution_chain_step_runs.attempt_count - 1", "0") + "), 0) AS retries, " +
+		"CAST(ROUND(COALESCE(SUM(" + stepTime + " * 1000), 0)) AS bigint) AS step_time_ms, " +
+		"COALESCE(SUM(execution_chain_step_runs.request_bytes), 0) AS request_bytes, " +
+		"COALESCE(SUM(execution_chain_step_runs.response_bytes), 0) AS response_bytes"
+}
+
+// GetChainUsage sums the step run resources of a tenant's runs with a single query
+// The overall row, whose chain_id is NULL, is returned even when the tenant has no runs
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant whose runs to sum
+//   - since: Earliest creation time of the runs to include, nil to include every run
+//
+// Returns: Overall usage and one entry per chain, named even when the chain was deleted, error if query fails
+func (r *executionChainRepository) GetChainUsage(ctx context.Context, tenantID string, since *time.Time) (*models.ChainUsageResponse, error) {
+	db := r.replicas.DB(r.db)
+	query := func() *gorm.DB {
+		query := db.WithContext(ctx).Model(&models.ExecutionChainRun{}).
+			Joins("LEFT JOIN execution_chain_step_runs ON execution_chain_step_runs.run_id = execution_chain_runs.id").
+			Joins("LEFT JOIN execution_chains ON execution_chains.id = execution_chain_runs.chain_id").
+			Where("execution_chain_runs.tenant_id = ?", tenantID)
+		if since != nil {
+			query = query.Where("execution_chain_runs.created_at >= ?", *since)
+		}
+		return query
+	}
+
+	rows, err := scanWithTotal[struct {
+		ChainID   *uuid.UUID
+		ChainName string
+		Runs      int64
+		models.ResourceUsage
+	}](query,
+		"execution_chain_runs.chain_id AS chain_id, execution_chains.name AS chain_name",
+		"NULL AS chain_id, NULL AS chain_name",
+		chainUsageColumns(db),
+		"execution_chain_runs.chain_id, execution_chains.name")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &models.ChainUsageResponse{Chains: []models.ChainResourceUsage{}}
+	for _, row := range rows {
+		if row.ChainID == nil {
+			usage.Runs = row.Runs
+			usage.ResourceUsage = row.ResourceUsage
+			continue
+		}
+		usage.Chains = append(usage.Chains, models.ChainResourceUsage{
+			ChainID:       *row.ChainID,
+			ChainName:     row.ChainName,
+			Runs:          row.Runs,
+			ResourceUsage: row.ResourceUsage,
+		})
+	}
+	return usage, nil
+}
+
+// GetChainRunsByTenantAndStatus retrieves all runs of a tenant that are in the given status
+// Used to release runs that were queued while chain executions were paused for the tenant
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter runs
+//   - status: Execution status the runs must be in
+//
+// Returns: Slice of ExecutionChainRun pointers ordered by descending priority and creation time, error if query fails
+func (r *executionChainRepository) GetChainRunsByTenantAndStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	var runs []*models.ExecutionChainRun
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND status = ?", tenantID, status).
+		Order("priority DESC, created_at ASC").
+		Find(&runs).Error
+	return runs, err
+}
+
+// GetChainRunsByStatus retrieves the runs of every tenant that are in the given status
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - status: Execution status the runs must be in
+//
+// Returns: Slice of ExecutionChainRun pointers ordered by descending priority and creation time, error if query fails
+func (r *executionChainRepository) GetChainRunsByStatus(ctx context.Context, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	var runs []*models.ExecutionChainRun
+	err := r.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order("priority DESC, created_at ASC").
+		Find(&runs).Error
+	return runs, err
+}
+
+// CountChainRunsByStatus counts the runs that are in the given status
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter runs, empty to count the runs of all tenants
+//   - status: Execution status the runs must be in
+//
+// Returns: Number of matching runs, error if query fails
+func (r *executionChainRepository) CountChainRunsByStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).Where("status = ?", status)
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CountQueuedChainRunsAhead counts the queued runs with a higher priority, or the same priority and an earlier creation time
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter runs, empty to count the runs of all tenants
+//   - priority: Priority of the run whose position is computed
+//   - createdAt: Creation time of the run whose position is computed
+//
+// Returns: Number of matching runs, error if query fails
+func (r *executionChainRepository) CountQueuedChainRunsAhead(ctx context.Context, tenantID string, priority int, createdAt time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).
+		Where("status = ? AND (priority > ? OR (priority = ? AND created_at < ?))",
+			models.ExecutionChainStatusQueued, priority, priority, createdAt)
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// GetChainRunsByChainAndStatus retrieves the runs of a chain in any of the given statuses
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - chainID: UUID of the execution chain whose runs to retrieve
+//   - statuses: Execution statuses the runs may be in
+//
+// Returns: Slice of ExecutionChainRun pointers ordered by creation time, error if query fails
+func (r *executionChainRepository) GetChainRunsByChainAndStatus(ctx context.Context, chainID uuid.UUID, statuses []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	var runs []*models.ExecutionChainRun
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND status IN ?", chainID, statuses).
+		Order("created_at ASC").
+		Find(&runs).Error
+	return runs, err
+}
+
+// GetChainRunRetries retrieves the runs that continue a failed run
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - runID: UUID of the failed run
+//
+// Returns: Slice of ExecutionChainRun pointers ordered by creation time, error if query fails
+func (r *executionChainRepository) GetChainRunRetries(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainRun, error) {
+	var runs []*models.ExecutionChainRun
+	err := r.db.WithContext(ctx).
+		Where("retry_of_run_id = ?", runID).
+		Order("created_at ASC").
+		Find(&runs).Error
+	return runs, err
+}
+
+// TouchChainRuns records a heartbeat for runs executing on an instance
+// Only running runs are touched, so runs that finished in the meantime keep their final state
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - runIDs: UUIDs of the runs the instance is executing
+//   - workerID: Identifier of the executing instance
+//   - now: Heartbeat time to record
+//
+// Returns: error if update fails, nil on success
+func (r *executionChainRepository) TouchChainRuns(ctx context.Context, runIDs []uuid.UUID, workerID string, now time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ExecutionChainRun{}).
+		Where("id IN ? AND status = ?", runIDs, models.ExecutionChainStatusRunning).
+		Updates(map[string]interface{}{
+			"worker_id":    workerID,
+			"heartbeat_at": now,
+		}).Error
+}
+
+// recoverableChainRuns restricts a query to runs no live instance is executing
+// Interrupted runs always qualify; running runs qualify without a heartbeat since staleBefore, or when
+// their last heartbeat came from an earlier process of the given instance, i.e. before ownBefore
+func recoverableChainRuns(db *gorm.DB, workerID string, ownBefore, staleBefore time.Time) *gorm.DB {
+	return db.Where("(status = ? OR (status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ? OR (worker_id = ? AND heartbeat_at < ?))))",
+		models.ExecutionChainStatusInterrupted, models.ExecutionChainStatusRunning, staleBefore, workerID, ownBefore)
+}
+
+// GetRecoverableChainRuns retrieves the runs left behind by stopped or crashed instances
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - workerID: Identifier of the recovering instance
+//   - ownBefore: Start time of the recovering process; its own earlier runs are recoverable right away
+//   - staleBefore: Runs of other instances without a heartbeat since this time are recoverable
+//
+// Returns: Slice of ExecutionChainRun pointers ordered by creation time, error if query fails
+func (r *executionChainRepository) GetRecoverableChainRuns(ctx context.Context, workerID string, ownBefore, staleBefore time.Time) ([]*models.ExecutionChainRun, error) {
+	var runs []*models.ExecutionChainRun
+	err := recoverableChainRuns(r.db.WithContext(ctx), workerID, ownBefore, staleBefore).
+		Order("created_at ASC").
+		Find(&runs).Error
+	return runs, err
+}
+
+// ClaimRecoverableChainRun moves a recoverable run to queued and assigns it to the recovering instance
+// The conditional update lets only one instance recover each run
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - runID: UUID of the run to claim
+//   - workerID: Identifier of the recovering instance
+//   - ownBefore: Start time of the recovering process
+//   - staleBefore: Expiry time of the heartbeat lease
+//   - now: Time recorded as the run's heartbeat
+//
+// Returns: true if this call claimed the run, error if the update fails
+func (r *executionChainRepository) ClaimRecoverableChainRun(ctx context.Context, runID uuid.UUID, workerID string, ownBefore, staleBefore, now time.Time) (bool, error) {
+	result := recoverableChainRuns(r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).Where("id = ?", runID), workerID, ownBefore, staleBefore).
+		Updates(map[string]interface{}{
+			"status":       models.ExecutionChainStatusQueued,
+			"worker_id":    workerID,
+			"heartbeat_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // UpdateChainRunStatus updates the execution status of a chain run
 // Automatically sets completion timestamp for terminal statuses (completed/failed)
 // Parameters:
@@ -299,8 +956,9 @@ func (r *executionChainRepository) UpdateChainRunStatus(ctx context.Context, run
 		"status": status,
 	}
 
-	if status == models.ExecutionChainStatusCompleted || status == models.ExecutionChainStatusFailed {
-		updates["completed_at"] = gorm.Expr("NOW()")
+	if status == models.ExecutionChainStatusCompleted || status == models.ExecutionChainStatusFailed ||
+		status == models.ExecutionChainStatusCancelled {
+		updates["completed_at"] = time.Now()
 	}
 
 	return r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).Where("id = ?", runID).Updates(updates).Error
@@ -320,6 +978,37 @@ func (r *executionChainRepository) UpdateChainRunStep(ctx context.Context, runID
 		Update("current_step", currentStep).Error
 }
 
+// UpdateChainRun modifies specific fields of a chain run
+// Allows partial updates using a map of field names to new values
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - runID: UUID of the chain execution run to update
+//   - updates: Map of field names to new values for selective updating
+//
+// Returns: error if update fails, nil on success
+func (r *executionChainRepository) UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).Where("id = ?", runID).Updates(updates).Error
+}
+
+// UpdateChainRunIfStatus modifies specific fields of a chain run if it still has the expected status
+// The conditional update lets only one caller move the run out of that status
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - runID: UUID of the chain execution run to update
+//   - status: Status the run must have for the update to apply
+//   - updates: Map of field names to new values for selective updating
+//
+// Returns: true if the run was updated, error if the update fails
+func (r *executionChainRepository) UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ExecutionChainRun{}).
+		Where("id = ? AND status = ?", runID, status).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // CreateStepRun records the execution of a single step within a chain run
 // Captures step-specific execution data, status, results, and error information
 // Parameters:
@@ -359,3 +1048,93 @@ func (r *executionChainRepository) GetStepRunsByRun(ctx context.Context, runID u
 func (r *executionChainRepository) UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error {
 	return r.db.WithContext(ctx).Model(&models.ExecutionChainStepRun{}).Where("id = ?", stepRunID).Updates(updates).Error
 }
+
+// stepRunBatchSize bounds the step runs of one insert, keeping its bind parameters below the Postgres limit
+const stepRunBatchSize = 500
+
+// CreateStepRuns records step runs in inserts of up to stepRunBatchSize rows
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - stepRuns: ExecutionChainStepRun models with their IDs set
+//
+// Returns: error if an insert fails, nil on success
+func (r *executionChainRepository) CreateStepRuns(ctx context.Context, stepRuns []*models.ExecutionChainStepRun) error {
+	if len(stepRuns) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(stepRuns, stepRunBatchSize).Error
+}
+
+// UpdateStepRuns modifies specific fields of several step runs, committing the updates together
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - updates: Map of step run IDs to the field names and new values of each
+//
+// Returns: error if an update fails, in which case none is applied; nil on success
+func (r *executionChainRepository) UpdateStepRuns(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for stepRunID, columns := range updates {
+			if err := tx.Model(&models.ExecutionChainStepRun{}).Where("id = ?", stepRunID).Updates(columns).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateCompensationRun records the call of a step's compensation webhook after its run failed
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - compensation: ExecutionChainCompensationRun model with run, step and webhook populated
+//
+// Returns: error if creation fails, nil on success
+func (r *executionChainRepository) CreateCompensationRun(ctx context.Context, compensation *models.ExecutionChainCompensationRun) error {
+	return r.db.WithContext(ctx).Create(compensation).Error
+}
+
+// UpdateCompensationRun modifies specific fields of a compensation webhook call
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - compensationID: UUID of the compensation run to update
+//   - updates: Map of field names to new values for selective updating
+//
+// Returns: error if update fails, nil on success
+func (r *executionChainRepository) UpdateCompensationRun(ctx context.Context, compensationID uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.ExecutionChainCompensationRun{}).Where("id = ?", compensationID).Updates(updates).Error
+}
+
+// GetStepsByWebhook retrieves every chain step that calls a webhook, as its step or its compensation webhook
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - webhookID: UUID of the webhook subscription referenced by the steps
+//
+// Returns: Slice of ExecutionChainStep pointers with their chain preloaded, error if query fails
+func (r *executionChainRepository) GetStepsByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*models.ExecutionChainStep, error) {
+	var steps []*models.ExecutionChainStep
+	err := r.db.WithContext(ctx).
+		Preload("Chain").
+		Where("(webhook_id = ? OR compensation_webhook_id = ?) AND retired_at IS NULL", webhookID, webhookID).
+		Where("chain_id IN (?)", r.db.Model(&models.ExecutionChain{}).Select("id")).
+		Order("chain_id ASC, step_order ASC").
+		Find(&steps).Error
+	return steps, err
+}
+
+// CountStepRunsByWebhookSince counts the step executions that called a webhook since a point in time
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - webhookID: UUID of the webhook subscription called by the steps
+//   - since: Only step runs created at or after this time are counted
+//
+// Returns: Number of matching step runs, error if query fails
+func (r *executionChainRepository) CountStepRunsByWebhookSince(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ExecutionChainStepRun{}).
+		Joins("JOIN execution_chain_steps ON execution_chain_steps.id = execution_chain_step_runs.step_id").
+		Where("execution_chain_steps.webhook_id = ? AND execution_chain_step_runs.created_at >= ?", webhookID, since).
+		Count(&count).Error
+	return count, err
+}
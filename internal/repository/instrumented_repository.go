@@ -0,0 +1,689 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// instrumentedWebhookRepository decorates a WebhookRepository with query metrics and slow query logging
+type instrumentedWebhookRepository struct {
+	next    WebhookRepository
+	metrics *QueryMetrics
+}
+
+// NewInstrumentedWebhookRepository wraps a webhook repository so every call is measured by metrics
+// Returns: WebhookRepository that delegates to next
+func NewInstrumentedWebhookRepository(next WebhookRepository, metrics *QueryMetrics) WebhookRepository {
+	return &instrumentedWebhookRepository{next: next, metrics: metrics}
+}
+
+// Each method measures the call and passes the traced context to the wrapped repository
+
+func (r *instrumentedWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateSubscription")
+	err := r.next.CreateSubscription(ctx, subscription)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetSubscriptionByID")
+	result, err := r.next.GetSubscriptionByID(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetActiveSubscriptionsByTenantAndEvent(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetActiveSubscriptionsByTenantAndEvent")
+	result, err := r.next.GetActiveSubscriptionsByTenantAndEvent(ctx, tenantID, event)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetSubscriptionsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]models.WebhookSubscription, int64, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetSubscriptionsByTenant")
+	result, total, err := r.next.GetSubscriptionsByTenant(ctx, tenantID, filter, page)
+	done(err)
+	return result, total, err
+}
+
+func (r *instrumentedWebhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "UpdateSubscription")
+	err := r.next.UpdateSubscription(ctx, subscription)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "DeleteSubscription")
+	err := r.next.DeleteSubscription(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) RestoreSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "RestoreSubscription")
+	err := r.next.RestoreSubscription(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) SetSubscriptionPause(ctx context.Context, id uuid.UUID, pausedUntil *time.Time) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "SetSubscriptionPause")
+	err := r.next.SetSubscriptionPause(ctx, id, pausedUntil)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetSubscriptionsPausedUntil(ctx context.Context, until time.Time) ([]models.WebhookSubscription, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetSubscriptionsPausedUntil")
+	result, err := r.next.GetSubscriptionsPausedUntil(ctx, until)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetFailingSubscriptions(ctx context.Context, tenantID string, since time.Time, failures int) ([]models.WebhookSubscription, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetFailingSubscriptions")
+	result, err := r.next.GetFailingSubscriptions(ctx, tenantID, since, failures)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) DisableSubscription(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string) (bool, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "DisableSubscription")
+	result, err := r.next.DisableSubscription(ctx, id, disabledAt, reason)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) EnableSubscription(ctx context.Context, id uuid.UUID, enabledAt time.Time) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "EnableSubscription")
+	err := r.next.EnableSubscription(ctx, id, enabledAt)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) CreateQueuedDelivery(ctx context.Context, delivery *models.QueuedDelivery) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateQueuedDelivery")
+	err := r.next.CreateQueuedDelivery(ctx, delivery)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetQueuedDeliveries(ctx context.Context, subscriptionID uuid.UUID) ([]models.QueuedDelivery, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetQueuedDeliveries")
+	result, err := r.next.GetQueuedDeliveries(ctx, subscriptionID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) DeleteQueuedDelivery(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "DeleteQueuedDelivery")
+	err := r.next.DeleteQueuedDelivery(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) CountQueuedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "CountQueuedDeliveriesByEvent")
+	result, err := r.next.CountQueuedDeliveriesByEvent(ctx, eventID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) CreateBatchedDelivery(ctx context.Context, delivery *models.BatchedDelivery) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateBatchedDelivery")
+	err := r.next.CreateBatchedDelivery(ctx, delivery)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetBatchedDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.BatchedDelivery, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetBatchedDeliveries")
+	result, err := r.next.GetBatchedDeliveries(ctx, subscriptionID, limit)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) DeleteBatchedDeliveries(ctx context.Context, ids []uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "DeleteBatchedDeliveries")
+	err := r.next.DeleteBatchedDeliveries(ctx, ids)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) CountBatchedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "CountBatchedDeliveriesByEvent")
+	result, err := r.next.CountBatchedDeliveriesByEvent(ctx, eventID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetSubscriptionsWithDueBatches(ctx context.Context, now time.Time) ([]models.WebhookSubscription, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetSubscriptionsWithDueBatches")
+	result, err := r.next.GetSubscriptionsWithDueBatches(ctx, now)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) CreateOrderedDelivery(ctx context.Context, delivery *models.OrderedDelivery) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateOrderedDelivery")
+	err := r.next.CreateOrderedDelivery(ctx, delivery)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) ClaimOrderedDelivery(ctx context.Context, subscriptionID uuid.UUID, orderingKey string, now, staleBefore time.Time) (*models.OrderedDelivery, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "ClaimOrderedDelivery")
+	result, err := r.next.ClaimOrderedDelivery(ctx, subscriptionID, orderingKey, now, staleBefore)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) ReleaseOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "ReleaseOrderedDelivery")
+	err := r.next.ReleaseOrderedDelivery(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) DeleteOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "DeleteOrderedDelivery")
+	err := r.next.DeleteOrderedDelivery(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) CountOrderedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "CountOrderedDeliveriesByEvent")
+	result, err := r.next.CountOrderedDeliveriesByEvent(ctx, eventID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetStalledOrderedDeliveries(ctx context.Context, staleBefore time.Time) ([]models.OrderedDelivery, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetStalledOrderedDeliveries")
+	result, err := r.next.GetStalledOrderedDeliveries(ctx, staleBefore)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) CreateEventType(ctx context.Context, eventType *models.EventType) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateEventType")
+	err := r.next.CreateEventType(ctx, eventType)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetEventTypeByID(ctx context.Context, id uuid.UUID) (*models.EventType, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetEventTypeByID")
+	result, err := r.next.GetEventTypeByID(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetEventTypeByName(ctx context.Context, tenantID, name string) (*models.EventType, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetEventTypeByName")
+	result, err := r.next.GetEventTypeByName(ctx, tenantID, name)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetEventTypesByTenant(ctx context.Context, tenantID string) ([]models.EventType, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetEventTypesByTenant")
+	result, err := r.next.GetEventTypesByTenant(ctx, tenantID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) UpdateEventType(ctx context.Context, eventType *models.EventType) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "UpdateEventType")
+	err := r.next.UpdateEventType(ctx, eventType)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) DeleteEventType(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "DeleteEventType")
+	err := r.next.DeleteEventType(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) CreateDeliveryAttempts(ctx context.Context, attempts []*models.WebhookDeliveryAttempt) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateDeliveryAttempts")
+	err := r.next.CreateDeliveryAttempts(ctx, attempts)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetDeliveryStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, bucket time.Duration) (*models.DeliveryStatsResponse, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetDeliveryStats")
+	result, err := r.next.GetDeliveryStats(ctx, tenantID, webhookID, since, bucket)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetLatencySLOStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, targetMs int64) (*models.LatencySLOReport, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetLatencySLOStats")
+	result, err := r.next.GetLatencySLOStats(ctx, tenantID, webhookID, since, targetMs)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) CreateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "CreateEvent")
+	err := r.next.CreateEvent(ctx, event)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetEventByID(ctx context.Context, id uuid.UUID) (*models.WebhookEvent, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetEventByID")
+	result, err := r.next.GetEventByID(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) UpdateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "UpdateEvent")
+	err := r.next.UpdateEvent(ctx, event)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetEventsByStatus(ctx context.Context, status models.WebhookStatus, limit int) ([]models.WebhookEvent, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetEventsByStatus")
+	result, err := r.next.GetEventsByStatus(ctx, status, limit)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) CountEventsSince(ctx context.Context, tenantID, event string, since time.Time) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "CountEventsSince")
+	result, err := r.next.CountEventsSince(ctx, tenantID, event, since)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetDueScheduledEvents(ctx context.Context, now time.Time, limit int) ([]models.WebhookEvent, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetDueScheduledEvents")
+	result, err := r.next.GetDueScheduledEvents(ctx, now, limit)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) GetScheduledEventsDueBy(ctx context.Context, until time.Time, after uuid.UUID, limit int) ([]models.WebhookEvent, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetScheduledEventsDueBy")
+	result, err := r.next.GetScheduledEventsDueBy(ctx, until, after, limit)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) ClaimScheduledEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "ClaimScheduledEvent")
+	result, err := r.next.ClaimScheduledEvent(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) SaveDeliveryPause(ctx context.Context, pause *models.DeliveryPause) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "SaveDeliveryPause")
+	err := r.next.SaveDeliveryPause(ctx, pause)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetDeliveryPauses(ctx context.Context) ([]models.DeliveryPause, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetDeliveryPauses")
+	result, err := r.next.GetDeliveryPauses(ctx)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) DeleteResumedDeliveryPause(ctx context.Context, scope string) error {
+	ctx, done := r.metrics.start(ctx, "webhook", "DeleteResumedDeliveryPause")
+	err := r.next.DeleteResumedDeliveryPause(ctx, scope)
+	done(err)
+	return err
+}
+
+func (r *instrumentedWebhookRepository) GetHeldEvents(ctx context.Context, tenantID string, excludeTenants []string, limit int) ([]models.WebhookEvent, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "GetHeldEvents")
+	result, err := r.next.GetHeldEvents(ctx, tenantID, excludeTenants, limit)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) CountHeldEvents(ctx context.Context, tenantID string, excludeTenants []string) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "CountHeldEvents")
+	result, err := r.next.CountHeldEvents(ctx, tenantID, excludeTenants)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookRepository) ReleaseHeldEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, done := r.metrics.start(ctx, "webhook", "ReleaseHeldEvent")
+	result, err := r.next.ReleaseHeldEvent(ctx, id)
+	done(err)
+	return result, err
+}
+
+// instrumentedExecutionChainRepository decorates an ExecutionChainRepository with query metrics and slow query logging
+type instrumentedExecutionChainRepository struct {
+	next    ExecutionChainRepository
+	metrics *QueryMetrics
+}
+
+// NewInstrumentedExecutionChainRepository wraps an execution chain repository so every call is measured by metrics
+// Returns: ExecutionChainRepository that delegates to next
+func NewInstrumentedExecutionChainRepository(next ExecutionChainRepository, metrics *QueryMetrics) ExecutionChainRepository {
+	return &instrumentedExecutionChainRepository{next: next, metrics: metrics}
+}
+
+// Each method measures the call and passes the traced context to the wrapped repository
+
+func (r *instrumentedExecutionChainRepository) CreateChain(ctx context.Context, chain *models.ExecutionChain) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CreateChain")
+	err := r.next.CreateChain(ctx, chain)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainByID(ctx context.Context, id uuid.UUID) (*models.ExecutionChain, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainByID")
+	result, err := r.next.GetChainByID(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]*models.ExecutionChain, int64, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainsByTenant")
+	result, total, err := r.next.GetChainsByTenant(ctx, tenantID, filter, page)
+	done(err)
+	return result, total, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainsByTriggerEvent(ctx context.Context, tenantID, event string) ([]*models.ExecutionChain, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainsByTriggerEvent")
+	result, err := r.next.GetChainsByTriggerEvent(ctx, tenantID, event)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetDueScheduledChains(ctx context.Context, now time.Time) ([]*models.ExecutionChain, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetDueScheduledChains")
+	result, err := r.next.GetDueScheduledChains(ctx, now)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) ClaimScheduledRun(ctx context.Context, chainID uuid.UUID, due time.Time, next *time.Time, triggeredAt time.Time) (bool, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "ClaimScheduledRun")
+	result, err := r.next.ClaimScheduledRun(ctx, chainID, due, next, triggeredAt)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChain(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChain")
+	err := r.next.UpdateChain(ctx, id, updates)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChainAtVersion(ctx context.Context, id uuid.UUID, lockVersion int64, updates map[string]interface{}) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChainAtVersion")
+	err := r.next.UpdateChainAtVersion(ctx, id, lockVersion, updates)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChainSteps(ctx context.Context, chainID uuid.UUID, kept, created []models.ExecutionChainStep, retired []uuid.UUID, version *models.ExecutionChainVersion) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChainSteps")
+	err := r.next.UpdateChainSteps(ctx, chainID, kept, created, retired, version)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) CreateChainVersion(ctx context.Context, version *models.ExecutionChainVersion) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CreateChainVersion")
+	err := r.next.CreateChainVersion(ctx, version)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainVersion(ctx context.Context, chainID uuid.UUID, version int) (*models.ExecutionChainVersion, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainVersion")
+	result, err := r.next.GetChainVersion(ctx, chainID, version)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainVersions(ctx context.Context, chainID uuid.UUID) ([]models.ExecutionChainVersion, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainVersions")
+	result, err := r.next.GetChainVersions(ctx, chainID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetStepsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.ExecutionChainStep, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetStepsByIDs")
+	result, err := r.next.GetStepsByIDs(ctx, ids)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) DeleteChain(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "DeleteChain")
+	err := r.next.DeleteChain(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) RestoreChain(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "RestoreChain")
+	err := r.next.RestoreChain(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) CreateChainRun(ctx context.Context, run *models.ExecutionChainRun) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CreateChainRun")
+	err := r.next.CreateChainRun(ctx, run)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunByID")
+	result, err := r.next.GetChainRunByID(ctx, runID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, filter models.ListFilter, page Page) ([]*models.ExecutionChainRun, int64, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunsByChain")
+	result, total, err := r.next.GetChainRunsByChain(ctx, chainID, filter, page)
+	done(err)
+	return result, total, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunsByTenantAndStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunsByTenantAndStatus")
+	result, err := r.next.GetChainRunsByTenantAndStatus(ctx, tenantID, status)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunsByStatus(ctx context.Context, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunsByStatus")
+	result, err := r.next.GetChainRunsByStatus(ctx, status)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) CountChainRunsByStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CountChainRunsByStatus")
+	result, err := r.next.CountChainRunsByStatus(ctx, tenantID, status)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) CountQueuedChainRunsAhead(ctx context.Context, tenantID string, priority int, createdAt time.Time) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CountQueuedChainRunsAhead")
+	result, err := r.next.CountQueuedChainRunsAhead(ctx, tenantID, priority, createdAt)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunStats(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainRunStats, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunStats")
+	result, err := r.next.GetChainRunStats(ctx, chainID, since)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainUsage(ctx context.Context, tenantID string, since *time.Time) (*models.ChainUsageResponse, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainUsage")
+	result, err := r.next.GetChainUsage(ctx, tenantID, since)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetMostFailingChainStep(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainStepFailures, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetMostFailingChainStep")
+	result, err := r.next.GetMostFailingChainStep(ctx, chainID, since)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunsByChainAndStatus(ctx context.Context, chainID uuid.UUID, statuses []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunsByChainAndStatus")
+	result, err := r.next.GetChainRunsByChainAndStatus(ctx, chainID, statuses)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) GetChainRunRetries(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetChainRunRetries")
+	result, err := r.next.GetChainRunRetries(ctx, runID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) TouchChainRuns(ctx context.Context, runIDs []uuid.UUID, workerID string, now time.Time) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "TouchChainRuns")
+	err := r.next.TouchChainRuns(ctx, runIDs, workerID, now)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) GetRecoverableChainRuns(ctx context.Context, workerID string, ownBefore, staleBefore time.Time) ([]*models.ExecutionChainRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetRecoverableChainRuns")
+	result, err := r.next.GetRecoverableChainRuns(ctx, workerID, ownBefore, staleBefore)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) ClaimRecoverableChainRun(ctx context.Context, runID uuid.UUID, workerID string, ownBefore, staleBefore, now time.Time) (bool, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "ClaimRecoverableChainRun")
+	result, err := r.next.ClaimRecoverableChainRun(ctx, runID, workerID, ownBefore, staleBefore, now)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChainRunStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChainRunStatus")
+	err := r.next.UpdateChainRunStatus(ctx, runID, status)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChainRunStep(ctx context.Context, runID uuid.UUID, currentStep int) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChainRunStep")
+	err := r.next.UpdateChainRunStep(ctx, runID, currentStep)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChainRun")
+	err := r.next.UpdateChainRun(ctx, runID, updates)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{}) (bool, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateChainRunIfStatus")
+	result, err := r.next.UpdateChainRunIfStatus(ctx, runID, status, updates)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) CreateStepRun(ctx context.Context, stepRun *models.ExecutionChainStepRun) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CreateStepRun")
+	err := r.next.CreateStepRun(ctx, stepRun)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) GetStepRunsByRun(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainStepRun, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetStepRunsByRun")
+	result, err := r.next.GetStepRunsByRun(ctx, runID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateStepRun")
+	err := r.next.UpdateStepRun(ctx, stepRunID, updates)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) CreateStepRuns(ctx context.Context, stepRuns []*models.ExecutionChainStepRun) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CreateStepRuns")
+	err := r.next.CreateStepRuns(ctx, stepRuns)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateStepRuns(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateStepRuns")
+	err := r.next.UpdateStepRuns(ctx, updates)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) CreateCompensationRun(ctx context.Context, compensation *models.ExecutionChainCompensationRun) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CreateCompensationRun")
+	err := r.next.CreateCompensationRun(ctx, compensation)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) UpdateCompensationRun(ctx context.Context, compensationID uuid.UUID, updates map[string]interface{}) error {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "UpdateCompensationRun")
+	err := r.next.UpdateCompensationRun(ctx, compensationID, updates)
+	done(err)
+	return err
+}
+
+func (r *instrumentedExecutionChainRepository) GetStepsByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*models.ExecutionChainStep, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "GetStepsByWebhook")
+	result, err := r.next.GetStepsByWebhook(ctx, webhookID)
+	done(err)
+	return result, err
+}
+
+func (r *instrumentedExecutionChainRepository) CountStepRunsByWebhookSince(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, error) {
+	ctx, done := r.metrics.start(ctx, "execution_chain", "CountStepRunsByWebhookSince")
+	result, err := r.next.CountStepRunsByWebhookSince(ctx, webhookID, since)
+	done(err)
+	return result, err
+}
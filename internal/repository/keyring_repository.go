@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// KeyringRepository defines the interface for JWT signing key data access
+// This interface provides methods for storing the keys of the JWT keyring and
+// switching the key new tokens are signed with
+type KeyringRepository interface {
+	// CreateKey stores a new JWT key
+	// A key created as primary replaces the current primary key in the same transaction
+	CreateKey(ctx context.Context, key *models.JWTKey) error
+
+	// ListKeys retrieves every JWT key, including retired ones, newest first
+	// Used to load the keyring
+	ListKeys(ctx context.Context) ([]*models.JWTKey, error)
+
+	// PromoteKey makes a key the primary key and demotes the current one
+	// Fails when the key does not exist or is retired
+	PromoteKey(ctx context.Context, id string) error
+
+	// RetireKey marks a key as retired so tokens signed with it stop verifying
+	// Fails when the key does not exist, is already retired or is the primary key
+	RetireKey(ctx context.Context, id string, updates map[string]interface{}) error
+}
+
+// keyringRepository implements KeyringRepository interface
+// Provides concrete implementation of JWT key data access using GORM ORM
+type keyringRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewKeyringRepository creates a new keyring repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: KeyringRepository interface implementation
+func NewKeyringRepository(db *gorm.DB) KeyringRepository {
+	return &keyringRepository{db: db}
+}
+
+// CreateKey stores a new JWT key in the database
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - key: JWTKey model with ID and secret
+//
+// Returns: error if creation fails, nil on success
+func (r *keyringRepository) CreateKey(ctx context.Context, key *models.JWTKey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if key.IsPrimary {
+			if err := tx.Model(&models.JWTKey{}).Where("is_primary = ?", true).Update("is_primary", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(key).Error
+	})
+}
+
+// ListKeys retrieves every JWT key
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//
+// Returns: Slice of JWTKey pointers newest first, error if query fails
+func (r *keyringRepository) ListKeys(ctx context.Context) ([]*models.JWTKey, error) {
+	var keys []*models.JWTKey
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// PromoteKey makes a key the primary key
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: Key identifier
+//
+// Returns: error if no unretired key has the ID or the update fails
+func (r *keyringRepository) PromoteKey(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.JWTKey{}).Where("is_primary = ? AND id <> ?", true, id).Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.JWTKey{}).Where("id = ? AND retired_at IS NULL", id).Update("is_primary", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("jwt key %s does not exist or is retired", id)
+		}
+		return nil
+	})
+}
+
+// RetireKey marks a key as retired
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: Key identifier
+//   - updates: Map of field names to new values, at least retired_at
+//
+// Returns: error if no unretired, non-primary key has the ID or the update fails
+func (r *keyringRepository) RetireKey(ctx context.Context, id string, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&models.JWTKey{}).
+		Where("id = ? AND retired_at IS NULL AND is_primary = ?", id, false).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("jwt key %s does not exist, is already retired or is the primary key", id)
+	}
+	return nil
+}
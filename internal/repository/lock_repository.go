@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// LockRepository defines the interface for locks shared by every instance using the database
+// This interface provides named locks so work that several instances pick up, such as admitting
+// queued chain runs or releasing a paused subscription's deliveries, is done by one instance at a time
+type LockRepository interface {
+	// WithLock runs fn while holding the named lock, waiting while another instance holds it
+	// The lock is released when fn returns, and when the instance's database session ends
+	WithLock(ctx context.Context, name string, fn func() error) error
+
+	// TryWithLock runs fn while holding the named lock if no other instance holds it
+	// Returns false without running fn when the lock is held elsewhere
+	TryWithLock(ctx context.Context, name string, fn func() error) (bool, error)
+}
+
+// lockRepository implements LockRepository interface
+// Provides the locks as PostgreSQL session-level advisory locks
+type lockRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewLockRepository creates a new lock repository instance
+// Factory function that initializes the repository with a database connection; SQLite has no advisory
+// locks, and its database is used by a single instance, so SQLite databases get locks held in the process
+// Returns: LockRepository interface implementation
+func NewLockRepository(db *gorm.DB) LockRepository {
+	if isSQLite(db) {
+		return newProcessLockRepository()
+	}
+	return &lockRepository{db: db}
+}
+
+// lockKey maps a lock name to the key of its advisory lock
+func lockKey(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+// WithLock runs fn while holding the named advisory lock
+// The lock is taken on a connection reserved for fn's duration, so fn's own queries use other connections
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control, also ending the wait for the lock
+//   - name: Name of the lock, e.g. chain-run-admission
+//   - fn: Work to do while holding the lock
+//
+// Returns: error if the lock cannot be taken, otherwise fn's error
+func (r *lockRepository) WithLock(ctx context.Context, name string, fn func() error) error {
+	_, err := r.withLock(ctx, name, true, fn)
+	return err
+}
+
+// TryWithLock runs fn while holding the named advisory lock if it is free
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - name: Name of the lock, e.g. receiver-pause:<subscription id>
+//   - fn: Work to do while holding the lock
+//
+// Returns: false if another instance holds the lock, error if the lock cannot be taken or fn fails
+func (r *lockRepository) TryWithLock(ctx context.Context, name string, fn func() error) (bool, error) {
+	return r.withLock(ctx, name, false, fn)
+}
+
+// withLock takes the lock, waiting for it or giving up when it is held elsewhere, and runs fn
+// The lock is released on the same connection even when ctx is done, so it is never left held
+func (r *lockRepository) withLock(ctx context.Context, name string, wait bool, fn func() error) (bool, error) {
+	key := lockKey(name)
+	held := false
+	var fnErr error
+	err := r.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if wait {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", key).Error; err != nil {
+				return fmt.Errorf("failed to take lock %s: %w", name, err)
+			}
+		} else {
+			var acquired bool
+			if err := conn.Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&acquired).Error; err != nil {
+				return fmt.Errorf("failed to take lock %s: %w", name, err)
+			}
+			if !acquired {
+				return nil
+			}
+		}
+
+		held = true
+		defer func() {
+			if err := conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", key).Error; err != nil {
+				fnErr = errors.Join(fnErr, fmt.Errorf("failed to release lock %s: %w", name, err))
+			}
+		}()
+		fnErr = fn()
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return held, fnErr
+}
+
+// processLockRepository implements LockRepository interface
+// Provides the locks within the process, for databases used by a single instance
+type processLockRepository struct {
+	mu sync.Mutex
+
+	// locks holds a channel per lock name, full while the lock is held
+	locks map[string]chan struct{}
+}
+
+// newProcessLockRepository creates a lock repository holding its locks in the process
+func newProcessLockRepository() *processLockRepository {
+	return &processLockRepository{locks: make(map[string]chan struct{})}
+}
+
+// lock returns the channel of the named lock
+func (r *processLockRepository) lock(name string) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lock, ok := r.locks[name]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		r.locks[name] = lock
+	}
+	return lock
+}
+
+// WithLock runs fn while holding the named lock, waiting while another goroutine holds it
+func (r *processLockRepository) WithLock(ctx context.Context, name string, fn func() error) error {
+	lock := r.lock(name)
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to take lock %s: %w", name, ctx.Err())
+	}
+	defer func() { <-lock }()
+	return fn()
+}
+
+// TryWithLock runs fn while holding the named lock if it is free
+func (r *processLockRepository) TryWithLock(ctx context.Context, name string, fn func() error) (bool, error) {
+	lock := r.lock(name)
+	select {
+	case lock <- struct{}{}:
+	default:
+		return false, nil
+	}
+	defer func() { <-lock }()
+	return true, fn()
+}
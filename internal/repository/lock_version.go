@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"maps"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned by updates made at a lock version the record is no longer at, because it was
+// changed or deleted since it was read
+var ErrVersionConflict = apperr.Conflict("version_conflict", "record was changed since it was read")
+
+// withLockVersion returns a copy of updates that also increments the lock_version of the updated rows
+func withLockVersion(updates map[string]interface{}) map[string]interface{} {
+	locked := maps.Clone(updates)
+	locked["lock_version"] = gorm.Expr("lock_version + 1")
+	return locked
+}
+
+// lockedUpdate returns the error of an update made at a lock version, ErrVersionConflict when it matched no row
+func lockedUpdate(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// memoryLockVersion returns a copy of updates that also increments a lock version, for the in-memory store
+func memoryLockVersion(updates map[string]interface{}, lockVersion int64) map[string]interface{} {
+	locked := maps.Clone(updates)
+	locked["lock_version"] = lockVersion + 1
+	return locked
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUpdateSubscription_LockVersion tests that of two updates of a subscription read at the same lock version
+// only the first is written, on the database and in memory
+func TestUpdateSubscription_LockVersion(t *testing.T) {
+	repos := map[string]func(t *testing.T) WebhookRepository{
+		"sqlite": func(t *testing.T) WebhookRepository { return NewWebhookRepository(openTestSQLite(t), nil) },
+		"memory": func(t *testing.T) WebhookRepository { return NewMemoryWebhookRepository(NewMemoryStore()) },
+	}
+
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newRepo(t)
+			subscription := &models.WebhookSubscription{
+				TenantID:        "acme",
+				AppName:         "billing",
+				TargetURL:       "https://billing.example.com/hooks",
+				SubscribedEvent: "invoice.paid",
+			}
+			require.NoError(t, repo.CreateSubscription(ctx, subscription))
+			first, err := repo.GetSubscriptionByID(ctx, subscription.ID)
+			require.NoError(t, err)
+			second, err := repo.GetSubscriptionByID(ctx, subscription.ID)
+			require.NoError(t, err)
+
+			// Act
+			first.TargetURL = "https://billing.example.com/v2/hooks"
+			firstErr := repo.UpdateSubscription(ctx, first)
+			second.AppName = "invoicing"
+			secondErr := repo.UpdateSubscription(ctx, second)
+
+			// Assert
+			require.NoError(t, firstErr)
+			assert.ErrorIs(t, secondErr, ErrVersionConflict)
+			stored, err := repo.GetSubscriptionByID(ctx, subscription.ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), stored.LockVersion)
+			assert.Equal(t, "https://billing.example.com/v2/hooks", stored.TargetURL)
+			assert.Equal(t, "billing", stored.AppName)
+		})
+	}
+}
+
+// TestUpdateChainAtVersion_Conflict tests that chain updates at a lock version fail once any update moved the
+// chain past it, on the database and in memory
+func TestUpdateChainAtVersion_Conflict(t *testing.T) {
+	repos := map[string]func(t *testing.T) ExecutionChainRepository{
+		"sqlite": func(t *testing.T) ExecutionChainRepository {
+			return NewExecutionChainRepository(openTestSQLite(t), nil)
+		},
+		"memory": func(t *testing.T) ExecutionChainRepository {
+			return NewMemoryExecutionChainRepository(NewMemoryStore())
+		},
+	}
+
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newRepo(t)
+			chain := &models.ExecutionChain{TenantID: "acme", Name: "fulfil order", TriggerEvent: "order.paid"}
+			require.NoError(t, repo.CreateChain(ctx, chain))
+			require.Equal(t, int64(1), chain.LockVersion)
+
+			// Act
+			atVersionErr := repo.UpdateChainAtVersion(ctx, chain.ID, 1, map[string]interface{}{"name": "fulfil paid order"})
+			paused := repo.UpdateChain(ctx, chain.ID, map[string]interface{}{"is_active": false})
+			staleErr := repo.UpdateChainAtVersion(ctx, chain.ID, 2, map[string]interface{}{"name": "ship order"})
+
+			// Assert
+			require.NoError(t, atVersionErr)
+			require.NoError(t, paused)
+			assert.ErrorIs(t, staleErr, ErrVersionConflict)
+			stored, err := repo.GetChainByID(ctx, chain.ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), stored.LockVersion)
+			assert.Equal(t, "fulfil paid order", stored.Name)
+		})
+	}
+}
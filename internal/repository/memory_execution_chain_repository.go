@@ -0,0 +1,859 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryExecutionChainRepository implements ExecutionChainRepository on a MemoryStore
+// Steps are loaded with the subscriptions they call from the same store, like the preloads of executionChainRepository
+type memoryExecutionChainRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryExecutionChainRepository creates an execution chain repository keeping its data in memory
+func NewMemoryExecutionChainRepository(store *MemoryStore) ExecutionChainRepository {
+	return &memoryExecutionChainRepository{store: store}
+}
+
+// liveChain returns the stored chain with the ID unless it was soft deleted
+func (s *MemoryStore) liveChain(id uuid.UUID) *models.ExecutionChain {
+	chain := s.chains.get(id)
+	if chain == nil || chain.DeletedAt.Valid {
+		return nil
+	}
+	return chain
+}
+
+// stepWebhook returns a copy of the subscription a step calls, nil when it calls none or it is deleted unless
+// deleted subscriptions are included
+func (s *MemoryStore) stepWebhook(id *uuid.UUID, includeDeleted bool) *models.WebhookSubscription {
+	if id == nil {
+		return nil
+	}
+	subscription := s.subscriptions.get(*id)
+	if subscription == nil || (subscription.DeletedAt.Valid && !includeDeleted) {
+		return nil
+	}
+	return cloneRecord(subscription)
+}
+
+// loadStep returns a copy of a stored step with the subscriptions it calls
+func (s *MemoryStore) loadStep(step *models.ExecutionChainStep) models.ExecutionChainStep {
+	loaded := *cloneRecord(step)
+	loaded.Webhook = s.stepWebhook(step.WebhookID, false)
+	loaded.CompensationWebhook = s.stepWebhook(step.CompensationWebhookID, false)
+	return loaded
+}
+
+// loadChain returns a copy of a stored chain with its active steps in step order
+func (s *MemoryStore) loadChain(chain *models.ExecutionChain) *models.ExecutionChain {
+	loaded := cloneRecord(chain)
+	steps := s.steps.scan(func(step *models.ExecutionChainStep) bool {
+		return step.ChainID == chain.ID && step.RetiredAt == nil
+	})
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].StepOrder < steps[j].StepOrder })
+	loaded.Steps = make([]models.ExecutionChainStep, 0, len(steps))
+	for _, step := range steps {
+		loaded.Steps = append(loaded.Steps, s.loadStep(step))
+	}
+	return loaded
+}
+
+// loadChains returns copies of stored chains with their active steps
+func (s *MemoryStore) loadChains(chains []*models.ExecutionChain) []*models.ExecutionChain {
+	loaded := make([]*models.ExecutionChain, 0, len(chains))
+	for _, chain := range chains {
+		loaded = append(loaded, s.loadChain(chain))
+	}
+	return loaded
+}
+
+// liveChains returns the stored chains that were not soft deleted and match a filter
+func (s *MemoryStore) liveChains(match func(*models.ExecutionChain) bool) []*models.ExecutionChain {
+	return s.chains.scan(func(chain *models.ExecutionChain) bool {
+		return !chain.DeletedAt.Valid && match(chain)
+	})
+}
+
+// Chain operations
+
+// CreateChain stores a chain with its steps and, for versioned chains, its first version
+// Nothing is stored when a step's request parameters are not a JSON object
+func (r *memoryExecutionChainRepository) CreateChain(ctx context.Context, chain *models.ExecutionChain) error {
+	for _, step := range chain.Steps {
+		if step.RequestParams != "" {
+			var params map[string]interface{}
+			if err := json.Unmarshal([]byte(step.RequestParams), &params); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	now := time.Now()
+	if err := prepareCreate(chain, now); err != nil {
+		return err
+	}
+	for i := range chain.Steps {
+		chain.Steps[i].ChainID = chain.ID
+		chain.Steps[i].StepOrder = i + 1
+		if err := prepareCreate(&chain.Steps[i], now); err != nil {
+			return err
+		}
+	}
+
+	stored := *chain
+	stored.Steps = nil
+	if err := r.store.chains.insert(stored.ID, &stored); err != nil {
+		return err
+	}
+	for i := range chain.Steps {
+		step := chain.Steps[i]
+		step.Chain, step.Webhook, step.CompensationWebhook = models.ExecutionChain{}, nil, nil
+		if err := r.store.steps.insert(step.ID, &step); err != nil {
+			return err
+		}
+	}
+
+	if chain.Version == 0 {
+		return nil
+	}
+	return r.store.insertVersion(&models.ExecutionChainVersion{
+		ChainID:   chain.ID,
+		Version:   chain.Version,
+		Steps:     models.PinVersionSteps(chain.Steps),
+		CreatedAt: chain.CreatedAt,
+	})
+}
+
+// insertVersion stores a chain version; each version number of a chain is stored once
+func (s *MemoryStore) insertVersion(version *models.ExecutionChainVersion) error {
+	if s.chainVersion(version.ChainID, version.Version) != nil {
+		return gorm.ErrDuplicatedKey
+	}
+	if err := prepareCreate(version, time.Now()); err != nil {
+		return err
+	}
+	return s.versions.insert(version.ID, version)
+}
+
+// chainVersion returns a stored version of a chain, nil when there is none
+func (s *MemoryStore) chainVersion(chainID uuid.UUID, version int) *models.ExecutionChainVersion {
+	versions := s.versions.scan(func(v *models.ExecutionChainVersion) bool {
+		return v.ChainID == chainID && v.Version == version
+	})
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[0]
+}
+
+// GetChainByID retrieves a chain that was not deleted, with its active steps and their webhooks
+func (r *memoryExecutionChainRepository) GetChainByID(ctx context.Context, id uuid.UUID) (*models.ExecutionChain, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	chain := r.store.liveChain(id)
+	if chain == nil || !inTenantScope(ctx, chain.TenantID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.store.loadChain(chain), nil
+}
+
+// GetChainsByTenant retrieves a page of a tenant's filtered chains with their total
+func (r *memoryExecutionChainRepository) GetChainsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]*models.ExecutionChain, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	chains := r.store.liveChains(func(chain *models.ExecutionChain) bool {
+		status := string(chain.Status)
+		values := listValues{isActive: &chain.IsActive, event: &chain.TriggerEvent, status: &status, createdAt: chain.CreatedAt}
+		return chain.TenantID == tenantID && values.matches(filter)
+	})
+	paged := paginateRows(chains, func(c *models.ExecutionChain) Cursor { return ChainCursor(c, page.Sort) }, page)
+	return r.store.loadChains(paged), int64(len(chains)), nil
+}
+
+// GetChainsByTriggerEvent finds the active chains of a tenant triggered by an event
+func (r *memoryExecutionChainRepository) GetChainsByTriggerEvent(ctx context.Context, tenantID, event string) ([]*models.ExecutionChain, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.loadChains(r.store.liveChains(func(chain *models.ExecutionChain) bool {
+		return chain.TenantID == tenantID && chain.TriggerEvent == event && chain.IsActive
+	})), nil
+}
+
+// GetDueScheduledChains finds the active chains whose next scheduled run is due, soonest first
+func (r *memoryExecutionChainRepository) GetDueScheduledChains(ctx context.Context, now time.Time) ([]*models.ExecutionChain, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	chains := r.store.liveChains(func(chain *models.ExecutionChain) bool {
+		return chain.IsActive && chain.Schedule != "" && chain.NextRunAt != nil && !chain.NextRunAt.After(now)
+	})
+	sort.SliceStable(chains, func(i, j int) bool { return chains[i].NextRunAt.Before(*chains[j].NextRunAt) })
+	return r.store.loadChains(chains), nil
+}
+
+// ClaimScheduledRun moves a chain's next scheduled run forward if it is still the due time it was read with
+func (r *memoryExecutionChainRepository) ClaimScheduledRun(ctx context.Context, chainID uuid.UUID, due time.Time, next *time.Time, triggeredAt time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	chain := r.store.liveChain(chainID)
+	if chain == nil || chain.NextRunAt == nil || !chain.NextRunAt.Equal(due) {
+		return false, nil
+	}
+	err := updateRow(chain, map[string]interface{}{
+		"next_run_at":       next,
+		"last_scheduled_at": triggeredAt,
+	}, time.Now())
+	return err == nil, err
+}
+
+// UpdateChain modifies the given columns of a chain
+func (r *memoryExecutionChainRepository) UpdateChain(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	chain := r.store.liveChain(id)
+	if chain == nil || !inTenantScope(ctx, chain.TenantID) {
+		return scopedMiss(ctx)
+	}
+	return updateRow(chain, memoryLockVersion(updates, chain.LockVersion), time.Now())
+}
+
+// UpdateChainAtVersion updates fields of a chain that is still at a lock version
+func (r *memoryExecutionChainRepository) UpdateChainAtVersion(ctx context.Context, id uuid.UUID, lockVersion int64, updates map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	chain := r.store.liveChain(id)
+	if chain == nil || !inTenantScope(ctx, chain.TenantID) || chain.LockVersion != lockVersion {
+		return ErrVersionConflict
+	}
+	return updateRow(chain, memoryLockVersion(updates, chain.LockVersion), time.Now())
+}
+
+// UpdateChainSteps replaces the active steps of a chain and records the new version
+// Fails without changes when the chain's version changed since the new version was computed
+func (r *memoryExecutionChainRepository) UpdateChainSteps(ctx context.Context, chainID uuid.UUID, kept, created []models.ExecutionChainStep, retired []uuid.UUID, version *models.ExecutionChainVersion) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.advanceChainVersion(version); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, id := range retired {
+		if step := r.store.steps.get(id); step != nil && step.ChainID == chainID {
+			if err := updateRow(step, map[string]interface{}{"retired_at": version.CreatedAt}, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range kept {
+		step := r.store.steps.get(kept[i].ID)
+		if step == nil {
+			continue
+		}
+		updatedAt := kept[i].UpdatedAt
+		if updatedAt.IsZero() {
+			updatedAt = now
+		}
+		if err := updateRow(step, map[string]interface{}{
+			"step_order": kept[i].StepOrder,
+			"depends_on": kept[i].DependsOn,
+			"updated_at": updatedAt,
+		}, now); err != nil {
+			return err
+		}
+	}
+
+	for i := range created {
+		created[i].ChainID = chainID
+		if err := prepareCreate(&created[i], now); err != nil {
+			return err
+		}
+		step := created[i]
+		step.Chain, step.Webhook, step.CompensationWebhook = models.ExecutionChain{}, nil, nil
+		if err := r.store.steps.insert(step.ID, &step); err != nil {
+			return err
+		}
+	}
+
+	return r.store.insertVersion(version)
+}
+
+// CreateChainVersion records a version of a chain's steps and makes it the chain's current version
+func (r *memoryExecutionChainRepository) CreateChainVersion(ctx context.Context, version *models.ExecutionChainVersion) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.advanceChainVersion(version); err != nil {
+		return err
+	}
+	return r.store.insertVersion(version)
+}
+
+// advanceChainVersion moves a chain from the version preceding the given one to the given version
+// Returns an error when the chain is at a different version, i.e. it was changed concurrently
+func (s *MemoryStore) advanceChainVersion(version *models.ExecutionChainVersion) error {
+	chain := s.liveChain(version.ChainID)
+	if chain == nil || chain.Version != version.Version-1 {
+		return ErrVersionConflict.Withf("chain %s is no longer at version %d", version.ChainID, version.Version-1)
+	}
+	return updateRow(chain, map[string]interface{}{
+		"version":      version.Version,
+		"updated_at":   version.CreatedAt,
+		"lock_version": chain.LockVersion + 1,
+	}, time.Now())
+}
+
+// GetChainVersion retrieves one version of a chain
+func (r *memoryExecutionChainRepository) GetChainVersion(ctx context.Context, chainID uuid.UUID, version int) (*models.ExecutionChainVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	chainVersion := r.store.chainVersion(chainID, version)
+	if chainVersion == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneRecord(chainVersion), nil
+}
+
+// GetChainVersions retrieves every version of a chain ordered by version
+func (r *memoryExecutionChainRepository) GetChainVersions(ctx context.Context, chainID uuid.UUID) ([]models.ExecutionChainVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	versions := r.store.versions.scan(func(v *models.ExecutionChainVersion) bool { return v.ChainID == chainID })
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return cloneValues(versions), nil
+}
+
+// GetStepsByIDs retrieves step definitions by ID, including retired steps, with their webhooks
+func (r *memoryExecutionChainRepository) GetStepsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.ExecutionChainStep, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	wanted := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	var steps []models.ExecutionChainStep
+	for _, step := range r.store.steps.scan(func(step *models.ExecutionChainStep) bool { return wanted[step.ID] }) {
+		steps = append(steps, r.store.loadStep(step))
+	}
+	return steps, nil
+}
+
+// DeleteChain soft deletes a chain; its steps, versions and runs are kept
+func (r *memoryExecutionChainRepository) DeleteChain(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	chain := r.store.liveChain(id)
+	if chain == nil || !inTenantScope(ctx, chain.TenantID) {
+		return scopedMiss(ctx)
+	}
+	chain.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// RestoreChain clears the deletion of a soft deleted chain
+func (r *memoryExecutionChainRepository) RestoreChain(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	chain := r.store.chains.get(id)
+	if chain == nil || !chain.DeletedAt.Valid || !inTenantScope(ctx, chain.TenantID) {
+		return gorm.ErrRecordNotFound
+	}
+	chain.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Chain run operations
+
+// CreateChainRun stores a new run of a chain
+func (r *memoryExecutionChainRepository) CreateChainRun(ctx context.Context, run *models.ExecutionChainRun) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(run, time.Now()); err != nil {
+		return err
+	}
+	stored := *run
+	stored.Chain, stored.StepRuns, stored.Compensations = models.ExecutionChain{}, nil, nil
+	return r.store.runs.insert(stored.ID, &stored)
+}
+
+// runStepRuns returns copies of the step runs of a run in step order, with their steps and, if asked for,
+// the webhooks the steps call including deleted ones
+func (s *MemoryStore) runStepRuns(runID uuid.UUID, withWebhooks bool) []models.ExecutionChainStepRun {
+	stepRuns := s.stepRuns.scan(func(stepRun *models.ExecutionChainStepRun) bool { return stepRun.RunID == runID })
+	sort.SliceStable(stepRuns, func(i, j int) bool { return stepRuns[i].StepOrder < stepRuns[j].StepOrder })
+
+	loaded := make([]models.ExecutionChainStepRun, 0, len(stepRuns))
+	for _, stepRun := range stepRuns {
+		copied := *cloneRecord(stepRun)
+		if step := s.steps.get(stepRun.StepID); step != nil {
+			copied.Step = *cloneRecord(step)
+			if withWebhooks {
+				copied.Step.Webhook = s.stepWebhook(step.WebhookID, true)
+			}
+		}
+		loaded = append(loaded, copied)
+	}
+	return loaded
+}
+
+// GetChainRunByID retrieves a run with its chain, its step runs and their steps and webhooks, and its compensations
+// Runs of deleted chains, and steps of deleted webhooks, keep showing what they executed
+func (r *memoryExecutionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	stored := r.store.runs.get(runID)
+	if stored == nil || !inTenantScope(ctx, stored.TenantID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	run := cloneRecord(stored)
+	if chain := r.store.chains.get(run.ChainID); chain != nil {
+		run.Chain = *cloneRecord(chain)
+	}
+	run.StepRuns = r.store.runStepRuns(runID, true)
+
+	compensations := r.store.compensations.scan(func(c *models.ExecutionChainCompensationRun) bool { return c.RunID == runID })
+	sort.SliceStable(compensations, func(i, j int) bool { return compensations[i].StepOrder > compensations[j].StepOrder })
+	run.Compensations = cloneValues(compensations)
+	return run, nil
+}
+
+// GetChainRunsByChain retrieves a page of a chain's filtered runs with their step runs and the total
+func (r *memoryExecutionChainRepository) GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, filter models.ListFilter, page Page) ([]*models.ExecutionChainRun, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		status := string(run.Status)
+		values := listValues{event: &run.TriggerEvent, status: &status, createdAt: run.CreatedAt}
+		return run.ChainID == chainID && inTenantScope(ctx, run.TenantID) && values.matches(filter)
+	})
+
+	loaded := cloneRecords(paginateRows(runs, func(r *models.ExecutionChainRun) Cursor { return ChainRunCursor(r, page.Sort) }, page))
+	for _, run := range loaded {
+		run.StepRuns = r.store.runStepRuns(run.ID, false)
+	}
+	return loaded, int64(len(runs)), nil
+}
+
+// GetChainRunStats aggregates the run counts and durations of a chain's runs created since the given time
+func (r *memoryExecutionChainRepository) GetChainRunStats(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainRunStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var stats models.ChainRunStats
+	var durations []float64
+	for _, run := range r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return run.ChainID == chainID && (since == nil || !run.CreatedAt.Before(*since))
+	}) {
+		stats.TotalRuns++
+		switch run.Status {
+		case models.ExecutionChainStatusCompleted:
+			stats.CompletedRuns++
+			if run.StartedAt != nil && run.CompletedAt != nil {
+				durations = append(durations, float64(run.CompletedAt.Sub(*run.StartedAt))/float64(time.Millisecond))
+			}
+		case models.ExecutionChainStatusFailed:
+			stats.FailedRuns++
+		case models.ExecutionChainStatusCancelled:
+			stats.CancelledRuns++
+		}
+	}
+	stats.ActiveRuns = stats.TotalRuns - stats.CompletedRuns - stats.FailedRuns - stats.CancelledRuns
+
+	if len(durations) > 0 {
+		var total float64
+		for _, duration := range durations {
+			total += duration
+		}
+		avg := total / float64(len(durations))
+		stats.AvgDurationMs = &avg
+	}
+	p := percentiles(durations, 0.5, 0.95, 0.99)
+	stats.P50DurationMs, stats.P95DurationMs, stats.P99DurationMs = p[0], p[1], p[2]
+	return &stats, nil
+}
+
+// GetMostFailingChainStep counts failed step runs per step order and returns the step with the most
+func (r *memoryExecutionChainRepository) GetMostFailingChainStep(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainStepFailures, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	failures := map[int]int64{}
+	for _, stepRun := range r.store.stepRuns.scan(func(stepRun *models.ExecutionChainStepRun) bool {
+		return stepRun.Status == models.WebhookStatusFailed
+	}) {
+		run := r.store.runs.get(stepRun.RunID)
+		if run != nil && run.ChainID == chainID && (since == nil || !run.CreatedAt.Before(*since)) {
+			failures[stepRun.StepOrder]++
+		}
+	}
+
+	var most *models.ChainStepFailures
+	for stepOrder, count := range failures {
+		if most == nil || count > most.Failures || (count == most.Failures && stepOrder < most.StepOrder) {
+			most = &models.ChainStepFailures{StepOrder: stepOrder, Failures: count}
+		}
+	}
+	return most, nil
+}
+
+// addStepRunUsage adds the resources of a step run like chainUsageColumns
+func addStepRunUsage(usage *models.ResourceUsage, stepRun *models.ExecutionChainStepRun) {
+	if stepRun.Status != models.WebhookStatusSkipped {
+		usage.Steps++
+	}
+	usage.Attempts += int64(stepRun.AttemptCount)
+	if stepRun.AttemptCount > 1 {
+		usage.Retries += int64(stepRun.AttemptCount - 1)
+	}
+	if stepRun.StartedAt != nil && stepRun.CompletedAt != nil {
+		usage.StepTimeMs += stepRun.CompletedAt.Sub(*stepRun.StartedAt).Milliseconds()
+	}
+	usage.RequestBytes += stepRun.RequestBytes
+	usage.ResponseBytes += stepRun.ResponseBytes
+}
+
+// GetChainUsage sums the resources used by the step runs of a tenant's runs created since the given time,
+// overall and per chain
+func (r *memoryExecutionChainRepository) GetChainUsage(ctx context.Context, tenantID string, since *time.Time) (*models.ChainUsageResponse, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return run.TenantID == tenantID && (since == nil || !run.CreatedAt.Before(*since))
+	})
+	runChains := make(map[uuid.UUID]uuid.UUID, len(runs))
+	usage := &models.ChainUsageResponse{Chains: []models.ChainResourceUsage{}}
+	chains := map[uuid.UUID]int{}
+	for _, run := range runs {
+		runChains[run.ID] = run.ChainID
+		usage.Runs++
+
+		i, ok := chains[run.ChainID]
+		if !ok {
+			i = len(usage.Chains)
+			chains[run.ChainID] = i
+			chainUsage := models.ChainResourceUsage{ChainID: run.ChainID}
+			if chain := r.store.chains.get(run.ChainID); chain != nil {
+				chainUsage.ChainName = chain.Name
+			}
+			usage.Chains = append(usage.Chains, chainUsage)
+		}
+		usage.Chains[i].Runs++
+	}
+
+	for _, stepRun := range r.store.stepRuns.scan(nil) {
+		chainID, ok := runChains[stepRun.RunID]
+		if !ok {
+			continue
+		}
+		addStepRunUsage(&usage.ResourceUsage, stepRun)
+		addStepRunUsage(&usage.Chains[chains[chainID]].ResourceUsage, stepRun)
+	}
+	return usage, nil
+}
+
+// sortRunsByPriority orders runs by descending priority, oldest first within a priority
+func sortRunsByPriority(runs []*models.ExecutionChainRun) {
+	sortByCreatedAt(runs, func(r *models.ExecutionChainRun) time.Time { return r.CreatedAt }, false)
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Priority > runs[j].Priority })
+}
+
+// GetChainRunsByTenantAndStatus retrieves all runs of a tenant in a status, by descending priority and then oldest first
+func (r *memoryExecutionChainRepository) GetChainRunsByTenantAndStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return run.TenantID == tenantID && run.Status == status
+	})
+	sortRunsByPriority(runs)
+	return cloneRecords(runs), nil
+}
+
+// GetChainRunsByStatus retrieves the runs of every tenant in a status, by descending priority and then oldest first
+func (r *memoryExecutionChainRepository) GetChainRunsByStatus(ctx context.Context, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool { return run.Status == status })
+	sortRunsByPriority(runs)
+	return cloneRecords(runs), nil
+}
+
+// CountChainRunsByStatus counts the runs in a status, of one tenant or of all tenants when tenantID is empty
+func (r *memoryExecutionChainRepository) CountChainRunsByStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return run.Status == status && (tenantID == "" || run.TenantID == tenantID)
+	}))), nil
+}
+
+// CountQueuedChainRunsAhead counts the queued runs with a higher priority, or the same priority and an earlier creation time
+func (r *memoryExecutionChainRepository) CountQueuedChainRunsAhead(ctx context.Context, tenantID string, priority int, createdAt time.Time) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return run.Status == models.ExecutionChainStatusQueued &&
+			(run.Priority > priority || (run.Priority == priority && run.CreatedAt.Before(createdAt))) &&
+			(tenantID == "" || run.TenantID == tenantID)
+	}))), nil
+}
+
+// GetChainRunsByChainAndStatus retrieves the runs of a chain in any of the given statuses, oldest first
+func (r *memoryExecutionChainRepository) GetChainRunsByChainAndStatus(ctx context.Context, chainID uuid.UUID, statuses []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		if run.ChainID != chainID {
+			return false
+		}
+		for _, status := range statuses {
+			if run.Status == status {
+				return true
+			}
+		}
+		return false
+	})
+	sortByCreatedAt(runs, func(r *models.ExecutionChainRun) time.Time { return r.CreatedAt }, false)
+	return cloneRecords(runs), nil
+}
+
+// GetChainRunRetries retrieves the runs that continue a failed run, oldest first
+func (r *memoryExecutionChainRepository) GetChainRunRetries(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return run.RetryOfRunID != nil && *run.RetryOfRunID == runID
+	})
+	sortByCreatedAt(runs, func(r *models.ExecutionChainRun) time.Time { return r.CreatedAt }, false)
+	return cloneRecords(runs), nil
+}
+
+// TouchChainRuns records a heartbeat for the running runs among the given ones
+func (r *memoryExecutionChainRepository) TouchChainRuns(ctx context.Context, runIDs []uuid.UUID, workerID string, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, id := range runIDs {
+		run := r.store.runs.get(id)
+		if run == nil || run.Status != models.ExecutionChainStatusRunning {
+			continue
+		}
+		if err := updateRow(run, map[string]interface{}{
+			"worker_id":    workerID,
+			"heartbeat_at": now,
+		}, time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverableChainRun reports whether no live instance is executing a run, like recoverableChainRuns
+func recoverableChainRun(run *models.ExecutionChainRun, workerID string, ownBefore, staleBefore time.Time) bool {
+	if run.Status == models.ExecutionChainStatusInterrupted {
+		return true
+	}
+	return run.Status == models.ExecutionChainStatusRunning && (run.HeartbeatAt == nil ||
+		run.HeartbeatAt.Before(staleBefore) || (run.WorkerID == workerID && run.HeartbeatAt.Before(ownBefore)))
+}
+
+// GetRecoverableChainRuns retrieves the runs left behind by stopped or crashed instances, oldest first
+func (r *memoryExecutionChainRepository) GetRecoverableChainRuns(ctx context.Context, workerID string, ownBefore, staleBefore time.Time) ([]*models.ExecutionChainRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	runs := r.store.runs.scan(func(run *models.ExecutionChainRun) bool {
+		return recoverableChainRun(run, workerID, ownBefore, staleBefore)
+	})
+	sortByCreatedAt(runs, func(r *models.ExecutionChainRun) time.Time { return r.CreatedAt }, false)
+	return cloneRecords(runs), nil
+}
+
+// ClaimRecoverableChainRun queues a recoverable run for the given instance if it is still recoverable
+func (r *memoryExecutionChainRepository) ClaimRecoverableChainRun(ctx context.Context, runID uuid.UUID, workerID string, ownBefore, staleBefore, now time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	run := r.store.runs.get(runID)
+	if run == nil || !recoverableChainRun(run, workerID, ownBefore, staleBefore) {
+		return false, nil
+	}
+	err := updateRow(run, map[string]interface{}{
+		"status":       models.ExecutionChainStatusQueued,
+		"worker_id":    workerID,
+		"heartbeat_at": now,
+		"updated_at":   now,
+	}, now)
+	return err == nil, err
+}
+
+// updateRun applies updates to a stored run, if there is one
+func (s *MemoryStore) updateRun(runID uuid.UUID, updates map[string]interface{}) error {
+	if run := s.runs.get(runID); run != nil {
+		return updateRow(run, updates, time.Now())
+	}
+	return nil
+}
+
+// UpdateChainRunStatus updates the status of a run, setting its completion time for terminal statuses
+func (r *memoryExecutionChainRepository) UpdateChainRunStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus) error {
+	updates := map[string]interface{}{
+		"status": status,
+	}
+	if status == models.ExecutionChainStatusCompleted || status == models.ExecutionChainStatusFailed ||
+		status == models.ExecutionChainStatusCancelled {
+		updates["completed_at"] = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.updateRun(runID, updates)
+}
+
+// UpdateChainRunStep advances the current step pointer of a run
+func (r *memoryExecutionChainRepository) UpdateChainRunStep(ctx context.Context, runID uuid.UUID, currentStep int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.updateRun(runID, map[string]interface{}{"current_step": currentStep})
+}
+
+// UpdateChainRun modifies the given columns of a run
+func (r *memoryExecutionChainRepository) UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.updateRun(runID, updates)
+}
+
+// UpdateChainRunIfStatus modifies the given columns of a run only while it still has the given status
+func (r *memoryExecutionChainRepository) UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{}) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	run := r.store.runs.get(runID)
+	if run == nil || run.Status != status {
+		return false, nil
+	}
+	err := updateRow(run, updates, time.Now())
+	return err == nil, err
+}
+
+// Step run operations
+
+// CreateStepRun stores the execution of a step within a run
+func (r *memoryExecutionChainRepository) CreateStepRun(ctx context.Context, stepRun *models.ExecutionChainStepRun) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(stepRun, time.Now()); err != nil {
+		return err
+	}
+	stored := *stepRun
+	stored.Run, stored.Step = models.ExecutionChainRun{}, models.ExecutionChainStep{}
+	return r.store.stepRuns.insert(stored.ID, &stored)
+}
+
+// GetStepRunsByRun retrieves the step runs of a run in step order, with their steps and webhooks
+func (r *memoryExecutionChainRepository) GetStepRunsByRun(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainStepRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stepRuns := r.store.runStepRuns(runID, false)
+	loaded := make([]*models.ExecutionChainStepRun, 0, len(stepRuns))
+	for i := range stepRuns {
+		stepRuns[i].Step.Webhook = r.store.stepWebhook(stepRuns[i].Step.WebhookID, false)
+		loaded = append(loaded, &stepRuns[i])
+	}
+	return loaded, nil
+}
+
+// UpdateStepRun modifies the given columns of a step run
+func (r *memoryExecutionChainRepository) UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if stepRun := r.store.stepRuns.get(stepRunID); stepRun != nil {
+		return updateRow(stepRun, updates, time.Now())
+	}
+	return nil
+}
+
+// CreateStepRuns stores several step runs
+func (r *memoryExecutionChainRepository) CreateStepRuns(ctx context.Context, stepRuns []*models.ExecutionChainStepRun) error {
+	for _, stepRun := range stepRuns {
+		if err := r.CreateStepRun(ctx, stepRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateStepRuns modifies the given columns of several step runs
+func (r *memoryExecutionChainRepository) UpdateStepRuns(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) error {
+	for stepRunID, columns := range updates {
+		if err := r.UpdateStepRun(ctx, stepRunID, columns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateCompensationRun stores the call of a step's compensation webhook
+func (r *memoryExecutionChainRepository) CreateCompensationRun(ctx context.Context, compensation *models.ExecutionChainCompensationRun) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(compensation, time.Now()); err != nil {
+		return err
+	}
+	return r.store.compensations.insert(compensation.ID, compensation)
+}
+
+// UpdateCompensationRun modifies the given columns of a compensation webhook call
+func (r *memoryExecutionChainRepository) UpdateCompensationRun(ctx context.Context, compensationID uuid.UUID, updates map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if compensation := r.store.compensations.get(compensationID); compensation != nil {
+		return updateRow(compensation, updates, time.Now())
+	}
+	return nil
+}
+
+// GetStepsByWebhook retrieves the active steps of chains that were not deleted calling a webhook, as their step
+// or their compensation webhook, with their chains
+func (r *memoryExecutionChainRepository) GetStepsByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*models.ExecutionChainStep, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	steps := r.store.steps.scan(func(step *models.ExecutionChainStep) bool {
+		calls := (step.WebhookID != nil && *step.WebhookID == webhookID) ||
+			(step.CompensationWebhookID != nil && *step.CompensationWebhookID == webhookID)
+		return calls && step.RetiredAt == nil && r.store.liveChain(step.ChainID) != nil
+	})
+	sort.SliceStable(steps, func(i, j int) bool {
+		if c := bytes.Compare(steps[i].ChainID[:], steps[j].ChainID[:]); c != 0 {
+			return c < 0
+		}
+		return steps[i].StepOrder < steps[j].StepOrder
+	})
+
+	loaded := cloneRecords(steps)
+	for _, step := range loaded {
+		step.Chain = *cloneRecord(r.store.liveChain(step.ChainID))
+	}
+	return loaded, nil
+}
+
+// CountStepRunsByWebhookSince counts the step executions that called a webhook since a point in time
+func (r *memoryExecutionChainRepository) CountStepRunsByWebhookSince(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.stepRuns.scan(func(stepRun *models.ExecutionChainStepRun) bool {
+		step := r.store.steps.get(stepRun.StepID)
+		return step != nil && step.WebhookID != nil && *step.WebhookID == webhookID && !stepRun.CreatedAt.Before(since)
+	}))), nil
+}
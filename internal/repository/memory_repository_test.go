@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestMemoryWebhookRepository_Subscriptions tests that subscriptions get the database's defaults, are copied on
+// the way in and out, and are soft deleted
+func TestMemoryWebhookRepository_Subscriptions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryWebhookRepository(NewMemoryStore())
+	subscription := &models.WebhookSubscription{
+		TenantID:        "acme",
+		AppName:         "billing",
+		TargetURL:       "https://billing.example.com/hooks",
+		SubscribedEvent: "invoice.paid",
+		Headers:         map[string]string{"X-Team": "billing"},
+	}
+
+	// Act
+	require.NoError(t, repo.CreateSubscription(ctx, subscription))
+	subscription.Headers["X-Team"] = "changed"
+	stored, err := repo.GetSubscriptionByID(ctx, subscription.ID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.NotEqual(t, uuid.Nil, subscription.ID)
+	assert.True(t, stored.IsActive)
+	assert.Equal(t, 3, stored.MaxRetries)
+	assert.Equal(t, 5, stored.RetryDelaySeconds)
+	assert.False(t, stored.CreatedAt.IsZero())
+	assert.Equal(t, "billing", stored.Headers["X-Team"])
+
+	active, err := repo.GetActiveSubscriptionsByTenantAndEvent(ctx, "acme", "invoice.paid")
+	require.NoError(t, err)
+	assert.Len(t, active, 1)
+
+	require.NoError(t, repo.DeleteSubscription(ctx, subscription.ID))
+	_, err = repo.GetSubscriptionByID(ctx, subscription.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	require.NoError(t, repo.RestoreSubscription(ctx, subscription.ID))
+	_, err = repo.GetSubscriptionByID(ctx, subscription.ID)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, repo.RestoreSubscription(ctx, subscription.ID), gorm.ErrRecordNotFound)
+}
+
+// TestMemoryWebhookRepository_DeliveryStats tests that delivery attempts are aggregated like the SQL queries
+func TestMemoryWebhookRepository_DeliveryStats(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryWebhookRepository(NewMemoryStore())
+	webhookID := uuid.New()
+	code := func(c int) *int { return &c }
+	ms := func(v int64) *int64 { return &v }
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.CreateDeliveryAttempts(ctx, []*models.WebhookDeliveryAttempt{
+		{TenantID: "acme", WebhookID: webhookID, Attempt: 1, ResponseCode: code(503), ErrorClass: models.DeliveryErrorClass("server_error"), LatencyMs: 100, CreatedAt: start},
+		{TenantID: "acme", WebhookID: webhookID, Attempt: 2, Final: true, Success: true, ResponseCode: code(200), LatencyMs: 300, EndToEndMs: ms(800), CreatedAt: start.Add(time.Minute)},
+		{TenantID: "acme", WebhookID: webhookID, Attempt: 1, Final: true, Success: true, ResponseCode: code(200), LatencyMs: 200, EndToEndMs: ms(6000), CreatedAt: start.Add(2 * time.Hour)},
+		{TenantID: "other", WebhookID: uuid.New(), Attempt: 1, Final: true, Success: true, ResponseCode: code(200), LatencyMs: 50, CreatedAt: start},
+	}))
+
+	// Act
+	stats, err := repo.GetDeliveryStats(ctx, "acme", nil, nil, time.Hour)
+	require.NoError(t, err)
+	slo, err := repo.GetLatencySLOStats(ctx, "acme", &webhookID, nil, 5000)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(3), stats.Attempts)
+	assert.Equal(t, int64(2), stats.Deliveries)
+	assert.Equal(t, int64(2), stats.Delivered)
+	require.NotNil(t, stats.P50LatencyMs)
+	assert.Equal(t, 200.0, *stats.P50LatencyMs)
+	assert.Equal(t, map[int]int64{503: 1}, stats.FailuresByStatusCode)
+	require.Len(t, stats.Buckets, 2)
+	assert.Equal(t, start, stats.Buckets[0].Start)
+	assert.Equal(t, int64(2), stats.Buckets[0].Attempts)
+	assert.Equal(t, int64(1), stats.Buckets[0].FailuresByClass["server_error"])
+
+	assert.Equal(t, int64(2), slo.Deliveries)
+	assert.Equal(t, int64(1), slo.WithinTarget)
+	require.NotNil(t, slo.P50Ms)
+	assert.Equal(t, 3400.0, *slo.P50Ms)
+	require.Len(t, slo.Subscriptions, 1)
+	assert.Equal(t, webhookID, slo.Subscriptions[0].WebhookID)
+}
+
+// TestMemoryExecutionChainRepository_Runs tests that chains load their steps' webhooks and that column updates,
+// including serialized columns given as JSON, are applied to runs and step runs
+func TestMemoryExecutionChainRepository_Runs(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewMemoryStore()
+	webhooks := NewMemoryWebhookRepository(store)
+	chains := NewMemoryExecutionChainRepository(store)
+
+	webhook := &models.WebhookSubscription{TenantID: "acme", AppName: "shipping", TargetURL: "https://shipping.example.com", SubscribedEvent: "order.paid"}
+	require.NoError(t, webhooks.CreateSubscription(ctx, webhook))
+	chain := &models.ExecutionChain{
+		TenantID:     "acme",
+		Name:         "fulfil order",
+		TriggerEvent: "order.paid",
+		Version:      1,
+		Steps:        []models.ExecutionChainStep{{Name: "ship", WebhookID: &webhook.ID, RequestParams: `{"carrier": "ups"}`}},
+	}
+	require.NoError(t, chains.CreateChain(ctx, chain))
+
+	// Act
+	loaded, err := chains.GetChainByID(ctx, chain.ID)
+	require.NoError(t, err)
+
+	run := &models.ExecutionChainRun{ChainID: chain.ID, TenantID: "acme", Status: models.ExecutionChainStatusRunning}
+	require.NoError(t, chains.CreateChainRun(ctx, run))
+	stepRun := &models.ExecutionChainStepRun{RunID: run.ID, StepID: chain.Steps[0].ID, StepOrder: 1}
+	require.NoError(t, chains.CreateStepRun(ctx, stepRun))
+
+	startedAt := time.Now().Add(-time.Second)
+	require.NoError(t, chains.UpdateChainRun(ctx, run.ID, map[string]interface{}{
+		"started_at": startedAt,
+		"variables":  map[string]interface{}{"tracking": "1Z999"},
+	}))
+	require.NoError(t, chains.UpdateStepRun(ctx, stepRun.ID, map[string]interface{}{
+		"status":        models.WebhookStatusSent,
+		"attempt_count": 2,
+		"approval":      &models.StepApproval{Decision: models.ApprovalDecision("approved")},
+	}))
+	require.NoError(t, chains.UpdateStepRun(ctx, stepRun.ID, map[string]interface{}{
+		"approval": `{"decision": "rejected", "approver": "ops"}`,
+	}))
+	require.NoError(t, chains.UpdateChainRunStatus(ctx, run.ID, models.ExecutionChainStatusCompleted))
+	stored, err := chains.GetChainRunByID(ctx, run.ID)
+	require.NoError(t, err)
+	stats, err := chains.GetChainRunStats(ctx, chain.ID, nil)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, loaded.Steps, 1)
+	assert.Equal(t, 1, loaded.Steps[0].StepOrder)
+	require.NotNil(t, loaded.Steps[0].Webhook)
+	assert.Equal(t, "shipping", loaded.Steps[0].Webhook.AppName)
+	assert.Equal(t, models.ExecutionChainStatus("pending"), loaded.Status)
+	_, err = chains.GetChainVersion(ctx, chain.ID, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "fulfil order", stored.Chain.Name)
+	assert.Equal(t, "1Z999", stored.Variables["tracking"])
+	assert.NotNil(t, stored.CompletedAt)
+	require.Len(t, stored.StepRuns, 1)
+	assert.Equal(t, 2, stored.StepRuns[0].AttemptCount)
+	assert.Equal(t, "ship", stored.StepRuns[0].Step.Name)
+	require.NotNil(t, stored.StepRuns[0].Approval)
+	assert.Equal(t, models.ApprovalDecision("rejected"), stored.StepRuns[0].Approval.Decision, "JSON updates are decoded")
+	assert.Equal(t, "ops", stored.StepRuns[0].Approval.Approver)
+	assert.Equal(t, int64(1), stats.CompletedRuns)
+	assert.NotNil(t, stats.P50DurationMs)
+
+	err = chains.UpdateChainRun(ctx, run.ID, map[string]interface{}{"no_such_column": 1})
+	assert.Error(t, err)
+	err = chains.CreateChainVersion(ctx, &models.ExecutionChainVersion{ChainID: chain.ID, Version: 3})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// MemoryStore keeps the tables of the in-memory webhook and execution chain repositories
+// It lets the service run without Postgres in integration tests and demos, and benchmarks measure the
+// delivery pipeline without database latency. Data lives as long as the process and is not shared between
+// instances, so it suits a single instance only
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	subscriptions memoryTable[models.WebhookSubscription]
+	queued        memoryTable[models.QueuedDelivery]
+	batched       memoryTable[models.BatchedDelivery]
+	ordered       memoryTable[models.OrderedDelivery]
+	eventTypes    memoryTable[models.EventType]
+	attempts      memoryTable[models.WebhookDeliveryAttempt]
+	events        memoryTable[models.WebhookEvent]
+
+	// pauses holds the delivery pauses by scope, which is not a UUID
+	pauses map[string]*models.DeliveryPause
+
+	chains        memoryTable[models.ExecutionChain]
+	steps         memoryTable[models.ExecutionChainStep]
+	versions      memoryTable[models.ExecutionChainVersion]
+	runs          memoryTable[models.ExecutionChainRun]
+	stepRuns      memoryTable[models.ExecutionChainStepRun]
+	compensations memoryTable[models.ExecutionChainCompensationRun]
+}
+
+// NewMemoryStore creates an empty in-memory store
+// Pass it to NewMemoryWebhookRepository and NewMemoryExecutionChainRepository, so chains see the
+// subscriptions their steps call
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// memoryTable holds the rows of one table by ID and remembers the order they were inserted in,
+// which breaks ties when rows are ordered by time
+type memoryTable[T any] struct {
+	rows  map[uuid.UUID]*T
+	order []uuid.UUID
+}
+
+// insert stores a copy of a row, failing like a primary key violation when the ID is taken
+func (t *memoryTable[T]) insert(id uuid.UUID, row *T) error {
+	if t.rows == nil {
+		t.rows = make(map[uuid.UUID]*T)
+	}
+	if _, ok := t.rows[id]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	t.rows[id] = cloneRecord(row)
+	t.order = append(t.order, id)
+	return nil
+}
+
+// get returns the stored row with the ID, nil when there is none
+func (t *memoryTable[T]) get(id uuid.UUID) *T {
+	return t.rows[id]
+}
+
+// remove deletes the row with the ID, compacting the insertion order once most of it is stale
+func (t *memoryTable[T]) remove(id uuid.UUID) {
+	if _, ok := t.rows[id]; !ok {
+		return
+	}
+	delete(t.rows, id)
+	if len(t.order) > 2*len(t.rows)+64 {
+		order := make([]uuid.UUID, 0, len(t.rows))
+		for _, id := range t.order {
+			if _, ok := t.rows[id]; ok {
+				order = append(order, id)
+			}
+		}
+		t.order = order
+	}
+}
+
+// scan returns the stored rows matching a filter in insertion order; nil matches every row
+func (t *memoryTable[T]) scan(match func(*T) bool) []*T {
+	var rows []*T
+	for _, id := range t.order {
+		row, ok := t.rows[id]
+		if ok && (match == nil || match(row)) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// memorySchemas caches the GORM schemas of the models kept in memory
+var memorySchemas sync.Map
+
+// memorySchema parses a model's GORM schema, which maps column names to fields and knows their defaults
+func memorySchema(record interface{}) (*schema.Schema, error) {
+	return schema.Parse(record, &memorySchemas, schema.NamingStrategy{})
+}
+
+// prepareCreate fills in what the database would on insert: a generated ID, the column defaults of zero
+// fields and the creation and update times
+func prepareCreate(record interface{}, now time.Time) error {
+	s, err := memorySchema(record)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	value := reflect.ValueOf(record).Elem()
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if _, zero := field.ValueOf(ctx, value); !zero {
+			continue
+		}
+
+		var fill interface{}
+		switch {
+		case field.PrimaryKey && field.FieldType == reflect.TypeOf(uuid.UUID{}):
+			fill = uuid.New()
+		case field.AutoCreateTime > 0 || field.AutoUpdateTime > 0:
+			fill = now
+		case field.DefaultValueInterface != nil:
+			fill = field.DefaultValueInterface
+		default:
+			continue
+		}
+		if err := field.Set(ctx, value, fill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyUpdates writes a map of column names to values to a record like GORM's Updates, setting its update
+// time unless the map does; nothing is written when a column is unknown
+func applyUpdates(record interface{}, updates map[string]interface{}, now time.Time) error {
+	s, err := memorySchema(record)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[*schema.Field]interface{}, len(updates)+1)
+	for column, update := range updates {
+		field := s.LookUpField(column)
+		if field == nil || field.DBName == "" {
+			return fmt.Errorf("column %q does not exist in %s", column, s.Table)
+		}
+		fields[field] = update
+	}
+	if _, ok := updates["updated_at"]; !ok {
+		if field := s.LookUpField("updated_at"); field != nil && field.AutoUpdateTime > 0 {
+			fields[field] = now
+		}
+	}
+
+	ctx := context.Background()
+	value := reflect.ValueOf(record).Elem()
+	for field, update := range fields {
+		// Serialized columns may be updated with their stored form, such as a JSON document, as in the database
+		if stored, ok := serializedUpdate(field, update); ok {
+			if err := field.Serializer.Scan(ctx, field, value, stored); err != nil {
+				return fmt.Errorf("column %q of %s: %w", field.DBName, s.Table, err)
+			}
+			continue
+		}
+		if err := field.Set(ctx, value, update); err != nil {
+			return fmt.Errorf("column %q of %s: %w", field.DBName, s.Table, err)
+		}
+	}
+	return nil
+}
+
+// serializedUpdate returns an update of a serialized column given in its stored form, a string or bytes,
+// when the column does not hold a string itself
+func serializedUpdate(field *schema.Field, update interface{}) (interface{}, bool) {
+	if field.Serializer == nil || field.IndirectFieldType.Kind() == reflect.String {
+		return nil, false
+	}
+	switch update.(type) {
+	case string, []byte:
+		return update, true
+	}
+	return nil, false
+}
+
+// updateRow applies updates to a stored row and keeps a copy, so the row shares no maps or slices with the caller
+func updateRow[T any](row *T, updates map[string]interface{}, now time.Time) error {
+	updated := cloneRecord(row)
+	if err := applyUpdates(updated, updates, now); err != nil {
+		return err
+	}
+	*row = *cloneRecord(updated)
+	return nil
+}
+
+// cloneRecord returns a deep copy of a record, so callers never share maps, slices or pointers with the store
+func cloneRecord[T any](record *T) *T {
+	clone := new(T)
+	copyValue(reflect.ValueOf(clone).Elem(), reflect.ValueOf(record).Elem())
+	return clone
+}
+
+// cloneRecords returns deep copies of stored rows
+func cloneRecords[T any](rows []*T) []*T {
+	clones := make([]*T, 0, len(rows))
+	for _, row := range rows {
+		clones = append(clones, cloneRecord(row))
+	}
+	return clones
+}
+
+// cloneValues returns deep copies of stored rows as values
+func cloneValues[T any](rows []*T) []T {
+	clones := make([]T, 0, len(rows))
+	for _, row := range rows {
+		clones = append(clones, *cloneRecord(row))
+	}
+	return clones
+}
+
+// copyValue deep copies src into dst; unexported fields, such as those of time.Time, are copied as they are
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		copyValue(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			value := reflect.New(src.Type().Elem()).Elem()
+			copyValue(value, iter.Value())
+			dst.SetMapIndex(iter.Key(), value)
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		value := reflect.New(src.Elem().Type()).Elem()
+		copyValue(value, src.Elem())
+		dst.Set(value)
+	default:
+		dst.Set(src)
+	}
+}
+
+// page applies an offset and a limit to rows like SQL's OFFSET and LIMIT; a negative limit returns every row
+func page[T any](rows []*T, offset, limit int) []*T {
+	if offset > 0 {
+		if offset >= len(rows) {
+			return nil
+		}
+		rows = rows[offset:]
+	}
+	if limit >= 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// percentiles interpolates values like PERCENTILE_CONT, one result per fraction; nil without values
+func percentiles(values []float64, fractions ...float64) []*float64 {
+	results := make([]*float64, len(fractions))
+	if len(values) == 0 {
+		return results
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	for i, fraction := range fractions {
+		position := fraction * float64(len(sorted)-1)
+		lower := int(math.Floor(position))
+		result := sorted[lower]
+		if lower+1 < len(sorted) {
+			result += (position - float64(lower)) * (sorted[lower+1] - sorted[lower])
+		}
+		results[i] = &result
+	}
+	return results
+}
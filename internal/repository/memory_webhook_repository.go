@@ -0,0 +1,855 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryWebhookRepository implements WebhookRepository on a MemoryStore
+// Queries follow the SQL of webhookRepository, including soft deletion and the ordering of results
+type memoryWebhookRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryWebhookRepository creates a webhook repository keeping its data in memory
+func NewMemoryWebhookRepository(store *MemoryStore) WebhookRepository {
+	return &memoryWebhookRepository{store: store}
+}
+
+// liveSubscription returns the stored subscription with the ID unless it was soft deleted
+func (s *MemoryStore) liveSubscription(id uuid.UUID) *models.WebhookSubscription {
+	subscription := s.subscriptions.get(id)
+	if subscription == nil || subscription.DeletedAt.Valid {
+		return nil
+	}
+	return subscription
+}
+
+// liveSubscriptions returns the stored subscriptions that were not soft deleted and match a filter
+func (s *MemoryStore) liveSubscriptions(match func(*models.WebhookSubscription) bool) []*models.WebhookSubscription {
+	return s.subscriptions.scan(func(subscription *models.WebhookSubscription) bool {
+		return !subscription.DeletedAt.Valid && match(subscription)
+	})
+}
+
+// sortByCreatedAt orders rows by their creation time, keeping the insertion order of rows created at once
+func sortByCreatedAt[T any](rows []*T, createdAt func(*T) time.Time, descending bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if descending {
+			return createdAt(rows[i]).After(createdAt(rows[j]))
+		}
+		return createdAt(rows[i]).Before(createdAt(rows[j]))
+	})
+}
+
+// Subscription operations
+
+// CreateSubscription stores a new webhook subscription
+func (r *memoryWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(subscription, time.Now()); err != nil {
+		return err
+	}
+	return r.store.subscriptions.insert(subscription.ID, subscription)
+}
+
+// GetSubscriptionByID retrieves a webhook subscription that was not deleted
+func (r *memoryWebhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	subscription := r.store.liveSubscription(id)
+	if subscription == nil || !inTenantScope(ctx, subscription.TenantID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneRecord(subscription), nil
+}
+
+// GetActiveSubscriptionsByTenantAndEvent finds the active subscriptions of a tenant to an event
+func (r *memoryWebhookRepository) GetActiveSubscriptionsByTenantAndEvent(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return cloneValues(r.store.liveSubscriptions(func(subscription *models.WebhookSubscription) bool {
+		return subscription.TenantID == tenantID && subscription.SubscribedEvent == event && subscription.IsActive
+	})), nil
+}
+
+// GetSubscriptionsByTenant retrieves a page of a tenant's filtered subscriptions with their total
+func (r *memoryWebhookRepository) GetSubscriptionsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]models.WebhookSubscription, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	subscriptions := r.store.liveSubscriptions(func(subscription *models.WebhookSubscription) bool {
+		typ := string(subscription.Type)
+		values := listValues{isActive: &subscription.IsActive, typ: &typ, event: &subscription.SubscribedEvent, createdAt: subscription.CreatedAt}
+		return subscription.TenantID == tenantID && values.matches(filter)
+	})
+	paged := paginateRows(subscriptions, func(s *models.WebhookSubscription) Cursor { return SubscriptionCursor(s, page.Sort) }, page)
+	return cloneValues(paged), int64(len(subscriptions)), nil
+}
+
+// UpdateSubscription saves the fields of a subscription still at the lock version it was read at, but its pause
+func (r *memoryWebhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored := r.store.liveSubscription(subscription.ID)
+	if stored == nil || stored.LockVersion != subscription.LockVersion {
+		return ErrVersionConflict
+	}
+	subscription.UpdatedAt = time.Now()
+	subscription.LockVersion++
+	updated := cloneRecord(subscription)
+	updated.CreatedAt, updated.PausedUntil = stored.CreatedAt, stored.PausedUntil
+	*stored = *updated
+	return nil
+}
+
+// DeleteSubscription soft deletes a subscription
+func (r *memoryWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	subscription := r.store.liveSubscription(id)
+	if subscription == nil || !inTenantScope(ctx, subscription.TenantID) {
+		return scopedMiss(ctx)
+	}
+	subscription.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// RestoreSubscription clears the deletion of a soft deleted subscription
+func (r *memoryWebhookRepository) RestoreSubscription(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	subscription := r.store.subscriptions.get(id)
+	if subscription == nil || !subscription.DeletedAt.Valid || !inTenantScope(ctx, subscription.TenantID) {
+		return gorm.ErrRecordNotFound
+	}
+	subscription.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// SetSubscriptionPause sets or clears the time until which deliveries to a subscription are paused
+func (r *memoryWebhookRepository) SetSubscriptionPause(ctx context.Context, id uuid.UUID, pausedUntil *time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if subscription := r.store.liveSubscription(id); subscription != nil {
+		return updateRow(subscription, map[string]interface{}{"paused_until": pausedUntil}, time.Now())
+	}
+	return nil
+}
+
+// GetSubscriptionsPausedUntil retrieves the subscriptions whose pause ended at or before a point in time
+func (r *memoryWebhookRepository) GetSubscriptionsPausedUntil(ctx context.Context, until time.Time) ([]models.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	subscriptions := r.store.liveSubscriptions(func(subscription *models.WebhookSubscription) bool {
+		return subscription.PausedUntil != nil && !subscription.PausedUntil.After(until)
+	})
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return subscriptions[i].PausedUntil.Before(*subscriptions[j].PausedUntil)
+	})
+	return cloneValues(subscriptions), nil
+}
+
+// GetFailingSubscriptions retrieves the active subscriptions of a tenant whose final delivery attempts since a
+// point in time, or since they were enabled again if later, include at least failures failed ones and no success
+func (r *memoryWebhookRepository) GetFailingSubscriptions(ctx context.Context, tenantID string, since time.Time, failures int) ([]models.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscriptions := r.store.liveSubscriptions(func(subscription *models.WebhookSubscription) bool {
+		return subscription.TenantID == tenantID && subscription.IsActive
+	})
+	windowStarts := make(map[uuid.UUID]time.Time, len(subscriptions))
+	for _, subscription := range subscriptions {
+		windowStarts[subscription.ID] = since
+		if subscription.ReenabledAt != nil && subscription.ReenabledAt.After(since) {
+			windowStarts[subscription.ID] = *subscription.ReenabledAt
+		}
+	}
+
+	failed := map[uuid.UUID]int{}
+	succeeded := map[uuid.UUID]bool{}
+	for _, attempt := range r.store.attempts.scan(func(attempt *models.WebhookDeliveryAttempt) bool {
+		windowStart, ok := windowStarts[attempt.WebhookID]
+		return ok && attempt.Final && !attempt.CreatedAt.Before(windowStart)
+	}) {
+		if attempt.Success {
+			succeeded[attempt.WebhookID] = true
+		} else {
+			failed[attempt.WebhookID]++
+		}
+	}
+
+	var failing []*models.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if failed[subscription.ID] >= failures && !succeeded[subscription.ID] {
+			failing = append(failing, subscription)
+		}
+	}
+	sortByCreatedAt(failing, func(s *models.WebhookSubscription) time.Time { return s.CreatedAt }, false)
+	return cloneValues(failing), nil
+}
+
+// DisableSubscription deactivates an active subscription, recording when and why
+func (r *memoryWebhookRepository) DisableSubscription(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	subscription := r.store.liveSubscription(id)
+	if subscription == nil || !subscription.IsActive {
+		return false, nil
+	}
+	err := updateRow(subscription, memoryLockVersion(map[string]interface{}{
+		"is_active":       false,
+		"disabled_at":     disabledAt,
+		"disabled_reason": reason,
+	}, subscription.LockVersion), time.Now())
+	return err == nil, err
+}
+
+// EnableSubscription activates a subscription again and clears why it was deactivated
+func (r *memoryWebhookRepository) EnableSubscription(ctx context.Context, id uuid.UUID, enabledAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	subscription := r.store.liveSubscription(id)
+	if subscription == nil || !inTenantScope(ctx, subscription.TenantID) {
+		return scopedMiss(ctx)
+	}
+	return updateRow(subscription, memoryLockVersion(map[string]interface{}{
+		"is_active":       true,
+		"disabled_at":     nil,
+		"disabled_reason": "",
+		"reenabled_at":    enabledAt,
+	}, subscription.LockVersion), time.Now())
+}
+
+// Queued delivery operations
+
+// CreateQueuedDelivery queues a delivery until its subscription's pause ends
+func (r *memoryWebhookRepository) CreateQueuedDelivery(ctx context.Context, delivery *models.QueuedDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(delivery, time.Now()); err != nil {
+		return err
+	}
+	return r.store.queued.insert(delivery.ID, delivery)
+}
+
+// GetQueuedDeliveries retrieves the queued deliveries of a subscription, oldest first
+func (r *memoryWebhookRepository) GetQueuedDeliveries(ctx context.Context, subscriptionID uuid.UUID) ([]models.QueuedDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	deliveries := r.store.queued.scan(func(delivery *models.QueuedDelivery) bool {
+		return delivery.SubscriptionID == subscriptionID
+	})
+	sortByCreatedAt(deliveries, func(d *models.QueuedDelivery) time.Time { return d.CreatedAt }, false)
+	return cloneValues(deliveries), nil
+}
+
+// DeleteQueuedDelivery removes a queued delivery once it has been sent
+func (r *memoryWebhookRepository) DeleteQueuedDelivery(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.queued.remove(id)
+	return nil
+}
+
+// CountQueuedDeliveriesByEvent counts the deliveries of an event that are still queued
+func (r *memoryWebhookRepository) CountQueuedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.queued.scan(func(delivery *models.QueuedDelivery) bool {
+		return delivery.EventID == eventID
+	}))), nil
+}
+
+// Batched delivery operations
+
+// CreateBatchedDelivery buffers a delivery for its subscription's next batch
+func (r *memoryWebhookRepository) CreateBatchedDelivery(ctx context.Context, delivery *models.BatchedDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(delivery, time.Now()); err != nil {
+		return err
+	}
+	return r.store.batched.insert(delivery.ID, delivery)
+}
+
+// subscriptionBatch returns the buffered deliveries of a subscription, oldest first
+func (s *MemoryStore) subscriptionBatch(subscriptionID uuid.UUID) []*models.BatchedDelivery {
+	deliveries := s.batched.scan(func(delivery *models.BatchedDelivery) bool {
+		return delivery.SubscriptionID == subscriptionID
+	})
+	sortByCreatedAt(deliveries, func(d *models.BatchedDelivery) time.Time { return d.CreatedAt }, false)
+	return deliveries
+}
+
+// GetBatchedDeliveries retrieves up to limit buffered deliveries of a subscription, oldest first
+func (r *memoryWebhookRepository) GetBatchedDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.BatchedDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return cloneValues(page(r.store.subscriptionBatch(subscriptionID), 0, limit)), nil
+}
+
+// DeleteBatchedDeliveries removes buffered deliveries once their batch has been sent
+func (r *memoryWebhookRepository) DeleteBatchedDeliveries(ctx context.Context, ids []uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, id := range ids {
+		r.store.batched.remove(id)
+	}
+	return nil
+}
+
+// CountBatchedDeliveriesByEvent counts the deliveries of an event that are still buffered
+func (r *memoryWebhookRepository) CountBatchedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.batched.scan(func(delivery *models.BatchedDelivery) bool {
+		return delivery.EventID == eventID
+	}))), nil
+}
+
+// GetSubscriptionsWithDueBatches retrieves the unpaused subscriptions with a full batch buffered, or whose
+// oldest buffered delivery has waited the batch window
+func (r *memoryWebhookRepository) GetSubscriptionsWithDueBatches(ctx context.Context, now time.Time) ([]models.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	batches := map[uuid.UUID][]*models.BatchedDelivery{}
+	for _, delivery := range r.store.batched.scan(nil) {
+		batches[delivery.SubscriptionID] = append(batches[delivery.SubscriptionID], delivery)
+	}
+	return cloneValues(r.store.liveSubscriptions(func(subscription *models.WebhookSubscription) bool {
+		batch := batches[subscription.ID]
+		if subscription.PausedUntil != nil || len(batch) == 0 {
+			return false
+		}
+		sortByCreatedAt(batch, func(d *models.BatchedDelivery) time.Time { return d.CreatedAt }, false)
+
+		maxEvents := subscription.BatchMaxEvents
+		if maxEvents == 0 {
+			maxEvents = models.MaxBatchEvents
+		}
+		window := time.Duration(subscription.BatchWindowSeconds) * time.Second
+		return len(batch) >= maxEvents || !batch[0].CreatedAt.After(now.Add(-window))
+	})), nil
+}
+
+// Ordered delivery operations
+
+// CreateOrderedDelivery queues a delivery behind the subscription's earlier deliveries with the same key
+func (r *memoryWebhookRepository) CreateOrderedDelivery(ctx context.Context, delivery *models.OrderedDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(delivery, time.Now()); err != nil {
+		return err
+	}
+	return r.store.ordered.insert(delivery.ID, delivery)
+}
+
+// orderedQueueHead returns the oldest delivery of a subscription and ordering key, nil when there is none
+func (s *MemoryStore) orderedQueueHead(subscriptionID uuid.UUID, orderingKey string) *models.OrderedDelivery {
+	deliveries := s.ordered.scan(func(delivery *models.OrderedDelivery) bool {
+		return delivery.SubscriptionID == subscriptionID && delivery.OrderingKey == orderingKey
+	})
+	sortByCreatedAt(deliveries, func(d *models.OrderedDelivery) time.Time { return d.CreatedAt }, false)
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return deliveries[0]
+}
+
+// ClaimOrderedDelivery claims the oldest delivery of a subscription and ordering key unless it is being sent
+func (r *memoryWebhookRepository) ClaimOrderedDelivery(ctx context.Context, subscriptionID uuid.UUID, orderingKey string, now, staleBefore time.Time) (*models.OrderedDelivery, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	head := r.store.orderedQueueHead(subscriptionID, orderingKey)
+	if head == nil || (head.ClaimedAt != nil && !head.ClaimedAt.Before(staleBefore)) {
+		return nil, nil
+	}
+	claimedAt := now
+	head.ClaimedAt = &claimedAt
+	return cloneRecord(head), nil
+}
+
+// ReleaseOrderedDelivery clears the claim on an ordered delivery that was not sent
+func (r *memoryWebhookRepository) ReleaseOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if delivery := r.store.ordered.get(id); delivery != nil {
+		delivery.ClaimedAt = nil
+	}
+	return nil
+}
+
+// DeleteOrderedDelivery removes an ordered delivery once it has been sent
+func (r *memoryWebhookRepository) DeleteOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.ordered.remove(id)
+	return nil
+}
+
+// CountOrderedDeliveriesByEvent counts the deliveries of an event still waiting for their turn
+func (r *memoryWebhookRepository) CountOrderedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.ordered.scan(func(delivery *models.OrderedDelivery) bool {
+		return delivery.EventID == eventID
+	}))), nil
+}
+
+// GetStalledOrderedDeliveries retrieves the heads of the ordering queues of unpaused subscriptions that
+// nobody is sending
+func (r *memoryWebhookRepository) GetStalledOrderedDeliveries(ctx context.Context, staleBefore time.Time) ([]models.OrderedDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type queue struct {
+		subscriptionID uuid.UUID
+		orderingKey    string
+	}
+	deliveries := r.store.ordered.scan(nil)
+	sortByCreatedAt(deliveries, func(d *models.OrderedDelivery) time.Time { return d.CreatedAt }, false)
+	seen := map[queue]bool{}
+	var stalled []*models.OrderedDelivery
+	for _, head := range deliveries {
+		key := queue{head.SubscriptionID, head.OrderingKey}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		subscription := r.store.liveSubscription(head.SubscriptionID)
+		if subscription == nil || subscription.PausedUntil != nil {
+			continue
+		}
+		if head.ClaimedAt == nil || head.ClaimedAt.Before(staleBefore) {
+			stalled = append(stalled, head)
+		}
+	}
+	sort.SliceStable(stalled, func(i, j int) bool {
+		if stalled[i].SubscriptionID != stalled[j].SubscriptionID {
+			return stalled[i].SubscriptionID.String() < stalled[j].SubscriptionID.String()
+		}
+		return stalled[i].OrderingKey < stalled[j].OrderingKey
+	})
+	return cloneValues(stalled), nil
+}
+
+// Event type operations
+
+// CreateEventType registers an event type; a tenant registers each event name once
+func (r *memoryWebhookRepository) CreateEventType(ctx context.Context, eventType *models.EventType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.eventTypeByName(eventType.TenantID, eventType.Name) != nil {
+		return gorm.ErrDuplicatedKey
+	}
+	if err := prepareCreate(eventType, time.Now()); err != nil {
+		return err
+	}
+	return r.store.eventTypes.insert(eventType.ID, eventType)
+}
+
+// eventTypeByName returns the stored event type a tenant registered for an event name, nil when there is none
+func (s *MemoryStore) eventTypeByName(tenantID, name string) *models.EventType {
+	eventTypes := s.eventTypes.scan(func(eventType *models.EventType) bool {
+		return eventType.TenantID == tenantID && eventType.Name == name
+	})
+	if len(eventTypes) == 0 {
+		return nil
+	}
+	return eventTypes[0]
+}
+
+// GetEventTypeByID retrieves an event type by its unique identifier
+func (r *memoryWebhookRepository) GetEventTypeByID(ctx context.Context, id uuid.UUID) (*models.EventType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	eventType := r.store.eventTypes.get(id)
+	if eventType == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneRecord(eventType), nil
+}
+
+// GetEventTypeByName retrieves the event type a tenant registered for an event name, nil when there is none
+func (r *memoryWebhookRepository) GetEventTypeByName(ctx context.Context, tenantID, name string) (*models.EventType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	eventType := r.store.eventTypeByName(tenantID, name)
+	if eventType == nil {
+		return nil, nil
+	}
+	return cloneRecord(eventType), nil
+}
+
+// GetEventTypesByTenant retrieves every event type of a tenant, ordered by name
+func (r *memoryWebhookRepository) GetEventTypesByTenant(ctx context.Context, tenantID string) ([]models.EventType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	eventTypes := r.store.eventTypes.scan(func(eventType *models.EventType) bool {
+		return eventType.TenantID == tenantID
+	})
+	sort.SliceStable(eventTypes, func(i, j int) bool { return eventTypes[i].Name < eventTypes[j].Name })
+	return cloneValues(eventTypes), nil
+}
+
+// UpdateEventType saves every field of an event type, inserting it when it is not stored
+func (r *memoryWebhookRepository) UpdateEventType(ctx context.Context, eventType *models.EventType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	eventType.UpdatedAt = time.Now()
+	if stored := r.store.eventTypes.get(eventType.ID); stored != nil {
+		*stored = *cloneRecord(eventType)
+		return nil
+	}
+	if err := prepareCreate(eventType, eventType.UpdatedAt); err != nil {
+		return err
+	}
+	return r.store.eventTypes.insert(eventType.ID, eventType)
+}
+
+// DeleteEventType removes an event type
+func (r *memoryWebhookRepository) DeleteEventType(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.eventTypes.remove(id)
+	return nil
+}
+
+// Delivery attempt operations
+
+// CreateDeliveryAttempts records the HTTP attempts of a delivery
+func (r *memoryWebhookRepository) CreateDeliveryAttempts(ctx context.Context, attempts []*models.WebhookDeliveryAttempt) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	now := time.Now()
+	for _, attempt := range attempts {
+		if err := prepareCreate(attempt, now); err != nil {
+			return err
+		}
+	}
+	for _, attempt := range attempts {
+		if err := r.store.attempts.insert(attempt.ID, attempt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tenantAttempts returns the stored delivery attempts of a tenant, or of one of its webhooks, made since a time
+func (s *MemoryStore) tenantAttempts(tenantID string, webhookID *uuid.UUID, since *time.Time, match func(*models.WebhookDeliveryAttempt) bool) []*models.WebhookDeliveryAttempt {
+	return s.attempts.scan(func(attempt *models.WebhookDeliveryAttempt) bool {
+		return attempt.TenantID == tenantID &&
+			(webhookID == nil || attempt.WebhookID == *webhookID) &&
+			(since == nil || !attempt.CreatedAt.Before(*since)) &&
+			(match == nil || match(attempt))
+	})
+}
+
+// deliveryStats aggregates attempts like deliveryStatsColumns
+func deliveryStats(attempts []*models.WebhookDeliveryAttempt) models.DeliveryStats {
+	var stats models.DeliveryStats
+	var latencies []float64
+	for _, attempt := range attempts {
+		stats.Attempts++
+		if attempt.Final {
+			stats.Deliveries++
+			if attempt.Success {
+				stats.Delivered++
+			}
+		}
+		if attempt.ResponseCode != nil {
+			latencies = append(latencies, float64(attempt.LatencyMs))
+		}
+	}
+	p := percentiles(latencies, 0.5, 0.95)
+	stats.P50LatencyMs, stats.P95LatencyMs = p[0], p[1]
+	return stats
+}
+
+// GetDeliveryStats aggregates delivery attempts overall, per time bucket, per error class and per status code
+func (r *memoryWebhookRepository) GetDeliveryStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, bucket time.Duration) (*models.DeliveryStatsResponse, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	attempts := r.store.tenantAttempts(tenantID, webhookID, since, nil)
+	stats := &models.DeliveryStatsResponse{
+		DeliveryStats:        deliveryStats(attempts),
+		FailuresByStatusCode: map[int]int64{},
+		FailuresByClass:      map[models.DeliveryErrorClass]int64{},
+		Buckets:              []models.DeliveryStatsBucket{},
+	}
+
+	bucketSeconds := int64(bucket / time.Second)
+	if bucketSeconds <= 0 {
+		bucketSeconds = 1
+	}
+	buckets := map[int64][]*models.WebhookDeliveryAttempt{}
+	for _, attempt := range attempts {
+		start := attempt.CreatedAt.Unix() / bucketSeconds * bucketSeconds
+		buckets[start] = append(buckets[start], attempt)
+
+		if attempt.Success {
+			continue
+		}
+		stats.FailuresByClass[attempt.ErrorClass]++
+		if attempt.ResponseCode != nil {
+			stats.FailuresByStatusCode[*attempt.ResponseCode]++
+		}
+	}
+
+	starts := make([]int64, 0, len(buckets))
+	for start := range buckets {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	for _, start := range starts {
+		failuresByClass := map[models.DeliveryErrorClass]int64{}
+		for _, attempt := range buckets[start] {
+			if !attempt.Success {
+				failuresByClass[attempt.ErrorClass]++
+			}
+		}
+		stats.Buckets = append(stats.Buckets, models.DeliveryStatsBucket{
+			Start:           time.Unix(start, 0).UTC(),
+			DeliveryStats:   deliveryStats(buckets[start]),
+			FailuresByClass: failuresByClass,
+		})
+	}
+	return stats, nil
+}
+
+// latencySLOStats measures successful attempts against a latency target like latencySLOColumns
+func latencySLOStats(attempts []*models.WebhookDeliveryAttempt, targetMs int64) models.LatencySLOStats {
+	var stats models.LatencySLOStats
+	latencies := make([]float64, 0, len(attempts))
+	for _, attempt := range attempts {
+		stats.Deliveries++
+		if *attempt.EndToEndMs <= targetMs {
+			stats.WithinTarget++
+		}
+		latencies = append(latencies, float64(*attempt.EndToEndMs))
+	}
+	p := percentiles(latencies, 0.5, 0.95, 0.99)
+	stats.P50Ms, stats.P95Ms, stats.P99Ms = p[0], p[1], p[2]
+	return stats
+}
+
+// GetLatencySLOStats measures the end-to-end latency of successful deliveries against a target, overall and
+// per subscription
+func (r *memoryWebhookRepository) GetLatencySLOStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, targetMs int64) (*models.LatencySLOReport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	attempts := r.store.tenantAttempts(tenantID, webhookID, since, func(attempt *models.WebhookDeliveryAttempt) bool {
+		return attempt.EndToEndMs != nil
+	})
+	report := &models.LatencySLOReport{
+		LatencySLOStats: latencySLOStats(attempts, targetMs),
+		Subscriptions:   []models.SubscriptionLatencySLO{},
+	}
+
+	var webhooks []uuid.UUID
+	byWebhook := map[uuid.UUID][]*models.WebhookDeliveryAttempt{}
+	for _, attempt := range attempts {
+		if _, ok := byWebhook[attempt.WebhookID]; !ok {
+			webhooks = append(webhooks, attempt.WebhookID)
+		}
+		byWebhook[attempt.WebhookID] = append(byWebhook[attempt.WebhookID], attempt)
+	}
+	for _, id := range webhooks {
+		report.Subscriptions = append(report.Subscriptions, models.SubscriptionLatencySLO{
+			WebhookID:       id,
+			LatencySLOStats: latencySLOStats(byWebhook[id], targetMs),
+		})
+	}
+	return report, nil
+}
+
+// Event operations
+
+// CreateEvent records a new webhook event
+func (r *memoryWebhookRepository) CreateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := prepareCreate(event, time.Now()); err != nil {
+		return err
+	}
+	return r.store.events.insert(event.ID, event)
+}
+
+// GetEventByID retrieves a webhook event by its unique identifier
+func (r *memoryWebhookRepository) GetEventByID(ctx context.Context, id uuid.UUID) (*models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	event := r.store.events.get(id)
+	if event == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneRecord(event), nil
+}
+
+// UpdateEvent saves every field of an event, inserting it when it is not stored
+func (r *memoryWebhookRepository) UpdateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	event.UpdatedAt = time.Now()
+	if stored := r.store.events.get(event.ID); stored != nil {
+		*stored = *cloneRecord(event)
+		return nil
+	}
+	if err := prepareCreate(event, event.UpdatedAt); err != nil {
+		return err
+	}
+	return r.store.events.insert(event.ID, event)
+}
+
+// GetEventsByStatus retrieves up to limit events in a delivery status, oldest first
+func (r *memoryWebhookRepository) GetEventsByStatus(ctx context.Context, status models.WebhookStatus, limit int) ([]models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	events := r.store.events.scan(func(event *models.WebhookEvent) bool { return event.Status == status })
+	sortByCreatedAt(events, func(e *models.WebhookEvent) time.Time { return e.CreatedAt }, false)
+	return cloneValues(page(events, 0, limit)), nil
+}
+
+// CountEventsSince counts the events of a type a tenant has sent since a point in time
+func (r *memoryWebhookRepository) CountEventsSince(ctx context.Context, tenantID, event string, since time.Time) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.events.scan(func(e *models.WebhookEvent) bool {
+		return e.TenantID == tenantID && e.EventName == event && !e.CreatedAt.Before(since)
+	}))), nil
+}
+
+// dueScheduledEvents returns the stored scheduled events due by a point in time
+func (s *MemoryStore) dueScheduledEvents(until time.Time) []*models.WebhookEvent {
+	return s.events.scan(func(event *models.WebhookEvent) bool {
+		return event.Status == models.WebhookStatusScheduled && event.DeliverAt != nil && !event.DeliverAt.After(until)
+	})
+}
+
+// GetDueScheduledEvents retrieves up to limit scheduled events that are due, highest priority first and in
+// delivery order within a priority
+func (r *memoryWebhookRepository) GetDueScheduledEvents(ctx context.Context, now time.Time, limit int) ([]models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	events := r.store.dueScheduledEvents(now)
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Priority != events[j].Priority {
+			return events[i].Priority > events[j].Priority
+		}
+		return events[i].DeliverAt.Before(*events[j].DeliverAt)
+	})
+	return cloneValues(page(events, 0, limit)), nil
+}
+
+// GetScheduledEventsDueBy retrieves the IDs and delivery times of a page of the scheduled events due by a time,
+// ordered by ID after the given one
+func (r *memoryWebhookRepository) GetScheduledEventsDueBy(ctx context.Context, until time.Time, after uuid.UUID, limit int) ([]models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var due []*models.WebhookEvent
+	for _, event := range r.store.dueScheduledEvents(until) {
+		if event.ID.String() > after.String() {
+			due = append(due, &models.WebhookEvent{ID: event.ID, DeliverAt: event.DeliverAt})
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID.String() < due[j].ID.String() })
+	return cloneValues(page(due, 0, limit)), nil
+}
+
+// ClaimScheduledEvent moves a scheduled event to pending if it is still scheduled
+func (r *memoryWebhookRepository) ClaimScheduledEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	event := r.store.events.get(id)
+	if event == nil || event.Status != models.WebhookStatusScheduled {
+		return false, nil
+	}
+	err := updateRow(event, map[string]interface{}{"status": models.WebhookStatusPending}, time.Now())
+	return err == nil, err
+}
+
+// Delivery pause operations
+
+// SaveDeliveryPause creates or replaces the delivery pause of a scope
+func (r *memoryWebhookRepository) SaveDeliveryPause(ctx context.Context, pause *models.DeliveryPause) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.pauses == nil {
+		r.store.pauses = make(map[string]*models.DeliveryPause)
+	}
+	pause.UpdatedAt = time.Now()
+	r.store.pauses[pause.Scope] = cloneRecord(pause)
+	return nil
+}
+
+// GetDeliveryPauses retrieves every delivery pause, ordered by scope
+func (r *memoryWebhookRepository) GetDeliveryPauses(ctx context.Context) ([]models.DeliveryPause, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	pauses := make([]*models.DeliveryPause, 0, len(r.store.pauses))
+	for _, pause := range r.store.pauses {
+		pauses = append(pauses, pause)
+	}
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].Scope < pauses[j].Scope })
+	return cloneValues(pauses), nil
+}
+
+// DeleteResumedDeliveryPause removes the delivery pause of a scope if it was resumed
+func (r *memoryWebhookRepository) DeleteResumedDeliveryPause(ctx context.Context, scope string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if pause := r.store.pauses[scope]; pause != nil && pause.ResumedAt != nil {
+		delete(r.store.pauses, scope)
+	}
+	return nil
+}
+
+// heldEvents returns the stored events held back by a delivery pause of a tenant, or of every tenant not excluded
+func (s *MemoryStore) heldEvents(tenantID string, excludeTenants []string) []*models.WebhookEvent {
+	return s.events.scan(func(event *models.WebhookEvent) bool {
+		return event.HeldAt != nil && (tenantID == "" || event.TenantID == tenantID) && !slices.Contains(excludeTenants, event.TenantID)
+	})
+}
+
+// GetHeldEvents retrieves up to limit held events, highest priority first and oldest first within a priority
+func (r *memoryWebhookRepository) GetHeldEvents(ctx context.Context, tenantID string, excludeTenants []string, limit int) ([]models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	events := r.store.heldEvents(tenantID, excludeTenants)
+	sortByCreatedAt(events, func(e *models.WebhookEvent) time.Time { return e.CreatedAt }, false)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Priority > events[j].Priority })
+	return cloneValues(page(events, 0, limit)), nil
+}
+
+// CountHeldEvents counts the held events, selected like GetHeldEvents
+func (r *memoryWebhookRepository) CountHeldEvents(ctx context.Context, tenantID string, excludeTenants []string) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.heldEvents(tenantID, excludeTenants))), nil
+}
+
+// ReleaseHeldEvent clears the hold of an event if it is still held
+func (r *memoryWebhookRepository) ReleaseHeldEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	event := r.store.events.get(id)
+	if event == nil || event.HeldAt == nil {
+		return false, nil
+	}
+	event.HeldAt = nil
+	event.UpdatedAt = time.Now()
+	return true, nil
+}
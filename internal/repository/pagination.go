@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Cursor is the position of a record in a sorted list: its value of the sorted column, its creation time, and
+// its ID to order the records created at the same time
+type Cursor struct {
+	// Value is the record's value of the sorted column; empty when the list is sorted by creation time
+	Value     string
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Page selects the records of a list sorted by a column, then by creation time, newest first unless Ascending
+// With After set the page starts after that record, found through the list's (created_at, id) index however
+// deep the page is; otherwise it starts after skipping Offset records, which reads every skipped record
+type Page struct {
+	After  *Cursor
+	Offset int
+	Limit  int
+
+	// Sort is the column the list is sorted by, models.ListSortCreatedAt when empty
+	Sort models.ListSort
+
+	// Ascending lists the lowest values and oldest records first
+	Ascending bool
+}
+
+// NextCursor returns the cursor continuing a list after a page of n records ending with last, or nil when
+// the page was the last one
+func (p Page) NextCursor(n int, last func() Cursor) *Cursor {
+	if p.Limit <= 0 || n < p.Limit {
+		return nil
+	}
+	cursor := last()
+	return &cursor
+}
+
+// sorted reports whether the list is sorted by a column other than the creation time
+func (p Page) sorted() bool {
+	return p.Sort != "" && p.Sort != models.ListSortCreatedAt
+}
+
+// paginate orders a query of a table with created_at and id columns by the page's sort and selects a page of it
+// The sorted columns are the models.ListSort values, which are validated before they reach a query
+func paginate(query *gorm.DB, page Page) *gorm.DB {
+	direction, comparison := "DESC", "<"
+	if page.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	columns := "created_at, id"
+	order := fmt.Sprintf("created_at %[1]s, id %[1]s", direction)
+	if page.sorted() {
+		columns = string(page.Sort) + ", " + columns
+		order = fmt.Sprintf("%s %s, %s", page.Sort, direction, order)
+	}
+	query = query.Order(order).Limit(page.Limit)
+	if page.After == nil {
+		return query.Offset(page.Offset)
+	}
+
+	// A row comparison, unlike the equivalent OR, is a range of the index rather than a filter of its rows
+	if page.sorted() {
+		return query.Where(fmt.Sprintf("(%s) %s (?, ?, ?)", columns, comparison), page.After.Value, page.After.CreatedAt, page.After.ID)
+	}
+	return query.Where(fmt.Sprintf("(%s) %s (?, ?)", columns, comparison), page.After.CreatedAt, page.After.ID)
+}
+
+// paginateRows sorts rows and selects a page of them like paginate
+func paginateRows[T any](rows []*T, cursor func(*T) Cursor, p Page) []*T {
+	follows := func(a, b Cursor) bool {
+		if p.Ascending {
+			return b.before(a)
+		}
+		return a.before(b)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return follows(cursor(rows[j]), cursor(rows[i])) })
+	if p.After == nil {
+		return page(rows, p.Offset, p.Limit)
+	}
+	start := sort.Search(len(rows), func(i int) bool { return follows(cursor(rows[i]), *p.After) })
+	return page(rows[start:], 0, p.Limit)
+}
+
+// before reports whether a record comes before another in ascending order, and so after it in a list
+// sorted in descending order
+func (c Cursor) before(other Cursor) bool {
+	if c.Value != other.Value {
+		return c.Value < other.Value
+	}
+	if !c.CreatedAt.Equal(other.CreatedAt) {
+		return c.CreatedAt.Before(other.CreatedAt)
+	}
+	return bytes.Compare(c.ID[:], other.ID[:]) < 0
+}
+
+// SubscriptionCursor returns the cursor of a subscription in a list sorted by sort
+func SubscriptionCursor(subscription *models.WebhookSubscription, sort models.ListSort) Cursor {
+	cursor := Cursor{CreatedAt: subscription.CreatedAt, ID: subscription.ID}
+	if sort == models.ListSortAppName {
+		cursor.Value = subscription.AppName
+	}
+	return cursor
+}
+
+// ChainCursor returns the cursor of a chain in a list sorted by sort
+func ChainCursor(chain *models.ExecutionChain, sort models.ListSort) Cursor {
+	cursor := Cursor{CreatedAt: chain.CreatedAt, ID: chain.ID}
+	if sort == models.ListSortStatus {
+		cursor.Value = string(chain.Status)
+	}
+	return cursor
+}
+
+// ChainRunCursor returns the cursor of a chain run in a list sorted by sort
+func ChainRunCursor(run *models.ExecutionChainRun, sort models.ListSort) Cursor {
+	cursor := Cursor{CreatedAt: run.CreatedAt, ID: run.ID}
+	if sort == models.ListSortStatus {
+		cursor.Value = string(run.Status)
+	}
+	return cursor
+}
+
+// EventCursor returns the cursor of an event in a list sorted by sort
+func EventCursor(event *models.WebhookEvent, sort models.ListSort) Cursor {
+	cursor := Cursor{CreatedAt: event.CreatedAt, ID: event.ID}
+	if sort == models.ListSortStatus {
+		cursor.Value = string(event.Status)
+	}
+	return cursor
+}
+
+// listColumns names the columns of a table the fields of a models.ListFilter apply to, empty for the fields
+// the table has no column for
+type listColumns struct {
+	isActive string
+	typ      string
+	event    string
+	status   string
+}
+
+// Columns of the filtered lists
+var (
+	subscriptionListColumns = listColumns{isActive: "is_active", typ: "type", event: "subscribed_event"}
+	chainListColumns        = listColumns{isActive: "is_active", event: "trigger_event", status: "status"}
+	chainRunListColumns     = listColumns{event: "trigger_event", status: "status"}
+	eventListColumns        = listColumns{event: "event_name", status: "status"}
+)
+
+// filterList narrows a query of a table with a created_at column by a filter
+func filterList(query *gorm.DB, filter models.ListFilter, columns listColumns) *gorm.DB {
+	if filter.IsActive != nil && columns.isActive != "" {
+		query = query.Where(columns.isActive+" = ?", *filter.IsActive)
+	}
+	if filter.Type != "" && columns.typ != "" {
+		query = query.Where(columns.typ+" = ?", filter.Type)
+	}
+	if filter.Event != "" && columns.event != "" {
+		query = query.Where(columns.event+" = ?", filter.Event)
+	}
+	if filter.Status != "" && columns.status != "" {
+		query = query.Where(columns.status+" = ?", filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	return query
+}
+
+// listValues are a record's values of the columns filterList filters, nil for those its table lacks
+type listValues struct {
+	isActive  *bool
+	typ       *string
+	event     *string
+	status    *string
+	createdAt time.Time
+}
+
+// matches reports whether a record with these values is kept by a filter, like filterList
+func (v listValues) matches(filter models.ListFilter) bool {
+	if filter.IsActive != nil && v.isActive != nil && *filter.IsActive != *v.isActive {
+		return false
+	}
+	if !matchesColumn(filter.Type, v.typ) || !matchesColumn(filter.Event, v.event) || !matchesColumn(filter.Status, v.status) {
+		return false
+	}
+	if filter.CreatedFrom != nil && v.createdAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	return filter.CreatedTo == nil || v.createdAt.Before(*filter.CreatedTo)
+}
+
+// matchesColumn reports whether a value is kept by the filter of its column, which an empty filter or a missing
+// column keeps every value of
+func matchesColumn(filter string, value *string) bool {
+	return filter == "" || value == nil || filter == *value
+}
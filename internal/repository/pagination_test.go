@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetSubscriptionsByTenant_Pages tests that pages continuing after a cursor list the same subscriptions as
+// numbered pages, including those created at the same time, on the database and in memory
+func TestGetSubscriptionsByTenant_Pages(t *testing.T) {
+	repos := map[string]func(t *testing.T) WebhookRepository{
+		"sqlite": func(t *testing.T) WebhookRepository { return NewWebhookRepository(openTestSQLite(t), nil) },
+		"memory": func(t *testing.T) WebhookRepository { return NewMemoryWebhookRepository(NewMemoryStore()) },
+	}
+
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newRepo(t)
+			createdAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+			for i := 0; i < 5; i++ {
+				require.NoError(t, repo.CreateSubscription(ctx, &models.WebhookSubscription{
+					TenantID:        "acme",
+					AppName:         "billing",
+					TargetURL:       "https://billing.example.com/hooks",
+					SubscribedEvent: "invoice.paid",
+					CreatedAt:       createdAt.Add(time.Duration(i/2) * time.Minute),
+				}))
+			}
+			require.NoError(t, repo.CreateSubscription(ctx, &models.WebhookSubscription{
+				TenantID: "other", AppName: "billing", TargetURL: "https://other.example.com", SubscribedEvent: "invoice.paid",
+			}))
+
+			// Act
+			var numbered, continued []uuid.UUID
+			for offset := 0; offset < 6; offset += 2 {
+				subscriptions, _, err := repo.GetSubscriptionsByTenant(ctx, "acme", models.ListFilter{}, Page{Offset: offset, Limit: 2})
+				require.NoError(t, err)
+				for _, subscription := range subscriptions {
+					numbered = append(numbered, subscription.ID)
+				}
+			}
+			page := Page{Limit: 2}
+			for {
+				subscriptions, total, err := repo.GetSubscriptionsByTenant(ctx, "acme", models.ListFilter{}, page)
+				require.NoError(t, err)
+				require.Equal(t, int64(5), total)
+				if len(subscriptions) == 0 {
+					break
+				}
+				for _, subscription := range subscriptions {
+					continued = append(continued, subscription.ID)
+				}
+				last := subscriptions[len(subscriptions)-1]
+				page.After = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+			}
+
+			// Assert
+			assert.Len(t, numbered, 5)
+			assert.Equal(t, numbered, continued)
+		})
+	}
+}
+
+// TestGetSubscriptionsByTenant_SortedPages tests that filtered lists sorted by app name in ascending order are
+// continued by cursors like those sorted by creation time, on the database and in memory
+func TestGetSubscriptionsByTenant_SortedPages(t *testing.T) {
+	repos := map[string]func(t *testing.T) WebhookRepository{
+		"sqlite": func(t *testing.T) WebhookRepository { return NewWebhookRepository(openTestSQLite(t), nil) },
+		"memory": func(t *testing.T) WebhookRepository { return NewMemoryWebhookRepository(NewMemoryStore()) },
+	}
+
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newRepo(t)
+			createdAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+			for i, appName := range []string{"shipping", "billing", "audit", "billing", "crm", "audit"} {
+				subscription := &models.WebhookSubscription{
+					TenantID:        "acme",
+					AppName:         appName,
+					TargetURL:       "https://" + appName + ".example.com/hooks",
+					SubscribedEvent: "invoice.paid",
+					Type:            models.WebhookTypePublic,
+					CreatedAt:       createdAt.Add(time.Duration(i) * time.Minute),
+				}
+				require.NoError(t, repo.CreateSubscription(ctx, subscription))
+				if appName == "crm" {
+					subscription.IsActive = false
+					require.NoError(t, repo.UpdateSubscription(ctx, subscription))
+				}
+			}
+			active := true
+			filter := models.ListFilter{IsActive: &active, CreatedFrom: &createdAt, Event: "invoice.paid"}
+
+			// Act
+			var listed []string
+			var totals []int64
+			page := Page{Limit: 2, Sort: models.ListSortAppName, Ascending: true}
+			for {
+				subscriptions, total, err := repo.GetSubscriptionsByTenant(ctx, "acme", filter, page)
+				require.NoError(t, err)
+				totals = append(totals, total)
+				for _, subscription := range subscriptions {
+					listed = append(listed, subscription.AppName+" "+subscription.CreatedAt.UTC().Format("15:04"))
+				}
+				if page.After = page.NextCursor(len(subscriptions), func() Cursor {
+					return SubscriptionCursor(&subscriptions[len(subscriptions)-1], page.Sort)
+				}); page.After == nil {
+					break
+				}
+			}
+
+			// Assert
+			assert.Equal(t, []string{"audit 12:02", "audit 12:05", "billing 12:01", "billing 12:03", "shipping 12:00"}, listed)
+			assert.Equal(t, []int64{5, 5, 5}, totals)
+		})
+	}
+}
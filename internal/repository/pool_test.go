@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfigurePool tests that the pool settings are applied and the pool metrics registered
+func TestConfigurePool(t *testing.T) {
+	// Arrange: connections are opened lazily, so no database is needed
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=loki dbname=loki sslmode=disable"),
+		&gorm.Config{DisableAutomaticPing: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	registry := prometheus.NewRegistry()
+
+	// Act
+	err = ConfigurePool(db, "loki", PoolConfig{MaxOpenConns: 10, MaxIdleConns: 5, ConnMaxLifetime: time.Minute}, registry)
+
+	// Assert
+	assert.NoError(t, err)
+	sqlDB, _ := db.DB()
+	assert.Equal(t, 10, sqlDB.Stats().MaxOpenConnections)
+
+	count, err := testutil.GatherAndCount(registry, "go_sql_max_open_connections", "go_sql_in_use_connections", "go_sql_wait_count_total")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
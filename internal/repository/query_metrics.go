@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DefaultSlowQueryThreshold is the repository call duration above which calls are logged as slow
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// maxTracedStatements caps how many SQL statements a single repository call keeps for the slow query log
+const maxTracedStatements = 20
+
+// QueryMetrics records the duration and outcome of repository calls
+// Durations and counts are exported as Prometheus metrics labelled by repository and method;
+// calls slower than the threshold are logged with the SQL they ran, bind parameters redacted
+type QueryMetrics struct {
+	duration      *prometheus.HistogramVec
+	calls         *prometheus.CounterVec
+	slowCalls     *prometheus.CounterVec
+	slowThreshold time.Duration
+	logger        logging.Logger
+}
+
+// NewQueryMetrics creates the repository metrics and registers them with the registerer
+// Parameters:
+//   - registerer: Prometheus registerer, typically prometheus.DefaultRegisterer
+//   - slowThreshold: Calls taking longer are logged; zero or negative disables slow query logging
+//   - logger: Logger slow calls are logged to
+//
+// Returns: QueryMetrics instance, error if the collectors cannot be registered
+func NewQueryMetrics(registerer prometheus.Registerer, slowThreshold time.Duration, logger logging.Logger) (*QueryMetrics, error) {
+	m := &QueryMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "loki",
+			Subsystem: "repository",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of repository calls, including all SQL statements they run.",
+			Buckets:   []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		}, []string{"repository", "method"}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "repository",
+			Name:      "queries_total",
+			Help:      "Number of repository calls by outcome.",
+		}, []string{"repository", "method", "status"}),
+		slowCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "repository",
+			Name:      "slow_queries_total",
+			Help:      "Number of repository calls slower than the slow query threshold.",
+		}, []string{"repository", "method"}),
+		slowThreshold: slowThreshold,
+		logger:        logger,
+	}
+
+	for _, collector := range []prometheus.Collector{m.duration, m.calls, m.slowCalls} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// TraceStatements registers GORM callbacks that attach the SQL of every statement to the
+// repository call that issued it, so slow calls can be logged with their queries
+// The statement text keeps its placeholders; bind parameters are never recorded
+func (m *QueryMetrics) TraceStatements(db *gorm.DB) error {
+	callbacks := db.Callback()
+	registrations := []func(name string, fn func(*gorm.DB)) error{
+		callbacks.Create().After("gorm:create").Register,
+		callbacks.Query().After("gorm:query").Register,
+		callbacks.Update().After("gorm:update").Register,
+		callbacks.Delete().After("gorm:delete").Register,
+		callbacks.Row().After("gorm:row").Register,
+		callbacks.Raw().After("gorm:raw").Register,
+	}
+
+	for _, register := range registrations {
+		if err := register("loki:trace_statement", traceStatement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// traceStatement records the SQL of a finished statement on the trace of its repository call
+func traceStatement(db *gorm.DB) {
+	if db.Statement == nil || db.Statement.Context == nil {
+		return
+	}
+	if trace, ok := db.Statement.Context.Value(queryTraceKey{}).(*queryTrace); ok {
+		trace.add(db.Statement.SQL.String())
+	}
+}
+
+// start begins measuring a repository call
+// The returned context carries a trace collecting the call's SQL statements; pass it to the
+// wrapped repository and call done with the call's error when it returns
+func (m *QueryMetrics) start(ctx context.Context, repository, method string) (context.Context, func(err error)) {
+	trace := &queryTrace{}
+	ctx = context.WithValue(ctx, queryTraceKey{}, trace)
+	begin := time.Now()
+
+	return ctx, func(err error) {
+		elapsed := time.Since(begin)
+
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+		m.duration.WithLabelValues(repository, method).Observe(elapsed.Seconds())
+		m.calls.WithLabelValues(repository, method, status).Inc()
+
+		if m.slowThreshold <= 0 || elapsed < m.slowThreshold {
+			return
+		}
+		m.slowCalls.WithLabelValues(repository, method).Inc()
+		m.logger.Warn(ctx, "Slow repository query",
+			zap.String("repository", repository),
+			zap.String("method", method),
+			zap.Duration("duration", elapsed),
+			zap.Duration("threshold", m.slowThreshold),
+			zap.Strings("statements", trace.list()),
+			zap.Bool("parameters_redacted", true),
+			zap.Error(err))
+	}
+}
+
+// queryTraceKey is the context key of the statement trace of a repository call
+type queryTraceKey struct{}
+
+// queryTrace collects the SQL statements run by one repository call
+// Transactions may run callbacks from other goroutines, so access is synchronized
+type queryTrace struct {
+	mu         sync.Mutex
+	statements []string
+	dropped    int
+}
+
+func (t *queryTrace) add(statement string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.statements) >= maxTracedStatements {
+		t.dropped++
+		return
+	}
+	t.statements = append(t.statements, statement)
+}
+
+func (t *queryTrace) list() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	statements := append([]string(nil), t.statements...)
+	if t.dropped > 0 {
+		statements = append(statements, "... "+strconv.Itoa(t.dropped)+" more statements")
+	}
+	return statements
+}
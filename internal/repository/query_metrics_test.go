@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+)
+
+// observeLogs returns a logger recording entries at or above level, and the entries it recorded
+func observeLogs(level zapcore.Level) (logging.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return logging.NewLogger(zap.New(core)), logs
+}
+
+// TestQueryMetrics tests that calls are counted by outcome and timed, and that slow calls are logged with the
+// SQL traced for them while the bind parameters stay out of the log
+func TestQueryMetrics(t *testing.T) {
+	// Arrange: a threshold of a nanosecond makes every call slow
+	logger, logs := observeLogs(zapcore.WarnLevel)
+	registry := prometheus.NewRegistry()
+	metrics, err := NewQueryMetrics(registry, 1, logger)
+	require.NoError(t, err)
+
+	// Act
+	ctx, done := metrics.start(context.Background(), "webhook", "CreateSubscription")
+	statement := &gorm.Statement{Context: ctx}
+	statement.SQL.WriteString(`INSERT INTO "webhook_subscriptions" ("tenant_id") VALUES ($1)`)
+	traceStatement(&gorm.DB{Statement: statement})
+	done(nil)
+	_, done = metrics.start(context.Background(), "webhook", "GetSubscriptionByID")
+	done(errors.New("record not found"))
+
+	// Assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("webhook", "CreateSubscription", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("webhook", "GetSubscriptionByID", "error")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.slowCalls.WithLabelValues("webhook", "GetSubscriptionByID")))
+	histograms, err := testutil.GatherAndCount(registry, "loki_repository_query_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 2, histograms)
+
+	entries := logs.FilterMessage("Slow repository query").All()
+	require.Len(t, entries, 2)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "webhook", fields["repository"])
+	assert.Equal(t, "CreateSubscription", fields["method"])
+	assert.Equal(t, true, fields["parameters_redacted"])
+	assert.Equal(t, []interface{}{`INSERT INTO "webhook_subscriptions" ("tenant_id") VALUES ($1)`}, fields["statements"])
+	assert.Contains(t, entries[1].ContextMap(), "error")
+}
+
+// TestQueryMetrics_SlowLoggingDisabled tests that calls are still measured but never logged when the slow
+// query threshold is zero
+func TestQueryMetrics_SlowLoggingDisabled(t *testing.T) {
+	// Arrange
+	logger, logs := observeLogs(zapcore.DebugLevel)
+	metrics, err := NewQueryMetrics(prometheus.NewRegistry(), 0, logger)
+	require.NoError(t, err)
+
+	// Act
+	_, done := metrics.start(context.Background(), "chain", "GetChain")
+	done(nil)
+
+	// Assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("chain", "GetChain", "ok")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.slowCalls.WithLabelValues("chain", "GetChain")))
+	assert.Zero(t, logs.Len())
+}
+
+// TestQueryTrace_CapsStatements tests that a call's trace keeps the first statements and counts the rest
+func TestQueryTrace_CapsStatements(t *testing.T) {
+	// Arrange
+	trace := &queryTrace{}
+
+	// Act
+	for i := 0; i < maxTracedStatements+3; i++ {
+		trace.add("SELECT " + strings.Repeat("1", i+1))
+	}
+	statements := trace.list()
+
+	// Assert
+	require.Len(t, statements, maxTracedStatements+1)
+	assert.Equal(t, "SELECT 1", statements[0])
+	assert.Equal(t, "... 3 more statements", statements[maxTracedStatements])
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// ReadReplicas routes the list and analytics queries of repositories to read replicas of the database
+// Replicas are used in turn; a nil ReadReplicas, or one without replicas, routes every query to the primary
+// Only queries that tolerate replication lag are routed: reads that decide what to deliver or execute,
+// and reads following a write in the same request, stay on the primary
+type ReadReplicas struct {
+	replicas []*gorm.DB
+	next     atomic.Uint64
+}
+
+// NewReadReplicas creates a router over the given replica connections
+func NewReadReplicas(replicas ...*gorm.DB) *ReadReplicas {
+	return &ReadReplicas{replicas: replicas}
+}
+
+// OpenReadReplica connects to a read replica
+// GORM logging is disabled since statements are traced through QueryMetrics, as on the primary
+func OpenReadReplica(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+	return db, nil
+}
+
+// DB returns the database the next read-only query runs on: the next replica, or primary without replicas
+func (r *ReadReplicas) DB(primary *gorm.DB) *gorm.DB {
+	if r == nil || len(r.replicas) == 0 {
+		return primary
+	}
+	return r.replicas[(r.next.Add(1)-1)%uint64(len(r.replicas))]
+}
+
+// Replicas returns the replica connections
+func (r *ReadReplicas) Replicas() []*gorm.DB {
+	if r == nil {
+		return nil
+	}
+	return r.replicas
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReadReplicas tests that replicas are used in turn and that the primary is used without replicas
+func TestReadReplicas(t *testing.T) {
+	primary, first, second := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+	replicas := NewReadReplicas(first, second)
+
+	assert.Same(t, first, replicas.DB(primary))
+	assert.Same(t, second, replicas.DB(primary))
+	assert.Same(t, first, replicas.DB(primary))
+
+	var none *ReadReplicas
+	assert.Same(t, primary, none.DB(primary))
+	assert.Same(t, primary, NewReadReplicas().DB(primary))
+}
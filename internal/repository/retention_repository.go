@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// RetentionRepository defines the interface for archiving expired events and chain runs
+// Expired rows are moved to the *_archive table of their table, stored as JSON, in batches so
+// the live tables are never locked for long; archives are pruned once older than the archive retention
+type RetentionRepository interface {
+	// ArchiveEvents moves a batch of expired webhook events, with their delivery attempts, to the archive
+	// Only delivered, failed and skipped events without queued deliveries expire
+	// Returns the number of events and delivery attempts archived; fewer events than limit means none are left
+	ArchiveEvents(ctx context.Context, cutoff RetentionCutoff, limit int) (int64, int64, error)
+
+	// ArchiveChainRuns moves a batch of expired chain runs, with their step and compensation runs, to the archive
+	// Only completed, failed and cancelled runs that are not compensating expire
+	// Returns the number of runs and step runs archived; fewer runs than limit means none are left
+	ArchiveChainRuns(ctx context.Context, cutoff RetentionCutoff, limit int) (int64, int64, error)
+
+	// PruneArchives deletes up to limit archived rows of every archive table archived before the given time
+	// Returns the number of rows deleted
+	PruneArchives(ctx context.Context, before time.Time, limit int) (int64, error)
+
+	// CreateArchivalRun stores a newly started archival run
+	CreateArchivalRun(ctx context.Context, run *models.ArchivalRun) error
+
+	// UpdateArchivalRun stores the progress or outcome of an archival run
+	UpdateArchivalRun(ctx context.Context, run *models.ArchivalRun) error
+
+	// ListArchivalRuns retrieves archival runs with pagination, newest first
+	ListArchivalRuns(ctx context.Context, offset, limit int) ([]models.ArchivalRun, int64, error)
+}
+
+// RetentionCutoff determines which rows have expired
+// A row expires once older than its tenant's retention_days, or DefaultDays for tenants without one;
+// tenants whose retention resolves to 0 days keep their rows forever
+type RetentionCutoff struct {
+	// Now is the time ages are measured against
+	Now time.Time
+
+	// DefaultDays is the retention of tenants without their own
+	DefaultDays int
+}
+
+// expiredCondition returns the condition matching rows of a table aliased t, joined with tenant_settings ts, whose
+// age column is older than their tenant's retention
+func expiredCondition(db *gorm.DB, column string) string {
+	if isSQLite(db) {
+		return `COALESCE(NULLIF(ts.retention_days, 0), @default_days) > 0
+	AND julianday(` + column + `) < julianday(@now) - COALESCE(NULLIF(ts.retention_days, 0), @default_days)`
+	}
+	return `COALESCE(NULLIF(ts.retention_days, 0), @default_days) > 0
+	AND ` + column + ` < @now::timestamptz - make_interval(days => COALESCE(NULLIF(ts.retention_days, 0), @default_days)::int)`
+}
+
+// expiredLock returns the locking clause of the selection of expired rows
+// SQLite has no row locks, but its transactions hold the database's write lock, so archivers never overlap
+func expiredLock(db *gorm.DB) string {
+	if isSQLite(db) {
+		return ""
+	}
+	return "FOR UPDATE OF t SKIP LOCKED"
+}
+
+// archivedTables are the archive tables pruned by PruneArchives, children before their parents
+var archivedTables = []string{
+	"webhook_delivery_attempts_archive",
+	"webhook_events_archive",
+	"execution_chain_step_runs_archive",
+	"execution_chain_compensation_runs_archive",
+	"execution_chain_runs_archive",
+}
+
+// retentionRepository implements RetentionRepository interface
+// Provides concrete implementation of archival using raw SQL through GORM, as rows are moved between tables
+type retentionRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: RetentionRepository interface implementation
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+// ArchiveEvents moves a batch of expired webhook events, with their delivery attempts, to the archive
+// Selected events are locked with SKIP LOCKED, so instances archiving at once move disjoint batches
+func (r *retentionRepository) ArchiveEvents(ctx context.Context, cutoff RetentionCutoff, limit int) (int64, int64, error) {
+	var events, attempts int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Raw(`SELECT t.id FROM "webhook_events" t
+	LEFT JOIN "tenant_settings" ts ON ts.tenant_id = t.tenant_id
+	WHERE t.status IN @statuses AND `+expiredCondition(tx, "t.created_at")+`
+	AND NOT EXISTS (SELECT 1 FROM "queued_deliveries" q WHERE q.event_id = t.id)
+	AND NOT EXISTS (SELECT 1 FROM "batched_deliveries" b WHERE b.event_id = t.id)
+	AND NOT EXISTS (SELECT 1 FROM "ordered_deliveries" o WHERE o.event_id = t.id)
+	ORDER BY t.created_at
+	LIMIT @limit
+	`+expiredLock(tx), map[string]interface{}{
+			"statuses":     []models.WebhookStatus{models.WebhookStatusSent, models.WebhookStatusFailed, models.WebhookStatusSkipped},
+			"default_days": cutoff.DefaultDays,
+			"now":          cutoff.Now,
+			"limit":        limit,
+		}).Scan(&ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		if attempts, err = archiveRows(tx, &models.WebhookDeliveryAttempt{}, `"id", "event_id", "created_at"`, "event_id IN @ids", ids, cutoff.Now); err != nil {
+			return err
+		}
+		events, err = archiveRows(tx, &models.WebhookEvent{}, `"id", "tenant_id", "created_at"`, "id IN @ids", ids, cutoff.Now)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return events, attempts, nil
+}
+
+// ArchiveChainRuns moves a batch of expired chain runs, with their step and compensation runs, to the archive
+// Runs age from their completion; child rows are archived first as deleting a run cascades to them
+func (r *retentionRepository) ArchiveChainRuns(ctx context.Context, cutoff RetentionCutoff, limit int) (int64, int64, error) {
+	var runs, stepRuns int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Raw(`SELECT t.id FROM "execution_chain_runs" t
+	LEFT JOIN "tenant_settings" ts ON ts.tenant_id = t.tenant_id
+	WHERE t.status IN @statuses AND `+expiredCondition(tx, "t.completed_at")+`
+	AND (t.compensation_status IS NULL OR t.compensation_status <> @compensating)
+	ORDER BY t.completed_at
+	LIMIT @limit
+	`+expiredLock(tx), map[string]interface{}{
+			"statuses": []models.ExecutionChainStatus{
+				models.ExecutionChainStatusCompleted, models.ExecutionChainStatusFailed, models.ExecutionChainStatusCancelled,
+			},
+			"compensating": models.CompensationStatusRunning,
+			"default_days": cutoff.DefaultDays,
+			"now":          cutoff.Now,
+			"limit":        limit,
+		}).Scan(&ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		steps, err := archiveRows(tx, &models.ExecutionChainStepRun{}, `"id", "run_id", "created_at"`, "run_id IN @ids", ids, cutoff.Now)
+		if err != nil {
+			return err
+		}
+		compensations, err := archiveRows(tx, &models.ExecutionChainCompensationRun{}, `"id", "run_id", "created_at"`, "run_id IN @ids", ids, cutoff.Now)
+		if err != nil {
+			return err
+		}
+		stepRuns = steps + compensations
+
+		runs, err = archiveRows(tx, &models.ExecutionChainRun{}, `"id", "tenant_id", "chain_id", "created_at"`, "id IN @ids", ids, cutoff.Now)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return runs, stepRuns, nil
+}
+
+// archiveRows moves the rows of a model's table matching a condition on @ids to its archive table
+// columns are copied into the archive columns of the same name; the whole row is stored in data
+func archiveRows(tx *gorm.DB, model interface{}, columns, condition string, ids []uuid.UUID, archivedAt time.Time) (int64, error) {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return 0, err
+	}
+	table := stmt.Schema.Table
+	params := map[string]interface{}{"ids": ids, "archived_at": archivedAt}
+
+	var result *gorm.DB
+	if isSQLite(tx) {
+		// SQLite cannot insert the rows returned by a DELETE, so they are copied before they are deleted
+		err := tx.Exec(fmt.Sprintf(`INSERT INTO %q (%s, "archived_at", "data")
+	SELECT %s, @archived_at, %s FROM %q WHERE %s`, table+"_archive", columns, columns, sqliteRowJSON(stmt.Schema), table, condition),
+			params).Error
+		if err != nil {
+			return 0, fmt.Errorf("failed to archive %s: %w", table, err)
+		}
+		result = tx.Exec(fmt.Sprintf(`DELETE FROM %q WHERE %s`, table, condition), params)
+	} else {
+		result = tx.Exec(fmt.Sprintf(`WITH moved AS (DELETE FROM %q WHERE %s RETURNING *)
+	INSERT INTO %q (%s, "archived_at", "data")
+	SELECT %s, @archived_at, to_jsonb(moved) FROM moved`, table, condition, table+"_archive", columns, columns), params)
+	}
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to archive %s: %w", table, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// sqliteRowJSON returns the SQLite expression for the JSON object of a row of the schema's table, the
+// counterpart of Postgres' to_jsonb; JSON columns are nested as JSON instead of strings
+func sqliteRowJSON(s *schema.Schema) string {
+	var entries []string
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		value := strconv.Quote(field.DBName)
+		if field.TagSettings["TYPE"] == "jsonb" {
+			value = fmt.Sprintf("CASE WHEN json_valid(%[1]s) THEN json(%[1]s) ELSE %[1]s END", value)
+		}
+		entries = append(entries, fmt.Sprintf("'%s', %s", field.DBName, value))
+	}
+	return "json_object(" + strings.Join(entries, ", ") + ")"
+}
+
+// PruneArchives deletes up to limit archived rows of every archive table archived before the given time
+func (r *retentionRepository) PruneArchives(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var pruned int64
+	for _, table := range archivedTables {
+		result := r.db.WithContext(ctx).Exec(fmt.Sprintf(`DELETE FROM %[1]q WHERE id IN (
+	SELECT id FROM %[1]q WHERE archived_at < ? ORDER BY archived_at LIMIT ?)`, table), before, limit)
+		if result.Error != nil {
+			return pruned, fmt.Errorf("failed to prune %s: %w", table, result.Error)
+		}
+		pruned += result.RowsAffected
+	}
+	return pruned, nil
+}
+
+// CreateArchivalRun stores a newly started archival run
+func (r *retentionRepository) CreateArchivalRun(ctx context.Context, run *models.ArchivalRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// UpdateArchivalRun stores the progress or outcome of an archival run
+func (r *retentionRepository) UpdateArchivalRun(ctx context.Context, run *models.ArchivalRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+// ListArchivalRuns retrieves archival runs with pagination, newest first
+func (r *retentionRepository) ListArchivalRuns(ctx context.Context, offset, limit int) ([]models.ArchivalRun, int64, error) {
+	var runs []models.ArchivalRun
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.ArchivalRun{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("started_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&runs).Error
+
+	return runs, total, err
+}
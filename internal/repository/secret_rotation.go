@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// secretRotationBatchSize is how many rows RotateSecrets reads at once
+const secretRotationBatchSize = 500
+
+// encryptedColumns lists every column stored with the "encrypted" serializer
+var encryptedColumns = []struct {
+	table  string
+	column string
+}{
+	{"webhook_subscriptions", "secret_token"},
+	{"webhook_subscriptions", "jwt_token"},
+	{"execution_chain_runs", "callback_secret"},
+	{"signing_keys", "private_key"},
+	{"jwt_keys", "secret"},
+}
+
+// SecretRotationResult counts the values of one column handled by RotateSecrets
+type SecretRotationResult struct {
+	Table     string
+	Column    string
+	Rotated   int // plaintext values encrypted and values re-encrypted with the current key
+	Unchanged int // values already encrypted with the current key
+}
+
+// RotateSecrets encrypts every plaintext secret and re-encrypts secrets encrypted with a previous key
+// Run it after configuring encryption for the first time and after changing the current key, with the
+// previous key still configured; values are updated one row at a time, so it can be interrupted and rerun
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - db: Database connection
+//   - c: Cipher holding the current key and every key existing values may be encrypted with
+//
+// Returns: Counts per column, error if a value cannot be read, decrypted or updated
+func RotateSecrets(ctx context.Context, db *gorm.DB, c *SecretCipher) ([]SecretRotationResult, error) {
+	results := make([]SecretRotationResult, 0, len(encryptedColumns))
+	for _, target := range encryptedColumns {
+		result, err := rotateColumn(ctx, db, c, target.table, target.column)
+		if err != nil {
+			return results, fmt.Errorf("failed to rotate %s.%s: %w", target.table, target.column, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// rotateColumn re-encrypts the values of one column that are not encrypted with the current key
+func rotateColumn(ctx context.Context, db *gorm.DB, c *SecretCipher, table, column string) (SecretRotationResult, error) {
+	result := SecretRotationResult{Table: table, Column: column}
+
+	type row struct {
+		ID    string
+		Value sql.NullString
+	}
+
+	// Page through the table by ID so large tables are not loaded at once
+	lastID := ""
+	for {
+		var rows []row
+		err := db.WithContext(ctx).Table(table).
+			Select("CAST(id AS text) AS id, "+column+" AS value").
+			Where(column+" IS NOT NULL AND "+column+" <> '' AND CAST(id AS text) > ?", lastID).
+			Order("CAST(id AS text) ASC").
+			Limit(secretRotationBatchSize).
+			Scan(&rows).Error
+		if err != nil {
+			return result, err
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+		lastID = rows[len(rows)-1].ID
+
+		for _, r := range rows {
+			if !c.NeedsRotation(r.Value.String) {
+				result.Unchanged++
+				continue
+			}
+			plaintext, err := c.Decrypt(column, r.Value.String)
+			if err != nil {
+				return result, fmt.Errorf("row %s: %w", r.ID, err)
+			}
+			encrypted, err := c.Encrypt(column, plaintext)
+			if err != nil {
+				return result, err
+			}
+
+			// Only update rows still holding the value read, so concurrent writes are not overwritten
+			update := db.WithContext(ctx).Table(table).
+				Where("CAST(id AS text) = ? AND "+column+" = ?", r.ID, r.Value.String).
+				UpdateColumn(column, encrypted)
+			if update.Error != nil {
+				return result, fmt.Errorf("row %s: %w", r.ID, update.Error)
+			}
+			if update.RowsAffected > 0 {
+				result.Rotated++
+			}
+		}
+	}
+}
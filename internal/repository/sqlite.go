@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sqlitedriver "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Database drivers, see DatabaseConfig
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+// DefaultSQLitePath is the file SQLite databases are kept in unless configured otherwise
+const DefaultSQLitePath = "loki.db"
+
+// DatabaseConfig selects the database of the service
+// DriverPostgres connects to the Postgres configured in the service configuration; DriverSQLite keeps the whole
+// database in the file at Path, for small deployments of a single instance without a database server;
+// DriverMemory keeps it in an SQLite database in process memory, lost on restart, for load tests and demos
+type DatabaseConfig struct {
+	Driver string
+	Path   string
+}
+
+// DefaultDatabaseConfig returns the default database settings
+func DefaultDatabaseConfig() DatabaseConfig {
+	return DatabaseConfig{
+		Driver: DriverPostgres,
+		Path:   DefaultSQLitePath,
+	}
+}
+
+// sqliteOptions enforce foreign keys as Postgres does, let readers proceed while a write is in progress, and
+// make writers wait for each other instead of failing; transactions take the write lock when they begin, so two
+// transactions never both read and then fail to upgrade, and their reads stay consistent with their writes
+const sqliteOptions = "_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_txlock=immediate"
+
+// registerSQLiteFunctions registers the SQL functions queries use on SQLite once per process
+var registerSQLiteFunctions sync.Once
+
+// OpenSQLite opens the SQLite database at path, creating the file if needed
+// GORM logging is disabled since statements are traced through QueryMetrics, as on Postgres
+func OpenSQLite(path string) (*gorm.DB, error) {
+	registerSQLiteFunctions.Do(func() {
+		sqlitedriver.MustRegisterDeterministicScalarFunction("percentile_cont", 2, sqlitePercentileCont)
+	})
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	db, err := gorm.Open(&sqliteDialector{Dialector: &sqlite.Dialector{DSN: path + separator + sqliteOptions}},
+		&gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("loki:generate_uuid", generateUUIDs); err != nil {
+		return nil, fmt.Errorf("failed to register UUID generation: %w", err)
+	}
+	return db, nil
+}
+
+// OpenMemorySQLite opens a new, empty SQLite database kept in process memory
+// The connections of the pool share the database, which is dropped once the last of them closes, so one
+// connection is held open for the life of the process
+func OpenMemorySQLite() (*gorm.DB, error) {
+	db, err := OpenSQLite("file:/loki-" + uuid.NewString() + "?vfs=memdb")
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection pool: %w", err)
+	}
+	if _, err := sqlDB.Conn(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to open in-memory SQLite database: %w", err)
+	}
+	return db, nil
+}
+
+// sqliteDialector binds timestamps in UTC
+// SQLite stores timestamps as text and compares them as text, which only orders them when they share a time zone
+type sqliteDialector struct {
+	*sqlite.Dialector
+}
+
+// BindVarTo converts the timestamp just added to the statement's variables to UTC
+func (d *sqliteDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	last := len(stmt.Vars) - 1
+	switch value := v.(type) {
+	case time.Time:
+		stmt.Vars[last] = value.UTC()
+	case *time.Time:
+		if value != nil {
+			stmt.Vars[last] = value.UTC()
+		}
+	case gorm.DeletedAt:
+		if value.Valid {
+			stmt.Vars[last] = value.Time.UTC()
+		}
+	case sql.NullTime:
+		if value.Valid {
+			stmt.Vars[last] = value.Time.UTC()
+		}
+	}
+	d.Dialector.BindVarTo(writer, stmt, v)
+}
+
+// generateUUIDs fills in the UUID primary keys of created records
+// Postgres generates them with gen_random_uuid() defaults, which SQLite has no equivalent for
+func generateUUIDs(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil || field.FieldType != reflect.TypeOf(uuid.UUID{}) {
+		return
+	}
+
+	ctx := db.Statement.Context
+	generate := func(record reflect.Value) {
+		if _, zero := field.ValueOf(ctx, record); zero {
+			_ = db.AddError(field.Set(ctx, record, uuid.New()))
+		}
+	}
+	switch value := db.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			generate(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		generate(value)
+	}
+}
+
+// sqlitePercentileCont implements percentile_cont(values, fraction) on SQLite, which has no PERCENTILE_CONT
+// aggregate: values is the group_concat of a group's values, interpolated like PERCENTILE_CONT
+func sqlitePercentileCont(_ *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+	list, ok := args[0].(string)
+	if !ok || list == "" {
+		return nil, nil
+	}
+	fraction, ok := args[1].(float64)
+	if !ok {
+		return nil, fmt.Errorf("percentile_cont: fraction must be a number, got %T", args[1])
+	}
+
+	items := strings.Split(list, ",")
+	values := make([]float64, len(items))
+	for i, item := range items {
+		value, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return nil, fmt.Errorf("percentile_cont: %w", err)
+		}
+		values[i] = value
+	}
+	return *percentiles(values, fraction)[0], nil
+}
+
+// isSQLite reports whether the database is SQLite, whose SQL lacks some of the Postgres functions queries use
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == DriverSQLite
+}
+
+// secondsBetween returns the SQL expression for the seconds from the timestamp start to the timestamp end
+func secondsBetween(db *gorm.DB, start, end string) string {
+	if isSQLite(db) {
+		return fmt.Sprintf("((julianday(%s) - julianday(%s)) * 86400.0)", end, start)
+	}
+	return fmt.Sprintf("EXTRACT(EPOCH FROM (%s - %s))", end, start)
+}
+
+// greatest returns the SQL expression for the larger of two values
+func greatest(db *gorm.DB, a, b string) string {
+	if isSQLite(db) {
+		return fmt.Sprintf("MAX(%s, %s)", a, b)
+	}
+	return fmt.Sprintf("GREATEST(%s, %s)", a, b)
+}
+
+// percentileCont returns the SQL aggregate for the continuous percentile of an expression over the rows of a group
+// matching filter, a FILTER clause or empty for every row
+func percentileCont(db *gorm.DB, fraction, expr, filter string) string {
+	if isSQLite(db) {
+		return strings.TrimSpace(fmt.Sprintf("percentile_cont(group_concat(%s) %s, %s)", expr, filter, fraction))
+	}
+	return strings.TrimSpace(fmt.Sprintf("PERCENTILE_CONT(%s) WITHIN GROUP (ORDER BY %s) %s", fraction, expr, filter))
+}
+
+// scanWithTotal scans the rows of a query grouped by group followed by its overall row
+// columns are the grouped columns, totals the NULLs selected in their place in the overall row, and aggregates the
+// aggregates of every row; Postgres selects them in one query with GROUPING SETS, which SQLite lacks
+func scanWithTotal[T any](query func() *gorm.DB, columns, totals, aggregates, group string, args ...interface{}) ([]T, error) {
+	var rows []T
+	if !isSQLite(query()) {
+		err := query().
+			Select(columns+", "+aggregates, args...).
+			Group("GROUPING SETS ((" + group + "), ())").
+			Scan(&rows).Error
+		return rows, err
+	}
+
+	if err := query().Select(columns+", "+aggregates, args...).Group(group).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	var total []T
+	if err := query().Select(totals+", "+aggregates, args...).Scan(&total).Error; err != nil {
+		return nil, err
+	}
+	return append(rows, total...), nil
+}
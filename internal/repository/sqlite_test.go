@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/migrations"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// openTestSQLite opens a migrated SQLite database in a temporary directory
+func openTestSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "loki.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	migrator, err := migrations.New(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, migrator.Verify(context.Background()))
+	return db
+}
+
+// TestSQLite_Subscriptions tests that subscriptions get generated IDs and keep their JSON columns and timestamps,
+// and that failing subscriptions and due batches are found with the SQLite expressions
+func TestSQLite_Subscriptions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	repo := NewWebhookRepository(db, nil)
+	local := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, local)
+	subscription := &models.WebhookSubscription{
+		TenantID:           "acme",
+		AppName:            "billing",
+		TargetURL:          "https://billing.example.com/hooks",
+		SubscribedEvent:    "invoice.paid",
+		Headers:            map[string]string{"X-Team": "billing"},
+		BatchWindowSeconds: 60,
+	}
+
+	// Act
+	require.NoError(t, repo.CreateSubscription(ctx, subscription))
+	stored, err := repo.GetSubscriptionByID(ctx, subscription.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateDeliveryAttempts(ctx, []*models.WebhookDeliveryAttempt{
+		{TenantID: "acme", WebhookID: subscription.ID, EventID: uuid.New(), Attempt: 1, Final: true, CreatedAt: now.Add(-time.Minute)},
+		{TenantID: "acme", WebhookID: subscription.ID, EventID: uuid.New(), Attempt: 1, Final: true, CreatedAt: now.Add(-2 * time.Minute)},
+	}))
+	failing, err := repo.GetFailingSubscriptions(ctx, "acme", now.Add(-time.Hour), 2)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateBatchedDelivery(ctx, &models.BatchedDelivery{
+		SubscriptionID: subscription.ID, EventID: uuid.New(), TenantID: "acme", Payload: `{}`, CreatedAt: now.Add(-30 * time.Second),
+	}))
+	notDue, err := repo.GetSubscriptionsWithDueBatches(ctx, now)
+	require.NoError(t, err)
+	due, err := repo.GetSubscriptionsWithDueBatches(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+
+	// Assert
+	assert.NotEqual(t, uuid.Nil, subscription.ID)
+	assert.True(t, stored.IsActive)
+	assert.Equal(t, "billing", stored.Headers["X-Team"])
+	assert.False(t, stored.CreatedAt.IsZero())
+
+	require.Len(t, failing, 1)
+	assert.Equal(t, subscription.ID, failing[0].ID)
+	assert.Empty(t, notDue)
+	assert.Len(t, due, 1)
+}
+
+// TestSQLite_DeliveryStats tests that delivery attempts are aggregated like on Postgres
+func TestSQLite_DeliveryStats(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewWebhookRepository(openTestSQLite(t), nil)
+	webhookID := uuid.New()
+	code := func(c int) *int { return &c }
+	ms := func(v int64) *int64 { return &v }
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.CreateDeliveryAttempts(ctx, []*models.WebhookDeliveryAttempt{
+		{TenantID: "acme", WebhookID: webhookID, Attempt: 1, ResponseCode: code(503), ErrorClass: models.DeliveryErrorClass("server_error"), LatencyMs: 100, CreatedAt: start},
+		{TenantID: "acme", WebhookID: webhookID, Attempt: 2, Final: true, Success: true, ResponseCode: code(200), LatencyMs: 300, EndToEndMs: ms(800), CreatedAt: start.Add(time.Minute)},
+		{TenantID: "acme", WebhookID: webhookID, Attempt: 1, Final: true, Success: true, ResponseCode: code(200), LatencyMs: 200, EndToEndMs: ms(6000), CreatedAt: start.Add(2 * time.Hour)},
+		{TenantID: "other", WebhookID: uuid.New(), Attempt: 1, Final: true, Success: true, ResponseCode: code(200), LatencyMs: 50, CreatedAt: start},
+	}))
+
+	// Act
+	stats, err := repo.GetDeliveryStats(ctx, "acme", nil, nil, time.Hour)
+	require.NoError(t, err)
+	slo, err := repo.GetLatencySLOStats(ctx, "acme", nil, nil, 5000)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(3), stats.Attempts)
+	assert.Equal(t, int64(2), stats.Deliveries)
+	assert.Equal(t, int64(2), stats.Delivered)
+	require.NotNil(t, stats.P50LatencyMs)
+	assert.Equal(t, 200.0, *stats.P50LatencyMs)
+	assert.Equal(t, map[int]int64{503: 1}, stats.FailuresByStatusCode)
+	require.Len(t, stats.Buckets, 2)
+	assert.Equal(t, start, stats.Buckets[0].Start)
+	assert.Equal(t, int64(2), stats.Buckets[0].Attempts)
+	assert.Equal(t, int64(1), stats.Buckets[0].FailuresByClass["server_error"])
+
+	assert.Equal(t, int64(2), slo.Deliveries)
+	assert.Equal(t, int64(1), slo.WithinTarget)
+	require.NotNil(t, slo.P50Ms)
+	assert.Equal(t, 3400.0, *slo.P50Ms)
+	require.Len(t, slo.Subscriptions, 1)
+	assert.Equal(t, webhookID, slo.Subscriptions[0].WebhookID)
+}
+
+// TestSQLite_DeliveryPauses tests that delivery pauses are replaced by scope, that held events are selected by
+// tenant and released once, and that a drained pause is only removed once resumed
+func TestSQLite_DeliveryPauses(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	repo := NewWebhookRepository(db, nil)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	held := func(tenantID string, priority int, age time.Duration) *models.WebhookEvent {
+		heldAt := now.Add(-age)
+		event := &models.WebhookEvent{
+			TenantID: tenantID, EventName: "order.created", Source: "shop", Payload: `{}`,
+			Status: models.WebhookStatusPending, Priority: priority, HeldAt: &heldAt, CreatedAt: heldAt,
+		}
+		require.NoError(t, repo.CreateEvent(ctx, event))
+		return event
+	}
+	oldest := held("acme", models.PriorityLevelNormal, time.Hour)
+	urgent := held("acme", models.PriorityLevelHigh, time.Minute)
+	held("globex", models.PriorityLevelNormal, 2*time.Hour)
+
+	// Act
+	require.NoError(t, repo.SaveDeliveryPause(ctx, &models.DeliveryPause{Scope: "acme", Reason: "ERP upgrade", PausedAt: now}))
+	require.NoError(t, repo.SaveDeliveryPause(ctx, &models.DeliveryPause{Scope: "acme", Reason: "ERP upgrade", PausedAt: now, ResumedAt: &now, DrainRate: 5}))
+	pauses, err := repo.GetDeliveryPauses(ctx)
+	require.NoError(t, err)
+
+	acme, err := repo.GetHeldEvents(ctx, "acme", nil, 10)
+	require.NoError(t, err)
+	others, err := repo.CountHeldEvents(ctx, "", []string{"acme"})
+	require.NoError(t, err)
+	released, err := repo.ReleaseHeldEvent(ctx, urgent.ID)
+	require.NoError(t, err)
+	releasedAgain, err := repo.ReleaseHeldEvent(ctx, urgent.ID)
+	require.NoError(t, err)
+	left, err := repo.CountHeldEvents(ctx, "acme", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.SaveDeliveryPause(ctx, &models.DeliveryPause{Scope: models.DeliveryPauseGlobal, PausedAt: now}))
+	require.NoError(t, repo.DeleteResumedDeliveryPause(ctx, models.DeliveryPauseGlobal))
+	require.NoError(t, repo.DeleteResumedDeliveryPause(ctx, "acme"))
+	remaining, err := repo.GetDeliveryPauses(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, pauses, 1)
+	assert.Equal(t, 5, pauses[0].DrainRate)
+	assert.NotNil(t, pauses[0].ResumedAt)
+
+	require.Len(t, acme, 2)
+	assert.Equal(t, urgent.ID, acme[0].ID)
+	assert.Equal(t, oldest.ID, acme[1].ID)
+	assert.Equal(t, int64(1), others)
+	assert.True(t, released)
+	assert.False(t, releasedAgain)
+	assert.Equal(t, int64(1), left)
+
+	require.Len(t, remaining, 1)
+	assert.Equal(t, models.DeliveryPauseGlobal, remaining[0].Scope)
+}
+
+// TestSQLite_ChainRuns tests that run durations and usage are measured, and that expired runs are archived
+func TestSQLite_ChainRuns(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	webhooks := NewWebhookRepository(db, nil)
+	chains := NewExecutionChainRepository(db, nil)
+	retention := NewRetentionRepository(db)
+
+	webhook := &models.WebhookSubscription{TenantID: "acme", AppName: "shipping", TargetURL: "https://shipping.example.com", SubscribedEvent: "order.paid"}
+	require.NoError(t, webhooks.CreateSubscription(ctx, webhook))
+	chain := &models.ExecutionChain{TenantID: "acme", Name: "fulfil order", TriggerEvent: "order.paid"}
+	require.NoError(t, chains.CreateChain(ctx, chain))
+	step := &models.ExecutionChainStep{ChainID: chain.ID, StepOrder: 1, Name: "ship", WebhookID: &webhook.ID, RequestParams: `{"carrier": "ups"}`}
+	require.NoError(t, db.Create(step).Error)
+
+	startedAt := time.Now().Add(-90 * time.Second)
+	run := &models.ExecutionChainRun{ChainID: chain.ID, TenantID: "acme", Status: models.ExecutionChainStatusRunning, StartedAt: &startedAt}
+	require.NoError(t, chains.CreateChainRun(ctx, run))
+	stepCompletedAt := startedAt.Add(1500 * time.Millisecond)
+	stepRun := &models.ExecutionChainStepRun{
+		RunID: run.ID, StepID: step.ID, StepOrder: 1, Status: models.WebhookStatusSent, AttemptCount: 3,
+		StartedAt: &startedAt, CompletedAt: &stepCompletedAt, RequestBytes: 120, ResponseBytes: 80,
+	}
+	require.NoError(t, chains.CreateStepRun(ctx, stepRun))
+
+	// Act
+	require.NoError(t, chains.UpdateChainRunStatus(ctx, run.ID, models.ExecutionChainStatusCompleted))
+	stats, err := chains.GetChainRunStats(ctx, chain.ID, nil)
+	require.NoError(t, err)
+	usage, err := chains.GetChainUsage(ctx, "acme", nil)
+	require.NoError(t, err)
+
+	kept, _, err := retention.ArchiveChainRuns(ctx, RetentionCutoff{Now: time.Now(), DefaultDays: 30}, 10)
+	require.NoError(t, err)
+	archived, steps, err := retention.ArchiveChainRuns(ctx, RetentionCutoff{Now: time.Now().AddDate(0, 0, 31), DefaultDays: 30}, 10)
+	require.NoError(t, err)
+	var data string
+	require.NoError(t, db.Raw(`SELECT json_extract("data", '$.status') FROM "execution_chain_runs_archive" WHERE "id" = ?`, run.ID).Scan(&data).Error)
+
+	// Assert
+	assert.Equal(t, int64(1), stats.CompletedRuns)
+	require.NotNil(t, stats.P50DurationMs)
+	assert.InDelta(t, 90_000, *stats.P50DurationMs, 5_000)
+
+	assert.Equal(t, int64(1), usage.Runs)
+	assert.Equal(t, int64(3), usage.Attempts)
+	assert.Equal(t, int64(2), usage.Retries)
+	assert.Equal(t, int64(1500), usage.StepTimeMs)
+	require.Len(t, usage.Chains, 1)
+	assert.Equal(t, "fulfil order", usage.Chains[0].ChainName)
+
+	assert.Zero(t, kept)
+	assert.Equal(t, int64(1), archived)
+	assert.Equal(t, int64(1), steps)
+	assert.Equal(t, string(models.ExecutionChainStatusCompleted), data)
+	_, err = chains.GetChainRunByID(ctx, run.ID)
+	assert.Error(t, err)
+}
+
+// TestSQLite_Locks tests that SQLite databases get locks held in the process
+func TestSQLite_Locks(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	locks := NewLockRepository(openTestSQLite(t))
+
+	// Act
+	var nested bool
+	err := locks.WithLock(ctx, "admission", func() error {
+		var err error
+		nested, err = locks.TryWithLock(ctx, "admission", func() error { return nil })
+		return err
+	})
+	require.NoError(t, err)
+	ran, err := locks.TryWithLock(ctx, "admission", func() error { return nil })
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, nested)
+	assert.True(t, ran)
+}
+
+// TestOpenMemorySQLite tests that in-memory databases are separate from each other and keep their data while the
+// pool closes idle connections
+func TestOpenMemorySQLite(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	open := func() *gorm.DB {
+		db, err := OpenMemorySQLite()
+		require.NoError(t, err)
+		migrator, err := migrations.New(db)
+		require.NoError(t, err)
+		_, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		return db
+	}
+	db, other := open(), open()
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxIdleConns(0)
+
+	// Act
+	require.NoError(t, NewTenantRepository(db).SaveTenantSettings(ctx, &models.TenantSettings{TenantID: "acme", ChainsPaused: true}))
+	settings, err := NewTenantRepository(db).GetTenantSettings(ctx, "acme")
+	require.NoError(t, err)
+	otherSettings, err := NewTenantRepository(other).GetTenantSettings(ctx, "acme")
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, settings.ChainsPaused)
+	assert.False(t, otherSettings.ChainsPaused)
+}
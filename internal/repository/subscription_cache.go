@@ -0,0 +1,390 @@
+package repository
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/gob"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Subscription cache defaults, see SubscriptionCacheConfig
+const (
+	DefaultSubscriptionCacheSize = 10000
+	DefaultSubscriptionCacheTTL  = 30 * time.Second
+)
+
+// SubscriptionCacheConfig bounds the subscription cache
+type SubscriptionCacheConfig struct {
+	// Size is the most tenant and event lookups the in-process cache keeps, least recently used first out
+	Size int
+
+	// TTL is how long a lookup is cached; it bounds how long changes not made through the cached
+	// repository, such as those of other instances to an in-process cache, go unnoticed
+	TTL time.Duration
+}
+
+// DefaultSubscriptionCacheConfig returns the default subscription cache settings
+func DefaultSubscriptionCacheConfig() SubscriptionCacheConfig {
+	return SubscriptionCacheConfig{
+		Size: DefaultSubscriptionCacheSize,
+		TTL:  DefaultSubscriptionCacheTTL,
+	}
+}
+
+// SubscriptionCache caches the active subscriptions of a tenant's event
+// Entries are invalidated per tenant; a tenant's version changes with every invalidation, so a lookup
+// read from the database before an invalidation is not cached after it
+type SubscriptionCache interface {
+	// Get returns the cached subscriptions of a tenant's event, and on a miss the tenant's version to
+	// pass to Set
+	Get(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, bool, string, error)
+
+	// Set caches the subscriptions of a tenant's event unless the tenant was invalidated since version
+	Set(ctx context.Context, tenantID, event, version string, subscriptions []models.WebhookSubscription) error
+
+	// Invalidate drops the cached subscriptions of every event of a tenant
+	Invalidate(ctx context.Context, tenantID string) error
+}
+
+// cachedLookup is an entry of the in-process subscription cache
+type cachedLookup struct {
+	key           string
+	tenantID      string
+	version       uint64
+	expiresAt     time.Time
+	subscriptions []models.WebhookSubscription
+}
+
+// lruSubscriptionCache implements SubscriptionCache in process memory
+type lruSubscriptionCache struct {
+	config SubscriptionCacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+
+	// versions counts the invalidations of every tenant; entries of an older version are stale
+	versions map[string]uint64
+}
+
+// NewLRUSubscriptionCache creates a subscription cache in process memory
+// Subscriptions are copied on the way in and out, so callers may modify them
+func NewLRUSubscriptionCache(config SubscriptionCacheConfig) SubscriptionCache {
+	if config.Size <= 0 {
+		config.Size = DefaultSubscriptionCacheSize
+	}
+	return &lruSubscriptionCache{
+		config:   config,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		versions: make(map[string]uint64),
+	}
+}
+
+// lookupKey identifies the lookup of a tenant's event
+func lookupKey(tenantID, event string) string {
+	return tenantID + "\x00" + event
+}
+
+func (c *lruSubscriptionCache) Get(_ context.Context, tenantID, event string) ([]models.WebhookSubscription, bool, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	version := c.versions[tenantID]
+	if element, ok := c.entries[lookupKey(tenantID, event)]; ok {
+		entry := element.Value.(*cachedLookup)
+		if entry.version == version && c.now().Before(entry.expiresAt) {
+			c.order.MoveToFront(element)
+			return cloneSubscriptions(entry.subscriptions), true, "", nil
+		}
+		c.remove(element)
+	}
+	return nil, false, strconv.FormatUint(version, 10), nil
+}
+
+func (c *lruSubscriptionCache) Set(_ context.Context, tenantID, event, version string, subscriptions []models.WebhookSubscription) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if strconv.FormatUint(c.versions[tenantID], 10) != version {
+		return nil
+	}
+
+	key := lookupKey(tenantID, event)
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&cachedLookup{
+		key:           key,
+		tenantID:      tenantID,
+		version:       c.versions[tenantID],
+		expiresAt:     c.now().Add(c.config.TTL),
+		subscriptions: cloneSubscriptions(subscriptions),
+	})
+	for c.order.Len() > c.config.Size {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *lruSubscriptionCache) Invalidate(_ context.Context, tenantID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versions[tenantID]++
+	return nil
+}
+
+// remove drops an entry
+func (c *lruSubscriptionCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cachedLookup).key)
+}
+
+// cloneSubscriptions deep copies subscriptions, so cached ones are not shared with callers
+func cloneSubscriptions(subscriptions []models.WebhookSubscription) []models.WebhookSubscription {
+	clones := make([]models.WebhookSubscription, len(subscriptions))
+	for i := range subscriptions {
+		clones[i] = *cloneRecord(&subscriptions[i])
+	}
+	return clones
+}
+
+// redisSubscriptionCache implements SubscriptionCache on a Redis server shared by every instance
+// A tenant's lookups are keyed by its invalidation count, so invalidating it makes them unreachable until they
+// expire; subscriptions are gob encoded, as their secrets are left out of JSON, and encrypted with the configured
+// secret cipher so secrets stay encrypted at rest
+type redisSubscriptionCache struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisSubscriptionCache creates a subscription cache on a Redis server, with keys starting with prefix
+func NewRedisSubscriptionCache(client *redis.Client, prefix string, ttl time.Duration) SubscriptionCache {
+	if prefix == "" {
+		prefix = "loki:subscriptions:"
+	}
+	return &redisSubscriptionCache{client: client, prefix: prefix, ttl: ttl}
+}
+
+// subscriptionCacheColumn authenticates encrypted cache entries, so other ciphertext does not decrypt as one
+const subscriptionCacheColumn = "subscription_cache"
+
+// redisCacheGet returns the tenant's invalidation count and the lookup cached for it
+var redisCacheGet = redis.NewScript(`local version = redis.call('GET', KEYS[1]) or '0'
+return {version, redis.call('GET', ARGV[1] .. version .. ARGV[2])}`)
+
+// versionKey returns the key counting the invalidations of a tenant
+func (c *redisSubscriptionCache) versionKey(tenantID string) string {
+	return c.prefix + "version:" + tenantID
+}
+
+// lookupKey returns the key of the lookup of a tenant's event in the given version, split around the version
+func (c *redisSubscriptionCache) lookupKey(tenantID, event string) (string, string) {
+	return c.prefix + "lookup:" + tenantID + "\x00", "\x00" + event
+}
+
+func (c *redisSubscriptionCache) Get(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, bool, string, error) {
+	before, after := c.lookupKey(tenantID, event)
+	values, err := redisCacheGet.Run(ctx, c.client, []string{c.versionKey(tenantID)}, before, after).Slice()
+	if err != nil {
+		return nil, false, "", err
+	}
+	if len(values) != 2 {
+		return nil, false, "", errors.New("redis: unexpected reply to the subscription cache lookup")
+	}
+	version, _ := values[0].(string)
+	encoded, ok := values[1].(string)
+	if !ok {
+		return nil, false, version, nil
+	}
+
+	if cipher := ConfiguredCipher(); cipher != nil {
+		if encoded, err = cipher.Decrypt(subscriptionCacheColumn, encoded); err != nil {
+			return nil, false, version, err
+		}
+	}
+	var subscriptions []models.WebhookSubscription
+	if err := gob.NewDecoder(bytes.NewBufferString(encoded)).Decode(&subscriptions); err != nil {
+		return nil, false, version, err
+	}
+	return subscriptions, true, "", nil
+}
+
+func (c *redisSubscriptionCache) Set(ctx context.Context, tenantID, event, version string, subscriptions []models.WebhookSubscription) error {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(subscriptions); err != nil {
+		return err
+	}
+	encoded := buffer.String()
+	if cipher := ConfiguredCipher(); cipher != nil {
+		var err error
+		if encoded, err = cipher.Encrypt(subscriptionCacheColumn, encoded); err != nil {
+			return err
+		}
+	}
+	before, after := c.lookupKey(tenantID, event)
+	return c.client.Set(ctx, before+version+after, encoded, c.ttl).Err()
+}
+
+func (c *redisSubscriptionCache) Invalidate(ctx context.Context, tenantID string) error {
+	return c.client.Incr(ctx, c.versionKey(tenantID)).Err()
+}
+
+// cachingWebhookRepository decorates a WebhookRepository with a read-through cache of the active
+// subscriptions of a tenant's event, looked up for every published event
+// Subscription changes made through it invalidate their tenant's lookups
+type cachingWebhookRepository struct {
+	WebhookRepository
+	cache  SubscriptionCache
+	logger logging.Logger
+
+	requests      *prometheus.CounterVec
+	invalidations prometheus.Counter
+}
+
+// NewCachingWebhookRepository wraps a webhook repository so active subscription lookups are served from cache
+// Cache failures fall back to the wrapped repository; lookups are counted by result on /metrics
+// Returns: WebhookRepository that delegates to next, error if the metrics cannot be registered
+func NewCachingWebhookRepository(next WebhookRepository, cache SubscriptionCache, registerer prometheus.Registerer, logger logging.Logger) (WebhookRepository, error) {
+	r := &cachingWebhookRepository{
+		WebhookRepository: next,
+		cache:             cache,
+		logger:            logger,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "subscription_cache",
+			Name:      "requests_total",
+			Help:      "Number of active subscription lookups by cache result: hit, miss or error.",
+		}, []string{"result"}),
+		invalidations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "subscription_cache",
+			Name:      "invalidations_total",
+			Help:      "Number of tenant invalidations of the subscription cache.",
+		}),
+	}
+	for _, collector := range []prometheus.Collector{r.requests, r.invalidations} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *cachingWebhookRepository) GetActiveSubscriptionsByTenantAndEvent(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, error) {
+	subscriptions, found, version, err := r.cache.Get(ctx, tenantID, event)
+	switch {
+	case err != nil:
+		r.requests.WithLabelValues("error").Inc()
+		r.logger.Warn(ctx, "Subscription cache lookup failed", zap.String("tenant_id", tenantID), zap.Error(err))
+		return r.WebhookRepository.GetActiveSubscriptionsByTenantAndEvent(ctx, tenantID, event)
+	case found:
+		r.requests.WithLabelValues("hit").Inc()
+		return subscriptions, nil
+	}
+
+	r.requests.WithLabelValues("miss").Inc()
+	subscriptions, err = r.WebhookRepository.GetActiveSubscriptionsByTenantAndEvent(ctx, tenantID, event)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cache.Set(ctx, tenantID, event, version, subscriptions); err != nil {
+		r.logger.Warn(ctx, "Failed to cache subscriptions", zap.String("tenant_id", tenantID), zap.Error(err))
+	}
+	return subscriptions, nil
+}
+
+func (r *cachingWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := r.WebhookRepository.CreateSubscription(ctx, subscription); err != nil {
+		return err
+	}
+	r.invalidate(ctx, subscription.TenantID)
+	return nil
+}
+
+func (r *cachingWebhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := r.WebhookRepository.UpdateSubscription(ctx, subscription); err != nil {
+		return err
+	}
+	r.invalidate(ctx, subscription.TenantID)
+	return nil
+}
+
+func (r *cachingWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	// The tenant is looked up first, as deleted subscriptions are not found
+	subscription, err := r.WebhookRepository.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return r.WebhookRepository.DeleteSubscription(ctx, id)
+	}
+	if err := r.WebhookRepository.DeleteSubscription(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, subscription.TenantID)
+	return nil
+}
+
+func (r *cachingWebhookRepository) RestoreSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := r.WebhookRepository.RestoreSubscription(ctx, id); err != nil {
+		return err
+	}
+	r.invalidateSubscription(ctx, id)
+	return nil
+}
+
+func (r *cachingWebhookRepository) SetSubscriptionPause(ctx context.Context, id uuid.UUID, pausedUntil *time.Time) error {
+	if err := r.WebhookRepository.SetSubscriptionPause(ctx, id, pausedUntil); err != nil {
+		return err
+	}
+	r.invalidateSubscription(ctx, id)
+	return nil
+}
+
+func (r *cachingWebhookRepository) DisableSubscription(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string) (bool, error) {
+	disabled, err := r.WebhookRepository.DisableSubscription(ctx, id, disabledAt, reason)
+	if err == nil && disabled {
+		r.invalidateSubscription(ctx, id)
+	}
+	return disabled, err
+}
+
+func (r *cachingWebhookRepository) EnableSubscription(ctx context.Context, id uuid.UUID, enabledAt time.Time) error {
+	if err := r.WebhookRepository.EnableSubscription(ctx, id, enabledAt); err != nil {
+		return err
+	}
+	r.invalidateSubscription(ctx, id)
+	return nil
+}
+
+// invalidateSubscription invalidates the lookups of the tenant of a subscription changed by ID
+func (r *cachingWebhookRepository) invalidateSubscription(ctx context.Context, id uuid.UUID) {
+	subscription, err := r.WebhookRepository.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		r.logger.Warn(ctx, "Failed to look up the tenant of a changed subscription, its cached lookups expire with their TTL",
+			zap.String("subscription_id", id.String()), zap.Error(err))
+		return
+	}
+	r.invalidate(ctx, subscription.TenantID)
+}
+
+// invalidate drops the cached lookups of a tenant
+// The change is already stored, so a failure is logged rather than returned; the lookups expire with their TTL
+func (r *cachingWebhookRepository) invalidate(ctx context.Context, tenantID string) {
+	r.invalidations.Inc()
+	if err := r.cache.Invalidate(ctx, tenantID); err != nil {
+		r.logger.Warn(ctx, "Failed to invalidate cached subscriptions, they expire with their TTL",
+			zap.String("tenant_id", tenantID), zap.Error(err))
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLRUSubscriptionCache tests that lookups expire, that the least recently used is evicted first, and that a
+// lookup read before an invalidation is not cached after it
+func TestLRUSubscriptionCache(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cache := NewLRUSubscriptionCache(SubscriptionCacheConfig{Size: 2, TTL: time.Minute}).(*lruSubscriptionCache)
+	cache.now = func() time.Time { return now }
+	subscriptions := []models.WebhookSubscription{{TenantID: "acme", SubscribedEvent: "invoice.paid", Headers: map[string]string{"X-Team": "billing"}}}
+
+	// Act
+	_, found, version, err := cache.Get(ctx, "acme", "invoice.paid")
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(ctx, "acme", "invoice.paid", version, subscriptions))
+	subscriptions[0].Headers["X-Team"] = "changed"
+	cached, hit, _, err := cache.Get(ctx, "acme", "invoice.paid")
+	require.NoError(t, err)
+
+	_, _, version, _ = cache.Get(ctx, "acme", "invoice.sent")
+	require.NoError(t, cache.Invalidate(ctx, "acme"))
+	require.NoError(t, cache.Set(ctx, "acme", "invoice.sent", version, subscriptions))
+	_, staleFound, _, _ := cache.Get(ctx, "acme", "invoice.sent")
+	_, invalidatedFound, _, _ := cache.Get(ctx, "acme", "invoice.paid")
+
+	for _, event := range []string{"a", "b", "c"} {
+		_, _, version, _ = cache.Get(ctx, "globex", event)
+		require.NoError(t, cache.Set(ctx, "globex", event, version, nil))
+	}
+	_, evictedFound, _, _ := cache.Get(ctx, "globex", "a")
+	_, keptFound, _, _ := cache.Get(ctx, "globex", "c")
+	now = now.Add(time.Minute)
+	_, expiredFound, _, _ := cache.Get(ctx, "globex", "c")
+
+	// Assert
+	assert.False(t, found)
+	assert.True(t, hit)
+	require.Len(t, cached, 1)
+	assert.Equal(t, "billing", cached[0].Headers["X-Team"])
+	assert.False(t, staleFound)
+	assert.False(t, invalidatedFound)
+	assert.False(t, evictedFound)
+	assert.True(t, keptFound)
+	assert.False(t, expiredFound)
+}
+
+// TestCachingWebhookRepository tests that active subscription lookups are served from cache until a subscription
+// of their tenant changes
+func TestCachingWebhookRepository(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	repo, err := NewCachingWebhookRepository(NewMemoryWebhookRepository(NewMemoryStore()),
+		NewLRUSubscriptionCache(DefaultSubscriptionCacheConfig()), registry, logging.Nop())
+	require.NoError(t, err)
+	newSubscription := func() *models.WebhookSubscription {
+		return &models.WebhookSubscription{TenantID: "acme", AppName: "billing", TargetURL: "https://billing.example.com", SubscribedEvent: "invoice.paid"}
+	}
+	first := newSubscription()
+	require.NoError(t, repo.CreateSubscription(ctx, first))
+	active := func() int {
+		subscriptions, err := repo.GetActiveSubscriptionsByTenantAndEvent(ctx, "acme", "invoice.paid")
+		require.NoError(t, err)
+		return len(subscriptions)
+	}
+
+	// Act
+	initial := active()
+	cached := active()
+	require.NoError(t, repo.CreateSubscription(ctx, newSubscription()))
+	created := active()
+	require.NoError(t, repo.DeleteSubscription(ctx, first.ID))
+	deleted := active()
+	require.NoError(t, repo.RestoreSubscription(ctx, first.ID))
+	restored := active()
+
+	// Assert
+	assert.Equal(t, 1, initial)
+	assert.Equal(t, 1, cached)
+	assert.Equal(t, 2, created)
+	assert.Equal(t, 1, deleted)
+	assert.Equal(t, 2, restored)
+	series, err := testutil.GatherAndCount(registry, "loki_subscription_cache_requests_total")
+	require.NoError(t, err)
+	assert.Equal(t, 2, series)
+	caching := repo.(*cachingWebhookRepository)
+	assert.Equal(t, 1.0, testutil.ToFloat64(caching.requests.WithLabelValues("hit")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(caching.requests.WithLabelValues("miss")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(caching.invalidations))
+}
+
+// TestRedisSubscriptionCache tests that lookups cached in Redis are returned with their secrets, expire, and are
+// unreachable once their tenant is invalidated, including a lookup read before the invalidation and set after it
+func TestRedisSubscriptionCache(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	cache := NewRedisSubscriptionCache(client, "", time.Minute)
+	subscriptions := []models.WebhookSubscription{{TenantID: "acme", SubscribedEvent: "invoice.paid", SecretToken: "s3cret"}}
+
+	// Act
+	_, found, version, err := cache.Get(ctx, "acme", "invoice.paid")
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(ctx, "acme", "invoice.paid", version, subscriptions))
+	cached, hit, _, err := cache.Get(ctx, "acme", "invoice.paid")
+	require.NoError(t, err)
+
+	_, _, version, _ = cache.Get(ctx, "acme", "invoice.sent")
+	require.NoError(t, cache.Invalidate(ctx, "acme"))
+	require.NoError(t, cache.Set(ctx, "acme", "invoice.sent", version, subscriptions))
+	_, staleFound, _, _ := cache.Get(ctx, "acme", "invoice.sent")
+	_, invalidatedFound, _, _ := cache.Get(ctx, "acme", "invoice.paid")
+
+	_, _, version, _ = cache.Get(ctx, "globex", "a")
+	require.NoError(t, cache.Set(ctx, "globex", "a", version, nil))
+	_, keptFound, _, _ := cache.Get(ctx, "globex", "a")
+	server.FastForward(time.Minute)
+	_, expiredFound, _, _ := cache.Get(ctx, "globex", "a")
+
+	// Assert
+	assert.False(t, found)
+	assert.True(t, hit)
+	require.Len(t, cached, 1)
+	assert.Equal(t, "s3cret", cached[0].SecretToken)
+	assert.False(t, staleFound)
+	assert.False(t, invalidatedFound)
+	assert.True(t, keptFound)
+	assert.False(t, expiredFound)
+}
@@ -0,0 +1,493 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantRepository defines the interface for tenant and tenant-wide settings data access
+// This interface provides methods for managing tenants and for reading and persisting operational
+// switches that apply to all subscriptions and execution chains owned by a tenant
+type TenantRepository interface {
+	// CreateTenant stores a new tenant together with its settings, if set
+	CreateTenant(ctx context.Context, tenant *models.Tenant) error
+
+	// GetTenant retrieves a tenant with its settings
+	// Returns nil without an error when no tenant has the ID or the tenant was deleted
+	GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+
+	// TenantExists reports whether a tenant has the ID, including deleted tenants
+	// Used to refuse reusing the ID of a deleted tenant
+	TenantExists(ctx context.Context, tenantID string) (bool, error)
+
+	// ListTenants retrieves tenants with their settings with pagination, oldest first
+	ListTenants(ctx context.Context, offset, limit int) ([]models.Tenant, int64, error)
+
+	// UpdateTenant stores the lifecycle state of a tenant; its settings are left unchanged
+	UpdateTenant(ctx context.Context, tenant *models.Tenant) error
+
+	// DeleteTenant soft deletes a tenant along with its subscriptions and chains
+	// Returns the number of subscriptions and chains deleted
+	DeleteTenant(ctx context.Context, tenantID string) (int64, int64, error)
+
+	// ListRateLimits retrieves the rate limit overrides of every tenant that has one
+	// Used to configure the management API rate limiter
+	ListRateLimits(ctx context.Context) (map[string]models.RateLimit, error)
+
+	// ListAutoDisablePolicies retrieves the settings of every tenant with an auto-disable policy
+	// Used to deactivate subscriptions that keep failing
+	ListAutoDisablePolicies(ctx context.Context) ([]models.TenantSettings, error)
+
+	// AddUsage adds events and deliveries to the usage of a tenant on a UTC day
+	AddUsage(ctx context.Context, tenantID string, day time.Time, events, deliveries int64) error
+
+	// ListUsage retrieves the usage of a tenant on the days from from to to, inclusive, oldest first
+	// Days without usage have no row
+	ListUsage(ctx context.Context, tenantID string, from, to time.Time) ([]models.TenantUsage, error)
+
+	// GetTenantSettings retrieves the settings for a tenant
+	// Returns default settings when the tenant has never stored any
+	GetTenantSettings(ctx context.Context, tenantID string) (*models.TenantSettings, error)
+
+	// SaveTenantSettings creates or replaces the settings row for a tenant
+	// Used when toggling tenant-wide switches such as chain execution pause
+	SaveTenantSettings(ctx context.Context, settings *models.TenantSettings) error
+
+	// GetAllSubscriptions retrieves every webhook subscription of a tenant without pagination
+	// Used to build the tenant dependency graph
+	GetAllSubscriptions(ctx context.Context, tenantID string) ([]models.WebhookSubscription, error)
+
+	// GetAllChains retrieves every execution chain of a tenant with its steps
+	// Used to build the tenant dependency graph
+	GetAllChains(ctx context.Context, tenantID string) ([]models.ExecutionChain, error)
+
+	// GetEventSources retrieves the distinct source and event name pairs a tenant has sent
+	// Used to determine which apps emit which events
+	GetEventSources(ctx context.Context, tenantID string) ([]models.EventSource, error)
+
+	// CreateSigningKey stores a newly generated signing key
+	CreateSigningKey(ctx context.Context, key *models.SigningKey) error
+
+	// GetSigningKey retrieves a signing key by its key ID
+	GetSigningKey(ctx context.Context, id string) (*models.SigningKey, error)
+
+	// ListSigningKeys retrieves the signing keys of a tenant, or of every tenant when tenantID is empty
+	// Used to publish the public key set
+	ListSigningKeys(ctx context.Context, tenantID string) ([]models.SigningKey, error)
+
+	// GetOrCreateSubjectKey retrieves the key of a tenant's data subject, storing the given key if it has none
+	// Concurrent calls for the same subject return the same key
+	GetOrCreateSubjectKey(ctx context.Context, key *models.SubjectKey) (*models.SubjectKey, error)
+
+	// GetSubjectKey retrieves a subject key of a tenant by its ID, nil when the subject was erased
+	GetSubjectKey(ctx context.Context, tenantID string, id uuid.UUID) (*models.SubjectKey, error)
+
+	// DeleteSubjectKey deletes the key of a tenant's data subject; returns whether the subject had one
+	DeleteSubjectKey(ctx context.Context, tenantID, subjectHash string) (bool, error)
+}
+
+// tenantRepository implements TenantRepository interface
+// Provides concrete implementation of tenant settings data access using GORM ORM
+type tenantRepository struct {
+	// db is the GORM database instance for executing queries
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository instance
+// Factory function that initializes the repository with a database connection
+// Returns: TenantRepository interface implementation
+func NewTenantRepository(db *gorm.DB) TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// CreateTenant stores a new tenant together with its settings, if set
+// Settings replace any row left by settings stored before the tenant was created
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenant: Tenant model with its ID and optional settings
+//
+// Returns: error if either insert fails, nil on success
+func (r *tenantRepository) CreateTenant(ctx context.Context, tenant *models.Tenant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Settings").Create(tenant).Error; err != nil {
+			return err
+		}
+		if tenant.Settings == nil {
+			return nil
+		}
+		tenant.Settings.TenantID = tenant.ID
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}},
+			UpdateAll: true,
+		}).Create(tenant.Settings).Error
+	})
+}
+
+// GetTenant retrieves a tenant with its settings
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//
+// Returns: Tenant pointer, nil if no tenant has the ID, error if query fails
+func (r *tenantRepository) GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	var tenant models.Tenant
+	err := r.db.WithContext(ctx).Preload("Settings").Where("id = ?", tenantID).First(&tenant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// TenantExists reports whether a tenant has the ID, including deleted tenants
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//
+// Returns: Whether the ID is taken, error if query fails
+func (r *tenantRepository) TenantExists(ctx context.Context, tenantID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Tenant{}).Where("id = ?", tenantID).Count(&count).Error
+	return count > 0, err
+}
+
+// ListTenants retrieves tenants with their settings with pagination
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - offset: Number of records to skip for pagination
+//   - limit: Maximum number of records to return
+//
+// Returns: Slice of Tenants ordered by creation time, total count, error if query fails
+func (r *tenantRepository) ListTenants(ctx context.Context, offset, limit int) ([]models.Tenant, int64, error) {
+	var tenants []models.Tenant
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Tenant{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Settings").
+		Order("created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&tenants).Error
+
+	return tenants, total, err
+}
+
+// UpdateTenant stores the lifecycle state of a tenant
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenant: Tenant model with the desired state
+//
+// Returns: error if the update fails, nil on success
+func (r *tenantRepository) UpdateTenant(ctx context.Context, tenant *models.Tenant) error {
+	return r.db.WithContext(ctx).Omit("Settings").Save(tenant).Error
+}
+
+// DeleteTenant soft deletes a tenant along with its subscriptions and chains in one transaction
+// Deleted subscriptions and chains can be restored individually or purged like any other
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//
+// Returns: Number of subscriptions and chains deleted, gorm.ErrRecordNotFound if no tenant has the ID
+func (r *tenantRepository) DeleteTenant(ctx context.Context, tenantID string) (int64, int64, error) {
+	var subscriptions, chains int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tenant := tx.Where("id = ?", tenantID).Delete(&models.Tenant{})
+		if tenant.Error != nil {
+			return tenant.Error
+		}
+		if tenant.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		deleted := tx.Where("tenant_id = ?", tenantID).Delete(&models.WebhookSubscription{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		subscriptions = deleted.RowsAffected
+
+		deleted = tx.Where("tenant_id = ?", tenantID).Delete(&models.ExecutionChain{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		chains = deleted.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return subscriptions, chains, nil
+}
+
+// ListRateLimits retrieves the rate limit overrides of every tenant that has one
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//
+// Returns: Rate limits by tenant ID, error if query fails
+func (r *tenantRepository) ListRateLimits(ctx context.Context) (map[string]models.RateLimit, error) {
+	var settings []models.TenantSettings
+	err := r.db.WithContext(ctx).
+		Where("rate_limit_requests_per_second > 0").
+		Where("tenant_id IN (?)", r.db.Model(&models.Tenant{}).Select("id")).
+		Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[string]models.RateLimit, len(settings))
+	for _, s := range settings {
+		limits[s.TenantID] = s.RateLimit
+	}
+	return limits, nil
+}
+
+// ListAutoDisablePolicies retrieves the settings of every tenant with an auto-disable policy
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//
+// Returns: Settings of the tenants with a policy, error if query fails
+func (r *tenantRepository) ListAutoDisablePolicies(ctx context.Context) ([]models.TenantSettings, error) {
+	var settings []models.TenantSettings
+	err := r.db.WithContext(ctx).
+		Where("auto_disable_failures > 0 AND auto_disable_days > 0").
+		Where("tenant_id IN (?)", r.db.Model(&models.Tenant{}).Select("id")).
+		Order("tenant_id ASC").
+		Find(&settings).Error
+	return settings, err
+}
+
+// AddUsage adds events and deliveries to the usage of a tenant on a UTC day
+// Counts are incremented in the database, so instances counting at the same time don't lose counts
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//   - day: Day the usage is counted on
+//   - events: Number of events published
+//   - deliveries: Number of deliveries made
+//
+// Returns: error if the upsert fails, nil on success
+func (r *tenantRepository) AddUsage(ctx context.Context, tenantID string, day time.Time, events, deliveries int64) error {
+	usage := &models.TenantUsage{
+		TenantID:   tenantID,
+		Day:        day,
+		Events:     events,
+		Deliveries: deliveries,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"events":     gorm.Expr("tenant_usage.events + ?", events),
+			"deliveries": gorm.Expr("tenant_usage.deliveries + ?", deliveries),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(usage).Error
+}
+
+// ListUsage retrieves the usage of a tenant on the days from from to to, inclusive, oldest first
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//   - from: First day
+//   - to: Last day
+//
+// Returns: Usage of every day with usage, error if query fails
+func (r *tenantRepository) ListUsage(ctx context.Context, tenantID string, from, to time.Time) ([]models.TenantUsage, error) {
+	var usage []models.TenantUsage
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND day >= ? AND day < ?", tenantID, from.Format(time.DateOnly), to.AddDate(0, 0, 1).Format(time.DateOnly)).
+		Order("day ASC").
+		Find(&usage).Error
+	return usage, err
+}
+
+// GetTenantSettings retrieves the settings for a tenant
+// Tenants without a stored row get zero-value settings so callers never need to special-case them
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to load settings for
+//
+// Returns: TenantSettings pointer (stored or default), error if query fails
+func (r *tenantRepository) GetTenantSettings(ctx context.Context, tenantID string) (*models.TenantSettings, error) {
+	var settings models.TenantSettings
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.TenantSettings{TenantID: tenantID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveTenantSettings creates or replaces the settings row for a tenant
+// Uses an upsert on the tenant ID so concurrent first writes do not conflict
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - settings: TenantSettings model with the desired state
+//
+// Returns: error if the upsert fails, nil on success
+func (r *tenantRepository) SaveTenantSettings(ctx context.Context, settings *models.TenantSettings) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		UpdateAll: true,
+	}).Create(settings).Error
+}
+
+// GetAllSubscriptions retrieves every webhook subscription of a tenant
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter subscriptions
+//
+// Returns: Slice of WebhookSubscriptions ordered by creation time, error if query fails
+func (r *tenantRepository) GetAllSubscriptions(ctx context.Context, tenantID string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("created_at ASC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetAllChains retrieves every execution chain of a tenant with its steps in execution order
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter chains
+//
+// Returns: Slice of ExecutionChains with steps preloaded, error if query fails
+func (r *tenantRepository) GetAllChains(ctx context.Context, tenantID string) ([]models.ExecutionChain, error) {
+	var chains []models.ExecutionChain
+	err := r.db.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Where("retired_at IS NULL").Order("step_order ASC")
+		}).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at ASC").
+		Find(&chains).Error
+	return chains, err
+}
+
+// GetEventSources retrieves the distinct source and event name pairs a tenant has sent
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter events
+//
+// Returns: Slice of EventSources ordered by source and event name, error if query fails
+func (r *tenantRepository) GetEventSources(ctx context.Context, tenantID string) ([]models.EventSource, error) {
+	var sources []models.EventSource
+	err := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).
+		Distinct("source", "event_name").
+		Where("tenant_id = ?", tenantID).
+		Order("source ASC, event_name ASC").
+		Scan(&sources).Error
+	return sources, err
+}
+
+// CreateSigningKey stores a newly generated signing key
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - key: SigningKey with its ID and key pair set
+//
+// Returns: error if the insert fails, nil on success
+func (r *tenantRepository) CreateSigningKey(ctx context.Context, key *models.SigningKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// GetSigningKey retrieves a signing key by its key ID
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: Key ID
+//
+// Returns: SigningKey pointer, error if not found or query fails
+func (r *tenantRepository) GetSigningKey(ctx context.Context, id string) (*models.SigningKey, error) {
+	var key models.SigningKey
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListSigningKeys retrieves signing keys, newest first
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier to filter keys, empty for every tenant
+//
+// Returns: Slice of SigningKeys, error if query fails
+func (r *tenantRepository) ListSigningKeys(ctx context.Context, tenantID string) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	query := r.db.WithContext(ctx).Order("created_at DESC")
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	err := query.Find(&keys).Error
+	return keys, err
+}
+
+// GetOrCreateSubjectKey retrieves the key of a tenant's data subject, storing the given key if it has none
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - key: SubjectKey with its tenant, subject hash and a newly generated key set
+//
+// Returns: The stored key, which is the given one unless the subject had a key, error if a query fails
+func (r *tenantRepository) GetOrCreateSubjectKey(ctx context.Context, key *models.SubjectKey) (*models.SubjectKey, error) {
+	db := r.db.WithContext(ctx)
+	var stored models.SubjectKey
+	err := db.Where("tenant_id = ? AND subject_hash = ?", key.TenantID, key.SubjectHash).First(&stored).Error
+	if err == nil {
+		return &stored, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Another instance may store a key for the subject first; both then use the key stored
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(key).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("tenant_id = ? AND subject_hash = ?", key.TenantID, key.SubjectHash).First(&stored).Error; err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// GetSubjectKey retrieves a subject key of a tenant by its ID
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//   - id: Key ID
+//
+// Returns: SubjectKey pointer, nil when no such key exists, error if query fails
+func (r *tenantRepository) GetSubjectKey(ctx context.Context, tenantID string, id uuid.UUID) (*models.SubjectKey, error) {
+	var key models.SubjectKey
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// DeleteSubjectKey deletes the key of a tenant's data subject
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//   - subjectHash: Hex encoded SHA-256 of the subject identifier
+//
+// Returns: Whether a key was deleted, error if the delete fails
+func (r *tenantRepository) DeleteSubjectKey(ctx context.Context, tenantID, subjectHash string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("tenant_id = ? AND subject_hash = ?", tenantID, subjectHash).Delete(&models.SubjectKey{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned for records that do not exist, or that belong to another tenant than the one the call
+// is scoped to; it is gorm.ErrRecordNotFound, which the repositories return for missing records
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// tenantScopeKey is the context key under which the tenant repository calls are scoped to is stored
+type tenantScopeKey struct{}
+
+// WithTenantScope returns a context scoping the chain, run and webhook subscription lookups, updates and deletions
+// made with it to the records of a tenant; the records of other tenants are not found, as if they did not exist
+// An empty tenant leaves the context unscoped, as for global credentials and the service's own calls
+func WithTenantScope(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantScopeKey{}, tenantID)
+}
+
+// TenantScope returns the tenant a context is scoped to, empty when it is unscoped
+func TenantScope(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantScopeKey{}).(string)
+	return tenantID
+}
+
+// scopeTenant narrows a query of a table with a tenant_id column to the tenant ctx is scoped to
+func scopeTenant(ctx context.Context, query *gorm.DB) *gorm.DB {
+	if tenantID := TenantScope(ctx); tenantID != "" {
+		return query.Where("tenant_id = ?", tenantID)
+	}
+	return query
+}
+
+// inTenantScope reports whether a record of a tenant is visible in the scope of ctx, for the in-memory store
+func inTenantScope(ctx context.Context, tenantID string) bool {
+	scope := TenantScope(ctx)
+	return scope == "" || scope == tenantID
+}
+
+// scopedWrite returns the error of an update or deletion of a record by ID, gorm.ErrRecordNotFound when it was
+// scoped to a tenant and matched no record of that tenant
+// Unscoped writes of missing records keep succeeding without changes
+func scopedWrite(ctx context.Context, result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 && TenantScope(ctx) != "" {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// scopedMiss returns the error of an update or deletion of a missing record in the in-memory store, like scopedWrite
+func scopedMiss(ctx context.Context) error {
+	if TenantScope(ctx) != "" {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
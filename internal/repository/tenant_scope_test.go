@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestTenantScope_Chains tests that chains and runs of another tenant than the one a context is scoped to are
+// neither found, updated nor deleted, while their own tenant and unscoped callers reach them, on the database
+// and in memory
+func TestTenantScope_Chains(t *testing.T) {
+	repos := map[string]func(t *testing.T) ExecutionChainRepository{
+		"sqlite": func(t *testing.T) ExecutionChainRepository {
+			return NewExecutionChainRepository(openTestSQLite(t), nil)
+		},
+		"memory": func(t *testing.T) ExecutionChainRepository {
+			return NewMemoryExecutionChainRepository(NewMemoryStore())
+		},
+	}
+
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newRepo(t)
+			chain := &models.ExecutionChain{TenantID: "acme", Name: "fulfil order", TriggerEvent: "order.paid"}
+			require.NoError(t, repo.CreateChain(ctx, chain))
+			run := &models.ExecutionChainRun{ChainID: chain.ID, TenantID: "acme", Status: models.ExecutionChainStatusPending}
+			require.NoError(t, repo.CreateChainRun(ctx, run))
+			owner := WithTenantScope(ctx, "acme")
+			other := WithTenantScope(ctx, "globex")
+
+			// Act
+			_, otherChainErr := repo.GetChainByID(other, chain.ID)
+			_, otherRunErr := repo.GetChainRunByID(other, run.ID)
+			otherUpdateErr := repo.UpdateChain(other, chain.ID, map[string]interface{}{"name": "taken over"})
+			otherAtVersionErr := repo.UpdateChainAtVersion(other, chain.ID, 1, map[string]interface{}{"name": "taken over"})
+			otherDeleteErr := repo.DeleteChain(other, chain.ID)
+			ownerChain, ownerChainErr := repo.GetChainByID(owner, chain.ID)
+			_, ownerRunErr := repo.GetChainRunByID(owner, run.ID)
+			ownerUpdateErr := repo.UpdateChain(owner, chain.ID, map[string]interface{}{"name": "fulfil paid order"})
+			_, unscopedRunErr := repo.GetChainRunByID(ctx, run.ID)
+			ownerDeleteErr := repo.DeleteChain(owner, chain.ID)
+
+			// Assert
+			assert.ErrorIs(t, otherChainErr, gorm.ErrRecordNotFound)
+			assert.ErrorIs(t, otherRunErr, gorm.ErrRecordNotFound)
+			assert.ErrorIs(t, otherUpdateErr, gorm.ErrRecordNotFound)
+			assert.ErrorIs(t, otherAtVersionErr, ErrVersionConflict)
+			assert.ErrorIs(t, otherDeleteErr, gorm.ErrRecordNotFound)
+			require.NoError(t, ownerChainErr)
+			assert.Equal(t, "fulfil order", ownerChain.Name)
+			assert.NoError(t, ownerRunErr)
+			assert.NoError(t, ownerUpdateErr)
+			assert.NoError(t, unscopedRunErr)
+			assert.NoError(t, ownerDeleteErr)
+			_, err := repo.GetChainByID(ctx, chain.ID)
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/sakibcoolz/loki-suite/internal/models"
 
 	"github.com/google/uuid"
@@ -15,45 +19,204 @@ type WebhookRepository interface {
 
 	// CreateSubscription registers a new webhook subscription for event notifications
 	// Establishes a webhook endpoint to receive specific event types
-	CreateSubscription(subscription *models.WebhookSubscription) error
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
 
 	// GetSubscriptionByID retrieves a specific webhook subscription by its unique identifier
 	// Used for subscription verification and configuration retrieval
-	GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error)
+	// Subscriptions of other tenants than the one ctx is scoped to, see WithTenantScope, are not found
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
 
 	// GetActiveSubscriptionsByTenantAndEvent finds active subscriptions for event delivery
 	// Critical method for determining which endpoints to notify when events occur
-	GetActiveSubscriptionsByTenantAndEvent(tenantID, event string) ([]models.WebhookSubscription, error)
+	GetActiveSubscriptionsByTenantAndEvent(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, error)
 
 	// GetSubscriptionsByTenant retrieves all webhook subscriptions for a tenant with pagination
-	// Provides subscription management dashboard data with pagination support
-	GetSubscriptionsByTenant(tenantID string, offset, limit int) ([]models.WebhookSubscription, int64, error)
+	// Provides subscription management dashboard data with filtering and pagination support
+	GetSubscriptionsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]models.WebhookSubscription, int64, error)
+
+	// UpdateSubscription modifies an existing webhook subscription at the lock version it was read at
+	// Allows changes to endpoint URL, event types, security settings, and active status; returns
+	// ErrVersionConflict when the subscription was changed or deleted since it was read
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+
+	// DeleteSubscription soft deletes a webhook subscription
+	// Stops future event deliveries; the row is kept so the subscription can be restored until it is purged
+	// Returns gorm.ErrRecordNotFound when ctx is scoped to a tenant the subscription does not belong to
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+
+	// RestoreSubscription restores a soft deleted webhook subscription
+	// Returns gorm.ErrRecordNotFound when no deleted subscription of the tenant ctx is scoped to has the ID
+	RestoreSubscription(ctx context.Context, id uuid.UUID) error
+
+	// SetSubscriptionPause sets or, with nil, clears the time until which deliveries to a subscription are paused
+	// Only the pause is written, so concurrent configuration changes are not overwritten
+	SetSubscriptionPause(ctx context.Context, id uuid.UUID, pausedUntil *time.Time) error
+
+	// GetSubscriptionsPausedUntil retrieves the paused subscriptions whose pause ended at or before a point in time
+	// Used to release the deliveries queued during the pause
+	GetSubscriptionsPausedUntil(ctx context.Context, until time.Time) ([]models.WebhookSubscription, error)
+
+	// GetFailingSubscriptions retrieves the active subscriptions of a tenant with at least failures failed deliveries
+	// and none succeeding since a point in time, or since they were last enabled again if later
+	// Used to apply the tenant's auto-disable policy
+	GetFailingSubscriptions(ctx context.Context, tenantID string, since time.Time, failures int) ([]models.WebhookSubscription, error)
+
+	// DisableSubscription deactivates an active subscription, recording when and why
+	// Returns false when the subscription was not active anymore
+	DisableSubscription(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string) (bool, error)
+
+	// EnableSubscription activates a subscription again, clearing why it was deactivated
+	// Returns gorm.ErrRecordNotFound when ctx is scoped to a tenant the subscription does not belong to
+	EnableSubscription(ctx context.Context, id uuid.UUID, enabledAt time.Time) error
+
+	// Queued delivery methods for holding back deliveries to paused subscriptions
+
+	// CreateQueuedDelivery queues a delivery until its subscription's pause ends
+	CreateQueuedDelivery(ctx context.Context, delivery *models.QueuedDelivery) error
+
+	// GetQueuedDeliveries retrieves the queued deliveries of a subscription, oldest first
+	GetQueuedDeliveries(ctx context.Context, subscriptionID uuid.UUID) ([]models.QueuedDelivery, error)
+
+	// DeleteQueuedDelivery removes a queued delivery once it has been sent
+	DeleteQueuedDelivery(ctx context.Context, id uuid.UUID) error
+
+	// CountQueuedDeliveriesByEvent counts the deliveries of an event that are still queued
+	// An event is complete once none of its deliveries are queued
+	CountQueuedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error)
+
+	// Batched delivery methods for buffering deliveries to batching subscriptions
+
+	// CreateBatchedDelivery buffers a delivery for its subscription's next batch
+	CreateBatchedDelivery(ctx context.Context, delivery *models.BatchedDelivery) error
+
+	// GetBatchedDeliveries retrieves up to limit buffered deliveries of a subscription, oldest first
+	GetBatchedDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.BatchedDelivery, error)
+
+	// DeleteBatchedDeliveries removes buffered deliveries once their batch has been sent
+	DeleteBatchedDeliveries(ctx context.Context, ids []uuid.UUID) error
+
+	// CountBatchedDeliveriesByEvent counts the deliveries of an event that are still buffered
+	CountBatchedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error)
+
+	// GetSubscriptionsWithDueBatches retrieves the unpaused subscriptions with a full batch buffered, or whose
+	// oldest buffered delivery has waited the subscription's batch window at a point in time
+	GetSubscriptionsWithDueBatches(ctx context.Context, now time.Time) ([]models.WebhookSubscription, error)
 
-	// UpdateSubscription modifies an existing webhook subscription
-	// Allows changes to endpoint URL, event types, security settings, and active status
-	UpdateSubscription(subscription *models.WebhookSubscription) error
+	// Ordered delivery methods for serializing the deliveries of events with the same ordering key
 
-	// DeleteSubscription removes a webhook subscription from the system
-	// Permanently deletes the subscription and stops future event deliveries
-	DeleteSubscription(id uuid.UUID) error
+	// CreateOrderedDelivery queues a delivery behind the subscription's earlier deliveries with the same key
+	CreateOrderedDelivery(ctx context.Context, delivery *models.OrderedDelivery) error
+
+	// ClaimOrderedDelivery claims the oldest delivery of a subscription and key, if it is not being sent
+	// Claims older than staleBefore are taken over; returns nil without an error when none can be claimed
+	ClaimOrderedDelivery(ctx context.Context, subscriptionID uuid.UUID, orderingKey string, now, staleBefore time.Time) (*models.OrderedDelivery, error)
+
+	// ReleaseOrderedDelivery gives up the claim on a delivery that was not sent, so it can be claimed again
+	ReleaseOrderedDelivery(ctx context.Context, id uuid.UUID) error
+
+	// DeleteOrderedDelivery removes an ordered delivery once it has been sent, letting the next one go
+	DeleteOrderedDelivery(ctx context.Context, id uuid.UUID) error
+
+	// CountOrderedDeliveriesByEvent counts the deliveries of an event still waiting for their turn
+	CountOrderedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error)
+
+	// GetStalledOrderedDeliveries retrieves the oldest delivery of each ordering key of the unpaused
+	// subscriptions that nobody is sending: unclaimed, or claimed before staleBefore
+	GetStalledOrderedDeliveries(ctx context.Context, staleBefore time.Time) ([]models.OrderedDelivery, error)
+
+	// Event type methods for the tenant event catalog
+
+	// CreateEventType registers an event type
+	CreateEventType(ctx context.Context, eventType *models.EventType) error
+
+	// GetEventTypeByID retrieves an event type by its unique identifier
+	GetEventTypeByID(ctx context.Context, id uuid.UUID) (*models.EventType, error)
+
+	// GetEventTypeByName retrieves the event type a tenant registered for an event name
+	// Returns nil without an error when the event has no registered type, as most events do not
+	GetEventTypeByName(ctx context.Context, tenantID, name string) (*models.EventType, error)
+
+	// GetEventTypesByTenant retrieves every event type of a tenant, ordered by name
+	GetEventTypesByTenant(ctx context.Context, tenantID string) ([]models.EventType, error)
+
+	// UpdateEventType saves changes to an event type
+	UpdateEventType(ctx context.Context, eventType *models.EventType) error
+
+	// DeleteEventType removes an event type; events with its name are delivered without validation afterwards
+	DeleteEventType(ctx context.Context, id uuid.UUID) error
+
+	// Delivery attempt methods for delivery statistics
+
+	// CreateDeliveryAttempts records the HTTP attempts of one or more deliveries
+	CreateDeliveryAttempts(ctx context.Context, attempts []*models.WebhookDeliveryAttempt) error
+
+	// GetDeliveryStats aggregates the delivery attempts of a tenant, or of one of its webhooks, made since the given time
+	// Computed by the database; the response's metadata and success rates are left for the caller to fill
+	GetDeliveryStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, bucket time.Duration) (*models.DeliveryStatsResponse, error)
+
+	// GetLatencySLOStats measures the end-to-end latency of a tenant's successful deliveries made since the given
+	// time against a target, overall and per subscription; compliance and the report's metadata are left for
+	// the caller to fill
+	GetLatencySLOStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, targetMs int64) (*models.LatencySLOReport, error)
 
 	// Event management methods for webhook delivery tracking and retry logic
 
 	// CreateEvent records a new webhook event for delivery processing
 	// Initiates the webhook delivery pipeline with event metadata and payload
-	CreateEvent(event *models.WebhookEvent) error
+	CreateEvent(ctx context.Context, event *models.WebhookEvent) error
 
 	// GetEventByID retrieves a specific webhook event by its unique identifier
 	// Used for event status checking and delivery result analysis
-	GetEventByID(id uuid.UUID) (*models.WebhookEvent, error)
+	GetEventByID(ctx context.Context, id uuid.UUID) (*models.WebhookEvent, error)
 
 	// UpdateEvent modifies webhook event fields during delivery processing
 	// Updates delivery status, retry count, error information, and completion timestamps
-	UpdateEvent(event *models.WebhookEvent) error
+	UpdateEvent(ctx context.Context, event *models.WebhookEvent) error
 
 	// GetEventsByStatus retrieves webhook events filtered by delivery status
 	// Essential for retry processing and delivery queue management
-	GetEventsByStatus(status models.WebhookStatus, limit int) ([]models.WebhookEvent, error)
+	GetEventsByStatus(ctx context.Context, status models.WebhookStatus, limit int) ([]models.WebhookEvent, error)
+
+	// CountEventsSince counts the events of a type a tenant has sent since a point in time
+	// Used to measure the traffic volume behind a subscription
+	CountEventsSince(ctx context.Context, tenantID, event string, since time.Time) (int64, error)
+
+	// GetDueScheduledEvents retrieves scheduled events whose delivery time has been reached
+	// Returns events highest priority first, in delivery order within a priority, for the event scheduler
+	GetDueScheduledEvents(ctx context.Context, now time.Time, limit int) ([]models.WebhookEvent, error)
+
+	// GetScheduledEventsDueBy pages through the IDs and delivery times of scheduled events due by a time
+	// Events are ordered by ID and start after the given ID; used to hand scheduled events to a work queue
+	GetScheduledEventsDueBy(ctx context.Context, until time.Time, after uuid.UUID, limit int) ([]models.WebhookEvent, error)
+
+	// ClaimScheduledEvent moves a scheduled event to pending if it is still scheduled
+	// Ensures a scheduled event is delivered once when several instances run the scheduler
+	ClaimScheduledEvent(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// Delivery pause methods for holding back outbound deliveries during maintenance windows
+
+	// SaveDeliveryPause creates or replaces the delivery pause of a scope
+	// The scope is a tenant ID, or models.DeliveryPauseGlobal for every tenant
+	SaveDeliveryPause(ctx context.Context, pause *models.DeliveryPause) error
+
+	// GetDeliveryPauses retrieves every delivery pause, paused or draining
+	// Read before each delivery decision, so it is expected to be a small table
+	GetDeliveryPauses(ctx context.Context) ([]models.DeliveryPause, error)
+
+	// DeleteResumedDeliveryPause removes the resumed delivery pause of a scope once its held events are drained
+	// A pause that was paused again in the meantime is kept
+	DeleteResumedDeliveryPause(ctx context.Context, scope string) error
+
+	// GetHeldEvents retrieves the events held back by a delivery pause, highest priority first and oldest first
+	// within a priority; an empty tenantID returns the events of every tenant not in excludeTenants
+	GetHeldEvents(ctx context.Context, tenantID string, excludeTenants []string, limit int) ([]models.WebhookEvent, error)
+
+	// CountHeldEvents counts the events held back by a delivery pause, selected like GetHeldEvents
+	CountHeldEvents(ctx context.Context, tenantID string, excludeTenants []string) (int64, error)
+
+	// ReleaseHeldEvent clears the hold of an event if it is still held
+	// Ensures a held event is delivered once when several instances drain a pause
+	ReleaseHeldEvent(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
 // webhookRepository implements WebhookRepository interface
@@ -63,13 +226,16 @@ type webhookRepository struct {
 	// db is the GORM database instance for executing queries
 	// Provides transaction support and advanced querying capabilities
 	db *gorm.DB
+
+	// replicas serve subscription listings and delivery statistics
+	replicas *ReadReplicas
 }
 
 // NewWebhookRepository creates a new webhook repository instance
-// Factory function that initializes the repository with a database connection
+// Factory function that initializes the repository with a database connection and optional read replicas
 // Returns: WebhookRepository interface implementation for dependency injection
-func NewWebhookRepository(db *gorm.DB) WebhookRepository {
-	return &webhookRepository{db: db}
+func NewWebhookRepository(db *gorm.DB, replicas *ReadReplicas) WebhookRepository {
+	return &webhookRepository{db: db, replicas: replicas}
 }
 
 // Subscription operations - Methods for managing webhook endpoint registrations
@@ -77,22 +243,24 @@ func NewWebhookRepository(db *gorm.DB) WebhookRepository {
 // CreateSubscription registers a new webhook subscription in the database
 // Establishes a webhook endpoint to receive notifications for specific event types
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - subscription: WebhookSubscription model with endpoint URL, events, and security config
 //
 // Returns: error if creation fails, nil on success
-func (r *webhookRepository) CreateSubscription(subscription *models.WebhookSubscription) error {
-	return r.db.Create(subscription).Error
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
 }
 
 // GetSubscriptionByID retrieves a specific webhook subscription by unique identifier
 // Used for subscription verification, configuration retrieval, and access control
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - id: UUID of the webhook subscription to retrieve
 //
 // Returns: WebhookSubscription pointer if found, error if not found or query fails
-func (r *webhookRepository) GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error) {
+func (r *webhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
 	var subscription models.WebhookSubscription
-	err := r.db.Where("id = ?", id).First(&subscription).Error
+	err := scopeTenant(ctx, r.db.WithContext(ctx)).Where("id = ?", id).First(&subscription).Error
 	if err != nil {
 		return nil, err
 	}
@@ -102,13 +270,14 @@ func (r *webhookRepository) GetSubscriptionByID(id uuid.UUID) (*models.WebhookSu
 // GetActiveSubscriptionsByTenantAndEvent finds active subscriptions for event delivery
 // Critical method for webhook delivery pipeline to determine notification targets
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - tenantID: Tenant identifier to scope subscription search
 //   - event: Event type that needs to be delivered to subscribed endpoints
 //
 // Returns: Slice of active WebhookSubscriptions, error if query fails
-func (r *webhookRepository) GetActiveSubscriptionsByTenantAndEvent(tenantID, event string) ([]models.WebhookSubscription, error) {
+func (r *webhookRepository) GetActiveSubscriptionsByTenantAndEvent(ctx context.Context, tenantID, event string) ([]models.WebhookSubscription, error) {
 	var subscriptions []models.WebhookSubscription
-	err := r.db.Where("tenant_id = ? AND subscribed_event = ? AND is_active = ?",
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND subscribed_event = ? AND is_active = ?",
 		tenantID, event, true).Find(&subscriptions).Error
 	return subscriptions, err
 }
@@ -116,48 +285,679 @@ func (r *webhookRepository) GetActiveSubscriptionsByTenantAndEvent(tenantID, eve
 // GetSubscriptionsByTenant retrieves all webhook subscriptions for a tenant with pagination
 // Provides subscription management dashboard data with total count for pagination
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - tenantID: Tenant identifier to filter subscriptions
-//   - offset: Number of records to skip for pagination
-//   - limit: Maximum number of records to return
+//   - filter: Narrows the subscriptions by activity, type, subscribed event and creation time
+//   - page: Page of the subscriptions, sorted by creation time or app name
 //
-// Returns: Slice of WebhookSubscriptions, total count, error if query fails
-func (r *webhookRepository) GetSubscriptionsByTenant(tenantID string, offset, limit int) ([]models.WebhookSubscription, int64, error) {
+// Returns: Slice of WebhookSubscriptions, total count of the filtered subscriptions, error if query fails
+func (r *webhookRepository) GetSubscriptionsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page Page) ([]models.WebhookSubscription, int64, error) {
+	db := r.replicas.DB(r.db)
 	var subscriptions []models.WebhookSubscription
 	var total int64
+	query := func() *gorm.DB {
+		return filterList(db.WithContext(ctx).Model(&models.WebhookSubscription{}).Where("tenant_id = ?", tenantID), filter, subscriptionListColumns)
+	}
 
 	// Get total count
-	if err := r.db.Model(&models.WebhookSubscription{}).Where("tenant_id = ?", tenantID).Count(&total).Error; err != nil {
+	if err := query().Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err := r.db.Where("tenant_id = ?", tenantID).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
+	err := paginate(query(), page).
 		Find(&subscriptions).Error
 
 	return subscriptions, total, err
 }
 
 // UpdateSubscription modifies an existing webhook subscription
-// Allows changes to endpoint configuration, event types, and security settings
+// Allows changes to endpoint configuration, event types, and security settings. Every column is written but
+// paused_until, which receivers set concurrently; the row is only written while it is still at the
+// subscription's lock version, which is then incremented
 // Parameters:
-//   - subscription: WebhookSubscription model with updated fields
+//   - ctx: Context for request cancellation and timeout control
+//   - subscription: WebhookSubscription model with updated fields, as read at its lock version
 //
-// Returns: error if update fails, nil on success
-func (r *webhookRepository) UpdateSubscription(subscription *models.WebhookSubscription) error {
-	return r.db.Save(subscription).Error
+// Returns: ErrVersionConflict if the subscription was changed since it was read, nil on success
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	lockVersion := subscription.LockVersion
+	subscription.LockVersion++
+	err := lockedUpdate(r.db.WithContext(ctx).Model(subscription).
+		Select("*").Omit("id", "created_at", "paused_until").
+		Where("lock_version = ?", lockVersion).
+		Updates(subscription))
+	if err != nil {
+		subscription.LockVersion = lockVersion
+	}
+	return err
 }
 
-// DeleteSubscription permanently removes a webhook subscription
+// DeleteSubscription soft deletes a webhook subscription by setting its deleted_at
 // Stops future event deliveries to the associated endpoint
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - id: UUID of the webhook subscription to delete
 //
 // Returns: error if deletion fails, nil on success
-func (r *webhookRepository) DeleteSubscription(id uuid.UUID) error {
-	return r.db.Delete(&models.WebhookSubscription{}, id).Error
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return scopedWrite(ctx, scopeTenant(ctx, r.db.WithContext(ctx)).Where("id = ?", id).Delete(&models.WebhookSubscription{}))
+}
+
+// RestoreSubscription restores a soft deleted webhook subscription by clearing its deleted_at
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the deleted webhook subscription
+//
+// Returns: gorm.ErrRecordNotFound if no deleted subscription has the ID, nil on success
+func (r *webhookRepository) RestoreSubscription(ctx context.Context, id uuid.UUID) error {
+	return restoreDeleted(scopeTenant(ctx, r.db.WithContext(ctx)), &models.WebhookSubscription{}, id)
+}
+
+// restoreDeleted clears deleted_at of a soft deleted row of a model's table
+// Returns gorm.ErrRecordNotFound when no deleted row has the ID
+func restoreDeleted(db *gorm.DB, model interface{}, id uuid.UUID) error {
+	result := db.Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetSubscriptionPause sets or clears the time until which deliveries to a subscription are paused
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the webhook subscription
+//   - pausedUntil: End of the pause, nil to clear it
+//
+// Returns: error if update fails, nil on success
+func (r *webhookRepository) SetSubscriptionPause(ctx context.Context, id uuid.UUID, pausedUntil *time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookSubscription{}).
+		Where("id = ?", id).
+		Update("paused_until", pausedUntil).Error
+}
+
+// GetSubscriptionsPausedUntil retrieves the paused subscriptions whose pause ended at or before a point in time
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - until: Subscriptions paused until this time or earlier are returned
+//
+// Returns: Slice of WebhookSubscriptions whose pause has ended, error if query fails
+func (r *webhookRepository) GetSubscriptionsPausedUntil(ctx context.Context, until time.Time) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.WithContext(ctx).
+		Where("paused_until IS NOT NULL AND paused_until <= ?", until).
+		Order("paused_until ASC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// failingAttempts selects the final delivery attempts of a subscription counted by the auto-disable policy: those
+// made since the window start, or since the subscription was last enabled again if later
+func failingAttempts(db *gorm.DB) string {
+	return `FROM "webhook_delivery_attempts" AS "a"
+	WHERE "a"."webhook_id" = "webhook_subscriptions"."id" AND "a"."final"
+		AND "a"."created_at" >= ` + greatest(db, "@since", `COALESCE("webhook_subscriptions"."reenabled_at", @since)`)
+}
+
+// GetFailingSubscriptions retrieves the active subscriptions of a tenant whose final delivery attempts since a
+// point in time include at least failures failed ones and no successful one
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//   - since: Start of the window failures are counted in
+//   - failures: Minimum number of failed deliveries
+//
+// Returns: Slice of failing WebhookSubscriptions, error if query fails
+func (r *webhookRepository) GetFailingSubscriptions(ctx context.Context, tenantID string, since time.Time, failures int) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	attempts := failingAttempts(r.db)
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND is_active = ?", tenantID, true).
+		Where(`(SELECT COUNT(*) `+attempts+` AND NOT "a"."success") >= @failures`+
+			` AND NOT EXISTS (SELECT 1 `+attempts+` AND "a"."success")`,
+			map[string]interface{}{"since": since, "failures": failures}).
+		Order("created_at ASC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// DisableSubscription deactivates an active subscription, recording when and why
+// Only the activation columns are written, so concurrent configuration changes are not overwritten
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the webhook subscription
+//   - disabledAt: Time the subscription was deactivated
+//   - reason: Why the subscription was deactivated
+//
+// Returns: false if the subscription was not active, error if update fails
+func (r *webhookRepository) DisableSubscription(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.WebhookSubscription{}).
+		Where("id = ? AND is_active = ?", id, true).
+		Updates(withLockVersion(map[string]interface{}{
+			"is_active":       false,
+			"disabled_at":     disabledAt,
+			"disabled_reason": reason,
+		}))
+	return result.RowsAffected > 0, result.Error
+}
+
+// EnableSubscription activates a subscription again and clears why it was deactivated
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the webhook subscription
+//   - enabledAt: Time the subscription was enabled again
+//
+// Returns: error if update fails, nil on success
+func (r *webhookRepository) EnableSubscription(ctx context.Context, id uuid.UUID, enabledAt time.Time) error {
+	return scopedWrite(ctx, scopeTenant(ctx, r.db.WithContext(ctx).Model(&models.WebhookSubscription{})).
+		Where("id = ?", id).
+		Updates(withLockVersion(map[string]interface{}{
+			"is_active":       true,
+			"disabled_at":     nil,
+			"disabled_reason": "",
+			"reenabled_at":    enabledAt,
+		})))
+}
+
+// Queued delivery operations - Methods for holding back deliveries to paused subscriptions
+
+// CreateQueuedDelivery queues a delivery until its subscription's pause ends
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - delivery: QueuedDelivery model with subscription, event and payload
+//
+// Returns: error if creation fails, nil on success
+func (r *webhookRepository) CreateQueuedDelivery(ctx context.Context, delivery *models.QueuedDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+// GetQueuedDeliveries retrieves the queued deliveries of a subscription
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - subscriptionID: UUID of the paused subscription
+//
+// Returns: Queued deliveries oldest first, error if query fails
+func (r *webhookRepository) GetQueuedDeliveries(ctx context.Context, subscriptionID uuid.UUID) ([]models.QueuedDelivery, error) {
+	var deliveries []models.QueuedDelivery
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at ASC").
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// DeleteQueuedDelivery removes a queued delivery once it has been sent
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the queued delivery
+//
+// Returns: error if deletion fails, nil on success
+func (r *webhookRepository) DeleteQueuedDelivery(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.QueuedDelivery{}, id).Error
+}
+
+// CountQueuedDeliveriesByEvent counts the deliveries of an event that are still queued
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - eventID: UUID of the webhook event
+//
+// Returns: Number of queued deliveries, error if query fails
+func (r *webhookRepository) CountQueuedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.QueuedDelivery{}).
+		Where("event_id = ?", eventID).
+		Count(&count).Error
+	return count, err
+}
+
+// Batched delivery operations - Methods for buffering deliveries to batching subscriptions
+
+// CreateBatchedDelivery buffers a delivery for its subscription's next batch
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - delivery: BatchedDelivery model with subscription, event and payload
+//
+// Returns: error if creation fails, nil on success
+func (r *webhookRepository) CreateBatchedDelivery(ctx context.Context, delivery *models.BatchedDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+// GetBatchedDeliveries retrieves the oldest buffered deliveries of a subscription
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - subscriptionID: UUID of the batching subscription
+//   - limit: Maximum number of deliveries, the subscription's batch size
+//
+// Returns: Buffered deliveries oldest first, error if query fails
+func (r *webhookRepository) GetBatchedDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.BatchedDelivery, error) {
+	var deliveries []models.BatchedDelivery
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// DeleteBatchedDeliveries removes buffered deliveries once their batch has been sent
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - ids: UUIDs of the buffered deliveries
+//
+// Returns: error if deletion fails, nil on success
+func (r *webhookRepository) DeleteBatchedDeliveries(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&models.BatchedDelivery{}).Error
+}
+
+// CountBatchedDeliveriesByEvent counts the deliveries of an event that are still buffered
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - eventID: UUID of the webhook event
+//
+// Returns: Number of buffered deliveries, error if query fails
+func (r *webhookRepository) CountBatchedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.BatchedDelivery{}).
+		Where("event_id = ?", eventID).
+		Count(&count).Error
+	return count, err
+}
+
+// GetSubscriptionsWithDueBatches retrieves the batching subscriptions whose next batch is due
+// A batch is due once the subscription's batch size is buffered or its oldest delivery has waited the
+// batch window, at once for subscriptions that stopped batching; paused subscriptions are left until their
+// pause is released
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - now: Point in time the batch windows are measured at
+//
+// Returns: Subscriptions with a due batch, error if query fails
+func (r *webhookRepository) GetSubscriptionsWithDueBatches(ctx context.Context, now time.Time) ([]models.WebhookSubscription, error) {
+	windowEnded := `b.created_at <= CAST(@now AS timestamptz) - make_interval(secs => "webhook_subscriptions".batch_window_seconds)`
+	if isSQLite(r.db) {
+		windowEnded = `julianday(b.created_at) <= julianday(@now) - "webhook_subscriptions".batch_window_seconds / 86400.0`
+	}
+
+	var subscriptions []models.WebhookSubscription
+	err := r.db.WithContext(ctx).
+		Where("paused_until IS NULL").
+		Where(`(EXISTS (SELECT 1 FROM "batched_deliveries" b WHERE b.subscription_id = "webhook_subscriptions".id
+	AND `+windowEnded+`)
+	OR (SELECT count(*) FROM "batched_deliveries" b WHERE b.subscription_id = "webhook_subscriptions".id)
+	>= COALESCE(NULLIF("webhook_subscriptions".batch_max_events, 0), @max_events))`,
+			map[string]interface{}{"now": now, "max_events": models.MaxBatchEvents}).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// Ordered delivery operations - Methods for serializing the deliveries of events with the same ordering key
+
+// CreateOrderedDelivery queues a delivery behind the subscription's earlier deliveries with the same key
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - delivery: OrderedDelivery model with subscription, event, ordering key and payload
+//
+// Returns: error if creation fails, nil on success
+func (r *webhookRepository) CreateOrderedDelivery(ctx context.Context, delivery *models.OrderedDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+// ClaimOrderedDelivery claims the oldest delivery of a subscription and ordering key
+// The conditional update lets only one instance send it; deliveries behind it cannot be claimed until it is
+// deleted, so the key's deliveries are sent one at a time in order
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - subscriptionID: UUID of the subscription
+//   - orderingKey: Ordering key of the deliveries
+//   - now: Time recorded as the claim
+//   - staleBefore: Claims older than this are taken over, as their sender is presumed gone
+//
+// Returns: The claimed delivery, nil if there is none or it is being sent, error if the update fails
+func (r *webhookRepository) ClaimOrderedDelivery(ctx context.Context, subscriptionID uuid.UUID, orderingKey string, now, staleBefore time.Time) (*models.OrderedDelivery, error) {
+	var deliveries []models.OrderedDelivery
+	err := r.db.WithContext(ctx).Raw(`UPDATE "ordered_deliveries" SET "claimed_at" = @now
+	WHERE "id" = (SELECT o.id FROM "ordered_deliveries" o WHERE o.subscription_id = @subscription AND o.ordering_key = @key
+		ORDER BY o.created_at, o.id LIMIT 1)
+	AND ("claimed_at" IS NULL OR "claimed_at" < @stale_before)
+	RETURNING *`, map[string]interface{}{
+		"now":          now,
+		"subscription": subscriptionID,
+		"key":          orderingKey,
+		"stale_before": staleBefore,
+	}).Scan(&deliveries).Error
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+// ReleaseOrderedDelivery clears the claim on an ordered delivery that was not sent
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the ordered delivery
+//
+// Returns: error if the update fails, nil on success
+func (r *webhookRepository) ReleaseOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.OrderedDelivery{}).
+		Where("id = ?", id).
+		Update("claimed_at", nil).Error
+}
+
+// DeleteOrderedDelivery removes an ordered delivery once it has been sent
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the ordered delivery
+//
+// Returns: error if deletion fails, nil on success
+func (r *webhookRepository) DeleteOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.OrderedDelivery{}, "id = ?", id).Error
+}
+
+// CountOrderedDeliveriesByEvent counts the deliveries of an event still waiting for their turn
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - eventID: UUID of the webhook event
+//
+// Returns: Number of ordered deliveries, error if query fails
+func (r *webhookRepository) CountOrderedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.OrderedDelivery{}).
+		Where("event_id = ?", eventID).
+		Count(&count).Error
+	return count, err
+}
+
+// GetStalledOrderedDeliveries retrieves the ordered deliveries at the head of their queue that nobody sends
+// Those are left when a sender stopped for a receiver's pause, or went away before or while sending;
+// queues of paused subscriptions are left until the pause is released
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - staleBefore: Claims older than this count as abandoned
+//
+// Returns: The oldest delivery of each stalled subscription and ordering key, error if query fails
+func (r *webhookRepository) GetStalledOrderedDeliveries(ctx context.Context, staleBefore time.Time) ([]models.OrderedDelivery, error) {
+	var deliveries []models.OrderedDelivery
+	err := r.db.WithContext(ctx).Raw(`SELECT h.* FROM "ordered_deliveries" h JOIN (
+	SELECT o.id, ROW_NUMBER() OVER (PARTITION BY o.subscription_id, o.ordering_key ORDER BY o.created_at, o.id) AS position
+	FROM "ordered_deliveries" o
+	JOIN "webhook_subscriptions" s ON s.id = o.subscription_id AND s.deleted_at IS NULL AND s.paused_until IS NULL
+) q ON q.id = h.id AND q.position = 1
+WHERE h.claimed_at IS NULL OR h.claimed_at < ?`, staleBefore).Scan(&deliveries).Error
+	return deliveries, err
+}
+
+// Event type operations - Methods for the tenant event catalog
+
+// CreateEventType registers an event type
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - eventType: EventType model with tenant, name and optional schema
+//
+// Returns: error if creation fails, e.g. when the tenant already registered the name
+func (r *webhookRepository) CreateEventType(ctx context.Context, eventType *models.EventType) error {
+	return r.db.WithContext(ctx).Create(eventType).Error
+}
+
+// GetEventTypeByID retrieves an event type by its unique identifier
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the event type
+//
+// Returns: EventType pointer, error if not found or query fails
+func (r *webhookRepository) GetEventTypeByID(ctx context.Context, id uuid.UUID) (*models.EventType, error) {
+	var eventType models.EventType
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&eventType).Error; err != nil {
+		return nil, err
+	}
+	return &eventType, nil
+}
+
+// GetEventTypeByName retrieves the event type a tenant registered for an event name
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//   - name: Event name
+//
+// Returns: EventType pointer, nil when none is registered, error if query fails
+func (r *webhookRepository) GetEventTypeByName(ctx context.Context, tenantID, name string) (*models.EventType, error) {
+	var eventType models.EventType
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND name = ?", tenantID, name).First(&eventType).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &eventType, nil
+}
+
+// GetEventTypesByTenant retrieves every event type of a tenant
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier
+//
+// Returns: Event types ordered by name, error if query fails
+func (r *webhookRepository) GetEventTypesByTenant(ctx context.Context, tenantID string) ([]models.EventType, error) {
+	var eventTypes []models.EventType
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&eventTypes).Error
+	return eventTypes, err
+}
+
+// UpdateEventType saves changes to an event type
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - eventType: EventType model with the new definition
+//
+// Returns: error if the update fails, nil on success
+func (r *webhookRepository) UpdateEventType(ctx context.Context, eventType *models.EventType) error {
+	return r.db.WithContext(ctx).Save(eventType).Error
+}
+
+// DeleteEventType removes an event type
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the event type
+//
+// Returns: error if deletion fails, nil on success
+func (r *webhookRepository) DeleteEventType(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.EventType{}, id).Error
+}
+
+// Delivery attempt operations - Methods for recording and aggregating delivery attempts
+
+// deliveryStatsColumns selects the attempt counts and latency percentiles of a group of attempts
+func deliveryStatsColumns(db *gorm.DB) string {
+	responded := "FILTER (WHERE response_code IS NOT NULL)"
+	return "COUNT(*) AS attempts, " +
+		"COUNT(*) FILTER (WHERE final) AS deliveries, " +
+		"COUNT(*) FILTER (WHERE final AND success) AS delivered, " +
+		percentileCont(db, "0.5", "latency_ms", responded) + " AS p50_latency_ms, " +
+		percentileCont(db, "0.95", "latency_ms", responded) + " AS p95_latency_ms"
+}
+
+// deliveryBucketStart returns the SQL expression for the start of the time bucket of an attempt in Unix seconds;
+// it takes the bucket length in seconds twice
+func deliveryBucketStart(db *gorm.DB) string {
+	if isSQLite(db) {
+		return "CAST(strftime('%s', created_at) AS INTEGER) / ? * ? AS bucket_start"
+	}
+	return "CAST(floor(extract(epoch from created_at) / ?) * ? AS bigint) AS bucket_start"
+}
+
+// deliveryAttemptBatchSize bounds the attempts of one insert, keeping its bind parameters below the Postgres limit
+const deliveryAttemptBatchSize = 500
+
+// CreateDeliveryAttempts records HTTP delivery attempts in inserts of up to deliveryAttemptBatchSize rows
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - attempts: Attempts of one or more deliveries, each delivery's in the order they were made
+//
+// Returns: error if creation fails, nil on success
+func (r *webhookRepository) CreateDeliveryAttempts(ctx context.Context, attempts []*models.WebhookDeliveryAttempt) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(attempts, deliveryAttemptBatchSize).Error
+}
+
+// GetDeliveryStats aggregates delivery attempts overall, per time bucket, per error class and per status code
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant whose attempts to aggregate
+//   - webhookID: Subscription to restrict the attempts to, nil for every subscription of the tenant
+//   - since: Earliest time of the attempts to include, nil to include every attempt
+//   - bucket: Length of the time buckets
+//
+// Returns: Aggregated statistics with buckets oldest first, error if any query fails
+func (r *webhookRepository) GetDeliveryStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, bucket time.Duration) (*models.DeliveryStatsResponse, error) {
+	db := r.replicas.DB(r.db)
+	attempts := func() *gorm.DB {
+		query := db.WithContext(ctx).Model(&models.WebhookDeliveryAttempt{}).Where("tenant_id = ?", tenantID)
+		if webhookID != nil {
+			query = query.Where("webhook_id = ?", *webhookID)
+		}
+		if since != nil {
+			query = query.Where("created_at >= ?", *since)
+		}
+		return query
+	}
+	bucketSeconds := int64(bucket / time.Second)
+	statsColumns := deliveryStatsColumns(db)
+	bucketStart := deliveryBucketStart(db)
+
+	stats := &models.DeliveryStatsResponse{
+		FailuresByStatusCode: map[int]int64{},
+		FailuresByClass:      map[models.DeliveryErrorClass]int64{},
+		Buckets:              []models.DeliveryStatsBucket{},
+	}
+	if err := attempts().Select(statsColumns).Scan(&stats.DeliveryStats).Error; err != nil {
+		return nil, err
+	}
+
+	var buckets []struct {
+		BucketStart int64
+		models.DeliveryStats
+	}
+	if err := attempts().
+		Select(bucketStart+", "+statsColumns, bucketSeconds, bucketSeconds).
+		Group("bucket_start").
+		Order("bucket_start ASC").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	bucketIndex := make(map[int64]int, len(buckets))
+	for i, row := range buckets {
+		bucketIndex[row.BucketStart] = i
+		stats.Buckets = append(stats.Buckets, models.DeliveryStatsBucket{
+			Start:           time.Unix(row.BucketStart, 0).UTC(),
+			DeliveryStats:   row.DeliveryStats,
+			FailuresByClass: map[models.DeliveryErrorClass]int64{},
+		})
+	}
+
+	var classes []struct {
+		BucketStart int64
+		ErrorClass  models.DeliveryErrorClass
+		Failures    int64
+	}
+	if err := attempts().
+		Select(bucketStart+", error_class, COUNT(*) AS failures", bucketSeconds, bucketSeconds).
+		Where("NOT success").
+		Group("bucket_start, error_class").
+		Scan(&classes).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range classes {
+		stats.FailuresByClass[row.ErrorClass] += row.Failures
+		if i, ok := bucketIndex[row.BucketStart]; ok {
+			stats.Buckets[i].FailuresByClass[row.ErrorClass] = row.Failures
+		}
+	}
+
+	var codes []struct {
+		ResponseCode int
+		Failures     int64
+	}
+	if err := attempts().
+		Select("response_code, COUNT(*) AS failures").
+		Where("NOT success AND response_code IS NOT NULL").
+		Group("response_code").
+		Scan(&codes).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range codes {
+		stats.FailuresByStatusCode[row.ResponseCode] = row.Failures
+	}
+
+	return stats, nil
+}
+
+// latencySLOColumns selects the delivery count, deliveries within the target and end-to-end latency percentiles of
+// a group of successful attempts; it takes the target in milliseconds
+func latencySLOColumns(db *gorm.DB) string {
+	return "COUNT(*) AS deliveries, " +
+		"COUNT(*) FILTER (WHERE end_to_end_ms <= ?) AS within_target, " +
+		percentileCont(db, "0.5", "end_to_end_ms", "") + " AS p50_ms, " +
+		percentileCont(db, "0.95", "end_to_end_ms", "") + " AS p95_ms, " +
+		percentileCont(db, "0.99", "end_to_end_ms", "") + " AS p99_ms"
+}
+
+// GetLatencySLOStats measures the end-to-end latency of successful deliveries against a target in a single query
+// The overall row, whose webhook_id is NULL, is returned even when no delivery matched
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant whose deliveries to measure
+//   - webhookID: Subscription to restrict the deliveries to, nil for every subscription of the tenant
+//   - since: Earliest time of the attempts to include, nil to include every attempt
+//   - targetMs: End-to-end latency a delivery must not exceed to count as within the target
+//
+// Returns: Report with the overall measurements and one entry per subscription, error if the query fails
+func (r *webhookRepository) GetLatencySLOStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, targetMs int64) (*models.LatencySLOReport, error) {
+	db := r.replicas.DB(r.db)
+	query := func() *gorm.DB {
+		query := db.WithContext(ctx).Model(&models.WebhookDeliveryAttempt{}).
+			Where("tenant_id = ? AND end_to_end_ms IS NOT NULL", tenantID)
+		if webhookID != nil {
+			query = query.Where("webhook_id = ?", *webhookID)
+		}
+		if since != nil {
+			query = query.Where("created_at >= ?", *since)
+		}
+		return query
+	}
+
+	rows, err := scanWithTotal[struct {
+		WebhookID *uuid.UUID
+		models.LatencySLOStats
+	}](query, "webhook_id", "NULL AS webhook_id", latencySLOColumns(db), "webhook_id", targetMs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.LatencySLOReport{Subscriptions: []models.SubscriptionLatencySLO{}}
+	for _, row := range rows {
+		if row.WebhookID == nil {
+			report.LatencySLOStats = row.LatencySLOStats
+			continue
+		}
+		report.Subscriptions = append(report.Subscriptions, models.SubscriptionLatencySLO{
+			WebhookID:       *row.WebhookID,
+			LatencySLOStats: row.LatencySLOStats,
+		})
+	}
+	return report, nil
 }
 
 // Event operations - Methods for managing webhook delivery tracking and processing
@@ -165,22 +965,24 @@ func (r *webhookRepository) DeleteSubscription(id uuid.UUID) error {
 // CreateEvent records a new webhook event for delivery processing
 // Initiates the webhook delivery pipeline with event metadata and payload data
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - event: WebhookEvent model with payload, subscription info, and initial status
 //
 // Returns: error if creation fails, nil on success
-func (r *webhookRepository) CreateEvent(event *models.WebhookEvent) error {
-	return r.db.Create(event).Error
+func (r *webhookRepository) CreateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
 }
 
 // GetEventByID retrieves a specific webhook event by unique identifier
 // Used for event status checking, delivery result analysis, and debugging
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - id: UUID of the webhook event to retrieve
 //
 // Returns: WebhookEvent pointer if found, error if not found or query fails
-func (r *webhookRepository) GetEventByID(id uuid.UUID) (*models.WebhookEvent, error) {
+func (r *webhookRepository) GetEventByID(ctx context.Context, id uuid.UUID) (*models.WebhookEvent, error) {
 	var event models.WebhookEvent
-	err := r.db.Where("id = ?", id).First(&event).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&event).Error
 	if err != nil {
 		return nil, err
 	}
@@ -190,25 +992,190 @@ func (r *webhookRepository) GetEventByID(id uuid.UUID) (*models.WebhookEvent, er
 // UpdateEvent modifies webhook event fields during delivery processing
 // Updates delivery status, retry count, error information, and completion timestamps
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - event: WebhookEvent model with updated delivery status and metadata
 //
 // Returns: error if update fails, nil on success
-func (r *webhookRepository) UpdateEvent(event *models.WebhookEvent) error {
-	return r.db.Save(event).Error
+func (r *webhookRepository) UpdateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	return r.db.WithContext(ctx).Save(event).Error
 }
 
 // GetEventsByStatus retrieves webhook events filtered by delivery status
 // Essential for retry processing, delivery queue management, and failure analysis
 // Parameters:
+//   - ctx: Context for request cancellation and timeout control
 //   - status: WebhookStatus to filter events (pending, delivered, failed, etc.)
 //   - limit: Maximum number of events to return for batch processing
 //
 // Returns: Slice of WebhookEvents matching the status, error if query fails
-func (r *webhookRepository) GetEventsByStatus(status models.WebhookStatus, limit int) ([]models.WebhookEvent, error) {
+func (r *webhookRepository) GetEventsByStatus(ctx context.Context, status models.WebhookStatus, limit int) ([]models.WebhookEvent, error) {
 	var events []models.WebhookEvent
-	err := r.db.Where("status = ?", status).
+	err := r.db.WithContext(ctx).Where("status = ?", status).
 		Order("created_at ASC").
 		Limit(limit).
 		Find(&events).Error
 	return events, err
 }
+
+// CountEventsSince counts the events of a type a tenant has sent since a point in time
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant identifier that sent the events
+//   - event: Event type to count
+//   - since: Only events created at or after this time are counted
+//
+// Returns: Number of matching events, error if query fails
+func (r *webhookRepository) CountEventsSince(ctx context.Context, tenantID, event string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).
+		Where("tenant_id = ? AND event_name = ? AND created_at >= ?", tenantID, event, since).
+		Count(&count).Error
+	return count, err
+}
+
+// GetDueScheduledEvents retrieves scheduled events that are due for delivery
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - now: Current time, events scheduled at or before it are due
+//   - limit: Maximum number of events to return for batch processing
+//
+// Returns: Slice of WebhookEvents ordered by descending priority and delivery time, error if query fails
+func (r *webhookRepository) GetDueScheduledEvents(ctx context.Context, now time.Time, limit int) ([]models.WebhookEvent, error) {
+	var events []models.WebhookEvent
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND deliver_at <= ?", models.WebhookStatusScheduled, now).
+		Order("priority DESC, deliver_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// GetScheduledEventsDueBy retrieves a page of the scheduled events due by a time
+// Only the ID and delivery time of the events are loaded
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - until: Events scheduled at or before it are returned
+//   - after: ID of the last event of the previous page, uuid.Nil for the first page
+//   - limit: Maximum number of events to return
+//
+// Returns: Slice of WebhookEvents ordered by ID, error if query fails
+func (r *webhookRepository) GetScheduledEventsDueBy(ctx context.Context, until time.Time, after uuid.UUID, limit int) ([]models.WebhookEvent, error) {
+	var events []models.WebhookEvent
+	err := r.db.WithContext(ctx).
+		Select("id", "deliver_at").
+		Where("status = ? AND deliver_at <= ? AND id > ?", models.WebhookStatusScheduled, until, after).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// ClaimScheduledEvent moves a scheduled event to pending
+// The conditional update lets only one scheduler instance deliver each scheduled event
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the scheduled webhook event
+//
+// Returns: true if this call claimed the event, error if the update fails
+func (r *webhookRepository) ClaimScheduledEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.WebhookEvent{}).
+		Where("id = ? AND status = ?", id, models.WebhookStatusScheduled).
+		Update("status", models.WebhookStatusPending)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SaveDeliveryPause creates or replaces the delivery pause of a scope
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - pause: Delivery pause to store, keyed by its scope
+//
+// Returns: error if the write fails
+func (r *webhookRepository) SaveDeliveryPause(ctx context.Context, pause *models.DeliveryPause) error {
+	return r.db.WithContext(ctx).Save(pause).Error
+}
+
+// GetDeliveryPauses retrieves every stored delivery pause
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//
+// Returns: Slice of DeliveryPauses ordered by scope, error if query fails
+func (r *webhookRepository) GetDeliveryPauses(ctx context.Context) ([]models.DeliveryPause, error) {
+	var pauses []models.DeliveryPause
+	err := r.db.WithContext(ctx).Order("scope ASC").Find(&pauses).Error
+	return pauses, err
+}
+
+// DeleteResumedDeliveryPause removes the delivery pause of a scope if it was resumed
+// The condition keeps a pause that was paused again since its held events were drained
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - scope: Tenant ID, or models.DeliveryPauseGlobal
+//
+// Returns: error if the delete fails
+func (r *webhookRepository) DeleteResumedDeliveryPause(ctx context.Context, scope string) error {
+	return r.db.WithContext(ctx).Where("scope = ? AND resumed_at IS NOT NULL", scope).Delete(&models.DeliveryPause{}).Error
+}
+
+// heldEvents selects the events held back by a delivery pause of a tenant, or of every tenant not excluded
+func (r *webhookRepository) heldEvents(ctx context.Context, tenantID string, excludeTenants []string) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).Where("held_at IS NOT NULL")
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	if len(excludeTenants) > 0 {
+		query = query.Where("tenant_id NOT IN ?", excludeTenants)
+	}
+	return query
+}
+
+// GetHeldEvents retrieves the events held back by a delivery pause
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant whose events are returned, empty for every tenant
+//   - excludeTenants: Tenants whose events are not returned
+//   - limit: Maximum number of events to return
+//
+// Returns: Slice of WebhookEvents ordered by descending priority and creation time, error if query fails
+func (r *webhookRepository) GetHeldEvents(ctx context.Context, tenantID string, excludeTenants []string, limit int) ([]models.WebhookEvent, error) {
+	var events []models.WebhookEvent
+	err := r.heldEvents(ctx, tenantID, excludeTenants).
+		Order("priority DESC, created_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// CountHeldEvents counts the events held back by a delivery pause
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - tenantID: Tenant whose events are counted, empty for every tenant
+//   - excludeTenants: Tenants whose events are not counted
+//
+// Returns: Number of held events, error if query fails
+func (r *webhookRepository) CountHeldEvents(ctx context.Context, tenantID string, excludeTenants []string) (int64, error) {
+	var count int64
+	err := r.heldEvents(ctx, tenantID, excludeTenants).Count(&count).Error
+	return count, err
+}
+
+// ReleaseHeldEvent clears the hold of a held event
+// The conditional update lets only one instance deliver each held event
+// Parameters:
+//   - ctx: Context for request cancellation and timeout control
+//   - id: UUID of the held webhook event
+//
+// Returns: true if this call released the event, error if the update fails
+func (r *webhookRepository) ReleaseHeldEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.WebhookEvent{}).
+		Where("id = ? AND held_at IS NOT NULL", id).
+		Update("held_at", nil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Write batching defaults, see WriteBatchConfig
+const (
+	DefaultWriteBatchInterval = time.Second
+	DefaultWriteBatchMaxRows  = 1000
+)
+
+// WriteBatchConfig bounds how long and how many writes a WriteBatcher buffers
+type WriteBatchConfig struct {
+	// Interval is how often buffered writes are flushed
+	Interval time.Duration
+
+	// MaxRows is how many buffered rows start a flush before the interval is over
+	MaxRows int
+}
+
+// DefaultWriteBatchConfig returns the default write batching settings
+func DefaultWriteBatchConfig() WriteBatchConfig {
+	return WriteBatchConfig{
+		Interval: DefaultWriteBatchInterval,
+		MaxRows:  DefaultWriteBatchMaxRows,
+	}
+}
+
+// WriteBatcher buffers the writes every delivery and chain step makes, and flushes them together, so an event
+// fanned out to many subscriptions costs a few batched inserts instead of an insert per delivery
+// Delivery attempts and step runs created finished, such as skipped steps, are inserted in batches; the progress
+// updates of retried steps are merged per step run and applied in one transaction. A step run's final update,
+// and reads and status changes of a run made through the batcher, flush the writes they depend on first, so
+// this instance reads its own writes; other instances see them up to an interval later
+type WriteBatcher struct {
+	webhooks WebhookRepository
+	chains   ExecutionChainRepository
+	config   WriteBatchConfig
+	logger   logging.Logger
+	full     chan struct{}
+
+	// flushing serializes the writes of flushes and final step run updates, so a merged progress update
+	// taken from the buffer is never applied after the final update of its step run
+	flushing sync.Mutex
+
+	mu       sync.Mutex
+	closed   bool
+	rows     int
+	attempts []*models.WebhookDeliveryAttempt
+	created  map[uuid.UUID][]*models.ExecutionChainStepRun
+	updates  map[uuid.UUID]map[string]interface{}
+}
+
+// NewWriteBatcher creates a write batcher flushing to the given repositories; Run must be started for the
+// buffered writes to be flushed, and Close called on shutdown to flush the last ones
+func NewWriteBatcher(webhooks WebhookRepository, chains ExecutionChainRepository, config WriteBatchConfig, logger logging.Logger) *WriteBatcher {
+	if config.Interval <= 0 {
+		config.Interval = DefaultWriteBatchInterval
+	}
+	if config.MaxRows <= 0 {
+		config.MaxRows = DefaultWriteBatchMaxRows
+	}
+	return &WriteBatcher{
+		webhooks: webhooks,
+		chains:   chains,
+		config:   config,
+		logger:   logger,
+		full:     make(chan struct{}, 1),
+		created:  make(map[uuid.UUID][]*models.ExecutionChainStepRun),
+		updates:  make(map[uuid.UUID]map[string]interface{}),
+	}
+}
+
+// Webhooks returns the webhook repository whose delivery attempts are buffered
+func (b *WriteBatcher) Webhooks() WebhookRepository {
+	return &batchingWebhookRepository{WebhookRepository: b.webhooks, batcher: b}
+}
+
+// Chains returns the chain repository whose step runs are buffered
+func (b *WriteBatcher) Chains() ExecutionChainRepository {
+	return &batchingExecutionChainRepository{ExecutionChainRepository: b.chains, batcher: b}
+}
+
+// Run flushes the buffered writes every interval, and as soon as MaxRows are buffered, until ctx is done
+func (b *WriteBatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-b.full:
+		}
+		b.flush(ctx, nil)
+	}
+}
+
+// Close flushes the buffered writes; writes made after it are written through
+func (b *WriteBatcher) Close(ctx context.Context) {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.flush(ctx, nil)
+}
+
+// buffer adds rows to the buffers unless the batcher is closed, and reports whether it did
+func (b *WriteBatcher) buffer(add func() int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return false
+	}
+	b.rows += add()
+	if b.rows >= b.config.MaxRows {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// flush writes the buffered writes, or only those a run depends on: its created step runs and the progress
+// updates of step runs, which are not tracked by run
+// Failures are logged only, as the writes were already acknowledged to their callers
+func (b *WriteBatcher) flush(ctx context.Context, runID *uuid.UUID) {
+	if runID != nil {
+		b.mu.Lock()
+		pending := len(b.created[*runID]) > 0 || len(b.updates) > 0
+		b.mu.Unlock()
+		if !pending {
+			return
+		}
+	}
+
+	b.flushing.Lock()
+	defer b.flushing.Unlock()
+
+	b.mu.Lock()
+	var attempts []*models.WebhookDeliveryAttempt
+	var created []*models.ExecutionChainStepRun
+	if runID == nil {
+		attempts = b.attempts
+		b.attempts = nil
+		for _, stepRuns := range b.created {
+			created = append(created, stepRuns...)
+		}
+		clear(b.created)
+	} else {
+		created = b.created[*runID]
+		delete(b.created, *runID)
+	}
+	updates := b.updates
+	b.updates = make(map[uuid.UUID]map[string]interface{})
+	b.rows -= len(attempts) + len(created) + len(updates)
+	b.mu.Unlock()
+
+	if len(attempts) > 0 {
+		if err := b.webhooks.CreateDeliveryAttempts(ctx, attempts); err != nil {
+			b.logger.Error(ctx, "Failed to flush delivery attempts", zap.Int("attempts", len(attempts)), zap.Error(err))
+		}
+	}
+	if len(created) > 0 {
+		if err := b.chains.CreateStepRuns(ctx, created); err != nil {
+			b.logger.Error(ctx, "Failed to flush step runs", zap.Int("step_runs", len(created)), zap.Error(err))
+		}
+	}
+	if len(updates) > 0 {
+		if err := b.chains.UpdateStepRuns(ctx, updates); err != nil {
+			b.logger.Error(ctx, "Failed to flush step run updates", zap.Int("step_runs", len(updates)), zap.Error(err))
+		}
+	}
+}
+
+// batchingWebhookRepository buffers the delivery attempts written through it in its WriteBatcher
+type batchingWebhookRepository struct {
+	WebhookRepository
+	batcher *WriteBatcher
+}
+
+func (r *batchingWebhookRepository) CreateDeliveryAttempts(ctx context.Context, attempts []*models.WebhookDeliveryAttempt) error {
+	buffered := r.batcher.buffer(func() int {
+		r.batcher.attempts = append(r.batcher.attempts, attempts...)
+		return len(attempts)
+	})
+	if buffered {
+		return nil
+	}
+	return r.WebhookRepository.CreateDeliveryAttempts(ctx, attempts)
+}
+
+// batchingExecutionChainRepository buffers the finished step runs and step run progress written through it in
+// its WriteBatcher, and flushes a run's buffered writes before reading or changing the run
+type batchingExecutionChainRepository struct {
+	ExecutionChainRepository
+	batcher *WriteBatcher
+}
+
+func (r *batchingExecutionChainRepository) CreateStepRun(ctx context.Context, stepRun *models.ExecutionChainStepRun) error {
+	// Unfinished step runs are inserted right away, as they are updated by ID until they finish
+	if stepRun.CompletedAt == nil {
+		return r.ExecutionChainRepository.CreateStepRun(ctx, stepRun)
+	}
+
+	if stepRun.ID == uuid.Nil {
+		stepRun.ID = uuid.New()
+	}
+	buffered := *stepRun
+	if r.batcher.buffer(func() int {
+		r.batcher.created[stepRun.RunID] = append(r.batcher.created[stepRun.RunID], &buffered)
+		return 1
+	}) {
+		return nil
+	}
+	return r.ExecutionChainRepository.CreateStepRun(ctx, stepRun)
+}
+
+func (r *batchingExecutionChainRepository) UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error {
+	// Progress updates are merged into the step run's buffered one
+	if _, final := updates["status"]; !final {
+		if r.batcher.buffer(func() int {
+			pending, ok := r.batcher.updates[stepRunID]
+			if !ok {
+				pending = make(map[string]interface{}, len(updates))
+				r.batcher.updates[stepRunID] = pending
+			}
+			maps.Copy(pending, updates)
+			if ok {
+				return 0
+			}
+			return 1
+		}) {
+			return nil
+		}
+	}
+
+	// A final update is applied with the progress buffered before it
+	r.batcher.flushing.Lock()
+	defer r.batcher.flushing.Unlock()
+	r.batcher.mu.Lock()
+	if pending, ok := r.batcher.updates[stepRunID]; ok {
+		delete(r.batcher.updates, stepRunID)
+		r.batcher.rows--
+		maps.Copy(pending, updates)
+		updates = pending
+	}
+	r.batcher.mu.Unlock()
+	return r.ExecutionChainRepository.UpdateStepRun(ctx, stepRunID, updates)
+}
+
+func (r *batchingExecutionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	r.batcher.flush(ctx, &runID)
+	return r.ExecutionChainRepository.GetChainRunByID(ctx, runID)
+}
+
+func (r *batchingExecutionChainRepository) GetStepRunsByRun(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainStepRun, error) {
+	r.batcher.flush(ctx, &runID)
+	return r.ExecutionChainRepository.GetStepRunsByRun(ctx, runID)
+}
+
+func (r *batchingExecutionChainRepository) UpdateChainRunStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus) error {
+	r.batcher.flush(ctx, &runID)
+	return r.ExecutionChainRepository.UpdateChainRunStatus(ctx, runID, status)
+}
+
+func (r *batchingExecutionChainRepository) UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error {
+	r.batcher.flush(ctx, &runID)
+	return r.ExecutionChainRepository.UpdateChainRun(ctx, runID, updates)
+}
+
+func (r *batchingExecutionChainRepository) UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{}) (bool, error) {
+	r.batcher.flush(ctx, &runID)
+	return r.ExecutionChainRepository.UpdateChainRunIfStatus(ctx, runID, status, updates)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteBatcher_DeliveryAttempts tests that delivery attempts are written when the batcher flushes, and
+// written through once it is closed
+func TestWriteBatcher_DeliveryAttempts(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewMemoryStore()
+	batcher := NewWriteBatcher(NewMemoryWebhookRepository(store), NewMemoryExecutionChainRepository(store), WriteBatchConfig{Interval: time.Hour, MaxRows: 2}, logging.Nop())
+	webhooks := batcher.Webhooks()
+	attempt := func() *models.WebhookDeliveryAttempt {
+		return &models.WebhookDeliveryAttempt{TenantID: "acme", WebhookID: uuid.New(), EventID: uuid.New(), Attempt: 1, Final: true, Success: true}
+	}
+	attempts := func() int64 {
+		stats, err := webhooks.GetDeliveryStats(ctx, "acme", nil, nil, time.Hour)
+		require.NoError(t, err)
+		return stats.Attempts
+	}
+
+	// Act
+	require.NoError(t, webhooks.CreateDeliveryAttempts(ctx, []*models.WebhookDeliveryAttempt{attempt()}))
+	buffered := attempts()
+	require.NoError(t, webhooks.CreateDeliveryAttempts(ctx, []*models.WebhookDeliveryAttempt{attempt()}))
+	_, full := <-batcher.full
+	batcher.Close(ctx)
+	flushed := attempts()
+	require.NoError(t, webhooks.CreateDeliveryAttempts(ctx, []*models.WebhookDeliveryAttempt{attempt()}))
+
+	// Assert
+	assert.Zero(t, buffered)
+	assert.True(t, full)
+	assert.Equal(t, int64(2), flushed)
+	assert.Equal(t, int64(3), attempts())
+}
+
+// TestWriteBatcher_StepRuns tests that a run's buffered step runs and merged progress updates are written
+// before the run is read, and that a final update is applied over the progress buffered before it
+func TestWriteBatcher_StepRuns(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewMemoryStore()
+	next := NewMemoryExecutionChainRepository(store)
+	batcher := NewWriteBatcher(NewMemoryWebhookRepository(store), next, DefaultWriteBatchConfig(), logging.Nop())
+	chains := batcher.Chains()
+	run := &models.ExecutionChainRun{ChainID: uuid.New(), TenantID: "acme", Status: models.ExecutionChainStatusRunning}
+	require.NoError(t, chains.CreateChainRun(ctx, run))
+	now := time.Now()
+	retried := &models.ExecutionChainStepRun{RunID: run.ID, StepID: uuid.New(), StepOrder: 1, Status: models.WebhookStatusPending}
+	skipped := &models.ExecutionChainStepRun{RunID: run.ID, StepID: uuid.New(), StepOrder: 2, Status: models.WebhookStatusSkipped, CompletedAt: &now}
+
+	// Act
+	require.NoError(t, chains.CreateStepRun(ctx, retried))
+	require.NoError(t, chains.CreateStepRun(ctx, skipped))
+	require.NoError(t, chains.UpdateStepRun(ctx, retried.ID, map[string]interface{}{"attempt_count": 1, "request_bytes": 100}))
+	require.NoError(t, chains.UpdateStepRun(ctx, retried.ID, map[string]interface{}{"attempt_count": 2, "request_bytes": 200}))
+	unflushed, err := next.GetStepRunsByRun(ctx, run.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, chains.UpdateStepRun(ctx, retried.ID, map[string]interface{}{"attempt_count": 3, "status": models.WebhookStatusSent}))
+	stepRuns, err := chains.GetStepRunsByRun(ctx, run.ID)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, unflushed, 1)
+	assert.Zero(t, unflushed[0].AttemptCount)
+
+	require.Len(t, stepRuns, 2)
+	assert.Equal(t, models.WebhookStatusSent, stepRuns[0].Status)
+	assert.Equal(t, 3, stepRuns[0].AttemptCount)
+	assert.Equal(t, int64(200), stepRuns[0].RequestBytes)
+	assert.Equal(t, skipped.ID, stepRuns[1].ID)
+	assert.Equal(t, models.WebhookStatusSkipped, stepRuns[1].Status)
+	assert.Zero(t, batcher.rows)
+}
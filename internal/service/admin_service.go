@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"go.uber.org/zap"
+)
+
+// AdminService provides operator views across all tenants
+type AdminService interface {
+	ListSubscriptions(ctx context.Context, filter models.AdminListFilter, page models.ListPage) (*models.WebhookListResponse, error)
+	ListEvents(ctx context.Context, filter models.AdminListFilter, page models.ListPage) (*models.AdminEventListResponse, error)
+	ListChainRuns(ctx context.Context, filter models.AdminListFilter, page models.ListPage) (*models.ExecutionChainRunsResponse, error)
+	GetTenantStats(ctx context.Context) (*models.TenantStatsResponse, error)
+
+	// JWT keyring management
+	ListJWTKeys(ctx context.Context) (*models.JWTKeyListResponse, error)
+	AddJWTKey(ctx context.Context, req *models.AddJWTKeyRequest) (*models.JWTKey, error)
+	PromoteJWTKey(ctx context.Context, kid string) error
+	RetireJWTKey(ctx context.Context, kid string) error
+
+	// PurgeDeleted permanently deletes subscriptions and chains deleted more than the given days ago
+	PurgeDeleted(ctx context.Context, req *models.PurgeRequest) (*models.PurgeResult, error)
+}
+
+// adminService implements AdminService
+type adminService struct {
+	adminRepo repository.AdminRepository
+	keyring   *Keyring
+	clock     Clock
+	logger    logging.Logger
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(adminRepo repository.AdminRepository, keyring *Keyring, logger logging.Logger) AdminService {
+	return &adminService{
+		adminRepo: adminRepo,
+		keyring:   keyring,
+		clock:     NewSystemClock(),
+		logger:    logger,
+	}
+}
+
+// ListSubscriptions lists webhook subscriptions of all tenants with pagination, sorted by creation time or app name
+func (s *adminService) ListSubscriptions(ctx context.Context, filter models.AdminListFilter, page models.ListPage) (*models.WebhookListResponse, error) {
+	repoPage, err := listPage(&page, models.ListSortAppName)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, total, err := s.adminRepo.ListSubscriptions(ctx, filter, repoPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	return &models.WebhookListResponse{
+		Webhooks: subscriptions,
+		Total:    total,
+		Page:     page.Page,
+		Limit:    page.Limit,
+		NextCursor: encodeCursor(repoPage, repoPage.NextCursor(len(subscriptions), func() repository.Cursor {
+			return repository.SubscriptionCursor(&subscriptions[len(subscriptions)-1], repoPage.Sort)
+		})),
+	}, nil
+}
+
+// ListEvents lists webhook events of all tenants with pagination, sorted by creation time or status
+func (s *adminService) ListEvents(ctx context.Context, filter models.AdminListFilter, page models.ListPage) (*models.AdminEventListResponse, error) {
+	repoPage, err := listPage(&page, models.ListSortStatus)
+	if err != nil {
+		return nil, err
+	}
+	events, total, err := s.adminRepo.ListEvents(ctx, filter, repoPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return &models.AdminEventListResponse{
+		Events: events,
+		Total:  total,
+		Page:   page.Page,
+		Limit:  page.Limit,
+		NextCursor: encodeCursor(repoPage, repoPage.NextCursor(len(events), func() repository.Cursor {
+			return repository.EventCursor(&events[len(events)-1], repoPage.Sort)
+		})),
+	}, nil
+}
+
+// ListChainRuns lists execution chain runs of all tenants with pagination, sorted by creation time or status
+func (s *adminService) ListChainRuns(ctx context.Context, filter models.AdminListFilter, page models.ListPage) (*models.ExecutionChainRunsResponse, error) {
+	repoPage, err := listPage(&page, models.ListSortStatus)
+	if err != nil {
+		return nil, err
+	}
+	runs, total, err := s.adminRepo.ListChainRuns(ctx, filter, repoPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chain runs: %w", err)
+	}
+
+	return &models.ExecutionChainRunsResponse{
+		Runs:  runs,
+		Total: total,
+		Page:  page.Page,
+		Limit: page.Limit,
+		NextCursor: encodeCursor(repoPage, repoPage.NextCursor(len(runs), func() repository.Cursor {
+			return repository.ChainRunCursor(&runs[len(runs)-1], repoPage.Sort)
+		})),
+	}, nil
+}
+
+// GetTenantStats returns aggregate resource counts for every tenant
+func (s *adminService) GetTenantStats(ctx context.Context) (*models.TenantStatsResponse, error) {
+	stats, err := s.adminRepo.GetTenantStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate tenant stats: %w", err)
+	}
+
+	return &models.TenantStatsResponse{
+		Tenants:      stats,
+		TotalTenants: len(stats),
+	}, nil
+}
+
+// ListJWTKeys lists the keys of the JWT keyring, including retired ones, newest first
+func (s *adminService) ListJWTKeys(ctx context.Context) (*models.JWTKeyListResponse, error) {
+	keys, err := s.keyring.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.JWTKeyListResponse{Keys: make([]models.JWTKey, len(keys))}
+	for i, key := range keys {
+		response.Keys[i] = *key
+		if key.IsPrimary && !key.IsRetired() {
+			response.PrimaryKeyID = key.ID
+		}
+	}
+	return response, nil
+}
+
+// AddJWTKey generates a new key for the JWT keyring, optionally making it the primary key
+func (s *adminService) AddJWTKey(ctx context.Context, req *models.AddJWTKeyRequest) (*models.JWTKey, error) {
+	return s.keyring.AddKey(ctx, req.Primary)
+}
+
+// PromoteJWTKey makes a key the one new tokens are signed with
+func (s *adminService) PromoteJWTKey(ctx context.Context, kid string) error {
+	return s.keyring.PromoteKey(ctx, kid)
+}
+
+// RetireJWTKey retires a key so tokens signed with it stop verifying
+func (s *adminService) RetireJWTKey(ctx context.Context, kid string) error {
+	return s.keyring.RetireKey(ctx, kid)
+}
+
+// PurgeDeleted permanently deletes subscriptions and chains deleted more than the given days ago
+// Purged records cannot be restored; their configuration history is kept
+func (s *adminService) PurgeDeleted(ctx context.Context, req *models.PurgeRequest) (*models.PurgeResult, error) {
+	if req.OlderThanDays < 0 {
+		return nil, fmt.Errorf("older_than_days must not be negative")
+	}
+
+	result, err := s.adminRepo.PurgeDeleted(ctx, s.clock.Now().AddDate(0, 0, -req.OlderThanDays))
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted records: %w", err)
+	}
+
+	s.logger.Info(ctx, "Deleted subscriptions and chains purged",
+		zap.Time("deleted_before", result.DeletedBefore),
+		zap.Int64("chains_purged", result.ChainsPurged),
+		zap.Int64("runs_purged", result.RunsPurged),
+		zap.Int64("subscriptions_purged", result.SubscriptionsPurged),
+		zap.Int64("subscriptions_kept", result.SubscriptionsKept))
+	return result, nil
+}
@@ -0,0 +1,594 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultAlertEvaluationInterval is how often alert rules are evaluated
+	DefaultAlertEvaluationInterval = time.Minute
+
+	// DefaultAlertWindowMinutes is how far back failure_rate and run_duration rules look without a window of their own
+	DefaultAlertWindowMinutes = 15
+
+	// MaxAlertWindowMinutes caps the window of alert rules at a week
+	MaxAlertWindowMinutes = 7 * 24 * 60
+
+	// DefaultAlertSuppressMinutes is how long notifications of a rule still firing are not repeated, without
+	// a suppression window of its own
+	DefaultAlertSuppressMinutes = 60
+
+	// MaxAlertConsecutiveFailures caps the threshold of consecutive_failures rules
+	MaxAlertConsecutiveFailures = 100
+
+	// notificationEventSource is the source of events raised by Loki Suite itself
+	notificationEventSource = "loki-suite"
+
+	// alertFiringEvent and alertResolvedEvent name the notification events of alert rules
+	alertFiringEvent   = "loki.alert.firing"
+	alertResolvedEvent = "loki.alert.resolved"
+)
+
+var (
+	// ErrAlertRuleNotFound is returned for alert rules that do not exist
+	ErrAlertRuleNotFound = apperr.NotFound("alert_rule_not_found", "alert rule not found")
+
+	// ErrInvalidAlertRule is returned when an alert rule's definition is incomplete or inconsistent
+	ErrInvalidAlertRule = apperr.Validation("invalid_alert_rule", "invalid alert rule")
+)
+
+// AlertNotifier delivers the notifications of alert rules to subscriptions; implemented by WebhookService
+type AlertNotifier interface {
+	SendNotification(ctx context.Context, webhookID uuid.UUID, event string, payload map[string]interface{}) error
+}
+
+// AlertService manages alert rules and evaluates them
+// A rule watches the deliveries of one subscription or the runs of one chain, and notifies a subscription of
+// the tenant when its condition starts holding, repeatedly while it keeps holding once its suppression window
+// passed, and when it stops holding. Every notification is recorded in the alert history
+type AlertService interface {
+	// CreateRule creates an alert rule of a tenant
+	// Returns ErrInvalidAlertRule if the definition is invalid or names subscriptions or chains of another tenant
+	CreateRule(ctx context.Context, req *models.CreateAlertRuleRequest) (*models.AlertRule, error)
+
+	// GetRule returns an alert rule with its state
+	// Returns ErrAlertRuleNotFound for unknown rules
+	GetRule(ctx context.Context, id uuid.UUID) (*models.AlertRule, error)
+
+	// ListRules returns the alert rules of a tenant, ordered by name
+	ListRules(ctx context.Context, tenantID string) (*models.AlertRuleListResponse, error)
+
+	// UpdateRule replaces the definition of an alert rule, keeping its state
+	// Returns ErrAlertRuleNotFound for unknown rules and ErrInvalidAlertRule for invalid definitions
+	UpdateRule(ctx context.Context, id uuid.UUID, req *models.AlertRuleRequest) (*models.AlertRule, error)
+
+	// DeleteRule deletes an alert rule; its history is kept
+	// Returns ErrAlertRuleNotFound for unknown rules
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// ListHistory returns the alert history of a tenant, or of one of its rules, with pagination, newest first
+	ListHistory(ctx context.Context, tenantID string, ruleID *uuid.UUID, page, limit int) (*models.AlertEventListResponse, error)
+
+	// EvaluateRules evaluates every active alert rule once and sends the notifications due
+	// Instances sharing the database take turns, so a rule is evaluated by one instance at a time
+	EvaluateRules(ctx context.Context) error
+
+	// RunEvaluator evaluates the alert rules every interval until ctx is cancelled
+	RunEvaluator(ctx context.Context, interval time.Duration)
+
+	// SetClock replaces the clock used for windows and timestamps
+	SetClock(clock Clock)
+
+	// SetLocks coordinates the instances sharing the database, nil when the instance runs alone
+	SetLocks(locks repository.LockRepository)
+}
+
+// alertService implements AlertService
+type alertService struct {
+	alertRepo   repository.AlertRepository
+	webhookRepo repository.WebhookRepository
+	chainRepo   repository.ExecutionChainRepository
+	notifier    AlertNotifier
+	clock       Clock
+	locks       repository.LockRepository
+	logger      logging.Logger
+}
+
+// NewAlertService creates a new alert service
+func NewAlertService(alertRepo repository.AlertRepository, webhookRepo repository.WebhookRepository, chainRepo repository.ExecutionChainRepository, notifier AlertNotifier, logger logging.Logger) AlertService {
+	return &alertService{
+		alertRepo:   alertRepo,
+		webhookRepo: webhookRepo,
+		chainRepo:   chainRepo,
+		notifier:    notifier,
+		clock:       NewSystemClock(),
+		logger:      logger,
+	}
+}
+
+// SetClock replaces the clock used for windows and timestamps
+func (s *alertService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// SetLocks coordinates the instances sharing the database, nil when the instance runs alone
+func (s *alertService) SetLocks(locks repository.LockRepository) {
+	s.locks = locks
+}
+
+// CreateRule creates an alert rule of a tenant, in the ok state
+func (s *alertService) CreateRule(ctx context.Context, req *models.CreateAlertRuleRequest) (*models.AlertRule, error) {
+	now := s.clock.Now()
+	rule := &models.AlertRule{
+		ID:        uuid.New(),
+		TenantID:  req.TenantID,
+		State:     models.AlertStateOK,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.applyRuleRequest(ctx, rule, &req.AlertRuleRequest); err != nil {
+		return nil, err
+	}
+	if err := s.alertRepo.CreateAlertRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	return rule, nil
+}
+
+// GetRule returns an alert rule with its state
+func (s *alertService) GetRule(ctx context.Context, id uuid.UUID) (*models.AlertRule, error) {
+	rule, err := s.alertRepo.GetAlertRuleByID(ctx, id)
+	if err != nil {
+		return nil, notFound(ErrAlertRuleNotFound, err)
+	}
+	return rule, nil
+}
+
+// ListRules returns the alert rules of a tenant, ordered by name
+func (s *alertService) ListRules(ctx context.Context, tenantID string) (*models.AlertRuleListResponse, error) {
+	rules, err := s.alertRepo.GetAlertRulesByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	if rules == nil {
+		rules = []models.AlertRule{}
+	}
+	return &models.AlertRuleListResponse{
+		TenantID: tenantID,
+		Rules:    rules,
+		Total:    len(rules),
+	}, nil
+}
+
+// UpdateRule replaces the definition of an alert rule
+// A rule that is firing stays firing until an evaluation finds the new condition no longer holds
+func (s *alertService) UpdateRule(ctx context.Context, id uuid.UUID, req *models.AlertRuleRequest) (*models.AlertRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRuleRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = s.clock.Now()
+	if err := s.alertRepo.UpdateAlertRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update alert rule: %w", err)
+	}
+	return rule, nil
+}
+
+// DeleteRule deletes an alert rule
+func (s *alertService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+	if err := s.alertRepo.DeleteAlertRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	return nil
+}
+
+// ListHistory returns the alert history of a tenant, or of one of its rules, with pagination, newest first
+func (s *alertService) ListHistory(ctx context.Context, tenantID string, ruleID *uuid.UUID, page, limit int) (*models.AlertEventListResponse, error) {
+	offset := (page - 1) * limit
+	events, total, err := s.alertRepo.GetAlertEvents(ctx, tenantID, ruleID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert history: %w", err)
+	}
+	if events == nil {
+		events = []models.AlertEvent{}
+	}
+	return &models.AlertEventListResponse{
+		Events: events,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, nil
+}
+
+// applyRuleRequest validates the definition of an alert rule and stores it on the rule
+// The watched subscription or chain and the notification subscription must belong to the rule's tenant
+func (s *alertService) applyRuleRequest(ctx context.Context, rule *models.AlertRule, req *models.AlertRuleRequest) error {
+	invalid := func(format string, args ...interface{}) error {
+		return ErrInvalidAlertRule.Withf(format, args...)
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return invalid("name is required")
+	}
+	if (req.WebhookID == nil) == (req.ChainID == nil) {
+		return invalid("exactly one of webhook_id and chain_id must be set")
+	}
+	switch req.Condition {
+	case models.AlertConditionFailureRate:
+		if req.Threshold < 0 || req.Threshold >= 100 {
+			return invalid("threshold of failure_rate must be a percentage from 0 to below 100")
+		}
+	case models.AlertConditionConsecutiveFailures:
+		if req.Threshold < 1 || req.Threshold > MaxAlertConsecutiveFailures || req.Threshold != math.Trunc(req.Threshold) {
+			return invalid("threshold of consecutive_failures must be a whole number from 1 to %d", MaxAlertConsecutiveFailures)
+		}
+	case models.AlertConditionRunDuration:
+		if req.ChainID == nil {
+			return invalid("run_duration watches the runs of a chain_id")
+		}
+		if req.Threshold <= 0 {
+			return invalid("threshold of run_duration must be a positive number of seconds")
+		}
+	default:
+		return invalid("unknown condition %q", req.Condition)
+	}
+
+	window := req.WindowMinutes
+	if window == 0 {
+		window = DefaultAlertWindowMinutes
+	}
+	if window < 0 || window > MaxAlertWindowMinutes {
+		return invalid("window_minutes must be from 1 to %d", MaxAlertWindowMinutes)
+	}
+	if req.MinSamples < 0 {
+		return invalid("min_samples must not be negative")
+	}
+	suppress := req.SuppressMinutes
+	if suppress == 0 {
+		suppress = DefaultAlertSuppressMinutes
+	}
+	if suppress < 0 {
+		return invalid("suppress_minutes must not be negative")
+	}
+
+	if req.WebhookID != nil {
+		if err := s.checkSubscription(ctx, rule.TenantID, *req.WebhookID); err != nil {
+			return invalid("webhook_id: %v", err)
+		}
+	}
+	if req.ChainID != nil {
+		chain, err := s.chainRepo.GetChainByID(ctx, *req.ChainID)
+		if err != nil || chain.TenantID != rule.TenantID {
+			return invalid("chain_id: chain %s not found", *req.ChainID)
+		}
+	}
+	if err := s.checkSubscription(ctx, rule.TenantID, req.NotifyWebhookID); err != nil {
+		return invalid("notify_webhook_id: %v", err)
+	}
+
+	rule.Name = req.Name
+	rule.WebhookID = req.WebhookID
+	rule.ChainID = req.ChainID
+	rule.Condition = req.Condition
+	rule.Threshold = req.Threshold
+	rule.WindowMinutes = window
+	rule.MinSamples = req.MinSamples
+	rule.NotifyWebhookID = req.NotifyWebhookID
+	rule.SuppressMinutes = suppress
+	rule.SilencedUntil = req.SilencedUntil
+	rule.IsActive = req.IsActive == nil || *req.IsActive
+	return nil
+}
+
+// checkSubscription checks that a subscription exists and belongs to a tenant
+func (s *alertService) checkSubscription(ctx context.Context, tenantID string, id uuid.UUID) error {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(ctx, id)
+	if err != nil || subscription.TenantID != tenantID {
+		return fmt.Errorf("webhook %s not found", id)
+	}
+	return nil
+}
+
+// RunEvaluator evaluates the alert rules every interval until ctx is cancelled
+func (s *alertService) RunEvaluator(ctx context.Context, interval time.Duration) {
+	for {
+		if err := s.EvaluateRules(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to evaluate alert rules", zap.Error(err))
+		}
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// EvaluateRules evaluates every active alert rule once
+// A rule that cannot be evaluated is logged and keeps its state; the other rules are still evaluated
+func (s *alertService) EvaluateRules(ctx context.Context) error {
+	_, err := tryInstanceLock(ctx, s.locks, alertEvaluationLock, func() error {
+		rules, err := s.alertRepo.GetActiveAlertRules(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load alert rules: %w", err)
+		}
+		for i := range rules {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := s.evaluateRule(ctx, &rules[i]); err != nil {
+				s.logger.Error(ctx, "Failed to evaluate alert rule",
+					zap.String("alert_rule_id", rules[i].ID.String()),
+					zap.String("tenant_id", rules[i].TenantID),
+					zap.Error(err))
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// alertMeasurement is what evaluating an alert rule's condition found
+type alertMeasurement struct {
+	value    float64
+	breached bool
+	message  string
+}
+
+// evaluateRule measures a rule's condition, records and sends the notification due and saves the rule's state
+func (s *alertService) evaluateRule(ctx context.Context, rule *models.AlertRule) error {
+	now := s.clock.Now()
+	measurement, err := s.measure(ctx, rule, now)
+	if err != nil {
+		return err
+	}
+	rule.Value = measurement.value
+	rule.EvaluatedAt = &now
+
+	switch {
+	case measurement.breached && rule.State != models.AlertStateFiring:
+		rule.State = models.AlertStateFiring
+		rule.FiringSince = &now
+		s.notify(ctx, rule, models.AlertEventFired, measurement.message, now)
+	case measurement.breached && s.repeatDue(rule, now):
+		s.notify(ctx, rule, models.AlertEventRepeated, measurement.message, now)
+	case !measurement.breached && rule.State == models.AlertStateFiring:
+		rule.State = models.AlertStateOK
+		rule.FiringSince = nil
+		s.notify(ctx, rule, models.AlertEventResolved, measurement.message, now)
+	}
+
+	if err := s.alertRepo.UpdateAlertRuleState(ctx, rule); err != nil {
+		return fmt.Errorf("failed to save alert rule state: %w", err)
+	}
+	return nil
+}
+
+// measure evaluates a rule's condition over the deliveries of its subscription or the runs of its chain
+func (s *alertService) measure(ctx context.Context, rule *models.AlertRule, now time.Time) (alertMeasurement, error) {
+	window := time.Duration(rule.WindowMinutes) * time.Minute
+	since := now.Add(-window)
+	noun := "deliveries"
+	if rule.ChainID != nil {
+		noun = "runs"
+	}
+
+	switch rule.Condition {
+	case models.AlertConditionFailureRate:
+		var total, failed int64
+		var err error
+		if rule.WebhookID != nil {
+			total, failed, err = s.alertRepo.CountDeliveryOutcomes(ctx, *rule.WebhookID, since)
+		} else {
+			total, failed, err = s.alertRepo.CountRunOutcomes(ctx, *rule.ChainID, since)
+		}
+		if err != nil {
+			return alertMeasurement{}, fmt.Errorf("failed to count %s: %w", noun, err)
+		}
+		var rate float64
+		if total > 0 {
+			rate = float64(failed) * 100 / float64(total)
+		}
+		return alertMeasurement{
+			value:    rate,
+			breached: total > 0 && total >= int64(rule.MinSamples) && rate > rule.Threshold,
+			message:  fmt.Sprintf("%.1f%% of %d %s failed in the last %d minutes", rate, total, noun, rule.WindowMinutes),
+		}, nil
+
+	case models.AlertConditionConsecutiveFailures:
+		limit := int(rule.Threshold)
+		var outcomes []bool
+		var err error
+		if rule.WebhookID != nil {
+			outcomes, err = s.alertRepo.RecentDeliveryOutcomes(ctx, *rule.WebhookID, limit)
+		} else {
+			outcomes, err = s.alertRepo.RecentRunOutcomes(ctx, *rule.ChainID, limit)
+		}
+		if err != nil {
+			return alertMeasurement{}, fmt.Errorf("failed to load recent %s: %w", noun, err)
+		}
+		failures := 0
+		for _, succeeded := range outcomes {
+			if succeeded {
+				break
+			}
+			failures++
+		}
+		return alertMeasurement{
+			value:    float64(failures),
+			breached: failures >= limit,
+			message:  fmt.Sprintf("the last %d %s failed", failures, noun),
+		}, nil
+
+	case models.AlertConditionRunDuration:
+		if rule.ChainID == nil {
+			return alertMeasurement{}, fmt.Errorf("run_duration rule without a chain")
+		}
+		seconds, err := s.alertRepo.LongestRunSeconds(ctx, *rule.ChainID, since, now)
+		if err != nil {
+			return alertMeasurement{}, fmt.Errorf("failed to measure run durations: %w", err)
+		}
+		return alertMeasurement{
+			value:    seconds,
+			breached: seconds > rule.Threshold,
+			message: fmt.Sprintf("the longest run in the last %d minutes took %s, the threshold is %s", rule.WindowMinutes,
+				secondsDuration(seconds), secondsDuration(rule.Threshold)),
+		}, nil
+	}
+	return alertMeasurement{}, fmt.Errorf("unknown condition %q", rule.Condition)
+}
+
+// secondsDuration formats a number of seconds as a duration rounded to the second
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+// silenced reports whether a rule's notifications are suppressed at the given time
+func silenced(rule *models.AlertRule, now time.Time) bool {
+	return rule.SilencedUntil != nil && now.Before(*rule.SilencedUntil)
+}
+
+// repeatDue reports whether a firing rule's notification is repeated: once its suppression window passed since
+// the last notification, or once it is no longer silenced if it fired silenced
+func (s *alertService) repeatDue(rule *models.AlertRule, now time.Time) bool {
+	if silenced(rule, now) {
+		return false
+	}
+	if rule.NotifiedAt == nil || (rule.FiringSince != nil && rule.NotifiedAt.Before(*rule.FiringSince)) {
+		return true
+	}
+	return now.Sub(*rule.NotifiedAt) >= time.Duration(rule.SuppressMinutes)*time.Minute
+}
+
+// notify sends the notification of a rule's history entry, unless the rule is silenced, and records the entry
+// Failed notifications are recorded and not retried; a firing rule repeats its notification after its
+// suppression window
+func (s *alertService) notify(ctx context.Context, rule *models.AlertRule, kind models.AlertEventKind, message string, now time.Time) {
+	event := &models.AlertEvent{
+		ID:        uuid.New(),
+		RuleID:    rule.ID,
+		TenantID:  rule.TenantID,
+		RuleName:  rule.Name,
+		Kind:      kind,
+		Value:     rule.Value,
+		Message:   message,
+		CreatedAt: now,
+	}
+
+	if silenced(rule, now) {
+		event.Notification = models.AlertNotificationSuppressed
+	} else {
+		rule.NotifiedAt = &now
+		eventName := alertFiringEvent
+		if kind == models.AlertEventResolved {
+			eventName = alertResolvedEvent
+		}
+		if err := s.notifier.SendNotification(ctx, rule.NotifyWebhookID, eventName, alertPayload(rule, event)); err != nil {
+			errMsg := err.Error()
+			event.Error = &errMsg
+			event.Notification = models.AlertNotificationFailed
+			s.logger.Warn(ctx, "Failed to send alert notification",
+				zap.String("alert_rule_id", rule.ID.String()),
+				zap.String("notify_webhook_id", rule.NotifyWebhookID.String()),
+				zap.Error(err))
+		} else {
+			event.Notification = models.AlertNotificationSent
+		}
+	}
+
+	s.logger.Info(ctx, "Alert "+string(kind),
+		zap.String("alert_rule_id", rule.ID.String()),
+		zap.String("tenant_id", rule.TenantID),
+		zap.String("message", message),
+		zap.String("notification", string(event.Notification)))
+
+	if err := s.alertRepo.CreateAlertEvent(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to record alert history",
+			zap.String("alert_rule_id", rule.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// alertPayload is the payload of the notification event of an alert history entry
+// severity is error while firing and info once resolved, for notification templates with
+// severity_field "payload.severity"
+func alertPayload(rule *models.AlertRule, event *models.AlertEvent) map[string]interface{} {
+	severity := models.NotificationSeverityError
+	if event.Kind == models.AlertEventResolved {
+		severity = models.NotificationSeverityInfo
+	}
+	payload := map[string]interface{}{
+		"alert_event_id": event.ID.String(),
+		"rule_id":        rule.ID.String(),
+		"rule_name":      rule.Name,
+		"kind":           string(event.Kind),
+		"state":          string(rule.State),
+		"condition":      string(rule.Condition),
+		"threshold":      rule.Threshold,
+		"value":          rule.Value,
+		"message":        event.Message,
+		"severity":       string(severity),
+	}
+	if rule.WebhookID != nil {
+		payload["webhook_id"] = rule.WebhookID.String()
+	}
+	if rule.ChainID != nil {
+		payload["chain_id"] = rule.ChainID.String()
+	}
+	if rule.FiringSince != nil {
+		payload["firing_since"] = rule.FiringSince.Format(time.RFC3339)
+	}
+	return payload
+}
+
+// SendNotification delivers an event raised by Loki Suite itself to a subscription, once
+func (s *webhookService) SendNotification(ctx context.Context, webhookID uuid.UUID, event string, payload map[string]interface{}) error {
+	subscription, err := s.repo.GetSubscriptionByID(ctx, webhookID)
+	if err != nil {
+		return notFound(ErrWebhookNotFound, err)
+	}
+	if !subscription.IsActive {
+		return fmt.Errorf("webhook %s is inactive", webhookID)
+	}
+	transport, ok := s.transports.Lookup(subscription.TargetType)
+	if !ok {
+		return fmt.Errorf("no transport for target type %s", subscription.TargetType)
+	}
+
+	eventID := uuid.New()
+	body, err := json.Marshal(&models.WebhookPayload{
+		Event:     event,
+		Source:    notificationEventSource,
+		Timestamp: s.clock.Now().Format(time.RFC3339),
+		Payload:   payload,
+		EventID:   eventID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to serialize notification: %w", err)
+	}
+
+	outcome := transport.Send(ctx, &Delivery{
+		Subscription: *subscription,
+		Headers:      subscription.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, subscription.TenantID)),
+		MessageID:    eventID.String(),
+		Body:         body,
+		Attempt:      1,
+	})
+	return outcome.Err
+}
@@ -0,0 +1,229 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestEvaluateRules_FailureRate tests that a failure_rate rule notifies when it fires, does not repeat its
+// notification within the suppression window, repeats it after and notifies again when it resolves
+func TestEvaluateRules_FailureRate(t *testing.T) {
+	// Arrange
+	alertRepo := mocks.NewMockAlertRepository(t)
+	notifier := mocks.NewMockWebhookService(t)
+	svc := service.NewAlertService(alertRepo, mocks.NewMockWebhookRepository(t), mocks.NewMockExecutionChainRepository(t), notifier, logging.Nop())
+	clock := newFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	svc.SetClock(clock)
+
+	webhookID := uuid.New()
+	rule := models.AlertRule{
+		ID:              uuid.New(),
+		TenantID:        "tenant-1",
+		Name:            "Payments failing",
+		WebhookID:       &webhookID,
+		Condition:       models.AlertConditionFailureRate,
+		Threshold:       10,
+		WindowMinutes:   15,
+		MinSamples:      5,
+		NotifyWebhookID: uuid.New(),
+		SuppressMinutes: 60,
+		IsActive:        true,
+		State:           models.AlertStateOK,
+	}
+	alertRepo.EXPECT().GetActiveAlertRules(mock.Anything).
+		RunAndReturn(func(context.Context) ([]models.AlertRule, error) {
+			return []models.AlertRule{rule}, nil
+		})
+	alertRepo.EXPECT().UpdateAlertRuleState(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, saved *models.AlertRule) error {
+			rule = *saved
+			return nil
+		})
+
+	var events []*models.AlertEvent
+	alertRepo.EXPECT().CreateAlertEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, event *models.AlertEvent) error {
+			events = append(events, event)
+			return nil
+		})
+
+	var sent []string
+	notifier.EXPECT().SendNotification(mock.Anything, rule.NotifyWebhookID, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ uuid.UUID, event string, payload map[string]interface{}) error {
+			sent = append(sent, event+" "+payload["severity"].(string))
+			return nil
+		})
+
+	evaluate := func(total, failed int64) {
+		alertRepo.EXPECT().CountDeliveryOutcomes(mock.Anything, webhookID, clock.Now().Add(-15*time.Minute)).
+			Return(total, failed, nil).Once()
+		assert.NoError(t, svc.EvaluateRules(context.Background()))
+	}
+
+	// Act
+	evaluate(20, 1) // 5% failed
+	evaluate(20, 4) // 20% failed: fires
+	clock.Advance(30 * time.Minute)
+	evaluate(20, 6) // still firing within the suppression window
+	clock.Advance(31 * time.Minute)
+	evaluate(20, 6) // still firing after the suppression window
+	clock.Advance(time.Minute)
+	evaluate(20, 0) // resolved
+
+	// Assert
+	assert.Equal(t, []string{"loki.alert.firing error", "loki.alert.firing error", "loki.alert.resolved info"}, sent)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, models.AlertEventFired, events[0].Kind)
+		assert.Equal(t, 20.0, events[0].Value)
+		assert.Equal(t, "20.0% of 20 deliveries failed in the last 15 minutes", events[0].Message)
+		assert.Equal(t, models.AlertEventRepeated, events[1].Kind)
+		assert.Equal(t, models.AlertEventResolved, events[2].Kind)
+		for _, event := range events {
+			assert.Equal(t, models.AlertNotificationSent, event.Notification)
+		}
+	}
+	assert.Equal(t, models.AlertStateOK, rule.State)
+	assert.Nil(t, rule.FiringSince)
+}
+
+// TestEvaluateRules_Silenced tests that a silenced rule records its history without notifying, and notifies
+// once the silence ends while it is still firing
+func TestEvaluateRules_Silenced(t *testing.T) {
+	// Arrange
+	alertRepo := mocks.NewMockAlertRepository(t)
+	notifier := mocks.NewMockWebhookService(t)
+	svc := service.NewAlertService(alertRepo, mocks.NewMockWebhookRepository(t), mocks.NewMockExecutionChainRepository(t), notifier, logging.Nop())
+	clock := newFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	svc.SetClock(clock)
+
+	chainID := uuid.New()
+	silencedUntil := clock.Now().Add(10 * time.Minute)
+	rule := models.AlertRule{
+		ID:              uuid.New(),
+		TenantID:        "tenant-1",
+		Name:            "Nightly import failing",
+		ChainID:         &chainID,
+		Condition:       models.AlertConditionConsecutiveFailures,
+		Threshold:       3,
+		NotifyWebhookID: uuid.New(),
+		SuppressMinutes: 60,
+		SilencedUntil:   &silencedUntil,
+		IsActive:        true,
+		State:           models.AlertStateOK,
+	}
+	alertRepo.EXPECT().GetActiveAlertRules(mock.Anything).
+		RunAndReturn(func(context.Context) ([]models.AlertRule, error) {
+			return []models.AlertRule{rule}, nil
+		})
+	alertRepo.EXPECT().UpdateAlertRuleState(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, saved *models.AlertRule) error {
+			rule = *saved
+			return nil
+		})
+	alertRepo.EXPECT().RecentRunOutcomes(mock.Anything, chainID, 3).Return([]bool{false, false, false}, nil)
+
+	var events []*models.AlertEvent
+	alertRepo.EXPECT().CreateAlertEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, event *models.AlertEvent) error {
+			events = append(events, event)
+			return nil
+		})
+	notifier.EXPECT().SendNotification(mock.Anything, rule.NotifyWebhookID, "loki.alert.firing", mock.Anything).
+		Return(errors.New("connection refused")).Once()
+
+	// Act
+	assert.NoError(t, svc.EvaluateRules(context.Background()))
+	clock.Advance(5 * time.Minute)
+	assert.NoError(t, svc.EvaluateRules(context.Background()))
+	clock.Advance(10 * time.Minute)
+	assert.NoError(t, svc.EvaluateRules(context.Background()))
+
+	// Assert
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, models.AlertEventFired, events[0].Kind)
+		assert.Equal(t, models.AlertNotificationSuppressed, events[0].Notification)
+		assert.Equal(t, models.AlertEventRepeated, events[1].Kind)
+		assert.Equal(t, models.AlertNotificationFailed, events[1].Notification)
+		if assert.NotNil(t, events[1].Error) {
+			assert.Equal(t, "connection refused", *events[1].Error)
+		}
+	}
+	assert.Equal(t, models.AlertStateFiring, rule.State)
+}
+
+// TestCreateAlertRule_Invalid tests that rule definitions that are inconsistent or name subscriptions of
+// another tenant are rejected with ErrInvalidAlertRule
+func TestCreateAlertRule_Invalid(t *testing.T) {
+	webhookID := uuid.New()
+	notifyID := uuid.New()
+
+	tests := []struct {
+		name string
+		req  models.AlertRuleRequest
+	}{
+		{
+			name: "run_duration of a subscription",
+			req: models.AlertRuleRequest{
+				Name:            "Slow",
+				WebhookID:       &webhookID,
+				Condition:       models.AlertConditionRunDuration,
+				Threshold:       60,
+				NotifyWebhookID: notifyID,
+			},
+		},
+		{
+			name: "fractional consecutive failures",
+			req: models.AlertRuleRequest{
+				Name:            "Failing",
+				WebhookID:       &webhookID,
+				Condition:       models.AlertConditionConsecutiveFailures,
+				Threshold:       2.5,
+				NotifyWebhookID: notifyID,
+			},
+		},
+		{
+			name: "notification subscription of another tenant",
+			req: models.AlertRuleRequest{
+				Name:            "Failing",
+				WebhookID:       &webhookID,
+				Condition:       models.AlertConditionFailureRate,
+				Threshold:       10,
+				NotifyWebhookID: notifyID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			alertRepo := mocks.NewMockAlertRepository(t)
+			webhookRepo := mocks.NewMockWebhookRepository(t)
+			svc := service.NewAlertService(alertRepo, webhookRepo, mocks.NewMockExecutionChainRepository(t), mocks.NewMockWebhookService(t), logging.Nop())
+			webhookRepo.EXPECT().GetSubscriptionByID(mock.Anything, webhookID).
+				Return(&models.WebhookSubscription{ID: webhookID, TenantID: "tenant-1"}, nil).Maybe()
+			webhookRepo.EXPECT().GetSubscriptionByID(mock.Anything, notifyID).
+				Return(&models.WebhookSubscription{ID: notifyID, TenantID: "tenant-2"}, nil).Maybe()
+
+			// Act
+			rule, err := svc.CreateRule(context.Background(), &models.CreateAlertRuleRequest{
+				TenantID:         "tenant-1",
+				AlertRuleRequest: tt.req,
+			})
+
+			// Assert
+			assert.ErrorIs(t, err, service.ErrInvalidAlertRule)
+			assert.Nil(t, rule)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sakibcoolz/loki-suite/internal/amqp"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// errAMQPNotConfigured is returned when delivering to an AMQP target without a broker connection
+var errAMQPNotConfigured = errors.New("AMQP delivery is not configured")
+
+// AMQPPublisher publishes messages to an AMQP broker; *amqp.Conn implements it
+type AMQPPublisher interface {
+	// Publish publishes a message to an exchange and returns once the broker has confirmed it
+	Publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error
+}
+
+// amqpTargetURL describes an AMQP target in the target_url of its subscription and delivery results
+func amqpTargetURL(exchange, routingKey string) string {
+	return fmt.Sprintf("amqp://%s/%s", url.PathEscape(exchange), url.PathEscape(routingKey))
+}
+
+// amqpTransport publishes deliveries to an exchange of the AMQP broker, with the headers an HTTP delivery
+// would carry as message headers
+type amqpTransport struct {
+	env *transportEnv
+}
+
+// Validate requires a routing key and describes the exchange and routing key in the target URL
+func (t *amqpTransport) Validate(req *models.SubscribeWebhookRequest, subscription *models.WebhookSubscription) error {
+	if req.AMQPRoutingKey == "" {
+		return fmt.Errorf("amqp_routing_key is required for AMQP targets")
+	}
+	subscription.AMQPExchange = req.AMQPExchange
+	subscription.AMQPRoutingKey = req.AMQPRoutingKey
+	subscription.TargetURL = amqpTargetURL(req.AMQPExchange, req.AMQPRoutingKey)
+	return nil
+}
+
+// newDeliveryMessage builds the message of one delivery attempt to an AMQP target
+// The message carries the headers an HTTP delivery would, so receivers verify it the same way; the
+// delivery's message ID is the message ID consumers detect redeliveries by
+func (t *amqpTransport) newDeliveryMessage(ctx context.Context, d *Delivery) (amqp.Publishing, error) {
+	req, err := t.env.newDeliveryRequest(ctx, d, d.Subscription.TargetURL)
+	if err != nil {
+		return amqp.Publishing{}, err
+	}
+
+	msg := amqp.Publishing{
+		ContentType: req.Header.Get("Content-Type"),
+		MessageID:   d.MessageID,
+		Timestamp:   t.env.clock.Now(),
+		Headers:     make(map[string]string, len(req.Header)),
+		Body:        d.Body,
+	}
+	for name := range req.Header {
+		if name != "Content-Type" && name != "User-Agent" {
+			msg.Headers[name] = req.Header.Get(name)
+		}
+	}
+	return msg, nil
+}
+
+// Send publishes the body and waits for the broker to confirm it
+// Messages the broker returns as unroutable fail for good, like HTTP deliveries answered with a 4xx
+func (t *amqpTransport) Send(ctx context.Context, d *Delivery) DeliveryOutcome {
+	if t.env.amqp == nil {
+		return failedDelivery(models.DeliveryErrorRequest, errAMQPNotConfigured, true)
+	}
+	msg, err := t.newDeliveryMessage(ctx, d)
+	if err != nil {
+		return failedDelivery(models.DeliveryErrorRequest, err, false)
+	}
+
+	outcome := DeliveryOutcome{SentBody: d.Body, SentHeaders: amqpHeaders(msg)}
+	err = t.env.amqp.Publish(ctx, d.Subscription.AMQPExchange, d.Subscription.AMQPRoutingKey, msg)
+	switch {
+	case err == nil:
+	case errors.Is(err, amqp.ErrUnroutable):
+		outcome.Err = fmt.Errorf("failed to publish message: %w", err)
+		outcome.ErrorClass = models.DeliveryErrorRejected
+		outcome.Permanent = true
+	case errors.Is(err, amqp.ErrNacked):
+		outcome.Err = fmt.Errorf("failed to publish message: %w", err)
+		outcome.ErrorClass = models.DeliveryErrorRejected
+	default:
+		outcome.Err = fmt.Errorf("failed to publish message: %w", err)
+		outcome.ErrorClass = classifyTransportError(err)
+	}
+	return outcome
+}
+
+// amqpHeaders returns the headers of a message as HTTP headers, for reporting them like those of a request
+func amqpHeaders(msg amqp.Publishing) http.Header {
+	header := make(http.Header, len(msg.Headers))
+	for name, value := range msg.Headers {
+		header.Set(name, value)
+	}
+	return header
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// apiKeyPrefix marks loki-suite API keys so they are easy to recognise in logs and secret scanners
+	apiKeyPrefix = "lk_"
+
+	// defaultTokenTTL is used when a token is issued without an explicit lifetime
+	defaultTokenTTL = 24 * time.Hour
+
+	// maxTokenTTL caps the lifetime of issued tokens
+	maxTokenTTL = 30 * 24 * time.Hour
+)
+
+// AuthService handles API credentials and authentication of management API callers
+type AuthService interface {
+	// Credential management
+	CreateCredential(ctx context.Context, req *models.CreateCredentialRequest) (*models.CreateCredentialResponse, error)
+	GetCredential(ctx context.Context, credentialID uuid.UUID) (*models.APICredential, error)
+	ListCredentials(ctx context.Context, tenantID string, page, limit int) (*models.CredentialListResponse, error)
+	RevokeCredential(ctx context.Context, credentialID uuid.UUID) error
+	IssueToken(ctx context.Context, credentialID uuid.UUID, ttlSeconds int) (*models.IssueTokenResponse, error)
+
+	// Authentication
+	AuthenticateAPIKey(ctx context.Context, apiKey string) (*models.Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (*models.Principal, error)
+
+	// Testing
+	SetClock(clock Clock)
+}
+
+// roleClaims are the JWT claims carried by tokens issued for a credential
+type roleClaims struct {
+	TenantID string      `json:"tenant_id"`
+	Role     models.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// authService implements AuthService
+type authService struct {
+	credentialRepo repository.CredentialRepository
+	keyring        *Keyring
+	bootstrapKey   string
+	clock          Clock
+	logger         logging.Logger
+}
+
+// NewAuthService creates a new auth service
+// Tokens are signed and verified with the keyring; bootstrapKey is an optional admin API key, not bound
+// to a tenant, used to create the first credentials
+func NewAuthService(
+	credentialRepo repository.CredentialRepository,
+	keyring *Keyring,
+	bootstrapKey string,
+	logger logging.Logger,
+) AuthService {
+	return &authService{
+		credentialRepo: credentialRepo,
+		keyring:        keyring,
+		bootstrapKey:   bootstrapKey,
+		clock:          NewSystemClock(),
+		logger:         logger,
+	}
+}
+
+// SetClock replaces the clock used for credential timestamps and token issuing and expiry checks
+func (s *authService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// CreateCredential creates a new API credential and returns its plain API key
+func (s *authService) CreateCredential(ctx context.Context, req *models.CreateCredentialRequest) (*models.CreateCredentialResponse, error) {
+	if !req.Role.IsValid() {
+		return nil, apperr.Validation("invalid_role", "invalid role").Withf("invalid role: %s", req.Role)
+	}
+
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	now := s.clock.Now()
+	credential := &models.APICredential{
+		ID:        uuid.New(),
+		TenantID:  req.TenantID,
+		Name:      req.Name,
+		KeyPrefix: apiKey[:len(apiKeyPrefix)+8],
+		KeyHash:   hashAPIKey(apiKey),
+		Role:      req.Role,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.credentialRepo.CreateCredential(ctx, credential); err != nil {
+		s.logger.Error(ctx, "Failed to create API credential", zap.Error(err))
+		return nil, fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	s.logger.Info(ctx, "API credential created",
+		zap.String("credential_id", credential.ID.String()),
+		zap.String("tenant_id", credential.TenantID),
+		zap.String("role", string(credential.Role)))
+
+	return &models.CreateCredentialResponse{
+		CredentialID: credential.ID,
+		TenantID:     credential.TenantID,
+		Name:         credential.Name,
+		Role:         credential.Role,
+		APIKey:       apiKey,
+		CreatedAt:    credential.CreatedAt,
+	}, nil
+}
+
+// GetCredential retrieves a credential by ID
+func (s *authService) GetCredential(ctx context.Context, credentialID uuid.UUID) (*models.APICredential, error) {
+	return s.credentialRepo.GetCredentialByID(ctx, credentialID)
+}
+
+// ListCredentials lists credentials for a tenant with pagination
+func (s *authService) ListCredentials(ctx context.Context, tenantID string, page, limit int) (*models.CredentialListResponse, error) {
+	offset := (page - 1) * limit
+	credentials, total, err := s.credentialRepo.GetCredentialsByTenant(ctx, tenantID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response format
+	responseCredentials := make([]models.APICredential, len(credentials))
+	for i, credential := range credentials {
+		responseCredentials[i] = *credential
+	}
+
+	return &models.CredentialListResponse{
+		Credentials: responseCredentials,
+		Total:       total,
+		Page:        page,
+		Limit:       limit,
+	}, nil
+}
+
+// RevokeCredential deactivates a credential so its API key and tokens stop authenticating
+func (s *authService) RevokeCredential(ctx context.Context, credentialID uuid.UUID) error {
+	return s.credentialRepo.UpdateCredential(ctx, credentialID, map[string]interface{}{
+		"is_active":  false,
+		"updated_at": s.clock.Now(),
+	})
+}
+
+// IssueToken issues a JWT carrying the tenant and role claims of a credential
+// The token is signed with the primary key of the keyring and names it in its kid header
+func (s *authService) IssueToken(ctx context.Context, credentialID uuid.UUID, ttlSeconds int) (*models.IssueTokenResponse, error) {
+	credential, err := s.credentialRepo.GetCredentialByID(ctx, credentialID)
+	if err != nil {
+		return nil, notFound(ErrCredentialNotFound, err)
+	}
+
+	if !credential.IsActive {
+		return nil, ErrCredentialRevoked
+	}
+
+	ttl := defaultTokenTTL
+	if ttlSeconds > 0 {
+		ttl = time.Duration(ttlSeconds) * time.Second
+	}
+	if ttl > maxTokenTTL {
+		ttl = maxTokenTTL
+	}
+
+	now := s.clock.Now()
+	expiresAt := now.Add(ttl)
+	claims := roleClaims{
+		TenantID: credential.TenantID,
+		Role:     credential.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   credential.ID.String(),
+			Issuer:    "loki-suite",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := s.keyring.Sign(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &models.IssueTokenResponse{
+		Token:     token,
+		TenantID:  credential.TenantID,
+		Role:      credential.Role,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// AuthenticateAPIKey resolves the caller of a request authenticated with an API key
+func (s *authService) AuthenticateAPIKey(ctx context.Context, apiKey string) (*models.Principal, error) {
+	if s.bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(s.bootstrapKey)) == 1 {
+		return &models.Principal{Role: models.RoleAdmin}, nil
+	}
+
+	credential, err := s.credentialRepo.GetCredentialByKeyHash(ctx, hashAPIKey(apiKey))
+	if err != nil {
+		return nil, ErrUnauthenticated.Withf("invalid API key").Wrap(err)
+	}
+
+	if err := s.credentialRepo.UpdateCredential(ctx, credential.ID, map[string]interface{}{
+		"last_used_at": s.clock.Now(),
+	}); err != nil {
+		s.logger.Warn(ctx, "Failed to record credential usage",
+			zap.String("credential_id", credential.ID.String()),
+			zap.Error(err))
+	}
+
+	return &models.Principal{
+		CredentialID: credential.ID,
+		TenantID:     credential.TenantID,
+		Role:         credential.Role,
+	}, nil
+}
+
+// AuthenticateToken resolves the caller of a request authenticated with a JWT
+// The credential behind the token must still be active so revocation takes effect immediately
+func (s *authService) AuthenticateToken(ctx context.Context, token string) (*models.Principal, error) {
+	claims := &roleClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.keyring.Keyfunc(ctx), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired(), jwt.WithTimeFunc(s.clock.Now))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrUnauthenticated.Withf("token is expired").Wrap(err)
+	}
+	if err != nil {
+		return nil, ErrUnauthenticated.Withf("invalid token").Wrap(err)
+	}
+
+	credentialID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, ErrUnauthenticated.Withf("invalid token subject")
+	}
+
+	credential, err := s.credentialRepo.GetCredentialByID(ctx, credentialID)
+	if err != nil || !credential.IsActive {
+		return nil, ErrUnauthenticated.Withf("credential is revoked")
+	}
+
+	if !claims.Role.IsValid() {
+		return nil, ErrUnauthenticated.Withf("invalid role claim")
+	}
+
+	return &models.Principal{
+		CredentialID: credentialID,
+		TenantID:     claims.TenantID,
+		Role:         claims.Role,
+	}, nil
+}
+
+// generateAPIKey creates a random API key with the loki-suite prefix
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashAPIKey returns the hex-encoded SHA-256 hash under which an API key is stored
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
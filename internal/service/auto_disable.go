@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultAutoDisableInterval is how often the auto-disable policies of the tenants are applied
+	DefaultAutoDisableInterval = 15 * time.Minute
+
+	// subscriptionDisabledEvent names the notification event of a subscription deactivated by its tenant's policy
+	subscriptionDisabledEvent = "loki.webhook.disabled"
+)
+
+// DisableFailingSubscriptions applies the auto-disable policy of every tenant having one
+// A subscription is deactivated once at least the policy's number of its deliveries failed within the policy's
+// window and none succeeded; failures before it was last enabled again are not counted. The reason is recorded
+// on the subscription and in its history, and the policy's notification subscription is told
+// Instances sharing the database take turns, so a subscription is disabled by one instance
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//
+// Returns:
+//   - int: Number of subscriptions disabled
+//   - error: If the policies cannot be loaded
+func (s *webhookService) DisableFailingSubscriptions(ctx context.Context) (int, error) {
+	disabled := 0
+	_, err := tryInstanceLock(ctx, s.locks, autoDisableLock, func() error {
+		policies, err := s.tenantRepo.ListAutoDisablePolicies(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load auto-disable policies: %w", err)
+		}
+
+		now := s.clock.Now()
+		for _, settings := range policies {
+			if ctx.Err() != nil {
+				return nil
+			}
+			policy := settings.AutoDisable
+			subscriptions, err := s.repo.GetFailingSubscriptions(ctx, settings.TenantID, now.AddDate(0, 0, -policy.Days), policy.Failures)
+			if err != nil {
+				s.logger.Error(ctx, "Failed to load failing webhooks",
+					zap.String("tenant_id", settings.TenantID),
+					zap.Error(err))
+				continue
+			}
+			for _, subscription := range subscriptions {
+				if s.disableSubscription(ctx, subscription, policy, now) {
+					disabled++
+				}
+			}
+		}
+		return nil
+	})
+	return disabled, err
+}
+
+// disableSubscription deactivates a failing subscription, records it in the subscription's history and notifies
+// the policy's notification subscription
+// Returns false if the subscription could not be disabled or was not active anymore
+func (s *webhookService) disableSubscription(ctx context.Context, subscription models.WebhookSubscription, policy models.AutoDisablePolicy, now time.Time) bool {
+	reason := fmt.Sprintf("auto-disabled: %d or more deliveries failed and none succeeded in %d days", policy.Failures, policy.Days)
+	changed, err := s.repo.DisableSubscription(ctx, subscription.ID, now, reason)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to disable failing webhook",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.Error(err))
+		return false
+	}
+	if !changed {
+		return false
+	}
+
+	s.logger.Warn(ctx, "Webhook subscription disabled by auto-disable policy",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.String("tenant_id", subscription.TenantID),
+		zap.String("target_url", subscription.TargetURL),
+		zap.String("reason", reason))
+
+	subscription.IsActive = false
+	subscription.DisabledAt = &now
+	subscription.DisabledReason = reason
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeDisabled, subscription)
+
+	if policy.NotifyWebhookID != nil {
+		s.notifySubscriptionDisabled(ctx, subscription, *policy.NotifyWebhookID)
+	}
+	return true
+}
+
+// notifySubscriptionDisabled tells the tenant's notification subscription that a subscription was disabled
+// Notification subscriptions of another tenant are not notified; failed notifications are logged and not retried
+func (s *webhookService) notifySubscriptionDisabled(ctx context.Context, subscription models.WebhookSubscription, notifyID uuid.UUID) {
+	target, err := s.repo.GetSubscriptionByID(ctx, notifyID)
+	if err != nil || target.TenantID != subscription.TenantID {
+		s.logger.Warn(ctx, "Auto-disable notification webhook not found",
+			zap.String("tenant_id", subscription.TenantID),
+			zap.String("notify_webhook_id", notifyID.String()))
+		return
+	}
+
+	payload := map[string]interface{}{
+		"webhook_id":  subscription.ID.String(),
+		"app_name":    subscription.AppName,
+		"event":       subscription.SubscribedEvent,
+		"target_url":  subscription.TargetURL,
+		"reason":      subscription.DisabledReason,
+		"disabled_at": subscription.DisabledAt.Format(time.RFC3339),
+		"severity":    string(models.NotificationSeverityWarning),
+	}
+	if err := s.SendNotification(ctx, notifyID, subscriptionDisabledEvent, payload); err != nil {
+		s.logger.Warn(ctx, "Failed to send auto-disable notification",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.String("notify_webhook_id", notifyID.String()),
+			zap.Error(err))
+	}
+}
+
+// RunAutoDisabler applies the auto-disable policies every interval until ctx is cancelled
+// Parameters:
+//   - ctx: Context whose cancellation stops the disabler
+//   - interval: Time between passes
+func (s *webhookService) RunAutoDisabler(ctx context.Context, interval time.Duration) {
+	for {
+		if disabled, err := s.DisableFailingSubscriptions(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to apply auto-disable policies", zap.Error(err))
+		} else if disabled > 0 {
+			s.logger.Info(ctx, "Disabled failing webhooks", zap.Int("disabled", disabled))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// EnableWebhook activates a deactivated webhook subscription again
+// The reason it was deactivated is cleared, and the auto-disable policy counts only failures made from now on
+// Enabling an active subscription changes nothing; with a tenant given, subscriptions of other tenants are not found
+//
+// Use case: Resuming deliveries once a dead receiver was fixed
+func (s *webhookService) EnableWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error) {
+	ctx = repository.WithTenantScope(ctx, tenantID)
+	subscription, err := s.repo.GetSubscriptionByID(ctx, webhookID)
+	if err != nil {
+		return nil, notFound(ErrWebhookNotFound, err)
+	}
+	if subscription.IsActive {
+		return subscription, nil
+	}
+
+	now := s.clock.Now()
+	if err := s.repo.EnableSubscription(ctx, webhookID, now); err != nil {
+		return nil, fmt.Errorf("failed to enable webhook: %w", err)
+	}
+	markEnabled(subscription, now)
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeEnabled, subscription)
+
+	s.logger.Info(ctx, "Webhook subscription enabled",
+		zap.String("webhook_id", webhookID.String()),
+		zap.String("tenant_id", subscription.TenantID))
+	return subscription, nil
+}
+
+// markEnabled activates a subscription in memory, clearing why it was deactivated, as EnableSubscription does
+func markEnabled(subscription *models.WebhookSubscription, now time.Time) {
+	subscription.IsActive = true
+	subscription.DisabledAt = nil
+	subscription.DisabledReason = ""
+	subscription.ReenabledAt = &now
+}
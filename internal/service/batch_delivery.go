@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// validateBatchPolicy checks the batching requested for a subscription with a target type
+// Batches are JSON arrays of event envelopes, so only HTTP targets can receive them
+func validateBatchPolicy(policy models.BatchPolicy, targetType models.TargetType) error {
+	if targetType != models.TargetTypeHTTP {
+		return fmt.Errorf("only http targets can be batched")
+	}
+	if maxWindow := int(models.MaxBatchWindow / time.Second); policy.WindowSeconds < 1 || policy.WindowSeconds > maxWindow {
+		return fmt.Errorf("window_seconds must be between 1 and %d", maxWindow)
+	}
+	if policy.MaxEvents < 0 || policy.MaxEvents > models.MaxBatchEvents {
+		return fmt.Errorf("max_events must be between 0 and %d", models.MaxBatchEvents)
+	}
+	return nil
+}
+
+// batchDelivery buffers a delivery for the subscription's next batch
+// The result is marked queued and batched, or failed with the error if the delivery cannot be stored
+func (s *webhookService) batchDelivery(ctx context.Context, subscription models.WebhookSubscription, eventID uuid.UUID, expiresAt *time.Time, payload []byte) models.WebhookDeliveryResult {
+	result := models.WebhookDeliveryResult{
+		WebhookID: subscription.ID,
+		TargetURL: subscription.TargetURL,
+	}
+	delivery := &models.BatchedDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		EventID:        eventID,
+		TenantID:       subscription.TenantID,
+		ExpiresAt:      expiresAt,
+		Payload:        string(payload),
+		CreatedAt:      s.clock.Now(),
+	}
+	if err := s.repo.CreateBatchedDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		s.logger.Error(ctx, "Failed to buffer delivery for batching webhook",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.String("event_id", eventID.String()),
+			zap.Error(err))
+		errMsg := fmt.Sprintf("failed to buffer delivery for batch: %v", err)
+		result.Error = &errMsg
+		return result
+	}
+
+	result.Queued = true
+	result.Batched = true
+	return result
+}
+
+// FlushDeliveryBatches sends the due batches of the batching subscriptions
+// A batch is due once the subscription's batch size is buffered or its oldest delivery has waited the
+// subscription's batch window; a subscription's batches are sent by one instance at a time
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//
+// Returns:
+//   - int: Number of buffered deliveries sent
+//   - error: If the subscriptions with due batches or the delivery pauses cannot be loaded
+func (s *webhookService) FlushDeliveryBatches(ctx context.Context) (int, error) {
+	subscriptions, err := s.repo.GetSubscriptionsWithDueBatches(ctx, s.clock.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to load subscriptions with due batches: %w", err)
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, subscription := range subscriptions {
+		// Batches of tenants whose deliveries are paused are sent once the delivery pause is resumed
+		if pauses.holds(subscription.TenantID) {
+			continue
+		}
+		_, err := tryInstanceLock(ctx, s.locks, deliveryBatchLockPrefix+subscription.ID.String(), func() error {
+			sent += s.flushSubscriptionBatches(ctx, subscription)
+			return nil
+		})
+		if err != nil {
+			s.logger.Error(ctx, "Failed to lock batching webhook for flushing",
+				zap.String("webhook_id", subscription.ID.String()),
+				zap.Error(err))
+		}
+	}
+	return sent, nil
+}
+
+// flushSubscriptionBatches sends a subscription's due batches oldest first, until the buffered deliveries
+// left are a partial batch within its window, a batch fails or the receiver requests a pause
+// Subscriptions that stopped batching have what is left buffered sent as one last batch
+// Callers hold the subscription's batch lock; returns the number of buffered deliveries sent
+func (s *webhookService) flushSubscriptionBatches(ctx context.Context, subscription models.WebhookSubscription) int {
+	maxEvents := subscription.BatchMaxEvents
+	if maxEvents <= 0 {
+		maxEvents = models.MaxBatchEvents
+	}
+	window := time.Duration(subscription.BatchWindowSeconds) * time.Second
+	headers := subscription.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, subscription.TenantID))
+
+	sent := 0
+	for ctx.Err() == nil {
+		deliveries, err := s.repo.GetBatchedDeliveries(ctx, subscription.ID, maxEvents)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to load buffered deliveries",
+				zap.String("webhook_id", subscription.ID.String()),
+				zap.Error(err))
+			return sent
+		}
+		// Another instance may have sent the batch since the due subscriptions were loaded
+		if len(deliveries) == 0 || (len(deliveries) < maxEvents && s.clock.Now().Sub(deliveries[0].CreatedAt) < window) {
+			return sent
+		}
+
+		batchSent, ok := s.sendBatch(ctx, subscription, headers, deliveries)
+		sent += batchSent
+		if !ok {
+			return sent
+		}
+	}
+	return sent
+}
+
+// sendBatch delivers buffered deliveries as one JSON array signed as a whole, and records each event's outcome
+// A batch the receiver did not accept fails all its events; a receiver accepting it can still reject some of
+// them in a models.BatchDeliveryReport. A batch not accepted because the receiver requested a pause stays
+// buffered until the pause is released
+// Deliveries of events that expired while buffered are dropped instead of sent
+// Returns the number of deliveries sent, and whether the next batch can be sent right away
+func (s *webhookService) sendBatch(ctx context.Context, subscription models.WebhookSubscription, headers models.SigningHeaders, deliveries []models.BatchedDelivery) (int, bool) {
+	deliveries, ok := s.dropExpiredBatchedDeliveries(ctx, subscription, deliveries)
+	if !ok || len(deliveries) == 0 {
+		return 0, ok
+	}
+
+	items := make([]json.RawMessage, len(deliveries))
+	ids := make([]uuid.UUID, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = json.RawMessage(delivery.Payload)
+		ids[i] = delivery.ID
+	}
+	body, err := json.Marshal(items)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to build delivery batch",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.Error(err))
+		return 0, false
+	}
+
+	batchID := uuid.New()
+	delivery := &Delivery{Subscription: subscription, Headers: headers, MessageID: batchID.String(), Body: body, Raw: true}
+	result, pause, answer := s.sendDelivery(ctx, delivery, batchID)
+	if pause > 0 {
+		s.pauseSubscription(ctx, subscription, pause)
+		if !result.Success {
+			return 0, false
+		}
+	}
+
+	// Deliveries that cannot be removed would be sent again, so stop until the next pass
+	if err := s.repo.DeleteBatchedDeliveries(context.WithoutCancel(ctx), ids); err != nil {
+		s.logger.Error(ctx, "Failed to remove sent buffered deliveries",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.String("batch_id", batchID.String()),
+			zap.Error(err))
+		return 0, false
+	}
+
+	var failures map[uuid.UUID]string
+	if result.Success {
+		failures = batchFailures(answer)
+	}
+	rejected := 0
+	for _, delivery := range deliveries {
+		itemResult := result
+		if reason, ok := failures[delivery.EventID]; ok {
+			errMsg := fmt.Sprintf("rejected in batch %s", batchID)
+			if reason != "" {
+				errMsg += ": " + reason
+			}
+			itemResult.Success = false
+			itemResult.Error = &errMsg
+			rejected++
+		}
+		s.recordQueuedDeliveryOutcome(ctx, delivery.EventID, itemResult)
+	}
+
+	s.logger.Info(ctx, "Webhook delivery batch sent",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.String("batch_id", batchID.String()),
+		zap.Int("events", len(deliveries)),
+		zap.Bool("success", result.Success),
+		zap.Int("rejected", rejected))
+	return len(deliveries), result.Success && pause <= 0
+}
+
+// dropExpiredBatchedDeliveries removes the buffered deliveries of expired events and records them as expired
+// Returns the deliveries left to send, and false when the expired ones cannot be removed
+func (s *webhookService) dropExpiredBatchedDeliveries(ctx context.Context, subscription models.WebhookSubscription, deliveries []models.BatchedDelivery) ([]models.BatchedDelivery, bool) {
+	now := s.clock.Now()
+	live := make([]models.BatchedDelivery, 0, len(deliveries))
+	var expired []models.BatchedDelivery
+	var ids []uuid.UUID
+	for _, delivery := range deliveries {
+		if eventExpired(delivery.ExpiresAt, now) {
+			expired = append(expired, delivery)
+			ids = append(ids, delivery.ID)
+			continue
+		}
+		live = append(live, delivery)
+	}
+	if len(expired) == 0 {
+		return deliveries, true
+	}
+
+	if err := s.repo.DeleteBatchedDeliveries(context.WithoutCancel(ctx), ids); err != nil {
+		s.logger.Error(ctx, "Failed to remove expired buffered deliveries",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.Error(err))
+		return nil, false
+	}
+	errMsg := errEventExpired.Error()
+	for _, delivery := range expired {
+		s.recordQueuedDeliveryOutcome(ctx, delivery.EventID, models.WebhookDeliveryResult{
+			WebhookID: subscription.ID,
+			TargetURL: subscription.TargetURL,
+			Error:     &errMsg,
+			Expired:   true,
+		})
+	}
+
+	s.logger.Info(ctx, "Buffered deliveries of expired events dropped",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.Int("expired", len(expired)))
+	return live, true
+}
+
+// batchFailures returns the events a receiver's answer to a batch reports as rejected, with their reasons
+// Answers that are not a models.BatchDeliveryReport reject none
+func batchFailures(answer []byte) map[uuid.UUID]string {
+	var report models.BatchDeliveryReport
+	if len(answer) == 0 || json.Unmarshal(answer, &report) != nil {
+		return nil
+	}
+	failures := make(map[uuid.UUID]string, len(report.Failed))
+	for _, failure := range report.Failed {
+		failures[failure.EventID] = failure.Error
+	}
+	return failures
+}
+
+// RunBatchFlusher sends the due delivery batches every interval until ctx is cancelled
+// Parameters:
+//   - ctx: Context whose cancellation stops the flusher
+//   - interval: Time between flush passes, bounding how late a batch is sent after it is due
+func (s *webhookService) RunBatchFlusher(ctx context.Context, interval time.Duration) {
+	for {
+		if sent, err := s.FlushDeliveryBatches(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to flush webhook delivery batches", zap.Error(err))
+		} else if sent > 0 {
+			s.logger.Info(ctx, "Flushed webhook delivery batches", zap.Int("sent", sent))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
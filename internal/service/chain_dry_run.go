@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// dryRunDialTimeout bounds the connection attempt that checks whether a webhook target is reachable
+const dryRunDialTimeout = 3 * time.Second
+
+// A dry run walks a chain's steps in execution order with the real template and condition code but without
+// calling webhooks or recording a run. Webhook steps are assumed to succeed with status 200 and the response
+// given for them in simulated_responses; templates that read the response of a step without one fail, so
+// the plan shows which data a step needs. Approval steps are assumed approved. Every webhook target is
+// checked by opening a TCP connection to it, which sends nothing. The per-run options apply as they
+// would to the run, including the step results reused from a source run
+//
+// validation_only skips the simulation and only checks the webhooks and the syntax of the templates
+
+// DryRunChain checks a chain instead of running it and returns the simulated plan
+// Inactive chains can be checked too, so a paused chain can be verified before it is activated
+func (s *executionChainService) DryRunChain(ctx context.Context, tenantID string, req *models.ExecuteChainRequest) (*models.ChainDryRunResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), req.ChainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+
+	options := models.ChainExecutionOptions{}
+	if req.Options != nil {
+		options = *req.Options
+	}
+	for key := range options.SimulatedResponses {
+		if !strings.HasPrefix(key, "step_") {
+			return nil, ErrInvalidRun.Withf("simulated_responses: key %q must name a step as step_N", key)
+		}
+	}
+
+	runOptions, source, err := s.prepareRunOptions(ctx, chain, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	triggerData := req.TriggerData
+	if triggerData == nil && source != nil && source.TriggerData != "" {
+		if err := json.Unmarshal([]byte(revealPayload(ctx, s.logger, s.tenantRepo, source.TenantID, source.TriggerData)), &triggerData); err != nil {
+			return nil, ErrInvalidRun.Withf("invalid trigger data of source run")
+		}
+	}
+	startAt := 0
+	if runOptions != nil {
+		startAt = runOptions.StartAtStep
+	}
+
+	mode := "dry_run"
+	if options.ValidationOnly {
+		mode = "validation_only"
+	}
+	response := &models.ChainDryRunResponse{
+		ChainID:      chain.ID,
+		ChainVersion: chain.Version,
+		Mode:         mode,
+		Steps:        []models.DryRunStep{},
+	}
+
+	probes := make(map[string]error)
+	check := func(webhookID uuid.UUID, webhook *models.WebhookSubscription) *models.DryRunTarget {
+		target := &models.DryRunTarget{WebhookID: webhookID}
+		if webhook == nil {
+			target.Error = "webhook not found"
+			return target
+		}
+		target.TargetURL = webhook.TargetURL
+		target.Active = webhook.IsActive
+		probeErr, probed := probes[webhook.TargetURL]
+		if !probed {
+			probeErr = probeTarget(ctx, webhook.TargetURL)
+			probes[webhook.TargetURL] = probeErr
+		}
+		target.Reachable = probeErr == nil
+		switch {
+		case probeErr != nil:
+			target.Error = probeErr.Error()
+		case !webhook.IsActive:
+			target.Error = "webhook is not active"
+		}
+		return target
+	}
+
+	rc := newRunContext(triggerData, seedStepRuns(source, runOptions))
+	rc.applyRunOptions(runOptions)
+	stopped := ""
+	for _, step := range simulationOrder(chain.Steps) {
+		planned := models.DryRunStep{
+			StepOrder: step.StepOrder,
+			Name:      step.Name,
+			Type:      step.Type,
+		}
+		if step.WebhookID != nil {
+			planned.Target = check(*step.WebhookID, step.Webhook)
+		}
+		if step.CompensationWebhookID != nil {
+			planned.Compensation = check(*step.CompensationWebhookID, step.CompensationWebhook)
+		}
+
+		if options.ValidationOnly {
+			planned.Action = "check"
+			planned.Errors = validateStepTemplates(&step)
+		} else if rc.succeeded(step.StepOrder) {
+			planned.Action = "reuse"
+			planned.Note = "result reused from the source run"
+		} else if stopped != "" {
+			planned.Action = "skip"
+			planned.SkipReason = stopped
+		} else if step.StepOrder < startAt {
+			planned.Action = "skip"
+			planned.SkipReason = "before start_at_step"
+		} else {
+			s.simulateStep(&step, rc, options.SimulatedResponses, &planned)
+			switch {
+			case planned.Action == "skip":
+			case step.OnSuccessAction == "stop":
+				stopped = fmt.Sprintf("run stops after step %d", step.StepOrder)
+			case step.OnSuccessAction == "pause":
+				planned.Note = "run pauses after this step until it is resumed"
+			}
+		}
+
+		for _, target := range []*models.DryRunTarget{planned.Target, planned.Compensation} {
+			if target != nil && target.Error != "" {
+				planned.Errors = append(planned.Errors, fmt.Sprintf("webhook %s: %s", target.WebhookID, target.Error))
+			}
+		}
+		response.Steps = append(response.Steps, planned)
+	}
+
+	if chain.CompletionWebhookID != nil {
+		webhook, err := s.webhookRepo.GetSubscriptionByID(ctx, *chain.CompletionWebhookID)
+		if err != nil {
+			webhook = nil
+		}
+		response.Completion = check(*chain.CompletionWebhookID, webhook)
+		if response.Completion.Error != "" {
+			response.Errors = append(response.Errors, fmt.Sprintf("completion webhook %s: %s", *chain.CompletionWebhookID, response.Completion.Error))
+		}
+	}
+
+	if !options.ValidationOnly {
+		response.Variables = rc.variables()
+	}
+	response.Valid = len(response.Errors) == 0
+	for _, planned := range response.Steps {
+		if len(planned.Errors) > 0 {
+			response.Valid = false
+		}
+	}
+	return response, nil
+}
+
+// simulateStep works out what a step would do at its point of the run and applies its assumed result to rc
+func (s *executionChainService) simulateStep(step *models.ExecutionChainStep, rc *runContext, responses map[string]interface{}, planned *models.DryRunStep) {
+	if rc.excluded(step.StepOrder) {
+		planned.Action = "skip"
+		planned.SkipReason = "excluded by steps_to_skip"
+		return
+	}
+	if step.BranchPath != "" && !rc.onTakenPath(step.BranchPath) {
+		planned.Action = "skip"
+		planned.SkipReason = fmt.Sprintf("branch path %s not taken", step.BranchPath)
+		return
+	}
+
+	if step.Condition != "" {
+		holds, err := evaluateCondition(step.Condition, rc.templateData())
+		if err != nil {
+			planned.Action = "skip"
+			planned.Errors = append(planned.Errors, fmt.Sprintf("condition could not be evaluated: %v", err))
+			return
+		}
+		planned.ConditionResult = &holds
+		if !holds {
+			planned.Action = "skip"
+			planned.SkipReason = "condition not met"
+			return
+		}
+	}
+
+	if !step.Type.CallsWebhook() {
+		planned.Action = string(step.Type)
+		output, err := builtinStepOutput(step, rc)
+		if err != nil {
+			planned.Errors = append(planned.Errors, err.Error())
+			return
+		}
+		if step.Type == models.StepTypeTransform {
+			planned.RequestParams = output
+		}
+		if step.Type == models.StepTypeBranch {
+			planned.Branch, _ = output["path"].(string)
+		}
+		outputBytes, err := json.Marshal(output)
+		if err != nil {
+			planned.Errors = append(planned.Errors, fmt.Sprintf("invalid step output: %v", err))
+			return
+		}
+		outputJSON := string(outputBytes)
+		rc.applyBuiltinResult(step, output)
+		rc.record(step.StepOrder, step.Name, nil, &outputJSON)
+		rc.extract(step)
+		return
+	}
+
+	planned.Action = "call"
+	if step.Type == models.StepTypeApproval {
+		planned.Action = "await_approval"
+		planned.Note = "run waits for an approval decision; later steps assume it is approved"
+	}
+	params, err := renderStepParams(step, rc)
+	if err != nil {
+		planned.Errors = append(planned.Errors, err.Error())
+	}
+	planned.RequestParams = params
+
+	statusCode := 200
+	var body *string
+	if response, ok := responses[stepKey(step.StepOrder)]; ok {
+		if responseBytes, err := json.Marshal(response); err == nil {
+			responseJSON := string(responseBytes)
+			body = &responseJSON
+		}
+	}
+	rc.record(step.StepOrder, step.Name, &statusCode, body)
+	rc.extract(step)
+}
+
+// simulationOrder returns the steps in an order they can run in: step order for sequential chains,
+// and for dependency graphs step order among the steps whose dependencies have been placed
+func simulationOrder(steps []models.ExecutionChainStep) []models.ExecutionChainStep {
+	sorted := make([]models.ExecutionChainStep, len(steps))
+	copy(sorted, steps)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StepOrder < sorted[j].StepOrder
+	})
+	if !hasStepDependencies(sorted) {
+		return sorted
+	}
+
+	placed := make(map[uuid.UUID]bool, len(sorted))
+	ordered := make([]models.ExecutionChainStep, 0, len(sorted))
+	for len(ordered) < len(sorted) {
+		progressed := false
+		for _, step := range sorted {
+			if placed[step.ID] {
+				continue
+			}
+			ready := true
+			for _, dependency := range step.DependsOn {
+				if !placed[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				placed[step.ID] = true
+				ordered = append(ordered, step)
+				progressed = true
+				break
+			}
+		}
+		// Dependencies are validated to be acyclic; stop instead of looping on a corrupted graph
+		if !progressed {
+			break
+		}
+	}
+	return ordered
+}
+
+// validateStepTemplates checks that the request params and compensation params of a step parse as templates
+func validateStepTemplates(step *models.ExecutionChainStep) []string {
+	var errs []string
+	for _, source := range []struct {
+		path   string
+		params *string
+	}{
+		{"request_params", &step.RequestParams},
+		{"compensation_params", step.CompensationParams},
+	} {
+		if source.params == nil || *source.params == "" {
+			continue
+		}
+		var params interface{}
+		if err := json.Unmarshal([]byte(*source.params), &params); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid %s: %v", source.path, err))
+			continue
+		}
+		if err := parseTemplateValue(params, source.path); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// parseTemplateValue parses the templates nested in a decoded JSON value without rendering them
+func parseTemplateValue(value interface{}, path string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if err := parseTemplateValue(item, path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if err := parseTemplateValue(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		if strings.Contains(v, "{{") {
+			if _, err := template.New(path).Funcs(stepTemplateFuncs).Parse(v); err != nil {
+				return fmt.Errorf("%s: invalid template: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// probeTarget checks that a webhook target accepts TCP connections, without sending a request
+func probeTarget(ctx context.Context, targetURL string) error {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid target URL")
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	dialer := net.Dialer{Timeout: dryRunDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(parsed.Hostname(), port))
+	if err != nil {
+		return fmt.Errorf("target not reachable: %w", err)
+	}
+	conn.Close()
+	return nil
+}
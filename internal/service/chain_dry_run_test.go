@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+)
+
+// createChain creates a chain of the tenant with the steps and returns its ID
+func (env *memoryChains) createChain(t *testing.T, steps ...models.CreateExecutionChainStep) uuid.UUID {
+	t.Helper()
+	chain, err := env.service.CreateChain(context.Background(), &models.CreateExecutionChainRequest{
+		TenantID: chainTenant, Name: "Order fulfilment", TriggerEvent: "order.created", Steps: steps,
+	})
+	require.NoError(t, err)
+	return chain.ChainID
+}
+
+// TestDryRunChain_DispatchesNothing tests that a dry run and a validation return the plan of the chain without
+// calling any step webhook or recording a run, the dry run rendering the params each step would send
+func TestDryRunChain_DispatchesNothing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+	chainID := env.createChain(t,
+		models.CreateExecutionChainStep{Name: "Reserve", WebhookID: env.webhook(t, server, "/reserve"),
+			RequestParams: map[string]interface{}{"order": "{{.trigger_data.order_id}}"}},
+		models.CreateExecutionChainStep{Name: "Charge", WebhookID: env.webhook(t, server, "/charge"),
+			RequestParams: map[string]interface{}{"reservation": "{{.step_1.response.reservation_id}}"}},
+	)
+	options := map[string]*models.ChainExecutionOptions{
+		"dry_run": {DryRun: true, SimulatedResponses: map[string]interface{}{
+			"step_1": map[string]interface{}{"reservation_id": "res-9"},
+		}},
+		"validation_only": {ValidationOnly: true},
+	}
+
+	for mode, opts := range options {
+		t.Run(mode, func(t *testing.T) {
+			// Act
+			plan, err := env.service.DryRunChain(ctx, chainTenant, &models.ExecuteChainRequest{
+				ChainID: chainID, TriggerData: map[string]interface{}{"order_id": "ord-1"}, Options: opts,
+			})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, mode, plan.Mode)
+			assert.True(t, plan.Valid, "%+v", plan)
+			require.Len(t, plan.Steps, 2)
+			for _, step := range plan.Steps {
+				require.NotNil(t, step.Target, step.Name)
+				assert.True(t, step.Target.Reachable, step.Name)
+				assert.Empty(t, step.Errors, step.Name)
+			}
+			if mode == "dry_run" {
+				assert.Equal(t, "call", plan.Steps[0].Action)
+				assert.Equal(t, map[string]interface{}{"order": "ord-1"}, plan.Steps[0].RequestParams)
+				assert.Equal(t, map[string]interface{}{"reservation": "res-9"}, plan.Steps[1].RequestParams)
+			} else {
+				assert.Equal(t, "check", plan.Steps[0].Action)
+				assert.Nil(t, plan.Steps[0].RequestParams)
+			}
+		})
+	}
+
+	assert.Empty(t, server.Calls(), "no step webhook may be called")
+	runs, err := env.service.ListChainRuns(ctx, chainTenant, chainID, models.ListFilter{}, models.ListPage{})
+	require.NoError(t, err)
+	assert.Zero(t, runs.Total, "no run may be recorded")
+}
+
+// TestDryRunChain_ValidationErrors tests that unreachable and inactive webhooks, missing simulated responses
+// and malformed options are reported back instead of being found by a run
+func TestDryRunChain_ValidationErrors(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+	offline := newStepServer(t, nil)
+	inactiveID := env.webhook(t, server, "/notify")
+	chainID := env.createChain(t,
+		models.CreateExecutionChainStep{Name: "Reserve", WebhookID: env.webhook(t, offline, "/reserve")},
+		models.CreateExecutionChainStep{Name: "Charge", WebhookID: env.webhook(t, server, "/charge"),
+			RequestParams: map[string]interface{}{"reservation": "{{.step_1.response.reservation_id}}"}},
+		models.CreateExecutionChainStep{Name: "Notify", WebhookID: inactiveID},
+	)
+	inactive, err := env.webhooks.GetSubscriptionByID(ctx, *inactiveID)
+	require.NoError(t, err)
+	inactive.IsActive = false
+	require.NoError(t, env.webhooks.UpdateSubscription(ctx, inactive))
+	offline.Close()
+
+	t.Run("dry run", func(t *testing.T) {
+		// Act
+		plan, err := env.service.DryRunChain(ctx, chainTenant, &models.ExecuteChainRequest{
+			ChainID: chainID, Options: &models.ChainExecutionOptions{DryRun: true},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, plan.Valid)
+		require.Len(t, plan.Steps, 3)
+		assert.False(t, plan.Steps[0].Target.Reachable)
+		require.Len(t, plan.Steps[0].Errors, 1)
+		assert.Contains(t, plan.Steps[0].Errors[0], "target not reachable")
+		require.NotEmpty(t, plan.Steps[1].Errors)
+		assert.Contains(t, plan.Steps[1].Errors[0], "reservation")
+		assert.Equal(t, []string{"webhook " + inactiveID.String() + ": webhook is not active"}, plan.Steps[2].Errors)
+	})
+
+	t.Run("validation only", func(t *testing.T) {
+		// Act
+		plan, err := env.service.DryRunChain(ctx, chainTenant, &models.ExecuteChainRequest{
+			ChainID: chainID, Options: &models.ChainExecutionOptions{ValidationOnly: true},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, plan.Valid)
+		assert.NotEmpty(t, plan.Steps[0].Errors)
+		assert.Empty(t, plan.Steps[1].Errors, "validation does not simulate responses")
+		assert.NotEmpty(t, plan.Steps[2].Errors)
+	})
+
+	t.Run("invalid simulated response", func(t *testing.T) {
+		// Act
+		plan, err := env.service.DryRunChain(ctx, chainTenant, &models.ExecuteChainRequest{
+			ChainID: chainID, Options: &models.ChainExecutionOptions{DryRun: true,
+				SimulatedResponses: map[string]interface{}{"charge": map[string]interface{}{}}},
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, service.ErrInvalidRun)
+		assert.ErrorContains(t, err, `simulated_responses: key "charge" must name a step as step_N`)
+		assert.Nil(t, plan)
+	})
+
+	assert.Empty(t, server.Calls())
+}
@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// newRunControlService creates an execution chain service over mocked chain and tenant repositories
+func newRunControlService(t *testing.T) (service.ExecutionChainService, *mocks.MockExecutionChainRepository, *mocks.MockTenantRepository) {
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	return service.NewExecutionChainService(chainRepo, nil, tenantRepo, nil, nil, nil, logging.Nop()), chainRepo, tenantRepo
+}
+
+// awaitRunFinished expects the tenant settings lookups of a run's goroutine, for the payload protection when it
+// starts and to admit queued runs of the tenant once it stops, and returns a channel closed by the last one
+func awaitRunFinished(tenantRepo *mocks.MockTenantRepository, tenantID string) <-chan struct{} {
+	finished := make(chan struct{})
+	tenantRepo.EXPECT().GetTenantSettings(mock.Anything, tenantID).Return(&models.TenantSettings{TenantID: tenantID}, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(mock.Anything, tenantID).
+		RunAndReturn(func(context.Context, string) (*models.TenantSettings, error) {
+			close(finished)
+			return &models.TenantSettings{TenantID: tenantID}, nil
+		}).Once()
+	return finished
+}
+
+// TestResumeChainRun_SkipsSucceededSteps tests that a paused run resumes after the steps that already succeeded
+// and completes without calling them again
+func TestResumeChainRun_SkipsSucceededSteps(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, tenantRepo := newRunControlService(t)
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", IsActive: true, Steps: []models.ExecutionChainStep{
+		{StepOrder: 1, Name: "Order", OnSuccessAction: "continue"},
+		{StepOrder: 2, Name: "Charge", OnSuccessAction: "pause"},
+	}}
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID, TenantID: "tenant-123",
+		Status: models.ExecutionChainStatusPaused, CurrentStep: 2, TotalSteps: 2}
+
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123"}, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetStepRunsByRun(mock.Anything, run.ID).Return([]*models.ExecutionChainStepRun{
+		{StepOrder: 1, Status: models.WebhookStatusSent},
+		{StepOrder: 2, Status: models.WebhookStatusSent},
+	}, nil)
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, run.ID, run.Status, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == models.ExecutionChainStatusRunning
+	})).Return(true, nil).Once()
+	chainRepo.EXPECT().UpdateChainRunStatus(mock.Anything, run.ID, models.ExecutionChainStatusCompleted).Return(nil).Once()
+	chainRepo.EXPECT().GetChainRunByID(mock.Anything, run.ID).Return(run, nil).Once()
+	finished := awaitRunFinished(tenantRepo, "tenant-123")
+
+	// Act
+	response, err := chainService.ResumeChainRun(ctx, run.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ExecutionChainStatusRunning), response.Status)
+	assert.Equal(t, 3, response.CurrentStep, "both steps already succeeded")
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("resumed run did not complete")
+	}
+}
+
+// TestResumeChainRun_TenantPaused tests that a run resumed while its tenant's chains are paused is queued
+// instead of executed
+func TestResumeChainRun_TenantPaused(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, tenantRepo := newRunControlService(t)
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: uuid.New(), TenantID: "tenant-123",
+		Status: models.ExecutionChainStatusInterrupted, CurrentStep: 1}
+
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").
+		Return(&models.TenantSettings{TenantID: "tenant-123", ChainsPaused: true}, nil).Once()
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, run.ID, run.Status, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == models.ExecutionChainStatusQueued
+	})).Return(true, nil).Once()
+
+	// Act
+	response, err := chainService.ResumeChainRun(ctx, run.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ExecutionChainStatusQueued), response.Status)
+}
+
+// TestCancelChainRun_Paused tests that cancelling a paused run records the default reason and marks the steps
+// it did not run skipped
+func TestCancelChainRun_Paused(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, _ := newRunControlService(t)
+	chain := &models.ExecutionChain{ID: uuid.New(), Steps: []models.ExecutionChainStep{
+		{ID: uuid.New(), StepOrder: 1, Name: "Order"},
+		{ID: uuid.New(), StepOrder: 2, Name: "Charge"},
+		{ID: uuid.New(), StepOrder: 3, Name: "Ship"},
+	}}
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID, Status: models.ExecutionChainStatusPaused, CurrentStep: 1}
+
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetStepRunsByRun(ctx, run.ID).Return([]*models.ExecutionChainStepRun{
+		{StepOrder: 1, Status: models.WebhookStatusSent},
+	}, nil).Once()
+	var skipped []int
+	chainRepo.EXPECT().CreateStepRun(ctx, mock.Anything).RunAndReturn(func(_ context.Context, stepRun *models.ExecutionChainStepRun) error {
+		assert.Equal(t, models.WebhookStatusSkipped, stepRun.Status)
+		skipped = append(skipped, stepRun.StepOrder)
+		return nil
+	}).Twice()
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, run.ID, models.ExecutionChainStatusPaused, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == models.ExecutionChainStatusCancelled && updates["cancel_reason"] == "cancelled via API"
+	})).Return(true, nil).Once()
+	notified := make(chan struct{})
+	chainRepo.EXPECT().GetChainRunByID(mock.Anything, run.ID).
+		RunAndReturn(func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error) {
+			close(notified)
+			return run, nil
+		}).Once()
+
+	// Act
+	response, err := chainService.CancelChainRun(ctx, run.ID, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ExecutionChainStatusCancelled), response.Status)
+	assert.Equal(t, run.ID, response.RunID)
+	assert.Equal(t, []int{2, 3}, skipped)
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run completion was not notified")
+	}
+}
+
+// TestCancelChainRun_ResumedMeanwhile tests that a cancellation losing the race against a resume of the run
+// reports a conflict and records no skipped steps
+func TestCancelChainRun_ResumedMeanwhile(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, _ := newRunControlService(t)
+	chain := &models.ExecutionChain{ID: uuid.New(), Steps: []models.ExecutionChainStep{
+		{ID: uuid.New(), StepOrder: 1, Name: "Order"},
+	}}
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID, Status: models.ExecutionChainStatusPaused, CurrentStep: 1}
+
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetStepRunsByRun(ctx, run.ID).Return(nil, nil).Once()
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, run.ID, models.ExecutionChainStatusPaused, mock.Anything).Return(false, nil).Once()
+
+	// Act
+	response, err := chainService.CancelChainRun(ctx, run.ID, "")
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrInvalidRunState)
+	assert.ErrorContains(t, err, "run is no longer paused")
+	assert.Nil(t, response)
+}
+
+// TestRunControl_InvalidState tests that only paused or interrupted runs are resumed and that finished runs
+// cannot be cancelled, without changing the run
+func TestRunControl_InvalidState(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, _ := newRunControlService(t)
+	run := &models.ExecutionChainRun{ID: uuid.New(), Status: models.ExecutionChainStatusCompleted}
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Twice()
+
+	// Act
+	_, resumeErr := chainService.ResumeChainRun(ctx, run.ID)
+	_, cancelErr := chainService.CancelChainRun(ctx, run.ID, "")
+
+	// Assert
+	assert.ErrorContains(t, resumeErr, "only paused or interrupted runs can be resumed")
+	assert.ErrorContains(t, cancelErr, "cannot be cancelled")
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// upcomingScheduledRuns is the number of upcoming runs listed for a chain's schedule
+const upcomingScheduledRuns = 5
+
+// unfinishedRunStatuses are the statuses of runs a schedule's overlap policy applies to
+var unfinishedRunStatuses = []models.ExecutionChainStatus{models.ExecutionChainStatusQueued, models.ExecutionChainStatusRunning}
+
+// parseChainSchedule validates the schedule of a chain creation or schedule update request
+// Returns a nil schedule when the request has no schedule; the overlap policy defaults to skip
+func parseChainSchedule(req models.ChainScheduleRequest) (*cronSchedule, models.ScheduleOverlapPolicy, error) {
+	if req.Schedule == "" {
+		return nil, "", nil
+	}
+
+	schedule, err := parseCronSchedule(req.Schedule, req.ScheduleTimezone)
+	if err != nil {
+		return nil, "", ErrInvalidChain.Withf("invalid schedule: %s", err.Error())
+	}
+
+	policy := models.ScheduleOverlapPolicy(req.OverlapPolicy)
+	switch policy {
+	case "":
+		policy = models.ScheduleOverlapSkip
+	case models.ScheduleOverlapSkip, models.ScheduleOverlapQueue, models.ScheduleOverlapAllow:
+	default:
+		return nil, "", ErrInvalidChain.Withf("invalid overlap policy %q, expected skip, queue or allow", req.OverlapPolicy)
+	}
+
+	return schedule, policy, nil
+}
+
+// nextScheduledRun returns the first activation of a schedule after the given time,
+// or nil when the schedule never fires again
+func nextScheduledRun(schedule *cronSchedule, after time.Time) *time.Time {
+	next := schedule.next(after)
+	if next.IsZero() {
+		return nil
+	}
+	next = next.UTC()
+	return &next
+}
+
+// resumeSchedule adds the next run of a scheduled chain that is being activated to its updates,
+// so runs missed while the chain was inactive are not caught up
+func (s *executionChainService) resumeSchedule(chain *models.ExecutionChain, updates map[string]interface{}) {
+	if chain.Schedule == "" {
+		return
+	}
+	schedule, err := parseCronSchedule(chain.Schedule, chain.ScheduleTimezone)
+	if err != nil {
+		return
+	}
+	updates["next_run_at"] = nextScheduledRun(schedule, s.clock.Now())
+}
+
+// GetChainSchedule retrieves a chain's schedule with its next runs
+func (s *executionChainService) GetChainSchedule(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainScheduleResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	return s.chainScheduleResponse(chain)
+}
+
+// SetChainSchedule sets or, with an empty schedule, removes the cron schedule of a chain
+// The next run is computed from the current time, so changing a schedule never catches up missed runs
+func (s *executionChainService) SetChainSchedule(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.ChainScheduleRequest) (*models.ChainScheduleResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+
+	schedule, policy, err := parseChainSchedule(*req)
+	if err != nil {
+		return nil, err
+	}
+
+	chain.Schedule = req.Schedule
+	chain.ScheduleTimezone = req.ScheduleTimezone
+	chain.OverlapPolicy = policy
+	chain.NextRunAt = nil
+	if schedule != nil {
+		chain.NextRunAt = nextScheduledRun(schedule, s.clock.Now())
+	} else {
+		chain.ScheduleTimezone = ""
+	}
+
+	if err := s.chainRepo.UpdateChain(ctx, chainID, map[string]interface{}{
+		"schedule":          chain.Schedule,
+		"schedule_timezone": chain.ScheduleTimezone,
+		"overlap_policy":    chain.OverlapPolicy,
+		"next_run_at":       chain.NextRunAt,
+		"updated_at":        s.clock.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update chain schedule: %w", err)
+	}
+	s.recordChainSnapshot(ctx, chainID, models.ConfigChangeUpdated)
+
+	s.logger.Info(ctx, "Execution chain schedule updated",
+		zap.String("chain_id", chainID.String()),
+		zap.String("schedule", chain.Schedule),
+		zap.String("timezone", chain.ScheduleTimezone))
+
+	return s.chainScheduleResponse(chain)
+}
+
+// chainScheduleResponse builds the schedule response of a chain, listing its upcoming runs from now
+func (s *executionChainService) chainScheduleResponse(chain *models.ExecutionChain) (*models.ChainScheduleResponse, error) {
+	response := &models.ChainScheduleResponse{
+		ChainID:          chain.ID,
+		Schedule:         chain.Schedule,
+		ScheduleTimezone: chain.ScheduleTimezone,
+		OverlapPolicy:    string(chain.OverlapPolicy),
+		IsActive:         chain.IsActive,
+		NextRunAt:        chain.NextRunAt,
+		LastScheduledAt:  chain.LastScheduledAt,
+		UpcomingRuns:     []time.Time{},
+	}
+	if chain.Schedule == "" {
+		return response, nil
+	}
+
+	schedule, err := parseCronSchedule(chain.Schedule, chain.ScheduleTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored schedule: %w", err)
+	}
+
+	// A due run that has not been triggered yet comes first
+	after := s.clock.Now()
+	if chain.NextRunAt != nil && !chain.NextRunAt.After(after) {
+		response.UpcomingRuns = append(response.UpcomingRuns, *chain.NextRunAt)
+	}
+	response.UpcomingRuns = append(response.UpcomingRuns, schedule.upcoming(after, upcomingScheduledRuns-len(response.UpcomingRuns))...)
+
+	return response, nil
+}
+
+// TriggerScheduledChains creates the runs of every active chain whose scheduled run is due
+// Each due chain triggers at most one run per pass, so activations missed while the scheduler
+// was not running are collapsed into a single run
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//
+// Returns:
+//   - int: Number of runs created
+//   - error: If the due chains cannot be loaded
+func (s *executionChainService) TriggerScheduledChains(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	chains, err := s.chainRepo.GetDueScheduledChains(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load scheduled chains: %w", err)
+	}
+
+	triggered := 0
+	for _, chain := range chains {
+		if ctx.Err() != nil {
+			break
+		}
+		if s.triggerScheduledChain(ctx, chain, now) {
+			triggered++
+		}
+	}
+	return triggered, nil
+}
+
+// triggerScheduledChain claims a chain's due run and creates it according to the overlap policy
+// Returns whether a run was created
+func (s *executionChainService) triggerScheduledChain(ctx context.Context, chain *models.ExecutionChain, now time.Time) bool {
+	due := *chain.NextRunAt
+
+	// An unparseable schedule is claimed without a next run so it stops being picked up
+	var next *time.Time
+	schedule, err := parseCronSchedule(chain.Schedule, chain.ScheduleTimezone)
+	if err != nil {
+		s.logger.Error(ctx, "Invalid chain schedule, scheduled runs stopped",
+			zap.String("chain_id", chain.ID.String()),
+			zap.String("schedule", chain.Schedule),
+			zap.Error(err))
+	} else {
+		next = nextScheduledRun(schedule, now)
+	}
+
+	claimed, err := s.chainRepo.ClaimScheduledRun(ctx, chain.ID, due, next, now)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to claim scheduled chain run",
+			zap.String("chain_id", chain.ID.String()),
+			zap.Error(err))
+		return false
+	}
+	if !claimed || schedule == nil {
+		return false
+	}
+
+	unfinished, err := s.chainRepo.GetChainRunsByChainAndStatus(ctx, chain.ID, unfinishedRunStatuses)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load unfinished chain runs",
+			zap.String("chain_id", chain.ID.String()),
+			zap.Error(err))
+		return false
+	}
+
+	queue := false
+	if len(unfinished) > 0 {
+		switch chain.OverlapPolicy {
+		case models.ScheduleOverlapAllow:
+		case models.ScheduleOverlapQueue:
+			queue = true
+		default:
+			s.logger.Info(ctx, "Scheduled chain run skipped, an earlier run is unfinished",
+				zap.String("chain_id", chain.ID.String()),
+				zap.Time("scheduled_at", due),
+				zap.Int("unfinished_runs", len(unfinished)))
+			return false
+		}
+	}
+
+	triggerData := map[string]interface{}{
+		"scheduled_at": due.UTC().Format(time.RFC3339),
+	}
+	run, err := s.createChainRun(ctx, chain, models.ScheduleTriggerEvent, triggerData, queue, runRequest{})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create scheduled chain run",
+			zap.String("chain_id", chain.ID.String()),
+			zap.Error(err))
+		return false
+	}
+
+	s.logger.Info(ctx, "Scheduled chain run triggered",
+		zap.String("chain_id", chain.ID.String()),
+		zap.String("run_id", run.ID.String()),
+		zap.String("status", string(run.Status)),
+		zap.Time("scheduled_at", due))
+
+	// The earlier runs may have finished before the run was queued
+	if queue {
+		s.startNextQueuedRun(ctx, chain)
+	}
+	return true
+}
+
+// startNextQueuedRun starts the oldest queued run of a chain with the queue overlap policy
+// once none of its runs is running and the tenant's chain executions are not paused
+func (s *executionChainService) startNextQueuedRun(ctx context.Context, chain *models.ExecutionChain) {
+	if chain.Schedule == "" || chain.OverlapPolicy != models.ScheduleOverlapQueue || ctx.Err() != nil {
+		return
+	}
+
+	runs, err := s.chainRepo.GetChainRunsByChainAndStatus(ctx, chain.ID, unfinishedRunStatuses)
+	if err != nil || len(runs) == 0 {
+		return
+	}
+	for _, run := range runs {
+		if run.Status == models.ExecutionChainStatusRunning {
+			return
+		}
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, chain.TenantID)
+	if err != nil || settings.ChainsPaused {
+		return
+	}
+
+	if err := s.startQueuedRun(ctx, runs[0]); err != nil && !runDeferred(err) {
+		s.logger.Error(ctx, "Failed to start queued scheduled chain run",
+			zap.String("run_id", runs[0].ID.String()),
+			zap.Error(err))
+	}
+}
+
+// RunScheduler triggers the due scheduled chains every interval until ctx is cancelled
+// Parameters:
+//   - ctx: Context whose cancellation stops the scheduler
+//   - interval: Time between scheduling passes
+func (s *executionChainService) RunScheduler(ctx context.Context, interval time.Duration) {
+	for {
+		if triggered, err := s.TriggerScheduledChains(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to trigger scheduled chains", zap.Error(err))
+		} else if triggered > 0 {
+			s.logger.Info(ctx, "Triggered scheduled chain runs", zap.Int("triggered", triggered))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+	"github.com/sakibcoolz/zcornor/pkg/config"
+	"github.com/sakibcoolz/zcornor/pkg/security"
+)
+
+// memoryChains is an execution chain service over the in-memory repositories, timed on a fake clock
+// Tenants are active with the default settings until settings are saved, and configuration snapshots are not kept
+type memoryChains struct {
+	service  service.ExecutionChainService
+	chains   repository.ExecutionChainRepository
+	webhooks repository.WebhookRepository
+	clock    *fakeClock
+}
+
+// newMemoryChains returns an execution chain service over empty in-memory repositories with the clock at now
+func newMemoryChains(t *testing.T, now time.Time) *memoryChains {
+	t.Helper()
+	store := repository.NewMemoryStore()
+	chains := repository.NewMemoryExecutionChainRepository(store)
+	webhooks := repository.NewMemoryWebhookRepository(store)
+
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	tenantRepo.EXPECT().
+		GetTenant(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, tenantID string) (*models.Tenant, error) {
+			return &models.Tenant{ID: tenantID, Status: models.TenantStatusActive, Settings: &models.TenantSettings{TenantID: tenantID}}, nil
+		}).
+		Maybe()
+	var settingsMu sync.Mutex
+	settings := map[string]models.TenantSettings{}
+	tenantRepo.EXPECT().
+		GetTenantSettings(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, tenantID string) (*models.TenantSettings, error) {
+			settingsMu.Lock()
+			defer settingsMu.Unlock()
+			if saved, ok := settings[tenantID]; ok {
+				return &saved, nil
+			}
+			return &models.TenantSettings{TenantID: tenantID}, nil
+		}).
+		Maybe()
+	tenantRepo.EXPECT().
+		SaveTenantSettings(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, saved *models.TenantSettings) error {
+			settingsMu.Lock()
+			defer settingsMu.Unlock()
+			settings[saved.TenantID] = *saved
+			return nil
+		}).
+		Maybe()
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	historyRepo.EXPECT().GetLatestVersion(mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Maybe()
+	historyRepo.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	clock := newFakeClock(now)
+	svc := service.NewExecutionChainService(chains, webhooks, tenantRepo, historyRepo,
+		security.NewSecurityService("test-jwt-secret", 3600, 300), &config.Config{}, logging.Nop())
+	svc.SetClock(clock)
+	t.Cleanup(func() {
+		_ = svc.Shutdown(context.Background())
+	})
+	return &memoryChains{service: svc, chains: chains, webhooks: webhooks, clock: clock}
+}
+
+// TestRunScheduler tests that the scheduler triggers a chain's run once the clock reaches its scheduled time,
+// moves its next run to the following activation and collapses activations missed between passes into one run
+func TestRunScheduler(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 8, 58, 0, 0, time.UTC)
+	env := newMemoryChains(t, start)
+	due := start.Add(2 * time.Minute)
+	chain := &models.ExecutionChain{
+		TenantID: "tenant-123", Name: "Nightly export", TriggerEvent: models.ScheduleTriggerEvent,
+		IsActive: true, Version: 1, Schedule: "*/15 * * * *", ScheduleTimezone: "UTC", NextRunAt: &due,
+		OverlapPolicy: models.ScheduleOverlapAllow,
+	}
+	require.NoError(t, env.chains.CreateChain(ctx, chain))
+
+	runs := func() []*models.ExecutionChainRun {
+		runs, _, err := env.chains.GetChainRunsByChain(ctx, chain.ID, models.ListFilter{}, repository.Page{Limit: 10})
+		require.NoError(t, err)
+		return runs
+	}
+	nextRunAt := func() time.Time {
+		stored, err := env.chains.GetChainByID(ctx, chain.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.NextRunAt)
+		return stored.NextRunAt.UTC()
+	}
+
+	schedulerCtx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		env.service.RunScheduler(schedulerCtx, time.Minute)
+	}()
+
+	// Act & Assert - each pass ends waiting for the next one on the clock
+	env.clock.BlockUntil(1)
+	assert.Empty(t, runs(), "nothing is due at 08:58")
+
+	env.clock.Advance(time.Minute)
+	env.clock.BlockUntil(1)
+	assert.Empty(t, runs(), "nothing is due at 08:59")
+
+	env.clock.Advance(time.Minute)
+	env.clock.BlockUntil(1)
+	assert.Len(t, runs(), 1, "the 09:00 run is triggered")
+	assert.Equal(t, start.Add(17*time.Minute), nextRunAt())
+
+	env.clock.Advance(30 * time.Minute)
+	env.clock.BlockUntil(1)
+	assert.Len(t, runs(), 2, "the missed 09:15 and 09:30 runs are triggered once")
+	assert.Equal(t, start.Add(47*time.Minute), nextRunAt())
+
+	cancel()
+	<-stopped
+}
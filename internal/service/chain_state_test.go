@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// newChainStateService creates an execution chain service over mocked repositories that accepts any
+// configuration snapshot of the chain
+func newChainStateService(t *testing.T, chainID uuid.UUID) (service.ExecutionChainService, *mocks.MockExecutionChainRepository, *mocks.MockTenantRepository) {
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	historyRepo.EXPECT().GetLatestVersion(mock.Anything, models.ConfigResourceChain, chainID).Return(1, nil).Maybe()
+	historyRepo.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return service.NewExecutionChainService(chainRepo, nil, tenantRepo, historyRepo, nil, nil, logging.Nop()), chainRepo, tenantRepo
+}
+
+// TestPauseChain_KeepsQueuedRuns tests that pausing a chain records the reason and leaves the chain's queued
+// runs queued, and that a paused chain can neither be executed nor paused again
+func TestPauseChain_KeepsQueuedRuns(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", IsActive: true}
+	chainService, chainRepo, _ := newChainStateService(t, chain.ID)
+	pausedAt := time.Now()
+	paused := &models.ExecutionChain{ID: chain.ID, TenantID: "tenant-123", PausedAt: &pausedAt}
+
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().UpdateChain(ctx, chain.ID, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["is_active"] == false && updates["pause_reason"] == "maintenance" && updates["paused_at"] != nil
+	})).Return(nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(paused, nil)
+	chainRepo.EXPECT().GetChainRunsByTenantAndStatus(ctx, "tenant-123", models.ExecutionChainStatusQueued).Return([]*models.ExecutionChainRun{
+		{ID: uuid.New(), ChainID: chain.ID},
+		{ID: uuid.New(), ChainID: uuid.New()},
+		{ID: uuid.New(), ChainID: chain.ID},
+	}, nil).Once()
+
+	// Act
+	response, err := chainService.PauseChain(ctx, "", chain.ID, &models.PauseChainRequest{Reason: "maintenance"})
+	_, executeErr := chainService.ExecuteChain(ctx, "", &models.ExecuteChainRequest{ChainID: chain.ID})
+	_, pauseErr := chainService.PauseChain(ctx, "", chain.ID, &models.PauseChainRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, response.IsActive)
+	require.NotNil(t, response.PauseReason)
+	assert.Equal(t, "maintenance", *response.PauseReason)
+	assert.Equal(t, 2, response.QueuedRuns, "queued runs of other chains are not counted")
+	assert.Zero(t, response.SuspendedRuns)
+	assert.ErrorContains(t, executeErr, "chain is paused")
+	assert.ErrorContains(t, pauseErr, "chain is already paused")
+}
+
+// TestPauseChain_SuspendsQueuedRuns tests that pausing a chain with suspend_queued_runs moves its queued runs to
+// paused with the pause reason
+func TestPauseChain_SuspendsQueuedRuns(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", IsActive: true}
+	chainService, chainRepo, _ := newChainStateService(t, chain.ID)
+	queued := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID}
+
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil)
+	chainRepo.EXPECT().UpdateChain(ctx, chain.ID, mock.Anything).Return(nil).Once()
+	chainRepo.EXPECT().GetChainRunsByTenantAndStatus(ctx, "tenant-123", models.ExecutionChainStatusQueued).
+		Return([]*models.ExecutionChainRun{queued}, nil).Once()
+	chainRepo.EXPECT().UpdateChainRun(ctx, queued.ID, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == models.ExecutionChainStatusPaused &&
+			updates["last_error"] == "suspended while the chain was paused: maintenance"
+	})).Return(nil).Once()
+
+	// Act
+	response, err := chainService.PauseChain(ctx, "", chain.ID, &models.PauseChainRequest{Reason: "maintenance", SuspendQueuedRuns: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.SuspendedRuns)
+	assert.Zero(t, response.QueuedRuns)
+}
+
+// TestActivateChain_StartsQueuedRuns tests that activating a paused chain ends the pause and starts the runs
+// that stayed queued during it
+func TestActivateChain_StartsQueuedRuns(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pausedAt := time.Now()
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", PausedAt: &pausedAt}
+	chainService, chainRepo, tenantRepo := newChainStateService(t, chain.ID)
+	active := &models.ExecutionChain{ID: chain.ID, TenantID: "tenant-123", IsActive: true}
+	queued := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID, TenantID: "tenant-123", Status: models.ExecutionChainStatusQueued}
+
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().UpdateChain(ctx, chain.ID, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["is_active"] == true && updates["paused_at"] == nil
+	})).Return(nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(active, nil)
+	chainRepo.EXPECT().GetChainRunsByTenantAndStatus(ctx, "tenant-123", models.ExecutionChainStatusQueued).
+		Return([]*models.ExecutionChainRun{queued}, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123"}, nil).Times(2)
+	chainRepo.EXPECT().GetStepRunsByRun(mock.Anything, queued.ID).Return(nil, nil)
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, queued.ID, models.ExecutionChainStatusQueued, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == models.ExecutionChainStatusRunning
+	})).Return(true, nil).Once()
+	chainRepo.EXPECT().UpdateChainRunStatus(mock.Anything, queued.ID, models.ExecutionChainStatusCompleted).Return(nil).Once()
+	chainRepo.EXPECT().GetChainRunByID(mock.Anything, queued.ID).Return(queued, nil).Once()
+	finished := awaitRunFinished(tenantRepo, "tenant-123")
+
+	// Act
+	response, err := chainService.ActivateChain(ctx, "", chain.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, response.IsActive)
+	assert.Equal(t, 1, response.StartedRuns)
+	assert.Zero(t, response.QueuedRuns)
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued run did not complete")
+	}
+}
+
+// TestActivateChain_TenantPaused tests that activating a chain of a tenant whose chains are paused keeps its
+// queued runs queued
+func TestActivateChain_TenantPaused(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pausedAt := time.Now()
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", PausedAt: &pausedAt}
+	chainService, chainRepo, tenantRepo := newChainStateService(t, chain.ID)
+
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil)
+	chainRepo.EXPECT().UpdateChain(ctx, chain.ID, mock.Anything).Return(nil).Once()
+	chainRepo.EXPECT().GetChainRunsByTenantAndStatus(ctx, "tenant-123", models.ExecutionChainStatusQueued).
+		Return([]*models.ExecutionChainRun{{ID: uuid.New(), ChainID: chain.ID}}, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").
+		Return(&models.TenantSettings{TenantID: "tenant-123", ChainsPaused: true}, nil).Once()
+
+	// Act
+	response, err := chainService.ActivateChain(ctx, "", chain.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, response.IsActive)
+	assert.Zero(t, response.StartedRuns)
+	assert.Equal(t, 1, response.QueuedRuns)
+}
+
+// TestPauseTenantChains_OtherTenantsUnaffected tests that pausing a tenant's chains queues the runs triggered
+// for it while runs of other tenants keep executing, and that resuming the tenant starts its queued runs
+func TestPauseTenantChains_OtherTenantsUnaffected(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+	pausedChain := env.createChain(t, models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")})
+
+	other := &models.WebhookSubscription{TenantID: "tenant-456", AppName: "billing", TargetURL: server.URL + "/bill",
+		Type: models.WebhookTypePublic, SecretToken: "test-secret", IsActive: true}
+	require.NoError(t, env.webhooks.CreateSubscription(ctx, other))
+	otherChain, err := env.service.CreateChain(ctx, &models.CreateExecutionChainRequest{
+		TenantID: "tenant-456", Name: "Billing", TriggerEvent: "order.created",
+		Steps: []models.CreateExecutionChainStep{{Name: "Bill", WebhookID: &other.ID}},
+	})
+	require.NoError(t, err)
+
+	// Act
+	paused, err := env.service.PauseTenantChains(ctx, chainTenant)
+	require.NoError(t, err)
+	queuedRun := env.executeWithOptions(t, pausedChain, nil)
+	otherRun, err := env.service.ExecuteChain(ctx, "tenant-456", &models.ExecuteChainRequest{ChainID: otherChain.ChainID})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		run, err := env.service.GetChainRun(ctx, "tenant-456", otherRun.RunID)
+		require.NoError(t, err)
+		return run.Status == models.ExecutionChainStatusCompleted
+	}, 5*time.Second, 5*time.Millisecond, "run of the other tenant never completed")
+	pausedAgain, err := env.service.PauseTenantChains(ctx, chainTenant)
+	require.NoError(t, err)
+	queued, err := env.service.GetChainRun(ctx, chainTenant, queuedRun)
+	require.NoError(t, err)
+	callsWhilePaused := server.Calls()
+	resumed, err := env.service.ResumeTenantChains(ctx, chainTenant)
+	require.NoError(t, err)
+	env.waitForRun(t, queuedRun, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	assert.True(t, paused.ChainsPaused)
+	require.NotNil(t, paused.ChainsPausedAt)
+	assert.Zero(t, paused.QueuedRuns)
+	assert.Equal(t, paused.ChainsPausedAt, pausedAgain.ChainsPausedAt, "pausing again keeps the time of the pause")
+	assert.Equal(t, 1, pausedAgain.QueuedRuns, "only the tenant's own runs are counted")
+	assert.Equal(t, models.ExecutionChainStatusQueued, queued.Status)
+	assert.Equal(t, []string{"/bill"}, callsWhilePaused)
+
+	assert.False(t, resumed.ChainsPaused)
+	assert.Equal(t, 1, resumed.ResumedRuns)
+	assert.Zero(t, resumed.QueuedRuns)
+	assert.Equal(t, []string{"/bill", "/ship"}, server.Calls())
+}
+
+// TestResumeTenantChains_NotPaused tests that resuming a tenant whose chains are not paused saves nothing and
+// starts nothing
+func TestResumeTenantChains_NotPaused(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	chainService := service.NewExecutionChainService(chainRepo, nil, tenantRepo, nil, nil, nil, logging.Nop())
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123"}, nil).Once()
+	chainRepo.EXPECT().GetChainRunsByTenantAndStatus(ctx, "tenant-123", models.ExecutionChainStatusQueued).Return(nil, nil).Once()
+
+	// Act
+	response, err := chainService.ResumeTenantChains(ctx, "tenant-123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &models.TenantChainControlResponse{TenantID: "tenant-123"}, response)
+}
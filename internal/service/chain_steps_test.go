@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestCreateChain_InvalidCondition tests that a chain with a malformed step condition is rejected before it is
+// stored
+func TestCreateChain_InvalidCondition(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	webhookID := uuid.New()
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(ctx, webhookID).
+		Return(&models.WebhookSubscription{ID: webhookID, TenantID: "tenant-123"}, nil).Maybe()
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	tenantRepo.EXPECT().GetTenant(ctx, "tenant-123").
+		Return(&models.Tenant{ID: "tenant-123", Status: models.TenantStatusActive}, nil).Maybe()
+	chainService := service.NewExecutionChainService(mocks.NewMockExecutionChainRepository(t), webhookRepo, tenantRepo, nil, nil, nil, logging.Nop())
+
+	// Act
+	response, err := chainService.CreateChain(ctx, &models.CreateExecutionChainRequest{
+		TenantID: "tenant-123", Name: "Orders", TriggerEvent: "order.created",
+		Steps: []models.CreateExecutionChainStep{
+			{Name: "Charge", Condition: ".trigger_data.amount >", WebhookID: &webhookID},
+		},
+	})
+
+	// Assert
+	assert.ErrorContains(t, err, "step 1: invalid condition")
+	assert.Nil(t, response)
+}
+
+// TestResumeChainRun_SkipsStepByCondition tests that a step whose condition is false is recorded as skipped
+// with the condition as the reason, without being sent, and that the run still completes
+func TestResumeChainRun_SkipsStepByCondition(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, tenantRepo := newRunControlService(t)
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", IsActive: true, Steps: []models.ExecutionChainStep{
+		{ID: uuid.New(), StepOrder: 1, Name: "Review", Condition: ".trigger_data.amount > 100"},
+	}}
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID, TenantID: "tenant-123",
+		Status: models.ExecutionChainStatusInterrupted, CurrentStep: 1, TotalSteps: 1, TriggerData: `{"amount": 20}`}
+
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123"}, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetStepRunsByRun(mock.Anything, run.ID).Return(nil, nil)
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, run.ID, run.Status, mock.Anything).Return(true, nil).Once()
+	chainRepo.EXPECT().UpdateChainRunStep(mock.Anything, run.ID, 1).Return(nil).Once()
+	var skipped *models.ExecutionChainStepRun
+	chainRepo.EXPECT().CreateStepRun(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, stepRun *models.ExecutionChainStepRun) error {
+		skipped = stepRun
+		return nil
+	}).Once()
+	chainRepo.EXPECT().UpdateChainRunStatus(mock.Anything, run.ID, models.ExecutionChainStatusCompleted).Return(nil).Once()
+	chainRepo.EXPECT().GetChainRunByID(mock.Anything, run.ID).Return(run, nil).Once()
+	finished := awaitRunFinished(tenantRepo, "tenant-123")
+
+	// Act
+	_, err := chainService.ResumeChainRun(ctx, run.ID)
+
+	// Assert
+	require.NoError(t, err)
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not complete")
+	}
+	require.NotNil(t, skipped)
+	assert.Equal(t, models.WebhookStatusSkipped, skipped.Status)
+	require.NotNil(t, skipped.LastError)
+	assert.Equal(t, `skipped: condition ".trigger_data.amount > 100" is false`, *skipped.LastError)
+}
+
+// TestUpdateChainSteps_RetiresReplacedSteps tests that an unchanged step listed by ID is kept in its new
+// position, that a changed or new step is created and that the steps no longer listed are retired
+func TestUpdateChainSteps_RetiresReplacedSteps(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chargeHook, shipHook, notifyHook, expressHook, invoiceHook := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	charge := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 1, Name: "Charge", WebhookID: &chargeHook, Type: models.StepTypeWebhook, OnSuccessAction: "continue", OnFailureAction: "stop", MaxRetries: 3}
+	ship := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 2, Name: "Ship", WebhookID: &shipHook, Type: models.StepTypeWebhook, OnSuccessAction: "continue", OnFailureAction: "stop", MaxRetries: 3}
+	notify := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 3, Name: "Notify", WebhookID: &notifyHook, Type: models.StepTypeWebhook, OnSuccessAction: "continue", OnFailureAction: "stop", MaxRetries: 3}
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", Version: 1, Steps: []models.ExecutionChainStep{charge, ship, notify}}
+
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(ctx, mock.Anything).RunAndReturn(func(_ context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+		return &models.WebhookSubscription{ID: id, TenantID: "tenant-123"}, nil
+	})
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	historyRepo.EXPECT().GetLatestVersion(ctx, models.ConfigResourceChain, chain.ID).Return(1, nil).Maybe()
+	historyRepo.EXPECT().CreateSnapshot(ctx, mock.Anything).Return(nil).Maybe()
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil)
+
+	var kept, created []models.ExecutionChainStep
+	var retired []uuid.UUID
+	chainRepo.EXPECT().UpdateChainSteps(ctx, chain.ID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ uuid.UUID, k, c []models.ExecutionChainStep, r []uuid.UUID, _ *models.ExecutionChainVersion) error {
+			kept, created, retired = k, c, r
+			return nil
+		}).Once()
+	chainService := service.NewExecutionChainService(chainRepo, webhookRepo, nil, historyRepo, nil, nil, logging.Nop())
+
+	// Act
+	response, err := chainService.UpdateChainSteps(ctx, "", chain.ID, &models.UpdateChainStepsRequest{
+		Steps: []models.UpdateExecutionChainStep{
+			{ID: &charge.ID, CreateExecutionChainStep: models.CreateExecutionChainStep{Name: "Charge", WebhookID: &chargeHook}},
+			{ID: &ship.ID, CreateExecutionChainStep: models.CreateExecutionChainStep{Name: "Ship express", WebhookID: &expressHook}},
+			{CreateExecutionChainStep: models.CreateExecutionChainStep{Name: "Invoice", WebhookID: &invoiceHook}},
+		},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Kept)
+	assert.Equal(t, 2, response.Added)
+	assert.Equal(t, 2, response.Retired)
+	assert.Equal(t, 2, response.Version)
+	require.Len(t, kept, 1)
+	assert.Equal(t, charge.ID, kept[0].ID)
+	assert.Equal(t, 1, kept[0].StepOrder)
+	require.Len(t, created, 2)
+	assert.Equal(t, "Ship express", created[0].Name)
+	assert.Equal(t, 2, created[0].StepOrder)
+	assert.NotEqual(t, ship.ID, created[0].ID)
+	assert.Equal(t, "Invoice", created[1].Name)
+	assert.Equal(t, 3, created[1].StepOrder)
+	assert.ElementsMatch(t, []uuid.UUID{ship.ID, notify.ID}, retired)
+}
+
+// TestUpdateChainSteps_UnknownStep tests that an update naming a step that is not a current step of the chain
+// is rejected without changing the steps
+func TestUpdateChainSteps_UnknownStep(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chargeHook, shipHook := uuid.New(), uuid.New()
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", Steps: []models.ExecutionChainStep{
+		{ID: uuid.New(), StepOrder: 1, Name: "Charge", WebhookID: &chargeHook},
+	}}
+	otherStep := uuid.New()
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(ctx, mock.Anything).Return(&models.WebhookSubscription{TenantID: "tenant-123"}, nil)
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainService := service.NewExecutionChainService(chainRepo, webhookRepo, nil, nil, nil, nil, logging.Nop())
+
+	// Act
+	response, err := chainService.UpdateChainSteps(ctx, "", chain.ID, &models.UpdateChainStepsRequest{
+		Steps: []models.UpdateExecutionChainStep{
+			{ID: &otherStep, CreateExecutionChainStep: models.CreateExecutionChainStep{Name: "Ship", WebhookID: &shipHook}},
+		},
+	})
+
+	// Assert
+	assert.ErrorContains(t, err, "is not a current step of this chain")
+	assert.Nil(t, response)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// chainSync is the planned change of one chain while applying a configuration manifest
+type chainSync struct {
+	// change is the index of the chain's entry in the planned changes
+	change  int
+	current *models.ExecutionChain
+	desired *models.CreateExecutionChainRequest
+}
+
+// SyncChains reconciles the chains of a tenant with the chains of a configuration manifest
+// Chains are matched by name: chains the manifest lists are created or updated to match it and the tenant's
+// other chains are deleted. Manifest webhooks are resolved against the given subscriptions, which are the
+// tenant's subscriptions as they are once the manifest's subscriptions are applied
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant whose chains are reconciled
+//   - templates: The manifest's chains
+//   - subscriptions: Subscriptions the manifest's webhooks are resolved against
+//   - dryRun: Whether to only plan the changes
+//
+// Returns:
+//   - []ConfigResourceChange: The change of each manifest chain in order, followed by the deletions
+//   - error: If the chains cannot be loaded, or applying a change fails; changes are only applied when no
+//     manifest chain is invalid
+func (s *executionChainService) SyncChains(ctx context.Context, tenantID string, templates []models.ChainTemplate, subscriptions []models.WebhookSubscription, dryRun bool) ([]models.ConfigResourceChange, error) {
+	existing, err := s.tenantRepo.GetAllChains(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution chains: %w", err)
+	}
+	byName := make(map[string][]*models.ExecutionChain, len(existing))
+	for i := range existing {
+		byName[existing[i].Name] = append(byName[existing[i].Name], &existing[i])
+	}
+	resolver := &webhookResolver{subscriptions: subscriptions}
+
+	changes := make([]models.ConfigResourceChange, 0, len(templates))
+	plans := make([]chainSync, 0, len(templates))
+	listed := make(map[string]bool, len(templates))
+	invalid := false
+	for i := range templates {
+		template := &templates[i]
+		index := i
+		change := models.ConfigResourceChange{ResourceType: models.ConfigResourceChain, Index: &index, Name: template.Name}
+
+		plan, err := syncedChain(tenantID, template, resolver, byName[template.Name], listed[template.Name])
+		listed[template.Name] = true
+		switch {
+		case err != nil:
+			change.Action = models.ConfigActionInvalid
+			change.Error = importError(err)
+			invalid = true
+		case plan.current == nil:
+			change.Action = models.ConfigActionCreate
+		default:
+			change.ResourceID = &plan.current.ID
+			current, err := chainRequest(plan.current)
+			if err == nil {
+				change.Diff, err = configDiff(current, plan.desired)
+			}
+			if err != nil {
+				change.Action = models.ConfigActionInvalid
+				change.Error = importError(fmt.Errorf("failed to compare with chain %s: %w", plan.current.ID, err))
+				invalid = true
+			} else if len(change.Diff) == 0 {
+				change.Action = models.ConfigActionUnchanged
+			} else {
+				change.Action = models.ConfigActionUpdate
+			}
+		}
+
+		plan.change = len(changes)
+		changes = append(changes, change)
+		if change.Action == models.ConfigActionCreate || change.Action == models.ConfigActionUpdate {
+			plans = append(plans, plan)
+		}
+	}
+
+	var deleted []uuid.UUID
+	for i := range existing {
+		if listed[existing[i].Name] {
+			continue
+		}
+		id := existing[i].ID
+		deleted = append(deleted, id)
+		changes = append(changes, models.ConfigResourceChange{
+			ResourceType: models.ConfigResourceChain,
+			Action:       models.ConfigActionDelete,
+			ResourceID:   &id,
+			Name:         existing[i].Name,
+		})
+	}
+
+	if dryRun || invalid {
+		return changes, nil
+	}
+
+	for _, plan := range plans {
+		change := &changes[plan.change]
+		if plan.current == nil {
+			response, err := s.CreateChain(ctx, plan.desired)
+			if err != nil {
+				return changes, fmt.Errorf("failed to create chain %q: %w", change.Name, err)
+			}
+			change.ResourceID = &response.ChainID
+			continue
+		}
+		if err := s.updateSyncedChain(ctx, plan.current, plan.desired); err != nil {
+			return changes, fmt.Errorf("failed to update chain %q: %w", change.Name, err)
+		}
+	}
+	for _, id := range deleted {
+		if err := s.DeleteChain(ctx, tenantID, id); err != nil {
+			return changes, fmt.Errorf("failed to delete chain %s: %w", id, err)
+		}
+	}
+
+	s.logger.Info(ctx, "Execution chains synced",
+		zap.String("tenant_id", tenantID),
+		zap.Int("created_or_updated", len(plans)),
+		zap.Int("deleted", len(deleted)))
+	return changes, nil
+}
+
+// syncedChain validates a manifest chain and finds the chain it updates, nil when it is created
+func syncedChain(tenantID string, template *models.ChainTemplate, resolver *webhookResolver, matches []*models.ExecutionChain, duplicate bool) (chainSync, error) {
+	if duplicate {
+		return chainSync{}, fmt.Errorf("chain %q is listed more than once", template.Name)
+	}
+	if len(matches) > 1 {
+		return chainSync{}, fmt.Errorf("%d chains of the tenant are named %q, delete or rename all but one", len(matches), template.Name)
+	}
+
+	desired, err := templateChainRequest(tenantID, template, resolver)
+	if err != nil {
+		return chainSync{}, err
+	}
+	if err := normalizeChainRequest(desired); err != nil {
+		return chainSync{}, err
+	}
+	if err := validateStepRequests(desired.Steps); err != nil {
+		return chainSync{}, err
+	}
+
+	plan := chainSync{desired: desired}
+	if len(matches) == 1 {
+		plan.current = matches[0]
+	}
+	return plan, nil
+}
+
+// normalizeChainRequest validates the schedule of a chain creation request and fills in the defaults
+// CreateChain applies, so the request compares equal to the request of the chain it creates
+func normalizeChainRequest(req *models.CreateExecutionChainRequest) error {
+	schedule, policy, err := parseChainSchedule(req.ChainScheduleRequest)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		req.ChainScheduleRequest = models.ChainScheduleRequest{}
+	} else {
+		req.OverlapPolicy = string(policy)
+	}
+
+	for i := range req.Steps {
+		step := &req.Steps[i]
+		if step.Type == "" {
+			step.Type = models.StepTypeWebhook
+		}
+		if step.OnSuccessAction == "" {
+			step.OnSuccessAction = "continue"
+		}
+		if step.OnFailureAction == "" {
+			step.OnFailureAction = "stop"
+		}
+		if step.MaxRetries == 0 {
+			step.MaxRetries = 3
+		}
+	}
+	return nil
+}
+
+// chainRequest converts a chain back into the request that creates it
+func chainRequest(chain *models.ExecutionChain) (*models.CreateExecutionChainRequest, error) {
+	req := &models.CreateExecutionChainRequest{
+		TenantID:     chain.TenantID,
+		Name:         chain.Name,
+		Description:  chain.Description,
+		TriggerEvent: chain.TriggerEvent,
+		ChainScheduleRequest: models.ChainScheduleRequest{
+			Schedule:         chain.Schedule,
+			ScheduleTimezone: chain.ScheduleTimezone,
+			OverlapPolicy:    string(chain.OverlapPolicy),
+		},
+		CompletionWebhookID: chain.CompletionWebhookID,
+		Steps:               make([]models.CreateExecutionChainStep, 0, len(chain.Steps)),
+	}
+
+	keys := stepKeysByID(chain.Steps)
+	for i, step := range chain.Steps {
+		reqStep, err := stepRequest(step, keys)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+		req.Steps = append(req.Steps, reqStep)
+	}
+	return req, nil
+}
+
+// updateSyncedChain changes a chain to match its manifest entry: its properties, its schedule and, as a
+// new version, its steps; unchanged steps at the same position are kept
+func (s *executionChainService) updateSyncedChain(ctx context.Context, chain *models.ExecutionChain, desired *models.CreateExecutionChainRequest) error {
+	updates := make(map[string]interface{})
+	if chain.Description != desired.Description {
+		updates["description"] = desired.Description
+	}
+	if chain.TriggerEvent != desired.TriggerEvent {
+		updates["trigger_event"] = desired.TriggerEvent
+	}
+	if !jsonEqual(chain.CompletionWebhookID, desired.CompletionWebhookID) {
+		if desired.CompletionWebhookID == nil {
+			updates["completion_webhook_id"] = nil
+		} else {
+			if err := s.validateCompletionWebhook(ctx, chain.TenantID, *desired.CompletionWebhookID); err != nil {
+				return err
+			}
+			updates["completion_webhook_id"] = *desired.CompletionWebhookID
+		}
+	}
+	if len(updates) > 0 {
+		updates["updated_at"] = s.clock.Now()
+		if err := s.chainRepo.UpdateChain(ctx, chain.ID, updates); err != nil {
+			return err
+		}
+		s.recordChainSnapshot(ctx, chain.ID, models.ConfigChangeUpdated)
+	}
+
+	if chain.Schedule != desired.Schedule || chain.ScheduleTimezone != desired.ScheduleTimezone || string(chain.OverlapPolicy) != desired.OverlapPolicy {
+		schedule := desired.ChainScheduleRequest
+		if _, err := s.SetChainSchedule(ctx, chain.TenantID, chain.ID, &schedule); err != nil {
+			return err
+		}
+	}
+
+	current, err := chainRequest(chain)
+	if err != nil {
+		return err
+	}
+	if jsonEqual(current.Steps, desired.Steps) {
+		return nil
+	}
+	steps := make([]models.UpdateExecutionChainStep, len(desired.Steps))
+	for i, step := range desired.Steps {
+		steps[i].CreateExecutionChainStep = step
+		if i < len(chain.Steps) {
+			id := chain.Steps[i].ID
+			steps[i].ID = &id
+		}
+	}
+	_, err = s.replaceChainSteps(ctx, chain, steps, nil)
+	return err
+}
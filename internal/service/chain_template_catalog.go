@@ -0,0 +1,122 @@
+package service
+
+import (
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// chainTemplateCatalog holds the built-in chain templates served by GET /api/chain-templates
+// Templates name webhooks by conventional app names; tenants whose apps are named differently map them when
+// instantiating a template
+var chainTemplateCatalog = []models.ChainTemplate{
+	{
+		Version:      models.ChainTemplateVersion,
+		ID:           "order-fulfillment",
+		Name:         "Order Fulfillment",
+		Description:  "Reserves inventory, captures the payment and creates the shipment of an order; a failed step releases the reservation and refunds the payment",
+		TriggerEvent: "order.created",
+		Steps: []models.ChainTemplateStep{
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "inventory-service", SubscribedEvent: "inventory.reserve"},
+				Name:          "Reserve Inventory",
+				Key:           "reserve",
+				RequestParams: map[string]interface{}{"order_id": "{{.trigger_data.order_id}}", "items": "{{.trigger_data.items}}"},
+				Extract:       map[string]string{"reservation_id": "response.reservation_id"},
+				MaxRetries:    3,
+				Compensation: &models.TemplateCompensation{
+					Webhook:       models.TemplateWebhook{AppName: "inventory-service", SubscribedEvent: "inventory.release"},
+					RequestParams: map[string]interface{}{"reservation_id": "{{.vars.reservation_id}}"},
+				},
+			},
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "payment-service", SubscribedEvent: "payment.capture"},
+				Name:          "Capture Payment",
+				Key:           "payment",
+				RequestParams: map[string]interface{}{"order_id": "{{.trigger_data.order_id}}", "amount": "{{.trigger_data.total}}"},
+				Extract:       map[string]string{"payment_id": "response.payment_id"},
+				MaxRetries:    2,
+				Compensation: &models.TemplateCompensation{
+					Webhook:       models.TemplateWebhook{AppName: "payment-service", SubscribedEvent: "payment.refund"},
+					RequestParams: map[string]interface{}{"payment_id": "{{.vars.payment_id}}"},
+				},
+			},
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "shipping-service"},
+				Name:          "Create Shipment",
+				RequestParams: map[string]interface{}{"order_id": "{{.trigger_data.order_id}}", "reservation_id": "{{.vars.reservation_id}}"},
+				MaxRetries:    5,
+			},
+		},
+	},
+	{
+		Version:      models.ChainTemplateVersion,
+		ID:           "expense-approval",
+		Name:         "Expense Approval",
+		Description:  "Sends large expenses to approvers and pays out approved and small expenses",
+		TriggerEvent: "expense.submitted",
+		Steps: []models.ChainTemplateStep{
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "approval-notifier"},
+				Name:          "Request Approval",
+				Type:          models.StepTypeApproval,
+				Condition:     ".trigger_data.amount > 1000",
+				RequestParams: map[string]interface{}{"expense_id": "{{.trigger_data.expense_id}}", "amount": "{{.trigger_data.amount}}"},
+			},
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "finance-service"},
+				Name:          "Pay Out Expense",
+				RequestParams: map[string]interface{}{"expense_id": "{{.trigger_data.expense_id}}"},
+				MaxRetries:    3,
+			},
+		},
+	},
+	{
+		Version:      models.ChainTemplateVersion,
+		ID:           "welcome-follow-up",
+		Name:         "Welcome Follow-up",
+		Description:  "Welcomes a new user and follows up a day later",
+		TriggerEvent: "user.signed_up",
+		Steps: []models.ChainTemplateStep{
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "email-service"},
+				Name:          "Send Welcome Email",
+				RequestParams: map[string]interface{}{"user_id": "{{.trigger_data.user_id}}", "template": "welcome"},
+			},
+			{
+				Name:         "Wait One Day",
+				Type:         models.StepTypeDelay,
+				DelaySeconds: 86400,
+			},
+			{
+				Webhook:         &models.TemplateWebhook{AppName: "email-service"},
+				Name:            "Send Follow-up Email",
+				RequestParams:   map[string]interface{}{"user_id": "{{.trigger_data.user_id}}", "template": "follow-up"},
+				OnFailureAction: "continue",
+			},
+		},
+	},
+	{
+		Version:      models.ChainTemplateVersion,
+		ID:           "daily-report",
+		Name:         "Daily Report",
+		Description:  "Builds the previous day's report every morning and delivers it",
+		TriggerEvent: "report.requested",
+		ChainScheduleRequest: models.ChainScheduleRequest{
+			Schedule:      "0 6 * * *",
+			OverlapPolicy: string(models.ScheduleOverlapSkip),
+		},
+		Steps: []models.ChainTemplateStep{
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "reporting-service"},
+				Name:          "Build Report",
+				RequestParams: map[string]interface{}{"period": "daily"},
+				Extract:       map[string]string{"report_url": "response.url"},
+				MaxRetries:    3,
+			},
+			{
+				Webhook:       &models.TemplateWebhook{AppName: "notification-service"},
+				Name:          "Deliver Report",
+				RequestParams: map[string]interface{}{"report_url": "{{.vars.report_url}}"},
+			},
+		},
+	},
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrChainTemplateNotFound is returned for template IDs that are not in the catalog
+var ErrChainTemplateNotFound = apperr.NotFound("template_not_found", "chain template not found")
+
+// ExportChain describes a chain as a portable template
+// Steps name their webhooks by app name, and by subscribed event when the tenant has other subscriptions of
+// the app, so the template can be imported for another tenant or environment
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant the chain must belong to, empty for any tenant
+//   - chainID: Chain to export
+//
+// Returns:
+//   - ChainTemplate: The chain's definition without tenant-specific IDs
+//   - error: If the chain or a webhook it calls cannot be loaded
+func (s *executionChainService) ExportChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainTemplate, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	subscriptions, err := tenantSubscriptions(ctx, s.webhookRepo, chain.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	refs := newWebhookRefs(subscriptions)
+
+	template := &models.ChainTemplate{
+		Version:      models.ChainTemplateVersion,
+		Name:         chain.Name,
+		Description:  chain.Description,
+		TriggerEvent: chain.TriggerEvent,
+		ChainScheduleRequest: models.ChainScheduleRequest{
+			Schedule:         chain.Schedule,
+			ScheduleTimezone: chain.ScheduleTimezone,
+			OverlapPolicy:    string(chain.OverlapPolicy),
+		},
+		Steps: make([]models.ChainTemplateStep, 0, len(chain.Steps)),
+	}
+	if chain.CompletionWebhookID != nil {
+		ref, err := refs.ref(*chain.CompletionWebhookID)
+		if err != nil {
+			return nil, fmt.Errorf("completion webhook: %w", err)
+		}
+		template.CompletionWebhook = &ref
+	}
+
+	steps := append([]models.ExecutionChainStep(nil), chain.Steps...)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].StepOrder < steps[j].StepOrder })
+	keys := stepKeysByID(steps)
+	for i, step := range steps {
+		req, err := stepRequest(step, keys)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+		templateStep, err := refs.templateStep(req)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+		template.Steps = append(template.Steps, templateStep)
+	}
+
+	s.logger.Info(ctx, "Execution chain exported",
+		zap.String("chain_id", chainID.String()),
+		zap.Int("steps", len(template.Steps)))
+	return template, nil
+}
+
+// ImportChain creates a chain for a tenant from a template, validated like a CreateChain request
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - template: The chain template, exported or from the catalog
+//   - req: Tenant, name and trigger event overrides, and subscriptions for webhook refs the tenant's app
+//     names do not match
+//
+// Returns:
+//   - CreateExecutionChainResponse: The created chain
+//   - error: If the template version is unsupported, a webhook ref matches no or several subscriptions,
+//     or the chain is invalid
+func (s *executionChainService) ImportChain(ctx context.Context, template *models.ChainTemplate, req *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error) {
+	if template.Version != models.ChainTemplateVersion {
+		return nil, ErrInvalidChain.Withf("unsupported template version %d, expected %d", template.Version, models.ChainTemplateVersion)
+	}
+	subscriptions, err := tenantSubscriptions(ctx, s.webhookRepo, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	resolver := &webhookResolver{subscriptions: subscriptions, mapped: req.Webhooks}
+
+	if req.Name != "" || req.TriggerEvent != "" {
+		overridden := *template
+		if req.Name != "" {
+			overridden.Name = req.Name
+		}
+		if req.TriggerEvent != "" {
+			overridden.TriggerEvent = req.TriggerEvent
+		}
+		template = &overridden
+	}
+	createReq, err := templateChainRequest(req.TenantID, template, resolver)
+	if err != nil {
+		return nil, invalid(ErrInvalidChain, err)
+	}
+
+	return s.CreateChain(ctx, createReq)
+}
+
+// templateChainRequest builds the request creating a template's chain for a tenant, resolving its webhooks
+func templateChainRequest(tenantID string, template *models.ChainTemplate, resolver *webhookResolver) (*models.CreateExecutionChainRequest, error) {
+	createReq := &models.CreateExecutionChainRequest{
+		TenantID:             tenantID,
+		Name:                 template.Name,
+		Description:          template.Description,
+		TriggerEvent:         template.TriggerEvent,
+		ChainScheduleRequest: template.ChainScheduleRequest,
+		Steps:                make([]models.CreateExecutionChainStep, 0, len(template.Steps)),
+	}
+	if createReq.Name == "" || createReq.TriggerEvent == "" {
+		return nil, fmt.Errorf("name and trigger_event are required")
+	}
+	if len(template.Steps) == 0 {
+		return nil, fmt.Errorf("at least one step is required")
+	}
+
+	if template.CompletionWebhook != nil {
+		id, err := resolver.resolve(*template.CompletionWebhook)
+		if err != nil {
+			return nil, fmt.Errorf("completion webhook: %w", err)
+		}
+		createReq.CompletionWebhookID = &id
+	}
+	for i, templateStep := range template.Steps {
+		step, err := resolver.step(templateStep)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+		createReq.Steps = append(createReq.Steps, step)
+	}
+	return createReq, nil
+}
+
+// ListChainTemplates returns the templates of the built-in catalog
+func (s *executionChainService) ListChainTemplates() *models.ChainTemplateListResponse {
+	response := &models.ChainTemplateListResponse{Templates: make([]models.ChainTemplateSummary, 0, len(chainTemplateCatalog))}
+	for _, template := range chainTemplateCatalog {
+		response.Templates = append(response.Templates, models.ChainTemplateSummary{
+			ID:           template.ID,
+			Name:         template.Name,
+			Description:  template.Description,
+			TriggerEvent: template.TriggerEvent,
+			Schedule:     template.Schedule,
+			StepsCount:   len(template.Steps),
+			Webhooks:     templateWebhookRefs(template),
+		})
+	}
+	return response
+}
+
+// GetChainTemplate returns a template of the built-in catalog, ErrChainTemplateNotFound for unknown IDs
+func (s *executionChainService) GetChainTemplate(templateID string) (*models.ChainTemplate, error) {
+	for i := range chainTemplateCatalog {
+		if chainTemplateCatalog[i].ID == templateID {
+			template := chainTemplateCatalog[i]
+			return &template, nil
+		}
+	}
+	return nil, ErrChainTemplateNotFound.Withf("chain template %s not found", templateID)
+}
+
+// InstantiateChainTemplate creates a chain for a tenant from a template of the built-in catalog
+func (s *executionChainService) InstantiateChainTemplate(ctx context.Context, templateID string, req *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error) {
+	template, err := s.GetChainTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportChain(ctx, template, req)
+}
+
+// templateWebhookRefs returns the refs of the webhooks a template calls, in order of first use
+func templateWebhookRefs(template models.ChainTemplate) []string {
+	refs := []string{}
+	seen := make(map[string]bool)
+	add := func(webhook models.TemplateWebhook) {
+		if ref := webhook.Ref(); !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	for _, step := range template.Steps {
+		if step.Webhook != nil {
+			add(*step.Webhook)
+		}
+		if step.Compensation != nil {
+			add(step.Compensation.Webhook)
+		}
+	}
+	if template.CompletionWebhook != nil {
+		add(*template.CompletionWebhook)
+	}
+	return refs
+}
+
+// webhookRefs names the subscriptions of a tenant for templates
+type webhookRefs struct {
+	byID  map[uuid.UUID]models.WebhookSubscription
+	byApp map[string]int
+}
+
+// newWebhookRefs indexes the subscriptions of a tenant
+func newWebhookRefs(subscriptions []models.WebhookSubscription) *webhookRefs {
+	refs := &webhookRefs{
+		byID:  make(map[uuid.UUID]models.WebhookSubscription, len(subscriptions)),
+		byApp: make(map[string]int),
+	}
+	for _, subscription := range subscriptions {
+		refs.byID[subscription.ID] = subscription
+		refs.byApp[subscription.AppName]++
+	}
+	return refs
+}
+
+// ref names a subscription by its app name, adding its subscribed event when the app has several
+func (r *webhookRefs) ref(id uuid.UUID) (models.TemplateWebhook, error) {
+	subscription, ok := r.byID[id]
+	if !ok {
+		return models.TemplateWebhook{}, fmt.Errorf("webhook %s not found", id)
+	}
+	ref := models.TemplateWebhook{AppName: subscription.AppName}
+	if r.byApp[subscription.AppName] > 1 {
+		ref.SubscribedEvent = subscription.SubscribedEvent
+	}
+	return ref, nil
+}
+
+// templateStep converts a step creation request into a template step
+func (r *webhookRefs) templateStep(req models.CreateExecutionChainStep) (models.ChainTemplateStep, error) {
+	step := models.ChainTemplateStep{
+		Name:            req.Name,
+		Description:     req.Description,
+		Type:            req.Type,
+		RequestParams:   req.RequestParams,
+		ResponseSchema:  req.ResponseSchema,
+		Condition:       req.Condition,
+		ParallelGroup:   req.ParallelGroup,
+		Key:             req.Key,
+		DependsOn:       req.DependsOn,
+		Branches:        req.Branches,
+		BranchPath:      req.BranchPath,
+		Extract:         req.Extract,
+		OnSuccessAction: req.OnSuccessAction,
+		OnFailureAction: req.OnFailureAction,
+		MaxRetries:      req.MaxRetries,
+		DelaySeconds:    req.DelaySeconds,
+	}
+	if req.WebhookID != nil {
+		ref, err := r.ref(*req.WebhookID)
+		if err != nil {
+			return step, err
+		}
+		step.Webhook = &ref
+	}
+	if req.Compensation != nil {
+		ref, err := r.ref(req.Compensation.WebhookID)
+		if err != nil {
+			return step, fmt.Errorf("compensation: %w", err)
+		}
+		step.Compensation = &models.TemplateCompensation{Webhook: ref, RequestParams: req.Compensation.RequestParams}
+	}
+	return step, nil
+}
+
+// webhookResolver finds the subscriptions of a tenant that template webhooks name
+type webhookResolver struct {
+	subscriptions []models.WebhookSubscription
+	mapped        map[string]uuid.UUID
+}
+
+// resolve returns the subscription mapped to a webhook ref, else the only subscription of its app and event
+func (r *webhookResolver) resolve(webhook models.TemplateWebhook) (uuid.UUID, error) {
+	if id, ok := r.mapped[webhook.Ref()]; ok {
+		return id, nil
+	}
+
+	var matches []uuid.UUID
+	for _, subscription := range r.subscriptions {
+		if subscription.AppName != webhook.AppName {
+			continue
+		}
+		if webhook.SubscribedEvent != "" && subscription.SubscribedEvent != webhook.SubscribedEvent {
+			continue
+		}
+		matches = append(matches, subscription.ID)
+	}
+	switch len(matches) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("no webhook matches %q, subscribe one or map it in webhooks", webhook.Ref())
+	case 1:
+		return matches[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("%d webhooks match %q, set subscribed_event or map it in webhooks", len(matches), webhook.Ref())
+	}
+}
+
+// step converts a template step into a step creation request
+func (r *webhookResolver) step(templateStep models.ChainTemplateStep) (models.CreateExecutionChainStep, error) {
+	step := models.CreateExecutionChainStep{
+		Name:            templateStep.Name,
+		Description:     templateStep.Description,
+		Type:            templateStep.Type,
+		RequestParams:   templateStep.RequestParams,
+		ResponseSchema:  templateStep.ResponseSchema,
+		Condition:       templateStep.Condition,
+		ParallelGroup:   templateStep.ParallelGroup,
+		Key:             templateStep.Key,
+		DependsOn:       templateStep.DependsOn,
+		Branches:        templateStep.Branches,
+		BranchPath:      templateStep.BranchPath,
+		Extract:         templateStep.Extract,
+		OnSuccessAction: templateStep.OnSuccessAction,
+		OnFailureAction: templateStep.OnFailureAction,
+		MaxRetries:      templateStep.MaxRetries,
+		DelaySeconds:    templateStep.DelaySeconds,
+	}
+	if step.Name == "" {
+		return step, fmt.Errorf("name is required")
+	}
+	if templateStep.Webhook != nil {
+		id, err := r.resolve(*templateStep.Webhook)
+		if err != nil {
+			return step, err
+		}
+		step.WebhookID = &id
+	}
+	if templateStep.Compensation != nil {
+		id, err := r.resolve(templateStep.Compensation.Webhook)
+		if err != nil {
+			return step, fmt.Errorf("compensation: %w", err)
+		}
+		step.Compensation = &models.StepCompensation{WebhookID: id, RequestParams: templateStep.Compensation.RequestParams}
+	}
+	return step, nil
+}
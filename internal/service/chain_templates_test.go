@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// subscription returns an active subscription of a tenant's app to an event
+func subscription(tenantID, appName, event string) models.WebhookSubscription {
+	return models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        tenantID,
+		AppName:         appName,
+		SubscribedEvent: event,
+		TargetURL:       "https://" + appName + ".example.com/hooks",
+		Type:            models.WebhookTypePublic,
+		IsActive:        true,
+	}
+}
+
+// expectSubscriptions makes the webhook repository list the subscriptions of a tenant and find each by ID
+func expectSubscriptions(webhookRepo *mocks.MockWebhookRepository, tenantID string, subscriptions ...models.WebhookSubscription) {
+	webhookRepo.EXPECT().GetSubscriptionsByTenant(mock.Anything, tenantID, mock.Anything, mock.Anything).
+		Return(subscriptions, int64(len(subscriptions)), nil).Maybe()
+	for i := range subscriptions {
+		webhookRepo.EXPECT().GetSubscriptionByID(mock.Anything, subscriptions[i].ID).Return(&subscriptions[i], nil).Maybe()
+	}
+}
+
+// newTemplateService creates an execution chain service over mocked repositories that stores the chains it
+// creates and returns them in the created pointer
+func newTemplateService(t *testing.T, tenantID string, created **models.ExecutionChain) (service.ExecutionChainService, *mocks.MockExecutionChainRepository, *mocks.MockWebhookRepository) {
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	tenantRepo.EXPECT().GetTenant(mock.Anything, tenantID).
+		Return(&models.Tenant{ID: tenantID, Status: models.TenantStatusActive}, nil).Maybe()
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	historyRepo.EXPECT().GetLatestVersion(mock.Anything, models.ConfigResourceChain, mock.Anything).Return(0, nil).Maybe()
+	historyRepo.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).Return(nil).Maybe()
+	chainRepo.EXPECT().CreateChain(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, chain *models.ExecutionChain) error {
+		*created = chain
+		return nil
+	}).Maybe()
+	return service.NewExecutionChainService(chainRepo, webhookRepo, tenantRepo, historyRepo, nil, nil, logging.Nop()), chainRepo, webhookRepo
+}
+
+// TestExportChain_ImportForAnotherTenant tests that an exported chain names its webhooks by app name, adding the
+// subscribed event only where the app has several subscriptions, and that importing it for another tenant
+// resolves those names, or the mapped refs, to that tenant's subscriptions
+func TestExportChain_ImportForAnotherTenant(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var created *models.ExecutionChain
+	chainService, chainRepo, webhookRepo := newTemplateService(t, "tenant-456", &created)
+
+	invoice := subscription("tenant-123", "billing", "invoice.create")
+	void := subscription("tenant-123", "billing", "invoice.void")
+	ship := subscription("tenant-123", "shipping", "shipment.create")
+	expectSubscriptions(webhookRepo, "tenant-123", invoice, void, ship)
+	otherInvoice := subscription("tenant-456", "billing", "invoice.create")
+	otherVoid := subscription("tenant-456", "billing", "invoice.void")
+	dispatch := subscription("tenant-456", "dispatch", "shipment.create")
+	expectSubscriptions(webhookRepo, "tenant-456", otherInvoice, otherVoid, dispatch)
+
+	invoiceStep := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 1, Name: "Invoice", Key: "invoice",
+		Type: models.StepTypeWebhook, WebhookID: &invoice.ID, CompensationWebhookID: &void.ID}
+	shipStep := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 2, Name: "Ship", Type: models.StepTypeWebhook,
+		WebhookID: &ship.ID, DependsOn: []uuid.UUID{invoiceStep.ID}, RequestParams: `{"order_id": "{{.trigger_data.order_id}}"}`}
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", Name: "Fulfilment", TriggerEvent: "order.created",
+		Steps: []models.ExecutionChainStep{shipStep, invoiceStep}}
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, mock.Anything).RunAndReturn(func(context.Context, uuid.UUID) (*models.ExecutionChain, error) {
+		return created, nil
+	}).Maybe()
+
+	// Act
+	template, err := chainService.ExportChain(ctx, "", chain.ID)
+	require.NoError(t, err)
+	imported, err := chainService.ImportChain(ctx, template, &models.InstantiateChainTemplateRequest{
+		TenantID: "tenant-456",
+		Name:     "Imported fulfilment",
+		Webhooks: map[string]uuid.UUID{"shipping": dispatch.ID},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, models.ChainTemplateVersion, template.Version)
+	assert.Empty(t, template.ID)
+	require.Len(t, template.Steps, 2)
+	assert.Equal(t, &models.TemplateWebhook{AppName: "billing", SubscribedEvent: "invoice.create"}, template.Steps[0].Webhook)
+	require.NotNil(t, template.Steps[0].Compensation)
+	assert.Equal(t, models.TemplateWebhook{AppName: "billing", SubscribedEvent: "invoice.void"}, template.Steps[0].Compensation.Webhook)
+	assert.Equal(t, &models.TemplateWebhook{AppName: "shipping"}, template.Steps[1].Webhook)
+	assert.Equal(t, []string{"invoice"}, template.Steps[1].DependsOn)
+
+	assert.Equal(t, "Imported fulfilment", imported.Name)
+	assert.Equal(t, "order.created", imported.TriggerEvent)
+	require.NotNil(t, created)
+	assert.Equal(t, "tenant-456", created.TenantID)
+	require.Len(t, created.Steps, 2)
+	assert.Equal(t, otherInvoice.ID, *created.Steps[0].WebhookID)
+	assert.Equal(t, &otherVoid.ID, created.Steps[0].CompensationWebhookID)
+	assert.Equal(t, dispatch.ID, *created.Steps[1].WebhookID)
+	assert.Equal(t, []uuid.UUID{created.Steps[0].ID}, created.Steps[1].DependsOn)
+}
+
+// TestImportChain_UnresolvedWebhooks tests that a template is rejected when a webhook matches no or several
+// subscriptions of the tenant, or when its format version is not supported
+func TestImportChain_UnresolvedWebhooks(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var created *models.ExecutionChain
+	chainService, _, webhookRepo := newTemplateService(t, "tenant-123", &created)
+	expectSubscriptions(webhookRepo, "tenant-123",
+		subscription("tenant-123", "billing", "invoice.create"),
+		subscription("tenant-123", "billing", "invoice.void"))
+	template := func(webhook models.TemplateWebhook) *models.ChainTemplate {
+		return &models.ChainTemplate{
+			Version: models.ChainTemplateVersion, Name: "Billing", TriggerEvent: "order.created",
+			Steps: []models.ChainTemplateStep{{Name: "Invoice", Webhook: &webhook}},
+		}
+	}
+	tests := []struct {
+		name     string
+		template *models.ChainTemplate
+		want     string
+	}{
+		{name: "no match", template: template(models.TemplateWebhook{AppName: "shipping"}),
+			want: `step 1: no webhook matches "shipping", subscribe one or map it in webhooks`},
+		{name: "several matches", template: template(models.TemplateWebhook{AppName: "billing"}),
+			want: `step 1: 2 webhooks match "billing", set subscribed_event or map it in webhooks`},
+		{name: "unsupported version", template: &models.ChainTemplate{Version: models.ChainTemplateVersion + 1},
+			want: "unsupported template version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			response, err := chainService.ImportChain(ctx, tt.template, &models.InstantiateChainTemplateRequest{TenantID: "tenant-123"})
+
+			// Assert
+			assert.ErrorContains(t, err, tt.want)
+			assert.Nil(t, response)
+		})
+	}
+	assert.Nil(t, created)
+}
+
+// TestInstantiateChainTemplate tests that the catalog lists its templates with the webhooks they call, and that
+// a catalog template is instantiated for a tenant subscribed to those webhooks
+func TestInstantiateChainTemplate(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var created *models.ExecutionChain
+	chainService, chainRepo, webhookRepo := newTemplateService(t, "tenant-123", &created)
+	expectSubscriptions(webhookRepo, "tenant-123",
+		subscription("tenant-123", "inventory-service", "inventory.reserve"),
+		subscription("tenant-123", "inventory-service", "inventory.release"),
+		subscription("tenant-123", "payment-service", "payment.capture"),
+		subscription("tenant-123", "payment-service", "payment.refund"),
+		subscription("tenant-123", "shipping-service", "shipment.create"))
+	chainRepo.EXPECT().GetChainByID(ctx, mock.Anything).RunAndReturn(func(context.Context, uuid.UUID) (*models.ExecutionChain, error) {
+		return created, nil
+	}).Maybe()
+
+	// Act
+	catalog := chainService.ListChainTemplates()
+	response, err := chainService.InstantiateChainTemplate(ctx, "order-fulfillment", &models.InstantiateChainTemplateRequest{TenantID: "tenant-123"})
+	_, unknownErr := chainService.InstantiateChainTemplate(ctx, "no-such-template", &models.InstantiateChainTemplateRequest{TenantID: "tenant-123"})
+
+	// Assert
+	var summary *models.ChainTemplateSummary
+	for i := range catalog.Templates {
+		if catalog.Templates[i].ID == "order-fulfillment" {
+			summary = &catalog.Templates[i]
+		}
+	}
+	require.NotNil(t, summary)
+	assert.Equal(t, 3, summary.StepsCount)
+	assert.Equal(t, []string{
+		"inventory-service/inventory.reserve", "inventory-service/inventory.release",
+		"payment-service/payment.capture", "payment-service/payment.refund", "shipping-service",
+	}, summary.Webhooks)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Order Fulfillment", response.Name)
+	assert.Equal(t, 3, response.StepsCount)
+	assert.ErrorIs(t, unknownErr, service.ErrChainTemplateNotFound)
+}
@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestResumeChainRun_PinnedVersion tests that a run resumed after its chain's steps were updated continues with
+// the steps of the version it started on
+func TestResumeChainRun_PinnedVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, tenantRepo := newRunControlService(t)
+	order := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 1, Name: "Order"}
+	ship := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 2, Name: "Ship"}
+	express := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 2, Name: "Ship express"}
+	invoice := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 3, Name: "Invoice"}
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", IsActive: true, Version: 2,
+		Steps: []models.ExecutionChainStep{order, express, invoice}}
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: chain.ID, TenantID: "tenant-123", ChainVersion: 1,
+		Status: models.ExecutionChainStatusPaused, CurrentStep: 2, TotalSteps: 2}
+
+	chainRepo.EXPECT().GetChainRunByID(ctx, run.ID).Return(run, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123"}, nil).Once()
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+	chainRepo.EXPECT().GetChainVersion(ctx, chain.ID, 1).Return(&models.ExecutionChainVersion{
+		ChainID: chain.ID, Version: 1, Steps: models.PinVersionSteps([]models.ExecutionChainStep{order, ship}),
+	}, nil).Once()
+	chainRepo.EXPECT().GetStepsByIDs(ctx, []uuid.UUID{order.ID, ship.ID}).Return([]models.ExecutionChainStep{ship, order}, nil).Once()
+	chainRepo.EXPECT().GetStepRunsByRun(mock.Anything, run.ID).Return([]*models.ExecutionChainStepRun{
+		{StepOrder: 1, Status: models.WebhookStatusSent},
+		{StepOrder: 2, Status: models.WebhookStatusSent},
+	}, nil)
+	chainRepo.EXPECT().UpdateChainRunIfStatus(ctx, run.ID, run.Status, mock.Anything).Return(true, nil).Once()
+	chainRepo.EXPECT().UpdateChainRunStatus(mock.Anything, run.ID, models.ExecutionChainStatusCompleted).Return(nil).Once()
+	chainRepo.EXPECT().GetChainRunByID(mock.Anything, run.ID).Return(run, nil).Once()
+	finished := awaitRunFinished(tenantRepo, "tenant-123")
+
+	// Act
+	response, err := chainService.ResumeChainRun(ctx, run.ID)
+
+	// Assert: both steps of version 1 succeeded, so no step of the current version runs
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.CurrentStep)
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("resumed run did not complete")
+	}
+}
+
+// TestRollbackChain tests that rolling back keeps the steps of the earlier version that are still current,
+// recreates the retired ones and retires the rest, recording the result as a new version
+func TestRollbackChain(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	orderHook, shipHook, expressHook := uuid.New(), uuid.New(), uuid.New()
+	retiredAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	order := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 1, Name: "Order", WebhookID: &orderHook, Type: models.StepTypeWebhook, OnSuccessAction: "continue", OnFailureAction: "stop", MaxRetries: 3}
+	ship := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 2, Name: "Ship", WebhookID: &shipHook, Type: models.StepTypeWebhook, OnSuccessAction: "continue", OnFailureAction: "stop", MaxRetries: 3, RetiredAt: &retiredAt}
+	express := models.ExecutionChainStep{ID: uuid.New(), StepOrder: 2, Name: "Ship express", WebhookID: &expressHook, Type: models.StepTypeWebhook, OnSuccessAction: "continue", OnFailureAction: "stop", MaxRetries: 3}
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", Version: 2, Steps: []models.ExecutionChainStep{order, express}}
+
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(ctx, mock.Anything).RunAndReturn(func(_ context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+		return &models.WebhookSubscription{ID: id, TenantID: "tenant-123"}, nil
+	})
+	historyRepo := mocks.NewMockConfigHistoryRepository(t)
+	historyRepo.EXPECT().GetLatestVersion(ctx, models.ConfigResourceChain, chain.ID).Return(2, nil).Maybe()
+	historyRepo.EXPECT().CreateSnapshot(ctx, mock.Anything).Return(nil).Maybe()
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil)
+	chainRepo.EXPECT().GetChainVersion(ctx, chain.ID, 1).Return(&models.ExecutionChainVersion{
+		ChainID: chain.ID, Version: 1, Steps: models.PinVersionSteps([]models.ExecutionChainStep{order, ship}),
+	}, nil).Once()
+	chainRepo.EXPECT().GetStepsByIDs(ctx, []uuid.UUID{order.ID, ship.ID}).Return([]models.ExecutionChainStep{order, ship}, nil).Once()
+
+	var kept, created []models.ExecutionChainStep
+	var retired []uuid.UUID
+	var version *models.ExecutionChainVersion
+	chainRepo.EXPECT().UpdateChainSteps(ctx, chain.ID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ uuid.UUID, k, c []models.ExecutionChainStep, r []uuid.UUID, v *models.ExecutionChainVersion) error {
+			kept, created, retired, version = k, c, r, v
+			return nil
+		}).Once()
+	chainService := service.NewExecutionChainService(chainRepo, webhookRepo, nil, historyRepo, nil, nil, logging.Nop())
+
+	// Act
+	response, err := chainService.RollbackChain(ctx, "", chain.ID, 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.Version)
+	assert.Equal(t, 1, response.Kept)
+	assert.Equal(t, 1, response.Added)
+	assert.Equal(t, 1, response.Retired)
+	require.Len(t, kept, 1)
+	assert.Equal(t, order.ID, kept[0].ID)
+	require.Len(t, created, 1)
+	assert.Equal(t, "Ship", created[0].Name)
+	assert.Equal(t, &shipHook, created[0].WebhookID)
+	assert.NotEqual(t, ship.ID, created[0].ID, "retired steps are recreated, not revived")
+	assert.Equal(t, []uuid.UUID{express.ID}, retired)
+	require.NotNil(t, version.RolledBackFrom)
+	assert.Equal(t, 1, *version.RolledBackFrom)
+	assert.Equal(t, []uuid.UUID{order.ID, created[0].ID}, []uuid.UUID{version.Steps[0].StepID, version.Steps[1].StepID})
+}
+
+// TestRollbackChain_CurrentVersion tests that rolling back to the current version is rejected
+func TestRollbackChain_CurrentVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	chainService, chainRepo, _ := newRunControlService(t)
+	chain := &models.ExecutionChain{ID: uuid.New(), TenantID: "tenant-123", Version: 2}
+	chainRepo.EXPECT().GetChainByID(ctx, chain.ID).Return(chain, nil).Once()
+
+	// Act
+	response, err := chainService.RollbackChain(ctx, "", chain.ID, 2)
+
+	// Assert
+	assert.ErrorContains(t, err, "version 2 is already the current version")
+	assert.Nil(t, response)
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for the services
+// Services read the time and wait through a Clock instead of calling time.Now and time.Sleep
+// directly, so tests can substitute a fake and drive retry, backoff and scheduling logic deterministically
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the real wall clock
+type systemClock struct{}
+
+// NewSystemClock returns the Clock backed by the real wall clock, used by default by all services
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+// Now returns the current wall clock time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse on a runtime timer
+// Unfired timers are garbage collected, so abandoning the channel does not leak
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// sleepContext waits for the given duration on clock unless ctx is cancelled first
+// Returns false if the wait was cut short by cancellation
+func sleepContext(ctx context.Context, clock Clock, d time.Duration) bool {
+	select {
+	case <-clock.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"sync"
+	"time"
+)
+
+// fakeClock is a Clock standing still at now until the test advances it
+// Waits started with After fire once the clock is advanced to their deadline, so tests drive retries,
+// expiries and scheduling passes without sleeping
+type fakeClock struct {
+	mu      sync.Mutex
+	waiting *sync.Cond
+	now     time.Time
+	waits   []fakeWait
+
+	// autoAdvance moves the clock to the deadline of every wait as it starts, ending the wait at once, for
+	// code waiting in the test's own goroutine
+	autoAdvance bool
+}
+
+// fakeWait is a wait started with After that has not fired yet
+type fakeWait struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// newFakeClock returns a fake clock standing at now
+func newFakeClock(now time.Time) *fakeClock {
+	clock := &fakeClock{now: now}
+	clock.waiting = sync.NewCond(&clock.mu)
+	return clock
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if c.autoAdvance && d > 0 {
+		c.now = c.now.Add(d)
+	}
+	if d <= 0 || c.autoAdvance {
+		ch <- c.now
+		return ch
+	}
+	c.waits = append(c.waits, fakeWait{deadline: c.now.Add(d), ch: ch})
+	c.waiting.Broadcast()
+	return ch
+}
+
+// Advance moves the clock forward by d and fires the waits due by then
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waits[:0]
+	for _, wait := range c.waits {
+		if wait.deadline.After(c.now) {
+			pending = append(pending, wait)
+			continue
+		}
+		wait.ch <- c.now
+	}
+	c.waits = pending
+}
+
+// BlockUntil returns once n waits are pending, so the test advances the clock only after the code under
+// test started waiting on it
+func (c *fakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waits) < n {
+		c.waiting.Wait()
+	}
+}
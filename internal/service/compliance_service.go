@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultErasureBatchSize is how many rows an erasure rewrites per transaction
+const DefaultErasureBatchSize = 500
+
+// ErrInvalidSubjectKey is returned when erasing a subject identifier that is too short to be erased safely
+var ErrInvalidSubjectKey = apperr.Validation("invalid_subject_key", "invalid subject key")
+
+// ComplianceService erases data subjects from a tenant's stored data
+// Erasing a subject deletes its subject key, crypto-shredding the sensitive fields encrypted with it, and
+// scrubs every stored payload, answer and error mentioning its identifier, in the live tables and the archives
+type ComplianceService interface {
+	// EraseSubject erases a data subject from a tenant's stored data and returns the signed erasure report
+	// Erasing is idempotent, so an erasure that failed part way can be repeated
+	// Returns ErrTenantNotFound for unknown tenants and ErrInvalidSubjectKey for too short identifiers
+	EraseSubject(ctx context.Context, tenantID, subject string) (*models.ErasureResponse, error)
+
+	// SetClock replaces the clock used for timestamps
+	SetClock(clock Clock)
+}
+
+// complianceService implements ComplianceService
+type complianceService struct {
+	complianceRepo repository.ComplianceRepository
+	tenantRepo     repository.TenantRepository
+	clock          Clock
+	logger         logging.Logger
+}
+
+// NewComplianceService creates a new compliance service
+func NewComplianceService(complianceRepo repository.ComplianceRepository, tenantRepo repository.TenantRepository, logger logging.Logger) ComplianceService {
+	return &complianceService{
+		complianceRepo: complianceRepo,
+		tenantRepo:     tenantRepo,
+		clock:          NewSystemClock(),
+		logger:         logger,
+	}
+}
+
+// SetClock replaces the clock used for timestamps
+func (s *complianceService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// EraseSubject erases a data subject from a tenant's stored data and returns the signed erasure report
+// The report is signed with the tenant's Ed25519 signing key, generated for tenants signing deliveries with
+// HMAC without changing how their deliveries are signed, so it verifies with the tenant's public key set
+func (s *complianceService) EraseSubject(ctx context.Context, tenantID, subject string) (*models.ErasureResponse, error) {
+	if len(subject) < models.MinSubjectKeyLength {
+		return nil, ErrInvalidSubjectKey.Withf("subject key must be at least %d characters", models.MinSubjectKeyLength)
+	}
+	exists, err := s.tenantRepo.TenantExists(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant: %w", err)
+	}
+	if !exists {
+		return nil, ErrTenantNotFound
+	}
+
+	// Load the key before erasing, so an erasure is not left without its report
+	key, err := s.reportSigningKey(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ErasureReport{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		SubjectHash: subjectHash(subject),
+	}
+	if report.SubjectKeyShredded, err = s.tenantRepo.DeleteSubjectKey(ctx, tenantID, report.SubjectHash); err != nil {
+		return nil, fmt.Errorf("failed to shred subject key: %w", err)
+	}
+	report.RowsScrubbed, err = s.complianceRepo.ScrubSubject(ctx, tenantID, subject, scrubSubject(subject), DefaultErasureBatchSize)
+	for _, rows := range report.RowsScrubbed {
+		report.TotalRowsScrubbed += rows
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Data subject erasure stopped part way",
+			zap.String("tenant_id", tenantID),
+			zap.String("erasure_id", report.ID.String()),
+			zap.Int64("rows_scrubbed", report.TotalRowsScrubbed),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to scrub stored data: %w", err)
+	}
+	report.ErasedAt = s.clock.Now()
+
+	signature, err := signErasureReport(report, key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Data subject erased",
+		zap.String("tenant_id", tenantID),
+		zap.String("erasure_id", report.ID.String()),
+		zap.String("subject_hash", report.SubjectHash),
+		zap.Int64("rows_scrubbed", report.TotalRowsScrubbed),
+		zap.Bool("subject_key_shredded", report.SubjectKeyShredded))
+
+	return &models.ErasureResponse{
+		Report:    *report,
+		Signature: signature,
+		KeyID:     key.ID,
+		KeysURL:   client.KeySetPath + "?tenant_id=" + url.QueryEscape(tenantID),
+	}, nil
+}
+
+// reportSigningKey returns the tenant's current Ed25519 key, generating one if the tenant has none
+func (s *complianceService) reportSigningKey(ctx context.Context, tenantID string) (*models.SigningKey, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+	if settings.SigningKeyID != "" {
+		key, err := s.tenantRepo.GetSigningKey(ctx, settings.SigningKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing key %s: %w", settings.SigningKeyID, err)
+		}
+		return key, nil
+	}
+
+	key, err := generateSigningKey(ctx, s.tenantRepo, s.clock, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	settings.TenantID = tenantID
+	settings.SigningKeyID = key.ID
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save tenant settings: %w", err)
+	}
+	return key, nil
+}
+
+// signErasureReport signs a report as a JWS with EdDSA, the report's fields being its payload
+func signErasureReport(report *models.ErasureReport, key *models.SigningKey) (string, error) {
+	privateKey, err := key.Ed25519PrivateKey()
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode erasure report: %w", err)
+	}
+	claims := jwt.MapClaims{}
+	if err := json.Unmarshal(encoded, &claims); err != nil {
+		return "", fmt.Errorf("failed to encode erasure report: %w", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = key.ID
+	signature, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign erasure report: %w", err)
+	}
+	return signature, nil
+}
+
+// scrubSubject returns the scrubber removing a data subject's identifier from stored values
+// In JSON values, strings mentioning the identifier and numbers equal to it are replaced with [ERASED] and
+// object fields named after it removed; other text has every mention replaced
+func scrubSubject(subject string) repository.SubjectScrubber {
+	return func(value string) (string, bool) {
+		if !json.Valid([]byte(value)) {
+			if !strings.Contains(value, subject) {
+				return value, false
+			}
+			return strings.ReplaceAll(value, subject, models.ErasedValue), true
+		}
+
+		decoded, ok := decodeJSONDocument([]byte(value))
+		if !ok {
+			return value, false
+		}
+		scrubbed, changed := scrubJSONValue(decoded, subject)
+		if !changed {
+			return value, false
+		}
+		encoded, err := encodeJSONDocument(scrubbed)
+		if err != nil {
+			return value, false
+		}
+		return string(encoded), true
+	}
+}
+
+// scrubJSONValue removes a data subject's identifier from a decoded JSON value; returns whether it changed
+func scrubJSONValue(value interface{}, subject string) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, subject) {
+			return models.ErasedValue, true
+		}
+	case json.Number:
+		if v.String() == subject {
+			return models.ErasedValue, true
+		}
+	case map[string]interface{}:
+		changed := false
+		for key, child := range v {
+			if strings.Contains(key, subject) {
+				delete(v, key)
+				changed = true
+				continue
+			}
+			if scrubbed, ok := scrubJSONValue(child, subject); ok {
+				v[key] = scrubbed
+				changed = true
+			}
+		}
+		return v, changed
+	case []interface{}:
+		changed := false
+		for i, child := range v {
+			if scrubbed, ok := scrubJSONValue(child, subject); ok {
+				v[i] = scrubbed
+				changed = true
+			}
+		}
+		return v, changed
+	}
+	return value, false
+}
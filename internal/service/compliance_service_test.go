@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestEraseSubject tests that erasing a subject shreds its key, scrubs stored values mentioning it,
+// and returns a report signed with a signing key generated for a tenant without one
+func TestEraseSubject(t *testing.T) {
+	// Arrange
+	complianceRepo := mocks.NewMockComplianceRepository(t)
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	svc := service.NewComplianceService(complianceRepo, tenantRepo, logging.Nop())
+
+	var signingKey *models.SigningKey
+	tenantRepo.EXPECT().TenantExists(mock.Anything, "tenant-1").Return(true, nil)
+	tenantRepo.EXPECT().GetTenantSettings(mock.Anything, "tenant-1").Return(&models.TenantSettings{TenantID: "tenant-1"}, nil)
+	tenantRepo.EXPECT().CreateSigningKey(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, key *models.SigningKey) error {
+			signingKey = key
+			return nil
+		}).Once()
+	tenantRepo.EXPECT().SaveTenantSettings(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, settings *models.TenantSettings) error {
+			assert.Equal(t, signingKey.ID, settings.SigningKeyID)
+			return nil
+		}).Once()
+	tenantRepo.EXPECT().DeleteSubjectKey(mock.Anything, "tenant-1", mock.Anything).Return(true, nil).Once()
+
+	var scrubbedPayload, scrubbedError string
+	var untouched bool
+	complianceRepo.EXPECT().ScrubSubject(mock.Anything, "tenant-1", "cust-42", mock.Anything, service.DefaultErasureBatchSize).
+		RunAndReturn(func(_ context.Context, _, _ string, scrub repository.SubjectScrubber, _ int) (map[string]int64, error) {
+			scrubbedPayload, _ = scrub(`{"customer":{"id":"cust-42","plan":"pro"},"refs":{"cust-42":true},"amount":10}`)
+			scrubbedError, _ = scrub(`lookup of cust-42 failed`)
+			_, changed := scrub(`{"customer":{"id":"cust-7"}}`)
+			untouched = !changed
+			return map[string]int64{"webhook_events": 2, "execution_chain_runs": 1}, nil
+		}).Once()
+
+	// Act
+	response, err := svc.EraseSubject(context.Background(), "tenant-1", "cust-42")
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.JSONEq(t, `{"customer":{"id":"[ERASED]","plan":"pro"},"refs":{},"amount":10}`, scrubbedPayload)
+	assert.Equal(t, "lookup of [ERASED] failed", scrubbedError)
+	assert.True(t, untouched)
+
+	assert.Equal(t, int64(3), response.Report.TotalRowsScrubbed)
+	assert.True(t, response.Report.SubjectKeyShredded)
+	assert.NotContains(t, response.Report.SubjectHash, "cust-42")
+	assert.Equal(t, signingKey.ID, response.KeyID)
+	assert.Equal(t, "/.well-known/loki-suite/keys.json?tenant_id=tenant-1", response.KeysURL)
+
+	// The signature is a JWS over the report, verifying with the tenant's public key
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(response.Signature, claims, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, signingKey.ID, token.Header["kid"])
+		return ed25519.PublicKey(signingKey.PublicKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, token.Valid)
+	assert.Equal(t, response.Report.ID.String(), claims["id"])
+	assert.Equal(t, response.Report.SubjectHash, claims["subject_hash"])
+}
+
+// TestEraseSubjectRejectsShortSubject tests that an identifier too short to erase safely is refused
+// before anything is erased
+func TestEraseSubjectRejectsShortSubject(t *testing.T) {
+	// Arrange
+	svc := service.NewComplianceService(mocks.NewMockComplianceRepository(t), mocks.NewMockTenantRepository(t), logging.Nop())
+
+	// Act
+	response, err := svc.EraseSubject(context.Background(), "tenant-1", "42")
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, service.ErrInvalidSubjectKey)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"go.uber.org/zap"
+)
+
+// configVolatileFields lists the fields left out of configuration snapshots per resource type
+// They hold timestamps and runtime state, not configuration, and would show up in every diff
+var configVolatileFields = map[models.ConfigResourceType]map[string]bool{
+	models.ConfigResourceSubscription: {"created_at": true, "updated_at": true, "deleted_at": true, "retry_count": true, "lock_version": true},
+	models.ConfigResourceChain:        {"created_at": true, "updated_at": true, "deleted_at": true, "status": true, "webhook": true, "retry_count": true, "next_run_at": true, "last_scheduled_at": true, "lock_version": true},
+}
+
+// recordConfigSnapshot records the next configuration version of a subscription or chain
+// Failures are logged rather than returned: the change itself has already been applied
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - repo: Repository the snapshot is stored in
+//   - clock: Clock the snapshot is timestamped with
+//   - resourceType: Kind of resource
+//   - resourceID: ID of the resource
+//   - tenantID: Tenant that owns the resource
+//   - change: Whether the resource was created, updated or deleted
+//   - resource: The resource model, serialized as JSON without secrets and volatile fields
+func recordConfigSnapshot(ctx context.Context, logger logging.Logger, repo repository.ConfigHistoryRepository, clock Clock, resourceType models.ConfigResourceType, resourceID uuid.UUID, tenantID string, change models.ConfigChange, resource interface{}) {
+	config, err := configSnapshotJSON(resourceType, resource)
+	if err == nil {
+		var version int
+		version, err = repo.GetLatestVersion(ctx, resourceType, resourceID)
+		if err == nil {
+			err = repo.CreateSnapshot(ctx, &models.ConfigSnapshot{
+				ID:           uuid.New(),
+				TenantID:     tenantID,
+				ResourceType: resourceType,
+				ResourceID:   resourceID,
+				Version:      version + 1,
+				Change:       change,
+				Config:       config,
+				CreatedAt:    clock.Now(),
+			})
+		}
+	}
+
+	if err != nil {
+		logger.Error(ctx, "Failed to record configuration snapshot",
+			zap.String("resource_type", string(resourceType)),
+			zap.String("resource_id", resourceID.String()),
+			zap.String("change", string(change)),
+			zap.Error(err))
+	}
+}
+
+// configSnapshotJSON serializes a resource for a snapshot, dropping the volatile fields of its type at any depth
+func configSnapshotJSON(resourceType models.ConfigResourceType, resource interface{}) (string, error) {
+	encoded, err := json.Marshal(resource)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	var config interface{}
+	if err := json.Unmarshal(encoded, &config); err != nil {
+		return "", fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	stripConfigFields(config, configVolatileFields[resourceType])
+
+	encoded, err = json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// stripConfigFields removes the given object keys from a decoded JSON value recursively
+func stripConfigFields(value interface{}, fields map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if fields[key] {
+				delete(v, key)
+				continue
+			}
+			stripConfigFields(item, fields)
+		}
+	case []interface{}:
+		for _, item := range v {
+			stripConfigFields(item, fields)
+		}
+	}
+}
+
+// loadConfigHistory builds the configuration history of a resource with the diff of every version
+// against the one before it; the first version has no diff
+// With a tenant given, snapshots of other tenants are left out
+func loadConfigHistory(ctx context.Context, repo repository.ConfigHistoryRepository, tenantID string, resourceType models.ConfigResourceType, resourceID uuid.UUID) (*models.ConfigHistoryResponse, error) {
+	snapshots, err := repo.GetSnapshots(ctx, resourceType, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration history: %w", err)
+	}
+	if tenantID != "" {
+		var owned []models.ConfigSnapshot
+		for _, snapshot := range snapshots {
+			if snapshot.TenantID == tenantID {
+				owned = append(owned, snapshot)
+			}
+		}
+		snapshots = owned
+	}
+
+	response := &models.ConfigHistoryResponse{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Versions:     make([]models.ConfigVersion, 0, len(snapshots)),
+	}
+
+	var previous map[string]interface{}
+	for i, snapshot := range snapshots {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(snapshot.Config), &config); err != nil {
+			return nil, fmt.Errorf("invalid configuration snapshot version %d: %w", snapshot.Version, err)
+		}
+
+		diff := []models.ConfigFieldDiff{}
+		if i > 0 {
+			diffConfig("", previous, config, &diff)
+		}
+
+		response.Versions = append(response.Versions, models.ConfigVersion{
+			Version:   snapshot.Version,
+			Change:    snapshot.Change,
+			CreatedAt: snapshot.CreatedAt,
+			Config:    config,
+			Diff:      diff,
+		})
+		previous = config
+	}
+
+	return response, nil
+}
+
+// configDiff lists the differences between two configurations, compared in their JSON form
+func configDiff(old, new interface{}) ([]models.ConfigFieldDiff, error) {
+	var decoded [2]interface{}
+	for i, value := range []interface{}{old, new} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode configuration: %w", err)
+		}
+		if err := json.Unmarshal(encoded, &decoded[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode configuration: %w", err)
+		}
+	}
+
+	diff := []models.ConfigFieldDiff{}
+	diffConfig("", decoded[0], decoded[1], &diff)
+	return diff, nil
+}
+
+// diffConfig appends the differences between two decoded JSON values to diff
+// Objects are compared key by key and arrays index by index; path locates the values, e.g. "steps[1].name"
+func diffConfig(path string, old, new interface{}, diff *[]models.ConfigFieldDiff) {
+	switch o := old.(type) {
+	case map[string]interface{}:
+		if n, ok := new.(map[string]interface{}); ok {
+			// Iterate in a stable order so the diff is deterministic
+			keys := make([]string, 0, len(o)+len(n))
+			for key := range o {
+				keys = append(keys, key)
+			}
+			for key := range n {
+				if _, ok := o[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				keyPath := key
+				if path != "" {
+					keyPath = path + "." + key
+				}
+				oldValue, inOld := o[key]
+				newValue, inNew := n[key]
+				switch {
+				case !inOld:
+					*diff = append(*diff, models.ConfigFieldDiff{Path: keyPath, Op: "added", New: newValue})
+				case !inNew:
+					*diff = append(*diff, models.ConfigFieldDiff{Path: keyPath, Op: "removed", Old: oldValue})
+				default:
+					diffConfig(keyPath, oldValue, newValue, diff)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if n, ok := new.([]interface{}); ok {
+			for i := 0; i < len(o) || i < len(n); i++ {
+				itemPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(o):
+					*diff = append(*diff, models.ConfigFieldDiff{Path: itemPath, Op: "added", New: n[i]})
+				case i >= len(n):
+					*diff = append(*diff, models.ConfigFieldDiff{Path: itemPath, Op: "removed", Old: o[i]})
+				default:
+					diffConfig(itemPath, o[i], n[i], diff)
+				}
+			}
+			return
+		}
+	}
+
+	if !jsonEqual(old, new) {
+		*diff = append(*diff, models.ConfigFieldDiff{Path: path, Op: "changed", Old: old, New: new})
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// subscriptionSync is the planned creation or update of one subscription while applying a manifest
+type subscriptionSync struct {
+	// change is the index of the subscription's entry in the planned changes
+	change       int
+	subscription *models.WebhookSubscription
+	create       bool
+}
+
+// subscriptionPlan is the plan of a manifest's subscriptions
+type subscriptionPlan struct {
+	changes []models.ConfigResourceChange
+	syncs   []subscriptionSync
+
+	// subscriptions are the tenant's subscriptions as they are once the plan is applied
+	subscriptions []models.WebhookSubscription
+
+	deleted []uuid.UUID
+}
+
+// ApplyConfig reconciles the subscriptions and chains of a tenant with a declarative manifest
+// Subscriptions are matched by subscribed event, target type and target, and chains by name: missing ones
+// are created, differing ones updated and those the manifest does not list deleted. Generated receive
+// endpoints are left as they are. Nothing is applied when any entry is invalid, and chains are checked
+// against the subscriptions as they are once the manifest is applied
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - manifest: The subscriptions and chains the tenant should have
+//   - opts: Target tenant and dry run
+//
+// Returns:
+//   - ConfigApplyResponse: The change of every subscription and chain, applied unless the manifest was a dry
+//     run or has invalid entries
+//   - error: If the tenant is unknown or suspended, the manifest version is unsupported, the tenant's
+//     configuration cannot be loaded, or applying a change fails
+//
+// Use case: Managing webhook configuration from version control (GitOps), planning with a dry run in review
+func (s *webhookService) ApplyConfig(ctx context.Context, manifest *models.ConfigManifest, opts models.ConfigApplyOptions) (*models.ConfigApplyResponse, error) {
+	if manifest.Version != models.ConfigManifestVersion {
+		return nil, ErrInvalidWebhook.Withf("unsupported manifest version %d, expected %d", manifest.Version, models.ConfigManifestVersion)
+	}
+	tenantID := opts.TenantID
+	if tenantID == "" {
+		tenantID = manifest.TenantID
+	}
+	tenant, err := activeTenant(ctx, s.tenantRepo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if s.chainService == nil {
+		return nil, fmt.Errorf("execution chains are not available")
+	}
+
+	existing, err := tenantSubscriptions(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	plan := s.planSubscriptions(ctx, tenant, manifest.Subscriptions, existing)
+	chainChanges, err := s.chainService.SyncChains(ctx, tenantID, manifest.Chains, plan.subscriptions, true)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ConfigApplyResponse{TenantID: tenantID, DryRun: opts.DryRun}
+	response.Changes = append(plan.changes, chainChanges...)
+	for _, change := range response.Changes {
+		response.Count(change.Action)
+	}
+
+	if opts.DryRun || response.Invalid > 0 {
+		// Secrets of subscriptions that are not created are not returned
+		for i := range response.Changes {
+			response.Changes[i].SecretToken = nil
+			response.Changes[i].JWTToken = nil
+		}
+		s.logger.Info(ctx, "Configuration manifest not applied",
+			zap.String("tenant_id", tenantID),
+			zap.Bool("dry_run", opts.DryRun),
+			zap.Int("invalid", response.Invalid))
+		return response, nil
+	}
+
+	// Subscriptions are created and updated before the chains calling them, and deleted after the chains
+	// that called them
+	for _, sync := range plan.syncs {
+		change := &plan.changes[sync.change]
+		subscription := sync.subscription
+		if sync.create {
+			if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+				return nil, fmt.Errorf("failed to create subscription %d: %w", *change.Index, err)
+			}
+			recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, tenantID, models.ConfigChangeCreated, subscription)
+			change.ResourceID = &subscription.ID
+			continue
+		}
+		if err := s.repo.UpdateSubscription(ctx, subscription); err != nil {
+			return nil, fmt.Errorf("failed to update subscription %s: %w", subscription.ID, err)
+		}
+		recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, tenantID, models.ConfigChangeUpdated, subscription)
+	}
+
+	if chainChanges, err = s.chainService.SyncChains(ctx, tenantID, manifest.Chains, plan.subscriptions, false); err != nil {
+		return nil, err
+	}
+	response.Changes = append(plan.changes, chainChanges...)
+
+	for _, id := range plan.deleted {
+		if err := s.DeleteWebhook(ctx, "", id); err != nil {
+			return nil, err
+		}
+	}
+	response.Applied = true
+
+	s.logger.Info(ctx, "Configuration manifest applied",
+		zap.String("tenant_id", tenantID),
+		zap.Int("created", response.Created),
+		zap.Int("updated", response.Updated),
+		zap.Int("deleted", response.Deleted))
+	return response, nil
+}
+
+// planSubscriptions plans the changes reconciling a tenant's subscriptions with a manifest's
+// Of several subscriptions of the tenant for the same event and target, the oldest is kept and the others
+// are deleted
+func (s *webhookService) planSubscriptions(ctx context.Context, tenant *models.Tenant, entries []models.ExportedSubscription, existing []models.WebhookSubscription) *subscriptionPlan {
+	plan := &subscriptionPlan{changes: make([]models.ConfigResourceChange, 0, len(entries))}
+
+	current := make(map[string]*models.WebhookSubscription, len(existing))
+	for i := range existing {
+		if isReceiveEndpoint(existing[i]) {
+			continue
+		}
+		if key := subscriptionKey(&existing[i]); current[key] == nil {
+			current[key] = &existing[i]
+		}
+	}
+
+	kept := make(map[uuid.UUID]bool, len(entries))
+	listed := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		index := i
+		change := models.ConfigResourceChange{
+			ResourceType: models.ConfigResourceSubscription,
+			Index:        &index,
+			Name:         entry.AppName + " " + entry.SubscribedEvent,
+		}
+
+		entry = withCurrentRoutingKey(entry, current)
+		subscription, err := s.importSubscription(ctx, tenant, entry)
+		if err == nil {
+			if earlier, ok := listed[subscriptionKey(subscription)]; ok {
+				err = fmt.Errorf("duplicates subscription %d of the manifest", earlier)
+			} else {
+				listed[subscriptionKey(subscription)] = i
+			}
+		}
+		if err != nil {
+			change.Action = models.ConfigActionInvalid
+			change.Error = importError(err)
+			plan.changes = append(plan.changes, change)
+			continue
+		}
+
+		match := current[subscriptionKey(subscription)]
+		if match == nil {
+			change.Action = models.ConfigActionCreate
+			if entry.SecretToken != nil && *entry.SecretToken != "" {
+				subscription.SecretToken = *entry.SecretToken
+			} else {
+				change.SecretToken = &subscription.SecretToken
+			}
+			change.JWTToken = subscription.JWTToken
+			plan.syncs = append(plan.syncs, subscriptionSync{change: len(plan.changes), subscription: subscription, create: true})
+			plan.subscriptions = append(plan.subscriptions, *subscription)
+			plan.changes = append(plan.changes, change)
+			continue
+		}
+
+		kept[match.ID] = true
+		change.ResourceID = &match.ID
+		updated, diff, err := s.syncedSubscription(ctx, match, subscription, entry)
+		switch {
+		case err != nil:
+			change.Action = models.ConfigActionInvalid
+			change.Error = importError(err)
+		case len(diff) == 0:
+			change.Action = models.ConfigActionUnchanged
+			plan.subscriptions = append(plan.subscriptions, *match)
+		default:
+			change.Action = models.ConfigActionUpdate
+			change.Diff = diff
+			if updated.Type == models.WebhookTypePrivate && match.Type != models.WebhookTypePrivate {
+				change.JWTToken = updated.JWTToken
+			}
+			plan.syncs = append(plan.syncs, subscriptionSync{change: len(plan.changes), subscription: updated})
+			plan.subscriptions = append(plan.subscriptions, *updated)
+		}
+		plan.changes = append(plan.changes, change)
+	}
+
+	for i := range existing {
+		subscription := existing[i]
+		switch {
+		case isReceiveEndpoint(subscription):
+			plan.subscriptions = append(plan.subscriptions, subscription)
+		case !kept[subscription.ID]:
+			plan.deleted = append(plan.deleted, subscription.ID)
+			plan.changes = append(plan.changes, models.ConfigResourceChange{
+				ResourceType: models.ConfigResourceSubscription,
+				Action:       models.ConfigActionDelete,
+				ResourceID:   &subscription.ID,
+				Name:         subscription.AppName + " " + subscription.SubscribedEvent,
+			})
+		}
+	}
+	return plan
+}
+
+// withCurrentRoutingKey gives a PagerDuty manifest entry without a routing key the key of the subscription
+// it matches, so manifests exported without secrets can be applied again
+func withCurrentRoutingKey(entry models.ExportedSubscription, current map[string]*models.WebhookSubscription) models.ExportedSubscription {
+	if entry.TargetType != models.TargetTypePagerDuty || entry.PagerDutyRoutingKey != nil {
+		return entry
+	}
+	target := &models.WebhookSubscription{SubscribedEvent: entry.SubscribedEvent, TargetType: entry.TargetType, TargetURL: entry.TargetURL}
+	if target.TargetURL == "" {
+		target.TargetURL = models.PagerDutyEventsURL
+	}
+	if match := current[subscriptionKey(target)]; match != nil {
+		entry.PagerDutyRoutingKey = match.PagerDutyRoutingKey
+	}
+	return entry
+}
+
+// syncedSubscription returns a subscription changed to match its manifest entry and the changes made
+// built is the subscription the entry creates; retry policies the entry leaves unset are kept
+func (s *webhookService) syncedSubscription(ctx context.Context, current, built *models.WebhookSubscription, entry models.ExportedSubscription) (*models.WebhookSubscription, []models.ConfigFieldDiff, error) {
+	if entry.RetryPolicy == nil && built.MaxRetries == 0 && built.RetryDelaySeconds == 0 {
+		built.MaxRetries = current.MaxRetries
+		built.RetryDelaySeconds = current.RetryDelaySeconds
+	}
+
+	before := exportSubscription(*current, false)
+	after := exportSubscription(*built, false)
+	before.SourceID, after.SourceID = uuid.Nil, uuid.Nil
+	diff, err := configDiff(before, after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := *current
+	updated.AppName = built.AppName
+	updated.Description = built.Description
+	updated.Type = built.Type
+	updated.IsActive = built.IsActive
+	if updated.IsActive && !current.IsActive {
+		markEnabled(&updated, s.clock.Now())
+	}
+	updated.AMQPExchange = built.AMQPExchange
+	updated.AMQPRoutingKey = built.AMQPRoutingKey
+	updated.Notification = built.Notification
+	updated.Recipients = built.Recipients
+	updated.Headers = built.Headers
+	updated.QueryParams = built.QueryParams
+	updated.MaxRetries = built.MaxRetries
+	updated.RetryDelaySeconds = built.RetryDelaySeconds
+	updated.BatchMaxEvents = built.BatchMaxEvents
+	updated.BatchWindowSeconds = built.BatchWindowSeconds
+	updated.ContentEncoding = built.ContentEncoding
+	updated.ResponseSchema = built.ResponseSchema
+	updated.SigningHeaders = built.SigningHeaders
+	updated.SignatureScheme = built.SignatureScheme
+	updated.AllowedSourceIPs = built.AllowedSourceIPs
+
+	// Secrets are compared but never listed with their values
+	if entry.SecretToken != nil && *entry.SecretToken != "" && *entry.SecretToken != current.SecretToken {
+		updated.SecretToken = *entry.SecretToken
+		diff = append(diff, models.ConfigFieldDiff{Path: "secret_token", Op: "changed"})
+	}
+	if built.PagerDutyRoutingKey != nil && (current.PagerDutyRoutingKey == nil || *current.PagerDutyRoutingKey != *built.PagerDutyRoutingKey) {
+		updated.PagerDutyRoutingKey = built.PagerDutyRoutingKey
+		diff = append(diff, models.ConfigFieldDiff{Path: "pagerduty_routing_key", Op: "changed"})
+	}
+
+	// JWTs name the subscription, so a subscription made private gets one for its own ID
+	if updated.Type != current.Type {
+		updated.JWTToken = nil
+		if updated.Type == models.WebhookTypePrivate {
+			securityData, err := s.generateWebhookSecurity(ctx, true, current.TenantID, current.ID.String(), updated.AppName)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to generate security credentials: %w", err)
+			}
+			updated.JWTToken = securityData.JWTToken
+		}
+	}
+	return &updated, diff, nil
+}
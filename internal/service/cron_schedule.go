@@ -0,0 +1,245 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros maps the supported shorthand expressions to their five-field form
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the allowed values of one field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	// 7 is accepted as an alias for Sunday
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+// cronSearchLimit bounds how far ahead the next activation is searched, so impossible
+// expressions such as "0 0 30 2 *" terminate
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronSchedule is a parsed five-field cron expression bound to a time zone
+// Fields are bitsets of the values they match; when both day fields are restricted a day
+// matches if either does, as in the classic cron implementations
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+	location                      *time.Location
+}
+
+// parseCronSchedule parses a cron expression such as "*/15 9-17 * * MON-FRI" or "@daily"
+// Parameters:
+//   - expr: Five-field expression (minute hour day-of-month month day-of-week) or a macro
+//   - timezone: IANA time zone the expression is evaluated in, UTC when empty
+//
+// Returns:
+//   - *cronSchedule: Parsed schedule
+//   - error: If the expression or time zone is invalid
+func parseCronSchedule(expr, timezone string) (*cronSchedule, error) {
+	location := time.UTC
+	if timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		location = loaded
+	}
+
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression must have %d fields, got %d", len(cronFields), len(parts))
+	}
+
+	sets := make([]uint64, len(parts))
+	for i, part := range parts {
+		set, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Fold Sunday as 7 into Sunday as 0
+	dow := sets[4]
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return &cronSchedule{
+		minute:        sets[0],
+		hour:          sets[1],
+		dom:           sets[2],
+		month:         sets[3],
+		dow:           dow,
+		domRestricted: parts[2] != "*",
+		dowRestricted: parts[4] != "*",
+		location:      location,
+	}, nil
+}
+
+// parseCronField parses one comma-separated field into the set of values it matches
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if slash := strings.Index(item, "/"); slash >= 0 {
+			rangePart = item[:slash]
+			parsed, err := strconv.Atoi(item[slash+1:])
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", spec.name, item)
+			}
+			step = parsed
+		}
+
+		low, high := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseCronValue(bounds[0], spec); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(bounds[1], spec); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s field %q", spec.name, item)
+			}
+		default:
+			value, err := parseCronValue(rangePart, spec)
+			if err != nil {
+				return 0, err
+			}
+			// "5/10" means every 10 starting at 5; a plain value matches only itself
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a single number or name of a cron field
+func parseCronValue(value string, spec cronField) (int, error) {
+	if number, ok := spec.names[strings.ToUpper(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < spec.min || number > spec.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", value, spec.name, spec.min, spec.max)
+	}
+	return number, nil
+}
+
+// next returns the first activation strictly after the given time, or the zero time if the
+// expression never matches within the search limit
+// Activations are searched on the wall clock, where every day has 24 hours, and then placed in the time zone
+// by at, so daylight saving transitions neither skip nor repeat them
+func (c *cronSchedule) next(after time.Time) time.Time {
+	t := wallClock(after.In(c.location)).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		if activation := c.at(t); activation.After(after) {
+			return activation
+		}
+		t = t.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+// at returns the time the wall clock of the schedule's time zone shows the wall clock time t, given in UTC
+// A time shown twice when the clocks go back is the first; a time skipped when they go forward is as late as
+// the clocks jumped, e.g. 02:30 is 03:30 when the clocks jump from 02:00 to 03:00
+func (c *cronSchedule) at(t time.Time) time.Time {
+	// The offsets a day before and after are those on either side of a transition near t
+	_, before := t.Add(-24 * time.Hour).In(c.location).Zone()
+	activation := t.Add(-time.Duration(before) * time.Second).In(c.location)
+	if !wallClock(activation).Equal(t) {
+		_, after := t.Add(24 * time.Hour).In(c.location).Zone()
+		if later := t.Add(-time.Duration(after) * time.Second).In(c.location); wallClock(later).Equal(t) {
+			return later
+		}
+	}
+	return activation
+}
+
+// wallClock returns the date and time t shows in its location as the same date and time in UTC
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// upcoming returns up to n activations after the given time
+func (c *cronSchedule) upcoming(after time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		after = c.next(after)
+		if after.IsZero() {
+			break
+		}
+		times = append(times, after)
+	}
+	return times
+}
+
+// dayMatches reports whether the day of t matches the day-of-month and day-of-week fields
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domRestricted && c.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseCronSchedule_Invalid tests that expressions with the wrong number of fields, values out of range,
+// malformed steps and ranges, unknown names and unknown time zones are rejected
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timezone string
+	}{
+		{name: "empty", expr: ""},
+		{name: "four fields", expr: "* * * *"},
+		{name: "six fields", expr: "0 * * * * *"},
+		{name: "unknown macro", expr: "@every 5m"},
+		{name: "minute out of range", expr: "60 * * * *"},
+		{name: "hour out of range", expr: "0 24 * * *"},
+		{name: "day of month zero", expr: "0 0 0 * *"},
+		{name: "day of month out of range", expr: "0 0 32 * *"},
+		{name: "month out of range", expr: "0 0 1 13 *"},
+		{name: "day of week out of range", expr: "0 0 * * 8"},
+		{name: "zero step", expr: "*/0 * * * *"},
+		{name: "non-numeric step", expr: "*/x * * * *"},
+		{name: "reversed range", expr: "0 17-9 * * *"},
+		{name: "range out of range", expr: "0 0 * * 5-9"},
+		{name: "non-numeric value", expr: "x * * * *"},
+		{name: "unknown month name", expr: "0 0 1 FOO *"},
+		{name: "month name in day of week", expr: "0 0 * * JAN"},
+		{name: "empty list item", expr: "0,,30 * * * *"},
+		{name: "unknown time zone", expr: "0 0 * * *", timezone: "Mars/Olympus_Mons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCronSchedule(tt.expr, tt.timezone)
+
+			assert.Error(t, err)
+			assert.Nil(t, schedule)
+		})
+	}
+}
+
+// TestCronSchedule_Upcoming tests the activations following a time for steps, ranges, lists, names and macros,
+// the day-of-month or day-of-week rule, month and year rollovers, time zones and daylight saving transitions
+func TestCronSchedule_Upcoming(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		expr     string
+		timezone string
+		after    time.Time
+		want     []time.Time
+	}{
+		{
+			name:  "minute step",
+			expr:  "*/15 * * * *",
+			after: utc(2026, 3, 2, 10, 7),
+			want:  []time.Time{utc(2026, 3, 2, 10, 15), utc(2026, 3, 2, 10, 30), utc(2026, 3, 2, 10, 45), utc(2026, 3, 2, 11, 0)},
+		},
+		{
+			name:  "step from a start value",
+			expr:  "5/20 * * * *",
+			after: utc(2026, 3, 2, 10, 0),
+			want:  []time.Time{utc(2026, 3, 2, 10, 5), utc(2026, 3, 2, 10, 25), utc(2026, 3, 2, 10, 45), utc(2026, 3, 2, 11, 5)},
+		},
+		{
+			name:  "activation strictly after",
+			expr:  "*/15 * * * *",
+			after: utc(2026, 3, 2, 10, 15),
+			want:  []time.Time{utc(2026, 3, 2, 10, 30)},
+		},
+		{
+			name:  "hour range",
+			expr:  "0 9-11 * * *",
+			after: utc(2026, 3, 2, 10, 30),
+			want:  []time.Time{utc(2026, 3, 2, 11, 0), utc(2026, 3, 3, 9, 0), utc(2026, 3, 3, 10, 0)},
+		},
+		{
+			name:  "range with step",
+			expr:  "0 8-18/4 * * *",
+			after: utc(2026, 3, 2, 0, 0),
+			want:  []time.Time{utc(2026, 3, 2, 8, 0), utc(2026, 3, 2, 12, 0), utc(2026, 3, 2, 16, 0), utc(2026, 3, 3, 8, 0)},
+		},
+		{
+			name:  "lists",
+			expr:  "0,30 6,18 * * *",
+			after: utc(2026, 3, 2, 6, 10),
+			want:  []time.Time{utc(2026, 3, 2, 6, 30), utc(2026, 3, 2, 18, 0), utc(2026, 3, 2, 18, 30), utc(2026, 3, 3, 6, 0)},
+		},
+		{
+			name:  "month and weekday names",
+			expr:  "0 12 * JAN,jul Mon-Fri",
+			after: utc(2026, 1, 30, 13, 0),
+			want:  []time.Time{utc(2026, 7, 1, 12, 0), utc(2026, 7, 2, 12, 0), utc(2026, 7, 3, 12, 0), utc(2026, 7, 6, 12, 0)},
+		},
+		{
+			name:  "sunday as 7",
+			expr:  "0 0 * * 7",
+			after: utc(2026, 3, 2, 0, 0),
+			want:  []time.Time{utc(2026, 3, 8, 0, 0), utc(2026, 3, 15, 0, 0)},
+		},
+		{
+			name:  "weekly macro",
+			expr:  "@weekly",
+			after: utc(2026, 3, 2, 0, 0),
+			want:  []time.Time{utc(2026, 3, 8, 0, 0), utc(2026, 3, 15, 0, 0)},
+		},
+		{
+			name:  "hourly macro in upper case",
+			expr:  "@HOURLY",
+			after: utc(2026, 3, 2, 23, 59),
+			want:  []time.Time{utc(2026, 3, 3, 0, 0), utc(2026, 3, 3, 1, 0)},
+		},
+		{
+			name:  "day of month or day of week",
+			expr:  "0 0 1 * MON",
+			after: utc(2026, 3, 2, 0, 0),
+			want: []time.Time{
+				utc(2026, 3, 9, 0, 0), utc(2026, 3, 16, 0, 0), utc(2026, 3, 23, 0, 0), utc(2026, 3, 30, 0, 0),
+				utc(2026, 4, 1, 0, 0), utc(2026, 4, 6, 0, 0),
+			},
+		},
+		{
+			name:  "day of week and every day of month",
+			expr:  "0 0 * * FRI",
+			after: utc(2026, 3, 2, 0, 0),
+			want:  []time.Time{utc(2026, 3, 6, 0, 0), utc(2026, 3, 13, 0, 0)},
+		},
+		{
+			name:  "day of month missing from short months",
+			expr:  "0 0 31 * *",
+			after: utc(2026, 1, 31, 0, 0),
+			want:  []time.Time{utc(2026, 3, 31, 0, 0), utc(2026, 5, 31, 0, 0), utc(2026, 7, 31, 0, 0)},
+		},
+		{
+			name:  "month rollover into the next year",
+			expr:  "0 0 1 * *",
+			after: utc(2026, 12, 15, 0, 0),
+			want:  []time.Time{utc(2027, 1, 1, 0, 0), utc(2027, 2, 1, 0, 0)},
+		},
+		{
+			name:  "last minute of the year",
+			expr:  "59 23 31 12 *",
+			after: utc(2026, 12, 31, 23, 59),
+			want:  []time.Time{utc(2027, 12, 31, 23, 59)},
+		},
+		{
+			name:  "leap day",
+			expr:  "0 0 29 2 *",
+			after: utc(2026, 3, 1, 0, 0),
+			want:  []time.Time{utc(2028, 2, 29, 0, 0), utc(2032, 2, 29, 0, 0)},
+		},
+		{
+			name:  "never",
+			expr:  "0 0 30 2 *",
+			after: utc(2026, 3, 1, 0, 0),
+		},
+		{
+			name:     "time zone",
+			expr:     "0 9 * * *",
+			timezone: "Asia/Kolkata",
+			after:    utc(2026, 3, 2, 0, 0),
+			want:     []time.Time{utc(2026, 3, 2, 3, 30), utc(2026, 3, 3, 3, 30)},
+		},
+		{
+			// New York clocks jump from 02:00 EST to 03:00 EDT on 8 March 2026
+			name:     "time skipped by the spring transition",
+			expr:     "30 2 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 3, 7, 3, 0, 0, 0, newYork),
+			want:     []time.Time{utc(2026, 3, 8, 7, 30), utc(2026, 3, 9, 6, 30)},
+		},
+		{
+			name:     "hours across the spring transition",
+			expr:     "0 * * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 3, 8, 0, 30, 0, 0, newYork),
+			want:     []time.Time{utc(2026, 3, 8, 6, 0), utc(2026, 3, 8, 7, 0), utc(2026, 3, 8, 8, 0)},
+		},
+		{
+			// New York clocks go back from 02:00 EDT to 01:00 EST on 1 November 2026
+			name:     "time repeated by the autumn transition",
+			expr:     "30 1 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 10, 31, 12, 0, 0, 0, newYork),
+			want:     []time.Time{utc(2026, 11, 1, 5, 30), utc(2026, 11, 2, 6, 30)},
+		},
+		{
+			name:     "hours across the autumn transition",
+			expr:     "0 * * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 11, 1, 0, 30, 0, 0, newYork),
+			want:     []time.Time{utc(2026, 11, 1, 5, 0), utc(2026, 11, 1, 7, 0), utc(2026, 11, 1, 8, 0)},
+		},
+		{
+			name:     "during the repeated hour",
+			expr:     "*/30 * * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 11, 1, 6, 10, 0, 0, time.UTC),
+			want:     []time.Time{utc(2026, 11, 1, 7, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			schedule, err := parseCronSchedule(tt.expr, tt.timezone)
+			require.NoError(t, err)
+
+			// Act
+			upcoming := schedule.upcoming(tt.after, len(tt.want)+1)
+
+			// Assert
+			var got []time.Time
+			for _, activation := range upcoming {
+				got = append(got, activation.UTC())
+			}
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			require.Greater(t, len(got), len(tt.want))
+			assert.Equal(t, tt.want, got[:len(tt.want)])
+		})
+	}
+}
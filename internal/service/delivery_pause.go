@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"go.uber.org/zap"
+)
+
+// deliveryPauses holds the stored delivery pauses by scope, paused or draining
+type deliveryPauses map[string]models.DeliveryPause
+
+// holds reports whether the deliveries of a tenant are held back, by a pause of the tenant or a global one
+// Pauses that were resumed no longer hold back deliveries; their held events are drained
+func (p deliveryPauses) holds(tenantID string) bool {
+	for _, scope := range []string{models.DeliveryPauseGlobal, tenantID} {
+		if pause, ok := p[scope]; ok && pause.Paused() {
+			return true
+		}
+	}
+	return false
+}
+
+// tenantScopes returns the tenants with a pause of their own, whose held events the global pause does not drain
+func (p deliveryPauses) tenantScopes() []string {
+	var tenants []string
+	for scope := range p {
+		if scope != models.DeliveryPauseGlobal {
+			tenants = append(tenants, scope)
+		}
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// loadDeliveryPauses returns the stored delivery pauses
+func (s *webhookService) loadDeliveryPauses(ctx context.Context) (deliveryPauses, error) {
+	stored, err := s.repo.GetDeliveryPauses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load delivery pauses: %w", err)
+	}
+	pauses := make(deliveryPauses, len(stored))
+	for _, pause := range stored {
+		pauses[pause.Scope] = pause
+	}
+	return pauses, nil
+}
+
+// deliveryPauseScope returns the scope of the delivery pause of a tenant, or of the global pause for an empty
+// tenantID, failing for tenants that do not exist
+func (s *webhookService) deliveryPauseScope(ctx context.Context, tenantID string) (string, error) {
+	if tenantID == "" {
+		return models.DeliveryPauseGlobal, nil
+	}
+	tenant, err := s.tenantRepo.GetTenant(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load tenant: %w", err)
+	}
+	if tenant == nil {
+		return "", ErrTenantNotFound.Withf("tenant %s not found", tenantID)
+	}
+	return tenantID, nil
+}
+
+// PauseDeliveries holds back the outbound deliveries of a tenant, or of every tenant for an empty tenantID
+// Events sent from now on are stored as held, pending events and scheduled events becoming due are held too;
+// deliveries already in flight complete. Pausing a paused scope again only updates the reason, and pausing a
+// draining one stops the drain
+func (s *webhookService) PauseDeliveries(ctx context.Context, tenantID string, req *models.PauseDeliveriesRequest) (*models.DeliveryPauseResponse, error) {
+	scope, err := s.deliveryPauseScope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pause, ok := pauses[scope]
+	if !ok || !pause.Paused() {
+		pause = models.DeliveryPause{Scope: scope, PausedAt: s.clock.Now()}
+	}
+	pause.Reason = req.Reason
+	if err := s.repo.SaveDeliveryPause(ctx, &pause); err != nil {
+		return nil, fmt.Errorf("failed to pause deliveries: %w", err)
+	}
+
+	s.logger.Info(ctx, "Outbound deliveries paused",
+		zap.String("scope", scope),
+		zap.String("reason", pause.Reason))
+
+	return s.deliveryPauseResponse(ctx, tenantID, &pause)
+}
+
+// ResumeDeliveries lifts the delivery pause of a tenant, or the global one for an empty tenantID
+// Events sent from now on are delivered right away, while the events held during the pause are drained at the
+// requested rate by RunDeliveryDrain, highest priority first; the held events of tenants still paused on their
+// own wait for their own resume
+func (s *webhookService) ResumeDeliveries(ctx context.Context, tenantID string, req *models.ResumeDeliveriesRequest) (*models.DeliveryPauseResponse, error) {
+	scope, err := s.deliveryPauseScope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	rate := req.DrainRate
+	if rate == 0 {
+		rate = models.DefaultDrainRate
+	}
+	if rate < 0 || rate > models.MaxDrainRate {
+		return nil, ErrInvalidDeliveryPause.Withf("drain_rate must be between 1 and %d events per second", models.MaxDrainRate)
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resuming deliveries that are not paused changes nothing, and resuming a draining pause changes its rate
+	pause, ok := pauses[scope]
+	if !ok {
+		return s.deliveryPauseResponse(ctx, tenantID, nil)
+	}
+	if pause.Paused() {
+		now := s.clock.Now()
+		pause.ResumedAt = &now
+	}
+	pause.DrainRate = rate
+	if err := s.repo.SaveDeliveryPause(ctx, &pause); err != nil {
+		return nil, fmt.Errorf("failed to resume deliveries: %w", err)
+	}
+
+	s.logger.Info(ctx, "Outbound deliveries resumed",
+		zap.String("scope", scope),
+		zap.Int("drain_rate", rate))
+
+	return s.deliveryPauseResponse(ctx, tenantID, &pause)
+}
+
+// GetDeliveryPause reports whether the deliveries of a tenant, or of every tenant for an empty tenantID, are
+// paused, with the number of events held
+func (s *webhookService) GetDeliveryPause(ctx context.Context, tenantID string) (*models.DeliveryPauseResponse, error) {
+	scope, err := s.deliveryPauseScope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pause, ok := pauses[scope]
+	if !ok {
+		return s.deliveryPauseResponse(ctx, tenantID, nil)
+	}
+	return s.deliveryPauseResponse(ctx, tenantID, &pause)
+}
+
+// deliveryPauseResponse reports a delivery pause of a tenant, or the global one, nil when there is none
+func (s *webhookService) deliveryPauseResponse(ctx context.Context, tenantID string, pause *models.DeliveryPause) (*models.DeliveryPauseResponse, error) {
+	held, err := s.repo.CountHeldEvents(ctx, tenantID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count held events: %w", err)
+	}
+
+	response := &models.DeliveryPauseResponse{TenantID: tenantID, HeldEvents: held}
+	if pause != nil {
+		response.Paused = pause.Paused()
+		response.Draining = !pause.Paused()
+		response.Reason = pause.Reason
+		response.PausedAt = &pause.PausedAt
+		response.ResumedAt = pause.ResumedAt
+		response.DrainRate = pause.DrainRate
+	}
+	return response, nil
+}
+
+// holdEvent stores an event sent while its tenant's deliveries are paused, to be delivered once they are resumed
+// The event is pending with its hold time set; subscriptions are matched and chains triggered when it is drained
+func (s *webhookService) holdEvent(ctx context.Context, event *models.WebhookEvent) (*models.EventProcessingResult, error) {
+	now := s.clock.Now()
+	event.HeldAt = &now
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to hold webhook event",
+			zap.Error(err),
+			zap.String("event_id", event.ID.String()))
+		return nil, fmt.Errorf("failed to hold event: %w", err)
+	}
+
+	s.logger.Info(ctx, "Webhook event held while deliveries are paused",
+		zap.String("event_id", event.ID.String()),
+		zap.String("tenant_id", event.TenantID),
+		zap.String("event", event.EventName))
+
+	return &models.EventProcessingResult{
+		EventID:  event.ID,
+		Webhooks: []models.WebhookDeliveryResult{},
+		Held:     true,
+	}, nil
+}
+
+// DrainHeldEvents delivers the events held by resumed delivery pauses, each pause's drain rate times elapsed
+// of them at most, and removes the pauses left without held events
+// Nothing is drained while the global pause lasts; the global pause does not drain the events of tenants with a
+// pause of their own, which drain at their own rate
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
+//   - elapsed: Time since the previous pass, typically the drain interval
+//
+// Returns:
+//   - int: Number of held events delivered
+//   - error: If the delivery pauses cannot be loaded
+func (s *webhookService) DrainHeldEvents(ctx context.Context, elapsed time.Duration) (int, error) {
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return 0, err
+	}
+	global, hasGlobal := pauses[models.DeliveryPauseGlobal]
+	if hasGlobal && global.Paused() {
+		return 0, nil
+	}
+
+	drained := 0
+	tenants := pauses.tenantScopes()
+	for _, tenantID := range tenants {
+		if pause := pauses[tenantID]; !pause.Paused() {
+			drained += s.drainDeliveryPause(ctx, pause, tenantID, nil, elapsed)
+		}
+	}
+	if hasGlobal {
+		drained += s.drainDeliveryPause(ctx, global, "", tenants, elapsed)
+	}
+	return drained, nil
+}
+
+// drainDeliveryPause delivers the next held events of a resumed pause under the pause's drain lock, so one
+// instance drains each pause at its rate, and removes the pause once no events are held
+// tenantID selects the held events of a tenant's pause, empty for the global pause, which skips excludeTenants
+// Returns the number of held events delivered
+func (s *webhookService) drainDeliveryPause(ctx context.Context, pause models.DeliveryPause, tenantID string, excludeTenants []string, elapsed time.Duration) int {
+	budget := max(int(float64(pause.DrainRate)*elapsed.Seconds()), 1)
+
+	drained := 0
+	_, err := tryInstanceLock(ctx, s.locks, deliveryDrainLockPrefix+pause.Scope, func() error {
+		events, err := s.repo.GetHeldEvents(ctx, tenantID, excludeTenants, budget)
+		if err != nil {
+			return fmt.Errorf("failed to load held events: %w", err)
+		}
+		for i := range events {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if s.drainHeldEvent(ctx, &events[i]) {
+				drained++
+			}
+		}
+		if len(events) == budget {
+			return nil
+		}
+
+		held, err := s.repo.CountHeldEvents(ctx, tenantID, excludeTenants)
+		if err != nil || held > 0 {
+			return err
+		}
+		if err := s.repo.DeleteResumedDeliveryPause(ctx, pause.Scope); err != nil {
+			return fmt.Errorf("failed to remove drained delivery pause: %w", err)
+		}
+		s.logger.Info(ctx, "Held webhook events drained", zap.String("scope", pause.Scope))
+		return nil
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to drain held webhook events",
+			zap.String("scope", pause.Scope),
+			zap.Error(err))
+	}
+	return drained
+}
+
+// drainHeldEvent releases a held event and delivers it to the currently matching subscriptions
+// Returns whether the event was delivered by this call
+func (s *webhookService) drainHeldEvent(ctx context.Context, event *models.WebhookEvent) bool {
+	release, err := s.acquireDeliverySlot(ctx, event.Priority)
+	if err != nil {
+		return false
+	}
+	defer release()
+
+	released, err := s.repo.ReleaseHeldEvent(ctx, event.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to release held webhook event",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+		return false
+	}
+	if !released {
+		return false
+	}
+	heldAt := event.HeldAt
+	event.HeldAt = nil
+	if eventExpired(event.ExpiresAt, s.clock.Now()) {
+		s.expireStoredEvent(ctx, event)
+		return false
+	}
+
+	result, ok := s.deliverStoredEvent(ctx, event)
+	if !ok {
+		return false
+	}
+
+	s.logger.Info(ctx, "Held webhook event delivered",
+		zap.String("event_id", event.ID.String()),
+		zap.Timep("held_at", heldAt),
+		zap.Int("total_sent", result.TotalSent),
+		zap.Int("total_failed", result.TotalFailed))
+	return true
+}
+
+// RunDeliveryDrain drains the events held by resumed delivery pauses every interval until ctx is cancelled
+// Parameters:
+//   - ctx: Context whose cancellation stops the drain
+//   - interval: Time between drain passes; each pass delivers a pause's drain rate times interval events
+func (s *webhookService) RunDeliveryDrain(ctx context.Context, interval time.Duration) {
+	for {
+		if drained, err := s.DrainHeldEvents(ctx, interval); err != nil {
+			s.logger.Error(ctx, "Failed to drain held webhook events", zap.Error(err))
+		} else if drained > 0 {
+			s.logger.Info(ctx, "Drained held webhook events", zap.Int("drained", drained))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
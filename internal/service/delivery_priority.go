@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// slotOrder lists the priorities in the order free delivery slots are handed out
+var slotOrder = []models.Priority{models.PriorityHigh, models.PriorityNormal, models.PriorityLow}
+
+// deliverySlots bounds how many events are delivered at once, handing freed slots to the waiting events highest
+// priority first, in arrival order within a priority
+// Slots reserved for high priority events are never taken by others, so critical alerts are not stuck behind
+// bulk traffic filling every slot
+type deliverySlots struct {
+	mu       sync.Mutex
+	size     int
+	reserved int
+	used     int
+
+	// waiting holds the channels of the waiting events by priority, closed once the event was given a slot
+	waiting map[models.Priority][]chan struct{}
+}
+
+// newDeliverySlots creates delivery slots of which reserved are kept for high priority events
+func newDeliverySlots(size, reserved int) *deliverySlots {
+	return &deliverySlots{
+		size:     size,
+		reserved: reserved,
+		waiting:  make(map[models.Priority][]chan struct{}),
+	}
+}
+
+// acquire waits for a slot for an event of the priority; a nil deliverySlots never waits
+// Returns the function releasing the slot, or ctx's error when ctx ends first
+func (d *deliverySlots) acquire(ctx context.Context, priority models.Priority) (func(), error) {
+	if d == nil {
+		return func() {}, nil
+	}
+
+	d.mu.Lock()
+	if d.fits(priority) && !d.queuedAhead(priority) {
+		d.used++
+		d.mu.Unlock()
+		return d.release, nil
+	}
+	ready := make(chan struct{})
+	d.waiting[priority] = append(d.waiting[priority], ready)
+	d.mu.Unlock()
+
+	select {
+	case <-ready:
+		return d.release, nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		select {
+		case <-ready:
+			// The slot was handed over as ctx ended, so it goes to the next event
+			d.used--
+			d.handOver()
+		default:
+			d.remove(priority, ready)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release frees a slot and hands it to the next waiting event
+func (d *deliverySlots) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.used--
+	d.handOver()
+}
+
+// fits reports whether an event of the priority may take a free slot; callers hold mu
+func (d *deliverySlots) fits(priority models.Priority) bool {
+	if priority == models.PriorityHigh {
+		return d.used < d.size
+	}
+	return d.used < d.size-d.reserved
+}
+
+// queuedAhead reports whether events of the priority or a higher one are waiting; callers hold mu
+func (d *deliverySlots) queuedAhead(priority models.Priority) bool {
+	for _, p := range slotOrder {
+		if len(d.waiting[p]) > 0 {
+			return true
+		}
+		if p == priority {
+			break
+		}
+	}
+	return false
+}
+
+// handOver gives free slots to the waiting events highest priority first; callers hold mu
+// An event that does not fit leaves every lower priority waiting too, since those fit in fewer slots
+func (d *deliverySlots) handOver() {
+	for _, p := range slotOrder {
+		for len(d.waiting[p]) > 0 {
+			if !d.fits(p) {
+				return
+			}
+			close(d.waiting[p][0])
+			d.waiting[p] = d.waiting[p][1:]
+			d.used++
+		}
+	}
+}
+
+// remove drops a waiting event that gave up; callers hold mu
+func (d *deliverySlots) remove(priority models.Priority, ready chan struct{}) {
+	queue := d.waiting[priority]
+	for i, waiting := range queue {
+		if waiting == ready {
+			d.waiting[priority] = append(queue[:i:i], queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// ConfigureDeliveryConcurrency bounds how many events are delivered at once, 0 for no bound
+// Freed slots go to waiting events highest priority first, and reservedHigh of the slots are only used by high
+// priority events; it is capped to leave normal and low priority events one slot
+// Must be called before any event is sent
+func (s *webhookService) ConfigureDeliveryConcurrency(maxEvents, reservedHigh int) {
+	if maxEvents <= 0 {
+		s.deliverySlots = nil
+		return
+	}
+	s.deliverySlots = newDeliverySlots(maxEvents, min(max(reservedHigh, 0), maxEvents-1))
+}
+
+// SetPriorityMetrics exports how events of each priority are served as Prometheus metrics
+// Parameters:
+//   - metrics: The metrics, nil to stop exporting
+//
+// Purpose: Shows whether high priority events wait for delivery slots behind bulk traffic
+func (s *webhookService) SetPriorityMetrics(metrics *PriorityMetrics) {
+	s.priorityMetrics = metrics
+}
+
+// acquireDeliverySlot waits for a slot to deliver an event of the priority level in, recording the wait
+// Returns the function releasing the slot, or ctx's error when ctx ends first
+func (s *webhookService) acquireDeliverySlot(ctx context.Context, level int) (func(), error) {
+	if s.deliverySlots == nil {
+		return func() {}, nil
+	}
+	priority := models.PriorityOf(level)
+	start := s.clock.Now()
+	s.priorityMetrics.addWaiting(priority, 1)
+	release, err := s.deliverySlots.acquire(ctx, priority)
+	s.priorityMetrics.addWaiting(priority, -1)
+	s.priorityMetrics.observeSlotWait(priority, s.clock.Now().Sub(start))
+	return release, err
+}
+
+// eventPriorityKey is the context key of the priority of the event whose chains are triggered
+type eventPriorityKey struct{}
+
+// withEventPriority returns a context passing the priority of an event to the chain runs it triggers
+func withEventPriority(ctx context.Context, priority models.Priority) context.Context {
+	return context.WithValue(ctx, eventPriorityKey{}, priority)
+}
+
+// eventPriority returns the priority of the event triggering chains with ctx, empty when there is none
+func eventPriority(ctx context.Context) models.Priority {
+	priority, _ := ctx.Value(eventPriorityKey{}).(models.Priority)
+	return priority
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// deliveryAttempts collects the HTTP attempts of one delivery so they are recorded together once it ends
+type deliveryAttempts struct {
+	subscription models.WebhookSubscription
+	eventID      uuid.UUID
+	acceptedAt   time.Time
+	attempts     []*models.WebhookDeliveryAttempt
+}
+
+// add records the outcome of an attempt that started at the given time
+func (d *deliveryAttempts) add(attempt int, started, now time.Time, responseCode *int, class models.DeliveryErrorClass, err error) {
+	record := &models.WebhookDeliveryAttempt{
+		ID:           uuid.New(),
+		TenantID:     d.subscription.TenantID,
+		WebhookID:    d.subscription.ID,
+		EventID:      d.eventID,
+		Attempt:      attempt,
+		Success:      class == "",
+		ResponseCode: responseCode,
+		ErrorClass:   class,
+		LatencyMs:    now.Sub(started).Milliseconds(),
+		CreatedAt:    started,
+	}
+	if err != nil {
+		errMsg := err.Error()
+		record.Error = &errMsg
+	}
+	if record.Success && !d.acceptedAt.IsZero() {
+		endToEnd := now.Sub(d.acceptedAt).Milliseconds()
+		record.EndToEndMs = &endToEnd
+	}
+	d.attempts = append(d.attempts, record)
+}
+
+// saveDeliveryAttempts records the attempts of a finished delivery, marking the last one final
+// Failures are logged only; statistics must never hold up or fail a delivery
+func (s *webhookService) saveDeliveryAttempts(ctx context.Context, d *deliveryAttempts) {
+	if len(d.attempts) == 0 {
+		return
+	}
+	final := d.attempts[len(d.attempts)-1]
+	final.Final = true
+	if final.EndToEndMs != nil {
+		s.latencyMetrics.observe(d.subscription.TenantID, *final.EndToEndMs)
+	}
+
+	if err := s.repo.CreateDeliveryAttempts(context.WithoutCancel(ctx), d.attempts); err != nil {
+		s.logger.Error(ctx, "Failed to record delivery attempts",
+			zap.String("webhook_id", d.subscription.ID.String()),
+			zap.String("event_id", d.eventID.String()),
+			zap.Error(err))
+	}
+}
+
+// classifyTransportError classifies an attempt that got no response
+func classifyTransportError(err error) models.DeliveryErrorClass {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return models.DeliveryErrorTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return models.DeliveryErrorRequest
+	}
+	return models.DeliveryErrorConnection
+}
+
+// classifyStatusCode classifies an attempt the receiver answered with a non-2xx status
+func classifyStatusCode(statusCode int) models.DeliveryErrorClass {
+	switch {
+	case statusCode >= 400 && statusCode < 500:
+		return models.DeliveryErrorClient
+	case statusCode >= 500 && statusCode < 600:
+		return models.DeliveryErrorServer
+	}
+	return models.DeliveryErrorStatus
+}
+
+// GetWebhookDeliveryStats aggregates the delivery attempts made to a webhook within a window
+// With a tenant given, subscriptions of other tenants are not found
+func (s *webhookService) GetWebhookDeliveryStats(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow) (*models.DeliveryStatsResponse, error) {
+	subscription, err := s.repo.GetSubscriptionByID(repository.WithTenantScope(ctx, tenantID), webhookID)
+	if err != nil {
+		return nil, notFound(ErrWebhookNotFound, err)
+	}
+	return s.deliveryStats(ctx, subscription.TenantID, &webhookID, window)
+}
+
+// GetTenantDeliveryStats aggregates the delivery attempts made to all webhooks of a tenant within a window
+func (s *webhookService) GetTenantDeliveryStats(ctx context.Context, tenantID string, window models.StatsWindow) (*models.DeliveryStatsResponse, error) {
+	return s.deliveryStats(ctx, tenantID, nil, window)
+}
+
+// deliveryStats loads the aggregated attempts of a window and derives the success rates
+func (s *webhookService) deliveryStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, window models.StatsWindow) (*models.DeliveryStatsResponse, error) {
+	duration, ok := window.Duration()
+	if !ok {
+		return nil, ErrInvalidStatsWindow.Withf("unknown stats window %q", window)
+	}
+	var since *time.Time
+	if duration > 0 {
+		start := s.clock.Now().Add(-duration)
+		since = &start
+	}
+	bucket := window.BucketSize()
+
+	stats, err := s.repo.GetDeliveryStats(ctx, tenantID, webhookID, since, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate delivery attempts: %w", err)
+	}
+
+	stats.TenantID = tenantID
+	stats.WebhookID = webhookID
+	stats.Window = window
+	stats.Since = since
+	stats.BucketSeconds = int64(bucket / time.Second)
+	stats.SuccessRate = deliverySuccessRate(stats.DeliveryStats)
+	for i := range stats.Buckets {
+		stats.Buckets[i].SuccessRate = deliverySuccessRate(stats.Buckets[i].DeliveryStats)
+	}
+	return stats, nil
+}
+
+// deliverySuccessRate returns the share of deliveries that succeeded, 0 when there are none
+func deliverySuccessRate(stats models.DeliveryStats) float64 {
+	if stats.Deliveries == 0 {
+		return 0
+	}
+	return float64(stats.Delivered) / float64(stats.Deliveries)
+}
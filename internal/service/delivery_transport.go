@@ -0,0 +1,299 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/zcornor/pkg/security"
+)
+
+// errEventExpired is the error of deliveries dropped because their event expired before they were made
+var errEventExpired = errors.New("event expired before it was delivered")
+
+// maxResponseBody caps how much of a receiver's answer is read, to record it and check its response schema
+const maxResponseBody = 1 << 20
+
+// DeliveryTransport delivers bodies to one kind of target, selected by the target type of a subscription
+// Transports make one attempt per call; the services retry, record attempts and honour receiver pauses
+type DeliveryTransport interface {
+	// Validate checks the target settings of a subscription request and stores them on the subscription,
+	// along with the subscription's TargetURL when the request has none
+	Validate(req *models.SubscribeWebhookRequest, subscription *models.WebhookSubscription) error
+
+	// Send makes one attempt at delivering a body
+	Send(ctx context.Context, delivery *Delivery) DeliveryOutcome
+}
+
+// Delivery is a body to deliver to a subscription
+type Delivery struct {
+	// Subscription is the subscription delivered to
+	Subscription models.WebhookSubscription
+
+	// Headers are the names of the signature, timestamp and attempt headers
+	Headers models.SigningHeaders
+
+	// MessageID identifies the delivery and is the same for every attempt
+	MessageID string
+
+	// Body is the JSON body: an event envelope, or a chain payload when Raw is set
+	Body []byte
+
+	// Raw marks bodies that are not event envelopes, which are sent without rendering them per the
+	// subscription's notification template
+	Raw bool
+
+	// Attempt is the number of the attempt, from 1
+	Attempt int
+
+	// Test marks test deliveries, so receivers can skip processing them
+	Test bool
+
+	// AcceptedAt is when the delivered event was accepted, from which its end-to-end latency is measured;
+	// zero for deliveries that are not measured, such as batches and chain steps
+	AcceptedAt time.Time
+
+	// ExpiresAt is when the delivered event expires, zero for events that do not; deliveries are not attempted
+	// from then on, nor retried when the retry would start after it
+	ExpiresAt time.Time
+
+	// State is kept between the attempts of a delivery, for transports that must not resend what an
+	// earlier attempt already delivered
+	State interface{}
+}
+
+// DeliveryOutcome is the result of one delivery attempt
+type DeliveryOutcome struct {
+	// Err is why the attempt failed, nil when the target accepted the delivery
+	Err error
+
+	// ErrorClass classifies Err for delivery statistics
+	ErrorClass models.DeliveryErrorClass
+
+	// Permanent marks failures retrying cannot fix, such as 4xx responses
+	Permanent bool
+
+	// TargetURL is where the attempt was sent, when it differs from the subscription's TargetURL
+	TargetURL string
+
+	// ResponseCode, ResponseBody and ResponseContentType are the receiver's answer, for transports that get one
+	ResponseCode        *int
+	ResponseBody        []byte
+	ResponseContentType string
+
+	// Pause is how long the receiver asked for deliveries to be held, 0 if it did not
+	Pause time.Duration
+
+	// SentBody and SentHeaders are what the attempt sent, as reported by test deliveries; SentHeaders is
+	// nil for transports without headers
+	SentBody    []byte
+	SentHeaders http.Header
+}
+
+// responseStatusError is the error of an attempt the receiver answered with a status other than 2xx
+func responseStatusError(statusCode int, body string) error {
+	return fmt.Errorf("webhook returned status %d: %s", statusCode, body)
+}
+
+// failedDelivery returns the outcome of an attempt that failed before reaching the target
+func failedDelivery(class models.DeliveryErrorClass, err error, permanent bool) DeliveryOutcome {
+	return DeliveryOutcome{Err: err, ErrorClass: class, Permanent: permanent}
+}
+
+// TransportRegistry holds the delivery transports by the target type they deliver to
+type TransportRegistry struct {
+	mu         sync.RWMutex
+	transports map[models.TargetType]DeliveryTransport
+}
+
+// NewTransportRegistry creates a registry without transports
+func NewTransportRegistry() *TransportRegistry {
+	return &TransportRegistry{transports: make(map[models.TargetType]DeliveryTransport)}
+}
+
+// Register makes subscriptions with a target type deliverable through a transport, replacing the
+// transport registered for it before
+func (r *TransportRegistry) Register(targetType models.TargetType, transport DeliveryTransport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transports[targetType] = transport
+}
+
+// Lookup returns the transport of a target type; empty is models.TargetTypeHTTP
+func (r *TransportRegistry) Lookup(targetType models.TargetType) (DeliveryTransport, bool) {
+	if targetType == "" {
+		targetType = models.TargetTypeHTTP
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	transport, ok := r.transports[targetType]
+	return transport, ok
+}
+
+// TargetTypes returns the registered target types in alphabetical order
+func (r *TransportRegistry) TargetTypes() []models.TargetType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]models.TargetType, 0, len(r.transports))
+	for targetType := range r.transports {
+		types = append(types, targetType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// transportEnv is what the built-in transports share with the service that registered them; the
+// service's setters update it
+type transportEnv struct {
+	httpClient *http.Client
+	security   *security.SecurityService
+	tenantRepo repository.TenantRepository
+	clock      Clock
+	amqp       AMQPPublisher
+	email      EmailSender
+	sms        SMSSender
+	offload    *payloadOffload
+}
+
+// newTransportRegistry creates a registry with the built-in transports
+func newTransportRegistry(env *transportEnv) *TransportRegistry {
+	registry := NewTransportRegistry()
+	httpTransport := &httpTransport{env: env}
+	registry.Register(models.TargetTypeHTTP, httpTransport)
+	registry.Register(models.TargetTypeAMQP, &amqpTransport{env: env})
+	for _, targetType := range []models.TargetType{models.TargetTypeSlack, models.TargetTypeTeams, models.TargetTypePagerDuty} {
+		registry.Register(targetType, &notificationTransport{http: httpTransport, targetType: targetType})
+	}
+	for _, targetType := range []models.TargetType{models.TargetTypeEmail, models.TargetTypeSMS} {
+		registry.Register(targetType, &messageTransport{env: env, targetType: targetType})
+	}
+	return registry
+}
+
+// newDeliveryRequest builds the signed HTTP request of one delivery attempt
+func (e *transportEnv) newDeliveryRequest(ctx context.Context, d *Delivery, targetURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(d.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set standard headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "github.com/sakibcoolz/loki-suite/2.0")
+
+	// Add custom headers from subscription
+	for key, value := range d.Subscription.Headers {
+		req.Header.Set(key, value)
+	}
+
+	// Sign per the subscription's signature scheme, with the tenant's Ed25519 key when it has one
+	key, err := tenantSigningKey(ctx, e.tenantRepo, d.Subscription.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := signRequest(req, e.security, d.Subscription, d.Headers, key, d.MessageID, d.Body, e.clock.Now()); err != nil {
+		return nil, err
+	}
+	req.Header.Set(d.Headers.Attempt, fmt.Sprintf("%d", d.Attempt))
+	setCorrelationHeaders(ctx, req, d.MessageID)
+	if d.Test {
+		req.Header.Set(testDeliveryHeader, "true")
+	}
+
+	// Add JWT token for private webhooks
+	if d.Subscription.Type == models.WebhookTypePrivate && d.Subscription.JWTToken != nil {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", *d.Subscription.JWTToken))
+	}
+	return req, nil
+}
+
+// httpTransport posts deliveries to the subscription's target URL with its query parameters
+type httpTransport struct {
+	env *transportEnv
+}
+
+// Validate requires the target URL to be an absolute HTTP or HTTPS URL
+func (t *httpTransport) Validate(req *models.SubscribeWebhookRequest, subscription *models.WebhookSubscription) error {
+	return validateHTTPTarget(subscription.TargetURL)
+}
+
+// validateHTTPTarget checks that a target URL is an absolute HTTP or HTTPS URL
+func validateHTTPTarget(targetURL string) error {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid target_url: must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// Send posts the body and reads the receiver's answer
+// Non-2xx responses fail the attempt, 4xx ones for good; a pause the receiver requests is reported
+// with any response. Bodies of HTTP targets above the offload threshold are sent as a reference, and
+// compressed per the subscription's content encoding unless the receiver answers 415 to that
+func (t *httpTransport) Send(ctx context.Context, d *Delivery) DeliveryOutcome {
+	targetURL, err := deliveryURL(d.Subscription)
+	if err != nil {
+		return failedDelivery(models.DeliveryErrorRequest, err, true)
+	}
+
+	sent := *d
+	if d.Subscription.TargetType == "" || d.Subscription.TargetType == models.TargetTypeHTTP {
+		if sent.Body, err = t.env.deliveryBody(ctx, d); err != nil {
+			return failedDelivery(models.DeliveryErrorRequest, err, false)
+		}
+	}
+
+	outcome := DeliveryOutcome{TargetURL: targetURL, SentBody: sent.Body}
+	req, err := t.env.newDeliveryRequest(ctx, &sent, targetURL)
+	if err == nil && d.Subscription.ContentEncoding == models.ContentEncodingGzip {
+		err = gzipRequest(req, sent.Body)
+	}
+	if err != nil {
+		outcome.Err = err
+		outcome.ErrorClass = models.DeliveryErrorRequest
+		return outcome
+	}
+	outcome.SentHeaders = req.Header
+
+	resp, err := t.env.httpClient.Do(req)
+	if err == nil && resp.StatusCode == http.StatusUnsupportedMediaType && req.Header.Get("Content-Encoding") != "" {
+		// The receiver cannot decompress, so the attempt is sent again uncompressed
+		resp.Body.Close()
+		if req, err = t.env.newDeliveryRequest(ctx, &sent, targetURL); err != nil {
+			outcome.Err = err
+			outcome.ErrorClass = models.DeliveryErrorRequest
+			return outcome
+		}
+		outcome.SentHeaders = req.Header
+		resp, err = t.env.httpClient.Do(req)
+	}
+	if err != nil {
+		outcome.Err = fmt.Errorf("failed to send request: %w", err)
+		outcome.ErrorClass = classifyTransportError(err)
+		return outcome
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	outcome.ResponseCode = &resp.StatusCode
+	outcome.ResponseBody = body
+	outcome.ResponseContentType = resp.Header.Get("Content-Type")
+	outcome.Pause = receiverPause(resp.Header)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return outcome
+	}
+
+	outcome.Err = responseStatusError(resp.StatusCode, string(body))
+	outcome.ErrorClass = classifyStatusCode(resp.StatusCode)
+	outcome.Permanent = resp.StatusCode >= 400 && resp.StatusCode < 500
+	return outcome
+}
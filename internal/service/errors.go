@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+)
+
+// Errors of resources looked up by ID that do not exist, or belong to another tenant than the caller's
+var (
+	ErrChainNotFound      = apperr.NotFound("chain_not_found", "execution chain not found")
+	ErrRunNotFound        = apperr.NotFound("run_not_found", "chain run not found")
+	ErrWebhookNotFound    = apperr.NotFound("webhook_not_found", "webhook subscription not found")
+	ErrEventTypeNotFound  = apperr.NotFound("event_type_not_found", "event type not found")
+	ErrCredentialNotFound = apperr.NotFound("credential_not_found", "credential not found")
+
+	ErrChainVersionNotFound = apperr.NotFound("chain_version_not_found", "chain version not found")
+)
+
+// ErrEventTypeExists is returned for event types registered under a name the tenant already uses
+var ErrEventTypeExists = apperr.Conflict("event_type_exists", "event type is already registered")
+
+// Errors of requests breaking a rule of the resource they create or change, reworded with Withf, or invalid, to
+// tell which
+var (
+	// ErrInvalidChain is returned for chains whose steps, schedule or completion webhook break a rule
+	ErrInvalidChain = apperr.Validation("invalid_chain", "invalid execution chain")
+
+	// ErrInvalidRun is returned for runs requested with invalid trigger data, variables or options
+	ErrInvalidRun = apperr.Validation("invalid_run", "invalid chain run request")
+
+	// ErrInvalidWebhook is returned for webhook subscriptions, imports and manifests breaking a rule
+	ErrInvalidWebhook = apperr.Validation("invalid_webhook", "invalid webhook subscription")
+
+	// ErrInvalidEvent is returned for events sent with an invalid delivery time
+	ErrInvalidEvent = apperr.Validation("invalid_event", "invalid event")
+
+	// ErrInvalidEventType is returned for event types with an invalid name or schema
+	ErrInvalidEventType = apperr.Validation("invalid_event_type", "invalid event type")
+
+	// ErrInvalidStatsWindow is returned for statistics requested over a window models.StatsWindow does not know
+	ErrInvalidStatsWindow = apperr.Validation("invalid_stats_window", "unknown stats window")
+
+	// ErrInvalidTenant is returned for tenants and tenant settings breaking a rule
+	ErrInvalidTenant = apperr.Validation("invalid_tenant", "invalid tenant settings")
+
+	// ErrInvalidDeliveryPause is returned for delivery pauses resumed with a drain rate out of range
+	ErrInvalidDeliveryPause = apperr.Validation("invalid_delivery_pause", "invalid delivery pause")
+)
+
+// Errors of credentials
+var (
+	// ErrUnauthenticated is returned for callers whose API key or token is missing, invalid, expired or of a
+	// revoked credential, reworded with Withf to tell which
+	ErrUnauthenticated = apperr.Unauthorized("unauthorized", "missing or invalid credentials")
+
+	// ErrCredentialRevoked is returned for tokens requested for a revoked credential
+	ErrCredentialRevoked = apperr.Conflict("credential_revoked", "credential is revoked")
+)
+
+// Errors of changes the current state of a chain or run does not allow, reworded with Withf to describe the state
+var (
+	// ErrInvalidChainState is returned for changes of a chain its activation or pause does not allow
+	ErrInvalidChainState = apperr.Conflict("invalid_chain_state", "the chain's state does not allow the change")
+
+	// ErrInvalidRunState is returned for changes of a run its status does not allow, such as cancelling a
+	// completed run
+	ErrInvalidRunState = apperr.Conflict("invalid_run_state", "the run's status does not allow the change")
+)
+
+// invalid returns the error of a request a validator rejected with err: rule, reworded with err's text, which
+// describes the request; typed errors are returned as they are
+// Only errors of validators checking requests on their own are passed, never ones of storage or delivery
+func invalid(rule *apperr.Error, err error) error {
+	if err == nil || apperr.As(err) != nil {
+		return err
+	}
+	return rule.Withf("%s", err.Error())
+}
+
+// notFound returns the error of a lookup that failed with err: missing, wrapping err, when the resource does not
+// exist, and an untyped error for other failures, such as an unavailable database, so they are not reported as
+// missing resources
+func notFound(missing *apperr.Error, err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return missing.Wrap(err)
+	}
+	return fmt.Errorf("%s: %w", missing.Message, err)
+}
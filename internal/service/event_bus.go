@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultEventBusQueue is the queue group instances ingest events in, so each message is processed once
+	DefaultEventBusQueue = "loki-suite"
+
+	// DefaultEventBusWorkers is how many messages of each subject an instance processes at once
+	DefaultEventBusWorkers = 4
+
+	// eventBusSource is the source of ingested events that don't name one
+	eventBusSource = "nats"
+
+	// natsStatusHeader is the header of the status messages JetStream sends push consumers
+	natsStatusHeader = "Status"
+)
+
+// errInvalidEventMessage is returned for ingested messages that are not events
+var errInvalidEventMessage = errors.New("invalid event message")
+
+// EventPublisher publishes notifications about delivered events and finished chain runs to a message bus
+// Publishing is best effort: failures are logged and never fail the delivery or run
+type EventPublisher interface {
+	// PublishDeliveryResult publishes the outcome of delivering an event to its subscriptions
+	PublishDeliveryResult(ctx context.Context, event *models.WebhookEvent, result *models.EventProcessingResult)
+
+	// PublishRunFinished publishes the summary of a finished chain run
+	PublishRunFinished(ctx context.Context, run *models.ExecutionChainRun, summary map[string]interface{})
+}
+
+// multiPublisher publishes to several publishers in turn
+type multiPublisher []EventPublisher
+
+// MultiPublisher returns a publisher publishing to every non-nil publisher given, nil if there is none
+func MultiPublisher(publishers ...EventPublisher) EventPublisher {
+	var multi multiPublisher
+	for _, publisher := range publishers {
+		if publisher != nil {
+			multi = append(multi, publisher)
+		}
+	}
+	switch len(multi) {
+	case 0:
+		return nil
+	case 1:
+		return multi[0]
+	}
+	return multi
+}
+
+func (m multiPublisher) PublishDeliveryResult(ctx context.Context, event *models.WebhookEvent, result *models.EventProcessingResult) {
+	for _, publisher := range m {
+		publisher.PublishDeliveryResult(ctx, event, result)
+	}
+}
+
+func (m multiPublisher) PublishRunFinished(ctx context.Context, run *models.ExecutionChainRun, summary map[string]interface{}) {
+	for _, publisher := range m {
+		publisher.PublishRunFinished(ctx, run, summary)
+	}
+}
+
+// EventBusConfig configures the NATS integration
+type EventBusConfig struct {
+	// Subjects are the subjects events are ingested from; wildcards are allowed
+	// The deliver subject of a JetStream push consumer ingests the consumer's messages
+	Subjects []string
+
+	// Queue is the queue group instances share; it must be the deliver group of JetStream push consumers
+	// Empty makes every instance process every message
+	Queue string
+
+	// Workers is how many messages of each subject are processed at once; 1 without a queue group
+	Workers int
+
+	// PublishPrefix prefixes the subjects delivery results and finished runs are published to
+	// Empty disables publishing
+	PublishPrefix string
+}
+
+// EventBus ingests events from NATS subjects and publishes delivery results and finished chain runs
+//
+// Ingested messages hold a SendEventRequest as JSON, as posted to POST /api/webhooks/event; the event
+// defaults to the message's subject and the source to "nats". JetStream messages are acknowledged once
+// the event is processed, terminated when it is rejected for good and negatively acknowledged for
+// redelivery when processing fails; other messages with a reply subject get the API's response as reply
+//
+// Delivery results are published to <prefix>.deliveries.<tenant_id>.<event> and finished runs to
+// <prefix>.chain_runs.<tenant_id>.<status>
+type EventBus struct {
+	conn           *nats.Conn
+	webhookService WebhookService
+	config         EventBusConfig
+	clock          Clock
+	logger         logging.Logger
+
+	subscriptions []*nats.Subscription
+	inFlight      sync.WaitGroup
+}
+
+// NewEventBus creates the NATS integration over an established connection
+func NewEventBus(conn *nats.Conn, webhookService WebhookService, config EventBusConfig, logger logging.Logger) *EventBus {
+	if config.Workers <= 0 {
+		config.Workers = DefaultEventBusWorkers
+	}
+	if config.Queue == "" {
+		config.Workers = 1
+	}
+	return &EventBus{
+		conn:           conn,
+		webhookService: webhookService,
+		config:         config,
+		clock:          NewSystemClock(),
+		logger:         logger,
+	}
+}
+
+// Start subscribes to the configured subjects; events are processed with ctx until Stop is called
+// Every worker subscribes in the queue group, so the server spreads messages over the workers
+func (b *EventBus) Start(ctx context.Context) error {
+	for _, subject := range b.config.Subjects {
+		for range b.config.Workers {
+			handler := func(msg *nats.Msg) {
+				b.inFlight.Add(1)
+				defer b.inFlight.Done()
+				b.handle(ctx, msg)
+			}
+			var subscription *nats.Subscription
+			var err error
+			if b.config.Queue != "" {
+				subscription, err = b.conn.QueueSubscribe(subject, b.config.Queue, handler)
+			} else {
+				subscription, err = b.conn.Subscribe(subject, handler)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+			}
+			b.subscriptions = append(b.subscriptions, subscription)
+		}
+		b.logger.Info(ctx, "Ingesting events from NATS",
+			zap.String("subject", subject),
+			zap.String("queue", b.config.Queue),
+			zap.Int("workers", b.config.Workers))
+	}
+	return nil
+}
+
+// Stop stops ingesting events and waits for the events being processed
+// Messages not yet processed are redelivered to other instances by JetStream, or lost for core NATS
+func (b *EventBus) Stop() {
+	for _, subscription := range b.subscriptions {
+		if err := subscription.Unsubscribe(); err != nil {
+			b.logger.Warn(context.Background(), "Failed to unsubscribe from NATS", zap.Error(err))
+		}
+	}
+	b.inFlight.Wait()
+}
+
+// handle processes an ingested message and acknowledges or answers it
+// Status messages are JetStream idle heartbeats and flow control requests, not published events; flow control
+// requests are answered so the consumer keeps delivering
+func (b *EventBus) handle(ctx context.Context, msg *nats.Msg) {
+	if msg.Header.Get(natsStatusHeader) != "" {
+		if msg.Reply != "" {
+			_ = msg.Respond(nil)
+		}
+		return
+	}
+	jetStream := isJetStream(msg)
+	result, err := b.ingest(ctx, msg)
+
+	var response interface{} = models.SuccessResponse{Message: "Webhook event processed successfully", Data: result}
+	permanent := false
+	if err != nil {
+		var problem models.Problem
+		problem, permanent = eventBusError(err)
+		response = problem
+		b.logger.Warn(ctx, "Failed to process event from NATS",
+			zap.String("subject", msg.Subject),
+			zap.Bool("redelivered", jetStream && !permanent),
+			zap.Error(err))
+	}
+
+	var replyErr error
+	switch {
+	case jetStream && err == nil:
+		replyErr = msg.Ack()
+	case jetStream && permanent:
+		replyErr = msg.Term()
+	case jetStream:
+		replyErr = msg.Nak()
+	case msg.Reply != "":
+		data, _ := json.Marshal(response)
+		replyErr = msg.Respond(data)
+	}
+	if replyErr != nil {
+		b.logger.Warn(ctx, "Failed to answer NATS message",
+			zap.String("subject", msg.Subject),
+			zap.Error(replyErr))
+	}
+}
+
+// isJetStream reports whether a message was delivered by a JetStream consumer and must be acknowledged
+func isJetStream(msg *nats.Msg) bool {
+	_, err := msg.Metadata()
+	return err == nil
+}
+
+// ingest decodes the event of a message and sends it
+func (b *EventBus) ingest(ctx context.Context, msg *nats.Msg) (*models.EventProcessingResult, error) {
+	var req models.SendEventRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidEventMessage, err)
+	}
+	if req.Event == "" {
+		req.Event = msg.Subject
+	}
+	if req.Source == "" {
+		req.Source = eventBusSource
+	}
+	if req.TenantID == "" || req.Payload == nil {
+		return nil, fmt.Errorf("%w: tenant_id and payload are required", errInvalidEventMessage)
+	}
+	return b.webhookService.SendEvent(ctx, &req)
+}
+
+// eventBusError returns the problem details the API would answer an error of SendEvent with, and whether the
+// event is rejected for good, so redelivering it can not succeed
+// Like the API, only errors describing the message, and typed errors, are detailed; others get a fixed detail
+func eventBusError(err error) (models.Problem, bool) {
+	response := func(code string, status int, detail string) models.Problem {
+		if typed := apperr.As(err); typed != nil {
+			code, detail = typed.Code, typed.Message
+		}
+		return models.Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Code: code}
+	}
+
+	var validationErr *PayloadValidationError
+	switch {
+	case errors.Is(err, errInvalidEventMessage):
+		return response("invalid_request", http.StatusBadRequest, err.Error()), true
+	case errors.As(err, &validationErr):
+		return response("payload_validation_failed", http.StatusUnprocessableEntity, err.Error()), true
+	case errors.Is(err, ErrTenantNotFound):
+		return response("tenant_not_found", http.StatusNotFound, ""), true
+	case errors.Is(err, ErrTenantSuspended):
+		return response("tenant_suspended", http.StatusForbidden, ""), true
+	case errors.Is(err, ErrQuotaExceeded):
+		return response("quota_exceeded", http.StatusTooManyRequests, ""), true
+	default:
+		return response("event_processing_failed", http.StatusInternalServerError, "failed to process the event"), false
+	}
+}
+
+// PublishDeliveryResult publishes the outcome of delivering an event to <prefix>.deliveries.<tenant_id>.<event>
+func (b *EventBus) PublishDeliveryResult(ctx context.Context, event *models.WebhookEvent, result *models.EventProcessingResult) {
+	message := models.DeliveryResultMessage{
+		Event:        "webhook.event_delivered",
+		EventID:      event.ID,
+		TenantID:     event.TenantID,
+		EventName:    event.EventName,
+		Source:       event.Source,
+		Status:       event.Status,
+		TotalSent:    result.TotalSent,
+		TotalFailed:  result.TotalFailed,
+		TotalQueued:  result.TotalQueued,
+		TotalExpired: result.TotalExpired,
+		Webhooks:     result.Webhooks,
+		Timestamp:    b.clock.Now().Format(time.RFC3339),
+	}
+	b.publish(ctx, b.subject("deliveries", subjectToken(event.TenantID), subjectTokens(event.EventName)), message)
+}
+
+// PublishRunFinished publishes the summary of a finished run to <prefix>.chain_runs.<tenant_id>.<status>
+func (b *EventBus) PublishRunFinished(ctx context.Context, run *models.ExecutionChainRun, summary map[string]interface{}) {
+	b.publish(ctx, b.subject("chain_runs", subjectToken(run.TenantID), subjectToken(string(run.Status))), summary)
+}
+
+// subject joins the publish prefix and tokens into a subject
+func (b *EventBus) subject(tokens ...string) string {
+	return b.config.PublishPrefix + "." + strings.Join(tokens, ".")
+}
+
+// publish publishes a message as JSON, logging failures
+func (b *EventBus) publish(ctx context.Context, subject string, message interface{}) {
+	if b.config.PublishPrefix == "" {
+		return
+	}
+	data, err := json.Marshal(message)
+	if err == nil {
+		err = b.conn.Publish(subject, data)
+	}
+	if err != nil {
+		b.logger.Warn(ctx, "Failed to publish to NATS",
+			zap.String("subject", subject),
+			zap.Error(err))
+	}
+}
+
+// subjectToken makes a value a single subject token, replacing dots, wildcards and whitespace
+func subjectToken(value string) string {
+	if value == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, value)
+}
+
+// subjectTokens makes a dotted value such as an event name subject tokens, keeping its dots
+func subjectTokens(value string) string {
+	parts := strings.Split(value, ".")
+	for i, part := range parts {
+		parts[i] = subjectToken(part)
+	}
+	return strings.Join(parts, ".")
+}
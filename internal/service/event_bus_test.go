@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// natsPublished is a message a client published to the fake NATS server
+type natsPublished struct {
+	subject string
+	data    string
+}
+
+// fakeNATS accepts one client speaking the NATS protocol, recording its subscriptions and publications
+type fakeNATS struct {
+	t         *testing.T
+	ln        net.Listener
+	conn      chan net.Conn
+	subs      chan []string
+	published chan natsPublished
+}
+
+func newFakeNATS(t *testing.T) *fakeNATS {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeNATS{t: t, ln: ln, conn: make(chan net.Conn, 1), subs: make(chan []string, 8), published: make(chan natsPublished, 8)}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		s.conn <- conn
+		s.serve(conn)
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+// serve answers the handshake and PINGs of a connection and records its SUBs and PUBs until it closes
+func (s *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	io.WriteString(conn, `INFO {"server_id":"test","version":"2.10.0","proto":1,"headers":true,"max_payload":1048576}`+"\r\n")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "PING":
+			io.WriteString(conn, "PONG\r\n")
+		case "SUB":
+			s.subs <- fields[1:]
+		case "PUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return
+			}
+			s.published <- natsPublished{subject: fields[1], data: string(data[:size])}
+		}
+	}
+}
+
+func (s *fakeNATS) url() string {
+	return "nats://" + s.ln.Addr().String()
+}
+
+// deliver sends a message to the subscription sid, with a status header when status is set
+func (s *fakeNATS) deliver(sid, subject, reply, status, data string) {
+	conn := <-s.conn
+	defer func() { s.conn <- conn }()
+	if status == "" {
+		fmt.Fprintf(conn, "MSG %s %s %s %d\r\n%s\r\n", subject, sid, reply, len(data), data)
+		return
+	}
+	header := "NATS/1.0 " + status + "\r\n\r\n"
+	fmt.Fprintf(conn, "HMSG %s %s %s %d %d\r\n%s%s\r\n", subject, sid, reply, len(header), len(header)+len(data), header, data)
+}
+
+// nextSub returns the arguments of the next SUB
+func (s *fakeNATS) nextSub() []string {
+	select {
+	case sub := <-s.subs:
+		return sub
+	case <-time.After(5 * time.Second):
+		s.t.Fatal("no subscription received")
+		return nil
+	}
+}
+
+// nextPublished returns the next message published
+func (s *fakeNATS) nextPublished() natsPublished {
+	select {
+	case msg := <-s.published:
+		return msg
+	case <-time.After(5 * time.Second):
+		s.t.Fatal("no message published")
+		return natsPublished{}
+	}
+}
+
+// jetStreamReply is the acknowledgement subject of the first message of a JetStream consumer
+const jetStreamReply = "$JS.ACK.ORDERS.loki.1.1.1.1700000000000000000.0"
+
+// TestEventBus_Ingest tests that events are ingested in the queue group, that JetStream messages are
+// acknowledged, terminated or negatively acknowledged by outcome, that core messages with a reply subject get
+// the API's response, and that JetStream status messages are not ingested
+func TestEventBus_Ingest(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := newFakeNATS(t)
+	conn, err := nats.Connect(server.url())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	webhookService := mocks.NewMockWebhookService(t)
+	webhookService.EXPECT().SendEvent(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, req *models.SendEventRequest) (*models.EventProcessingResult, error) {
+			assert.Equal(t, "nats", req.Source)
+			if req.TenantID == "broken" {
+				return nil, errors.New("database unavailable")
+			}
+			return &models.EventProcessingResult{TotalSent: 1}, nil
+		})
+	bus := service.NewEventBus(conn, webhookService, service.EventBusConfig{
+		Subjects: []string{"orders.>"}, Queue: "loki-suite", Workers: 1,
+	}, logging.Nop())
+
+	// Act
+	require.NoError(t, bus.Start(ctx))
+	sub := server.nextSub()
+	sid := sub[len(sub)-1]
+	event := `{"tenant_id":"acme","payload":{"id":1}}`
+
+	server.deliver(sid, "orders.created", jetStreamReply, "", event)
+	acked := server.nextPublished()
+	server.deliver(sid, "orders.created", jetStreamReply, "", `not json`)
+	terminated := server.nextPublished()
+	server.deliver(sid, "orders.created", jetStreamReply, "", `{"tenant_id":"broken","payload":{}}`)
+	nacked := server.nextPublished()
+	server.deliver(sid, "orders.created", "", "100 Idle Heartbeat", "")
+	server.deliver(sid, "orders.created", "flow.control", "100 FlowControl Request", "")
+	flowControl := server.nextPublished()
+	server.deliver(sid, "orders.created", "_INBOX.reply", "", event)
+	replied := server.nextPublished()
+	bus.Stop()
+
+	// Assert
+	assert.Equal(t, []string{"orders.>", "loki-suite", sid}, sub)
+	assert.Equal(t, natsPublished{subject: jetStreamReply, data: "+ACK"}, acked)
+	assert.Equal(t, natsPublished{subject: jetStreamReply, data: "+TERM"}, terminated)
+	assert.Equal(t, natsPublished{subject: jetStreamReply, data: "-NAK"}, nacked)
+	assert.Equal(t, natsPublished{subject: "flow.control"}, flowControl)
+	assert.Equal(t, "_INBOX.reply", replied.subject)
+	assert.Contains(t, replied.data, `"message":"Webhook event processed successfully"`)
+	assert.Contains(t, replied.data, `"total_sent":1`)
+}
+
+// TestEventBus_PublishDeliveryResult tests that delivery results are published under the prefix, with the
+// tenant and event as subject tokens
+func TestEventBus_PublishDeliveryResult(t *testing.T) {
+	// Arrange
+	server := newFakeNATS(t)
+	conn, err := nats.Connect(server.url())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	bus := service.NewEventBus(conn, mocks.NewMockWebhookService(t), service.EventBusConfig{PublishPrefix: "loki"}, logging.Nop())
+	event := &models.WebhookEvent{ID: uuid.New(), TenantID: "acme.eu", EventName: "order.created"}
+
+	// Act
+	bus.PublishDeliveryResult(context.Background(), event, &models.EventProcessingResult{TotalSent: 2})
+	published := server.nextPublished()
+
+	// Assert
+	assert.Equal(t, "loki.deliveries.acme_eu.order.created", published.subject)
+	assert.Contains(t, published.data, `"event_id":"`+event.ID.String()+`"`)
+	assert.Contains(t, published.data, `"total_sent":2`)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Event types form a tenant's event catalog. Registering one documents an event and its payload;
+// with validate_payloads, or with strict payload validation enabled for the tenant, SendEvent rejects
+// events whose payload violates the schema before anything is stored or delivered. Events without a
+// registered type or schema are never validated
+
+// RegisterEventType declares an event type for a tenant
+func (s *webhookService) RegisterEventType(ctx context.Context, req *models.RegisterEventTypeRequest) (*models.EventType, error) {
+	existing, err := s.repo.GetEventTypeByName(ctx, req.TenantID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up event type: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrEventTypeExists.Withf("event type %q is already registered", req.Name)
+	}
+
+	schema, err := compileEventSchema(req.Schema, req.ValidatePayloads)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	eventType := &models.EventType{
+		ID:               uuid.New(),
+		TenantID:         req.TenantID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Schema:           schema,
+		ValidatePayloads: req.ValidatePayloads,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := s.repo.CreateEventType(ctx, eventType); err != nil {
+		return nil, fmt.Errorf("failed to register event type: %w", err)
+	}
+	return eventType, nil
+}
+
+// UpdateEventType replaces the description, schema and validation switch of an event type
+func (s *webhookService) UpdateEventType(ctx context.Context, id uuid.UUID, req *models.UpdateEventTypeRequest) (*models.EventType, error) {
+	eventType, err := s.repo.GetEventTypeByID(ctx, id)
+	if err != nil {
+		return nil, notFound(ErrEventTypeNotFound, err)
+	}
+
+	schema, err := compileEventSchema(req.Schema, req.ValidatePayloads)
+	if err != nil {
+		return nil, err
+	}
+
+	eventType.Description = req.Description
+	eventType.Schema = schema
+	eventType.ValidatePayloads = req.ValidatePayloads
+	eventType.UpdatedAt = s.clock.Now()
+	if err := s.repo.UpdateEventType(ctx, eventType); err != nil {
+		return nil, fmt.Errorf("failed to update event type: %w", err)
+	}
+	return eventType, nil
+}
+
+// DeleteEventType removes an event type from the catalog
+func (s *webhookService) DeleteEventType(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.repo.GetEventTypeByID(ctx, id); err != nil {
+		return notFound(ErrEventTypeNotFound, err)
+	}
+	if err := s.repo.DeleteEventType(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete event type: %w", err)
+	}
+	return nil
+}
+
+// ListEventTypes returns a tenant's event catalog: the registered event types together with the events
+// its subscriptions and chains use without one, each with the active subscriptions and chains it reaches
+func (s *webhookService) ListEventTypes(ctx context.Context, tenantID string) (*models.EventTypeListResponse, error) {
+	eventTypes, err := s.repo.GetEventTypesByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event types: %w", err)
+	}
+	subscriptions, err := s.tenantRepo.GetAllSubscriptions(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriptions: %w", err)
+	}
+	chains, err := s.tenantRepo.GetAllChains(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution chains: %w", err)
+	}
+
+	catalog := make(map[string]*models.EventTypeSummary)
+	summary := func(name string) *models.EventTypeSummary {
+		entry, ok := catalog[name]
+		if !ok {
+			entry = &models.EventTypeSummary{Name: name}
+			catalog[name] = entry
+		}
+		return entry
+	}
+
+	for _, eventType := range eventTypes {
+		entry := summary(eventType.Name)
+		id := eventType.ID
+		entry.Registered = true
+		entry.ID = &id
+		entry.Description = eventType.Description
+		entry.ValidatePayloads = eventType.ValidatePayloads
+		if eventType.Schema != nil {
+			if err := json.Unmarshal([]byte(*eventType.Schema), &entry.Schema); err != nil {
+				return nil, fmt.Errorf("invalid schema of event type %q: %w", eventType.Name, err)
+			}
+		}
+	}
+	for _, subscription := range subscriptions {
+		entry := summary(subscription.SubscribedEvent)
+		if subscription.IsActive {
+			entry.Subscribers++
+		}
+	}
+	for _, chain := range chains {
+		entry := summary(chain.TriggerEvent)
+		if chain.IsActive {
+			entry.Chains++
+		}
+	}
+
+	response := &models.EventTypeListResponse{
+		TenantID:   tenantID,
+		EventTypes: make([]models.EventTypeSummary, 0, len(catalog)),
+	}
+	for _, entry := range catalog {
+		response.EventTypes = append(response.EventTypes, *entry)
+	}
+	sort.Slice(response.EventTypes, func(i, j int) bool {
+		return response.EventTypes[i].Name < response.EventTypes[j].Name
+	})
+	response.Total = len(response.EventTypes)
+	return response, nil
+}
+
+// maxPayloadViolations caps the violations reported for a rejected payload
+const maxPayloadViolations = 50
+
+// PayloadValidationError is returned by SendEvent when an event's payload violates its event type's schema
+type PayloadValidationError struct {
+	EventType  string
+	Violations []models.SchemaViolation
+}
+
+// Error summarizes the violations, naming the first
+func (e *PayloadValidationError) Error() string {
+	first := e.Violations[0]
+	return fmt.Sprintf("payload violates the schema of event type %q: %s: %s (%d violations)",
+		e.EventType, first.Path, first.Message, len(e.Violations))
+}
+
+// validateEventPayload checks an event's payload against the schema of its event type
+// Payloads are validated when the event type validates payloads or the tenant enforces strict validation
+// Returns nil when the event has no registered type or its type has no schema, and a
+// *PayloadValidationError listing the violations when the payload does not conform
+func (s *webhookService) validateEventPayload(ctx context.Context, req *models.SendEventRequest) error {
+	eventType, err := s.repo.GetEventTypeByName(ctx, req.TenantID, req.Event)
+	if err != nil {
+		return fmt.Errorf("failed to look up event type: %w", err)
+	}
+	if eventType == nil || eventType.Schema == nil {
+		return nil
+	}
+	if !eventType.ValidatePayloads {
+		settings, err := s.tenantRepo.GetTenantSettings(ctx, req.TenantID)
+		if err != nil {
+			return fmt.Errorf("failed to load tenant settings: %w", err)
+		}
+		if !settings.StrictPayloadValidation {
+			return nil
+		}
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(*eventType.Schema), &schema); err != nil {
+		return fmt.Errorf("invalid schema of event type %q: %w", eventType.Name, err)
+	}
+
+	// Round-trip the payload so it has the shape validation expects, e.g. float64 numbers
+	payloadBytes, err := json.Marshal(req.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize payload: %w", err)
+	}
+	var payload interface{}
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return fmt.Errorf("failed to serialize payload: %w", err)
+	}
+
+	if violations := schemaViolations(schema, payload, "$", maxPayloadViolations); len(violations) > 0 {
+		return &PayloadValidationError{EventType: eventType.Name, Violations: violations}
+	}
+	return nil
+}
+
+// GetTenantPayloadValidation retrieves whether a tenant enforces strict payload validation
+func (s *webhookService) GetTenantPayloadValidation(ctx context.Context, tenantID string) (*models.TenantPayloadValidationResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	return &models.TenantPayloadValidationResponse{
+		TenantID: tenantID,
+		Strict:   settings.StrictPayloadValidation,
+	}, nil
+}
+
+// UpdateTenantPayloadValidation switches strict payload validation for a tenant
+func (s *webhookService) UpdateTenantPayloadValidation(ctx context.Context, tenantID string, strict bool) (*models.TenantPayloadValidationResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	settings.StrictPayloadValidation = strict
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save tenant settings: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant payload validation updated",
+		zap.String("tenant_id", tenantID),
+		zap.Bool("strict", strict))
+
+	return &models.TenantPayloadValidationResponse{
+		TenantID: tenantID,
+		Strict:   strict,
+	}, nil
+}
+
+// compileEventSchema checks an event type's payload schema and serializes it for storage
+func compileEventSchema(schema map[string]interface{}, validatePayloads bool) (*string, error) {
+	if schema == nil {
+		if validatePayloads {
+			return nil, ErrInvalidEventType.Withf("validate_payloads requires a schema")
+		}
+		return nil, nil
+	}
+
+	compiled, err := compileResponseSchema(schema)
+	if err != nil {
+		return nil, ErrInvalidEventType.Withf("invalid schema: %v", err)
+	}
+	return &compiled, nil
+}
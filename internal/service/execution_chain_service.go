@@ -1,14 +1,17 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
 	"github.com/sakibcoolz/loki-suite/internal/models"
 	"github.com/sakibcoolz/loki-suite/internal/repository"
 	"github.com/sakibcoolz/zcornor/pkg/config"
@@ -18,76 +21,186 @@ import (
 	"go.uber.org/zap"
 )
 
-var logger *zap.Logger
-
-func init() {
-	var err error
-	logger, err = zap.NewProduction()
-	if err != nil {
-		panic("Failed to initialize logger: " + err.Error())
-	}
-}
-
 // ExecutionChainService handles execution chain business logic
 type ExecutionChainService interface {
 	// Chain management
+	// The methods taking a tenant act for a caller of that tenant: the chains and runs of other tenants are not
+	// found, returning ErrNotFound, or left out of lists; an empty tenant, for global callers, reaches every tenant's
 	CreateChain(ctx context.Context, req *models.CreateExecutionChainRequest) (*models.CreateExecutionChainResponse, error)
-	GetChain(ctx context.Context, chainID uuid.UUID) (*models.ExecutionChain, error)
-	ListChains(ctx context.Context, tenantID string, page, limit int) (*models.ExecutionChainListResponse, error)
-	UpdateChain(ctx context.Context, chainID uuid.UUID, req *models.UpdateExecutionChainRequest) error
-	DeleteChain(ctx context.Context, chainID uuid.UUID) error
+	GetChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ExecutionChain, error)
+	ListChains(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage) (*models.ExecutionChainListResponse, error)
+	UpdateChain(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateExecutionChainRequest) error
+	UpdateChainSteps(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateChainStepsRequest) (*models.UpdateChainStepsResponse, error)
+	GetChainVersions(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainVersionsResponse, error)
+	RollbackChain(ctx context.Context, tenantID string, chainID uuid.UUID, version int) (*models.UpdateChainStepsResponse, error)
+	DeleteChain(ctx context.Context, tenantID string, chainID uuid.UUID) error
+	PauseChain(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.PauseChainRequest) (*models.ChainStateResponse, error)
+	ActivateChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainStateResponse, error)
+	GetChainSchedule(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainScheduleResponse, error)
+	SetChainSchedule(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.ChainScheduleRequest) (*models.ChainScheduleResponse, error)
+	GetChainHistory(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ConfigHistoryResponse, error)
+	RestoreChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ExecutionChain, error)
+	ExportChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainTemplate, error)
+	ImportChain(ctx context.Context, template *models.ChainTemplate, req *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error)
+
+	// SyncChains reconciles a tenant's chains with the chains of a configuration manifest, resolving their
+	// webhooks against the given subscriptions; only plans the changes on a dry run
+	SyncChains(ctx context.Context, tenantID string, templates []models.ChainTemplate, subscriptions []models.WebhookSubscription, dryRun bool) ([]models.ConfigResourceChange, error)
+
+	// Template catalog
+	ListChainTemplates() *models.ChainTemplateListResponse
+	GetChainTemplate(templateID string) (*models.ChainTemplate, error)
+	InstantiateChainTemplate(ctx context.Context, templateID string, req *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error)
 
 	// Chain execution
-	ExecuteChain(ctx context.Context, req *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error)
+	ExecuteChain(ctx context.Context, tenantID string, req *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error)
 	ExecuteChainByEvent(ctx context.Context, tenantID, event string, eventData map[string]interface{}) error
-	GetChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error)
-	ListChainRuns(ctx context.Context, chainID uuid.UUID, page, limit int) (*models.ExecutionChainRunsResponse, error)
+	DryRunChain(ctx context.Context, tenantID string, req *models.ExecuteChainRequest) (*models.ChainDryRunResponse, error)
+	GetChainRun(ctx context.Context, tenantID string, runID uuid.UUID) (*models.ExecutionChainRun, error)
+	GetChainRunOutputs(ctx context.Context, tenantID string, runID uuid.UUID) (*models.ChainRunOutputsResponse, error)
+	RetryChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecuteChainResponse, error)
+	ListChainRuns(ctx context.Context, tenantID string, chainID uuid.UUID, filter models.ListFilter, page models.ListPage) (*models.ExecutionChainRunsResponse, error)
+	GetChainStats(ctx context.Context, tenantID string, chainID uuid.UUID, window models.StatsWindow) (*models.ChainStatsResponse, error)
+	GetChainUsage(ctx context.Context, tenantID string, window models.StatsWindow) (*models.ChainUsageResponse, error)
+	ResumeChainRun(ctx context.Context, runID uuid.UUID) (*models.ChainRunControlResponse, error)
+	CancelChainRun(ctx context.Context, runID uuid.UUID, reason string) (*models.ChainRunControlResponse, error)
+	ApproveChainRun(ctx context.Context, runID uuid.UUID, approval models.StepApproval) (*models.ChainRunControlResponse, error)
+	RejectChainRun(ctx context.Context, runID uuid.UUID, approval models.StepApproval) (*models.ChainRunControlResponse, error)
+
+	// Tenant-wide execution control
+	PauseTenantChains(ctx context.Context, tenantID string) (*models.TenantChainControlResponse, error)
+	ResumeTenantChains(ctx context.Context, tenantID string) (*models.TenantChainControlResponse, error)
+	GetTenantRunLimit(ctx context.Context, tenantID string) (*models.TenantRunLimitResponse, error)
+	SetTenantRunLimit(ctx context.Context, tenantID string, req *models.TenantRunLimitRequest) (*models.TenantRunLimitResponse, error)
+
+	// Scheduled triggers
+	TriggerScheduledChains(ctx context.Context) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+
+	// Run recovery
+	RecoverChainRuns(ctx context.Context, lease time.Duration) (int, error)
+	RunRecovery(ctx context.Context, interval time.Duration)
+
+	// Lifecycle
+	ConfigureWorkers(instanceID string, maxConcurrentRuns int)
+	ConfigureRunLimit(maxRunningRuns int)
+	SetEventPublisher(publisher EventPublisher)
+	SetLocks(locks repository.LockRepository)
+	SetWorkQueue(queue WorkQueue)
+	SetResponseCapture(capture ResponseCapture)
+	SetPriorityMetrics(metrics *PriorityMetrics)
+	RunAdmissionQueue(ctx context.Context, interval time.Duration)
+	Shutdown(ctx context.Context) error
+
+	// SetTransports replaces the transports step webhooks are delivered through, by their target type
+	SetTransports(transports *TransportRegistry)
+
+	// Testing
+	SetClock(clock Clock)
 }
 
+const (
+	// shutdownGracePeriod is how long cancelled runs get to wind down after the drain deadline
+	shutdownGracePeriod = 5 * time.Second
+
+	// defaultCancelReason is recorded when a run is cancelled without a reason
+	defaultCancelReason = "cancelled via API"
+)
+
+// errChainPaused is returned when a queued run cannot start because its chain is paused
+// Such runs stay queued until the chain is activated
+var errChainPaused = apperr.Conflict("chain_paused", "chain is paused")
+
 // executionChainService implements ExecutionChainService
 type executionChainService struct {
 	chainRepo   repository.ExecutionChainRepository
 	webhookRepo repository.WebhookRepository
+	tenantRepo  repository.TenantRepository
+	historyRepo repository.ConfigHistoryRepository
 	security    *security.SecurityService
 	config      *config.Config
-	httpClient  *http.Client
+	env         *transportEnv
+	transports  *TransportRegistry
+	workers     *workerRegistry
+	publisher   EventPublisher
+	locks       repository.LockRepository
+	queue       WorkQueue
+	capture     ResponseCapture
+	clock       Clock
+	logger      logging.Logger
+	instanceID  string
+	startedAt   time.Time
+
+	// admission serializes counting running runs and starting queued ones against the concurrency limits
+	// within this instance; the admission lock serializes it across instances
+	admission      sync.Mutex
+	globalRunLimit int
+
+	// priorityMetrics is nil when priorities are not exported
+	priorityMetrics *PriorityMetrics
 }
 
 // NewExecutionChainService creates a new execution chain service
 func NewExecutionChainService(
 	chainRepo repository.ExecutionChainRepository,
 	webhookRepo repository.WebhookRepository,
+	tenantRepo repository.TenantRepository,
+	historyRepo repository.ConfigHistoryRepository,
 	security *security.SecurityService,
 	config *config.Config,
+	logger logging.Logger,
 ) ExecutionChainService {
+	env := &transportEnv{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second, // Default timeout
+		},
+		security:   security,
+		tenantRepo: tenantRepo,
+		clock:      NewSystemClock(),
+	}
 	return &executionChainService{
 		chainRepo:   chainRepo,
 		webhookRepo: webhookRepo,
+		tenantRepo:  tenantRepo,
+		historyRepo: historyRepo,
 		security:    security,
 		config:      config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second, // Default timeout
-		},
+		env:         env,
+		transports:  newTransportRegistry(env),
+		workers:     newWorkerRegistry(defaultMaxConcurrentRuns),
+		clock:       env.clock,
+		logger:      logger,
+		instanceID:  defaultInstanceID(),
+		startedAt:   time.Now(),
 	}
 }
 
+// SetClock replaces the clock used for timestamps, step delays and retry backoff
+func (s *executionChainService) SetClock(clock Clock) {
+	s.clock = clock
+	s.env.clock = clock
+}
+
+// SetTransports replaces the transports step webhooks are delivered through
+// The webhook service's registry is shared this way, so steps reach every target type events do
+func (s *executionChainService) SetTransports(transports *TransportRegistry) {
+	s.transports = transports
+}
+
 // CreateChain creates a new execution chain
 func (s *executionChainService) CreateChain(ctx context.Context, req *models.CreateExecutionChainRequest) (*models.CreateExecutionChainResponse, error) {
-	logger.Info("Creating execution chain",
+	s.logger.Info(ctx, "Creating execution chain",
 		zap.String("tenant_id", req.TenantID),
 		zap.String("name", req.Name),
 		zap.String("trigger_event", req.TriggerEvent),
 		zap.Int("steps_count", len(req.Steps)))
 
-	// Validate that all webhook IDs exist and belong to the tenant
-	for i, step := range req.Steps {
-		webhook, err := s.webhookRepo.GetSubscriptionByID(step.WebhookID)
-		if err != nil {
-			return nil, fmt.Errorf("step %d: webhook not found: %w", i+1, err)
-		}
-		if webhook.TenantID != req.TenantID {
-			return nil, fmt.Errorf("step %d: webhook belongs to different tenant", i+1)
-		}
+	if _, err := activeTenant(ctx, s.tenantRepo, req.TenantID); err != nil {
+		return nil, err
+	}
+	schedule, policy, err := parseChainSchedule(req.ChainScheduleRequest)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create execution chain
@@ -99,12 +212,84 @@ func (s *executionChainService) CreateChain(ctx context.Context, req *models.Cre
 		TriggerEvent: req.TriggerEvent,
 		Status:       models.ExecutionChainStatusPending,
 		IsActive:     true,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		Version:      1,
+		CreatedAt:    s.clock.Now(),
+		UpdatedAt:    s.clock.Now(),
+	}
+	if schedule != nil {
+		chain.Schedule = req.Schedule
+		chain.ScheduleTimezone = req.ScheduleTimezone
+		chain.OverlapPolicy = policy
+		chain.NextRunAt = nextScheduledRun(schedule, chain.CreatedAt)
+	}
+
+	if req.CompletionWebhookID != nil {
+		if err := s.validateCompletionWebhook(ctx, req.TenantID, *req.CompletionWebhookID); err != nil {
+			return nil, err
+		}
+		chain.CompletionWebhookID = req.CompletionWebhookID
+	}
+
+	steps, err := s.buildChainSteps(ctx, req.TenantID, req.Steps)
+	if err != nil {
+		return nil, err
+	}
+	chain.Steps = steps
+
+	// Save to database
+	if err := s.chainRepo.CreateChain(ctx, chain); err != nil {
+		s.logger.Error(ctx, "Failed to create execution chain", zap.Error(err))
+		return nil, fmt.Errorf("failed to create execution chain: %w", err)
+	}
+	s.recordChainSnapshot(ctx, chain.ID, models.ConfigChangeCreated)
+
+	s.logger.Info(ctx, "Execution chain created successfully",
+		zap.String("chain_id", chain.ID.String()),
+		zap.String("tenant_id", req.TenantID))
+
+	return &models.CreateExecutionChainResponse{
+		ChainID:      chain.ID,
+		Name:         chain.Name,
+		TriggerEvent: chain.TriggerEvent,
+		StepsCount:   len(chain.Steps),
+		Status:       string(chain.Status),
+		CreatedAt:    chain.CreatedAt,
+	}, nil
+}
+
+// buildChainSteps validates the steps of a chain creation or step update request and builds their models
+// Webhooks must belong to the chain's tenant; step orders are assigned by the repository
+func (s *executionChainService) buildChainSteps(ctx context.Context, tenantID string, reqSteps []models.CreateExecutionChainStep) ([]models.ExecutionChainStep, error) {
+	if err := validateStepRequests(reqSteps); err != nil {
+		return nil, invalid(ErrInvalidChain, err)
+	}
+
+	// Validate that all webhook IDs exist and belong to the tenant
+	for i, step := range reqSteps {
+		if step.WebhookID != nil {
+			webhook, err := s.webhookRepo.GetSubscriptionByID(ctx, *step.WebhookID)
+			if err != nil {
+				return nil, notFound(ErrInvalidChain.Withf("step %d: webhook not found", i+1), err)
+			}
+			if webhook.TenantID != tenantID {
+				return nil, ErrInvalidChain.Withf("step %d: webhook belongs to different tenant", i+1)
+			}
+		}
+
+		if step.Compensation != nil {
+			compensation, err := s.webhookRepo.GetSubscriptionByID(ctx, step.Compensation.WebhookID)
+			if err != nil {
+				return nil, notFound(ErrInvalidChain.Withf("step %d: compensation webhook not found", i+1), err)
+			}
+			if compensation.TenantID != tenantID {
+				return nil, ErrInvalidChain.Withf("step %d: compensation webhook belongs to different tenant", i+1)
+			}
+		}
 	}
 
 	// Create steps
-	for i, stepReq := range req.Steps {
+	steps := make([]models.ExecutionChainStep, 0, len(reqSteps))
+	for i, stepReq := range reqSteps {
 		// Convert request params to JSON
 		var requestParamsJSON string
 		if stepReq.RequestParams != nil {
@@ -115,6 +300,15 @@ func (s *executionChainService) CreateChain(ctx context.Context, req *models.Cre
 			requestParamsJSON = string(paramsBytes)
 		}
 
+		var responseSchema *string
+		if stepReq.ResponseSchema != nil {
+			schema, err := compileResponseSchema(stepReq.ResponseSchema)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: invalid response schema: %w", i+1, err)
+			}
+			responseSchema = &schema
+		}
+
 		// Set default actions
 		onSuccessAction := stepReq.OnSuccessAction
 		if onSuccessAction == "" {
@@ -131,52 +325,115 @@ func (s *executionChainService) CreateChain(ctx context.Context, req *models.Cre
 			maxRetries = 3
 		}
 
+		stepType := stepReq.Type
+		if stepType == "" {
+			stepType = models.StepTypeWebhook
+		}
+
+		var compensationWebhookID *uuid.UUID
+		var compensationParams *string
+		if stepReq.Compensation != nil {
+			webhookID := stepReq.Compensation.WebhookID
+			compensationWebhookID = &webhookID
+			if stepReq.Compensation.RequestParams != nil {
+				paramsBytes, err := json.Marshal(stepReq.Compensation.RequestParams)
+				if err != nil {
+					return nil, fmt.Errorf("step %d: invalid compensation params: %w", i+1, err)
+				}
+				params := string(paramsBytes)
+				compensationParams = &params
+			}
+		}
+
 		step := models.ExecutionChainStep{
 			ID:              uuid.New(),
 			WebhookID:       stepReq.WebhookID,
 			Name:            stepReq.Name,
 			Description:     stepReq.Description,
+			Type:            stepType,
 			RequestParams:   requestParamsJSON,
+			ResponseSchema:  responseSchema,
+			Condition:       stepReq.Condition,
+			ParallelGroup:   stepReq.ParallelGroup,
+			Key:             stepReq.Key,
+			Branches:        stepReq.Branches,
+			BranchPath:      stepReq.BranchPath,
+			Extract:         stepReq.Extract,
 			OnSuccessAction: onSuccessAction,
 			OnFailureAction: onFailureAction,
 			MaxRetries:      maxRetries,
 			DelaySeconds:    stepReq.DelaySeconds,
-			CreatedAt:       time.Now(),
-			UpdatedAt:       time.Now(),
+			CreatedAt:       s.clock.Now(),
+			UpdatedAt:       s.clock.Now(),
+
+			CompensationWebhookID: compensationWebhookID,
+			CompensationParams:    compensationParams,
 		}
 
-		chain.Steps = append(chain.Steps, step)
+		steps = append(steps, step)
 	}
+	resolveStepDependencies(reqSteps, steps)
 
-	// Save to database
-	if err := s.chainRepo.CreateChain(ctx, chain); err != nil {
-		logger.Error("Failed to create execution chain", zap.Error(err))
-		return nil, fmt.Errorf("failed to create execution chain: %w", err)
+	return steps, nil
+}
+
+// validateStepRequests checks the steps of a chain creation or step update request on their own: step types,
+// extract rules, conditions, response schemas, parallel groups, dependencies and branches
+// The webhooks they reference are checked by buildChainSteps
+func validateStepRequests(reqSteps []models.CreateExecutionChainStep) error {
+	for i, step := range reqSteps {
+		if err := validateStepType(step); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if err := validateStepExtract(step); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if step.Condition != "" {
+			if _, err := parseCondition(step.Condition); err != nil {
+				return fmt.Errorf("step %d: invalid condition: %w", i+1, err)
+			}
+		}
+		if step.ResponseSchema != nil {
+			if _, err := compileResponseSchema(step.ResponseSchema); err != nil {
+				return fmt.Errorf("step %d: invalid response schema: %w", i+1, err)
+			}
+		}
 	}
 
-	logger.Info("Execution chain created successfully",
-		zap.String("chain_id", chain.ID.String()),
-		zap.String("tenant_id", req.TenantID))
+	// Steps of a parallel group must be consecutive
+	closedGroups := make(map[string]bool)
+	for i, stepReq := range reqSteps {
+		if i > 0 && reqSteps[i-1].ParallelGroup != "" && reqSteps[i-1].ParallelGroup != stepReq.ParallelGroup {
+			closedGroups[reqSteps[i-1].ParallelGroup] = true
+		}
+		if closedGroups[stepReq.ParallelGroup] {
+			return fmt.Errorf("step %d: parallel group %q must be consecutive", i+1, stepReq.ParallelGroup)
+		}
+	}
 
-	return &models.CreateExecutionChainResponse{
-		ChainID:      chain.ID,
-		Name:         chain.Name,
-		TriggerEvent: chain.TriggerEvent,
-		StepsCount:   len(chain.Steps),
-		Status:       string(chain.Status),
-		CreatedAt:    chain.CreatedAt,
-	}, nil
+	if err := validateStepDependencies(reqSteps); err != nil {
+		return err
+	}
+	return validateStepBranches(reqSteps)
 }
 
-// GetChain retrieves a chain by ID
-func (s *executionChainService) GetChain(ctx context.Context, chainID uuid.UUID) (*models.ExecutionChain, error) {
-	return s.chainRepo.GetChainByID(ctx, chainID)
+// GetChain retrieves a chain by ID, if it belongs to the tenant when one is given
+func (s *executionChainService) GetChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ExecutionChain, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	return chain, nil
 }
 
-// ListChains lists chains for a tenant with pagination
-func (s *executionChainService) ListChains(ctx context.Context, tenantID string, page, limit int) (*models.ExecutionChainListResponse, error) {
-	offset := (page - 1) * limit
-	chains, total, err := s.chainRepo.GetChainsByTenant(ctx, tenantID, offset, limit)
+// ListChains lists the filtered chains of a tenant with pagination, sorted by creation time or status
+func (s *executionChainService) ListChains(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage) (*models.ExecutionChainListResponse, error) {
+	repoPage, err := listPage(&page, models.ListSortStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	chains, total, err := s.chainRepo.GetChainsByTenant(ctx, tenantID, filter, repoPage)
 	if err != nil {
 		return nil, err
 	}
@@ -190,13 +447,18 @@ func (s *executionChainService) ListChains(ctx context.Context, tenantID string,
 	return &models.ExecutionChainListResponse{
 		Chains: responseChains,
 		Total:  total,
-		Page:   page,
-		Limit:  limit,
+		Page:   page.Page,
+		Limit:  page.Limit,
+		NextCursor: encodeCursor(repoPage, repoPage.NextCursor(len(chains), func() repository.Cursor {
+			return repository.ChainCursor(chains[len(chains)-1], repoPage.Sort)
+		})),
 	}, nil
 }
 
-// UpdateChain updates a chain's properties
-func (s *executionChainService) UpdateChain(ctx context.Context, chainID uuid.UUID, req *models.UpdateExecutionChainRequest) error {
+// UpdateChain updates a chain's properties, at the lock version the request names when it names one
+// With a tenant given, the chain is updated only if it belongs to that tenant
+func (s *executionChainService) UpdateChain(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateExecutionChainRequest) error {
+	ctx = repository.WithTenantScope(ctx, tenantID)
 	updates := make(map[string]interface{})
 
 	if req.Name != nil {
@@ -207,270 +469,1917 @@ func (s *executionChainService) UpdateChain(ctx context.Context, chainID uuid.UU
 	}
 	if req.IsActive != nil {
 		updates["is_active"] = *req.IsActive
+		// Activating through the generic flag also ends a pause
+		if *req.IsActive {
+			updates["paused_at"] = nil
+			updates["pause_reason"] = nil
+			if chain, err := s.chainRepo.GetChainByID(ctx, chainID); err == nil {
+				s.resumeSchedule(chain, updates)
+			}
+		}
+	}
+	if req.CompletionWebhookID != nil {
+		if *req.CompletionWebhookID == uuid.Nil {
+			updates["completion_webhook_id"] = nil
+		} else {
+			chain, err := s.chainRepo.GetChainByID(ctx, chainID)
+			if err != nil {
+				return notFound(ErrChainNotFound, err)
+			}
+			if err := s.validateCompletionWebhook(ctx, chain.TenantID, *req.CompletionWebhookID); err != nil {
+				return err
+			}
+			updates["completion_webhook_id"] = *req.CompletionWebhookID
+		}
 	}
 
 	if len(updates) > 0 {
-		updates["updated_at"] = time.Now()
-		return s.chainRepo.UpdateChain(ctx, chainID, updates)
+		updates["updated_at"] = s.clock.Now()
+		var err error
+		if req.LockVersion != nil {
+			err = s.chainRepo.UpdateChainAtVersion(ctx, chainID, *req.LockVersion, updates)
+		} else {
+			err = s.chainRepo.UpdateChain(ctx, chainID, updates)
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrChainNotFound.Wrap(err)
+		}
+		if err != nil {
+			return err
+		}
+		s.recordChainSnapshot(ctx, chainID, models.ConfigChangeUpdated)
 	}
 
 	return nil
 }
 
-// DeleteChain deletes a chain
-func (s *executionChainService) DeleteChain(ctx context.Context, chainID uuid.UUID) error {
-	return s.chainRepo.DeleteChain(ctx, chainID)
-}
-
-// ExecuteChain manually executes a chain
-func (s *executionChainService) ExecuteChain(ctx context.Context, req *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error) {
-	logger.Info("Executing chain manually",
-		zap.String("chain_id", req.ChainID.String()))
-
-	// Get the chain
-	chain, err := s.chainRepo.GetChainByID(ctx, req.ChainID)
+// PauseChain stops a chain from being picked up for trigger events and manual executions
+// Runs already executing continue; runs queued by a tenant-wide pause stay queued until the chain
+// is activated, or are moved to paused when the request asks to suspend them
+func (s *executionChainService) PauseChain(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.PauseChainRequest) (*models.ChainStateResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
 	if err != nil {
-		return nil, fmt.Errorf("chain not found: %w", err)
+		return nil, notFound(ErrChainNotFound, err)
 	}
-
 	if !chain.IsActive {
-		return nil, fmt.Errorf("chain is not active")
+		if chain.PausedAt != nil {
+			return nil, ErrInvalidChainState.Withf("chain is already paused")
+		}
+		return nil, ErrInvalidChainState.Withf("chain is inactive, activate it before pausing")
 	}
 
-	// Create trigger data JSON
-	var triggerDataJSON string
-	if req.TriggerData != nil {
-		triggerBytes, err := json.Marshal(req.TriggerData)
-		if err != nil {
-			return nil, fmt.Errorf("invalid trigger data: %w", err)
-		}
-		triggerDataJSON = string(triggerBytes)
+	now := s.clock.Now()
+	updates := map[string]interface{}{
+		"is_active":    false,
+		"paused_at":    now,
+		"pause_reason": nil,
+		"updated_at":   now,
+	}
+	var reason *string
+	if req.Reason != "" {
+		reason = &req.Reason
+		updates["pause_reason"] = req.Reason
 	}
+	if err := s.chainRepo.UpdateChain(ctx, chainID, updates); err != nil {
+		return nil, fmt.Errorf("failed to pause chain: %w", err)
+	}
+	s.recordChainSnapshot(ctx, chainID, models.ConfigChangeUpdated)
 
-	// Create chain run
-	now := time.Now()
-	run := &models.ExecutionChainRun{
-		ID:           uuid.New(),
-		ChainID:      req.ChainID,
-		TenantID:     chain.TenantID,
-		Status:       models.ExecutionChainStatusRunning,
-		TriggerEvent: chain.TriggerEvent,
-		TriggerData:  triggerDataJSON,
-		CurrentStep:  0,
-		TotalSteps:   len(chain.Steps),
-		StartedAt:    &now,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+	queued, err := s.queuedChainRuns(ctx, chain)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.chainRepo.CreateChainRun(ctx, run); err != nil {
-		return nil, fmt.Errorf("failed to create chain run: %w", err)
+	response := &models.ChainStateResponse{
+		ChainID:     chainID,
+		IsActive:    false,
+		PausedAt:    &now,
+		PauseReason: reason,
+		QueuedRuns:  len(queued),
 	}
 
-	// Start executing the chain asynchronously
-	go s.executeChainSteps(context.Background(), run.ID, chain, req.TriggerData)
+	if req.SuspendQueuedRuns {
+		lastError := "suspended while the chain was paused"
+		if reason != nil {
+			lastError = fmt.Sprintf("%s: %s", lastError, *reason)
+		}
+		for _, run := range queued {
+			if err := s.chainRepo.UpdateChainRun(ctx, run.ID, map[string]interface{}{
+				"status":     models.ExecutionChainStatusPaused,
+				"last_error": lastError,
+				"updated_at": now,
+			}); err != nil {
+				s.logger.Error(ctx, "Failed to suspend queued chain run",
+					zap.String("run_id", run.ID.String()),
+					zap.Error(err))
+				continue
+			}
+			response.SuspendedRuns++
+		}
+		response.QueuedRuns -= response.SuspendedRuns
+	}
 
-	return &models.ExecuteChainResponse{
-		RunID:      run.ID,
-		ChainID:    req.ChainID,
-		Status:     string(run.Status),
-		TotalSteps: run.TotalSteps,
-		StartedAt:  *run.StartedAt,
-	}, nil
-}
+	s.logger.Info(ctx, "Execution chain paused",
+		zap.String("chain_id", chainID.String()),
+		zap.Int("queued_runs", response.QueuedRuns),
+		zap.Int("suspended_runs", response.SuspendedRuns))
 
-// ExecuteChainByEvent executes chains triggered by an event
-func (s *executionChainService) ExecuteChainByEvent(ctx context.Context, tenantID, event string, eventData map[string]interface{}) error {
-	logger.Info("Executing chains by event",
-		zap.String("tenant_id", tenantID),
-		zap.String("event", event))
+	return response, nil
+}
 
-	// Find chains that listen to this event
-	chains, err := s.chainRepo.GetChainsByTriggerEvent(ctx, tenantID, event)
+// ActivateChain ends a chain's pause so it is picked up for trigger events again
+// Runs that stayed queued during the pause start right away unless the tenant's chains are paused;
+// suspended runs stay paused and are resumed individually
+func (s *executionChainService) ActivateChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainStateResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
 	if err != nil {
-		return fmt.Errorf("failed to find chains for event: %w", err)
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	if chain.IsActive {
+		return nil, ErrInvalidChainState.Withf("chain is already active")
 	}
 
-	logger.Info("Found chains for event",
-		zap.String("event", event),
-		zap.Int("chains_count", len(chains)))
+	updates := map[string]interface{}{
+		"is_active":    true,
+		"paused_at":    nil,
+		"pause_reason": nil,
+		"updated_at":   s.clock.Now(),
+	}
+	s.resumeSchedule(chain, updates)
+	if err := s.chainRepo.UpdateChain(ctx, chainID, updates); err != nil {
+		return nil, fmt.Errorf("failed to activate chain: %w", err)
+	}
+	s.recordChainSnapshot(ctx, chainID, models.ConfigChangeUpdated)
 
-	// Execute each chain
-	for _, chain := range chains {
-		req := &models.ExecuteChainRequest{
-			ChainID:     chain.ID,
-			TriggerData: eventData,
-		}
+	queued, err := s.queuedChainRuns(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
 
-		if _, err := s.ExecuteChain(ctx, req); err != nil {
-			logger.Error("Failed to execute chain",
-				zap.String("chain_id", chain.ID.String()),
-				zap.Error(err))
-			// Continue with other chains even if one fails
+	response := &models.ChainStateResponse{
+		ChainID:    chainID,
+		IsActive:   true,
+		QueuedRuns: len(queued),
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, chain.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+	if !settings.ChainsPaused {
+		for _, run := range queued {
+			if err := s.startQueuedRun(ctx, run); err != nil {
+				if !runDeferred(err) {
+					s.logger.Error(ctx, "Failed to start queued chain run",
+						zap.String("run_id", run.ID.String()),
+						zap.Error(err))
+				}
+				continue
+			}
+			response.StartedRuns++
 		}
+		response.QueuedRuns -= response.StartedRuns
 	}
 
-	return nil
-}
+	s.logger.Info(ctx, "Execution chain activated",
+		zap.String("chain_id", chainID.String()),
+		zap.Int("started_runs", response.StartedRuns))
 
-// GetChainRun retrieves a chain run by ID
-func (s *executionChainService) GetChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
-	return s.chainRepo.GetChainRunByID(ctx, runID)
+	return response, nil
 }
 
-// ListChainRuns lists runs for a chain with pagination
-func (s *executionChainService) ListChainRuns(ctx context.Context, chainID uuid.UUID, page, limit int) (*models.ExecutionChainRunsResponse, error) {
-	offset := (page - 1) * limit
-	runs, total, err := s.chainRepo.GetChainRunsByChain(ctx, chainID, offset, limit)
+// queuedChainRuns retrieves the queued runs of a chain in admission order
+func (s *executionChainService) queuedChainRuns(ctx context.Context, chain *models.ExecutionChain) ([]*models.ExecutionChainRun, error) {
+	runs, err := s.chainRepo.GetChainRunsByTenantAndStatus(ctx, chain.TenantID, models.ExecutionChainStatusQueued)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to load queued runs: %w", err)
 	}
 
-	// Convert to response format
-	responseRuns := make([]models.ExecutionChainRun, len(runs))
-	for i, run := range runs {
-		responseRuns[i] = *run
+	var queued []*models.ExecutionChainRun
+	for _, run := range runs {
+		if run.ChainID == chain.ID {
+			queued = append(queued, run)
+		}
+	}
+	return queued, nil
+}
+
+// UpdateChainSteps replaces the steps of a chain with the steps of the request, in their new order
+// A request step carrying the ID of a current step whose definition is unchanged keeps that step, only moving it;
+// every other request step is created anew, and current steps that are not kept are retired instead of deleted,
+// so historical runs keep referencing the step definitions they executed
+// The new steps are recorded as the chain's next version; runs already started stay on their version
+// A request naming a lock version fails with ErrVersionConflict once the chain moved past it
+func (s *executionChainService) UpdateChainSteps(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateChainStepsRequest) (*models.UpdateChainStepsResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	if req.LockVersion != nil && *req.LockVersion != chain.LockVersion {
+		return nil, ErrVersionConflict.Withf("chain is at lock version %d, not %d", chain.LockVersion, *req.LockVersion)
 	}
 
-	return &models.ExecutionChainRunsResponse{
-		Runs:  responseRuns,
-		Total: total,
-		Page:  page,
-		Limit: limit,
-	}, nil
+	return s.replaceChainSteps(ctx, chain, req.Steps, nil)
 }
 
-// executeChainSteps executes the steps of a chain sequentially
-func (s *executionChainService) executeChainSteps(ctx context.Context, runID uuid.UUID, chain *models.ExecutionChain, triggerData map[string]interface{}) {
-	logger.Info("Starting chain execution",
-		zap.String("run_id", runID.String()),
-		zap.String("chain_id", chain.ID.String()),
-		zap.Int("total_steps", len(chain.Steps)))
+// RollbackChain restores the steps of an earlier chain version as a new version
+// Steps of the version that are still current and unchanged are kept, retired ones are recreated
+func (s *executionChainService) RollbackChain(ctx context.Context, tenantID string, chainID uuid.UUID, version int) (*models.UpdateChainStepsResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	if version == chain.Version {
+		return nil, ErrInvalidChainState.Withf("version %d is already the current version", version)
+	}
+
+	target, err := s.chainRepo.GetChainVersion(ctx, chainID, version)
+	if err != nil {
+		return nil, notFound(ErrChainVersionNotFound.Withf("chain version %d not found", version), err)
+	}
+	steps, err := s.versionSteps(ctx, target)
+	if err != nil {
+		return nil, err
+	}
 
+	current := make(map[uuid.UUID]bool, len(chain.Steps))
 	for _, step := range chain.Steps {
-		logger.Info("Executing step",
-			zap.String("run_id", runID.String()),
-			zap.Int("step_order", step.StepOrder),
-			zap.String("step_name", step.Name))
+		current[step.ID] = true
+	}
+	keys := stepKeysByID(steps)
 
-		// Update current step
-		if err := s.chainRepo.UpdateChainRunStep(ctx, runID, step.StepOrder); err != nil {
-			logger.Error("Failed to update current step", zap.Error(err))
+	reqSteps := make([]models.UpdateExecutionChainStep, len(steps))
+	for i, step := range steps {
+		reqStep, err := stepRequest(step, keys)
+		if err != nil {
+			return nil, fmt.Errorf("step %d of version %d: %w", i+1, version, err)
 		}
-
-		// Apply delay if specified
-		if step.DelaySeconds > 0 {
-			logger.Info("Applying step delay",
-				zap.Int("delay_seconds", step.DelaySeconds))
-			time.Sleep(time.Duration(step.DelaySeconds) * time.Second)
+		if current[step.ID] {
+			id := step.ID
+			reqSteps[i].ID = &id
 		}
+		reqSteps[i].CreateExecutionChainStep = reqStep
+	}
 
-		// Execute the step
-		success := s.executeStep(ctx, runID, &step, triggerData)
+	s.logger.Info(ctx, "Rolling back execution chain",
+		zap.String("chain_id", chainID.String()),
+		zap.Int("from_version", chain.Version),
+		zap.Int("to_version", version))
 
-		// Handle step result
-		if success {
-			logger.Info("Step executed successfully",
-				zap.String("step_name", step.Name))
-
-			if step.OnSuccessAction == "stop" {
-				logger.Info("Stopping chain execution due to success action")
-				break
-			} else if step.OnSuccessAction == "pause" {
-				logger.Info("Pausing chain execution")
-				s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusPaused)
-				return
-			}
-		} else {
-			logger.Error("Step execution failed",
-				zap.String("step_name", step.Name))
+	return s.replaceChainSteps(ctx, chain, reqSteps, &version)
+}
 
-			if step.OnFailureAction == "stop" {
-				logger.Info("Stopping chain execution due to failure")
-				s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusFailed)
-				return
-			} else if step.OnFailureAction == "continue" {
-				logger.Info("Continuing chain execution despite failure")
-				continue
+// stepRequest converts a step definition back into the request that creates it
+// Dependencies are expressed by the keys of the given steps
+func stepRequest(step models.ExecutionChainStep, keys map[uuid.UUID]string) (models.CreateExecutionChainStep, error) {
+	req := models.CreateExecutionChainStep{
+		WebhookID:       step.WebhookID,
+		Name:            step.Name,
+		Description:     step.Description,
+		Type:            step.Type,
+		Condition:       step.Condition,
+		ParallelGroup:   step.ParallelGroup,
+		Key:             step.Key,
+		Branches:        step.Branches,
+		BranchPath:      step.BranchPath,
+		Extract:         step.Extract,
+		OnSuccessAction: step.OnSuccessAction,
+		OnFailureAction: step.OnFailureAction,
+		MaxRetries:      step.MaxRetries,
+		DelaySeconds:    step.DelaySeconds,
+	}
+	if step.RequestParams != "" {
+		if err := json.Unmarshal([]byte(step.RequestParams), &req.RequestParams); err != nil {
+			return req, fmt.Errorf("invalid request params: %w", err)
+		}
+	}
+	if step.ResponseSchema != nil && *step.ResponseSchema != "" {
+		if err := json.Unmarshal([]byte(*step.ResponseSchema), &req.ResponseSchema); err != nil {
+			return req, fmt.Errorf("invalid response schema: %w", err)
+		}
+	}
+	if step.CompensationWebhookID != nil {
+		req.Compensation = &models.StepCompensation{WebhookID: *step.CompensationWebhookID}
+		if step.CompensationParams != nil && *step.CompensationParams != "" {
+			if err := json.Unmarshal([]byte(*step.CompensationParams), &req.Compensation.RequestParams); err != nil {
+				return req, fmt.Errorf("invalid compensation params: %w", err)
 			}
 		}
 	}
-
-	// Mark chain as completed
-	logger.Info("Chain execution completed", zap.String("run_id", runID.String()))
-	s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusCompleted)
+	for _, dependency := range step.DependsOn {
+		key, ok := keys[dependency]
+		if !ok {
+			return req, fmt.Errorf("dependency %s has no key", dependency)
+		}
+		req.DependsOn = append(req.DependsOn, key)
+	}
+	return req, nil
 }
 
-// executeStep executes a single step with retry logic
-func (s *executionChainService) executeStep(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, triggerData map[string]interface{}) bool {
-	// Create step run
-	now := time.Now()
-	stepRun := &models.ExecutionChainStepRun{
-		ID:        uuid.New(),
-		RunID:     runID,
-		StepID:    step.ID,
-		StepOrder: step.StepOrder,
-		Status:    models.WebhookStatusPending,
+// replaceChainSteps applies a step update to a chain and records the result as the chain's next version
+// rolledBackFrom is set when the steps restore an earlier version
+func (s *executionChainService) replaceChainSteps(ctx context.Context, chain *models.ExecutionChain, updates []models.UpdateExecutionChainStep, rolledBackFrom *int) (*models.UpdateChainStepsResponse, error) {
+	chainID := chain.ID
+
+	reqSteps := make([]models.CreateExecutionChainStep, len(updates))
+	for i, update := range updates {
+		reqSteps[i] = update.CreateExecutionChainStep
+	}
+	steps, err := s.buildChainSteps(ctx, chain.TenantID, reqSteps)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[uuid.UUID]models.ExecutionChainStep, len(chain.Steps))
+	for _, step := range chain.Steps {
+		current[step.ID] = step
+	}
+	currentKeys := stepKeysByID(chain.Steps)
+	newKeys := stepKeysByID(steps)
+
+	// Decide which current steps are kept; kept steps take the place of the built ones
+	kept := make(map[int]models.ExecutionChainStep)
+	replacedIDs := make(map[uuid.UUID]uuid.UUID)
+	claimed := make(map[uuid.UUID]bool)
+	for i, reqStep := range updates {
+		if reqStep.ID == nil {
+			continue
+		}
+		existing, ok := current[*reqStep.ID]
+		if !ok {
+			return nil, ErrInvalidChain.Withf("step %d: %s is not a current step of this chain", i+1, reqStep.ID)
+		}
+		if claimed[existing.ID] {
+			return nil, ErrInvalidChain.Withf("step %d: step %s is listed more than once", i+1, existing.ID)
+		}
+		claimed[existing.ID] = true
+
+		if sameStepDefinition(existing, currentKeys, steps[i], newKeys) {
+			kept[i] = existing
+			replacedIDs[steps[i].ID] = existing.ID
+		}
+	}
+
+	now := s.clock.Now()
+	var keptSteps, createdSteps []models.ExecutionChainStep
+	for i := range steps {
+		step := steps[i]
+		if existing, ok := kept[i]; ok {
+			step = existing
+			step.UpdatedAt = now
+		}
+		step.StepOrder = i + 1
+
+		// Point dependencies at the kept steps instead of the built ones they replace
+		step.DependsOn = nil
+		for _, dependency := range steps[i].DependsOn {
+			if id, ok := replacedIDs[dependency]; ok {
+				dependency = id
+			}
+			step.DependsOn = append(step.DependsOn, dependency)
+		}
+
+		if _, ok := kept[i]; ok {
+			keptSteps = append(keptSteps, step)
+		} else {
+			createdSteps = append(createdSteps, step)
+		}
+	}
+
+	var retired []uuid.UUID
+	for _, step := range chain.Steps {
+		if !isKeptStep(keptSteps, step.ID) {
+			retired = append(retired, step.ID)
+		}
+	}
+
+	// Chains created before versioning first get their current steps recorded as version 1,
+	// so the steps being replaced remain available for rollback
+	if chain.Version == 0 {
+		baseline := &models.ExecutionChainVersion{
+			ID:        uuid.New(),
+			ChainID:   chainID,
+			Version:   1,
+			Steps:     models.PinVersionSteps(chain.Steps),
+			CreatedAt: now,
+		}
+		if err := s.chainRepo.CreateChainVersion(ctx, baseline); err != nil {
+			return nil, fmt.Errorf("failed to record baseline chain version: %w", err)
+		}
+		chain.Version = baseline.Version
+	}
+
+	version := &models.ExecutionChainVersion{
+		ID:             uuid.New(),
+		ChainID:        chainID,
+		Version:        chain.Version + 1,
+		Steps:          models.PinVersionSteps(append(append([]models.ExecutionChainStep{}, keptSteps...), createdSteps...)),
+		RolledBackFrom: rolledBackFrom,
+		CreatedAt:      now,
+	}
+	if err := s.chainRepo.UpdateChainSteps(ctx, chainID, keptSteps, createdSteps, retired, version); err != nil {
+		s.logger.Error(ctx, "Failed to update chain steps", zap.Error(err))
+		return nil, fmt.Errorf("failed to update chain steps: %w", err)
+	}
+	s.recordChainSnapshot(ctx, chainID, models.ConfigChangeUpdated)
+
+	s.logger.Info(ctx, "Execution chain steps updated",
+		zap.String("chain_id", chainID.String()),
+		zap.Int("version", version.Version),
+		zap.Int("kept", len(keptSteps)),
+		zap.Int("added", len(createdSteps)),
+		zap.Int("retired", len(retired)))
+
+	updated, err := s.chainRepo.GetChainByID(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload chain: %w", err)
+	}
+
+	return &models.UpdateChainStepsResponse{
+		ChainID: chainID,
+		Steps:   updated.Steps,
+		Kept:    len(keptSteps),
+		Added:   len(createdSteps),
+		Retired: len(retired),
+		Version: version.Version,
+	}, nil
+}
+
+// GetChainVersions lists the versions of a chain with their resolved step definitions
+func (s *executionChainService) GetChainVersions(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainVersionsResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+
+	versions, err := s.chainRepo.GetChainVersions(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chain versions: %w", err)
+	}
+
+	response := &models.ChainVersionsResponse{
+		ChainID:        chainID,
+		CurrentVersion: chain.Version,
+		Versions:       make([]models.ChainVersionResponse, 0, len(versions)),
+	}
+	for i := range versions {
+		steps, err := s.versionSteps(ctx, &versions[i])
+		if err != nil {
+			return nil, err
+		}
+		response.Versions = append(response.Versions, models.ChainVersionResponse{
+			Version:        versions[i].Version,
+			RolledBackFrom: versions[i].RolledBackFrom,
+			CreatedAt:      versions[i].CreatedAt,
+			Steps:          steps,
+		})
+	}
+
+	return response, nil
+}
+
+// versionSteps resolves the step definitions of a chain version, with the order and dependencies of that version
+func (s *executionChainService) versionSteps(ctx context.Context, version *models.ExecutionChainVersion) ([]models.ExecutionChainStep, error) {
+	ids := make([]uuid.UUID, len(version.Steps))
+	for i, pinned := range version.Steps {
+		ids[i] = pinned.StepID
+	}
+
+	definitions, err := s.chainRepo.GetStepsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load steps of chain version %d: %w", version.Version, err)
+	}
+	byID := make(map[uuid.UUID]models.ExecutionChainStep, len(definitions))
+	for _, step := range definitions {
+		byID[step.ID] = step
+	}
+
+	steps := make([]models.ExecutionChainStep, 0, len(version.Steps))
+	for _, pinned := range version.Steps {
+		step, ok := byID[pinned.StepID]
+		if !ok {
+			return nil, fmt.Errorf("step %s of chain version %d no longer exists", pinned.StepID, version.Version)
+		}
+		step.StepOrder = pinned.StepOrder
+		step.DependsOn = pinned.DependsOn
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// runChain loads the chain of a run with the steps of the version the run is pinned to
+// Runs created before versioning, or pinned to the current version, use the chain's current steps
+func (s *executionChainService) runChain(ctx context.Context, run *models.ExecutionChainRun) (*models.ExecutionChain, error) {
+	chain, err := s.chainRepo.GetChainByID(ctx, run.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if run.ChainVersion == 0 || run.ChainVersion == chain.Version {
+		return chain, nil
+	}
+
+	version, err := s.chainRepo.GetChainVersion(ctx, chain.ID, run.ChainVersion)
+	if err != nil {
+		return nil, fmt.Errorf("chain version %d not found: %w", run.ChainVersion, err)
+	}
+	steps, err := s.versionSteps(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	chain.Steps = steps
+	return chain, nil
+}
+
+// stepKeysByID maps the IDs of steps that have a key to that key
+func stepKeysByID(steps []models.ExecutionChainStep) map[uuid.UUID]string {
+	keys := make(map[uuid.UUID]string, len(steps))
+	for _, step := range steps {
+		if step.Key != "" {
+			keys[step.ID] = step.Key
+		}
+	}
+	return keys
+}
+
+// isKeptStep reports whether the step with the given ID is among the kept steps
+func isKeptStep(kept []models.ExecutionChainStep, id uuid.UUID) bool {
+	for _, step := range kept {
+		if step.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sameStepDefinition reports whether two steps behave identically
+// Dependencies are compared by key, since a dependency replaced by an update gets a new ID
+func sameStepDefinition(a models.ExecutionChainStep, aKeys map[uuid.UUID]string, b models.ExecutionChainStep, bKeys map[uuid.UUID]string) bool {
+	if a.Name != b.Name || a.Description != b.Description || a.Type != b.Type ||
+		a.Condition != b.Condition || a.ParallelGroup != b.ParallelGroup || a.Key != b.Key || a.BranchPath != b.BranchPath ||
+		a.OnSuccessAction != b.OnSuccessAction || a.OnFailureAction != b.OnFailureAction ||
+		a.MaxRetries != b.MaxRetries || a.DelaySeconds != b.DelaySeconds {
+		return false
+	}
+
+	if (a.WebhookID == nil) != (b.WebhookID == nil) || (a.WebhookID != nil && *a.WebhookID != *b.WebhookID) {
+		return false
+	}
+	if len(a.Branches) != len(b.Branches) {
+		return false
+	}
+	for i := range a.Branches {
+		if a.Branches[i] != b.Branches[i] {
+			return false
+		}
+	}
+	if len(a.Extract) != len(b.Extract) {
+		return false
+	}
+	for name, path := range a.Extract {
+		if bPath, ok := b.Extract[name]; !ok || bPath != path {
+			return false
+		}
+	}
+
+	if !jsonEqual(decodeStoredJSON(a.RequestParams), decodeStoredJSON(b.RequestParams)) {
+		return false
+	}
+	var aSchema, bSchema string
+	if a.ResponseSchema != nil {
+		aSchema = *a.ResponseSchema
+	}
+	if b.ResponseSchema != nil {
+		bSchema = *b.ResponseSchema
+	}
+	if !jsonEqual(decodeStoredJSON(aSchema), decodeStoredJSON(bSchema)) {
+		return false
+	}
+
+	if (a.CompensationWebhookID == nil) != (b.CompensationWebhookID == nil) ||
+		(a.CompensationWebhookID != nil && *a.CompensationWebhookID != *b.CompensationWebhookID) {
+		return false
+	}
+	var aCompensation, bCompensation string
+	if a.CompensationParams != nil {
+		aCompensation = *a.CompensationParams
+	}
+	if b.CompensationParams != nil {
+		bCompensation = *b.CompensationParams
+	}
+	if !jsonEqual(decodeStoredJSON(aCompensation), decodeStoredJSON(bCompensation)) {
+		return false
+	}
+
+	if len(a.DependsOn) != len(b.DependsOn) {
+		return false
+	}
+	dependencies := make(map[string]int, len(a.DependsOn))
+	for _, id := range a.DependsOn {
+		dependencies[aKeys[id]]++
+	}
+	for _, id := range b.DependsOn {
+		if dependencies[bKeys[id]] == 0 {
+			return false
+		}
+		dependencies[bKeys[id]]--
+	}
+	return true
+}
+
+// decodeStoredJSON decodes a JSON column for comparison; empty or invalid JSON decodes to nil
+// The database may reformat stored JSON, so columns are compared by value rather than by text
+func decodeStoredJSON(raw string) interface{} {
+	var value interface{}
+	if raw == "" || json.Unmarshal([]byte(raw), &value) != nil {
+		return nil
+	}
+	return value
+}
+
+// DeleteChain soft deletes a chain, recording its last configuration in the chain's history
+// Deleted chains are no longer triggered, resumed or retried, and can be restored until they are purged
+// With a tenant given, the chain is deleted only if it belongs to that tenant
+func (s *executionChainService) DeleteChain(ctx context.Context, tenantID string, chainID uuid.UUID) error {
+	ctx = repository.WithTenantScope(ctx, tenantID)
+	chain, err := s.chainRepo.GetChainByID(ctx, chainID)
+	if err != nil {
+		return notFound(ErrChainNotFound, err)
+	}
+
+	if err := s.chainRepo.DeleteChain(ctx, chainID); err != nil {
+		return err
+	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceChain, chain.ID, chain.TenantID, models.ConfigChangeDeleted, chain)
+	return nil
+}
+
+// RestoreChain restores a soft deleted chain with the steps it had when it was deleted
+// A restored schedule resumes at its next due time; triggers missed while deleted are not replayed
+// With a tenant given, the chain is restored only if it belongs to that tenant
+func (s *executionChainService) RestoreChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ExecutionChain, error) {
+	if err := s.chainRepo.RestoreChain(repository.WithTenantScope(ctx, tenantID), chainID); err != nil {
+		return nil, notFound(ErrChainNotFound.Withf("deleted chain not found"), err)
+	}
+
+	chain, err := s.chainRepo.GetChainByID(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load restored chain: %w", err)
+	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceChain, chain.ID, chain.TenantID, models.ConfigChangeRestored, chain)
+
+	s.logger.Info(ctx, "Execution chain restored",
+		zap.String("chain_id", chainID.String()),
+		zap.String("tenant_id", chain.TenantID))
+	return chain, nil
+}
+
+// GetChainHistory retrieves the versioned configuration history of a chain, including deleted chains
+// With a tenant given, a chain without history of that tenant is not found
+func (s *executionChainService) GetChainHistory(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ConfigHistoryResponse, error) {
+	history, err := loadConfigHistory(ctx, s.historyRepo, tenantID, models.ConfigResourceChain, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if tenantID != "" && len(history.Versions) == 0 {
+		return nil, ErrChainNotFound
+	}
+	return history, nil
+}
+
+// recordChainSnapshot records the current configuration of a chain, with its steps, in the chain's history
+func (s *executionChainService) recordChainSnapshot(ctx context.Context, chainID uuid.UUID, change models.ConfigChange) {
+	chain, err := s.chainRepo.GetChainByID(ctx, chainID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load chain for configuration snapshot",
+			zap.String("chain_id", chainID.String()),
+			zap.Error(err))
+		return
+	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceChain, chain.ID, chain.TenantID, change, chain)
+}
+
+// ExecuteChain manually executes a chain, if it belongs to the tenant when one is given
+func (s *executionChainService) ExecuteChain(ctx context.Context, tenantID string, req *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error) {
+	s.logger.Info(ctx, "Executing chain manually",
+		zap.String("chain_id", req.ChainID.String()))
+
+	// Get the chain
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), req.ChainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+
+	if !chain.IsActive && chain.PausedAt != nil {
+		return nil, errChainPaused
+	}
+	if !chain.IsActive {
+		return nil, ErrInvalidChainState.Withf("chain is not active")
+	}
+	if req.CallbackURL != "" {
+		if err := validateCallbackURL(req.CallbackURL); err != nil {
+			return nil, err
+		}
+	}
+	if !req.Priority.IsValid() {
+		return nil, ErrInvalidRun.Withf("unknown priority %q, use high, normal or low", req.Priority)
+	}
+
+	runOptions, source, err := s.prepareRunOptions(ctx, chain, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	triggerData := req.TriggerData
+	if triggerData == nil && source != nil && source.TriggerData != "" {
+		// Reprocessing reuses the source run's trigger data unless the request replaces it
+		if err := json.Unmarshal([]byte(revealPayload(ctx, s.logger, s.tenantRepo, source.TenantID, source.TriggerData)), &triggerData); err != nil {
+			return nil, ErrInvalidRun.Withf("invalid trigger data of source run")
+		}
+	}
+
+	runReq := runRequest{callbackURL: req.CallbackURL, options: runOptions, priority: req.Priority.Level()}
+	if runOptions != nil {
+		runReq.seed = seedStepRuns(source, runOptions)
+	}
+
+	run, err := s.createChainRun(ctx, chain, chain.TriggerEvent, triggerData, false, runReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExecuteChainResponse{
+		RunID:          run.ID,
+		ChainID:        req.ChainID,
+		Status:         string(run.Status),
+		TotalSteps:     run.TotalSteps,
+		StartedAt:      run.StartedAt,
+		QueuePosition:  s.queuePosition(ctx, run),
+		CallbackSecret: run.CallbackSecret,
+	}, nil
+}
+
+// createChainRun records a run of a chain pinned to its current version and starts it asynchronously
+// The run is queued instead of started when queue is set or the tenant's chain executions are paused;
+// runs subject to a concurrency limit are queued and started right away only when next in line with a free slot
+func (s *executionChainService) createChainRun(ctx context.Context, chain *models.ExecutionChain, triggerEvent string, triggerData map[string]interface{}, queue bool, req runRequest) (*models.ExecutionChainRun, error) {
+	// Create trigger data JSON
+	var triggerDataJSON string
+	if triggerData != nil {
+		triggerBytes, err := json.Marshal(triggerData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trigger data: %w", err)
+		}
+		triggerDataJSON = string(triggerBytes)
+	}
+
+	// Suspended tenants cannot run chains
+	if _, err := activeTenant(ctx, s.tenantRepo, chain.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Runs triggered while the tenant's chains are paused are queued instead of started
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, chain.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	// Create chain run
+	now := s.clock.Now()
+	run := &models.ExecutionChainRun{
+		ID:           uuid.New(),
+		ChainID:      chain.ID,
+		TenantID:     chain.TenantID,
+		Status:       models.ExecutionChainStatusRunning,
+		TriggerEvent: triggerEvent,
+		ChainVersion: chain.Version,
+		TriggerData:  newPayloadProtection(s.logger, s.tenantRepo, settings).protect(ctx, triggerDataJSON, true),
+		CurrentStep:  0,
+		TotalSteps:   len(chain.Steps),
+		StartedAt:    &now,
+		WorkerID:     s.instanceID,
+		HeartbeatAt:  &now,
+		Priority:     req.priority,
+		Options:      req.options,
+		RetryOfRunID: req.retryOf,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if req.options != nil {
+		run.CurrentStep = req.options.StartAtStep
+	}
+
+	if callbackURL := req.callbackURL; callbackURL != "" {
+		run.CallbackURL = &callbackURL
+		run.CallbackSecret = req.callbackSecret
+		if run.CallbackSecret == "" {
+			callbackSecurity, err := s.security.GenerateWebhookSecurity(false, chain.TenantID, run.ID.String(), "execution-chain")
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate callback secret: %w", err)
+			}
+			run.CallbackSecret = callbackSecurity.SecretToken
+		}
+	}
+
+	limited := s.runLimited(settings)
+	if queue || settings.ChainsPaused || limited {
+		run.Status = models.ExecutionChainStatusQueued
+		run.StartedAt = nil
+	}
+
+	if err := s.chainRepo.CreateChainRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to create chain run: %w", err)
+	}
+	s.priorityMetrics.countRun(models.PriorityOf(run.Priority))
+	if err := s.copySeedStepRuns(ctx, run.ID, req.seed); err != nil {
+		s.chainRepo.UpdateChainRun(ctx, run.ID, map[string]interface{}{
+			"status":       models.ExecutionChainStatusFailed,
+			"last_error":   err.Error(),
+			"completed_at": now,
+			"updated_at":   now,
+		})
+		return nil, err
+	}
+
+	if limited && !queue && !settings.ChainsPaused && s.admitQueuedRuns(ctx, chain.TenantID)[run.ID] {
+		run.Status = models.ExecutionChainStatusRunning
+		run.StartedAt = &now
+	} else if run.Status == models.ExecutionChainStatusQueued {
+		s.logger.Info(ctx, "Chain run queued",
+			zap.String("run_id", run.ID.String()),
+			zap.String("chain_id", chain.ID.String()),
+			zap.String("tenant_id", chain.TenantID),
+			zap.Bool("tenant_paused", settings.ChainsPaused),
+			zap.Bool("run_limited", limited))
+	} else {
+		// Start executing the chain asynchronously
+		s.startRun(ctx, run.ID, chain, triggerData, max(run.CurrentStep, 1), run.Options)
+	}
+
+	return run, nil
+}
+
+// ExecuteChainByEvent executes chains triggered by an event
+func (s *executionChainService) ExecuteChainByEvent(ctx context.Context, tenantID, event string, eventData map[string]interface{}) error {
+	s.logger.Info(ctx, "Executing chains by event",
+		zap.String("tenant_id", tenantID),
+		zap.String("event", event))
+
+	// Find chains that listen to this event
+	chains, err := s.chainRepo.GetChainsByTriggerEvent(ctx, tenantID, event)
+	if err != nil {
+		return fmt.Errorf("failed to find chains for event: %w", err)
+	}
+
+	s.logger.Info(ctx, "Found chains for event",
+		zap.String("event", event),
+		zap.Int("chains_count", len(chains)))
+
+	// Execute each chain
+	for _, chain := range chains {
+		req := &models.ExecuteChainRequest{
+			ChainID:     chain.ID,
+			TriggerData: eventData,
+			Priority:    eventPriority(ctx),
+		}
+
+		if _, err := s.ExecuteChain(ctx, tenantID, req); err != nil {
+			s.logger.Error(ctx, "Failed to execute chain",
+				zap.String("chain_id", chain.ID.String()),
+				zap.Error(err))
+			// Continue with other chains even if one fails
+		}
+	}
+
+	return nil
+}
+
+// GetChainRun retrieves a chain run by ID, if it belongs to the tenant when one is given
+func (s *executionChainService) GetChainRun(ctx context.Context, tenantID string, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	run, err := s.chainRepo.GetChainRunByID(repository.WithTenantScope(ctx, tenantID), runID)
+	if err != nil {
+		return nil, notFound(ErrRunNotFound, err)
+	}
+	run.QueuePosition = s.queuePosition(ctx, run)
+	run.Resources = runResources(run)
+	return run, nil
+}
+
+// ListChainRuns lists the filtered runs of a chain with pagination, sorted by creation time or status
+// With a tenant given, only runs of that tenant are listed
+func (s *executionChainService) ListChainRuns(ctx context.Context, tenantID string, chainID uuid.UUID, filter models.ListFilter, page models.ListPage) (*models.ExecutionChainRunsResponse, error) {
+	repoPage, err := listPage(&page, models.ListSortStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, total, err := s.chainRepo.GetChainRunsByChain(repository.WithTenantScope(ctx, tenantID), chainID, filter, repoPage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response format
+	responseRuns := make([]models.ExecutionChainRun, len(runs))
+	for i, run := range runs {
+		responseRuns[i] = *run
+	}
+
+	return &models.ExecutionChainRunsResponse{
+		Runs:  responseRuns,
+		Total: total,
+		Page:  page.Page,
+		Limit: page.Limit,
+		NextCursor: encodeCursor(repoPage, repoPage.NextCursor(len(runs), func() repository.Cursor {
+			return repository.ChainRunCursor(runs[len(runs)-1], repoPage.Sort)
+		})),
+	}, nil
+}
+
+// GetChainStats aggregates the runs of a chain created within a window
+// Steps are named after the chain's current steps; a failing step removed since has no name
+func (s *executionChainService) GetChainStats(ctx context.Context, tenantID string, chainID uuid.UUID, window models.StatsWindow) (*models.ChainStatsResponse, error) {
+	chain, err := s.chainRepo.GetChainByID(repository.WithTenantScope(ctx, tenantID), chainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+
+	duration, ok := window.Duration()
+	if !ok {
+		return nil, ErrInvalidStatsWindow.Withf("unknown stats window %q", window)
+	}
+	var since *time.Time
+	if duration > 0 {
+		start := s.clock.Now().Add(-duration)
+		since = &start
+	}
+
+	stats, err := s.chainRepo.GetChainRunStats(ctx, chainID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate chain runs: %w", err)
+	}
+	failing, err := s.chainRepo.GetMostFailingChainStep(ctx, chainID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate step failures: %w", err)
+	}
+	if failing != nil {
+		for _, step := range chain.Steps {
+			if step.StepOrder == failing.StepOrder {
+				failing.Name = step.Name
+			}
+		}
+	}
+
+	response := &models.ChainStatsResponse{
+		ChainID:         chainID,
+		Window:          window,
+		Since:           since,
+		ChainRunStats:   *stats,
+		MostFailingStep: failing,
+	}
+	if decided := stats.CompletedRuns + stats.FailedRuns; decided > 0 {
+		response.SuccessRate = float64(stats.CompletedRuns) / float64(decided)
+	}
+	return response, nil
+}
+
+// PauseTenantChains stops new chain runs from starting for a tenant
+// Runs already in flight keep executing; runs triggered from now on are queued
+func (s *executionChainService) PauseTenantChains(ctx context.Context, tenantID string) (*models.TenantChainControlResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	if !settings.ChainsPaused {
+		now := s.clock.Now()
+		settings.ChainsPaused = true
+		settings.ChainsPausedAt = &now
+		if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+			return nil, fmt.Errorf("failed to pause tenant chains: %w", err)
+		}
+	}
+
+	queued, err := s.chainRepo.GetChainRunsByTenantAndStatus(ctx, tenantID, models.ExecutionChainStatusQueued)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count queued runs: %w", err)
+	}
+
+	s.logger.Info(ctx, "Chain executions paused for tenant",
+		zap.String("tenant_id", tenantID),
+		zap.Int("queued_runs", len(queued)))
+
+	return &models.TenantChainControlResponse{
+		TenantID:       tenantID,
+		ChainsPaused:   true,
+		ChainsPausedAt: settings.ChainsPausedAt,
+		QueuedRuns:     len(queued),
+	}, nil
+}
+
+// ResumeTenantChains lifts a tenant-wide pause and starts every run queued while it was active
+// Queued runs are started in the order they were triggered
+func (s *executionChainService) ResumeTenantChains(ctx context.Context, tenantID string) (*models.TenantChainControlResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	if settings.ChainsPaused {
+		settings.ChainsPaused = false
+		settings.ChainsPausedAt = nil
+		if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+			return nil, fmt.Errorf("failed to resume tenant chains: %w", err)
+		}
+	}
+
+	queued, err := s.chainRepo.GetChainRunsByTenantAndStatus(ctx, tenantID, models.ExecutionChainStatusQueued)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load queued runs: %w", err)
+	}
+
+	resumed := 0
+	for _, run := range queued {
+		if err := s.startQueuedRun(ctx, run); err != nil {
+			if runDeferred(err) {
+				continue
+			}
+			s.logger.Error(ctx, "Failed to start queued chain run",
+				zap.String("run_id", run.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		resumed++
+	}
+
+	s.logger.Info(ctx, "Chain executions resumed for tenant",
+		zap.String("tenant_id", tenantID),
+		zap.Int("resumed_runs", resumed))
+
+	return &models.TenantChainControlResponse{
+		TenantID:     tenantID,
+		ChainsPaused: false,
+		QueuedRuns:   len(queued) - resumed,
+		ResumedRuns:  resumed,
+	}, nil
+}
+
+// startQueuedRun moves a queued run to running and executes it asynchronously
+// Runs whose chain was deleted or deactivated while queued are marked as failed;
+// runs of a paused chain stay queued and errChainPaused is returned, as do runs over a
+// concurrency limit with errRunLimitReached or errGlobalRunLimitReached
+// The run is claimed with a conditional update, so a run another instance started returns errRunClaimed
+func (s *executionChainService) startQueuedRun(ctx context.Context, run *models.ExecutionChainRun) error {
+	chain, err := s.runChain(ctx, run)
+	if err == nil && !chain.IsActive && chain.PausedAt != nil {
+		return errChainPaused
+	}
+	if err == nil && !chain.IsActive {
+		err = fmt.Errorf("chain is not active")
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("queued run could not be started: %v", err)
+		failed, _ := s.chainRepo.UpdateChainRunIfStatus(ctx, run.ID, models.ExecutionChainStatusQueued, map[string]interface{}{
+			"status":       models.ExecutionChainStatusFailed,
+			"last_error":   errMsg,
+			"completed_at": s.clock.Now(),
+			"updated_at":   s.clock.Now(),
+		})
+		if failed {
+			go s.notifyRunFinished(context.WithoutCancel(ctx), run.ID)
+		}
+		return err
+	}
+
+	var triggerData map[string]interface{}
+	if run.TriggerData != "" {
+		if err := json.Unmarshal([]byte(revealPayload(ctx, s.logger, s.tenantRepo, run.TenantID, run.TriggerData)), &triggerData); err != nil {
+			return fmt.Errorf("invalid trigger data: %w", err)
+		}
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, run.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	admit := func() error {
+		if err := s.checkRunLimits(ctx, settings); err != nil {
+			return err
+		}
+
+		// Runs resumed while the tenant was paused already executed some steps
+		fromStep, err := s.resumeStepOrder(ctx, run)
+		if err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+		updates := map[string]interface{}{
+			"status":       models.ExecutionChainStatusRunning,
+			"total_steps":  len(chain.Steps),
+			"worker_id":    s.instanceID,
+			"heartbeat_at": now,
+			"updated_at":   now,
+		}
+		if run.StartedAt == nil {
+			updates["started_at"] = now
+		}
+		claimed, err := s.chainRepo.UpdateChainRunIfStatus(ctx, run.ID, models.ExecutionChainStatusQueued, updates)
+		if err != nil {
+			return fmt.Errorf("failed to update chain run: %w", err)
+		}
+		if !claimed {
+			return errRunClaimed
+		}
+		if run.StartedAt == nil {
+			s.priorityMetrics.observeRunQueuing(models.PriorityOf(run.Priority), now.Sub(run.CreatedAt))
+		}
+
+		s.startRun(ctx, run.ID, chain, triggerData, fromStep, run.Options)
+		return nil
+	}
+
+	s.admission.Lock()
+	defer s.admission.Unlock()
+	if !s.runLimited(settings) {
+		return admit()
+	}
+	// Runs are counted and started under a lock shared with the other instances admitting the same queue
+	return withInstanceLock(ctx, s.locks, admissionLockPrefix+s.admissionScope(run.TenantID), admit)
+}
+
+// ResumeChainRun continues a paused or interrupted run from the first step that has not succeeded
+// The run keeps its stored trigger data; steps that already succeeded are not executed again
+func (s *executionChainService) ResumeChainRun(ctx context.Context, runID uuid.UUID) (*models.ChainRunControlResponse, error) {
+	run, err := s.chainRepo.GetChainRunByID(ctx, runID)
+	if err != nil {
+		return nil, notFound(ErrRunNotFound, err)
+	}
+
+	if run.Status != models.ExecutionChainStatusPaused && run.Status != models.ExecutionChainStatusInterrupted {
+		return nil, ErrInvalidRunState.Withf("run is %s, only paused or interrupted runs can be resumed", run.Status)
+	}
+	if s.workers.Running(run.ID) {
+		return nil, ErrInvalidRunState.Withf("run is already executing")
+	}
+
+	return s.resumeRun(ctx, run)
+}
+
+// resumeRun restarts a stopped run from the first step that has not succeeded, or queues it when
+// the tenant's chain executions are paused or a concurrency limit is reached
+// The run is only moved while it still has the status it was read with, so concurrent resumes
+// through different instances start it once
+func (s *executionChainService) resumeRun(ctx context.Context, run *models.ExecutionChainRun) (*models.ChainRunControlResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, run.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	// Respect a tenant-wide pause and the concurrency limits: the run is released together with the other queued runs
+	if settings.ChainsPaused || s.runLimited(settings) {
+		queued, err := s.chainRepo.UpdateChainRunIfStatus(ctx, run.ID, run.Status, map[string]interface{}{
+			"status":     models.ExecutionChainStatusQueued,
+			"updated_at": s.clock.Now(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to queue chain run: %w", err)
+		}
+		if !queued {
+			return nil, ErrInvalidRunState.Withf("run is no longer %s, it was resumed or cancelled meanwhile", run.Status)
+		}
+
+		if !settings.ChainsPaused && s.admitQueuedRuns(ctx, run.TenantID)[run.ID] {
+			return chainRunControlResponse(run, models.ExecutionChainStatusRunning), nil
+		}
+
+		s.logger.Info(ctx, "Resumed run queued",
+			zap.String("run_id", run.ID.String()),
+			zap.String("tenant_id", run.TenantID),
+			zap.Bool("tenant_paused", settings.ChainsPaused))
+
+		return chainRunControlResponse(run, models.ExecutionChainStatusQueued), nil
+	}
+
+	chain, err := s.runChain(ctx, run)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	if !chain.IsActive {
+		return nil, ErrInvalidChainState.Withf("chain is not active")
+	}
+
+	var triggerData map[string]interface{}
+	if run.TriggerData != "" {
+		if err := json.Unmarshal([]byte(revealPayload(ctx, s.logger, s.tenantRepo, run.TenantID, run.TriggerData)), &triggerData); err != nil {
+			return nil, fmt.Errorf("invalid trigger data: %w", err)
+		}
+	}
+
+	fromStep, err := s.resumeStepOrder(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+
+	resumed, err := s.chainRepo.UpdateChainRunIfStatus(ctx, run.ID, run.Status, map[string]interface{}{
+		"status":       models.ExecutionChainStatusRunning,
+		"last_error":   nil,
+		"worker_id":    s.instanceID,
+		"heartbeat_at": s.clock.Now(),
+		"updated_at":   s.clock.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chain run: %w", err)
+	}
+	if !resumed {
+		return nil, ErrInvalidRunState.Withf("run is no longer %s, it was resumed or cancelled meanwhile", run.Status)
+	}
+
+	s.logger.Info(ctx, "Resuming chain run",
+		zap.String("run_id", run.ID.String()),
+		zap.String("previous_status", string(run.Status)),
+		zap.Int("from_step", fromStep))
+
+	s.startRun(ctx, run.ID, chain, triggerData, fromStep, run.Options)
+
+	run.CurrentStep = fromStep
+	return chainRunControlResponse(run, models.ExecutionChainStatusRunning), nil
+}
+
+// CancelChainRun cancels a run so it is never resumed; its remaining steps are marked skipped
+// Running runs are cancelled cooperatively: the executing goroutine stops at the next cancellation
+// point, aborting an in-flight step request, and records the cancellation itself
+func (s *executionChainService) CancelChainRun(ctx context.Context, runID uuid.UUID, reason string) (*models.ChainRunControlResponse, error) {
+	if reason == "" {
+		reason = defaultCancelReason
+	}
+
+	run, err := s.chainRepo.GetChainRunByID(ctx, runID)
+	if err != nil {
+		return nil, notFound(ErrRunNotFound, err)
+	}
+
+	switch run.Status {
+	case models.ExecutionChainStatusRunning:
+		if s.workers.Cancel(ctx, run.ID, &runCancelledError{reason: reason}) {
+			// Reload so the response reflects what the stopped goroutine recorded
+			if run, err = s.chainRepo.GetChainRunByID(ctx, runID); err != nil {
+				return nil, fmt.Errorf("failed to reload chain run: %w", err)
+			}
+			return chainRunControlResponse(run, run.Status), nil
+		}
+
+		// No goroutine executes the run: it either just finished or was left running by a crash
+		if run, err = s.chainRepo.GetChainRunByID(ctx, runID); err != nil {
+			return nil, fmt.Errorf("failed to reload chain run: %w", err)
+		}
+		if run.Status != models.ExecutionChainStatusRunning {
+			return nil, ErrInvalidRunState.Withf("run is %s and cannot be cancelled", run.Status)
+		}
+	case models.ExecutionChainStatusPaused, models.ExecutionChainStatusQueued,
+		models.ExecutionChainStatusInterrupted, models.ExecutionChainStatusPending,
+		models.ExecutionChainStatusAwaitingApproval:
+		if s.workers.Running(run.ID) {
+			return nil, ErrInvalidRunState.Withf("run is being resumed, retry the cancellation")
+		}
+	default:
+		return nil, ErrInvalidRunState.Withf("run is %s and cannot be cancelled", run.Status)
+	}
+
+	var remaining []models.ExecutionChainStep
+	if chain, err := s.runChain(ctx, run); err == nil {
+		fromStep, err := s.resumeStepOrder(ctx, run)
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range sortedSteps(chain) {
+			if step.StepOrder >= fromStep {
+				remaining = append(remaining, step)
+			}
+		}
+	}
+
+	if err := s.markRunCancelled(ctx, run.ID, run.Status, reason, remaining); err != nil {
+		return nil, fmt.Errorf("failed to cancel chain run: %w", err)
+	}
+	go s.notifyRunFinished(context.WithoutCancel(ctx), run.ID)
+
+	return chainRunControlResponse(run, models.ExecutionChainStatusCancelled), nil
+}
+
+// resumeStepOrder returns the order of the first step at or after the run's current step
+// that has not succeeded yet; a step paused after succeeding is therefore not repeated
+func (s *executionChainService) resumeStepOrder(ctx context.Context, run *models.ExecutionChainRun) (int, error) {
+	stepRuns, err := s.chainRepo.GetStepRunsByRun(ctx, run.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load step runs: %w", err)
+	}
+
+	succeeded := make(map[int]bool)
+	for _, stepRun := range stepRuns {
+		if stepRun.Status == models.WebhookStatusSent {
+			succeeded[stepRun.StepOrder] = true
+		}
+	}
+
+	fromStep := run.CurrentStep
+	if fromStep < 1 {
+		fromStep = 1
+	}
+	for succeeded[fromStep] {
+		fromStep++
+	}
+	return fromStep, nil
+}
+
+// chainRunControlResponse builds the response for a run control operation
+func chainRunControlResponse(run *models.ExecutionChainRun, status models.ExecutionChainStatus) *models.ChainRunControlResponse {
+	return &models.ChainRunControlResponse{
+		RunID:       run.ID,
+		ChainID:     run.ChainID,
+		Status:      string(status),
+		CurrentStep: run.CurrentStep,
+		TotalSteps:  run.TotalSteps,
+	}
+}
+
+// startRun executes a run from the given step order in a goroutine tracked by the worker registry
+// The run waits for a free worker slot first; once it stops, the next queued run of a chain with
+// the queue overlap policy is started and queued runs waiting for a concurrency slot are admitted
+// Runs started while the server is shutting down are marked interrupted so they can be resumed later
+// The run's requests and logs carry the request ID of ctx, but the run outlives ctx
+func (s *executionChainService) startRun(ctx context.Context, runID uuid.UUID, chain *models.ExecutionChain, triggerData map[string]interface{}, fromStep int, options *models.RunOptions) {
+	started := s.workers.Go(ctx, runID, func(ctx context.Context) {
+		s.executeChainSteps(ctx, runID, chain, triggerData, fromStep, options)
+		s.startNextQueuedRun(ctx, chain)
+		s.admitAfterRun(ctx, chain.TenantID)
+	}, func(ctx context.Context) {
+		// Cancelled or drained while waiting for a slot, before any step ran
+		var remaining []models.ExecutionChainStep
+		for _, step := range sortedSteps(chain) {
+			if step.StepOrder >= fromStep {
+				remaining = append(remaining, step)
+			}
+		}
+		s.stopRun(ctx, runID, remaining)
+	})
+	if !started {
+		s.markRunInterrupted(ctx, runID, "server is shutting down")
+	}
+}
+
+// Shutdown waits for in-flight chain runs to finish until ctx expires
+// Runs that do not finish in time are cancelled and marked interrupted
+func (s *executionChainService) Shutdown(ctx context.Context) error {
+	s.logger.Info(ctx, "Draining in-flight chain runs",
+		zap.Int("in_flight", s.workers.InFlight()))
+
+	stuck := s.workers.Drain(ctx, shutdownGracePeriod)
+	for _, runID := range stuck {
+		s.markRunInterrupted(ctx, runID, "run did not stop before shutdown deadline")
+	}
+
+	if len(stuck) > 0 {
+		return fmt.Errorf("%d chain runs did not stop before shutdown deadline", len(stuck))
+	}
+
+	s.logger.Info(ctx, "Chain runs drained")
+	return nil
+}
+
+// markRunInterrupted records that a run was stopped by a shutdown before it finished
+// The run is updated even when ctx is already cancelled
+func (s *executionChainService) markRunInterrupted(ctx context.Context, runID uuid.UUID, reason string) {
+	errMsg := fmt.Sprintf("interrupted: %s", reason)
+	if err := s.chainRepo.UpdateChainRun(context.WithoutCancel(ctx), runID, map[string]interface{}{
+		"status":     models.ExecutionChainStatusInterrupted,
+		"last_error": errMsg,
+		"updated_at": s.clock.Now(),
+	}); err != nil {
+		s.logger.Error(ctx, "Failed to mark chain run interrupted",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+		return
+	}
+
+	s.logger.Warn(ctx, "Chain run interrupted",
+		zap.String("run_id", runID.String()),
+		zap.String("reason", reason))
+}
+
+// markRunCancelled records the cancellation of a run still in the status it was read in, and marks the steps it
+// will not execute as skipped
+// Returns ErrInvalidRunState when the run left that status meanwhile, such as when it was resumed or completed
+func (s *executionChainService) markRunCancelled(ctx context.Context, runID uuid.UUID, from models.ExecutionChainStatus, reason string, remaining []models.ExecutionChainStep) error {
+	now := s.clock.Now()
+	cancelled, err := s.chainRepo.UpdateChainRunIfStatus(ctx, runID, from, map[string]interface{}{
+		"status":        models.ExecutionChainStatusCancelled,
+		"cancel_reason": reason,
+		"cancelled_at":  now,
+		"completed_at":  now,
+		"updated_at":    now,
+	})
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrInvalidRunState.Withf("run is no longer %s, it was resumed, finished or cancelled meanwhile", from)
+	}
+
+	skipReason := fmt.Sprintf("skipped: run cancelled: %s", reason)
+	for _, step := range remaining {
+		if err := s.chainRepo.CreateStepRun(ctx, &models.ExecutionChainStepRun{
+			ID:          uuid.New(),
+			RunID:       runID,
+			StepID:      step.ID,
+			StepOrder:   step.StepOrder,
+			Status:      models.WebhookStatusSkipped,
+			LastError:   &skipReason,
+			CompletedAt: &now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			s.logger.Error(ctx, "Failed to record skipped step",
+				zap.String("run_id", runID.String()),
+				zap.Int("step_order", step.StepOrder),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info(ctx, "Chain run cancelled",
+		zap.String("run_id", runID.String()),
+		zap.String("reason", reason),
+		zap.Int("skipped_steps", len(remaining)))
+	return nil
+}
+
+// stopRun records why a run's goroutine stopped early: an API cancellation or a server shutdown
+// remaining holds the steps that were not executed
+func (s *executionChainService) stopRun(ctx context.Context, runID uuid.UUID, remaining []models.ExecutionChainStep) {
+	var cancelled *runCancelledError
+	if errors.As(context.Cause(ctx), &cancelled) {
+		if err := s.markRunCancelled(context.WithoutCancel(ctx), runID, models.ExecutionChainStatusRunning, cancelled.reason, remaining); err != nil {
+			s.logger.Error(ctx, "Failed to mark chain run cancelled",
+				zap.String("run_id", runID.String()),
+				zap.Error(err))
+			return
+		}
+		// The cancellation request waits for this goroutine to stop, not for the run's callbacks
+		go s.notifyRunFinished(context.WithoutCancel(ctx), runID)
+		return
+	}
+
+	s.markRunInterrupted(ctx, runID, "server shutdown")
+}
+
+// sortedSteps returns a copy of the chain steps in execution order
+func sortedSteps(chain *models.ExecutionChain) []models.ExecutionChainStep {
+	steps := make([]models.ExecutionChainStep, len(chain.Steps))
+	copy(steps, chain.Steps)
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].StepOrder < steps[j].StepOrder
+	})
+	return steps
+}
+
+// executeChainSteps executes the steps of a chain sequentially, skipping steps ordered before fromStep
+// and the steps the run's options exclude
+// Chains whose steps declare dependencies are handed to executeStepGraph instead
+// Stops when ctx is cancelled and records whether the run was cancelled or interrupted
+func (s *executionChainService) executeChainSteps(ctx context.Context, runID uuid.UUID, chain *models.ExecutionChain, triggerData map[string]interface{}, fromStep int, options *models.RunOptions) {
+	s.logger.Info(ctx, "Starting chain execution",
+		zap.String("run_id", runID.String()),
+		zap.String("chain_id", chain.ID.String()),
+		zap.Int("total_steps", len(chain.Steps)),
+		zap.Int("from_step", fromStep))
+
+	// Seed the run context with the responses of steps a resumed run already executed
+	stepRuns, err := s.chainRepo.GetStepRunsByRun(ctx, runID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load earlier step results",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+	}
+	rc := newRunContext(triggerData, stepRuns)
+	rc.applyRunOptions(options)
+	rc.protection = tenantPayloadProtection(ctx, s.logger, s.tenantRepo, chain.TenantID).forSubject(ctx, triggerData)
+
+	// Variables extracted from an approval step are only applied once the run resumes after its approval
+	if len(rc.variables()) > 0 {
+		s.saveRunVariables(ctx, runID, rc)
+	}
+
+	steps := sortedSteps(chain)
+	if hasStepDependencies(steps) {
+		s.executeStepGraph(ctx, runID, steps, rc)
+		return
+	}
+
+steps:
+	for i := 0; i < len(steps); i++ {
+		step := steps[i]
+		if step.StepOrder < fromStep {
+			continue
+		}
+
+		// Update current step
+		if err := s.chainRepo.UpdateChainRunStep(ctx, runID, step.StepOrder); err != nil {
+			s.logger.Error(ctx, "Failed to update current step", zap.Error(err))
+		}
+
+		// Consecutive steps sharing a parallel group run concurrently
+		if end := parallelGroupEnd(steps, i); end-i > 1 {
+			action, notStarted := s.executeParallelGroup(ctx, runID, steps[i:end], rc)
+			if ctx.Err() != nil {
+				s.stopRun(ctx, runID, append(notStarted, steps[end:]...))
+				return
+			}
+
+			switch action {
+			case stepActionFail:
+				s.logger.Info(ctx, "Stopping chain execution due to failure in parallel group")
+				s.failRun(ctx, runID, steps, rc)
+				return
+			case stepActionPause:
+				s.logger.Info(ctx, "Pausing chain execution")
+				s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusPaused)
+				return
+			case stepActionAwaitApproval:
+				s.logger.Info(ctx, "Pausing chain execution until approval")
+				s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusAwaitingApproval)
+				return
+			case stepActionStop:
+				s.logger.Info(ctx, "Stopping chain execution due to success action")
+				break steps
+			}
+
+			i = end - 1
+			continue
+		}
+
+		s.logger.Info(ctx, "Executing step",
+			zap.String("run_id", runID.String()),
+			zap.Int("step_order", step.StepOrder),
+			zap.String("step_name", step.Name))
+
+		result := s.runStep(ctx, runID, &step, rc)
+
+		// Cancelled during the step delay, before the step was sent
+		if !result.started {
+			s.stopRun(ctx, runID, steps[i:])
+			return
+		}
+
+		// A step stopped by cancellation did not really fail; interrupted runs resume from this step
+		if ctx.Err() != nil {
+			s.stopRun(ctx, runID, steps[i+1:])
+			return
+		}
+		if result.skipped {
+			continue
+		}
+
+		// Handle step result
+		switch result.action(ctx, s.logger, &step) {
+		case stepActionStop:
+			s.logger.Info(ctx, "Stopping chain execution due to success action")
+			break steps
+		case stepActionPause:
+			s.logger.Info(ctx, "Pausing chain execution")
+			s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusPaused)
+			return
+		case stepActionAwaitApproval:
+			s.logger.Info(ctx, "Pausing chain execution until approval")
+			s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusAwaitingApproval)
+			return
+		case stepActionFail:
+			s.logger.Info(ctx, "Stopping chain execution due to failure")
+			s.failRun(ctx, runID, steps, rc)
+			return
+		}
+	}
+
+	// Mark chain as completed
+	s.logger.Info(ctx, "Chain execution completed", zap.String("run_id", runID.String()))
+	s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusCompleted)
+	s.notifyRunFinished(ctx, runID)
+}
+
+// stepAction is what a chain run does after a step or parallel group finished
+type stepAction int
+
+const (
+	// stepActionContinue moves on to the next step
+	stepActionContinue stepAction = iota
+	// stepActionStop ends the run as completed
+	stepActionStop
+	// stepActionPause pauses the run until it is resumed
+	stepActionPause
+	// stepActionAwaitApproval pauses the run until its approval step is approved or rejected
+	stepActionAwaitApproval
+	// stepActionFail ends the run as failed
+	stepActionFail
+)
+
+// stepResult is the outcome of running a single step
+type stepResult struct {
+	// started is false when the run was cancelled before the step was sent
+	started bool
+	// skipped is true when the step's condition did not hold
+	skipped bool
+	success bool
+	// awaitingApproval is true when an approval step notified its approvers and waits for a decision
+	awaitingApproval bool
+}
+
+// action applies the step's success or failure action to its result
+func (r stepResult) action(ctx context.Context, logger logging.Logger, step *models.ExecutionChainStep) stepAction {
+	if r.skipped || !r.started {
+		return stepActionContinue
+	}
+
+	if r.awaitingApproval {
+		logger.Info(ctx, "Approval requested, waiting for a decision",
+			zap.String("step_name", step.Name))
+		return stepActionAwaitApproval
+	}
+
+	if r.success {
+		logger.Info(ctx, "Step executed successfully",
+			zap.String("step_name", step.Name))
+		switch step.OnSuccessAction {
+		case "stop":
+			return stepActionStop
+		case "pause":
+			return stepActionPause
+		}
+		return stepActionContinue
+	}
+
+	logger.Error(ctx, "Step execution failed",
+		zap.String("step_name", step.Name))
+	if step.OnFailureAction == "stop" {
+		return stepActionFail
+	}
+	if step.OnFailureAction == "continue" {
+		logger.Info(ctx, "Continuing chain execution despite failure")
+	}
+	return stepActionContinue
+}
+
+// parallelGroupEnd returns the index after the run of consecutive steps starting at i that share
+// step i's parallel group; i+1 when the step is not part of a group
+func parallelGroupEnd(steps []models.ExecutionChainStep, i int) int {
+	end := i + 1
+	if steps[i].ParallelGroup == "" {
+		return end
+	}
+	for end < len(steps) && steps[end].ParallelGroup == steps[i].ParallelGroup {
+		end++
+	}
+	return end
+}
+
+// runStep evaluates a step's condition, applies its delay and executes it
+func (s *executionChainService) runStep(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, rc *runContext) stepResult {
+	if rc.excluded(step.StepOrder) {
+		s.skipStep(ctx, runID, step, "skipped: excluded by the run's steps_to_skip")
+		return stepResult{started: true, skipped: true}
+	}
+
+	// Skip the step when it is on a path its branch step did not choose
+	if step.BranchPath != "" && !rc.onTakenPath(step.BranchPath) {
+		s.skipStep(ctx, runID, step, fmt.Sprintf("skipped: branch path %q not taken", step.BranchPath))
+		return stepResult{started: true, skipped: true}
+	}
+
+	// Skip the step when its condition does not hold
+	if step.Condition != "" {
+		run, err := evaluateCondition(step.Condition, rc.templateData())
+		if err != nil {
+			s.logger.Error(ctx, "Failed to evaluate step condition",
+				zap.String("step_name", step.Name),
+				zap.Error(err))
+		}
+		if !run {
+			reason := fmt.Sprintf("skipped: condition %q is false", step.Condition)
+			if err != nil {
+				reason = fmt.Sprintf("skipped: condition %q could not be evaluated: %v", step.Condition, err)
+			}
+			s.skipStep(ctx, runID, step, reason)
+			return stepResult{started: true, skipped: true}
+		}
+	}
+
+	// Apply delay if specified
+	if step.DelaySeconds > 0 {
+		s.logger.Info(ctx, "Applying step delay",
+			zap.Int("delay_seconds", step.DelaySeconds))
+		if !sleepContext(ctx, s.clock, time.Duration(step.DelaySeconds)*time.Second) {
+			return stepResult{}
+		}
+	}
+
+	if !step.Type.CallsWebhook() {
+		return stepResult{started: true, success: s.executeBuiltinStep(ctx, runID, step, rc)}
+	}
+
+	success := s.executeStep(ctx, runID, step, rc)
+	return stepResult{started: true, success: success, awaitingApproval: success && step.Type == models.StepTypeApproval}
+}
+
+// executeParallelGroup runs the steps of a parallel group concurrently and waits for all of them
+// Each branch applies its own condition, delay, retries and actions; the group's action is the most
+// severe of its branches: a failing "stop" branch fails the run, then an approval request, then "pause",
+// then "stop" on success
+// Branches that already succeeded earlier in the run, before it was paused or interrupted, are not repeated
+// Returns the group's action and the branches that were never sent because the run was cancelled
+func (s *executionChainService) executeParallelGroup(ctx context.Context, runID uuid.UUID, group []models.ExecutionChainStep, rc *runContext) (stepAction, []models.ExecutionChainStep) {
+	s.logger.Info(ctx, "Executing parallel step group",
+		zap.String("run_id", runID.String()),
+		zap.String("parallel_group", group[0].ParallelGroup),
+		zap.Int("branches", len(group)))
+
+	results := make([]stepResult, len(group))
+	var wg sync.WaitGroup
+	for i := range group {
+		if rc.succeeded(group[i].StepOrder) {
+			results[i] = stepResult{started: true, skipped: true}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = s.runStep(ctx, runID, &group[i], rc)
+		}(i)
+	}
+	wg.Wait()
+
+	action := stepActionContinue
+	var notStarted []models.ExecutionChainStep
+	for i, result := range results {
+		if !result.started {
+			notStarted = append(notStarted, group[i])
+			continue
+		}
+		if branchAction := result.action(ctx, s.logger, &group[i]); branchAction > action {
+			action = branchAction
+		}
+	}
+	return action, notStarted
+}
+
+// skipStep records a step that was not executed because its condition did not hold
+// or it is on a branch path that was not taken
+func (s *executionChainService) skipStep(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, reason string) {
+	s.logger.Info(ctx, "Skipping step",
+		zap.String("run_id", runID.String()),
+		zap.Int("step_order", step.StepOrder),
+		zap.String("reason", reason))
+
+	now := s.clock.Now()
+	if err := s.chainRepo.CreateStepRun(ctx, &models.ExecutionChainStepRun{
+		ID:          uuid.New(),
+		RunID:       runID,
+		StepID:      step.ID,
+		StepOrder:   step.StepOrder,
+		Status:      models.WebhookStatusSkipped,
+		LastError:   &reason,
+		StartedAt:   &now,
+		CompletedAt: &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		s.logger.Error(ctx, "Failed to record skipped step", zap.Error(err))
+	}
+}
+
+// executeStep executes a single step with retry logic
+// On success the step's response is added to the run context for the steps that follow
+// An approval step whose approvers were notified stays pending until it is approved or rejected
+func (s *executionChainService) executeStep(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, rc *runContext) bool {
+	// Step results are recorded even when the run is cancelled mid-step
+	dbCtx := context.WithoutCancel(ctx)
+
+	// Render request params against the trigger data and the responses of earlier steps
+	requestParams, renderErr := renderStepParams(step, rc)
+
+	// Create step run
+	now := s.clock.Now()
+	stepRun := &models.ExecutionChainStepRun{
+		ID:        uuid.New(),
+		RunID:     runID,
+		StepID:    step.ID,
+		StepOrder: step.StepOrder,
+		Status:    models.WebhookStatusPending,
 		StartedAt: &now,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
+	if requestParams != nil {
+		if paramsBytes, err := json.Marshal(requestParams); err == nil {
+			stepRun.RequestPayload = rc.protection.protect(ctx, string(paramsBytes), false)
+		}
+	}
+
+	// A template error is a configuration problem that retrying cannot fix
+	if renderErr != nil {
+		errMsg := renderErr.Error()
+		stepRun.Status = models.WebhookStatusFailed
+		stepRun.LastError = &errMsg
+		stepRun.CompletedAt = &now
+	}
+
+	if err := s.chainRepo.CreateStepRun(dbCtx, stepRun); err != nil {
+		s.logger.Error(ctx, "Failed to create step run", zap.Error(err))
+		return false
+	}
 
-	if err := s.chainRepo.CreateStepRun(ctx, stepRun); err != nil {
-		logger.Error("Failed to create step run", zap.Error(err))
+	if renderErr != nil {
+		s.logger.Error(ctx, "Failed to render step request params",
+			zap.String("run_id", runID.String()),
+			zap.Int("step_order", step.StepOrder),
+			zap.Error(renderErr))
 		return false
 	}
 
+	// Payload sizes are summed over the attempts for resource accounting
+	var requestBytes, responseBytes int64
+
 	// Retry logic
 	for attempt := 0; attempt <= step.MaxRetries; attempt++ {
 		if attempt > 0 {
 			// Exponential backoff
 			delay := time.Duration(attempt*attempt) * time.Second
-			logger.Info("Retrying step execution",
+			s.logger.Info(ctx, "Retrying step execution",
 				zap.Int("attempt", attempt),
 				zap.Duration("delay", delay))
-			time.Sleep(delay)
+			if !sleepContext(ctx, s.clock, delay) {
+				if err := s.chainRepo.UpdateStepRun(dbCtx, stepRun.ID, map[string]interface{}{
+					"status":       models.WebhookStatusFailed,
+					"last_error":   context.Cause(ctx).Error(),
+					"completed_at": s.clock.Now(),
+					"updated_at":   s.clock.Now(),
+				}); err != nil {
+					s.logger.Error(ctx, "Failed to update step run", zap.Error(err))
+				}
+				return false
+			}
 		}
 
-		success, responseCode, responseBody, err := s.sendStepWebhook(ctx, step, triggerData)
+		success, response, err := s.sendStepWebhook(ctx, runID, step, rc, requestParams, attempt+1)
 
 		// Update step run
+		requestBytes += int64(response.requestBytes)
+		responseBytes += int64(response.responseBytes)
 		updates := map[string]interface{}{
-			"attempt_count": attempt + 1,
-			"updated_at":    time.Now(),
+			"attempt_count":  attempt + 1,
+			"request_bytes":  requestBytes,
+			"response_bytes": responseBytes,
+			"updated_at":     s.clock.Now(),
 		}
 
-		if responseCode != nil {
-			updates["response_code"] = *responseCode
+		if response.code != nil {
+			updates["response_code"] = *response.code
 		}
 
-		if responseBody != nil {
-			updates["response_body"] = *responseBody
+		if response.captured != nil {
+			updates["response_body"] = *response.captured
 		}
 
 		if success {
-			updates["status"] = models.WebhookStatusSent
-			updates["completed_at"] = time.Now()
+			if step.Type != models.StepTypeApproval {
+				updates["status"] = models.WebhookStatusSent
+				updates["completed_at"] = s.clock.Now()
+			}
 		} else {
 			if err != nil {
 				errMsg := err.Error()
 				updates["last_error"] = errMsg
 			}
-			if attempt == step.MaxRetries {
+			if attempt == step.MaxRetries || ctx.Err() != nil {
 				updates["status"] = models.WebhookStatusFailed
-				updates["completed_at"] = time.Now()
+				updates["completed_at"] = s.clock.Now()
+			}
+			if ctx.Err() != nil {
+				updates["last_error"] = context.Cause(ctx).Error()
 			}
 		}
 
-		if err := s.chainRepo.UpdateStepRun(ctx, stepRun.ID, updates); err != nil {
-			logger.Error("Failed to update step run", zap.Error(err))
+		if err := s.chainRepo.UpdateStepRun(dbCtx, stepRun.ID, updates); err != nil {
+			s.logger.Error(ctx, "Failed to update step run", zap.Error(err))
 		}
 
 		if success {
+			if step.Type != models.StepTypeApproval {
+				rc.record(step.StepOrder, step.Name, response.code, response.body)
+				if rc.extract(step) {
+					s.saveRunVariables(dbCtx, runID, rc)
+				}
+			}
 			return true
 		}
 
-		logger.Error("Step execution attempt failed",
+		s.logger.Error(ctx, "Step execution attempt failed",
 			zap.Int("attempt", attempt+1),
 			zap.Error(err))
 	}
@@ -478,63 +2387,124 @@ func (s *executionChainService) executeStep(ctx context.Context, runID uuid.UUID
 	return false
 }
 
-// sendStepWebhook sends the webhook for a step
-func (s *executionChainService) sendStepWebhook(ctx context.Context, step *models.ExecutionChainStep, triggerData map[string]interface{}) (bool, *int, *string, error) {
+// renderStepParams renders a step's request params against the trigger data and earlier step responses
+// Returns nil params when the step has none
+func renderStepParams(step *models.ExecutionChainStep, rc *runContext) (map[string]interface{}, error) {
+	var params map[string]interface{}
+	if step.RequestParams != "" {
+		if err := json.Unmarshal([]byte(step.RequestParams), &params); err != nil {
+			return nil, fmt.Errorf("invalid request params: %w", err)
+		}
+	}
+
+	params = rc.overrideParams(step.StepOrder, params)
+	if params == nil {
+		return nil, nil
+	}
+
+	rendered, err := renderRequestParams(params, rc.templateData())
+	if err != nil {
+		return nil, fmt.Errorf("failed to render request params: %w", err)
+	}
+	return rendered, nil
+}
+
+// stepResponse is a step webhook's answer, in full for the later steps of the run and as stored per the
+// response capture; the bodies are nil without an answer
+type stepResponse struct {
+	code     *int
+	body     *string
+	captured *string
+
+	// requestBytes and responseBytes are the sizes of the request sent and the answer read
+	requestBytes  int
+	responseBytes int
+}
+
+// sendStepWebhook sends the webhook for a step with its rendered request params
+// The payload carries the trigger data and, under previous_steps, the responses of earlier successful steps
+// For approval steps it also carries, under approval, the endpoints approvers submit their decision to
+// The error of a failed attempt quotes the answer as captured
+func (s *executionChainService) sendStepWebhook(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, rc *runContext, requestParams map[string]interface{}, attempt int) (bool, stepResponse, error) {
 	// Prepare payload
 	payload := map[string]interface{}{
-		"step_name":    step.Name,
-		"step_order":   step.StepOrder,
-		"trigger_data": triggerData,
-		"timestamp":    time.Now().Format(time.RFC3339),
+		"step_name":      step.Name,
+		"step_order":     step.StepOrder,
+		"trigger_data":   rc.triggerData,
+		"previous_steps": rc.previousSteps(),
+		"timestamp":      s.clock.Now().Format(time.RFC3339),
+	}
+
+	// Expose the run variables set so far
+	if variables := rc.variables(); len(variables) > 0 {
+		payload["variables"] = variables
 	}
 
 	// Merge step-specific request params
-	if step.RequestParams != "" {
-		var stepParams map[string]interface{}
-		if err := json.Unmarshal([]byte(step.RequestParams), &stepParams); err == nil {
-			payload["request_params"] = stepParams
+	if requestParams != nil {
+		payload["request_params"] = requestParams
+	}
+
+	if step.Type == models.StepTypeApproval {
+		payload["approval"] = map[string]interface{}{
+			"run_id":       runID,
+			"approve_path": fmt.Sprintf("/api/v1/execution-chains/runs/%s/approve", runID),
+			"reject_path":  fmt.Sprintf("/api/v1/execution-chains/runs/%s/reject", runID),
 		}
 	}
 
-	// Convert to JSON
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return false, nil, nil, fmt.Errorf("failed to marshal payload: %w", err)
+	if step.Webhook == nil {
+		return false, stepResponse{}, fmt.Errorf("step has no webhook")
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", step.Webhook.TargetURL, bytes.NewBuffer(payloadBytes))
+	outcome, requestBytes := s.deliverChainPayload(ctx, step.Webhook, payload, attempt)
+	response := stepResponse{code: outcome.ResponseCode, requestBytes: requestBytes, responseBytes: len(outcome.ResponseBody)}
+	captured, err := s.capture.outcome(outcome)
+	if outcome.ResponseCode != nil {
+		body := string(outcome.ResponseBody)
+		response.body, response.captured = &body, captured
+	}
 	if err != nil {
-		return false, nil, nil, fmt.Errorf("failed to create request: %w", err)
+		return false, response, err
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "github.com/sakibcoolz/loki-suite-execution-chain/2.0")
-
-	// Generate HMAC signature
-	signature := s.security.GenerateHMACSignature(payloadBytes, step.Webhook.SecretToken)
-	req.Header.Set("X-Shavix-Signature", fmt.Sprintf("sha256=%s", signature))
-	req.Header.Set("X-Shavix-Timestamp", time.Now().Format(time.RFC3339))
-
-	// Add JWT token for private webhooks
-	if step.Webhook.Type == models.WebhookTypePrivate && step.Webhook.JWTToken != nil {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", *step.Webhook.JWTToken))
+	// A response must also conform to the step's response schema, or the webhook's when unset
+	schema := step.ResponseSchema
+	if schema == nil {
+		schema = step.Webhook.ResponseSchema
 	}
+	if schema != nil && outcome.ResponseCode != nil {
+		if err := validateResponseBody(*schema, outcome.ResponseBody); err != nil {
+			return false, response, err
+		}
+	}
+
+	return true, response, nil
+}
 
-	// Send request
-	resp, err := s.httpClient.Do(req)
+// deliverChainPayload makes one attempt at delivering a chain payload to a webhook, through the transport
+// of its target type; chain payloads are sent as they are, without rendering them per a notification template
+// Returns the outcome and the size of the body sent, 0 when nothing was sent
+func (s *executionChainService) deliverChainPayload(ctx context.Context, webhook *models.WebhookSubscription, payload map[string]interface{}, attempt int) (DeliveryOutcome, int) {
+	// Convert to JSON
+	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
-		return false, nil, nil, fmt.Errorf("failed to send request: %w", err)
+		return failedDelivery(models.DeliveryErrorRequest, fmt.Errorf("failed to marshal payload: %w", err), true), 0
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	responseBody := string(bodyBytes)
-
-	// Check response status
-	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	transport, ok := s.transports.Lookup(webhook.TargetType)
+	if !ok {
+		return failedDelivery(models.DeliveryErrorRequest, fmt.Errorf("no transport for target type %s", webhook.TargetType), true), 0
+	}
 
-	return success, &resp.StatusCode, &responseBody, nil
+	// Sign under the subscription's or tenant's header names
+	outcome := transport.Send(ctx, &Delivery{
+		Subscription: *webhook,
+		Headers:      webhook.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, webhook.TenantID)),
+		MessageID:    uuid.New().String(),
+		Body:         payloadBytes,
+		Raw:          true,
+		Attempt:      attempt,
+	})
+	return outcome, len(payloadBytes)
 }
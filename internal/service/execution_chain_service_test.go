@@ -0,0 +1,370 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+)
+
+const chainTenant = "tenant-123"
+
+// stepServer answers the step webhooks of chain runs with 200 and records the paths called, in order
+// Paths with a handler are answered by it instead, after being recorded
+type stepServer struct {
+	*httptest.Server
+	handlers map[string]http.HandlerFunc
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// newStepServer starts a step server answering the given paths with their handlers
+func newStepServer(t *testing.T, handlers map[string]http.HandlerFunc) *stepServer {
+	t.Helper()
+	server := &stepServer{handlers: handlers}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.record(r.URL.Path)
+		if handler, ok := server.handlers[r.URL.Path]; ok {
+			handler(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// record appends an entry to the calls
+func (s *stepServer) record(entry string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, entry)
+}
+
+// Calls returns the entries recorded so far
+func (s *stepServer) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.calls)
+}
+
+// webhook registers a subscription of the tenant calling path on the server and returns its ID
+func (env *memoryChains) webhook(t *testing.T, server *stepServer, path string) *uuid.UUID {
+	t.Helper()
+	subscription := &models.WebhookSubscription{
+		TenantID:    chainTenant,
+		AppName:     "orders",
+		TargetURL:   server.URL + path,
+		Type:        models.WebhookTypePublic,
+		SecretToken: "test-secret",
+		IsActive:    true,
+	}
+	require.NoError(t, env.webhooks.CreateSubscription(context.Background(), subscription))
+	return &subscription.ID
+}
+
+// execute creates a chain of the tenant with the steps and starts a run of it
+func (env *memoryChains) execute(t *testing.T, steps ...models.CreateExecutionChainStep) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	chain, err := env.service.CreateChain(ctx, &models.CreateExecutionChainRequest{
+		TenantID: chainTenant, Name: "Order fulfilment", TriggerEvent: "order.created", Steps: steps,
+	})
+	require.NoError(t, err)
+
+	run, err := env.service.ExecuteChain(ctx, chainTenant, &models.ExecuteChainRequest{
+		ChainID:     chain.ChainID,
+		TriggerData: map[string]interface{}{"order_id": "ord-1"},
+	})
+	require.NoError(t, err)
+	return run.RunID
+}
+
+// waitForRun waits until the run has the status and returns it
+func (env *memoryChains) waitForRun(t *testing.T, runID uuid.UUID, status models.ExecutionChainStatus) *models.ExecutionChainRun {
+	t.Helper()
+	var run *models.ExecutionChainRun
+	require.Eventually(t, func() bool {
+		var err error
+		run, err = env.service.GetChainRun(context.Background(), chainTenant, runID)
+		require.NoError(t, err)
+		return run.Status == status
+	}, 5*time.Second, 5*time.Millisecond, "run never became %s", status)
+	return run
+}
+
+// stepRunsByOrder returns the step runs of a run by step order
+func stepRunsByOrder(run *models.ExecutionChainRun) map[int]models.ExecutionChainStepRun {
+	byOrder := make(map[int]models.ExecutionChainStepRun, len(run.StepRuns))
+	for _, stepRun := range run.StepRuns {
+		byOrder[stepRun.StepOrder] = stepRun
+	}
+	return byOrder
+}
+
+// failing answers with 500 while fail is set
+func failing(fail *atomic.Bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}
+}
+
+// TestExecuteChain_DependencyGraph tests that the steps of a chain declaring depends_on run once all their
+// dependencies finished, whatever their order in the chain, and that the run then completes
+func TestExecuteChain_DependencyGraph(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+
+	// Act
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Report", Key: "report", DependsOn: []string{"ship", "bill"}, WebhookID: env.webhook(t, server, "/report")},
+		models.CreateExecutionChainStep{Name: "Ship", Key: "ship", DependsOn: []string{"order"}, WebhookID: env.webhook(t, server, "/ship")},
+		models.CreateExecutionChainStep{Name: "Bill", Key: "bill", DependsOn: []string{"order"}, WebhookID: env.webhook(t, server, "/bill")},
+		models.CreateExecutionChainStep{Name: "Order", Key: "order", WebhookID: env.webhook(t, server, "/order")},
+	)
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	calls := server.Calls()
+	require.Len(t, calls, 4)
+	assert.Equal(t, "/order", calls[0])
+	assert.ElementsMatch(t, []string{"/ship", "/bill"}, calls[1:3])
+	assert.Equal(t, "/report", calls[3])
+	for order, stepRun := range stepRunsByOrder(run) {
+		assert.Equal(t, models.WebhookStatusSent, stepRun.Status, "step %d", order)
+	}
+}
+
+// TestCreateChain_DependencyCycle tests that a chain whose depends_on references form a cycle is rejected
+// as invalid, naming the steps of the cycle
+func TestCreateChain_DependencyCycle(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+	webhookID := env.webhook(t, server, "/step")
+
+	// Act
+	response, err := env.service.CreateChain(context.Background(), &models.CreateExecutionChainRequest{
+		TenantID: chainTenant, Name: "Circular", TriggerEvent: "order.created",
+		Steps: []models.CreateExecutionChainStep{
+			{Name: "Order", Key: "order", WebhookID: webhookID},
+			{Name: "Ship", Key: "ship", DependsOn: []string{"order", "bill"}, WebhookID: webhookID},
+			{Name: "Bill", Key: "bill", DependsOn: []string{"ship"}, WebhookID: webhookID},
+		},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrInvalidChain)
+	assert.ErrorContains(t, err, "cycle involving steps 2 (Ship), 3 (Bill)")
+	assert.Nil(t, response)
+}
+
+// TestExecuteChain_ParallelGroupJoin tests that the steps of a parallel group are sent concurrently and that
+// the step after the group starts only once every branch of the group finished
+func TestExecuteChain_ParallelGroupJoin(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	chargeStarted := make(chan struct{})
+	var server *stepServer
+	server = newStepServer(t, map[string]http.HandlerFunc{
+		// Reserve answers only once charge was sent, so the run hangs unless both are in flight together
+		"/reserve": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-chargeStarted:
+			case <-time.After(5 * time.Second):
+				w.WriteHeader(http.StatusGatewayTimeout)
+				return
+			}
+			server.record("done /reserve")
+		},
+		"/charge": func(w http.ResponseWriter, r *http.Request) {
+			close(chargeStarted)
+			time.Sleep(20 * time.Millisecond)
+			server.record("done /charge")
+		},
+	})
+
+	// Act
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Reserve", ParallelGroup: "prepare", MaxRetries: 1, WebhookID: env.webhook(t, server, "/reserve")},
+		models.CreateExecutionChainStep{Name: "Charge", ParallelGroup: "prepare", MaxRetries: 1, WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+	)
+	env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	calls := server.Calls()
+	require.Len(t, calls, 5)
+	assert.ElementsMatch(t, []string{"/reserve", "/charge", "done /reserve", "done /charge"}, calls[:4])
+	assert.Equal(t, "/ship", calls[4])
+}
+
+// TestCancelChainRun_SkipsRemainingSteps tests that cancelling a running run aborts its in-flight step and
+// marks the steps that did not run yet skipped with the cancellation reason, without sending them
+func TestCancelChainRun_SkipsRemainingSteps(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	release := make(chan struct{})
+	server := newStepServer(t, map[string]http.HandlerFunc{
+		"/charge": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		},
+	})
+	t.Cleanup(func() { close(release) })
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Order", WebhookID: env.webhook(t, server, "/order")},
+		models.CreateExecutionChainStep{Name: "Charge", MaxRetries: 1, WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+		models.CreateExecutionChainStep{Name: "Notify", WebhookID: env.webhook(t, server, "/notify")},
+	)
+	require.Eventually(t, func() bool {
+		return slices.Contains(server.Calls(), "/charge")
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// Act
+	response, err := env.service.CancelChainRun(ctx, runID, "customer withdrew the order")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ExecutionChainStatusCancelled), response.Status)
+
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCancelled)
+	stepRuns := stepRunsByOrder(run)
+	require.Len(t, stepRuns, 4)
+	assert.Equal(t, models.WebhookStatusSent, stepRuns[1].Status)
+	assert.Equal(t, models.WebhookStatusFailed, stepRuns[2].Status, "the in-flight step is aborted")
+	for _, order := range []int{3, 4} {
+		assert.Equal(t, models.WebhookStatusSkipped, stepRuns[order].Status, "step %d", order)
+		require.NotNil(t, stepRuns[order].LastError)
+		assert.Equal(t, "skipped: run cancelled: customer withdrew the order", *stepRuns[order].LastError)
+	}
+	assert.Equal(t, []string{"/order", "/charge"}, server.Calls())
+}
+
+// TestRetryChainRun_ReusesCompletedSteps tests that retrying a failed run starts a new run that reuses the
+// results of the steps completed before the failure and sends only the failed step and those after it
+func TestRetryChainRun_ReusesCompletedSteps(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	env.clock.autoAdvance = true
+	var fail atomic.Bool
+	fail.Store(true)
+	server := newStepServer(t, map[string]http.HandlerFunc{"/charge": failing(&fail)})
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Order", WebhookID: env.webhook(t, server, "/order")},
+		models.CreateExecutionChainStep{Name: "Charge", MaxRetries: 1, WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+	)
+	env.waitForRun(t, runID, models.ExecutionChainStatusFailed)
+	require.Equal(t, []string{"/order", "/charge", "/charge"}, server.Calls())
+	fail.Store(false)
+
+	// Act
+	response, err := env.service.RetryChainRun(ctx, runID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, runID, response.RunID)
+	retry := env.waitForRun(t, response.RunID, models.ExecutionChainStatusCompleted)
+	assert.Equal(t, []string{"/order", "/charge", "/charge", "/charge", "/ship"}, server.Calls())
+
+	stepRuns := stepRunsByOrder(retry)
+	require.Len(t, stepRuns, 3)
+	for order, stepRun := range stepRuns {
+		assert.Equal(t, models.WebhookStatusSent, stepRun.Status, "step %d", order)
+	}
+
+	_, err = env.service.RetryChainRun(ctx, runID)
+	assert.ErrorIs(t, err, service.ErrInvalidRunState, "a run is retried once")
+}
+
+// TestApproveChainRun_ResumesAfterApproval tests that a run reaching an approval step notifies the approvers
+// and waits without sending later steps, and that approving it records the decision and completes the run
+func TestApproveChainRun_ResumesAfterApproval(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Order", WebhookID: env.webhook(t, server, "/order")},
+		models.CreateExecutionChainStep{Name: "Sign off", Type: models.StepTypeApproval, WebhookID: env.webhook(t, server, "/approvers")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+	)
+	waiting := env.waitForRun(t, runID, models.ExecutionChainStatusAwaitingApproval)
+	assert.Equal(t, []string{"/order", "/approvers"}, server.Calls())
+	assert.Equal(t, models.WebhookStatusPending, stepRunsByOrder(waiting)[2].Status)
+
+	_, err := env.service.ResumeChainRun(ctx, runID)
+	assert.ErrorIs(t, err, service.ErrInvalidRunState, "a run awaiting approval is not resumed without a decision")
+
+	// Act - the goroutine that requested the approval may still be winding down
+	require.Eventually(t, func() bool {
+		_, err := env.service.ApproveChainRun(ctx, runID, models.StepApproval{Approver: "ops@example.com"})
+		return err == nil
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// Assert
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+	assert.Equal(t, []string{"/order", "/approvers", "/ship"}, server.Calls())
+	approval := stepRunsByOrder(run)[2]
+	assert.Equal(t, models.WebhookStatusSent, approval.Status)
+	require.NotNil(t, approval.Approval)
+	assert.Equal(t, models.ApprovalDecisionApproved, approval.Approval.Decision)
+	assert.Equal(t, "ops@example.com", approval.Approval.Approver)
+}
+
+// TestExecuteChain_CompensatesInReverseOrder tests that a failed run calls the compensation webhooks of the
+// steps completed before the failure newest step first, records each call and marks the compensation completed
+func TestExecuteChain_CompensatesInReverseOrder(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	env.clock.autoAdvance = true
+	var fail atomic.Bool
+	fail.Store(true)
+	server := newStepServer(t, map[string]http.HandlerFunc{"/ship": failing(&fail)})
+
+	// Act
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Reserve", WebhookID: env.webhook(t, server, "/reserve"),
+			Compensation: &models.StepCompensation{WebhookID: *env.webhook(t, server, "/release")}},
+		models.CreateExecutionChainStep{Name: "Charge", WebhookID: env.webhook(t, server, "/charge"),
+			Compensation: &models.StepCompensation{WebhookID: *env.webhook(t, server, "/refund")}},
+		models.CreateExecutionChainStep{Name: "Ship", MaxRetries: 1, WebhookID: env.webhook(t, server, "/ship"),
+			Compensation: &models.StepCompensation{WebhookID: *env.webhook(t, server, "/recall")}},
+	)
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusFailed)
+
+	// Assert
+	assert.Equal(t, []string{"/reserve", "/charge", "/ship", "/ship", "/refund", "/release"}, server.Calls(),
+		"the failed step is not compensated")
+	assert.Equal(t, models.CompensationStatusCompleted, run.CompensationStatus)
+	require.Len(t, run.Compensations, 2)
+	assert.Equal(t, 2, run.Compensations[0].StepOrder)
+	assert.Equal(t, 1, run.Compensations[1].StepOrder)
+	for _, compensation := range run.Compensations {
+		assert.Equal(t, models.WebhookStatusSent, compensation.Status)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+)
+
+const (
+	// admissionLockPrefix names the lock serializing admission of the queued runs of an admission scope
+	admissionLockPrefix = "chain-run-admission:"
+
+	// retryLockPrefix names the lock serializing retries of a failed run
+	retryLockPrefix = "chain-run-retry:"
+
+	// pauseReleaseLockPrefix names the lock held while a paused subscription's queued deliveries are released
+	pauseReleaseLockPrefix = "receiver-pause:"
+
+	// deliveryBatchLockPrefix names the lock held while a batching subscription's due batches are sent
+	deliveryBatchLockPrefix = "delivery-batch:"
+
+	// deliveryDrainLockPrefix names the lock held while the events held by a resumed delivery pause are drained
+	deliveryDrainLockPrefix = "delivery-drain:"
+
+	// alertEvaluationLock names the lock held while the alert rules are evaluated
+	alertEvaluationLock = "alert-evaluation"
+
+	// autoDisableLock names the lock held while the auto-disable policies are applied
+	autoDisableLock = "auto-disable"
+)
+
+// withInstanceLock runs fn while holding a lock shared by every instance, or right away without locks
+func withInstanceLock(ctx context.Context, locks repository.LockRepository, name string, fn func() error) error {
+	if locks == nil {
+		return fn()
+	}
+	return locks.WithLock(ctx, name, fn)
+}
+
+// tryInstanceLock runs fn if no other instance holds the lock, or right away without locks
+// Returns false without running fn when another instance holds the lock
+func tryInstanceLock(ctx context.Context, locks repository.LockRepository, name string, fn func() error) (bool, error) {
+	if locks == nil {
+		return true, fn()
+	}
+	return locks.TryWithLock(ctx, name, fn)
+}
+
+// SetLocks coordinates the instances sharing the database, nil when the instance runs alone
+// Queued runs are admitted under a lock per admission queue, so concurrency limits hold across
+// instances, and failed runs are retried under a lock per run so a retry is started once
+func (s *executionChainService) SetLocks(locks repository.LockRepository) {
+	s.locks = locks
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseJSONPath splits a JSONPath expression into the field names and array indexes it selects from the root
+// Segments are written .name, [index] or ['name'], and * selects every field or item
+func parseJSONPath(jsonPath string) ([]string, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(jsonPath), "$")
+	var segments []string
+	for rest != "" {
+		var segment string
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if end == 0 {
+				end = len(rest)
+			}
+			segment, rest = rest[1:end], rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unclosed [", jsonPath)
+			}
+			segment, rest = strings.Trim(rest[1:end], `'"`), rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q: expected . or [ at %q", jsonPath, rest)
+		}
+		if segment == "" {
+			return nil, fmt.Errorf("invalid JSONPath %q: empty segment", jsonPath)
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid JSONPath %q: must select a field", jsonPath)
+	}
+	return segments, nil
+}
+
+// decodeJSONDocument decodes a JSON document keeping numbers as written; false if it is not JSON
+func decodeJSONDocument(body []byte) (interface{}, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var document interface{}
+	if decoder.Decode(&document) != nil {
+		return nil, false
+	}
+	return document, true
+}
+
+// encodeJSONDocument encodes a document decoded by decodeJSONDocument, without escaping HTML characters
+func encodeJSONDocument(document interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// replaceJSONPath replaces the values a path selects under a node with what replace returns for them
+// Returns whether a value was replaced
+func replaceJSONPath(node interface{}, segments []string, replace func(value interface{}) interface{}) bool {
+	segment, last := segments[0], len(segments) == 1
+	replaced := false
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if segment != "*" && segment != key {
+				continue
+			}
+			if last {
+				value[key] = replace(child)
+				replaced = true
+			} else if replaceJSONPath(child, segments[1:], replace) {
+				replaced = true
+			}
+		}
+	case []interface{}:
+		for i, child := range value {
+			if segment != "*" && segment != strconv.Itoa(i) {
+				continue
+			}
+			if last {
+				value[i] = replace(child)
+				replaced = true
+			} else if replaceJSONPath(child, segments[1:], replace) {
+				replaced = true
+			}
+		}
+	}
+	return replaced
+}
+
+// lookupJSONPath returns the first value a path selects under a node; false if it selects none
+func lookupJSONPath(node interface{}, segments []string) (interface{}, bool) {
+	segment, last := segments[0], len(segments) == 1
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if segment != "*" {
+			child, ok := value[segment]
+			if !ok || last {
+				return child, ok
+			}
+			return lookupJSONPath(child, segments[1:])
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if last {
+				return value[key], true
+			}
+			if found, ok := lookupJSONPath(value[key], segments[1:]); ok {
+				return found, true
+			}
+		}
+	case []interface{}:
+		for i, child := range value {
+			if segment != "*" && segment != strconv.Itoa(i) {
+				continue
+			}
+			if last {
+				return child, true
+			}
+			if found, ok := lookupJSONPath(child, segments[1:]); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// jwtKeyPrefix marks the IDs of keyring keys
+	jwtKeyPrefix = "jk_"
+
+	// keyringRefreshInterval bounds how long keys added, promoted or retired through another instance
+	// take to be picked up
+	keyringRefreshInterval = time.Minute
+
+	// keyringMissRefreshInterval limits how often a token naming an unknown key reloads the keyring
+	keyringMissRefreshInterval = 5 * time.Second
+)
+
+// Keyring holds the HMAC keys JWTs are signed and verified with
+// New tokens are signed with the primary key and name it in their "kid" header, so promoting a new key
+// does not invalidate tokens signed with earlier ones. Tokens without a kid were signed with the single
+// JWT secret of the configuration and keep verifying while it is set
+type Keyring struct {
+	repo         repository.KeyringRepository
+	legacySecret []byte
+	clock        Clock
+	logger       logging.Logger
+
+	mu       sync.RWMutex
+	keys     map[string]*models.JWTKey
+	primary  *models.JWTKey
+	loadedAt time.Time
+}
+
+// NewKeyring creates a keyring backed by the repository
+// legacySecret is the configured JWT secret tokens issued before the keyring were signed with, empty to reject them
+func NewKeyring(repo repository.KeyringRepository, legacySecret string, logger logging.Logger) *Keyring {
+	return &Keyring{
+		repo:         repo,
+		legacySecret: []byte(legacySecret),
+		clock:        NewSystemClock(),
+		logger:       logger,
+		keys:         map[string]*models.JWTKey{},
+	}
+}
+
+// SetClock replaces the clock used for key timestamps and refreshes
+func (k *Keyring) SetClock(clock Clock) {
+	k.clock = clock
+}
+
+// Load reads the keys and generates a primary key when there is none, so a new installation signs
+// with a keyring key from the start
+func (k *Keyring) Load(ctx context.Context) error {
+	if err := k.refresh(ctx); err != nil {
+		return err
+	}
+
+	k.mu.RLock()
+	primary := k.primary
+	k.mu.RUnlock()
+	if primary != nil {
+		return nil
+	}
+
+	key, err := k.AddKey(ctx, true)
+	if err != nil {
+		return err
+	}
+	k.logger.Info(ctx, "Generated primary JWT key", zap.String("kid", key.ID))
+	return nil
+}
+
+// Sign signs the claims with the primary key and embeds its ID in the token's kid header
+func (k *Keyring) Sign(ctx context.Context, claims jwt.Claims) (string, error) {
+	if err := k.refreshIfStale(ctx, keyringRefreshInterval); err != nil {
+		k.logger.Warn(ctx, "Failed to refresh JWT keyring, signing with cached keys", zap.Error(err))
+	}
+
+	k.mu.RLock()
+	key := k.primary
+	k.mu.RUnlock()
+	if key == nil {
+		return "", fmt.Errorf("keyring has no primary key")
+	}
+
+	secret, err := key.SecretBytes()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(secret)
+}
+
+// Keyfunc returns a jwt.Keyfunc resolving the key a token was signed with from its kid header
+// Tokens naming a retired key are rejected
+func (k *Keyring) Keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if len(k.legacySecret) == 0 {
+				return nil, fmt.Errorf("token has no key ID")
+			}
+			return k.legacySecret, nil
+		}
+
+		key, err := k.lookup(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if key.IsRetired() {
+			return nil, fmt.Errorf("jwt key %s is retired", kid)
+		}
+		return key.SecretBytes()
+	}
+}
+
+// ListKeys retrieves every key, including retired ones, newest first
+func (k *Keyring) ListKeys(ctx context.Context) ([]*models.JWTKey, error) {
+	keys, err := k.repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jwt keys: %w", err)
+	}
+	return keys, nil
+}
+
+// AddKey generates and stores a new key; a primary key signs every token issued from now on
+// Add a key without making it primary first when several instances run, and promote it once every
+// instance has picked it up, so no instance receives tokens signed with a key it does not know yet
+func (k *Keyring) AddKey(ctx context.Context, primary bool) (*models.JWTKey, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate jwt key: %w", err)
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate jwt key ID: %w", err)
+	}
+
+	key := &models.JWTKey{
+		ID:        jwtKeyPrefix + hex.EncodeToString(id),
+		Secret:    base64.StdEncoding.EncodeToString(secret),
+		IsPrimary: primary,
+		CreatedAt: k.clock.Now(),
+	}
+	if err := k.repo.CreateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to save jwt key: %w", err)
+	}
+	k.reload(ctx)
+
+	k.logger.Info(ctx, "JWT key added", zap.String("kid", key.ID), zap.Bool("primary", primary))
+	return key, nil
+}
+
+// PromoteKey makes a key the primary key; tokens signed with the previous primary key keep verifying
+func (k *Keyring) PromoteKey(ctx context.Context, id string) error {
+	if err := k.repo.PromoteKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to promote jwt key: %w", err)
+	}
+	k.reload(ctx)
+
+	k.logger.Info(ctx, "JWT key promoted", zap.String("kid", id))
+	return nil
+}
+
+// RetireKey retires a key so tokens signed with it stop verifying; the primary key cannot be retired
+func (k *Keyring) RetireKey(ctx context.Context, id string) error {
+	if err := k.repo.RetireKey(ctx, id, map[string]interface{}{
+		"retired_at": k.clock.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to retire jwt key: %w", err)
+	}
+	k.reload(ctx)
+
+	k.logger.Info(ctx, "JWT key retired", zap.String("kid", id))
+	return nil
+}
+
+// lookup returns the key with the ID, reloading the keyring when it is stale or does not know the key
+func (k *Keyring) lookup(ctx context.Context, kid string) (*models.JWTKey, error) {
+	if err := k.refreshIfStale(ctx, keyringRefreshInterval); err != nil {
+		k.logger.Warn(ctx, "Failed to refresh JWT keyring, verifying with cached keys", zap.Error(err))
+	}
+
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	// The key may have been added through another instance since the last refresh
+	if err := k.refreshIfStale(ctx, keyringMissRefreshInterval); err != nil {
+		return nil, fmt.Errorf("failed to load jwt keys: %w", err)
+	}
+	k.mu.RLock()
+	key, ok = k.keys[kid]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown jwt key %s", kid)
+	}
+	return key, nil
+}
+
+// refreshIfStale reloads the keys when they were loaded longer than maxAge ago
+func (k *Keyring) refreshIfStale(ctx context.Context, maxAge time.Duration) error {
+	k.mu.RLock()
+	stale := k.clock.Now().Sub(k.loadedAt) >= maxAge
+	k.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+	return k.refresh(ctx)
+}
+
+// reload refreshes the keys after a change, logging failures since the change itself succeeded
+func (k *Keyring) reload(ctx context.Context) {
+	if err := k.refresh(ctx); err != nil {
+		k.logger.Warn(ctx, "Failed to reload JWT keyring", zap.Error(err))
+	}
+}
+
+// refresh replaces the cached keys with the stored ones
+func (k *Keyring) refresh(ctx context.Context) error {
+	keys, err := k.repo.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*models.JWTKey, len(keys))
+	var primary *models.JWTKey
+	for _, key := range keys {
+		byID[key.ID] = key
+		if key.IsPrimary && !key.IsRetired() {
+			primary = key
+		}
+	}
+
+	k.mu.Lock()
+	k.keys = byID
+	k.primary = primary
+	k.loadedAt = k.clock.Now()
+	k.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+)
+
+// LatencyMetrics exports the end-to-end latency of successful deliveries, from the event being accepted until
+// the receiver accepted it, as a Prometheus histogram labelled by tenant
+// Compliance with a target is the share of observations in the bucket at or below it, so targets are best
+// set to a bucket bound
+type LatencyMetrics struct {
+	endToEnd *prometheus.HistogramVec
+}
+
+// NewLatencyMetrics creates the delivery latency metrics and registers them with the registerer
+// Parameters:
+//   - registerer: Prometheus registerer, typically prometheus.DefaultRegisterer
+//
+// Returns: LatencyMetrics instance, error if the collectors cannot be registered
+func NewLatencyMetrics(registerer prometheus.Registerer) (*LatencyMetrics, error) {
+	m := &LatencyMetrics{
+		endToEnd: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "loki",
+			Subsystem: "delivery",
+			Name:      "end_to_end_seconds",
+			Help:      "Time from an event being accepted until a subscription's receiver accepted it, retries included.",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60, 300, 900, 3600},
+		}, []string{"tenant_id"}),
+	}
+	if err := registerer.Register(m.endToEnd); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// observe records the end-to-end latency of a successful delivery; a nil LatencyMetrics records nothing
+func (m *LatencyMetrics) observe(tenantID string, endToEndMs int64) {
+	if m == nil {
+		return
+	}
+	m.endToEnd.WithLabelValues(tenantID).Observe(float64(endToEndMs) / 1000)
+}
+
+// SetLatencyMetrics exports the end-to-end latency of successful deliveries as Prometheus metrics
+// Parameters:
+//   - metrics: The metrics, nil to stop exporting
+//
+// Purpose: Lets latency SLOs be alerted on from Prometheus as well as reported through the API
+func (s *webhookService) SetLatencyMetrics(metrics *LatencyMetrics) {
+	s.latencyMetrics = metrics
+}
+
+// GetWebhookLatencySLO reports how the deliveries to a webhook met its tenant's latency SLO within a window
+// With a tenant given, subscriptions of other tenants are not found
+func (s *webhookService) GetWebhookLatencySLO(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow) (*models.LatencySLOReport, error) {
+	subscription, err := s.repo.GetSubscriptionByID(repository.WithTenantScope(ctx, tenantID), webhookID)
+	if err != nil {
+		return nil, notFound(ErrWebhookNotFound, err)
+	}
+	return s.latencySLOReport(ctx, subscription.TenantID, &webhookID, window)
+}
+
+// GetTenantLatencySLO reports how the deliveries to all webhooks of a tenant met its latency SLO within a window
+func (s *webhookService) GetTenantLatencySLO(ctx context.Context, tenantID string, window models.StatsWindow) (*models.LatencySLOReport, error) {
+	return s.latencySLOReport(ctx, tenantID, nil, window)
+}
+
+// latencySLOReport measures the successful deliveries of a window against the tenant's latency SLO
+func (s *webhookService) latencySLOReport(ctx context.Context, tenantID string, webhookID *uuid.UUID, window models.StatsWindow) (*models.LatencySLOReport, error) {
+	duration, ok := window.Duration()
+	if !ok {
+		return nil, ErrInvalidStatsWindow.Withf("unknown stats window %q", window)
+	}
+	var since *time.Time
+	if duration > 0 {
+		start := s.clock.Now().Add(-duration)
+		since = &start
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+	slo := settings.LatencySLO.OrDefault()
+
+	report, err := s.repo.GetLatencySLOStats(ctx, tenantID, webhookID, since, slo.TargetMs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate delivery latencies: %w", err)
+	}
+
+	report.TenantID = tenantID
+	report.WebhookID = webhookID
+	report.Window = window
+	report.Since = since
+	report.TargetMs = slo.TargetMs
+	report.Objective = slo.Objective
+	applyLatencyObjective(&report.LatencySLOStats, slo.Objective)
+	for i := range report.Subscriptions {
+		applyLatencyObjective(&report.Subscriptions[i].LatencySLOStats, slo.Objective)
+	}
+	sort.SliceStable(report.Subscriptions, func(i, j int) bool {
+		return *report.Subscriptions[i].Compliance < *report.Subscriptions[j].Compliance
+	})
+	return report, nil
+}
+
+// applyLatencyObjective derives the compliance of measured deliveries and whether it meets the objective
+// Without deliveries nothing missed the target, so the objective is met
+func applyLatencyObjective(stats *models.LatencySLOStats, objective float64) {
+	stats.Compliance = nil
+	stats.MeetsObjective = true
+	if stats.Deliveries == 0 {
+		return
+	}
+	compliance := float64(stats.WithinTarget) / float64(stats.Deliveries) * 100
+	stats.Compliance = &compliance
+	stats.MeetsObjective = compliance >= objective
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidListPage is returned for a list page with a sort the list does not support or a malformed cursor
+var ErrInvalidListPage = apperr.Validation("invalid_list_page", "invalid list page")
+
+// cursorToken is the JSON of the opaque cursors of lists
+// It keeps the sort and order of its list, so following pages need not repeat them and cannot change them
+type cursorToken struct {
+	Sort      models.ListSort `json:"s,omitempty"`
+	Ascending bool            `json:"a,omitempty"`
+	Value     string          `json:"v,omitempty"`
+	CreatedAt time.Time       `json:"t"`
+	ID        uuid.UUID       `json:"id"`
+}
+
+// listPage converts a requested page of a list sortable by sorts to a repository page
+// The page number and size are normalized in the request, to be echoed in the response
+func listPage(request *models.ListPage, sorts ...models.ListSort) (repository.Page, error) {
+	if request.Page < 1 {
+		request.Page = 1
+	}
+	if request.Limit < 1 || request.Limit > 100 {
+		request.Limit = 10
+	}
+
+	page := repository.Page{
+		Offset:    (request.Page - 1) * request.Limit,
+		Limit:     request.Limit,
+		Sort:      request.Sort,
+		Ascending: request.Order == models.ListOrderAsc,
+	}
+	if request.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(request.Cursor)
+		var token cursorToken
+		if err == nil {
+			err = json.Unmarshal(raw, &token)
+		}
+		if err != nil || token.ID == uuid.Nil {
+			return page, ErrInvalidListPage.Withf("malformed cursor")
+		}
+		if (request.Sort != "" && request.Sort != token.Sort) || (request.Order != "" && page.Ascending != token.Ascending) {
+			return page, ErrInvalidListPage.Withf("the cursor continues a list in another order")
+		}
+		page.Sort, page.Ascending, page.Offset = token.Sort, token.Ascending, 0
+		page.After = &repository.Cursor{Value: token.Value, CreatedAt: token.CreatedAt, ID: token.ID}
+	}
+
+	if page.Sort == "" {
+		page.Sort = models.ListSortCreatedAt
+	}
+	if page.Sort != models.ListSortCreatedAt && !slices.Contains(sorts, page.Sort) {
+		return page, ErrInvalidListPage.Withf("the list cannot be sorted by %s", page.Sort)
+	}
+	return page, nil
+}
+
+// encodeCursor returns the opaque cursor continuing a list in the order of page after a record, empty without one
+func encodeCursor(page repository.Page, cursor *repository.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(cursorToken{
+		Sort:      page.Sort,
+		Ascending: page.Ascending,
+		Value:     cursor.Value,
+		CreatedAt: cursor.CreatedAt,
+		ID:        cursor.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
@@ -0,0 +1,9 @@
+package service
+
+import (
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+)
+
+// ErrVersionConflict is returned for updates of chains and subscriptions based on a lock_version the resource
+// is no longer at, because another change was made since it was read
+var ErrVersionConflict = repository.ErrVersionConflict
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/email"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/sms"
+)
+
+// maxRecipients is how many recipients an email or SMS target may have
+const maxRecipients = 20
+
+var (
+	// errEmailNotConfigured is returned when delivering to an email target without an SMTP server
+	errEmailNotConfigured = errors.New("email delivery is not configured")
+
+	// errSMSNotConfigured is returned when delivering to an SMS target without an SMS provider
+	errSMSNotConfigured = errors.New("SMS delivery is not configured")
+)
+
+// EmailSender sends email; *email.Sender implements it
+type EmailSender interface {
+	// Send returns once the server has accepted the message; permanent refusals wrap email.ErrRejected
+	Send(ctx context.Context, msg email.Message) error
+}
+
+// SMSSender sends text messages through an SMS provider; *sms.Twilio implements it
+type SMSSender interface {
+	// Send returns once the provider has accepted the message; permanent refusals wrap sms.ErrRejected
+	Send(ctx context.Context, msg sms.Message) error
+}
+
+// messageTargetURL describes an email or SMS target in the target_url of its subscription and delivery results
+func messageTargetURL(targetType models.TargetType, recipients []string) string {
+	if targetType == models.TargetTypeEmail {
+		return "mailto:" + strings.Join(recipients, ",")
+	}
+	return "sms:" + strings.Join(recipients, ",")
+}
+
+// validateRecipients checks the recipients of an email or SMS target
+// Returns the recipients normalized: email addresses without display names
+func validateRecipients(targetType models.TargetType, recipients []string) ([]string, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("recipients are required for %s targets", targetType)
+	}
+	if len(recipients) > maxRecipients {
+		return nil, fmt.Errorf("at most %d recipients are allowed", maxRecipients)
+	}
+
+	normalized := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if targetType == models.TargetTypeEmail {
+			address, err := mail.ParseAddress(recipient)
+			if err != nil {
+				return nil, fmt.Errorf("invalid email address %q: %w", recipient, err)
+			}
+			normalized = append(normalized, address.Address)
+			continue
+		}
+		if !sms.ValidNumber(recipient) {
+			return nil, fmt.Errorf("invalid phone number %q, must be in E.164 format such as +14155550123", recipient)
+		}
+		normalized = append(normalized, recipient)
+	}
+	return normalized, nil
+}
+
+// messageTransport sends events as email or text messages to a subscription's recipients
+type messageTransport struct {
+	env        *transportEnv
+	targetType models.TargetType
+}
+
+// messageState is what a delivery to a message target keeps between attempts
+type messageState struct {
+	email   email.Message
+	sms     string
+	pending []string
+	refused []string
+}
+
+// Validate requires valid recipients, checks the notification template and describes the recipients in
+// the target URL
+func (t *messageTransport) Validate(req *models.SubscribeWebhookRequest, subscription *models.WebhookSubscription) error {
+	recipients, err := validateRecipients(t.targetType, req.Recipients)
+	if err != nil {
+		return err
+	}
+	if err := validateNotification(t.targetType, req.Notification, ""); err != nil {
+		return fmt.Errorf("invalid notification: %w", err)
+	}
+	subscription.Notification = req.Notification
+	subscription.Recipients = recipients
+	subscription.TargetURL = messageTargetURL(t.targetType, recipients)
+	return nil
+}
+
+// Send renders the event on the first attempt and sends it
+// Text messages are sent one per recipient, and later attempts only resend to recipients that failed
+// transiently, so retries reach no recipient twice; refused recipients fail the delivery once no
+// recipient is left to retry
+func (t *messageTransport) Send(ctx context.Context, d *Delivery) DeliveryOutcome {
+	if d.Raw {
+		return failedDelivery(models.DeliveryErrorRequest, fmt.Errorf("%s targets only receive events", t.targetType), true)
+	}
+
+	state, ok := d.State.(*messageState)
+	if !ok {
+		n, err := renderNotification(d.Subscription, d.Body)
+		if err != nil {
+			return failedDelivery(models.DeliveryErrorRequest, err, true)
+		}
+		state = &messageState{pending: d.Subscription.Recipients}
+		if t.targetType == models.TargetTypeEmail {
+			state.email = emailMessage(n, d.Subscription, d.MessageID, t.env.clock.Now())
+			if d.Test {
+				state.email.Headers[testDeliveryHeader] = "true"
+			}
+		} else {
+			state.sms = smsBody(n)
+		}
+		d.State = state
+	}
+
+	var err error
+	if t.targetType == models.TargetTypeEmail {
+		err = t.sendEmail(ctx, state)
+	} else {
+		err = t.sendSMS(ctx, state)
+	}
+
+	outcome := DeliveryOutcome{SentBody: []byte(state.email.Text)}
+	if t.targetType == models.TargetTypeSMS {
+		outcome.SentBody = []byte(state.sms)
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, errEmailNotConfigured), errors.Is(err, errSMSNotConfigured):
+		outcome.Err = err
+		outcome.ErrorClass = models.DeliveryErrorRequest
+		outcome.Permanent = true
+	case errors.Is(err, email.ErrRejected), errors.Is(err, sms.ErrRejected):
+		outcome.Err = fmt.Errorf("failed to send message: %w", err)
+		outcome.ErrorClass = models.DeliveryErrorRejected
+		outcome.Permanent = true
+	default:
+		outcome.Err = fmt.Errorf("failed to send message: %w", err)
+		outcome.ErrorClass = classifyTransportError(err)
+	}
+	return outcome
+}
+
+// sendEmail sends the rendered email to every recipient at once
+func (t *messageTransport) sendEmail(ctx context.Context, state *messageState) error {
+	if t.env.email == nil {
+		return errEmailNotConfigured
+	}
+	return t.env.email.Send(ctx, state.email)
+}
+
+// sendSMS sends the rendered text to the recipients not reached yet
+func (t *messageTransport) sendSMS(ctx context.Context, state *messageState) error {
+	if t.env.sms == nil {
+		return errSMSNotConfigured
+	}
+
+	var failed []string
+	var errs []error
+	for _, to := range state.pending {
+		err := t.env.sms.Send(ctx, sms.Message{To: to, Body: state.sms})
+		switch {
+		case err == nil:
+		case errors.Is(err, sms.ErrRejected):
+			state.refused = append(state.refused, fmt.Sprintf("%s: %v", to, err))
+		default:
+			failed = append(failed, to)
+			errs = append(errs, fmt.Errorf("%s: %w", to, err))
+		}
+	}
+	state.pending = failed
+
+	if len(errs) > 0 {
+		for _, reason := range state.refused {
+			errs = append(errs, errors.New(reason))
+		}
+		return errors.Join(errs...)
+	}
+	if len(state.refused) > 0 {
+		return fmt.Errorf("%w: %s", sms.ErrRejected, strings.Join(state.refused, "; "))
+	}
+	return nil
+}
+
+// emailMessage builds the email of a rendered event: the text, the fields as "label: value" lines and
+// the event's metadata; critical and error events are marked as high priority
+func emailMessage(n *notification, subscription models.WebhookSubscription, messageID string, now time.Time) email.Message {
+	var text strings.Builder
+	if n.text != "" {
+		text.WriteString(n.text)
+		text.WriteString("\n\n")
+	}
+	for _, field := range n.fields {
+		fmt.Fprintf(&text, "%s: %s\n", field.label, field.value)
+	}
+	if len(n.fields) > 0 {
+		text.WriteString("\n")
+	}
+	fmt.Fprintf(&text, "Severity: %s\nSource: %v\nEvent ID: %v\n", n.severity, n.data["source"], n.data["event_id"])
+
+	msg := email.Message{
+		To:        subscription.Recipients,
+		Subject:   n.title,
+		Text:      text.String(),
+		MessageID: fmt.Sprintf("%s.%s@loki-suite", messageID, subscription.ID),
+		Date:      now,
+		Headers: map[string]string{
+			models.DeliveryIDHeader: messageID,
+			"X-Loki-Event":          fmt.Sprint(n.data["event"]),
+			"X-Loki-Severity":       string(n.severity),
+		},
+	}
+	if n.severity == models.NotificationSeverityCritical || n.severity == models.NotificationSeverityError {
+		msg.Headers["X-Priority"] = "1"
+		msg.Headers["Importance"] = "high"
+	}
+	return msg
+}
+
+// smsBody builds the text message of a rendered event: the title, the text and the fields as
+// "label: value" lines, cut to the length providers accept
+func smsBody(n *notification) string {
+	lines := []string{n.title}
+	if n.text != "" {
+		lines = append(lines, n.text)
+	}
+	for _, field := range n.fields {
+		lines = append(lines, field.label+": "+field.value)
+	}
+	return truncateText(strings.Join(lines, "\n"), sms.MaxBodyLength)
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+const (
+	// defaultNotificationTitle is the title of notifications whose template sets none
+	defaultNotificationTitle = "{{.event}} from {{.source}}"
+
+	// notificationTitleLimit and notificationTextLimit bound rendered titles and texts; Slack rejects longer
+	// header blocks and section texts
+	notificationTitleLimit = 150
+	notificationTextLimit  = 2990
+)
+
+// notificationSeverityStyle is how a severity is shown in Slack and Teams messages
+var notificationSeverityStyle = map[models.NotificationSeverity]struct {
+	emoji string
+	color string
+}{
+	models.NotificationSeverityCritical: {":rotating_light:", "Attention"},
+	models.NotificationSeverityError:    {":red_circle:", "Attention"},
+	models.NotificationSeverityWarning:  {":warning:", "Warning"},
+	models.NotificationSeverityInfo:     {":information_source:", "Accent"},
+}
+
+// notification is an event rendered per a subscription's notification template
+type notification struct {
+	title    string
+	text     string
+	fields   []notificationField
+	severity models.NotificationSeverity
+	dedupKey string
+	data     map[string]interface{}
+}
+
+// notificationField is a rendered label and value pair of a notification
+type notificationField struct {
+	label string
+	value string
+}
+
+// validateNotification checks the notification settings of a subscription with a notification target
+// Returns:
+//   - error: If a template does not parse, a severity is unknown or a PagerDuty target has no routing key
+func validateNotification(targetType models.TargetType, tmpl *models.NotificationTemplate, routingKey string) error {
+	if targetType == models.TargetTypePagerDuty && routingKey == "" {
+		return fmt.Errorf("pagerduty_routing_key is required for PagerDuty targets")
+	}
+	if tmpl == nil {
+		return nil
+	}
+
+	templates := map[string]string{"title": tmpl.Title, "text": tmpl.Text, "dedup_key": tmpl.DedupKey}
+	for label, field := range tmpl.Fields {
+		templates["fields."+label] = field
+	}
+	for name, text := range templates {
+		if _, err := parseNotificationTemplate(name, text); err != nil {
+			return err
+		}
+	}
+
+	if !tmpl.Severity.IsValid() {
+		return fmt.Errorf("invalid severity: %s", tmpl.Severity)
+	}
+	for value, severity := range tmpl.SeverityMap {
+		if severity == "" || !severity.IsValid() {
+			return fmt.Errorf("invalid severity for %q: %s", value, severity)
+		}
+	}
+	return nil
+}
+
+// parseNotificationTemplate parses one template of a notification template
+func parseNotificationTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(stepTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("notification %s: invalid template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// notificationTransport posts events to Slack, Teams and PagerDuty as the messages their providers expect
+// Raw bodies, such as the payloads of chain steps, are posted unchanged
+type notificationTransport struct {
+	http       *httpTransport
+	targetType models.TargetType
+}
+
+// Validate checks the notification template and, for PagerDuty, requires a routing key and defaults the
+// target URL to the Events API
+func (t *notificationTransport) Validate(req *models.SubscribeWebhookRequest, subscription *models.WebhookSubscription) error {
+	if err := validateNotification(t.targetType, req.Notification, req.PagerDutyRoutingKey); err != nil {
+		return fmt.Errorf("invalid notification: %w", err)
+	}
+	subscription.Notification = req.Notification
+	if t.targetType == models.TargetTypePagerDuty {
+		subscription.PagerDutyRoutingKey = &req.PagerDutyRoutingKey
+		if subscription.TargetURL == "" {
+			subscription.TargetURL = models.PagerDutyEventsURL
+		}
+	}
+	return validateHTTPTarget(subscription.TargetURL)
+}
+
+// Send renders the event as the provider's message and posts it
+func (t *notificationTransport) Send(ctx context.Context, d *Delivery) DeliveryOutcome {
+	if d.Raw {
+		return t.http.Send(ctx, d)
+	}
+	body, err := t.render(d.Subscription, d.Body)
+	if err != nil {
+		return failedDelivery(models.DeliveryErrorRequest, err, true)
+	}
+	rendered := *d
+	rendered.Body = body
+	return t.http.Send(ctx, &rendered)
+}
+
+// render turns an event envelope into the body the provider expects
+func (t *notificationTransport) render(subscription models.WebhookSubscription, payload []byte) ([]byte, error) {
+	n, err := renderNotification(subscription, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var body interface{}
+	switch t.targetType {
+	case models.TargetTypeSlack:
+		body = slackMessage(n)
+	case models.TargetTypeTeams:
+		body = teamsMessage(n)
+	case models.TargetTypePagerDuty:
+		routingKey := ""
+		if subscription.PagerDutyRoutingKey != nil {
+			routingKey = *subscription.PagerDutyRoutingKey
+		}
+		body = pagerDutyEvent(n, routingKey)
+	}
+	return json.Marshal(body)
+}
+
+// renderNotification renders the templates of a subscription against an event envelope
+func renderNotification(subscription models.WebhookSubscription, payload []byte) (*notification, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode event for notification: %w", err)
+	}
+
+	tmpl := subscription.Notification
+	if tmpl == nil {
+		tmpl = &models.NotificationTemplate{}
+	}
+	render := func(name, text, fallback string) (string, error) {
+		if text == "" {
+			text = fallback
+		}
+		if text == "" {
+			return "", nil
+		}
+		parsed, err := parseNotificationTemplate(name, text)
+		if err != nil {
+			return "", err
+		}
+		var out bytes.Buffer
+		if err := parsed.Execute(&out, data); err != nil {
+			return "", fmt.Errorf("notification %s: %w", name, err)
+		}
+		// Fields missing from an event render as nothing rather than failing the delivery
+		return strings.ReplaceAll(out.String(), "<no value>", ""), nil
+	}
+
+	n := &notification{data: data}
+	var err error
+	if n.title, err = render("title", tmpl.Title, defaultNotificationTitle); err != nil {
+		return nil, err
+	}
+	if n.text, err = render("text", tmpl.Text, ""); err != nil {
+		return nil, err
+	}
+	if n.dedupKey, err = render("dedup_key", tmpl.DedupKey, "{{.event_id}}"); err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(tmpl.Fields))
+	for label := range tmpl.Fields {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		value, err := render("fields."+label, tmpl.Fields[label], "")
+		if err != nil {
+			return nil, err
+		}
+		n.fields = append(n.fields, notificationField{label: label, value: value})
+	}
+
+	n.title = truncateText(n.title, notificationTitleLimit)
+	n.text = truncateText(n.text, notificationTextLimit)
+
+	// Without a text or fields the message would only carry its title, so show the payload instead
+	if n.text == "" && len(n.fields) == 0 {
+		if encoded, err := json.MarshalIndent(data["payload"], "", "  "); err == nil {
+			n.text = truncateText(string(encoded), notificationTextLimit)
+			if subscription.TargetType == models.TargetTypeSlack {
+				n.text = "```" + n.text + "```"
+			}
+		}
+	}
+	n.severity = notificationSeverity(subscription.TargetType, tmpl, data)
+	return n, nil
+}
+
+// notificationSeverity returns the severity of an event: the mapped value of the severity field, the
+// field's value if it is a severity name, or the template's default severity
+func notificationSeverity(targetType models.TargetType, tmpl *models.NotificationTemplate, data map[string]interface{}) models.NotificationSeverity {
+	if tmpl.SeverityField != "" {
+		if value, ok := lookupTemplatePath(data, tmpl.SeverityField); ok && value != nil {
+			key := fmt.Sprint(value)
+			if severity, ok := tmpl.SeverityMap[key]; ok {
+				return severity
+			}
+			if severity := models.NotificationSeverity(strings.ToLower(key)); severity != "" && severity.IsValid() {
+				return severity
+			}
+		}
+	}
+	if tmpl.Severity != "" {
+		return tmpl.Severity
+	}
+	if targetType == models.TargetTypePagerDuty {
+		return models.NotificationSeverityError
+	}
+	return models.NotificationSeverityInfo
+}
+
+// slackMessage builds a Slack incoming webhook message with Block Kit blocks; text is the fallback shown
+// in notifications
+func slackMessage(n *notification) map[string]interface{} {
+	style := notificationSeverityStyle[n.severity]
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": n.title, "emoji": true},
+		},
+	}
+	if n.text != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": n.text},
+		})
+	}
+
+	// Slack allows at most 10 fields per section
+	for start := 0; start < len(n.fields); start += 10 {
+		end := min(start+10, len(n.fields))
+		fields := make([]interface{}, 0, end-start)
+		for _, field := range n.fields[start:end] {
+			fields = append(fields, map[string]interface{}{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n%s", field.label, field.value)})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+
+	blocks = append(blocks, map[string]interface{}{
+		"type": "context",
+		"elements": []interface{}{
+			map[string]interface{}{"type": "mrkdwn", "text": fmt.Sprintf("%s %s | %v | %v", style.emoji, n.severity, n.data["source"], n.data["event_id"])},
+		},
+	})
+
+	return map[string]interface{}{
+		"text":   fmt.Sprintf("%s %s", style.emoji, n.title),
+		"blocks": blocks,
+	}
+}
+
+// teamsMessage builds a Teams message carrying an Adaptive Card, as accepted by incoming webhooks and workflows
+func teamsMessage(n *notification) map[string]interface{} {
+	body := []interface{}{
+		map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   n.title,
+			"size":   "Medium",
+			"weight": "Bolder",
+			"color":  notificationSeverityStyle[n.severity].color,
+			"wrap":   true,
+		},
+	}
+	if n.text != "" {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": n.text, "wrap": true})
+	}
+
+	facts := []interface{}{map[string]interface{}{"title": "Severity", "value": string(n.severity)}}
+	for _, field := range n.fields {
+		facts = append(facts, map[string]interface{}{"title": field.label, "value": field.value})
+	}
+	facts = append(facts,
+		map[string]interface{}{"title": "Source", "value": fmt.Sprint(n.data["source"])},
+		map[string]interface{}{"title": "Event ID", "value": fmt.Sprint(n.data["event_id"])})
+	body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			},
+		},
+	}
+}
+
+// pagerDutyEvent builds a trigger event of the PagerDuty Events API v2
+// The fields and the event payload are sent as custom details
+func pagerDutyEvent(n *notification, routingKey string) map[string]interface{} {
+	details := map[string]interface{}{"payload": n.data["payload"]}
+	for _, field := range n.fields {
+		details[field.label] = field.value
+	}
+	if n.text != "" {
+		details["text"] = n.text
+	}
+
+	event := map[string]interface{}{
+		"summary":        n.title,
+		"source":         fmt.Sprint(n.data["source"]),
+		"severity":       string(n.severity),
+		"custom_details": details,
+	}
+	if event["source"] == "" {
+		event["source"] = "loki-suite"
+	}
+	if timestamp, ok := n.data["timestamp"].(string); ok && timestamp != "" {
+		event["timestamp"] = timestamp
+	}
+	if name, ok := n.data["event"].(string); ok {
+		event["class"] = name
+	}
+
+	return map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    n.dedupKey,
+		"payload":      event,
+		"client":       "Loki Suite",
+	}
+}
+
+// truncateText shortens a text to at most limit characters, ending it with an ellipsis when cut
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
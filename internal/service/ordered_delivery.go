@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// orderedDeliveryClaimTimeout is how long an ordered delivery can be in flight before its sender is presumed
+// gone and another instance takes it over; it outlasts the retries of any reasonable retry policy
+const orderedDeliveryClaimTimeout = 15 * time.Minute
+
+// orderedSend is a delivery of an event with an ordering key, queued once the event's outcome is stored
+type orderedSend struct {
+	index        int
+	subscription models.WebhookSubscription
+	headers      models.SigningHeaders
+	payload      []byte
+}
+
+// sendInOrder queues an event's deliveries behind the earlier deliveries with the same ordering key, and sends
+// each subscription's queue as far as it can go; deliveries of the event sent here have their result updated
+// The event must be stored first, as another sender may record the outcome of its deliveries at any time
+func (s *webhookService) sendInOrder(ctx context.Context, event *models.WebhookEvent, sends []orderedSend, result *models.EventProcessingResult) {
+	for _, send := range sends {
+		delivery := &models.OrderedDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: send.subscription.ID,
+			EventID:        event.ID,
+			TenantID:       event.TenantID,
+			OrderingKey:    event.OrderingKey,
+			ExpiresAt:      event.ExpiresAt,
+			Payload:        string(send.payload),
+			CreatedAt:      s.clock.Now(),
+		}
+		if err := s.repo.CreateOrderedDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+			s.logger.Error(ctx, "Failed to queue ordered delivery",
+				zap.String("webhook_id", send.subscription.ID.String()),
+				zap.String("event_id", event.ID.String()),
+				zap.Error(err))
+			errMsg := fmt.Sprintf("failed to queue delivery in order: %v", err)
+			failed := models.WebhookDeliveryResult{
+				WebhookID: send.subscription.ID,
+				TargetURL: send.subscription.TargetURL,
+				Error:     &errMsg,
+			}
+			s.recordQueuedDeliveryOutcome(ctx, event.ID, failed)
+			settleOrderedResult(result, send.index, failed)
+			continue
+		}
+
+		// Deliveries to paused subscriptions wait for the releaser once the pause is over
+		if send.subscription.PausedUntil != nil {
+			continue
+		}
+		sent := s.sendOrderedDeliveries(ctx, send.subscription, send.headers, event.OrderingKey)
+		if deliveryResult, ok := sent[event.ID]; ok {
+			settleOrderedResult(result, send.index, deliveryResult)
+		}
+	}
+}
+
+// settleOrderedResult replaces the queued result of a delivery with its outcome
+func settleOrderedResult(result *models.EventProcessingResult, index int, deliveryResult models.WebhookDeliveryResult) {
+	result.Webhooks[index] = deliveryResult
+	result.TotalQueued--
+	switch {
+	case deliveryResult.Success:
+		result.TotalSent++
+	case deliveryResult.Expired:
+		result.TotalExpired++
+	default:
+		result.TotalFailed++
+	}
+}
+
+// sendOrderedDeliveries sends a subscription's deliveries with an ordering key oldest first, each after the
+// previous one succeeded or failed its last retry, and records each event's outcome
+// Stops when none is left, the oldest is being sent elsewhere or the receiver requests a pause; a delivery
+// the receiver did not accept because of the pause keeps its place until the pause is released
+// Returns the outcome of the deliveries sent, by event
+func (s *webhookService) sendOrderedDeliveries(ctx context.Context, subscription models.WebhookSubscription, headers models.SigningHeaders, orderingKey string) map[uuid.UUID]models.WebhookDeliveryResult {
+	sent := make(map[uuid.UUID]models.WebhookDeliveryResult)
+	for ctx.Err() == nil {
+		now := s.clock.Now()
+		delivery, err := s.repo.ClaimOrderedDelivery(ctx, subscription.ID, orderingKey, now, now.Add(-orderedDeliveryClaimTimeout))
+		if err != nil {
+			s.logger.Error(ctx, "Failed to claim ordered delivery",
+				zap.String("webhook_id", subscription.ID.String()),
+				zap.String("ordering_key", orderingKey),
+				zap.Error(err))
+			return sent
+		}
+		if delivery == nil {
+			return sent
+		}
+
+		result, pause := s.sendWebhookToSubscription(ctx, subscription, headers, delivery.EventID, delivery.ExpiresAt, []byte(delivery.Payload), delivery.CreatedAt)
+		if pause > 0 {
+			result.PausedUntil = s.pauseSubscription(ctx, subscription, pause)
+		}
+		if !result.Success && (result.PausedUntil != nil || ctx.Err() != nil) {
+			if err := s.repo.ReleaseOrderedDelivery(context.WithoutCancel(ctx), delivery.ID); err != nil {
+				s.logger.Error(ctx, "Failed to release ordered delivery",
+					zap.String("delivery_id", delivery.ID.String()),
+					zap.Error(err))
+			}
+			return sent
+		}
+
+		// A delivery left claimed is sent again once its claim goes stale, holding back the key until then
+		deleteErr := s.repo.DeleteOrderedDelivery(context.WithoutCancel(ctx), delivery.ID)
+		if deleteErr != nil {
+			s.logger.Error(ctx, "Failed to remove sent ordered delivery",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(deleteErr))
+		}
+		s.recordQueuedDeliveryOutcome(ctx, delivery.EventID, result)
+		sent[delivery.EventID] = result
+
+		if deleteErr != nil || pause > 0 {
+			return sent
+		}
+	}
+	return sent
+}
+
+// ReleaseOrderedDeliveries sends the queues of ordered deliveries nobody is sending
+// Those are left when a receiver's pause ends, or when the instance sending them went away; queues are
+// picked up again once their oldest delivery is unclaimed or its claim is older than the claim timeout
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//
+// Returns:
+//   - int: Number of ordered deliveries sent
+//   - error: If the stalled queues or the delivery pauses cannot be loaded
+func (s *webhookService) ReleaseOrderedDeliveries(ctx context.Context) (int, error) {
+	heads, err := s.repo.GetStalledOrderedDeliveries(ctx, s.clock.Now().Add(-orderedDeliveryClaimTimeout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load stalled ordered deliveries: %w", err)
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, head := range heads {
+		if ctx.Err() != nil {
+			break
+		}
+		// Queues of tenants whose deliveries are paused are released once the delivery pause is resumed
+		if pauses.holds(head.TenantID) {
+			continue
+		}
+		subscription, err := s.repo.GetSubscriptionByID(ctx, head.SubscriptionID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to load webhook of ordered deliveries",
+				zap.String("webhook_id", head.SubscriptionID.String()),
+				zap.Error(err))
+			continue
+		}
+		headers := subscription.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, subscription.TenantID))
+		released += len(s.sendOrderedDeliveries(ctx, *subscription, headers, head.OrderingKey))
+	}
+	return released, nil
+}
+
+// RunOrderedDeliveryReleaser releases stalled ordered deliveries every interval until ctx is cancelled
+// Parameters:
+//   - ctx: Context whose cancellation stops the releaser
+//   - interval: Time between release passes
+func (s *webhookService) RunOrderedDeliveryReleaser(ctx context.Context, interval time.Duration) {
+	for {
+		if released, err := s.ReleaseOrderedDeliveries(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to release ordered webhook deliveries", zap.Error(err))
+		} else if released > 0 {
+			s.logger.Info(ctx, "Released ordered webhook deliveries", zap.Int("released", released))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
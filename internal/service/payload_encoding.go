@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+const (
+	// DefaultPayloadOffloadThreshold is the largest body delivered inline unless configured otherwise, 1 MiB
+	DefaultPayloadOffloadThreshold = 1 << 20
+
+	// DefaultPayloadURLTTL is how long the URLs of offloaded bodies stay valid unless configured otherwise
+	DefaultPayloadURLTTL = 24 * time.Hour
+
+	// offloadKeyPrefix prefixes the object keys of offloaded bodies
+	offloadKeyPrefix = "deliveries/"
+)
+
+// PayloadStore stores the bodies of deliveries too large to send inline; *objectstore.S3 implements it
+type PayloadStore interface {
+	// Put stores a body under a key
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+
+	// PresignGet returns a URL the body stored under a key can be downloaded with until it expires
+	PresignGet(key string, now time.Time, expires time.Duration) (string, error)
+}
+
+// payloadOffload is where and from which size the bodies of HTTP deliveries are offloaded
+type payloadOffload struct {
+	store     PayloadStore
+	threshold int
+	urlTTL    time.Duration
+}
+
+// offloadedBody is what an HTTP delivery whose body was offloaded keeps between attempts
+type offloadedBody struct {
+	body []byte
+}
+
+// SetPayloadOffload stores the bodies of HTTP deliveries larger than a threshold in object storage, and
+// delivers a reference with a presigned URL to them instead
+// Parameters:
+//   - store: The object storage; without one every body is delivered inline
+//   - threshold: Largest body in bytes delivered inline, DefaultPayloadOffloadThreshold if 0
+//   - urlTTL: How long the presigned URLs stay valid, DefaultPayloadURLTTL if 0
+//
+// Purpose: Keeps receivers with request size limits able to take large events
+func (s *webhookService) SetPayloadOffload(store PayloadStore, threshold int, urlTTL time.Duration) {
+	if store == nil {
+		s.env.offload = nil
+		return
+	}
+	if threshold <= 0 {
+		threshold = DefaultPayloadOffloadThreshold
+	}
+	if urlTTL <= 0 {
+		urlTTL = DefaultPayloadURLTTL
+	}
+	s.env.offload = &payloadOffload{store: store, threshold: threshold, urlTTL: urlTTL}
+}
+
+// deliveryBody returns the body an HTTP delivery is sent with: its own, or a reference to it once it was
+// offloaded for being larger than the threshold
+// A body is stored on the first attempt; later attempts send the same reference
+func (e *transportEnv) deliveryBody(ctx context.Context, d *Delivery) ([]byte, error) {
+	if state, ok := d.State.(*offloadedBody); ok {
+		return state.body, nil
+	}
+	if e.offload == nil || len(d.Body) <= e.offload.threshold {
+		return d.Body, nil
+	}
+
+	key := fmt.Sprintf("%s%s/%s/%s.json", offloadKeyPrefix, d.Subscription.TenantID, d.Subscription.ID, d.MessageID)
+	if err := e.offload.store.Put(ctx, key, d.Body, "application/json"); err != nil {
+		return nil, fmt.Errorf("failed to offload body: %w", err)
+	}
+	now := e.clock.Now()
+	presigned, err := e.offload.store.PresignGet(key, now, e.offload.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign offloaded body: %w", err)
+	}
+
+	digest := sha256.Sum256(d.Body)
+	body, err := referenceBody(d, models.PayloadReference{
+		URL:       presigned,
+		Size:      len(d.Body),
+		SHA256:    hex.EncodeToString(digest[:]),
+		ExpiresAt: now.Add(e.offload.urlTTL).UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.State = &offloadedBody{body: body}
+	return body, nil
+}
+
+// referenceBody is what is sent in place of an offloaded body: the event envelope with a payload_ref instead of
+// its payload, or only the payload_ref for bodies that are not envelopes
+func referenceBody(d *Delivery, ref models.PayloadReference) ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if !d.Raw && json.Unmarshal(d.Body, &fields) == nil {
+		delete(fields, "payload")
+	}
+	encoded, err := json.Marshal(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload reference: %w", err)
+	}
+	fields["payload_ref"] = encoded
+	return json.Marshal(fields)
+}
+
+// gzipRequest compresses the body of a signed request; the signature keeps covering the uncompressed body
+func gzipRequest(req *http.Request, body []byte) error {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("failed to compress body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to compress body: %w", err)
+	}
+
+	compressed := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", string(models.ContentEncodingGzip))
+	return nil
+}
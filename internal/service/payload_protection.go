@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// sensitiveFieldColumn is what encrypted sensitive values are authenticated with, so they only decrypt as such
+	sensitiveFieldColumn = "sensitive_field"
+
+	// subjectKeyPrefix marks sensitive values encrypted with the key of their data subject; the key's ID and
+	// the value encrypted by a repository.SecretCipher with the key follow
+	subjectKeyPrefix = "enc:subject:"
+)
+
+// A tenant's sensitive fields are protected in every stored copy of its event payloads, chain trigger data and
+// step requests, while deliveries carry them as sent. Copies that are final have redacted fields replaced and
+// encrypted fields encrypted; copies still needed to deliver or run, scheduled events until they are delivered
+// and the trigger data queued, resumed and retried runs start from, have both encrypted, or kept as sent
+// without an encryption key. Encrypted values are stored as strings and decrypted when the copy is used.
+// Tenants with a subject path encrypt the values of payloads identifying a data subject with the subject's
+// own key; once the subject is erased its key is gone and the values read as [ERASED]
+
+// validateSensitiveFields checks the sensitive fields of a tenant settings request
+// Returns the fields with their default action filled in
+func validateSensitiveFields(fields []models.SensitiveField) ([]models.SensitiveField, error) {
+	if len(fields) > models.MaxSensitiveFields {
+		return nil, fmt.Errorf("at most %d sensitive fields can be set", models.MaxSensitiveFields)
+	}
+	validated := make([]models.SensitiveField, 0, len(fields))
+	for _, field := range fields {
+		if _, err := parseJSONPath(field.Path); err != nil {
+			return nil, fmt.Errorf("sensitive_fields: %w", err)
+		}
+		if field.Action == "" {
+			field.Action = models.SensitiveFieldRedact
+		}
+		if !field.Action.IsValid() {
+			return nil, fmt.Errorf("sensitive_fields: invalid action %q of %s, expected redact or encrypt", field.Action, field.Path)
+		}
+		if field.Action == models.SensitiveFieldEncrypt && repository.ConfiguredCipher() == nil {
+			return nil, fmt.Errorf("sensitive_fields: encrypting %s requires an encryption key to be configured", field.Path)
+		}
+		validated = append(validated, field)
+	}
+	return validated, nil
+}
+
+// validateSubjectPath checks the subject path of a tenant settings request, empty to clear it
+func validateSubjectPath(subjectPath string) error {
+	if subjectPath == "" {
+		return nil
+	}
+	if _, err := parseJSONPath(subjectPath); err != nil {
+		return fmt.Errorf("subject_path: %w", err)
+	}
+	return nil
+}
+
+// subjectHash identifies a data subject without storing its identifier
+func subjectHash(subject string) string {
+	sum := sha256.Sum256([]byte(subject))
+	return hex.EncodeToString(sum[:])
+}
+
+// subjectCipher encrypts the sensitive values of one data subject with the subject's key
+type subjectCipher struct {
+	id     uuid.UUID
+	cipher *repository.SecretCipher
+}
+
+// payloadProtection is a tenant's sensitive fields, applied to payloads before they are stored
+type payloadProtection struct {
+	fields []models.SensitiveField
+	logger logging.Logger
+
+	// tenantID and keys resolve the subject keys of the tenant's data subjects
+	tenantID string
+	keys     repository.TenantRepository
+
+	// subjectPath selects the value identifying the data subject of a payload, nil without one
+	subjectPath []string
+
+	// subject encrypts the values of payloads without a subject of their own, set by forSubject
+	subject *subjectCipher
+}
+
+// newPayloadProtection returns the protection of the tenant with the settings, none without settings
+func newPayloadProtection(logger logging.Logger, keys repository.TenantRepository, settings *models.TenantSettings) payloadProtection {
+	if settings == nil {
+		return payloadProtection{}
+	}
+	protection := payloadProtection{fields: settings.SensitiveFields, logger: logger, tenantID: settings.TenantID, keys: keys}
+	if settings.SubjectPath != "" {
+		protection.subjectPath, _ = parseJSONPath(settings.SubjectPath)
+	}
+	return protection
+}
+
+// tenantPayloadProtection loads the protection of a tenant
+// If the settings cannot be loaded payloads are stored as sent, so deliveries are not blocked
+func tenantPayloadProtection(ctx context.Context, logger logging.Logger, tenantRepo repository.TenantRepository, tenantID string) payloadProtection {
+	settings, err := tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		logger.Warn(ctx, "Failed to load tenant sensitive fields, storing payloads as sent",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		return payloadProtection{}
+	}
+	return newPayloadProtection(logger, tenantRepo, settings)
+}
+
+// forSubject returns the protection of payloads about the data subject a decoded document identifies,
+// e.g. the step requests of a run about the subject of its trigger data
+func (p payloadProtection) forSubject(ctx context.Context, document interface{}) payloadProtection {
+	if len(p.fields) > 0 {
+		p.subject = p.subjectOf(ctx, document)
+	}
+	return p
+}
+
+// subjectOf returns the cipher of the data subject a decoded document identifies at the subject path
+// The subject's key is created with its first protected payload; returns nil without a subject path or an
+// encryption key, when the document has no subject, or when the key cannot be stored
+func (p payloadProtection) subjectOf(ctx context.Context, document interface{}) *subjectCipher {
+	if p.subjectPath == nil || p.keys == nil || repository.ConfiguredCipher() == nil {
+		return nil
+	}
+	value, ok := lookupJSONPath(document, p.subjectPath)
+	if !ok {
+		return nil
+	}
+	subject := subjectIdentifier(value)
+	if subject == "" {
+		return nil
+	}
+
+	material := make([]byte, 32)
+	if _, err := rand.Read(material); err != nil {
+		p.logger.Error(ctx, "Failed to generate subject key", zap.Error(err))
+		return nil
+	}
+	key, err := p.keys.GetOrCreateSubjectKey(ctx, &models.SubjectKey{
+		ID:          uuid.New(),
+		TenantID:    p.tenantID,
+		SubjectHash: subjectHash(subject),
+		Key:         base64.StdEncoding.EncodeToString(material),
+	})
+	if err != nil {
+		p.logger.Error(ctx, "Failed to load subject key, encrypting with the installation key",
+			zap.String("tenant_id", p.tenantID),
+			zap.Error(err))
+		return nil
+	}
+	cipher, err := repository.NewSecretCipher(key.Key)
+	if err != nil {
+		p.logger.Error(ctx, "Invalid subject key, encrypting with the installation key",
+			zap.String("key_id", key.ID.String()),
+			zap.Error(err))
+		return nil
+	}
+	return &subjectCipher{id: key.ID, cipher: cipher}
+}
+
+// subjectIdentifier returns the identifier a subject path selected, empty unless it is a string or number
+func subjectIdentifier(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// protect returns the copy of a JSON document to store, with the sensitive fields under root protected
+// Parameters:
+//   - document: The document as sent
+//   - pending: Whether the copy is still needed to deliver or run, which keeps redacted fields recoverable
+//   - root: Field names leading from the document's root to the payload the paths select from
+//
+// Returns the document unchanged when it is not JSON or has none of the fields; a value that cannot be
+// encrypted is redacted, or kept for pending copies
+func (p payloadProtection) protect(ctx context.Context, document string, pending bool, root ...string) string {
+	if len(p.fields) == 0 || document == "" {
+		return document
+	}
+	decoded, ok := decodeJSONDocument([]byte(document))
+	if !ok {
+		return document
+	}
+
+	cipher := repository.ConfiguredCipher()
+	subject, subjectResolved := p.subject, p.subject != nil
+	protected := false
+	for _, field := range p.fields {
+		segments, err := parseJSONPath(field.Path)
+		if err != nil {
+			continue
+		}
+		encrypt := cipher != nil && (pending || field.Action == models.SensitiveFieldEncrypt)
+		if !encrypt && pending {
+			continue
+		}
+		path := append(append([]string{}, root...), segments...)
+		if replaceJSONPath(decoded, path, func(value interface{}) interface{} {
+			if !encrypt {
+				return redactedValue
+			}
+			// Values of erased subjects stay erased, so the subject is not given a new key
+			if value == models.ErasedValue {
+				return value
+			}
+			// The subject is only looked up, and its key created, once a value is encrypted
+			if !subjectResolved {
+				subject, subjectResolved = p.subjectOf(ctx, lookupRoot(decoded, root)), true
+			}
+			encrypted, err := encryptSensitiveValue(cipher, subject, value)
+			if err != nil {
+				p.logger.Error(ctx, "Failed to encrypt sensitive field, redacting it",
+					zap.String("path", field.Path),
+					zap.Error(err))
+				return redactedValue
+			}
+			return encrypted
+		}) {
+			protected = true
+		}
+	}
+	if !protected {
+		return document
+	}
+
+	encoded, err := encodeJSONDocument(decoded)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode protected payload", zap.Error(err))
+		return document
+	}
+	return string(encoded)
+}
+
+// lookupRoot returns the payload the paths of a document select from, nil if the document has none
+func lookupRoot(document interface{}, root []string) interface{} {
+	if len(root) == 0 {
+		return document
+	}
+	payload, _ := lookupJSONPath(document, root)
+	return payload
+}
+
+// encryptSensitiveValue encrypts a value as JSON, so numbers and objects decrypt to what they were
+// Values are encrypted with the key of their data subject, or with the installation key without one
+func encryptSensitiveValue(cipher *repository.SecretCipher, subject *subjectCipher, value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	if subject == nil {
+		return cipher.Encrypt(sensitiveFieldColumn, string(plaintext))
+	}
+	encrypted, err := subject.cipher.Encrypt(sensitiveFieldColumn, string(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return subjectKeyPrefix + subject.id.String() + ":" + encrypted, nil
+}
+
+// revealer decrypts the sensitive values of a tenant's stored payloads, loading subject keys once per payload
+type revealer struct {
+	logger   logging.Logger
+	tenantID string
+	keys     repository.TenantRepository
+	cipher   *repository.SecretCipher
+	subjects map[uuid.UUID]*repository.SecretCipher
+}
+
+// revealPayload returns a stored copy of a tenant's JSON document with its encrypted sensitive fields decrypted
+// Redacted fields stay redacted and values of erased data subjects read as [ERASED]; values that cannot be
+// decrypted, e.g. without the key they were encrypted with, are kept encrypted and logged
+func revealPayload(ctx context.Context, logger logging.Logger, keys repository.TenantRepository, tenantID, document string) string {
+	if document == "" {
+		return document
+	}
+	decoded, ok := decodeJSONDocument([]byte(document))
+	if !ok {
+		return document
+	}
+	r := &revealer{logger: logger, tenantID: tenantID, keys: keys, cipher: repository.ConfiguredCipher()}
+	revealed, changed := r.value(ctx, decoded)
+	if !changed {
+		return document
+	}
+
+	encoded, err := encodeJSONDocument(revealed)
+	if err != nil {
+		logger.Error(ctx, "Failed to encode revealed payload", zap.Error(err))
+		return document
+	}
+	return string(encoded)
+}
+
+// value decrypts the encrypted strings of a decoded document; returns whether one was decrypted
+func (r *revealer) value(ctx context.Context, value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		cipher, encrypted, ok := r.cipherOf(ctx, v)
+		if !ok {
+			return v, false
+		}
+		if cipher == nil {
+			return models.ErasedValue, true
+		}
+		plaintext, err := cipher.Decrypt(sensitiveFieldColumn, encrypted)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to decrypt sensitive field", zap.Error(err))
+			return v, false
+		}
+		decoded, ok := decodeJSONDocument([]byte(plaintext))
+		if !ok {
+			return v, false
+		}
+		return decoded, true
+	case map[string]interface{}:
+		changed := false
+		for key, child := range v {
+			if revealed, ok := r.value(ctx, child); ok {
+				v[key] = revealed
+				changed = true
+			}
+		}
+		return v, changed
+	case []interface{}:
+		changed := false
+		for i, child := range v {
+			if revealed, ok := r.value(ctx, child); ok {
+				v[i] = revealed
+				changed = true
+			}
+		}
+		return v, changed
+	}
+	return value, false
+}
+
+// cipherOf returns the cipher an encrypted value decrypts with and the value to decrypt, nil for values of
+// erased data subjects; false for values that are not encrypted or whose key is not available
+func (r *revealer) cipherOf(ctx context.Context, value string) (*repository.SecretCipher, string, bool) {
+	if repository.IsEncrypted(value) {
+		if r.cipher == nil {
+			r.logger.Error(ctx, "Stored payload has encrypted fields but no encryption key is configured")
+			return nil, "", false
+		}
+		return r.cipher, value, true
+	}
+	if !strings.HasPrefix(value, subjectKeyPrefix) {
+		return nil, "", false
+	}
+
+	keyID, encrypted, _ := strings.Cut(strings.TrimPrefix(value, subjectKeyPrefix), ":")
+	id, err := uuid.Parse(keyID)
+	if err != nil || r.keys == nil {
+		r.logger.Error(ctx, "Stored payload has a malformed subject encrypted field")
+		return nil, "", false
+	}
+	if cipher, ok := r.subjects[id]; ok {
+		return cipher, encrypted, true
+	}
+	key, err := r.keys.GetSubjectKey(ctx, r.tenantID, id)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to load subject key",
+			zap.String("key_id", keyID),
+			zap.Error(err))
+		return nil, "", false
+	}
+	var cipher *repository.SecretCipher
+	if key != nil {
+		if cipher, err = repository.NewSecretCipher(key.Key); err != nil {
+			r.logger.Error(ctx, "Invalid subject key",
+				zap.String("key_id", keyID),
+				zap.Error(err))
+			return nil, "", false
+		}
+	}
+	if r.subjects == nil {
+		r.subjects = make(map[uuid.UUID]*repository.SecretCipher)
+	}
+	r.subjects[id] = cipher
+	return cipher, encrypted, true
+}
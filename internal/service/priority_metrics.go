@@ -0,0 +1,107 @@
+package service
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// PriorityMetrics exports how events and chain runs of each priority are served as Prometheus metrics labelled
+// by priority, so it can be seen whether high priority traffic waits behind bulk traffic
+type PriorityMetrics struct {
+	events     *prometheus.CounterVec
+	slotWait   *prometheus.HistogramVec
+	waiting    *prometheus.GaugeVec
+	runs       *prometheus.CounterVec
+	runQueuing *prometheus.HistogramVec
+}
+
+// NewPriorityMetrics creates the priority metrics and registers them with the registerer
+// Parameters:
+//   - registerer: Prometheus registerer, typically prometheus.DefaultRegisterer
+//
+// Returns: PriorityMetrics instance, error if the collectors cannot be registered
+func NewPriorityMetrics(registerer prometheus.Registerer) (*PriorityMetrics, error) {
+	waitBuckets := []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 900}
+	m := &PriorityMetrics{
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "delivery",
+			Name:      "events_total",
+			Help:      "Events delivered to their subscriptions, by priority and resulting status.",
+		}, []string{"priority", "status"}),
+		slotWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "loki",
+			Subsystem: "delivery",
+			Name:      "slot_wait_seconds",
+			Help:      "Time events waited for a delivery slot, by priority.",
+			Buckets:   waitBuckets,
+		}, []string{"priority"}),
+		waiting: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "loki",
+			Subsystem: "delivery",
+			Name:      "waiting_events",
+			Help:      "Events currently waiting for a delivery slot, by priority.",
+		}, []string{"priority"}),
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "chain",
+			Name:      "runs_total",
+			Help:      "Chain runs created, by priority.",
+		}, []string{"priority"}),
+		runQueuing: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "loki",
+			Subsystem: "chain",
+			Name:      "run_queue_seconds",
+			Help:      "Time queued chain runs waited in the admission queue before they started, by priority.",
+			Buckets:   waitBuckets,
+		}, []string{"priority"}),
+	}
+	for _, collector := range []prometheus.Collector{m.events, m.slotWait, m.waiting, m.runs, m.runQueuing} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// countEvent records an event delivered with the status; a nil PriorityMetrics records nothing
+func (m *PriorityMetrics) countEvent(priority models.Priority, status models.WebhookStatus) {
+	if m == nil {
+		return
+	}
+	m.events.WithLabelValues(string(priority), string(status)).Inc()
+}
+
+// observeSlotWait records how long an event waited for a delivery slot; a nil PriorityMetrics records nothing
+func (m *PriorityMetrics) observeSlotWait(priority models.Priority, wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.slotWait.WithLabelValues(string(priority)).Observe(wait.Seconds())
+}
+
+// addWaiting adds delta to the events waiting for a delivery slot; a nil PriorityMetrics records nothing
+func (m *PriorityMetrics) addWaiting(priority models.Priority, delta float64) {
+	if m == nil {
+		return
+	}
+	m.waiting.WithLabelValues(string(priority)).Add(delta)
+}
+
+// countRun records a created chain run; a nil PriorityMetrics records nothing
+func (m *PriorityMetrics) countRun(priority models.Priority) {
+	if m == nil {
+		return
+	}
+	m.runs.WithLabelValues(string(priority)).Inc()
+}
+
+// observeRunQueuing records how long a queued run waited before it started; a nil PriorityMetrics records nothing
+func (m *PriorityMetrics) observeRunQueuing(priority models.Priority, wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.runQueuing.WithLabelValues(string(priority)).Observe(wait.Seconds())
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// receiverPause parses the pause a receiver requested through the pause header
+// Returns 0 when the header is absent or not a positive number of seconds; longer pauses are capped
+func receiverPause(header http.Header) time.Duration {
+	value := strings.TrimSpace(header.Get(models.PauseHeader))
+	if value == "" {
+		return 0
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	if seconds > int64(models.MaxReceiverPause/time.Second) {
+		return models.MaxReceiverPause
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// deliverOrQueue delivers an event to a subscription, or queues it while the subscription is paused
+// A receiver that requests a pause has the subscription paused; a delivery it did not accept is queued
+// Deliveries to batching subscriptions are buffered for the subscription's next batch
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - subscription: Subscription to deliver to
+//   - headers: Resolved names of the signature, timestamp and attempt headers
+//   - eventID: ID of the event being delivered
+//   - expiresAt: When the event expires, nil if it does not
+//   - payload: Subscription-specific JSON payload
+//   - acceptedAt: When the event was accepted, from which the end-to-end latency is measured
+//
+// Returns:
+//   - WebhookDeliveryResult: Delivery outcome, with Queued set when the delivery was held back and Batched
+//     when it was buffered for a batch
+func (s *webhookService) deliverOrQueue(ctx context.Context, subscription models.WebhookSubscription, headers models.SigningHeaders, eventID uuid.UUID, expiresAt *time.Time, payload []byte, acceptedAt time.Time) models.WebhookDeliveryResult {
+	// Deliveries keep queueing until the releaser has drained the queue, so they stay in order
+	if subscription.PausedUntil != nil {
+		return s.queueDelivery(ctx, subscription, eventID, expiresAt, payload, models.WebhookDeliveryResult{
+			WebhookID:   subscription.ID,
+			TargetURL:   subscription.TargetURL,
+			PausedUntil: subscription.PausedUntil,
+		})
+	}
+	if subscription.BatchWindowSeconds > 0 {
+		return s.batchDelivery(ctx, subscription, eventID, expiresAt, payload)
+	}
+
+	result, pause := s.sendWebhookToSubscription(ctx, subscription, headers, eventID, expiresAt, payload, acceptedAt)
+	if pause <= 0 {
+		return result
+	}
+
+	result.PausedUntil = s.pauseSubscription(ctx, subscription, pause)
+	if result.Success || result.PausedUntil == nil {
+		return result
+	}
+	return s.queueDelivery(ctx, subscription, eventID, expiresAt, payload, result)
+}
+
+// queueDelivery stores a delivery until the subscription's pause ends
+// The result is marked queued, or failed with the error if the delivery cannot be stored
+func (s *webhookService) queueDelivery(ctx context.Context, subscription models.WebhookSubscription, eventID uuid.UUID, expiresAt *time.Time, payload []byte, result models.WebhookDeliveryResult) models.WebhookDeliveryResult {
+	delivery := &models.QueuedDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		EventID:        eventID,
+		TenantID:       subscription.TenantID,
+		ExpiresAt:      expiresAt,
+		Payload:        string(payload),
+		CreatedAt:      s.clock.Now(),
+	}
+	if err := s.repo.CreateQueuedDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		s.logger.Error(ctx, "Failed to queue delivery for paused webhook",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.String("event_id", eventID.String()),
+			zap.Error(err))
+		errMsg := fmt.Sprintf("failed to queue delivery: %v", err)
+		result.Error = &errMsg
+		return result
+	}
+
+	result.Queued = true
+	result.Error = nil
+	return result
+}
+
+// pauseSubscription pauses deliveries to a subscription for the requested duration
+// and records the pause in the subscription's configuration history
+// Returns the end of the pause, or nil if the pause could not be stored
+func (s *webhookService) pauseSubscription(ctx context.Context, subscription models.WebhookSubscription, pause time.Duration) *time.Time {
+	ctx = context.WithoutCancel(ctx)
+	pausedUntil := s.clock.Now().Add(pause)
+	if err := s.repo.SetSubscriptionPause(ctx, subscription.ID, &pausedUntil); err != nil {
+		s.logger.Error(ctx, "Failed to pause webhook at receiver's request",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.Error(err))
+		return nil
+	}
+
+	s.logger.Info(ctx, "Receiver paused webhook deliveries",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.String("target_url", subscription.TargetURL),
+		zap.Time("paused_until", pausedUntil))
+
+	subscription.PausedUntil = &pausedUntil
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangePaused, subscription)
+	return &pausedUntil
+}
+
+// ReleasePausedDeliveries sends the deliveries queued for subscriptions whose pause has ended
+// Each subscription's queue is sent oldest first; the pause is cleared once the queue is empty,
+// and a receiver requesting another pause while its queue is released is paused again
+// A subscription's queue is released by one instance at a time; the others skip it
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//
+// Returns:
+//   - int: Number of queued deliveries sent
+//   - error: If the paused subscriptions or the delivery pauses cannot be loaded
+func (s *webhookService) ReleasePausedDeliveries(ctx context.Context) (int, error) {
+	subscriptions, err := s.repo.GetSubscriptionsPausedUntil(ctx, s.clock.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to load paused subscriptions: %w", err)
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, subscription := range subscriptions {
+		// Queues of tenants whose deliveries are paused wait for the delivery pause too
+		if pauses.holds(subscription.TenantID) {
+			continue
+		}
+		_, err := tryInstanceLock(ctx, s.locks, pauseReleaseLockPrefix+subscription.ID.String(), func() error {
+			released += s.releasePausedSubscription(ctx, subscription)
+			return nil
+		})
+		if err != nil {
+			s.logger.Error(ctx, "Failed to lock paused webhook for release",
+				zap.String("webhook_id", subscription.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	return released, nil
+}
+
+// releasePausedSubscription sends a paused subscription's queued deliveries and clears its pause
+// Callers hold the subscription's release lock; returns the number of queued deliveries sent
+func (s *webhookService) releasePausedSubscription(ctx context.Context, subscription models.WebhookSubscription) int {
+	if s.locks != nil {
+		// Another instance may have released the queue since the paused subscriptions were loaded
+		current, err := s.repo.GetSubscriptionByID(ctx, subscription.ID)
+		if err != nil || current.PausedUntil == nil || current.PausedUntil.After(s.clock.Now()) {
+			return 0
+		}
+		subscription = *current
+	}
+	headers := subscription.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, subscription.TenantID))
+
+	sent, paused := s.sendQueuedDeliveries(ctx, subscription, headers)
+	if paused || ctx.Err() != nil {
+		return sent
+	}
+
+	if err := s.repo.SetSubscriptionPause(ctx, subscription.ID, nil); err != nil {
+		s.logger.Error(ctx, "Failed to resume paused webhook",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.Error(err))
+		return sent
+	}
+	subscription.PausedUntil = nil
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeResumed, subscription)
+
+	// Deliveries queued while the pause was being cleared
+	late, _ := s.sendQueuedDeliveries(ctx, subscription, headers)
+
+	s.logger.Info(ctx, "Webhook deliveries resumed after receiver pause",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.Int("released", sent+late))
+	return sent + late
+}
+
+// sendQueuedDeliveries sends a subscription's queued deliveries oldest first
+// Stops early, leaving the rest queued, when the receiver requests another pause
+// Returns the number of deliveries sent and whether the subscription was paused again
+func (s *webhookService) sendQueuedDeliveries(ctx context.Context, subscription models.WebhookSubscription, headers models.SigningHeaders) (int, bool) {
+	deliveries, err := s.repo.GetQueuedDeliveries(ctx, subscription.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load queued deliveries",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.Error(err))
+		return 0, true
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return sent, true
+		}
+
+		result, pause := s.sendWebhookToSubscription(ctx, subscription, headers, delivery.EventID, delivery.ExpiresAt, []byte(delivery.Payload), delivery.CreatedAt)
+		if pause > 0 {
+			s.pauseSubscription(ctx, subscription, pause)
+			if !result.Success {
+				return sent, true
+			}
+		}
+
+		if err := s.repo.DeleteQueuedDelivery(ctx, delivery.ID); err != nil {
+			s.logger.Error(ctx, "Failed to remove sent queued delivery",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(err))
+		}
+		s.recordQueuedDeliveryOutcome(ctx, delivery.EventID, result)
+		sent++
+
+		if pause > 0 {
+			return sent, true
+		}
+	}
+	return sent, false
+}
+
+// recordQueuedDeliveryOutcome updates an event after one of its queued, batched or ordered deliveries was sent
+// A failed delivery fails the event and one dropped because the event expired expires a pending event; the
+// event is sent once none of its deliveries are held back
+func (s *webhookService) recordQueuedDeliveryOutcome(ctx context.Context, eventID uuid.UUID, result models.WebhookDeliveryResult) {
+	event, err := s.repo.GetEventByID(ctx, eventID)
+	if err != nil {
+		s.logger.Warn(ctx, "Event of queued delivery not found",
+			zap.String("event_id", eventID.String()),
+			zap.Error(err))
+		return
+	}
+
+	// Dropped deliveries were never attempted
+	if !result.Expired {
+		event.Attempts++
+	}
+	switch {
+	case result.Expired:
+		if event.Status == models.WebhookStatusPending {
+			event.Status = models.WebhookStatusExpired
+			event.LastError = result.Error
+		}
+	case !result.Success:
+		event.Status = models.WebhookStatusFailed
+		event.LastError = result.Error
+	case event.Status == models.WebhookStatusPending:
+		remaining, err := s.heldDeliveries(ctx, eventID)
+		if err == nil && remaining == 0 {
+			now := s.clock.Now()
+			event.Status = models.WebhookStatusSent
+			event.SentAt = &now
+		}
+	}
+
+	if err := s.repo.UpdateEvent(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to update event of queued delivery",
+			zap.String("event_id", eventID.String()),
+			zap.Error(err))
+	}
+}
+
+// heldDeliveries counts the deliveries of an event still queued for a paused subscription, buffered for a batch
+// or waiting for their turn behind earlier deliveries with the same ordering key
+func (s *webhookService) heldDeliveries(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	queued, err := s.repo.CountQueuedDeliveriesByEvent(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	batched, err := s.repo.CountBatchedDeliveriesByEvent(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	ordered, err := s.repo.CountOrderedDeliveriesByEvent(ctx, eventID)
+	return queued + batched + ordered, err
+}
+
+// RunPauseReleaser releases the deliveries of paused subscriptions every interval until ctx is cancelled
+// Parameters:
+//   - ctx: Context whose cancellation stops the releaser
+//   - interval: Time between release passes
+func (s *webhookService) RunPauseReleaser(ctx context.Context, interval time.Duration) {
+	for {
+		if released, err := s.ReleasePausedDeliveries(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to release paused webhook deliveries", zap.Error(err))
+		} else if released > 0 {
+			s.logger.Info(ctx, "Released queued webhook deliveries", zap.Int("released", released))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
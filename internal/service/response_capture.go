@@ -0,0 +1,176 @@
+package service
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultResponseCaptureBytes is how much of a step webhook's answer is stored unless configured otherwise
+	DefaultResponseCaptureBytes = 64 << 10
+
+	// redactedValue replaces what a redactor removes from an answer
+	redactedValue = "[REDACTED]"
+)
+
+// DefaultCapturedContentTypes are the media types of the answers stored unless configured otherwise
+var DefaultCapturedContentTypes = []string{"application/json", "application/*+json", "application/xml", "application/*+xml", "text/*"}
+
+// ResponseRedactor rewrites a webhook's answer before it is stored, e.g. to remove secrets
+// It sees the whole answer, before it is truncated
+type ResponseRedactor func(contentType string, body []byte) []byte
+
+// ResponseCapture is what is stored of the answers of step and compensation webhooks
+// The zero value stores the first DefaultResponseCaptureBytes of answers of the DefaultCapturedContentTypes
+type ResponseCapture struct {
+	// MaxBytes is how much of an answer is stored, DefaultResponseCaptureBytes if 0; longer answers are truncated
+	// and end with a marker saying how much was left out
+	MaxBytes int
+
+	// ContentTypes are the media types of the answers stored, DefaultCapturedContentTypes if empty; patterns
+	// such as "text/*" or "application/*+json" are allowed and "*/*" stores every answer. Answers of other
+	// types are stored as a note of their type and size, answers without a type are stored
+	ContentTypes []string
+
+	// Redact rewrites answers before they are stored, nil stores them as received
+	Redact ResponseRedactor
+}
+
+// body returns what is stored of an answer
+func (c ResponseCapture) body(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if contentType != "" && (err != nil || !c.captures(mediaType)) {
+		return fmt.Sprintf("[%d bytes of %s not captured]", len(body), contentType)
+	}
+	if c.Redact != nil {
+		body = c.Redact(contentType, body)
+	}
+
+	maxBytes := c.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultResponseCaptureBytes
+	}
+	if len(body) <= maxBytes {
+		return string(body)
+	}
+	// Cut between characters so the stored text stays valid UTF-8
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...[truncated %d bytes]", body[:cut], len(body)-cut)
+}
+
+// captures reports whether answers of a media type are stored
+func (c ResponseCapture) captures(mediaType string) bool {
+	patterns := c.ContentTypes
+	if len(patterns) == 0 {
+		patterns = DefaultCapturedContentTypes
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// outcome returns what is stored of an attempt's answer, nil without one, and the attempt's error with the
+// answer it quotes captured the same way
+func (c ResponseCapture) outcome(outcome DeliveryOutcome) (*string, error) {
+	if outcome.ResponseCode == nil {
+		return nil, outcome.Err
+	}
+	body := c.body(outcome.ResponseContentType, outcome.ResponseBody)
+	err := outcome.Err
+	if err != nil && (*outcome.ResponseCode < 200 || *outcome.ResponseCode >= 300) {
+		err = responseStatusError(*outcome.ResponseCode, body)
+	}
+	return &body, err
+}
+
+// SetResponseCapture limits and sanitizes what is stored of the answers of step and compensation webhooks
+// Later steps of a run still see the answers in full
+func (s *executionChainService) SetResponseCapture(capture ResponseCapture) {
+	s.capture = capture
+}
+
+// NewResponseRedactor returns a redactor replacing the values at JSONPath expressions in JSON answers, and then
+// the matches of regular expressions in any answer, with [REDACTED]
+// Paths select object fields and array items from the root, with * selecting all of them:
+// $.token, $.data.credentials.secret, $.items[*].card or $.users.*.password
+// Parameters:
+//   - patterns: Regular expressions in RE2 syntax
+//   - paths: JSONPath expressions
+//
+// Returns:
+//   - ResponseRedactor: The redactor, nil when there are neither patterns nor paths
+//   - error: If a pattern or path is invalid
+func NewResponseRedactor(patterns, paths []string) (ResponseRedactor, error) {
+	var expressions []*regexp.Regexp
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		expressions = append(expressions, expression)
+	}
+	var selectors [][]string
+	for _, jsonPath := range paths {
+		if strings.TrimSpace(jsonPath) == "" {
+			continue
+		}
+		segments, err := parseJSONPath(jsonPath)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, segments)
+	}
+	if len(expressions) == 0 && len(selectors) == 0 {
+		return nil, nil
+	}
+
+	return func(contentType string, body []byte) []byte {
+		if len(selectors) > 0 {
+			body = redactJSONPaths(body, selectors)
+		}
+		for _, expression := range expressions {
+			body = expression.ReplaceAllLiteral(body, []byte(redactedValue))
+		}
+		return body
+	}, nil
+}
+
+// redactJSONPaths replaces the values at the selected paths of a JSON body
+// Bodies that are not JSON, or without any of the paths, are returned unchanged; others are encoded again
+func redactJSONPaths(body []byte, selectors [][]string) []byte {
+	document, ok := decodeJSONDocument(body)
+	if !ok {
+		return body
+	}
+
+	redacted := false
+	for _, segments := range selectors {
+		if replaceJSONPath(document, segments, func(interface{}) interface{} { return redactedValue }) {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return body
+	}
+	encoded, err := encodeJSONDocument(document)
+	if err != nil {
+		return body
+	}
+	return encoded
+}
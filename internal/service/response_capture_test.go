@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sakibcoolz/loki-suite/internal/service"
+)
+
+// TestNewResponseRedactor tests that values at JSONPath expressions and matches of patterns are redacted,
+// and that bodies without them are kept byte for byte
+func TestNewResponseRedactor(t *testing.T) {
+	// Arrange
+	redact, err := service.NewResponseRedactor(
+		[]string{`Bearer [A-Za-z0-9._-]+`},
+		[]string{"$.token", "$.items[*].card", "$.users.*.password", "$.data['secret']"},
+	)
+	assert.NoError(t, err)
+	body := `{"token":"abc","items":[{"card":"4242","id":1}],"users":{"ann":{"password":"p","name":"Ann"}},` +
+		`"data":{"secret":"s","note":"<Bearer xyz>"}}`
+
+	// Act
+	redacted := string(redact("application/json", []byte(body)))
+	untouched := string(redact("application/json", []byte(`{"id": 1,  "ok": true}`)))
+	text := string(redact("text/plain", []byte("Authorization: Bearer xyz.123")))
+
+	// Assert
+	assert.JSONEq(t, `{"token":"[REDACTED]","items":[{"card":"[REDACTED]","id":1}],`+
+		`"users":{"ann":{"password":"[REDACTED]","name":"Ann"}},"data":{"secret":"[REDACTED]","note":"<[REDACTED]>"}}`, redacted)
+	assert.Equal(t, `{"id": 1,  "ok": true}`, untouched)
+	assert.Equal(t, "Authorization: [REDACTED]", text)
+}
+
+// TestNewResponseRedactorRejectsInvalidRules tests that invalid patterns and paths are refused, and that
+// no rules mean no redactor
+func TestNewResponseRedactorRejectsInvalidRules(t *testing.T) {
+	_, err := service.NewResponseRedactor([]string{"("}, nil)
+	assert.ErrorContains(t, err, `invalid redaction pattern "("`)
+
+	_, err = service.NewResponseRedactor(nil, []string{"$.items[0"})
+	assert.ErrorContains(t, err, "unclosed [")
+
+	_, err = service.NewResponseRedactor(nil, []string{"$"})
+	assert.ErrorContains(t, err, "must select a field")
+
+	redact, err := service.NewResponseRedactor([]string{""}, []string{" "})
+	assert.NoError(t, err)
+	assert.Nil(t, redact)
+}
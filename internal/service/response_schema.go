@@ -0,0 +1,388 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// responseSchemaKeywords lists the JSON Schema keywords supported for receiver response validation
+// Schemas using other keywords are rejected when the subscription or step is created,
+// so a typo never silently disables a check
+var responseSchemaKeywords = map[string]bool{
+	"$schema":              true,
+	"title":                true,
+	"description":          true,
+	"type":                 true,
+	"enum":                 true,
+	"const":                true,
+	"properties":           true,
+	"required":             true,
+	"additionalProperties": true,
+	"items":                true,
+	"minItems":             true,
+	"maxItems":             true,
+	"minLength":            true,
+	"maxLength":            true,
+	"pattern":              true,
+	"minimum":              true,
+	"maximum":              true,
+}
+
+// compileResponseSchema checks a receiver response schema and serializes it for storage
+// Parameters:
+//   - schema: JSON Schema object supplied in the request, nil when validation is disabled
+//
+// Returns:
+//   - string: The schema as JSON, empty when schema is nil
+//   - error: If the schema uses unsupported keywords or malformed values
+func compileResponseSchema(schema map[string]interface{}) (string, error) {
+	if schema == nil {
+		return "", nil
+	}
+	if err := checkSchemaNode(schema, "$"); err != nil {
+		return "", err
+	}
+
+	schemaBytes, err := json.Marshal(schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response schema: %w", err)
+	}
+	return string(schemaBytes), nil
+}
+
+// validateResponseBody checks a receiver response body against a stored response schema
+// A body that is not JSON, such as an HTML error page, always violates the schema
+// Parameters:
+//   - schemaJSON: Schema stored by compileResponseSchema, empty when validation is disabled
+//   - body: Raw response body returned by the receiver
+//
+// Returns:
+//   - error: nil if the body conforms, a description of the first violation otherwise
+func validateResponseBody(schemaJSON string, body []byte) error {
+	if schemaJSON == "" {
+		return nil
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+		return fmt.Errorf("invalid response schema: %w", err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("response schema violation: body is not valid JSON")
+	}
+
+	if err := validateSchemaNode(schema, value, "$"); err != nil {
+		return fmt.Errorf("response schema violation: %w", err)
+	}
+	return nil
+}
+
+// checkSchemaNode validates the structure of a schema object and its nested schemas
+func checkSchemaNode(schema map[string]interface{}, path string) error {
+	for keyword, value := range schema {
+		if !responseSchemaKeywords[keyword] {
+			return fmt.Errorf("%s: unsupported schema keyword %q", path, keyword)
+		}
+
+		switch keyword {
+		case "type":
+			for _, name := range schemaTypes(value) {
+				switch name {
+				case "object", "array", "string", "number", "integer", "boolean", "null":
+				default:
+					return fmt.Errorf("%s: unknown type %q", path, name)
+				}
+			}
+		case "enum":
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s: enum must be an array", path)
+			}
+		case "properties":
+			properties, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: properties must be an object", path)
+			}
+			for name, property := range properties {
+				propertySchema, ok := property.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%s.%s: schema must be an object", path, name)
+				}
+				if err := checkSchemaNode(propertySchema, path+"."+name); err != nil {
+					return err
+				}
+			}
+		case "required":
+			names, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s: required must be an array of strings", path)
+			}
+			for _, name := range names {
+				if _, ok := name.(string); !ok {
+					return fmt.Errorf("%s: required must be an array of strings", path)
+				}
+			}
+		case "additionalProperties":
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("%s: additionalProperties must be a boolean", path)
+			}
+		case "items":
+			itemSchema, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: items must be an object", path)
+			}
+			if err := checkSchemaNode(itemSchema, path+"[]"); err != nil {
+				return err
+			}
+		case "minItems", "maxItems", "minLength", "maxLength":
+			if n, ok := value.(float64); !ok || n < 0 || n != math.Trunc(n) {
+				return fmt.Errorf("%s: %s must be a non-negative integer", path, keyword)
+			}
+		case "minimum", "maximum":
+			if _, ok := value.(float64); !ok {
+				return fmt.Errorf("%s: %s must be a number", path, keyword)
+			}
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%s: pattern must be a string", path)
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("%s: invalid pattern: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSchemaNode validates a decoded JSON value against a schema object
+// path locates the value in the response body for error messages; only the first violation is reported
+func validateSchemaNode(schema map[string]interface{}, value interface{}, path string) error {
+	violations := schemaViolations(schema, value, path, 1)
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", violations[0].Path, violations[0].Message)
+}
+
+// schemaViolations validates a decoded JSON value against a schema object and reports its violations
+// Parameters:
+//   - schema: Schema object stored by compileResponseSchema
+//   - value: Decoded JSON value to validate
+//   - path: Location of value for violation paths, "$" for the document root
+//   - limit: Maximum number of violations to collect, so huge documents stay cheap to reject
+//
+// Returns:
+//   - []models.SchemaViolation: Violations in document order, empty if the value conforms
+func schemaViolations(schema map[string]interface{}, value interface{}, path string, limit int) []models.SchemaViolation {
+	collector := &schemaCollector{limit: limit}
+	collector.node(schema, value, path)
+	if len(collector.violations) > limit {
+		return collector.violations[:limit]
+	}
+	return collector.violations
+}
+
+// schemaCollector gathers schema violations until its limit is reached
+type schemaCollector struct {
+	violations []models.SchemaViolation
+	limit      int
+}
+
+// add records a violation at path
+func (c *schemaCollector) add(path, format string, args ...interface{}) {
+	c.violations = append(c.violations, models.SchemaViolation{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// full reports whether the collector stopped accepting violations
+func (c *schemaCollector) full() bool {
+	return len(c.violations) >= c.limit
+}
+
+// node applies a schema object to a decoded JSON value
+// A value of the wrong type is not checked any further, since its other keywords cannot apply
+func (c *schemaCollector) node(schema map[string]interface{}, value interface{}, path string) {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, name := range types {
+			if matchesSchemaType(name, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			c.add(path, "expected %s, got %s", strings.Join(types, " or "), jsonTypeName(value))
+			return
+		}
+	}
+
+	if expected, ok := schema["const"]; ok && !jsonEqual(expected, value) {
+		c.add(path, "expected constant %s", jsonString(expected))
+		return
+	}
+
+	if options, ok := schema["enum"].([]interface{}); ok {
+		matched := false
+		for _, option := range options {
+			if jsonEqual(option, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			c.add(path, "value %s is not one of %s", jsonString(value), jsonString(options))
+			return
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c.object(schema, v, path)
+	case []interface{}:
+		if n, ok := schema["minItems"].(float64); ok && float64(len(v)) < n {
+			c.add(path, "expected at least %d items", int(n))
+		}
+		if n, ok := schema["maxItems"].(float64); ok && float64(len(v)) > n {
+			c.add(path, "expected at most %d items", int(n))
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if c.full() {
+					return
+				}
+				c.node(itemSchema, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case string:
+		length := len([]rune(v))
+		if n, ok := schema["minLength"].(float64); ok && float64(length) < n {
+			c.add(path, "expected at least %d characters", int(n))
+		}
+		if n, ok := schema["maxLength"].(float64); ok && float64(length) > n {
+			c.add(path, "expected at most %d characters", int(n))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				c.add(path, "invalid pattern: %v", err)
+			} else if !re.MatchString(v) {
+				c.add(path, "value does not match pattern %q", pattern)
+			}
+		}
+	case float64:
+		if n, ok := schema["minimum"].(float64); ok && v < n {
+			c.add(path, "value %v is below minimum %v", v, n)
+		}
+		if n, ok := schema["maximum"].(float64); ok && v > n {
+			c.add(path, "value %v is above maximum %v", v, n)
+		}
+	}
+}
+
+// object applies the object keywords of a schema to a decoded JSON object
+func (c *schemaCollector) object(schema map[string]interface{}, object map[string]interface{}, path string) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, present := object[name.(string)]; !present {
+				c.add(path, "missing required property %q", name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	// Iterate in a stable order so the reported violations are deterministic
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if c.full() {
+			return
+		}
+		propertySchema, declared := properties[name].(map[string]interface{})
+		if !declared {
+			if allowed, ok := schema["additionalProperties"].(bool); ok && !allowed {
+				c.add(path, "unexpected property %q", name)
+			}
+			continue
+		}
+		c.node(propertySchema, object[name], path+"."+name)
+	}
+}
+
+// schemaTypes normalizes the "type" keyword, which may be a string or an array of strings
+func schemaTypes(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// matchesSchemaType reports whether a decoded JSON value is of the given schema type
+func matchesSchemaType(name string, value interface{}) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeName(value) == name
+	}
+}
+
+// jsonTypeName returns the schema type name of a decoded JSON value
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return "unknown"
+}
+
+// jsonEqual compares two decoded JSON values by their canonical encoding
+func jsonEqual(a, b interface{}) bool {
+	return jsonString(a) == jsonString(b)
+}
+
+// jsonString encodes a decoded JSON value for comparison and error messages
+func jsonString(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateResponseBody tests every supported keyword against conforming and violating bodies, nested
+// objects and arrays, and that bodies which are not JSON always violate the schema
+func TestValidateResponseBody(t *testing.T) {
+	order := `{
+		"type": "object",
+		"required": ["id", "status"],
+		"properties": {
+			"id": {"type": "string", "pattern": "^ord_[0-9]+$"},
+			"status": {"enum": ["accepted", "queued"]},
+			"customer": {
+				"type": "object",
+				"required": ["tier"],
+				"additionalProperties": false,
+				"properties": {"tier": {"type": "string", "minLength": 3, "maxLength": 8}}
+			},
+			"lines": {
+				"type": "array",
+				"minItems": 1,
+				"maxItems": 2,
+				"items": {"type": "object", "properties": {"quantity": {"type": "integer", "minimum": 1, "maximum": 10}}}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name   string
+		schema string
+		body   string
+		want   string
+	}{
+		{name: "conforming", schema: order,
+			body: `{"id": "ord_1", "status": "queued", "customer": {"tier": "gold"}, "lines": [{"quantity": 2}]}`},
+		{name: "type", schema: `{"type": "object"}`, body: `[1]`,
+			want: "$: expected object, got array"},
+		{name: "type list", schema: `{"type": ["string", "null"]}`, body: `null`},
+		{name: "integer", schema: `{"type": "integer"}`, body: `1.5`,
+			want: "$: expected integer, got number"},
+		{name: "required", schema: order, body: `{"id": "ord_1"}`,
+			want: `$: missing required property "status"`},
+		{name: "properties", schema: order, body: `{"id": 7, "status": "queued"}`,
+			want: "$.id: expected string, got number"},
+		{name: "pattern", schema: order, body: `{"id": "order-1", "status": "queued"}`,
+			want: `$.id: value does not match pattern "^ord_[0-9]+$"`},
+		{name: "enum", schema: order, body: `{"id": "ord_1", "status": "rejected"}`,
+			want: `$.status: value "rejected" is not one of ["accepted","queued"]`},
+		{name: "const", schema: `{"const": "ok"}`, body: `"fine"`,
+			want: `$: expected constant "ok"`},
+		{name: "nested required", schema: order, body: `{"id": "ord_1", "status": "queued", "customer": {}}`,
+			want: `$.customer: missing required property "tier"`},
+		{name: "nested additional property", schema: order,
+			body: `{"id": "ord_1", "status": "queued", "customer": {"tier": "gold", "vip": true}}`,
+			want: `$.customer: unexpected property "vip"`},
+		{name: "minLength", schema: order, body: `{"id": "ord_1", "status": "queued", "customer": {"tier": "b"}}`,
+			want: "$.customer.tier: expected at least 3 characters"},
+		{name: "maxLength", schema: order, body: `{"id": "ord_1", "status": "queued", "customer": {"tier": "platinum+"}}`,
+			want: "$.customer.tier: expected at most 8 characters"},
+		{name: "minItems", schema: order, body: `{"id": "ord_1", "status": "queued", "lines": []}`,
+			want: "$.lines: expected at least 1 items"},
+		{name: "maxItems", schema: order,
+			body: `{"id": "ord_1", "status": "queued", "lines": [{"quantity": 1}, {"quantity": 1}, {"quantity": 1}]}`,
+			want: "$.lines: expected at most 2 items"},
+		{name: "items minimum", schema: order, body: `{"id": "ord_1", "status": "queued", "lines": [{"quantity": 0}]}`,
+			want: "$.lines[0].quantity: value 0 is below minimum 1"},
+		{name: "items maximum", schema: order,
+			body: `{"id": "ord_1", "status": "queued", "lines": [{"quantity": 1}, {"quantity": 11}]}`,
+			want: "$.lines[1].quantity: value 11 is above maximum 10"},
+		{name: "html body", schema: order, body: `<html><body>Bad Gateway</body></html>`,
+			want: "body is not valid JSON"},
+		{name: "empty body", schema: order, body: ``,
+			want: "body is not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			schemaJSON, err := compileResponseSchema(decodeSchema(t, tt.schema))
+			require.NoError(t, err)
+
+			// Act
+			err = validateResponseBody(schemaJSON, []byte(tt.body))
+
+			// Assert
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, "response schema violation: "+tt.want)
+		})
+	}
+
+	t.Run("no schema", func(t *testing.T) {
+		assert.NoError(t, validateResponseBody("", []byte("not json")))
+	})
+}
+
+// TestCompileResponseSchema_Invalid tests that schemas with unsupported keywords or malformed values are rejected
+// with the location of the problem, including inside nested schemas
+func TestCompileResponseSchema_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{name: "unsupported keyword", schema: `{"oneOf": []}`,
+			want: `$: unsupported schema keyword "oneOf"`},
+		{name: "unknown type", schema: `{"type": "float"}`,
+			want: `$: unknown type "float"`},
+		{name: "enum not array", schema: `{"enum": "a"}`,
+			want: "$: enum must be an array"},
+		{name: "required not strings", schema: `{"required": [1]}`,
+			want: "$: required must be an array of strings"},
+		{name: "negative minItems", schema: `{"minItems": -1}`,
+			want: "$: minItems must be a non-negative integer"},
+		{name: "fractional maxLength", schema: `{"maxLength": 1.5}`,
+			want: "$: maxLength must be a non-negative integer"},
+		{name: "minimum not number", schema: `{"minimum": "1"}`,
+			want: "$: minimum must be a number"},
+		{name: "invalid pattern", schema: `{"pattern": "("}`,
+			want: "$: invalid pattern"},
+		{name: "nested property", schema: `{"properties": {"customer": {"properties": {"tier": {"typ": "string"}}}}}`,
+			want: `$.customer.tier: unsupported schema keyword "typ"`},
+		{name: "nested items", schema: `{"items": {"type": "decimal"}}`,
+			want: `$[]: unknown type "decimal"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			schemaJSON, err := compileResponseSchema(decodeSchema(t, tt.schema))
+
+			// Assert
+			assert.ErrorContains(t, err, tt.want)
+			assert.Empty(t, schemaJSON)
+		})
+	}
+}
+
+// decodeSchema decodes a JSON schema as the API decodes the one of a request
+func decodeSchema(t *testing.T, schema string) map[string]interface{} {
+	t.Helper()
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(schema), &decoded))
+	return decoded
+}
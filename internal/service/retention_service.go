@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"go.uber.org/zap"
+)
+
+// DefaultArchivalBatchSize is how many events or runs are archived per transaction by default
+const DefaultArchivalBatchSize = 1000
+
+// ErrArchivalRunning is returned by TriggerArchival while this instance is already archiving
+var ErrArchivalRunning = apperr.Conflict("archival_running", "an archival run is already in progress")
+
+// RetentionConfig configures how long events and chain runs are kept
+type RetentionConfig struct {
+	// DefaultDays is the retention of tenants without their own, 0 keeps their rows forever
+	DefaultDays int
+
+	// ArchiveDays is how long archived rows are kept before they are pruned, 0 keeps them forever
+	ArchiveDays int
+
+	// BatchSize is how many events or runs are archived per transaction, DefaultArchivalBatchSize if 0
+	BatchSize int
+}
+
+// RetentionService manages retention periods and archives expired events and chain runs
+// Finished events and runs older than their tenant's retention are moved to archive tables, keeping the
+// live tables, and the queries on them, small; archives older than the archive retention are pruned
+type RetentionService interface {
+	// GetTenantRetention retrieves a tenant's retention period and the period in effect
+	GetTenantRetention(ctx context.Context, tenantID string) (*models.TenantRetentionResponse, error)
+
+	// SetTenantRetention sets a tenant's retention period, 0 to use the global default
+	SetTenantRetention(ctx context.Context, tenantID string, req *models.TenantRetentionRequest) (*models.TenantRetentionResponse, error)
+
+	// TriggerArchival starts an archival run in the background and returns it
+	// Returns ErrArchivalRunning while this instance is already archiving
+	TriggerArchival(ctx context.Context) (*models.ArchivalRun, error)
+
+	// ListArchivalRuns lists archival runs with pagination, newest first
+	ListArchivalRuns(ctx context.Context, page, limit int) (*models.ArchivalRunListResponse, error)
+
+	// RunArchiver archives expired rows every interval until ctx is cancelled
+	RunArchiver(ctx context.Context, interval time.Duration)
+
+	// SetClock replaces the clock used for ages and timestamps
+	SetClock(clock Clock)
+}
+
+// retentionService implements RetentionService
+type retentionService struct {
+	retentionRepo repository.RetentionRepository
+	tenantRepo    repository.TenantRepository
+	config        RetentionConfig
+	clock         Clock
+	logger        logging.Logger
+
+	// archiving is set while an archival run of this instance is in progress
+	archiving atomic.Bool
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(retentionRepo repository.RetentionRepository, tenantRepo repository.TenantRepository, config RetentionConfig, logger logging.Logger) RetentionService {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultArchivalBatchSize
+	}
+	return &retentionService{
+		retentionRepo: retentionRepo,
+		tenantRepo:    tenantRepo,
+		config:        config,
+		clock:         NewSystemClock(),
+		logger:        logger,
+	}
+}
+
+// SetClock replaces the clock used for ages and timestamps
+func (s *retentionService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// GetTenantRetention retrieves a tenant's retention period and the period in effect
+func (s *retentionService) GetTenantRetention(ctx context.Context, tenantID string) (*models.TenantRetentionResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+	return s.retentionResponse(settings), nil
+}
+
+// SetTenantRetention sets a tenant's retention period, 0 to use the global default
+func (s *retentionService) SetTenantRetention(ctx context.Context, tenantID string, req *models.TenantRetentionRequest) (*models.TenantRetentionResponse, error) {
+	if req.RetentionDays < 0 {
+		return nil, fmt.Errorf("retention_days must not be negative")
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	settings.RetentionDays = req.RetentionDays
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save retention: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant retention updated",
+		zap.String("tenant_id", tenantID),
+		zap.Int("retention_days", settings.RetentionDays))
+
+	return s.retentionResponse(settings), nil
+}
+
+// retentionResponse describes the retention of a tenant's settings
+func (s *retentionService) retentionResponse(settings *models.TenantSettings) *models.TenantRetentionResponse {
+	effective := settings.RetentionDays
+	if effective == 0 {
+		effective = s.config.DefaultDays
+	}
+	return &models.TenantRetentionResponse{
+		TenantID:             settings.TenantID,
+		RetentionDays:        settings.RetentionDays,
+		DefaultRetentionDays: s.config.DefaultDays,
+		EffectiveDays:        effective,
+	}
+}
+
+// TriggerArchival starts an archival run in the background and returns it
+// The run outlives the request; its progress is reported by ListArchivalRuns
+func (s *retentionService) TriggerArchival(ctx context.Context) (*models.ArchivalRun, error) {
+	if !s.archiving.CompareAndSwap(false, true) {
+		return nil, ErrArchivalRunning
+	}
+
+	run, err := s.startArchivalRun(ctx, models.ArchivalTriggerManual)
+	if err != nil {
+		s.archiving.Store(false)
+		return nil, err
+	}
+
+	started := *run
+	go func() {
+		defer s.archiving.Store(false)
+		s.archive(context.WithoutCancel(ctx), run)
+	}()
+	return &started, nil
+}
+
+// ListArchivalRuns lists archival runs with pagination, newest first
+func (s *retentionService) ListArchivalRuns(ctx context.Context, page, limit int) (*models.ArchivalRunListResponse, error) {
+	offset := (page - 1) * limit
+	runs, total, err := s.retentionRepo.ListArchivalRuns(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archival runs: %w", err)
+	}
+
+	return &models.ArchivalRunListResponse{
+		Runs:  runs,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
+
+// RunArchiver archives expired rows every interval until ctx is cancelled
+// Instances archiving at once move disjoint batches, so every instance can run the archiver
+func (s *retentionService) RunArchiver(ctx context.Context, interval time.Duration) {
+	for {
+		if s.archiving.CompareAndSwap(false, true) {
+			if run, err := s.startArchivalRun(ctx, models.ArchivalTriggerScheduled); err != nil {
+				s.logger.Error(ctx, "Failed to start archival run", zap.Error(err))
+			} else {
+				s.archive(ctx, run)
+			}
+			s.archiving.Store(false)
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// startArchivalRun records the start of an archival run
+func (s *retentionService) startArchivalRun(ctx context.Context, trigger models.ArchivalTrigger) (*models.ArchivalRun, error) {
+	run := &models.ArchivalRun{
+		Trigger:   trigger,
+		Status:    models.ArchivalStatusRunning,
+		StartedAt: s.clock.Now(),
+	}
+	if err := s.retentionRepo.CreateArchivalRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to record archival run: %w", err)
+	}
+	return run, nil
+}
+
+// archive archives expired events and chain runs, prunes expired archives and records the outcome on run
+// Cancelling ctx stops the run after the current batch and marks it failed
+func (s *retentionService) archive(ctx context.Context, run *models.ArchivalRun) {
+	err := s.archiveExpired(ctx, run)
+
+	completedAt := s.clock.Now()
+	run.CompletedAt = &completedAt
+	if err != nil {
+		run.Status = models.ArchivalStatusFailed
+		run.Error = err.Error()
+		s.logger.Error(ctx, "Archival run failed", zap.String("archival_run_id", run.ID.String()), zap.Error(err))
+	} else {
+		run.Status = models.ArchivalStatusCompleted
+		s.logger.Info(ctx, "Archival run completed",
+			zap.String("archival_run_id", run.ID.String()),
+			zap.Int64("events_archived", run.EventsArchived),
+			zap.Int64("runs_archived", run.RunsArchived),
+			zap.Int64("archives_pruned", run.ArchivesPruned))
+	}
+
+	if err := s.retentionRepo.UpdateArchivalRun(context.WithoutCancel(ctx), run); err != nil {
+		s.logger.Error(ctx, "Failed to record archival run outcome", zap.String("archival_run_id", run.ID.String()), zap.Error(err))
+	}
+}
+
+// archiveExpired moves expired rows batch by batch, recording the progress on run after every batch
+func (s *retentionService) archiveExpired(ctx context.Context, run *models.ArchivalRun) error {
+	cutoff := repository.RetentionCutoff{Now: run.StartedAt, DefaultDays: s.config.DefaultDays}
+	batchSize := int64(s.config.BatchSize)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("archival interrupted: %w", err)
+		}
+		events, attempts, err := s.retentionRepo.ArchiveEvents(ctx, cutoff, s.config.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to archive events: %w", err)
+		}
+		run.EventsArchived += events
+		run.AttemptsArchived += attempts
+		s.recordProgress(ctx, run)
+		if events < batchSize {
+			break
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("archival interrupted: %w", err)
+		}
+		runs, stepRuns, err := s.retentionRepo.ArchiveChainRuns(ctx, cutoff, s.config.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to archive chain runs: %w", err)
+		}
+		run.RunsArchived += runs
+		run.StepRunsArchived += stepRuns
+		s.recordProgress(ctx, run)
+		if runs < batchSize {
+			break
+		}
+	}
+
+	if s.config.ArchiveDays == 0 {
+		return nil
+	}
+	before := run.StartedAt.AddDate(0, 0, -s.config.ArchiveDays)
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("archival interrupted: %w", err)
+		}
+		pruned, err := s.retentionRepo.PruneArchives(ctx, before, s.config.BatchSize)
+		run.ArchivesPruned += pruned
+		if err != nil {
+			return fmt.Errorf("failed to prune archives: %w", err)
+		}
+		if pruned == 0 {
+			return nil
+		}
+		s.recordProgress(ctx, run)
+	}
+}
+
+// recordProgress stores the counts of a running archival run, so they can be monitored while it runs
+func (s *retentionService) recordProgress(ctx context.Context, run *models.ArchivalRun) {
+	if err := s.retentionRepo.UpdateArchivalRun(ctx, run); err != nil {
+		s.logger.Warn(ctx, "Failed to record archival run progress", zap.String("archival_run_id", run.ID.String()), zap.Error(err))
+	}
+}
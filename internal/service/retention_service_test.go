@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestTriggerArchival tests that expired rows are archived batch by batch, expired archives pruned,
+// the outcome recorded, and that a second run is refused while one is in progress
+func TestTriggerArchival(t *testing.T) {
+	// Arrange
+	retentionRepo := mocks.NewMockRetentionRepository(t)
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	svc := service.NewRetentionService(retentionRepo, tenantRepo, service.RetentionConfig{
+		DefaultDays: 30,
+		ArchiveDays: 365,
+		BatchSize:   2,
+	}, logging.Nop())
+
+	release := make(chan struct{})
+	done := make(chan models.ArchivalRun, 1)
+	var startedAt time.Time
+
+	retentionRepo.EXPECT().CreateArchivalRun(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, run *models.ArchivalRun) error {
+			startedAt = run.StartedAt
+			return nil
+		}).Once()
+	retentionRepo.EXPECT().UpdateArchivalRun(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, run *models.ArchivalRun) error {
+			if run.Status != models.ArchivalStatusRunning {
+				done <- *run
+			}
+			return nil
+		})
+
+	// A full batch means more rows may be expired, so events are archived until a batch comes back short
+	retentionRepo.EXPECT().ArchiveEvents(mock.Anything, mock.Anything, 2).
+		RunAndReturn(func(_ context.Context, cutoff repository.RetentionCutoff, _ int) (int64, int64, error) {
+			<-release
+			assert.Equal(t, 30, cutoff.DefaultDays)
+			return 2, 5, nil
+		}).Once()
+	retentionRepo.EXPECT().ArchiveEvents(mock.Anything, mock.Anything, 2).Return(1, 1, nil).Once()
+	retentionRepo.EXPECT().ArchiveChainRuns(mock.Anything, mock.Anything, 2).Return(1, 4, nil).Once()
+	retentionRepo.EXPECT().PruneArchives(mock.Anything, mock.Anything, 2).Return(3, nil).Once()
+	retentionRepo.EXPECT().PruneArchives(mock.Anything, mock.Anything, 2).Return(0, nil).Once()
+
+	// Act
+	run, err := svc.TriggerArchival(context.Background())
+	_, concurrentErr := svc.TriggerArchival(context.Background())
+	close(release)
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, models.ArchivalTriggerManual, run.Trigger)
+	assert.Equal(t, models.ArchivalStatusRunning, run.Status)
+	assert.ErrorIs(t, concurrentErr, service.ErrArchivalRunning)
+
+	select {
+	case finished := <-done:
+		assert.Equal(t, models.ArchivalStatusCompleted, finished.Status)
+		assert.Equal(t, int64(3), finished.EventsArchived)
+		assert.Equal(t, int64(6), finished.AttemptsArchived)
+		assert.Equal(t, int64(1), finished.RunsArchived)
+		assert.Equal(t, int64(4), finished.StepRunsArchived)
+		assert.Equal(t, int64(3), finished.ArchivesPruned)
+		assert.NotNil(t, finished.CompletedAt)
+	case <-time.After(5 * time.Second):
+		t.Fatal("archival run did not complete")
+	}
+	retentionRepo.AssertCalled(t, "PruneArchives", mock.Anything, startedAt.AddDate(0, 0, -365), 2)
+}
+
+// TestSetTenantRetention tests that tenants without their own retention use the global default
+func TestSetTenantRetention(t *testing.T) {
+	// Arrange
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	svc := service.NewRetentionService(mocks.NewMockRetentionRepository(t), tenantRepo, service.RetentionConfig{DefaultDays: 30}, logging.Nop())
+
+	settings := &models.TenantSettings{TenantID: "acme", RetentionDays: 90}
+	tenantRepo.EXPECT().GetTenantSettings(mock.Anything, "acme").Return(settings, nil)
+	tenantRepo.EXPECT().SaveTenantSettings(mock.Anything, settings).Return(nil).Once()
+
+	// Act
+	current, err := svc.GetTenantRetention(context.Background(), "acme")
+	assert.NoError(t, err)
+	updated, updateErr := svc.SetTenantRetention(context.Background(), "acme", &models.TenantRetentionRequest{RetentionDays: 0})
+
+	// Assert
+	assert.Equal(t, 90, current.EffectiveDays)
+	assert.NoError(t, updateErr)
+	assert.Equal(t, 0, updated.RetentionDays)
+	assert.Equal(t, 30, updated.EffectiveDays)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// completionMaxRetries is the number of times a run summary delivery is retried after failing
+const completionMaxRetries = 3
+
+// validateCallbackURL checks the callback URL of a manual execution request
+// Returns:
+//   - error: If the URL is not an absolute http or https URL
+func validateCallbackURL(callbackURL string) error {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidRun.Withf("callback_url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// validateCompletionWebhook checks that a completion webhook exists and belongs to the chain's tenant
+func (s *executionChainService) validateCompletionWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) error {
+	webhook, err := s.webhookRepo.GetSubscriptionByID(ctx, webhookID)
+	if err != nil {
+		return notFound(ErrInvalidChain.Withf("completion webhook not found"), err)
+	}
+	if webhook.TenantID != tenantID {
+		return ErrInvalidChain.Withf("completion webhook belongs to different tenant")
+	}
+	return nil
+}
+
+// SetEventPublisher publishes the summary of every finished run to a message bus, nil to stop publishing
+func (s *executionChainService) SetEventPublisher(publisher EventPublisher) {
+	s.publisher = publisher
+}
+
+// notifyRunFinished sends the summary of a finished run to the chain's completion webhook and the
+// run's callback URL, retrying each delivery, and records the outcome as the run's callback status
+// Summaries sent to the callback URL are signed with the run's callback secret, summaries sent to
+// the completion webhook with the webhook's secret, both under the tenant's signing header names
+// With an event publisher, the summary is also published to the message bus
+func (s *executionChainService) notifyRunFinished(ctx context.Context, runID uuid.UUID) {
+	run, err := s.chainRepo.GetChainRunByID(ctx, runID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load finished run for its completion callback",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+		return
+	}
+
+	payload := runSummary(run, s.clock.Now())
+	if s.publisher != nil {
+		s.publisher.PublishRunFinished(ctx, run, payload)
+	}
+	if run.Chain.CompletionWebhookID == nil && run.CallbackURL == nil {
+		return
+	}
+	status := models.WebhookStatusSent
+	var lastErr error
+	deliver := func(targetURL string, err error) {
+		if err != nil {
+			status = models.WebhookStatusFailed
+			lastErr = err
+			s.logger.Error(ctx, "Failed to deliver run summary",
+				zap.String("run_id", runID.String()),
+				zap.String("target_url", targetURL),
+				zap.Error(err))
+		}
+	}
+
+	if run.Chain.CompletionWebhookID != nil {
+		webhook, err := s.webhookRepo.GetSubscriptionByID(ctx, *run.Chain.CompletionWebhookID)
+		if err != nil {
+			deliver("", fmt.Errorf("completion webhook not found: %w", err))
+		} else {
+			deliver(webhook.TargetURL, s.deliverRunSummary(ctx, webhook, payload))
+		}
+	}
+	if run.CallbackURL != nil {
+		callback := &models.WebhookSubscription{
+			TenantID:    run.TenantID,
+			TargetURL:   *run.CallbackURL,
+			Type:        models.WebhookTypePublic,
+			SecretToken: run.CallbackSecret,
+		}
+		deliver(callback.TargetURL, s.deliverRunSummary(ctx, callback, payload))
+	}
+
+	updates := map[string]interface{}{
+		"callback_status": status,
+		"updated_at":      s.clock.Now(),
+	}
+	if lastErr != nil {
+		updates["callback_error"] = lastErr.Error()
+	}
+	if err := s.chainRepo.UpdateChainRun(context.WithoutCancel(ctx), runID, updates); err != nil {
+		s.logger.Error(ctx, "Failed to record callback status",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+	}
+}
+
+// deliverRunSummary posts a run summary to a target, retrying with a growing backoff until it is accepted
+func (s *executionChainService) deliverRunSummary(ctx context.Context, target *models.WebhookSubscription, payload map[string]interface{}) error {
+	var lastErr error
+	for attempt := 0; attempt <= completionMaxRetries; attempt++ {
+		if attempt > 0 && !sleepContext(ctx, s.clock, time.Duration(attempt*attempt)*time.Second) {
+			break
+		}
+
+		outcome, _ := s.deliverChainPayload(ctx, target, payload, attempt+1)
+		if outcome.Err == nil {
+			return nil
+		}
+		lastErr = outcome.Err
+	}
+	if lastErr == nil {
+		lastErr = context.Cause(ctx)
+	}
+	return lastErr
+}
+
+// runSummary builds the payload describing a finished run: its outcome, duration, variables and step results
+func runSummary(run *models.ExecutionChainRun, now time.Time) map[string]interface{} {
+	steps := make([]map[string]interface{}, 0, len(run.StepRuns))
+	for _, stepRun := range run.StepRuns {
+		step := map[string]interface{}{
+			"step_order":    stepRun.StepOrder,
+			"name":          stepRun.Step.Name,
+			"status":        stepRun.Status,
+			"attempt_count": stepRun.AttemptCount,
+		}
+		if stepRun.ResponseCode != nil {
+			step["response_code"] = *stepRun.ResponseCode
+		}
+		if stepRun.LastError != nil {
+			step["last_error"] = *stepRun.LastError
+		}
+		steps = append(steps, step)
+	}
+
+	summary := map[string]interface{}{
+		"event":         "execution_chain.run_finished",
+		"run_id":        run.ID,
+		"chain_id":      run.ChainID,
+		"chain_name":    run.Chain.Name,
+		"tenant_id":     run.TenantID,
+		"trigger_event": run.TriggerEvent,
+		"status":        run.Status,
+		"steps":         steps,
+		"timestamp":     now.Format(time.RFC3339),
+	}
+	if run.StartedAt != nil {
+		summary["started_at"] = run.StartedAt.Format(time.RFC3339)
+	}
+	if run.CompletedAt != nil {
+		summary["completed_at"] = run.CompletedAt.Format(time.RFC3339)
+		if run.StartedAt != nil {
+			summary["duration_ms"] = run.CompletedAt.Sub(*run.StartedAt).Milliseconds()
+		}
+	}
+	if run.LastError != nil {
+		summary["last_error"] = *run.LastError
+	}
+	if run.CancelReason != nil {
+		summary["cancel_reason"] = *run.CancelReason
+	}
+	if run.CompensationStatus != "" {
+		summary["compensation_status"] = run.CompensationStatus
+	}
+	if len(run.Variables) > 0 {
+		summary["variables"] = run.Variables
+	}
+	return summary
+}
@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+)
+
+// summaryDelivery is a run summary received by a completion receiver
+type summaryDelivery struct {
+	path      string
+	body      []byte
+	signature string
+	timestamp string
+}
+
+// TestExecuteChain_RunCompletion tests that the summary of a finished run is posted to the chain's completion
+// webhook and to the run's callback URL, each signed with its own secret so that receivers verify it with the
+// client package, and that the run records the delivery as its callback status
+func TestExecuteChain_RunCompletion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	steps := newStepServer(t, nil)
+	deliveries := make(chan summaryDelivery, 2)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deliveries <- summaryDelivery{path: r.URL.Path, body: body,
+			signature: r.Header.Get(models.DefaultSignatureHeader), timestamp: r.Header.Get("X-Shavix-Timestamp")}
+	}))
+	t.Cleanup(receiver.Close)
+
+	completion := &models.WebhookSubscription{TenantID: chainTenant, AppName: "reporting", TargetURL: receiver.URL + "/completion",
+		Type: models.WebhookTypePublic, SecretToken: "completion-secret", IsActive: true}
+	require.NoError(t, env.webhooks.CreateSubscription(ctx, completion))
+	chain, err := env.service.CreateChain(ctx, &models.CreateExecutionChainRequest{
+		TenantID: chainTenant, Name: "Order fulfilment", TriggerEvent: "order.created", CompletionWebhookID: &completion.ID,
+		Steps: []models.CreateExecutionChainStep{{Name: "Ship", WebhookID: env.webhook(t, steps, "/ship")}},
+	})
+	require.NoError(t, err)
+
+	// Act
+	run, err := env.service.ExecuteChain(ctx, chainTenant, &models.ExecuteChainRequest{
+		ChainID:     chain.ChainID,
+		TriggerData: map[string]interface{}{"order_id": "ord-1"},
+		CallbackURL: receiver.URL + "/callback",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, run.CallbackSecret)
+	received := map[string]summaryDelivery{}
+	for range 2 {
+		select {
+		case delivery := <-deliveries:
+			received[delivery.path] = delivery
+		case <-time.After(5 * time.Second):
+			t.Fatal("run summary was not delivered to both receivers")
+		}
+	}
+
+	// Assert
+	secrets := map[string]string{"/completion": "completion-secret", "/callback": run.CallbackSecret}
+	for path, secret := range secrets {
+		delivery, ok := received[path]
+		require.True(t, ok, path)
+		assert.NoError(t, client.VerifySignature(delivery.body, delivery.signature, secret), path)
+		assert.NotEmpty(t, delivery.timestamp, path)
+
+		var summary map[string]interface{}
+		require.NoError(t, json.Unmarshal(delivery.body, &summary), path)
+		assert.Equal(t, "execution_chain.run_finished", summary["event"], path)
+		assert.Equal(t, run.RunID.String(), summary["run_id"], path)
+		assert.Equal(t, chain.ChainID.String(), summary["chain_id"], path)
+		assert.Equal(t, "Order fulfilment", summary["chain_name"], path)
+		assert.Equal(t, chainTenant, summary["tenant_id"], path)
+		assert.Equal(t, string(models.ExecutionChainStatusCompleted), summary["status"], path)
+		assert.Contains(t, summary, "duration_ms", path)
+		require.Len(t, summary["steps"], 1, path)
+		step := summary["steps"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "Ship", step["name"], path)
+		assert.Equal(t, string(models.WebhookStatusSent), step["status"], path)
+	}
+	assert.ErrorIs(t, client.VerifySignature(received["/callback"].body, received["/callback"].signature, "completion-secret"),
+		client.ErrSignatureMismatch, "the callback must not be signed with the completion webhook's secret")
+
+	require.Eventually(t, func() bool {
+		finished, err := env.service.GetChainRun(ctx, chainTenant, run.RunID)
+		require.NoError(t, err)
+		return finished.CallbackStatus == models.WebhookStatusSent
+	}, 5*time.Second, 5*time.Millisecond, "callback status was never recorded")
+}
+
+// TestExecuteChain_RunCompletionFailure tests that a callback URL rejecting the summary is retried and recorded
+// as a failed callback with its error
+func TestExecuteChain_RunCompletionFailure(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	env.clock.autoAdvance = true
+	steps := newStepServer(t, map[string]http.HandlerFunc{
+		"/callback": func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+	})
+	chain, err := env.service.CreateChain(ctx, &models.CreateExecutionChainRequest{
+		TenantID: chainTenant, Name: "Order fulfilment", TriggerEvent: "order.created",
+		Steps: []models.CreateExecutionChainStep{{Name: "Ship", WebhookID: env.webhook(t, steps, "/ship")}},
+	})
+	require.NoError(t, err)
+
+	// Act
+	run, err := env.service.ExecuteChain(ctx, chainTenant, &models.ExecuteChainRequest{
+		ChainID: chain.ChainID, CallbackURL: steps.URL + "/callback",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	var finished *models.ExecutionChainRun
+	require.Eventually(t, func() bool {
+		finished, err = env.service.GetChainRun(ctx, chainTenant, run.RunID)
+		require.NoError(t, err)
+		return finished.CallbackStatus == models.WebhookStatusFailed
+	}, 5*time.Second, 5*time.Millisecond, "failed callback was never recorded")
+	assert.NotNil(t, finished.CallbackError)
+	assert.Equal(t, []string{"/ship", "/callback", "/callback", "/callback", "/callback"}, steps.Calls())
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// errRunLimitReached is returned when a queued run cannot start because its tenant's concurrency limit is reached
+var errRunLimitReached = errors.New("tenant run concurrency limit reached")
+
+// errGlobalRunLimitReached is returned when a queued run cannot start because the global concurrency limit is reached
+var errGlobalRunLimitReached = errors.New("global run concurrency limit reached")
+
+// errRunClaimed is returned when a queued run is no longer queued because another instance or caller started it
+var errRunClaimed = errors.New("run was already started")
+
+// runDeferred reports whether a queued run that could not start stays queued, or was started elsewhere,
+// rather than having failed
+func runDeferred(err error) bool {
+	return errors.Is(err, errChainPaused) || errors.Is(err, errRunLimitReached) || errors.Is(err, errGlobalRunLimitReached) ||
+		errors.Is(err, errRunClaimed)
+}
+
+// ConfigureRunLimit sets how many chain runs of all tenants may run at once, 0 for no limit
+// Must be called before any run is started
+func (s *executionChainService) ConfigureRunLimit(maxRunningRuns int) {
+	if maxRunningRuns < 0 {
+		maxRunningRuns = 0
+	}
+	s.globalRunLimit = maxRunningRuns
+}
+
+// SetPriorityMetrics exports how runs of each priority are served as Prometheus metrics, nil to stop exporting
+func (s *executionChainService) SetPriorityMetrics(metrics *PriorityMetrics) {
+	s.priorityMetrics = metrics
+}
+
+// runLimited reports whether a concurrency limit applies to the runs of a tenant
+func (s *executionChainService) runLimited(settings *models.TenantSettings) bool {
+	return s.globalRunLimit > 0 || settings.MaxConcurrentRuns > 0
+}
+
+// checkRunLimits returns errRunLimitReached or errGlobalRunLimitReached when starting another run
+// of the tenant would exceed a concurrency limit
+// Callers hold s.admission and the admission lock, so runs are counted and started atomically across instances
+func (s *executionChainService) checkRunLimits(ctx context.Context, settings *models.TenantSettings) error {
+	if settings.MaxConcurrentRuns > 0 {
+		running, err := s.chainRepo.CountChainRunsByStatus(ctx, settings.TenantID, models.ExecutionChainStatusRunning)
+		if err != nil {
+			return fmt.Errorf("failed to count running runs: %w", err)
+		}
+		if running >= int64(settings.MaxConcurrentRuns) {
+			return errRunLimitReached
+		}
+	}
+
+	if s.globalRunLimit > 0 {
+		running, err := s.chainRepo.CountChainRunsByStatus(ctx, "", models.ExecutionChainStatusRunning)
+		if err != nil {
+			return fmt.Errorf("failed to count running runs: %w", err)
+		}
+		if running >= int64(s.globalRunLimit) {
+			return errGlobalRunLimitReached
+		}
+	}
+	return nil
+}
+
+// admissionScope returns the tenant whose queued runs share an admission queue with the tenant's runs,
+// or an empty tenant when a global limit puts the runs of all tenants in one queue
+func (s *executionChainService) admissionScope(tenantID string) string {
+	if s.globalRunLimit > 0 {
+		return ""
+	}
+	return tenantID
+}
+
+// queuePosition returns the 1-based position of a queued run in its admission queue, 0 for other runs
+func (s *executionChainService) queuePosition(ctx context.Context, run *models.ExecutionChainRun) int {
+	if run.Status != models.ExecutionChainStatusQueued {
+		return 0
+	}
+	ahead, err := s.chainRepo.CountQueuedChainRunsAhead(ctx, s.admissionScope(run.TenantID), run.Priority, run.CreatedAt)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to compute queue position",
+			zap.String("run_id", run.ID.String()),
+			zap.Error(err))
+		return 0
+	}
+	return int(ahead) + 1
+}
+
+// admitQueuedRuns starts queued runs highest priority first, oldest first within a priority, while the concurrency limits allow
+// Runs of paused tenants or chains, and runs waiting for an unfinished run of a chain with the
+// queue overlap policy, are passed over and keep their place
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant whose admission queue to process, empty for the queued runs of all tenants
+//
+// Returns: The IDs of the runs started
+func (s *executionChainService) admitQueuedRuns(ctx context.Context, tenantID string) map[uuid.UUID]bool {
+	var queued []*models.ExecutionChainRun
+	var err error
+	if scope := s.admissionScope(tenantID); scope == "" {
+		queued, err = s.chainRepo.GetChainRunsByStatus(ctx, models.ExecutionChainStatusQueued)
+	} else {
+		queued, err = s.chainRepo.GetChainRunsByTenantAndStatus(ctx, scope, models.ExecutionChainStatusQueued)
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load queued chain runs", zap.Error(err))
+		return nil
+	}
+
+	started := make(map[uuid.UUID]bool)
+	tenants := make(map[string]*models.TenantSettings)
+	full := make(map[string]bool)
+	for _, run := range queued {
+		if ctx.Err() != nil {
+			break
+		}
+		if full[run.TenantID] {
+			continue
+		}
+
+		settings, ok := tenants[run.TenantID]
+		if !ok {
+			if settings, err = s.tenantRepo.GetTenantSettings(ctx, run.TenantID); err != nil {
+				s.logger.Error(ctx, "Failed to load tenant settings",
+					zap.String("tenant_id", run.TenantID),
+					zap.Error(err))
+				full[run.TenantID] = true
+				continue
+			}
+			tenants[run.TenantID] = settings
+		}
+		if settings.ChainsPaused || s.overlapBlocked(ctx, run) {
+			continue
+		}
+
+		err := s.startQueuedRun(ctx, run)
+		switch {
+		case err == nil:
+			started[run.ID] = true
+		case errors.Is(err, errGlobalRunLimitReached):
+			return started
+		case errors.Is(err, errRunLimitReached):
+			full[run.TenantID] = true
+		case runDeferred(err):
+		default:
+			s.logger.Error(ctx, "Failed to start queued chain run",
+				zap.String("run_id", run.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	if len(started) > 0 {
+		s.logger.Info(ctx, "Admitted queued chain runs",
+			zap.String("tenant_id", tenantID),
+			zap.Int("started_runs", len(started)))
+	}
+	return started
+}
+
+// overlapBlocked reports whether a queued run waits for an unfinished run of its chain under the queue overlap policy
+func (s *executionChainService) overlapBlocked(ctx context.Context, run *models.ExecutionChainRun) bool {
+	chain, err := s.chainRepo.GetChainByID(ctx, run.ChainID)
+	if err != nil || chain.Schedule == "" || chain.OverlapPolicy != models.ScheduleOverlapQueue {
+		return false
+	}
+	running, err := s.chainRepo.GetChainRunsByChainAndStatus(ctx, chain.ID, []models.ExecutionChainStatus{models.ExecutionChainStatusRunning})
+	return err != nil || len(running) > 0
+}
+
+// admitAfterRun admits the queued runs waiting for the slot a finished run of the tenant freed
+// Runs cancelled through the API free their slot too; during shutdown admission is left to recovery
+func (s *executionChainService) admitAfterRun(ctx context.Context, tenantID string) {
+	var cancelled *runCancelledError
+	if errors.As(context.Cause(ctx), &cancelled) {
+		ctx = context.WithoutCancel(ctx)
+	}
+	if ctx.Err() != nil || s.workers.Draining() {
+		return
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil || !s.runLimited(settings) {
+		return
+	}
+	s.requestAdmission(ctx, tenantID)
+}
+
+// SetWorkQueue hands the admission of queued runs to a work queue the instances share, nil to admit them locally
+// Runs finishing and recovery passes then queue an admission job for the runs' admission queue, which the
+// first instance with a free worker takes; runs created with a free slot still start right away
+func (s *executionChainService) SetWorkQueue(queue WorkQueue) {
+	s.queue = queue
+}
+
+// requestAdmission admits the queued runs of a tenant's admission queue, empty for all tenants, or queues
+// the admission for an instance with a free worker when there is a work queue
+func (s *executionChainService) requestAdmission(ctx context.Context, tenantID string) {
+	if s.queue == nil {
+		s.admitQueuedRuns(ctx, tenantID)
+		return
+	}
+
+	scope := s.admissionScope(tenantID)
+	if scope == "" {
+		scope = allTenantsScope
+	}
+	if err := s.queue.Push(ctx, runAdmissionQueue, scope, s.clock.Now()); err != nil {
+		s.logger.Error(ctx, "Failed to queue run admission, admitting locally",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		s.admitQueuedRuns(ctx, tenantID)
+	}
+}
+
+// RunAdmissionQueue admits the queued runs of the admission jobs the work queue hands out every interval
+// until ctx is cancelled; jobs are only taken while a worker is free. Does nothing without a work queue
+// Parameters:
+//   - ctx: Context whose cancellation stops the consumer
+//   - interval: Time between polls of the work queue
+func (s *executionChainService) RunAdmissionQueue(ctx context.Context, interval time.Duration) {
+	if s.queue == nil {
+		return
+	}
+	for {
+		if free := s.workers.Free(); free > 0 && !s.workers.Draining() {
+			jobs, err := s.queue.PopDue(ctx, runAdmissionQueue, s.clock.Now(), free)
+			if err != nil {
+				s.logger.Error(ctx, "Failed to take run admissions from the work queue", zap.Error(err))
+			}
+			for _, scope := range jobs {
+				if scope == allTenantsScope {
+					scope = ""
+				}
+				s.admitQueuedRuns(ctx, scope)
+			}
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// GetTenantRunLimit retrieves a tenant's run concurrency limit with its running and queued runs
+func (s *executionChainService) GetTenantRunLimit(ctx context.Context, tenantID string) (*models.TenantRunLimitResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	running, err := s.chainRepo.CountChainRunsByStatus(ctx, tenantID, models.ExecutionChainStatusRunning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count running runs: %w", err)
+	}
+	queued, err := s.chainRepo.CountChainRunsByStatus(ctx, tenantID, models.ExecutionChainStatusQueued)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count queued runs: %w", err)
+	}
+
+	return &models.TenantRunLimitResponse{
+		TenantID:                tenantID,
+		MaxConcurrentRuns:       settings.MaxConcurrentRuns,
+		GlobalMaxConcurrentRuns: s.globalRunLimit,
+		RunningRuns:             running,
+		QueuedRuns:              queued,
+	}, nil
+}
+
+// SetTenantRunLimit sets how many of a tenant's chain runs may run at once, 0 for no limit
+// Runs in flight are never stopped; a raised or lifted limit starts queued runs right away
+func (s *executionChainService) SetTenantRunLimit(ctx context.Context, tenantID string, req *models.TenantRunLimitRequest) (*models.TenantRunLimitResponse, error) {
+	if req.MaxConcurrentRuns < 0 {
+		return nil, fmt.Errorf("max_concurrent_runs must not be negative")
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	settings.MaxConcurrentRuns = req.MaxConcurrentRuns
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save run limit: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant run concurrency limit updated",
+		zap.String("tenant_id", tenantID),
+		zap.Int("max_concurrent_runs", settings.MaxConcurrentRuns))
+
+	s.admitQueuedRuns(ctx, tenantID)
+
+	return s.GetTenantRunLimit(ctx, tenantID)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// runRequest holds what a manual execution sets on its run when the run is created
+type runRequest struct {
+	callbackURL string
+	priority    int
+	options     *models.RunOptions
+
+	// callbackSecret keeps the callback secret of the run a retry continues instead of generating one
+	callbackSecret string
+
+	// retryOf is the failed run a retry continues
+	retryOf *uuid.UUID
+
+	// seed holds the step runs of the source run that are copied into the new run before it starts
+	seed []*models.ExecutionChainStepRun
+}
+
+// prepareRunOptions validates the per-run options of a manual execution against the chain
+// Parameters:
+//   - chain: Chain the run executes, with its current steps
+//   - options: Options of the execution request, nil when it has none
+//
+// Returns:
+//   - *models.RunOptions: Options to store on the run, nil when none apply
+//   - *models.ExecutionChainRun: Source run whose results are reused, nil when the request names none
+//   - error: If a step order or override key names no step of the chain, start_at_step is used on a
+//     dependency graph chain, or the source run is of another chain or version or has not finished
+func (s *executionChainService) prepareRunOptions(ctx context.Context, chain *models.ExecutionChain, options *models.ChainExecutionOptions) (*models.RunOptions, *models.ExecutionChainRun, error) {
+	if options == nil || (len(options.StepsToSkip) == 0 && options.StartAtStep == 0 &&
+		len(options.OverrideParams) == 0 && options.SourceRunID == nil) {
+		return nil, nil, nil
+	}
+
+	orders := make(map[int]bool, len(chain.Steps))
+	for _, step := range chain.Steps {
+		orders[step.StepOrder] = true
+	}
+	graph := hasStepDependencies(chain.Steps)
+
+	for _, order := range options.StepsToSkip {
+		if !orders[order] {
+			return nil, nil, ErrInvalidRun.Withf("steps_to_skip: chain has no step %d", order)
+		}
+	}
+	if options.StartAtStep != 0 {
+		if graph {
+			return nil, nil, ErrInvalidRun.Withf("start_at_step is not supported for chains whose steps declare depends_on")
+		}
+		if !orders[options.StartAtStep] {
+			return nil, nil, ErrInvalidRun.Withf("start_at_step: chain has no step %d", options.StartAtStep)
+		}
+	}
+	for key := range options.OverrideParams {
+		order, ok := parseStepKey(key)
+		if !ok || !orders[order] {
+			return nil, nil, ErrInvalidRun.Withf("override_params: key %q must name a step of the chain as step_N", key)
+		}
+	}
+
+	runOptions := &models.RunOptions{
+		StepsToSkip:    options.StepsToSkip,
+		StartAtStep:    options.StartAtStep,
+		OverrideParams: options.OverrideParams,
+		SourceRunID:    options.SourceRunID,
+	}
+	if options.SourceRunID == nil {
+		return runOptions, nil, nil
+	}
+
+	source, err := s.chainRepo.GetChainRunByID(ctx, *options.SourceRunID)
+	if err != nil {
+		return nil, nil, notFound(ErrRunNotFound, err)
+	}
+	if source.ChainID != chain.ID {
+		return nil, nil, ErrInvalidRun.Withf("source run belongs to a different chain")
+	}
+	if source.ChainVersion != chain.Version {
+		return nil, nil, ErrInvalidRun.Withf("source run ran version %d of the chain, not the current version %d", source.ChainVersion, chain.Version)
+	}
+	switch source.Status {
+	case models.ExecutionChainStatusCompleted, models.ExecutionChainStatusFailed, models.ExecutionChainStatusCancelled:
+	default:
+		return nil, nil, ErrInvalidRun.Withf("source run is %s, only finished runs can be reprocessed", source.Status)
+	}
+
+	// Without a start step a sequential chain resumes at the first step the source run did not complete
+	if runOptions.StartAtStep == 0 && !graph {
+		succeeded := make(map[int]bool)
+		for _, stepRun := range source.StepRuns {
+			if stepRun.Status == models.WebhookStatusSent {
+				succeeded[stepRun.StepOrder] = true
+			}
+		}
+		for _, step := range sortedSteps(chain) {
+			if !succeeded[step.StepOrder] {
+				runOptions.StartAtStep = step.StepOrder
+				break
+			}
+		}
+		if runOptions.StartAtStep == 0 {
+			return nil, nil, ErrInvalidRun.Withf("source run completed every step, set start_at_step to reprocess some of them")
+		}
+	}
+	return runOptions, source, nil
+}
+
+// seedStepRuns returns the successful step runs of a source run that a run with the given options reuses:
+// those ordered before the start step, or all of them for dependency graph chains, which skip steps that succeeded
+func seedStepRuns(source *models.ExecutionChainRun, options *models.RunOptions) []*models.ExecutionChainStepRun {
+	if source == nil {
+		return nil
+	}
+	var seed []*models.ExecutionChainStepRun
+	for i := range source.StepRuns {
+		stepRun := &source.StepRuns[i]
+		if stepRun.Status != models.WebhookStatusSent {
+			continue
+		}
+		if options.StartAtStep != 0 && stepRun.StepOrder >= options.StartAtStep {
+			continue
+		}
+		seed = append(seed, stepRun)
+	}
+	return seed
+}
+
+// copySeedStepRuns records the reused step runs of a source run as step runs of a new run
+func (s *executionChainService) copySeedStepRuns(ctx context.Context, runID uuid.UUID, seed []*models.ExecutionChainStepRun) error {
+	now := s.clock.Now()
+	for _, stepRun := range seed {
+		if err := s.chainRepo.CreateStepRun(ctx, &models.ExecutionChainStepRun{
+			ID:           uuid.New(),
+			RunID:        runID,
+			StepID:       stepRun.StepID,
+			StepOrder:    stepRun.StepOrder,
+			Status:       stepRun.Status,
+			AttemptCount: stepRun.AttemptCount,
+			ResponseCode: stepRun.ResponseCode,
+			ResponseBody: stepRun.ResponseBody,
+			Approval:     stepRun.Approval,
+			StartedAt:    stepRun.StartedAt,
+			CompletedAt:  stepRun.CompletedAt,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}); err != nil {
+			return fmt.Errorf("failed to copy result of step %d: %w", stepRun.StepOrder, err)
+		}
+	}
+	return nil
+}
+
+// parseStepKey parses a step key such as "step_2" into the step order
+func parseStepKey(key string) (int, bool) {
+	order, err := strconv.Atoi(strings.TrimPrefix(key, "step_"))
+	if err != nil || !strings.HasPrefix(key, "step_") {
+		return 0, false
+	}
+	return order, true
+}
+
+// applyRunOptions makes the run context skip and override the steps the run's options name
+func (rc *runContext) applyRunOptions(options *models.RunOptions) {
+	if options == nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.excludedSteps = make(map[int]bool, len(options.StepsToSkip))
+	for _, order := range options.StepsToSkip {
+		rc.excludedSteps[order] = true
+	}
+	rc.overrides = options.OverrideParams
+}
+
+// excluded reports whether the run's options skip the step with the given order
+func (rc *runContext) excluded(stepOrder int) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.excludedSteps[stepOrder]
+}
+
+// overrideParams merges the run's override params of a step over the step's request params
+func (rc *runContext) overrideParams(stepOrder int, params map[string]interface{}) map[string]interface{} {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	overrides, ok := rc.overrides[stepKey(stepOrder)]
+	if !ok {
+		return params
+	}
+
+	merged := make(map[string]interface{}, len(params)+len(overrides))
+	for name, value := range params {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		merged[name] = value
+	}
+	return merged
+}
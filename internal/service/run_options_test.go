@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+)
+
+// executeWithOptions starts a run of a chain with the per-run execution options
+func (env *memoryChains) executeWithOptions(t *testing.T, chainID uuid.UUID, options *models.ChainExecutionOptions) uuid.UUID {
+	t.Helper()
+	run, err := env.service.ExecuteChain(context.Background(), chainTenant, &models.ExecuteChainRequest{
+		ChainID:     chainID,
+		TriggerData: map[string]interface{}{"order_id": "ord-1"},
+		Options:     options,
+	})
+	require.NoError(t, err)
+	return run.RunID
+}
+
+// TestExecuteChain_RunOptions tests that a run started at a later step with steps to skip and overridden params
+// calls only the remaining steps, records the skipped ones with the reason, and sends the overridden params
+// merged over those of the step
+func TestExecuteChain_RunOptions(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	notifyBody := make(chan string, 1)
+	server := newStepServer(t, map[string]http.HandlerFunc{
+		"/notify": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			notifyBody <- string(body)
+			_, _ = w.Write([]byte(`{"ok": true}`))
+		},
+	})
+	chainID := env.createChain(t,
+		models.CreateExecutionChainStep{Name: "Reserve", WebhookID: env.webhook(t, server, "/reserve")},
+		models.CreateExecutionChainStep{Name: "Charge", WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+		models.CreateExecutionChainStep{Name: "Notify", WebhookID: env.webhook(t, server, "/notify"),
+			RequestParams: map[string]interface{}{"order": "{{.trigger_data.order_id}}", "channel": "email"}},
+	)
+
+	// Act
+	runID := env.executeWithOptions(t, chainID, &models.ChainExecutionOptions{
+		StartAtStep:    2,
+		StepsToSkip:    []int{3},
+		OverrideParams: map[string]map[string]interface{}{"step_4": {"channel": "sms"}},
+	})
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	assert.Equal(t, []string{"/charge", "/notify"}, server.Calls())
+	body := <-notifyBody
+	assert.Contains(t, body, `"channel":"sms"`)
+	assert.Contains(t, body, `"order":"ord-1"`, "params not overridden are kept")
+
+	stepRuns := stepRunsByOrder(run)
+	assert.NotContains(t, stepRuns, 1, "steps before start_at_step are not executed")
+	assert.Equal(t, models.WebhookStatusSkipped, stepRuns[3].Status)
+	require.NotNil(t, stepRuns[3].LastError)
+	assert.Equal(t, "skipped: excluded by the run's steps_to_skip", *stepRuns[3].LastError)
+	require.NotNil(t, run.Options)
+	assert.Equal(t, 2, run.Options.StartAtStep)
+}
+
+// TestExecuteChain_ReprocessSourceRun tests that a run reprocessing a failed run reuses the results of the steps
+// the failed run completed and resumes at the first step it did not complete
+func TestExecuteChain_ReprocessSourceRun(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	env.clock.autoAdvance = true
+	var fail atomic.Bool
+	fail.Store(true)
+	server := newStepServer(t, map[string]http.HandlerFunc{"/ship": failing(&fail)})
+	chainID := env.createChain(t,
+		models.CreateExecutionChainStep{Name: "Charge", WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+		models.CreateExecutionChainStep{Name: "Notify", WebhookID: env.webhook(t, server, "/notify")},
+	)
+	sourceID := env.executeWithOptions(t, chainID, nil)
+	env.waitForRun(t, sourceID, models.ExecutionChainStatusFailed)
+	fail.Store(false)
+	callsBefore := len(server.Calls())
+
+	// Act
+	runID := env.executeWithOptions(t, chainID, &models.ChainExecutionOptions{SourceRunID: &sourceID})
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	assert.Equal(t, []string{"/ship", "/notify"}, server.Calls()[callsBefore:])
+	require.NotNil(t, run.Options)
+	assert.Equal(t, 2, run.Options.StartAtStep)
+	stepRuns := stepRunsByOrder(run)
+	assert.Equal(t, models.WebhookStatusSent, stepRuns[1].Status, "the charge result is reused")
+}
+
+// TestExecuteChain_InvalidRunOptions tests that options referencing steps the chain does not have, or options the
+// chain does not support, are rejected before a run is created
+func TestExecuteChain_InvalidRunOptions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+	chainID := env.createChain(t,
+		models.CreateExecutionChainStep{Name: "Charge", WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", WebhookID: env.webhook(t, server, "/ship")},
+	)
+	graphID := env.createChain(t,
+		models.CreateExecutionChainStep{Name: "Charge", Key: "charge", WebhookID: env.webhook(t, server, "/charge")},
+		models.CreateExecutionChainStep{Name: "Ship", Key: "ship", DependsOn: []string{"charge"}, WebhookID: env.webhook(t, server, "/ship")},
+	)
+	otherRun := uuid.New()
+
+	tests := []struct {
+		name    string
+		chainID uuid.UUID
+		options *models.ChainExecutionOptions
+		want    string
+	}{
+		{name: "unknown step to skip", chainID: chainID, options: &models.ChainExecutionOptions{StepsToSkip: []int{1, 3}},
+			want: "steps_to_skip: chain has no step 3"},
+		{name: "unknown start step", chainID: chainID, options: &models.ChainExecutionOptions{StartAtStep: 5},
+			want: "start_at_step: chain has no step 5"},
+		{name: "start step on dependency graph", chainID: graphID, options: &models.ChainExecutionOptions{StartAtStep: 2},
+			want: "start_at_step is not supported for chains whose steps declare depends_on"},
+		{name: "override of unknown step", chainID: chainID,
+			options: &models.ChainExecutionOptions{OverrideParams: map[string]map[string]interface{}{"step_7": {"a": 1}}},
+			want:    `override_params: key "step_7" must name a step of the chain as step_N`},
+		{name: "override not naming a step", chainID: chainID,
+			options: &models.ChainExecutionOptions{OverrideParams: map[string]map[string]interface{}{"ship": {"a": 1}}},
+			want:    `override_params: key "ship" must name a step of the chain as step_N`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			response, err := env.service.ExecuteChain(ctx, chainTenant, &models.ExecuteChainRequest{ChainID: tt.chainID, Options: tt.options})
+
+			// Assert
+			assert.ErrorIs(t, err, service.ErrInvalidRun)
+			assert.ErrorContains(t, err, tt.want)
+			assert.Nil(t, response)
+		})
+	}
+
+	t.Run("unknown source run", func(t *testing.T) {
+		// Act
+		response, err := env.service.ExecuteChain(ctx, chainTenant, &models.ExecuteChainRequest{
+			ChainID: chainID, Options: &models.ChainExecutionOptions{SourceRunID: &otherRun},
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, service.ErrRunNotFound)
+		assert.Nil(t, response)
+	})
+
+	for _, id := range []uuid.UUID{chainID, graphID} {
+		runs, err := env.service.ListChainRuns(ctx, chainTenant, id, models.ListFilter{}, models.ListPage{})
+		require.NoError(t, err)
+		assert.Zero(t, runs.Total)
+	}
+	assert.Empty(t, server.Calls())
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// recoveryLeaseIntervals is the number of recovery intervals a run's heartbeat stays valid
+// Runs of other instances without a heartbeat for that long are considered abandoned
+const recoveryLeaseIntervals = 4
+
+// defaultInstanceID identifies this server instance on the runs it executes
+func defaultInstanceID() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return uuid.New().String()
+}
+
+// ConfigureWorkers sets the instance identifier and the number of chain runs executed at once
+// Must be called before any run is started
+// Parameters:
+//   - instanceID: Identifier recorded on the runs this instance executes, the host name when empty
+//   - maxConcurrentRuns: Maximum number of runs executing at once, the default when not positive
+func (s *executionChainService) ConfigureWorkers(instanceID string, maxConcurrentRuns int) {
+	if instanceID != "" {
+		s.instanceID = instanceID
+	}
+	s.workers = newWorkerRegistry(maxConcurrentRuns)
+}
+
+// RecoverChainRuns re-enqueues the runs no live instance is executing
+// In-flight runs of this instance get a heartbeat first; interrupted runs, runs left running by
+// an earlier process of this instance and runs of other instances whose heartbeat expired are then
+// claimed, queued and resumed from the first step that has not succeeded
+// Every pass also admits the queued runs the concurrency limits allow to start
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - lease: Time after which a run of another instance without a heartbeat is recovered
+//
+// Returns:
+//   - int: Number of runs recovered
+//   - error: If the recoverable runs cannot be loaded
+func (s *executionChainService) RecoverChainRuns(ctx context.Context, lease time.Duration) (int, error) {
+	now := s.clock.Now()
+	if inFlight := s.workers.RunIDs(); len(inFlight) > 0 {
+		if err := s.chainRepo.TouchChainRuns(ctx, inFlight, s.instanceID, now); err != nil {
+			s.logger.Error(ctx, "Failed to record chain run heartbeats", zap.Error(err))
+		}
+	}
+
+	staleBefore := now.Add(-lease)
+	runs, err := s.chainRepo.GetRecoverableChainRuns(ctx, s.instanceID, s.startedAt, staleBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load recoverable chain runs: %w", err)
+	}
+
+	recovered := 0
+	for _, run := range runs {
+		if ctx.Err() != nil {
+			break
+		}
+		if s.workers.Running(run.ID) {
+			continue
+		}
+
+		claimed, err := s.chainRepo.ClaimRecoverableChainRun(ctx, run.ID, s.instanceID, s.startedAt, staleBefore, now)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to claim chain run for recovery",
+				zap.String("run_id", run.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		recovered++
+
+		s.logger.Info(ctx, "Recovering chain run",
+			zap.String("run_id", run.ID.String()),
+			zap.String("previous_status", string(run.Status)),
+			zap.String("previous_worker", run.WorkerID))
+	}
+
+	// Recovered runs start in trigger order with the other queued runs; runs of a paused tenant
+	// or chain and runs over a concurrency limit stay queued until they can start
+	s.requestAdmission(ctx, "")
+	return recovered, nil
+}
+
+// RunRecovery recovers incomplete chain runs every interval until ctx is cancelled
+// The first pass runs right away, so runs interrupted by a restart resume on startup
+// Parameters:
+//   - ctx: Context whose cancellation stops the recovery loop
+//   - interval: Time between recovery passes; heartbeats expire after several intervals
+func (s *executionChainService) RunRecovery(ctx context.Context, interval time.Duration) {
+	lease := recoveryLeaseIntervals * interval
+	for {
+		if recovered, err := s.RecoverChainRuns(ctx, lease); err != nil {
+			s.logger.Error(ctx, "Failed to recover chain runs", zap.Error(err))
+		} else if recovered > 0 {
+			s.logger.Info(ctx, "Recovered chain runs", zap.Int("recovered", recovered))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
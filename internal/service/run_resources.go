@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// runResources sums the resources used by the step runs of a run and breaks them down per step run
+// Skipped steps are listed in the breakdown but not counted
+func runResources(run *models.ExecutionChainRun) *models.RunResources {
+	resources := &models.RunResources{
+		DurationMs:           elapsedMs(run.StartedAt, run.CompletedAt),
+		PerformanceBreakdown: make([]models.StepRunResources, 0, len(run.StepRuns)),
+	}
+
+	stepRuns := make([]models.ExecutionChainStepRun, len(run.StepRuns))
+	copy(stepRuns, run.StepRuns)
+	sort.SliceStable(stepRuns, func(i, j int) bool {
+		return stepRuns[i].StepOrder < stepRuns[j].StepOrder
+	})
+
+	for _, stepRun := range stepRuns {
+		step := models.StepRunResources{
+			StepOrder:     stepRun.StepOrder,
+			StepName:      stepRun.Step.Name,
+			Status:        stepRun.Status,
+			DurationMs:    elapsedMs(stepRun.StartedAt, stepRun.CompletedAt),
+			Attempts:      stepRun.AttemptCount,
+			Retries:       max(stepRun.AttemptCount-1, 0),
+			RequestBytes:  stepRun.RequestBytes,
+			ResponseBytes: stepRun.ResponseBytes,
+		}
+		resources.PerformanceBreakdown = append(resources.PerformanceBreakdown, step)
+		if stepRun.Status == models.WebhookStatusSkipped {
+			continue
+		}
+
+		resources.Steps++
+		resources.Attempts += int64(step.Attempts)
+		resources.Retries += int64(step.Retries)
+		resources.RequestBytes += step.RequestBytes
+		resources.ResponseBytes += step.ResponseBytes
+		if step.DurationMs != nil {
+			resources.StepTimeMs += *step.DurationMs
+		}
+	}
+	return resources
+}
+
+// elapsedMs returns the milliseconds from start to end, nil unless both are set
+func elapsedMs(start, end *time.Time) *int64 {
+	if start == nil || end == nil {
+		return nil
+	}
+	ms := end.Sub(*start).Milliseconds()
+	return &ms
+}
+
+// GetChainUsage sums the resources used by the runs of a tenant's chains created within a window
+func (s *executionChainService) GetChainUsage(ctx context.Context, tenantID string, window models.StatsWindow) (*models.ChainUsageResponse, error) {
+	duration, ok := window.Duration()
+	if !ok {
+		return nil, ErrInvalidStatsWindow.Withf("unknown stats window %q", window)
+	}
+	var since *time.Time
+	if duration > 0 {
+		start := s.clock.Now().Add(-duration)
+		since = &start
+	}
+
+	usage, err := s.chainRepo.GetChainUsage(ctx, tenantID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate chain usage: %w", err)
+	}
+	usage.TenantID = tenantID
+	usage.Window = window
+	usage.Since = since
+	sort.SliceStable(usage.Chains, func(i, j int) bool {
+		return usage.Chains[i].StepTimeMs > usage.Chains[j].StepTimeMs
+	})
+	return usage, nil
+}
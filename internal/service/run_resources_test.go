@@ -0,0 +1,78 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+	"github.com/sakibcoolz/zcornor/pkg/config"
+)
+
+// TestGetChainRun_Resources tests that a retrieved run reports the wall time, attempts, retries and payload sizes
+// of its steps in step order, listing skipped steps without counting them
+func TestGetChainRun_Resources(t *testing.T) {
+	// Arrange
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	svc := service.NewExecutionChainService(chainRepo, mocks.NewMockWebhookRepository(t), mocks.NewMockTenantRepository(t),
+		mocks.NewMockConfigHistoryRepository(t), nil, &config.Config{}, logging.Nop())
+
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := started.Add(d)
+		return &t
+	}
+	run := &models.ExecutionChainRun{
+		ID:          uuid.New(),
+		Status:      models.ExecutionChainStatusCompleted,
+		StartedAt:   at(0),
+		CompletedAt: at(10 * time.Second),
+		StepRuns: []models.ExecutionChainStepRun{
+			{
+				StepOrder: 3, Status: models.WebhookStatusSkipped, StartedAt: at(9 * time.Second), CompletedAt: at(9 * time.Second),
+				Step: models.ExecutionChainStep{Name: "Notify"},
+			},
+			{
+				StepOrder: 1, Status: models.WebhookStatusSent, AttemptCount: 1, RequestBytes: 300, ResponseBytes: 120,
+				StartedAt: at(0), CompletedAt: at(2 * time.Second), Step: models.ExecutionChainStep{Name: "Charge"},
+			},
+			{
+				StepOrder: 2, Status: models.WebhookStatusSent, AttemptCount: 3, RequestBytes: 900, ResponseBytes: 60,
+				StartedAt: at(2 * time.Second), CompletedAt: at(9 * time.Second), Step: models.ExecutionChainStep{Name: "Ship"},
+			},
+		},
+	}
+	chainRepo.EXPECT().GetChainRunByID(mock.Anything, run.ID).Return(run, nil).Once()
+
+	// Act
+	result, err := svc.GetChainRun(context.Background(), "", run.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	resources := result.Resources
+	if assert.NotNil(t, resources) {
+		assert.Equal(t, int64(10000), *resources.DurationMs)
+		assert.Equal(t, models.ResourceUsage{
+			Steps:         2,
+			Attempts:      4,
+			Retries:       2,
+			StepTimeMs:    9000,
+			RequestBytes:  1200,
+			ResponseBytes: 180,
+		}, resources.ResourceUsage)
+		if assert.Len(t, resources.PerformanceBreakdown, 3) {
+			assert.Equal(t, "Charge", resources.PerformanceBreakdown[0].StepName)
+			assert.Equal(t, "Ship", resources.PerformanceBreakdown[1].StepName)
+			assert.Equal(t, 2, resources.PerformanceBreakdown[1].Retries)
+			assert.Equal(t, int64(7000), *resources.PerformanceBreakdown[1].DurationMs)
+			assert.Equal(t, models.WebhookStatusSkipped, resources.PerformanceBreakdown[2].Status)
+		}
+	}
+}
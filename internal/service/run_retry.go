@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RetryChainRun starts a continuation of a failed run at its failed step
+// The new run reuses the failed run's trigger data, priority, callback and per-run options, keeps the results of
+// the steps that succeeded, and references the failed run in retry_of_run_id. Runs whose failure was compensated
+// cannot be retried, as the effects of the steps whose results would be reused have been undone
+// A run is retried once: retries of the same run wait for each other across instances, and a run whose
+// retry has not failed is not retried again
+func (s *executionChainService) RetryChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecuteChainResponse, error) {
+	var response *models.ExecuteChainResponse
+	err := withInstanceLock(ctx, s.locks, retryLockPrefix+runID.String(), func() error {
+		var err error
+		response, err = s.retryChainRun(ctx, runID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// retryChainRun starts the retry of a failed run; callers hold the run's retry lock
+func (s *executionChainService) retryChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecuteChainResponse, error) {
+	original, err := s.chainRepo.GetChainRunByID(ctx, runID)
+	if err != nil {
+		return nil, notFound(ErrRunNotFound, err)
+	}
+	if original.Status != models.ExecutionChainStatusFailed {
+		return nil, ErrInvalidRunState.Withf("run is %s, only failed runs can be retried", original.Status)
+	}
+	if original.CompensationStatus != "" {
+		return nil, ErrInvalidRunState.Withf("run was compensated, start a new run instead")
+	}
+
+	retries, err := s.chainRepo.GetChainRunRetries(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load retries of the run: %w", err)
+	}
+	for _, retry := range retries {
+		if retry.Status != models.ExecutionChainStatusFailed && retry.Status != models.ExecutionChainStatusCancelled {
+			return nil, ErrInvalidRunState.Withf("run was already retried as run %s, which is %s", retry.ID, retry.Status)
+		}
+	}
+
+	s.logger.Info(ctx, "Retrying failed chain run",
+		zap.String("run_id", runID.String()),
+		zap.String("chain_id", original.ChainID.String()))
+
+	chain, err := s.chainRepo.GetChainByID(ctx, original.ChainID)
+	if err != nil {
+		return nil, notFound(ErrChainNotFound, err)
+	}
+	if !chain.IsActive && chain.PausedAt != nil {
+		return nil, errChainPaused
+	}
+	if !chain.IsActive {
+		return nil, ErrInvalidChainState.Withf("chain is not active")
+	}
+
+	options := &models.ChainExecutionOptions{SourceRunID: &runID}
+	if original.Options != nil {
+		options.StepsToSkip = original.Options.StepsToSkip
+		options.OverrideParams = original.Options.OverrideParams
+	}
+	runOptions, source, err := s.prepareRunOptions(ctx, chain, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggerData map[string]interface{}
+	if source.TriggerData != "" {
+		if err := json.Unmarshal([]byte(revealPayload(ctx, s.logger, s.tenantRepo, source.TenantID, source.TriggerData)), &triggerData); err != nil {
+			return nil, fmt.Errorf("invalid trigger data of failed run: %w", err)
+		}
+	}
+
+	runReq := runRequest{
+		priority: original.Priority,
+		options:  runOptions,
+		retryOf:  &runID,
+		seed:     seedStepRuns(source, runOptions),
+	}
+	if original.CallbackURL != nil {
+		runReq.callbackURL = *original.CallbackURL
+		runReq.callbackSecret = original.CallbackSecret
+	}
+
+	run, err := s.createChainRun(ctx, chain, original.TriggerEvent, triggerData, false, runReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExecuteChainResponse{
+		RunID:         run.ID,
+		ChainID:       chain.ID,
+		Status:        string(run.Status),
+		TotalSteps:    run.TotalSteps,
+		StartedAt:     run.StartedAt,
+		QueuePosition: s.queuePosition(ctx, run),
+		RetryOfRunID:  run.RetryOfRunID,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// A run's variables are written by transform steps and by the extraction rules of any step, and are
+// read by later steps as .vars.<name>. The run context holds them while the run executes; every change
+// is saved on the run so the outputs endpoint can return them. A resumed run rebuilds them from its
+// step runs, applying transforms and extraction rules again in step order
+
+// validateStepExtract checks the extraction rules of a step of a chain creation request
+// Returns:
+//   - error: If a variable name is empty or a path does not start with response or status_code
+func validateStepExtract(step models.CreateExecutionChainStep) error {
+	for name, path := range step.Extract {
+		if name == "" {
+			return fmt.Errorf("extract: variable names must be non-empty")
+		}
+		root, _, _ := strings.Cut(strings.TrimPrefix(path, "."), ".")
+		if root != "response" && root != "status_code" {
+			return fmt.Errorf("extract: path %q of variable %q must start with response or status_code", path, name)
+		}
+	}
+	return nil
+}
+
+// extract sets the run variables named by a step's extraction rules from the step's recorded result
+// Returns true when a variable was set; paths that do not resolve leave their variable unchanged
+func (rc *runContext) extract(step *models.ExecutionChainStep) bool {
+	if len(step.Extract) == 0 {
+		return false
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	result, ok := rc.steps[stepKey(step.StepOrder)].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	set := false
+	for name, path := range step.Extract {
+		if value, ok := lookupTemplatePath(result, strings.TrimPrefix(path, ".")); ok {
+			rc.vars[name] = value
+			set = true
+		}
+	}
+	return set
+}
+
+// variables returns a snapshot of the run variables
+func (rc *runContext) variables() map[string]interface{} {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	vars := make(map[string]interface{}, len(rc.vars))
+	for name, value := range rc.vars {
+		vars[name] = value
+	}
+	return vars
+}
+
+// saveRunVariables stores the run context's variables on the run
+func (s *executionChainService) saveRunVariables(ctx context.Context, runID uuid.UUID, rc *runContext) {
+	// Snapshot and write under one lock so parallel branches never overwrite newer variables with older ones
+	rc.saving.Lock()
+	defer rc.saving.Unlock()
+
+	variablesJSON, err := json.Marshal(rc.variables())
+	if err != nil {
+		s.logger.Error(ctx, "Failed to encode run variables",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+		return
+	}
+
+	if err := s.chainRepo.UpdateChainRun(ctx, runID, map[string]interface{}{
+		"variables":  string(variablesJSON),
+		"updated_at": s.clock.Now(),
+	}); err != nil {
+		s.logger.Error(ctx, "Failed to save run variables",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+	}
+}
+
+// GetChainRunOutputs returns the variables of a run together with the results of its successful steps
+// Outputs can be read at any time; they are final once the run's status is completed
+// With a tenant given, the runs of other tenants are not found
+func (s *executionChainService) GetChainRunOutputs(ctx context.Context, tenantID string, runID uuid.UUID) (*models.ChainRunOutputsResponse, error) {
+	run, err := s.chainRepo.GetChainRunByID(repository.WithTenantScope(ctx, tenantID), runID)
+	if err != nil {
+		return nil, notFound(ErrRunNotFound, err)
+	}
+
+	stepRuns := make([]*models.ExecutionChainStepRun, len(run.StepRuns))
+	for i := range run.StepRuns {
+		stepRuns[i] = &run.StepRuns[i]
+	}
+
+	variables := run.Variables
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+
+	return &models.ChainRunOutputsResponse{
+		RunID:       run.ID,
+		ChainID:     run.ChainID,
+		Status:      string(run.Status),
+		CompletedAt: run.CompletedAt,
+		Variables:   variables,
+		Steps:       newRunContext(nil, stepRuns).previousSteps(),
+	}, nil
+}
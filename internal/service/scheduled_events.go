@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// scheduledEventBatchSize is the number of due scheduled events loaded at a time
+const scheduledEventBatchSize = 100
+
+// eventDeliveryTime returns when a sent event is to be delivered, or nil to deliver it right away
+// Delivery times that are not in the future deliver the event right away
+func (s *webhookService) eventDeliveryTime(req *models.SendEventRequest) (*time.Time, error) {
+	if req.DeliverAt != nil && req.DelaySeconds != 0 {
+		return nil, ErrInvalidEvent.Withf("deliver_at and delay_seconds are mutually exclusive")
+	}
+	if req.DelaySeconds < 0 {
+		return nil, ErrInvalidEvent.Withf("delay_seconds must not be negative")
+	}
+
+	now := s.clock.Now()
+	var deliverAt time.Time
+	switch {
+	case req.DeliverAt != nil:
+		deliverAt = *req.DeliverAt
+	case req.DelaySeconds > 0:
+		deliverAt = now.Add(time.Duration(req.DelaySeconds) * time.Second)
+	default:
+		return nil, nil
+	}
+
+	if !deliverAt.After(now) {
+		return nil, nil
+	}
+	if deliverAt.Sub(now) > models.MaxEventDelay {
+		return nil, ErrInvalidEvent.Withf("events can be scheduled at most %s ahead", models.MaxEventDelay)
+	}
+	deliverAt = deliverAt.UTC()
+	return &deliverAt, nil
+}
+
+// eventExpiry returns when a sent event expires, or nil for events that do not
+// Events must not have expired already, nor expire before their delivery time
+func (s *webhookService) eventExpiry(req *models.SendEventRequest, deliverAt *time.Time) (*time.Time, error) {
+	if req.ExpiresAt == nil {
+		return nil, nil
+	}
+	expiresAt := req.ExpiresAt.UTC()
+	if !expiresAt.After(s.clock.Now()) {
+		return nil, ErrInvalidEvent.Withf("expires_at must be in the future")
+	}
+	if deliverAt != nil && !expiresAt.After(*deliverAt) {
+		return nil, ErrInvalidEvent.Withf("expires_at must be after the event's delivery time")
+	}
+	return &expiresAt, nil
+}
+
+// eventExpired reports whether an event with the expiry has expired by t
+func eventExpired(expiresAt *time.Time, t time.Time) bool {
+	return expiresAt != nil && !t.Before(*expiresAt)
+}
+
+// scheduleEvent stores an event for delivery at a later time
+// Subscriptions are matched and chains triggered when the event is delivered, not when it is scheduled
+func (s *webhookService) scheduleEvent(ctx context.Context, event *models.WebhookEvent, deliverAt time.Time) (*models.EventProcessingResult, error) {
+	event.Status = models.WebhookStatusScheduled
+	event.DeliverAt = &deliverAt
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to schedule webhook event",
+			zap.Error(err),
+			zap.String("event_id", event.ID.String()))
+		return nil, fmt.Errorf("failed to schedule event: %w", err)
+	}
+
+	s.logger.Info(ctx, "Webhook event scheduled",
+		zap.String("event_id", event.ID.String()),
+		zap.String("tenant_id", event.TenantID),
+		zap.String("event", event.EventName),
+		zap.Time("deliver_at", deliverAt))
+
+	// An event the work queue did not take is delivered by the database sweep
+	if s.queue != nil {
+		if err := s.queue.Push(ctx, scheduledEventQueue, event.ID.String(), deliverAt); err != nil {
+			s.logger.Error(ctx, "Failed to queue scheduled webhook event",
+				zap.String("event_id", event.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	return &models.EventProcessingResult{
+		EventID:   event.ID,
+		Webhooks:  []models.WebhookDeliveryResult{},
+		Scheduled: true,
+		DeliverAt: &deliverAt,
+	}, nil
+}
+
+// DispatchScheduledEvents delivers the scheduled events whose delivery time has been reached
+// Scheduled events are stored, so events that became due while the server was down are delivered
+// on the first pass after a restart
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//
+// Returns:
+//   - int: Number of scheduled events delivered
+//   - error: If the due events cannot be loaded
+func (s *webhookService) DispatchScheduledEvents(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		events, err := s.repo.GetDueScheduledEvents(ctx, s.clock.Now(), scheduledEventBatchSize)
+		if err != nil {
+			return dispatched, fmt.Errorf("failed to load scheduled events: %w", err)
+		}
+		pauses, err := s.loadDeliveryPauses(ctx)
+		if err != nil {
+			return dispatched, err
+		}
+
+		batch := 0
+		for i := range events {
+			if ctx.Err() != nil {
+				return dispatched, nil
+			}
+			if s.dispatchScheduledEvent(ctx, &events[i], pauses) {
+				batch++
+			}
+		}
+		dispatched += batch
+
+		// Stop on a partial batch, or when none of the batch could be claimed
+		if len(events) < scheduledEventBatchSize || batch == 0 {
+			return dispatched, nil
+		}
+	}
+}
+
+// dispatchScheduledEvent claims a due scheduled event and delivers it to the currently matching subscriptions
+// Events of tenants whose deliveries are paused are held instead, see holdScheduledEvent
+// Returns whether the event was delivered by this call
+func (s *webhookService) dispatchScheduledEvent(ctx context.Context, event *models.WebhookEvent, pauses deliveryPauses) bool {
+	if pauses.holds(event.TenantID) {
+		s.holdScheduledEvent(ctx, event)
+		return false
+	}
+
+	// The slot is taken before the claim, so the event stays scheduled if the wait is cut short
+	release, err := s.acquireDeliverySlot(ctx, event.Priority)
+	if err != nil {
+		return false
+	}
+	defer release()
+
+	claimed, err := s.repo.ClaimScheduledEvent(ctx, event.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to claim scheduled webhook event",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+		return false
+	}
+	if !claimed {
+		return false
+	}
+	if eventExpired(event.ExpiresAt, s.clock.Now()) {
+		s.expireStoredEvent(ctx, event)
+		return false
+	}
+	event.Status = models.WebhookStatusPending
+
+	result, ok := s.deliverStoredEvent(ctx, event)
+	if !ok {
+		return false
+	}
+
+	s.logger.Info(ctx, "Scheduled webhook event delivered",
+		zap.String("event_id", event.ID.String()),
+		zap.Timep("deliver_at", event.DeliverAt),
+		zap.Int("total_sent", result.TotalSent),
+		zap.Int("total_failed", result.TotalFailed))
+	return true
+}
+
+// holdScheduledEvent claims a due scheduled event of a tenant whose deliveries are paused and holds it, so it is
+// drained with the events sent during the pause once the pause is resumed
+func (s *webhookService) holdScheduledEvent(ctx context.Context, event *models.WebhookEvent) {
+	claimed, err := s.repo.ClaimScheduledEvent(ctx, event.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to claim scheduled webhook event",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	now := s.clock.Now()
+	event.Status = models.WebhookStatusPending
+	event.HeldAt = &now
+	if err := s.repo.UpdateEvent(context.WithoutCancel(ctx), event); err != nil {
+		s.logger.Error(ctx, "Failed to hold scheduled webhook event",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+		return
+	}
+	s.logger.Info(ctx, "Scheduled webhook event held while deliveries are paused",
+		zap.String("event_id", event.ID.String()),
+		zap.String("tenant_id", event.TenantID),
+		zap.Timep("deliver_at", event.DeliverAt))
+}
+
+// deliverStoredEvent delivers a claimed event that was stored before its delivery, scheduled or held during a
+// delivery pause, to the currently matching subscriptions
+// Returns the delivery summary, and whether the event could be delivered; events that cannot are marked failed
+func (s *webhookService) deliverStoredEvent(ctx context.Context, event *models.WebhookEvent) (*models.EventProcessingResult, bool) {
+	// The stored copy is protected for good once the event is delivered
+	payload := revealPayload(ctx, s.logger, s.tenantRepo, event.TenantID, event.Payload)
+	var webhookPayload models.WebhookPayload
+	if err := json.Unmarshal([]byte(payload), &webhookPayload); err != nil {
+		s.failStoredEvent(ctx, event, fmt.Sprintf("invalid stored payload: %v", err))
+		return nil, false
+	}
+	if payload != event.Payload {
+		event.Payload = tenantPayloadProtection(ctx, s.logger, s.tenantRepo, event.TenantID).protect(ctx, payload, false, "payload")
+	}
+
+	subscriptions, err := s.repo.GetActiveSubscriptionsByTenantAndEvent(ctx, event.TenantID, event.EventName)
+	if err != nil {
+		s.failStoredEvent(ctx, event, fmt.Sprintf("failed to find webhook subscriptions: %v", err))
+		return nil, false
+	}
+
+	return s.deliverEvent(ctx, event, subscriptions, &webhookPayload, []byte(payload), webhookPayload.Payload), true
+}
+
+// failStoredEvent marks a claimed scheduled or held event as failed when it cannot be delivered
+func (s *webhookService) failStoredEvent(ctx context.Context, event *models.WebhookEvent, errMsg string) {
+	s.logger.Error(ctx, "Stored webhook event could not be delivered",
+		zap.String("event_id", event.ID.String()),
+		zap.String("error", errMsg))
+
+	event.Status = models.WebhookStatusFailed
+	event.LastError = &errMsg
+	if err := s.repo.UpdateEvent(context.WithoutCancel(ctx), event); err != nil {
+		s.logger.Error(ctx, "Failed to update stored webhook event",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// expireStoredEvent marks a claimed scheduled or held event that expired before it was delivered as expired,
+// without delivering it or triggering its chains
+func (s *webhookService) expireStoredEvent(ctx context.Context, event *models.WebhookEvent) {
+	s.logger.Info(ctx, "Stored webhook event expired before it was delivered",
+		zap.String("event_id", event.ID.String()),
+		zap.Timep("deliver_at", event.DeliverAt),
+		zap.Timep("expires_at", event.ExpiresAt))
+
+	errMsg := errEventExpired.Error()
+	event.Status = models.WebhookStatusExpired
+	event.LastError = &errMsg
+	if err := s.repo.UpdateEvent(context.WithoutCancel(ctx), event); err != nil {
+		s.logger.Error(ctx, "Failed to update stored webhook event",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// RunEventScheduler delivers the due scheduled events every interval until ctx is cancelled
+// With a work queue the passes are a sweep: they deliver the due events the queue lost and hand the events
+// due before the next pass to the queue, so events scheduled before the queue was configured are queued too
+// Parameters:
+//   - ctx: Context whose cancellation stops the scheduler
+//   - interval: Time between dispatch passes
+func (s *webhookService) RunEventScheduler(ctx context.Context, interval time.Duration) {
+	for {
+		if dispatched, err := s.DispatchScheduledEvents(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to dispatch scheduled webhook events", zap.Error(err))
+		} else if dispatched > 0 {
+			s.logger.Info(ctx, "Dispatched scheduled webhook events", zap.Int("dispatched", dispatched))
+		}
+
+		if s.queue != nil {
+			if _, err := s.queueScheduledEvents(ctx, s.clock.Now().Add(interval)); err != nil {
+				s.logger.Error(ctx, "Failed to queue scheduled webhook events", zap.Error(err))
+			}
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// queueScheduledEvents hands the scheduled events due by a time to the work queue
+// Events already in the queue keep their place, so every sweep can queue them again
+// Returns the number of events queued
+func (s *webhookService) queueScheduledEvents(ctx context.Context, until time.Time) (int, error) {
+	queued := 0
+	after := uuid.Nil
+	for {
+		events, err := s.repo.GetScheduledEventsDueBy(ctx, until, after, scheduledEventBatchSize)
+		if err != nil {
+			return queued, fmt.Errorf("failed to load scheduled events: %w", err)
+		}
+		for _, event := range events {
+			if err := s.queue.Push(ctx, scheduledEventQueue, event.ID.String(), *event.DeliverAt); err != nil {
+				return queued, fmt.Errorf("failed to queue scheduled event: %w", err)
+			}
+			queued++
+		}
+		if len(events) < scheduledEventBatchSize {
+			return queued, nil
+		}
+		after = events[len(events)-1].ID
+	}
+}
+
+// RunEventQueue delivers the scheduled events the work queue hands out every interval until ctx is cancelled
+// Does nothing without a work queue
+// Parameters:
+//   - ctx: Context whose cancellation stops the consumer
+//   - interval: Time between polls of the work queue
+func (s *webhookService) RunEventQueue(ctx context.Context, interval time.Duration) {
+	if s.queue == nil {
+		return
+	}
+	for {
+		if dispatched, err := s.dispatchQueuedEvents(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to take scheduled webhook events from the work queue", zap.Error(err))
+		} else if dispatched > 0 {
+			s.logger.Info(ctx, "Dispatched scheduled webhook events", zap.Int("dispatched", dispatched))
+		}
+
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// dispatchQueuedEvents delivers the due scheduled events taken from the work queue
+// Events that were deleted, or delivered by the sweep or another instance, are skipped
+// Returns the number of events delivered
+func (s *webhookService) dispatchQueuedEvents(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		pauses, err := s.loadDeliveryPauses(ctx)
+		if err != nil {
+			return dispatched, err
+		}
+		jobs, err := s.queue.PopDue(ctx, scheduledEventQueue, s.clock.Now(), scheduledEventBatchSize)
+		if err != nil {
+			return dispatched, err
+		}
+		for _, job := range jobs {
+			if ctx.Err() != nil {
+				return dispatched, nil
+			}
+			eventID, err := uuid.Parse(job)
+			if err != nil {
+				continue
+			}
+			event, err := s.repo.GetEventByID(ctx, eventID)
+			if err != nil || event.Status != models.WebhookStatusScheduled {
+				continue
+			}
+			if s.dispatchScheduledEvent(ctx, event, pauses) {
+				dispatched++
+			}
+		}
+		if len(jobs) < scheduledEventBatchSize {
+			return dispatched, nil
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+)
+
+// VerifySignature checks a signature a receiver computed or received against a subscription's secret
+// Verification runs through pkg/client, the same code receivers are encouraged to use, and never returns
+// the expected signature so the endpoint cannot be used to sign arbitrary bodies
+// With a tenant given, subscriptions of other tenants are not found
+func (s *webhookService) VerifySignature(ctx context.Context, tenantID string, req *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error) {
+	subscription, err := s.repo.GetSubscriptionByID(repository.WithTenantScope(ctx, tenantID), req.WebhookID)
+	if err != nil {
+		return nil, notFound(ErrWebhookNotFound, err)
+	}
+
+	response := &models.VerifySignatureResponse{
+		WebhookID: subscription.ID,
+		Problems:  []string{},
+		Hints:     []string{},
+	}
+	if subscription.SignatureScheme == models.SignatureSchemeStandardWebhooks {
+		s.verifyStandardSignature(subscription, req, response)
+		return response, nil
+	}
+
+	headers := subscription.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, subscription.TenantID))
+	response.SignatureHeader = headers.Signature
+	response.TimestampHeader = headers.Timestamp
+
+	body := []byte(req.Body)
+	signature := strings.TrimSpace(req.Signature)
+	secret := subscription.SecretToken
+
+	if client.IsEd25519Signature(signature) {
+		err = s.verifyEd25519Signature(ctx, subscription.TenantID, body, signature, req.Timestamp)
+	} else {
+		err = client.VerifySignature(body, signature, secret)
+	}
+	response.SignatureValid = err == nil
+	if err != nil {
+		response.Problems = append(response.Problems, err.Error())
+		if client.IsEd25519Signature(signature) {
+			response.Hints = append(response.Hints, "Ed25519 signatures cover <timestamp header>.<raw body>; send the timestamp and verify with the key named by "+client.KeyIDHeader)
+		} else {
+			response.Hints = append(response.Hints, signatureHints(body, signature, secret, err)...)
+		}
+	}
+
+	if req.Timestamp != "" {
+		err := client.VerifyTimestamp(req.Timestamp, s.clock.Now(), client.DefaultTolerance)
+		valid := err == nil
+		response.TimestampValid = &valid
+		if signedAt, parseErr := time.Parse(time.RFC3339, req.Timestamp); parseErr == nil {
+			skew := int64(s.clock.Now().Sub(signedAt).Seconds())
+			response.TimestampSkewSeconds = &skew
+		}
+		if err != nil {
+			response.Problems = append(response.Problems, err.Error())
+			if _, convErr := strconv.ParseInt(req.Timestamp, 10, 64); convErr == nil {
+				response.Hints = append(response.Hints, "the timestamp header is an RFC3339 time such as 2024-01-15T10:30:00Z, not Unix seconds")
+			}
+		}
+	}
+
+	response.Valid = response.SignatureValid && (response.TimestampValid == nil || *response.TimestampValid)
+	return response, nil
+}
+
+// verifyEd25519Signature checks an Ed25519 signature against every signing key of the tenant,
+// so deliveries signed before a key rotation verify too
+func (s *webhookService) verifyEd25519Signature(ctx context.Context, tenantID string, body []byte, signature, timestamp string) error {
+	keys, err := s.tenantRepo.ListSigningKeys(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	if len(keys) == 0 {
+		return client.ErrUnknownKey
+	}
+
+	for _, key := range keys {
+		err = client.VerifyEd25519(body, signature, timestamp, ed25519.PublicKey(key.PublicKey))
+		if err == nil || !errors.Is(err, client.ErrSignatureMismatch) {
+			return err
+		}
+	}
+	return err
+}
+
+// verifyStandardSignature checks a delivery signed per the Standard Webhooks specification
+// The message ID and Unix timestamp are part of the signed content, so both are required
+func (s *webhookService) verifyStandardSignature(subscription *models.WebhookSubscription, req *models.VerifySignatureRequest, response *models.VerifySignatureResponse) {
+	response.SignatureHeader = client.StandardSignatureHeader
+	response.TimestampHeader = client.StandardTimestampHeader
+
+	if req.MessageID == "" || req.Timestamp == "" {
+		response.Problems = append(response.Problems, "message_id and timestamp are required for Standard Webhooks signatures")
+		response.Hints = append(response.Hints, "send the values of the webhook-id and webhook-timestamp headers; both are signed with the body")
+		return
+	}
+
+	header := http.Header{}
+	header.Set(client.StandardIDHeader, req.MessageID)
+	header.Set(client.StandardTimestampHeader, req.Timestamp)
+	header.Set(client.StandardSignatureHeader, strings.TrimSpace(req.Signature))
+
+	// Check the signature and the timestamp separately to report both
+	err := client.VerifyStandard(header, []byte(req.Body), client.StandardSecret(subscription.SecretToken), s.clock.Now(), 0)
+	response.SignatureValid = err == nil
+	if err != nil {
+		response.Problems = append(response.Problems, err.Error())
+		if errors.Is(err, client.ErrInvalidTimestamp) {
+			response.Hints = append(response.Hints, "webhook-timestamp is Unix seconds, e.g. 1705314600, not an RFC3339 time")
+		}
+		if errors.Is(err, client.ErrSignatureMismatch) {
+			response.Hints = append(response.Hints, "the signed content is <webhook-id>.<webhook-timestamp>.<raw body>, keyed with the base64-decoded part of the whsec_ secret")
+		}
+	}
+
+	if seconds, parseErr := strconv.ParseInt(req.Timestamp, 10, 64); parseErr == nil {
+		skew := s.clock.Now().Unix() - seconds
+		valid := skew <= int64(client.DefaultTolerance.Seconds()) && -skew <= int64(client.DefaultTolerance.Seconds())
+		response.TimestampSkewSeconds = &skew
+		response.TimestampValid = &valid
+		if !valid {
+			response.Problems = append(response.Problems, client.ErrTimestampOutsideTolerance.Error())
+		}
+	}
+
+	response.Valid = response.SignatureValid && response.TimestampValid != nil && *response.TimestampValid
+}
+
+// signatureHints guesses why a signature did not verify by checking common receiver mistakes
+func signatureHints(body []byte, signature, secret string, err error) []string {
+	var hints []string
+
+	if errors.Is(err, client.ErrInvalidSignatureFormat) && client.VerifySignature(body, "sha256="+signature, secret) == nil {
+		return append(hints, "the digest is correct but the header value must keep its sha256= prefix")
+	}
+	if !errors.Is(err, client.ErrSignatureMismatch) {
+		return hints
+	}
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) != len(body) && client.VerifySignature(trimmed, signature, secret) == nil {
+		hints = append(hints, "the signature matches the body without surrounding whitespace; verify over the exact bytes received")
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, body) == nil && !bytes.Equal(compact.Bytes(), body) && client.VerifySignature(compact.Bytes(), signature, secret) == nil {
+		hints = append(hints, "the signature matches the compact JSON body; verify over the raw bytes, not re-formatted JSON")
+	}
+	if len(hints) == 0 {
+		hints = append(hints, "check that the subscription's current secret token is used and that the body was not parsed and re-encoded before verifying")
+	}
+	return hints
+}
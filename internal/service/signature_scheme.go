@@ -0,0 +1,64 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+	"github.com/sakibcoolz/zcornor/pkg/security"
+)
+
+// signRequest sets the signature headers of a delivery according to the subscription's signature scheme
+// messageID identifies the message for Standard Webhooks receivers, which use it to drop duplicates,
+// so it must stay the same across retries of one delivery. With a tenant signing key the delivery is
+// signed with Ed25519 instead of the subscription's secret, and the key ID is sent along
+func signRequest(req *http.Request, securitySvc *security.SecurityService, subscription models.WebhookSubscription, headers models.SigningHeaders, key *models.SigningKey, messageID string, payload []byte, now time.Time) error {
+	var privateKey ed25519.PrivateKey
+	if key != nil {
+		var err error
+		if privateKey, err = key.Ed25519PrivateKey(); err != nil {
+			return err
+		}
+		req.Header.Set(client.KeyIDHeader, key.ID)
+	}
+
+	if subscription.SignatureScheme == models.SignatureSchemeStandardWebhooks {
+		var signature string
+		if key != nil {
+			signature = client.SignStandardEd25519(messageID, now, payload, privateKey)
+		} else {
+			var err error
+			signature, err = client.SignStandard(messageID, now, payload, client.StandardSecret(subscription.SecretToken))
+			if err != nil {
+				return fmt.Errorf("failed to sign request: %w", err)
+			}
+		}
+		req.Header.Set(client.StandardIDHeader, messageID)
+		req.Header.Set(client.StandardTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+		req.Header.Set(client.StandardSignatureHeader, signature)
+		return nil
+	}
+
+	timestamp := now.Format(time.RFC3339)
+	if key != nil {
+		req.Header.Set(headers.Signature, client.SignEd25519(payload, timestamp, privateKey))
+	} else {
+		signature := securitySvc.GenerateHMACSignature(payload, subscription.SecretToken)
+		req.Header.Set(headers.Signature, fmt.Sprintf("sha256=%s", signature))
+	}
+	req.Header.Set(headers.Timestamp, timestamp)
+	return nil
+}
+
+// signatureHeaderNames returns the names of the signature headers signRequest sets for a subscription,
+// besides the key ID header of Ed25519 signatures
+func signatureHeaderNames(subscription models.WebhookSubscription, headers models.SigningHeaders) []string {
+	if subscription.SignatureScheme == models.SignatureSchemeStandardWebhooks {
+		return []string{client.StandardIDHeader, client.StandardTimestampHeader, client.StandardSignatureHeader}
+	}
+	return []string{headers.Signature, headers.Timestamp}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"go.uber.org/zap"
+)
+
+// reservedHeaders are set by the delivery itself and cannot carry the signature or its metadata
+var reservedHeaders = map[string]bool{
+	"Authorization":  true,
+	"Content-Length": true,
+	"Content-Type":   true,
+	"Host":           true,
+	"User-Agent":     true,
+}
+
+// normalizeSigningHeaders validates signing header name overrides and canonicalizes them
+// Parameters:
+//   - headers: Overrides to validate; empty names are left empty
+//   - fallback: Overrides the empty names resolve to, used to check the resolved names are distinct
+//
+// Returns:
+//   - SigningHeaders: Overrides with canonical header names, e.g. "x-acme-signature" becomes "X-Acme-Signature"
+//   - error: If a name is not a valid header name, is reserved, or two names resolve to the same header
+func normalizeSigningHeaders(headers, fallback models.SigningHeaders) (models.SigningHeaders, error) {
+	fields := []struct {
+		label string
+		name  *string
+	}{
+		{"signature", &headers.Signature},
+		{"timestamp", &headers.Timestamp},
+		{"attempt", &headers.Attempt},
+	}
+
+	for _, field := range fields {
+		name := strings.TrimSpace(*field.name)
+		if name == "" {
+			*field.name = ""
+			continue
+		}
+		if !isHeaderName(name) {
+			return models.SigningHeaders{}, fmt.Errorf("%s header %q is not a valid header name", field.label, name)
+		}
+		name = http.CanonicalHeaderKey(name)
+		if reservedHeaders[name] {
+			return models.SigningHeaders{}, fmt.Errorf("%s header %q is reserved", field.label, name)
+		}
+		*field.name = name
+	}
+
+	resolved := headers.Or(fallback).Or(models.DefaultSigningHeaders())
+	if resolved.Signature == resolved.Timestamp || resolved.Signature == resolved.Attempt || resolved.Timestamp == resolved.Attempt {
+		return models.SigningHeaders{}, fmt.Errorf("signature, timestamp and attempt headers must be distinct")
+	}
+	return headers, nil
+}
+
+// isHeaderName reports whether name consists only of letters, digits, hyphens and underscores
+func isHeaderName(name string) bool {
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// tenantSigningHeaders returns the tenant's signing header names with defaults filled in
+// Subscription overrides are applied on top by the caller; if the settings cannot be loaded
+// the defaults are used so deliveries are not blocked
+func tenantSigningHeaders(ctx context.Context, logger logging.Logger, tenantRepo repository.TenantRepository, tenantID string) models.SigningHeaders {
+	settings, err := tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		logger.Warn(ctx, "Failed to load tenant signing headers, using defaults",
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		return models.DefaultSigningHeaders()
+	}
+	return settings.SigningHeaders.Or(models.DefaultSigningHeaders())
+}
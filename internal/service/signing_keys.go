@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+
+	"go.uber.org/zap"
+)
+
+// tenantSigningKey returns the Ed25519 key a tenant's deliveries are signed with, nil for HMAC signing
+// Unlike header names, failing to load the key fails the delivery: falling back to HMAC would send
+// signatures the receiver cannot verify
+func tenantSigningKey(ctx context.Context, tenantRepo repository.TenantRepository, tenantID string) (*models.SigningKey, error) {
+	settings, err := tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant signing settings: %w", err)
+	}
+	if settings.SigningAlgorithm != models.SigningAlgorithmEd25519 {
+		return nil, nil
+	}
+
+	key, err := tenantRepo.GetSigningKey(ctx, settings.SigningKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing key %s: %w", settings.SigningKeyID, err)
+	}
+	return key, nil
+}
+
+// GetTenantSigning retrieves a tenant's delivery signing algorithm and current key
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant identifier
+//
+// Returns:
+//   - TenantSigningResponse: The algorithm and, for Ed25519, the current key ID and public key
+//   - error: If the tenant settings or key cannot be loaded
+func (s *webhookService) GetTenantSigning(ctx context.Context, tenantID string) (*models.TenantSigningResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	var key *models.SigningKey
+	if settings.SigningKeyID != "" {
+		if key, err = s.tenantRepo.GetSigningKey(ctx, settings.SigningKeyID); err != nil {
+			return nil, fmt.Errorf("failed to load signing key: %w", err)
+		}
+	}
+	return tenantSigningResponse(settings, key), nil
+}
+
+// UpdateTenantSigning switches the algorithm a tenant's deliveries are signed with
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant identifier
+//   - req: The algorithm and whether to rotate the Ed25519 key
+//
+// Returns:
+//   - TenantSigningResponse: The stored algorithm and current key
+//   - error: If the algorithm is unknown or the key or settings cannot be saved
+//
+// Switching to Ed25519 generates a key pair unless the tenant already has one; rotating generates a new
+// one. Earlier keys stay in the key set, so deliveries already in flight still verify
+func (s *webhookService) UpdateTenantSigning(ctx context.Context, tenantID string, req *models.TenantSigningRequest) (*models.TenantSigningResponse, error) {
+	if !req.Algorithm.IsValid() {
+		return nil, fmt.Errorf("invalid signing algorithm: %s", req.Algorithm)
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+	settings.TenantID = tenantID
+	settings.SigningAlgorithm = req.Algorithm
+
+	var key *models.SigningKey
+	if req.Algorithm == models.SigningAlgorithmEd25519 && (settings.SigningKeyID == "" || req.Rotate) {
+		if key, err = generateSigningKey(ctx, s.tenantRepo, s.clock, tenantID); err != nil {
+			return nil, err
+		}
+		settings.SigningKeyID = key.ID
+	} else if settings.SigningKeyID != "" {
+		if key, err = s.tenantRepo.GetSigningKey(ctx, settings.SigningKeyID); err != nil {
+			return nil, fmt.Errorf("failed to load signing key: %w", err)
+		}
+	}
+
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save tenant settings: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant signing algorithm updated",
+		zap.String("tenant_id", tenantID),
+		zap.String("algorithm", string(req.Algorithm)),
+		zap.String("key_id", settings.SigningKeyID))
+
+	return tenantSigningResponse(settings, key), nil
+}
+
+// GetSigningKeySet retrieves the public keys deliveries are signed with as a JSON Web Key Set
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant identifier to filter keys, empty for every tenant
+//
+// Returns:
+//   - SigningKeySet: Public keys, newest first
+//   - error: If the keys cannot be loaded
+func (s *webhookService) GetSigningKeySet(ctx context.Context, tenantID string) (*models.SigningKeySet, error) {
+	keys, err := s.tenantRepo.ListSigningKeys(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	set := &models.SigningKeySet{Keys: make([]models.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, models.JSONWebKey{
+			Kty:      "OKP",
+			Crv:      "Ed25519",
+			X:        base64.RawURLEncoding.EncodeToString(key.PublicKey),
+			Kid:      key.ID,
+			Use:      "sig",
+			Alg:      "EdDSA",
+			TenantID: key.TenantID,
+		})
+	}
+	return set, nil
+}
+
+// generateSigningKey creates and stores a new Ed25519 key pair for a tenant
+func generateSigningKey(ctx context.Context, tenantRepo repository.TenantRepository, clock Clock, tenantID string) (*models.SigningKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key ID: %w", err)
+	}
+
+	key := &models.SigningKey{
+		ID:         "lk_" + hex.EncodeToString(id),
+		TenantID:   tenantID,
+		Algorithm:  models.SigningAlgorithmEd25519,
+		PublicKey:  publicKey,
+		PrivateKey: base64.StdEncoding.EncodeToString(privateKey),
+		CreatedAt:  clock.Now(),
+	}
+	if err := tenantRepo.CreateSigningKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to save signing key: %w", err)
+	}
+	return key, nil
+}
+
+// tenantSigningResponse builds the response describing a tenant's signing settings
+func tenantSigningResponse(settings *models.TenantSettings, key *models.SigningKey) *models.TenantSigningResponse {
+	response := &models.TenantSigningResponse{
+		TenantID:  settings.TenantID,
+		Algorithm: settings.SigningAlgorithm,
+		KeysURL:   client.KeySetPath + "?tenant_id=" + url.QueryEscape(settings.TenantID),
+	}
+	if response.Algorithm == "" {
+		response.Algorithm = models.SigningAlgorithmHMAC
+	}
+	if key != nil {
+		response.KeyID = key.ID
+		response.PublicKey = base64.RawURLEncoding.EncodeToString(key.PublicKey)
+	}
+	return response
+}
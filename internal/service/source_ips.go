@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// ErrSourceIPNotAllowed is returned by VerifyWebhook when a request comes from an address outside
+// the receive allowlists
+var ErrSourceIPNotAllowed = apperr.Forbidden("source_ip_not_allowed", "source IP is not allowed")
+
+// ConfigureNetwork sets the published egress addresses and the installation-wide receive allowlist
+// Entries are IPs or CIDR ranges; an empty allowlist lets every source post to receive endpoints
+func (s *webhookService) ConfigureNetwork(egressIPs, receiveAllowlist []string) error {
+	egressIPs = trimEntries(egressIPs)
+	if _, err := parseIPAllowlist(egressIPs); err != nil {
+		return fmt.Errorf("invalid egress IPs: %w", err)
+	}
+
+	receiveAllowlist = trimEntries(receiveAllowlist)
+	var networks []*net.IPNet
+	if len(receiveAllowlist) > 0 {
+		parsed, err := parseIPAllowlist(receiveAllowlist)
+		if err != nil {
+			return fmt.Errorf("invalid receive allowlist: %w", err)
+		}
+		networks = parsed
+	}
+
+	s.egressIPs = egressIPs
+	s.receiveAllowlist = networks
+	return nil
+}
+
+// GetEgressIPs retrieves the published addresses deliveries are sent from
+func (s *webhookService) GetEgressIPs() *models.EgressIPsResponse {
+	egressIPs := s.egressIPs
+	if egressIPs == nil {
+		egressIPs = []string{}
+	}
+	return &models.EgressIPsResponse{EgressIPs: egressIPs}
+}
+
+// checkSubscriptionSource rejects requests from addresses outside a subscription's allowlist
+// Entries were validated when the subscription was created, invalid ones are skipped
+func checkSubscriptionSource(subscription *models.WebhookSubscription, sourceIP string) error {
+	if len(subscription.AllowedSourceIPs) == 0 {
+		return nil
+	}
+
+	networks := make([]*net.IPNet, 0, len(subscription.AllowedSourceIPs))
+	for _, entry := range subscription.AllowedSourceIPs {
+		if network, err := parseIPEntry(entry); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	if !ipAllowed(networks, sourceIP) {
+		return ErrSourceIPNotAllowed.Withf("source IP %s is not allowed", sourceIP)
+	}
+	return nil
+}
+
+// parseIPAllowlist parses IPs and CIDR ranges, e.g. "203.0.113.7" and "198.51.100.0/24"
+func parseIPAllowlist(entries []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		network, err := parseIPEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// parseIPEntry parses an IP or CIDR range; a single IP becomes a range containing only that address
+func parseIPEntry(entry string) (*net.IPNet, error) {
+	entry = strings.TrimSpace(entry)
+	if strings.Contains(entry, "/") {
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a CIDR range", entry)
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IP address", entry)
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return &net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// ipAllowed reports whether the IP is inside one of the networks
+func ipAllowed(networks []*net.IPNet, sourceIP string) bool {
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimEntries drops empty entries, as left by splitting an unset environment variable
+func trimEntries(entries []string) []string {
+	var trimmed []string
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			trimmed = append(trimmed, entry)
+		}
+	}
+	return trimmed
+}
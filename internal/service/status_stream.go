@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// DefaultStreamBuffer is how many events a subscriber of the status stream may lag behind before
+// further events are dropped for it
+const DefaultStreamBuffer = 256
+
+// StreamFilter selects the events a subscriber of the status stream receives
+type StreamFilter struct {
+	// TenantID is the tenant whose events are received
+	TenantID string
+
+	// Types are the event types received, every type if empty
+	Types []models.StreamEventType
+
+	// ChainID and RunID restrict run and step events to a chain or a run; delivery events are not
+	// received when either is set
+	ChainID *uuid.UUID
+	RunID   *uuid.UUID
+}
+
+// matches reports whether the filter selects an event
+func (f StreamFilter) matches(event *models.StreamEvent) bool {
+	if event.TenantID != f.TenantID {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+	if f.ChainID == nil && f.RunID == nil {
+		return true
+	}
+
+	var chainID, runID uuid.UUID
+	switch {
+	case event.Run != nil:
+		chainID, runID = event.Run.ChainID, event.Run.RunID
+	case event.Step != nil:
+		chainID, runID = event.Step.ChainID, event.Step.RunID
+	default:
+		return false
+	}
+	return (f.ChainID == nil || *f.ChainID == chainID) && (f.RunID == nil || *f.RunID == runID)
+}
+
+// StatusStream fans delivery results, run status changes and step completions out to subscribers
+// such as the clients of GET /api/streams/events
+//
+// The stream is in-process: subscribers receive the events of the deliveries and runs processed by
+// this instance. Publishing never blocks; events are dropped for subscribers whose buffer is full
+type StatusStream struct {
+	clock Clock
+
+	mu          sync.RWMutex
+	subscribers map[*StreamSubscription]struct{}
+	closed      bool
+}
+
+// NewStatusStream creates a status stream without subscribers
+func NewStatusStream() *StatusStream {
+	return &StatusStream{
+		clock:       NewSystemClock(),
+		subscribers: make(map[*StreamSubscription]struct{}),
+	}
+}
+
+// StreamSubscription receives the events of a status stream selected by its filter
+type StreamSubscription struct {
+	stream  *StatusStream
+	filter  StreamFilter
+	events  chan *models.StreamEvent
+	dropped atomic.Int64
+	once    sync.Once
+}
+
+// Subscribe registers a subscriber receiving the events selected by filter until it is closed
+// Subscriptions to a closed stream receive no events
+func (s *StatusStream) Subscribe(filter StreamFilter) *StreamSubscription {
+	subscription := &StreamSubscription{
+		stream: s,
+		filter: filter,
+		events: make(chan *models.StreamEvent, DefaultStreamBuffer),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		subscription.once.Do(func() { close(subscription.events) })
+		return subscription
+	}
+	s.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Active reports whether the stream has subscribers, so publishers can skip building events nobody receives
+func (s *StatusStream) Active() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.subscribers) > 0
+}
+
+// Publish sends an event to every subscriber it matches, stamping it with the current time
+func (s *StatusStream) Publish(event *models.StreamEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = s.clock.Now().UTC()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for subscription := range s.subscribers {
+		if !subscription.filter.matches(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.dropped.Add(1)
+		}
+	}
+}
+
+// Close ends every subscription and makes later ones receive nothing
+// Call it on shutdown, before the HTTP server waits for requests, so streaming requests return
+func (s *StatusStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for subscription := range s.subscribers {
+		delete(s.subscribers, subscription)
+		subscription.once.Do(func() { close(subscription.events) })
+	}
+}
+
+// PublishDeliveryResult publishes the outcome of delivering an event as a delivery.completed event
+func (s *StatusStream) PublishDeliveryResult(_ context.Context, event *models.WebhookEvent, result *models.EventProcessingResult) {
+	s.Publish(&models.StreamEvent{
+		Type:     models.StreamEventDeliveryCompleted,
+		TenantID: event.TenantID,
+		Delivery: &models.DeliveryStreamData{
+			EventID:      event.ID,
+			Event:        event.EventName,
+			Source:       event.Source,
+			Status:       event.Status,
+			TotalSent:    result.TotalSent,
+			TotalFailed:  result.TotalFailed,
+			TotalQueued:  result.TotalQueued,
+			TotalExpired: result.TotalExpired,
+			Webhooks:     result.Webhooks,
+		},
+	})
+}
+
+// PublishRunFinished does nothing; finished runs are published as run.status_changed events by the
+// repository returned from NewStreamingExecutionChainRepository
+func (s *StatusStream) PublishRunFinished(context.Context, *models.ExecutionChainRun, map[string]interface{}) {
+}
+
+// Events returns the channel the subscription's events are received on; it is closed with the subscription
+func (s *StreamSubscription) Events() <-chan *models.StreamEvent {
+	return s.events
+}
+
+// Dropped returns how many events were dropped because the subscriber fell behind
+func (s *StreamSubscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close stops the subscription and closes its channel
+func (s *StreamSubscription) Close() {
+	s.stream.mu.Lock()
+	defer s.stream.mu.Unlock()
+	delete(s.stream.subscribers, s)
+	s.once.Do(func() { close(s.events) })
+}
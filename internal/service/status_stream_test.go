@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// receive returns the events buffered for a subscription without waiting
+func receive(subscription *service.StreamSubscription) []*models.StreamEvent {
+	var events []*models.StreamEvent
+	for {
+		select {
+		case event, open := <-subscription.Events():
+			if !open {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// TestStatusStream tests that events reach the subscribers whose filter selects them, that slow
+// subscribers lose events instead of blocking publishers, and that closing the stream ends subscriptions
+func TestStatusStream(t *testing.T) {
+	// Arrange
+	stream := service.NewStatusStream()
+	chainID, runID := uuid.New(), uuid.New()
+
+	all := stream.Subscribe(service.StreamFilter{TenantID: "acme"})
+	deliveries := stream.Subscribe(service.StreamFilter{TenantID: "acme", Types: []models.StreamEventType{models.StreamEventDeliveryCompleted}})
+	oneRun := stream.Subscribe(service.StreamFilter{TenantID: "acme", RunID: &runID})
+	otherTenant := stream.Subscribe(service.StreamFilter{TenantID: "globex"})
+
+	// Act
+	stream.PublishDeliveryResult(context.Background(),
+		&models.WebhookEvent{ID: uuid.New(), TenantID: "acme", EventName: "order.created", Status: models.WebhookStatusSent},
+		&models.EventProcessingResult{TotalSent: 2})
+	stream.Publish(&models.StreamEvent{Type: models.StreamEventRunStatusChanged, TenantID: "acme",
+		Run: &models.RunStreamData{RunID: runID, ChainID: chainID, Status: models.ExecutionChainStatusRunning}})
+	stream.Publish(&models.StreamEvent{Type: models.StreamEventRunStatusChanged, TenantID: "acme",
+		Run: &models.RunStreamData{RunID: uuid.New(), ChainID: chainID, Status: models.ExecutionChainStatusRunning}})
+
+	allEvents, deliveryEvents, runEvents, otherEvents := receive(all), receive(deliveries), receive(oneRun), receive(otherTenant)
+
+	for range service.DefaultStreamBuffer + 5 {
+		stream.Publish(&models.StreamEvent{Type: models.StreamEventDeliveryCompleted, TenantID: "acme", Delivery: &models.DeliveryStreamData{}})
+	}
+	stream.Close()
+	_, open := <-otherTenant.Events()
+
+	// Assert
+	assert.Len(t, allEvents, 3)
+	assert.Equal(t, 2, allEvents[0].Delivery.TotalSent)
+	assert.False(t, allEvents[0].Timestamp.IsZero())
+	assert.Len(t, deliveryEvents, 1)
+	assert.Len(t, runEvents, 1)
+	assert.Equal(t, runID, runEvents[0].Run.RunID)
+	assert.Empty(t, otherEvents)
+	assert.Equal(t, int64(5), all.Dropped())
+	assert.Equal(t, int64(0), oneRun.Dropped())
+	assert.False(t, open)
+	assert.False(t, stream.Active())
+}
+
+// TestStreamingExecutionChainRepository tests that run status changes and step completions written through
+// the repository are published with the run's tenant and chain, and that nothing is published without subscribers
+func TestStreamingExecutionChainRepository(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := mocks.NewMockExecutionChainRepository(t)
+	stream := service.NewStatusStream()
+	repo := service.NewStreamingExecutionChainRepository(next, stream, logging.Nop())
+
+	run := &models.ExecutionChainRun{ID: uuid.New(), ChainID: uuid.New(), TenantID: "acme", Status: models.ExecutionChainStatusPending}
+	untracked := &models.ExecutionChainRun{ID: uuid.New(), ChainID: uuid.New(), TenantID: "acme", Status: models.ExecutionChainStatusRunning}
+	stepRun := &models.ExecutionChainStepRun{ID: uuid.New(), RunID: run.ID, StepID: uuid.New(), StepOrder: 1, Status: models.WebhookStatusPending}
+
+	next.EXPECT().CreateChainRun(mock.Anything, mock.Anything).Return(nil).Once()
+	next.EXPECT().UpdateChainRunStatus(mock.Anything, run.ID, models.ExecutionChainStatusRunning).Return(nil).Once()
+	next.EXPECT().CreateStepRun(mock.Anything, stepRun).Return(nil).Once()
+	next.EXPECT().UpdateStepRun(mock.Anything, stepRun.ID, mock.Anything).Return(nil).Twice()
+	next.EXPECT().UpdateChainRun(mock.Anything, run.ID, mock.Anything).Return(nil).Once()
+	next.EXPECT().UpdateChainRunIfStatus(mock.Anything, untracked.ID, models.ExecutionChainStatusRunning, mock.Anything).Return(true, nil).Once()
+	next.EXPECT().GetChainRunByID(mock.Anything, untracked.ID).Return(untracked, nil).Once()
+
+	// Act
+	assert.NoError(t, repo.CreateChainRun(ctx, run))
+	subscription := stream.Subscribe(service.StreamFilter{TenantID: "acme"})
+	assert.NoError(t, repo.UpdateChainRunStatus(ctx, run.ID, models.ExecutionChainStatusRunning))
+	assert.NoError(t, repo.CreateStepRun(ctx, stepRun))
+	assert.NoError(t, repo.UpdateStepRun(ctx, stepRun.ID, map[string]interface{}{"attempt_count": 1}))
+	assert.NoError(t, repo.UpdateStepRun(ctx, stepRun.ID, map[string]interface{}{
+		"status": models.WebhookStatusSent, "response_code": 200, "attempt_count": 2,
+	}))
+	assert.NoError(t, repo.UpdateChainRun(ctx, run.ID, map[string]interface{}{"status": models.ExecutionChainStatusCompleted}))
+	_, err := repo.UpdateChainRunIfStatus(ctx, untracked.ID, models.ExecutionChainStatusRunning,
+		map[string]interface{}{"status": models.ExecutionChainStatusCancelled})
+	events := receive(subscription)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, models.StreamEventRunStatusChanged, events[0].Type)
+		assert.Equal(t, run.ChainID, events[0].Run.ChainID)
+		assert.Equal(t, models.ExecutionChainStatusRunning, events[0].Run.Status)
+
+		assert.Equal(t, models.StreamEventStepCompleted, events[1].Type)
+		assert.Equal(t, run.ID, events[1].Step.RunID)
+		assert.Equal(t, stepRun.StepID, events[1].Step.StepID)
+		assert.Equal(t, 200, *events[1].Step.ResponseCode)
+		assert.Equal(t, 2, events[1].Step.AttemptCount)
+
+		assert.Equal(t, models.ExecutionChainStatusCompleted, events[2].Run.Status)
+		assert.Equal(t, untracked.ChainID, events[3].Run.ChainID)
+		assert.Equal(t, models.ExecutionChainStatusCancelled, events[3].Run.Status)
+	}
+}
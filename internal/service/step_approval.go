@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ApproveChainRun approves the approval step a run is waiting on and resumes the run after it
+// The run is queued instead when the tenant's chain executions are paused or a concurrency limit is reached
+func (s *executionChainService) ApproveChainRun(ctx context.Context, runID uuid.UUID, approval models.StepApproval) (*models.ChainRunControlResponse, error) {
+	approval.Decision = models.ApprovalDecisionApproved
+	run, err := s.decideApproval(ctx, runID, &approval, map[string]interface{}{
+		"status":     models.ExecutionChainStatusPaused,
+		"updated_at": s.clock.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	run.Status = models.ExecutionChainStatusPaused
+
+	response, err := s.resumeRun(ctx, run)
+	if err != nil {
+		// The decision stands; the run is paused and can be resumed once the problem is resolved
+		return nil, ErrInvalidRunState.Withf("run approved but not resumed, resume it once resolved").Wrap(err)
+	}
+	return response, nil
+}
+
+// RejectChainRun rejects the approval step a run is waiting on and fails the run
+// Completed steps with a compensation webhook are compensated in the background, as for any failed run
+func (s *executionChainService) RejectChainRun(ctx context.Context, runID uuid.UUID, approval models.StepApproval) (*models.ChainRunControlResponse, error) {
+	approval.Decision = models.ApprovalDecisionRejected
+	now := s.clock.Now()
+	run, err := s.decideApproval(ctx, runID, &approval, map[string]interface{}{
+		"status":       models.ExecutionChainStatusRunning,
+		"last_error":   rejectionMessage(approval),
+		"worker_id":    s.instanceID,
+		"heartbeat_at": now,
+		"updated_at":   now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var triggerData map[string]interface{}
+	if run.TriggerData != "" {
+		if err := json.Unmarshal([]byte(revealPayload(ctx, s.logger, s.tenantRepo, run.TenantID, run.TriggerData)), &triggerData); err != nil {
+			s.logger.Error(ctx, "Invalid trigger data of rejected run",
+				zap.String("run_id", run.ID.String()),
+				zap.Error(err))
+		}
+	}
+	stepRuns, err := s.chainRepo.GetStepRunsByRun(ctx, run.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load step results of rejected run",
+			zap.String("run_id", run.ID.String()),
+			zap.Error(err))
+	}
+	rc := newRunContext(triggerData, stepRuns)
+	rc.protection = tenantPayloadProtection(ctx, s.logger, s.tenantRepo, run.TenantID).forSubject(ctx, triggerData)
+
+	var steps []models.ExecutionChainStep
+	if chain, err := s.runChain(ctx, run); err == nil {
+		steps = sortedSteps(chain)
+	} else {
+		s.logger.Error(ctx, "Failed to load steps of rejected run, skipping compensation",
+			zap.String("run_id", run.ID.String()),
+			zap.Error(err))
+	}
+
+	fail := func(ctx context.Context) {
+		s.failRun(ctx, run.ID, steps, rc)
+		s.admitAfterRun(ctx, run.TenantID)
+	}
+	if !s.workers.Go(ctx, run.ID, fail, func(ctx context.Context) { fail(context.WithoutCancel(ctx)) }) {
+		fail(context.WithoutCancel(ctx))
+	}
+
+	return chainRunControlResponse(run, models.ExecutionChainStatusFailed), nil
+}
+
+// decideApproval records a decision on the approval steps a run is waiting on
+// The run is moved out of the awaiting approval status with the given updates first, so that of two
+// concurrent decisions only one applies; approval.DecidedAt is set to the current time
+func (s *executionChainService) decideApproval(ctx context.Context, runID uuid.UUID, approval *models.StepApproval, updates map[string]interface{}) (*models.ExecutionChainRun, error) {
+	run, err := s.chainRepo.GetChainRunByID(ctx, runID)
+	if err != nil {
+		return nil, notFound(ErrRunNotFound, err)
+	}
+
+	if run.Status != models.ExecutionChainStatusAwaitingApproval {
+		return nil, ErrInvalidRunState.Withf("run is %s, only runs awaiting approval can be %s", run.Status, approval.Decision)
+	}
+	// The goroutine that requested the approval may still be winding down
+	if s.workers.Running(run.ID) {
+		return nil, ErrInvalidRunState.Withf("run is still stopping, retry the decision")
+	}
+
+	pending := pendingApprovals(run)
+	if len(pending) == 0 {
+		return nil, ErrInvalidRunState.Withf("run has no approval step awaiting a decision")
+	}
+
+	approval.DecidedAt = s.clock.Now()
+	approvalJSON, err := json.Marshal(approval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approval metadata: %w", err)
+	}
+
+	decided, err := s.chainRepo.UpdateChainRunIfStatus(ctx, run.ID, models.ExecutionChainStatusAwaitingApproval, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chain run: %w", err)
+	}
+	if !decided {
+		return nil, ErrInvalidRunState.Withf("run was already approved, rejected or cancelled")
+	}
+
+	stepUpdates := map[string]interface{}{
+		"status":       models.WebhookStatusSent,
+		"approval":     string(approvalJSON),
+		"completed_at": approval.DecidedAt,
+		"updated_at":   approval.DecidedAt,
+	}
+	if approval.Decision == models.ApprovalDecisionRejected {
+		stepUpdates["status"] = models.WebhookStatusFailed
+		stepUpdates["last_error"] = rejectionMessage(*approval)
+	}
+	for _, stepRun := range pending {
+		if err := s.chainRepo.UpdateStepRun(ctx, stepRun.ID, stepUpdates); err != nil {
+			return nil, fmt.Errorf("failed to record approval decision: %w", err)
+		}
+	}
+
+	s.logger.Info(ctx, "Approval decision recorded",
+		zap.String("run_id", run.ID.String()),
+		zap.String("decision", string(approval.Decision)),
+		zap.String("approver", approval.Approver),
+		zap.Int("approval_steps", len(pending)))
+
+	return run, nil
+}
+
+// pendingApprovals returns the step runs of approval steps that wait for a decision
+// Parallel branches or independent graph steps can leave a run waiting on several approval steps;
+// a decision applies to all of them
+func pendingApprovals(run *models.ExecutionChainRun) []*models.ExecutionChainStepRun {
+	var pending []*models.ExecutionChainStepRun
+	for i := range run.StepRuns {
+		stepRun := &run.StepRuns[i]
+		if stepRun.Step.Type == models.StepTypeApproval && stepRun.Status == models.WebhookStatusPending && stepRun.Approval == nil {
+			pending = append(pending, stepRun)
+		}
+	}
+	return pending
+}
+
+// rejectionMessage describes a rejection for the run's and the step run's last error
+func rejectionMessage(approval models.StepApproval) string {
+	msg := "approval rejected"
+	if approval.Approver != "" {
+		msg += " by " + approval.Approver
+	}
+	if approval.Comment != "" {
+		msg += ": " + approval.Comment
+	}
+	return msg
+}
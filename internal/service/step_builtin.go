@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Built-in steps run inside the chain service instead of calling a webhook:
+//
+//   - delay waits for the step's delay_seconds and succeeds
+//   - transform renders its request_params like a webhook step would and merges the result into the
+//     run variables, which later steps reference as .vars.<name> in templates and conditions
+//   - branch evaluates its branches in order and chooses the first whose condition holds; steps whose
+//     branch_path names another branch of the step are skipped
+//
+// Their output is recorded as the step run's response, so a resumed run restores run variables and
+// branch choices from the step runs, and later steps can also reference it as .step_N.response
+
+// validateStepType checks that a step of a chain creation request has the fields its type requires
+// Returns:
+//   - error: If the type is unknown, a webhook step has no webhook, a built-in step has one, or a
+//     built-in step misses its delay, request params or branches
+func validateStepType(step models.CreateExecutionChainStep) error {
+	switch step.Type {
+	case "", models.StepTypeWebhook, models.StepTypeApproval:
+		if step.WebhookID == nil {
+			return fmt.Errorf("webhook_id is required for %s steps", stepTypeName(step.Type))
+		}
+	case models.StepTypeDelay:
+		if step.DelaySeconds <= 0 {
+			return fmt.Errorf("delay steps require a positive delay_seconds")
+		}
+	case models.StepTypeTransform:
+		if len(step.RequestParams) == 0 {
+			return fmt.Errorf("transform steps require request_params to render into run variables")
+		}
+	case models.StepTypeBranch:
+		if step.Key == "" {
+			return fmt.Errorf("branch steps require a key for branch_path to reference")
+		}
+		if len(step.Branches) == 0 {
+			return fmt.Errorf("branch steps require at least one branch")
+		}
+	default:
+		return fmt.Errorf("unknown step type %q, must be webhook, approval, delay, transform or branch", step.Type)
+	}
+
+	if !step.Type.CallsWebhook() && step.WebhookID != nil {
+		return fmt.Errorf("%s steps do not call a webhook, omit webhook_id", step.Type)
+	}
+	if step.Type != models.StepTypeBranch && len(step.Branches) > 0 {
+		return fmt.Errorf("branches are only valid on branch steps")
+	}
+	return nil
+}
+
+// stepTypeName returns the name of a step type for messages, webhook when unset
+func stepTypeName(stepType models.StepType) string {
+	if stepType == "" {
+		return string(models.StepTypeWebhook)
+	}
+	return string(stepType)
+}
+
+// validateStepBranches checks the branches and branch_path references of a chain creation request
+// Parameters:
+//   - steps: Steps of the request, already checked by validateStepType and validateStepDependencies
+//
+// Returns:
+//   - error: If a branch name is empty, duplicated or contains "/", a branch condition does not parse,
+//     or a branch_path references an unknown branch or a branch step that does not run before the step
+func validateStepBranches(steps []models.CreateExecutionChainStep) error {
+	keys := make(map[string]int, len(steps))
+	usesDependencies := false
+	for i, step := range steps {
+		if step.Key != "" {
+			keys[step.Key] = i
+		}
+		if len(step.DependsOn) > 0 {
+			usesDependencies = true
+		}
+
+		names := make(map[string]bool, len(step.Branches))
+		for _, branch := range step.Branches {
+			if branch.Name == "" || strings.Contains(branch.Name, "/") {
+				return fmt.Errorf("step %d: branch names must be non-empty and must not contain \"/\"", i+1)
+			}
+			if names[branch.Name] {
+				return fmt.Errorf("step %d: branch %q is listed twice", i+1, branch.Name)
+			}
+			names[branch.Name] = true
+
+			if branch.Condition != "" {
+				if _, err := parseCondition(branch.Condition); err != nil {
+					return fmt.Errorf("step %d: branch %q: invalid condition: %w", i+1, branch.Name, err)
+				}
+			}
+		}
+	}
+
+	for i, step := range steps {
+		if step.BranchPath == "" {
+			continue
+		}
+
+		key, name, ok := strings.Cut(step.BranchPath, "/")
+		if !ok {
+			return fmt.Errorf("step %d: branch_path %q must be <branch step key>/<branch name>", i+1, step.BranchPath)
+		}
+		b, ok := keys[key]
+		if !ok || steps[b].Type != models.StepTypeBranch {
+			return fmt.Errorf("step %d: branch_path references unknown branch step %q", i+1, key)
+		}
+		if !hasBranch(steps[b].Branches, name) {
+			return fmt.Errorf("step %d: branch step %q has no branch %q", i+1, key, name)
+		}
+
+		// The branch step must have chosen a path by the time the step starts
+		if usesDependencies {
+			if !dependsOnStep(steps, keys, i, key) {
+				return fmt.Errorf("step %d: must depend on branch step %q to be on one of its paths", i+1, key)
+			}
+		} else if b >= i || (step.ParallelGroup != "" && step.ParallelGroup == steps[b].ParallelGroup) {
+			return fmt.Errorf("step %d: branch step %q must run before the steps on its paths", i+1, key)
+		}
+	}
+	return nil
+}
+
+// hasBranch reports whether branches contains a branch with the given name
+func hasBranch(branches []models.StepBranch, name string) bool {
+	for _, branch := range branches {
+		if branch.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOnStep reports whether step i depends on the step with the given key, directly or through other steps
+func dependsOnStep(steps []models.CreateExecutionChainStep, keys map[string]int, i int, key string) bool {
+	visited := make(map[int]bool)
+	pending := []int{i}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		for _, dependency := range steps[current].DependsOn {
+			if dependency == key {
+				return true
+			}
+			if j, ok := keys[dependency]; ok && !visited[j] {
+				visited[j] = true
+				pending = append(pending, j)
+			}
+		}
+	}
+	return false
+}
+
+// executeBuiltinStep runs a delay, transform or branch step and records its step run
+// A step whose output cannot be produced fails without retries, since retrying cannot change the result
+func (s *executionChainService) executeBuiltinStep(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, rc *runContext) bool {
+	// Step results are recorded even when the run is cancelled meanwhile
+	dbCtx := context.WithoutCancel(ctx)
+
+	output, outputErr := builtinStepOutput(step, rc)
+
+	now := s.clock.Now()
+	stepRun := &models.ExecutionChainStepRun{
+		ID:           uuid.New(),
+		RunID:        runID,
+		StepID:       step.ID,
+		StepOrder:    step.StepOrder,
+		Status:       models.WebhookStatusSent,
+		AttemptCount: 1,
+		StartedAt:    &now,
+		CompletedAt:  &now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if outputErr == nil {
+		outputBytes, err := json.Marshal(output)
+		if err != nil {
+			outputErr = fmt.Errorf("invalid step output: %w", err)
+		} else {
+			outputJSON := string(outputBytes)
+			stepRun.ResponseBody = &outputJSON
+			stepRun.ResponseBytes = int64(len(outputBytes))
+		}
+	}
+	if outputErr != nil {
+		errMsg := outputErr.Error()
+		stepRun.Status = models.WebhookStatusFailed
+		stepRun.LastError = &errMsg
+	}
+
+	if err := s.chainRepo.CreateStepRun(dbCtx, stepRun); err != nil {
+		s.logger.Error(ctx, "Failed to create step run", zap.Error(err))
+		return false
+	}
+
+	if outputErr != nil {
+		s.logger.Error(ctx, "Built-in step failed",
+			zap.String("run_id", runID.String()),
+			zap.Int("step_order", step.StepOrder),
+			zap.String("step_type", string(step.Type)),
+			zap.Error(outputErr))
+		return false
+	}
+
+	rc.applyBuiltinResult(step, output)
+	rc.record(step.StepOrder, step.Name, nil, stepRun.ResponseBody)
+	if extracted := rc.extract(step); extracted || step.Type == models.StepTypeTransform {
+		s.saveRunVariables(dbCtx, runID, rc)
+	}
+	return true
+}
+
+// builtinStepOutput produces the output of a built-in step: the rendered variables of a transform
+// step, the path chosen by a branch step, or the delay of a delay step
+func builtinStepOutput(step *models.ExecutionChainStep, rc *runContext) (map[string]interface{}, error) {
+	switch step.Type {
+	case models.StepTypeDelay:
+		return map[string]interface{}{"delay_seconds": step.DelaySeconds}, nil
+	case models.StepTypeTransform:
+		vars, err := renderStepParams(step, rc)
+		if err != nil {
+			return nil, err
+		}
+		if vars == nil {
+			vars = map[string]interface{}{}
+		}
+		return vars, nil
+	case models.StepTypeBranch:
+		path, err := selectBranch(step, rc)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"path": path}, nil
+	}
+	return nil, fmt.Errorf("step type %q does not run built in", step.Type)
+}
+
+// selectBranch returns the name of the first branch whose condition holds, or an empty name
+// when none does, in which case the steps on every path of the branch step are skipped
+func selectBranch(step *models.ExecutionChainStep, rc *runContext) (string, error) {
+	data := rc.templateData()
+	for _, branch := range step.Branches {
+		if branch.Condition == "" {
+			return branch.Name, nil
+		}
+		selected, err := evaluateCondition(branch.Condition, data)
+		if err != nil {
+			return "", fmt.Errorf("branch %q: condition could not be evaluated: %w", branch.Name, err)
+		}
+		if selected {
+			return branch.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// applyBuiltinResult updates the run variables or branch choices from the output of a built-in step
+func (rc *runContext) applyBuiltinResult(step *models.ExecutionChainStep, output map[string]interface{}) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	switch step.Type {
+	case models.StepTypeTransform:
+		for name, value := range output {
+			rc.vars[name] = value
+		}
+	case models.StepTypeBranch:
+		path, _ := output["path"].(string)
+		rc.branches[step.Key] = path
+	}
+}
+
+// onTakenPath reports whether the branch step named by a "<branch step key>/<branch name>" path
+// has run and chosen that path
+func (rc *runContext) onTakenPath(branchPath string) bool {
+	key, name, _ := strings.Cut(branchPath, "/")
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	chosen, ok := rc.branches[key]
+	return ok && chosen == name
+}
@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// TestExecuteChain_BuiltinSteps tests that a delay step holds the run for its delay without calling anything,
+// that a transform step renders its params into run variables that later steps reference, and that a branch
+// step takes the first path whose condition holds and skips the steps on its other paths
+func TestExecuteChain_BuiltinSteps(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	expressBody := make(chan string, 1)
+	server := newStepServer(t, map[string]http.HandlerFunc{
+		"/express": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			expressBody <- string(body)
+			_, _ = w.Write([]byte(`{"ok": true}`))
+		},
+	})
+
+	// Act
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Cool off", Type: models.StepTypeDelay, DelaySeconds: 30},
+		models.CreateExecutionChainStep{Name: "Prepare", Type: models.StepTypeTransform,
+			RequestParams: map[string]interface{}{"order": "{{.trigger_data.order_id}}", "route": "express"}},
+		models.CreateExecutionChainStep{Name: "Route", Key: "route", Type: models.StepTypeBranch, Branches: []models.StepBranch{
+			{Name: "standard", Condition: ".vars.route == 'standard'"},
+			{Name: "express", Condition: ".vars.route == 'express'"},
+			{Name: "fallback"},
+		}},
+		models.CreateExecutionChainStep{Name: "Ship standard", BranchPath: "route/standard", WebhookID: env.webhook(t, server, "/standard")},
+		models.CreateExecutionChainStep{Name: "Ship express", BranchPath: "route/express", WebhookID: env.webhook(t, server, "/express"),
+			RequestParams: map[string]interface{}{"order": "{{.vars.order}}"}},
+	)
+	env.clock.BlockUntil(1)
+	assert.Empty(t, server.Calls(), "no step runs before the delay elapsed")
+	env.clock.Advance(30 * time.Second)
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	assert.Equal(t, []string{"/express"}, server.Calls())
+	assert.Contains(t, <-expressBody, `"order":"ord-1"`)
+	assert.Equal(t, map[string]interface{}{"order": "ord-1", "route": "express"}, run.Variables)
+
+	stepRuns := stepRunsByOrder(run)
+	require.Len(t, stepRuns, 5)
+	for order, want := range map[int]string{
+		1: `{"delay_seconds":30}`,
+		2: `{"order":"ord-1","route":"express"}`,
+		3: `{"path":"express"}`,
+	} {
+		assert.Equal(t, models.WebhookStatusSent, stepRuns[order].Status, "step %d", order)
+		require.NotNil(t, stepRuns[order].ResponseBody, "step %d", order)
+		assert.JSONEq(t, want, *stepRuns[order].ResponseBody, "step %d", order)
+	}
+	assert.Equal(t, models.WebhookStatusSkipped, stepRuns[4].Status)
+	require.NotNil(t, stepRuns[4].LastError)
+	assert.Equal(t, `skipped: branch path "route/standard" not taken`, *stepRuns[4].LastError)
+	assert.Equal(t, models.WebhookStatusSent, stepRuns[5].Status)
+}
+
+// TestExecuteChain_BranchWithoutMatch tests that a branch step whose conditions all fail chooses no path, so the
+// steps on every path are skipped while the steps on no path still run
+func TestExecuteChain_BranchWithoutMatch(t *testing.T) {
+	// Arrange
+	env := newMemoryChains(t, time.Now())
+	server := newStepServer(t, nil)
+
+	// Act
+	runID := env.execute(t,
+		models.CreateExecutionChainStep{Name: "Route", Key: "route", Type: models.StepTypeBranch, Branches: []models.StepBranch{
+			{Name: "refund", Condition: ".trigger_data.refund"},
+		}},
+		models.CreateExecutionChainStep{Name: "Refund", BranchPath: "route/refund", WebhookID: env.webhook(t, server, "/refund")},
+		models.CreateExecutionChainStep{Name: "Notify", WebhookID: env.webhook(t, server, "/notify")},
+	)
+	run := env.waitForRun(t, runID, models.ExecutionChainStatusCompleted)
+
+	// Assert
+	assert.Equal(t, []string{"/notify"}, server.Calls())
+	stepRuns := stepRunsByOrder(run)
+	require.NotNil(t, stepRuns[1].ResponseBody)
+	assert.JSONEq(t, `{"path":""}`, *stepRuns[1].ResponseBody)
+	assert.Equal(t, models.WebhookStatusSkipped, stepRuns[2].Status)
+}
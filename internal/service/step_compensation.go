@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// failRun ends a run as failed after compensating the steps that completed before the failure
+// Compensation follows the saga pattern: the compensation webhooks of completed steps are called
+// newest step first, and each call is recorded as a compensation run of the chain run
+func (s *executionChainService) failRun(ctx context.Context, runID uuid.UUID, steps []models.ExecutionChainStep, rc *runContext) {
+	if status := s.compensateRun(ctx, runID, steps, rc); status != "" {
+		if err := s.chainRepo.UpdateChainRun(ctx, runID, map[string]interface{}{
+			"compensation_status": status,
+			"updated_at":          s.clock.Now(),
+		}); err != nil {
+			s.logger.Error(ctx, "Failed to record compensation status",
+				zap.String("run_id", runID.String()),
+				zap.Error(err))
+		}
+	}
+	s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusFailed)
+	s.notifyRunFinished(ctx, runID)
+}
+
+// compensateRun calls the compensation webhooks of the completed steps in reverse step order
+// A failed compensation does not stop the others, so as much as possible is undone
+// Returns the run's compensation status, or an empty status when no completed step has a compensation webhook
+func (s *executionChainService) compensateRun(ctx context.Context, runID uuid.UUID, steps []models.ExecutionChainStep, rc *runContext) models.CompensationStatus {
+	var completed []models.ExecutionChainStep
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].CompensationWebhookID != nil && steps[i].CompensationWebhook != nil && rc.succeeded(steps[i].StepOrder) {
+			completed = append(completed, steps[i])
+		}
+	}
+	if len(completed) == 0 {
+		return ""
+	}
+
+	s.logger.Info(ctx, "Compensating completed steps of failed run",
+		zap.String("run_id", runID.String()),
+		zap.Int("steps", len(completed)))
+
+	if err := s.chainRepo.UpdateChainRun(ctx, runID, map[string]interface{}{
+		"compensation_status": models.CompensationStatusRunning,
+		"updated_at":          s.clock.Now(),
+	}); err != nil {
+		s.logger.Error(ctx, "Failed to record compensation status",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+	}
+
+	status := models.CompensationStatusCompleted
+	for i := range completed {
+		if !s.executeCompensation(ctx, runID, &completed[i], rc) {
+			status = models.CompensationStatusFailed
+		}
+	}
+	return status
+}
+
+// executeCompensation calls the compensation webhook of a step with the step's retry settings
+// The compensation params are rendered against the same data as request params, including the step's own response
+func (s *executionChainService) executeCompensation(ctx context.Context, runID uuid.UUID, step *models.ExecutionChainStep, rc *runContext) bool {
+	// Compensation results are recorded even when the run is cancelled meanwhile
+	dbCtx := context.WithoutCancel(ctx)
+
+	var requestParams map[string]interface{}
+	var renderErr error
+	if step.CompensationParams != nil && *step.CompensationParams != "" {
+		if err := json.Unmarshal([]byte(*step.CompensationParams), &requestParams); err != nil {
+			renderErr = fmt.Errorf("invalid compensation params: %w", err)
+		} else if requestParams, err = renderRequestParams(requestParams, rc.templateData()); err != nil {
+			renderErr = fmt.Errorf("failed to render compensation params: %w", err)
+		}
+	}
+
+	now := s.clock.Now()
+	compensation := &models.ExecutionChainCompensationRun{
+		ID:        uuid.New(),
+		RunID:     runID,
+		StepID:    step.ID,
+		StepOrder: step.StepOrder,
+		WebhookID: *step.CompensationWebhookID,
+		Status:    models.WebhookStatusPending,
+		StartedAt: &now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if requestParams != nil {
+		if paramsBytes, err := json.Marshal(requestParams); err == nil {
+			compensation.RequestPayload = rc.protection.protect(ctx, string(paramsBytes), false)
+		}
+	}
+	if renderErr != nil {
+		errMsg := renderErr.Error()
+		compensation.Status = models.WebhookStatusFailed
+		compensation.LastError = &errMsg
+		compensation.CompletedAt = &now
+	}
+
+	if err := s.chainRepo.CreateCompensationRun(dbCtx, compensation); err != nil {
+		s.logger.Error(ctx, "Failed to create compensation run", zap.Error(err))
+		return false
+	}
+	if renderErr != nil {
+		s.logger.Error(ctx, "Failed to render compensation params",
+			zap.String("run_id", runID.String()),
+			zap.Int("step_order", step.StepOrder),
+			zap.Error(renderErr))
+		return false
+	}
+
+	payload := map[string]interface{}{
+		"step_name":      step.Name,
+		"step_order":     step.StepOrder,
+		"compensation":   true,
+		"trigger_data":   rc.triggerData,
+		"previous_steps": rc.previousSteps(),
+		"timestamp":      s.clock.Now().Format(time.RFC3339),
+	}
+	if requestParams != nil {
+		payload["request_params"] = requestParams
+	}
+
+	for attempt := 0; attempt <= step.MaxRetries; attempt++ {
+		if attempt > 0 && !sleepContext(ctx, s.clock, time.Duration(attempt*attempt)*time.Second) {
+			break
+		}
+
+		outcome, _ := s.deliverChainPayload(ctx, step.CompensationWebhook, payload, attempt+1)
+		responseBody, err := s.capture.outcome(outcome)
+		success := err == nil
+
+		updates := map[string]interface{}{
+			"attempt_count": attempt + 1,
+			"updated_at":    s.clock.Now(),
+		}
+		if outcome.ResponseCode != nil {
+			updates["response_code"] = *outcome.ResponseCode
+			updates["response_body"] = *responseBody
+		}
+		if err != nil {
+			updates["last_error"] = err.Error()
+		}
+		if success {
+			updates["status"] = models.WebhookStatusSent
+			updates["completed_at"] = s.clock.Now()
+		} else if attempt == step.MaxRetries || ctx.Err() != nil {
+			updates["status"] = models.WebhookStatusFailed
+			updates["completed_at"] = s.clock.Now()
+		}
+		if err := s.chainRepo.UpdateCompensationRun(dbCtx, compensation.ID, updates); err != nil {
+			s.logger.Error(ctx, "Failed to update compensation run", zap.Error(err))
+		}
+
+		if success {
+			s.logger.Info(ctx, "Step compensated",
+				zap.String("run_id", runID.String()),
+				zap.Int("step_order", step.StepOrder))
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		s.logger.Error(ctx, "Compensation attempt failed",
+			zap.String("run_id", runID.String()),
+			zap.Int("step_order", step.StepOrder),
+			zap.Int("attempt", attempt+1),
+			zap.Error(err))
+		if attempt == step.MaxRetries {
+			return false
+		}
+	}
+
+	// Cancelled while backing off before a retry
+	if err := s.chainRepo.UpdateCompensationRun(dbCtx, compensation.ID, map[string]interface{}{
+		"status":       models.WebhookStatusFailed,
+		"last_error":   context.Cause(ctx).Error(),
+		"completed_at": s.clock.Now(),
+		"updated_at":   s.clock.Now(),
+	}); err != nil {
+		s.logger.Error(ctx, "Failed to update compensation run", zap.Error(err))
+	}
+	return false
+}
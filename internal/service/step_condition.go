@@ -0,0 +1,387 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Step conditions are boolean expressions deciding whether a chain step runs, evaluated against the
+// same data as request param templates (.trigger_data and .step_N.*)
+//
+// Grammar:
+//
+//	expr       = or
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand ]
+//	operand    = path | number | string | "true" | "false" | "null" | "(" expr ")"
+//	path       = "." name { "." name }
+//
+// Strings use single or double quotes. A path that does not resolve evaluates to null, so conditions
+// on optional fields do not fail the run. An operand used without a comparison is tested for truthiness:
+// null, false, 0, "" and empty arrays and objects are false
+
+// conditionNode is a node of a parsed step condition
+type conditionNode interface {
+	eval(data map[string]interface{}) interface{}
+}
+
+// conditionToken is a lexical token of a step condition
+type conditionToken struct {
+	kind  string // "path", "number", "string", "ident", "op" or "eof"
+	text  string
+	value interface{}
+	pos   int
+}
+
+// parseCondition parses a step condition expression
+// Parameters:
+//   - expr: Condition expression, see the grammar above
+//
+// Returns:
+//   - conditionNode: Parsed expression ready for evaluation
+//   - error: If the expression is malformed
+func parseCondition(expr string) (conditionNode, error) {
+	tokens, err := lexCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &conditionParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != "eof" {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return node, nil
+}
+
+// evaluateCondition parses and evaluates a step condition against template data
+// Returns true when the step should run
+func evaluateCondition(expr string, data map[string]interface{}) (bool, error) {
+	node, err := parseCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	return truthy(node.eval(data)), nil
+}
+
+// lexCondition splits a condition expression into tokens
+func lexCondition(expr string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '.':
+			start := i
+			i++
+			for i < len(expr) && (isIdentChar(expr[i]) || expr[i] == '.') {
+				i++
+			}
+			text := expr[start:i]
+			if text == "." || strings.Contains(text, "..") || strings.HasSuffix(text, ".") {
+				return nil, fmt.Errorf("invalid path %q at position %d", text, start)
+			}
+			tokens = append(tokens, conditionToken{kind: "path", text: text, pos: start})
+		case c == '\'' || c == '"':
+			start := i
+			i++
+			var sb strings.Builder
+			for i < len(expr) && expr[i] != c {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					i++
+				}
+				sb.WriteByte(expr[i])
+				i++
+			}
+			if i >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, conditionToken{kind: "string", text: expr[start:i], value: sb.String(), pos: start})
+		case c == '-' || (c >= '0' && c <= '9'):
+			start := i
+			i++
+			for i < len(expr) && (expr[i] >= '0' && expr[i] <= '9' || expr[i] == '.' || expr[i] == 'e' || expr[i] == 'E') {
+				i++
+			}
+			n, err := strconv.ParseFloat(expr[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", expr[start:i], start)
+			}
+			tokens = append(tokens, conditionToken{kind: "number", text: expr[start:i], value: n, pos: start})
+		case isIdentChar(c):
+			start := i
+			for i < len(expr) && isIdentChar(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: "ident", text: expr[start:i], pos: start})
+		default:
+			start := i
+			op := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")"} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, start)
+			}
+			i += len(op)
+			tokens = append(tokens, conditionToken{kind: "op", text: op, pos: start})
+		}
+	}
+	return append(tokens, conditionToken{kind: "eof", text: "end of expression", pos: len(expr)}), nil
+}
+
+// isIdentChar reports whether c may appear in an identifier or path segment
+func isIdentChar(c byte) bool {
+	return c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// conditionParser is a recursive descent parser over condition tokens
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != "eof" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == "||" && p.peek().kind == "op" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == "&&" && p.peek().kind == "op" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if tok := p.peek(); tok.kind == "op" && tok.text == "!" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if tok.kind != "op" {
+		return left, nil
+	}
+	switch tok.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return comparisonNode{op: tok.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseOperand() (conditionNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case "path":
+		return pathNode{path: strings.TrimPrefix(tok.text, ".")}, nil
+	case "number", "string":
+		return literalNode{value: tok.value}, nil
+	case "ident":
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		}
+		return nil, fmt.Errorf("unknown identifier %q at position %d, paths start with a dot", tok.text, tok.pos)
+	case "op":
+		if tok.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if closing := p.next(); closing.text != ")" {
+				return nil, fmt.Errorf("expected ) at position %d", closing.pos)
+			}
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+// literalNode is a constant operand
+type literalNode struct {
+	value interface{}
+}
+
+func (n literalNode) eval(map[string]interface{}) interface{} {
+	return n.value
+}
+
+// pathNode references template data; unresolved paths evaluate to null
+type pathNode struct {
+	path string
+}
+
+func (n pathNode) eval(data map[string]interface{}) interface{} {
+	value, _ := lookupTemplatePath(data, n.path)
+	return toConditionValue(value)
+}
+
+// notNode negates the truthiness of its operand
+type notNode struct {
+	operand conditionNode
+}
+
+func (n notNode) eval(data map[string]interface{}) interface{} {
+	return !truthy(n.operand.eval(data))
+}
+
+// logicalNode combines two operands with && or ||, short-circuiting
+type logicalNode struct {
+	op          string
+	left, right conditionNode
+}
+
+func (n logicalNode) eval(data map[string]interface{}) interface{} {
+	left := truthy(n.left.eval(data))
+	if n.op == "&&" {
+		return left && truthy(n.right.eval(data))
+	}
+	return left || truthy(n.right.eval(data))
+}
+
+// comparisonNode compares two operands
+// Ordering comparisons apply to two numbers or two strings and are false for any other operands
+type comparisonNode struct {
+	op          string
+	left, right conditionNode
+}
+
+func (n comparisonNode) eval(data map[string]interface{}) interface{} {
+	left, right := n.left.eval(data), n.right.eval(data)
+	switch n.op {
+	case "==":
+		return jsonEqual(left, right)
+	case "!=":
+		return !jsonEqual(left, right)
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return false
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// toConditionValue normalizes numbers from template data, such as status codes stored as int, to float64
+func toConditionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return value
+}
+
+// truthy reports whether a condition value counts as true
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
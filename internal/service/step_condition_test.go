@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEvaluateCondition tests comparisons of numbers, strings, booleans and null, logical operators and their
+// precedence, truthiness of bare operands and that paths that do not resolve evaluate to null
+func TestEvaluateCondition(t *testing.T) {
+	data := map[string]interface{}{
+		"trigger_data": map[string]interface{}{
+			"amount":   float64(120),
+			"currency": "EUR",
+			"express":  true,
+			"tags":     []interface{}{},
+			"customer": map[string]interface{}{"tier": "gold"},
+		},
+		"step_1": map[string]interface{}{
+			"name":        "Charge",
+			"status_code": 201,
+			"response":    map[string]interface{}{"approved": false},
+		},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: ".trigger_data.amount > 100", want: true},
+		{expr: ".trigger_data.amount <= 100", want: false},
+		{expr: ".trigger_data.amount == 120.0", want: true},
+		{expr: ".trigger_data.amount >= -5", want: true},
+		{expr: ".trigger_data.currency == 'EUR'", want: true},
+		{expr: `.trigger_data.currency != "EUR"`, want: false},
+		{expr: ".trigger_data.currency < 'USD'", want: true},
+		{expr: ".trigger_data.customer.tier == 'gold'", want: true},
+		{expr: ".step_1.status_code == 201", want: true},
+		{expr: ".step_1.response.approved == false", want: true},
+		{expr: ".trigger_data.express", want: true},
+		{expr: "!.trigger_data.express", want: false},
+		{expr: ".trigger_data.tags", want: false},
+		{expr: ".trigger_data.customer", want: true},
+		{expr: ".trigger_data.coupon", want: false},
+		{expr: ".trigger_data.coupon == null", want: true},
+		{expr: ".step_2.response.ok", want: false},
+		{expr: ".trigger_data.amount > '100'", want: false},
+		{expr: ".trigger_data.currency == 'EUR' && .trigger_data.amount > 500", want: false},
+		{expr: ".trigger_data.currency == 'USD' || .trigger_data.express", want: true},
+		{expr: "true || false && false", want: true},
+		{expr: "(true || false) && false", want: false},
+		{expr: "!(.trigger_data.amount > 100 && .trigger_data.express)", want: false},
+		{expr: "0", want: false},
+		{expr: "''", want: false},
+		{expr: "'it\\'s'", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			// Act
+			got, err := evaluateCondition(tt.expr, data)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestParseCondition_Invalid tests that malformed conditions are rejected with the position of the problem
+func TestParseCondition_Invalid(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "", want: "end of expression"},
+		{expr: ".", want: `invalid path "." at position 0`},
+		{expr: ".trigger_data..amount", want: "invalid path"},
+		{expr: ".trigger_data. == 1", want: "invalid path"},
+		{expr: ".status == 'open", want: "unterminated string at position 11"},
+		{expr: ".amount > 1.2.3", want: `invalid number "1.2.3"`},
+		{expr: ".amount = 1", want: "unexpected character '='"},
+		{expr: ".amount > ", want: "end of expression"},
+		{expr: "(.amount > 1", want: "expected ) at position 12"},
+		{expr: ".amount > 1)", want: `unexpected ")" at position 11`},
+		{expr: ".a .b", want: `unexpected ".b" at position 3`},
+		{expr: "amount > 1", want: `unknown identifier "amount" at position 0, paths start with a dot`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			// Act
+			node, err := parseCondition(tt.expr)
+
+			// Assert
+			assert.ErrorContains(t, err, tt.want)
+			assert.Nil(t, node)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"go.uber.org/zap"
+)
+
+// A chain whose steps declare depends_on runs as a dependency graph instead of a sequence:
+// steps without dependencies start immediately and every other step starts once all of its
+// dependencies have finished, so independent branches run concurrently
+//
+// A dependency counts as finished when it succeeded, was skipped by its condition, or failed with
+// on_failure_action "continue". A failure with "stop", a "pause", an approval request or a success with
+// "stop" keeps new steps from starting; the run ends once the steps already running have finished
+
+// validateStepDependencies checks the depends_on references of a chain creation request
+// Parameters:
+//   - steps: Steps of the request; depends_on entries reference the key of another step
+//
+// Returns:
+//   - error: If a key is duplicated, a reference is unknown or self-referencing, dependencies are combined
+//     with parallel groups, or the dependencies contain a cycle
+func validateStepDependencies(steps []models.CreateExecutionChainStep) error {
+	keys := make(map[string]int, len(steps))
+	usesDependencies := false
+	for i, step := range steps {
+		if step.Key != "" {
+			if first, ok := keys[step.Key]; ok {
+				return fmt.Errorf("step %d: key %q is already used by step %d", i+1, step.Key, first+1)
+			}
+			keys[step.Key] = i
+		}
+		if len(step.DependsOn) > 0 {
+			usesDependencies = true
+		}
+	}
+	if !usesDependencies {
+		return nil
+	}
+
+	dependents := make([][]int, len(steps))
+	pending := make([]int, len(steps))
+	for i, step := range steps {
+		if step.ParallelGroup != "" {
+			return fmt.Errorf("step %d: parallel_group cannot be combined with depends_on, steps without common dependencies already run concurrently", i+1)
+		}
+
+		seen := make(map[string]bool, len(step.DependsOn))
+		for _, key := range step.DependsOn {
+			dependency, ok := keys[key]
+			if !ok {
+				return fmt.Errorf("step %d: depends on unknown step key %q", i+1, key)
+			}
+			if dependency == i {
+				return fmt.Errorf("step %d: cannot depend on itself", i+1)
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			dependents[dependency] = append(dependents[dependency], i)
+			pending[i]++
+		}
+	}
+
+	// Kahn's algorithm: every step is reachable in topological order unless the graph has a cycle
+	var ready []int
+	for i := range steps {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		current := ready[0]
+		ready = ready[1:]
+		for _, dependent := range dependents[current] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	var cycle []string
+	for i, step := range steps {
+		if pending[i] > 0 {
+			cycle = append(cycle, fmt.Sprintf("%d (%s)", i+1, step.Name))
+		}
+	}
+	if len(cycle) > 0 {
+		return fmt.Errorf("depends_on contains a cycle involving steps %s", strings.Join(cycle, ", "))
+	}
+	return nil
+}
+
+// resolveStepDependencies replaces the depends_on keys of a chain creation request by the IDs of the created steps
+// steps must be in request order and validated by validateStepDependencies
+func resolveStepDependencies(reqSteps []models.CreateExecutionChainStep, steps []models.ExecutionChainStep) {
+	ids := make(map[string]uuid.UUID, len(steps))
+	for i, step := range reqSteps {
+		if step.Key != "" {
+			ids[step.Key] = steps[i].ID
+		}
+	}
+
+	for i, step := range reqSteps {
+		for _, key := range step.DependsOn {
+			steps[i].DependsOn = append(steps[i].DependsOn, ids[key])
+		}
+	}
+}
+
+// hasStepDependencies reports whether a chain's steps form a dependency graph instead of a sequence
+func hasStepDependencies(steps []models.ExecutionChainStep) bool {
+	for _, step := range steps {
+		if len(step.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// graphStepResult is the outcome of a step started by executeStepGraph
+type graphStepResult struct {
+	index  int
+	result stepResult
+}
+
+// executeStepGraph runs the steps of a dependency graph chain, each as soon as its dependencies have finished
+// Steps that already succeeded earlier in the run, before it was paused or interrupted, are not repeated
+// Records the final run status like executeChainSteps
+func (s *executionChainService) executeStepGraph(ctx context.Context, runID uuid.UUID, steps []models.ExecutionChainStep, rc *runContext) {
+	const (
+		stepPending = iota
+		stepRunning
+		stepFinished
+	)
+
+	index := make(map[uuid.UUID]int, len(steps))
+	state := make([]int, len(steps))
+	for i, step := range steps {
+		index[step.ID] = i
+		if rc.succeeded(step.StepOrder) {
+			state[i] = stepFinished
+		}
+	}
+
+	ready := func(step models.ExecutionChainStep) bool {
+		for _, dependency := range step.DependsOn {
+			if i, ok := index[dependency]; ok && state[i] != stepFinished {
+				return false
+			}
+		}
+		return true
+	}
+
+	results := make(chan graphStepResult)
+	running := 0
+	action := stepActionContinue
+	for {
+		// Start every step whose dependencies have finished, unless the run is ending
+		if action == stepActionContinue && ctx.Err() == nil {
+			for i := range steps {
+				if state[i] != stepPending || !ready(steps[i]) {
+					continue
+				}
+
+				if err := s.chainRepo.UpdateChainRunStep(ctx, runID, steps[i].StepOrder); err != nil {
+					s.logger.Error(ctx, "Failed to update current step", zap.Error(err))
+				}
+				s.logger.Info(ctx, "Executing step",
+					zap.String("run_id", runID.String()),
+					zap.Int("step_order", steps[i].StepOrder),
+					zap.String("step_name", steps[i].Name))
+
+				state[i] = stepRunning
+				running++
+				go func(i int) {
+					results <- graphStepResult{index: i, result: s.runStep(ctx, runID, &steps[i], rc)}
+				}(i)
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+
+		finished := <-results
+		running--
+
+		// Cancelled during the step delay, before the step was sent
+		if !finished.result.started {
+			state[finished.index] = stepPending
+			continue
+		}
+		state[finished.index] = stepFinished
+
+		// A step stopped by cancellation did not really fail
+		if ctx.Err() != nil {
+			continue
+		}
+		if stepAction := finished.result.action(ctx, s.logger, &steps[finished.index]); stepAction > action {
+			action = stepAction
+		}
+	}
+
+	// Steps that never started are skipped when cancelled and resumed after an interruption
+	if ctx.Err() != nil {
+		var remaining []models.ExecutionChainStep
+		for i, step := range steps {
+			if state[i] == stepPending {
+				remaining = append(remaining, step)
+			}
+		}
+		s.stopRun(ctx, runID, remaining)
+		return
+	}
+
+	switch action {
+	case stepActionFail:
+		s.logger.Info(ctx, "Stopping chain execution due to failure")
+		s.failRun(ctx, runID, steps, rc)
+	case stepActionPause:
+		s.logger.Info(ctx, "Pausing chain execution")
+		s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusPaused)
+	case stepActionAwaitApproval:
+		s.logger.Info(ctx, "Pausing chain execution until approval")
+		s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusAwaitingApproval)
+	default:
+		s.logger.Info(ctx, "Chain execution completed", zap.String("run_id", runID.String()))
+		s.chainRepo.UpdateChainRunStatus(ctx, runID, models.ExecutionChainStatusCompleted)
+		s.notifyRunFinished(ctx, runID)
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// singlePlaceholder matches a parameter that is exactly one field reference such as "{{.trigger_data}}"
+// Such parameters are replaced by the referenced value itself so objects, arrays and numbers keep their type
+var singlePlaceholder = regexp.MustCompile(`^\{\{\s*\.([\w.]+)\s*\}\}$`)
+
+// stepTemplateFuncs are the functions available in request param templates
+var stepTemplateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+}
+
+// runContext accumulates the data flowing through a chain run: the trigger data, the parsed
+// responses of the steps that succeeded so far and the results of built-in steps
+// Branches of a parallel step group share it, so the step results are guarded by a mutex
+type runContext struct {
+	triggerData map[string]interface{}
+
+	mu sync.Mutex
+
+	// steps maps "step_N" to the result of step N: its name, status code and parsed response
+	steps map[string]interface{}
+
+	// vars holds the run variables set by transform steps
+	vars map[string]interface{}
+
+	// branches maps the key of every branch step that ran to the name of the path it chose
+	branches map[string]string
+
+	// excludedSteps and overrides hold the steps the run's options skip and their override params
+	excludedSteps map[int]bool
+	overrides     map[string]map[string]interface{}
+
+	// protection is applied to the step requests recorded for the run
+	protection payloadProtection
+
+	// saving serializes saving the variables on the run
+	saving sync.Mutex
+}
+
+// newRunContext creates the execution context of a run
+// Parameters:
+//   - triggerData: Data the run was triggered with
+//   - stepRuns: Step runs already recorded for the run, so a resumed run sees the responses of
+//     the steps executed before it stopped
+func newRunContext(triggerData map[string]interface{}, stepRuns []*models.ExecutionChainStepRun) *runContext {
+	rc := &runContext{
+		triggerData: triggerData,
+		steps:       make(map[string]interface{}),
+		vars:        make(map[string]interface{}),
+		branches:    make(map[string]string),
+	}
+
+	for _, stepRun := range stepRuns {
+		if stepRun.Status != models.WebhookStatusSent {
+			continue
+		}
+		rc.record(stepRun.StepOrder, stepRun.Step.Name, stepRun.ResponseCode, stepRun.ResponseBody)
+
+		if !stepRun.Step.Type.CallsWebhook() && stepRun.ResponseBody != nil {
+			var output map[string]interface{}
+			if err := json.Unmarshal([]byte(*stepRun.ResponseBody), &output); err == nil {
+				rc.applyBuiltinResult(&stepRun.Step, output)
+			}
+		}
+		rc.extract(&stepRun.Step)
+	}
+
+	return rc
+}
+
+// record adds the result of a successful step to the context
+// The response body is decoded as JSON, falling back to the raw body when it is not JSON
+func (rc *runContext) record(stepOrder int, name string, responseCode *int, responseBody *string) {
+	var response interface{}
+	if responseBody != nil {
+		if err := json.Unmarshal([]byte(*responseBody), &response); err != nil {
+			response = *responseBody
+		}
+	}
+
+	result := map[string]interface{}{
+		"name":     name,
+		"response": response,
+	}
+	if responseCode != nil {
+		result["status_code"] = *responseCode
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.steps[stepKey(stepOrder)] = result
+}
+
+// succeeded reports whether the step with the given order already succeeded in this run
+func (rc *runContext) succeeded(stepOrder int) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	_, ok := rc.steps[stepKey(stepOrder)]
+	return ok
+}
+
+// previousSteps returns a snapshot of the results of the steps that succeeded so far
+func (rc *runContext) previousSteps() map[string]interface{} {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	steps := make(map[string]interface{}, len(rc.steps))
+	for key, result := range rc.steps {
+		steps[key] = result
+	}
+	return steps
+}
+
+// templateData returns the data request params are rendered against:
+// .trigger_data, .vars and .step_N.name / .step_N.response / .step_N.status_code for every successful step
+func (rc *runContext) templateData() map[string]interface{} {
+	data := rc.previousSteps()
+	data["trigger_data"] = rc.triggerData
+	data["vars"] = rc.variables()
+	return data
+}
+
+// stepKey returns the key a step's result is stored under, e.g. "step_2"
+func stepKey(stepOrder int) string {
+	return fmt.Sprintf("step_%d", stepOrder)
+}
+
+// renderRequestParams renders every string in a step's request params as a template
+// Parameters:
+//   - params: Decoded request params of the step
+//   - data: Template data built by runContext.templateData
+//
+// Returns:
+//   - map[string]interface{}: Params with placeholders replaced
+//   - error: If a template is malformed or references data that does not exist
+func renderRequestParams(params map[string]interface{}, data map[string]interface{}) (map[string]interface{}, error) {
+	rendered, err := renderTemplateValue(params, data, "request_params")
+	if err != nil {
+		return nil, err
+	}
+	return rendered.(map[string]interface{}), nil
+}
+
+// renderTemplateValue renders the strings nested in a decoded JSON value
+// path locates the value in the request params for error messages
+func renderTemplateValue(value interface{}, data map[string]interface{}, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			renderedItem, err := renderTemplateValue(item, data, path+"."+key)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = renderedItem
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, item := range v {
+			renderedItem, err := renderTemplateValue(item, data, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = renderedItem
+		}
+		return rendered, nil
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		if match := singlePlaceholder.FindStringSubmatch(v); match != nil {
+			resolved, ok := lookupTemplatePath(data, match[1])
+			if !ok {
+				return nil, fmt.Errorf("%s: %q references missing data", path, v)
+			}
+			return resolved, nil
+		}
+
+		tmpl, err := template.New(path).Funcs(stepTemplateFuncs).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid template: %w", path, err)
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return out.String(), nil
+	default:
+		return v, nil
+	}
+}
+
+// lookupTemplatePath resolves a dotted field path such as "step_1.response.payment_id" in template data
+func lookupTemplatePath(data map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+)
+
+// TestRunContext_PreviousSteps tests that the run context holds the parsed responses of the steps that
+// succeeded, including those a resumed run executed before it stopped, and that request params reference them
+func TestRunContext_PreviousSteps(t *testing.T) {
+	// Arrange
+	ok := http.StatusOK
+	charged := `{"charge_id": "ch_1", "amount": 42}`
+	failed := "boom"
+	rc := newRunContext(map[string]interface{}{"order_id": "ord-1"}, []*models.ExecutionChainStepRun{
+		{StepOrder: 1, Status: models.WebhookStatusSent, ResponseCode: &ok, ResponseBody: &charged, Step: models.ExecutionChainStep{Name: "Charge"}},
+		{StepOrder: 2, Status: models.WebhookStatusFailed, ResponseBody: &failed, Step: models.ExecutionChainStep{Name: "Audit"}},
+	})
+	queued := "queued"
+	rc.record(3, "Receipt", &ok, &queued)
+	step := &models.ExecutionChainStep{Name: "Ship", RequestParams: `{
+		"amount": "{{.step_1.response.amount}}",
+		"note": "charge {{.step_1.response.charge_id}} for order {{.trigger_data.order_id}}"
+	}`}
+
+	// Act
+	params, err := renderStepParams(step, rc)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"step_1": map[string]interface{}{
+			"name":        "Charge",
+			"status_code": http.StatusOK,
+			"response":    map[string]interface{}{"charge_id": "ch_1", "amount": float64(42)},
+		},
+		"step_3": map[string]interface{}{
+			"name":        "Receipt",
+			"status_code": http.StatusOK,
+			"response":    "queued",
+		},
+	}, rc.steps, "failed steps are left out")
+	assert.Equal(t, map[string]interface{}{
+		"amount": float64(42),
+		"note":   "charge ch_1 for order ord-1",
+	}, params)
+}
+
+// TestRenderStepParams_MissingStep tests that request params referencing a step that has not succeeded fail to
+// render
+func TestRenderStepParams_MissingStep(t *testing.T) {
+	// Arrange
+	rc := newRunContext(nil, nil)
+	step := &models.ExecutionChainStep{Name: "Ship", RequestParams: `{"tracking": "{{.step_3.response.tracking}}"}`}
+
+	// Act
+	params, err := renderStepParams(step, rc)
+
+	// Assert
+	assert.ErrorContains(t, err, `"{{.step_3.response.tracking}}" references missing data`)
+	assert.Nil(t, params)
+}
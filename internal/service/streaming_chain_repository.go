@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxTrackedRuns bounds the runs and step runs a streaming repository remembers; the memory is reset
+// when it grows past the bound, and forgotten runs are looked up again on their next change
+const maxTrackedRuns = 10000
+
+// trackedRun is what a streaming repository remembers of a run to address its events
+type trackedRun struct {
+	tenantID string
+	chainID  uuid.UUID
+}
+
+// trackedStepRun is what a streaming repository remembers of a step run to address its events
+type trackedStepRun struct {
+	runID     uuid.UUID
+	stepID    uuid.UUID
+	stepOrder int
+}
+
+// streamingExecutionChainRepository publishes the run status changes and step completions written
+// through it to a status stream
+type streamingExecutionChainRepository struct {
+	repository.ExecutionChainRepository
+	stream *StatusStream
+	logger logging.Logger
+
+	mu       sync.Mutex
+	runs     map[uuid.UUID]trackedRun
+	stepRuns map[uuid.UUID]trackedStepRun
+}
+
+// NewStreamingExecutionChainRepository wraps a chain repository to publish run.status_changed events
+// for every run created or changing status and step.completed events for every step run that
+// succeeded, failed or was skipped; events are only published after the wrapped write succeeded
+func NewStreamingExecutionChainRepository(next repository.ExecutionChainRepository, stream *StatusStream, logger logging.Logger) repository.ExecutionChainRepository {
+	return &streamingExecutionChainRepository{
+		ExecutionChainRepository: next,
+		stream:                   stream,
+		logger:                   logger,
+		runs:                     make(map[uuid.UUID]trackedRun),
+		stepRuns:                 make(map[uuid.UUID]trackedStepRun),
+	}
+}
+
+func (r *streamingExecutionChainRepository) CreateChainRun(ctx context.Context, run *models.ExecutionChainRun) error {
+	if err := r.ExecutionChainRepository.CreateChainRun(ctx, run); err != nil {
+		return err
+	}
+	r.trackRun(run)
+	r.publishRun(ctx, run.ID, run.Status)
+	return nil
+}
+
+func (r *streamingExecutionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	run, err := r.ExecutionChainRepository.GetChainRunByID(ctx, runID)
+	if err == nil && !runFinished(run.Status) {
+		r.trackRun(run)
+	}
+	return run, err
+}
+
+func (r *streamingExecutionChainRepository) UpdateChainRunStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus) error {
+	if err := r.ExecutionChainRepository.UpdateChainRunStatus(ctx, runID, status); err != nil {
+		return err
+	}
+	r.publishRun(ctx, runID, status)
+	return nil
+}
+
+func (r *streamingExecutionChainRepository) UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error {
+	if err := r.ExecutionChainRepository.UpdateChainRun(ctx, runID, updates); err != nil {
+		return err
+	}
+	if status, ok := runStatusUpdate(updates); ok {
+		r.publishRun(ctx, runID, status)
+	}
+	return nil
+}
+
+func (r *streamingExecutionChainRepository) UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, current models.ExecutionChainStatus, updates map[string]interface{}) (bool, error) {
+	applied, err := r.ExecutionChainRepository.UpdateChainRunIfStatus(ctx, runID, current, updates)
+	if err != nil || !applied {
+		return applied, err
+	}
+	if status, ok := runStatusUpdate(updates); ok && status != current {
+		r.publishRun(ctx, runID, status)
+	}
+	return applied, nil
+}
+
+func (r *streamingExecutionChainRepository) CreateStepRun(ctx context.Context, stepRun *models.ExecutionChainStepRun) error {
+	if err := r.ExecutionChainRepository.CreateStepRun(ctx, stepRun); err != nil {
+		return err
+	}
+	if stepCompleted(stepRun.Status) {
+		r.publishStep(ctx, stepRun.RunID, &models.StepStreamData{
+			StepID:       stepRun.StepID,
+			StepOrder:    stepRun.StepOrder,
+			Status:       stepRun.Status,
+			ResponseCode: stepRun.ResponseCode,
+			AttemptCount: stepRun.AttemptCount,
+			Error:        stepRun.LastError,
+		})
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.stepRuns) >= maxTrackedRuns {
+		clear(r.stepRuns)
+	}
+	r.stepRuns[stepRun.ID] = trackedStepRun{runID: stepRun.RunID, stepID: stepRun.StepID, stepOrder: stepRun.StepOrder}
+	return nil
+}
+
+func (r *streamingExecutionChainRepository) UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error {
+	if err := r.ExecutionChainRepository.UpdateStepRun(ctx, stepRunID, updates); err != nil {
+		return err
+	}
+	status, ok := stepStatusUpdate(updates)
+	if !ok || !stepCompleted(status) {
+		return nil
+	}
+
+	r.mu.Lock()
+	tracked, known := r.stepRuns[stepRunID]
+	delete(r.stepRuns, stepRunID)
+	r.mu.Unlock()
+	if !known {
+		return nil
+	}
+
+	step := &models.StepStreamData{StepID: tracked.stepID, StepOrder: tracked.stepOrder, Status: status}
+	if code, ok := updates["response_code"].(int); ok {
+		step.ResponseCode = &code
+	}
+	if attempts, ok := updates["attempt_count"].(int); ok {
+		step.AttemptCount = attempts
+	}
+	if lastError, ok := updates["last_error"].(string); ok {
+		step.Error = &lastError
+	}
+	r.publishStep(ctx, tracked.runID, step)
+	return nil
+}
+
+// trackRun remembers the tenant and chain of a run
+func (r *streamingExecutionChainRepository) trackRun(run *models.ExecutionChainRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.runs) >= maxTrackedRuns {
+		clear(r.runs)
+	}
+	r.runs[run.ID] = trackedRun{tenantID: run.TenantID, chainID: run.ChainID}
+}
+
+// lookupRun returns the tenant and chain of a run, loading the run if it is not remembered
+// Runs are forgotten once they reach a terminal status
+func (r *streamingExecutionChainRepository) lookupRun(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus) (trackedRun, bool) {
+	r.mu.Lock()
+	tracked, ok := r.runs[runID]
+	if ok && runFinished(status) {
+		delete(r.runs, runID)
+	}
+	r.mu.Unlock()
+	if ok {
+		return tracked, true
+	}
+
+	run, err := r.ExecutionChainRepository.GetChainRunByID(context.WithoutCancel(ctx), runID)
+	if err != nil {
+		r.logger.Warn(ctx, "Failed to load run for the status stream",
+			zap.String("run_id", runID.String()),
+			zap.Error(err))
+		return trackedRun{}, false
+	}
+	tracked = trackedRun{tenantID: run.TenantID, chainID: run.ChainID}
+	if !runFinished(status) {
+		r.trackRun(run)
+	}
+	return tracked, true
+}
+
+// publishRun publishes a run.status_changed event if anyone is subscribed
+func (r *streamingExecutionChainRepository) publishRun(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus) {
+	if !r.stream.Active() {
+		if runFinished(status) {
+			r.forgetRun(runID)
+		}
+		return
+	}
+	tracked, ok := r.lookupRun(ctx, runID, status)
+	if !ok {
+		return
+	}
+	r.stream.Publish(&models.StreamEvent{
+		Type:     models.StreamEventRunStatusChanged,
+		TenantID: tracked.tenantID,
+		Run:      &models.RunStreamData{RunID: runID, ChainID: tracked.chainID, Status: status},
+	})
+}
+
+// publishStep publishes a step.completed event for a step of a run if anyone is subscribed
+func (r *streamingExecutionChainRepository) publishStep(ctx context.Context, runID uuid.UUID, step *models.StepStreamData) {
+	if !r.stream.Active() {
+		return
+	}
+	tracked, ok := r.lookupRun(ctx, runID, "")
+	if !ok {
+		return
+	}
+	step.RunID = runID
+	step.ChainID = tracked.chainID
+	r.stream.Publish(&models.StreamEvent{
+		Type:     models.StreamEventStepCompleted,
+		TenantID: tracked.tenantID,
+		Step:     step,
+	})
+}
+
+// forgetRun drops what is remembered of a run
+func (r *streamingExecutionChainRepository) forgetRun(runID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.runs, runID)
+}
+
+// runStatusUpdate returns the run status set by an update map
+func runStatusUpdate(updates map[string]interface{}) (models.ExecutionChainStatus, bool) {
+	switch status := updates["status"].(type) {
+	case models.ExecutionChainStatus:
+		return status, true
+	case string:
+		return models.ExecutionChainStatus(status), true
+	}
+	return "", false
+}
+
+// stepStatusUpdate returns the step status set by an update map
+func stepStatusUpdate(updates map[string]interface{}) (models.WebhookStatus, bool) {
+	switch status := updates["status"].(type) {
+	case models.WebhookStatus:
+		return status, true
+	case string:
+		return models.WebhookStatus(status), true
+	}
+	return "", false
+}
+
+// stepCompleted reports whether a step run status means the step is done
+func stepCompleted(status models.WebhookStatus) bool {
+	return status == models.WebhookStatusSent || status == models.WebhookStatusFailed || status == models.WebhookStatusSkipped
+}
+
+// runFinished reports whether a run status is final
+func runFinished(status models.ExecutionChainStatus) bool {
+	switch status {
+	case models.ExecutionChainStatusCompleted, models.ExecutionChainStatusFailed, models.ExecutionChainStatusCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package service
+
+import (
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+)
+
+// ErrNotFound is returned for chains and runs that do not exist, or that belong to another tenant than the
+// caller's, so callers cannot tell the resources of other tenants apart from missing ones
+var ErrNotFound = repository.ErrNotFound
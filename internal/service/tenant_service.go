@@ -0,0 +1,532 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/apperr"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"go.uber.org/zap"
+)
+
+// DefaultRateLimitSyncInterval is how often tenant rate limits changed on other instances are picked up
+const DefaultRateLimitSyncInterval = time.Minute
+
+var (
+	// ErrTenantNotFound is returned for tenant IDs no tenant has, or whose tenant was deleted
+	ErrTenantNotFound = apperr.NotFound("tenant_not_found", "tenant not found")
+
+	// ErrTenantSuspended is returned when a suspended tenant sends events, runs chains or creates resources
+	ErrTenantSuspended = apperr.Forbidden("tenant_suspended", "tenant is suspended")
+
+	// ErrTenantExists is returned when creating a tenant with the ID of an existing or deleted tenant
+	ErrTenantExists = apperr.Conflict("tenant_exists", "tenant already exists")
+
+	// ErrQuotaExceeded is returned when a tenant's event would exceed its daily or monthly quota
+	ErrQuotaExceeded = apperr.LimitExceeded("quota_exceeded", "quota exceeded")
+)
+
+// TenantRateLimiter applies tenant rate limit overrides, implemented by the management API rate limiter
+type TenantRateLimiter interface {
+	// SetTenantLimits replaces the rate limit overrides of every tenant
+	SetTenantLimits(limits map[string]models.RateLimit)
+}
+
+// TenantService manages the lifecycle and settings of tenants
+// Subscriptions, events and chains can only be created for active tenants; suspending a tenant rejects
+// its events and chain runs until it is activated, deleting it deletes its subscriptions and chains
+type TenantService interface {
+	// CreateTenant creates a tenant, applying the settings of the request
+	// Returns ErrTenantExists when the ID is taken, also by a deleted tenant
+	CreateTenant(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error)
+
+	// GetTenant retrieves a tenant with its settings
+	GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+
+	// ListTenants lists tenants with pagination, oldest first
+	ListTenants(ctx context.Context, page, limit int) (*models.TenantListResponse, error)
+
+	// SuspendTenant suspends a tenant, rejecting its events and chain runs until it is activated
+	SuspendTenant(ctx context.Context, tenantID string, req *models.SuspendTenantRequest) (*models.Tenant, error)
+
+	// ActivateTenant activates a suspended tenant
+	ActivateTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+
+	// DeleteTenant deletes a tenant along with its subscriptions and chains
+	DeleteTenant(ctx context.Context, tenantID string) (*models.TenantDeleteResponse, error)
+
+	// GetTenantSettings retrieves the settings of a tenant
+	GetTenantSettings(ctx context.Context, tenantID string) (*models.TenantSettings, error)
+
+	// UpdateTenantSettings changes the settings set in the request, leaving the others unchanged
+	UpdateTenantSettings(ctx context.Context, tenantID string, req *models.TenantSettingsRequest) (*models.TenantSettings, error)
+
+	// GetUsage reports the events and deliveries of a tenant per UTC day from from to to, inclusive,
+	// with its quota and how much of it is used in the current day and month
+	GetUsage(ctx context.Context, tenantID string, from, to time.Time) (*models.TenantUsageResponse, error)
+
+	// RunRateLimitSync reloads the tenant rate limits into the rate limiter every interval until ctx is cancelled
+	RunRateLimitSync(ctx context.Context, interval time.Duration)
+
+	// SetRateLimiter sets the rate limiter tenant rate limits are applied to
+	SetRateLimiter(limiter TenantRateLimiter)
+
+	// SetClock replaces the clock used for timestamps
+	SetClock(clock Clock)
+}
+
+// tenantService implements TenantService
+type tenantService struct {
+	tenantRepo     repository.TenantRepository
+	webhookService WebhookService
+	limiter        TenantRateLimiter
+	clock          Clock
+	logger         logging.Logger
+}
+
+// NewTenantService creates a new tenant service
+// The webhook service switches signing algorithms, which may generate signing keys
+func NewTenantService(tenantRepo repository.TenantRepository, webhookService WebhookService, logger logging.Logger) TenantService {
+	return &tenantService{
+		tenantRepo:     tenantRepo,
+		webhookService: webhookService,
+		clock:          NewSystemClock(),
+		logger:         logger,
+	}
+}
+
+// SetRateLimiter sets the rate limiter tenant rate limits are applied to
+func (s *tenantService) SetRateLimiter(limiter TenantRateLimiter) {
+	s.limiter = limiter
+}
+
+// SetClock replaces the clock used for timestamps
+func (s *tenantService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// CreateTenant creates a tenant, applying the settings of the request
+// Switching to Ed25519 signing generates the tenant's key pair once the tenant exists
+func (s *tenantService) CreateTenant(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error) {
+	exists, err := s.tenantRepo.TenantExists(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tenant: %w", err)
+	}
+	if exists {
+		return nil, ErrTenantExists.Withf("tenant %s already exists", req.ID)
+	}
+
+	settings := &models.TenantSettings{TenantID: req.ID}
+	var signing *models.SigningAlgorithm
+	if req.Settings != nil {
+		if err := applyTenantSettings(settings, req.Settings); err != nil {
+			return nil, invalid(ErrInvalidTenant, err)
+		}
+		signing = req.Settings.SigningAlgorithm
+	}
+
+	tenant := &models.Tenant{
+		ID:       req.ID,
+		Name:     req.Name,
+		Status:   models.TenantStatusActive,
+		Settings: settings,
+	}
+	if err := s.tenantRepo.CreateTenant(ctx, tenant); err != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+
+	if signing != nil {
+		if _, err := s.webhookService.UpdateTenantSigning(ctx, tenant.ID, &models.TenantSigningRequest{Algorithm: *signing}); err != nil {
+			return nil, fmt.Errorf("tenant created but signing could not be configured: %w", err)
+		}
+	}
+	s.reloadRateLimits(ctx)
+
+	s.logger.Info(ctx, "Tenant created", zap.String("tenant_id", tenant.ID))
+	return s.GetTenant(ctx, tenant.ID)
+}
+
+// GetTenant retrieves a tenant with its settings
+func (s *tenantService) GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	tenant, err := s.tenantRepo.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant: %w", err)
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound.Withf("tenant %s not found", tenantID)
+	}
+	return tenant, nil
+}
+
+// ListTenants lists tenants with pagination, oldest first
+func (s *tenantService) ListTenants(ctx context.Context, page, limit int) (*models.TenantListResponse, error) {
+	offset := (page - 1) * limit
+	tenants, total, err := s.tenantRepo.ListTenants(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	return &models.TenantListResponse{
+		Tenants: tenants,
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
+	}, nil
+}
+
+// SuspendTenant suspends a tenant, rejecting its events and chain runs until it is activated
+// Runs already started finish; suspending a suspended tenant only updates the reason
+func (s *tenantService) SuspendTenant(ctx context.Context, tenantID string, req *models.SuspendTenantRequest) (*models.Tenant, error) {
+	tenant, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.IsActive() {
+		now := s.clock.Now()
+		tenant.Status = models.TenantStatusSuspended
+		tenant.SuspendedAt = &now
+	}
+	tenant.SuspendedReason = req.Reason
+	if err := s.tenantRepo.UpdateTenant(ctx, tenant); err != nil {
+		return nil, fmt.Errorf("failed to suspend tenant: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant suspended",
+		zap.String("tenant_id", tenantID),
+		zap.String("reason", req.Reason))
+	return tenant, nil
+}
+
+// ActivateTenant activates a suspended tenant
+func (s *tenantService) ActivateTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	tenant, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.IsActive() {
+		return tenant, nil
+	}
+
+	tenant.Status = models.TenantStatusActive
+	tenant.SuspendedAt = nil
+	tenant.SuspendedReason = ""
+	if err := s.tenantRepo.UpdateTenant(ctx, tenant); err != nil {
+		return nil, fmt.Errorf("failed to activate tenant: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant activated", zap.String("tenant_id", tenantID))
+	return tenant, nil
+}
+
+// DeleteTenant deletes a tenant along with its subscriptions and chains
+// The subscriptions and chains are soft deleted and removed for good by the admin purge
+func (s *tenantService) DeleteTenant(ctx context.Context, tenantID string) (*models.TenantDeleteResponse, error) {
+	if _, err := s.GetTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	subscriptions, chains, err := s.tenantRepo.DeleteTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tenant: %w", err)
+	}
+	s.reloadRateLimits(ctx)
+
+	s.logger.Info(ctx, "Tenant deleted",
+		zap.String("tenant_id", tenantID),
+		zap.Int64("subscriptions_deleted", subscriptions),
+		zap.Int64("chains_deleted", chains))
+
+	return &models.TenantDeleteResponse{
+		TenantID:             tenantID,
+		SubscriptionsDeleted: subscriptions,
+		ChainsDeleted:        chains,
+	}, nil
+}
+
+// GetTenantSettings retrieves the settings of a tenant
+func (s *tenantService) GetTenantSettings(ctx context.Context, tenantID string) (*models.TenantSettings, error) {
+	if _, err := s.GetTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateTenantSettings changes the settings set in the request, leaving the others unchanged
+func (s *tenantService) UpdateTenantSettings(ctx context.Context, tenantID string, req *models.TenantSettingsRequest) (*models.TenantSettings, error) {
+	settings, err := s.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyTenantSettings(settings, req); err != nil {
+		return nil, invalid(ErrInvalidTenant, err)
+	}
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save tenant settings: %w", err)
+	}
+
+	if req.SigningAlgorithm != nil {
+		if _, err := s.webhookService.UpdateTenantSigning(ctx, tenantID, &models.TenantSigningRequest{Algorithm: *req.SigningAlgorithm}); err != nil {
+			return nil, err
+		}
+		if settings, err = s.tenantRepo.GetTenantSettings(ctx, tenantID); err != nil {
+			return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+		}
+	}
+	if req.RateLimit != nil {
+		s.reloadRateLimits(ctx)
+	}
+
+	s.logger.Info(ctx, "Tenant settings updated", zap.String("tenant_id", tenantID))
+	return settings, nil
+}
+
+// applyTenantSettings copies the settings set in req to settings, except the signing algorithm, which
+// is switched through the webhook service as it may need a key pair
+func applyTenantSettings(settings *models.TenantSettings, req *models.TenantSettingsRequest) error {
+	if req.SigningAlgorithm != nil && !req.SigningAlgorithm.IsValid() {
+		return fmt.Errorf("invalid signing algorithm: %s", *req.SigningAlgorithm)
+	}
+	if policy := req.DefaultRetryPolicy; policy != nil {
+		if policy.MaxRetries < 0 || policy.RetryDelaySeconds < 0 {
+			return fmt.Errorf("default retry policy must not be negative")
+		}
+		settings.DefaultRetryPolicy = *policy
+	}
+	if limit := req.RateLimit; limit != nil {
+		if limit.RequestsPerSecond < 0 || limit.Burst < 0 {
+			return fmt.Errorf("rate limit must not be negative")
+		}
+		settings.RateLimit = *limit
+	}
+	if req.RetentionDays != nil {
+		if *req.RetentionDays < 0 {
+			return fmt.Errorf("retention_days must not be negative")
+		}
+		settings.RetentionDays = *req.RetentionDays
+	}
+	if quota := req.Quota; quota != nil {
+		if quota.EventsPerDay < 0 || quota.EventsPerMonth < 0 || quota.DeliveriesPerDay < 0 || quota.DeliveriesPerMonth < 0 {
+			return fmt.Errorf("quota must not be negative")
+		}
+		settings.Quota = *quota
+	}
+	if req.SensitiveFields != nil {
+		fields, err := validateSensitiveFields(*req.SensitiveFields)
+		if err != nil {
+			return err
+		}
+		settings.SensitiveFields = fields
+	}
+	if req.SubjectPath != nil {
+		if err := validateSubjectPath(*req.SubjectPath); err != nil {
+			return err
+		}
+		settings.SubjectPath = *req.SubjectPath
+	}
+	if policy := req.AutoDisable; policy != nil {
+		if policy.Failures < 0 {
+			return fmt.Errorf("auto_disable failures must not be negative")
+		}
+		if policy.Failures > 0 && (policy.Days < 1 || policy.Days > models.MaxAutoDisableDays) {
+			return fmt.Errorf("auto_disable days must be from 1 to %d", models.MaxAutoDisableDays)
+		}
+		settings.AutoDisable = *policy
+	}
+	if slo := req.LatencySLO; slo != nil {
+		if slo.TargetMs < 0 {
+			return fmt.Errorf("latency_slo target_ms must not be negative")
+		}
+		if slo.Objective < 0 || slo.Objective > 100 {
+			return fmt.Errorf("latency_slo objective must be from 0 to 100")
+		}
+		settings.LatencySLO = *slo
+	}
+	return nil
+}
+
+// GetUsage reports the events and deliveries of a tenant per UTC day from from to to, inclusive
+// Usage of deleted subscriptions and archived events is included, so the report can be used for billing
+func (s *tenantService) GetUsage(ctx context.Context, tenantID string, from, to time.Time) (*models.TenantUsageResponse, error) {
+	tenant, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.tenantRepo.ListUsage(ctx, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant usage: %w", err)
+	}
+	current, err := quotaUsage(ctx, s.tenantRepo, tenantID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.TenantUsageResponse{
+		TenantID: tenantID,
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		Days:     make([]models.TenantUsageDay, 0, len(usage)),
+		Current:  current,
+	}
+	if tenant.Settings != nil {
+		response.Quota = tenant.Settings.Quota
+	}
+	for _, day := range usage {
+		response.Events += day.Events
+		response.Deliveries += day.Deliveries
+		response.Days = append(response.Days, models.TenantUsageDay{
+			Date:       day.Day.Format(time.DateOnly),
+			Events:     day.Events,
+			Deliveries: day.Deliveries,
+		})
+	}
+	return response, nil
+}
+
+// RunRateLimitSync reloads the tenant rate limits into the rate limiter every interval until ctx is cancelled
+// Changes made on this instance apply right away; the sync picks up changes made on other instances
+func (s *tenantService) RunRateLimitSync(ctx context.Context, interval time.Duration) {
+	for {
+		s.reloadRateLimits(ctx)
+		if !sleepContext(ctx, s.clock, interval) {
+			return
+		}
+	}
+}
+
+// reloadRateLimits loads the tenant rate limits into the rate limiter, if one is set
+func (s *tenantService) reloadRateLimits(ctx context.Context) {
+	if s.limiter == nil {
+		return
+	}
+	limits, err := s.tenantRepo.ListRateLimits(ctx)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to load tenant rate limits", zap.Error(err))
+		return
+	}
+	s.limiter.SetTenantLimits(limits)
+}
+
+// activeTenant retrieves a tenant with its settings
+// Returns ErrTenantNotFound or ErrTenantSuspended unless the tenant exists and is active
+func activeTenant(ctx context.Context, tenantRepo repository.TenantRepository, tenantID string) (*models.Tenant, error) {
+	tenant, err := tenantRepo.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant: %w", err)
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound.Withf("tenant %s not found", tenantID)
+	}
+	if !tenant.IsActive() {
+		return nil, ErrTenantSuspended.Withf("tenant %s is suspended", tenantID)
+	}
+	return tenant, nil
+}
+
+// subscriptionRetryPolicy returns the retry policy of a new subscription of a tenant:
+// the requested one, else the tenant's default, nil when neither is set
+func subscriptionRetryPolicy(tenant *models.Tenant, requested *models.RetryPolicy) *models.RetryPolicy {
+	if requested != nil {
+		return requested
+	}
+	if tenant.Settings != nil && tenant.Settings.DefaultRetryPolicy != (models.RetryPolicy{}) {
+		policy := tenant.Settings.DefaultRetryPolicy
+		return &policy
+	}
+	return nil
+}
+
+// usageDay returns the UTC day usage at t is counted on
+func usageDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// quotaUsage returns how much of its quota a tenant used in the UTC day and month of now
+func quotaUsage(ctx context.Context, tenantRepo repository.TenantRepository, tenantID string, now time.Time) (models.QuotaUsage, error) {
+	today := usageDay(now)
+	monthStart := today.AddDate(0, 0, 1-today.Day())
+
+	var current models.QuotaUsage
+	usage, err := tenantRepo.ListUsage(ctx, tenantID, monthStart, today)
+	if err != nil {
+		return current, fmt.Errorf("failed to load tenant usage: %w", err)
+	}
+	for _, day := range usage {
+		current.EventsThisMonth += day.Events
+		current.DeliveriesThisMonth += day.Deliveries
+		if day.Day.Equal(today) {
+			current.EventsToday = day.Events
+			current.DeliveriesToday = day.Deliveries
+		}
+	}
+	return current, nil
+}
+
+// checkQuota returns ErrQuotaExceeded when publishing an event with the given number of deliveries
+// exceeds a quota of the tenant, or when its delivery quota is already used up
+// Instances check concurrently, so simultaneous events can exceed a quota by the events in flight
+func checkQuota(ctx context.Context, tenantRepo repository.TenantRepository, tenant *models.Tenant, now time.Time, deliveries int) error {
+	if tenant.Settings == nil || tenant.Settings.Quota == (models.Quota{}) {
+		return nil
+	}
+	quota := tenant.Settings.Quota
+	current, err := quotaUsage(ctx, tenantRepo, tenant.ID, now)
+	if err != nil {
+		return err
+	}
+
+	today := usageDay(now)
+	nextDay := today.AddDate(0, 0, 1)
+	nextMonth := today.AddDate(0, 1, 1-today.Day())
+	checks := []struct {
+		name        string
+		used, limit int64
+		needed      int64
+		resets      time.Time
+	}{
+		{"events per day", current.EventsToday, quota.EventsPerDay, 1, nextDay},
+		{"events per month", current.EventsThisMonth, quota.EventsPerMonth, 1, nextMonth},
+		{"deliveries per day", current.DeliveriesToday, quota.DeliveriesPerDay, int64(deliveries), nextDay},
+		{"deliveries per month", current.DeliveriesThisMonth, quota.DeliveriesPerMonth, int64(deliveries), nextMonth},
+	}
+	for _, check := range checks {
+		if check.limit == 0 {
+			continue
+		}
+		if check.used >= check.limit {
+			return ErrQuotaExceeded.Withf("quota exceeded: %d of %d %s used, resets at %s",
+				check.used, check.limit, check.name, check.resets.Format(time.RFC3339))
+		}
+		if check.used+check.needed > check.limit {
+			return ErrQuotaExceeded.Withf("quota exceeded: %d of %d %s used and the event needs %d, resets at %s",
+				check.used, check.limit, check.name, check.needed, check.resets.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// recordUsage counts events published and deliveries made for a tenant on the UTC day of now
+// Failures are logged rather than returned, since the event was already accepted
+func recordUsage(ctx context.Context, logger logging.Logger, tenantRepo repository.TenantRepository, tenantID string, now time.Time, events, deliveries int64) {
+	if events == 0 && deliveries == 0 {
+		return
+	}
+	if err := tenantRepo.AddUsage(context.WithoutCancel(ctx), tenantID, usageDay(now), events, deliveries); err != nil {
+		logger.Warn(ctx, "Failed to record tenant usage",
+			zap.String("tenant_id", tenantID),
+			zap.Int64("events", events),
+			zap.Int64("deliveries", deliveries),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// testDeliverySource is the source of synthetic events sent by TestWebhook
+	testDeliverySource = "loki-suite.test"
+
+	// testDeliveryHeader marks test deliveries so receivers can skip processing them
+	testDeliveryHeader = "X-Loki-Test"
+
+	// testResponseExcerpt is how much of the receiver's answer a test delivery returns
+	testResponseExcerpt = 1024
+)
+
+// TestWebhook sends a single synthetic, signed event to a subscription and reports how the receiver answered
+// The request is built exactly like a real delivery, but is sent once, ignores receiver pauses, works for
+// inactive subscriptions and is not recorded in delivery statistics
+// With a tenant given, subscriptions of other tenants are not found
+func (s *webhookService) TestWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID, req *models.TestWebhookRequest) (*models.TestWebhookResponse, error) {
+	subscription, err := s.repo.GetSubscriptionByID(repository.WithTenantScope(ctx, tenantID), webhookID)
+	if err != nil {
+		return nil, notFound(ErrWebhookNotFound, err)
+	}
+
+	event := req.Event
+	if event == "" {
+		event = subscription.SubscribedEvent
+	}
+	payload := req.Payload
+	if payload == nil {
+		payload = map[string]interface{}{
+			"test":    true,
+			"message": "Test delivery from Loki Suite",
+		}
+	}
+
+	eventID := uuid.New()
+	payloadBytes, err := json.Marshal(&models.WebhookPayload{
+		Event:     event,
+		Source:    testDeliverySource,
+		Timestamp: s.clock.Now().Format(time.RFC3339),
+		Payload:   payload,
+		EventID:   eventID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize test payload: %w", err)
+	}
+
+	response := &models.TestWebhookResponse{
+		WebhookID:   subscription.ID,
+		TargetURL:   subscription.TargetURL,
+		RequestBody: string(payloadBytes),
+	}
+	fail := func(err error) (*models.TestWebhookResponse, error) {
+		errMsg := err.Error()
+		response.Error = &errMsg
+		return response, nil
+	}
+
+	transport, ok := s.transports.Lookup(subscription.TargetType)
+	if !ok {
+		return fail(fmt.Errorf("no transport for target type %s", subscription.TargetType))
+	}
+
+	headers := subscription.SigningHeaders.Or(tenantSigningHeaders(ctx, s.logger, s.tenantRepo, subscription.TenantID))
+	started := s.clock.Now()
+	outcome := transport.Send(ctx, &Delivery{
+		Subscription: *subscription,
+		Headers:      headers,
+		MessageID:    eventID.String(),
+		Body:         payloadBytes,
+		Attempt:      1,
+		Test:         true,
+	})
+	response.LatencyMs = s.clock.Now().Sub(started).Milliseconds()
+	if outcome.TargetURL != "" {
+		response.TargetURL = outcome.TargetURL
+	}
+	if outcome.SentBody != nil {
+		response.RequestBody = string(outcome.SentBody)
+	}
+	if outcome.SentHeaders != nil {
+		response.Headers = testDeliveryHeaders(*subscription, headers, outcome.SentHeaders)
+	}
+	if outcome.ResponseCode != nil {
+		response.ResponseCode = outcome.ResponseCode
+		response.ResponseBody = string(outcome.ResponseBody)
+		if len(outcome.ResponseBody) > testResponseExcerpt {
+			response.ResponseBody = string(outcome.ResponseBody[:testResponseExcerpt])
+			response.Truncated = true
+		}
+	}
+
+	s.logger.Info(ctx, "Test delivery sent",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.String("target_url", response.TargetURL),
+		zap.Any("status_code", outcome.ResponseCode),
+		zap.Int64("latency_ms", response.LatencyMs))
+
+	if outcome.Err != nil {
+		return fail(outcome.Err)
+	}
+	if outcome.ResponseCode != nil && subscription.ResponseSchema != nil {
+		if err := validateResponseBody(*subscription.ResponseSchema, outcome.ResponseBody); err != nil {
+			return fail(err)
+		}
+	}
+	response.Success = true
+	return response, nil
+}
+
+// testDeliveryHeaders returns the attempt, test and signature headers of a test delivery, as reported to the caller
+func testDeliveryHeaders(subscription models.WebhookSubscription, headers models.SigningHeaders, sent http.Header) map[string]string {
+	reported := map[string]string{
+		headers.Attempt:    sent.Get(headers.Attempt),
+		testDeliveryHeader: "true",
+	}
+	for _, name := range signatureHeaderNames(subscription, headers) {
+		reported[name] = sent.Get(name)
+	}
+	if keyID := sent.Get(client.KeyIDHeader); keyID != "" {
+		reported[client.KeyIDHeader] = keyID
+	}
+	return reported
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// TopologyService builds dependency graphs of the apps, webhooks, events and chains of a tenant
+// and analyses the impact of changing a single webhook
+type TopologyService interface {
+	GetTenantTopology(ctx context.Context, tenantID string) (*models.TenantTopologyResponse, error)
+	GetWebhookImpact(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookImpactResponse, error)
+	ExplainRoute(ctx context.Context, req *models.RouteExplainRequest) (*models.RouteExplainResponse, error)
+
+	// Testing
+	SetClock(clock Clock)
+}
+
+// topologyService implements TopologyService
+type topologyService struct {
+	tenantRepo  repository.TenantRepository
+	webhookRepo repository.WebhookRepository
+	chainRepo   repository.ExecutionChainRepository
+	clock       Clock
+}
+
+// NewTopologyService creates a new topology service
+func NewTopologyService(
+	tenantRepo repository.TenantRepository,
+	webhookRepo repository.WebhookRepository,
+	chainRepo repository.ExecutionChainRepository,
+) TopologyService {
+	return &topologyService{
+		tenantRepo:  tenantRepo,
+		webhookRepo: webhookRepo,
+		chainRepo:   chainRepo,
+		clock:       NewSystemClock(),
+	}
+}
+
+// SetClock replaces the clock used for graph timestamps and traffic windows
+func (s *topologyService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// GetTenantTopology returns the dependency graph of a tenant
+// Apps are derived from subscription app names and event sources; events from subscriptions,
+// chain triggers and sent events
+func (s *topologyService) GetTenantTopology(ctx context.Context, tenantID string) (*models.TenantTopologyResponse, error) {
+	subscriptions, err := s.tenantRepo.GetAllSubscriptions(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriptions: %w", err)
+	}
+
+	chains, err := s.tenantRepo.GetAllChains(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution chains: %w", err)
+	}
+
+	sources, err := s.tenantRepo.GetEventSources(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event sources: %w", err)
+	}
+
+	graph := newTopologyGraph()
+
+	for _, sub := range subscriptions {
+		webhookNode := graph.addNode(topologyNodeID(models.TopologyNodeWebhook, sub.ID.String()), models.TopologyNodeWebhook, sub.AppName, map[string]interface{}{
+			"type":       sub.Type,
+			"target_url": sub.TargetURL,
+			"is_active":  sub.IsActive,
+		})
+		appNode := graph.addNode(topologyNodeID(models.TopologyNodeApp, sub.AppName), models.TopologyNodeApp, sub.AppName, nil)
+		eventNode := graph.addNode(topologyNodeID(models.TopologyNodeEvent, sub.SubscribedEvent), models.TopologyNodeEvent, sub.SubscribedEvent, nil)
+
+		graph.addEdge(appNode, webhookNode, models.TopologyEdgeOwns, "")
+		graph.addEdge(eventNode, webhookNode, models.TopologyEdgeDeliversTo, "")
+	}
+
+	for _, chain := range chains {
+		chainNode := graph.addNode(topologyNodeID(models.TopologyNodeChain, chain.ID.String()), models.TopologyNodeChain, chain.Name, map[string]interface{}{
+			"is_active":   chain.IsActive,
+			"steps_count": len(chain.Steps),
+		})
+		eventNode := graph.addNode(topologyNodeID(models.TopologyNodeEvent, chain.TriggerEvent), models.TopologyNodeEvent, chain.TriggerEvent, nil)
+		graph.addEdge(eventNode, chainNode, models.TopologyEdgeTriggers, "")
+
+		for _, step := range chain.Steps {
+			// Steps may reference webhooks owned by other tenants or since deleted; keep the edge visible
+			if step.WebhookID != nil {
+				webhookNode := graph.addNode(topologyNodeID(models.TopologyNodeWebhook, step.WebhookID.String()), models.TopologyNodeWebhook, step.WebhookID.String(), nil)
+				graph.addEdge(chainNode, webhookNode, models.TopologyEdgeCalls, fmt.Sprintf("step %d: %s", step.StepOrder, step.Name))
+			}
+
+			if step.CompensationWebhookID != nil {
+				compensationNode := graph.addNode(topologyNodeID(models.TopologyNodeWebhook, step.CompensationWebhookID.String()), models.TopologyNodeWebhook, step.CompensationWebhookID.String(), nil)
+				graph.addEdge(chainNode, compensationNode, models.TopologyEdgeCalls, fmt.Sprintf("step %d compensation: %s", step.StepOrder, step.Name))
+			}
+		}
+	}
+
+	for _, source := range sources {
+		appNode := graph.addNode(topologyNodeID(models.TopologyNodeApp, source.Source), models.TopologyNodeApp, source.Source, nil)
+		eventNode := graph.addNode(topologyNodeID(models.TopologyNodeEvent, source.EventName), models.TopologyNodeEvent, source.EventName, nil)
+		graph.addEdge(appNode, eventNode, models.TopologyEdgeEmits, "")
+	}
+
+	return &models.TenantTopologyResponse{
+		TenantID:    tenantID,
+		Nodes:       graph.nodes,
+		Edges:       graph.edges,
+		GeneratedAt: s.clock.Now(),
+	}, nil
+}
+
+// GetWebhookImpact reports what would break if a webhook were disabled or deleted
+// The change is breaking when active chains call the webhook or its event would lose its last subscriber
+// With a tenant given, webhooks of other tenants are not found
+func (s *topologyService) GetWebhookImpact(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookImpactResponse, error) {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(repository.WithTenantScope(ctx, tenantID), webhookID)
+	if err != nil {
+		return nil, notFound(ErrWebhookNotFound, err)
+	}
+
+	subscribers, err := s.webhookRepo.GetActiveSubscriptionsByTenantAndEvent(ctx, subscription.TenantID, subscription.SubscribedEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event subscribers: %w", err)
+	}
+
+	otherSubscribers := 0
+	for _, sub := range subscribers {
+		if sub.ID != subscription.ID {
+			otherSubscribers++
+		}
+	}
+
+	steps, err := s.chainRepo.GetStepsByWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chain steps: %w", err)
+	}
+
+	now := s.clock.Now()
+	var traffic models.WebhookImpactTraffic
+	if traffic.EventsLast24h, err = s.webhookRepo.CountEventsSince(ctx, subscription.TenantID, subscription.SubscribedEvent, now.Add(-24*time.Hour)); err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+	if traffic.EventsLast7d, err = s.webhookRepo.CountEventsSince(ctx, subscription.TenantID, subscription.SubscribedEvent, now.Add(-7*24*time.Hour)); err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+	if traffic.StepCallsLast24h, err = s.chainRepo.CountStepRunsByWebhookSince(ctx, webhookID, now.Add(-24*time.Hour)); err != nil {
+		return nil, fmt.Errorf("failed to count step runs: %w", err)
+	}
+	if traffic.StepCallsLast7d, err = s.chainRepo.CountStepRunsByWebhookSince(ctx, webhookID, now.Add(-7*24*time.Hour)); err != nil {
+		return nil, fmt.Errorf("failed to count step runs: %w", err)
+	}
+
+	// Group steps by chain, keeping chains in the order their first step was returned
+	chains := []models.ImpactedChain{}
+	chainIndex := make(map[uuid.UUID]int)
+	for _, step := range steps {
+		idx, ok := chainIndex[step.ChainID]
+		if !ok {
+			idx = len(chains)
+			chainIndex[step.ChainID] = idx
+			chains = append(chains, models.ImpactedChain{
+				ChainID:      step.ChainID,
+				Name:         step.Chain.Name,
+				TriggerEvent: step.Chain.TriggerEvent,
+				IsActive:     step.Chain.IsActive,
+				Steps:        []models.ImpactedStep{},
+			})
+		}
+		chains[idx].Steps = append(chains[idx].Steps, models.ImpactedStep{
+			StepID:          step.ID,
+			StepOrder:       step.StepOrder,
+			Name:            step.Name,
+			OnFailureAction: step.OnFailureAction,
+		})
+	}
+
+	response := &models.WebhookImpactResponse{
+		WebhookID: subscription.ID,
+		TenantID:  subscription.TenantID,
+		AppName:   subscription.AppName,
+		IsActive:  subscription.IsActive,
+		Event: models.ImpactedEvent{
+			EventName:              subscription.SubscribedEvent,
+			OtherActiveSubscribers: otherSubscribers,
+			Orphaned:               subscription.IsActive && otherSubscribers == 0,
+		},
+		Chains:   chains,
+		Traffic:  traffic,
+		Warnings: []string{},
+	}
+
+	if response.Event.Orphaned {
+		response.Breaking = true
+		response.Warnings = append(response.Warnings, fmt.Sprintf(
+			"event %q would have no active subscribers (%d events in the last 7 days)",
+			subscription.SubscribedEvent, traffic.EventsLast7d))
+	}
+	for _, chain := range chains {
+		if !chain.IsActive {
+			continue
+		}
+		response.Breaking = true
+		response.Warnings = append(response.Warnings, fmt.Sprintf(
+			"active chain %q calls this webhook in %d step(s)", chain.Name, len(chain.Steps)))
+	}
+
+	return response, nil
+}
+
+// ExplainRoute evaluates a hypothetical event against the routing rules of every subscription
+// and chain of the tenant, mirroring SendEvent and ExecuteChainByEvent without delivering anything
+func (s *topologyService) ExplainRoute(ctx context.Context, req *models.RouteExplainRequest) (*models.RouteExplainResponse, error) {
+	subscriptions, err := s.tenantRepo.GetAllSubscriptions(ctx, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriptions: %w", err)
+	}
+
+	chains, err := s.tenantRepo.GetAllChains(ctx, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution chains: %w", err)
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	response := &models.RouteExplainResponse{
+		TenantID:      req.TenantID,
+		Event:         req.Event,
+		Subscriptions: make([]models.RouteExplainSubscription, 0, len(subscriptions)),
+		Chains:        make([]models.RouteExplainChain, 0, len(chains)),
+		Warnings:      []string{},
+	}
+
+	if len(subscriptions) == 0 && len(chains) == 0 {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("tenant %q has no subscriptions or chains", req.TenantID))
+	}
+
+	for _, sub := range subscriptions {
+		checks := []models.RouteCheck{
+			eventNameCheck(sub.SubscribedEvent, req.Event),
+			activeCheck(sub.IsActive, "subscription"),
+		}
+		if sub.Payload != "" {
+			var subscriptionData map[string]interface{}
+			if err := json.Unmarshal([]byte(sub.Payload), &subscriptionData); err != nil {
+				checks = append(checks, routeCheck("subscription_payload", true, "static payload is not a JSON object and will not be merged"))
+			} else {
+				checks = append(checks, routeCheck("subscription_payload", true, "static payload will be merged into the event payload"))
+			}
+		}
+
+		explained := models.RouteExplainSubscription{
+			WebhookID:       sub.ID,
+			AppName:         sub.AppName,
+			TargetURL:       sub.TargetURL,
+			SubscribedEvent: sub.SubscribedEvent,
+			Matched:         allPassed(checks),
+			Checks:          checks,
+		}
+		if explained.Matched {
+			response.MatchedSubscriptions++
+		}
+		response.Subscriptions = append(response.Subscriptions, explained)
+	}
+
+	_, payloadIsObject := req.Payload.(map[string]interface{})
+	for _, chain := range chains {
+		checks := []models.RouteCheck{
+			eventNameCheck(chain.TriggerEvent, req.Event),
+			activeCheck(chain.IsActive, "chain"),
+		}
+
+		explained := models.RouteExplainChain{
+			ChainID:      chain.ID,
+			Name:         chain.Name,
+			TriggerEvent: chain.TriggerEvent,
+			WouldTrigger: allPassed(checks),
+			Checks:       checks,
+		}
+		if explained.WouldTrigger {
+			response.TriggeredChains++
+			explained.Queued = settings.ChainsPaused
+			if settings.ChainsPaused {
+				explained.Checks = append(explained.Checks, routeCheck("tenant_not_paused", true, "tenant chain executions are paused; the run would be queued"))
+			}
+			if req.Payload != nil && !payloadIsObject {
+				explained.Checks = append(explained.Checks, routeCheck("trigger_data", true, "payload is not a JSON object; steps receive it only if it converts to one"))
+			}
+		}
+		response.Chains = append(response.Chains, explained)
+	}
+
+	if response.MatchedSubscriptions == 0 && response.TriggeredChains == 0 {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("event %q would not be delivered to any subscription or trigger any chain", req.Event))
+	}
+
+	return response, nil
+}
+
+// eventNameCheck compares a configured event name with the event being routed
+// Event names are matched exactly, so near misses are called out explicitly
+func eventNameCheck(configured, event string) models.RouteCheck {
+	switch {
+	case configured == event:
+		return routeCheck("event_name", true, fmt.Sprintf("event %q matches", event))
+	case strings.EqualFold(strings.TrimSpace(configured), strings.TrimSpace(event)):
+		return routeCheck("event_name", false, fmt.Sprintf("configured for %q, which differs from %q only in case or whitespace; names are matched exactly", configured, event))
+	default:
+		return routeCheck("event_name", false, fmt.Sprintf("configured for %q, not %q", configured, event))
+	}
+}
+
+// activeCheck reports whether a subscription or chain is enabled
+func activeCheck(isActive bool, kind string) models.RouteCheck {
+	if isActive {
+		return routeCheck("active", true, kind+" is active")
+	}
+	return routeCheck("active", false, kind+" is disabled")
+}
+
+// routeCheck builds a single routing rule outcome
+func routeCheck(name string, passed bool, reason string) models.RouteCheck {
+	return models.RouteCheck{Name: name, Passed: passed, Reason: reason}
+}
+
+// allPassed reports whether every routing rule passed
+func allPassed(checks []models.RouteCheck) bool {
+	for _, check := range checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// topologyGraph accumulates nodes and edges while skipping duplicates
+type topologyGraph struct {
+	nodes     []models.TopologyNode
+	edges     []models.TopologyEdge
+	nodeIndex map[string]struct{}
+	edgeIndex map[models.TopologyEdge]struct{}
+}
+
+// newTopologyGraph creates an empty topology graph
+func newTopologyGraph() *topologyGraph {
+	return &topologyGraph{
+		nodes:     []models.TopologyNode{},
+		edges:     []models.TopologyEdge{},
+		nodeIndex: make(map[string]struct{}),
+		edgeIndex: make(map[models.TopologyEdge]struct{}),
+	}
+}
+
+// addNode adds a node unless one with the same ID exists and returns the node ID
+func (g *topologyGraph) addNode(id string, nodeType models.TopologyNodeType, label string, metadata map[string]interface{}) string {
+	if _, exists := g.nodeIndex[id]; !exists {
+		g.nodeIndex[id] = struct{}{}
+		g.nodes = append(g.nodes, models.TopologyNode{
+			ID:       id,
+			Type:     nodeType,
+			Label:    label,
+			Metadata: metadata,
+		})
+	}
+	return id
+}
+
+// addEdge adds an edge unless an identical one exists
+func (g *topologyGraph) addEdge(source, target string, edgeType models.TopologyEdgeType, label string) {
+	edge := models.TopologyEdge{Source: source, Target: target, Type: edgeType, Label: label}
+	if _, exists := g.edgeIndex[edge]; exists {
+		return
+	}
+	g.edgeIndex[edge] = struct{}{}
+	g.edges = append(g.edges, edge)
+}
+
+// topologyNodeID builds a graph-unique node ID from the node type and resource key
+func topologyNodeID(nodeType models.TopologyNodeType, key string) string {
+	return string(nodeType) + ":" + key
+}
@@ -0,0 +1,265 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/mocks"
+)
+
+// TestGetTenantTopology tests that the graph links apps to the webhooks they own and the events they emit,
+// events to their subscribers and chains, and chains to the webhooks their steps and compensations call,
+// with every node and edge once
+func TestGetTenantTopology(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	charge, refund, audit := uuid.New(), uuid.New(), uuid.New()
+	chainID := uuid.New()
+
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	tenantRepo.EXPECT().GetAllSubscriptions(ctx, "tenant-123").Return([]models.WebhookSubscription{
+		{ID: charge, AppName: "billing", SubscribedEvent: "order.created", TargetURL: "https://billing.example.com/charge", IsActive: true},
+		{ID: refund, AppName: "billing", SubscribedEvent: "order.refunded", TargetURL: "https://billing.example.com/refund", IsActive: true},
+		{ID: audit, AppName: "audit", SubscribedEvent: "order.created", TargetURL: "https://audit.example.com", IsActive: false},
+	}, nil).Once()
+	tenantRepo.EXPECT().GetAllChains(ctx, "tenant-123").Return([]models.ExecutionChain{{
+		ID: chainID, Name: "Fulfil order", TriggerEvent: "order.created", IsActive: true,
+		Steps: []models.ExecutionChainStep{{StepOrder: 1, Name: "Charge", WebhookID: &charge, CompensationWebhookID: &refund}},
+	}}, nil).Once()
+	tenantRepo.EXPECT().GetEventSources(ctx, "tenant-123").Return([]models.EventSource{
+		{Source: "shop", EventName: "order.created"},
+		{Source: "shop", EventName: "order.refunded"},
+	}, nil).Once()
+
+	topology := service.NewTopologyService(tenantRepo, nil, nil)
+	topology.SetClock(newFakeClock(now))
+
+	// Act
+	response, err := topology.GetTenantTopology(ctx, "tenant-123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-123", response.TenantID)
+	assert.Equal(t, now, response.GeneratedAt)
+
+	nodes := make(map[string]models.TopologyNodeType)
+	for _, node := range response.Nodes {
+		_, duplicate := nodes[node.ID]
+		assert.False(t, duplicate, "node %s is listed once", node.ID)
+		nodes[node.ID] = node.Type
+	}
+	assert.Equal(t, map[string]models.TopologyNodeType{
+		"app:billing":                models.TopologyNodeApp,
+		"app:audit":                  models.TopologyNodeApp,
+		"app:shop":                   models.TopologyNodeApp,
+		"event:order.created":        models.TopologyNodeEvent,
+		"event:order.refunded":       models.TopologyNodeEvent,
+		"webhook:" + charge.String(): models.TopologyNodeWebhook,
+		"webhook:" + refund.String(): models.TopologyNodeWebhook,
+		"webhook:" + audit.String():  models.TopologyNodeWebhook,
+		"chain:" + chainID.String():  models.TopologyNodeChain,
+	}, nodes)
+
+	assert.ElementsMatch(t, []models.TopologyEdge{
+		{Source: "app:billing", Target: "webhook:" + charge.String(), Type: models.TopologyEdgeOwns},
+		{Source: "event:order.created", Target: "webhook:" + charge.String(), Type: models.TopologyEdgeDeliversTo},
+		{Source: "app:billing", Target: "webhook:" + refund.String(), Type: models.TopologyEdgeOwns},
+		{Source: "event:order.refunded", Target: "webhook:" + refund.String(), Type: models.TopologyEdgeDeliversTo},
+		{Source: "app:audit", Target: "webhook:" + audit.String(), Type: models.TopologyEdgeOwns},
+		{Source: "event:order.created", Target: "webhook:" + audit.String(), Type: models.TopologyEdgeDeliversTo},
+		{Source: "event:order.created", Target: "chain:" + chainID.String(), Type: models.TopologyEdgeTriggers},
+		{Source: "chain:" + chainID.String(), Target: "webhook:" + charge.String(), Type: models.TopologyEdgeCalls, Label: "step 1: Charge"},
+		{Source: "chain:" + chainID.String(), Target: "webhook:" + refund.String(), Type: models.TopologyEdgeCalls, Label: "step 1 compensation: Charge"},
+		{Source: "app:shop", Target: "event:order.created", Type: models.TopologyEdgeEmits},
+		{Source: "app:shop", Target: "event:order.refunded", Type: models.TopologyEdgeEmits},
+	}, response.Edges)
+}
+
+// TestGetWebhookImpact tests that removing the last active subscriber of an event or a webhook called by an
+// active chain is reported as breaking, with the calling steps grouped by chain and the webhook's recent traffic
+func TestGetWebhookImpact(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	webhook := models.WebhookSubscription{ID: uuid.New(), TenantID: "tenant-123", AppName: "billing", SubscribedEvent: "order.created", IsActive: true}
+	fulfil := models.ExecutionChain{ID: uuid.New(), Name: "Fulfil order", TriggerEvent: "order.created", IsActive: true}
+	legacy := models.ExecutionChain{ID: uuid.New(), Name: "Legacy billing", TriggerEvent: "order.paid"}
+
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(ctx, webhook.ID).Return(&webhook, nil).Once()
+	webhookRepo.EXPECT().GetActiveSubscriptionsByTenantAndEvent(ctx, "tenant-123", "order.created").
+		Return([]models.WebhookSubscription{webhook}, nil).Once()
+	webhookRepo.EXPECT().CountEventsSince(ctx, "tenant-123", "order.created", now.Add(-24*time.Hour)).Return(12, nil).Once()
+	webhookRepo.EXPECT().CountEventsSince(ctx, "tenant-123", "order.created", now.Add(-7*24*time.Hour)).Return(80, nil).Once()
+
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	chainRepo.EXPECT().GetStepsByWebhook(ctx, webhook.ID).Return([]*models.ExecutionChainStep{
+		{ID: uuid.New(), ChainID: fulfil.ID, Chain: fulfil, StepOrder: 1, Name: "Charge", OnFailureAction: "stop"},
+		{ID: uuid.New(), ChainID: legacy.ID, Chain: legacy, StepOrder: 2, Name: "Invoice", OnFailureAction: "continue"},
+		{ID: uuid.New(), ChainID: fulfil.ID, Chain: fulfil, StepOrder: 3, Name: "Capture", OnFailureAction: "stop"},
+	}, nil).Once()
+	chainRepo.EXPECT().CountStepRunsByWebhookSince(ctx, webhook.ID, now.Add(-24*time.Hour)).Return(4, nil).Once()
+	chainRepo.EXPECT().CountStepRunsByWebhookSince(ctx, webhook.ID, now.Add(-7*24*time.Hour)).Return(30, nil).Once()
+
+	topology := service.NewTopologyService(nil, webhookRepo, chainRepo)
+	topology.SetClock(newFakeClock(now))
+
+	// Act
+	response, err := topology.GetWebhookImpact(ctx, "", webhook.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, response.Breaking)
+	assert.Equal(t, models.ImpactedEvent{EventName: "order.created", OtherActiveSubscribers: 0, Orphaned: true}, response.Event)
+	assert.Equal(t, models.WebhookImpactTraffic{EventsLast24h: 12, EventsLast7d: 80, StepCallsLast24h: 4, StepCallsLast7d: 30}, response.Traffic)
+
+	require.Len(t, response.Chains, 2)
+	assert.Equal(t, fulfil.ID, response.Chains[0].ChainID)
+	require.Len(t, response.Chains[0].Steps, 2)
+	assert.Equal(t, "Charge", response.Chains[0].Steps[0].Name)
+	assert.Equal(t, "Capture", response.Chains[0].Steps[1].Name)
+	assert.Equal(t, legacy.ID, response.Chains[1].ChainID)
+	assert.False(t, response.Chains[1].IsActive)
+
+	assert.Equal(t, []string{
+		`event "order.created" would have no active subscribers (80 events in the last 7 days)`,
+		`active chain "Fulfil order" calls this webhook in 2 step(s)`,
+	}, response.Warnings, "inactive chains do not make the change breaking")
+}
+
+// TestGetWebhookImpact_OtherSubscribers tests that a webhook whose event has other active subscribers and that
+// no chain calls can be removed without breaking anything
+func TestGetWebhookImpact_OtherSubscribers(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	webhook := models.WebhookSubscription{ID: uuid.New(), TenantID: "tenant-123", SubscribedEvent: "order.created", IsActive: true}
+
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(ctx, webhook.ID).Return(&webhook, nil).Once()
+	webhookRepo.EXPECT().GetActiveSubscriptionsByTenantAndEvent(ctx, "tenant-123", "order.created").
+		Return([]models.WebhookSubscription{webhook, {ID: uuid.New()}, {ID: uuid.New()}}, nil).Once()
+	webhookRepo.EXPECT().CountEventsSince(ctx, "tenant-123", "order.created", mock.Anything).Return(0, nil).Twice()
+	chainRepo := mocks.NewMockExecutionChainRepository(t)
+	chainRepo.EXPECT().GetStepsByWebhook(ctx, webhook.ID).Return(nil, nil).Once()
+	chainRepo.EXPECT().CountStepRunsByWebhookSince(ctx, webhook.ID, mock.Anything).Return(0, nil).Twice()
+
+	// Act
+	response, err := service.NewTopologyService(nil, webhookRepo, chainRepo).GetWebhookImpact(ctx, "", webhook.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, response.Breaking)
+	assert.Equal(t, 2, response.Event.OtherActiveSubscribers)
+	assert.False(t, response.Event.Orphaned)
+	assert.Empty(t, response.Chains)
+	assert.Empty(t, response.Warnings)
+}
+
+// TestGetWebhookImpact_NotFound tests that the impact of an unknown webhook is reported as not found
+func TestGetWebhookImpact_NotFound(t *testing.T) {
+	// Arrange
+	webhookID := uuid.New()
+	webhookRepo := mocks.NewMockWebhookRepository(t)
+	webhookRepo.EXPECT().GetSubscriptionByID(mock.Anything, webhookID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	// Act
+	response, err := service.NewTopologyService(nil, webhookRepo, nil).GetWebhookImpact(context.Background(), "", webhookID)
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrWebhookNotFound)
+	assert.Nil(t, response)
+}
+
+// TestExplainRoute tests that an event is matched against every subscription and chain of the tenant by exact
+// event name and active state, that near misses are explained, and that chains of a paused tenant are queued
+func TestExplainRoute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	exact, nearMiss, disabled := uuid.New(), uuid.New(), uuid.New()
+	chainID := uuid.New()
+
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	tenantRepo.EXPECT().GetAllSubscriptions(ctx, "tenant-123").Return([]models.WebhookSubscription{
+		{ID: exact, AppName: "billing", SubscribedEvent: "order.created", IsActive: true, Payload: `{"source": "loki"}`},
+		{ID: nearMiss, AppName: "audit", SubscribedEvent: "Order.Created ", IsActive: true},
+		{ID: disabled, AppName: "crm", SubscribedEvent: "order.created", IsActive: false},
+	}, nil).Once()
+	tenantRepo.EXPECT().GetAllChains(ctx, "tenant-123").Return([]models.ExecutionChain{
+		{ID: chainID, Name: "Fulfil order", TriggerEvent: "order.created", IsActive: true},
+		{ID: uuid.New(), Name: "Refund", TriggerEvent: "order.refunded", IsActive: true},
+	}, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123", ChainsPaused: true}, nil).Once()
+
+	// Act
+	response, err := service.NewTopologyService(tenantRepo, nil, nil).ExplainRoute(ctx, &models.RouteExplainRequest{
+		TenantID: "tenant-123", Event: "order.created", Payload: []interface{}{"not", "an", "object"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.MatchedSubscriptions)
+	assert.Equal(t, 1, response.TriggeredChains)
+	assert.Empty(t, response.Warnings)
+
+	require.Len(t, response.Subscriptions, 3)
+	assert.True(t, response.Subscriptions[0].Matched)
+	assert.Equal(t, models.RouteCheck{Name: "subscription_payload", Passed: true, Reason: "static payload will be merged into the event payload"},
+		response.Subscriptions[0].Checks[2])
+	assert.False(t, response.Subscriptions[1].Matched)
+	assert.Equal(t, models.RouteCheck{
+		Name: "event_name", Passed: false,
+		Reason: `configured for "Order.Created ", which differs from "order.created" only in case or whitespace; names are matched exactly`,
+	}, response.Subscriptions[1].Checks[0])
+	assert.False(t, response.Subscriptions[2].Matched)
+	assert.Equal(t, models.RouteCheck{Name: "active", Passed: false, Reason: "subscription is disabled"}, response.Subscriptions[2].Checks[1])
+
+	require.Len(t, response.Chains, 2)
+	triggered := response.Chains[0]
+	assert.Equal(t, chainID, triggered.ChainID)
+	assert.True(t, triggered.WouldTrigger)
+	assert.True(t, triggered.Queued, "chain executions of the tenant are paused")
+	assert.Equal(t, []string{"event_name", "active", "tenant_not_paused", "trigger_data"}, checkNames(triggered.Checks))
+	assert.False(t, response.Chains[1].WouldTrigger)
+	assert.False(t, response.Chains[1].Queued)
+}
+
+// TestExplainRoute_Unrouted tests that an event matching nothing is explained with a warning
+func TestExplainRoute_Unrouted(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	tenantRepo := mocks.NewMockTenantRepository(t)
+	tenantRepo.EXPECT().GetAllSubscriptions(ctx, "tenant-123").Return([]models.WebhookSubscription{
+		{ID: uuid.New(), SubscribedEvent: "order.created", IsActive: true},
+	}, nil).Once()
+	tenantRepo.EXPECT().GetAllChains(ctx, "tenant-123").Return(nil, nil).Once()
+	tenantRepo.EXPECT().GetTenantSettings(ctx, "tenant-123").Return(&models.TenantSettings{TenantID: "tenant-123"}, nil).Once()
+
+	// Act
+	response, err := service.NewTopologyService(tenantRepo, nil, nil).ExplainRoute(ctx, &models.RouteExplainRequest{
+		TenantID: "tenant-123", Event: "order.shipped",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, response.MatchedSubscriptions)
+	assert.Equal(t, []string{`event "order.shipped" would not be delivered to any subscription or trigger any chain`}, response.Warnings)
+}
+
+// checkNames returns the names of routing checks in order
+func checkNames(checks []models.RouteCheck) []string {
+	names := make([]string, 0, len(checks))
+	for _, check := range checks {
+		names = append(names, check.Name)
+	}
+	return names
+}
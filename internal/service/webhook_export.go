@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// exportPageSize is how many subscriptions are read per query while exporting and checking for conflicts
+const exportPageSize = 100
+
+// ExportWebhooks describes the subscriptions of a tenant portably, for ImportWebhooks in another environment
+// Receive endpoints made by GenerateWebhook are not exported, as their URL is bound to their ID
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant whose subscriptions are exported
+//   - includeSecrets: Whether signing secrets and PagerDuty routing keys are exported
+//
+// Returns:
+//   - WebhookExport: The subscriptions in creation order
+//   - error: If the subscriptions cannot be loaded
+func (s *webhookService) ExportWebhooks(ctx context.Context, tenantID string, includeSecrets bool) (*models.WebhookExport, error) {
+	subscriptions, err := tenantSubscriptions(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.WebhookExport{
+		Version:         models.WebhookExportVersion,
+		TenantID:        tenantID,
+		ExportedAt:      s.clock.Now(),
+		IncludesSecrets: includeSecrets,
+		Subscriptions:   make([]models.ExportedSubscription, 0, len(subscriptions)),
+	}
+	for _, subscription := range subscriptions {
+		if isReceiveEndpoint(subscription) {
+			continue
+		}
+		export.Subscriptions = append(export.Subscriptions, exportSubscription(subscription, includeSecrets))
+	}
+
+	s.logger.Info(ctx, "Webhook subscriptions exported",
+		zap.String("tenant_id", tenantID),
+		zap.Int("subscriptions", len(export.Subscriptions)),
+		zap.Bool("include_secrets", includeSecrets))
+	return export, nil
+}
+
+// tenantSubscriptions loads every subscription of a tenant, page by page, each continuing after the last
+// subscription of the previous one
+func tenantSubscriptions(ctx context.Context, repo repository.WebhookRepository, tenantID string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	page := repository.Page{Limit: exportPageSize}
+	for {
+		loaded, total, err := repo.GetSubscriptionsByTenant(ctx, tenantID, models.ListFilter{}, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch webhooks: %w", err)
+		}
+		subscriptions = append(subscriptions, loaded...)
+		if len(loaded) < exportPageSize || int64(len(subscriptions)) >= total {
+			return subscriptions, nil
+		}
+		last := loaded[len(loaded)-1]
+		page.After = &repository.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// isReceiveEndpoint reports whether a subscription is a receive endpoint made by GenerateWebhook
+// Endpoints made before the API was versioned are under /api rather than /api/v1
+func isReceiveEndpoint(subscription models.WebhookSubscription) bool {
+	return strings.HasSuffix(subscription.TargetURL, "/webhooks/receive/"+subscription.ID.String())
+}
+
+// exportSubscription describes a subscription as the request that subscribes it again
+func exportSubscription(subscription models.WebhookSubscription, includeSecrets bool) models.ExportedSubscription {
+	exported := models.ExportedSubscription{
+		SourceID:        subscription.ID,
+		AppName:         subscription.AppName,
+		Description:     subscription.Description,
+		SubscribedEvent: subscription.SubscribedEvent,
+		Type:            subscription.Type,
+		IsActive:        subscription.IsActive,
+		TargetType:      subscription.TargetType,
+		TargetURL:       subscription.TargetURL,
+		AMQPExchange:    subscription.AMQPExchange,
+		AMQPRoutingKey:  subscription.AMQPRoutingKey,
+		Notification:    subscription.Notification,
+		Recipients:      subscription.Recipients,
+		Headers:         subscription.Headers,
+		QueryParams:     subscription.QueryParams,
+		RetryPolicy: &models.RetryPolicy{
+			MaxRetries:        subscription.MaxRetries,
+			RetryDelaySeconds: subscription.RetryDelaySeconds,
+		},
+		SignatureScheme:  subscription.SignatureScheme,
+		ContentEncoding:  subscription.ContentEncoding,
+		AllowedSourceIPs: subscription.AllowedSourceIPs,
+	}
+	if exported.TargetType == "" {
+		exported.TargetType = models.TargetTypeHTTP
+	}
+
+	// Targets described by other settings get their target URL from them again
+	switch exported.TargetType {
+	case models.TargetTypeAMQP, models.TargetTypeEmail, models.TargetTypeSMS:
+		exported.TargetURL = ""
+	}
+
+	if subscription.ResponseSchema != nil {
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(*subscription.ResponseSchema), &schema); err == nil {
+			exported.ResponseSchema = schema
+		}
+	}
+	if subscription.SigningHeaders != (models.SigningHeaders{}) {
+		headers := subscription.SigningHeaders
+		exported.SigningHeaders = &headers
+	}
+	if subscription.BatchWindowSeconds > 0 {
+		exported.Batching = &models.BatchPolicy{
+			MaxEvents:     subscription.BatchMaxEvents,
+			WindowSeconds: subscription.BatchWindowSeconds,
+		}
+	}
+	if includeSecrets {
+		secret := subscription.SecretToken
+		exported.SecretToken = &secret
+		exported.PagerDutyRoutingKey = subscription.PagerDutyRoutingKey
+	}
+	return exported
+}
+
+// subscribeRequest returns the request subscribing an exported subscription for a tenant
+func subscribeRequest(tenantID string, exported models.ExportedSubscription) *models.SubscribeWebhookRequest {
+	isActive := exported.IsActive
+	req := &models.SubscribeWebhookRequest{
+		TenantID:         tenantID,
+		AppName:          exported.AppName,
+		TargetURL:        exported.TargetURL,
+		TargetType:       exported.TargetType,
+		Notification:     exported.Notification,
+		Recipients:       exported.Recipients,
+		AMQPExchange:     exported.AMQPExchange,
+		AMQPRoutingKey:   exported.AMQPRoutingKey,
+		SubscribedEvent:  exported.SubscribedEvent,
+		Type:             exported.Type,
+		Description:      exported.Description,
+		IsActive:         &isActive,
+		Headers:          exported.Headers,
+		RetryPolicy:      exported.RetryPolicy,
+		Batching:         exported.Batching,
+		ContentEncoding:  exported.ContentEncoding,
+		QueryParams:      exported.QueryParams,
+		IsPublic:         exported.Type == models.WebhookTypePublic,
+		ResponseSchema:   exported.ResponseSchema,
+		SigningHeaders:   exported.SigningHeaders,
+		SignatureScheme:  exported.SignatureScheme,
+		AllowedSourceIPs: exported.AllowedSourceIPs,
+	}
+	if exported.PagerDutyRoutingKey != nil {
+		req.PagerDutyRoutingKey = *exported.PagerDutyRoutingKey
+	}
+	return req
+}
+
+// importSubscription builds the subscription of an exported subscription for a tenant, validated like a
+// SubscribeWebhook request including the fields its binding requires
+func (s *webhookService) importSubscription(ctx context.Context, tenant *models.Tenant, exported models.ExportedSubscription) (*models.WebhookSubscription, error) {
+	if exported.AppName == "" {
+		return nil, ErrInvalidWebhook.Withf("app_name is required")
+	}
+	if exported.SubscribedEvent == "" {
+		return nil, ErrInvalidWebhook.Withf("subscribed_event is required")
+	}
+	return s.newSubscription(ctx, tenant, subscribeRequest(tenant.ID, exported))
+}
+
+// subscriptionKey identifies what a subscription delivers where, to detect imports of existing subscriptions
+func subscriptionKey(subscription *models.WebhookSubscription) string {
+	targetType := subscription.TargetType
+	if targetType == "" {
+		targetType = models.TargetTypeHTTP
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s", subscription.SubscribedEvent, targetType, subscription.TargetURL)
+}
+
+// ImportWebhooks subscribes the subscriptions of an export for a tenant
+// Every subscription is validated like a SubscribeWebhook request and checked against the tenant's
+// subscriptions before any is created: invalid subscriptions reject the whole import, and so do conflicts
+// under models.ImportConflictFail; otherwise conflicting subscriptions are skipped
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - export: The subscriptions to import
+//   - opts: Target tenant, dry run, secret regeneration and conflict policy
+//
+// Returns:
+//   - WebhookImportResponse: The outcome of each subscription, with the new secrets of created ones
+//   - error: If the tenant is unknown or suspended, the export or options are invalid, or the tenant's
+//     subscriptions cannot be loaded
+//
+// Use case: Promoting subscriptions from staging to production, where receivers may need new secrets
+func (s *webhookService) ImportWebhooks(ctx context.Context, export *models.WebhookExport, opts models.WebhookImportOptions) (*models.WebhookImportResponse, error) {
+	if export.Version != models.WebhookExportVersion {
+		return nil, ErrInvalidWebhook.Withf("unsupported export version %d, expected %d", export.Version, models.WebhookExportVersion)
+	}
+	if !opts.OnConflict.IsValid() {
+		return nil, ErrInvalidWebhook.Withf("invalid conflict policy: %s", opts.OnConflict)
+	}
+	tenantID := opts.TenantID
+	if tenantID == "" {
+		tenantID = export.TenantID
+	}
+	tenant, err := activeTenant(ctx, s.tenantRepo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := tenantSubscriptions(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]uuid.UUID, len(existing))
+	for i := range existing {
+		seen[subscriptionKey(&existing[i])] = existing[i].ID
+	}
+
+	response := &models.WebhookImportResponse{
+		TenantID: tenantID,
+		DryRun:   opts.DryRun,
+		Results:  make([]models.WebhookImportResult, len(export.Subscriptions)),
+	}
+	subscriptions := make([]*models.WebhookSubscription, len(export.Subscriptions))
+	exportIndex := make(map[string]int)
+	for i, exported := range export.Subscriptions {
+		result := &response.Results[i]
+		result.Index = i
+		result.SourceID = exported.SourceID
+		result.SubscribedEvent = exported.SubscribedEvent
+		result.TargetType = exported.TargetType
+		result.TargetURL = exported.TargetURL
+
+		subscription, err := s.importSubscription(ctx, tenant, exported)
+		if err != nil {
+			result.Status = models.ImportStatusInvalid
+			result.Error = importError(err)
+			response.Invalid++
+			continue
+		}
+		result.TargetType = subscription.TargetType
+		result.TargetURL = subscription.TargetURL
+
+		key := subscriptionKey(subscription)
+		if id, ok := seen[key]; ok {
+			result.Status = models.ImportStatusConflict
+			result.ConflictsWith = &id
+			response.Conflicts++
+			continue
+		}
+		if earlier, ok := exportIndex[key]; ok {
+			result.Status = models.ImportStatusConflict
+			result.Error = importError(fmt.Errorf("duplicates subscription %d of the export", earlier))
+			response.Conflicts++
+			continue
+		}
+		exportIndex[key] = i
+
+		// Keep the exported secret unless asked for a new one; JWTs name the subscription and are always new
+		if exported.SecretToken != nil && *exported.SecretToken != "" && !opts.RegenerateSecrets {
+			subscription.SecretToken = *exported.SecretToken
+		} else {
+			result.SecretToken = &subscription.SecretToken
+		}
+		result.JWTToken = subscription.JWTToken
+
+		result.Status = models.ImportStatusValid
+		subscriptions[i] = subscription
+	}
+
+	rejected := response.Invalid > 0 || (response.Conflicts > 0 && opts.OnConflict == models.ImportConflictFail)
+	if opts.DryRun || rejected {
+		if rejected {
+			markNotImported(response)
+		}
+		// Secrets of subscriptions that are not created are not returned
+		for i := range response.Results {
+			response.Results[i].SecretToken = nil
+			response.Results[i].JWTToken = nil
+		}
+		s.logger.Info(ctx, "Webhook import not applied",
+			zap.String("tenant_id", tenantID),
+			zap.Bool("dry_run", opts.DryRun),
+			zap.Int("invalid", response.Invalid),
+			zap.Int("conflicts", response.Conflicts))
+		return response, nil
+	}
+
+	for i, subscription := range subscriptions {
+		if subscription == nil {
+			continue
+		}
+		result := &response.Results[i]
+		if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+			s.logger.Error(ctx, "Failed to import webhook subscription",
+				zap.String("tenant_id", tenantID),
+				zap.Int("index", i),
+				zap.Error(err))
+			result.Status = models.ImportStatusNotImported
+			result.Error = importError(fmt.Errorf("failed to create webhook subscription: %w", err))
+			result.SecretToken = nil
+			result.JWTToken = nil
+			continue
+		}
+		recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeCreated, subscription)
+		result.Status = models.ImportStatusCreated
+		result.WebhookID = &subscription.ID
+		response.Created++
+	}
+	response.Applied = true
+
+	s.logger.Info(ctx, "Webhook subscriptions imported",
+		zap.String("tenant_id", tenantID),
+		zap.String("source_tenant_id", export.TenantID),
+		zap.Int("created", response.Created),
+		zap.Int("conflicts", response.Conflicts))
+	return response, nil
+}
+
+// markNotImported marks the valid subscriptions of a rejected import as not imported
+func markNotImported(response *models.WebhookImportResponse) {
+	for i := range response.Results {
+		if response.Results[i].Status == models.ImportStatusValid {
+			response.Results[i].Status = models.ImportStatusNotImported
+		}
+	}
+}
+
+// importError returns an error message for an import result
+func importError(err error) *string {
+	msg := err.Error()
+	return &msg
+}
@@ -1,11 +1,10 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"time"
@@ -13,8 +12,10 @@ import (
 	"github.com/sakibcoolz/zcornor/pkg/config"
 	"github.com/sakibcoolz/zcornor/pkg/security"
 
+	"github.com/sakibcoolz/loki-suite/internal/logging"
 	"github.com/sakibcoolz/loki-suite/internal/models"
 	"github.com/sakibcoolz/loki-suite/internal/repository"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -26,71 +27,565 @@ import (
 type WebhookService interface {
 	// GenerateWebhook creates a new webhook subscription and returns the webhook URL
 	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
 	//   - req: Contains webhook configuration including tenant ID, app name, event type, and webhook type
 	// Returns:
 	//   - GenerateWebhookResponse: Contains the generated webhook URL, security tokens, and webhook ID
 	//   - error: If webhook creation fails due to validation or database errors
-	GenerateWebhook(req *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error)
+	GenerateWebhook(ctx context.Context, req *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error)
 
 	// SubscribeWebhook creates a webhook subscription with a custom target URL
 	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
 	//   - req: Contains subscription details including target URL, tenant ID, and event filters
 	// Returns:
 	//   - GenerateWebhookResponse: Contains security credentials and webhook configuration
 	//   - error: If subscription creation fails
-	SubscribeWebhook(req *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error)
+	SubscribeWebhook(ctx context.Context, req *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error)
 
 	// SendEvent broadcasts an event to all matching webhook subscriptions
 	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
 	//   - req: Contains event data, tenant ID, event name, source, and payload
 	// Returns:
 	//   - EventProcessingResult: Summary of delivery results including success/failure counts
-	//   - error: If event processing fails
-	SendEvent(req *models.SendEventRequest) (*models.EventProcessingResult, error)
+	//   - error: *PayloadValidationError if the payload violates its event type's schema, or if event processing fails
+	SendEvent(ctx context.Context, req *models.SendEventRequest) (*models.EventProcessingResult, error)
 
 	// VerifyWebhook validates the authenticity and authorization of incoming webhook requests
 	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
 	//   - webhookID: UUID of the webhook subscription
 	//   - payload: Raw request body bytes for signature verification
 	//   - signature: HMAC signature from request headers
 	//   - timestamp: Request timestamp for replay attack prevention
 	//   - authHeader: Authorization header containing JWT token (for private webhooks)
+	//   - sourceIP: IP the request was received from, checked against the receive allowlists
 	// Returns:
-	//   - error: If verification fails due to invalid signature, expired timestamp, or unauthorized access
-	VerifyWebhook(webhookID uuid.UUID, payload []byte, signature, timestamp, authHeader string) error
+	//   - error: If verification fails due to invalid signature, expired timestamp, or unauthorized access;
+	//     wraps ErrSourceIPNotAllowed when the source IP is not allowlisted
+	VerifyWebhook(ctx context.Context, webhookID uuid.UUID, payload []byte, signature, timestamp, authHeader, sourceIP string) error
 
 	// ListWebhooks retrieves paginated webhook subscriptions for a tenant
 	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
 	//   - tenantID: Filter webhooks by tenant identifier
-	//   - page: Page number for pagination (1-based)
-	//   - limit: Maximum number of results per page (1-100, default 10)
+	//   - filter: Narrows the webhooks by activity, type, subscribed event and creation time
+	//   - page: Page number or cursor, size (1-100, default 10) and sort, by created_at or app_name
 	// Returns:
 	//   - WebhookListResponse: Contains webhooks array, total count, and pagination info
+	//   - error: Wrapping ErrInvalidListPage for an unsupported sort or a malformed cursor, or if database query fails
+	ListWebhooks(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage) (*models.WebhookListResponse, error)
+
+	// ExportWebhooks describes a tenant's subscriptions portably, to import them in another environment
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant whose subscriptions are exported
+	//   - includeSecrets: Whether signing secrets and PagerDuty routing keys are exported
+	// Returns:
+	//   - WebhookExport: The subscriptions, without generated receive endpoints
+	//   - error: If database query fails
+	ExportWebhooks(ctx context.Context, tenantID string, includeSecrets bool) (*models.WebhookExport, error)
+
+	// ImportWebhooks creates the subscriptions of an export for a tenant, or validates them on a dry run
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - export: The subscriptions to import
+	//   - opts: Target tenant, dry run, secret regeneration and conflict policy
+	// Returns:
+	//   - WebhookImportResponse: The outcome per subscription; not applied when any is invalid, or conflicts
+	//     under models.ImportConflictFail
+	//   - error: If the tenant is unknown or suspended, the export version or options are invalid, or
+	//     database query fails
+	ImportWebhooks(ctx context.Context, export *models.WebhookExport, opts models.WebhookImportOptions) (*models.WebhookImportResponse, error)
+
+	// ApplyConfig reconciles a tenant's subscriptions and chains with a declarative manifest, creating,
+	// updating and deleting them to match, or only plans the changes on a dry run
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - manifest: The subscriptions and chains the tenant should have
+	//   - opts: Target tenant and dry run
+	// Returns:
+	//   - ConfigApplyResponse: The change of every subscription and chain; not applied when any is invalid
+	//   - error: If the tenant is unknown or suspended, the manifest version is unsupported, or database
+	//     query fails
+	ApplyConfig(ctx context.Context, manifest *models.ConfigManifest, opts models.ConfigApplyOptions) (*models.ConfigApplyResponse, error)
+
+	// GetTenantSigningHeaders retrieves a tenant's signing header name overrides
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	// Returns:
+	//   - TenantSigningHeadersResponse: The tenant's overrides and the header names they resolve to
+	//   - error: If database query fails
+	GetTenantSigningHeaders(ctx context.Context, tenantID string) (*models.TenantSigningHeadersResponse, error)
+
+	// UpdateTenantSigningHeaders replaces a tenant's signing header name overrides
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	//   - headers: New overrides; empty names restore the defaults
+	// Returns:
+	//   - TenantSigningHeadersResponse: The stored overrides and the header names they resolve to
+	//   - error: If a header name is invalid or the settings cannot be saved
+	UpdateTenantSigningHeaders(ctx context.Context, tenantID string, headers models.SigningHeaders) (*models.TenantSigningHeadersResponse, error)
+
+	// GetTenantPayloadValidation retrieves whether a tenant enforces strict payload validation
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	// Returns:
+	//   - TenantPayloadValidationResponse: The tenant's validation mode
+	//   - error: If database query fails
+	GetTenantPayloadValidation(ctx context.Context, tenantID string) (*models.TenantPayloadValidationResponse, error)
+
+	// UpdateTenantPayloadValidation switches strict payload validation for a tenant
+	// In strict mode every event whose type has a schema is validated, whatever the type's validate_payloads
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	//   - strict: Whether to enforce strict validation
+	// Returns:
+	//   - TenantPayloadValidationResponse: The stored validation mode
+	//   - error: If the settings cannot be saved
+	UpdateTenantPayloadValidation(ctx context.Context, tenantID string, strict bool) (*models.TenantPayloadValidationResponse, error)
+
+	// GetTenantSigning retrieves the algorithm a tenant's deliveries are signed with and its current key
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	// Returns:
+	//   - TenantSigningResponse: The algorithm and, for Ed25519, the current key ID and public key
+	//   - error: If database query fails
+	GetTenantSigning(ctx context.Context, tenantID string) (*models.TenantSigningResponse, error)
+
+	// UpdateTenantSigning switches a tenant between HMAC and Ed25519 signing, generating keys as needed
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	//   - req: The algorithm and whether to rotate the Ed25519 key
+	// Returns:
+	//   - TenantSigningResponse: The stored algorithm and current key
+	//   - error: If the algorithm is unknown or the key or settings cannot be saved
+	UpdateTenantSigning(ctx context.Context, tenantID string, req *models.TenantSigningRequest) (*models.TenantSigningResponse, error)
+
+	// GetSigningKeySet retrieves the Ed25519 public keys deliveries are signed with as a JSON Web Key Set
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier to filter keys, empty for every tenant
+	// Returns:
+	//   - SigningKeySet: Public keys, newest first
 	//   - error: If database query fails
-	ListWebhooks(tenantID string, page, limit int) (*models.WebhookListResponse, error)
+	GetSigningKeySet(ctx context.Context, tenantID string) (*models.SigningKeySet, error)
+
+	// GetEgressIPs retrieves the published addresses deliveries are sent from
+	// Returns:
+	//   - EgressIPsResponse: The configured egress IPs and CIDR ranges
+	GetEgressIPs() *models.EgressIPsResponse
+
+	// TestWebhook sends a synthetic signed event to a subscription once and reports the receiver's answer
+	// Test deliveries carry X-Loki-Test: true, ignore receiver pauses and are not recorded in delivery statistics
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP call
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	//   - req: Optional event name and payload; defaults to the subscribed event and a sample payload
+	// Returns:
+	//   - TestWebhookResponse: Response code, latency, body excerpt and the signed request that was sent;
+	//     a failed delivery is reported in the response, not as an error
+	//   - error: If the subscription does not exist or the payload cannot be serialized
+	TestWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID, req *models.TestWebhookRequest) (*models.TestWebhookResponse, error)
+
+	// SendNotification delivers an event raised by Loki Suite itself, such as an alert, to a subscription
+	// The event is signed and rendered like any delivery, but sent once without being stored or counted in
+	// delivery statistics
+	// Returns an error if the subscription does not exist or is inactive, or the delivery failed
+	SendNotification(ctx context.Context, webhookID uuid.UUID, event string, payload map[string]interface{}) error
+
+	// VerifySignature checks a signature and timestamp a receiver got against a subscription's secret
+	// The expected signature is never returned; hints point at common mistakes such as re-encoded bodies
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - req: Subscription, raw body, signature header value and optional timestamp header value
+	// Returns:
+	//   - VerifySignatureResponse: Whether signature and timestamp are valid, the problems found and hints
+	//   - error: If the subscription does not exist
+	VerifySignature(ctx context.Context, tenantID string, req *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error)
+
+	// GetWebhook retrieves a webhook subscription
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	// Returns:
+	//   - WebhookSubscription: The subscription, without its secret
+	//   - error: If the subscription does not exist or database query fails
+	GetWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error)
+
+	// GetWebhookHistory retrieves the versioned configuration history of a webhook subscription
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose snapshots alone are returned; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	// Returns:
+	//   - ConfigHistoryResponse: Every configuration version with its diff against the previous one
+	//   - error: If the caller's tenant has no history of the subscription or database query fails
+	GetWebhookHistory(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.ConfigHistoryResponse, error)
+
+	// DeleteWebhook soft deletes a webhook subscription, recording its last configuration in its history
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	// Returns:
+	//   - error: If the subscription does not exist or cannot be deleted
+	DeleteWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) error
+
+	// RestoreWebhook restores a soft deleted webhook subscription
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the deleted webhook subscription
+	// Returns:
+	//   - WebhookSubscription: The restored subscription
+	//   - error: If no deleted subscription has the ID
+	RestoreWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error)
+
+	// EnableWebhook activates a deactivated webhook subscription again, such as one disabled by its tenant's
+	// auto-disable policy, clearing why it was deactivated
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	// Returns:
+	//   - WebhookSubscription: The enabled subscription
+	//   - error: If the subscription does not exist or cannot be enabled
+	EnableWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error)
+
+	// RegisterEventType declares an event type in a tenant's event catalog
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - req: Tenant, event name, description and optional payload schema
+	// Returns:
+	//   - EventType: The registered event type
+	//   - error: If the name is already registered, the schema is invalid or database operation fails
+	RegisterEventType(ctx context.Context, req *models.RegisterEventTypeRequest) (*models.EventType, error)
+
+	// UpdateEventType replaces the definition of an event type
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - id: UUID of the event type
+	//   - req: New description, payload schema and validation switch
+	// Returns:
+	//   - EventType: The updated event type
+	//   - error: If the event type does not exist, the schema is invalid or database operation fails
+	UpdateEventType(ctx context.Context, id uuid.UUID, req *models.UpdateEventTypeRequest) (*models.EventType, error)
+
+	// DeleteEventType removes an event type from the catalog
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - id: UUID of the event type
+	// Returns:
+	//   - error: If the event type does not exist or database operation fails
+	DeleteEventType(ctx context.Context, id uuid.UUID) error
+
+	// ListEventTypes retrieves a tenant's event catalog with subscriber and chain counts
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	// Returns:
+	//   - EventTypeListResponse: Registered event types and the events used without one, ordered by name
+	//   - error: If database query fails
+	ListEventTypes(ctx context.Context, tenantID string) (*models.EventTypeListResponse, error)
+
+	// GetWebhookDeliveryStats aggregates the delivery attempts made to a webhook subscription
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	//   - window: How far back the statistics reach; also sets the bucket length
+	// Returns:
+	//   - DeliveryStatsResponse: Success rate, latency percentiles and failure breakdowns, overall and per time bucket
+	//   - error: If the webhook does not exist, the window is unknown or database query fails
+	GetWebhookDeliveryStats(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow) (*models.DeliveryStatsResponse, error)
+
+	// GetTenantDeliveryStats aggregates the delivery attempts made to all webhook subscriptions of a tenant
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	//   - window: How far back the statistics reach; also sets the bucket length
+	// Returns:
+	//   - DeliveryStatsResponse: Success rate, latency percentiles and failure breakdowns, overall and per time bucket
+	//   - error: If the window is unknown or database query fails
+	GetTenantDeliveryStats(ctx context.Context, tenantID string, window models.StatsWindow) (*models.DeliveryStatsResponse, error)
+
+	// GetWebhookLatencySLO reports how the deliveries to a webhook subscription met its tenant's latency SLO
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant of the caller, whose subscriptions alone are found; empty for global callers
+	//   - webhookID: UUID of the webhook subscription
+	//   - window: How far back the report reaches
+	// Returns:
+	//   - LatencySLOReport: Compliance and end-to-end latency percentiles of the successful deliveries
+	//   - error: If the webhook does not exist, the window is unknown or database query fails
+	GetWebhookLatencySLO(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow) (*models.LatencySLOReport, error)
+
+	// GetTenantLatencySLO reports how the deliveries to all webhook subscriptions of a tenant met its latency SLO
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository
+	//   - tenantID: Tenant identifier
+	//   - window: How far back the report reaches
+	// Returns:
+	//   - LatencySLOReport: Compliance and end-to-end latency percentiles, overall and per subscription
+	//   - error: If the window is unknown or database query fails
+	GetTenantLatencySLO(ctx context.Context, tenantID string, window models.StatsWindow) (*models.LatencySLOReport, error)
+
+	// ReleasePausedDeliveries sends the deliveries queued for subscriptions whose receiver-requested pause has ended
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
+	// Returns:
+	//   - int: Number of queued deliveries sent
+	//   - error: If the paused subscriptions cannot be loaded
+	ReleasePausedDeliveries(ctx context.Context) (int, error)
+
+	// RunPauseReleaser calls ReleasePausedDeliveries every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the releaser
+	//   - interval: Time between release passes
+	RunPauseReleaser(ctx context.Context, interval time.Duration)
+
+	// DisableFailingSubscriptions deactivates the subscriptions failing persistently under their tenant's
+	// auto-disable policy and notifies the policy's notification subscription
+	// Returns:
+	//   - int: Number of subscriptions disabled
+	//   - error: If the policies cannot be loaded
+	DisableFailingSubscriptions(ctx context.Context) (int, error)
+
+	// RunAutoDisabler calls DisableFailingSubscriptions every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the disabler
+	//   - interval: Time between passes
+	RunAutoDisabler(ctx context.Context, interval time.Duration)
+
+	// FlushDeliveryBatches sends the due batches of subscriptions delivering their events in batches
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
+	// Returns:
+	//   - int: Number of buffered deliveries sent
+	//   - error: If the subscriptions with due batches cannot be loaded
+	FlushDeliveryBatches(ctx context.Context) (int, error)
+
+	// RunBatchFlusher calls FlushDeliveryBatches every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the flusher
+	//   - interval: Time between flush passes
+	RunBatchFlusher(ctx context.Context, interval time.Duration)
+
+	// ReleaseOrderedDeliveries sends the queues of deliveries with an ordering key that nobody is sending,
+	// left by a receiver's pause or by an instance that went away
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
+	// Returns:
+	//   - int: Number of ordered deliveries sent
+	//   - error: If the stalled queues cannot be loaded
+	ReleaseOrderedDeliveries(ctx context.Context) (int, error)
+
+	// RunOrderedDeliveryReleaser calls ReleaseOrderedDeliveries every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the releaser
+	//   - interval: Time between release passes
+	RunOrderedDeliveryReleaser(ctx context.Context, interval time.Duration)
+
+	// DispatchScheduledEvents delivers the scheduled events whose delivery time has been reached
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
+	// Returns:
+	//   - int: Number of scheduled events delivered
+	//   - error: If the due events cannot be loaded
+	DispatchScheduledEvents(ctx context.Context) (int, error)
+
+	// RunEventScheduler calls DispatchScheduledEvents every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the scheduler
+	//   - interval: Time between dispatch passes
+	RunEventScheduler(ctx context.Context, interval time.Duration)
+
+	// RunEventQueue delivers the scheduled events the work queue hands out every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the consumer
+	//   - interval: Time between polls of the work queue
+	RunEventQueue(ctx context.Context, interval time.Duration)
+
+	// PauseDeliveries holds back the outbound deliveries of a tenant, or of every tenant, for a maintenance window
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines
+	//   - tenantID: Tenant whose deliveries are paused, empty for every tenant
+	//   - req: Reason of the pause
+	// Returns:
+	//   - DeliveryPauseResponse: The pause with the number of events held so far
+	//   - error: If the tenant does not exist or the pause cannot be stored
+	PauseDeliveries(ctx context.Context, tenantID string, req *models.PauseDeliveriesRequest) (*models.DeliveryPauseResponse, error)
+
+	// ResumeDeliveries lifts a delivery pause; the events held during it are drained at the requested rate
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines
+	//   - tenantID: Tenant whose deliveries are resumed, empty for the global pause
+	//   - req: Drain rate of the held events
+	// Returns:
+	//   - DeliveryPauseResponse: The draining pause with the number of events held
+	//   - error: If the tenant does not exist, the drain rate is out of range or the pause cannot be stored
+	ResumeDeliveries(ctx context.Context, tenantID string, req *models.ResumeDeliveriesRequest) (*models.DeliveryPauseResponse, error)
+
+	// GetDeliveryPause reports whether the deliveries of a tenant, or of every tenant, are paused or draining
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines
+	//   - tenantID: Tenant whose pause is reported, empty for the global pause
+	// Returns:
+	//   - DeliveryPauseResponse: The pause with the number of events held
+	//   - error: If the tenant does not exist or the pause cannot be loaded
+	GetDeliveryPause(ctx context.Context, tenantID string) (*models.DeliveryPauseResponse, error)
+
+	// DrainHeldEvents delivers the events held by resumed delivery pauses at the pauses' drain rates
+	// Parameters:
+	//   - ctx: Context for request cancellation and deadlines, propagated to the repository and HTTP calls
+	//   - elapsed: Time since the previous pass, which with the drain rate bounds the events delivered
+	// Returns:
+	//   - int: Number of held events delivered
+	//   - error: If the delivery pauses cannot be loaded
+	DrainHeldEvents(ctx context.Context, elapsed time.Duration) (int, error)
+
+	// RunDeliveryDrain calls DrainHeldEvents every interval until ctx is cancelled
+	// Parameters:
+	//   - ctx: Context whose cancellation stops the drain
+	//   - interval: Time between drain passes
+	RunDeliveryDrain(ctx context.Context, interval time.Duration)
 
 	// SetChainService injects the execution chain service dependency
 	// This is used to avoid circular dependencies between webhook and chain services
 	// Parameters:
 	//   - chainService: The execution chain service instance for triggering workflows
 	SetChainService(chainService ExecutionChainService)
+
+	// SetKeyring makes private webhook JWTs signed with the keyring
+	// Parameters:
+	//   - keyring: The JWT keyring; without one the configured JWT secret is used
+	SetKeyring(keyring *Keyring)
+
+	// SetEventPublisher publishes the outcome of every delivered event to a message bus
+	// Parameters:
+	//   - publisher: The publisher, nil to stop publishing
+	SetEventPublisher(publisher EventPublisher)
+
+	// SetLocks coordinates the instances sharing the database, so each releases different paused subscriptions
+	// Parameters:
+	//   - locks: The locks shared by the instances; without them the instance assumes it runs alone
+	SetLocks(locks repository.LockRepository)
+
+	// SetLatencyMetrics exports the end-to-end latency of successful deliveries as Prometheus metrics
+	// Parameters:
+	//   - metrics: The metrics, nil to stop exporting
+	SetLatencyMetrics(metrics *LatencyMetrics)
+
+	// SetPriorityMetrics exports how events of each priority are served as Prometheus metrics
+	// Parameters:
+	//   - metrics: The metrics, nil to stop exporting
+	SetPriorityMetrics(metrics *PriorityMetrics)
+
+	// ConfigureDeliveryConcurrency bounds how many events are delivered at once, handing freed slots to waiting
+	// events highest priority first
+	// Parameters:
+	//   - maxEvents: Events delivered at once, 0 for no bound
+	//   - reservedHigh: Slots only high priority events are delivered in
+	ConfigureDeliveryConcurrency(maxEvents, reservedHigh int)
+
+	// SetWorkQueue hands scheduled events to a work queue the instances share instead of only polling the database
+	// Parameters:
+	//   - queue: The work queue; without one the database is polled
+	SetWorkQueue(queue WorkQueue)
+
+	// SetAMQPPublisher makes deliveries to AMQP targets possible
+	// Parameters:
+	//   - publisher: The connection to the AMQP broker; without one deliveries to AMQP targets fail
+	SetAMQPPublisher(publisher AMQPPublisher)
+
+	// SetEmailSender makes deliveries to email targets possible
+	// Parameters:
+	//   - sender: The SMTP server's sender; without one deliveries to email targets fail
+	SetEmailSender(sender EmailSender)
+
+	// SetSMSSender makes deliveries to SMS targets possible
+	// Parameters:
+	//   - sender: The SMS provider's sender; without one deliveries to SMS targets fail
+	SetSMSSender(sender SMSSender)
+
+	// SetPayloadOffload stores the bodies of HTTP deliveries larger than a threshold in object storage and
+	// delivers a reference with a presigned URL to them instead
+	// Parameters:
+	//   - store: The object storage; without one every body is delivered inline
+	//   - threshold: Largest body in bytes delivered inline, DefaultPayloadOffloadThreshold if 0
+	//   - urlTTL: How long the presigned URLs stay valid, DefaultPayloadURLTTL if 0
+	SetPayloadOffload(store PayloadStore, threshold int, urlTTL time.Duration)
+
+	// Transports returns the registry of delivery transports by target type
+	// Registering a transport makes subscriptions with its target type possible; the registry can be shared
+	// with the execution chain service so chain steps call such subscriptions too
+	Transports() *TransportRegistry
+
+	// ConfigureNetwork sets the published egress addresses and the installation-wide receive allowlist
+	// Parameters:
+	//   - egressIPs: IPs and CIDR ranges deliveries are sent from, published to receivers
+	//   - receiveAllowlist: IPs and CIDR ranges allowed to post to receive endpoints, empty for any
+	// Returns:
+	//   - error: If an entry is not an IP or CIDR range
+	ConfigureNetwork(egressIPs, receiveAllowlist []string) error
+
+	// SetClock replaces the clock used for timestamps and retry delays
+	// Parameters:
+	//   - clock: Clock instance, typically a fake in tests
+	SetClock(clock Clock)
 }
 
 // webhookService implements WebhookService
 type webhookService struct {
 	repo         repository.WebhookRepository
+	tenantRepo   repository.TenantRepository
+	historyRepo  repository.ConfigHistoryRepository
 	securitySvc  *security.SecurityService
 	config       *config.Config
-	httpClient   *http.Client
 	chainService ExecutionChainService
+	keyring      *Keyring
+	publisher    EventPublisher
+	locks        repository.LockRepository
+	queue        WorkQueue
+	clock        Clock
+	logger       logging.Logger
+
+	// latencyMetrics is nil when end-to-end latencies are not exported
+	latencyMetrics *LatencyMetrics
+
+	// priorityMetrics is nil when priorities are not exported; deliverySlots is nil when deliveries are unbounded
+	priorityMetrics *PriorityMetrics
+	deliverySlots   *deliverySlots
+
+	// env holds the clients and settings the built-in transports deliver with; the setters update it
+	env        *transportEnv
+	transports *TransportRegistry
+
+	// egressIPs are published to receivers; receiveAllowlist is nil when every source may post
+	egressIPs        []string
+	receiveAllowlist []*net.IPNet
 }
 
 // NewWebhookService creates a new webhook service instance with required dependencies
 // This constructor initializes the service with repository, security service, and configuration
 // Parameters:
 //   - repo: WebhookRepository for database operations (subscriptions, events)
+//   - tenantRepo: TenantRepository for tenant-wide settings such as signing header names
+//   - historyRepo: ConfigHistoryRepository for recording configuration snapshots of subscriptions
 //   - securitySvc: SecurityService for generating tokens, signatures, and verification
 //   - cfg: Application configuration containing webhook and security settings
+//   - logger: Logger for delivery and subscription events
 //
 // Returns:
 //   - WebhookService: Configured service instance ready for use
@@ -98,17 +593,31 @@ type webhookService struct {
 // Note: The execution chain service is set separately via SetChainService to avoid circular dependencies
 func NewWebhookService(
 	repo repository.WebhookRepository,
+	tenantRepo repository.TenantRepository,
+	historyRepo repository.ConfigHistoryRepository,
 	securitySvc *security.SecurityService,
 	cfg *config.Config,
+	logger logging.Logger,
 ) WebhookService {
-	return &webhookService{
-		repo:        repo,
-		securitySvc: securitySvc,
-		config:      cfg,
+	env := &transportEnv{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second, // Default timeout
 		},
+		security:   securitySvc,
+		tenantRepo: tenantRepo,
+		clock:      NewSystemClock(),
+	}
+	return &webhookService{
+		repo:         repo,
+		tenantRepo:   tenantRepo,
+		historyRepo:  historyRepo,
+		securitySvc:  securitySvc,
+		config:       cfg,
 		chainService: nil, // Will be set via SetChainService
+		clock:        env.clock,
+		logger:       logger,
+		env:          env,
+		transports:   newTransportRegistry(env),
 	}
 }
 
@@ -122,9 +631,80 @@ func (s *webhookService) SetChainService(chainService ExecutionChainService) {
 	s.chainService = chainService
 }
 
+// SetEventPublisher publishes the outcome of every delivered event to a message bus
+// Parameters:
+//   - publisher: The publisher, nil to stop publishing
+//
+// Purpose: Lets internal services react to deliveries without polling the API
+func (s *webhookService) SetEventPublisher(publisher EventPublisher) {
+	s.publisher = publisher
+}
+
+// SetLocks coordinates the instances sharing the database
+// Parameters:
+//   - locks: The locks shared by the instances; without them the instance assumes it runs alone
+//
+// Purpose: Lets several replicas release paused deliveries without sending any of them twice
+func (s *webhookService) SetLocks(locks repository.LockRepository) {
+	s.locks = locks
+}
+
+// SetWorkQueue hands scheduled events to a work queue the instances share
+// Parameters:
+//   - queue: The work queue; without one the database is polled
+//
+// Purpose: Lets RunEventQueue deliver scheduled events on time while the database is swept rarely
+func (s *webhookService) SetWorkQueue(queue WorkQueue) {
+	s.queue = queue
+}
+
+// SetAMQPPublisher makes deliveries to AMQP targets possible
+// Parameters:
+//   - publisher: The connection to the AMQP broker; without one deliveries to AMQP targets fail
+//
+// Purpose: Lets internal consumers receive events from a queue instead of an HTTP endpoint
+func (s *webhookService) SetAMQPPublisher(publisher AMQPPublisher) {
+	s.env.amqp = publisher
+}
+
+// SetEmailSender makes deliveries to email targets possible
+// Parameters:
+//   - sender: The SMTP server's sender; without one deliveries to email targets fail
+//
+// Purpose: Lets alert-type events reach people by email
+func (s *webhookService) SetEmailSender(sender EmailSender) {
+	s.env.email = sender
+}
+
+// SetSMSSender makes deliveries to SMS targets possible
+// Parameters:
+//   - sender: The SMS provider's sender; without one deliveries to SMS targets fail
+//
+// Purpose: Lets alert-type events reach people by text message
+func (s *webhookService) SetSMSSender(sender SMSSender) {
+	s.env.sms = sender
+}
+
+// Transports returns the registry of delivery transports by target type
+// Purpose: Lets new delivery channels be added without changing the service
+func (s *webhookService) Transports() *TransportRegistry {
+	return s.transports
+}
+
+// SetClock replaces the clock used for timestamps and retry delays
+// Parameters:
+//   - clock: Clock instance; the system clock is used unless replaced
+//
+// Purpose: Lets tests control time so retry behaviour can be verified without real delays
+func (s *webhookService) SetClock(clock Clock) {
+	s.clock = clock
+	s.env.clock = clock
+}
+
 // GenerateWebhook creates a new webhook subscription and generates a unique webhook URL
 // This method handles the complete webhook creation flow including security credential generation
 // Parameters:
+//   - ctx: Context for request cancellation and deadlines
 //   - req: GenerateWebhookRequest containing tenant ID, app name, subscribed event, and webhook type
 //
 // Returns:
@@ -136,10 +716,14 @@ func (s *webhookService) SetChainService(chainService ExecutionChainService) {
 //  2. Generates unique webhook ID and security credentials
 //  3. Creates subscription record in database
 //  4. Returns webhook URL and security information
-func (s *webhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error) {
+func (s *webhookService) GenerateWebhook(ctx context.Context, req *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error) {
 	// Validate webhook type
 	if req.Type != models.WebhookTypePublic && req.Type != models.WebhookTypePrivate {
-		return nil, fmt.Errorf("invalid webhook type: %s", req.Type)
+		return nil, ErrInvalidWebhook.Withf("invalid webhook type: %s", req.Type)
+	}
+	tenant, err := activeTenant(ctx, s.tenantRepo, req.TenantID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate webhook ID
@@ -147,13 +731,13 @@ func (s *webhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*m
 
 	// Generate security credentials
 	isPrivate := req.Type == models.WebhookTypePrivate
-	securityData, err := s.securitySvc.GenerateWebhookSecurity(isPrivate, req.TenantID, webhookID.String(), req.AppName)
+	securityData, err := s.generateWebhookSecurity(ctx, isPrivate, req.TenantID, webhookID.String(), req.AppName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate security credentials: %w", err)
 	}
 
 	// Create webhook URL
-	webhookURL := fmt.Sprintf("http://localhost:8080/api/webhooks/receive/%s", webhookID.String())
+	webhookURL := fmt.Sprintf("http://localhost:8080/api/v1/webhooks/receive/%s", webhookID.String())
 
 	// Create subscription
 	subscription := &models.WebhookSubscription{
@@ -176,22 +760,31 @@ func (s *webhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*m
 		}
 	}
 
-	// Set retry policy if provided
-	if req.RetryPolicy != nil {
-		subscription.MaxRetries = req.RetryPolicy.MaxRetries
-		subscription.RetryDelaySeconds = req.RetryPolicy.RetryDelaySeconds
+	// Set retry policy if provided, else the tenant's default
+	if policy := subscriptionRetryPolicy(tenant, req.RetryPolicy); policy != nil {
+		subscription.MaxRetries = policy.MaxRetries
+		subscription.RetryDelaySeconds = policy.RetryDelaySeconds
+	}
+
+	// Set source IP allowlist if provided
+	if len(req.AllowedSourceIPs) > 0 {
+		if _, err := parseIPAllowlist(req.AllowedSourceIPs); err != nil {
+			return nil, ErrInvalidWebhook.Withf("invalid allowed source IPs: %v", err)
+		}
+		subscription.AllowedSourceIPs = req.AllowedSourceIPs
 	}
 
 	// Save to database
-	if err := s.repo.CreateSubscription(subscription); err != nil {
-		logger.Error("Failed to create webhook subscription",
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		s.logger.Error(ctx, "Failed to create webhook subscription",
 			zap.Error(err),
 			zap.String("tenant_id", req.TenantID),
 			zap.String("app_name", req.AppName))
 		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
 	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeCreated, subscription)
 
-	logger.Info("Webhook subscription created",
+	s.logger.Info(ctx, "Webhook subscription created",
 		zap.String("webhook_id", webhookID.String()),
 		zap.String("tenant_id", req.TenantID),
 		zap.String("app_name", req.AppName),
@@ -205,12 +798,13 @@ func (s *webhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*m
 		WebhookID:   webhookID,
 		Payload:     req.Payload,
 		QueryParams: req.QueryParams,
-		RetryPolicy: req.RetryPolicy,
+		RetryPolicy: subscriptionRetryPolicy(tenant, req.RetryPolicy),
 	}
 
 	if securityData.JWTToken != nil {
 		response.JWTToken = securityData.JWTToken
 	}
+	response.AllowedSourceIPs = subscription.AllowedSourceIPs
 
 	return response, nil
 }
@@ -218,6 +812,7 @@ func (s *webhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*m
 // SubscribeWebhook creates a webhook subscription with a custom target URL
 // This method allows external services to register their own endpoints for webhook delivery
 // Parameters:
+//   - ctx: Context for request cancellation and deadlines
 //   - req: SubscribeWebhookRequest containing target URL, tenant ID, app name, event filter, and type
 //
 // Returns:
@@ -231,10 +826,61 @@ func (s *webhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*m
 //  4. Returns security information for the subscriber
 //
 // Use case: When external services want to receive webhooks at their own endpoints
-func (s *webhookService) SubscribeWebhook(req *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error) {
+func (s *webhookService) SubscribeWebhook(ctx context.Context, req *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error) {
+	tenant, err := activeTenant(ctx, s.tenantRepo, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.newSubscription(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save to database
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		s.logger.Error(ctx, "Failed to create webhook subscription",
+			zap.Error(err),
+			zap.String("tenant_id", req.TenantID),
+			zap.String("app_name", req.AppName))
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeCreated, subscription)
+
+	s.logger.Info(ctx, "Manual webhook subscription created",
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.String("tenant_id", req.TenantID),
+		zap.String("target_url", subscription.TargetURL))
+
+	// Prepare response
+	response := &models.GenerateWebhookResponse{
+		WebhookURL:  subscription.TargetURL,
+		SecretToken: subscription.SecretToken,
+		Type:        req.Type,
+		WebhookID:   subscription.ID,
+		QueryParams: req.QueryParams,
+		RetryPolicy: subscriptionRetryPolicy(tenant, req.RetryPolicy),
+
+		SignatureScheme: subscription.SignatureScheme,
+		TargetType:      subscription.TargetType,
+	}
+
+	if subscription.JWTToken != nil {
+		response.JWTToken = subscription.JWTToken
+	}
+	if subscription.SignatureScheme == models.SignatureSchemeStandardWebhooks {
+		response.StandardSecret = client.StandardSecret(subscription.SecretToken)
+	}
+	response.AllowedSourceIPs = subscription.AllowedSourceIPs
+
+	return response, nil
+}
+
+// newSubscription validates a subscription request and builds the subscription it creates, with a new ID
+// and newly generated security credentials; the subscription is not saved
+func (s *webhookService) newSubscription(ctx context.Context, tenant *models.Tenant, req *models.SubscribeWebhookRequest) (*models.WebhookSubscription, error) {
 	// Validate webhook type
 	if req.Type != models.WebhookTypePublic && req.Type != models.WebhookTypePrivate {
-		return nil, fmt.Errorf("invalid webhook type: %s", req.Type)
+		return nil, ErrInvalidWebhook.Withf("invalid webhook type: %s", req.Type)
 	}
 
 	// Generate webhook ID
@@ -242,7 +888,7 @@ func (s *webhookService) SubscribeWebhook(req *models.SubscribeWebhookRequest) (
 
 	// Generate security credentials
 	isPrivate := req.Type == models.WebhookTypePrivate
-	securityData, err := s.securitySvc.GenerateWebhookSecurity(isPrivate, req.TenantID, webhookID.String(), req.AppName)
+	securityData, err := s.generateWebhookSecurity(ctx, isPrivate, req.TenantID, webhookID.String(), req.AppName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate security credentials: %w", err)
 	}
@@ -253,6 +899,7 @@ func (s *webhookService) SubscribeWebhook(req *models.SubscribeWebhookRequest) (
 		TenantID:        req.TenantID,
 		AppName:         req.AppName,
 		TargetURL:       req.TargetURL,
+		TargetType:      models.TargetTypeHTTP,
 		SubscribedEvent: req.SubscribedEvent,
 		Type:            req.Type,
 		SecretToken:     securityData.SecretToken,
@@ -272,46 +919,90 @@ func (s *webhookService) SubscribeWebhook(req *models.SubscribeWebhookRequest) (
 		subscription.IsActive = *req.IsActive
 	}
 
-	// Set retry policy if provided
-	if req.RetryPolicy != nil {
-		subscription.MaxRetries = req.RetryPolicy.MaxRetries
-		subscription.RetryDelaySeconds = req.RetryPolicy.RetryDelaySeconds
+	// Set retry policy if provided, else the tenant's default
+	if policy := subscriptionRetryPolicy(tenant, req.RetryPolicy); policy != nil {
+		subscription.MaxRetries = policy.MaxRetries
+		subscription.RetryDelaySeconds = policy.RetryDelaySeconds
 	}
 
-	// Save to database
-	if err := s.repo.CreateSubscription(subscription); err != nil {
-		logger.Error("Failed to create webhook subscription",
-			zap.Error(err),
-			zap.String("tenant_id", req.TenantID),
-			zap.String("app_name", req.AppName))
-		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	// Set response schema if provided
+	if req.ResponseSchema != nil {
+		schema, err := compileResponseSchema(req.ResponseSchema)
+		if err != nil {
+			return nil, ErrInvalidWebhook.Withf("invalid response schema: %v", err)
+		}
+		subscription.ResponseSchema = &schema
 	}
 
-	logger.Info("Manual webhook subscription created",
-		zap.String("webhook_id", webhookID.String()),
-		zap.String("tenant_id", req.TenantID),
-		zap.String("target_url", req.TargetURL))
+	// Set target type if provided; its transport validates and stores the target's settings
+	transport, ok := s.transports.Lookup(req.TargetType)
+	if !ok {
+		return nil, ErrInvalidWebhook.Withf("invalid target type: %s", req.TargetType)
+	}
+	if req.TargetType != "" {
+		subscription.TargetType = req.TargetType
+	}
+	if err := transport.Validate(req, subscription); err != nil {
+		return nil, invalid(ErrInvalidWebhook, err)
+	}
 
-	// Prepare response
-	response := &models.GenerateWebhookResponse{
-		WebhookURL:  req.TargetURL,
-		SecretToken: securityData.SecretToken,
-		Type:        req.Type,
-		WebhookID:   webhookID,
-		QueryParams: req.QueryParams,
-		RetryPolicy: req.RetryPolicy,
+	// Set batching if requested
+	if req.Batching != nil {
+		if err := validateBatchPolicy(*req.Batching, subscription.TargetType); err != nil {
+			return nil, ErrInvalidWebhook.Withf("invalid batching: %v", err)
+		}
+		subscription.BatchMaxEvents = req.Batching.MaxEvents
+		subscription.BatchWindowSeconds = req.Batching.WindowSeconds
 	}
 
-	if securityData.JWTToken != nil {
-		response.JWTToken = securityData.JWTToken
+	// Set compression if requested
+	if req.ContentEncoding != "" {
+		if !req.ContentEncoding.IsValid() {
+			return nil, ErrInvalidWebhook.Withf("invalid content encoding: %s", req.ContentEncoding)
+		}
+		if subscription.TargetType != models.TargetTypeHTTP {
+			return nil, ErrInvalidWebhook.Withf("invalid content encoding: only http targets can be compressed")
+		}
+		subscription.ContentEncoding = req.ContentEncoding
 	}
 
-	return response, nil
+	// Set signature scheme if provided
+	if !req.SignatureScheme.IsValid() {
+		return nil, ErrInvalidWebhook.Withf("invalid signature scheme: %s", req.SignatureScheme)
+	}
+	subscription.SignatureScheme = req.SignatureScheme
+	if subscription.SignatureScheme == "" {
+		subscription.SignatureScheme = models.SignatureSchemeLoki
+	}
+
+	// Set signing header names if provided
+	if req.SigningHeaders != nil {
+		settings, err := s.tenantRepo.GetTenantSettings(ctx, req.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+		}
+		headers, err := normalizeSigningHeaders(*req.SigningHeaders, settings.SigningHeaders)
+		if err != nil {
+			return nil, ErrInvalidWebhook.Withf("invalid signing headers: %v", err)
+		}
+		subscription.SigningHeaders = headers
+	}
+
+	// Set source IP allowlist if provided
+	if len(req.AllowedSourceIPs) > 0 {
+		if _, err := parseIPAllowlist(req.AllowedSourceIPs); err != nil {
+			return nil, ErrInvalidWebhook.Withf("invalid allowed source IPs: %v", err)
+		}
+		subscription.AllowedSourceIPs = req.AllowedSourceIPs
+	}
+
+	return subscription, nil
 }
 
 // SendEvent broadcasts an event to all matching webhook subscriptions and triggers execution chains
 // This is the core event delivery method that handles the complete webhook notification flow
 // Parameters:
+//   - ctx: Context for request cancellation and deadlines
 //   - req: SendEventRequest containing tenant ID, event name, source, and payload data
 //
 // Returns:
@@ -319,32 +1010,50 @@ func (s *webhookService) SubscribeWebhook(req *models.SubscribeWebhookRequest) (
 //   - error: If event creation fails or critical processing errors occur
 //
 // Process:
-//  1. Finds all active subscriptions matching tenant and event
-//  2. Creates event record in database for tracking
-//  3. Delivers webhook to each subscription with proper security headers
-//  4. Updates event status based on delivery results
-//  5. Triggers any execution chains configured for this event
+//  1. Rejects payloads violating the schema of the event's type when the type validates payloads
+//     or the tenant enforces strict validation, returning every violation in a *PayloadValidationError
+//  2. Stores events with a future delivery time as scheduled and returns, see DispatchScheduledEvents, and
+//     events of tenants whose deliveries are paused as held, see PauseDeliveries
+//  3. Finds all active subscriptions matching tenant and event and creates the event record for tracking
+//  4. Delivers webhook to each subscription with proper security headers
+//  5. Updates event status based on delivery results
+//  6. Triggers any execution chains configured for this event
 //
 // Note: Chain execution failures don't fail the entire operation
-func (s *webhookService) SendEvent(req *models.SendEventRequest) (*models.EventProcessingResult, error) {
-	// Find matching subscriptions
-	subscriptions, err := s.repo.GetActiveSubscriptionsByTenantAndEvent(req.TenantID, req.Event)
+func (s *webhookService) SendEvent(ctx context.Context, req *models.SendEventRequest) (*models.EventProcessingResult, error) {
+	tenant, err := activeTenant(ctx, s.tenantRepo, req.TenantID)
 	if err != nil {
-		logger.Error("Failed to find webhook subscriptions",
-			zap.Error(err),
-			zap.String("tenant_id", req.TenantID),
-			zap.String("event", req.Event))
-		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+		return nil, err
+	}
+	deliverAt, err := s.eventDeliveryTime(req)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := s.eventExpiry(req, deliverAt)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Priority.IsValid() {
+		return nil, ErrInvalidEvent.Withf("unknown priority %q, use high, normal or low", req.Priority)
 	}
+	if err := s.validateEventPayload(ctx, req); err != nil {
+		return nil, err
+	}
+	pauses, err := s.loadDeliveryPauses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	held := deliverAt == nil && pauses.holds(req.TenantID)
 
 	// Create event record
 	eventID := uuid.New()
 	webhookPayload := &models.WebhookPayload{
-		Event:     req.Event,
-		Source:    req.Source,
-		Timestamp: time.Now().Format(time.RFC3339),
-		Payload:   req.Payload,
-		EventID:   eventID,
+		Event:       req.Event,
+		Source:      req.Source,
+		Timestamp:   s.clock.Now().Format(time.RFC3339),
+		Payload:     req.Payload,
+		EventID:     eventID,
+		OrderingKey: req.OrderingKey,
 	}
 
 	payloadBytes, err := json.Marshal(webhookPayload)
@@ -352,20 +1061,88 @@ func (s *webhookService) SendEvent(req *models.SendEventRequest) (*models.EventP
 		return nil, fmt.Errorf("failed to serialize webhook payload: %w", err)
 	}
 
+	// The stored copy has the tenant's sensitive fields protected; scheduled and held events are delivered from it
 	event := &models.WebhookEvent{
-		ID:        eventID,
-		TenantID:  req.TenantID,
-		EventName: req.Event,
-		Source:    req.Source,
-		Payload:   string(payloadBytes),
-		Status:    models.WebhookStatusPending,
+		ID:          eventID,
+		TenantID:    req.TenantID,
+		EventName:   req.Event,
+		Source:      req.Source,
+		Payload:     newPayloadProtection(s.logger, s.tenantRepo, tenant.Settings).protect(ctx, string(payloadBytes), deliverAt != nil || held, "payload"),
+		Status:      models.WebhookStatusPending,
+		ExpiresAt:   expiresAt,
+		OrderingKey: req.OrderingKey,
+		Priority:    req.Priority.Level(),
+		CreatedAt:   s.clock.Now(),
+	}
+
+	// Scheduled and held events count their deliveries when they are delivered, so only a used up quota
+	// rejects them
+	if deliverAt != nil || held {
+		if err := checkQuota(ctx, s.tenantRepo, tenant, s.clock.Now(), 0); err != nil {
+			return nil, err
+		}
+		var result *models.EventProcessingResult
+		if held {
+			result, err = s.holdEvent(ctx, event)
+		} else {
+			result, err = s.scheduleEvent(ctx, event, *deliverAt)
+		}
+		if err == nil {
+			recordUsage(ctx, s.logger, s.tenantRepo, req.TenantID, s.clock.Now(), 1, 0)
+		}
+		return result, err
 	}
 
-	if err := s.repo.CreateEvent(event); err != nil {
-		logger.Error("Failed to create webhook event",
+	// Find matching subscriptions
+	subscriptions, err := s.repo.GetActiveSubscriptionsByTenantAndEvent(ctx, req.TenantID, req.Event)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find webhook subscriptions",
+			zap.Error(err),
+			zap.String("tenant_id", req.TenantID),
+			zap.String("event", req.Event))
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+	if err := checkQuota(ctx, s.tenantRepo, tenant, s.clock.Now(), len(subscriptions)); err != nil {
+		return nil, err
+	}
+
+	// With bounded deliveries the event waits for a slot, behind events of a higher priority
+	release, err := s.acquireDeliverySlot(ctx, event.Priority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for a delivery slot: %w", err)
+	}
+	defer release()
+
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to create webhook event",
 			zap.Error(err),
 			zap.String("event_id", eventID.String()))
 	}
+	recordUsage(ctx, s.logger, s.tenantRepo, req.TenantID, s.clock.Now(), 1, 0)
+
+	return s.deliverEvent(ctx, event, subscriptions, webhookPayload, payloadBytes, req.Payload), nil
+}
+
+// deliverEvent delivers an event to its subscriptions, records the outcome and triggers the event's chains
+// Shared by events sent for immediate delivery and scheduled events reaching their delivery time
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - event: Stored event record, updated with the delivery outcome
+//   - subscriptions: Active subscriptions matching the event
+//   - webhookPayload: Envelope delivered to the subscriptions
+//   - payloadBytes: JSON-encoded envelope, used when a subscription-specific payload cannot be built
+//   - payload: Event data passed to triggered chains
+//
+// Returns:
+//   - EventProcessingResult: Summary containing event ID, delivery results, and success/failure counts
+func (s *webhookService) deliverEvent(ctx context.Context, event *models.WebhookEvent, subscriptions []models.WebhookSubscription, webhookPayload *models.WebhookPayload, payloadBytes []byte, payload interface{}) *models.EventProcessingResult {
+	eventID := event.ID
+
+	// End-to-end latency is measured from when the event was accepted, or became due if it was scheduled
+	acceptedAt := event.CreatedAt
+	if event.DeliverAt != nil {
+		acceptedAt = *event.DeliverAt
+	}
 
 	// Send webhooks
 	result := &models.EventProcessingResult{
@@ -373,6 +1150,13 @@ func (s *webhookService) SendEvent(req *models.SendEventRequest) (*models.EventP
 		Webhooks: make([]models.WebhookDeliveryResult, len(subscriptions)),
 	}
 
+	// Resolve the tenant's signing header names once for all deliveries
+	var tenantHeaders models.SigningHeaders
+	if len(subscriptions) > 0 {
+		tenantHeaders = tenantSigningHeaders(ctx, s.logger, s.tenantRepo, event.TenantID)
+	}
+
+	var ordered []orderedSend
 	for i, subscription := range subscriptions {
 		// Create subscription-specific payload by merging event payload with subscription payload
 		finalPayload := webhookPayload
@@ -402,25 +1186,44 @@ func (s *webhookService) SendEvent(req *models.SendEventRequest) (*models.EventP
 		if err != nil {
 			// Fall back to original payload if serialization fails
 			subscriptionPayloadBytes = payloadBytes
-			logger.Warn("Failed to serialize subscription-specific payload, using default",
+			s.logger.Warn(ctx, "Failed to serialize subscription-specific payload, using default",
 				zap.String("subscription_id", subscription.ID.String()),
 				zap.Error(err))
 		}
 
-		deliveryResult := s.sendWebhookToSubscription(subscription, subscriptionPayloadBytes)
+		headers := subscription.SigningHeaders.Or(tenantHeaders)
+
+		// Deliveries in order are queued and sent once the event is stored; batches keep their order already
+		if event.OrderingKey != "" && subscription.BatchWindowSeconds == 0 {
+			result.Webhooks[i] = models.WebhookDeliveryResult{
+				WebhookID:   subscription.ID,
+				TargetURL:   subscription.TargetURL,
+				Queued:      true,
+				PausedUntil: subscription.PausedUntil,
+			}
+			result.TotalQueued++
+			ordered = append(ordered, orderedSend{index: i, subscription: subscription, headers: headers, payload: subscriptionPayloadBytes})
+			continue
+		}
+		deliveryResult := s.deliverOrQueue(ctx, subscription, headers, eventID, event.ExpiresAt, subscriptionPayloadBytes, acceptedAt)
 		result.Webhooks[i] = deliveryResult
 
-		if deliveryResult.Success {
+		switch {
+		case deliveryResult.Success:
 			result.TotalSent++
-		} else {
+		case deliveryResult.Queued:
+			result.TotalQueued++
+		case deliveryResult.Expired:
+			result.TotalExpired++
+		default:
 			result.TotalFailed++
 		}
 	}
 
-	// Update event status
-	if result.TotalSent > 0 && result.TotalFailed == 0 {
+	// Update event status; events with queued, batched or ordered deliveries stay pending until they are sent
+	if result.TotalSent > 0 && result.TotalFailed == 0 && result.TotalQueued == 0 && result.TotalExpired == 0 {
 		event.Status = models.WebhookStatusSent
-		now := time.Now()
+		now := s.clock.Now()
 		event.SentAt = &now
 	} else if result.TotalFailed > 0 {
 		event.Status = models.WebhookStatusFailed
@@ -428,90 +1231,116 @@ func (s *webhookService) SendEvent(req *models.SendEventRequest) (*models.EventP
 			errMsg := fmt.Sprintf("all %d webhook deliveries failed", result.TotalFailed)
 			event.LastError = &errMsg
 		}
+	} else if result.TotalExpired > 0 {
+		event.Status = models.WebhookStatusExpired
+		errMsg := fmt.Sprintf("expired before %d of %d webhook deliveries were made", result.TotalExpired, len(subscriptions))
+		event.LastError = &errMsg
 	}
 
+	// Record the outcome even if the caller went away during delivery
 	event.Attempts = 1
-	s.repo.UpdateEvent(event)
+	s.repo.UpdateEvent(context.WithoutCancel(ctx), event)
+	s.priorityMetrics.countEvent(models.PriorityOf(event.Priority), event.Status)
+	if len(ordered) > 0 {
+		s.sendInOrder(ctx, event, ordered, result)
+	}
+	recordUsage(ctx, s.logger, s.tenantRepo, event.TenantID, s.clock.Now(), 0, int64(len(subscriptions)))
+	if s.publisher != nil {
+		s.publisher.PublishDeliveryResult(ctx, event, result)
+	}
 
-	logger.Info("Webhook event processed",
+	s.logger.Info(ctx, "Webhook event processed",
 		zap.String("event_id", eventID.String()),
-		zap.String("tenant_id", req.TenantID),
-		zap.String("event", req.Event),
+		zap.String("tenant_id", event.TenantID),
+		zap.String("event", event.EventName),
 		zap.Int("total_sent", result.TotalSent),
-		zap.Int("total_failed", result.TotalFailed))
-
-	// Execute chains triggered by this event
-	if s.chainService != nil {
-		ctx := context.Background()
-
+		zap.Int("total_failed", result.TotalFailed),
+		zap.Int("total_queued", result.TotalQueued),
+		zap.Int("total_expired", result.TotalExpired))
+
+	// Execute chains triggered by this event, unless it expired while it was being delivered
+	if s.chainService != nil && eventExpired(event.ExpiresAt, s.clock.Now()) {
+		s.logger.Info(ctx, "Chains of expired event skipped",
+			zap.String("event_id", eventID.String()),
+			zap.String("event", event.EventName),
+			zap.Timep("expires_at", event.ExpiresAt))
+	} else if s.chainService != nil {
 		// Convert payload to map[string]interface{}
 		var eventData map[string]interface{}
-		if req.Payload != nil {
-			if payloadMap, ok := req.Payload.(map[string]interface{}); ok {
+		if payload != nil {
+			if payloadMap, ok := payload.(map[string]interface{}); ok {
 				eventData = payloadMap
 			} else {
 				// Try to convert via JSON marshal/unmarshal
-				if payloadBytes, err := json.Marshal(req.Payload); err == nil {
+				if payloadBytes, err := json.Marshal(payload); err == nil {
 					json.Unmarshal(payloadBytes, &eventData)
 				}
 			}
 		}
 
-		if err := s.chainService.ExecuteChainByEvent(ctx, req.TenantID, req.Event, eventData); err != nil {
-			logger.Error("Failed to execute chains for event",
-				zap.String("event", req.Event),
-				zap.String("tenant_id", req.TenantID),
+		// Triggered runs inherit the event's priority
+		chainCtx := withEventPriority(ctx, models.PriorityOf(event.Priority))
+		if err := s.chainService.ExecuteChainByEvent(chainCtx, event.TenantID, event.EventName, eventData); err != nil {
+			s.logger.Error(ctx, "Failed to execute chains for event",
+				zap.String("event", event.EventName),
+				zap.String("tenant_id", event.TenantID),
 				zap.Error(err))
 			// Don't fail the entire operation if chain execution fails
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // sendWebhookToSubscription delivers a webhook payload to a single subscription endpoint
-// This is an internal helper method that handles the HTTP delivery and security headers
-// Implements retry logic based on the subscription's retry policy configuration
+// This is an internal helper method that delivers through the transport of the subscription's target
+// type and implements retry logic based on the subscription's retry policy configuration
 // Parameters:
-//   - subscription: WebhookSubscription containing target URL and security credentials
+//   - ctx: Context for request cancellation and deadlines
+//   - subscription: WebhookSubscription containing target settings and security credentials
+//   - headers: Resolved names of the signature, timestamp and attempt headers
+//   - eventID: ID of the event being delivered, recorded with each attempt
+//   - expiresAt: When the event expires, nil if it does not
 //   - payload: JSON-encoded webhook payload to be delivered
+//   - acceptedAt: When the event was accepted, from which the end-to-end latency is measured
 //
 // Returns:
-//   - WebhookDeliveryResult: Contains delivery status, response code, error details, and attempt count
+//   - WebhookDeliveryResult: Contains delivery status, response code, error details, and attempt count; Expired
+//     is set when the event expired before the delivery succeeded
+//   - time.Duration: Pause the receiver requested through the pause header, 0 if none
 //
 // Process:
-//  1. Creates HTTP POST request to target URL with query parameters
-//  2. Adds security headers (Content-Type, User-Agent, HMAC signature, timestamp)
-//  3. Adds custom headers from subscription configuration
-//  4. Adds JWT authorization for private webhooks
-//  5. Attempts delivery with retry logic based on subscription policy
-//  6. Logs delivery success/failure with details
-//
-// Security: Includes HMAC signature verification and JWT tokens for private webhooks
-func (s *webhookService) sendWebhookToSubscription(subscription models.WebhookSubscription, payload []byte) models.WebhookDeliveryResult {
+//  1. Looks up the transport registered for the subscription's target type
+//  2. Attempts delivery with retry logic based on subscription policy, stopping on permanent failures,
+//     when the receiver requests a pause and when the event expires
+//  3. Checks the receiver's answer against the subscription's response schema, if it has one
+//  4. Logs delivery success/failure with details and records every attempt for delivery statistics
+func (s *webhookService) sendWebhookToSubscription(ctx context.Context, subscription models.WebhookSubscription, headers models.SigningHeaders, eventID uuid.UUID, expiresAt *time.Time, payload []byte, acceptedAt time.Time) (models.WebhookDeliveryResult, time.Duration) {
+	delivery := &Delivery{Subscription: subscription, Headers: headers, MessageID: eventID.String(), Body: payload, AcceptedAt: acceptedAt}
+	if expiresAt != nil {
+		delivery.ExpiresAt = *expiresAt
+	}
+	result, pause, _ := s.sendDelivery(ctx, delivery, eventID)
+	return result, pause
+}
+
+// sendDelivery delivers a body to its subscription with the subscription's retry policy, see
+// sendWebhookToSubscription; attempts are recorded under recordID, the event or batch delivered
+// Returns the delivery's outcome, the pause the receiver requested and the receiver's answer to the
+// successful attempt
+func (s *webhookService) sendDelivery(ctx context.Context, delivery *Delivery, recordID uuid.UUID) (models.WebhookDeliveryResult, time.Duration, []byte) {
+	subscription := delivery.Subscription
 	result := models.WebhookDeliveryResult{
 		WebhookID: subscription.ID,
 		TargetURL: subscription.TargetURL,
 		Success:   false,
 	}
 
-	// Build target URL with query parameters
-	targetURL := subscription.TargetURL
-	if len(subscription.QueryParams) > 0 {
-		parsedURL, err := url.Parse(targetURL)
-		if err != nil {
-			errMsg := fmt.Sprintf("failed to parse target URL: %v", err)
-			result.Error = &errMsg
-			return result
-		}
-
-		query := parsedURL.Query()
-		for key, value := range subscription.QueryParams {
-			query.Set(key, value)
-		}
-		parsedURL.RawQuery = query.Encode()
-		targetURL = parsedURL.String()
-		result.TargetURL = targetURL // Update result to show the final URL
+	transport, ok := s.transports.Lookup(subscription.TargetType)
+	if !ok {
+		errMsg := fmt.Sprintf("no transport for target type %s", subscription.TargetType)
+		result.Error = &errMsg
+		return result, 0, nil
 	}
 
 	// Implement retry logic based on subscription policy
@@ -525,95 +1354,95 @@ func (s *webhookService) sendWebhookToSubscription(subscription models.WebhookSu
 	}
 
 	var lastError error
-	var lastResponseCode *int
+	var pause time.Duration
+
+	attempts := &deliveryAttempts{subscription: subscription, eventID: recordID, acceptedAt: delivery.AcceptedAt}
+	defer s.saveDeliveryAttempts(ctx, attempts)
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
+		// Deliveries of expired events are dropped, and not retried when the retry would start past the expiry
+		var delay time.Duration
+		if attempt > 1 {
+			delay = time.Duration(retryDelaySeconds) * time.Second
+		}
+		if !delivery.ExpiresAt.IsZero() && !s.clock.Now().Add(delay).Before(delivery.ExpiresAt) {
+			result.Expired = true
+			lastError = errEventExpired
+			break
+		}
 		result.AttemptCount = attempt
 
 		// Add delay before retry attempts (not on first attempt)
 		if attempt > 1 {
-			time.Sleep(time.Duration(retryDelaySeconds) * time.Second)
-			logger.Debug("Retrying webhook delivery",
+			if !sleepContext(ctx, s.clock, delay) {
+				lastError = fmt.Errorf("delivery cancelled: %w", ctx.Err())
+				break
+			}
+			s.logger.Debug(ctx, "Retrying webhook delivery",
 				zap.String("webhook_id", subscription.ID.String()),
-				zap.String("target_url", targetURL),
+				zap.String("target_url", result.TargetURL),
 				zap.Int("attempt", attempt),
 				zap.Int("max_retries", maxRetries))
 		}
 
-		// Create HTTP request for this attempt
-		req, err := http.NewRequest("POST", targetURL, bytes.NewBuffer(payload))
-		if err != nil {
-			lastError = fmt.Errorf("failed to create request: %w", err)
-			continue
+		delivery.Attempt = attempt
+		started := s.clock.Now()
+		outcome := transport.Send(ctx, delivery)
+		finished := s.clock.Now()
+		if outcome.TargetURL != "" {
+			result.TargetURL = outcome.TargetURL // Update result to show the final URL
 		}
-
-		// Set standard headers
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "github.com/sakibcoolz/loki-suite/2.0")
-
-		// Add custom headers from subscription
-		for key, value := range subscription.Headers {
-			req.Header.Set(key, value)
+		if outcome.ResponseCode != nil {
+			result.ResponseCode = outcome.ResponseCode
 		}
 
-		// Generate HMAC signature
-		signature := s.securitySvc.GenerateHMACSignature(payload, subscription.SecretToken)
-		req.Header.Set("X-Shavix-Signature", fmt.Sprintf("sha256=%s", signature))
-		req.Header.Set("X-Shavix-Timestamp", time.Now().Format(time.RFC3339))
-		req.Header.Set("X-Shavix-Attempt", fmt.Sprintf("%d", attempt))
-
-		// Add JWT token for private webhooks
-		if subscription.Type == models.WebhookTypePrivate && subscription.JWTToken != nil {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", *subscription.JWTToken))
-		}
+		// A receiver asking for a pause is not retried; the caller queues the delivery instead
+		pause = outcome.Pause
 
-		// Send request
-		resp, err := s.httpClient.Do(req)
-		if err != nil {
-			lastError = fmt.Errorf("failed to send request: %w", err)
-			logger.Warn("Webhook delivery attempt failed",
-				zap.String("webhook_id", subscription.ID.String()),
-				zap.String("target_url", targetURL),
-				zap.Int("attempt", attempt),
-				zap.Error(err))
-			continue
+		// With a response schema the receiver's answer must also conform to it
+		if outcome.Err == nil && outcome.ResponseCode != nil && subscription.ResponseSchema != nil {
+			if err := validateResponseBody(*subscription.ResponseSchema, outcome.ResponseBody); err != nil {
+				// Receivers answering 2xx with an error page are often failing transiently, so retry
+				outcome.Err = err
+				outcome.ErrorClass = models.DeliveryErrorSchema
+			}
 		}
 
-		lastResponseCode = &resp.StatusCode
-		result.ResponseCode = lastResponseCode
-
-		// Check response status
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if outcome.Err == nil {
 			result.Success = true
-			resp.Body.Close()
+			attempts.add(attempt, started, finished, outcome.ResponseCode, "", nil)
 
-			logger.Debug("Webhook delivered successfully",
+			s.logger.Debug(ctx, "Webhook delivered successfully",
 				zap.String("webhook_id", subscription.ID.String()),
-				zap.String("target_url", targetURL),
-				zap.Int("status_code", resp.StatusCode),
+				zap.String("target_url", result.TargetURL),
+				zap.Any("status_code", outcome.ResponseCode),
 				zap.Int("attempt", attempt))
 
-			return result
+			return result, pause, outcome.ResponseBody
 		}
 
-		// Read response body for error logging
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		lastError = outcome.Err
+		attempts.add(attempt, started, finished, outcome.ResponseCode, outcome.ErrorClass, lastError)
 
-		lastError = fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(bodyBytes))
-
-		logger.Warn("Webhook delivery attempt failed",
+		s.logger.Warn(ctx, "Webhook delivery attempt failed",
 			zap.String("webhook_id", subscription.ID.String()),
-			zap.String("target_url", targetURL),
-			zap.Int("status_code", resp.StatusCode),
+			zap.String("target_url", result.TargetURL),
+			zap.Any("status_code", outcome.ResponseCode),
 			zap.Int("attempt", attempt),
-			zap.String("response", string(bodyBytes)))
+			zap.Error(lastError))
 
-		// For 4xx errors (client errors), don't retry as they indicate permanent failures
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			logger.Info("Webhook delivery failed with client error, not retrying",
+		if pause > 0 {
+			s.logger.Info(ctx, "Receiver requested a pause, not retrying",
 				zap.String("webhook_id", subscription.ID.String()),
-				zap.Int("status_code", resp.StatusCode))
+				zap.Duration("pause", pause))
+			break
+		}
+
+		// Permanent failures such as 4xx responses are not retried
+		if outcome.Permanent {
+			s.logger.Info(ctx, "Webhook delivery failed permanently, not retrying",
+				zap.String("webhook_id", subscription.ID.String()),
+				zap.String("error_class", string(outcome.ErrorClass)))
 			break
 		}
 	}
@@ -624,46 +1453,96 @@ func (s *webhookService) sendWebhookToSubscription(subscription models.WebhookSu
 		result.Error = &errMsg
 	}
 
-	logger.Error("Webhook delivery failed after all retries",
+	if result.Expired {
+		s.logger.Info(ctx, "Webhook delivery dropped, event expired",
+			zap.String("webhook_id", subscription.ID.String()),
+			zap.String("target_url", result.TargetURL),
+			zap.Int("attempts", result.AttemptCount),
+			zap.Time("expires_at", delivery.ExpiresAt))
+		return result, pause, nil
+	}
+
+	s.logger.Error(ctx, "Webhook delivery failed after all retries",
 		zap.String("webhook_id", subscription.ID.String()),
-		zap.String("target_url", targetURL),
+		zap.String("target_url", result.TargetURL),
 		zap.Int("attempts", result.AttemptCount),
 		zap.Int("max_retries", maxRetries),
-		zap.Any("last_response_code", lastResponseCode))
+		zap.Any("last_response_code", result.ResponseCode))
 
-	return result
+	return result, pause, nil
+}
+
+// setCorrelationHeaders sets the delivery ID of an outbound request and the ID of the API request it
+// results from, so receivers can correlate their logs with ours
+func setCorrelationHeaders(ctx context.Context, req *http.Request, messageID string) {
+	req.Header.Set(models.DeliveryIDHeader, messageID)
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		req.Header.Set(models.RequestIDHeader, requestID)
+	}
+}
+
+// deliveryURL returns the target URL of a subscription with its query parameters applied
+func deliveryURL(subscription models.WebhookSubscription) (string, error) {
+	if len(subscription.QueryParams) == 0 {
+		return subscription.TargetURL, nil
+	}
+
+	parsedURL, err := url.Parse(subscription.TargetURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse target URL: %w", err)
+	}
+
+	query := parsedURL.Query()
+	for key, value := range subscription.QueryParams {
+		query.Set(key, value)
+	}
+	parsedURL.RawQuery = query.Encode()
+	return parsedURL.String(), nil
 }
 
 // VerifyWebhook validates the authenticity and authorization of incoming webhook requests
 // This method provides comprehensive security validation for webhook endpoints
 // Parameters:
+//   - ctx: Context for request cancellation and deadlines
 //   - webhookID: UUID identifying the webhook subscription
 //   - payload: Raw request body bytes used for HMAC signature verification
 //   - signature: HMAC signature from X-Shavix-Signature header
 //   - timestamp: Request timestamp from X-Shavix-Timestamp header
 //   - authHeader: Authorization header containing JWT token (required for private webhooks)
+//   - sourceIP: IP the request was received from
 //
 // Returns:
 //   - error: nil if verification succeeds, descriptive error if validation fails
 //
 // Security Checks:
-//  1. Webhook subscription exists and is active
-//  2. HMAC signature matches payload and secret token
-//  3. Timestamp is within acceptable tolerance (prevents replay attacks)
-//  4. JWT token is valid and claims match webhook (for private webhooks only)
+//  1. Source IP is in the installation-wide receive allowlist, if configured
+//  2. Webhook subscription exists and is active
+//  3. Source IP is in the subscription's allowlist, if it has one
+//  4. HMAC signature matches payload and secret token
+//  5. Timestamp is within acceptable tolerance (prevents replay attacks)
+//  6. JWT token is valid and claims match webhook (for private webhooks only)
 //
 // Use case: Called by webhook receive endpoints to ensure request authenticity
-func (s *webhookService) VerifyWebhook(webhookID uuid.UUID, payload []byte, signature, timestamp, authHeader string) error {
+func (s *webhookService) VerifyWebhook(ctx context.Context, webhookID uuid.UUID, payload []byte, signature, timestamp, authHeader, sourceIP string) error {
+	// Reject sources outside the receive allowlist before looking the subscription up
+	if s.receiveAllowlist != nil && !ipAllowed(s.receiveAllowlist, sourceIP) {
+		return ErrSourceIPNotAllowed.Withf("source IP %s is not allowed", sourceIP)
+	}
+
 	// Find webhook subscription
-	subscription, err := s.repo.GetSubscriptionByID(webhookID)
+	subscription, err := s.repo.GetSubscriptionByID(ctx, webhookID)
 	if err != nil {
-		return fmt.Errorf("webhook subscription not found: %w", err)
+		return notFound(ErrWebhookNotFound, err)
 	}
 
 	if !subscription.IsActive {
 		return fmt.Errorf("webhook subscription is inactive")
 	}
 
+	if err := checkSubscriptionSource(subscription, sourceIP); err != nil {
+		return err
+	}
+
 	// Extract and verify HMAC signature
 	sig, err := s.securitySvc.ExtractSignatureFromHeader(signature)
 	if err != nil {
@@ -690,18 +1569,18 @@ func (s *webhookService) VerifyWebhook(webhookID uuid.UUID, payload []byte, sign
 			return fmt.Errorf("invalid authorization header: %w", err)
 		}
 
-		claims, err := s.securitySvc.VerifyJWTToken(token)
+		claimedWebhookID, claimedTenantID, err := s.verifyWebhookToken(ctx, token)
 		if err != nil {
 			return fmt.Errorf("JWT token verification failed: %w", err)
 		}
 
 		// Verify claims match the webhook
-		if claims.WebhookID != webhookID.String() || claims.TenantID != subscription.TenantID {
+		if claimedWebhookID != webhookID.String() || claimedTenantID != subscription.TenantID {
 			return fmt.Errorf("JWT token claims do not match webhook")
 		}
 	}
 
-	logger.Debug("Webhook verification successful",
+	s.logger.Debug(ctx, "Webhook verification successful",
 		zap.String("webhook_id", webhookID.String()),
 		zap.String("tenant_id", subscription.TenantID),
 		zap.String("type", string(subscription.Type)))
@@ -712,32 +1591,28 @@ func (s *webhookService) VerifyWebhook(webhookID uuid.UUID, payload []byte, sign
 // ListWebhooks retrieves paginated webhook subscriptions for a specific tenant
 // This method provides filtered and paginated access to webhook subscriptions
 // Parameters:
+//   - ctx: Context for request cancellation and deadlines
 //   - tenantID: Tenant identifier to filter subscriptions (required)
-//   - page: Page number for pagination, 1-based (minimum 1, defaults to 1)
-//   - limit: Maximum results per page (range 1-100, defaults to 10)
+//   - filter: Narrows the subscriptions by activity, type, subscribed event and creation time
+//   - page: Page number (minimum 1, defaults to 1) or cursor, size (range 1-100, defaults to 10) and sort
 //
 // Returns:
-//   - WebhookListResponse: Contains webhooks array, total count, current page, and limit
+//   - WebhookListResponse: Contains webhooks array, total count, current page, limit and next cursor
 //   - error: If database query fails or parameters are invalid
 //
 // Process:
-//  1. Validates and normalizes pagination parameters
-//  2. Calculates offset for database query
-//  3. Retrieves subscriptions with total count
-//  4. Returns structured response with pagination metadata
+//  1. Validates and normalizes pagination parameters, decoding the cursor
+//  2. Retrieves the filtered subscriptions with total count
+//  3. Returns structured response with pagination metadata and the cursor of the next page
 //
 // Use case: Management dashboards, webhook administration, and subscription overview
-func (s *webhookService) ListWebhooks(tenantID string, page, limit int) (*models.WebhookListResponse, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+func (s *webhookService) ListWebhooks(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage) (*models.WebhookListResponse, error) {
+	repoPage, err := listPage(&page, models.ListSortAppName)
+	if err != nil {
+		return nil, err
 	}
 
-	offset := (page - 1) * limit
-
-	webhooks, total, err := s.repo.GetSubscriptionsByTenant(tenantID, offset, limit)
+	webhooks, total, err := s.repo.GetSubscriptionsByTenant(ctx, tenantID, filter, repoPage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch webhooks: %w", err)
 	}
@@ -745,7 +1620,156 @@ func (s *webhookService) ListWebhooks(tenantID string, page, limit int) (*models
 	return &models.WebhookListResponse{
 		Webhooks: webhooks,
 		Total:    total,
-		Page:     page,
-		Limit:    limit,
+		Page:     page.Page,
+		Limit:    page.Limit,
+		NextCursor: encodeCursor(repoPage, repoPage.NextCursor(len(webhooks), func() repository.Cursor {
+			return repository.SubscriptionCursor(&webhooks[len(webhooks)-1], repoPage.Sort)
+		})),
+	}, nil
+}
+
+// GetTenantSigningHeaders retrieves a tenant's signing header name overrides
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant identifier
+//
+// Returns:
+//   - TenantSigningHeadersResponse: The stored overrides and the effective header names
+//   - error: If the tenant settings cannot be loaded
+//
+// Use case: Lets tenants check which header names their receivers must read the signature from
+func (s *webhookService) GetTenantSigningHeaders(ctx context.Context, tenantID string) (*models.TenantSigningHeadersResponse, error) {
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	return &models.TenantSigningHeadersResponse{
+		TenantID:  tenantID,
+		Overrides: settings.SigningHeaders,
+		Effective: settings.SigningHeaders.Or(models.DefaultSigningHeaders()),
 	}, nil
 }
+
+// UpdateTenantSigningHeaders replaces a tenant's signing header name overrides
+// Subscriptions with their own overrides keep them; all other deliveries of the tenant use the new names
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant identifier
+//   - headers: New overrides; empty names restore the defaults
+//
+// Returns:
+//   - TenantSigningHeadersResponse: The stored overrides and the effective header names
+//   - error: If a header name is invalid, reserved or duplicated, or the settings cannot be saved
+func (s *webhookService) UpdateTenantSigningHeaders(ctx context.Context, tenantID string, headers models.SigningHeaders) (*models.TenantSigningHeadersResponse, error) {
+	normalized, err := normalizeSigningHeaders(headers, models.SigningHeaders{})
+	if err != nil {
+		return nil, ErrInvalidTenant.Withf("invalid signing headers: %v", err)
+	}
+
+	settings, err := s.tenantRepo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant settings: %w", err)
+	}
+
+	settings.SigningHeaders = normalized
+	if err := s.tenantRepo.SaveTenantSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save tenant settings: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tenant signing headers updated",
+		zap.String("tenant_id", tenantID),
+		zap.String("signature_header", normalized.Signature),
+		zap.String("timestamp_header", normalized.Timestamp),
+		zap.String("attempt_header", normalized.Attempt))
+
+	return &models.TenantSigningHeadersResponse{
+		TenantID:  tenantID,
+		Overrides: normalized,
+		Effective: normalized.Or(models.DefaultSigningHeaders()),
+	}, nil
+}
+
+// GetWebhook retrieves a webhook subscription
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant of the caller, empty for global callers; subscriptions of other tenants are not found
+//   - webhookID: UUID identifying the webhook subscription
+//
+// Returns:
+//   - WebhookSubscription: The subscription, whose secret is never serialized
+//   - error: If the subscription does not exist
+//
+// Use case: Reading a subscription before editing it, revalidated cheaply through its ETag
+func (s *webhookService) GetWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error) {
+	return s.repo.GetSubscriptionByID(repository.WithTenantScope(ctx, tenantID), webhookID)
+}
+
+// GetWebhookHistory retrieves the versioned configuration history of a webhook subscription
+// Parameters:
+//   - ctx: Context for request cancellation and deadlines
+//   - tenantID: Tenant of the caller, empty for global callers; snapshots of other tenants are left out
+//   - webhookID: UUID identifying the webhook subscription
+//
+// Returns:
+//   - ConfigHistoryResponse: Configuration versions ordered oldest first, each with its diff against the previous one
+//   - error: ErrWebhookNotFound if the caller's tenant has no history of the subscription, or if the snapshots
+//     cannot be loaded
+//
+// Use case: Tying a change in delivery behavior to the configuration change that caused it
+func (s *webhookService) GetWebhookHistory(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.ConfigHistoryResponse, error) {
+	history, err := loadConfigHistory(ctx, s.historyRepo, tenantID, models.ConfigResourceSubscription, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if tenantID != "" && len(history.Versions) == 0 {
+		return nil, ErrWebhookNotFound
+	}
+	return history, nil
+}
+
+// DeleteWebhook soft deletes a webhook subscription, recording its last configuration in its history
+// Deliveries stop at once; deliveries queued during a receiver pause are kept until the subscription is
+// restored or purged. With a tenant given, subscriptions of other tenants are not found
+//
+// Use case: Decommissioning an endpoint while keeping its audit history and the option to undo it
+func (s *webhookService) DeleteWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) error {
+	ctx = repository.WithTenantScope(ctx, tenantID)
+	subscription, err := s.repo.GetSubscriptionByID(ctx, webhookID)
+	if err != nil {
+		return notFound(ErrWebhookNotFound, err)
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, webhookID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeDeleted, subscription)
+
+	s.logger.Info(ctx, "Webhook subscription deleted",
+		zap.String("webhook_id", webhookID.String()),
+		zap.String("tenant_id", subscription.TenantID))
+	return nil
+}
+
+// RestoreWebhook restores a soft deleted webhook subscription
+// Deliveries resume with the next event; events sent while it was deleted are not delivered
+// With a tenant given, deleted subscriptions of other tenants are not found
+//
+// Use case: Undoing an accidental deletion
+func (s *webhookService) RestoreWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error) {
+	ctx = repository.WithTenantScope(ctx, tenantID)
+	if err := s.repo.RestoreSubscription(ctx, webhookID); err != nil {
+		return nil, notFound(ErrWebhookNotFound.Withf("deleted webhook not found"), err)
+	}
+
+	subscription, err := s.repo.GetSubscriptionByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load restored webhook: %w", err)
+	}
+	recordConfigSnapshot(ctx, s.logger, s.historyRepo, s.clock, models.ConfigResourceSubscription, subscription.ID, subscription.TenantID, models.ConfigChangeRestored, subscription)
+
+	s.logger.Info(ctx, "Webhook subscription restored",
+		zap.String("webhook_id", webhookID.String()),
+		zap.String("tenant_id", subscription.TenantID))
+	return subscription, nil
+}
@@ -1,10 +1,15 @@
 package service_test
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,9 +18,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/sakibcoolz/loki-suite/internal/amqp"
+	"github.com/sakibcoolz/loki-suite/internal/email"
+	"github.com/sakibcoolz/loki-suite/internal/logging"
 	"github.com/sakibcoolz/loki-suite/internal/models"
+	"github.com/sakibcoolz/loki-suite/internal/repository"
 	"github.com/sakibcoolz/loki-suite/internal/service"
+	"github.com/sakibcoolz/loki-suite/internal/sms"
 	"github.com/sakibcoolz/loki-suite/mocks"
+	"github.com/sakibcoolz/loki-suite/pkg/client"
 	"github.com/sakibcoolz/zcornor/pkg/config"
 	"github.com/sakibcoolz/zcornor/pkg/security"
 )
@@ -23,20 +34,125 @@ import (
 // WebhookServiceTestSuite is the test suite for webhook service
 type WebhookServiceTestSuite struct {
 	suite.Suite
-	service      service.WebhookService
-	mockRepo     *mocks.MockWebhookRepository
-	mockChainSvc *mocks.MockExecutionChainService
-	securitySvc  *security.SecurityService
-	config       *config.Config
-	testServer   *httptest.Server
+	service        service.WebhookService
+	mockRepo       *mocks.MockWebhookRepository
+	mockTenantRepo *mocks.MockTenantRepository
+	mockHistory    *mocks.MockConfigHistoryRepository
+	mockChainSvc   *mocks.MockExecutionChainService
+	securitySvc    *security.SecurityService
+	config         *config.Config
+	testServer     *httptest.Server
+
+	// clock is the service's clock, standing still unless a test advances it
+	clock *fakeClock
+
+	// attempts collects the delivery attempts the service records
+	attempts []*models.WebhookDeliveryAttempt
+
+	// eventType is the registered event type returned for every event, nil for none
+	eventType *models.EventType
+
+	// tenantSettings are the settings returned for every tenant
+	tenantSettings *models.TenantSettings
+
+	// tenantStatus is the status of every tenant
+	tenantStatus models.TenantStatus
+
+	// usage is the usage counted per day
+	usage map[time.Time]*models.TenantUsage
+
+	// deliveryPauses are the stored delivery pauses, none unless a test pauses deliveries
+	deliveryPauses []models.DeliveryPause
 }
 
 // SetupTest initializes test dependencies before each test
 func (suite *WebhookServiceTestSuite) SetupTest() {
 	// Create mocks
 	suite.mockRepo = mocks.NewMockWebhookRepository(suite.T())
+	suite.mockTenantRepo = mocks.NewMockTenantRepository(suite.T())
+	suite.mockHistory = mocks.NewMockConfigHistoryRepository(suite.T())
 	suite.mockChainSvc = mocks.NewMockExecutionChainService(suite.T())
 
+	// Tenants are active and use the default settings unless a test overrides them
+	suite.tenantSettings = &models.TenantSettings{}
+	suite.tenantStatus = models.TenantStatusActive
+	suite.mockTenantRepo.EXPECT().
+		GetTenantSettings(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string) (*models.TenantSettings, error) {
+			return suite.tenantSettings, nil
+		}).
+		Maybe()
+	suite.mockTenantRepo.EXPECT().
+		GetTenant(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, tenantID string) (*models.Tenant, error) {
+			return &models.Tenant{ID: tenantID, Status: suite.tenantStatus, Settings: suite.tenantSettings}, nil
+		}).
+		Maybe()
+
+	// Usage is counted per day in memory
+	suite.usage = map[time.Time]*models.TenantUsage{}
+	suite.mockTenantRepo.EXPECT().
+		AddUsage(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, tenantID string, day time.Time, events, deliveries int64) error {
+			if suite.usage[day] == nil {
+				suite.usage[day] = &models.TenantUsage{TenantID: tenantID, Day: day}
+			}
+			suite.usage[day].Events += events
+			suite.usage[day].Deliveries += deliveries
+			return nil
+		}).
+		Maybe()
+	suite.mockTenantRepo.EXPECT().
+		ListUsage(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, from, to time.Time) ([]models.TenantUsage, error) {
+			var usage []models.TenantUsage
+			for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+				if suite.usage[day] != nil {
+					usage = append(usage, *suite.usage[day])
+				}
+			}
+			return usage, nil
+		}).
+		Maybe()
+
+	// Configuration snapshots are recorded whenever a subscription is created
+	suite.mockHistory.EXPECT().
+		GetLatestVersion(mock.Anything, models.ConfigResourceSubscription, mock.Anything).
+		Return(0, nil).
+		Maybe()
+	suite.mockHistory.EXPECT().
+		CreateSnapshot(mock.Anything, mock.Anything).
+		Return(nil).
+		Maybe()
+
+	// Events have no registered event type unless a test sets one
+	suite.eventType = nil
+	suite.mockRepo.EXPECT().
+		GetEventTypeByName(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string, string) (*models.EventType, error) {
+			return suite.eventType, nil
+		}).
+		Maybe()
+
+	// Deliveries are not paused unless a test stores a pause
+	suite.deliveryPauses = nil
+	suite.mockRepo.EXPECT().
+		GetDeliveryPauses(mock.Anything).
+		RunAndReturn(func(context.Context) ([]models.DeliveryPause, error) {
+			return suite.deliveryPauses, nil
+		}).
+		Maybe()
+
+	// Delivery attempts are recorded after every delivery
+	suite.attempts = nil
+	suite.mockRepo.EXPECT().
+		CreateDeliveryAttempts(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, attempts []*models.WebhookDeliveryAttempt) {
+			suite.attempts = append(suite.attempts, attempts...)
+		}).
+		Return(nil).
+		Maybe()
+
 	// Create test configuration
 	suite.config = &config.Config{}
 
@@ -46,13 +162,21 @@ func (suite *WebhookServiceTestSuite) SetupTest() {
 	// Create webhook service
 	webhookService := service.NewWebhookService(
 		suite.mockRepo,
+		suite.mockTenantRepo,
+		suite.mockHistory,
 		suite.securitySvc,
 		suite.config,
+		logging.Nop(),
 	)
 
 	// Set chain service
 	webhookService.SetChainService(suite.mockChainSvc)
 
+	// Retries, expiries and scheduled deliveries are timed on a fake clock, jumping through retry delays
+	suite.clock = newFakeClock(time.Now())
+	suite.clock.autoAdvance = true
+	webhookService.SetClock(suite.clock)
+
 	// Create test HTTP server for webhook delivery testing
 	suite.testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -62,9 +186,28 @@ func (suite *WebhookServiceTestSuite) SetupTest() {
 		case "/failure":
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(`{"error": "internal server error"}`))
+		case "/custom-headers":
+			// Receiver with existing verification code reading its own header names
+			if r.Header.Get("X-Acme-Signature") == "" || r.Header.Get("X-Shavix-Signature") != "" || r.Header.Get("X-Shavix-Timestamp") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"status": "success"}`))
+		case "/correlated":
+			// Receiver logging the IDs it correlates deliveries with the sender's logs by
+			if r.Header.Get(models.DeliveryIDHeader) == "" || r.Header.Get(models.RequestIDHeader) != "req-123" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
 		case "/client-error":
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(`{"error": "bad request"}`))
+		case "/deploying":
+			// Receiver asking for a pause while it deploys
+			w.Header().Set(models.PauseHeader, "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -96,7 +239,7 @@ func (suite *WebhookServiceTestSuite) TestGenerateWebhook_Success() {
 
 	// Mock repository call
 	suite.mockRepo.EXPECT().
-		CreateSubscription(mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
 			return sub.TenantID == req.TenantID &&
 				sub.AppName == req.AppName &&
 				sub.SubscribedEvent == req.SubscribedEvent &&
@@ -110,7 +253,7 @@ func (suite *WebhookServiceTestSuite) TestGenerateWebhook_Success() {
 		Once()
 
 	// Act
-	result, err := suite.service.GenerateWebhook(req)
+	result, err := suite.service.GenerateWebhook(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -137,14 +280,14 @@ func (suite *WebhookServiceTestSuite) TestGenerateWebhook_PrivateWebhook() {
 
 	// Mock repository call
 	suite.mockRepo.EXPECT().
-		CreateSubscription(mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
 			return sub.Type == models.WebhookTypePrivate && sub.JWTToken != nil
 		})).
 		Return(nil).
 		Once()
 
 	// Act
-	result, err := suite.service.GenerateWebhook(req)
+	result, err := suite.service.GenerateWebhook(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -165,12 +308,12 @@ func (suite *WebhookServiceTestSuite) TestGenerateWebhook_RepositoryError() {
 
 	// Mock repository error
 	suite.mockRepo.EXPECT().
-		CreateSubscription(mock.AnythingOfType("*models.WebhookSubscription")).
+		CreateSubscription(mock.Anything, mock.AnythingOfType("*models.WebhookSubscription")).
 		Return(fmt.Errorf("database error")).
 		Once()
 
 	// Act
-	result, err := suite.service.GenerateWebhook(req)
+	result, err := suite.service.GenerateWebhook(context.Background(), req)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -200,7 +343,7 @@ func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_Success() {
 
 	// Mock repository call
 	suite.mockRepo.EXPECT().
-		CreateSubscription(mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
 			return sub.TenantID == req.TenantID &&
 				sub.AppName == req.AppName &&
 				sub.TargetURL == req.TargetURL &&
@@ -218,7 +361,7 @@ func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_Success() {
 		Once()
 
 	// Act
-	result, err := suite.service.SubscribeWebhook(req)
+	result, err := suite.service.SubscribeWebhook(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -230,6 +373,95 @@ func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_Success() {
 	assert.Equal(suite.T(), req.RetryPolicy, result.RetryPolicy)
 }
 
+// TestSubscribeWebhook_TenantDefaultRetryPolicy tests that subscriptions without a retry policy get the tenant's default
+func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_TenantDefaultRetryPolicy() {
+	// Arrange
+	suite.tenantSettings.DefaultRetryPolicy = models.RetryPolicy{MaxRetries: 7, RetryDelaySeconds: 30}
+	req := &models.SubscribeWebhookRequest{
+		TenantID:        "tenant-123",
+		AppName:         "external-app",
+		TargetURL:       "https://example.com/webhook",
+		SubscribedEvent: "order.completed",
+		Type:            models.WebhookTypePublic,
+	}
+	suite.mockRepo.EXPECT().
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+			return sub.MaxRetries == 7 && sub.RetryDelaySeconds == 30
+		})).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SubscribeWebhook(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &models.RetryPolicy{MaxRetries: 7, RetryDelaySeconds: 30}, result.RetryPolicy)
+}
+
+// TestSendEvent_TenantSuspended tests that events of a suspended tenant are rejected before anything is stored
+func (suite *WebhookServiceTestSuite) TestSendEvent_TenantSuspended() {
+	// Arrange
+	suite.tenantStatus = models.TenantStatusSuspended
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.completed",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": "12345"},
+	}
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, service.ErrTenantSuspended)
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEvent", mock.Anything, mock.Anything)
+}
+
+// TestDeleteAndRestoreWebhook tests that deleting and restoring a subscription records both changes
+func (suite *WebhookServiceTestSuite) TestDeleteAndRestoreWebhook() {
+	// Arrange
+	subscription := &models.WebhookSubscription{
+		ID:       uuid.New(),
+		TenantID: "tenant-123",
+		AppName:  "external-app",
+		IsActive: true,
+	}
+	suite.mockRepo.EXPECT().GetSubscriptionByID(mock.Anything, subscription.ID).Return(subscription, nil).Twice()
+	suite.mockRepo.EXPECT().DeleteSubscription(mock.Anything, subscription.ID).Return(nil).Once()
+	suite.mockRepo.EXPECT().RestoreSubscription(mock.Anything, subscription.ID).Return(nil).Once()
+
+	// Act
+	deleteErr := suite.service.DeleteWebhook(context.Background(), "", subscription.ID)
+	restored, restoreErr := suite.service.RestoreWebhook(context.Background(), "", subscription.ID)
+
+	// Assert
+	assert.NoError(suite.T(), deleteErr)
+	assert.NoError(suite.T(), restoreErr)
+	assert.Equal(suite.T(), subscription.ID, restored.ID)
+	for _, change := range []models.ConfigChange{models.ConfigChangeDeleted, models.ConfigChangeRestored} {
+		suite.mockHistory.AssertCalled(suite.T(), "CreateSnapshot", mock.Anything, mock.MatchedBy(func(snapshot *models.ConfigSnapshot) bool {
+			return snapshot.ResourceID == subscription.ID && snapshot.Change == change
+		}))
+	}
+}
+
+// TestRestoreWebhook_NotDeleted tests that restoring a subscription that is not deleted fails
+func (suite *WebhookServiceTestSuite) TestRestoreWebhook_NotDeleted() {
+	// Arrange
+	webhookID := uuid.New()
+	suite.mockRepo.EXPECT().RestoreSubscription(mock.Anything, webhookID).Return(fmt.Errorf("record not found")).Once()
+
+	// Act
+	result, err := suite.service.RestoreWebhook(context.Background(), "", webhookID)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "deleted webhook not found")
+}
+
 // TestSendEvent_Success tests successful event sending
 func (suite *WebhookServiceTestSuite) TestSendEvent_Success() {
 	// Arrange
@@ -256,12 +488,12 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_Success() {
 
 	// Mock repository calls
 	suite.mockRepo.EXPECT().
-		GetActiveSubscriptionsByTenantAndEvent(req.TenantID, req.Event).
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
 		Return(subscriptions, nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		CreateEvent(mock.MatchedBy(func(event *models.WebhookEvent) bool {
+		CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
 			return event.TenantID == req.TenantID &&
 				event.EventName == req.Event &&
 				event.Source == req.Source
@@ -270,7 +502,7 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_Success() {
 		Once()
 
 	suite.mockRepo.EXPECT().
-		UpdateEvent(mock.MatchedBy(func(event *models.WebhookEvent) bool {
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
 			return event.Status == models.WebhookStatusSent &&
 				event.SentAt != nil
 		})).
@@ -284,7 +516,7 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_Success() {
 		Once()
 
 	// Act
-	result, err := suite.service.SendEvent(req)
+	result, err := suite.service.SendEvent(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -294,6 +526,57 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_Success() {
 	assert.Len(suite.T(), result.Webhooks, 1)
 	assert.True(suite.T(), result.Webhooks[0].Success)
 	assert.Equal(suite.T(), http.StatusOK, *result.Webhooks[0].ResponseCode)
+
+	// The successful attempt is recorded for delivery statistics
+	assert.Len(suite.T(), suite.attempts, 1)
+	assert.True(suite.T(), suite.attempts[0].Success)
+	assert.True(suite.T(), suite.attempts[0].Final)
+	assert.Empty(suite.T(), suite.attempts[0].ErrorClass)
+	assert.Equal(suite.T(), subscriptions[0].ID, suite.attempts[0].WebhookID)
+	if assert.NotNil(suite.T(), suite.attempts[0].EndToEndMs) {
+		assert.GreaterOrEqual(suite.T(), *suite.attempts[0].EndToEndMs, suite.attempts[0].LatencyMs)
+	}
+
+	// The event and its delivery count towards the tenant's usage
+	var events, deliveries int64
+	for _, usage := range suite.usage {
+		events += usage.Events
+		deliveries += usage.Deliveries
+	}
+	assert.Equal(suite.T(), int64(1), events)
+	assert.Equal(suite.T(), int64(1), deliveries)
+}
+
+// TestSendEvent_QuotaExceeded tests that an event whose deliveries exceed the tenant's daily quota is rejected
+func (suite *WebhookServiceTestSuite) TestSendEvent_QuotaExceeded() {
+	// Arrange
+	suite.tenantSettings.Quota = models.Quota{DeliveriesPerDay: 10}
+	today := suite.clock.Now().UTC().Truncate(24 * time.Hour)
+	suite.usage[today] = &models.TenantUsage{TenantID: "tenant-123", Day: today, Events: 4, Deliveries: 9}
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "user.created",
+		Source:   "user-service",
+		Payload:  map[string]interface{}{"user_id": "123"},
+	}
+	subscriptions := []models.WebhookSubscription{
+		{ID: uuid.New(), TenantID: req.TenantID, SubscribedEvent: req.Event, IsActive: true},
+		{ID: uuid.New(), TenantID: req.TenantID, SubscribedEvent: req.Event, IsActive: true},
+	}
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, service.ErrQuotaExceeded)
+	assert.ErrorContains(suite.T(), err, "9 of 10 deliveries per day used and the event needs 2")
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEvent", mock.Anything, mock.Anything)
+	assert.Equal(suite.T(), int64(4), suite.usage[today].Events)
 }
 
 // TestSendEvent_WithRetries tests event sending with retry logic
@@ -322,17 +605,17 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_WithRetries() {
 
 	// Mock repository calls
 	suite.mockRepo.EXPECT().
-		GetActiveSubscriptionsByTenantAndEvent(req.TenantID, req.Event).
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
 		Return(subscriptions, nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		CreateEvent(mock.AnythingOfType("*models.WebhookEvent")).
+		CreateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
 		Return(nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		UpdateEvent(mock.MatchedBy(func(event *models.WebhookEvent) bool {
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
 			return event.Status == models.WebhookStatusFailed
 		})).
 		Return(nil).
@@ -345,7 +628,8 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_WithRetries() {
 		Once()
 
 	// Act
-	result, err := suite.service.SendEvent(req)
+	start := suite.clock.Now()
+	result, err := suite.service.SendEvent(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -356,6 +640,16 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_WithRetries() {
 	assert.False(suite.T(), result.Webhooks[0].Success)
 	assert.Equal(suite.T(), 3, result.Webhooks[0].AttemptCount) // Should retry 3 times
 	assert.Equal(suite.T(), http.StatusInternalServerError, *result.Webhooks[0].ResponseCode)
+
+	// Every attempt is recorded as a server error a retry delay after the one before; only the last one is final
+	assert.Len(suite.T(), suite.attempts, 3)
+	for i, attempt := range suite.attempts {
+		assert.Equal(suite.T(), i+1, attempt.Attempt)
+		assert.Equal(suite.T(), start.Add(time.Duration(i)*time.Second), attempt.CreatedAt)
+		assert.Equal(suite.T(), models.DeliveryErrorServer, attempt.ErrorClass)
+		assert.Equal(suite.T(), i == 2, attempt.Final)
+		assert.Equal(suite.T(), result.EventID, attempt.EventID)
+	}
 }
 
 // TestSendEvent_ClientErrorNoRetry tests that client errors don't trigger retries
@@ -384,17 +678,17 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_ClientErrorNoRetry() {
 
 	// Mock repository calls
 	suite.mockRepo.EXPECT().
-		GetActiveSubscriptionsByTenantAndEvent(req.TenantID, req.Event).
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
 		Return(subscriptions, nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		CreateEvent(mock.AnythingOfType("*models.WebhookEvent")).
+		CreateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
 		Return(nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		UpdateEvent(mock.MatchedBy(func(event *models.WebhookEvent) bool {
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
 			return event.Status == models.WebhookStatusFailed
 		})).
 		Return(nil).
@@ -407,7 +701,7 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_ClientErrorNoRetry() {
 		Once()
 
 	// Act
-	result, err := suite.service.SendEvent(req)
+	result, err := suite.service.SendEvent(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -418,6 +712,86 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_ClientErrorNoRetry() {
 	assert.False(suite.T(), result.Webhooks[0].Success)
 	assert.Equal(suite.T(), 1, result.Webhooks[0].AttemptCount) // Should NOT retry on client error
 	assert.Equal(suite.T(), http.StatusBadRequest, *result.Webhooks[0].ResponseCode)
+
+	// The single attempt is recorded as a final client error
+	assert.Len(suite.T(), suite.attempts, 1)
+	assert.Equal(suite.T(), models.DeliveryErrorClient, suite.attempts[0].ErrorClass)
+	assert.Equal(suite.T(), http.StatusBadRequest, *suite.attempts[0].ResponseCode)
+	assert.True(suite.T(), suite.attempts[0].Final)
+	assert.False(suite.T(), suite.attempts[0].Success)
+}
+
+// TestSendEvent_ReceiverPause tests that a receiver requesting a pause gets its delivery queued instead of retried
+func (suite *WebhookServiceTestSuite) TestSendEvent_ReceiverPause() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "user.created",
+		Source:   "user-service",
+		Payload:  map[string]interface{}{"user_id": "123"},
+	}
+
+	subscription := models.WebhookSubscription{
+		ID:                uuid.New(),
+		TenantID:          req.TenantID,
+		TargetURL:         suite.testServer.URL + "/deploying",
+		SubscribedEvent:   req.Event,
+		Type:              models.WebhookTypePublic,
+		SecretToken:       "test-secret",
+		MaxRetries:        3,
+		RetryDelaySeconds: 1,
+		IsActive:          true,
+	}
+
+	// Mock repository calls
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		SetSubscriptionPause(mock.Anything, subscription.ID, mock.MatchedBy(func(until *time.Time) bool {
+			return until != nil && until.Equal(suite.clock.Now().Add(time.Hour))
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		CreateQueuedDelivery(mock.Anything, mock.MatchedBy(func(delivery *models.QueuedDelivery) bool {
+			return delivery.SubscriptionID == subscription.ID && delivery.Payload != ""
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusPending
+		})).
+		Return(nil).
+		Once()
+
+	// Mock chain service call
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.TotalFailed)
+	assert.Equal(suite.T(), 1, result.TotalQueued)
+	assert.True(suite.T(), result.Webhooks[0].Queued)
+	assert.NotNil(suite.T(), result.Webhooks[0].PausedUntil)
+	assert.Nil(suite.T(), result.Webhooks[0].Error)
+	assert.Equal(suite.T(), 1, result.Webhooks[0].AttemptCount) // Should NOT retry a paused receiver
 }
 
 // TestSendEvent_WithPayloadMerging tests payload merging functionality
@@ -451,17 +825,17 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_WithPayloadMerging() {
 
 	// Mock repository calls
 	suite.mockRepo.EXPECT().
-		GetActiveSubscriptionsByTenantAndEvent(req.TenantID, req.Event).
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
 		Return(subscriptions, nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		CreateEvent(mock.AnythingOfType("*models.WebhookEvent")).
+		CreateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
 		Return(nil).
 		Once()
 
 	suite.mockRepo.EXPECT().
-		UpdateEvent(mock.MatchedBy(func(event *models.WebhookEvent) bool {
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
 			return event.Status == models.WebhookStatusSent
 		})).
 		Return(nil).
@@ -474,7 +848,7 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_WithPayloadMerging() {
 		Once()
 
 	// Act
-	result, err := suite.service.SendEvent(req)
+	result, err := suite.service.SendEvent(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -484,209 +858,2972 @@ func (suite *WebhookServiceTestSuite) TestSendEvent_WithPayloadMerging() {
 	assert.True(suite.T(), result.Webhooks[0].Success)
 }
 
-// TestSendEvent_NoSubscriptions tests sending event with no matching subscriptions
-func (suite *WebhookServiceTestSuite) TestSendEvent_NoSubscriptions() {
+// TestSendEvent_SubscriptionSigningHeaders tests that subscription header name overrides are used for delivery
+func (suite *WebhookServiceTestSuite) TestSendEvent_SubscriptionSigningHeaders() {
 	// Arrange
 	req := &models.SendEventRequest{
 		TenantID: "tenant-123",
-		Event:    "user.created",
-		Source:   "user-service",
-		Payload:  map[string]interface{}{"user_id": "123"},
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": "456"},
+	}
+
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetURL:         suite.testServer.URL + "/custom-headers",
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			SecretToken:       "test-secret",
+			MaxRetries:        1,
+			RetryDelaySeconds: 1,
+			IsActive:          true,
+			SigningHeaders:    models.SigningHeaders{Signature: "X-Acme-Signature"},
+		},
 	}
 
-	// Mock repository calls - return empty subscriptions
 	suite.mockRepo.EXPECT().
-		GetActiveSubscriptionsByTenantAndEvent(req.TenantID, req.Event).
-		Return([]models.WebhookSubscription{}, nil).
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
 		Once()
 
-	// Mock CreateEvent call since the service always creates an event record
 	suite.mockRepo.EXPECT().
-		CreateEvent(mock.AnythingOfType("*models.WebhookEvent")).
+		CreateEvent(mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
-	// Mock UpdateEvent call since the service always updates the event status
 	suite.mockRepo.EXPECT().
-		UpdateEvent(mock.AnythingOfType("*models.WebhookEvent")).
+		UpdateEvent(mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
-	// Mock chain service call
 	suite.mockChainSvc.EXPECT().
 		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
 		Return(nil).
 		Once()
 
 	// Act
-	result, err := suite.service.SendEvent(req)
+	result, err := suite.service.SendEvent(context.Background(), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), result)
-	assert.Equal(suite.T(), 0, result.TotalSent)
-	assert.Equal(suite.T(), 0, result.TotalFailed)
-	assert.Len(suite.T(), result.Webhooks, 0)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.True(suite.T(), result.Webhooks[0].Success)
 }
 
-// TestVerifyWebhook_Success tests successful webhook verification
-func (suite *WebhookServiceTestSuite) TestVerifyWebhook_Success() {
+// TestSendEvent_CorrelationHeaders tests that deliveries carry a delivery ID and the ID of the request that sent the event
+func (suite *WebhookServiceTestSuite) TestSendEvent_CorrelationHeaders() {
 	// Arrange
-	webhookID := uuid.New()
-	payload := []byte(`{"test": "data"}`)
-	secretToken := "test-secret"
-	timestamp := time.Now().Format(time.RFC3339)
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": "456"},
+	}
 
-	subscription := &models.WebhookSubscription{
-		ID:          webhookID,
-		Type:        models.WebhookTypePublic,
-		SecretToken: secretToken,
-		IsActive:    true,
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetURL:         suite.testServer.URL + "/correlated",
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			SecretToken:       "test-secret",
+			MaxRetries:        1,
+			RetryDelaySeconds: 1,
+			IsActive:          true,
+		},
 	}
 
-	signature := suite.securitySvc.GenerateHMACSignature(payload, secretToken)
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Once()
 
-	// Mock repository call
 	suite.mockRepo.EXPECT().
-		GetSubscriptionByID(webhookID).
-		Return(subscription, nil).
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
 		Once()
 
 	// Act
-	err := suite.service.VerifyWebhook(webhookID, payload, fmt.Sprintf("sha256=%s", signature), timestamp, "")
+	result, err := suite.service.SendEvent(logging.WithRequestID(context.Background(), "req-123"), req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.True(suite.T(), result.Webhooks[0].Success)
 }
 
-// TestVerifyWebhook_InvalidSignature tests verification with invalid signature
-func (suite *WebhookServiceTestSuite) TestVerifyWebhook_InvalidSignature() {
-	// Arrange
-	webhookID := uuid.New()
-	payload := []byte(`{"test": "data"}`)
-	secretToken := "test-secret"
-	timestamp := time.Now().Format(time.RFC3339)
-	invalidSignature := "invalid-signature"
+// amqpPublisher records the messages published to AMQP targets, failing them with err when set
+type amqpPublisher struct {
+	err      error
+	messages []amqp.Publishing
+	keys     []string
+}
 
-	subscription := &models.WebhookSubscription{
-		ID:          webhookID,
-		Type:        models.WebhookTypePublic,
-		SecretToken: secretToken,
-		IsActive:    true,
+func (p *amqpPublisher) Publish(_ context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	p.messages = append(p.messages, msg)
+	p.keys = append(p.keys, exchange+"/"+routingKey)
+	return p.err
+}
+
+// TestSubscribeWebhook_AMQPTarget tests that AMQP targets store their exchange and routing key and need a routing key
+func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_AMQPTarget() {
+	req := &models.SubscribeWebhookRequest{
+		TenantID:        "tenant-123",
+		AppName:         "billing",
+		SubscribedEvent: "order.created",
+		Type:            models.WebhookTypePublic,
+		IsPublic:        true,
+		TargetType:      models.TargetTypeAMQP,
+		AMQPExchange:    "events",
 	}
 
-	// Mock repository call
+	_, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "amqp_routing_key is required")
+
+	req.AMQPRoutingKey = "billing.orders"
 	suite.mockRepo.EXPECT().
-		GetSubscriptionByID(webhookID).
-		Return(subscription, nil).
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+			return sub.TargetType == models.TargetTypeAMQP &&
+				sub.AMQPExchange == "events" &&
+				sub.AMQPRoutingKey == "billing.orders" &&
+				sub.TargetURL == "amqp://events/billing.orders"
+		})).
+		Return(nil).
 		Once()
 
-	// Act
-	err := suite.service.VerifyWebhook(webhookID, payload, invalidSignature, timestamp, "")
-
-	// Assert
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "invalid signature format")
+	result, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.TargetTypeAMQP, result.TargetType)
+	assert.Equal(suite.T(), "amqp://events/billing.orders", result.WebhookURL)
 }
 
-// TestVerifyWebhook_SubscriptionNotFound tests verification when subscription is not found
-func (suite *WebhookServiceTestSuite) TestVerifyWebhook_SubscriptionNotFound() {
-	// Arrange
-	webhookID := uuid.New()
-	payload := []byte(`{"test": "data"}`)
-	timestamp := time.Now().Format(time.RFC3339)
-	signature := "sha256=test"
-
-	// Mock repository call - return error
-	suite.mockRepo.EXPECT().
-		GetSubscriptionByID(webhookID).
-		Return(nil, fmt.Errorf("subscription not found")).
+// TestSendEvent_AMQPTarget tests that AMQP targets receive the delivery body with its signature headers and that
+// unroutable messages are not retried
+func (suite *WebhookServiceTestSuite) TestSendEvent_AMQPTarget() {
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": "456"},
+	}
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetURL:         "amqp://events/billing.orders",
+			TargetType:        models.TargetTypeAMQP,
+			AMQPExchange:      "events",
+			AMQPRoutingKey:    "billing.orders",
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			SecretToken:       "test-secret",
+			MaxRetries:        3,
+			RetryDelaySeconds: 1,
+			IsActive:          true,
+		},
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Twice()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Twice()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Twice()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Twice()
+
+	publisher := &amqpPublisher{}
+	suite.service.SetAMQPPublisher(publisher)
+
+	result, err := suite.service.SendEvent(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.Equal(suite.T(), []string{"events/billing.orders"}, publisher.keys)
+
+	msg := publisher.messages[0]
+	signature := "sha256=" + suite.securitySvc.GenerateHMACSignature(msg.Body, "test-secret")
+	assert.Equal(suite.T(), signature, msg.Headers["X-Shavix-Signature"])
+	assert.Equal(suite.T(), "1", msg.Headers["X-Shavix-Attempt"])
+	assert.Equal(suite.T(), result.EventID.String(), msg.MessageID)
+	assert.Equal(suite.T(), "application/json", msg.ContentType)
+
+	publisher.err = fmt.Errorf("%w: 312 NO_ROUTE", amqp.ErrUnroutable)
+	result, err = suite.service.SendEvent(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalFailed)
+	assert.Equal(suite.T(), 1, result.Webhooks[0].AttemptCount)
+	assert.Equal(suite.T(), models.DeliveryErrorRejected, suite.attempts[len(suite.attempts)-1].ErrorClass)
+}
+
+// TestSubscribeWebhook_NotificationTargets tests that PagerDuty targets need a routing key and default to the
+// Events API, and that notification templates and severities are validated
+func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_NotificationTargets() {
+	req := &models.SubscribeWebhookRequest{
+		TenantID:        "tenant-123",
+		AppName:         "on-call",
+		SubscribedEvent: "payment.failed",
+		Type:            models.WebhookTypePublic,
+		IsPublic:        true,
+		TargetType:      models.TargetTypePagerDuty,
+		Notification:    &models.NotificationTemplate{Title: "Payment {{.payload.payment_id}} failed"},
+	}
+
+	_, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "pagerduty_routing_key is required")
+
+	req.PagerDutyRoutingKey = "routing-key"
+	req.Notification.SeverityMap = map[string]models.NotificationSeverity{"P1": "fatal"}
+	_, err = suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "invalid severity")
+
+	req.Notification.SeverityMap = nil
+	req.Notification.Title = "{{.payload.payment_id"
+	_, err = suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "invalid template")
+
+	req.Notification.Title = "Payment {{.payload.payment_id}} failed"
+	suite.mockRepo.EXPECT().
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+			return sub.TargetType == models.TargetTypePagerDuty &&
+				sub.TargetURL == models.PagerDutyEventsURL &&
+				sub.PagerDutyRoutingKey != nil && *sub.PagerDutyRoutingKey == "routing-key" &&
+				sub.Notification.Title == req.Notification.Title
+		})).
+		Return(nil).
+		Once()
+
+	result, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.TargetTypePagerDuty, result.TargetType)
+}
+
+// TestSendEvent_NotificationTargets tests that Slack, Teams and PagerDuty targets receive messages rendered from
+// their templates, with the severity mapped from the event
+func (suite *WebhookServiceTestSuite) TestSendEvent_NotificationTargets() {
+	// Arrange
+	var mu sync.Mutex
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("X-Shavix-Signature") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		bodies[r.URL.Path] = body
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "payment.failed",
+		Source:   "payment-service",
+		Payload:  map[string]interface{}{"payment_id": "pay-1", "customer_id": "cus-9", "priority": "P1"},
+	}
+	routingKey := "routing-key"
+	tmpl := &models.NotificationTemplate{
+		Title:         "Payment {{.payload.payment_id}} failed",
+		Text:          "Customer *{{.payload.customer_id}}* {{.payload.missing}}",
+		Fields:        map[string]string{"Priority": "{{.payload.priority}}", "Customer": "{{.payload.customer_id}}"},
+		SeverityField: "payload.priority",
+		SeverityMap:   map[string]models.NotificationSeverity{"P1": models.NotificationSeverityCritical},
+		DedupKey:      "payment-{{.payload.payment_id}}",
+	}
+	subscription := func(targetType models.TargetType, path string) models.WebhookSubscription {
+		return models.WebhookSubscription{
+			ID:                  uuid.New(),
+			TenantID:            req.TenantID,
+			TargetURL:           server.URL + path,
+			TargetType:          targetType,
+			Notification:        tmpl,
+			PagerDutyRoutingKey: &routingKey,
+			SubscribedEvent:     req.Event,
+			Type:                models.WebhookTypePublic,
+			SecretToken:         "test-secret",
+			MaxRetries:          1,
+			RetryDelaySeconds:   1,
+			IsActive:            true,
+		}
+	}
+	subscriptions := []models.WebhookSubscription{
+		subscription(models.TargetTypeSlack, "/slack"),
+		subscription(models.TargetTypeTeams, "/teams"),
+		subscription(models.TargetTypePagerDuty, "/pagerduty"),
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, result.TotalSent)
+
+	slack := bodies["/slack"]
+	assert.Equal(suite.T(), ":rotating_light: Payment pay-1 failed", slack["text"])
+	blocks := slack["blocks"].([]interface{})
+	assert.Equal(suite.T(), "Payment pay-1 failed", blocks[0].(map[string]interface{})["text"].(map[string]interface{})["text"])
+	assert.Equal(suite.T(), "Customer *cus-9* ", blocks[1].(map[string]interface{})["text"].(map[string]interface{})["text"])
+	fields := blocks[2].(map[string]interface{})["fields"].([]interface{})
+	assert.Equal(suite.T(), "*Customer*\ncus-9", fields[0].(map[string]interface{})["text"])
+	assert.Equal(suite.T(), "*Priority*\nP1", fields[1].(map[string]interface{})["text"])
+
+	card := bodies["/teams"]["attachments"].([]interface{})[0].(map[string]interface{})["content"].(map[string]interface{})
+	assert.Equal(suite.T(), "AdaptiveCard", card["type"])
+	title := card["body"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(suite.T(), "Payment pay-1 failed", title["text"])
+	assert.Equal(suite.T(), "Attention", title["color"])
+
+	pagerDuty := bodies["/pagerduty"]
+	assert.Equal(suite.T(), "routing-key", pagerDuty["routing_key"])
+	assert.Equal(suite.T(), "trigger", pagerDuty["event_action"])
+	assert.Equal(suite.T(), "payment-pay-1", pagerDuty["dedup_key"])
+	alert := pagerDuty["payload"].(map[string]interface{})
+	assert.Equal(suite.T(), "Payment pay-1 failed", alert["summary"])
+	assert.Equal(suite.T(), "critical", alert["severity"])
+	assert.Equal(suite.T(), "payment-service", alert["source"])
+	assert.Equal(suite.T(), "payment.failed", alert["class"])
+	assert.Equal(suite.T(), "cus-9", alert["custom_details"].(map[string]interface{})["Customer"])
+}
+
+// messageSenders records the email and text messages sent to message targets, failing text messages to
+// the numbers in smsErrs
+type messageSenders struct {
+	emails  []email.Message
+	texts   []sms.Message
+	smsErrs map[string]error
+}
+
+func (m *messageSenders) Send(_ context.Context, msg email.Message) error {
+	m.emails = append(m.emails, msg)
+	return nil
+}
+
+// smsSender returns the SMS side of the senders
+func (m *messageSenders) smsSender() service.SMSSender {
+	return smsSenderFunc(func(_ context.Context, msg sms.Message) error {
+		m.texts = append(m.texts, msg)
+		return m.smsErrs[msg.To]
+	})
+}
+
+// smsSenderFunc adapts a function to service.SMSSender
+type smsSenderFunc func(context.Context, sms.Message) error
+
+func (f smsSenderFunc) Send(ctx context.Context, msg sms.Message) error {
+	return f(ctx, msg)
+}
+
+// TestSubscribeWebhook_MessageTargets tests that email and SMS targets need valid recipients and are
+// described by them
+func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_MessageTargets() {
+	req := &models.SubscribeWebhookRequest{
+		TenantID:        "tenant-123",
+		AppName:         "alerts",
+		SubscribedEvent: "payment.failed",
+		Type:            models.WebhookTypePublic,
+		IsPublic:        true,
+		TargetType:      models.TargetTypeSMS,
+	}
+
+	_, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "recipients are required")
+
+	req.Recipients = []string{"555-0123"}
+	_, err = suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "E.164")
+
+	req.TargetType = models.TargetTypeEmail
+	req.Recipients = []string{"Ops <ops@example.com>", "oncall@example.com"}
+	suite.mockRepo.EXPECT().
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+			return sub.TargetType == models.TargetTypeEmail &&
+				assert.ObjectsAreEqual([]string{"ops@example.com", "oncall@example.com"}, sub.Recipients)
+		})).
+		Return(nil).
+		Once()
+
+	result, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "mailto:ops@example.com,oncall@example.com", result.WebhookURL)
+}
+
+// TestSendEvent_MessageTargets tests that email and SMS targets receive the rendered event, that text
+// messages are only resent to numbers that failed transiently and that rejected numbers fail the delivery
+func (suite *WebhookServiceTestSuite) TestSendEvent_MessageTargets() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "payment.failed",
+		Source:   "payment-service",
+		Payload:  map[string]interface{}{"payment_id": "pay-1", "level": "critical"},
+	}
+	tmpl := &models.NotificationTemplate{
+		Title:         "Payment {{.payload.payment_id}} failed",
+		Fields:        map[string]string{"Payment": "{{.payload.payment_id}}"},
+		SeverityField: "payload.level",
+	}
+	subscription := func(targetType models.TargetType, recipients ...string) models.WebhookSubscription {
+		return models.WebhookSubscription{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetType:        targetType,
+			Recipients:        recipients,
+			Notification:      tmpl,
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			MaxRetries:        2,
+			RetryDelaySeconds: 1,
+			IsActive:          true,
+		}
+	}
+	subscriptions := []models.WebhookSubscription{
+		subscription(models.TargetTypeEmail, "ops@example.com"),
+		subscription(models.TargetTypeSMS, "+14155550123", "+14155550124", "+14155550125"),
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	senders := &messageSenders{smsErrs: map[string]error{
+		"+14155550124": fmt.Errorf("%w: status 400: blocked", sms.ErrRejected),
+		"+14155550125": fmt.Errorf("sms: provider returned status 503"),
+	}}
+	suite.service.SetEmailSender(senders)
+	suite.service.SetSMSSender(senders.smsSender())
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.Equal(suite.T(), 1, result.TotalFailed)
+
+	if assert.Len(suite.T(), senders.emails, 1) {
+		msg := senders.emails[0]
+		assert.Equal(suite.T(), []string{"ops@example.com"}, msg.To)
+		assert.Equal(suite.T(), "Payment pay-1 failed", msg.Subject)
+		assert.Contains(suite.T(), msg.Text, "Payment: pay-1\n")
+		assert.Contains(suite.T(), msg.Text, "Severity: critical\n")
+		assert.Equal(suite.T(), "1", msg.Headers["X-Priority"])
+	}
+
+	var to []string
+	for _, text := range senders.texts {
+		to = append(to, text.To)
+		assert.Equal(suite.T(), "Payment pay-1 failed\nPayment: pay-1", text.Body)
+	}
+	assert.Equal(suite.T(), []string{"+14155550123", "+14155550124", "+14155550125", "+14155550125"}, to)
+	for _, webhook := range result.Webhooks {
+		if !webhook.Success {
+			assert.Equal(suite.T(), 2, webhook.AttemptCount)
+			assert.Contains(suite.T(), *webhook.Error, "+14155550124")
+		}
+	}
+}
+
+// recordingTransport is a custom delivery transport recording what it is asked to deliver, failing the
+// attempts listed in failures
+type recordingTransport struct {
+	deliveries []service.Delivery
+	failures   map[int]service.DeliveryOutcome
+}
+
+func (t *recordingTransport) Validate(req *models.SubscribeWebhookRequest, subscription *models.WebhookSubscription) error {
+	if req.Headers["X-Queue"] == "" {
+		return fmt.Errorf("an X-Queue header is required for queue targets")
+	}
+	subscription.TargetURL = "queue://" + req.Headers["X-Queue"]
+	return nil
+}
+
+func (t *recordingTransport) Send(_ context.Context, d *service.Delivery) service.DeliveryOutcome {
+	t.deliveries = append(t.deliveries, *d)
+	return t.failures[d.Attempt]
+}
+
+// TestSubscribeWebhook_CustomTransport tests that target types are accepted once a transport is
+// registered for them, and that the transport validates and describes the target
+func (suite *WebhookServiceTestSuite) TestSubscribeWebhook_CustomTransport() {
+	req := &models.SubscribeWebhookRequest{
+		TenantID:        "tenant-123",
+		AppName:         "orders",
+		SubscribedEvent: "order.created",
+		Type:            models.WebhookTypePublic,
+		IsPublic:        true,
+		TargetType:      "queue",
+	}
+
+	_, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "invalid target type: queue")
+
+	suite.service.Transports().Register("queue", &recordingTransport{})
+	_, err = suite.service.SubscribeWebhook(context.Background(), req)
+	assert.ErrorContains(suite.T(), err, "X-Queue header is required")
+
+	req.Headers = map[string]string{"X-Queue": "orders"}
+	suite.mockRepo.EXPECT().
+		CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+			return sub.TargetType == "queue"
+		})).
+		Return(nil).
+		Once()
+
+	result, err := suite.service.SubscribeWebhook(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "queue://orders", result.WebhookURL)
+	assert.Contains(suite.T(), suite.service.Transports().TargetTypes(), models.TargetType("queue"))
+}
+
+// TestSendEvent_CustomTransport tests that events are delivered through the transport registered for the
+// target type, with the service retrying failed attempts under the same message ID and stopping at
+// permanent failures
+func (suite *WebhookServiceTestSuite) TestSendEvent_CustomTransport() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": "ORD-1"},
+	}
+	subscription := func(targetURL string) models.WebhookSubscription {
+		return models.WebhookSubscription{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetType:        "queue",
+			TargetURL:         targetURL,
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			MaxRetries:        3,
+			RetryDelaySeconds: 1,
+			IsActive:          true,
+		}
+	}
+	retried := &recordingTransport{failures: map[int]service.DeliveryOutcome{
+		1: {Err: fmt.Errorf("queue busy"), ErrorClass: models.DeliveryErrorConnection},
+	}}
+	refused := &recordingTransport{failures: map[int]service.DeliveryOutcome{
+		1: {Err: fmt.Errorf("queue does not exist"), ErrorClass: models.DeliveryErrorRejected, Permanent: true},
+	}}
+	suite.service.Transports().Register("queue", retried)
+	suite.service.Transports().Register("missing-queue", refused)
+	missing := subscription("queue://missing")
+	missing.TargetType = "missing-queue"
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription("queue://orders"), missing}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.Equal(suite.T(), 1, result.TotalFailed)
+
+	if assert.Len(suite.T(), retried.deliveries, 2) {
+		first, second := retried.deliveries[0], retried.deliveries[1]
+		assert.Equal(suite.T(), []int{1, 2}, []int{first.Attempt, second.Attempt})
+		assert.Equal(suite.T(), first.MessageID, second.MessageID)
+		assert.False(suite.T(), first.Raw)
+		assert.Contains(suite.T(), string(first.Body), "ORD-1")
+	}
+	assert.Len(suite.T(), refused.deliveries, 1)
+	for _, webhook := range result.Webhooks {
+		if webhook.Success {
+			assert.Equal(suite.T(), 2, webhook.AttemptCount)
+			assert.Equal(suite.T(), "queue://orders", webhook.TargetURL)
+		} else {
+			assert.Equal(suite.T(), 1, webhook.AttemptCount)
+			assert.Contains(suite.T(), *webhook.Error, "queue does not exist")
+		}
+	}
+}
+
+// TestSendEvent_NoSubscriptions tests sending event with no matching subscriptions
+func (suite *WebhookServiceTestSuite) TestSendEvent_NoSubscriptions() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "user.created",
+		Source:   "user-service",
+		Payload:  map[string]interface{}{"user_id": "123"},
+	}
+
+	// Mock repository calls - return empty subscriptions
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{}, nil).
+		Once()
+
+	// Mock CreateEvent call since the service always creates an event record
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
+		Return(nil).
+		Once()
+
+	// Mock UpdateEvent call since the service always updates the event status
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
+		Return(nil).
+		Once()
+
+	// Mock chain service call
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+	assert.Equal(suite.T(), 0, result.TotalSent)
+	assert.Equal(suite.T(), 0, result.TotalFailed)
+	assert.Len(suite.T(), result.Webhooks, 0)
+}
+
+// TestSendEvent_PayloadSchemaViolation tests that an event type validating payloads rejects a violating event
+func (suite *WebhookServiceTestSuite) TestSendEvent_PayloadSchemaViolation() {
+	// Arrange
+	schema := `{"type": "object", "required": ["order_id"], "properties": {"order_id": {"type": "string"}}}`
+	suite.eventType = &models.EventType{
+		ID:               uuid.New(),
+		TenantID:         "tenant-123",
+		Name:             "order.created",
+		Schema:           &schema,
+		ValidatePayloads: true,
+	}
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": 42},
+	}
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert - nothing is stored or delivered
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "order_id")
+}
+
+// TestSendEvent_StrictModeReportsAllViolations tests that a strict tenant validates event types
+// that do not validate payloads themselves and reports every violation
+func (suite *WebhookServiceTestSuite) TestSendEvent_StrictModeReportsAllViolations() {
+	// Arrange
+	schema := `{"type": "object", "required": ["order_id"], "properties": {"order_id": {"type": "string"}, "total": {"type": "number"}}}`
+	suite.eventType = &models.EventType{
+		ID:       uuid.New(),
+		TenantID: "tenant-123",
+		Name:     "order.created",
+		Schema:   &schema,
+	}
+	suite.tenantSettings = &models.TenantSettings{TenantID: "tenant-123", StrictPayloadValidation: true}
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"total": "12.50"},
+	}
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	var validationErr *service.PayloadValidationError
+	if !assert.ErrorAs(suite.T(), err, &validationErr) {
+		return
+	}
+	assert.Equal(suite.T(), "order.created", validationErr.EventType)
+	assert.Equal(suite.T(), []models.SchemaViolation{
+		{Path: "$", Message: `missing required property "order_id"`},
+		{Path: "$.total", Message: "expected number, got string"},
+	}, validationErr.Violations)
+}
+
+// TestSendEvent_PayloadMatchesSchema tests that a conforming payload is delivered as usual
+func (suite *WebhookServiceTestSuite) TestSendEvent_PayloadMatchesSchema() {
+	// Arrange
+	schema := `{"type": "object", "required": ["order_id"], "properties": {"order_id": {"type": "string"}}}`
+	suite.eventType = &models.EventType{
+		ID:               uuid.New(),
+		TenantID:         "tenant-123",
+		Name:             "order.created",
+		Schema:           &schema,
+		ValidatePayloads: true,
+	}
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "order-service",
+		Payload:  map[string]interface{}{"order_id": "ORD-42"},
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.AnythingOfType("*models.WebhookEvent")).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+}
+
+// TestSendEvent_Scheduled tests that an event with a delay is stored for later delivery instead of being sent
+func (suite *WebhookServiceTestSuite) TestSendEvent_Scheduled() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID:     "tenant-123",
+		Event:        "trial.expiring",
+		Source:       "billing-service",
+		Payload:      map[string]interface{}{"user_id": "123"},
+		DelaySeconds: 3600,
+	}
+
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusScheduled &&
+				event.DeliverAt != nil && event.DeliverAt.Equal(suite.clock.Now().Add(time.Hour))
+		})).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert - subscriptions are matched and chains triggered only on delivery
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Scheduled)
+	assert.NotNil(suite.T(), result.DeliverAt)
+	assert.Len(suite.T(), result.Webhooks, 0)
+}
+
+// TestSendEvent_ScheduleConflict tests that deliver_at and delay_seconds cannot be combined
+func (suite *WebhookServiceTestSuite) TestSendEvent_ScheduleConflict() {
+	deliverAt := suite.clock.Now().Add(time.Hour)
+	req := &models.SendEventRequest{
+		TenantID:     "tenant-123",
+		Event:        "trial.expiring",
+		Source:       "billing-service",
+		Payload:      map[string]interface{}{"user_id": "123"},
+		DeliverAt:    &deliverAt,
+		DelaySeconds: 60,
+	}
+
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+}
+
+// TestDispatchScheduledEvents tests that a due scheduled event is delivered and triggers its chains
+func (suite *WebhookServiceTestSuite) TestDispatchScheduledEvents() {
+	// Arrange
+	eventID := uuid.New()
+	deliverAt := suite.clock.Now().Add(-time.Second)
+	payload, _ := json.Marshal(models.WebhookPayload{
+		Event:     "trial.expiring",
+		Source:    "billing-service",
+		Timestamp: suite.clock.Now().Add(-time.Hour).Format(time.RFC3339),
+		Payload:   map[string]interface{}{"user_id": "123"},
+		EventID:   eventID,
+	})
+	event := models.WebhookEvent{
+		ID:        eventID,
+		TenantID:  "tenant-123",
+		EventName: "trial.expiring",
+		Source:    "billing-service",
+		Payload:   string(payload),
+		Status:    models.WebhookStatusScheduled,
+		DeliverAt: &deliverAt,
+	}
+
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        event.TenantID,
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: event.EventName,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		IsActive:        true,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetDueScheduledEvents(mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.WebhookEvent{event}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		ClaimScheduledEvent(mock.Anything, eventID).
+		Return(true, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, event.TenantID, event.EventName).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(updated *models.WebhookEvent) bool {
+			return updated.ID == eventID && updated.Status == models.WebhookStatusSent
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, event.TenantID, event.EventName, map[string]interface{}{"user_id": "123"}).
+		Return(nil).
+		Once()
+
+	// Act
+	dispatched, err := suite.service.DispatchScheduledEvents(context.Background())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, dispatched)
+}
+
+// TestSendEvent_ExpiryInPast tests that events cannot be sent already expired, nor expiring before they are due
+func (suite *WebhookServiceTestSuite) TestSendEvent_ExpiryInPast() {
+	expired := suite.clock.Now().Add(-time.Minute)
+	beforeDue := suite.clock.Now().Add(time.Minute)
+	requests := map[string]*models.SendEventRequest{
+		"expired": {
+			TenantID:  "tenant-123",
+			Event:     "flash.sale",
+			Source:    "shop-service",
+			Payload:   map[string]interface{}{"sale_id": "123"},
+			ExpiresAt: &expired,
+		},
+		"expiring before due": {
+			TenantID:     "tenant-123",
+			Event:        "flash.sale",
+			Source:       "shop-service",
+			Payload:      map[string]interface{}{"sale_id": "123"},
+			DelaySeconds: 3600,
+			ExpiresAt:    &beforeDue,
+		},
+	}
+
+	for name, req := range requests {
+		result, err := suite.service.SendEvent(context.Background(), req)
+
+		assert.ErrorIs(suite.T(), err, service.ErrInvalidEvent, name)
+		assert.Nil(suite.T(), result, name)
+	}
+}
+
+// TestSendEvent_ExpiresBeforeRetry tests that a failed delivery is not retried when the retry would start after
+// the event expired, and the event is marked expired
+func (suite *WebhookServiceTestSuite) TestSendEvent_ExpiresBeforeRetry() {
+	// Arrange
+	expiresAt := suite.clock.Now().Add(time.Minute)
+	req := &models.SendEventRequest{
+		TenantID:  "tenant-123",
+		Event:     "flash.sale",
+		Source:    "shop-service",
+		Payload:   map[string]interface{}{"sale_id": "123"},
+		ExpiresAt: &expiresAt,
+	}
+
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetURL:         suite.testServer.URL + "/failure",
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			SecretToken:       "test-secret",
+			MaxRetries:        3,
+			RetryDelaySeconds: 120,
+			IsActive:          true,
+		},
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.ExpiresAt != nil && event.ExpiresAt.Equal(expiresAt)
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusExpired
+		})).
+		Return(nil).
+		Once()
+
+	// The event has not expired yet when its delivery ends, so its chains still run
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.TotalSent)
+	assert.Equal(suite.T(), 0, result.TotalFailed)
+	assert.Equal(suite.T(), 1, result.TotalExpired)
+	assert.True(suite.T(), result.Webhooks[0].Expired)
+	assert.Equal(suite.T(), 1, result.Webhooks[0].AttemptCount)
+	assert.Len(suite.T(), suite.attempts, 1)
+}
+
+// TestSendEvent_ExpiresBetweenRetries tests that a delivery is retried while the clock is before the expiry and
+// stops once the next retry would start after it
+func (suite *WebhookServiceTestSuite) TestSendEvent_ExpiresBetweenRetries() {
+	// Arrange
+	expiresAt := suite.clock.Now().Add(90 * time.Second)
+	req := &models.SendEventRequest{
+		TenantID:  "tenant-123",
+		Event:     "flash.sale",
+		Source:    "shop-service",
+		Payload:   map[string]interface{}{"sale_id": "123"},
+		ExpiresAt: &expiresAt,
+	}
+
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:                uuid.New(),
+			TenantID:          req.TenantID,
+			TargetURL:         suite.testServer.URL + "/failure",
+			SubscribedEvent:   req.Event,
+			Type:              models.WebhookTypePublic,
+			SecretToken:       "test-secret",
+			MaxRetries:        3,
+			RetryDelaySeconds: 60,
+			IsActive:          true,
+		},
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return(subscriptions, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.ExpiresAt != nil && event.ExpiresAt.Equal(expiresAt)
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusExpired
+		})).
+		Return(nil).
+		Once()
+
+	// The event has not expired yet when its delivery ends, so its chains still run
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.TotalSent)
+	assert.Equal(suite.T(), 0, result.TotalFailed)
+	assert.Equal(suite.T(), 1, result.TotalExpired)
+	assert.True(suite.T(), result.Webhooks[0].Expired)
+	assert.Equal(suite.T(), 2, result.Webhooks[0].AttemptCount)
+	assert.Len(suite.T(), suite.attempts, 2)
+	assert.Equal(suite.T(), expiresAt.Add(-30*time.Second), suite.clock.Now(), "the clock only moved through the one retry delay")
+}
+
+// TestDispatchScheduledEvents_Expired tests that a scheduled event that expired before it was due is marked
+// expired, without being delivered or triggering its chains
+func (suite *WebhookServiceTestSuite) TestDispatchScheduledEvents_Expired() {
+	// Arrange
+	deliverAt := suite.clock.Now().Add(-time.Hour)
+	expiresAt := suite.clock.Now().Add(-time.Minute)
+	event := models.WebhookEvent{
+		ID:        uuid.New(),
+		TenantID:  "tenant-123",
+		EventName: "flash.sale",
+		Source:    "shop-service",
+		Payload:   `{"event":"flash.sale"}`,
+		Status:    models.WebhookStatusScheduled,
+		DeliverAt: &deliverAt,
+		ExpiresAt: &expiresAt,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetDueScheduledEvents(mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.WebhookEvent{event}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		ClaimScheduledEvent(mock.Anything, event.ID).
+		Return(true, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(updated *models.WebhookEvent) bool {
+			return updated.ID == event.ID && updated.Status == models.WebhookStatusExpired && updated.LastError != nil
+		})).
+		Return(nil).
+		Once()
+
+	// Act
+	dispatched, err := suite.service.DispatchScheduledEvents(context.Background())
+
+	// Assert - subscriptions are not looked up and chains not triggered, so the mocks fail the test if they are
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, dispatched)
+}
+
+// TestSendEvent_Priority tests that an event is stored with the level of its priority and unknown priorities
+// are rejected
+func (suite *WebhookServiceTestSuite) TestSendEvent_Priority() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID:     "tenant-123",
+		Event:        "disk.full",
+		Source:       "monitoring",
+		Payload:      map[string]interface{}{"host": "db-1"},
+		DelaySeconds: 60,
+		Priority:     models.PriorityHigh,
+	}
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Priority == models.PriorityLevelHigh
+		})).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+	req.Priority = "urgent"
+	_, unknownErr := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Scheduled)
+	assert.ErrorIs(suite.T(), unknownErr, service.ErrInvalidEvent)
+}
+
+// TestSendEvent_ReservedHighPrioritySlots tests that with every delivery slot open to normal events taken, normal
+// events wait while high priority events are delivered in the reserved slot
+func (suite *WebhookServiceTestSuite) TestSendEvent_ReservedHighPrioritySlots() {
+	// Arrange
+	suite.service.ConfigureDeliveryConcurrency(2, 1)
+	received := make(chan struct{})
+	unblock := make(chan struct{})
+	slowReceiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-unblock
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slowReceiver.Close()
+
+	subscription := func(event, targetURL string) []models.WebhookSubscription {
+		return []models.WebhookSubscription{{
+			ID:              uuid.New(),
+			TenantID:        "tenant-123",
+			TargetURL:       targetURL,
+			SubscribedEvent: event,
+			Type:            models.WebhookTypePublic,
+			SecretToken:     "test-secret",
+			IsActive:        true,
+		}}
+	}
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, "tenant-123", "report.generated").
+		Return(subscription("report.generated", slowReceiver.URL), nil)
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, "tenant-123", "disk.full").
+		Return(subscription("disk.full", suite.testServer.URL+"/success"), nil)
+	suite.mockRepo.EXPECT().CreateEvent(mock.Anything, mock.Anything).Return(nil)
+	suite.mockRepo.EXPECT().UpdateEvent(mock.Anything, mock.Anything).Return(nil)
+	suite.mockChainSvc.EXPECT().ExecuteChainByEvent(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	bulk := &models.SendEventRequest{TenantID: "tenant-123", Event: "report.generated", Source: "reports", Payload: map[string]interface{}{}}
+	alert := &models.SendEventRequest{TenantID: "tenant-123", Event: "disk.full", Source: "monitoring", Payload: map[string]interface{}{}, Priority: models.PriorityHigh}
+
+	// The first normal event holds the only slot open to normal events until the receiver answers
+	done := make(chan error)
+	go func() {
+		_, err := suite.service.SendEvent(context.Background(), bulk)
+		done <- err
+	}()
+	<-received
+
+	// Act
+	waitCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, waitErr := suite.service.SendEvent(waitCtx, bulk)
+	alertResult, alertErr := suite.service.SendEvent(context.Background(), alert)
+	close(unblock)
+
+	// Assert
+	assert.ErrorIs(suite.T(), waitErr, context.DeadlineExceeded)
+	if assert.NoError(suite.T(), alertErr) {
+		assert.Equal(suite.T(), 1, alertResult.TotalSent)
+	}
+	assert.NoError(suite.T(), <-done)
+}
+
+// TestSendEvent_ScheduledQueued tests that with a work queue a scheduled event is queued for its delivery time
+func (suite *WebhookServiceTestSuite) TestSendEvent_ScheduledQueued() {
+	// Arrange
+	queue := mocks.NewMockWorkQueue(suite.T())
+	suite.service.SetWorkQueue(queue)
+	deliverAt := suite.clock.Now().Add(time.Hour).UTC()
+	req := &models.SendEventRequest{
+		TenantID:  "tenant-123",
+		Event:     "trial.expiring",
+		Source:    "billing-service",
+		Payload:   map[string]interface{}{"user_id": "123"},
+		DeliverAt: &deliverAt,
+	}
+
+	var eventID uuid.UUID
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, event *models.WebhookEvent) error {
+			eventID = event.ID
+			return nil
+		}).
+		Once()
+	queue.EXPECT().
+		Push(mock.Anything, "scheduled-events", mock.Anything, deliverAt).
+		RunAndReturn(func(_ context.Context, _ string, job string, _ time.Time) error {
+			assert.Equal(suite.T(), eventID.String(), job)
+			return nil
+		}).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Scheduled)
+}
+
+// TestRunEventQueue_SkipsDeliveredEvents tests that a queued event the database sweep already delivered is
+// not claimed again
+func (suite *WebhookServiceTestSuite) TestRunEventQueue_SkipsDeliveredEvents() {
+	// Arrange
+	queue := mocks.NewMockWorkQueue(suite.T())
+	suite.service.SetWorkQueue(queue)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	event := &models.WebhookEvent{ID: uuid.New(), TenantID: "tenant-123", Status: models.WebhookStatusSent}
+
+	// The consumer waits for its next poll until it is cancelled
+	suite.clock.autoAdvance = false
+
+	queue.EXPECT().
+		PopDue(mock.Anything, "scheduled-events", mock.Anything, mock.Anything).
+		Return([]string{event.ID.String()}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetEventByID(mock.Anything, event.ID).
+		RunAndReturn(func(context.Context, uuid.UUID) (*models.WebhookEvent, error) {
+			// Stop the consumer after this pass
+			cancel()
+			return event, nil
+		}).
+		Once()
+
+	// Act
+	suite.service.RunEventQueue(ctx, time.Hour)
+
+	// Assert - ClaimScheduledEvent is not expected, so the mock fails the test if it is called
+	assert.Error(suite.T(), ctx.Err())
+}
+
+// TestTestWebhook_Success tests that a test delivery is signed like a real one and not recorded
+func (suite *WebhookServiceTestSuite) TestTestWebhook_Success() {
+	// Arrange
+	subscription := &models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-123",
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: "order.created",
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+	}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, subscription.ID).
+		Return(subscription, nil).
+		Once()
+
+	// Act
+	response, err := suite.service.TestWebhook(context.Background(), "", subscription.ID, &models.TestWebhookRequest{})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+	assert.Equal(suite.T(), http.StatusOK, *response.ResponseCode)
+	assert.Equal(suite.T(), `{"status": "success"}`, response.ResponseBody)
+	assert.Contains(suite.T(), response.RequestBody, `"event":"order.created"`)
+
+	signature := suite.securitySvc.GenerateHMACSignature([]byte(response.RequestBody), subscription.SecretToken)
+	assert.Equal(suite.T(), "sha256="+signature, response.Headers["X-Shavix-Signature"])
+	assert.Empty(suite.T(), suite.attempts)
+}
+
+// TestTestWebhook_StandardWebhooksScheme tests that deliveries can be signed per the Standard Webhooks specification
+func (suite *WebhookServiceTestSuite) TestTestWebhook_StandardWebhooksScheme() {
+	// Arrange
+	subscription := &models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-123",
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: "order.created",
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		SignatureScheme: models.SignatureSchemeStandardWebhooks,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, subscription.ID).
+		Return(subscription, nil).
+		Once()
+
+	// Act
+	response, err := suite.service.TestWebhook(context.Background(), "", subscription.ID, &models.TestWebhookRequest{})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+	assert.NotContains(suite.T(), response.Headers, "X-Shavix-Signature")
+
+	header := http.Header{}
+	for name, value := range response.Headers {
+		header.Set(name, value)
+	}
+	secret := client.StandardSecret(subscription.SecretToken)
+	assert.NoError(suite.T(), client.VerifyStandard(header, []byte(response.RequestBody), secret, time.Now(), client.DefaultTolerance))
+}
+
+// TestTestWebhook_Ed25519Signing tests that tenants signing with Ed25519 send signatures receivers verify with the public key
+func (suite *WebhookServiceTestSuite) TestTestWebhook_Ed25519Signing() {
+	// Arrange
+	publicKey, privateKey, _ := ed25519.GenerateKey(nil)
+	key := &models.SigningKey{ID: "lk_test", TenantID: "tenant-123", Algorithm: models.SigningAlgorithmEd25519, PublicKey: publicKey, PrivateKey: base64.StdEncoding.EncodeToString(privateKey)}
+	suite.tenantSettings = &models.TenantSettings{SigningAlgorithm: models.SigningAlgorithmEd25519, SigningKeyID: key.ID}
+
+	subscription := &models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-123",
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: "order.created",
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+	}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, subscription.ID).
+		Return(subscription, nil).
+		Once()
+	suite.mockTenantRepo.EXPECT().
+		GetSigningKey(mock.Anything, key.ID).
+		Return(key, nil).
+		Once()
+
+	// Act
+	response, err := suite.service.TestWebhook(context.Background(), "", subscription.ID, &models.TestWebhookRequest{})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+	assert.Equal(suite.T(), key.ID, response.Headers[client.KeyIDHeader])
+
+	header := http.Header{}
+	for name, value := range response.Headers {
+		header.Set(name, value)
+	}
+	verifier := client.NewKeySetVerifier(map[string]ed25519.PublicKey{key.ID: publicKey})
+	assert.NoError(suite.T(), verifier.Verify(header, []byte(response.RequestBody)))
+}
+
+// TestVerifySignature_ReformattedBody tests that a signature over re-formatted JSON fails with a hint
+func (suite *WebhookServiceTestSuite) TestVerifySignature_ReformattedBody() {
+	// Arrange
+	subscription := &models.WebhookSubscription{
+		ID:          uuid.New(),
+		TenantID:    "tenant-123",
+		SecretToken: "test-secret",
+	}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, subscription.ID).
+		Return(subscription, nil).
+		Once()
+
+	sent := `{"event":"order.created","payload":{"order_id":"ORD-1"}}`
+	signature := suite.securitySvc.GenerateHMACSignature([]byte(sent), subscription.SecretToken)
+
+	// Act
+	response, err := suite.service.VerifySignature(context.Background(), "", &models.VerifySignatureRequest{
+		WebhookID: subscription.ID,
+		Body:      "{\n  \"event\": \"order.created\",\n  \"payload\": {\"order_id\": \"ORD-1\"}\n}",
+		Signature: "sha256=" + signature,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), response.Valid)
+	assert.False(suite.T(), response.SignatureValid)
+	assert.True(suite.T(), *response.TimestampValid)
+	assert.Equal(suite.T(), "X-Shavix-Signature", response.SignatureHeader)
+	assert.Len(suite.T(), response.Hints, 1)
+	assert.Contains(suite.T(), response.Hints[0], "compact JSON")
+	assert.NotContains(suite.T(), fmt.Sprint(response), signature)
+}
+
+// TestTestWebhook_ReceiverError tests that a failing receiver is reported in the response, not as an error
+func (suite *WebhookServiceTestSuite) TestTestWebhook_ReceiverError() {
+	// Arrange
+	subscription := &models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-123",
+		TargetURL:       suite.testServer.URL + "/failure",
+		SubscribedEvent: "order.created",
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		MaxRetries:      3,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, subscription.ID).
+		Return(subscription, nil).
+		Once()
+
+	// Act
+	response, err := suite.service.TestWebhook(context.Background(), "", subscription.ID, &models.TestWebhookRequest{
+		Payload: map[string]interface{}{"order_id": "ORD-TEST"},
+	})
+
+	// Assert - sent once despite the retry policy
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), response.Success)
+	assert.Equal(suite.T(), http.StatusInternalServerError, *response.ResponseCode)
+	assert.Contains(suite.T(), response.ResponseBody, "internal server error")
+	assert.Contains(suite.T(), *response.Error, "500")
+	assert.Contains(suite.T(), response.RequestBody, "ORD-TEST")
+}
+
+// TestVerifyWebhook_Success tests successful webhook verification
+func (suite *WebhookServiceTestSuite) TestVerifyWebhook_Success() {
+	// Arrange
+	webhookID := uuid.New()
+	payload := []byte(`{"test": "data"}`)
+	secretToken := "test-secret"
+	timestamp := time.Now().Format(time.RFC3339)
+
+	subscription := &models.WebhookSubscription{
+		ID:          webhookID,
+		Type:        models.WebhookTypePublic,
+		SecretToken: secretToken,
+		IsActive:    true,
+	}
+
+	signature := suite.securitySvc.GenerateHMACSignature(payload, secretToken)
+
+	// Mock repository call
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, webhookID).
+		Return(subscription, nil).
+		Once()
+
+	// Act
+	err := suite.service.VerifyWebhook(context.Background(), webhookID, payload, fmt.Sprintf("sha256=%s", signature), timestamp, "", "203.0.113.7")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+}
+
+// TestVerifyWebhook_KeyringRotation tests that private webhook JWTs keep verifying after a new key is
+// promoted and stop verifying once their key is retired
+func (suite *WebhookServiceTestSuite) TestVerifyWebhook_KeyringRotation() {
+	// Arrange
+	keys := []*models.JWTKey{{ID: "jk_old", Secret: base64.StdEncoding.EncodeToString([]byte("old-secret")), IsPrimary: true}}
+	keyringRepo := mocks.NewMockKeyringRepository(suite.T())
+	keyringRepo.EXPECT().ListKeys(mock.Anything).
+		RunAndReturn(func(context.Context) ([]*models.JWTKey, error) { return keys, nil }).
+		Maybe()
+	keyringRepo.EXPECT().CreateKey(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, key *models.JWTKey) error {
+			keys[0] = &models.JWTKey{ID: keys[0].ID, Secret: keys[0].Secret}
+			keys = append(keys, key)
+			return nil
+		}).
+		Once()
+	keyringRepo.EXPECT().RetireKey(mock.Anything, "jk_old", mock.Anything).
+		RunAndReturn(func(context.Context, string, map[string]interface{}) error {
+			retiredAt := time.Now()
+			keys[0] = &models.JWTKey{ID: keys[0].ID, Secret: keys[0].Secret, RetiredAt: &retiredAt}
+			return nil
+		}).
+		Once()
+
+	keyring := service.NewKeyring(keyringRepo, "", logging.Nop())
+	assert.NoError(suite.T(), keyring.Load(context.Background()))
+	suite.service.SetKeyring(keyring)
+
+	var subscription *models.WebhookSubscription
+	suite.mockRepo.EXPECT().
+		CreateSubscription(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, sub *models.WebhookSubscription) error {
+			subscription = sub
+			return nil
+		}).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, uuid.UUID) (*models.WebhookSubscription, error) { return subscription, nil }).
+		Times(3)
+
+	result, err := suite.service.GenerateWebhook(context.Background(), &models.GenerateWebhookRequest{
+		TenantID:        "tenant-123",
+		AppName:         "test-app",
+		SubscribedEvent: "user.created",
+		Type:            models.WebhookTypePrivate,
+	})
+	assert.NoError(suite.T(), err)
+
+	payload := []byte(`{"test": "data"}`)
+	signature := "sha256=" + suite.securitySvc.GenerateHMACSignature(payload, subscription.SecretToken)
+	timestamp := time.Now().Format(time.RFC3339)
+	authHeader := "Bearer " + *result.JWTToken
+	verify := func() error {
+		return suite.service.VerifyWebhook(context.Background(), result.WebhookID, payload, signature, timestamp, authHeader, "203.0.113.7")
+	}
+
+	// Act & Assert
+	assert.NoError(suite.T(), verify())
+
+	newKey, err := keyring.AddKey(context.Background(), true)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), newKey.IsPrimary)
+	assert.NoError(suite.T(), verify())
+
+	assert.NoError(suite.T(), keyring.RetireKey(context.Background(), "jk_old"))
+	assert.ErrorContains(suite.T(), verify(), "jwt key jk_old is retired")
+}
+
+// TestVerifyWebhook_InvalidSignature tests verification with invalid signature
+func (suite *WebhookServiceTestSuite) TestVerifyWebhook_InvalidSignature() {
+	// Arrange
+	webhookID := uuid.New()
+	payload := []byte(`{"test": "data"}`)
+	secretToken := "test-secret"
+	timestamp := time.Now().Format(time.RFC3339)
+	invalidSignature := "invalid-signature"
+
+	subscription := &models.WebhookSubscription{
+		ID:          webhookID,
+		Type:        models.WebhookTypePublic,
+		SecretToken: secretToken,
+		IsActive:    true,
+	}
+
+	// Mock repository call
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, webhookID).
+		Return(subscription, nil).
+		Once()
+
+	// Act
+	err := suite.service.VerifyWebhook(context.Background(), webhookID, payload, invalidSignature, timestamp, "", "203.0.113.7")
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "invalid signature format")
+}
+
+// TestVerifyWebhook_SubscriptionNotFound tests verification when subscription is not found
+func (suite *WebhookServiceTestSuite) TestVerifyWebhook_SubscriptionNotFound() {
+	// Arrange
+	webhookID := uuid.New()
+	payload := []byte(`{"test": "data"}`)
+	timestamp := time.Now().Format(time.RFC3339)
+	signature := "sha256=test"
+
+	// Mock repository call - return error
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, webhookID).
+		Return(nil, fmt.Errorf("subscription not found")).
+		Once()
+
+	// Act
+	err := suite.service.VerifyWebhook(context.Background(), webhookID, payload, signature, timestamp, "", "203.0.113.7")
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "subscription not found")
+}
+
+// TestVerifyWebhook_SourceIPAllowlists tests that the receive allowlist and the subscription's allowlist both apply
+func (suite *WebhookServiceTestSuite) TestVerifyWebhook_SourceIPAllowlists() {
+	// Arrange
+	webhookID := uuid.New()
+	payload := []byte(`{"test": "data"}`)
+	timestamp := time.Now().Format(time.RFC3339)
+	signature := "sha256=" + suite.securitySvc.GenerateHMACSignature(payload, "test-secret")
+
+	subscription := &models.WebhookSubscription{
+		ID:               webhookID,
+		Type:             models.WebhookTypePublic,
+		SecretToken:      "test-secret",
+		IsActive:         true,
+		AllowedSourceIPs: []string{"198.51.100.0/24"},
+	}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, webhookID).
+		Return(subscription, nil).
+		Twice()
+
+	assert.NoError(suite.T(), suite.service.ConfigureNetwork(nil, []string{"198.51.100.0/16", "2001:db8::1"}))
+
+	// Act & Assert
+	assert.NoError(suite.T(), suite.service.VerifyWebhook(context.Background(), webhookID, payload, signature, timestamp, "", "198.51.100.7"))
+
+	// Outside the receive allowlist; rejected before the subscription is looked up
+	err := suite.service.VerifyWebhook(context.Background(), webhookID, payload, signature, timestamp, "", "203.0.113.7")
+	assert.ErrorIs(suite.T(), err, service.ErrSourceIPNotAllowed)
+
+	// Inside the receive allowlist but outside the subscription's
+	err = suite.service.VerifyWebhook(context.Background(), webhookID, payload, signature, timestamp, "", "198.51.101.7")
+	assert.ErrorIs(suite.T(), err, service.ErrSourceIPNotAllowed)
+
+	assert.Error(suite.T(), suite.service.ConfigureNetwork([]string{"not-an-ip"}, nil))
+}
+
+// TestListWebhooks_Success tests successful webhook listing
+func (suite *WebhookServiceTestSuite) TestListWebhooks_Success() {
+	// Arrange
+	tenantID := "tenant-123"
+	page := 1
+	limit := 10
+
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:              uuid.New(),
+			TenantID:        tenantID,
+			AppName:         "app1",
+			SubscribedEvent: "user.created",
+			Type:            models.WebhookTypePublic,
+			IsActive:        true,
+		},
+		{
+			ID:              uuid.New(),
+			TenantID:        tenantID,
+			AppName:         "app2",
+			SubscribedEvent: "order.completed",
+			Type:            models.WebhookTypePrivate,
+			IsActive:        true,
+		},
+	}
+
+	// Mock repository call
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, tenantID, models.ListFilter{}, repository.Page{Limit: limit, Sort: models.ListSortCreatedAt}).
+		Return(subscriptions, int64(2), nil).
+		Once()
+
+	// Act
+	result, err := suite.service.ListWebhooks(context.Background(), tenantID, models.ListFilter{}, models.ListPage{Page: page, Limit: limit})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+	assert.Len(suite.T(), result.Webhooks, 2)
+	assert.Equal(suite.T(), int64(2), result.Total)
+	assert.Equal(suite.T(), page, result.Page)
+	assert.Equal(suite.T(), limit, result.Limit)
+}
+
+// TestListWebhooks_RepositoryError tests listing when repository returns error
+func (suite *WebhookServiceTestSuite) TestListWebhooks_RepositoryError() {
+	// Arrange
+	tenantID := "tenant-123"
+	page := 1
+	limit := 10
+
+	// Mock repository call - return error
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, tenantID, models.ListFilter{}, repository.Page{Limit: limit, Sort: models.ListSortCreatedAt}).
+		Return(nil, int64(0), fmt.Errorf("database error")).
+		Once()
+
+	// Act
+	result, err := suite.service.ListWebhooks(context.Background(), tenantID, models.ListFilter{}, models.ListPage{Page: page, Limit: limit})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "failed to fetch webhooks")
+}
+
+// TestListWebhooks_Cursor tests that a full page returns a cursor continuing the list in its sort and order,
+// and that sorts the list lacks and cursors of other orders are rejected
+func (suite *WebhookServiceTestSuite) TestListWebhooks_Cursor() {
+	// Arrange
+	tenantID := "tenant-123"
+	active := true
+	filter := models.ListFilter{IsActive: &active}
+	last := models.WebhookSubscription{ID: uuid.New(), TenantID: tenantID, AppName: "billing", CreatedAt: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	first := repository.Page{Limit: 2, Sort: models.ListSortAppName, Ascending: true}
+	next := first
+	next.After = &repository.Cursor{Value: "billing", CreatedAt: last.CreatedAt, ID: last.ID}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, tenantID, filter, first).
+		Return([]models.WebhookSubscription{{ID: uuid.New(), AppName: "audit"}, last}, int64(3), nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, tenantID, filter, next).
+		Return([]models.WebhookSubscription{{ID: uuid.New(), AppName: "shipping"}}, int64(3), nil).
+		Once()
+
+	// Act
+	firstPage, err := suite.service.ListWebhooks(context.Background(), tenantID, filter, models.ListPage{Limit: 2, Sort: models.ListSortAppName, Order: models.ListOrderAsc})
+	suite.Require().NoError(err)
+	nextPage, err := suite.service.ListWebhooks(context.Background(), tenantID, filter, models.ListPage{Limit: 2, Cursor: firstPage.NextCursor})
+	suite.Require().NoError(err)
+
+	_, unsupportedErr := suite.service.ListWebhooks(context.Background(), tenantID, filter, models.ListPage{Sort: models.ListSortStatus})
+	_, reorderedErr := suite.service.ListWebhooks(context.Background(), tenantID, filter, models.ListPage{Cursor: firstPage.NextCursor, Order: models.ListOrderDesc})
+	_, malformedErr := suite.service.ListWebhooks(context.Background(), tenantID, filter, models.ListPage{Cursor: "not-a-cursor"})
+
+	// Assert
+	assert.NotEmpty(suite.T(), firstPage.NextCursor)
+	assert.Len(suite.T(), nextPage.Webhooks, 1)
+	assert.Empty(suite.T(), nextPage.NextCursor)
+	assert.ErrorIs(suite.T(), unsupportedErr, service.ErrInvalidListPage)
+	assert.ErrorIs(suite.T(), reorderedErr, service.ErrInvalidListPage)
+	assert.ErrorIs(suite.T(), malformedErr, service.ErrInvalidListPage)
+}
+
+// TestExportWebhooks tests that subscriptions are exported without generated receive endpoints, and with
+// their secrets only when asked for
+func (suite *WebhookServiceTestSuite) TestExportWebhooks() {
+	// Arrange
+	tenantID := "tenant-123"
+	receiveID := uuid.New()
+	subscriptions := []models.WebhookSubscription{
+		{
+			ID:                uuid.New(),
+			TenantID:          tenantID,
+			AppName:           "inventory-service",
+			TargetURL:         "https://inventory.example.com/webhooks",
+			SubscribedEvent:   "order.created",
+			Type:              models.WebhookTypePublic,
+			SecretToken:       "secret-1",
+			IsActive:          true,
+			MaxRetries:        5,
+			RetryDelaySeconds: 30,
+		},
+		{
+			ID:              receiveID,
+			TenantID:        tenantID,
+			AppName:         "receiver",
+			TargetURL:       "http://localhost:8080/api/webhooks/receive/" + receiveID.String(),
+			SubscribedEvent: "order.created",
+			Type:            models.WebhookTypePublic,
+			SecretToken:     "secret-2",
+		},
+	}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, tenantID, mock.Anything, mock.Anything).
+		Return(subscriptions, int64(len(subscriptions)), nil).
+		Twice()
+
+	// Act
+	export, err := suite.service.ExportWebhooks(context.Background(), tenantID, false)
+	withSecrets, secretsErr := suite.service.ExportWebhooks(context.Background(), tenantID, true)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), secretsErr)
+	assert.Equal(suite.T(), models.WebhookExportVersion, export.Version)
+	assert.Len(suite.T(), export.Subscriptions, 1)
+	exported := export.Subscriptions[0]
+	assert.Equal(suite.T(), subscriptions[0].ID, exported.SourceID)
+	assert.Equal(suite.T(), models.TargetTypeHTTP, exported.TargetType)
+	assert.Equal(suite.T(), &models.RetryPolicy{MaxRetries: 5, RetryDelaySeconds: 30}, exported.RetryPolicy)
+	assert.Nil(suite.T(), exported.SecretToken)
+	assert.False(suite.T(), export.IncludesSecrets)
+
+	assert.True(suite.T(), withSecrets.IncludesSecrets)
+	assert.Equal(suite.T(), stringPtr("secret-1"), withSecrets.Subscriptions[0].SecretToken)
+}
+
+// exportedSubscription returns an exported HTTP subscription
+func exportedSubscription(event, targetURL string) models.ExportedSubscription {
+	return models.ExportedSubscription{
+		SourceID:        uuid.New(),
+		AppName:         "inventory-service",
+		SubscribedEvent: event,
+		Type:            models.WebhookTypePublic,
+		IsActive:        true,
+		TargetType:      models.TargetTypeHTTP,
+		TargetURL:       targetURL,
+	}
+}
+
+// TestImportWebhooks_DryRunReportsConflicts tests that a dry run reports subscriptions the tenant already
+// has and duplicates within the export as conflicts, without creating anything
+func (suite *WebhookServiceTestSuite) TestImportWebhooks_DryRunReportsConflicts() {
+	// Arrange
+	existing := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-prod",
+		TargetURL:       "https://inventory.example.com/orders",
+		SubscribedEvent: "order.created",
+	}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, "tenant-prod", mock.Anything, mock.Anything).
+		Return([]models.WebhookSubscription{existing}, int64(1), nil).
+		Once()
+	export := &models.WebhookExport{
+		Version:  models.WebhookExportVersion,
+		TenantID: "tenant-staging",
+		Subscriptions: []models.ExportedSubscription{
+			exportedSubscription("order.created", "https://inventory.example.com/orders"),
+			exportedSubscription("order.shipped", "https://inventory.example.com/shipments"),
+			exportedSubscription("order.shipped", "https://inventory.example.com/shipments"),
+		},
+	}
+
+	// Act
+	result, err := suite.service.ImportWebhooks(context.Background(), export, models.WebhookImportOptions{
+		TenantID: "tenant-prod",
+		DryRun:   true,
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.Applied)
+	assert.Equal(suite.T(), 2, result.Conflicts)
+	assert.Equal(suite.T(), models.ImportStatusConflict, result.Results[0].Status)
+	assert.Equal(suite.T(), &existing.ID, result.Results[0].ConflictsWith)
+	assert.Equal(suite.T(), models.ImportStatusValid, result.Results[1].Status)
+	assert.Nil(suite.T(), result.Results[1].SecretToken)
+	assert.Equal(suite.T(), models.ImportStatusConflict, result.Results[2].Status)
+	assert.Contains(suite.T(), *result.Results[2].Error, "duplicates subscription 1")
+}
+
+// TestImportWebhooks_Secrets tests that exported secrets are kept unless regeneration is asked for, in which
+// case the new secret is returned
+func (suite *WebhookServiceTestSuite) TestImportWebhooks_Secrets() {
+	// Arrange
+	exported := exportedSubscription("order.created", "https://inventory.example.com/orders")
+	exported.SecretToken = stringPtr("exported-secret")
+	export := &models.WebhookExport{
+		Version:       models.WebhookExportVersion,
+		TenantID:      "tenant-prod",
+		Subscriptions: []models.ExportedSubscription{exported},
+	}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, "tenant-prod", mock.Anything, mock.Anything).
+		Return(nil, int64(0), nil).
+		Twice()
+	var created []string
+	suite.mockRepo.EXPECT().
+		CreateSubscription(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, sub *models.WebhookSubscription) error {
+			created = append(created, sub.SecretToken)
+			return nil
+		}).
+		Twice()
+
+	// Act
+	kept, err := suite.service.ImportWebhooks(context.Background(), export, models.WebhookImportOptions{})
+	regenerated, regenerateErr := suite.service.ImportWebhooks(context.Background(), export, models.WebhookImportOptions{
+		RegenerateSecrets: true,
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), regenerateErr)
+	assert.True(suite.T(), kept.Applied)
+	assert.Equal(suite.T(), 1, kept.Created)
+	assert.Equal(suite.T(), models.ImportStatusCreated, kept.Results[0].Status)
+	assert.NotNil(suite.T(), kept.Results[0].WebhookID)
+	assert.Nil(suite.T(), kept.Results[0].SecretToken)
+	assert.Equal(suite.T(), "exported-secret", created[0])
+
+	assert.NotEqual(suite.T(), "exported-secret", created[1])
+	assert.Equal(suite.T(), &created[1], regenerated.Results[0].SecretToken)
+}
+
+// TestImportWebhooks_RejectsInvalid tests that an invalid subscription rejects the whole import
+func (suite *WebhookServiceTestSuite) TestImportWebhooks_RejectsInvalid() {
+	// Arrange
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, "tenant-prod", mock.Anything, mock.Anything).
+		Return(nil, int64(0), nil).
+		Once()
+	export := &models.WebhookExport{
+		Version:  models.WebhookExportVersion,
+		TenantID: "tenant-prod",
+		Subscriptions: []models.ExportedSubscription{
+			exportedSubscription("order.created", "https://inventory.example.com/orders"),
+			exportedSubscription("order.shipped", "ftp://inventory.example.com/shipments"),
+		},
+	}
+
+	// Act
+	result, err := suite.service.ImportWebhooks(context.Background(), export, models.WebhookImportOptions{})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.Applied)
+	assert.Equal(suite.T(), 1, result.Invalid)
+	assert.Equal(suite.T(), models.ImportStatusNotImported, result.Results[0].Status)
+	assert.Equal(suite.T(), models.ImportStatusInvalid, result.Results[1].Status)
+	assert.Contains(suite.T(), *result.Results[1].Error, "invalid target_url")
+}
+
+// TestImportWebhooks_UnsupportedVersion tests that exports of other format versions are refused
+func (suite *WebhookServiceTestSuite) TestImportWebhooks_UnsupportedVersion() {
+	// Act
+	result, err := suite.service.ImportWebhooks(context.Background(), &models.WebhookExport{Version: 2, TenantID: "tenant-prod"}, models.WebhookImportOptions{})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.ErrorContains(suite.T(), err, "unsupported export version 2")
+}
+
+// TestApplyConfig_DryRunPlansChanges tests that a dry run plans creating the subscriptions a manifest adds and
+// deleting those it omits, resolving chains against the planned subscriptions, without changing anything
+func (suite *WebhookServiceTestSuite) TestApplyConfig_DryRunPlansChanges() {
+	// Arrange
+	omitted := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-prod",
+		AppName:         "legacy-service",
+		TargetURL:       "https://legacy.example.com/orders",
+		SubscribedEvent: "order.created",
+	}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, "tenant-prod", mock.Anything, mock.Anything).
+		Return([]models.WebhookSubscription{omitted}, int64(1), nil).
+		Once()
+	chain := models.ChainTemplate{Name: "Order Fulfillment", TriggerEvent: "order.paid"}
+	suite.mockChainSvc.EXPECT().
+		SyncChains(mock.Anything, "tenant-prod", []models.ChainTemplate{chain}, mock.Anything, true).
+		RunAndReturn(func(_ context.Context, _ string, _ []models.ChainTemplate, subscriptions []models.WebhookSubscription, _ bool) ([]models.ConfigResourceChange, error) {
+			assert.Len(suite.T(), subscriptions, 1)
+			assert.Equal(suite.T(), "order.shipped", subscriptions[0].SubscribedEvent)
+			return []models.ConfigResourceChange{{ResourceType: models.ConfigResourceChain, Action: models.ConfigActionCreate, Name: chain.Name}}, nil
+		}).
+		Once()
+	manifest := &models.ConfigManifest{
+		Version:       models.ConfigManifestVersion,
+		TenantID:      "tenant-prod",
+		Subscriptions: []models.ExportedSubscription{exportedSubscription("order.shipped", "https://inventory.example.com/shipments")},
+		Chains:        []models.ChainTemplate{chain},
+	}
+
+	// Act
+	result, err := suite.service.ApplyConfig(context.Background(), manifest, models.ConfigApplyOptions{DryRun: true})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.Applied)
+	assert.Equal(suite.T(), 2, result.Created)
+	assert.Equal(suite.T(), 1, result.Deleted)
+	assert.Len(suite.T(), result.Changes, 3)
+	assert.Equal(suite.T(), models.ConfigActionCreate, result.Changes[0].Action)
+	assert.Nil(suite.T(), result.Changes[0].SecretToken)
+	assert.Equal(suite.T(), models.ConfigActionDelete, result.Changes[1].Action)
+	assert.Equal(suite.T(), &omitted.ID, result.Changes[1].ResourceID)
+	assert.Equal(suite.T(), models.ConfigResourceChain, result.Changes[2].ResourceType)
+}
+
+// TestApplyConfig_InvalidEntryAppliesNothing tests that a manifest with an invalid entry is not applied
+func (suite *WebhookServiceTestSuite) TestApplyConfig_InvalidEntryAppliesNothing() {
+	// Arrange
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsByTenant(mock.Anything, "tenant-prod", mock.Anything, mock.Anything).
+		Return(nil, int64(0), nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		SyncChains(mock.Anything, "tenant-prod", mock.Anything, mock.Anything, true).
+		Return(nil, nil).
+		Once()
+	manifest := &models.ConfigManifest{
+		Version:  models.ConfigManifestVersion,
+		TenantID: "tenant-prod",
+		Subscriptions: []models.ExportedSubscription{
+			exportedSubscription("order.created", "https://inventory.example.com/orders"),
+			exportedSubscription("order.created", "https://inventory.example.com/orders"),
+		},
+	}
+
+	// Act
+	result, err := suite.service.ApplyConfig(context.Background(), manifest, models.ConfigApplyOptions{})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.Applied)
+	assert.Equal(suite.T(), 1, result.Invalid)
+	assert.Equal(suite.T(), models.ConfigActionInvalid, result.Changes[1].Action)
+	assert.Contains(suite.T(), *result.Changes[1].Error, "duplicates subscription 0")
+}
+
+// TestReleasePausedDeliveries_SkipsSubscriptionLockedElsewhere tests that a paused subscription whose queue
+// another instance is releasing is left to that instance
+func (suite *WebhookServiceTestSuite) TestReleasePausedDeliveries_SkipsSubscriptionLockedElsewhere() {
+	// Arrange
+	locks := mocks.NewMockLockRepository(suite.T())
+	suite.service.SetLocks(locks)
+	subscription := models.WebhookSubscription{ID: uuid.New(), TenantID: "tenant-prod", TargetURL: suite.testServer.URL}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsPausedUntil(mock.Anything, mock.Anything).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	locks.EXPECT().
+		TryWithLock(mock.Anything, "receiver-pause:"+subscription.ID.String(), mock.Anything).
+		Return(false, nil).
+		Once()
+
+	// Act
+	released, err := suite.service.ReleasePausedDeliveries(context.Background())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, released)
+}
+
+// TestReleasePausedDeliveries_SkipsQueueReleasedElsewhere tests that a subscription another instance resumed
+// after the paused subscriptions were loaded is neither sent to nor resumed again
+func (suite *WebhookServiceTestSuite) TestReleasePausedDeliveries_SkipsQueueReleasedElsewhere() {
+	// Arrange
+	locks := mocks.NewMockLockRepository(suite.T())
+	suite.service.SetLocks(locks)
+	pausedUntil := time.Now().Add(-time.Minute)
+	subscription := models.WebhookSubscription{ID: uuid.New(), TenantID: "tenant-prod", TargetURL: suite.testServer.URL, PausedUntil: &pausedUntil}
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsPausedUntil(mock.Anything, mock.Anything).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	locks.EXPECT().
+		TryWithLock(mock.Anything, "receiver-pause:"+subscription.ID.String(), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, fn func() error) (bool, error) {
+			return true, fn()
+		}).
+		Once()
+	resumed := subscription
+	resumed.PausedUntil = nil
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, subscription.ID).
+		Return(&resumed, nil).
+		Once()
+
+	// Act
+	released, err := suite.service.ReleasePausedDeliveries(context.Background())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, released)
+}
+
+// Helper functions
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// TestSendEvent_Batched tests that an event for a batching subscription is buffered and stays pending
+func (suite *WebhookServiceTestSuite) TestSendEvent_Batched() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "order.created",
+		Source:   "shop-service",
+		Payload:  map[string]interface{}{"order_id": "42"},
+	}
+	subscription := models.WebhookSubscription{
+		ID:                 uuid.New(),
+		TenantID:           req.TenantID,
+		TargetURL:          suite.testServer.URL + "/success",
+		SubscribedEvent:    req.Event,
+		Type:               models.WebhookTypePublic,
+		SecretToken:        "test-secret",
+		BatchMaxEvents:     100,
+		BatchWindowSeconds: 10,
+		IsActive:           true,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateBatchedDelivery(mock.Anything, mock.MatchedBy(func(delivery *models.BatchedDelivery) bool {
+			return delivery.SubscriptionID == subscription.ID && strings.Contains(delivery.Payload, `"order_id":"42"`)
+		})).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusPending
+		})).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert - nothing is sent until the batch is due
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalQueued)
+	assert.True(suite.T(), result.Webhooks[0].Batched)
+	assert.Empty(suite.T(), suite.attempts)
+}
+
+// TestFlushDeliveryBatches_ReportsPartialFailures tests that a due batch is sent as one signed array and that
+// the events the receiver reports as rejected fail while the others are sent
+func (suite *WebhookServiceTestSuite) TestFlushDeliveryBatches_ReportsPartialFailures() {
+	// Arrange
+	accepted, rejected := uuid.New(), uuid.New()
+	var received []models.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(models.DefaultSignatureHeader) == "" || json.NewDecoder(r.Body).Decode(&received) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"failed": [{"event_id": "` + rejected.String() + `", "error": "duplicate order"}]}`))
+	}))
+	defer server.Close()
+
+	subscription := models.WebhookSubscription{
+		ID:                 uuid.New(),
+		TenantID:           "tenant-123",
+		TargetURL:          server.URL,
+		Type:               models.WebhookTypePublic,
+		SecretToken:        "test-secret",
+		BatchMaxEvents:     2,
+		BatchWindowSeconds: 60,
+	}
+	deliveries := make([]models.BatchedDelivery, 0, 2)
+	for _, eventID := range []uuid.UUID{accepted, rejected} {
+		payload, _ := json.Marshal(models.WebhookPayload{Event: "order.created", EventID: eventID})
+		deliveries = append(deliveries, models.BatchedDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, EventID: eventID, Payload: string(payload), CreatedAt: time.Now()})
+	}
+
+	suite.mockRepo.EXPECT().
+		GetSubscriptionsWithDueBatches(mock.Anything, mock.Anything).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetBatchedDeliveries(mock.Anything, subscription.ID, 2).
+		Return(deliveries, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetBatchedDeliveries(mock.Anything, subscription.ID, 2).
+		Return(nil, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		DeleteBatchedDeliveries(mock.Anything, []uuid.UUID{deliveries[0].ID, deliveries[1].ID}).
+		Return(nil).
+		Once()
+	for _, eventID := range []uuid.UUID{accepted, rejected} {
+		suite.mockRepo.EXPECT().
+			GetEventByID(mock.Anything, eventID).
+			Return(&models.WebhookEvent{ID: eventID, Status: models.WebhookStatusPending}, nil).
+			Once()
+	}
+	suite.mockRepo.EXPECT().CountQueuedDeliveriesByEvent(mock.Anything, accepted).Return(0, nil).Once()
+	suite.mockRepo.EXPECT().CountBatchedDeliveriesByEvent(mock.Anything, accepted).Return(0, nil).Once()
+	suite.mockRepo.EXPECT().CountOrderedDeliveriesByEvent(mock.Anything, accepted).Return(0, nil).Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.ID == accepted && event.Status == models.WebhookStatusSent
+		})).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.ID == rejected && event.Status == models.WebhookStatusFailed &&
+				strings.Contains(*event.LastError, "duplicate order")
+		})).
+		Return(nil).
+		Once()
+
+	// Act
+	sent, err := suite.service.FlushDeliveryBatches(context.Background())
+
+	// Assert - one request carried both events, recorded as one attempt
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, sent)
+	if assert.Len(suite.T(), received, 2) {
+		assert.Equal(suite.T(), accepted, received[0].EventID)
+		assert.Equal(suite.T(), rejected, received[1].EventID)
+	}
+	assert.Len(suite.T(), suite.attempts, 1)
+}
+
+// TestSendEvent_OrderedSentWhenFirstInLine tests that an event with an ordering key is queued once the event is
+// stored, and sent right away when no earlier delivery with the key is waiting
+func (suite *WebhookServiceTestSuite) TestSendEvent_OrderedSentWhenFirstInLine() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID:    "tenant-123",
+		Event:       "order.updated",
+		Source:      "shop-service",
+		Payload:     map[string]interface{}{"status": "paid"},
+		OrderingKey: "order-123",
+	}
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        req.TenantID,
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: req.Event,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		IsActive:        true,
+	}
+	var queued *models.OrderedDelivery
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.OrderingKey == req.OrderingKey
+		})).
+		Return(nil).
+		Once()
+	stored := suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusPending && event.Attempts == 1
+		})).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateOrderedDelivery(mock.Anything, mock.MatchedBy(func(delivery *models.OrderedDelivery) bool {
+			return delivery.SubscriptionID == subscription.ID && delivery.OrderingKey == req.OrderingKey &&
+				strings.Contains(delivery.Payload, `"ordering_key":"order-123"`)
+		})).
+		RunAndReturn(func(_ context.Context, delivery *models.OrderedDelivery) error {
+			queued = delivery
+			return nil
+		}).
+		Once().
+		NotBefore(stored)
+	suite.mockRepo.EXPECT().
+		ClaimOrderedDelivery(mock.Anything, subscription.ID, req.OrderingKey, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, uuid.UUID, string, time.Time, time.Time) (*models.OrderedDelivery, error) {
+			return queued, nil
+		}).
+		Once()
+	suite.mockRepo.EXPECT().
+		DeleteOrderedDelivery(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetEventByID(mock.Anything, mock.Anything).
+		Return(&models.WebhookEvent{Status: models.WebhookStatusPending, Attempts: 1}, nil).
+		Once()
+	suite.mockRepo.EXPECT().CountQueuedDeliveriesByEvent(mock.Anything, mock.Anything).Return(0, nil).Once()
+	suite.mockRepo.EXPECT().CountBatchedDeliveriesByEvent(mock.Anything, mock.Anything).Return(0, nil).Once()
+	suite.mockRepo.EXPECT().CountOrderedDeliveriesByEvent(mock.Anything, mock.Anything).Return(0, nil).Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusSent
+		})).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		ClaimOrderedDelivery(mock.Anything, subscription.ID, req.OrderingKey, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.Equal(suite.T(), 0, result.TotalQueued)
+	assert.True(suite.T(), result.Webhooks[0].Success)
+	assert.Len(suite.T(), suite.attempts, 1)
+}
+
+// TestSendEvent_OrderedWaitsBehindEarlierDelivery tests that an event with an ordering key is left queued while
+// an earlier delivery with the same key is being sent
+func (suite *WebhookServiceTestSuite) TestSendEvent_OrderedWaitsBehindEarlierDelivery() {
+	// Arrange
+	req := &models.SendEventRequest{
+		TenantID:    "tenant-123",
+		Event:       "order.updated",
+		Source:      "shop-service",
+		Payload:     map[string]interface{}{"status": "shipped"},
+		OrderingKey: "order-123",
+	}
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        req.TenantID,
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: req.Event,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		IsActive:        true,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Status == models.WebhookStatusPending
+		})).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateOrderedDelivery(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		ClaimOrderedDelivery(mock.Anything, subscription.ID, req.OrderingKey, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
 		Once()
 
 	// Act
-	err := suite.service.VerifyWebhook(webhookID, payload, signature, timestamp, "")
+	result, err := suite.service.SendEvent(context.Background(), req)
 
-	// Assert
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "subscription not found")
+	// Assert - the sender of the earlier delivery sends this one next
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalQueued)
+	assert.True(suite.T(), result.Webhooks[0].Queued)
+	assert.Empty(suite.T(), suite.attempts)
 }
 
-// TestListWebhooks_Success tests successful webhook listing
-func (suite *WebhookServiceTestSuite) TestListWebhooks_Success() {
+// TestSendEvent_CompressedAndOffloaded tests that a large body is stored and delivered as a signed reference,
+// gzip-compressed for a subscription asking for it
+func (suite *WebhookServiceTestSuite) TestSendEvent_CompressedAndOffloaded() {
 	// Arrange
-	tenantID := "tenant-123"
-	page := 1
-	limit := 10
+	var encoding string
+	var received map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		payload, err := client.NewVerifier("test-secret").VerifyRequest(r)
+		if err != nil || json.NewDecoder(r.Body).Decode(&received) != nil || payload.PayloadRef == nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
 
-	subscriptions := []models.WebhookSubscription{
-		{
-			ID:              uuid.New(),
-			TenantID:        tenantID,
-			AppName:         "app1",
-			SubscribedEvent: "user.created",
-			Type:            models.WebhookTypePublic,
-			IsActive:        true,
-		},
-		{
-			ID:              uuid.New(),
-			TenantID:        tenantID,
-			AppName:         "app2",
-			SubscribedEvent: "order.completed",
-			Type:            models.WebhookTypePrivate,
-			IsActive:        true,
-		},
+	req := &models.SendEventRequest{
+		TenantID: "tenant-123",
+		Event:    "report.generated",
+		Source:   "reports",
+		Payload:  map[string]interface{}{"rows": strings.Repeat("x", 2048)},
+	}
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        req.TenantID,
+		TargetURL:       server.URL,
+		SubscribedEvent: req.Event,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		ContentEncoding: models.ContentEncodingGzip,
+		IsActive:        true,
 	}
+	store := mocks.NewMockPayloadStore(suite.T())
+	suite.service.SetPayloadOffload(store, 1024, 0)
+
+	var stored []byte
+	store.EXPECT().
+		Put(mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "deliveries/tenant-123/"+subscription.ID.String()+"/")
+		}), mock.Anything, "application/json").
+		RunAndReturn(func(_ context.Context, _ string, body []byte, _ string) error {
+			stored = body
+			return nil
+		}).
+		Once()
+	store.EXPECT().
+		PresignGet(mock.Anything, mock.Anything, service.DefaultPayloadURLTTL).
+		Return("https://payloads.example.com/signed", nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		CreateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
+		Once()
 
-	// Mock repository call
+	// Act
+	result, err := suite.service.SendEvent(context.Background(), req)
+
+	// Assert - the receiver got the envelope without its payload, pointing to the stored delivery
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalSent)
+	assert.Equal(suite.T(), "gzip", encoding)
+	assert.Contains(suite.T(), string(stored), strings.Repeat("x", 2048))
+	assert.NotContains(suite.T(), received, "payload")
+	var ref models.PayloadReference
+	assert.NoError(suite.T(), json.Unmarshal(received["payload_ref"], &ref))
+	assert.Equal(suite.T(), "https://payloads.example.com/signed", ref.URL)
+	assert.Equal(suite.T(), len(stored), ref.Size)
+}
+
+// TestSendEvent_SensitiveFieldsProtected tests that a scheduled event is stored with its sensitive fields
+// encrypted, delivered as sent, and stored with redacted fields redacted once delivered
+func (suite *WebhookServiceTestSuite) TestSendEvent_SensitiveFieldsProtected() {
+	// Arrange
+	cipher, err := repository.NewSecretCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	assert.NoError(suite.T(), err)
+	repository.ConfigureEncryption(cipher)
+	defer repository.ConfigureEncryption(nil)
+	suite.tenantSettings.SensitiveFields = []models.SensitiveField{
+		{Path: "$.card.number", Action: models.SensitiveFieldRedact},
+		{Path: "$.email", Action: models.SensitiveFieldEncrypt},
+	}
+
+	var received models.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	req := &models.SendEventRequest{
+		TenantID:     "tenant-123",
+		Event:        "payment.scheduled",
+		Source:       "billing-service",
+		Payload:      map[string]interface{}{"card": map[string]interface{}{"number": "4242424242424242"}, "email": "ann@example.com", "amount": 42.0},
+		DelaySeconds: 60,
+	}
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        req.TenantID,
+		TargetURL:       server.URL,
+		SubscribedEvent: req.Event,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		IsActive:        true,
+	}
+
+	var scheduled, delivered *models.WebhookEvent
 	suite.mockRepo.EXPECT().
-		GetSubscriptionsByTenant(tenantID, 0, limit).
-		Return(subscriptions, int64(2), nil).
+		CreateEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, event *models.WebhookEvent) {
+			copied := *event
+			scheduled = &copied
+		}).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetDueScheduledEvents(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, time.Time, int) ([]models.WebhookEvent, error) {
+			return []models.WebhookEvent{*scheduled}, nil
+		}).
+		Once()
+	suite.mockRepo.EXPECT().
+		ClaimScheduledEvent(mock.Anything, mock.Anything).
+		Return(true, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, event *models.WebhookEvent) {
+			delivered = event
+		}).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
 		Once()
 
 	// Act
-	result, err := suite.service.ListWebhooks(tenantID, page, limit)
+	_, err = suite.service.SendEvent(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	_, err = suite.service.DispatchScheduledEvents(context.Background())
 
-	// Assert
+	// Assert - the stored copies never hold the sensitive values in plaintext
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), result)
-	assert.Len(suite.T(), result.Webhooks, 2)
-	assert.Equal(suite.T(), int64(2), result.Total)
-	assert.Equal(suite.T(), page, result.Page)
-	assert.Equal(suite.T(), limit, result.Limit)
+	assert.NotContains(suite.T(), scheduled.Payload, "4242424242424242")
+	assert.NotContains(suite.T(), scheduled.Payload, "ann@example.com")
+	assert.Equal(suite.T(), req.Payload, received.Payload)
+	assert.Contains(suite.T(), delivered.Payload, `"number":"[REDACTED]"`)
+	assert.NotContains(suite.T(), delivered.Payload, "ann@example.com")
+	assert.Contains(suite.T(), delivered.Payload, `"email":"enc:v1:`)
+	assert.Contains(suite.T(), delivered.Payload, `"amount":42`)
 }
 
-// TestListWebhooks_RepositoryError tests listing when repository returns error
-func (suite *WebhookServiceTestSuite) TestListWebhooks_RepositoryError() {
+// TestSendEvent_SubjectErased tests that sensitive fields of a payload identifying a data subject are encrypted
+// with the subject's key, and delivered as [ERASED] once the subject is erased before the event is due
+func (suite *WebhookServiceTestSuite) TestSendEvent_SubjectErased() {
 	// Arrange
-	tenantID := "tenant-123"
-	page := 1
-	limit := 10
+	cipher, err := repository.NewSecretCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	assert.NoError(suite.T(), err)
+	repository.ConfigureEncryption(cipher)
+	defer repository.ConfigureEncryption(nil)
+	suite.tenantSettings.TenantID = "tenant-123"
+	suite.tenantSettings.SubjectPath = "$.customer_id"
+	suite.tenantSettings.SensitiveFields = []models.SensitiveField{
+		{Path: "$.email", Action: models.SensitiveFieldEncrypt},
+	}
 
-	// Mock repository call - return error
+	var received models.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	req := &models.SendEventRequest{
+		TenantID:     "tenant-123",
+		Event:        "customer.updated",
+		Source:       "crm-service",
+		Payload:      map[string]interface{}{"customer_id": "cust-42", "email": "ann@example.com"},
+		DelaySeconds: 60,
+	}
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        req.TenantID,
+		TargetURL:       server.URL,
+		SubscribedEvent: req.Event,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		IsActive:        true,
+	}
+
+	var subjectKey *models.SubjectKey
+	suite.mockTenantRepo.EXPECT().
+		GetOrCreateSubjectKey(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, key *models.SubjectKey) (*models.SubjectKey, error) {
+			subjectKey = key
+			return key, nil
+		}).
+		Once()
+	// The subject is erased before the event is due, so its key is gone
+	suite.mockTenantRepo.EXPECT().
+		GetSubjectKey(mock.Anything, req.TenantID, mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	var scheduled *models.WebhookEvent
 	suite.mockRepo.EXPECT().
-		GetSubscriptionsByTenant(tenantID, 0, limit).
-		Return(nil, int64(0), fmt.Errorf("database error")).
+		CreateEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, event *models.WebhookEvent) {
+			copied := *event
+			scheduled = &copied
+		}).
+		Return(nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetDueScheduledEvents(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, time.Time, int) ([]models.WebhookEvent, error) {
+			return []models.WebhookEvent{*scheduled}, nil
+		}).
+		Once()
+	suite.mockRepo.EXPECT().
+		ClaimScheduledEvent(mock.Anything, mock.Anything).
+		Return(true, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, req.TenantID, req.Event).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, req.TenantID, req.Event, mock.Anything).
+		Return(nil).
 		Once()
 
 	// Act
-	result, err := suite.service.ListWebhooks(tenantID, page, limit)
+	_, err = suite.service.SendEvent(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	_, err = suite.service.DispatchScheduledEvents(context.Background())
 
 	// Assert
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), result)
-	assert.Contains(suite.T(), err.Error(), "failed to fetch webhooks")
+	assert.NoError(suite.T(), err)
+	if !assert.NotNil(suite.T(), subjectKey) {
+		return
+	}
+	assert.NotContains(suite.T(), subjectKey.SubjectHash, "cust-42")
+	assert.Contains(suite.T(), scheduled.Payload, `"email":"enc:subject:`+subjectKey.ID.String()+`:`)
+	assert.Equal(suite.T(), map[string]interface{}{"customer_id": "cust-42", "email": models.ErasedValue}, received.Payload)
 }
 
-// Helper functions
-func stringPtr(s string) *string {
-	return &s
+// TestDisableFailingSubscriptions_DisablesAndNotifies tests that a subscription failing under its tenant's
+// auto-disable policy is disabled with a reason, recorded in its history and reported to the notification subscription
+func (suite *WebhookServiceTestSuite) TestDisableFailingSubscriptions_DisablesAndNotifies() {
+	// Arrange
+	var notification models.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&notification)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifyID := uuid.New()
+	policy := models.AutoDisablePolicy{Failures: 50, Days: 7, NotifyWebhookID: &notifyID}
+	failing := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        "tenant-123",
+		AppName:         "legacy-crm",
+		TargetURL:       "https://crm.example.com/gone",
+		SubscribedEvent: "user.created",
+		IsActive:        true,
+	}
+
+	suite.mockTenantRepo.EXPECT().
+		ListAutoDisablePolicies(mock.Anything).
+		Return([]models.TenantSettings{{TenantID: "tenant-123", AutoDisable: policy}}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetFailingSubscriptions(mock.Anything, "tenant-123", mock.Anything, 50).
+		Return([]models.WebhookSubscription{failing}, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		DisableSubscription(mock.Anything, failing.ID, mock.Anything, "auto-disabled: 50 or more deliveries failed and none succeeded in 7 days").
+		Return(true, nil).
+		Once()
+	suite.mockRepo.EXPECT().
+		GetSubscriptionByID(mock.Anything, notifyID).
+		Return(&models.WebhookSubscription{ID: notifyID, TenantID: "tenant-123", TargetURL: server.URL, Type: models.WebhookTypePublic, SecretToken: "test-secret", IsActive: true}, nil)
+
+	// Act
+	disabled, err := suite.service.DisableFailingSubscriptions(context.Background())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, disabled)
+	suite.mockHistory.AssertCalled(suite.T(), "CreateSnapshot", mock.Anything, mock.MatchedBy(func(snapshot *models.ConfigSnapshot) bool {
+		return snapshot.ResourceID == failing.ID && snapshot.Change == models.ConfigChangeDisabled
+	}))
+	assert.Equal(suite.T(), "loki.webhook.disabled", notification.Event)
+	if payload, ok := notification.Payload.(map[string]interface{}); assert.True(suite.T(), ok) {
+		assert.Equal(suite.T(), failing.ID.String(), payload["webhook_id"])
+		assert.Equal(suite.T(), "warning", payload["severity"])
+	}
 }
 
-func boolPtr(b bool) *bool {
-	return &b
+// TestEnableWebhook_ClearsDisabledReason tests that enabling a disabled subscription activates it, clears why it
+// was disabled and records it in its history
+func (suite *WebhookServiceTestSuite) TestEnableWebhook_ClearsDisabledReason() {
+	// Arrange
+	disabledAt := time.Now().Add(-time.Hour)
+	subscription := &models.WebhookSubscription{
+		ID:             uuid.New(),
+		TenantID:       "tenant-123",
+		IsActive:       false,
+		DisabledAt:     &disabledAt,
+		DisabledReason: "auto-disabled: 50 or more deliveries failed and none succeeded in 7 days",
+	}
+	suite.mockRepo.EXPECT().GetSubscriptionByID(mock.Anything, subscription.ID).Return(subscription, nil).Once()
+	suite.mockRepo.EXPECT().EnableSubscription(mock.Anything, subscription.ID, mock.Anything).Return(nil).Once()
+
+	// Act
+	enabled, err := suite.service.EnableWebhook(context.Background(), "", subscription.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), enabled.IsActive)
+	assert.Nil(suite.T(), enabled.DisabledAt)
+	assert.Empty(suite.T(), enabled.DisabledReason)
+	assert.NotNil(suite.T(), enabled.ReenabledAt)
+	suite.mockHistory.AssertCalled(suite.T(), "CreateSnapshot", mock.Anything, mock.MatchedBy(func(snapshot *models.ConfigSnapshot) bool {
+		return snapshot.ResourceID == subscription.ID && snapshot.Change == models.ConfigChangeEnabled
+	}))
 }
 
 // TestWebhookServiceTestSuite runs the test suite
 func TestWebhookServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(WebhookServiceTestSuite))
 }
+
+// TestGetTenantLatencySLO_Compliance tests that the latency SLO report measures deliveries against the tenant's
+// target, falls back to the default objective and lists the least compliant subscriptions first
+func (suite *WebhookServiceTestSuite) TestGetTenantLatencySLO_Compliance() {
+	// Arrange
+	suite.tenantSettings.LatencySLO = models.LatencySLO{TargetMs: 2000}
+	fastID, slowID := uuid.New(), uuid.New()
+	suite.mockRepo.EXPECT().
+		GetLatencySLOStats(mock.Anything, "tenant-123", (*uuid.UUID)(nil), mock.Anything, int64(2000)).
+		Return(&models.LatencySLOReport{
+			LatencySLOStats: models.LatencySLOStats{Deliveries: 1000, WithinTarget: 985},
+			Subscriptions: []models.SubscriptionLatencySLO{
+				{WebhookID: fastID, LatencySLOStats: models.LatencySLOStats{Deliveries: 800, WithinTarget: 800}},
+				{WebhookID: slowID, LatencySLOStats: models.LatencySLOStats{Deliveries: 200, WithinTarget: 185}},
+			},
+		}, nil).
+		Once()
+
+	// Act
+	report, err := suite.service.GetTenantLatencySLO(context.Background(), "tenant-123", models.StatsWindowWeek)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2000), report.TargetMs)
+	assert.Equal(suite.T(), models.DefaultLatencySLOObjective, report.Objective)
+	assert.NotNil(suite.T(), report.Since)
+	if assert.NotNil(suite.T(), report.Compliance) {
+		assert.InDelta(suite.T(), 98.5, *report.Compliance, 0.001)
+	}
+	assert.False(suite.T(), report.MeetsObjective)
+	if assert.Len(suite.T(), report.Subscriptions, 2) {
+		assert.Equal(suite.T(), slowID, report.Subscriptions[0].WebhookID)
+		assert.InDelta(suite.T(), 92.5, *report.Subscriptions[0].Compliance, 0.001)
+		assert.False(suite.T(), report.Subscriptions[0].MeetsObjective)
+		assert.Equal(suite.T(), fastID, report.Subscriptions[1].WebhookID)
+		assert.True(suite.T(), report.Subscriptions[1].MeetsObjective)
+	}
+}
+
+// TestSendEvent_DeliveriesPaused tests that events of a tenant whose deliveries are paused, on their own or
+// globally, are held as pending events without being delivered or triggering chains
+func (suite *WebhookServiceTestSuite) TestSendEvent_DeliveriesPaused() {
+	pauses := map[string]models.DeliveryPause{
+		"tenant": {Scope: "tenant-123", PausedAt: time.Now()},
+		"global": {Scope: models.DeliveryPauseGlobal, PausedAt: time.Now()},
+	}
+	for name, pause := range pauses {
+		suite.Run(name, func() {
+			// Arrange
+			suite.deliveryPauses = []models.DeliveryPause{pause}
+			req := &models.SendEventRequest{
+				TenantID: "tenant-123",
+				Event:    "order.created",
+				Source:   "shop-service",
+				Payload:  map[string]interface{}{"order_id": "123"},
+			}
+
+			suite.mockRepo.EXPECT().
+				CreateEvent(mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+					return event.Status == models.WebhookStatusPending && event.HeldAt != nil
+				})).
+				Return(nil).
+				Once()
+
+			// Act
+			result, err := suite.service.SendEvent(context.Background(), req)
+
+			// Assert - subscriptions are matched and chains triggered only once the event is drained
+			if assert.NoError(suite.T(), err) {
+				assert.True(suite.T(), result.Held)
+				assert.Len(suite.T(), result.Webhooks, 0)
+			}
+		})
+	}
+}
+
+// TestDispatchScheduledEvents_DeliveriesPaused tests that a scheduled event becoming due while its tenant's
+// deliveries are paused is held instead of delivered
+func (suite *WebhookServiceTestSuite) TestDispatchScheduledEvents_DeliveriesPaused() {
+	// Arrange
+	suite.deliveryPauses = []models.DeliveryPause{{Scope: "tenant-123", PausedAt: time.Now()}}
+	deliverAt := suite.clock.Now().Add(-time.Second)
+	event := models.WebhookEvent{
+		ID:        uuid.New(),
+		TenantID:  "tenant-123",
+		EventName: "trial.expiring",
+		Source:    "billing-service",
+		Payload:   `{"event":"trial.expiring"}`,
+		Status:    models.WebhookStatusScheduled,
+		DeliverAt: &deliverAt,
+	}
+
+	suite.mockRepo.EXPECT().
+		GetDueScheduledEvents(mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.WebhookEvent{event}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		ClaimScheduledEvent(mock.Anything, event.ID).
+		Return(true, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(updated *models.WebhookEvent) bool {
+			return updated.ID == event.ID && updated.Status == models.WebhookStatusPending && updated.HeldAt != nil
+		})).
+		Return(nil).
+		Once()
+
+	// Act
+	dispatched, err := suite.service.DispatchScheduledEvents(context.Background())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, dispatched)
+}
+
+// TestResumeDeliveries tests that resuming a pause keeps it draining at the requested rate, and that drain rates
+// out of range are rejected
+func (suite *WebhookServiceTestSuite) TestResumeDeliveries() {
+	// Arrange
+	suite.deliveryPauses = []models.DeliveryPause{{Scope: "tenant-123", PausedAt: time.Now(), Reason: "ERP upgrade"}}
+
+	suite.mockRepo.EXPECT().
+		SaveDeliveryPause(mock.Anything, mock.MatchedBy(func(pause *models.DeliveryPause) bool {
+			return pause.Scope == "tenant-123" && pause.ResumedAt != nil && pause.DrainRate == 25
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		CountHeldEvents(mock.Anything, "tenant-123", []string(nil)).
+		Return(int64(300), nil).
+		Once()
+
+	// Act
+	response, err := suite.service.ResumeDeliveries(context.Background(), "tenant-123", &models.ResumeDeliveriesRequest{DrainRate: 25})
+	_, tooFast := suite.service.ResumeDeliveries(context.Background(), "tenant-123", &models.ResumeDeliveriesRequest{DrainRate: models.MaxDrainRate + 1})
+
+	// Assert
+	if assert.NoError(suite.T(), err) {
+		assert.False(suite.T(), response.Paused)
+		assert.True(suite.T(), response.Draining)
+		assert.Equal(suite.T(), "ERP upgrade", response.Reason)
+		assert.Equal(suite.T(), 25, response.DrainRate)
+		assert.Equal(suite.T(), int64(300), response.HeldEvents)
+	}
+	assert.ErrorIs(suite.T(), tooFast, service.ErrInvalidDeliveryPause)
+}
+
+// TestDrainHeldEvents tests that a resumed pause delivers at most its drain rate of held events per second, and
+// is removed once none are left
+func (suite *WebhookServiceTestSuite) TestDrainHeldEvents() {
+	// Arrange
+	resumedAt := time.Now()
+	suite.deliveryPauses = []models.DeliveryPause{{Scope: "tenant-123", PausedAt: resumedAt.Add(-time.Hour), ResumedAt: &resumedAt, DrainRate: 2}}
+
+	eventID := uuid.New()
+	heldAt := resumedAt.Add(-time.Minute)
+	payload, _ := json.Marshal(models.WebhookPayload{
+		Event:   "order.created",
+		Source:  "shop-service",
+		Payload: map[string]interface{}{"order_id": "123"},
+		EventID: eventID,
+	})
+	event := models.WebhookEvent{
+		ID:        eventID,
+		TenantID:  "tenant-123",
+		EventName: "order.created",
+		Source:    "shop-service",
+		Payload:   string(payload),
+		Status:    models.WebhookStatusPending,
+		HeldAt:    &heldAt,
+	}
+	subscription := models.WebhookSubscription{
+		ID:              uuid.New(),
+		TenantID:        event.TenantID,
+		TargetURL:       suite.testServer.URL + "/success",
+		SubscribedEvent: event.EventName,
+		Type:            models.WebhookTypePublic,
+		SecretToken:     "test-secret",
+		IsActive:        true,
+	}
+
+	// A pass of 5 seconds at 2 events per second takes up to 10 events
+	suite.mockRepo.EXPECT().
+		GetHeldEvents(mock.Anything, "tenant-123", []string(nil), 10).
+		Return([]models.WebhookEvent{event}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		ReleaseHeldEvent(mock.Anything, eventID).
+		Return(true, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		GetActiveSubscriptionsByTenantAndEvent(mock.Anything, event.TenantID, event.EventName).
+		Return([]models.WebhookSubscription{subscription}, nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		UpdateEvent(mock.Anything, mock.MatchedBy(func(updated *models.WebhookEvent) bool {
+			return updated.ID == eventID && updated.Status == models.WebhookStatusSent && updated.HeldAt == nil
+		})).
+		Return(nil).
+		Once()
+
+	suite.mockChainSvc.EXPECT().
+		ExecuteChainByEvent(mock.Anything, event.TenantID, event.EventName, map[string]interface{}{"order_id": "123"}).
+		Return(nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		CountHeldEvents(mock.Anything, "tenant-123", []string(nil)).
+		Return(int64(0), nil).
+		Once()
+
+	suite.mockRepo.EXPECT().
+		DeleteResumedDeliveryPause(mock.Anything, "tenant-123").
+		Return(nil).
+		Once()
+
+	// Act
+	drained, err := suite.service.DrainHeldEvents(context.Background(), 5*time.Second)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, drained)
+}
+
+// TestDrainHeldEvents_GlobalPause tests that nothing is drained while the global pause lasts, even for tenants
+// whose own pause was resumed
+func (suite *WebhookServiceTestSuite) TestDrainHeldEvents_GlobalPause() {
+	// Arrange
+	resumedAt := time.Now()
+	suite.deliveryPauses = []models.DeliveryPause{
+		{Scope: models.DeliveryPauseGlobal, PausedAt: resumedAt.Add(-time.Hour)},
+		{Scope: "tenant-123", PausedAt: resumedAt.Add(-time.Hour), ResumedAt: &resumedAt, DrainRate: 10},
+	}
+
+	// Act
+	drained, err := suite.service.DrainHeldEvents(context.Background(), time.Second)
+
+	// Assert - held events are not loaded, so the mocks fail the test if they are
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, drained)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sakibcoolz/zcornor/pkg/security"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// webhookClaims are the claims of the JWTs private webhooks are authenticated with
+type webhookClaims struct {
+	WebhookID string `json:"webhook_id"`
+	TenantID  string `json:"tenant_id"`
+	AppName   string `json:"app_name"`
+	jwt.RegisteredClaims
+}
+
+// SetKeyring makes private webhook JWTs signed with the keyring instead of the single configured secret
+// Tokens issued before keep verifying through the security service
+func (s *webhookService) SetKeyring(keyring *Keyring) {
+	s.keyring = keyring
+}
+
+// generateWebhookSecurity generates the secret token and, for private webhooks, the JWT of a subscription
+// With a keyring the JWT is signed with its primary key and names it in the kid header
+func (s *webhookService) generateWebhookSecurity(ctx context.Context, isPrivate bool, tenantID, webhookID, appName string) (*security.WebhookSecurity, error) {
+	securityData, err := s.securitySvc.GenerateWebhookSecurity(isPrivate, tenantID, webhookID, appName)
+	if err != nil || !isPrivate || s.keyring == nil {
+		return securityData, err
+	}
+
+	now := s.clock.Now()
+	claims := webhookClaims{
+		WebhookID: webhookID,
+		TenantID:  tenantID,
+		AppName:   appName,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  webhookID,
+			Issuer:   "loki-suite",
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+	}
+	// JWT_TOKEN_EXPIRATION is in hours, as for tokens of the security service
+	if s.config.JWT.Exp > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(now.Add(time.Duration(s.config.JWT.Exp) * time.Hour))
+	}
+
+	token, err := s.keyring.Sign(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign webhook token: %w", err)
+	}
+	securityData.JWTToken = &token
+	return securityData, nil
+}
+
+// verifyWebhookToken verifies the JWT of a private webhook and returns its webhook and tenant IDs
+// Tokens naming a keyring key are verified with it; tokens without a kid with the security service
+func (s *webhookService) verifyWebhookToken(ctx context.Context, token string) (string, string, error) {
+	if s.keyring == nil || !hasKeyID(token) {
+		claims, err := s.securitySvc.VerifyJWTToken(token)
+		if err != nil {
+			return "", "", err
+		}
+		return claims.WebhookID, claims.TenantID, nil
+	}
+
+	claims := &webhookClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, s.keyring.Keyfunc(ctx),
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithTimeFunc(s.clock.Now)); err != nil {
+		return "", "", err
+	}
+	return claims.WebhookID, claims.TenantID, nil
+}
+
+// hasKeyID reports whether a token names the key it was signed with, without verifying it
+func hasKeyID(token string) bool {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return false
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid != ""
+}
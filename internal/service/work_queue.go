@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultWorkQueuePrefix prefixes the Redis keys of the work queues
+	DefaultWorkQueuePrefix = "loki:queue:"
+
+	// scheduledEventQueue holds the IDs of scheduled events, due at their delivery time
+	scheduledEventQueue = "scheduled-events"
+
+	// runAdmissionQueue holds the admission queues whose queued runs may be able to start, by tenant
+	runAdmissionQueue = "run-admission"
+
+	// allTenantsScope is the admission job of the queue shared by all tenants under a global run limit
+	allTenantsScope = "*"
+)
+
+// WorkQueue hands out the due jobs of the scheduled event and run admission queues to the instances sharing it
+// The database stays the record of the work: jobs are claimed there as they are without a work queue, so a job
+// handed out twice is processed once, and a job the queue loses is picked up by the next database sweep
+// Delivery and step retries are not queued: they wait in the memory of the instance making them, see sendDelivery
+type WorkQueue interface {
+	// Push adds a job to a queue, due at a time; pushing a waiting job again keeps its earliest due time
+	Push(ctx context.Context, queue, job string, due time.Time) error
+
+	// PopDue removes and returns up to limit jobs of a queue due at or before now, earliest first
+	PopDue(ctx context.Context, queue string, now time.Time, limit int) ([]string, error)
+}
+
+// popDueScript removes and returns the due jobs of a sorted set atomically, so each is handed out once
+var popDueScript = redis.NewScript(`
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+if #jobs > 0 then
+	redis.call('ZREM', KEYS[1], unpack(jobs))
+end
+return jobs`)
+
+// redisWorkQueue keeps every queue in a sorted set scored by the jobs' due time in Unix milliseconds
+type redisWorkQueue struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisWorkQueue returns a work queue kept on a Redis server, 6.2 or later
+// Parameters:
+//   - client: Client of the Redis server
+//   - prefix: Prefix of the queues' keys, DefaultWorkQueuePrefix when empty
+func NewRedisWorkQueue(client *redis.Client, prefix string) WorkQueue {
+	if prefix == "" {
+		prefix = DefaultWorkQueuePrefix
+	}
+	return &redisWorkQueue{client: client, prefix: prefix}
+}
+
+// Push adds the job with ZADD LT, which only ever moves a waiting job's due time earlier
+func (q *redisWorkQueue) Push(ctx context.Context, queue, job string, due time.Time) error {
+	return q.client.ZAddArgs(ctx, q.prefix+queue, redis.ZAddArgs{
+		LT:      true,
+		Members: []redis.Z{{Score: float64(due.UnixMilli()), Member: job}},
+	}).Err()
+}
+
+// PopDue removes and returns the due jobs with popDueScript
+func (q *redisWorkQueue) PopDue(ctx context.Context, queue string, now time.Time, limit int) ([]string, error) {
+	return popDueScript.Run(ctx, q.client, []string{q.prefix + queue},
+		strconv.FormatInt(now.UnixMilli(), 10), limit).StringSlice()
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sakibcoolz/loki-suite/internal/service"
+)
+
+// TestRedisWorkQueue tests that due jobs are popped earliest first up to the limit and only once, that pushing a
+// waiting job again only moves its due time earlier, and that queues and prefixes are kept apart
+func TestRedisWorkQueue(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	queue := service.NewRedisWorkQueue(client, "")
+	other := service.NewRedisWorkQueue(client, "other:")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	require.NoError(t, queue.Push(ctx, "events", "late", now.Add(time.Minute)))
+	require.NoError(t, queue.Push(ctx, "events", "first", now.Add(-2*time.Second)))
+	require.NoError(t, queue.Push(ctx, "events", "second", now.Add(-time.Second)))
+	require.NoError(t, queue.Push(ctx, "events", "moved", now.Add(time.Hour)))
+	require.NoError(t, queue.Push(ctx, "events", "moved", now))
+	require.NoError(t, queue.Push(ctx, "events", "first", now.Add(time.Hour)))
+	require.NoError(t, queue.Push(ctx, "runs", "tenant-1", now.Add(-time.Hour)))
+	require.NoError(t, other.Push(ctx, "events", "elsewhere", now.Add(-time.Hour)))
+
+	popped, err := queue.PopDue(ctx, "events", now, 2)
+	require.NoError(t, err)
+	rest, err := queue.PopDue(ctx, "events", now, 10)
+	require.NoError(t, err)
+	empty, err := queue.PopDue(ctx, "events", now, 10)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"first", "second"}, popped)
+	assert.Equal(t, []string{"moved"}, rest)
+	assert.Empty(t, empty)
+	assert.True(t, server.Exists(service.DefaultWorkQueuePrefix+"events"), "the late job is still waiting")
+	assert.True(t, server.Exists(service.DefaultWorkQueuePrefix+"runs"))
+	assert.True(t, server.Exists("other:events"))
+}
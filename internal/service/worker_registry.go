@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultMaxConcurrentRuns is the number of chain runs executed at once unless configured otherwise
+const defaultMaxConcurrentRuns = 32
+
+// errServerShutdown is the cancellation cause of runs stopped because the server is shutting down
+var errServerShutdown = errors.New("server shutdown")
+
+// runCancelledError is the cancellation cause of runs cancelled through the API
+type runCancelledError struct {
+	reason string
+}
+
+func (e *runCancelledError) Error() string {
+	return "run cancelled: " + e.reason
+}
+
+// worker is a chain run executing in a background goroutine
+type worker struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// workerRegistry tracks the chain runs executing in background goroutines
+// so they can be cancelled individually, and drained when the server shuts down
+// At most cap(slots) runs execute at once; further runs wait for a slot in their goroutine
+type workerRegistry struct {
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	workers  map[uuid.UUID]*worker
+	slots    chan struct{}
+	draining bool
+}
+
+// newWorkerRegistry creates an empty worker registry executing at most maxConcurrent runs at once
+func newWorkerRegistry(maxConcurrent int) *workerRegistry {
+	if maxConcurrent < 1 {
+		maxConcurrent = defaultMaxConcurrentRuns
+	}
+	return &workerRegistry{
+		workers: make(map[uuid.UUID]*worker),
+		slots:   make(chan struct{}, maxConcurrent),
+	}
+}
+
+// Go runs fn for the given run in a tracked goroutine once a worker slot is free
+// The context passed to fn carries the values of parent, such as the ID of the request that started the run,
+// but not its cancellation: it is cancelled when the run is cancelled or not drained in time;
+// context.Cause reports which of the two happened
+// When that happens while the run is still waiting for a slot, abandoned is called instead of fn
+// Returns false without starting fn once draining has begun
+func (r *workerRegistry) Go(parent context.Context, runID uuid.UUID, fn, abandoned func(ctx context.Context)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.draining {
+		return false
+	}
+
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(parent))
+	w := &worker{cancel: cancel, done: make(chan struct{})}
+	r.workers[runID] = w
+	r.wg.Add(1)
+
+	go func() {
+		defer func() {
+			r.mu.Lock()
+			delete(r.workers, runID)
+			r.mu.Unlock()
+			cancel(nil)
+			close(w.done)
+			r.wg.Done()
+		}()
+
+		select {
+		case r.slots <- struct{}{}:
+		case <-ctx.Done():
+			abandoned(ctx)
+			return
+		}
+		defer func() { <-r.slots }()
+		fn(ctx)
+	}()
+
+	return true
+}
+
+// Running reports whether a worker for the run is still executing
+func (r *workerRegistry) Running(runID uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.workers[runID]
+	return ok
+}
+
+// Cancel cancels the worker of a run with the given cause and waits for it to stop until ctx is done
+// Returns false when no worker is executing the run
+func (r *workerRegistry) Cancel(ctx context.Context, runID uuid.UUID, cause error) bool {
+	r.mu.Lock()
+	w, ok := r.workers[runID]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	w.cancel(cause)
+
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+	}
+	return true
+}
+
+// RunIDs returns the IDs of the runs executing or waiting for a worker slot
+func (r *workerRegistry) RunIDs() []uuid.UUID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]uuid.UUID, 0, len(r.workers))
+	for runID := range r.workers {
+		ids = append(ids, runID)
+	}
+	return ids
+}
+
+// Draining reports whether the registry stopped accepting new workers
+func (r *workerRegistry) Draining() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.draining
+}
+
+// InFlight returns the number of workers still running
+func (r *workerRegistry) InFlight() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.workers)
+}
+
+// Free returns the number of worker slots no run is executing or waiting for
+func (r *workerRegistry) Free() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return max(cap(r.slots)-len(r.workers), 0)
+}
+
+// Drain stops new workers from starting and waits for running ones to finish
+// When ctx expires first, the remaining workers are cancelled and given grace
+// to wind down; the IDs of runs still executing after that are returned
+func (r *workerRegistry) Drain(ctx context.Context, grace time.Duration) []uuid.UUID {
+	r.mu.Lock()
+	r.draining = true
+	r.mu.Unlock()
+
+	if r.wait(ctx) {
+		return nil
+	}
+
+	r.mu.Lock()
+	for _, w := range r.workers {
+		w.cancel(errServerShutdown)
+	}
+	r.mu.Unlock()
+
+	graceCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if r.wait(graceCtx) {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stuck := make([]uuid.UUID, 0, len(r.workers))
+	for runID := range r.workers {
+		stuck = append(stuck, runID)
+	}
+	return stuck
+}
+
+// wait blocks until all workers have finished or ctx is done
+// Returns true when all workers finished
+func (r *workerRegistry) wait(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// abandon is the callback of runs cancelled before a worker slot was free; the tests never fill the pool
+func abandon(context.Context) {}
+
+// TestWorkerRegistry_DrainWaitsForWorkers tests that draining waits for a worker in flight to finish and that
+// no worker starts once draining has begun
+func TestWorkerRegistry_DrainWaitsForWorkers(t *testing.T) {
+	// Arrange
+	registry := newWorkerRegistry(0)
+	release := make(chan struct{})
+	require.True(t, registry.Go(context.Background(), uuid.New(), func(ctx context.Context) {
+		<-release
+	}, abandon))
+
+	// Act
+	drained := make(chan []uuid.UUID, 1)
+	go func() {
+		drained <- registry.Drain(context.Background(), time.Second)
+	}()
+
+	// Assert
+	assert.Never(t, func() bool { return len(drained) > 0 }, 50*time.Millisecond, 5*time.Millisecond,
+		"draining waits for the worker")
+	close(release)
+	assert.Empty(t, <-drained)
+	assert.Equal(t, 0, registry.InFlight())
+
+	started := registry.Go(context.Background(), uuid.New(), func(ctx context.Context) {
+		t.Error("worker started while draining")
+	}, abandon)
+	assert.False(t, started)
+}
+
+// TestWorkerRegistry_DrainCancelsWorkersPastDeadline tests that workers still running when the drain deadline
+// passes have their context cancelled, and that those ignoring it past the grace period are reported
+func TestWorkerRegistry_DrainCancelsWorkersPastDeadline(t *testing.T) {
+	// Arrange
+	registry := newWorkerRegistry(0)
+	cancelled := make(chan struct{})
+	require.True(t, registry.Go(context.Background(), uuid.New(), func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+	}, abandon))
+	stuckID := uuid.New()
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	require.True(t, registry.Go(context.Background(), stuckID, func(ctx context.Context) {
+		<-release
+	}, abandon))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	stuck := registry.Drain(ctx, 50*time.Millisecond)
+
+	// Assert
+	assert.Equal(t, []uuid.UUID{stuckID}, stuck)
+	select {
+	case <-cancelled:
+	default:
+		t.Fatal("worker context was not cancelled")
+	}
+	assert.Equal(t, 1, registry.InFlight())
+}
@@ -0,0 +1,131 @@
+// Package sms sends text messages through SMS providers
+// Twilio implements the Messages API of Twilio, which providers such as SignalWire and Telnyx also offer
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTwilioURL is the base URL of the Twilio API
+	DefaultTwilioURL = "https://api.twilio.com"
+
+	// MaxBodyLength is the longest message body providers accept, in characters
+	MaxBodyLength = 1600
+
+	// responseLimit caps how much of a provider's answer is read
+	responseLimit = 64 * 1024
+)
+
+// ErrRejected is returned when the provider refuses a message for good, e.g. for an invalid or blocked number
+var ErrRejected = errors.New("sms: message rejected")
+
+// e164 matches phone numbers in E.164 format, such as +14155550123
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// ValidNumber reports whether a phone number is in E.164 format
+func ValidNumber(number string) bool {
+	return e164.MatchString(number)
+}
+
+// Message is a text message to one phone number
+type Message struct {
+	// To is the recipient's phone number in E.164 format
+	To string
+
+	// Body is the text of the message
+	Body string
+}
+
+// TwilioConfig configures the account messages are sent from
+type TwilioConfig struct {
+	// AccountSID and AuthToken authenticate with the API
+	AccountSID string
+	AuthToken  string
+
+	// From is the sender phone number, or the SID of a messaging service (starting with "MG")
+	From string
+
+	// BaseURL is the base URL of the API, DefaultTwilioURL if empty
+	BaseURL string
+}
+
+// Twilio sends messages through the Twilio Messages API
+type Twilio struct {
+	cfg    TwilioConfig
+	client *http.Client
+}
+
+// NewTwilio creates a sender for a Twilio account; a nil client uses one with a 10 second timeout
+// Returns:
+//   - error: If the credentials or the sender are missing
+func NewTwilio(cfg TwilioConfig, client *http.Client) (*Twilio, error) {
+	if cfg.AccountSID == "" || cfg.AuthToken == "" {
+		return nil, errors.New("sms: account SID and auth token are required")
+	}
+	if cfg.From == "" {
+		return nil, errors.New("sms: sender is required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultTwilioURL
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Twilio{cfg: cfg, client: client}, nil
+}
+
+// twilioError is the error body of the Twilio API
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Send sends a message, returning once the provider has accepted it
+// Messages refused with a 4xx other than 429 fail with ErrRejected
+func (t *Twilio) Send(ctx context.Context, msg Message) error {
+	form := url.Values{"To": {msg.To}, "Body": {msg.Body}}
+	if strings.HasPrefix(t.cfg.From, "MG") {
+		form.Set("MessagingServiceSid", t.cfg.From)
+	} else {
+		form.Set("From", t.cfg.From)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", t.cfg.BaseURL, url.PathEscape(t.cfg.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("sms: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.cfg.AccountSID, t.cfg.AuthToken)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms: failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseLimit))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	reason := strings.TrimSpace(string(body))
+	var apiErr twilioError
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		reason = fmt.Sprintf("%s (code %d)", apiErr.Message, apiErr.Code)
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: status %d: %s", ErrRejected, resp.StatusCode, reason)
+	}
+	return fmt.Errorf("sms: provider returned status %d: %s", resp.StatusCode, reason)
+}
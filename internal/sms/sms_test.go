@@ -0,0 +1,85 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTwilio_Send tests that messages are posted to the account's Messages endpoint with basic auth, that
+// refused messages fail with ErrRejected and that rate limited ones do not
+func TestTwilio_Send(t *testing.T) {
+	// Arrange
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" || user != "AC123" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.ParseForm()
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		switch r.PostForm.Get("To") {
+		case "+15005550001":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": 21211, "message": "The 'To' number is not a valid phone number."}`))
+		case "+15005550002":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	sender, err := NewTwilio(TwilioConfig{AccountSID: "AC123", AuthToken: "token", From: "+15005550006", BaseURL: server.URL + "/"}, nil)
+	assert.NoError(t, err)
+
+	// Act
+	sent := sender.Send(context.Background(), Message{To: "+14155550123", Body: "Payment failed"})
+	sentForm := form
+	rejected := sender.Send(context.Background(), Message{To: "+15005550001", Body: "Payment failed"})
+	limited := sender.Send(context.Background(), Message{To: "+15005550002", Body: "Payment failed"})
+
+	// Assert
+	assert.NoError(t, sent)
+	assert.Equal(t, map[string]string{"To": "+14155550123", "From": "+15005550006", "Body": "Payment failed"}, sentForm)
+	assert.ErrorIs(t, rejected, ErrRejected)
+	assert.ErrorContains(t, rejected, "not a valid phone number. (code 21211)")
+	assert.Error(t, limited)
+	assert.NotErrorIs(t, limited, ErrRejected)
+}
+
+// TestTwilio_MessagingService tests that senders starting with MG are sent as a messaging service
+func TestTwilio_MessagingService(t *testing.T) {
+	// Arrange
+	var service string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		service = r.PostForm.Get("MessagingServiceSid")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	sender, _ := NewTwilio(TwilioConfig{AccountSID: "AC123", AuthToken: "token", From: "MG456", BaseURL: server.URL}, nil)
+
+	// Act
+	err := sender.Send(context.Background(), Message{To: "+14155550123", Body: "hello"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "MG456", service)
+}
+
+// TestValidNumber tests the E.164 check of recipients
+func TestValidNumber(t *testing.T) {
+	assert.True(t, ValidNumber("+14155550123"))
+	assert.True(t, ValidNumber("+442071838750"))
+	assert.False(t, ValidNumber("4155550123"))
+	assert.False(t, ValidNumber("+0155550123"))
+	assert.False(t, ValidNumber("+1 415 555 0123"))
+}
@@ -4,8 +4,10 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	models "github.com/sakibcoolz/loki-suite/internal/models"
+	repository "github.com/sakibcoolz/loki-suite/internal/repository"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -24,217 +26,1684 @@ func (_m *MockExecutionChainRepository) EXPECT() *MockExecutionChainRepository_E
 	return &MockExecutionChainRepository_Expecter{mock: &_m.Mock}
 }
 
+// ClaimRecoverableChainRun provides a mock function with given fields: ctx, runID, workerID, ownBefore, staleBefore, now
+func (_m *MockExecutionChainRepository) ClaimRecoverableChainRun(ctx context.Context, runID uuid.UUID, workerID string, ownBefore time.Time, staleBefore time.Time, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, runID, workerID, ownBefore, staleBefore, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimRecoverableChainRun")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, runID, workerID, ownBefore, staleBefore, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, runID, workerID, ownBefore, staleBefore, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, runID, workerID, ownBefore, staleBefore, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_ClaimRecoverableChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimRecoverableChainRun'
+type MockExecutionChainRepository_ClaimRecoverableChainRun_Call struct {
+	*mock.Call
+}
+
+// ClaimRecoverableChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+//   - workerID string
+//   - ownBefore time.Time
+//   - staleBefore time.Time
+//   - now time.Time
+func (_e *MockExecutionChainRepository_Expecter) ClaimRecoverableChainRun(ctx interface{}, runID interface{}, workerID interface{}, ownBefore interface{}, staleBefore interface{}, now interface{}) *MockExecutionChainRepository_ClaimRecoverableChainRun_Call {
+	return &MockExecutionChainRepository_ClaimRecoverableChainRun_Call{Call: _e.mock.On("ClaimRecoverableChainRun", ctx, runID, workerID, ownBefore, staleBefore, now)}
+}
+
+func (_c *MockExecutionChainRepository_ClaimRecoverableChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID, workerID string, ownBefore time.Time, staleBefore time.Time, now time.Time)) *MockExecutionChainRepository_ClaimRecoverableChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time), args[4].(time.Time), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_ClaimRecoverableChainRun_Call) Return(_a0 bool, _a1 error) *MockExecutionChainRepository_ClaimRecoverableChainRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_ClaimRecoverableChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time, time.Time, time.Time) (bool, error)) *MockExecutionChainRepository_ClaimRecoverableChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimScheduledRun provides a mock function with given fields: ctx, chainID, due, next, triggeredAt
+func (_m *MockExecutionChainRepository) ClaimScheduledRun(ctx context.Context, chainID uuid.UUID, due time.Time, next *time.Time, triggeredAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, chainID, due, next, triggeredAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimScheduledRun")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, chainID, due, next, triggeredAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, chainID, due, next, triggeredAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, *time.Time, time.Time) error); ok {
+		r1 = rf(ctx, chainID, due, next, triggeredAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_ClaimScheduledRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimScheduledRun'
+type MockExecutionChainRepository_ClaimScheduledRun_Call struct {
+	*mock.Call
+}
+
+// ClaimScheduledRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+//   - due time.Time
+//   - next *time.Time
+//   - triggeredAt time.Time
+func (_e *MockExecutionChainRepository_Expecter) ClaimScheduledRun(ctx interface{}, chainID interface{}, due interface{}, next interface{}, triggeredAt interface{}) *MockExecutionChainRepository_ClaimScheduledRun_Call {
+	return &MockExecutionChainRepository_ClaimScheduledRun_Call{Call: _e.mock.On("ClaimScheduledRun", ctx, chainID, due, next, triggeredAt)}
+}
+
+func (_c *MockExecutionChainRepository_ClaimScheduledRun_Call) Run(run func(ctx context.Context, chainID uuid.UUID, due time.Time, next *time.Time, triggeredAt time.Time)) *MockExecutionChainRepository_ClaimScheduledRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(*time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_ClaimScheduledRun_Call) Return(_a0 bool, _a1 error) *MockExecutionChainRepository_ClaimScheduledRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_ClaimScheduledRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, *time.Time, time.Time) (bool, error)) *MockExecutionChainRepository_ClaimScheduledRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountChainRunsByStatus provides a mock function with given fields: ctx, tenantID, status
+func (_m *MockExecutionChainRepository) CountChainRunsByStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) (int64, error) {
+	ret := _m.Called(ctx, tenantID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountChainRunsByStatus")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ExecutionChainStatus) (int64, error)); ok {
+		return rf(ctx, tenantID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ExecutionChainStatus) int64); ok {
+		r0 = rf(ctx, tenantID, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ExecutionChainStatus) error); ok {
+		r1 = rf(ctx, tenantID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_CountChainRunsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountChainRunsByStatus'
+type MockExecutionChainRepository_CountChainRunsByStatus_Call struct {
+	*mock.Call
+}
+
+// CountChainRunsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - status models.ExecutionChainStatus
+func (_e *MockExecutionChainRepository_Expecter) CountChainRunsByStatus(ctx interface{}, tenantID interface{}, status interface{}) *MockExecutionChainRepository_CountChainRunsByStatus_Call {
+	return &MockExecutionChainRepository_CountChainRunsByStatus_Call{Call: _e.mock.On("CountChainRunsByStatus", ctx, tenantID, status)}
+}
+
+func (_c *MockExecutionChainRepository_CountChainRunsByStatus_Call) Run(run func(ctx context.Context, tenantID string, status models.ExecutionChainStatus)) *MockExecutionChainRepository_CountChainRunsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ExecutionChainStatus))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CountChainRunsByStatus_Call) Return(_a0 int64, _a1 error) *MockExecutionChainRepository_CountChainRunsByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CountChainRunsByStatus_Call) RunAndReturn(run func(context.Context, string, models.ExecutionChainStatus) (int64, error)) *MockExecutionChainRepository_CountChainRunsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountQueuedChainRunsAhead provides a mock function with given fields: ctx, tenantID, priority, createdAt
+func (_m *MockExecutionChainRepository) CountQueuedChainRunsAhead(ctx context.Context, tenantID string, priority int, createdAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, tenantID, priority, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountQueuedChainRunsAhead")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time) (int64, error)); ok {
+		return rf(ctx, tenantID, priority, createdAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time) int64); ok {
+		r0 = rf(ctx, tenantID, priority, createdAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, priority, createdAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_CountQueuedChainRunsAhead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountQueuedChainRunsAhead'
+type MockExecutionChainRepository_CountQueuedChainRunsAhead_Call struct {
+	*mock.Call
+}
+
+// CountQueuedChainRunsAhead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - priority int
+//   - createdAt time.Time
+func (_e *MockExecutionChainRepository_Expecter) CountQueuedChainRunsAhead(ctx interface{}, tenantID interface{}, priority interface{}, createdAt interface{}) *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call {
+	return &MockExecutionChainRepository_CountQueuedChainRunsAhead_Call{Call: _e.mock.On("CountQueuedChainRunsAhead", ctx, tenantID, priority, createdAt)}
+}
+
+func (_c *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call) Run(run func(ctx context.Context, tenantID string, priority int, createdAt time.Time)) *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call) Return(_a0 int64, _a1 error) *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call) RunAndReturn(run func(context.Context, string, int, time.Time) (int64, error)) *MockExecutionChainRepository_CountQueuedChainRunsAhead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountStepRunsByWebhookSince provides a mock function with given fields: ctx, webhookID, since
+func (_m *MockExecutionChainRepository) CountStepRunsByWebhookSince(ctx context.Context, webhookID uuid.UUID, since time.Time) (int64, error) {
+	ret := _m.Called(ctx, webhookID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStepRunsByWebhookSince")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (int64, error)); ok {
+		return rf(ctx, webhookID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) int64); ok {
+		r0 = rf(ctx, webhookID, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, webhookID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_CountStepRunsByWebhookSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountStepRunsByWebhookSince'
+type MockExecutionChainRepository_CountStepRunsByWebhookSince_Call struct {
+	*mock.Call
+}
+
+// CountStepRunsByWebhookSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID uuid.UUID
+//   - since time.Time
+func (_e *MockExecutionChainRepository_Expecter) CountStepRunsByWebhookSince(ctx interface{}, webhookID interface{}, since interface{}) *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call {
+	return &MockExecutionChainRepository_CountStepRunsByWebhookSince_Call{Call: _e.mock.On("CountStepRunsByWebhookSince", ctx, webhookID, since)}
+}
+
+func (_c *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, since time.Time)) *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call) Return(_a0 int64, _a1 error) *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (int64, error)) *MockExecutionChainRepository_CountStepRunsByWebhookSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateChain provides a mock function with given fields: ctx, chain
 func (_m *MockExecutionChainRepository) CreateChain(ctx context.Context, chain *models.ExecutionChain) error {
 	ret := _m.Called(ctx, chain)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChain")
+		panic("no return value specified for CreateChain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChain) error); ok {
+		r0 = rf(ctx, chain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_CreateChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChain'
+type MockExecutionChainRepository_CreateChain_Call struct {
+	*mock.Call
+}
+
+// CreateChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain *models.ExecutionChain
+func (_e *MockExecutionChainRepository_Expecter) CreateChain(ctx interface{}, chain interface{}) *MockExecutionChainRepository_CreateChain_Call {
+	return &MockExecutionChainRepository_CreateChain_Call{Call: _e.mock.On("CreateChain", ctx, chain)}
+}
+
+func (_c *MockExecutionChainRepository_CreateChain_Call) Run(run func(ctx context.Context, chain *models.ExecutionChain)) *MockExecutionChainRepository_CreateChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ExecutionChain))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateChain_Call) Return(_a0 error) *MockExecutionChainRepository_CreateChain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateChain_Call) RunAndReturn(run func(context.Context, *models.ExecutionChain) error) *MockExecutionChainRepository_CreateChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChainRun provides a mock function with given fields: ctx, run
+func (_m *MockExecutionChainRepository) CreateChainRun(ctx context.Context, run *models.ExecutionChainRun) error {
+	ret := _m.Called(ctx, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChainRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChainRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_CreateChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChainRun'
+type MockExecutionChainRepository_CreateChainRun_Call struct {
+	*mock.Call
+}
+
+// CreateChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run *models.ExecutionChainRun
+func (_e *MockExecutionChainRepository_Expecter) CreateChainRun(ctx interface{}, run interface{}) *MockExecutionChainRepository_CreateChainRun_Call {
+	return &MockExecutionChainRepository_CreateChainRun_Call{Call: _e.mock.On("CreateChainRun", ctx, run)}
+}
+
+func (_c *MockExecutionChainRepository_CreateChainRun_Call) Run(run func(ctx context.Context, run *models.ExecutionChainRun)) *MockExecutionChainRepository_CreateChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ExecutionChainRun))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateChainRun_Call) Return(_a0 error) *MockExecutionChainRepository_CreateChainRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateChainRun_Call) RunAndReturn(run func(context.Context, *models.ExecutionChainRun) error) *MockExecutionChainRepository_CreateChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChainVersion provides a mock function with given fields: ctx, version
+func (_m *MockExecutionChainRepository) CreateChainVersion(ctx context.Context, version *models.ExecutionChainVersion) error {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChainVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChainVersion) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_CreateChainVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChainVersion'
+type MockExecutionChainRepository_CreateChainVersion_Call struct {
+	*mock.Call
+}
+
+// CreateChainVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version *models.ExecutionChainVersion
+func (_e *MockExecutionChainRepository_Expecter) CreateChainVersion(ctx interface{}, version interface{}) *MockExecutionChainRepository_CreateChainVersion_Call {
+	return &MockExecutionChainRepository_CreateChainVersion_Call{Call: _e.mock.On("CreateChainVersion", ctx, version)}
+}
+
+func (_c *MockExecutionChainRepository_CreateChainVersion_Call) Run(run func(ctx context.Context, version *models.ExecutionChainVersion)) *MockExecutionChainRepository_CreateChainVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ExecutionChainVersion))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateChainVersion_Call) Return(_a0 error) *MockExecutionChainRepository_CreateChainVersion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateChainVersion_Call) RunAndReturn(run func(context.Context, *models.ExecutionChainVersion) error) *MockExecutionChainRepository_CreateChainVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCompensationRun provides a mock function with given fields: ctx, compensation
+func (_m *MockExecutionChainRepository) CreateCompensationRun(ctx context.Context, compensation *models.ExecutionChainCompensationRun) error {
+	ret := _m.Called(ctx, compensation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCompensationRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChainCompensationRun) error); ok {
+		r0 = rf(ctx, compensation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_CreateCompensationRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCompensationRun'
+type MockExecutionChainRepository_CreateCompensationRun_Call struct {
+	*mock.Call
+}
+
+// CreateCompensationRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - compensation *models.ExecutionChainCompensationRun
+func (_e *MockExecutionChainRepository_Expecter) CreateCompensationRun(ctx interface{}, compensation interface{}) *MockExecutionChainRepository_CreateCompensationRun_Call {
+	return &MockExecutionChainRepository_CreateCompensationRun_Call{Call: _e.mock.On("CreateCompensationRun", ctx, compensation)}
+}
+
+func (_c *MockExecutionChainRepository_CreateCompensationRun_Call) Run(run func(ctx context.Context, compensation *models.ExecutionChainCompensationRun)) *MockExecutionChainRepository_CreateCompensationRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ExecutionChainCompensationRun))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateCompensationRun_Call) Return(_a0 error) *MockExecutionChainRepository_CreateCompensationRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateCompensationRun_Call) RunAndReturn(run func(context.Context, *models.ExecutionChainCompensationRun) error) *MockExecutionChainRepository_CreateCompensationRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStepRun provides a mock function with given fields: ctx, stepRun
+func (_m *MockExecutionChainRepository) CreateStepRun(ctx context.Context, stepRun *models.ExecutionChainStepRun) error {
+	ret := _m.Called(ctx, stepRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStepRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChainStepRun) error); ok {
+		r0 = rf(ctx, stepRun)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_CreateStepRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStepRun'
+type MockExecutionChainRepository_CreateStepRun_Call struct {
+	*mock.Call
+}
+
+// CreateStepRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stepRun *models.ExecutionChainStepRun
+func (_e *MockExecutionChainRepository_Expecter) CreateStepRun(ctx interface{}, stepRun interface{}) *MockExecutionChainRepository_CreateStepRun_Call {
+	return &MockExecutionChainRepository_CreateStepRun_Call{Call: _e.mock.On("CreateStepRun", ctx, stepRun)}
+}
+
+func (_c *MockExecutionChainRepository_CreateStepRun_Call) Run(run func(ctx context.Context, stepRun *models.ExecutionChainStepRun)) *MockExecutionChainRepository_CreateStepRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ExecutionChainStepRun))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateStepRun_Call) Return(_a0 error) *MockExecutionChainRepository_CreateStepRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateStepRun_Call) RunAndReturn(run func(context.Context, *models.ExecutionChainStepRun) error) *MockExecutionChainRepository_CreateStepRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStepRuns provides a mock function with given fields: ctx, stepRuns
+func (_m *MockExecutionChainRepository) CreateStepRuns(ctx context.Context, stepRuns []*models.ExecutionChainStepRun) error {
+	ret := _m.Called(ctx, stepRuns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStepRuns")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.ExecutionChainStepRun) error); ok {
+		r0 = rf(ctx, stepRuns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_CreateStepRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStepRuns'
+type MockExecutionChainRepository_CreateStepRuns_Call struct {
+	*mock.Call
+}
+
+// CreateStepRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stepRuns []*models.ExecutionChainStepRun
+func (_e *MockExecutionChainRepository_Expecter) CreateStepRuns(ctx interface{}, stepRuns interface{}) *MockExecutionChainRepository_CreateStepRuns_Call {
+	return &MockExecutionChainRepository_CreateStepRuns_Call{Call: _e.mock.On("CreateStepRuns", ctx, stepRuns)}
+}
+
+func (_c *MockExecutionChainRepository_CreateStepRuns_Call) Run(run func(ctx context.Context, stepRuns []*models.ExecutionChainStepRun)) *MockExecutionChainRepository_CreateStepRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.ExecutionChainStepRun))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateStepRuns_Call) Return(_a0 error) *MockExecutionChainRepository_CreateStepRuns_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_CreateStepRuns_Call) RunAndReturn(run func(context.Context, []*models.ExecutionChainStepRun) error) *MockExecutionChainRepository_CreateStepRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChain provides a mock function with given fields: ctx, id
+func (_m *MockExecutionChainRepository) DeleteChain(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_DeleteChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChain'
+type MockExecutionChainRepository_DeleteChain_Call struct {
+	*mock.Call
+}
+
+// DeleteChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) DeleteChain(ctx interface{}, id interface{}) *MockExecutionChainRepository_DeleteChain_Call {
+	return &MockExecutionChainRepository_DeleteChain_Call{Call: _e.mock.On("DeleteChain", ctx, id)}
+}
+
+func (_c *MockExecutionChainRepository_DeleteChain_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockExecutionChainRepository_DeleteChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_DeleteChain_Call) Return(_a0 error) *MockExecutionChainRepository_DeleteChain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_DeleteChain_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockExecutionChainRepository_DeleteChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainByID provides a mock function with given fields: ctx, id
+func (_m *MockExecutionChainRepository) GetChainByID(ctx context.Context, id uuid.UUID) (*models.ExecutionChain, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainByID")
+	}
+
+	var r0 *models.ExecutionChain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecutionChain, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecutionChain); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecutionChain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainByID'
+type MockExecutionChainRepository_GetChainByID_Call struct {
+	*mock.Call
+}
+
+// GetChainByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetChainByID(ctx interface{}, id interface{}) *MockExecutionChainRepository_GetChainByID_Call {
+	return &MockExecutionChainRepository_GetChainByID_Call{Call: _e.mock.On("GetChainByID", ctx, id)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockExecutionChainRepository_GetChainByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainByID_Call) Return(_a0 *models.ExecutionChain, _a1 error) *MockExecutionChainRepository_GetChainByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecutionChain, error)) *MockExecutionChainRepository_GetChainByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunByID provides a mock function with given fields: ctx, runID
+func (_m *MockExecutionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunByID")
+	}
+
+	var r0 *models.ExecutionChainRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecutionChainRun); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecutionChainRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainRunByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunByID'
+type MockExecutionChainRepository_GetChainRunByID_Call struct {
+	*mock.Call
+}
+
+// GetChainRunByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunByID(ctx interface{}, runID interface{}) *MockExecutionChainRepository_GetChainRunByID_Call {
+	return &MockExecutionChainRepository_GetChainRunByID_Call{Call: _e.mock.On("GetChainRunByID", ctx, runID)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunByID_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainRepository_GetChainRunByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunByID_Call) Return(_a0 *models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetChainRunByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetChainRunByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunRetries provides a mock function with given fields: ctx, runID
+func (_m *MockExecutionChainRepository) GetChainRunRetries(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunRetries")
+	}
+
+	var r0 []*models.ExecutionChainRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.ExecutionChainRun); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainRunRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunRetries'
+type MockExecutionChainRepository_GetChainRunRetries_Call struct {
+	*mock.Call
+}
+
+// GetChainRunRetries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunRetries(ctx interface{}, runID interface{}) *MockExecutionChainRepository_GetChainRunRetries_Call {
+	return &MockExecutionChainRepository_GetChainRunRetries_Call{Call: _e.mock.On("GetChainRunRetries", ctx, runID)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunRetries_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainRepository_GetChainRunRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunRetries_Call) Return(_a0 []*models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetChainRunRetries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunRetries_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetChainRunRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunStats provides a mock function with given fields: ctx, chainID, since
+func (_m *MockExecutionChainRepository) GetChainRunStats(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainRunStats, error) {
+	ret := _m.Called(ctx, chainID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunStats")
+	}
+
+	var r0 *models.ChainRunStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time) (*models.ChainRunStats, error)); ok {
+		return rf(ctx, chainID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time) *models.ChainRunStats); ok {
+		r0 = rf(ctx, chainID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainRunStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *time.Time) error); ok {
+		r1 = rf(ctx, chainID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainRunStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunStats'
+type MockExecutionChainRepository_GetChainRunStats_Call struct {
+	*mock.Call
+}
+
+// GetChainRunStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+//   - since *time.Time
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunStats(ctx interface{}, chainID interface{}, since interface{}) *MockExecutionChainRepository_GetChainRunStats_Call {
+	return &MockExecutionChainRepository_GetChainRunStats_Call{Call: _e.mock.On("GetChainRunStats", ctx, chainID, since)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunStats_Call) Run(run func(ctx context.Context, chainID uuid.UUID, since *time.Time)) *MockExecutionChainRepository_GetChainRunStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunStats_Call) Return(_a0 *models.ChainRunStats, _a1 error) *MockExecutionChainRepository_GetChainRunStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunStats_Call) RunAndReturn(run func(context.Context, uuid.UUID, *time.Time) (*models.ChainRunStats, error)) *MockExecutionChainRepository_GetChainRunStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunsByChain provides a mock function with given fields: ctx, chainID, filter, page
+func (_m *MockExecutionChainRepository) GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, filter models.ListFilter, page repository.Page) ([]*models.ExecutionChainRun, int64, error) {
+	ret := _m.Called(ctx, chainID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunsByChain")
+	}
+
+	var r0 []*models.ExecutionChainRun
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ListFilter, repository.Page) ([]*models.ExecutionChainRun, int64, error)); ok {
+		return rf(ctx, chainID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ListFilter, repository.Page) []*models.ExecutionChainRun); ok {
+		r0 = rf(ctx, chainID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.ListFilter, repository.Page) int64); ok {
+		r1 = rf(ctx, chainID, filter, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, models.ListFilter, repository.Page) error); ok {
+		r2 = rf(ctx, chainID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockExecutionChainRepository_GetChainRunsByChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunsByChain'
+type MockExecutionChainRepository_GetChainRunsByChain_Call struct {
+	*mock.Call
+}
+
+// GetChainRunsByChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+//   - filter models.ListFilter
+//   - page repository.Page
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunsByChain(ctx interface{}, chainID interface{}, filter interface{}, page interface{}) *MockExecutionChainRepository_GetChainRunsByChain_Call {
+	return &MockExecutionChainRepository_GetChainRunsByChain_Call{Call: _e.mock.On("GetChainRunsByChain", ctx, chainID, filter, page)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByChain_Call) Run(run func(ctx context.Context, chainID uuid.UUID, filter models.ListFilter, page repository.Page)) *MockExecutionChainRepository_GetChainRunsByChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.ListFilter), args[3].(repository.Page))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByChain_Call) Return(_a0 []*models.ExecutionChainRun, _a1 int64, _a2 error) *MockExecutionChainRepository_GetChainRunsByChain_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByChain_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.ListFilter, repository.Page) ([]*models.ExecutionChainRun, int64, error)) *MockExecutionChainRepository_GetChainRunsByChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunsByChainAndStatus provides a mock function with given fields: ctx, chainID, statuses
+func (_m *MockExecutionChainRepository) GetChainRunsByChainAndStatus(ctx context.Context, chainID uuid.UUID, statuses []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, chainID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunsByChainAndStatus")
+	}
+
+	var r0 []*models.ExecutionChainRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, chainID, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []models.ExecutionChainStatus) []*models.ExecutionChainRun); ok {
+		r0 = rf(ctx, chainID, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []models.ExecutionChainStatus) error); ok {
+		r1 = rf(ctx, chainID, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunsByChainAndStatus'
+type MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call struct {
+	*mock.Call
+}
+
+// GetChainRunsByChainAndStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+//   - statuses []models.ExecutionChainStatus
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunsByChainAndStatus(ctx interface{}, chainID interface{}, statuses interface{}) *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call {
+	return &MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call{Call: _e.mock.On("GetChainRunsByChainAndStatus", ctx, chainID, statuses)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call) Run(run func(ctx context.Context, chainID uuid.UUID, statuses []models.ExecutionChainStatus)) *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]models.ExecutionChainStatus))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call) Return(_a0 []*models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, []models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetChainRunsByChainAndStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunsByStatus provides a mock function with given fields: ctx, status
+func (_m *MockExecutionChainRepository) GetChainRunsByStatus(ctx context.Context, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunsByStatus")
+	}
+
+	var r0 []*models.ExecutionChainRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ExecutionChainStatus) []*models.ExecutionChainRun); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ExecutionChainStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainRunsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunsByStatus'
+type MockExecutionChainRepository_GetChainRunsByStatus_Call struct {
+	*mock.Call
+}
+
+// GetChainRunsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status models.ExecutionChainStatus
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunsByStatus(ctx interface{}, status interface{}) *MockExecutionChainRepository_GetChainRunsByStatus_Call {
+	return &MockExecutionChainRepository_GetChainRunsByStatus_Call{Call: _e.mock.On("GetChainRunsByStatus", ctx, status)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByStatus_Call) Run(run func(ctx context.Context, status models.ExecutionChainStatus)) *MockExecutionChainRepository_GetChainRunsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ExecutionChainStatus))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByStatus_Call) Return(_a0 []*models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetChainRunsByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByStatus_Call) RunAndReturn(run func(context.Context, models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetChainRunsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainRunsByTenantAndStatus provides a mock function with given fields: ctx, tenantID, status
+func (_m *MockExecutionChainRepository) GetChainRunsByTenantAndStatus(ctx context.Context, tenantID string, status models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, tenantID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainRunsByTenantAndStatus")
+	}
+
+	var r0 []*models.ExecutionChainRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, tenantID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ExecutionChainStatus) []*models.ExecutionChainRun); ok {
+		r0 = rf(ctx, tenantID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ExecutionChainStatus) error); ok {
+		r1 = rf(ctx, tenantID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunsByTenantAndStatus'
+type MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call struct {
+	*mock.Call
+}
+
+// GetChainRunsByTenantAndStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - status models.ExecutionChainStatus
+func (_e *MockExecutionChainRepository_Expecter) GetChainRunsByTenantAndStatus(ctx interface{}, tenantID interface{}, status interface{}) *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call {
+	return &MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call{Call: _e.mock.On("GetChainRunsByTenantAndStatus", ctx, tenantID, status)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call) Run(run func(ctx context.Context, tenantID string, status models.ExecutionChainStatus)) *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ExecutionChainStatus))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call) Return(_a0 []*models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call) RunAndReturn(run func(context.Context, string, models.ExecutionChainStatus) ([]*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetChainRunsByTenantAndStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainUsage provides a mock function with given fields: ctx, tenantID, since
+func (_m *MockExecutionChainRepository) GetChainUsage(ctx context.Context, tenantID string, since *time.Time) (*models.ChainUsageResponse, error) {
+	ret := _m.Called(ctx, tenantID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainUsage")
+	}
+
+	var r0 *models.ChainUsageResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) (*models.ChainUsageResponse, error)); ok {
+		return rf(ctx, tenantID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) *models.ChainUsageResponse); ok {
+		r0 = rf(ctx, tenantID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainUsageResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time) error); ok {
+		r1 = rf(ctx, tenantID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainUsage'
+type MockExecutionChainRepository_GetChainUsage_Call struct {
+	*mock.Call
+}
+
+// GetChainUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - since *time.Time
+func (_e *MockExecutionChainRepository_Expecter) GetChainUsage(ctx interface{}, tenantID interface{}, since interface{}) *MockExecutionChainRepository_GetChainUsage_Call {
+	return &MockExecutionChainRepository_GetChainUsage_Call{Call: _e.mock.On("GetChainUsage", ctx, tenantID, since)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainUsage_Call) Run(run func(ctx context.Context, tenantID string, since *time.Time)) *MockExecutionChainRepository_GetChainUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainUsage_Call) Return(_a0 *models.ChainUsageResponse, _a1 error) *MockExecutionChainRepository_GetChainUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainUsage_Call) RunAndReturn(run func(context.Context, string, *time.Time) (*models.ChainUsageResponse, error)) *MockExecutionChainRepository_GetChainUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainVersion provides a mock function with given fields: ctx, chainID, version
+func (_m *MockExecutionChainRepository) GetChainVersion(ctx context.Context, chainID uuid.UUID, version int) (*models.ExecutionChainVersion, error) {
+	ret := _m.Called(ctx, chainID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainVersion")
+	}
+
+	var r0 *models.ExecutionChainVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (*models.ExecutionChainVersion, error)); ok {
+		return rf(ctx, chainID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) *models.ExecutionChainVersion); ok {
+		r0 = rf(ctx, chainID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecutionChainVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, chainID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainVersion'
+type MockExecutionChainRepository_GetChainVersion_Call struct {
+	*mock.Call
+}
+
+// GetChainVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+//   - version int
+func (_e *MockExecutionChainRepository_Expecter) GetChainVersion(ctx interface{}, chainID interface{}, version interface{}) *MockExecutionChainRepository_GetChainVersion_Call {
+	return &MockExecutionChainRepository_GetChainVersion_Call{Call: _e.mock.On("GetChainVersion", ctx, chainID, version)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainVersion_Call) Run(run func(ctx context.Context, chainID uuid.UUID, version int)) *MockExecutionChainRepository_GetChainVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainVersion_Call) Return(_a0 *models.ExecutionChainVersion, _a1 error) *MockExecutionChainRepository_GetChainVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainVersion_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) (*models.ExecutionChainVersion, error)) *MockExecutionChainRepository_GetChainVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainVersions provides a mock function with given fields: ctx, chainID
+func (_m *MockExecutionChainRepository) GetChainVersions(ctx context.Context, chainID uuid.UUID) ([]models.ExecutionChainVersion, error) {
+	ret := _m.Called(ctx, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainVersions")
+	}
+
+	var r0 []models.ExecutionChainVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]models.ExecutionChainVersion, error)); ok {
+		return rf(ctx, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []models.ExecutionChainVersion); ok {
+		r0 = rf(ctx, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ExecutionChainVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainVersions'
+type MockExecutionChainRepository_GetChainVersions_Call struct {
+	*mock.Call
+}
+
+// GetChainVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetChainVersions(ctx interface{}, chainID interface{}) *MockExecutionChainRepository_GetChainVersions_Call {
+	return &MockExecutionChainRepository_GetChainVersions_Call{Call: _e.mock.On("GetChainVersions", ctx, chainID)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainVersions_Call) Run(run func(ctx context.Context, chainID uuid.UUID)) *MockExecutionChainRepository_GetChainVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainVersions_Call) Return(_a0 []models.ExecutionChainVersion, _a1 error) *MockExecutionChainRepository_GetChainVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainVersions_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]models.ExecutionChainVersion, error)) *MockExecutionChainRepository_GetChainVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainsByTenant provides a mock function with given fields: ctx, tenantID, filter, page
+func (_m *MockExecutionChainRepository) GetChainsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page repository.Page) ([]*models.ExecutionChain, int64, error) {
+	ret := _m.Called(ctx, tenantID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainsByTenant")
+	}
+
+	var r0 []*models.ExecutionChain
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, repository.Page) ([]*models.ExecutionChain, int64, error)); ok {
+		return rf(ctx, tenantID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, repository.Page) []*models.ExecutionChain); ok {
+		r0 = rf(ctx, tenantID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ListFilter, repository.Page) int64); ok {
+		r1 = rf(ctx, tenantID, filter, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.ListFilter, repository.Page) error); ok {
+		r2 = rf(ctx, tenantID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockExecutionChainRepository_GetChainsByTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainsByTenant'
+type MockExecutionChainRepository_GetChainsByTenant_Call struct {
+	*mock.Call
+}
+
+// GetChainsByTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - filter models.ListFilter
+//   - page repository.Page
+func (_e *MockExecutionChainRepository_Expecter) GetChainsByTenant(ctx interface{}, tenantID interface{}, filter interface{}, page interface{}) *MockExecutionChainRepository_GetChainsByTenant_Call {
+	return &MockExecutionChainRepository_GetChainsByTenant_Call{Call: _e.mock.On("GetChainsByTenant", ctx, tenantID, filter, page)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainsByTenant_Call) Run(run func(ctx context.Context, tenantID string, filter models.ListFilter, page repository.Page)) *MockExecutionChainRepository_GetChainsByTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ListFilter), args[3].(repository.Page))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainsByTenant_Call) Return(_a0 []*models.ExecutionChain, _a1 int64, _a2 error) *MockExecutionChainRepository_GetChainsByTenant_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainsByTenant_Call) RunAndReturn(run func(context.Context, string, models.ListFilter, repository.Page) ([]*models.ExecutionChain, int64, error)) *MockExecutionChainRepository_GetChainsByTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainsByTriggerEvent provides a mock function with given fields: ctx, tenantID, event
+func (_m *MockExecutionChainRepository) GetChainsByTriggerEvent(ctx context.Context, tenantID string, event string) ([]*models.ExecutionChain, error) {
+	ret := _m.Called(ctx, tenantID, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainsByTriggerEvent")
+	}
+
+	var r0 []*models.ExecutionChain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.ExecutionChain, error)); ok {
+		return rf(ctx, tenantID, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.ExecutionChain); ok {
+		r0 = rf(ctx, tenantID, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainRepository_GetChainsByTriggerEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainsByTriggerEvent'
+type MockExecutionChainRepository_GetChainsByTriggerEvent_Call struct {
+	*mock.Call
+}
+
+// GetChainsByTriggerEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - event string
+func (_e *MockExecutionChainRepository_Expecter) GetChainsByTriggerEvent(ctx interface{}, tenantID interface{}, event interface{}) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
+	return &MockExecutionChainRepository_GetChainsByTriggerEvent_Call{Call: _e.mock.On("GetChainsByTriggerEvent", ctx, tenantID, event)}
+}
+
+func (_c *MockExecutionChainRepository_GetChainsByTriggerEvent_Call) Run(run func(ctx context.Context, tenantID string, event string)) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainsByTriggerEvent_Call) Return(_a0 []*models.ExecutionChain, _a1 error) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_GetChainsByTriggerEvent_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.ExecutionChain, error)) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDueScheduledChains provides a mock function with given fields: ctx, now
+func (_m *MockExecutionChainRepository) GetDueScheduledChains(ctx context.Context, now time.Time) ([]*models.ExecutionChain, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueScheduledChains")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChain) error); ok {
-		r0 = rf(ctx, chain)
+	var r0 []*models.ExecutionChain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.ExecutionChain, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.ExecutionChain); ok {
+		r0 = rf(ctx, now)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChain)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExecutionChainRepository_CreateChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChain'
-type MockExecutionChainRepository_CreateChain_Call struct {
+// MockExecutionChainRepository_GetDueScheduledChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueScheduledChains'
+type MockExecutionChainRepository_GetDueScheduledChains_Call struct {
 	*mock.Call
 }
 
-// CreateChain is a helper method to define mock.On call
+// GetDueScheduledChains is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chain *models.ExecutionChain
-func (_e *MockExecutionChainRepository_Expecter) CreateChain(ctx interface{}, chain interface{}) *MockExecutionChainRepository_CreateChain_Call {
-	return &MockExecutionChainRepository_CreateChain_Call{Call: _e.mock.On("CreateChain", ctx, chain)}
+//   - now time.Time
+func (_e *MockExecutionChainRepository_Expecter) GetDueScheduledChains(ctx interface{}, now interface{}) *MockExecutionChainRepository_GetDueScheduledChains_Call {
+	return &MockExecutionChainRepository_GetDueScheduledChains_Call{Call: _e.mock.On("GetDueScheduledChains", ctx, now)}
 }
 
-func (_c *MockExecutionChainRepository_CreateChain_Call) Run(run func(ctx context.Context, chain *models.ExecutionChain)) *MockExecutionChainRepository_CreateChain_Call {
+func (_c *MockExecutionChainRepository_GetDueScheduledChains_Call) Run(run func(ctx context.Context, now time.Time)) *MockExecutionChainRepository_GetDueScheduledChains_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.ExecutionChain))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_CreateChain_Call) Return(_a0 error) *MockExecutionChainRepository_CreateChain_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExecutionChainRepository_GetDueScheduledChains_Call) Return(_a0 []*models.ExecutionChain, _a1 error) *MockExecutionChainRepository_GetDueScheduledChains_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_CreateChain_Call) RunAndReturn(run func(context.Context, *models.ExecutionChain) error) *MockExecutionChainRepository_CreateChain_Call {
+func (_c *MockExecutionChainRepository_GetDueScheduledChains_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.ExecutionChain, error)) *MockExecutionChainRepository_GetDueScheduledChains_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateChainRun provides a mock function with given fields: ctx, run
-func (_m *MockExecutionChainRepository) CreateChainRun(ctx context.Context, run *models.ExecutionChainRun) error {
-	ret := _m.Called(ctx, run)
+// GetMostFailingChainStep provides a mock function with given fields: ctx, chainID, since
+func (_m *MockExecutionChainRepository) GetMostFailingChainStep(ctx context.Context, chainID uuid.UUID, since *time.Time) (*models.ChainStepFailures, error) {
+	ret := _m.Called(ctx, chainID, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChainRun")
+		panic("no return value specified for GetMostFailingChainStep")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChainRun) error); ok {
-		r0 = rf(ctx, run)
+	var r0 *models.ChainStepFailures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time) (*models.ChainStepFailures, error)); ok {
+		return rf(ctx, chainID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time) *models.ChainStepFailures); ok {
+		r0 = rf(ctx, chainID, since)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainStepFailures)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *time.Time) error); ok {
+		r1 = rf(ctx, chainID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExecutionChainRepository_CreateChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChainRun'
-type MockExecutionChainRepository_CreateChainRun_Call struct {
+// MockExecutionChainRepository_GetMostFailingChainStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMostFailingChainStep'
+type MockExecutionChainRepository_GetMostFailingChainStep_Call struct {
 	*mock.Call
 }
 
-// CreateChainRun is a helper method to define mock.On call
+// GetMostFailingChainStep is a helper method to define mock.On call
 //   - ctx context.Context
-//   - run *models.ExecutionChainRun
-func (_e *MockExecutionChainRepository_Expecter) CreateChainRun(ctx interface{}, run interface{}) *MockExecutionChainRepository_CreateChainRun_Call {
-	return &MockExecutionChainRepository_CreateChainRun_Call{Call: _e.mock.On("CreateChainRun", ctx, run)}
+//   - chainID uuid.UUID
+//   - since *time.Time
+func (_e *MockExecutionChainRepository_Expecter) GetMostFailingChainStep(ctx interface{}, chainID interface{}, since interface{}) *MockExecutionChainRepository_GetMostFailingChainStep_Call {
+	return &MockExecutionChainRepository_GetMostFailingChainStep_Call{Call: _e.mock.On("GetMostFailingChainStep", ctx, chainID, since)}
 }
 
-func (_c *MockExecutionChainRepository_CreateChainRun_Call) Run(run func(ctx context.Context, run *models.ExecutionChainRun)) *MockExecutionChainRepository_CreateChainRun_Call {
+func (_c *MockExecutionChainRepository_GetMostFailingChainStep_Call) Run(run func(ctx context.Context, chainID uuid.UUID, since *time.Time)) *MockExecutionChainRepository_GetMostFailingChainStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.ExecutionChainRun))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*time.Time))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_CreateChainRun_Call) Return(_a0 error) *MockExecutionChainRepository_CreateChainRun_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExecutionChainRepository_GetMostFailingChainStep_Call) Return(_a0 *models.ChainStepFailures, _a1 error) *MockExecutionChainRepository_GetMostFailingChainStep_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_CreateChainRun_Call) RunAndReturn(run func(context.Context, *models.ExecutionChainRun) error) *MockExecutionChainRepository_CreateChainRun_Call {
+func (_c *MockExecutionChainRepository_GetMostFailingChainStep_Call) RunAndReturn(run func(context.Context, uuid.UUID, *time.Time) (*models.ChainStepFailures, error)) *MockExecutionChainRepository_GetMostFailingChainStep_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateStepRun provides a mock function with given fields: ctx, stepRun
-func (_m *MockExecutionChainRepository) CreateStepRun(ctx context.Context, stepRun *models.ExecutionChainStepRun) error {
-	ret := _m.Called(ctx, stepRun)
+// GetRecoverableChainRuns provides a mock function with given fields: ctx, workerID, ownBefore, staleBefore
+func (_m *MockExecutionChainRepository) GetRecoverableChainRuns(ctx context.Context, workerID string, ownBefore time.Time, staleBefore time.Time) ([]*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, workerID, ownBefore, staleBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateStepRun")
+		panic("no return value specified for GetRecoverableChainRuns")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecutionChainStepRun) error); ok {
-		r0 = rf(ctx, stepRun)
+	var r0 []*models.ExecutionChainRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, workerID, ownBefore, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []*models.ExecutionChainRun); ok {
+		r0 = rf(ctx, workerID, ownBefore, staleBefore)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainRun)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, workerID, ownBefore, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExecutionChainRepository_CreateStepRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStepRun'
-type MockExecutionChainRepository_CreateStepRun_Call struct {
+// MockExecutionChainRepository_GetRecoverableChainRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecoverableChainRuns'
+type MockExecutionChainRepository_GetRecoverableChainRuns_Call struct {
 	*mock.Call
 }
 
-// CreateStepRun is a helper method to define mock.On call
+// GetRecoverableChainRuns is a helper method to define mock.On call
 //   - ctx context.Context
-//   - stepRun *models.ExecutionChainStepRun
-func (_e *MockExecutionChainRepository_Expecter) CreateStepRun(ctx interface{}, stepRun interface{}) *MockExecutionChainRepository_CreateStepRun_Call {
-	return &MockExecutionChainRepository_CreateStepRun_Call{Call: _e.mock.On("CreateStepRun", ctx, stepRun)}
+//   - workerID string
+//   - ownBefore time.Time
+//   - staleBefore time.Time
+func (_e *MockExecutionChainRepository_Expecter) GetRecoverableChainRuns(ctx interface{}, workerID interface{}, ownBefore interface{}, staleBefore interface{}) *MockExecutionChainRepository_GetRecoverableChainRuns_Call {
+	return &MockExecutionChainRepository_GetRecoverableChainRuns_Call{Call: _e.mock.On("GetRecoverableChainRuns", ctx, workerID, ownBefore, staleBefore)}
 }
 
-func (_c *MockExecutionChainRepository_CreateStepRun_Call) Run(run func(ctx context.Context, stepRun *models.ExecutionChainStepRun)) *MockExecutionChainRepository_CreateStepRun_Call {
+func (_c *MockExecutionChainRepository_GetRecoverableChainRuns_Call) Run(run func(ctx context.Context, workerID string, ownBefore time.Time, staleBefore time.Time)) *MockExecutionChainRepository_GetRecoverableChainRuns_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.ExecutionChainStepRun))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_CreateStepRun_Call) Return(_a0 error) *MockExecutionChainRepository_CreateStepRun_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExecutionChainRepository_GetRecoverableChainRuns_Call) Return(_a0 []*models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetRecoverableChainRuns_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_CreateStepRun_Call) RunAndReturn(run func(context.Context, *models.ExecutionChainStepRun) error) *MockExecutionChainRepository_CreateStepRun_Call {
+func (_c *MockExecutionChainRepository_GetRecoverableChainRuns_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) ([]*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetRecoverableChainRuns_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteChain provides a mock function with given fields: ctx, id
-func (_m *MockExecutionChainRepository) DeleteChain(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// GetStepRunsByRun provides a mock function with given fields: ctx, runID
+func (_m *MockExecutionChainRepository) GetStepRunsByRun(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainStepRun, error) {
+	ret := _m.Called(ctx, runID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteChain")
+		panic("no return value specified for GetStepRunsByRun")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	var r0 []*models.ExecutionChainStepRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.ExecutionChainStepRun, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.ExecutionChainStepRun); ok {
+		r0 = rf(ctx, runID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExecutionChainStepRun)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExecutionChainRepository_DeleteChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChain'
-type MockExecutionChainRepository_DeleteChain_Call struct {
+// MockExecutionChainRepository_GetStepRunsByRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepRunsByRun'
+type MockExecutionChainRepository_GetStepRunsByRun_Call struct {
 	*mock.Call
 }
 
-// DeleteChain is a helper method to define mock.On call
+// GetStepRunsByRun is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockExecutionChainRepository_Expecter) DeleteChain(ctx interface{}, id interface{}) *MockExecutionChainRepository_DeleteChain_Call {
-	return &MockExecutionChainRepository_DeleteChain_Call{Call: _e.mock.On("DeleteChain", ctx, id)}
+//   - runID uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetStepRunsByRun(ctx interface{}, runID interface{}) *MockExecutionChainRepository_GetStepRunsByRun_Call {
+	return &MockExecutionChainRepository_GetStepRunsByRun_Call{Call: _e.mock.On("GetStepRunsByRun", ctx, runID)}
 }
 
-func (_c *MockExecutionChainRepository_DeleteChain_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockExecutionChainRepository_DeleteChain_Call {
+func (_c *MockExecutionChainRepository_GetStepRunsByRun_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainRepository_GetStepRunsByRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_DeleteChain_Call) Return(_a0 error) *MockExecutionChainRepository_DeleteChain_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExecutionChainRepository_GetStepRunsByRun_Call) Return(_a0 []*models.ExecutionChainStepRun, _a1 error) *MockExecutionChainRepository_GetStepRunsByRun_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_DeleteChain_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockExecutionChainRepository_DeleteChain_Call {
+func (_c *MockExecutionChainRepository_GetStepRunsByRun_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.ExecutionChainStepRun, error)) *MockExecutionChainRepository_GetStepRunsByRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChainByID provides a mock function with given fields: ctx, id
-func (_m *MockExecutionChainRepository) GetChainByID(ctx context.Context, id uuid.UUID) (*models.ExecutionChain, error) {
-	ret := _m.Called(ctx, id)
+// GetStepsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockExecutionChainRepository) GetStepsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.ExecutionChainStep, error) {
+	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChainByID")
+		panic("no return value specified for GetStepsByIDs")
 	}
 
-	var r0 *models.ExecutionChain
+	var r0 []models.ExecutionChainStep
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecutionChain, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]models.ExecutionChainStep, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecutionChain); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []models.ExecutionChainStep); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ExecutionChain)
+			r0 = ret.Get(0).([]models.ExecutionChainStep)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -242,58 +1711,58 @@ func (_m *MockExecutionChainRepository) GetChainByID(ctx context.Context, id uui
 	return r0, r1
 }
 
-// MockExecutionChainRepository_GetChainByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainByID'
-type MockExecutionChainRepository_GetChainByID_Call struct {
+// MockExecutionChainRepository_GetStepsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepsByIDs'
+type MockExecutionChainRepository_GetStepsByIDs_Call struct {
 	*mock.Call
 }
 
-// GetChainByID is a helper method to define mock.On call
+// GetStepsByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockExecutionChainRepository_Expecter) GetChainByID(ctx interface{}, id interface{}) *MockExecutionChainRepository_GetChainByID_Call {
-	return &MockExecutionChainRepository_GetChainByID_Call{Call: _e.mock.On("GetChainByID", ctx, id)}
+//   - ids []uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetStepsByIDs(ctx interface{}, ids interface{}) *MockExecutionChainRepository_GetStepsByIDs_Call {
+	return &MockExecutionChainRepository_GetStepsByIDs_Call{Call: _e.mock.On("GetStepsByIDs", ctx, ids)}
 }
 
-func (_c *MockExecutionChainRepository_GetChainByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockExecutionChainRepository_GetChainByID_Call {
+func (_c *MockExecutionChainRepository_GetStepsByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockExecutionChainRepository_GetStepsByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainByID_Call) Return(_a0 *models.ExecutionChain, _a1 error) *MockExecutionChainRepository_GetChainByID_Call {
+func (_c *MockExecutionChainRepository_GetStepsByIDs_Call) Return(_a0 []models.ExecutionChainStep, _a1 error) *MockExecutionChainRepository_GetStepsByIDs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecutionChain, error)) *MockExecutionChainRepository_GetChainByID_Call {
+func (_c *MockExecutionChainRepository_GetStepsByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]models.ExecutionChainStep, error)) *MockExecutionChainRepository_GetStepsByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChainRunByID provides a mock function with given fields: ctx, runID
-func (_m *MockExecutionChainRepository) GetChainRunByID(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
-	ret := _m.Called(ctx, runID)
+// GetStepsByWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *MockExecutionChainRepository) GetStepsByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*models.ExecutionChainStep, error) {
+	ret := _m.Called(ctx, webhookID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChainRunByID")
+		panic("no return value specified for GetStepsByWebhook")
 	}
 
-	var r0 *models.ExecutionChainRun
+	var r0 []*models.ExecutionChainStep
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error)); ok {
-		return rf(ctx, runID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.ExecutionChainStep, error)); ok {
+		return rf(ctx, webhookID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecutionChainRun); ok {
-		r0 = rf(ctx, runID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.ExecutionChainStep); ok {
+		r0 = rf(ctx, webhookID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ExecutionChainRun)
+			r0 = ret.Get(0).([]*models.ExecutionChainStep)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, runID)
+		r1 = rf(ctx, webhookID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -301,334 +1770,331 @@ func (_m *MockExecutionChainRepository) GetChainRunByID(ctx context.Context, run
 	return r0, r1
 }
 
-// MockExecutionChainRepository_GetChainRunByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunByID'
-type MockExecutionChainRepository_GetChainRunByID_Call struct {
+// MockExecutionChainRepository_GetStepsByWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepsByWebhook'
+type MockExecutionChainRepository_GetStepsByWebhook_Call struct {
 	*mock.Call
 }
 
-// GetChainRunByID is a helper method to define mock.On call
+// GetStepsByWebhook is a helper method to define mock.On call
 //   - ctx context.Context
-//   - runID uuid.UUID
-func (_e *MockExecutionChainRepository_Expecter) GetChainRunByID(ctx interface{}, runID interface{}) *MockExecutionChainRepository_GetChainRunByID_Call {
-	return &MockExecutionChainRepository_GetChainRunByID_Call{Call: _e.mock.On("GetChainRunByID", ctx, runID)}
+//   - webhookID uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) GetStepsByWebhook(ctx interface{}, webhookID interface{}) *MockExecutionChainRepository_GetStepsByWebhook_Call {
+	return &MockExecutionChainRepository_GetStepsByWebhook_Call{Call: _e.mock.On("GetStepsByWebhook", ctx, webhookID)}
 }
 
-func (_c *MockExecutionChainRepository_GetChainRunByID_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainRepository_GetChainRunByID_Call {
+func (_c *MockExecutionChainRepository_GetStepsByWebhook_Call) Run(run func(ctx context.Context, webhookID uuid.UUID)) *MockExecutionChainRepository_GetStepsByWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainRunByID_Call) Return(_a0 *models.ExecutionChainRun, _a1 error) *MockExecutionChainRepository_GetChainRunByID_Call {
+func (_c *MockExecutionChainRepository_GetStepsByWebhook_Call) Return(_a0 []*models.ExecutionChainStep, _a1 error) *MockExecutionChainRepository_GetStepsByWebhook_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainRunByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error)) *MockExecutionChainRepository_GetChainRunByID_Call {
+func (_c *MockExecutionChainRepository_GetStepsByWebhook_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.ExecutionChainStep, error)) *MockExecutionChainRepository_GetStepsByWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChainRunsByChain provides a mock function with given fields: ctx, chainID, offset, limit
-func (_m *MockExecutionChainRepository) GetChainRunsByChain(ctx context.Context, chainID uuid.UUID, offset int, limit int) ([]*models.ExecutionChainRun, int64, error) {
-	ret := _m.Called(ctx, chainID, offset, limit)
+// RestoreChain provides a mock function with given fields: ctx, id
+func (_m *MockExecutionChainRepository) RestoreChain(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChainRunsByChain")
+		panic("no return value specified for RestoreChain")
 	}
 
-	var r0 []*models.ExecutionChainRun
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]*models.ExecutionChainRun, int64, error)); ok {
-		return rf(ctx, chainID, offset, limit)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []*models.ExecutionChainRun); ok {
-		r0 = rf(ctx, chainID, offset, limit)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.ExecutionChainRun)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) int64); ok {
-		r1 = rf(ctx, chainID, offset, limit)
-	} else {
-		r1 = ret.Get(1).(int64)
+	return r0
+}
+
+// MockExecutionChainRepository_RestoreChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreChain'
+type MockExecutionChainRepository_RestoreChain_Call struct {
+	*mock.Call
+}
+
+// RestoreChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockExecutionChainRepository_Expecter) RestoreChain(ctx interface{}, id interface{}) *MockExecutionChainRepository_RestoreChain_Call {
+	return &MockExecutionChainRepository_RestoreChain_Call{Call: _e.mock.On("RestoreChain", ctx, id)}
+}
+
+func (_c *MockExecutionChainRepository_RestoreChain_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockExecutionChainRepository_RestoreChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_RestoreChain_Call) Return(_a0 error) *MockExecutionChainRepository_RestoreChain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_RestoreChain_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockExecutionChainRepository_RestoreChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchChainRuns provides a mock function with given fields: ctx, runIDs, workerID, now
+func (_m *MockExecutionChainRepository) TouchChainRuns(ctx context.Context, runIDs []uuid.UUID, workerID string, now time.Time) error {
+	ret := _m.Called(ctx, runIDs, workerID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchChainRuns")
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int, int) error); ok {
-		r2 = rf(ctx, chainID, offset, limit)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(ctx, runIDs, workerID, now)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// MockExecutionChainRepository_GetChainRunsByChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunsByChain'
-type MockExecutionChainRepository_GetChainRunsByChain_Call struct {
+// MockExecutionChainRepository_TouchChainRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchChainRuns'
+type MockExecutionChainRepository_TouchChainRuns_Call struct {
 	*mock.Call
 }
 
-// GetChainRunsByChain is a helper method to define mock.On call
+// TouchChainRuns is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chainID uuid.UUID
-//   - offset int
-//   - limit int
-func (_e *MockExecutionChainRepository_Expecter) GetChainRunsByChain(ctx interface{}, chainID interface{}, offset interface{}, limit interface{}) *MockExecutionChainRepository_GetChainRunsByChain_Call {
-	return &MockExecutionChainRepository_GetChainRunsByChain_Call{Call: _e.mock.On("GetChainRunsByChain", ctx, chainID, offset, limit)}
+//   - runIDs []uuid.UUID
+//   - workerID string
+//   - now time.Time
+func (_e *MockExecutionChainRepository_Expecter) TouchChainRuns(ctx interface{}, runIDs interface{}, workerID interface{}, now interface{}) *MockExecutionChainRepository_TouchChainRuns_Call {
+	return &MockExecutionChainRepository_TouchChainRuns_Call{Call: _e.mock.On("TouchChainRuns", ctx, runIDs, workerID, now)}
 }
 
-func (_c *MockExecutionChainRepository_GetChainRunsByChain_Call) Run(run func(ctx context.Context, chainID uuid.UUID, offset int, limit int)) *MockExecutionChainRepository_GetChainRunsByChain_Call {
+func (_c *MockExecutionChainRepository_TouchChainRuns_Call) Run(run func(ctx context.Context, runIDs []uuid.UUID, workerID string, now time.Time)) *MockExecutionChainRepository_TouchChainRuns_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(string), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainRunsByChain_Call) Return(_a0 []*models.ExecutionChainRun, _a1 int64, _a2 error) *MockExecutionChainRepository_GetChainRunsByChain_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockExecutionChainRepository_TouchChainRuns_Call) Return(_a0 error) *MockExecutionChainRepository_TouchChainRuns_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainRunsByChain_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, int) ([]*models.ExecutionChainRun, int64, error)) *MockExecutionChainRepository_GetChainRunsByChain_Call {
+func (_c *MockExecutionChainRepository_TouchChainRuns_Call) RunAndReturn(run func(context.Context, []uuid.UUID, string, time.Time) error) *MockExecutionChainRepository_TouchChainRuns_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChainsByTenant provides a mock function with given fields: ctx, tenantID, offset, limit
-func (_m *MockExecutionChainRepository) GetChainsByTenant(ctx context.Context, tenantID string, offset int, limit int) ([]*models.ExecutionChain, int64, error) {
-	ret := _m.Called(ctx, tenantID, offset, limit)
+// UpdateChain provides a mock function with given fields: ctx, id, updates
+func (_m *MockExecutionChainRepository) UpdateChain(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	ret := _m.Called(ctx, id, updates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChainsByTenant")
-	}
-
-	var r0 []*models.ExecutionChain
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*models.ExecutionChain, int64, error)); ok {
-		return rf(ctx, tenantID, offset, limit)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*models.ExecutionChain); ok {
-		r0 = rf(ctx, tenantID, offset, limit)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.ExecutionChain)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
-		r1 = rf(ctx, tenantID, offset, limit)
-	} else {
-		r1 = ret.Get(1).(int64)
+		panic("no return value specified for UpdateChain")
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, tenantID, offset, limit)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
+		r0 = rf(ctx, id, updates)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// MockExecutionChainRepository_GetChainsByTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainsByTenant'
-type MockExecutionChainRepository_GetChainsByTenant_Call struct {
+// MockExecutionChainRepository_UpdateChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChain'
+type MockExecutionChainRepository_UpdateChain_Call struct {
 	*mock.Call
 }
 
-// GetChainsByTenant is a helper method to define mock.On call
+// UpdateChain is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-//   - offset int
-//   - limit int
-func (_e *MockExecutionChainRepository_Expecter) GetChainsByTenant(ctx interface{}, tenantID interface{}, offset interface{}, limit interface{}) *MockExecutionChainRepository_GetChainsByTenant_Call {
-	return &MockExecutionChainRepository_GetChainsByTenant_Call{Call: _e.mock.On("GetChainsByTenant", ctx, tenantID, offset, limit)}
+//   - id uuid.UUID
+//   - updates map[string]interface{}
+func (_e *MockExecutionChainRepository_Expecter) UpdateChain(ctx interface{}, id interface{}, updates interface{}) *MockExecutionChainRepository_UpdateChain_Call {
+	return &MockExecutionChainRepository_UpdateChain_Call{Call: _e.mock.On("UpdateChain", ctx, id, updates)}
 }
 
-func (_c *MockExecutionChainRepository_GetChainsByTenant_Call) Run(run func(ctx context.Context, tenantID string, offset int, limit int)) *MockExecutionChainRepository_GetChainsByTenant_Call {
+func (_c *MockExecutionChainRepository_UpdateChain_Call) Run(run func(ctx context.Context, id uuid.UUID, updates map[string]interface{})) *MockExecutionChainRepository_UpdateChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainsByTenant_Call) Return(_a0 []*models.ExecutionChain, _a1 int64, _a2 error) *MockExecutionChainRepository_GetChainsByTenant_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockExecutionChainRepository_UpdateChain_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateChain_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainsByTenant_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*models.ExecutionChain, int64, error)) *MockExecutionChainRepository_GetChainsByTenant_Call {
+func (_c *MockExecutionChainRepository_UpdateChain_Call) RunAndReturn(run func(context.Context, uuid.UUID, map[string]interface{}) error) *MockExecutionChainRepository_UpdateChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChainsByTriggerEvent provides a mock function with given fields: ctx, tenantID, event
-func (_m *MockExecutionChainRepository) GetChainsByTriggerEvent(ctx context.Context, tenantID string, event string) ([]*models.ExecutionChain, error) {
-	ret := _m.Called(ctx, tenantID, event)
+// UpdateChainAtVersion provides a mock function with given fields: ctx, id, lockVersion, updates
+func (_m *MockExecutionChainRepository) UpdateChainAtVersion(ctx context.Context, id uuid.UUID, lockVersion int64, updates map[string]interface{}) error {
+	ret := _m.Called(ctx, id, lockVersion, updates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChainsByTriggerEvent")
-	}
-
-	var r0 []*models.ExecutionChain
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.ExecutionChain, error)); ok {
-		return rf(ctx, tenantID, event)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.ExecutionChain); ok {
-		r0 = rf(ctx, tenantID, event)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.ExecutionChain)
-		}
+		panic("no return value specified for UpdateChainAtVersion")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, tenantID, event)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, map[string]interface{}) error); ok {
+		r0 = rf(ctx, id, lockVersion, updates)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockExecutionChainRepository_GetChainsByTriggerEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainsByTriggerEvent'
-type MockExecutionChainRepository_GetChainsByTriggerEvent_Call struct {
+// MockExecutionChainRepository_UpdateChainAtVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChainAtVersion'
+type MockExecutionChainRepository_UpdateChainAtVersion_Call struct {
 	*mock.Call
 }
 
-// GetChainsByTriggerEvent is a helper method to define mock.On call
+// UpdateChainAtVersion is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-//   - event string
-func (_e *MockExecutionChainRepository_Expecter) GetChainsByTriggerEvent(ctx interface{}, tenantID interface{}, event interface{}) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
-	return &MockExecutionChainRepository_GetChainsByTriggerEvent_Call{Call: _e.mock.On("GetChainsByTriggerEvent", ctx, tenantID, event)}
+//   - id uuid.UUID
+//   - lockVersion int64
+//   - updates map[string]interface{}
+func (_e *MockExecutionChainRepository_Expecter) UpdateChainAtVersion(ctx interface{}, id interface{}, lockVersion interface{}, updates interface{}) *MockExecutionChainRepository_UpdateChainAtVersion_Call {
+	return &MockExecutionChainRepository_UpdateChainAtVersion_Call{Call: _e.mock.On("UpdateChainAtVersion", ctx, id, lockVersion, updates)}
 }
 
-func (_c *MockExecutionChainRepository_GetChainsByTriggerEvent_Call) Run(run func(ctx context.Context, tenantID string, event string)) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
+func (_c *MockExecutionChainRepository_UpdateChainAtVersion_Call) Run(run func(ctx context.Context, id uuid.UUID, lockVersion int64, updates map[string]interface{})) *MockExecutionChainRepository_UpdateChainAtVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainsByTriggerEvent_Call) Return(_a0 []*models.ExecutionChain, _a1 error) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockExecutionChainRepository_UpdateChainAtVersion_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateChainAtVersion_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetChainsByTriggerEvent_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.ExecutionChain, error)) *MockExecutionChainRepository_GetChainsByTriggerEvent_Call {
+func (_c *MockExecutionChainRepository_UpdateChainAtVersion_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64, map[string]interface{}) error) *MockExecutionChainRepository_UpdateChainAtVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStepRunsByRun provides a mock function with given fields: ctx, runID
-func (_m *MockExecutionChainRepository) GetStepRunsByRun(ctx context.Context, runID uuid.UUID) ([]*models.ExecutionChainStepRun, error) {
-	ret := _m.Called(ctx, runID)
+// UpdateChainRun provides a mock function with given fields: ctx, runID, updates
+func (_m *MockExecutionChainRepository) UpdateChainRun(ctx context.Context, runID uuid.UUID, updates map[string]interface{}) error {
+	ret := _m.Called(ctx, runID, updates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStepRunsByRun")
-	}
-
-	var r0 []*models.ExecutionChainStepRun
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.ExecutionChainStepRun, error)); ok {
-		return rf(ctx, runID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.ExecutionChainStepRun); ok {
-		r0 = rf(ctx, runID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.ExecutionChainStepRun)
-		}
+		panic("no return value specified for UpdateChainRun")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, runID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
+		r0 = rf(ctx, runID, updates)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockExecutionChainRepository_GetStepRunsByRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepRunsByRun'
-type MockExecutionChainRepository_GetStepRunsByRun_Call struct {
+// MockExecutionChainRepository_UpdateChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChainRun'
+type MockExecutionChainRepository_UpdateChainRun_Call struct {
 	*mock.Call
 }
 
-// GetStepRunsByRun is a helper method to define mock.On call
+// UpdateChainRun is a helper method to define mock.On call
 //   - ctx context.Context
 //   - runID uuid.UUID
-func (_e *MockExecutionChainRepository_Expecter) GetStepRunsByRun(ctx interface{}, runID interface{}) *MockExecutionChainRepository_GetStepRunsByRun_Call {
-	return &MockExecutionChainRepository_GetStepRunsByRun_Call{Call: _e.mock.On("GetStepRunsByRun", ctx, runID)}
+//   - updates map[string]interface{}
+func (_e *MockExecutionChainRepository_Expecter) UpdateChainRun(ctx interface{}, runID interface{}, updates interface{}) *MockExecutionChainRepository_UpdateChainRun_Call {
+	return &MockExecutionChainRepository_UpdateChainRun_Call{Call: _e.mock.On("UpdateChainRun", ctx, runID, updates)}
 }
 
-func (_c *MockExecutionChainRepository_GetStepRunsByRun_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainRepository_GetStepRunsByRun_Call {
+func (_c *MockExecutionChainRepository_UpdateChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID, updates map[string]interface{})) *MockExecutionChainRepository_UpdateChainRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetStepRunsByRun_Call) Return(_a0 []*models.ExecutionChainStepRun, _a1 error) *MockExecutionChainRepository_GetStepRunsByRun_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockExecutionChainRepository_UpdateChainRun_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateChainRun_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_GetStepRunsByRun_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.ExecutionChainStepRun, error)) *MockExecutionChainRepository_GetStepRunsByRun_Call {
+func (_c *MockExecutionChainRepository_UpdateChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, map[string]interface{}) error) *MockExecutionChainRepository_UpdateChainRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateChain provides a mock function with given fields: ctx, id, updates
-func (_m *MockExecutionChainRepository) UpdateChain(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
-	ret := _m.Called(ctx, id, updates)
+// UpdateChainRunIfStatus provides a mock function with given fields: ctx, runID, status, updates
+func (_m *MockExecutionChainRepository) UpdateChainRunIfStatus(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{}) (bool, error) {
+	ret := _m.Called(ctx, runID, status, updates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateChain")
+		panic("no return value specified for UpdateChainRunIfStatus")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
-		r0 = rf(ctx, id, updates)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ExecutionChainStatus, map[string]interface{}) (bool, error)); ok {
+		return rf(ctx, runID, status, updates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ExecutionChainStatus, map[string]interface{}) bool); ok {
+		r0 = rf(ctx, runID, status, updates)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.ExecutionChainStatus, map[string]interface{}) error); ok {
+		r1 = rf(ctx, runID, status, updates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExecutionChainRepository_UpdateChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChain'
-type MockExecutionChainRepository_UpdateChain_Call struct {
+// MockExecutionChainRepository_UpdateChainRunIfStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChainRunIfStatus'
+type MockExecutionChainRepository_UpdateChainRunIfStatus_Call struct {
 	*mock.Call
 }
 
-// UpdateChain is a helper method to define mock.On call
+// UpdateChainRunIfStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
+//   - runID uuid.UUID
+//   - status models.ExecutionChainStatus
 //   - updates map[string]interface{}
-func (_e *MockExecutionChainRepository_Expecter) UpdateChain(ctx interface{}, id interface{}, updates interface{}) *MockExecutionChainRepository_UpdateChain_Call {
-	return &MockExecutionChainRepository_UpdateChain_Call{Call: _e.mock.On("UpdateChain", ctx, id, updates)}
+func (_e *MockExecutionChainRepository_Expecter) UpdateChainRunIfStatus(ctx interface{}, runID interface{}, status interface{}, updates interface{}) *MockExecutionChainRepository_UpdateChainRunIfStatus_Call {
+	return &MockExecutionChainRepository_UpdateChainRunIfStatus_Call{Call: _e.mock.On("UpdateChainRunIfStatus", ctx, runID, status, updates)}
 }
 
-func (_c *MockExecutionChainRepository_UpdateChain_Call) Run(run func(ctx context.Context, id uuid.UUID, updates map[string]interface{})) *MockExecutionChainRepository_UpdateChain_Call {
+func (_c *MockExecutionChainRepository_UpdateChainRunIfStatus_Call) Run(run func(ctx context.Context, runID uuid.UUID, status models.ExecutionChainStatus, updates map[string]interface{})) *MockExecutionChainRepository_UpdateChainRunIfStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(map[string]interface{}))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.ExecutionChainStatus), args[3].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_UpdateChain_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateChain_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExecutionChainRepository_UpdateChainRunIfStatus_Call) Return(_a0 bool, _a1 error) *MockExecutionChainRepository_UpdateChainRunIfStatus_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainRepository_UpdateChain_Call) RunAndReturn(run func(context.Context, uuid.UUID, map[string]interface{}) error) *MockExecutionChainRepository_UpdateChain_Call {
+func (_c *MockExecutionChainRepository_UpdateChainRunIfStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.ExecutionChainStatus, map[string]interface{}) (bool, error)) *MockExecutionChainRepository_UpdateChainRunIfStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -729,6 +2195,105 @@ func (_c *MockExecutionChainRepository_UpdateChainRunStep_Call) RunAndReturn(run
 	return _c
 }
 
+// UpdateChainSteps provides a mock function with given fields: ctx, chainID, kept, created, retired, version
+func (_m *MockExecutionChainRepository) UpdateChainSteps(ctx context.Context, chainID uuid.UUID, kept []models.ExecutionChainStep, created []models.ExecutionChainStep, retired []uuid.UUID, version *models.ExecutionChainVersion) error {
+	ret := _m.Called(ctx, chainID, kept, created, retired, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChainSteps")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []models.ExecutionChainStep, []models.ExecutionChainStep, []uuid.UUID, *models.ExecutionChainVersion) error); ok {
+		r0 = rf(ctx, chainID, kept, created, retired, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_UpdateChainSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChainSteps'
+type MockExecutionChainRepository_UpdateChainSteps_Call struct {
+	*mock.Call
+}
+
+// UpdateChainSteps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID uuid.UUID
+//   - kept []models.ExecutionChainStep
+//   - created []models.ExecutionChainStep
+//   - retired []uuid.UUID
+//   - version *models.ExecutionChainVersion
+func (_e *MockExecutionChainRepository_Expecter) UpdateChainSteps(ctx interface{}, chainID interface{}, kept interface{}, created interface{}, retired interface{}, version interface{}) *MockExecutionChainRepository_UpdateChainSteps_Call {
+	return &MockExecutionChainRepository_UpdateChainSteps_Call{Call: _e.mock.On("UpdateChainSteps", ctx, chainID, kept, created, retired, version)}
+}
+
+func (_c *MockExecutionChainRepository_UpdateChainSteps_Call) Run(run func(ctx context.Context, chainID uuid.UUID, kept []models.ExecutionChainStep, created []models.ExecutionChainStep, retired []uuid.UUID, version *models.ExecutionChainVersion)) *MockExecutionChainRepository_UpdateChainSteps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]models.ExecutionChainStep), args[3].([]models.ExecutionChainStep), args[4].([]uuid.UUID), args[5].(*models.ExecutionChainVersion))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_UpdateChainSteps_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateChainSteps_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_UpdateChainSteps_Call) RunAndReturn(run func(context.Context, uuid.UUID, []models.ExecutionChainStep, []models.ExecutionChainStep, []uuid.UUID, *models.ExecutionChainVersion) error) *MockExecutionChainRepository_UpdateChainSteps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCompensationRun provides a mock function with given fields: ctx, compensationID, updates
+func (_m *MockExecutionChainRepository) UpdateCompensationRun(ctx context.Context, compensationID uuid.UUID, updates map[string]interface{}) error {
+	ret := _m.Called(ctx, compensationID, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCompensationRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
+		r0 = rf(ctx, compensationID, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_UpdateCompensationRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCompensationRun'
+type MockExecutionChainRepository_UpdateCompensationRun_Call struct {
+	*mock.Call
+}
+
+// UpdateCompensationRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - compensationID uuid.UUID
+//   - updates map[string]interface{}
+func (_e *MockExecutionChainRepository_Expecter) UpdateCompensationRun(ctx interface{}, compensationID interface{}, updates interface{}) *MockExecutionChainRepository_UpdateCompensationRun_Call {
+	return &MockExecutionChainRepository_UpdateCompensationRun_Call{Call: _e.mock.On("UpdateCompensationRun", ctx, compensationID, updates)}
+}
+
+func (_c *MockExecutionChainRepository_UpdateCompensationRun_Call) Run(run func(ctx context.Context, compensationID uuid.UUID, updates map[string]interface{})) *MockExecutionChainRepository_UpdateCompensationRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_UpdateCompensationRun_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateCompensationRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_UpdateCompensationRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, map[string]interface{}) error) *MockExecutionChainRepository_UpdateCompensationRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateStepRun provides a mock function with given fields: ctx, stepRunID, updates
 func (_m *MockExecutionChainRepository) UpdateStepRun(ctx context.Context, stepRunID uuid.UUID, updates map[string]interface{}) error {
 	ret := _m.Called(ctx, stepRunID, updates)
@@ -777,6 +2342,53 @@ func (_c *MockExecutionChainRepository_UpdateStepRun_Call) RunAndReturn(run func
 	return _c
 }
 
+// UpdateStepRuns provides a mock function with given fields: ctx, updates
+func (_m *MockExecutionChainRepository) UpdateStepRuns(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) error {
+	ret := _m.Called(ctx, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStepRuns")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[uuid.UUID]map[string]interface{}) error); ok {
+		r0 = rf(ctx, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainRepository_UpdateStepRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStepRuns'
+type MockExecutionChainRepository_UpdateStepRuns_Call struct {
+	*mock.Call
+}
+
+// UpdateStepRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updates map[uuid.UUID]map[string]interface{}
+func (_e *MockExecutionChainRepository_Expecter) UpdateStepRuns(ctx interface{}, updates interface{}) *MockExecutionChainRepository_UpdateStepRuns_Call {
+	return &MockExecutionChainRepository_UpdateStepRuns_Call{Call: _e.mock.On("UpdateStepRuns", ctx, updates)}
+}
+
+func (_c *MockExecutionChainRepository_UpdateStepRuns_Call) Run(run func(ctx context.Context, updates map[uuid.UUID]map[string]interface{})) *MockExecutionChainRepository_UpdateStepRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[uuid.UUID]map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_UpdateStepRuns_Call) Return(_a0 error) *MockExecutionChainRepository_UpdateStepRuns_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainRepository_UpdateStepRuns_Call) RunAndReturn(run func(context.Context, map[uuid.UUID]map[string]interface{}) error) *MockExecutionChainRepository_UpdateStepRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockExecutionChainRepository creates a new instance of MockExecutionChainRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockExecutionChainRepository(t interface {
@@ -4,8 +4,11 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	models "github.com/sakibcoolz/loki-suite/internal/models"
+	repository "github.com/sakibcoolz/loki-suite/internal/repository"
+	service "github.com/sakibcoolz/loki-suite/internal/service"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -24,6 +27,253 @@ func (_m *MockExecutionChainService) EXPECT() *MockExecutionChainService_Expecte
 	return &MockExecutionChainService_Expecter{mock: &_m.Mock}
 }
 
+// ActivateChain provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) ActivateChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainStateResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateChain")
+	}
+
+	var r0 *models.ChainStateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ChainStateResponse, error)); ok {
+		return rf(ctx, tenantID, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ChainStateResponse); ok {
+		r0 = rf(ctx, tenantID, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainStateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ActivateChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivateChain'
+type MockExecutionChainService_ActivateChain_Call struct {
+	*mock.Call
+}
+
+// ActivateChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) ActivateChain(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_ActivateChain_Call {
+	return &MockExecutionChainService_ActivateChain_Call{Call: _e.mock.On("ActivateChain", ctx, tenantID, chainID)}
+}
+
+func (_c *MockExecutionChainService_ActivateChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_ActivateChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ActivateChain_Call) Return(_a0 *models.ChainStateResponse, _a1 error) *MockExecutionChainService_ActivateChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ActivateChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ChainStateResponse, error)) *MockExecutionChainService_ActivateChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApproveChainRun provides a mock function with given fields: ctx, runID, approval
+func (_m *MockExecutionChainService) ApproveChainRun(ctx context.Context, runID uuid.UUID, approval models.StepApproval) (*models.ChainRunControlResponse, error) {
+	ret := _m.Called(ctx, runID, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveChainRun")
+	}
+
+	var r0 *models.ChainRunControlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.StepApproval) (*models.ChainRunControlResponse, error)); ok {
+		return rf(ctx, runID, approval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.StepApproval) *models.ChainRunControlResponse); ok {
+		r0 = rf(ctx, runID, approval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainRunControlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.StepApproval) error); ok {
+		r1 = rf(ctx, runID, approval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ApproveChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveChainRun'
+type MockExecutionChainService_ApproveChainRun_Call struct {
+	*mock.Call
+}
+
+// ApproveChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+//   - approval models.StepApproval
+func (_e *MockExecutionChainService_Expecter) ApproveChainRun(ctx interface{}, runID interface{}, approval interface{}) *MockExecutionChainService_ApproveChainRun_Call {
+	return &MockExecutionChainService_ApproveChainRun_Call{Call: _e.mock.On("ApproveChainRun", ctx, runID, approval)}
+}
+
+func (_c *MockExecutionChainService_ApproveChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID, approval models.StepApproval)) *MockExecutionChainService_ApproveChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.StepApproval))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ApproveChainRun_Call) Return(_a0 *models.ChainRunControlResponse, _a1 error) *MockExecutionChainService_ApproveChainRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ApproveChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.StepApproval) (*models.ChainRunControlResponse, error)) *MockExecutionChainService_ApproveChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelChainRun provides a mock function with given fields: ctx, runID, reason
+func (_m *MockExecutionChainService) CancelChainRun(ctx context.Context, runID uuid.UUID, reason string) (*models.ChainRunControlResponse, error) {
+	ret := _m.Called(ctx, runID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelChainRun")
+	}
+
+	var r0 *models.ChainRunControlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*models.ChainRunControlResponse, error)); ok {
+		return rf(ctx, runID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *models.ChainRunControlResponse); ok {
+		r0 = rf(ctx, runID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainRunControlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, runID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_CancelChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelChainRun'
+type MockExecutionChainService_CancelChainRun_Call struct {
+	*mock.Call
+}
+
+// CancelChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+//   - reason string
+func (_e *MockExecutionChainService_Expecter) CancelChainRun(ctx interface{}, runID interface{}, reason interface{}) *MockExecutionChainService_CancelChainRun_Call {
+	return &MockExecutionChainService_CancelChainRun_Call{Call: _e.mock.On("CancelChainRun", ctx, runID, reason)}
+}
+
+func (_c *MockExecutionChainService_CancelChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID, reason string)) *MockExecutionChainService_CancelChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_CancelChainRun_Call) Return(_a0 *models.ChainRunControlResponse, _a1 error) *MockExecutionChainService_CancelChainRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_CancelChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*models.ChainRunControlResponse, error)) *MockExecutionChainService_CancelChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfigureRunLimit provides a mock function with given fields: maxRunningRuns
+func (_m *MockExecutionChainService) ConfigureRunLimit(maxRunningRuns int) {
+	_m.Called(maxRunningRuns)
+}
+
+// MockExecutionChainService_ConfigureRunLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigureRunLimit'
+type MockExecutionChainService_ConfigureRunLimit_Call struct {
+	*mock.Call
+}
+
+// ConfigureRunLimit is a helper method to define mock.On call
+//   - maxRunningRuns int
+func (_e *MockExecutionChainService_Expecter) ConfigureRunLimit(maxRunningRuns interface{}) *MockExecutionChainService_ConfigureRunLimit_Call {
+	return &MockExecutionChainService_ConfigureRunLimit_Call{Call: _e.mock.On("ConfigureRunLimit", maxRunningRuns)}
+}
+
+func (_c *MockExecutionChainService_ConfigureRunLimit_Call) Run(run func(maxRunningRuns int)) *MockExecutionChainService_ConfigureRunLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ConfigureRunLimit_Call) Return() *MockExecutionChainService_ConfigureRunLimit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_ConfigureRunLimit_Call) RunAndReturn(run func(int)) *MockExecutionChainService_ConfigureRunLimit_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ConfigureWorkers provides a mock function with given fields: instanceID, maxConcurrentRuns
+func (_m *MockExecutionChainService) ConfigureWorkers(instanceID string, maxConcurrentRuns int) {
+	_m.Called(instanceID, maxConcurrentRuns)
+}
+
+// MockExecutionChainService_ConfigureWorkers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigureWorkers'
+type MockExecutionChainService_ConfigureWorkers_Call struct {
+	*mock.Call
+}
+
+// ConfigureWorkers is a helper method to define mock.On call
+//   - instanceID string
+//   - maxConcurrentRuns int
+func (_e *MockExecutionChainService_Expecter) ConfigureWorkers(instanceID interface{}, maxConcurrentRuns interface{}) *MockExecutionChainService_ConfigureWorkers_Call {
+	return &MockExecutionChainService_ConfigureWorkers_Call{Call: _e.mock.On("ConfigureWorkers", instanceID, maxConcurrentRuns)}
+}
+
+func (_c *MockExecutionChainService_ConfigureWorkers_Call) Run(run func(instanceID string, maxConcurrentRuns int)) *MockExecutionChainService_ConfigureWorkers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ConfigureWorkers_Call) Return() *MockExecutionChainService_ConfigureWorkers_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_ConfigureWorkers_Call) RunAndReturn(run func(string, int)) *MockExecutionChainService_ConfigureWorkers_Call {
+	_c.Run(run)
+	return _c
+}
+
 // CreateChain provides a mock function with given fields: ctx, req
 func (_m *MockExecutionChainService) CreateChain(ctx context.Context, req *models.CreateExecutionChainRequest) (*models.CreateExecutionChainResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -83,17 +333,17 @@ func (_c *MockExecutionChainService_CreateChain_Call) RunAndReturn(run func(cont
 	return _c
 }
 
-// DeleteChain provides a mock function with given fields: ctx, chainID
-func (_m *MockExecutionChainService) DeleteChain(ctx context.Context, chainID uuid.UUID) error {
-	ret := _m.Called(ctx, chainID)
+// DeleteChain provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) DeleteChain(ctx context.Context, tenantID string, chainID uuid.UUID) error {
+	ret := _m.Called(ctx, tenantID, chainID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteChain")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, chainID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, tenantID, chainID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -108,14 +358,15 @@ type MockExecutionChainService_DeleteChain_Call struct {
 
 // DeleteChain is a helper method to define mock.On call
 //   - ctx context.Context
+//   - tenantID string
 //   - chainID uuid.UUID
-func (_e *MockExecutionChainService_Expecter) DeleteChain(ctx interface{}, chainID interface{}) *MockExecutionChainService_DeleteChain_Call {
-	return &MockExecutionChainService_DeleteChain_Call{Call: _e.mock.On("DeleteChain", ctx, chainID)}
+func (_e *MockExecutionChainService_Expecter) DeleteChain(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_DeleteChain_Call {
+	return &MockExecutionChainService_DeleteChain_Call{Call: _e.mock.On("DeleteChain", ctx, tenantID, chainID)}
 }
 
-func (_c *MockExecutionChainService_DeleteChain_Call) Run(run func(ctx context.Context, chainID uuid.UUID)) *MockExecutionChainService_DeleteChain_Call {
+func (_c *MockExecutionChainService_DeleteChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_DeleteChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
@@ -125,14 +376,74 @@ func (_c *MockExecutionChainService_DeleteChain_Call) Return(_a0 error) *MockExe
 	return _c
 }
 
-func (_c *MockExecutionChainService_DeleteChain_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockExecutionChainService_DeleteChain_Call {
+func (_c *MockExecutionChainService_DeleteChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) error) *MockExecutionChainService_DeleteChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteChain provides a mock function with given fields: ctx, req
-func (_m *MockExecutionChainService) ExecuteChain(ctx context.Context, req *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error) {
-	ret := _m.Called(ctx, req)
+// DryRunChain provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockExecutionChainService) DryRunChain(ctx context.Context, tenantID string, req *models.ExecuteChainRequest) (*models.ChainDryRunResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DryRunChain")
+	}
+
+	var r0 *models.ChainDryRunResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ExecuteChainRequest) (*models.ChainDryRunResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ExecuteChainRequest) *models.ChainDryRunResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainDryRunResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ExecuteChainRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_DryRunChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DryRunChain'
+type MockExecutionChainService_DryRunChain_Call struct {
+	*mock.Call
+}
+
+// DryRunChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - req *models.ExecuteChainRequest
+func (_e *MockExecutionChainService_Expecter) DryRunChain(ctx interface{}, tenantID interface{}, req interface{}) *MockExecutionChainService_DryRunChain_Call {
+	return &MockExecutionChainService_DryRunChain_Call{Call: _e.mock.On("DryRunChain", ctx, tenantID, req)}
+}
+
+func (_c *MockExecutionChainService_DryRunChain_Call) Run(run func(ctx context.Context, tenantID string, req *models.ExecuteChainRequest)) *MockExecutionChainService_DryRunChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ExecuteChainRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_DryRunChain_Call) Return(_a0 *models.ChainDryRunResponse, _a1 error) *MockExecutionChainService_DryRunChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_DryRunChain_Call) RunAndReturn(run func(context.Context, string, *models.ExecuteChainRequest) (*models.ChainDryRunResponse, error)) *MockExecutionChainService_DryRunChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteChain provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockExecutionChainService) ExecuteChain(ctx context.Context, tenantID string, req *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteChain")
@@ -140,19 +451,19 @@ func (_m *MockExecutionChainService) ExecuteChain(ctx context.Context, req *mode
 
 	var r0 *models.ExecuteChainResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error)); ok {
-		return rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error)); ok {
+		return rf(ctx, tenantID, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.ExecuteChainRequest) *models.ExecuteChainResponse); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ExecuteChainRequest) *models.ExecuteChainResponse); ok {
+		r0 = rf(ctx, tenantID, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.ExecuteChainResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.ExecuteChainRequest) error); ok {
-		r1 = rf(ctx, req)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ExecuteChainRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,14 +478,15 @@ type MockExecutionChainService_ExecuteChain_Call struct {
 
 // ExecuteChain is a helper method to define mock.On call
 //   - ctx context.Context
+//   - tenantID string
 //   - req *models.ExecuteChainRequest
-func (_e *MockExecutionChainService_Expecter) ExecuteChain(ctx interface{}, req interface{}) *MockExecutionChainService_ExecuteChain_Call {
-	return &MockExecutionChainService_ExecuteChain_Call{Call: _e.mock.On("ExecuteChain", ctx, req)}
+func (_e *MockExecutionChainService_Expecter) ExecuteChain(ctx interface{}, tenantID interface{}, req interface{}) *MockExecutionChainService_ExecuteChain_Call {
+	return &MockExecutionChainService_ExecuteChain_Call{Call: _e.mock.On("ExecuteChain", ctx, tenantID, req)}
 }
 
-func (_c *MockExecutionChainService_ExecuteChain_Call) Run(run func(ctx context.Context, req *models.ExecuteChainRequest)) *MockExecutionChainService_ExecuteChain_Call {
+func (_c *MockExecutionChainService_ExecuteChain_Call) Run(run func(ctx context.Context, tenantID string, req *models.ExecuteChainRequest)) *MockExecutionChainService_ExecuteChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.ExecuteChainRequest))
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ExecuteChainRequest))
 	})
 	return _c
 }
@@ -184,7 +496,7 @@ func (_c *MockExecutionChainService_ExecuteChain_Call) Return(_a0 *models.Execut
 	return _c
 }
 
-func (_c *MockExecutionChainService_ExecuteChain_Call) RunAndReturn(run func(context.Context, *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error)) *MockExecutionChainService_ExecuteChain_Call {
+func (_c *MockExecutionChainService_ExecuteChain_Call) RunAndReturn(run func(context.Context, string, *models.ExecuteChainRequest) (*models.ExecuteChainResponse, error)) *MockExecutionChainService_ExecuteChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -238,29 +550,29 @@ func (_c *MockExecutionChainService_ExecuteChainByEvent_Call) RunAndReturn(run f
 	return _c
 }
 
-// GetChain provides a mock function with given fields: ctx, chainID
-func (_m *MockExecutionChainService) GetChain(ctx context.Context, chainID uuid.UUID) (*models.ExecutionChain, error) {
-	ret := _m.Called(ctx, chainID)
+// ExportChain provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) ExportChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainTemplate, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChain")
+		panic("no return value specified for ExportChain")
 	}
 
-	var r0 *models.ExecutionChain
+	var r0 *models.ChainTemplate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecutionChain, error)); ok {
-		return rf(ctx, chainID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ChainTemplate, error)); ok {
+		return rf(ctx, tenantID, chainID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecutionChain); ok {
-		r0 = rf(ctx, chainID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ChainTemplate); ok {
+		r0 = rf(ctx, tenantID, chainID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ExecutionChain)
+			r0 = ret.Get(0).(*models.ChainTemplate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, chainID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -268,58 +580,59 @@ func (_m *MockExecutionChainService) GetChain(ctx context.Context, chainID uuid.
 	return r0, r1
 }
 
-// MockExecutionChainService_GetChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChain'
-type MockExecutionChainService_GetChain_Call struct {
+// MockExecutionChainService_ExportChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportChain'
+type MockExecutionChainService_ExportChain_Call struct {
 	*mock.Call
 }
 
-// GetChain is a helper method to define mock.On call
+// ExportChain is a helper method to define mock.On call
 //   - ctx context.Context
+//   - tenantID string
 //   - chainID uuid.UUID
-func (_e *MockExecutionChainService_Expecter) GetChain(ctx interface{}, chainID interface{}) *MockExecutionChainService_GetChain_Call {
-	return &MockExecutionChainService_GetChain_Call{Call: _e.mock.On("GetChain", ctx, chainID)}
+func (_e *MockExecutionChainService_Expecter) ExportChain(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_ExportChain_Call {
+	return &MockExecutionChainService_ExportChain_Call{Call: _e.mock.On("ExportChain", ctx, tenantID, chainID)}
 }
 
-func (_c *MockExecutionChainService_GetChain_Call) Run(run func(ctx context.Context, chainID uuid.UUID)) *MockExecutionChainService_GetChain_Call {
+func (_c *MockExecutionChainService_ExportChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_ExportChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainService_GetChain_Call) Return(_a0 *models.ExecutionChain, _a1 error) *MockExecutionChainService_GetChain_Call {
+func (_c *MockExecutionChainService_ExportChain_Call) Return(_a0 *models.ChainTemplate, _a1 error) *MockExecutionChainService_ExportChain_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainService_GetChain_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecutionChain, error)) *MockExecutionChainService_GetChain_Call {
+func (_c *MockExecutionChainService_ExportChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ChainTemplate, error)) *MockExecutionChainService_ExportChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChainRun provides a mock function with given fields: ctx, runID
-func (_m *MockExecutionChainService) GetChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecutionChainRun, error) {
-	ret := _m.Called(ctx, runID)
+// GetChain provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) GetChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ExecutionChain, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChainRun")
+		panic("no return value specified for GetChain")
 	}
 
-	var r0 *models.ExecutionChainRun
+	var r0 *models.ExecutionChain
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error)); ok {
-		return rf(ctx, runID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ExecutionChain, error)); ok {
+		return rf(ctx, tenantID, chainID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecutionChainRun); ok {
-		r0 = rf(ctx, runID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ExecutionChain); ok {
+		r0 = rf(ctx, tenantID, chainID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ExecutionChainRun)
+			r0 = ret.Get(0).(*models.ExecutionChain)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, runID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -327,58 +640,59 @@ func (_m *MockExecutionChainService) GetChainRun(ctx context.Context, runID uuid
 	return r0, r1
 }
 
-// MockExecutionChainService_GetChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRun'
-type MockExecutionChainService_GetChainRun_Call struct {
+// MockExecutionChainService_GetChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChain'
+type MockExecutionChainService_GetChain_Call struct {
 	*mock.Call
 }
 
-// GetChainRun is a helper method to define mock.On call
+// GetChain is a helper method to define mock.On call
 //   - ctx context.Context
-//   - runID uuid.UUID
-func (_e *MockExecutionChainService_Expecter) GetChainRun(ctx interface{}, runID interface{}) *MockExecutionChainService_GetChainRun_Call {
-	return &MockExecutionChainService_GetChainRun_Call{Call: _e.mock.On("GetChainRun", ctx, runID)}
+//   - tenantID string
+//   - chainID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) GetChain(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_GetChain_Call {
+	return &MockExecutionChainService_GetChain_Call{Call: _e.mock.On("GetChain", ctx, tenantID, chainID)}
 }
 
-func (_c *MockExecutionChainService_GetChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainService_GetChainRun_Call {
+func (_c *MockExecutionChainService_GetChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_GetChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainService_GetChainRun_Call) Return(_a0 *models.ExecutionChainRun, _a1 error) *MockExecutionChainService_GetChainRun_Call {
+func (_c *MockExecutionChainService_GetChain_Call) Return(_a0 *models.ExecutionChain, _a1 error) *MockExecutionChainService_GetChain_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainService_GetChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecutionChainRun, error)) *MockExecutionChainService_GetChainRun_Call {
+func (_c *MockExecutionChainService_GetChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ExecutionChain, error)) *MockExecutionChainService_GetChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListChainRuns provides a mock function with given fields: ctx, chainID, page, limit
-func (_m *MockExecutionChainService) ListChainRuns(ctx context.Context, chainID uuid.UUID, page int, limit int) (*models.ExecutionChainRunsResponse, error) {
-	ret := _m.Called(ctx, chainID, page, limit)
+// GetChainHistory provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) GetChainHistory(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ConfigHistoryResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListChainRuns")
+		panic("no return value specified for GetChainHistory")
 	}
 
-	var r0 *models.ExecutionChainRunsResponse
+	var r0 *models.ConfigHistoryResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) (*models.ExecutionChainRunsResponse, error)); ok {
-		return rf(ctx, chainID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ConfigHistoryResponse, error)); ok {
+		return rf(ctx, tenantID, chainID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) *models.ExecutionChainRunsResponse); ok {
-		r0 = rf(ctx, chainID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ConfigHistoryResponse); ok {
+		r0 = rf(ctx, tenantID, chainID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ExecutionChainRunsResponse)
+			r0 = ret.Get(0).(*models.ConfigHistoryResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
-		r1 = rf(ctx, chainID, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -386,60 +700,59 @@ func (_m *MockExecutionChainService) ListChainRuns(ctx context.Context, chainID
 	return r0, r1
 }
 
-// MockExecutionChainService_ListChainRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChainRuns'
-type MockExecutionChainService_ListChainRuns_Call struct {
+// MockExecutionChainService_GetChainHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainHistory'
+type MockExecutionChainService_GetChainHistory_Call struct {
 	*mock.Call
 }
 
-// ListChainRuns is a helper method to define mock.On call
+// GetChainHistory is a helper method to define mock.On call
 //   - ctx context.Context
+//   - tenantID string
 //   - chainID uuid.UUID
-//   - page int
-//   - limit int
-func (_e *MockExecutionChainService_Expecter) ListChainRuns(ctx interface{}, chainID interface{}, page interface{}, limit interface{}) *MockExecutionChainService_ListChainRuns_Call {
-	return &MockExecutionChainService_ListChainRuns_Call{Call: _e.mock.On("ListChainRuns", ctx, chainID, page, limit)}
+func (_e *MockExecutionChainService_Expecter) GetChainHistory(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_GetChainHistory_Call {
+	return &MockExecutionChainService_GetChainHistory_Call{Call: _e.mock.On("GetChainHistory", ctx, tenantID, chainID)}
 }
 
-func (_c *MockExecutionChainService_ListChainRuns_Call) Run(run func(ctx context.Context, chainID uuid.UUID, page int, limit int)) *MockExecutionChainService_ListChainRuns_Call {
+func (_c *MockExecutionChainService_GetChainHistory_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_GetChainHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainService_ListChainRuns_Call) Return(_a0 *models.ExecutionChainRunsResponse, _a1 error) *MockExecutionChainService_ListChainRuns_Call {
+func (_c *MockExecutionChainService_GetChainHistory_Call) Return(_a0 *models.ConfigHistoryResponse, _a1 error) *MockExecutionChainService_GetChainHistory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainService_ListChainRuns_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, int) (*models.ExecutionChainRunsResponse, error)) *MockExecutionChainService_ListChainRuns_Call {
+func (_c *MockExecutionChainService_GetChainHistory_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ConfigHistoryResponse, error)) *MockExecutionChainService_GetChainHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListChains provides a mock function with given fields: ctx, tenantID, page, limit
-func (_m *MockExecutionChainService) ListChains(ctx context.Context, tenantID string, page int, limit int) (*models.ExecutionChainListResponse, error) {
-	ret := _m.Called(ctx, tenantID, page, limit)
+// GetChainRun provides a mock function with given fields: ctx, tenantID, runID
+func (_m *MockExecutionChainService) GetChainRun(ctx context.Context, tenantID string, runID uuid.UUID) (*models.ExecutionChainRun, error) {
+	ret := _m.Called(ctx, tenantID, runID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListChains")
+		panic("no return value specified for GetChainRun")
 	}
 
-	var r0 *models.ExecutionChainListResponse
+	var r0 *models.ExecutionChainRun
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*models.ExecutionChainListResponse, error)); ok {
-		return rf(ctx, tenantID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ExecutionChainRun, error)); ok {
+		return rf(ctx, tenantID, runID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *models.ExecutionChainListResponse); ok {
-		r0 = rf(ctx, tenantID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ExecutionChainRun); ok {
+		r0 = rf(ctx, tenantID, runID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ExecutionChainListResponse)
+			r0 = ret.Get(0).(*models.ExecutionChainRun)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
-		r1 = rf(ctx, tenantID, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, runID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -447,49 +760,1909 @@ func (_m *MockExecutionChainService) ListChains(ctx context.Context, tenantID st
 	return r0, r1
 }
 
-// MockExecutionChainService_ListChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChains'
-type MockExecutionChainService_ListChains_Call struct {
+// MockExecutionChainService_GetChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRun'
+type MockExecutionChainService_GetChainRun_Call struct {
 	*mock.Call
 }
 
-// ListChains is a helper method to define mock.On call
+// GetChainRun is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tenantID string
-//   - page int
-//   - limit int
-func (_e *MockExecutionChainService_Expecter) ListChains(ctx interface{}, tenantID interface{}, page interface{}, limit interface{}) *MockExecutionChainService_ListChains_Call {
-	return &MockExecutionChainService_ListChains_Call{Call: _e.mock.On("ListChains", ctx, tenantID, page, limit)}
+//   - runID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) GetChainRun(ctx interface{}, tenantID interface{}, runID interface{}) *MockExecutionChainService_GetChainRun_Call {
+	return &MockExecutionChainService_GetChainRun_Call{Call: _e.mock.On("GetChainRun", ctx, tenantID, runID)}
 }
 
-func (_c *MockExecutionChainService_ListChains_Call) Run(run func(ctx context.Context, tenantID string, page int, limit int)) *MockExecutionChainService_ListChains_Call {
+func (_c *MockExecutionChainService_GetChainRun_Call) Run(run func(ctx context.Context, tenantID string, runID uuid.UUID)) *MockExecutionChainService_GetChainRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockExecutionChainService_ListChains_Call) Return(_a0 *models.ExecutionChainListResponse, _a1 error) *MockExecutionChainService_ListChains_Call {
+func (_c *MockExecutionChainService_GetChainRun_Call) Return(_a0 *models.ExecutionChainRun, _a1 error) *MockExecutionChainService_GetChainRun_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExecutionChainService_ListChains_Call) RunAndReturn(run func(context.Context, string, int, int) (*models.ExecutionChainListResponse, error)) *MockExecutionChainService_ListChains_Call {
+func (_c *MockExecutionChainService_GetChainRun_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ExecutionChainRun, error)) *MockExecutionChainService_GetChainRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateChain provides a mock function with given fields: ctx, chainID, req
-func (_m *MockExecutionChainService) UpdateChain(ctx context.Context, chainID uuid.UUID, req *models.UpdateExecutionChainRequest) error {
-	ret := _m.Called(ctx, chainID, req)
+// GetChainRunOutputs provides a mock function with given fields: ctx, tenantID, runID
+func (_m *MockExecutionChainService) GetChainRunOutputs(ctx context.Context, tenantID string, runID uuid.UUID) (*models.ChainRunOutputsResponse, error) {
+	ret := _m.Called(ctx, tenantID, runID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateChain")
+		panic("no return value specified for GetChainRunOutputs")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.UpdateExecutionChainRequest) error); ok {
-		r0 = rf(ctx, chainID, req)
-	} else {
+	var r0 *models.ChainRunOutputsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ChainRunOutputsResponse, error)); ok {
+		return rf(ctx, tenantID, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ChainRunOutputsResponse); ok {
+		r0 = rf(ctx, tenantID, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainRunOutputsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetChainRunOutputs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainRunOutputs'
+type MockExecutionChainService_GetChainRunOutputs_Call struct {
+	*mock.Call
+}
+
+// GetChainRunOutputs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - runID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) GetChainRunOutputs(ctx interface{}, tenantID interface{}, runID interface{}) *MockExecutionChainService_GetChainRunOutputs_Call {
+	return &MockExecutionChainService_GetChainRunOutputs_Call{Call: _e.mock.On("GetChainRunOutputs", ctx, tenantID, runID)}
+}
+
+func (_c *MockExecutionChainService_GetChainRunOutputs_Call) Run(run func(ctx context.Context, tenantID string, runID uuid.UUID)) *MockExecutionChainService_GetChainRunOutputs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainRunOutputs_Call) Return(_a0 *models.ChainRunOutputsResponse, _a1 error) *MockExecutionChainService_GetChainRunOutputs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainRunOutputs_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ChainRunOutputsResponse, error)) *MockExecutionChainService_GetChainRunOutputs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainSchedule provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) GetChainSchedule(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainScheduleResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainSchedule")
+	}
+
+	var r0 *models.ChainScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ChainScheduleResponse, error)); ok {
+		return rf(ctx, tenantID, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ChainScheduleResponse); ok {
+		r0 = rf(ctx, tenantID, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainScheduleResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetChainSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainSchedule'
+type MockExecutionChainService_GetChainSchedule_Call struct {
+	*mock.Call
+}
+
+// GetChainSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) GetChainSchedule(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_GetChainSchedule_Call {
+	return &MockExecutionChainService_GetChainSchedule_Call{Call: _e.mock.On("GetChainSchedule", ctx, tenantID, chainID)}
+}
+
+func (_c *MockExecutionChainService_GetChainSchedule_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_GetChainSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainSchedule_Call) Return(_a0 *models.ChainScheduleResponse, _a1 error) *MockExecutionChainService_GetChainSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainSchedule_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ChainScheduleResponse, error)) *MockExecutionChainService_GetChainSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainStats provides a mock function with given fields: ctx, tenantID, chainID, window
+func (_m *MockExecutionChainService) GetChainStats(ctx context.Context, tenantID string, chainID uuid.UUID, window models.StatsWindow) (*models.ChainStatsResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainStats")
+	}
+
+	var r0 *models.ChainStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.StatsWindow) (*models.ChainStatsResponse, error)); ok {
+		return rf(ctx, tenantID, chainID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.StatsWindow) *models.ChainStatsResponse); ok {
+		r0 = rf(ctx, tenantID, chainID, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, models.StatsWindow) error); ok {
+		r1 = rf(ctx, tenantID, chainID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetChainStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainStats'
+type MockExecutionChainService_GetChainStats_Call struct {
+	*mock.Call
+}
+
+// GetChainStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+//   - window models.StatsWindow
+func (_e *MockExecutionChainService_Expecter) GetChainStats(ctx interface{}, tenantID interface{}, chainID interface{}, window interface{}) *MockExecutionChainService_GetChainStats_Call {
+	return &MockExecutionChainService_GetChainStats_Call{Call: _e.mock.On("GetChainStats", ctx, tenantID, chainID, window)}
+}
+
+func (_c *MockExecutionChainService_GetChainStats_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, window models.StatsWindow)) *MockExecutionChainService_GetChainStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(models.StatsWindow))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainStats_Call) Return(_a0 *models.ChainStatsResponse, _a1 error) *MockExecutionChainService_GetChainStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainStats_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, models.StatsWindow) (*models.ChainStatsResponse, error)) *MockExecutionChainService_GetChainStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainTemplate provides a mock function with given fields: templateID
+func (_m *MockExecutionChainService) GetChainTemplate(templateID string) (*models.ChainTemplate, error) {
+	ret := _m.Called(templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainTemplate")
+	}
+
+	var r0 *models.ChainTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.ChainTemplate, error)); ok {
+		return rf(templateID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.ChainTemplate); ok {
+		r0 = rf(templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetChainTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainTemplate'
+type MockExecutionChainService_GetChainTemplate_Call struct {
+	*mock.Call
+}
+
+// GetChainTemplate is a helper method to define mock.On call
+//   - templateID string
+func (_e *MockExecutionChainService_Expecter) GetChainTemplate(templateID interface{}) *MockExecutionChainService_GetChainTemplate_Call {
+	return &MockExecutionChainService_GetChainTemplate_Call{Call: _e.mock.On("GetChainTemplate", templateID)}
+}
+
+func (_c *MockExecutionChainService_GetChainTemplate_Call) Run(run func(templateID string)) *MockExecutionChainService_GetChainTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainTemplate_Call) Return(_a0 *models.ChainTemplate, _a1 error) *MockExecutionChainService_GetChainTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainTemplate_Call) RunAndReturn(run func(string) (*models.ChainTemplate, error)) *MockExecutionChainService_GetChainTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainUsage provides a mock function with given fields: ctx, tenantID, window
+func (_m *MockExecutionChainService) GetChainUsage(ctx context.Context, tenantID string, window models.StatsWindow) (*models.ChainUsageResponse, error) {
+	ret := _m.Called(ctx, tenantID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainUsage")
+	}
+
+	var r0 *models.ChainUsageResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.StatsWindow) (*models.ChainUsageResponse, error)); ok {
+		return rf(ctx, tenantID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.StatsWindow) *models.ChainUsageResponse); ok {
+		r0 = rf(ctx, tenantID, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainUsageResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.StatsWindow) error); ok {
+		r1 = rf(ctx, tenantID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetChainUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainUsage'
+type MockExecutionChainService_GetChainUsage_Call struct {
+	*mock.Call
+}
+
+// GetChainUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - window models.StatsWindow
+func (_e *MockExecutionChainService_Expecter) GetChainUsage(ctx interface{}, tenantID interface{}, window interface{}) *MockExecutionChainService_GetChainUsage_Call {
+	return &MockExecutionChainService_GetChainUsage_Call{Call: _e.mock.On("GetChainUsage", ctx, tenantID, window)}
+}
+
+func (_c *MockExecutionChainService_GetChainUsage_Call) Run(run func(ctx context.Context, tenantID string, window models.StatsWindow)) *MockExecutionChainService_GetChainUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.StatsWindow))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainUsage_Call) Return(_a0 *models.ChainUsageResponse, _a1 error) *MockExecutionChainService_GetChainUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainUsage_Call) RunAndReturn(run func(context.Context, string, models.StatsWindow) (*models.ChainUsageResponse, error)) *MockExecutionChainService_GetChainUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainVersions provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) GetChainVersions(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ChainVersionsResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainVersions")
+	}
+
+	var r0 *models.ChainVersionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ChainVersionsResponse, error)); ok {
+		return rf(ctx, tenantID, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ChainVersionsResponse); ok {
+		r0 = rf(ctx, tenantID, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainVersionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetChainVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainVersions'
+type MockExecutionChainService_GetChainVersions_Call struct {
+	*mock.Call
+}
+
+// GetChainVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) GetChainVersions(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_GetChainVersions_Call {
+	return &MockExecutionChainService_GetChainVersions_Call{Call: _e.mock.On("GetChainVersions", ctx, tenantID, chainID)}
+}
+
+func (_c *MockExecutionChainService_GetChainVersions_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_GetChainVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainVersions_Call) Return(_a0 *models.ChainVersionsResponse, _a1 error) *MockExecutionChainService_GetChainVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetChainVersions_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ChainVersionsResponse, error)) *MockExecutionChainService_GetChainVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantRunLimit provides a mock function with given fields: ctx, tenantID
+func (_m *MockExecutionChainService) GetTenantRunLimit(ctx context.Context, tenantID string) (*models.TenantRunLimitResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantRunLimit")
+	}
+
+	var r0 *models.TenantRunLimitResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TenantRunLimitResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TenantRunLimitResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantRunLimitResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_GetTenantRunLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantRunLimit'
+type MockExecutionChainService_GetTenantRunLimit_Call struct {
+	*mock.Call
+}
+
+// GetTenantRunLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockExecutionChainService_Expecter) GetTenantRunLimit(ctx interface{}, tenantID interface{}) *MockExecutionChainService_GetTenantRunLimit_Call {
+	return &MockExecutionChainService_GetTenantRunLimit_Call{Call: _e.mock.On("GetTenantRunLimit", ctx, tenantID)}
+}
+
+func (_c *MockExecutionChainService_GetTenantRunLimit_Call) Run(run func(ctx context.Context, tenantID string)) *MockExecutionChainService_GetTenantRunLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetTenantRunLimit_Call) Return(_a0 *models.TenantRunLimitResponse, _a1 error) *MockExecutionChainService_GetTenantRunLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_GetTenantRunLimit_Call) RunAndReturn(run func(context.Context, string) (*models.TenantRunLimitResponse, error)) *MockExecutionChainService_GetTenantRunLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportChain provides a mock function with given fields: ctx, template, req
+func (_m *MockExecutionChainService) ImportChain(ctx context.Context, template *models.ChainTemplate, req *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error) {
+	ret := _m.Called(ctx, template, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportChain")
+	}
+
+	var r0 *models.CreateExecutionChainResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ChainTemplate, *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error)); ok {
+		return rf(ctx, template, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ChainTemplate, *models.InstantiateChainTemplateRequest) *models.CreateExecutionChainResponse); ok {
+		r0 = rf(ctx, template, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CreateExecutionChainResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ChainTemplate, *models.InstantiateChainTemplateRequest) error); ok {
+		r1 = rf(ctx, template, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ImportChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportChain'
+type MockExecutionChainService_ImportChain_Call struct {
+	*mock.Call
+}
+
+// ImportChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - template *models.ChainTemplate
+//   - req *models.InstantiateChainTemplateRequest
+func (_e *MockExecutionChainService_Expecter) ImportChain(ctx interface{}, template interface{}, req interface{}) *MockExecutionChainService_ImportChain_Call {
+	return &MockExecutionChainService_ImportChain_Call{Call: _e.mock.On("ImportChain", ctx, template, req)}
+}
+
+func (_c *MockExecutionChainService_ImportChain_Call) Run(run func(ctx context.Context, template *models.ChainTemplate, req *models.InstantiateChainTemplateRequest)) *MockExecutionChainService_ImportChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ChainTemplate), args[2].(*models.InstantiateChainTemplateRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ImportChain_Call) Return(_a0 *models.CreateExecutionChainResponse, _a1 error) *MockExecutionChainService_ImportChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ImportChain_Call) RunAndReturn(run func(context.Context, *models.ChainTemplate, *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error)) *MockExecutionChainService_ImportChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstantiateChainTemplate provides a mock function with given fields: ctx, templateID, req
+func (_m *MockExecutionChainService) InstantiateChainTemplate(ctx context.Context, templateID string, req *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error) {
+	ret := _m.Called(ctx, templateID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstantiateChainTemplate")
+	}
+
+	var r0 *models.CreateExecutionChainResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error)); ok {
+		return rf(ctx, templateID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.InstantiateChainTemplateRequest) *models.CreateExecutionChainResponse); ok {
+		r0 = rf(ctx, templateID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CreateExecutionChainResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.InstantiateChainTemplateRequest) error); ok {
+		r1 = rf(ctx, templateID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_InstantiateChainTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstantiateChainTemplate'
+type MockExecutionChainService_InstantiateChainTemplate_Call struct {
+	*mock.Call
+}
+
+// InstantiateChainTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID string
+//   - req *models.InstantiateChainTemplateRequest
+func (_e *MockExecutionChainService_Expecter) InstantiateChainTemplate(ctx interface{}, templateID interface{}, req interface{}) *MockExecutionChainService_InstantiateChainTemplate_Call {
+	return &MockExecutionChainService_InstantiateChainTemplate_Call{Call: _e.mock.On("InstantiateChainTemplate", ctx, templateID, req)}
+}
+
+func (_c *MockExecutionChainService_InstantiateChainTemplate_Call) Run(run func(ctx context.Context, templateID string, req *models.InstantiateChainTemplateRequest)) *MockExecutionChainService_InstantiateChainTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.InstantiateChainTemplateRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_InstantiateChainTemplate_Call) Return(_a0 *models.CreateExecutionChainResponse, _a1 error) *MockExecutionChainService_InstantiateChainTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_InstantiateChainTemplate_Call) RunAndReturn(run func(context.Context, string, *models.InstantiateChainTemplateRequest) (*models.CreateExecutionChainResponse, error)) *MockExecutionChainService_InstantiateChainTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChainRuns provides a mock function with given fields: ctx, tenantID, chainID, filter, page
+func (_m *MockExecutionChainService) ListChainRuns(ctx context.Context, tenantID string, chainID uuid.UUID, filter models.ListFilter, page models.ListPage) (*models.ExecutionChainRunsResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChainRuns")
+	}
+
+	var r0 *models.ExecutionChainRunsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.ListFilter, models.ListPage) (*models.ExecutionChainRunsResponse, error)); ok {
+		return rf(ctx, tenantID, chainID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.ListFilter, models.ListPage) *models.ExecutionChainRunsResponse); ok {
+		r0 = rf(ctx, tenantID, chainID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecutionChainRunsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, models.ListFilter, models.ListPage) error); ok {
+		r1 = rf(ctx, tenantID, chainID, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ListChainRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChainRuns'
+type MockExecutionChainService_ListChainRuns_Call struct {
+	*mock.Call
+}
+
+// ListChainRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+//   - filter models.ListFilter
+//   - page models.ListPage
+func (_e *MockExecutionChainService_Expecter) ListChainRuns(ctx interface{}, tenantID interface{}, chainID interface{}, filter interface{}, page interface{}) *MockExecutionChainService_ListChainRuns_Call {
+	return &MockExecutionChainService_ListChainRuns_Call{Call: _e.mock.On("ListChainRuns", ctx, tenantID, chainID, filter, page)}
+}
+
+func (_c *MockExecutionChainService_ListChainRuns_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, filter models.ListFilter, page models.ListPage)) *MockExecutionChainService_ListChainRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(models.ListFilter), args[4].(models.ListPage))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ListChainRuns_Call) Return(_a0 *models.ExecutionChainRunsResponse, _a1 error) *MockExecutionChainService_ListChainRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ListChainRuns_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, models.ListFilter, models.ListPage) (*models.ExecutionChainRunsResponse, error)) *MockExecutionChainService_ListChainRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChainTemplates provides a mock function with no fields
+func (_m *MockExecutionChainService) ListChainTemplates() *models.ChainTemplateListResponse {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChainTemplates")
+	}
+
+	var r0 *models.ChainTemplateListResponse
+	if rf, ok := ret.Get(0).(func() *models.ChainTemplateListResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainTemplateListResponse)
+		}
+	}
+
+	return r0
+}
+
+// MockExecutionChainService_ListChainTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChainTemplates'
+type MockExecutionChainService_ListChainTemplates_Call struct {
+	*mock.Call
+}
+
+// ListChainTemplates is a helper method to define mock.On call
+func (_e *MockExecutionChainService_Expecter) ListChainTemplates() *MockExecutionChainService_ListChainTemplates_Call {
+	return &MockExecutionChainService_ListChainTemplates_Call{Call: _e.mock.On("ListChainTemplates")}
+}
+
+func (_c *MockExecutionChainService_ListChainTemplates_Call) Run(run func()) *MockExecutionChainService_ListChainTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ListChainTemplates_Call) Return(_a0 *models.ChainTemplateListResponse) *MockExecutionChainService_ListChainTemplates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ListChainTemplates_Call) RunAndReturn(run func() *models.ChainTemplateListResponse) *MockExecutionChainService_ListChainTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChains provides a mock function with given fields: ctx, tenantID, filter, page
+func (_m *MockExecutionChainService) ListChains(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage) (*models.ExecutionChainListResponse, error) {
+	ret := _m.Called(ctx, tenantID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChains")
+	}
+
+	var r0 *models.ExecutionChainListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, models.ListPage) (*models.ExecutionChainListResponse, error)); ok {
+		return rf(ctx, tenantID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, models.ListPage) *models.ExecutionChainListResponse); ok {
+		r0 = rf(ctx, tenantID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecutionChainListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ListFilter, models.ListPage) error); ok {
+		r1 = rf(ctx, tenantID, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ListChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChains'
+type MockExecutionChainService_ListChains_Call struct {
+	*mock.Call
+}
+
+// ListChains is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - filter models.ListFilter
+//   - page models.ListPage
+func (_e *MockExecutionChainService_Expecter) ListChains(ctx interface{}, tenantID interface{}, filter interface{}, page interface{}) *MockExecutionChainService_ListChains_Call {
+	return &MockExecutionChainService_ListChains_Call{Call: _e.mock.On("ListChains", ctx, tenantID, filter, page)}
+}
+
+func (_c *MockExecutionChainService_ListChains_Call) Run(run func(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage)) *MockExecutionChainService_ListChains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ListFilter), args[3].(models.ListPage))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ListChains_Call) Return(_a0 *models.ExecutionChainListResponse, _a1 error) *MockExecutionChainService_ListChains_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ListChains_Call) RunAndReturn(run func(context.Context, string, models.ListFilter, models.ListPage) (*models.ExecutionChainListResponse, error)) *MockExecutionChainService_ListChains_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseChain provides a mock function with given fields: ctx, tenantID, chainID, req
+func (_m *MockExecutionChainService) PauseChain(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.PauseChainRequest) (*models.ChainStateResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseChain")
+	}
+
+	var r0 *models.ChainStateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.PauseChainRequest) (*models.ChainStateResponse, error)); ok {
+		return rf(ctx, tenantID, chainID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.PauseChainRequest) *models.ChainStateResponse); ok {
+		r0 = rf(ctx, tenantID, chainID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainStateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, *models.PauseChainRequest) error); ok {
+		r1 = rf(ctx, tenantID, chainID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_PauseChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseChain'
+type MockExecutionChainService_PauseChain_Call struct {
+	*mock.Call
+}
+
+// PauseChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+//   - req *models.PauseChainRequest
+func (_e *MockExecutionChainService_Expecter) PauseChain(ctx interface{}, tenantID interface{}, chainID interface{}, req interface{}) *MockExecutionChainService_PauseChain_Call {
+	return &MockExecutionChainService_PauseChain_Call{Call: _e.mock.On("PauseChain", ctx, tenantID, chainID, req)}
+}
+
+func (_c *MockExecutionChainService_PauseChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.PauseChainRequest)) *MockExecutionChainService_PauseChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(*models.PauseChainRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_PauseChain_Call) Return(_a0 *models.ChainStateResponse, _a1 error) *MockExecutionChainService_PauseChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_PauseChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, *models.PauseChainRequest) (*models.ChainStateResponse, error)) *MockExecutionChainService_PauseChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseTenantChains provides a mock function with given fields: ctx, tenantID
+func (_m *MockExecutionChainService) PauseTenantChains(ctx context.Context, tenantID string) (*models.TenantChainControlResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseTenantChains")
+	}
+
+	var r0 *models.TenantChainControlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TenantChainControlResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TenantChainControlResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantChainControlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_PauseTenantChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseTenantChains'
+type MockExecutionChainService_PauseTenantChains_Call struct {
+	*mock.Call
+}
+
+// PauseTenantChains is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockExecutionChainService_Expecter) PauseTenantChains(ctx interface{}, tenantID interface{}) *MockExecutionChainService_PauseTenantChains_Call {
+	return &MockExecutionChainService_PauseTenantChains_Call{Call: _e.mock.On("PauseTenantChains", ctx, tenantID)}
+}
+
+func (_c *MockExecutionChainService_PauseTenantChains_Call) Run(run func(ctx context.Context, tenantID string)) *MockExecutionChainService_PauseTenantChains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_PauseTenantChains_Call) Return(_a0 *models.TenantChainControlResponse, _a1 error) *MockExecutionChainService_PauseTenantChains_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_PauseTenantChains_Call) RunAndReturn(run func(context.Context, string) (*models.TenantChainControlResponse, error)) *MockExecutionChainService_PauseTenantChains_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecoverChainRuns provides a mock function with given fields: ctx, lease
+func (_m *MockExecutionChainService) RecoverChainRuns(ctx context.Context, lease time.Duration) (int, error) {
+	ret := _m.Called(ctx, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecoverChainRuns")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int, error)); ok {
+		return rf(ctx, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int); ok {
+		r0 = rf(ctx, lease)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_RecoverChainRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecoverChainRuns'
+type MockExecutionChainService_RecoverChainRuns_Call struct {
+	*mock.Call
+}
+
+// RecoverChainRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lease time.Duration
+func (_e *MockExecutionChainService_Expecter) RecoverChainRuns(ctx interface{}, lease interface{}) *MockExecutionChainService_RecoverChainRuns_Call {
+	return &MockExecutionChainService_RecoverChainRuns_Call{Call: _e.mock.On("RecoverChainRuns", ctx, lease)}
+}
+
+func (_c *MockExecutionChainService_RecoverChainRuns_Call) Run(run func(ctx context.Context, lease time.Duration)) *MockExecutionChainService_RecoverChainRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RecoverChainRuns_Call) Return(_a0 int, _a1 error) *MockExecutionChainService_RecoverChainRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_RecoverChainRuns_Call) RunAndReturn(run func(context.Context, time.Duration) (int, error)) *MockExecutionChainService_RecoverChainRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectChainRun provides a mock function with given fields: ctx, runID, approval
+func (_m *MockExecutionChainService) RejectChainRun(ctx context.Context, runID uuid.UUID, approval models.StepApproval) (*models.ChainRunControlResponse, error) {
+	ret := _m.Called(ctx, runID, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectChainRun")
+	}
+
+	var r0 *models.ChainRunControlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.StepApproval) (*models.ChainRunControlResponse, error)); ok {
+		return rf(ctx, runID, approval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.StepApproval) *models.ChainRunControlResponse); ok {
+		r0 = rf(ctx, runID, approval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainRunControlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.StepApproval) error); ok {
+		r1 = rf(ctx, runID, approval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_RejectChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectChainRun'
+type MockExecutionChainService_RejectChainRun_Call struct {
+	*mock.Call
+}
+
+// RejectChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+//   - approval models.StepApproval
+func (_e *MockExecutionChainService_Expecter) RejectChainRun(ctx interface{}, runID interface{}, approval interface{}) *MockExecutionChainService_RejectChainRun_Call {
+	return &MockExecutionChainService_RejectChainRun_Call{Call: _e.mock.On("RejectChainRun", ctx, runID, approval)}
+}
+
+func (_c *MockExecutionChainService_RejectChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID, approval models.StepApproval)) *MockExecutionChainService_RejectChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.StepApproval))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RejectChainRun_Call) Return(_a0 *models.ChainRunControlResponse, _a1 error) *MockExecutionChainService_RejectChainRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_RejectChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.StepApproval) (*models.ChainRunControlResponse, error)) *MockExecutionChainService_RejectChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreChain provides a mock function with given fields: ctx, tenantID, chainID
+func (_m *MockExecutionChainService) RestoreChain(ctx context.Context, tenantID string, chainID uuid.UUID) (*models.ExecutionChain, error) {
+	ret := _m.Called(ctx, tenantID, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreChain")
+	}
+
+	var r0 *models.ExecutionChain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ExecutionChain, error)); ok {
+		return rf(ctx, tenantID, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ExecutionChain); ok {
+		r0 = rf(ctx, tenantID, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecutionChain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_RestoreChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreChain'
+type MockExecutionChainService_RestoreChain_Call struct {
+	*mock.Call
+}
+
+// RestoreChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) RestoreChain(ctx interface{}, tenantID interface{}, chainID interface{}) *MockExecutionChainService_RestoreChain_Call {
+	return &MockExecutionChainService_RestoreChain_Call{Call: _e.mock.On("RestoreChain", ctx, tenantID, chainID)}
+}
+
+func (_c *MockExecutionChainService_RestoreChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID)) *MockExecutionChainService_RestoreChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RestoreChain_Call) Return(_a0 *models.ExecutionChain, _a1 error) *MockExecutionChainService_RestoreChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_RestoreChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ExecutionChain, error)) *MockExecutionChainService_RestoreChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeChainRun provides a mock function with given fields: ctx, runID
+func (_m *MockExecutionChainService) ResumeChainRun(ctx context.Context, runID uuid.UUID) (*models.ChainRunControlResponse, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeChainRun")
+	}
+
+	var r0 *models.ChainRunControlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ChainRunControlResponse, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ChainRunControlResponse); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainRunControlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ResumeChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeChainRun'
+type MockExecutionChainService_ResumeChainRun_Call struct {
+	*mock.Call
+}
+
+// ResumeChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) ResumeChainRun(ctx interface{}, runID interface{}) *MockExecutionChainService_ResumeChainRun_Call {
+	return &MockExecutionChainService_ResumeChainRun_Call{Call: _e.mock.On("ResumeChainRun", ctx, runID)}
+}
+
+func (_c *MockExecutionChainService_ResumeChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainService_ResumeChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ResumeChainRun_Call) Return(_a0 *models.ChainRunControlResponse, _a1 error) *MockExecutionChainService_ResumeChainRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ResumeChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ChainRunControlResponse, error)) *MockExecutionChainService_ResumeChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeTenantChains provides a mock function with given fields: ctx, tenantID
+func (_m *MockExecutionChainService) ResumeTenantChains(ctx context.Context, tenantID string) (*models.TenantChainControlResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeTenantChains")
+	}
+
+	var r0 *models.TenantChainControlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TenantChainControlResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TenantChainControlResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantChainControlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_ResumeTenantChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeTenantChains'
+type MockExecutionChainService_ResumeTenantChains_Call struct {
+	*mock.Call
+}
+
+// ResumeTenantChains is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockExecutionChainService_Expecter) ResumeTenantChains(ctx interface{}, tenantID interface{}) *MockExecutionChainService_ResumeTenantChains_Call {
+	return &MockExecutionChainService_ResumeTenantChains_Call{Call: _e.mock.On("ResumeTenantChains", ctx, tenantID)}
+}
+
+func (_c *MockExecutionChainService_ResumeTenantChains_Call) Run(run func(ctx context.Context, tenantID string)) *MockExecutionChainService_ResumeTenantChains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_ResumeTenantChains_Call) Return(_a0 *models.TenantChainControlResponse, _a1 error) *MockExecutionChainService_ResumeTenantChains_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_ResumeTenantChains_Call) RunAndReturn(run func(context.Context, string) (*models.TenantChainControlResponse, error)) *MockExecutionChainService_ResumeTenantChains_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryChainRun provides a mock function with given fields: ctx, runID
+func (_m *MockExecutionChainService) RetryChainRun(ctx context.Context, runID uuid.UUID) (*models.ExecuteChainResponse, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryChainRun")
+	}
+
+	var r0 *models.ExecuteChainResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.ExecuteChainResponse, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ExecuteChainResponse); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExecuteChainResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_RetryChainRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryChainRun'
+type MockExecutionChainService_RetryChainRun_Call struct {
+	*mock.Call
+}
+
+// RetryChainRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID uuid.UUID
+func (_e *MockExecutionChainService_Expecter) RetryChainRun(ctx interface{}, runID interface{}) *MockExecutionChainService_RetryChainRun_Call {
+	return &MockExecutionChainService_RetryChainRun_Call{Call: _e.mock.On("RetryChainRun", ctx, runID)}
+}
+
+func (_c *MockExecutionChainService_RetryChainRun_Call) Run(run func(ctx context.Context, runID uuid.UUID)) *MockExecutionChainService_RetryChainRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RetryChainRun_Call) Return(_a0 *models.ExecuteChainResponse, _a1 error) *MockExecutionChainService_RetryChainRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_RetryChainRun_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.ExecuteChainResponse, error)) *MockExecutionChainService_RetryChainRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackChain provides a mock function with given fields: ctx, tenantID, chainID, version
+func (_m *MockExecutionChainService) RollbackChain(ctx context.Context, tenantID string, chainID uuid.UUID, version int) (*models.UpdateChainStepsResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackChain")
+	}
+
+	var r0 *models.UpdateChainStepsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) (*models.UpdateChainStepsResponse, error)); ok {
+		return rf(ctx, tenantID, chainID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) *models.UpdateChainStepsResponse); ok {
+		r0 = rf(ctx, tenantID, chainID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UpdateChainStepsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, tenantID, chainID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_RollbackChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackChain'
+type MockExecutionChainService_RollbackChain_Call struct {
+	*mock.Call
+}
+
+// RollbackChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+//   - version int
+func (_e *MockExecutionChainService_Expecter) RollbackChain(ctx interface{}, tenantID interface{}, chainID interface{}, version interface{}) *MockExecutionChainService_RollbackChain_Call {
+	return &MockExecutionChainService_RollbackChain_Call{Call: _e.mock.On("RollbackChain", ctx, tenantID, chainID, version)}
+}
+
+func (_c *MockExecutionChainService_RollbackChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, version int)) *MockExecutionChainService_RollbackChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RollbackChain_Call) Return(_a0 *models.UpdateChainStepsResponse, _a1 error) *MockExecutionChainService_RollbackChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_RollbackChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, int) (*models.UpdateChainStepsResponse, error)) *MockExecutionChainService_RollbackChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunAdmissionQueue provides a mock function with given fields: ctx, interval
+func (_m *MockExecutionChainService) RunAdmissionQueue(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockExecutionChainService_RunAdmissionQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunAdmissionQueue'
+type MockExecutionChainService_RunAdmissionQueue_Call struct {
+	*mock.Call
+}
+
+// RunAdmissionQueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockExecutionChainService_Expecter) RunAdmissionQueue(ctx interface{}, interval interface{}) *MockExecutionChainService_RunAdmissionQueue_Call {
+	return &MockExecutionChainService_RunAdmissionQueue_Call{Call: _e.mock.On("RunAdmissionQueue", ctx, interval)}
+}
+
+func (_c *MockExecutionChainService_RunAdmissionQueue_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockExecutionChainService_RunAdmissionQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RunAdmissionQueue_Call) Return() *MockExecutionChainService_RunAdmissionQueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_RunAdmissionQueue_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockExecutionChainService_RunAdmissionQueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunRecovery provides a mock function with given fields: ctx, interval
+func (_m *MockExecutionChainService) RunRecovery(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockExecutionChainService_RunRecovery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunRecovery'
+type MockExecutionChainService_RunRecovery_Call struct {
+	*mock.Call
+}
+
+// RunRecovery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockExecutionChainService_Expecter) RunRecovery(ctx interface{}, interval interface{}) *MockExecutionChainService_RunRecovery_Call {
+	return &MockExecutionChainService_RunRecovery_Call{Call: _e.mock.On("RunRecovery", ctx, interval)}
+}
+
+func (_c *MockExecutionChainService_RunRecovery_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockExecutionChainService_RunRecovery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RunRecovery_Call) Return() *MockExecutionChainService_RunRecovery_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_RunRecovery_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockExecutionChainService_RunRecovery_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunScheduler provides a mock function with given fields: ctx, interval
+func (_m *MockExecutionChainService) RunScheduler(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockExecutionChainService_RunScheduler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunScheduler'
+type MockExecutionChainService_RunScheduler_Call struct {
+	*mock.Call
+}
+
+// RunScheduler is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockExecutionChainService_Expecter) RunScheduler(ctx interface{}, interval interface{}) *MockExecutionChainService_RunScheduler_Call {
+	return &MockExecutionChainService_RunScheduler_Call{Call: _e.mock.On("RunScheduler", ctx, interval)}
+}
+
+func (_c *MockExecutionChainService_RunScheduler_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockExecutionChainService_RunScheduler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_RunScheduler_Call) Return() *MockExecutionChainService_RunScheduler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_RunScheduler_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockExecutionChainService_RunScheduler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetChainSchedule provides a mock function with given fields: ctx, tenantID, chainID, req
+func (_m *MockExecutionChainService) SetChainSchedule(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.ChainScheduleRequest) (*models.ChainScheduleResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChainSchedule")
+	}
+
+	var r0 *models.ChainScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.ChainScheduleRequest) (*models.ChainScheduleResponse, error)); ok {
+		return rf(ctx, tenantID, chainID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.ChainScheduleRequest) *models.ChainScheduleResponse); ok {
+		r0 = rf(ctx, tenantID, chainID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChainScheduleResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, *models.ChainScheduleRequest) error); ok {
+		r1 = rf(ctx, tenantID, chainID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_SetChainSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetChainSchedule'
+type MockExecutionChainService_SetChainSchedule_Call struct {
+	*mock.Call
+}
+
+// SetChainSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+//   - req *models.ChainScheduleRequest
+func (_e *MockExecutionChainService_Expecter) SetChainSchedule(ctx interface{}, tenantID interface{}, chainID interface{}, req interface{}) *MockExecutionChainService_SetChainSchedule_Call {
+	return &MockExecutionChainService_SetChainSchedule_Call{Call: _e.mock.On("SetChainSchedule", ctx, tenantID, chainID, req)}
+}
+
+func (_c *MockExecutionChainService_SetChainSchedule_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.ChainScheduleRequest)) *MockExecutionChainService_SetChainSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(*models.ChainScheduleRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetChainSchedule_Call) Return(_a0 *models.ChainScheduleResponse, _a1 error) *MockExecutionChainService_SetChainSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetChainSchedule_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, *models.ChainScheduleRequest) (*models.ChainScheduleResponse, error)) *MockExecutionChainService_SetChainSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetClock provides a mock function with given fields: clock
+func (_m *MockExecutionChainService) SetClock(clock service.Clock) {
+	_m.Called(clock)
+}
+
+// MockExecutionChainService_SetClock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetClock'
+type MockExecutionChainService_SetClock_Call struct {
+	*mock.Call
+}
+
+// SetClock is a helper method to define mock.On call
+//   - clock service.Clock
+func (_e *MockExecutionChainService_Expecter) SetClock(clock interface{}) *MockExecutionChainService_SetClock_Call {
+	return &MockExecutionChainService_SetClock_Call{Call: _e.mock.On("SetClock", clock)}
+}
+
+func (_c *MockExecutionChainService_SetClock_Call) Run(run func(clock service.Clock)) *MockExecutionChainService_SetClock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.Clock))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetClock_Call) Return() *MockExecutionChainService_SetClock_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetClock_Call) RunAndReturn(run func(service.Clock)) *MockExecutionChainService_SetClock_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetEventPublisher provides a mock function with given fields: publisher
+func (_m *MockExecutionChainService) SetEventPublisher(publisher service.EventPublisher) {
+	_m.Called(publisher)
+}
+
+// MockExecutionChainService_SetEventPublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventPublisher'
+type MockExecutionChainService_SetEventPublisher_Call struct {
+	*mock.Call
+}
+
+// SetEventPublisher is a helper method to define mock.On call
+//   - publisher service.EventPublisher
+func (_e *MockExecutionChainService_Expecter) SetEventPublisher(publisher interface{}) *MockExecutionChainService_SetEventPublisher_Call {
+	return &MockExecutionChainService_SetEventPublisher_Call{Call: _e.mock.On("SetEventPublisher", publisher)}
+}
+
+func (_c *MockExecutionChainService_SetEventPublisher_Call) Run(run func(publisher service.EventPublisher)) *MockExecutionChainService_SetEventPublisher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.EventPublisher))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetEventPublisher_Call) Return() *MockExecutionChainService_SetEventPublisher_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetEventPublisher_Call) RunAndReturn(run func(service.EventPublisher)) *MockExecutionChainService_SetEventPublisher_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetLocks provides a mock function with given fields: locks
+func (_m *MockExecutionChainService) SetLocks(locks repository.LockRepository) {
+	_m.Called(locks)
+}
+
+// MockExecutionChainService_SetLocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLocks'
+type MockExecutionChainService_SetLocks_Call struct {
+	*mock.Call
+}
+
+// SetLocks is a helper method to define mock.On call
+//   - locks repository.LockRepository
+func (_e *MockExecutionChainService_Expecter) SetLocks(locks interface{}) *MockExecutionChainService_SetLocks_Call {
+	return &MockExecutionChainService_SetLocks_Call{Call: _e.mock.On("SetLocks", locks)}
+}
+
+func (_c *MockExecutionChainService_SetLocks_Call) Run(run func(locks repository.LockRepository)) *MockExecutionChainService_SetLocks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.LockRepository))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetLocks_Call) Return() *MockExecutionChainService_SetLocks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetLocks_Call) RunAndReturn(run func(repository.LockRepository)) *MockExecutionChainService_SetLocks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetPriorityMetrics provides a mock function with given fields: metrics
+func (_m *MockExecutionChainService) SetPriorityMetrics(metrics *service.PriorityMetrics) {
+	_m.Called(metrics)
+}
+
+// MockExecutionChainService_SetPriorityMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriorityMetrics'
+type MockExecutionChainService_SetPriorityMetrics_Call struct {
+	*mock.Call
+}
+
+// SetPriorityMetrics is a helper method to define mock.On call
+//   - metrics *service.PriorityMetrics
+func (_e *MockExecutionChainService_Expecter) SetPriorityMetrics(metrics interface{}) *MockExecutionChainService_SetPriorityMetrics_Call {
+	return &MockExecutionChainService_SetPriorityMetrics_Call{Call: _e.mock.On("SetPriorityMetrics", metrics)}
+}
+
+func (_c *MockExecutionChainService_SetPriorityMetrics_Call) Run(run func(metrics *service.PriorityMetrics)) *MockExecutionChainService_SetPriorityMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*service.PriorityMetrics))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetPriorityMetrics_Call) Return() *MockExecutionChainService_SetPriorityMetrics_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetPriorityMetrics_Call) RunAndReturn(run func(*service.PriorityMetrics)) *MockExecutionChainService_SetPriorityMetrics_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetResponseCapture provides a mock function with given fields: capture
+func (_m *MockExecutionChainService) SetResponseCapture(capture service.ResponseCapture) {
+	_m.Called(capture)
+}
+
+// MockExecutionChainService_SetResponseCapture_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetResponseCapture'
+type MockExecutionChainService_SetResponseCapture_Call struct {
+	*mock.Call
+}
+
+// SetResponseCapture is a helper method to define mock.On call
+//   - capture service.ResponseCapture
+func (_e *MockExecutionChainService_Expecter) SetResponseCapture(capture interface{}) *MockExecutionChainService_SetResponseCapture_Call {
+	return &MockExecutionChainService_SetResponseCapture_Call{Call: _e.mock.On("SetResponseCapture", capture)}
+}
+
+func (_c *MockExecutionChainService_SetResponseCapture_Call) Run(run func(capture service.ResponseCapture)) *MockExecutionChainService_SetResponseCapture_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.ResponseCapture))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetResponseCapture_Call) Return() *MockExecutionChainService_SetResponseCapture_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetResponseCapture_Call) RunAndReturn(run func(service.ResponseCapture)) *MockExecutionChainService_SetResponseCapture_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetTenantRunLimit provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockExecutionChainService) SetTenantRunLimit(ctx context.Context, tenantID string, req *models.TenantRunLimitRequest) (*models.TenantRunLimitResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTenantRunLimit")
+	}
+
+	var r0 *models.TenantRunLimitResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.TenantRunLimitRequest) (*models.TenantRunLimitResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.TenantRunLimitRequest) *models.TenantRunLimitResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantRunLimitResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.TenantRunLimitRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_SetTenantRunLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTenantRunLimit'
+type MockExecutionChainService_SetTenantRunLimit_Call struct {
+	*mock.Call
+}
+
+// SetTenantRunLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - req *models.TenantRunLimitRequest
+func (_e *MockExecutionChainService_Expecter) SetTenantRunLimit(ctx interface{}, tenantID interface{}, req interface{}) *MockExecutionChainService_SetTenantRunLimit_Call {
+	return &MockExecutionChainService_SetTenantRunLimit_Call{Call: _e.mock.On("SetTenantRunLimit", ctx, tenantID, req)}
+}
+
+func (_c *MockExecutionChainService_SetTenantRunLimit_Call) Run(run func(ctx context.Context, tenantID string, req *models.TenantRunLimitRequest)) *MockExecutionChainService_SetTenantRunLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.TenantRunLimitRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetTenantRunLimit_Call) Return(_a0 *models.TenantRunLimitResponse, _a1 error) *MockExecutionChainService_SetTenantRunLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetTenantRunLimit_Call) RunAndReturn(run func(context.Context, string, *models.TenantRunLimitRequest) (*models.TenantRunLimitResponse, error)) *MockExecutionChainService_SetTenantRunLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTransports provides a mock function with given fields: transports
+func (_m *MockExecutionChainService) SetTransports(transports *service.TransportRegistry) {
+	_m.Called(transports)
+}
+
+// MockExecutionChainService_SetTransports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTransports'
+type MockExecutionChainService_SetTransports_Call struct {
+	*mock.Call
+}
+
+// SetTransports is a helper method to define mock.On call
+//   - transports *service.TransportRegistry
+func (_e *MockExecutionChainService_Expecter) SetTransports(transports interface{}) *MockExecutionChainService_SetTransports_Call {
+	return &MockExecutionChainService_SetTransports_Call{Call: _e.mock.On("SetTransports", transports)}
+}
+
+func (_c *MockExecutionChainService_SetTransports_Call) Run(run func(transports *service.TransportRegistry)) *MockExecutionChainService_SetTransports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*service.TransportRegistry))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetTransports_Call) Return() *MockExecutionChainService_SetTransports_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetTransports_Call) RunAndReturn(run func(*service.TransportRegistry)) *MockExecutionChainService_SetTransports_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetWorkQueue provides a mock function with given fields: queue
+func (_m *MockExecutionChainService) SetWorkQueue(queue service.WorkQueue) {
+	_m.Called(queue)
+}
+
+// MockExecutionChainService_SetWorkQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkQueue'
+type MockExecutionChainService_SetWorkQueue_Call struct {
+	*mock.Call
+}
+
+// SetWorkQueue is a helper method to define mock.On call
+//   - queue service.WorkQueue
+func (_e *MockExecutionChainService_Expecter) SetWorkQueue(queue interface{}) *MockExecutionChainService_SetWorkQueue_Call {
+	return &MockExecutionChainService_SetWorkQueue_Call{Call: _e.mock.On("SetWorkQueue", queue)}
+}
+
+func (_c *MockExecutionChainService_SetWorkQueue_Call) Run(run func(queue service.WorkQueue)) *MockExecutionChainService_SetWorkQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.WorkQueue))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetWorkQueue_Call) Return() *MockExecutionChainService_SetWorkQueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutionChainService_SetWorkQueue_Call) RunAndReturn(run func(service.WorkQueue)) *MockExecutionChainService_SetWorkQueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Shutdown provides a mock function with given fields: ctx
+func (_m *MockExecutionChainService) Shutdown(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExecutionChainService_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockExecutionChainService_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockExecutionChainService_Expecter) Shutdown(ctx interface{}) *MockExecutionChainService_Shutdown_Call {
+	return &MockExecutionChainService_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MockExecutionChainService_Shutdown_Call) Run(run func(ctx context.Context)) *MockExecutionChainService_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_Shutdown_Call) Return(_a0 error) *MockExecutionChainService_Shutdown_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExecutionChainService_Shutdown_Call) RunAndReturn(run func(context.Context) error) *MockExecutionChainService_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncChains provides a mock function with given fields: ctx, tenantID, templates, subscriptions, dryRun
+func (_m *MockExecutionChainService) SyncChains(ctx context.Context, tenantID string, templates []models.ChainTemplate, subscriptions []models.WebhookSubscription, dryRun bool) ([]models.ConfigResourceChange, error) {
+	ret := _m.Called(ctx, tenantID, templates, subscriptions, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncChains")
+	}
+
+	var r0 []models.ConfigResourceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.ChainTemplate, []models.WebhookSubscription, bool) ([]models.ConfigResourceChange, error)); ok {
+		return rf(ctx, tenantID, templates, subscriptions, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.ChainTemplate, []models.WebhookSubscription, bool) []models.ConfigResourceChange); ok {
+		r0 = rf(ctx, tenantID, templates, subscriptions, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ConfigResourceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []models.ChainTemplate, []models.WebhookSubscription, bool) error); ok {
+		r1 = rf(ctx, tenantID, templates, subscriptions, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_SyncChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncChains'
+type MockExecutionChainService_SyncChains_Call struct {
+	*mock.Call
+}
+
+// SyncChains is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - templates []models.ChainTemplate
+//   - subscriptions []models.WebhookSubscription
+//   - dryRun bool
+func (_e *MockExecutionChainService_Expecter) SyncChains(ctx interface{}, tenantID interface{}, templates interface{}, subscriptions interface{}, dryRun interface{}) *MockExecutionChainService_SyncChains_Call {
+	return &MockExecutionChainService_SyncChains_Call{Call: _e.mock.On("SyncChains", ctx, tenantID, templates, subscriptions, dryRun)}
+}
+
+func (_c *MockExecutionChainService_SyncChains_Call) Run(run func(ctx context.Context, tenantID string, templates []models.ChainTemplate, subscriptions []models.WebhookSubscription, dryRun bool)) *MockExecutionChainService_SyncChains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]models.ChainTemplate), args[3].([]models.WebhookSubscription), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_SyncChains_Call) Return(_a0 []models.ConfigResourceChange, _a1 error) *MockExecutionChainService_SyncChains_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_SyncChains_Call) RunAndReturn(run func(context.Context, string, []models.ChainTemplate, []models.WebhookSubscription, bool) ([]models.ConfigResourceChange, error)) *MockExecutionChainService_SyncChains_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TriggerScheduledChains provides a mock function with given fields: ctx
+func (_m *MockExecutionChainService) TriggerScheduledChains(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerScheduledChains")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_TriggerScheduledChains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TriggerScheduledChains'
+type MockExecutionChainService_TriggerScheduledChains_Call struct {
+	*mock.Call
+}
+
+// TriggerScheduledChains is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockExecutionChainService_Expecter) TriggerScheduledChains(ctx interface{}) *MockExecutionChainService_TriggerScheduledChains_Call {
+	return &MockExecutionChainService_TriggerScheduledChains_Call{Call: _e.mock.On("TriggerScheduledChains", ctx)}
+}
+
+func (_c *MockExecutionChainService_TriggerScheduledChains_Call) Run(run func(ctx context.Context)) *MockExecutionChainService_TriggerScheduledChains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_TriggerScheduledChains_Call) Return(_a0 int, _a1 error) *MockExecutionChainService_TriggerScheduledChains_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_TriggerScheduledChains_Call) RunAndReturn(run func(context.Context) (int, error)) *MockExecutionChainService_TriggerScheduledChains_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChain provides a mock function with given fields: ctx, tenantID, chainID, req
+func (_m *MockExecutionChainService) UpdateChain(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateExecutionChainRequest) error {
+	ret := _m.Called(ctx, tenantID, chainID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.UpdateExecutionChainRequest) error); ok {
+		r0 = rf(ctx, tenantID, chainID, req)
+	} else {
 		r0 = ret.Error(0)
 	}
 
@@ -503,15 +2676,16 @@ type MockExecutionChainService_UpdateChain_Call struct {
 
 // UpdateChain is a helper method to define mock.On call
 //   - ctx context.Context
+//   - tenantID string
 //   - chainID uuid.UUID
 //   - req *models.UpdateExecutionChainRequest
-func (_e *MockExecutionChainService_Expecter) UpdateChain(ctx interface{}, chainID interface{}, req interface{}) *MockExecutionChainService_UpdateChain_Call {
-	return &MockExecutionChainService_UpdateChain_Call{Call: _e.mock.On("UpdateChain", ctx, chainID, req)}
+func (_e *MockExecutionChainService_Expecter) UpdateChain(ctx interface{}, tenantID interface{}, chainID interface{}, req interface{}) *MockExecutionChainService_UpdateChain_Call {
+	return &MockExecutionChainService_UpdateChain_Call{Call: _e.mock.On("UpdateChain", ctx, tenantID, chainID, req)}
 }
 
-func (_c *MockExecutionChainService_UpdateChain_Call) Run(run func(ctx context.Context, chainID uuid.UUID, req *models.UpdateExecutionChainRequest)) *MockExecutionChainService_UpdateChain_Call {
+func (_c *MockExecutionChainService_UpdateChain_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateExecutionChainRequest)) *MockExecutionChainService_UpdateChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*models.UpdateExecutionChainRequest))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(*models.UpdateExecutionChainRequest))
 	})
 	return _c
 }
@@ -521,7 +2695,68 @@ func (_c *MockExecutionChainService_UpdateChain_Call) Return(_a0 error) *MockExe
 	return _c
 }
 
-func (_c *MockExecutionChainService_UpdateChain_Call) RunAndReturn(run func(context.Context, uuid.UUID, *models.UpdateExecutionChainRequest) error) *MockExecutionChainService_UpdateChain_Call {
+func (_c *MockExecutionChainService_UpdateChain_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, *models.UpdateExecutionChainRequest) error) *MockExecutionChainService_UpdateChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChainSteps provides a mock function with given fields: ctx, tenantID, chainID, req
+func (_m *MockExecutionChainService) UpdateChainSteps(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateChainStepsRequest) (*models.UpdateChainStepsResponse, error) {
+	ret := _m.Called(ctx, tenantID, chainID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChainSteps")
+	}
+
+	var r0 *models.UpdateChainStepsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.UpdateChainStepsRequest) (*models.UpdateChainStepsResponse, error)); ok {
+		return rf(ctx, tenantID, chainID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.UpdateChainStepsRequest) *models.UpdateChainStepsResponse); ok {
+		r0 = rf(ctx, tenantID, chainID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UpdateChainStepsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, *models.UpdateChainStepsRequest) error); ok {
+		r1 = rf(ctx, tenantID, chainID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExecutionChainService_UpdateChainSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChainSteps'
+type MockExecutionChainService_UpdateChainSteps_Call struct {
+	*mock.Call
+}
+
+// UpdateChainSteps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - chainID uuid.UUID
+//   - req *models.UpdateChainStepsRequest
+func (_e *MockExecutionChainService_Expecter) UpdateChainSteps(ctx interface{}, tenantID interface{}, chainID interface{}, req interface{}) *MockExecutionChainService_UpdateChainSteps_Call {
+	return &MockExecutionChainService_UpdateChainSteps_Call{Call: _e.mock.On("UpdateChainSteps", ctx, tenantID, chainID, req)}
+}
+
+func (_c *MockExecutionChainService_UpdateChainSteps_Call) Run(run func(ctx context.Context, tenantID string, chainID uuid.UUID, req *models.UpdateChainStepsRequest)) *MockExecutionChainService_UpdateChainSteps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(*models.UpdateChainStepsRequest))
+	})
+	return _c
+}
+
+func (_c *MockExecutionChainService_UpdateChainSteps_Call) Return(_a0 *models.UpdateChainStepsResponse, _a1 error) *MockExecutionChainService_UpdateChainSteps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExecutionChainService_UpdateChainSteps_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, *models.UpdateChainStepsRequest) (*models.UpdateChainStepsResponse, error)) *MockExecutionChainService_UpdateChainSteps_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	context "context"
+	time "time"
+
 	models "github.com/sakibcoolz/loki-suite/internal/models"
+	repository "github.com/sakibcoolz/loki-suite/internal/repository"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -22,167 +26,203 @@ func (_m *MockWebhookRepository) EXPECT() *MockWebhookRepository_Expecter {
 	return &MockWebhookRepository_Expecter{mock: &_m.Mock}
 }
 
-// CreateEvent provides a mock function with given fields: event
-func (_m *MockWebhookRepository) CreateEvent(event *models.WebhookEvent) error {
-	ret := _m.Called(event)
+// ClaimOrderedDelivery provides a mock function with given fields: ctx, subscriptionID, orderingKey, now, staleBefore
+func (_m *MockWebhookRepository) ClaimOrderedDelivery(ctx context.Context, subscriptionID uuid.UUID, orderingKey string, now time.Time, staleBefore time.Time) (*models.OrderedDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, orderingKey, now, staleBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateEvent")
+		panic("no return value specified for ClaimOrderedDelivery")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.WebhookEvent) error); ok {
-		r0 = rf(event)
+	var r0 *models.OrderedDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time, time.Time) (*models.OrderedDelivery, error)); ok {
+		return rf(ctx, subscriptionID, orderingKey, now, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time, time.Time) *models.OrderedDelivery); ok {
+		r0 = rf(ctx, subscriptionID, orderingKey, now, staleBefore)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderedDelivery)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, subscriptionID, orderingKey, now, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockWebhookRepository_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
-type MockWebhookRepository_CreateEvent_Call struct {
+// MockWebhookRepository_ClaimOrderedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimOrderedDelivery'
+type MockWebhookRepository_ClaimOrderedDelivery_Call struct {
 	*mock.Call
 }
 
-// CreateEvent is a helper method to define mock.On call
-//   - event *models.WebhookEvent
-func (_e *MockWebhookRepository_Expecter) CreateEvent(event interface{}) *MockWebhookRepository_CreateEvent_Call {
-	return &MockWebhookRepository_CreateEvent_Call{Call: _e.mock.On("CreateEvent", event)}
+// ClaimOrderedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID uuid.UUID
+//   - orderingKey string
+//   - now time.Time
+//   - staleBefore time.Time
+func (_e *MockWebhookRepository_Expecter) ClaimOrderedDelivery(ctx interface{}, subscriptionID interface{}, orderingKey interface{}, now interface{}, staleBefore interface{}) *MockWebhookRepository_ClaimOrderedDelivery_Call {
+	return &MockWebhookRepository_ClaimOrderedDelivery_Call{Call: _e.mock.On("ClaimOrderedDelivery", ctx, subscriptionID, orderingKey, now, staleBefore)}
 }
 
-func (_c *MockWebhookRepository_CreateEvent_Call) Run(run func(event *models.WebhookEvent)) *MockWebhookRepository_CreateEvent_Call {
+func (_c *MockWebhookRepository_ClaimOrderedDelivery_Call) Run(run func(ctx context.Context, subscriptionID uuid.UUID, orderingKey string, now time.Time, staleBefore time.Time)) *MockWebhookRepository_ClaimOrderedDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.WebhookEvent))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time), args[4].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_CreateEvent_Call) Return(_a0 error) *MockWebhookRepository_CreateEvent_Call {
-	_c.Call.Return(_a0)
+func (_c *MockWebhookRepository_ClaimOrderedDelivery_Call) Return(_a0 *models.OrderedDelivery, _a1 error) *MockWebhookRepository_ClaimOrderedDelivery_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_CreateEvent_Call) RunAndReturn(run func(*models.WebhookEvent) error) *MockWebhookRepository_CreateEvent_Call {
+func (_c *MockWebhookRepository_ClaimOrderedDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time, time.Time) (*models.OrderedDelivery, error)) *MockWebhookRepository_ClaimOrderedDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateSubscription provides a mock function with given fields: subscription
-func (_m *MockWebhookRepository) CreateSubscription(subscription *models.WebhookSubscription) error {
-	ret := _m.Called(subscription)
+// ClaimScheduledEvent provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) ClaimScheduledEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSubscription")
+		panic("no return value specified for ClaimScheduledEvent")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.WebhookSubscription) error); ok {
-		r0 = rf(subscription)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockWebhookRepository_CreateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubscription'
-type MockWebhookRepository_CreateSubscription_Call struct {
+// MockWebhookRepository_ClaimScheduledEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimScheduledEvent'
+type MockWebhookRepository_ClaimScheduledEvent_Call struct {
 	*mock.Call
 }
 
-// CreateSubscription is a helper method to define mock.On call
-//   - subscription *models.WebhookSubscription
-func (_e *MockWebhookRepository_Expecter) CreateSubscription(subscription interface{}) *MockWebhookRepository_CreateSubscription_Call {
-	return &MockWebhookRepository_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", subscription)}
+// ClaimScheduledEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) ClaimScheduledEvent(ctx interface{}, id interface{}) *MockWebhookRepository_ClaimScheduledEvent_Call {
+	return &MockWebhookRepository_ClaimScheduledEvent_Call{Call: _e.mock.On("ClaimScheduledEvent", ctx, id)}
 }
 
-func (_c *MockWebhookRepository_CreateSubscription_Call) Run(run func(subscription *models.WebhookSubscription)) *MockWebhookRepository_CreateSubscription_Call {
+func (_c *MockWebhookRepository_ClaimScheduledEvent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_ClaimScheduledEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.WebhookSubscription))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_CreateSubscription_Call) Return(_a0 error) *MockWebhookRepository_CreateSubscription_Call {
-	_c.Call.Return(_a0)
+func (_c *MockWebhookRepository_ClaimScheduledEvent_Call) Return(_a0 bool, _a1 error) *MockWebhookRepository_ClaimScheduledEvent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_CreateSubscription_Call) RunAndReturn(run func(*models.WebhookSubscription) error) *MockWebhookRepository_CreateSubscription_Call {
+func (_c *MockWebhookRepository_ClaimScheduledEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockWebhookRepository_ClaimScheduledEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteSubscription provides a mock function with given fields: id
-func (_m *MockWebhookRepository) DeleteSubscription(id uuid.UUID) error {
-	ret := _m.Called(id)
+// CountBatchedDeliveriesByEvent provides a mock function with given fields: ctx, eventID
+func (_m *MockWebhookRepository) CountBatchedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, eventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSubscription")
+		panic("no return value specified for CountBatchedDeliveriesByEvent")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = rf(id)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, eventID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockWebhookRepository_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
-type MockWebhookRepository_DeleteSubscription_Call struct {
+// MockWebhookRepository_CountBatchedDeliveriesByEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBatchedDeliveriesByEvent'
+type MockWebhookRepository_CountBatchedDeliveriesByEvent_Call struct {
 	*mock.Call
 }
 
-// DeleteSubscription is a helper method to define mock.On call
-//   - id uuid.UUID
-func (_e *MockWebhookRepository_Expecter) DeleteSubscription(id interface{}) *MockWebhookRepository_DeleteSubscription_Call {
-	return &MockWebhookRepository_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", id)}
+// CountBatchedDeliveriesByEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID uuid.UUID
+func (_e *MockWebhookRepository_Expecter) CountBatchedDeliveriesByEvent(ctx interface{}, eventID interface{}) *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call {
+	return &MockWebhookRepository_CountBatchedDeliveriesByEvent_Call{Call: _e.mock.On("CountBatchedDeliveriesByEvent", ctx, eventID)}
 }
 
-func (_c *MockWebhookRepository_DeleteSubscription_Call) Run(run func(id uuid.UUID)) *MockWebhookRepository_DeleteSubscription_Call {
+func (_c *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call) Run(run func(ctx context.Context, eventID uuid.UUID)) *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_DeleteSubscription_Call) Return(_a0 error) *MockWebhookRepository_DeleteSubscription_Call {
-	_c.Call.Return(_a0)
+func (_c *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_DeleteSubscription_Call) RunAndReturn(run func(uuid.UUID) error) *MockWebhookRepository_DeleteSubscription_Call {
+func (_c *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockWebhookRepository_CountBatchedDeliveriesByEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveSubscriptionsByTenantAndEvent provides a mock function with given fields: tenantID, event
-func (_m *MockWebhookRepository) GetActiveSubscriptionsByTenantAndEvent(tenantID string, event string) ([]models.WebhookSubscription, error) {
-	ret := _m.Called(tenantID, event)
+// CountEventsSince provides a mock function with given fields: ctx, tenantID, event, since
+func (_m *MockWebhookRepository) CountEventsSince(ctx context.Context, tenantID string, event string, since time.Time) (int64, error) {
+	ret := _m.Called(ctx, tenantID, event, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveSubscriptionsByTenantAndEvent")
+		panic("no return value specified for CountEventsSince")
 	}
 
-	var r0 []models.WebhookSubscription
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]models.WebhookSubscription, error)); ok {
-		return rf(tenantID, event)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (int64, error)); ok {
+		return rf(ctx, tenantID, event, since)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []models.WebhookSubscription); ok {
-		r0 = rf(tenantID, event)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) int64); ok {
+		r0 = rf(ctx, tenantID, event, since)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.WebhookSubscription)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(tenantID, event)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, event, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,58 +230,58 @@ func (_m *MockWebhookRepository) GetActiveSubscriptionsByTenantAndEvent(tenantID
 	return r0, r1
 }
 
-// MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveSubscriptionsByTenantAndEvent'
-type MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call struct {
+// MockWebhookRepository_CountEventsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountEventsSince'
+type MockWebhookRepository_CountEventsSince_Call struct {
 	*mock.Call
 }
 
-// GetActiveSubscriptionsByTenantAndEvent is a helper method to define mock.On call
+// CountEventsSince is a helper method to define mock.On call
+//   - ctx context.Context
 //   - tenantID string
 //   - event string
-func (_e *MockWebhookRepository_Expecter) GetActiveSubscriptionsByTenantAndEvent(tenantID interface{}, event interface{}) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
-	return &MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call{Call: _e.mock.On("GetActiveSubscriptionsByTenantAndEvent", tenantID, event)}
+//   - since time.Time
+func (_e *MockWebhookRepository_Expecter) CountEventsSince(ctx interface{}, tenantID interface{}, event interface{}, since interface{}) *MockWebhookRepository_CountEventsSince_Call {
+	return &MockWebhookRepository_CountEventsSince_Call{Call: _e.mock.On("CountEventsSince", ctx, tenantID, event, since)}
 }
 
-func (_c *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call) Run(run func(tenantID string, event string)) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+func (_c *MockWebhookRepository_CountEventsSince_Call) Run(run func(ctx context.Context, tenantID string, event string, since time.Time)) *MockWebhookRepository_CountEventsSince_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call) Return(_a0 []models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+func (_c *MockWebhookRepository_CountEventsSince_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_CountEventsSince_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call) RunAndReturn(run func(string, string) ([]models.WebhookSubscription, error)) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+func (_c *MockWebhookRepository_CountEventsSince_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (int64, error)) *MockWebhookRepository_CountEventsSince_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventByID provides a mock function with given fields: id
-func (_m *MockWebhookRepository) GetEventByID(id uuid.UUID) (*models.WebhookEvent, error) {
-	ret := _m.Called(id)
+// CountHeldEvents provides a mock function with given fields: ctx, tenantID, excludeTenants
+func (_m *MockWebhookRepository) CountHeldEvents(ctx context.Context, tenantID string, excludeTenants []string) (int64, error) {
+	ret := _m.Called(ctx, tenantID, excludeTenants)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventByID")
+		panic("no return value specified for CountHeldEvents")
 	}
 
-	var r0 *models.WebhookEvent
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.WebhookEvent, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (int64, error)); ok {
+		return rf(ctx, tenantID, excludeTenants)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.WebhookEvent); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) int64); ok {
+		r0 = rf(ctx, tenantID, excludeTenants)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.WebhookEvent)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, tenantID, excludeTenants)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -249,57 +289,57 @@ func (_m *MockWebhookRepository) GetEventByID(id uuid.UUID) (*models.WebhookEven
 	return r0, r1
 }
 
-// MockWebhookRepository_GetEventByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventByID'
-type MockWebhookRepository_GetEventByID_Call struct {
+// MockWebhookRepository_CountHeldEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountHeldEvents'
+type MockWebhookRepository_CountHeldEvents_Call struct {
 	*mock.Call
 }
 
-// GetEventByID is a helper method to define mock.On call
-//   - id uuid.UUID
-func (_e *MockWebhookRepository_Expecter) GetEventByID(id interface{}) *MockWebhookRepository_GetEventByID_Call {
-	return &MockWebhookRepository_GetEventByID_Call{Call: _e.mock.On("GetEventByID", id)}
+// CountHeldEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - excludeTenants []string
+func (_e *MockWebhookRepository_Expecter) CountHeldEvents(ctx interface{}, tenantID interface{}, excludeTenants interface{}) *MockWebhookRepository_CountHeldEvents_Call {
+	return &MockWebhookRepository_CountHeldEvents_Call{Call: _e.mock.On("CountHeldEvents", ctx, tenantID, excludeTenants)}
 }
 
-func (_c *MockWebhookRepository_GetEventByID_Call) Run(run func(id uuid.UUID)) *MockWebhookRepository_GetEventByID_Call {
+func (_c *MockWebhookRepository_CountHeldEvents_Call) Run(run func(ctx context.Context, tenantID string, excludeTenants []string)) *MockWebhookRepository_CountHeldEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetEventByID_Call) Return(_a0 *models.WebhookEvent, _a1 error) *MockWebhookRepository_GetEventByID_Call {
+func (_c *MockWebhookRepository_CountHeldEvents_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_CountHeldEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetEventByID_Call) RunAndReturn(run func(uuid.UUID) (*models.WebhookEvent, error)) *MockWebhookRepository_GetEventByID_Call {
+func (_c *MockWebhookRepository_CountHeldEvents_Call) RunAndReturn(run func(context.Context, string, []string) (int64, error)) *MockWebhookRepository_CountHeldEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsByStatus provides a mock function with given fields: status, limit
-func (_m *MockWebhookRepository) GetEventsByStatus(status models.WebhookStatus, limit int) ([]models.WebhookEvent, error) {
-	ret := _m.Called(status, limit)
+// CountOrderedDeliveriesByEvent provides a mock function with given fields: ctx, eventID
+func (_m *MockWebhookRepository) CountOrderedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, eventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsByStatus")
+		panic("no return value specified for CountOrderedDeliveriesByEvent")
 	}
 
-	var r0 []models.WebhookEvent
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.WebhookStatus, int) ([]models.WebhookEvent, error)); ok {
-		return rf(status, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, eventID)
 	}
-	if rf, ok := ret.Get(0).(func(models.WebhookStatus, int) []models.WebhookEvent); ok {
-		r0 = rf(status, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, eventID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.WebhookEvent)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(models.WebhookStatus, int) error); ok {
-		r1 = rf(status, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -307,58 +347,56 @@ func (_m *MockWebhookRepository) GetEventsByStatus(status models.WebhookStatus,
 	return r0, r1
 }
 
-// MockWebhookRepository_GetEventsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsByStatus'
-type MockWebhookRepository_GetEventsByStatus_Call struct {
+// MockWebhookRepository_CountOrderedDeliveriesByEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrderedDeliveriesByEvent'
+type MockWebhookRepository_CountOrderedDeliveriesByEvent_Call struct {
 	*mock.Call
 }
 
-// GetEventsByStatus is a helper method to define mock.On call
-//   - status models.WebhookStatus
-//   - limit int
-func (_e *MockWebhookRepository_Expecter) GetEventsByStatus(status interface{}, limit interface{}) *MockWebhookRepository_GetEventsByStatus_Call {
-	return &MockWebhookRepository_GetEventsByStatus_Call{Call: _e.mock.On("GetEventsByStatus", status, limit)}
+// CountOrderedDeliveriesByEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID uuid.UUID
+func (_e *MockWebhookRepository_Expecter) CountOrderedDeliveriesByEvent(ctx interface{}, eventID interface{}) *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call {
+	return &MockWebhookRepository_CountOrderedDeliveriesByEvent_Call{Call: _e.mock.On("CountOrderedDeliveriesByEvent", ctx, eventID)}
 }
 
-func (_c *MockWebhookRepository_GetEventsByStatus_Call) Run(run func(status models.WebhookStatus, limit int)) *MockWebhookRepository_GetEventsByStatus_Call {
+func (_c *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call) Run(run func(ctx context.Context, eventID uuid.UUID)) *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(models.WebhookStatus), args[1].(int))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetEventsByStatus_Call) Return(_a0 []models.WebhookEvent, _a1 error) *MockWebhookRepository_GetEventsByStatus_Call {
+func (_c *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetEventsByStatus_Call) RunAndReturn(run func(models.WebhookStatus, int) ([]models.WebhookEvent, error)) *MockWebhookRepository_GetEventsByStatus_Call {
+func (_c *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockWebhookRepository_CountOrderedDeliveriesByEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSubscriptionByID provides a mock function with given fields: id
-func (_m *MockWebhookRepository) GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error) {
-	ret := _m.Called(id)
+// CountQueuedDeliveriesByEvent provides a mock function with given fields: ctx, eventID
+func (_m *MockWebhookRepository) CountQueuedDeliveriesByEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, eventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionByID")
+		panic("no return value specified for CountQueuedDeliveriesByEvent")
 	}
 
-	var r0 *models.WebhookSubscription
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.WebhookSubscription, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, eventID)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.WebhookSubscription); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, eventID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.WebhookSubscription)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -366,112 +404,234 @@ func (_m *MockWebhookRepository) GetSubscriptionByID(id uuid.UUID) (*models.Webh
 	return r0, r1
 }
 
-// MockWebhookRepository_GetSubscriptionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionByID'
-type MockWebhookRepository_GetSubscriptionByID_Call struct {
+// MockWebhookRepository_CountQueuedDeliveriesByEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountQueuedDeliveriesByEvent'
+type MockWebhookRepository_CountQueuedDeliveriesByEvent_Call struct {
 	*mock.Call
 }
 
-// GetSubscriptionByID is a helper method to define mock.On call
-//   - id uuid.UUID
-func (_e *MockWebhookRepository_Expecter) GetSubscriptionByID(id interface{}) *MockWebhookRepository_GetSubscriptionByID_Call {
-	return &MockWebhookRepository_GetSubscriptionByID_Call{Call: _e.mock.On("GetSubscriptionByID", id)}
+// CountQueuedDeliveriesByEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID uuid.UUID
+func (_e *MockWebhookRepository_Expecter) CountQueuedDeliveriesByEvent(ctx interface{}, eventID interface{}) *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call {
+	return &MockWebhookRepository_CountQueuedDeliveriesByEvent_Call{Call: _e.mock.On("CountQueuedDeliveriesByEvent", ctx, eventID)}
 }
 
-func (_c *MockWebhookRepository_GetSubscriptionByID_Call) Run(run func(id uuid.UUID)) *MockWebhookRepository_GetSubscriptionByID_Call {
+func (_c *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call) Run(run func(ctx context.Context, eventID uuid.UUID)) *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetSubscriptionByID_Call) Return(_a0 *models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetSubscriptionByID_Call {
+func (_c *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetSubscriptionByID_Call) RunAndReturn(run func(uuid.UUID) (*models.WebhookSubscription, error)) *MockWebhookRepository_GetSubscriptionByID_Call {
+func (_c *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockWebhookRepository_CountQueuedDeliveriesByEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSubscriptionsByTenant provides a mock function with given fields: tenantID, offset, limit
-func (_m *MockWebhookRepository) GetSubscriptionsByTenant(tenantID string, offset int, limit int) ([]models.WebhookSubscription, int64, error) {
-	ret := _m.Called(tenantID, offset, limit)
+// CreateBatchedDelivery provides a mock function with given fields: ctx, delivery
+func (_m *MockWebhookRepository) CreateBatchedDelivery(ctx context.Context, delivery *models.BatchedDelivery) error {
+	ret := _m.Called(ctx, delivery)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionsByTenant")
+		panic("no return value specified for CreateBatchedDelivery")
 	}
 
-	var r0 []models.WebhookSubscription
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.WebhookSubscription, int64, error)); ok {
-		return rf(tenantID, offset, limit)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BatchedDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_CreateBatchedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatchedDelivery'
+type MockWebhookRepository_CreateBatchedDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateBatchedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *models.BatchedDelivery
+func (_e *MockWebhookRepository_Expecter) CreateBatchedDelivery(ctx interface{}, delivery interface{}) *MockWebhookRepository_CreateBatchedDelivery_Call {
+	return &MockWebhookRepository_CreateBatchedDelivery_Call{Call: _e.mock.On("CreateBatchedDelivery", ctx, delivery)}
+}
+
+func (_c *MockWebhookRepository_CreateBatchedDelivery_Call) Run(run func(ctx context.Context, delivery *models.BatchedDelivery)) *MockWebhookRepository_CreateBatchedDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.BatchedDelivery))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateBatchedDelivery_Call) Return(_a0 error) *MockWebhookRepository_CreateBatchedDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateBatchedDelivery_Call) RunAndReturn(run func(context.Context, *models.BatchedDelivery) error) *MockWebhookRepository_CreateBatchedDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDeliveryAttempts provides a mock function with given fields: ctx, attempts
+func (_m *MockWebhookRepository) CreateDeliveryAttempts(ctx context.Context, attempts []*models.WebhookDeliveryAttempt) error {
+	ret := _m.Called(ctx, attempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeliveryAttempts")
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []models.WebhookSubscription); ok {
-		r0 = rf(tenantID, offset, limit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.WebhookDeliveryAttempt) error); ok {
+		r0 = rf(ctx, attempts)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.WebhookSubscription)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_CreateDeliveryAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDeliveryAttempts'
+type MockWebhookRepository_CreateDeliveryAttempts_Call struct {
+	*mock.Call
+}
+
+// CreateDeliveryAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attempts []*models.WebhookDeliveryAttempt
+func (_e *MockWebhookRepository_Expecter) CreateDeliveryAttempts(ctx interface{}, attempts interface{}) *MockWebhookRepository_CreateDeliveryAttempts_Call {
+	return &MockWebhookRepository_CreateDeliveryAttempts_Call{Call: _e.mock.On("CreateDeliveryAttempts", ctx, attempts)}
+}
+
+func (_c *MockWebhookRepository_CreateDeliveryAttempts_Call) Run(run func(ctx context.Context, attempts []*models.WebhookDeliveryAttempt)) *MockWebhookRepository_CreateDeliveryAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.WebhookDeliveryAttempt))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateDeliveryAttempts_Call) Return(_a0 error) *MockWebhookRepository_CreateDeliveryAttempts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateDeliveryAttempts_Call) RunAndReturn(run func(context.Context, []*models.WebhookDeliveryAttempt) error) *MockWebhookRepository_CreateDeliveryAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEvent provides a mock function with given fields: ctx, event
+func (_m *MockWebhookRepository) CreateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEvent")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
-		r1 = rf(tenantID, offset, limit)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookEvent) error); ok {
+		r0 = rf(ctx, event)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
+type MockWebhookRepository_CreateEvent_Call struct {
+	*mock.Call
+}
+
+// CreateEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *models.WebhookEvent
+func (_e *MockWebhookRepository_Expecter) CreateEvent(ctx interface{}, event interface{}) *MockWebhookRepository_CreateEvent_Call {
+	return &MockWebhookRepository_CreateEvent_Call{Call: _e.mock.On("CreateEvent", ctx, event)}
+}
+
+func (_c *MockWebhookRepository_CreateEvent_Call) Run(run func(ctx context.Context, event *models.WebhookEvent)) *MockWebhookRepository_CreateEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookEvent))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateEvent_Call) Return(_a0 error) *MockWebhookRepository_CreateEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateEvent_Call) RunAndReturn(run func(context.Context, *models.WebhookEvent) error) *MockWebhookRepository_CreateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEventType provides a mock function with given fields: ctx, eventType
+func (_m *MockWebhookRepository) CreateEventType(ctx context.Context, eventType *models.EventType) error {
+	ret := _m.Called(ctx, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEventType")
 	}
 
-	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
-		r2 = rf(tenantID, offset, limit)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EventType) error); ok {
+		r0 = rf(ctx, eventType)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// MockWebhookRepository_GetSubscriptionsByTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsByTenant'
-type MockWebhookRepository_GetSubscriptionsByTenant_Call struct {
+// MockWebhookRepository_CreateEventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEventType'
+type MockWebhookRepository_CreateEventType_Call struct {
 	*mock.Call
 }
 
-// GetSubscriptionsByTenant is a helper method to define mock.On call
-//   - tenantID string
-//   - offset int
-//   - limit int
-func (_e *MockWebhookRepository_Expecter) GetSubscriptionsByTenant(tenantID interface{}, offset interface{}, limit interface{}) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
-	return &MockWebhookRepository_GetSubscriptionsByTenant_Call{Call: _e.mock.On("GetSubscriptionsByTenant", tenantID, offset, limit)}
+// CreateEventType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventType *models.EventType
+func (_e *MockWebhookRepository_Expecter) CreateEventType(ctx interface{}, eventType interface{}) *MockWebhookRepository_CreateEventType_Call {
+	return &MockWebhookRepository_CreateEventType_Call{Call: _e.mock.On("CreateEventType", ctx, eventType)}
 }
 
-func (_c *MockWebhookRepository_GetSubscriptionsByTenant_Call) Run(run func(tenantID string, offset int, limit int)) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
+func (_c *MockWebhookRepository_CreateEventType_Call) Run(run func(ctx context.Context, eventType *models.EventType)) *MockWebhookRepository_CreateEventType_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(*models.EventType))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetSubscriptionsByTenant_Call) Return(_a0 []models.WebhookSubscription, _a1 int64, _a2 error) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockWebhookRepository_CreateEventType_Call) Return(_a0 error) *MockWebhookRepository_CreateEventType_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockWebhookRepository_GetSubscriptionsByTenant_Call) RunAndReturn(run func(string, int, int) ([]models.WebhookSubscription, int64, error)) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
+func (_c *MockWebhookRepository_CreateEventType_Call) RunAndReturn(run func(context.Context, *models.EventType) error) *MockWebhookRepository_CreateEventType_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateEvent provides a mock function with given fields: event
-func (_m *MockWebhookRepository) UpdateEvent(event *models.WebhookEvent) error {
-	ret := _m.Called(event)
+// CreateOrderedDelivery provides a mock function with given fields: ctx, delivery
+func (_m *MockWebhookRepository) CreateOrderedDelivery(ctx context.Context, delivery *models.OrderedDelivery) error {
+	ret := _m.Called(ctx, delivery)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateEvent")
+		panic("no return value specified for CreateOrderedDelivery")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.WebhookEvent) error); ok {
-		r0 = rf(event)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OrderedDelivery) error); ok {
+		r0 = rf(ctx, delivery)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -479,45 +639,2074 @@ func (_m *MockWebhookRepository) UpdateEvent(event *models.WebhookEvent) error {
 	return r0
 }
 
-// MockWebhookRepository_UpdateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEvent'
-type MockWebhookRepository_UpdateEvent_Call struct {
+// MockWebhookRepository_CreateOrderedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrderedDelivery'
+type MockWebhookRepository_CreateOrderedDelivery_Call struct {
 	*mock.Call
 }
 
-// UpdateEvent is a helper method to define mock.On call
-//   - event *models.WebhookEvent
-func (_e *MockWebhookRepository_Expecter) UpdateEvent(event interface{}) *MockWebhookRepository_UpdateEvent_Call {
-	return &MockWebhookRepository_UpdateEvent_Call{Call: _e.mock.On("UpdateEvent", event)}
+// CreateOrderedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *models.OrderedDelivery
+func (_e *MockWebhookRepository_Expecter) CreateOrderedDelivery(ctx interface{}, delivery interface{}) *MockWebhookRepository_CreateOrderedDelivery_Call {
+	return &MockWebhookRepository_CreateOrderedDelivery_Call{Call: _e.mock.On("CreateOrderedDelivery", ctx, delivery)}
 }
 
-func (_c *MockWebhookRepository_UpdateEvent_Call) Run(run func(event *models.WebhookEvent)) *MockWebhookRepository_UpdateEvent_Call {
+func (_c *MockWebhookRepository_CreateOrderedDelivery_Call) Run(run func(ctx context.Context, delivery *models.OrderedDelivery)) *MockWebhookRepository_CreateOrderedDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.WebhookEvent))
+		run(args[0].(context.Context), args[1].(*models.OrderedDelivery))
 	})
 	return _c
 }
 
-func (_c *MockWebhookRepository_UpdateEvent_Call) Return(_a0 error) *MockWebhookRepository_UpdateEvent_Call {
+func (_c *MockWebhookRepository_CreateOrderedDelivery_Call) Return(_a0 error) *MockWebhookRepository_CreateOrderedDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateOrderedDelivery_Call) RunAndReturn(run func(context.Context, *models.OrderedDelivery) error) *MockWebhookRepository_CreateOrderedDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQueuedDelivery provides a mock function with given fields: ctx, delivery
+func (_m *MockWebhookRepository) CreateQueuedDelivery(ctx context.Context, delivery *models.QueuedDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQueuedDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.QueuedDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_CreateQueuedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQueuedDelivery'
+type MockWebhookRepository_CreateQueuedDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateQueuedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *models.QueuedDelivery
+func (_e *MockWebhookRepository_Expecter) CreateQueuedDelivery(ctx interface{}, delivery interface{}) *MockWebhookRepository_CreateQueuedDelivery_Call {
+	return &MockWebhookRepository_CreateQueuedDelivery_Call{Call: _e.mock.On("CreateQueuedDelivery", ctx, delivery)}
+}
+
+func (_c *MockWebhookRepository_CreateQueuedDelivery_Call) Run(run func(ctx context.Context, delivery *models.QueuedDelivery)) *MockWebhookRepository_CreateQueuedDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.QueuedDelivery))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateQueuedDelivery_Call) Return(_a0 error) *MockWebhookRepository_CreateQueuedDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateQueuedDelivery_Call) RunAndReturn(run func(context.Context, *models.QueuedDelivery) error) *MockWebhookRepository_CreateQueuedDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSubscription provides a mock function with given fields: ctx, subscription
+func (_m *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ret := _m.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookSubscription) error); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_CreateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubscription'
+type MockWebhookRepository_CreateSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.WebhookSubscription
+func (_e *MockWebhookRepository_Expecter) CreateSubscription(ctx interface{}, subscription interface{}) *MockWebhookRepository_CreateSubscription_Call {
+	return &MockWebhookRepository_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", ctx, subscription)}
+}
+
+func (_c *MockWebhookRepository_CreateSubscription_Call) Run(run func(ctx context.Context, subscription *models.WebhookSubscription)) *MockWebhookRepository_CreateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookSubscription))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateSubscription_Call) Return(_a0 error) *MockWebhookRepository_CreateSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateSubscription_Call) RunAndReturn(run func(context.Context, *models.WebhookSubscription) error) *MockWebhookRepository_CreateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBatchedDeliveries provides a mock function with given fields: ctx, ids
+func (_m *MockWebhookRepository) DeleteBatchedDeliveries(ctx context.Context, ids []uuid.UUID) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBatchedDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_DeleteBatchedDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBatchedDeliveries'
+type MockWebhookRepository_DeleteBatchedDeliveries_Call struct {
+	*mock.Call
+}
+
+// DeleteBatchedDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockWebhookRepository_Expecter) DeleteBatchedDeliveries(ctx interface{}, ids interface{}) *MockWebhookRepository_DeleteBatchedDeliveries_Call {
+	return &MockWebhookRepository_DeleteBatchedDeliveries_Call{Call: _e.mock.On("DeleteBatchedDeliveries", ctx, ids)}
+}
+
+func (_c *MockWebhookRepository_DeleteBatchedDeliveries_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockWebhookRepository_DeleteBatchedDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteBatchedDeliveries_Call) Return(_a0 error) *MockWebhookRepository_DeleteBatchedDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteBatchedDeliveries_Call) RunAndReturn(run func(context.Context, []uuid.UUID) error) *MockWebhookRepository_DeleteBatchedDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEventType provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) DeleteEventType(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEventType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_DeleteEventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEventType'
+type MockWebhookRepository_DeleteEventType_Call struct {
+	*mock.Call
+}
+
+// DeleteEventType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) DeleteEventType(ctx interface{}, id interface{}) *MockWebhookRepository_DeleteEventType_Call {
+	return &MockWebhookRepository_DeleteEventType_Call{Call: _e.mock.On("DeleteEventType", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_DeleteEventType_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_DeleteEventType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteEventType_Call) Return(_a0 error) *MockWebhookRepository_DeleteEventType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteEventType_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookRepository_DeleteEventType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOrderedDelivery provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) DeleteOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOrderedDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_DeleteOrderedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOrderedDelivery'
+type MockWebhookRepository_DeleteOrderedDelivery_Call struct {
+	*mock.Call
+}
+
+// DeleteOrderedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) DeleteOrderedDelivery(ctx interface{}, id interface{}) *MockWebhookRepository_DeleteOrderedDelivery_Call {
+	return &MockWebhookRepository_DeleteOrderedDelivery_Call{Call: _e.mock.On("DeleteOrderedDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_DeleteOrderedDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_DeleteOrderedDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteOrderedDelivery_Call) Return(_a0 error) *MockWebhookRepository_DeleteOrderedDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteOrderedDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookRepository_DeleteOrderedDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteQueuedDelivery provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) DeleteQueuedDelivery(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQueuedDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_DeleteQueuedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQueuedDelivery'
+type MockWebhookRepository_DeleteQueuedDelivery_Call struct {
+	*mock.Call
+}
+
+// DeleteQueuedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) DeleteQueuedDelivery(ctx interface{}, id interface{}) *MockWebhookRepository_DeleteQueuedDelivery_Call {
+	return &MockWebhookRepository_DeleteQueuedDelivery_Call{Call: _e.mock.On("DeleteQueuedDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_DeleteQueuedDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_DeleteQueuedDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteQueuedDelivery_Call) Return(_a0 error) *MockWebhookRepository_DeleteQueuedDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteQueuedDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookRepository_DeleteQueuedDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteResumedDeliveryPause provides a mock function with given fields: ctx, scope
+func (_m *MockWebhookRepository) DeleteResumedDeliveryPause(ctx context.Context, scope string) error {
+	ret := _m.Called(ctx, scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteResumedDeliveryPause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_DeleteResumedDeliveryPause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteResumedDeliveryPause'
+type MockWebhookRepository_DeleteResumedDeliveryPause_Call struct {
+	*mock.Call
+}
+
+// DeleteResumedDeliveryPause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+func (_e *MockWebhookRepository_Expecter) DeleteResumedDeliveryPause(ctx interface{}, scope interface{}) *MockWebhookRepository_DeleteResumedDeliveryPause_Call {
+	return &MockWebhookRepository_DeleteResumedDeliveryPause_Call{Call: _e.mock.On("DeleteResumedDeliveryPause", ctx, scope)}
+}
+
+func (_c *MockWebhookRepository_DeleteResumedDeliveryPause_Call) Run(run func(ctx context.Context, scope string)) *MockWebhookRepository_DeleteResumedDeliveryPause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteResumedDeliveryPause_Call) Return(_a0 error) *MockWebhookRepository_DeleteResumedDeliveryPause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteResumedDeliveryPause_Call) RunAndReturn(run func(context.Context, string) error) *MockWebhookRepository_DeleteResumedDeliveryPause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type MockWebhookRepository_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) DeleteSubscription(ctx interface{}, id interface{}) *MockWebhookRepository_DeleteSubscription_Call {
+	return &MockWebhookRepository_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_DeleteSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteSubscription_Call) Return(_a0 error) *MockWebhookRepository_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookRepository_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableSubscription provides a mock function with given fields: ctx, id, disabledAt, reason
+func (_m *MockWebhookRepository) DisableSubscription(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string) (bool, error) {
+	ret := _m.Called(ctx, id, disabledAt, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableSubscription")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, string) (bool, error)); ok {
+		return rf(ctx, id, disabledAt, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, string) bool); ok {
+		r0 = rf(ctx, id, disabledAt, reason)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, string) error); ok {
+		r1 = rf(ctx, id, disabledAt, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_DisableSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableSubscription'
+type MockWebhookRepository_DisableSubscription_Call struct {
+	*mock.Call
+}
+
+// DisableSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - disabledAt time.Time
+//   - reason string
+func (_e *MockWebhookRepository_Expecter) DisableSubscription(ctx interface{}, id interface{}, disabledAt interface{}, reason interface{}) *MockWebhookRepository_DisableSubscription_Call {
+	return &MockWebhookRepository_DisableSubscription_Call{Call: _e.mock.On("DisableSubscription", ctx, id, disabledAt, reason)}
+}
+
+func (_c *MockWebhookRepository_DisableSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID, disabledAt time.Time, reason string)) *MockWebhookRepository_DisableSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DisableSubscription_Call) Return(_a0 bool, _a1 error) *MockWebhookRepository_DisableSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DisableSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, string) (bool, error)) *MockWebhookRepository_DisableSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableSubscription provides a mock function with given fields: ctx, id, enabledAt
+func (_m *MockWebhookRepository) EnableSubscription(ctx context.Context, id uuid.UUID, enabledAt time.Time) error {
+	ret := _m.Called(ctx, id, enabledAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, id, enabledAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_EnableSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableSubscription'
+type MockWebhookRepository_EnableSubscription_Call struct {
+	*mock.Call
+}
+
+// EnableSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - enabledAt time.Time
+func (_e *MockWebhookRepository_Expecter) EnableSubscription(ctx interface{}, id interface{}, enabledAt interface{}) *MockWebhookRepository_EnableSubscription_Call {
+	return &MockWebhookRepository_EnableSubscription_Call{Call: _e.mock.On("EnableSubscription", ctx, id, enabledAt)}
+}
+
+func (_c *MockWebhookRepository_EnableSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID, enabledAt time.Time)) *MockWebhookRepository_EnableSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_EnableSubscription_Call) Return(_a0 error) *MockWebhookRepository_EnableSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_EnableSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *MockWebhookRepository_EnableSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveSubscriptionsByTenantAndEvent provides a mock function with given fields: ctx, tenantID, event
+func (_m *MockWebhookRepository) GetActiveSubscriptionsByTenantAndEvent(ctx context.Context, tenantID string, event string) ([]models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, tenantID, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveSubscriptionsByTenantAndEvent")
+	}
+
+	var r0 []models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.WebhookSubscription, error)); ok {
+		return rf(ctx, tenantID, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.WebhookSubscription); ok {
+		r0 = rf(ctx, tenantID, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveSubscriptionsByTenantAndEvent'
+type MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call struct {
+	*mock.Call
+}
+
+// GetActiveSubscriptionsByTenantAndEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - event string
+func (_e *MockWebhookRepository_Expecter) GetActiveSubscriptionsByTenantAndEvent(ctx interface{}, tenantID interface{}, event interface{}) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+	return &MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call{Call: _e.mock.On("GetActiveSubscriptionsByTenantAndEvent", ctx, tenantID, event)}
+}
+
+func (_c *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call) Run(run func(ctx context.Context, tenantID string, event string)) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call) Return(_a0 []models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call) RunAndReturn(run func(context.Context, string, string) ([]models.WebhookSubscription, error)) *MockWebhookRepository_GetActiveSubscriptionsByTenantAndEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchedDeliveries provides a mock function with given fields: ctx, subscriptionID, limit
+func (_m *MockWebhookRepository) GetBatchedDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.BatchedDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchedDeliveries")
+	}
+
+	var r0 []models.BatchedDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]models.BatchedDelivery, error)); ok {
+		return rf(ctx, subscriptionID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []models.BatchedDelivery); ok {
+		r0 = rf(ctx, subscriptionID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BatchedDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, subscriptionID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetBatchedDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchedDeliveries'
+type MockWebhookRepository_GetBatchedDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetBatchedDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID uuid.UUID
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) GetBatchedDeliveries(ctx interface{}, subscriptionID interface{}, limit interface{}) *MockWebhookRepository_GetBatchedDeliveries_Call {
+	return &MockWebhookRepository_GetBatchedDeliveries_Call{Call: _e.mock.On("GetBatchedDeliveries", ctx, subscriptionID, limit)}
+}
+
+func (_c *MockWebhookRepository_GetBatchedDeliveries_Call) Run(run func(ctx context.Context, subscriptionID uuid.UUID, limit int)) *MockWebhookRepository_GetBatchedDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetBatchedDeliveries_Call) Return(_a0 []models.BatchedDelivery, _a1 error) *MockWebhookRepository_GetBatchedDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetBatchedDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) ([]models.BatchedDelivery, error)) *MockWebhookRepository_GetBatchedDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveryPauses provides a mock function with given fields: ctx
+func (_m *MockWebhookRepository) GetDeliveryPauses(ctx context.Context) ([]models.DeliveryPause, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryPauses")
+	}
+
+	var r0 []models.DeliveryPause
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.DeliveryPause, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.DeliveryPause); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeliveryPause)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetDeliveryPauses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveryPauses'
+type MockWebhookRepository_GetDeliveryPauses_Call struct {
+	*mock.Call
+}
+
+// GetDeliveryPauses is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookRepository_Expecter) GetDeliveryPauses(ctx interface{}) *MockWebhookRepository_GetDeliveryPauses_Call {
+	return &MockWebhookRepository_GetDeliveryPauses_Call{Call: _e.mock.On("GetDeliveryPauses", ctx)}
+}
+
+func (_c *MockWebhookRepository_GetDeliveryPauses_Call) Run(run func(ctx context.Context)) *MockWebhookRepository_GetDeliveryPauses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDeliveryPauses_Call) Return(_a0 []models.DeliveryPause, _a1 error) *MockWebhookRepository_GetDeliveryPauses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDeliveryPauses_Call) RunAndReturn(run func(context.Context) ([]models.DeliveryPause, error)) *MockWebhookRepository_GetDeliveryPauses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveryStats provides a mock function with given fields: ctx, tenantID, webhookID, since, bucket
+func (_m *MockWebhookRepository) GetDeliveryStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, bucket time.Duration) (*models.DeliveryStatsResponse, error) {
+	ret := _m.Called(ctx, tenantID, webhookID, since, bucket)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryStats")
+	}
+
+	var r0 *models.DeliveryStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID, *time.Time, time.Duration) (*models.DeliveryStatsResponse, error)); ok {
+		return rf(ctx, tenantID, webhookID, since, bucket)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID, *time.Time, time.Duration) *models.DeliveryStatsResponse); ok {
+		r0 = rf(ctx, tenantID, webhookID, since, bucket)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *uuid.UUID, *time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, tenantID, webhookID, since, bucket)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetDeliveryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveryStats'
+type MockWebhookRepository_GetDeliveryStats_Call struct {
+	*mock.Call
+}
+
+// GetDeliveryStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID *uuid.UUID
+//   - since *time.Time
+//   - bucket time.Duration
+func (_e *MockWebhookRepository_Expecter) GetDeliveryStats(ctx interface{}, tenantID interface{}, webhookID interface{}, since interface{}, bucket interface{}) *MockWebhookRepository_GetDeliveryStats_Call {
+	return &MockWebhookRepository_GetDeliveryStats_Call{Call: _e.mock.On("GetDeliveryStats", ctx, tenantID, webhookID, since, bucket)}
+}
+
+func (_c *MockWebhookRepository_GetDeliveryStats_Call) Run(run func(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, bucket time.Duration)) *MockWebhookRepository_GetDeliveryStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*uuid.UUID), args[3].(*time.Time), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDeliveryStats_Call) Return(_a0 *models.DeliveryStatsResponse, _a1 error) *MockWebhookRepository_GetDeliveryStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDeliveryStats_Call) RunAndReturn(run func(context.Context, string, *uuid.UUID, *time.Time, time.Duration) (*models.DeliveryStatsResponse, error)) *MockWebhookRepository_GetDeliveryStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDueScheduledEvents provides a mock function with given fields: ctx, now, limit
+func (_m *MockWebhookRepository) GetDueScheduledEvents(ctx context.Context, now time.Time, limit int) ([]models.WebhookEvent, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueScheduledEvents")
+	}
+
+	var r0 []models.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]models.WebhookEvent, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []models.WebhookEvent); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetDueScheduledEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueScheduledEvents'
+type MockWebhookRepository_GetDueScheduledEvents_Call struct {
+	*mock.Call
+}
+
+// GetDueScheduledEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) GetDueScheduledEvents(ctx interface{}, now interface{}, limit interface{}) *MockWebhookRepository_GetDueScheduledEvents_Call {
+	return &MockWebhookRepository_GetDueScheduledEvents_Call{Call: _e.mock.On("GetDueScheduledEvents", ctx, now, limit)}
+}
+
+func (_c *MockWebhookRepository_GetDueScheduledEvents_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *MockWebhookRepository_GetDueScheduledEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDueScheduledEvents_Call) Return(_a0 []models.WebhookEvent, _a1 error) *MockWebhookRepository_GetDueScheduledEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDueScheduledEvents_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]models.WebhookEvent, error)) *MockWebhookRepository_GetDueScheduledEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventByID provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) GetEventByID(ctx context.Context, id uuid.UUID) (*models.WebhookEvent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventByID")
+	}
+
+	var r0 *models.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.WebhookEvent, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.WebhookEvent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetEventByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventByID'
+type MockWebhookRepository_GetEventByID_Call struct {
+	*mock.Call
+}
+
+// GetEventByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) GetEventByID(ctx interface{}, id interface{}) *MockWebhookRepository_GetEventByID_Call {
+	return &MockWebhookRepository_GetEventByID_Call{Call: _e.mock.On("GetEventByID", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_GetEventByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_GetEventByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventByID_Call) Return(_a0 *models.WebhookEvent, _a1 error) *MockWebhookRepository_GetEventByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.WebhookEvent, error)) *MockWebhookRepository_GetEventByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventTypeByID provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) GetEventTypeByID(ctx context.Context, id uuid.UUID) (*models.EventType, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventTypeByID")
+	}
+
+	var r0 *models.EventType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.EventType, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.EventType); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EventType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetEventTypeByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventTypeByID'
+type MockWebhookRepository_GetEventTypeByID_Call struct {
+	*mock.Call
+}
+
+// GetEventTypeByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) GetEventTypeByID(ctx interface{}, id interface{}) *MockWebhookRepository_GetEventTypeByID_Call {
+	return &MockWebhookRepository_GetEventTypeByID_Call{Call: _e.mock.On("GetEventTypeByID", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_GetEventTypeByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_GetEventTypeByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventTypeByID_Call) Return(_a0 *models.EventType, _a1 error) *MockWebhookRepository_GetEventTypeByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventTypeByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.EventType, error)) *MockWebhookRepository_GetEventTypeByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventTypeByName provides a mock function with given fields: ctx, tenantID, name
+func (_m *MockWebhookRepository) GetEventTypeByName(ctx context.Context, tenantID string, name string) (*models.EventType, error) {
+	ret := _m.Called(ctx, tenantID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventTypeByName")
+	}
+
+	var r0 *models.EventType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.EventType, error)); ok {
+		return rf(ctx, tenantID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.EventType); ok {
+		r0 = rf(ctx, tenantID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EventType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetEventTypeByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventTypeByName'
+type MockWebhookRepository_GetEventTypeByName_Call struct {
+	*mock.Call
+}
+
+// GetEventTypeByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - name string
+func (_e *MockWebhookRepository_Expecter) GetEventTypeByName(ctx interface{}, tenantID interface{}, name interface{}) *MockWebhookRepository_GetEventTypeByName_Call {
+	return &MockWebhookRepository_GetEventTypeByName_Call{Call: _e.mock.On("GetEventTypeByName", ctx, tenantID, name)}
+}
+
+func (_c *MockWebhookRepository_GetEventTypeByName_Call) Run(run func(ctx context.Context, tenantID string, name string)) *MockWebhookRepository_GetEventTypeByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventTypeByName_Call) Return(_a0 *models.EventType, _a1 error) *MockWebhookRepository_GetEventTypeByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventTypeByName_Call) RunAndReturn(run func(context.Context, string, string) (*models.EventType, error)) *MockWebhookRepository_GetEventTypeByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventTypesByTenant provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookRepository) GetEventTypesByTenant(ctx context.Context, tenantID string) ([]models.EventType, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventTypesByTenant")
+	}
+
+	var r0 []models.EventType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.EventType, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.EventType); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EventType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetEventTypesByTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventTypesByTenant'
+type MockWebhookRepository_GetEventTypesByTenant_Call struct {
+	*mock.Call
+}
+
+// GetEventTypesByTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookRepository_Expecter) GetEventTypesByTenant(ctx interface{}, tenantID interface{}) *MockWebhookRepository_GetEventTypesByTenant_Call {
+	return &MockWebhookRepository_GetEventTypesByTenant_Call{Call: _e.mock.On("GetEventTypesByTenant", ctx, tenantID)}
+}
+
+func (_c *MockWebhookRepository_GetEventTypesByTenant_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookRepository_GetEventTypesByTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventTypesByTenant_Call) Return(_a0 []models.EventType, _a1 error) *MockWebhookRepository_GetEventTypesByTenant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventTypesByTenant_Call) RunAndReturn(run func(context.Context, string) ([]models.EventType, error)) *MockWebhookRepository_GetEventTypesByTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsByStatus provides a mock function with given fields: ctx, status, limit
+func (_m *MockWebhookRepository) GetEventsByStatus(ctx context.Context, status models.WebhookStatus, limit int) ([]models.WebhookEvent, error) {
+	ret := _m.Called(ctx, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsByStatus")
+	}
+
+	var r0 []models.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.WebhookStatus, int) ([]models.WebhookEvent, error)); ok {
+		return rf(ctx, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.WebhookStatus, int) []models.WebhookEvent); ok {
+		r0 = rf(ctx, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.WebhookStatus, int) error); ok {
+		r1 = rf(ctx, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetEventsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsByStatus'
+type MockWebhookRepository_GetEventsByStatus_Call struct {
+	*mock.Call
+}
+
+// GetEventsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status models.WebhookStatus
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) GetEventsByStatus(ctx interface{}, status interface{}, limit interface{}) *MockWebhookRepository_GetEventsByStatus_Call {
+	return &MockWebhookRepository_GetEventsByStatus_Call{Call: _e.mock.On("GetEventsByStatus", ctx, status, limit)}
+}
+
+func (_c *MockWebhookRepository_GetEventsByStatus_Call) Run(run func(ctx context.Context, status models.WebhookStatus, limit int)) *MockWebhookRepository_GetEventsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.WebhookStatus), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventsByStatus_Call) Return(_a0 []models.WebhookEvent, _a1 error) *MockWebhookRepository_GetEventsByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetEventsByStatus_Call) RunAndReturn(run func(context.Context, models.WebhookStatus, int) ([]models.WebhookEvent, error)) *MockWebhookRepository_GetEventsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFailingSubscriptions provides a mock function with given fields: ctx, tenantID, since, failures
+func (_m *MockWebhookRepository) GetFailingSubscriptions(ctx context.Context, tenantID string, since time.Time, failures int) ([]models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, tenantID, since, failures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFailingSubscriptions")
+	}
+
+	var r0 []models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int) ([]models.WebhookSubscription, error)); ok {
+		return rf(ctx, tenantID, since, failures)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int) []models.WebhookSubscription); ok {
+		r0 = rf(ctx, tenantID, since, failures)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, int) error); ok {
+		r1 = rf(ctx, tenantID, since, failures)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetFailingSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFailingSubscriptions'
+type MockWebhookRepository_GetFailingSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetFailingSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - since time.Time
+//   - failures int
+func (_e *MockWebhookRepository_Expecter) GetFailingSubscriptions(ctx interface{}, tenantID interface{}, since interface{}, failures interface{}) *MockWebhookRepository_GetFailingSubscriptions_Call {
+	return &MockWebhookRepository_GetFailingSubscriptions_Call{Call: _e.mock.On("GetFailingSubscriptions", ctx, tenantID, since, failures)}
+}
+
+func (_c *MockWebhookRepository_GetFailingSubscriptions_Call) Run(run func(ctx context.Context, tenantID string, since time.Time, failures int)) *MockWebhookRepository_GetFailingSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetFailingSubscriptions_Call) Return(_a0 []models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetFailingSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetFailingSubscriptions_Call) RunAndReturn(run func(context.Context, string, time.Time, int) ([]models.WebhookSubscription, error)) *MockWebhookRepository_GetFailingSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHeldEvents provides a mock function with given fields: ctx, tenantID, excludeTenants, limit
+func (_m *MockWebhookRepository) GetHeldEvents(ctx context.Context, tenantID string, excludeTenants []string, limit int) ([]models.WebhookEvent, error) {
+	ret := _m.Called(ctx, tenantID, excludeTenants, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeldEvents")
+	}
+
+	var r0 []models.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, int) ([]models.WebhookEvent, error)); ok {
+		return rf(ctx, tenantID, excludeTenants, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, int) []models.WebhookEvent); ok {
+		r0 = rf(ctx, tenantID, excludeTenants, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, int) error); ok {
+		r1 = rf(ctx, tenantID, excludeTenants, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetHeldEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHeldEvents'
+type MockWebhookRepository_GetHeldEvents_Call struct {
+	*mock.Call
+}
+
+// GetHeldEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - excludeTenants []string
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) GetHeldEvents(ctx interface{}, tenantID interface{}, excludeTenants interface{}, limit interface{}) *MockWebhookRepository_GetHeldEvents_Call {
+	return &MockWebhookRepository_GetHeldEvents_Call{Call: _e.mock.On("GetHeldEvents", ctx, tenantID, excludeTenants, limit)}
+}
+
+func (_c *MockWebhookRepository_GetHeldEvents_Call) Run(run func(ctx context.Context, tenantID string, excludeTenants []string, limit int)) *MockWebhookRepository_GetHeldEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetHeldEvents_Call) Return(_a0 []models.WebhookEvent, _a1 error) *MockWebhookRepository_GetHeldEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetHeldEvents_Call) RunAndReturn(run func(context.Context, string, []string, int) ([]models.WebhookEvent, error)) *MockWebhookRepository_GetHeldEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatencySLOStats provides a mock function with given fields: ctx, tenantID, webhookID, since, targetMs
+func (_m *MockWebhookRepository) GetLatencySLOStats(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, targetMs int64) (*models.LatencySLOReport, error) {
+	ret := _m.Called(ctx, tenantID, webhookID, since, targetMs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatencySLOStats")
+	}
+
+	var r0 *models.LatencySLOReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID, *time.Time, int64) (*models.LatencySLOReport, error)); ok {
+		return rf(ctx, tenantID, webhookID, since, targetMs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID, *time.Time, int64) *models.LatencySLOReport); ok {
+		r0 = rf(ctx, tenantID, webhookID, since, targetMs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LatencySLOReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *uuid.UUID, *time.Time, int64) error); ok {
+		r1 = rf(ctx, tenantID, webhookID, since, targetMs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetLatencySLOStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatencySLOStats'
+type MockWebhookRepository_GetLatencySLOStats_Call struct {
+	*mock.Call
+}
+
+// GetLatencySLOStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID *uuid.UUID
+//   - since *time.Time
+//   - targetMs int64
+func (_e *MockWebhookRepository_Expecter) GetLatencySLOStats(ctx interface{}, tenantID interface{}, webhookID interface{}, since interface{}, targetMs interface{}) *MockWebhookRepository_GetLatencySLOStats_Call {
+	return &MockWebhookRepository_GetLatencySLOStats_Call{Call: _e.mock.On("GetLatencySLOStats", ctx, tenantID, webhookID, since, targetMs)}
+}
+
+func (_c *MockWebhookRepository_GetLatencySLOStats_Call) Run(run func(ctx context.Context, tenantID string, webhookID *uuid.UUID, since *time.Time, targetMs int64)) *MockWebhookRepository_GetLatencySLOStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*uuid.UUID), args[3].(*time.Time), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetLatencySLOStats_Call) Return(_a0 *models.LatencySLOReport, _a1 error) *MockWebhookRepository_GetLatencySLOStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetLatencySLOStats_Call) RunAndReturn(run func(context.Context, string, *uuid.UUID, *time.Time, int64) (*models.LatencySLOReport, error)) *MockWebhookRepository_GetLatencySLOStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQueuedDeliveries provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockWebhookRepository) GetQueuedDeliveries(ctx context.Context, subscriptionID uuid.UUID) ([]models.QueuedDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueuedDeliveries")
+	}
+
+	var r0 []models.QueuedDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]models.QueuedDelivery, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []models.QueuedDelivery); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.QueuedDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetQueuedDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueuedDeliveries'
+type MockWebhookRepository_GetQueuedDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetQueuedDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID uuid.UUID
+func (_e *MockWebhookRepository_Expecter) GetQueuedDeliveries(ctx interface{}, subscriptionID interface{}) *MockWebhookRepository_GetQueuedDeliveries_Call {
+	return &MockWebhookRepository_GetQueuedDeliveries_Call{Call: _e.mock.On("GetQueuedDeliveries", ctx, subscriptionID)}
+}
+
+func (_c *MockWebhookRepository_GetQueuedDeliveries_Call) Run(run func(ctx context.Context, subscriptionID uuid.UUID)) *MockWebhookRepository_GetQueuedDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetQueuedDeliveries_Call) Return(_a0 []models.QueuedDelivery, _a1 error) *MockWebhookRepository_GetQueuedDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetQueuedDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]models.QueuedDelivery, error)) *MockWebhookRepository_GetQueuedDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetScheduledEventsDueBy provides a mock function with given fields: ctx, until, after, limit
+func (_m *MockWebhookRepository) GetScheduledEventsDueBy(ctx context.Context, until time.Time, after uuid.UUID, limit int) ([]models.WebhookEvent, error) {
+	ret := _m.Called(ctx, until, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledEventsDueBy")
+	}
+
+	var r0 []models.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uuid.UUID, int) ([]models.WebhookEvent, error)); ok {
+		return rf(ctx, until, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uuid.UUID, int) []models.WebhookEvent); ok {
+		r0 = rf(ctx, until, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, until, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetScheduledEventsDueBy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetScheduledEventsDueBy'
+type MockWebhookRepository_GetScheduledEventsDueBy_Call struct {
+	*mock.Call
+}
+
+// GetScheduledEventsDueBy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - until time.Time
+//   - after uuid.UUID
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) GetScheduledEventsDueBy(ctx interface{}, until interface{}, after interface{}, limit interface{}) *MockWebhookRepository_GetScheduledEventsDueBy_Call {
+	return &MockWebhookRepository_GetScheduledEventsDueBy_Call{Call: _e.mock.On("GetScheduledEventsDueBy", ctx, until, after, limit)}
+}
+
+func (_c *MockWebhookRepository_GetScheduledEventsDueBy_Call) Run(run func(ctx context.Context, until time.Time, after uuid.UUID, limit int)) *MockWebhookRepository_GetScheduledEventsDueBy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(uuid.UUID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetScheduledEventsDueBy_Call) Return(_a0 []models.WebhookEvent, _a1 error) *MockWebhookRepository_GetScheduledEventsDueBy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetScheduledEventsDueBy_Call) RunAndReturn(run func(context.Context, time.Time, uuid.UUID, int) ([]models.WebhookEvent, error)) *MockWebhookRepository_GetScheduledEventsDueBy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStalledOrderedDeliveries provides a mock function with given fields: ctx, staleBefore
+func (_m *MockWebhookRepository) GetStalledOrderedDeliveries(ctx context.Context, staleBefore time.Time) ([]models.OrderedDelivery, error) {
+	ret := _m.Called(ctx, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStalledOrderedDeliveries")
+	}
+
+	var r0 []models.OrderedDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.OrderedDelivery, error)); ok {
+		return rf(ctx, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.OrderedDelivery); ok {
+		r0 = rf(ctx, staleBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OrderedDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetStalledOrderedDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStalledOrderedDeliveries'
+type MockWebhookRepository_GetStalledOrderedDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetStalledOrderedDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - staleBefore time.Time
+func (_e *MockWebhookRepository_Expecter) GetStalledOrderedDeliveries(ctx interface{}, staleBefore interface{}) *MockWebhookRepository_GetStalledOrderedDeliveries_Call {
+	return &MockWebhookRepository_GetStalledOrderedDeliveries_Call{Call: _e.mock.On("GetStalledOrderedDeliveries", ctx, staleBefore)}
+}
+
+func (_c *MockWebhookRepository_GetStalledOrderedDeliveries_Call) Run(run func(ctx context.Context, staleBefore time.Time)) *MockWebhookRepository_GetStalledOrderedDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetStalledOrderedDeliveries_Call) Return(_a0 []models.OrderedDelivery, _a1 error) *MockWebhookRepository_GetStalledOrderedDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetStalledOrderedDeliveries_Call) RunAndReturn(run func(context.Context, time.Time) ([]models.OrderedDelivery, error)) *MockWebhookRepository_GetStalledOrderedDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionByID provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionByID")
+	}
+
+	var r0 *models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.WebhookSubscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.WebhookSubscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetSubscriptionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionByID'
+type MockWebhookRepository_GetSubscriptionByID_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) GetSubscriptionByID(ctx interface{}, id interface{}) *MockWebhookRepository_GetSubscriptionByID_Call {
+	return &MockWebhookRepository_GetSubscriptionByID_Call{Call: _e.mock.On("GetSubscriptionByID", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_GetSubscriptionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionByID_Call) Return(_a0 *models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetSubscriptionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.WebhookSubscription, error)) *MockWebhookRepository_GetSubscriptionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionsByTenant provides a mock function with given fields: ctx, tenantID, filter, page
+func (_m *MockWebhookRepository) GetSubscriptionsByTenant(ctx context.Context, tenantID string, filter models.ListFilter, page repository.Page) ([]models.WebhookSubscription, int64, error) {
+	ret := _m.Called(ctx, tenantID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsByTenant")
+	}
+
+	var r0 []models.WebhookSubscription
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, repository.Page) ([]models.WebhookSubscription, int64, error)); ok {
+		return rf(ctx, tenantID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, repository.Page) []models.WebhookSubscription); ok {
+		r0 = rf(ctx, tenantID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ListFilter, repository.Page) int64); ok {
+		r1 = rf(ctx, tenantID, filter, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.ListFilter, repository.Page) error); ok {
+		r2 = rf(ctx, tenantID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockWebhookRepository_GetSubscriptionsByTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsByTenant'
+type MockWebhookRepository_GetSubscriptionsByTenant_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionsByTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - filter models.ListFilter
+//   - page repository.Page
+func (_e *MockWebhookRepository_Expecter) GetSubscriptionsByTenant(ctx interface{}, tenantID interface{}, filter interface{}, page interface{}) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
+	return &MockWebhookRepository_GetSubscriptionsByTenant_Call{Call: _e.mock.On("GetSubscriptionsByTenant", ctx, tenantID, filter, page)}
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsByTenant_Call) Run(run func(ctx context.Context, tenantID string, filter models.ListFilter, page repository.Page)) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ListFilter), args[3].(repository.Page))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsByTenant_Call) Return(_a0 []models.WebhookSubscription, _a1 int64, _a2 error) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsByTenant_Call) RunAndReturn(run func(context.Context, string, models.ListFilter, repository.Page) ([]models.WebhookSubscription, int64, error)) *MockWebhookRepository_GetSubscriptionsByTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionsPausedUntil provides a mock function with given fields: ctx, until
+func (_m *MockWebhookRepository) GetSubscriptionsPausedUntil(ctx context.Context, until time.Time) ([]models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsPausedUntil")
+	}
+
+	var r0 []models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.WebhookSubscription, error)); ok {
+		return rf(ctx, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.WebhookSubscription); ok {
+		r0 = rf(ctx, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetSubscriptionsPausedUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsPausedUntil'
+type MockWebhookRepository_GetSubscriptionsPausedUntil_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionsPausedUntil is a helper method to define mock.On call
+//   - ctx context.Context
+//   - until time.Time
+func (_e *MockWebhookRepository_Expecter) GetSubscriptionsPausedUntil(ctx interface{}, until interface{}) *MockWebhookRepository_GetSubscriptionsPausedUntil_Call {
+	return &MockWebhookRepository_GetSubscriptionsPausedUntil_Call{Call: _e.mock.On("GetSubscriptionsPausedUntil", ctx, until)}
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsPausedUntil_Call) Run(run func(ctx context.Context, until time.Time)) *MockWebhookRepository_GetSubscriptionsPausedUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsPausedUntil_Call) Return(_a0 []models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetSubscriptionsPausedUntil_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsPausedUntil_Call) RunAndReturn(run func(context.Context, time.Time) ([]models.WebhookSubscription, error)) *MockWebhookRepository_GetSubscriptionsPausedUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionsWithDueBatches provides a mock function with given fields: ctx, now
+func (_m *MockWebhookRepository) GetSubscriptionsWithDueBatches(ctx context.Context, now time.Time) ([]models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsWithDueBatches")
+	}
+
+	var r0 []models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.WebhookSubscription, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.WebhookSubscription); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetSubscriptionsWithDueBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsWithDueBatches'
+type MockWebhookRepository_GetSubscriptionsWithDueBatches_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionsWithDueBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockWebhookRepository_Expecter) GetSubscriptionsWithDueBatches(ctx interface{}, now interface{}) *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call {
+	return &MockWebhookRepository_GetSubscriptionsWithDueBatches_Call{Call: _e.mock.On("GetSubscriptionsWithDueBatches", ctx, now)}
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call) Run(run func(ctx context.Context, now time.Time)) *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call) Return(_a0 []models.WebhookSubscription, _a1 error) *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call) RunAndReturn(run func(context.Context, time.Time) ([]models.WebhookSubscription, error)) *MockWebhookRepository_GetSubscriptionsWithDueBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseHeldEvent provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) ReleaseHeldEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseHeldEvent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_ReleaseHeldEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseHeldEvent'
+type MockWebhookRepository_ReleaseHeldEvent_Call struct {
+	*mock.Call
+}
+
+// ReleaseHeldEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) ReleaseHeldEvent(ctx interface{}, id interface{}) *MockWebhookRepository_ReleaseHeldEvent_Call {
+	return &MockWebhookRepository_ReleaseHeldEvent_Call{Call: _e.mock.On("ReleaseHeldEvent", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_ReleaseHeldEvent_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_ReleaseHeldEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ReleaseHeldEvent_Call) Return(_a0 bool, _a1 error) *MockWebhookRepository_ReleaseHeldEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ReleaseHeldEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockWebhookRepository_ReleaseHeldEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseOrderedDelivery provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) ReleaseOrderedDelivery(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseOrderedDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_ReleaseOrderedDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseOrderedDelivery'
+type MockWebhookRepository_ReleaseOrderedDelivery_Call struct {
+	*mock.Call
+}
+
+// ReleaseOrderedDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) ReleaseOrderedDelivery(ctx interface{}, id interface{}) *MockWebhookRepository_ReleaseOrderedDelivery_Call {
+	return &MockWebhookRepository_ReleaseOrderedDelivery_Call{Call: _e.mock.On("ReleaseOrderedDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_ReleaseOrderedDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_ReleaseOrderedDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ReleaseOrderedDelivery_Call) Return(_a0 error) *MockWebhookRepository_ReleaseOrderedDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ReleaseOrderedDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookRepository_ReleaseOrderedDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSubscription provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) RestoreSubscription(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_RestoreSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSubscription'
+type MockWebhookRepository_RestoreSubscription_Call struct {
+	*mock.Call
+}
+
+// RestoreSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) RestoreSubscription(ctx interface{}, id interface{}) *MockWebhookRepository_RestoreSubscription_Call {
+	return &MockWebhookRepository_RestoreSubscription_Call{Call: _e.mock.On("RestoreSubscription", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_RestoreSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_RestoreSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_RestoreSubscription_Call) Return(_a0 error) *MockWebhookRepository_RestoreSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_RestoreSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookRepository_RestoreSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDeliveryPause provides a mock function with given fields: ctx, pause
+func (_m *MockWebhookRepository) SaveDeliveryPause(ctx context.Context, pause *models.DeliveryPause) error {
+	ret := _m.Called(ctx, pause)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeliveryPause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryPause) error); ok {
+		r0 = rf(ctx, pause)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_SaveDeliveryPause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDeliveryPause'
+type MockWebhookRepository_SaveDeliveryPause_Call struct {
+	*mock.Call
+}
+
+// SaveDeliveryPause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pause *models.DeliveryPause
+func (_e *MockWebhookRepository_Expecter) SaveDeliveryPause(ctx interface{}, pause interface{}) *MockWebhookRepository_SaveDeliveryPause_Call {
+	return &MockWebhookRepository_SaveDeliveryPause_Call{Call: _e.mock.On("SaveDeliveryPause", ctx, pause)}
+}
+
+func (_c *MockWebhookRepository_SaveDeliveryPause_Call) Run(run func(ctx context.Context, pause *models.DeliveryPause)) *MockWebhookRepository_SaveDeliveryPause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeliveryPause))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_SaveDeliveryPause_Call) Return(_a0 error) *MockWebhookRepository_SaveDeliveryPause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_SaveDeliveryPause_Call) RunAndReturn(run func(context.Context, *models.DeliveryPause) error) *MockWebhookRepository_SaveDeliveryPause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSubscriptionPause provides a mock function with given fields: ctx, id, pausedUntil
+func (_m *MockWebhookRepository) SetSubscriptionPause(ctx context.Context, id uuid.UUID, pausedUntil *time.Time) error {
+	ret := _m.Called(ctx, id, pausedUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSubscriptionPause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time) error); ok {
+		r0 = rf(ctx, id, pausedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_SetSubscriptionPause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSubscriptionPause'
+type MockWebhookRepository_SetSubscriptionPause_Call struct {
+	*mock.Call
+}
+
+// SetSubscriptionPause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - pausedUntil *time.Time
+func (_e *MockWebhookRepository_Expecter) SetSubscriptionPause(ctx interface{}, id interface{}, pausedUntil interface{}) *MockWebhookRepository_SetSubscriptionPause_Call {
+	return &MockWebhookRepository_SetSubscriptionPause_Call{Call: _e.mock.On("SetSubscriptionPause", ctx, id, pausedUntil)}
+}
+
+func (_c *MockWebhookRepository_SetSubscriptionPause_Call) Run(run func(ctx context.Context, id uuid.UUID, pausedUntil *time.Time)) *MockWebhookRepository_SetSubscriptionPause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_SetSubscriptionPause_Call) Return(_a0 error) *MockWebhookRepository_SetSubscriptionPause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_SetSubscriptionPause_Call) RunAndReturn(run func(context.Context, uuid.UUID, *time.Time) error) *MockWebhookRepository_SetSubscriptionPause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEvent provides a mock function with given fields: ctx, event
+func (_m *MockWebhookRepository) UpdateEvent(ctx context.Context, event *models.WebhookEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_UpdateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEvent'
+type MockWebhookRepository_UpdateEvent_Call struct {
+	*mock.Call
+}
+
+// UpdateEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *models.WebhookEvent
+func (_e *MockWebhookRepository_Expecter) UpdateEvent(ctx interface{}, event interface{}) *MockWebhookRepository_UpdateEvent_Call {
+	return &MockWebhookRepository_UpdateEvent_Call{Call: _e.mock.On("UpdateEvent", ctx, event)}
+}
+
+func (_c *MockWebhookRepository_UpdateEvent_Call) Run(run func(ctx context.Context, event *models.WebhookEvent)) *MockWebhookRepository_UpdateEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookEvent))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_UpdateEvent_Call) Return(_a0 error) *MockWebhookRepository_UpdateEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_UpdateEvent_Call) RunAndReturn(run func(context.Context, *models.WebhookEvent) error) *MockWebhookRepository_UpdateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEventType provides a mock function with given fields: ctx, eventType
+func (_m *MockWebhookRepository) UpdateEventType(ctx context.Context, eventType *models.EventType) error {
+	ret := _m.Called(ctx, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEventType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EventType) error); ok {
+		r0 = rf(ctx, eventType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_UpdateEventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEventType'
+type MockWebhookRepository_UpdateEventType_Call struct {
+	*mock.Call
+}
+
+// UpdateEventType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventType *models.EventType
+func (_e *MockWebhookRepository_Expecter) UpdateEventType(ctx interface{}, eventType interface{}) *MockWebhookRepository_UpdateEventType_Call {
+	return &MockWebhookRepository_UpdateEventType_Call{Call: _e.mock.On("UpdateEventType", ctx, eventType)}
+}
+
+func (_c *MockWebhookRepository_UpdateEventType_Call) Run(run func(ctx context.Context, eventType *models.EventType)) *MockWebhookRepository_UpdateEventType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EventType))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_UpdateEventType_Call) Return(_a0 error) *MockWebhookRepository_UpdateEventType_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockWebhookRepository_UpdateEvent_Call) RunAndReturn(run func(*models.WebhookEvent) error) *MockWebhookRepository_UpdateEvent_Call {
+func (_c *MockWebhookRepository_UpdateEventType_Call) RunAndReturn(run func(context.Context, *models.EventType) error) *MockWebhookRepository_UpdateEventType_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateSubscription provides a mock function with given fields: subscription
-func (_m *MockWebhookRepository) UpdateSubscription(subscription *models.WebhookSubscription) error {
-	ret := _m.Called(subscription)
+// UpdateSubscription provides a mock function with given fields: ctx, subscription
+func (_m *MockWebhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ret := _m.Called(ctx, subscription)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateSubscription")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.WebhookSubscription) error); ok {
-		r0 = rf(subscription)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookSubscription) error); ok {
+		r0 = rf(ctx, subscription)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -531,14 +2720,15 @@ type MockWebhookRepository_UpdateSubscription_Call struct {
 }
 
 // UpdateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
 //   - subscription *models.WebhookSubscription
-func (_e *MockWebhookRepository_Expecter) UpdateSubscription(subscription interface{}) *MockWebhookRepository_UpdateSubscription_Call {
-	return &MockWebhookRepository_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", subscription)}
+func (_e *MockWebhookRepository_Expecter) UpdateSubscription(ctx interface{}, subscription interface{}) *MockWebhookRepository_UpdateSubscription_Call {
+	return &MockWebhookRepository_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", ctx, subscription)}
 }
 
-func (_c *MockWebhookRepository_UpdateSubscription_Call) Run(run func(subscription *models.WebhookSubscription)) *MockWebhookRepository_UpdateSubscription_Call {
+func (_c *MockWebhookRepository_UpdateSubscription_Call) Run(run func(ctx context.Context, subscription *models.WebhookSubscription)) *MockWebhookRepository_UpdateSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.WebhookSubscription))
+		run(args[0].(context.Context), args[1].(*models.WebhookSubscription))
 	})
 	return _c
 }
@@ -548,7 +2738,7 @@ func (_c *MockWebhookRepository_UpdateSubscription_Call) Return(_a0 error) *Mock
 	return _c
 }
 
-func (_c *MockWebhookRepository_UpdateSubscription_Call) RunAndReturn(run func(*models.WebhookSubscription) error) *MockWebhookRepository_UpdateSubscription_Call {
+func (_c *MockWebhookRepository_UpdateSubscription_Call) RunAndReturn(run func(context.Context, *models.WebhookSubscription) error) *MockWebhookRepository_UpdateSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	context "context"
+	time "time"
+
 	models "github.com/sakibcoolz/loki-suite/internal/models"
+	repository "github.com/sakibcoolz/loki-suite/internal/repository"
 	service "github.com/sakibcoolz/loki-suite/internal/service"
 	mock "github.com/stretchr/testify/mock"
 
@@ -23,29 +27,29 @@ func (_m *MockWebhookService) EXPECT() *MockWebhookService_Expecter {
 	return &MockWebhookService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateWebhook provides a mock function with given fields: req
-func (_m *MockWebhookService) GenerateWebhook(req *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error) {
-	ret := _m.Called(req)
+// ApplyConfig provides a mock function with given fields: ctx, manifest, opts
+func (_m *MockWebhookService) ApplyConfig(ctx context.Context, manifest *models.ConfigManifest, opts models.ConfigApplyOptions) (*models.ConfigApplyResponse, error) {
+	ret := _m.Called(ctx, manifest, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateWebhook")
+		panic("no return value specified for ApplyConfig")
 	}
 
-	var r0 *models.GenerateWebhookResponse
+	var r0 *models.ConfigApplyResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ConfigManifest, models.ConfigApplyOptions) (*models.ConfigApplyResponse, error)); ok {
+		return rf(ctx, manifest, opts)
 	}
-	if rf, ok := ret.Get(0).(func(*models.GenerateWebhookRequest) *models.GenerateWebhookResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ConfigManifest, models.ConfigApplyOptions) *models.ConfigApplyResponse); ok {
+		r0 = rf(ctx, manifest, opts)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.GenerateWebhookResponse)
+			r0 = ret.Get(0).(*models.ConfigApplyResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*models.GenerateWebhookRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ConfigManifest, models.ConfigApplyOptions) error); ok {
+		r1 = rf(ctx, manifest, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,57 +57,233 @@ func (_m *MockWebhookService) GenerateWebhook(req *models.GenerateWebhookRequest
 	return r0, r1
 }
 
-// MockWebhookService_GenerateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateWebhook'
-type MockWebhookService_GenerateWebhook_Call struct {
+// MockWebhookService_ApplyConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyConfig'
+type MockWebhookService_ApplyConfig_Call struct {
 	*mock.Call
 }
 
-// GenerateWebhook is a helper method to define mock.On call
-//   - req *models.GenerateWebhookRequest
-func (_e *MockWebhookService_Expecter) GenerateWebhook(req interface{}) *MockWebhookService_GenerateWebhook_Call {
-	return &MockWebhookService_GenerateWebhook_Call{Call: _e.mock.On("GenerateWebhook", req)}
+// ApplyConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - manifest *models.ConfigManifest
+//   - opts models.ConfigApplyOptions
+func (_e *MockWebhookService_Expecter) ApplyConfig(ctx interface{}, manifest interface{}, opts interface{}) *MockWebhookService_ApplyConfig_Call {
+	return &MockWebhookService_ApplyConfig_Call{Call: _e.mock.On("ApplyConfig", ctx, manifest, opts)}
 }
 
-func (_c *MockWebhookService_GenerateWebhook_Call) Run(run func(req *models.GenerateWebhookRequest)) *MockWebhookService_GenerateWebhook_Call {
+func (_c *MockWebhookService_ApplyConfig_Call) Run(run func(ctx context.Context, manifest *models.ConfigManifest, opts models.ConfigApplyOptions)) *MockWebhookService_ApplyConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.GenerateWebhookRequest))
+		run(args[0].(context.Context), args[1].(*models.ConfigManifest), args[2].(models.ConfigApplyOptions))
 	})
 	return _c
 }
 
-func (_c *MockWebhookService_GenerateWebhook_Call) Return(_a0 *models.GenerateWebhookResponse, _a1 error) *MockWebhookService_GenerateWebhook_Call {
+func (_c *MockWebhookService_ApplyConfig_Call) Return(_a0 *models.ConfigApplyResponse, _a1 error) *MockWebhookService_ApplyConfig_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookService_GenerateWebhook_Call) RunAndReturn(run func(*models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error)) *MockWebhookService_GenerateWebhook_Call {
+func (_c *MockWebhookService_ApplyConfig_Call) RunAndReturn(run func(context.Context, *models.ConfigManifest, models.ConfigApplyOptions) (*models.ConfigApplyResponse, error)) *MockWebhookService_ApplyConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListWebhooks provides a mock function with given fields: tenantID, page, limit
-func (_m *MockWebhookService) ListWebhooks(tenantID string, page int, limit int) (*models.WebhookListResponse, error) {
-	ret := _m.Called(tenantID, page, limit)
+// ConfigureDeliveryConcurrency provides a mock function with given fields: maxEvents, reservedHigh
+func (_m *MockWebhookService) ConfigureDeliveryConcurrency(maxEvents int, reservedHigh int) {
+	_m.Called(maxEvents, reservedHigh)
+}
+
+// MockWebhookService_ConfigureDeliveryConcurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigureDeliveryConcurrency'
+type MockWebhookService_ConfigureDeliveryConcurrency_Call struct {
+	*mock.Call
+}
+
+// ConfigureDeliveryConcurrency is a helper method to define mock.On call
+//   - maxEvents int
+//   - reservedHigh int
+func (_e *MockWebhookService_Expecter) ConfigureDeliveryConcurrency(maxEvents interface{}, reservedHigh interface{}) *MockWebhookService_ConfigureDeliveryConcurrency_Call {
+	return &MockWebhookService_ConfigureDeliveryConcurrency_Call{Call: _e.mock.On("ConfigureDeliveryConcurrency", maxEvents, reservedHigh)}
+}
+
+func (_c *MockWebhookService_ConfigureDeliveryConcurrency_Call) Run(run func(maxEvents int, reservedHigh int)) *MockWebhookService_ConfigureDeliveryConcurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ConfigureDeliveryConcurrency_Call) Return() *MockWebhookService_ConfigureDeliveryConcurrency_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_ConfigureDeliveryConcurrency_Call) RunAndReturn(run func(int, int)) *MockWebhookService_ConfigureDeliveryConcurrency_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ConfigureNetwork provides a mock function with given fields: egressIPs, receiveAllowlist
+func (_m *MockWebhookService) ConfigureNetwork(egressIPs []string, receiveAllowlist []string) error {
+	ret := _m.Called(egressIPs, receiveAllowlist)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListWebhooks")
+		panic("no return value specified for ConfigureNetwork")
 	}
 
-	var r0 *models.WebhookListResponse
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, []string) error); ok {
+		r0 = rf(egressIPs, receiveAllowlist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookService_ConfigureNetwork_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigureNetwork'
+type MockWebhookService_ConfigureNetwork_Call struct {
+	*mock.Call
+}
+
+// ConfigureNetwork is a helper method to define mock.On call
+//   - egressIPs []string
+//   - receiveAllowlist []string
+func (_e *MockWebhookService_Expecter) ConfigureNetwork(egressIPs interface{}, receiveAllowlist interface{}) *MockWebhookService_ConfigureNetwork_Call {
+	return &MockWebhookService_ConfigureNetwork_Call{Call: _e.mock.On("ConfigureNetwork", egressIPs, receiveAllowlist)}
+}
+
+func (_c *MockWebhookService_ConfigureNetwork_Call) Run(run func(egressIPs []string, receiveAllowlist []string)) *MockWebhookService_ConfigureNetwork_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ConfigureNetwork_Call) Return(_a0 error) *MockWebhookService_ConfigureNetwork_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_ConfigureNetwork_Call) RunAndReturn(run func([]string, []string) error) *MockWebhookService_ConfigureNetwork_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEventType provides a mock function with given fields: ctx, id
+func (_m *MockWebhookService) DeleteEventType(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEventType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookService_DeleteEventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEventType'
+type MockWebhookService_DeleteEventType_Call struct {
+	*mock.Call
+}
+
+// DeleteEventType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookService_Expecter) DeleteEventType(ctx interface{}, id interface{}) *MockWebhookService_DeleteEventType_Call {
+	return &MockWebhookService_DeleteEventType_Call{Call: _e.mock.On("DeleteEventType", ctx, id)}
+}
+
+func (_c *MockWebhookService_DeleteEventType_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookService_DeleteEventType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteEventType_Call) Return(_a0 error) *MockWebhookService_DeleteEventType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteEventType_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockWebhookService_DeleteEventType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, tenantID, webhookID
+func (_m *MockWebhookService) DeleteWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) error {
+	ret := _m.Called(ctx, tenantID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, tenantID, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookService_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockWebhookService_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+func (_e *MockWebhookService_Expecter) DeleteWebhook(ctx interface{}, tenantID interface{}, webhookID interface{}) *MockWebhookService_DeleteWebhook_Call {
+	return &MockWebhookService_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, tenantID, webhookID)}
+}
+
+func (_c *MockWebhookService_DeleteWebhook_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID)) *MockWebhookService_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteWebhook_Call) Return(_a0 error) *MockWebhookService_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) error) *MockWebhookService_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableFailingSubscriptions provides a mock function with given fields: ctx
+func (_m *MockWebhookService) DisableFailingSubscriptions(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableFailingSubscriptions")
+	}
+
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int) (*models.WebhookListResponse, error)); ok {
-		return rf(tenantID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) *models.WebhookListResponse); ok {
-		r0 = rf(tenantID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.WebhookListResponse)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = rf(tenantID, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,59 +291,55 @@ func (_m *MockWebhookService) ListWebhooks(tenantID string, page int, limit int)
 	return r0, r1
 }
 
-// MockWebhookService_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
-type MockWebhookService_ListWebhooks_Call struct {
+// MockWebhookService_DisableFailingSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableFailingSubscriptions'
+type MockWebhookService_DisableFailingSubscriptions_Call struct {
 	*mock.Call
 }
 
-// ListWebhooks is a helper method to define mock.On call
-//   - tenantID string
-//   - page int
-//   - limit int
-func (_e *MockWebhookService_Expecter) ListWebhooks(tenantID interface{}, page interface{}, limit interface{}) *MockWebhookService_ListWebhooks_Call {
-	return &MockWebhookService_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", tenantID, page, limit)}
+// DisableFailingSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) DisableFailingSubscriptions(ctx interface{}) *MockWebhookService_DisableFailingSubscriptions_Call {
+	return &MockWebhookService_DisableFailingSubscriptions_Call{Call: _e.mock.On("DisableFailingSubscriptions", ctx)}
 }
 
-func (_c *MockWebhookService_ListWebhooks_Call) Run(run func(tenantID string, page int, limit int)) *MockWebhookService_ListWebhooks_Call {
+func (_c *MockWebhookService_DisableFailingSubscriptions_Call) Run(run func(ctx context.Context)) *MockWebhookService_DisableFailingSubscriptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockWebhookService_ListWebhooks_Call) Return(_a0 *models.WebhookListResponse, _a1 error) *MockWebhookService_ListWebhooks_Call {
+func (_c *MockWebhookService_DisableFailingSubscriptions_Call) Return(_a0 int, _a1 error) *MockWebhookService_DisableFailingSubscriptions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookService_ListWebhooks_Call) RunAndReturn(run func(string, int, int) (*models.WebhookListResponse, error)) *MockWebhookService_ListWebhooks_Call {
+func (_c *MockWebhookService_DisableFailingSubscriptions_Call) RunAndReturn(run func(context.Context) (int, error)) *MockWebhookService_DisableFailingSubscriptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SendEvent provides a mock function with given fields: req
-func (_m *MockWebhookService) SendEvent(req *models.SendEventRequest) (*models.EventProcessingResult, error) {
-	ret := _m.Called(req)
+// DispatchScheduledEvents provides a mock function with given fields: ctx
+func (_m *MockWebhookService) DispatchScheduledEvents(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendEvent")
+		panic("no return value specified for DispatchScheduledEvents")
 	}
 
-	var r0 *models.EventProcessingResult
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*models.SendEventRequest) (*models.EventProcessingResult, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(*models.SendEventRequest) *models.EventProcessingResult); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.EventProcessingResult)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(*models.SendEventRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -171,90 +347,114 @@ func (_m *MockWebhookService) SendEvent(req *models.SendEventRequest) (*models.E
 	return r0, r1
 }
 
-// MockWebhookService_SendEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendEvent'
-type MockWebhookService_SendEvent_Call struct {
+// MockWebhookService_DispatchScheduledEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DispatchScheduledEvents'
+type MockWebhookService_DispatchScheduledEvents_Call struct {
 	*mock.Call
 }
 
-// SendEvent is a helper method to define mock.On call
-//   - req *models.SendEventRequest
-func (_e *MockWebhookService_Expecter) SendEvent(req interface{}) *MockWebhookService_SendEvent_Call {
-	return &MockWebhookService_SendEvent_Call{Call: _e.mock.On("SendEvent", req)}
+// DispatchScheduledEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) DispatchScheduledEvents(ctx interface{}) *MockWebhookService_DispatchScheduledEvents_Call {
+	return &MockWebhookService_DispatchScheduledEvents_Call{Call: _e.mock.On("DispatchScheduledEvents", ctx)}
 }
 
-func (_c *MockWebhookService_SendEvent_Call) Run(run func(req *models.SendEventRequest)) *MockWebhookService_SendEvent_Call {
+func (_c *MockWebhookService_DispatchScheduledEvents_Call) Run(run func(ctx context.Context)) *MockWebhookService_DispatchScheduledEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.SendEventRequest))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockWebhookService_SendEvent_Call) Return(_a0 *models.EventProcessingResult, _a1 error) *MockWebhookService_SendEvent_Call {
+func (_c *MockWebhookService_DispatchScheduledEvents_Call) Return(_a0 int, _a1 error) *MockWebhookService_DispatchScheduledEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookService_SendEvent_Call) RunAndReturn(run func(*models.SendEventRequest) (*models.EventProcessingResult, error)) *MockWebhookService_SendEvent_Call {
+func (_c *MockWebhookService_DispatchScheduledEvents_Call) RunAndReturn(run func(context.Context) (int, error)) *MockWebhookService_DispatchScheduledEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetChainService provides a mock function with given fields: chainService
-func (_m *MockWebhookService) SetChainService(chainService service.ExecutionChainService) {
-	_m.Called(chainService)
+// DrainHeldEvents provides a mock function with given fields: ctx, elapsed
+func (_m *MockWebhookService) DrainHeldEvents(ctx context.Context, elapsed time.Duration) (int, error) {
+	ret := _m.Called(ctx, elapsed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrainHeldEvents")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int, error)); ok {
+		return rf(ctx, elapsed)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int); ok {
+		r0 = rf(ctx, elapsed)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, elapsed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockWebhookService_SetChainService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetChainService'
-type MockWebhookService_SetChainService_Call struct {
+// MockWebhookService_DrainHeldEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DrainHeldEvents'
+type MockWebhookService_DrainHeldEvents_Call struct {
 	*mock.Call
 }
 
-// SetChainService is a helper method to define mock.On call
-//   - chainService service.ExecutionChainService
-func (_e *MockWebhookService_Expecter) SetChainService(chainService interface{}) *MockWebhookService_SetChainService_Call {
-	return &MockWebhookService_SetChainService_Call{Call: _e.mock.On("SetChainService", chainService)}
+// DrainHeldEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - elapsed time.Duration
+func (_e *MockWebhookService_Expecter) DrainHeldEvents(ctx interface{}, elapsed interface{}) *MockWebhookService_DrainHeldEvents_Call {
+	return &MockWebhookService_DrainHeldEvents_Call{Call: _e.mock.On("DrainHeldEvents", ctx, elapsed)}
 }
 
-func (_c *MockWebhookService_SetChainService_Call) Run(run func(chainService service.ExecutionChainService)) *MockWebhookService_SetChainService_Call {
+func (_c *MockWebhookService_DrainHeldEvents_Call) Run(run func(ctx context.Context, elapsed time.Duration)) *MockWebhookService_DrainHeldEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(service.ExecutionChainService))
+		run(args[0].(context.Context), args[1].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockWebhookService_SetChainService_Call) Return() *MockWebhookService_SetChainService_Call {
-	_c.Call.Return()
+func (_c *MockWebhookService_DrainHeldEvents_Call) Return(_a0 int, _a1 error) *MockWebhookService_DrainHeldEvents_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookService_SetChainService_Call) RunAndReturn(run func(service.ExecutionChainService)) *MockWebhookService_SetChainService_Call {
-	_c.Run(run)
+func (_c *MockWebhookService_DrainHeldEvents_Call) RunAndReturn(run func(context.Context, time.Duration) (int, error)) *MockWebhookService_DrainHeldEvents_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// SubscribeWebhook provides a mock function with given fields: req
-func (_m *MockWebhookService) SubscribeWebhook(req *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error) {
-	ret := _m.Called(req)
+// EnableWebhook provides a mock function with given fields: ctx, tenantID, webhookID
+func (_m *MockWebhookService) EnableWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, tenantID, webhookID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SubscribeWebhook")
+		panic("no return value specified for EnableWebhook")
 	}
 
-	var r0 *models.GenerateWebhookResponse
+	var r0 *models.WebhookSubscription
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.WebhookSubscription, error)); ok {
+		return rf(ctx, tenantID, webhookID)
 	}
-	if rf, ok := ret.Get(0).(func(*models.SubscribeWebhookRequest) *models.GenerateWebhookResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.WebhookSubscription); ok {
+		r0 = rf(ctx, tenantID, webhookID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.GenerateWebhookResponse)
+			r0 = ret.Get(0).(*models.WebhookSubscription)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*models.SubscribeWebhookRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, webhookID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -262,45 +462,2668 @@ func (_m *MockWebhookService) SubscribeWebhook(req *models.SubscribeWebhookReque
 	return r0, r1
 }
 
-// MockWebhookService_SubscribeWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeWebhook'
-type MockWebhookService_SubscribeWebhook_Call struct {
+// MockWebhookService_EnableWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableWebhook'
+type MockWebhookService_EnableWebhook_Call struct {
 	*mock.Call
 }
 
-// SubscribeWebhook is a helper method to define mock.On call
-//   - req *models.SubscribeWebhookRequest
-func (_e *MockWebhookService_Expecter) SubscribeWebhook(req interface{}) *MockWebhookService_SubscribeWebhook_Call {
-	return &MockWebhookService_SubscribeWebhook_Call{Call: _e.mock.On("SubscribeWebhook", req)}
+// EnableWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+func (_e *MockWebhookService_Expecter) EnableWebhook(ctx interface{}, tenantID interface{}, webhookID interface{}) *MockWebhookService_EnableWebhook_Call {
+	return &MockWebhookService_EnableWebhook_Call{Call: _e.mock.On("EnableWebhook", ctx, tenantID, webhookID)}
 }
 
-func (_c *MockWebhookService_SubscribeWebhook_Call) Run(run func(req *models.SubscribeWebhookRequest)) *MockWebhookService_SubscribeWebhook_Call {
+func (_c *MockWebhookService_EnableWebhook_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID)) *MockWebhookService_EnableWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.SubscribeWebhookRequest))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockWebhookService_SubscribeWebhook_Call) Return(_a0 *models.GenerateWebhookResponse, _a1 error) *MockWebhookService_SubscribeWebhook_Call {
+func (_c *MockWebhookService_EnableWebhook_Call) Return(_a0 *models.WebhookSubscription, _a1 error) *MockWebhookService_EnableWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_EnableWebhook_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.WebhookSubscription, error)) *MockWebhookService_EnableWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportWebhooks provides a mock function with given fields: ctx, tenantID, includeSecrets
+func (_m *MockWebhookService) ExportWebhooks(ctx context.Context, tenantID string, includeSecrets bool) (*models.WebhookExport, error) {
+	ret := _m.Called(ctx, tenantID, includeSecrets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportWebhooks")
+	}
+
+	var r0 *models.WebhookExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*models.WebhookExport, error)); ok {
+		return rf(ctx, tenantID, includeSecrets)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *models.WebhookExport); ok {
+		r0 = rf(ctx, tenantID, includeSecrets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, tenantID, includeSecrets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ExportWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportWebhooks'
+type MockWebhookService_ExportWebhooks_Call struct {
+	*mock.Call
+}
+
+// ExportWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - includeSecrets bool
+func (_e *MockWebhookService_Expecter) ExportWebhooks(ctx interface{}, tenantID interface{}, includeSecrets interface{}) *MockWebhookService_ExportWebhooks_Call {
+	return &MockWebhookService_ExportWebhooks_Call{Call: _e.mock.On("ExportWebhooks", ctx, tenantID, includeSecrets)}
+}
+
+func (_c *MockWebhookService_ExportWebhooks_Call) Run(run func(ctx context.Context, tenantID string, includeSecrets bool)) *MockWebhookService_ExportWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ExportWebhooks_Call) Return(_a0 *models.WebhookExport, _a1 error) *MockWebhookService_ExportWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ExportWebhooks_Call) RunAndReturn(run func(context.Context, string, bool) (*models.WebhookExport, error)) *MockWebhookService_ExportWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushDeliveryBatches provides a mock function with given fields: ctx
+func (_m *MockWebhookService) FlushDeliveryBatches(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushDeliveryBatches")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_FlushDeliveryBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushDeliveryBatches'
+type MockWebhookService_FlushDeliveryBatches_Call struct {
+	*mock.Call
+}
+
+// FlushDeliveryBatches is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) FlushDeliveryBatches(ctx interface{}) *MockWebhookService_FlushDeliveryBatches_Call {
+	return &MockWebhookService_FlushDeliveryBatches_Call{Call: _e.mock.On("FlushDeliveryBatches", ctx)}
+}
+
+func (_c *MockWebhookService_FlushDeliveryBatches_Call) Run(run func(ctx context.Context)) *MockWebhookService_FlushDeliveryBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_FlushDeliveryBatches_Call) Return(_a0 int, _a1 error) *MockWebhookService_FlushDeliveryBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_FlushDeliveryBatches_Call) RunAndReturn(run func(context.Context) (int, error)) *MockWebhookService_FlushDeliveryBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateWebhook provides a mock function with given fields: ctx, req
+func (_m *MockWebhookService) GenerateWebhook(ctx context.Context, req *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateWebhook")
+	}
+
+	var r0 *models.GenerateWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateWebhookRequest) *models.GenerateWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GenerateWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GenerateWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GenerateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateWebhook'
+type MockWebhookService_GenerateWebhook_Call struct {
+	*mock.Call
+}
+
+// GenerateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.GenerateWebhookRequest
+func (_e *MockWebhookService_Expecter) GenerateWebhook(ctx interface{}, req interface{}) *MockWebhookService_GenerateWebhook_Call {
+	return &MockWebhookService_GenerateWebhook_Call{Call: _e.mock.On("GenerateWebhook", ctx, req)}
+}
+
+func (_c *MockWebhookService_GenerateWebhook_Call) Run(run func(ctx context.Context, req *models.GenerateWebhookRequest)) *MockWebhookService_GenerateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.GenerateWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GenerateWebhook_Call) Return(_a0 *models.GenerateWebhookResponse, _a1 error) *MockWebhookService_GenerateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GenerateWebhook_Call) RunAndReturn(run func(context.Context, *models.GenerateWebhookRequest) (*models.GenerateWebhookResponse, error)) *MockWebhookService_GenerateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveryPause provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookService) GetDeliveryPause(ctx context.Context, tenantID string) (*models.DeliveryPauseResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryPause")
+	}
+
+	var r0 *models.DeliveryPauseResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.DeliveryPauseResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DeliveryPauseResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryPauseResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetDeliveryPause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveryPause'
+type MockWebhookService_GetDeliveryPause_Call struct {
+	*mock.Call
+}
+
+// GetDeliveryPause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookService_Expecter) GetDeliveryPause(ctx interface{}, tenantID interface{}) *MockWebhookService_GetDeliveryPause_Call {
+	return &MockWebhookService_GetDeliveryPause_Call{Call: _e.mock.On("GetDeliveryPause", ctx, tenantID)}
+}
+
+func (_c *MockWebhookService_GetDeliveryPause_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookService_GetDeliveryPause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetDeliveryPause_Call) Return(_a0 *models.DeliveryPauseResponse, _a1 error) *MockWebhookService_GetDeliveryPause_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetDeliveryPause_Call) RunAndReturn(run func(context.Context, string) (*models.DeliveryPauseResponse, error)) *MockWebhookService_GetDeliveryPause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEgressIPs provides a mock function with no fields
+func (_m *MockWebhookService) GetEgressIPs() *models.EgressIPsResponse {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEgressIPs")
+	}
+
+	var r0 *models.EgressIPsResponse
+	if rf, ok := ret.Get(0).(func() *models.EgressIPsResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EgressIPsResponse)
+		}
+	}
+
+	return r0
+}
+
+// MockWebhookService_GetEgressIPs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEgressIPs'
+type MockWebhookService_GetEgressIPs_Call struct {
+	*mock.Call
+}
+
+// GetEgressIPs is a helper method to define mock.On call
+func (_e *MockWebhookService_Expecter) GetEgressIPs() *MockWebhookService_GetEgressIPs_Call {
+	return &MockWebhookService_GetEgressIPs_Call{Call: _e.mock.On("GetEgressIPs")}
+}
+
+func (_c *MockWebhookService_GetEgressIPs_Call) Run(run func()) *MockWebhookService_GetEgressIPs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetEgressIPs_Call) Return(_a0 *models.EgressIPsResponse) *MockWebhookService_GetEgressIPs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_GetEgressIPs_Call) RunAndReturn(run func() *models.EgressIPsResponse) *MockWebhookService_GetEgressIPs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSigningKeySet provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookService) GetSigningKeySet(ctx context.Context, tenantID string) (*models.SigningKeySet, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSigningKeySet")
+	}
+
+	var r0 *models.SigningKeySet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.SigningKeySet, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.SigningKeySet); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SigningKeySet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetSigningKeySet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSigningKeySet'
+type MockWebhookService_GetSigningKeySet_Call struct {
+	*mock.Call
+}
+
+// GetSigningKeySet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookService_Expecter) GetSigningKeySet(ctx interface{}, tenantID interface{}) *MockWebhookService_GetSigningKeySet_Call {
+	return &MockWebhookService_GetSigningKeySet_Call{Call: _e.mock.On("GetSigningKeySet", ctx, tenantID)}
+}
+
+func (_c *MockWebhookService_GetSigningKeySet_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookService_GetSigningKeySet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetSigningKeySet_Call) Return(_a0 *models.SigningKeySet, _a1 error) *MockWebhookService_GetSigningKeySet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetSigningKeySet_Call) RunAndReturn(run func(context.Context, string) (*models.SigningKeySet, error)) *MockWebhookService_GetSigningKeySet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantDeliveryStats provides a mock function with given fields: ctx, tenantID, window
+func (_m *MockWebhookService) GetTenantDeliveryStats(ctx context.Context, tenantID string, window models.StatsWindow) (*models.DeliveryStatsResponse, error) {
+	ret := _m.Called(ctx, tenantID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantDeliveryStats")
+	}
+
+	var r0 *models.DeliveryStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.StatsWindow) (*models.DeliveryStatsResponse, error)); ok {
+		return rf(ctx, tenantID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.StatsWindow) *models.DeliveryStatsResponse); ok {
+		r0 = rf(ctx, tenantID, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.StatsWindow) error); ok {
+		r1 = rf(ctx, tenantID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetTenantDeliveryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantDeliveryStats'
+type MockWebhookService_GetTenantDeliveryStats_Call struct {
+	*mock.Call
+}
+
+// GetTenantDeliveryStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - window models.StatsWindow
+func (_e *MockWebhookService_Expecter) GetTenantDeliveryStats(ctx interface{}, tenantID interface{}, window interface{}) *MockWebhookService_GetTenantDeliveryStats_Call {
+	return &MockWebhookService_GetTenantDeliveryStats_Call{Call: _e.mock.On("GetTenantDeliveryStats", ctx, tenantID, window)}
+}
+
+func (_c *MockWebhookService_GetTenantDeliveryStats_Call) Run(run func(ctx context.Context, tenantID string, window models.StatsWindow)) *MockWebhookService_GetTenantDeliveryStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.StatsWindow))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantDeliveryStats_Call) Return(_a0 *models.DeliveryStatsResponse, _a1 error) *MockWebhookService_GetTenantDeliveryStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantDeliveryStats_Call) RunAndReturn(run func(context.Context, string, models.StatsWindow) (*models.DeliveryStatsResponse, error)) *MockWebhookService_GetTenantDeliveryStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantLatencySLO provides a mock function with given fields: ctx, tenantID, window
+func (_m *MockWebhookService) GetTenantLatencySLO(ctx context.Context, tenantID string, window models.StatsWindow) (*models.LatencySLOReport, error) {
+	ret := _m.Called(ctx, tenantID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantLatencySLO")
+	}
+
+	var r0 *models.LatencySLOReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.StatsWindow) (*models.LatencySLOReport, error)); ok {
+		return rf(ctx, tenantID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.StatsWindow) *models.LatencySLOReport); ok {
+		r0 = rf(ctx, tenantID, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LatencySLOReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.StatsWindow) error); ok {
+		r1 = rf(ctx, tenantID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetTenantLatencySLO_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantLatencySLO'
+type MockWebhookService_GetTenantLatencySLO_Call struct {
+	*mock.Call
+}
+
+// GetTenantLatencySLO is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - window models.StatsWindow
+func (_e *MockWebhookService_Expecter) GetTenantLatencySLO(ctx interface{}, tenantID interface{}, window interface{}) *MockWebhookService_GetTenantLatencySLO_Call {
+	return &MockWebhookService_GetTenantLatencySLO_Call{Call: _e.mock.On("GetTenantLatencySLO", ctx, tenantID, window)}
+}
+
+func (_c *MockWebhookService_GetTenantLatencySLO_Call) Run(run func(ctx context.Context, tenantID string, window models.StatsWindow)) *MockWebhookService_GetTenantLatencySLO_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.StatsWindow))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantLatencySLO_Call) Return(_a0 *models.LatencySLOReport, _a1 error) *MockWebhookService_GetTenantLatencySLO_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantLatencySLO_Call) RunAndReturn(run func(context.Context, string, models.StatsWindow) (*models.LatencySLOReport, error)) *MockWebhookService_GetTenantLatencySLO_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantPayloadValidation provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookService) GetTenantPayloadValidation(ctx context.Context, tenantID string) (*models.TenantPayloadValidationResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantPayloadValidation")
+	}
+
+	var r0 *models.TenantPayloadValidationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TenantPayloadValidationResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TenantPayloadValidationResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantPayloadValidationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetTenantPayloadValidation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantPayloadValidation'
+type MockWebhookService_GetTenantPayloadValidation_Call struct {
+	*mock.Call
+}
+
+// GetTenantPayloadValidation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookService_Expecter) GetTenantPayloadValidation(ctx interface{}, tenantID interface{}) *MockWebhookService_GetTenantPayloadValidation_Call {
+	return &MockWebhookService_GetTenantPayloadValidation_Call{Call: _e.mock.On("GetTenantPayloadValidation", ctx, tenantID)}
+}
+
+func (_c *MockWebhookService_GetTenantPayloadValidation_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookService_GetTenantPayloadValidation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantPayloadValidation_Call) Return(_a0 *models.TenantPayloadValidationResponse, _a1 error) *MockWebhookService_GetTenantPayloadValidation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantPayloadValidation_Call) RunAndReturn(run func(context.Context, string) (*models.TenantPayloadValidationResponse, error)) *MockWebhookService_GetTenantPayloadValidation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantSigning provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookService) GetTenantSigning(ctx context.Context, tenantID string) (*models.TenantSigningResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantSigning")
+	}
+
+	var r0 *models.TenantSigningResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TenantSigningResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TenantSigningResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantSigningResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetTenantSigning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantSigning'
+type MockWebhookService_GetTenantSigning_Call struct {
+	*mock.Call
+}
+
+// GetTenantSigning is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookService_Expecter) GetTenantSigning(ctx interface{}, tenantID interface{}) *MockWebhookService_GetTenantSigning_Call {
+	return &MockWebhookService_GetTenantSigning_Call{Call: _e.mock.On("GetTenantSigning", ctx, tenantID)}
+}
+
+func (_c *MockWebhookService_GetTenantSigning_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookService_GetTenantSigning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantSigning_Call) Return(_a0 *models.TenantSigningResponse, _a1 error) *MockWebhookService_GetTenantSigning_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantSigning_Call) RunAndReturn(run func(context.Context, string) (*models.TenantSigningResponse, error)) *MockWebhookService_GetTenantSigning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantSigningHeaders provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookService) GetTenantSigningHeaders(ctx context.Context, tenantID string) (*models.TenantSigningHeadersResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantSigningHeaders")
+	}
+
+	var r0 *models.TenantSigningHeadersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TenantSigningHeadersResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TenantSigningHeadersResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantSigningHeadersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetTenantSigningHeaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantSigningHeaders'
+type MockWebhookService_GetTenantSigningHeaders_Call struct {
+	*mock.Call
+}
+
+// GetTenantSigningHeaders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookService_Expecter) GetTenantSigningHeaders(ctx interface{}, tenantID interface{}) *MockWebhookService_GetTenantSigningHeaders_Call {
+	return &MockWebhookService_GetTenantSigningHeaders_Call{Call: _e.mock.On("GetTenantSigningHeaders", ctx, tenantID)}
+}
+
+func (_c *MockWebhookService_GetTenantSigningHeaders_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookService_GetTenantSigningHeaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantSigningHeaders_Call) Return(_a0 *models.TenantSigningHeadersResponse, _a1 error) *MockWebhookService_GetTenantSigningHeaders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetTenantSigningHeaders_Call) RunAndReturn(run func(context.Context, string) (*models.TenantSigningHeadersResponse, error)) *MockWebhookService_GetTenantSigningHeaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: ctx, tenantID, webhookID
+func (_m *MockWebhookService) GetWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, tenantID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 *models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.WebhookSubscription, error)); ok {
+		return rf(ctx, tenantID, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.WebhookSubscription); ok {
+		r0 = rf(ctx, tenantID, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type MockWebhookService_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+func (_e *MockWebhookService_Expecter) GetWebhook(ctx interface{}, tenantID interface{}, webhookID interface{}) *MockWebhookService_GetWebhook_Call {
+	return &MockWebhookService_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, tenantID, webhookID)}
+}
+
+func (_c *MockWebhookService_GetWebhook_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID)) *MockWebhookService_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhook_Call) Return(_a0 *models.WebhookSubscription, _a1 error) *MockWebhookService_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhook_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.WebhookSubscription, error)) *MockWebhookService_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDeliveryStats provides a mock function with given fields: ctx, tenantID, webhookID, window
+func (_m *MockWebhookService) GetWebhookDeliveryStats(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow) (*models.DeliveryStatsResponse, error) {
+	ret := _m.Called(ctx, tenantID, webhookID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveryStats")
+	}
+
+	var r0 *models.DeliveryStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.StatsWindow) (*models.DeliveryStatsResponse, error)); ok {
+		return rf(ctx, tenantID, webhookID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.StatsWindow) *models.DeliveryStatsResponse); ok {
+		r0 = rf(ctx, tenantID, webhookID, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, models.StatsWindow) error); ok {
+		r1 = rf(ctx, tenantID, webhookID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetWebhookDeliveryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveryStats'
+type MockWebhookService_GetWebhookDeliveryStats_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveryStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+//   - window models.StatsWindow
+func (_e *MockWebhookService_Expecter) GetWebhookDeliveryStats(ctx interface{}, tenantID interface{}, webhookID interface{}, window interface{}) *MockWebhookService_GetWebhookDeliveryStats_Call {
+	return &MockWebhookService_GetWebhookDeliveryStats_Call{Call: _e.mock.On("GetWebhookDeliveryStats", ctx, tenantID, webhookID, window)}
+}
+
+func (_c *MockWebhookService_GetWebhookDeliveryStats_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow)) *MockWebhookService_GetWebhookDeliveryStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(models.StatsWindow))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookDeliveryStats_Call) Return(_a0 *models.DeliveryStatsResponse, _a1 error) *MockWebhookService_GetWebhookDeliveryStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookDeliveryStats_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, models.StatsWindow) (*models.DeliveryStatsResponse, error)) *MockWebhookService_GetWebhookDeliveryStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookHistory provides a mock function with given fields: ctx, tenantID, webhookID
+func (_m *MockWebhookService) GetWebhookHistory(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.ConfigHistoryResponse, error) {
+	ret := _m.Called(ctx, tenantID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookHistory")
+	}
+
+	var r0 *models.ConfigHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.ConfigHistoryResponse, error)); ok {
+		return rf(ctx, tenantID, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.ConfigHistoryResponse); ok {
+		r0 = rf(ctx, tenantID, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetWebhookHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookHistory'
+type MockWebhookService_GetWebhookHistory_Call struct {
+	*mock.Call
+}
+
+// GetWebhookHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+func (_e *MockWebhookService_Expecter) GetWebhookHistory(ctx interface{}, tenantID interface{}, webhookID interface{}) *MockWebhookService_GetWebhookHistory_Call {
+	return &MockWebhookService_GetWebhookHistory_Call{Call: _e.mock.On("GetWebhookHistory", ctx, tenantID, webhookID)}
+}
+
+func (_c *MockWebhookService_GetWebhookHistory_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID)) *MockWebhookService_GetWebhookHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookHistory_Call) Return(_a0 *models.ConfigHistoryResponse, _a1 error) *MockWebhookService_GetWebhookHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookHistory_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.ConfigHistoryResponse, error)) *MockWebhookService_GetWebhookHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookLatencySLO provides a mock function with given fields: ctx, tenantID, webhookID, window
+func (_m *MockWebhookService) GetWebhookLatencySLO(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow) (*models.LatencySLOReport, error) {
+	ret := _m.Called(ctx, tenantID, webhookID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookLatencySLO")
+	}
+
+	var r0 *models.LatencySLOReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.StatsWindow) (*models.LatencySLOReport, error)); ok {
+		return rf(ctx, tenantID, webhookID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, models.StatsWindow) *models.LatencySLOReport); ok {
+		r0 = rf(ctx, tenantID, webhookID, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LatencySLOReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, models.StatsWindow) error); ok {
+		r1 = rf(ctx, tenantID, webhookID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetWebhookLatencySLO_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookLatencySLO'
+type MockWebhookService_GetWebhookLatencySLO_Call struct {
+	*mock.Call
+}
+
+// GetWebhookLatencySLO is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+//   - window models.StatsWindow
+func (_e *MockWebhookService_Expecter) GetWebhookLatencySLO(ctx interface{}, tenantID interface{}, webhookID interface{}, window interface{}) *MockWebhookService_GetWebhookLatencySLO_Call {
+	return &MockWebhookService_GetWebhookLatencySLO_Call{Call: _e.mock.On("GetWebhookLatencySLO", ctx, tenantID, webhookID, window)}
+}
+
+func (_c *MockWebhookService_GetWebhookLatencySLO_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID, window models.StatsWindow)) *MockWebhookService_GetWebhookLatencySLO_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(models.StatsWindow))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookLatencySLO_Call) Return(_a0 *models.LatencySLOReport, _a1 error) *MockWebhookService_GetWebhookLatencySLO_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookLatencySLO_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, models.StatsWindow) (*models.LatencySLOReport, error)) *MockWebhookService_GetWebhookLatencySLO_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportWebhooks provides a mock function with given fields: ctx, export, opts
+func (_m *MockWebhookService) ImportWebhooks(ctx context.Context, export *models.WebhookExport, opts models.WebhookImportOptions) (*models.WebhookImportResponse, error) {
+	ret := _m.Called(ctx, export, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportWebhooks")
+	}
+
+	var r0 *models.WebhookImportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookExport, models.WebhookImportOptions) (*models.WebhookImportResponse, error)); ok {
+		return rf(ctx, export, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookExport, models.WebhookImportOptions) *models.WebhookImportResponse); ok {
+		r0 = rf(ctx, export, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookImportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.WebhookExport, models.WebhookImportOptions) error); ok {
+		r1 = rf(ctx, export, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ImportWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportWebhooks'
+type MockWebhookService_ImportWebhooks_Call struct {
+	*mock.Call
+}
+
+// ImportWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - export *models.WebhookExport
+//   - opts models.WebhookImportOptions
+func (_e *MockWebhookService_Expecter) ImportWebhooks(ctx interface{}, export interface{}, opts interface{}) *MockWebhookService_ImportWebhooks_Call {
+	return &MockWebhookService_ImportWebhooks_Call{Call: _e.mock.On("ImportWebhooks", ctx, export, opts)}
+}
+
+func (_c *MockWebhookService_ImportWebhooks_Call) Run(run func(ctx context.Context, export *models.WebhookExport, opts models.WebhookImportOptions)) *MockWebhookService_ImportWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookExport), args[2].(models.WebhookImportOptions))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ImportWebhooks_Call) Return(_a0 *models.WebhookImportResponse, _a1 error) *MockWebhookService_ImportWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ImportWebhooks_Call) RunAndReturn(run func(context.Context, *models.WebhookExport, models.WebhookImportOptions) (*models.WebhookImportResponse, error)) *MockWebhookService_ImportWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEventTypes provides a mock function with given fields: ctx, tenantID
+func (_m *MockWebhookService) ListEventTypes(ctx context.Context, tenantID string) (*models.EventTypeListResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEventTypes")
+	}
+
+	var r0 *models.EventTypeListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EventTypeListResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EventTypeListResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EventTypeListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ListEventTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEventTypes'
+type MockWebhookService_ListEventTypes_Call struct {
+	*mock.Call
+}
+
+// ListEventTypes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockWebhookService_Expecter) ListEventTypes(ctx interface{}, tenantID interface{}) *MockWebhookService_ListEventTypes_Call {
+	return &MockWebhookService_ListEventTypes_Call{Call: _e.mock.On("ListEventTypes", ctx, tenantID)}
+}
+
+func (_c *MockWebhookService_ListEventTypes_Call) Run(run func(ctx context.Context, tenantID string)) *MockWebhookService_ListEventTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListEventTypes_Call) Return(_a0 *models.EventTypeListResponse, _a1 error) *MockWebhookService_ListEventTypes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ListEventTypes_Call) RunAndReturn(run func(context.Context, string) (*models.EventTypeListResponse, error)) *MockWebhookService_ListEventTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, tenantID, filter, page
+func (_m *MockWebhookService) ListWebhooks(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage) (*models.WebhookListResponse, error) {
+	ret := _m.Called(ctx, tenantID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 *models.WebhookListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, models.ListPage) (*models.WebhookListResponse, error)); ok {
+		return rf(ctx, tenantID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListFilter, models.ListPage) *models.WebhookListResponse); ok {
+		r0 = rf(ctx, tenantID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ListFilter, models.ListPage) error); ok {
+		r1 = rf(ctx, tenantID, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type MockWebhookService_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - filter models.ListFilter
+//   - page models.ListPage
+func (_e *MockWebhookService_Expecter) ListWebhooks(ctx interface{}, tenantID interface{}, filter interface{}, page interface{}) *MockWebhookService_ListWebhooks_Call {
+	return &MockWebhookService_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx, tenantID, filter, page)}
+}
+
+func (_c *MockWebhookService_ListWebhooks_Call) Run(run func(ctx context.Context, tenantID string, filter models.ListFilter, page models.ListPage)) *MockWebhookService_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ListFilter), args[3].(models.ListPage))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListWebhooks_Call) Return(_a0 *models.WebhookListResponse, _a1 error) *MockWebhookService_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ListWebhooks_Call) RunAndReturn(run func(context.Context, string, models.ListFilter, models.ListPage) (*models.WebhookListResponse, error)) *MockWebhookService_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseDeliveries provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockWebhookService) PauseDeliveries(ctx context.Context, tenantID string, req *models.PauseDeliveriesRequest) (*models.DeliveryPauseResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseDeliveries")
+	}
+
+	var r0 *models.DeliveryPauseResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PauseDeliveriesRequest) (*models.DeliveryPauseResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PauseDeliveriesRequest) *models.DeliveryPauseResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryPauseResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PauseDeliveriesRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_PauseDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseDeliveries'
+type MockWebhookService_PauseDeliveries_Call struct {
+	*mock.Call
+}
+
+// PauseDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - req *models.PauseDeliveriesRequest
+func (_e *MockWebhookService_Expecter) PauseDeliveries(ctx interface{}, tenantID interface{}, req interface{}) *MockWebhookService_PauseDeliveries_Call {
+	return &MockWebhookService_PauseDeliveries_Call{Call: _e.mock.On("PauseDeliveries", ctx, tenantID, req)}
+}
+
+func (_c *MockWebhookService_PauseDeliveries_Call) Run(run func(ctx context.Context, tenantID string, req *models.PauseDeliveriesRequest)) *MockWebhookService_PauseDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PauseDeliveriesRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_PauseDeliveries_Call) Return(_a0 *models.DeliveryPauseResponse, _a1 error) *MockWebhookService_PauseDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_PauseDeliveries_Call) RunAndReturn(run func(context.Context, string, *models.PauseDeliveriesRequest) (*models.DeliveryPauseResponse, error)) *MockWebhookService_PauseDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterEventType provides a mock function with given fields: ctx, req
+func (_m *MockWebhookService) RegisterEventType(ctx context.Context, req *models.RegisterEventTypeRequest) (*models.EventType, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterEventType")
+	}
+
+	var r0 *models.EventType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RegisterEventTypeRequest) (*models.EventType, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RegisterEventTypeRequest) *models.EventType); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EventType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.RegisterEventTypeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_RegisterEventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterEventType'
+type MockWebhookService_RegisterEventType_Call struct {
+	*mock.Call
+}
+
+// RegisterEventType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.RegisterEventTypeRequest
+func (_e *MockWebhookService_Expecter) RegisterEventType(ctx interface{}, req interface{}) *MockWebhookService_RegisterEventType_Call {
+	return &MockWebhookService_RegisterEventType_Call{Call: _e.mock.On("RegisterEventType", ctx, req)}
+}
+
+func (_c *MockWebhookService_RegisterEventType_Call) Run(run func(ctx context.Context, req *models.RegisterEventTypeRequest)) *MockWebhookService_RegisterEventType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RegisterEventTypeRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RegisterEventType_Call) Return(_a0 *models.EventType, _a1 error) *MockWebhookService_RegisterEventType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_RegisterEventType_Call) RunAndReturn(run func(context.Context, *models.RegisterEventTypeRequest) (*models.EventType, error)) *MockWebhookService_RegisterEventType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseOrderedDeliveries provides a mock function with given fields: ctx
+func (_m *MockWebhookService) ReleaseOrderedDeliveries(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseOrderedDeliveries")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ReleaseOrderedDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseOrderedDeliveries'
+type MockWebhookService_ReleaseOrderedDeliveries_Call struct {
+	*mock.Call
+}
+
+// ReleaseOrderedDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) ReleaseOrderedDeliveries(ctx interface{}) *MockWebhookService_ReleaseOrderedDeliveries_Call {
+	return &MockWebhookService_ReleaseOrderedDeliveries_Call{Call: _e.mock.On("ReleaseOrderedDeliveries", ctx)}
+}
+
+func (_c *MockWebhookService_ReleaseOrderedDeliveries_Call) Run(run func(ctx context.Context)) *MockWebhookService_ReleaseOrderedDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ReleaseOrderedDeliveries_Call) Return(_a0 int, _a1 error) *MockWebhookService_ReleaseOrderedDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ReleaseOrderedDeliveries_Call) RunAndReturn(run func(context.Context) (int, error)) *MockWebhookService_ReleaseOrderedDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleasePausedDeliveries provides a mock function with given fields: ctx
+func (_m *MockWebhookService) ReleasePausedDeliveries(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleasePausedDeliveries")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ReleasePausedDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleasePausedDeliveries'
+type MockWebhookService_ReleasePausedDeliveries_Call struct {
+	*mock.Call
+}
+
+// ReleasePausedDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) ReleasePausedDeliveries(ctx interface{}) *MockWebhookService_ReleasePausedDeliveries_Call {
+	return &MockWebhookService_ReleasePausedDeliveries_Call{Call: _e.mock.On("ReleasePausedDeliveries", ctx)}
+}
+
+func (_c *MockWebhookService_ReleasePausedDeliveries_Call) Run(run func(ctx context.Context)) *MockWebhookService_ReleasePausedDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ReleasePausedDeliveries_Call) Return(_a0 int, _a1 error) *MockWebhookService_ReleasePausedDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ReleasePausedDeliveries_Call) RunAndReturn(run func(context.Context) (int, error)) *MockWebhookService_ReleasePausedDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreWebhook provides a mock function with given fields: ctx, tenantID, webhookID
+func (_m *MockWebhookService) RestoreWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID) (*models.WebhookSubscription, error) {
+	ret := _m.Called(ctx, tenantID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreWebhook")
+	}
+
+	var r0 *models.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*models.WebhookSubscription, error)); ok {
+		return rf(ctx, tenantID, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.WebhookSubscription); ok {
+		r0 = rf(ctx, tenantID, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_RestoreWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreWebhook'
+type MockWebhookService_RestoreWebhook_Call struct {
+	*mock.Call
+}
+
+// RestoreWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+func (_e *MockWebhookService_Expecter) RestoreWebhook(ctx interface{}, tenantID interface{}, webhookID interface{}) *MockWebhookService_RestoreWebhook_Call {
+	return &MockWebhookService_RestoreWebhook_Call{Call: _e.mock.On("RestoreWebhook", ctx, tenantID, webhookID)}
+}
+
+func (_c *MockWebhookService_RestoreWebhook_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID)) *MockWebhookService_RestoreWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RestoreWebhook_Call) Return(_a0 *models.WebhookSubscription, _a1 error) *MockWebhookService_RestoreWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_RestoreWebhook_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*models.WebhookSubscription, error)) *MockWebhookService_RestoreWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeDeliveries provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockWebhookService) ResumeDeliveries(ctx context.Context, tenantID string, req *models.ResumeDeliveriesRequest) (*models.DeliveryPauseResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeDeliveries")
+	}
+
+	var r0 *models.DeliveryPauseResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ResumeDeliveriesRequest) (*models.DeliveryPauseResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ResumeDeliveriesRequest) *models.DeliveryPauseResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryPauseResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ResumeDeliveriesRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ResumeDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeDeliveries'
+type MockWebhookService_ResumeDeliveries_Call struct {
+	*mock.Call
+}
+
+// ResumeDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - req *models.ResumeDeliveriesRequest
+func (_e *MockWebhookService_Expecter) ResumeDeliveries(ctx interface{}, tenantID interface{}, req interface{}) *MockWebhookService_ResumeDeliveries_Call {
+	return &MockWebhookService_ResumeDeliveries_Call{Call: _e.mock.On("ResumeDeliveries", ctx, tenantID, req)}
+}
+
+func (_c *MockWebhookService_ResumeDeliveries_Call) Run(run func(ctx context.Context, tenantID string, req *models.ResumeDeliveriesRequest)) *MockWebhookService_ResumeDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ResumeDeliveriesRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ResumeDeliveries_Call) Return(_a0 *models.DeliveryPauseResponse, _a1 error) *MockWebhookService_ResumeDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ResumeDeliveries_Call) RunAndReturn(run func(context.Context, string, *models.ResumeDeliveriesRequest) (*models.DeliveryPauseResponse, error)) *MockWebhookService_ResumeDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunAutoDisabler provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunAutoDisabler(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunAutoDisabler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunAutoDisabler'
+type MockWebhookService_RunAutoDisabler_Call struct {
+	*mock.Call
+}
+
+// RunAutoDisabler is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunAutoDisabler(ctx interface{}, interval interface{}) *MockWebhookService_RunAutoDisabler_Call {
+	return &MockWebhookService_RunAutoDisabler_Call{Call: _e.mock.On("RunAutoDisabler", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunAutoDisabler_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunAutoDisabler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunAutoDisabler_Call) Return() *MockWebhookService_RunAutoDisabler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunAutoDisabler_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunAutoDisabler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunBatchFlusher provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunBatchFlusher(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunBatchFlusher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunBatchFlusher'
+type MockWebhookService_RunBatchFlusher_Call struct {
+	*mock.Call
+}
+
+// RunBatchFlusher is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunBatchFlusher(ctx interface{}, interval interface{}) *MockWebhookService_RunBatchFlusher_Call {
+	return &MockWebhookService_RunBatchFlusher_Call{Call: _e.mock.On("RunBatchFlusher", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunBatchFlusher_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunBatchFlusher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunBatchFlusher_Call) Return() *MockWebhookService_RunBatchFlusher_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunBatchFlusher_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunBatchFlusher_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunDeliveryDrain provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunDeliveryDrain(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunDeliveryDrain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunDeliveryDrain'
+type MockWebhookService_RunDeliveryDrain_Call struct {
+	*mock.Call
+}
+
+// RunDeliveryDrain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunDeliveryDrain(ctx interface{}, interval interface{}) *MockWebhookService_RunDeliveryDrain_Call {
+	return &MockWebhookService_RunDeliveryDrain_Call{Call: _e.mock.On("RunDeliveryDrain", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunDeliveryDrain_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunDeliveryDrain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunDeliveryDrain_Call) Return() *MockWebhookService_RunDeliveryDrain_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunDeliveryDrain_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunDeliveryDrain_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunEventQueue provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunEventQueue(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunEventQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunEventQueue'
+type MockWebhookService_RunEventQueue_Call struct {
+	*mock.Call
+}
+
+// RunEventQueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunEventQueue(ctx interface{}, interval interface{}) *MockWebhookService_RunEventQueue_Call {
+	return &MockWebhookService_RunEventQueue_Call{Call: _e.mock.On("RunEventQueue", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunEventQueue_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunEventQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunEventQueue_Call) Return() *MockWebhookService_RunEventQueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunEventQueue_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunEventQueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunEventScheduler provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunEventScheduler(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunEventScheduler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunEventScheduler'
+type MockWebhookService_RunEventScheduler_Call struct {
+	*mock.Call
+}
+
+// RunEventScheduler is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunEventScheduler(ctx interface{}, interval interface{}) *MockWebhookService_RunEventScheduler_Call {
+	return &MockWebhookService_RunEventScheduler_Call{Call: _e.mock.On("RunEventScheduler", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunEventScheduler_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunEventScheduler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunEventScheduler_Call) Return() *MockWebhookService_RunEventScheduler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunEventScheduler_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunEventScheduler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunOrderedDeliveryReleaser provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunOrderedDeliveryReleaser(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunOrderedDeliveryReleaser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunOrderedDeliveryReleaser'
+type MockWebhookService_RunOrderedDeliveryReleaser_Call struct {
+	*mock.Call
+}
+
+// RunOrderedDeliveryReleaser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunOrderedDeliveryReleaser(ctx interface{}, interval interface{}) *MockWebhookService_RunOrderedDeliveryReleaser_Call {
+	return &MockWebhookService_RunOrderedDeliveryReleaser_Call{Call: _e.mock.On("RunOrderedDeliveryReleaser", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunOrderedDeliveryReleaser_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunOrderedDeliveryReleaser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunOrderedDeliveryReleaser_Call) Return() *MockWebhookService_RunOrderedDeliveryReleaser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunOrderedDeliveryReleaser_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunOrderedDeliveryReleaser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunPauseReleaser provides a mock function with given fields: ctx, interval
+func (_m *MockWebhookService) RunPauseReleaser(ctx context.Context, interval time.Duration) {
+	_m.Called(ctx, interval)
+}
+
+// MockWebhookService_RunPauseReleaser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunPauseReleaser'
+type MockWebhookService_RunPauseReleaser_Call struct {
+	*mock.Call
+}
+
+// RunPauseReleaser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+func (_e *MockWebhookService_Expecter) RunPauseReleaser(ctx interface{}, interval interface{}) *MockWebhookService_RunPauseReleaser_Call {
+	return &MockWebhookService_RunPauseReleaser_Call{Call: _e.mock.On("RunPauseReleaser", ctx, interval)}
+}
+
+func (_c *MockWebhookService_RunPauseReleaser_Call) Run(run func(ctx context.Context, interval time.Duration)) *MockWebhookService_RunPauseReleaser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RunPauseReleaser_Call) Return() *MockWebhookService_RunPauseReleaser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_RunPauseReleaser_Call) RunAndReturn(run func(context.Context, time.Duration)) *MockWebhookService_RunPauseReleaser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SendEvent provides a mock function with given fields: ctx, req
+func (_m *MockWebhookService) SendEvent(ctx context.Context, req *models.SendEventRequest) (*models.EventProcessingResult, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendEvent")
+	}
+
+	var r0 *models.EventProcessingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SendEventRequest) (*models.EventProcessingResult, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SendEventRequest) *models.EventProcessingResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EventProcessingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SendEventRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_SendEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendEvent'
+type MockWebhookService_SendEvent_Call struct {
+	*mock.Call
+}
+
+// SendEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.SendEventRequest
+func (_e *MockWebhookService_Expecter) SendEvent(ctx interface{}, req interface{}) *MockWebhookService_SendEvent_Call {
+	return &MockWebhookService_SendEvent_Call{Call: _e.mock.On("SendEvent", ctx, req)}
+}
+
+func (_c *MockWebhookService_SendEvent_Call) Run(run func(ctx context.Context, req *models.SendEventRequest)) *MockWebhookService_SendEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SendEventRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SendEvent_Call) Return(_a0 *models.EventProcessingResult, _a1 error) *MockWebhookService_SendEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_SendEvent_Call) RunAndReturn(run func(context.Context, *models.SendEventRequest) (*models.EventProcessingResult, error)) *MockWebhookService_SendEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendNotification provides a mock function with given fields: ctx, webhookID, event, payload
+func (_m *MockWebhookService) SendNotification(ctx context.Context, webhookID uuid.UUID, event string, payload map[string]interface{}) error {
+	ret := _m.Called(ctx, webhookID, event, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, map[string]interface{}) error); ok {
+		r0 = rf(ctx, webhookID, event, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookService_SendNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendNotification'
+type MockWebhookService_SendNotification_Call struct {
+	*mock.Call
+}
+
+// SendNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID uuid.UUID
+//   - event string
+//   - payload map[string]interface{}
+func (_e *MockWebhookService_Expecter) SendNotification(ctx interface{}, webhookID interface{}, event interface{}, payload interface{}) *MockWebhookService_SendNotification_Call {
+	return &MockWebhookService_SendNotification_Call{Call: _e.mock.On("SendNotification", ctx, webhookID, event, payload)}
+}
+
+func (_c *MockWebhookService_SendNotification_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, event string, payload map[string]interface{})) *MockWebhookService_SendNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SendNotification_Call) Return(_a0 error) *MockWebhookService_SendNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_SendNotification_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, map[string]interface{}) error) *MockWebhookService_SendNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAMQPPublisher provides a mock function with given fields: publisher
+func (_m *MockWebhookService) SetAMQPPublisher(publisher service.AMQPPublisher) {
+	_m.Called(publisher)
+}
+
+// MockWebhookService_SetAMQPPublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAMQPPublisher'
+type MockWebhookService_SetAMQPPublisher_Call struct {
+	*mock.Call
+}
+
+// SetAMQPPublisher is a helper method to define mock.On call
+//   - publisher service.AMQPPublisher
+func (_e *MockWebhookService_Expecter) SetAMQPPublisher(publisher interface{}) *MockWebhookService_SetAMQPPublisher_Call {
+	return &MockWebhookService_SetAMQPPublisher_Call{Call: _e.mock.On("SetAMQPPublisher", publisher)}
+}
+
+func (_c *MockWebhookService_SetAMQPPublisher_Call) Run(run func(publisher service.AMQPPublisher)) *MockWebhookService_SetAMQPPublisher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.AMQPPublisher))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetAMQPPublisher_Call) Return() *MockWebhookService_SetAMQPPublisher_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetAMQPPublisher_Call) RunAndReturn(run func(service.AMQPPublisher)) *MockWebhookService_SetAMQPPublisher_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetChainService provides a mock function with given fields: chainService
+func (_m *MockWebhookService) SetChainService(chainService service.ExecutionChainService) {
+	_m.Called(chainService)
+}
+
+// MockWebhookService_SetChainService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetChainService'
+type MockWebhookService_SetChainService_Call struct {
+	*mock.Call
+}
+
+// SetChainService is a helper method to define mock.On call
+//   - chainService service.ExecutionChainService
+func (_e *MockWebhookService_Expecter) SetChainService(chainService interface{}) *MockWebhookService_SetChainService_Call {
+	return &MockWebhookService_SetChainService_Call{Call: _e.mock.On("SetChainService", chainService)}
+}
+
+func (_c *MockWebhookService_SetChainService_Call) Run(run func(chainService service.ExecutionChainService)) *MockWebhookService_SetChainService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.ExecutionChainService))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetChainService_Call) Return() *MockWebhookService_SetChainService_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetChainService_Call) RunAndReturn(run func(service.ExecutionChainService)) *MockWebhookService_SetChainService_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetClock provides a mock function with given fields: clock
+func (_m *MockWebhookService) SetClock(clock service.Clock) {
+	_m.Called(clock)
+}
+
+// MockWebhookService_SetClock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetClock'
+type MockWebhookService_SetClock_Call struct {
+	*mock.Call
+}
+
+// SetClock is a helper method to define mock.On call
+//   - clock service.Clock
+func (_e *MockWebhookService_Expecter) SetClock(clock interface{}) *MockWebhookService_SetClock_Call {
+	return &MockWebhookService_SetClock_Call{Call: _e.mock.On("SetClock", clock)}
+}
+
+func (_c *MockWebhookService_SetClock_Call) Run(run func(clock service.Clock)) *MockWebhookService_SetClock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.Clock))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetClock_Call) Return() *MockWebhookService_SetClock_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetClock_Call) RunAndReturn(run func(service.Clock)) *MockWebhookService_SetClock_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetEmailSender provides a mock function with given fields: sender
+func (_m *MockWebhookService) SetEmailSender(sender service.EmailSender) {
+	_m.Called(sender)
+}
+
+// MockWebhookService_SetEmailSender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEmailSender'
+type MockWebhookService_SetEmailSender_Call struct {
+	*mock.Call
+}
+
+// SetEmailSender is a helper method to define mock.On call
+//   - sender service.EmailSender
+func (_e *MockWebhookService_Expecter) SetEmailSender(sender interface{}) *MockWebhookService_SetEmailSender_Call {
+	return &MockWebhookService_SetEmailSender_Call{Call: _e.mock.On("SetEmailSender", sender)}
+}
+
+func (_c *MockWebhookService_SetEmailSender_Call) Run(run func(sender service.EmailSender)) *MockWebhookService_SetEmailSender_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.EmailSender))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetEmailSender_Call) Return() *MockWebhookService_SetEmailSender_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetEmailSender_Call) RunAndReturn(run func(service.EmailSender)) *MockWebhookService_SetEmailSender_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetEventPublisher provides a mock function with given fields: publisher
+func (_m *MockWebhookService) SetEventPublisher(publisher service.EventPublisher) {
+	_m.Called(publisher)
+}
+
+// MockWebhookService_SetEventPublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventPublisher'
+type MockWebhookService_SetEventPublisher_Call struct {
+	*mock.Call
+}
+
+// SetEventPublisher is a helper method to define mock.On call
+//   - publisher service.EventPublisher
+func (_e *MockWebhookService_Expecter) SetEventPublisher(publisher interface{}) *MockWebhookService_SetEventPublisher_Call {
+	return &MockWebhookService_SetEventPublisher_Call{Call: _e.mock.On("SetEventPublisher", publisher)}
+}
+
+func (_c *MockWebhookService_SetEventPublisher_Call) Run(run func(publisher service.EventPublisher)) *MockWebhookService_SetEventPublisher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.EventPublisher))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetEventPublisher_Call) Return() *MockWebhookService_SetEventPublisher_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetEventPublisher_Call) RunAndReturn(run func(service.EventPublisher)) *MockWebhookService_SetEventPublisher_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetKeyring provides a mock function with given fields: keyring
+func (_m *MockWebhookService) SetKeyring(keyring *service.Keyring) {
+	_m.Called(keyring)
+}
+
+// MockWebhookService_SetKeyring_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetKeyring'
+type MockWebhookService_SetKeyring_Call struct {
+	*mock.Call
+}
+
+// SetKeyring is a helper method to define mock.On call
+//   - keyring *service.Keyring
+func (_e *MockWebhookService_Expecter) SetKeyring(keyring interface{}) *MockWebhookService_SetKeyring_Call {
+	return &MockWebhookService_SetKeyring_Call{Call: _e.mock.On("SetKeyring", keyring)}
+}
+
+func (_c *MockWebhookService_SetKeyring_Call) Run(run func(keyring *service.Keyring)) *MockWebhookService_SetKeyring_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*service.Keyring))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetKeyring_Call) Return() *MockWebhookService_SetKeyring_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetKeyring_Call) RunAndReturn(run func(*service.Keyring)) *MockWebhookService_SetKeyring_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetLatencyMetrics provides a mock function with given fields: metrics
+func (_m *MockWebhookService) SetLatencyMetrics(metrics *service.LatencyMetrics) {
+	_m.Called(metrics)
+}
+
+// MockWebhookService_SetLatencyMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLatencyMetrics'
+type MockWebhookService_SetLatencyMetrics_Call struct {
+	*mock.Call
+}
+
+// SetLatencyMetrics is a helper method to define mock.On call
+//   - metrics *service.LatencyMetrics
+func (_e *MockWebhookService_Expecter) SetLatencyMetrics(metrics interface{}) *MockWebhookService_SetLatencyMetrics_Call {
+	return &MockWebhookService_SetLatencyMetrics_Call{Call: _e.mock.On("SetLatencyMetrics", metrics)}
+}
+
+func (_c *MockWebhookService_SetLatencyMetrics_Call) Run(run func(metrics *service.LatencyMetrics)) *MockWebhookService_SetLatencyMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*service.LatencyMetrics))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetLatencyMetrics_Call) Return() *MockWebhookService_SetLatencyMetrics_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetLatencyMetrics_Call) RunAndReturn(run func(*service.LatencyMetrics)) *MockWebhookService_SetLatencyMetrics_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetLocks provides a mock function with given fields: locks
+func (_m *MockWebhookService) SetLocks(locks repository.LockRepository) {
+	_m.Called(locks)
+}
+
+// MockWebhookService_SetLocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLocks'
+type MockWebhookService_SetLocks_Call struct {
+	*mock.Call
+}
+
+// SetLocks is a helper method to define mock.On call
+//   - locks repository.LockRepository
+func (_e *MockWebhookService_Expecter) SetLocks(locks interface{}) *MockWebhookService_SetLocks_Call {
+	return &MockWebhookService_SetLocks_Call{Call: _e.mock.On("SetLocks", locks)}
+}
+
+func (_c *MockWebhookService_SetLocks_Call) Run(run func(locks repository.LockRepository)) *MockWebhookService_SetLocks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.LockRepository))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetLocks_Call) Return() *MockWebhookService_SetLocks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetLocks_Call) RunAndReturn(run func(repository.LockRepository)) *MockWebhookService_SetLocks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetPayloadOffload provides a mock function with given fields: store, threshold, urlTTL
+func (_m *MockWebhookService) SetPayloadOffload(store service.PayloadStore, threshold int, urlTTL time.Duration) {
+	_m.Called(store, threshold, urlTTL)
+}
+
+// MockWebhookService_SetPayloadOffload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPayloadOffload'
+type MockWebhookService_SetPayloadOffload_Call struct {
+	*mock.Call
+}
+
+// SetPayloadOffload is a helper method to define mock.On call
+//   - store service.PayloadStore
+//   - threshold int
+//   - urlTTL time.Duration
+func (_e *MockWebhookService_Expecter) SetPayloadOffload(store interface{}, threshold interface{}, urlTTL interface{}) *MockWebhookService_SetPayloadOffload_Call {
+	return &MockWebhookService_SetPayloadOffload_Call{Call: _e.mock.On("SetPayloadOffload", store, threshold, urlTTL)}
+}
+
+func (_c *MockWebhookService_SetPayloadOffload_Call) Run(run func(store service.PayloadStore, threshold int, urlTTL time.Duration)) *MockWebhookService_SetPayloadOffload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.PayloadStore), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetPayloadOffload_Call) Return() *MockWebhookService_SetPayloadOffload_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetPayloadOffload_Call) RunAndReturn(run func(service.PayloadStore, int, time.Duration)) *MockWebhookService_SetPayloadOffload_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetPriorityMetrics provides a mock function with given fields: metrics
+func (_m *MockWebhookService) SetPriorityMetrics(metrics *service.PriorityMetrics) {
+	_m.Called(metrics)
+}
+
+// MockWebhookService_SetPriorityMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriorityMetrics'
+type MockWebhookService_SetPriorityMetrics_Call struct {
+	*mock.Call
+}
+
+// SetPriorityMetrics is a helper method to define mock.On call
+//   - metrics *service.PriorityMetrics
+func (_e *MockWebhookService_Expecter) SetPriorityMetrics(metrics interface{}) *MockWebhookService_SetPriorityMetrics_Call {
+	return &MockWebhookService_SetPriorityMetrics_Call{Call: _e.mock.On("SetPriorityMetrics", metrics)}
+}
+
+func (_c *MockWebhookService_SetPriorityMetrics_Call) Run(run func(metrics *service.PriorityMetrics)) *MockWebhookService_SetPriorityMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*service.PriorityMetrics))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetPriorityMetrics_Call) Return() *MockWebhookService_SetPriorityMetrics_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetPriorityMetrics_Call) RunAndReturn(run func(*service.PriorityMetrics)) *MockWebhookService_SetPriorityMetrics_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetSMSSender provides a mock function with given fields: sender
+func (_m *MockWebhookService) SetSMSSender(sender service.SMSSender) {
+	_m.Called(sender)
+}
+
+// MockWebhookService_SetSMSSender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSMSSender'
+type MockWebhookService_SetSMSSender_Call struct {
+	*mock.Call
+}
+
+// SetSMSSender is a helper method to define mock.On call
+//   - sender service.SMSSender
+func (_e *MockWebhookService_Expecter) SetSMSSender(sender interface{}) *MockWebhookService_SetSMSSender_Call {
+	return &MockWebhookService_SetSMSSender_Call{Call: _e.mock.On("SetSMSSender", sender)}
+}
+
+func (_c *MockWebhookService_SetSMSSender_Call) Run(run func(sender service.SMSSender)) *MockWebhookService_SetSMSSender_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.SMSSender))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetSMSSender_Call) Return() *MockWebhookService_SetSMSSender_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetSMSSender_Call) RunAndReturn(run func(service.SMSSender)) *MockWebhookService_SetSMSSender_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetWorkQueue provides a mock function with given fields: queue
+func (_m *MockWebhookService) SetWorkQueue(queue service.WorkQueue) {
+	_m.Called(queue)
+}
+
+// MockWebhookService_SetWorkQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkQueue'
+type MockWebhookService_SetWorkQueue_Call struct {
+	*mock.Call
+}
+
+// SetWorkQueue is a helper method to define mock.On call
+//   - queue service.WorkQueue
+func (_e *MockWebhookService_Expecter) SetWorkQueue(queue interface{}) *MockWebhookService_SetWorkQueue_Call {
+	return &MockWebhookService_SetWorkQueue_Call{Call: _e.mock.On("SetWorkQueue", queue)}
+}
+
+func (_c *MockWebhookService_SetWorkQueue_Call) Run(run func(queue service.WorkQueue)) *MockWebhookService_SetWorkQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(service.WorkQueue))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SetWorkQueue_Call) Return() *MockWebhookService_SetWorkQueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookService_SetWorkQueue_Call) RunAndReturn(run func(service.WorkQueue)) *MockWebhookService_SetWorkQueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SubscribeWebhook provides a mock function with given fields: ctx, req
+func (_m *MockWebhookService) SubscribeWebhook(ctx context.Context, req *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeWebhook")
+	}
+
+	var r0 *models.GenerateWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscribeWebhookRequest) *models.GenerateWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GenerateWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SubscribeWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_SubscribeWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeWebhook'
+type MockWebhookService_SubscribeWebhook_Call struct {
+	*mock.Call
+}
+
+// SubscribeWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.SubscribeWebhookRequest
+func (_e *MockWebhookService_Expecter) SubscribeWebhook(ctx interface{}, req interface{}) *MockWebhookService_SubscribeWebhook_Call {
+	return &MockWebhookService_SubscribeWebhook_Call{Call: _e.mock.On("SubscribeWebhook", ctx, req)}
+}
+
+func (_c *MockWebhookService_SubscribeWebhook_Call) Run(run func(ctx context.Context, req *models.SubscribeWebhookRequest)) *MockWebhookService_SubscribeWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SubscribeWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_SubscribeWebhook_Call) Return(_a0 *models.GenerateWebhookResponse, _a1 error) *MockWebhookService_SubscribeWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_SubscribeWebhook_Call) RunAndReturn(run func(context.Context, *models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error)) *MockWebhookService_SubscribeWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TestWebhook provides a mock function with given fields: ctx, tenantID, webhookID, req
+func (_m *MockWebhookService) TestWebhook(ctx context.Context, tenantID string, webhookID uuid.UUID, req *models.TestWebhookRequest) (*models.TestWebhookResponse, error) {
+	ret := _m.Called(ctx, tenantID, webhookID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestWebhook")
+	}
+
+	var r0 *models.TestWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.TestWebhookRequest) (*models.TestWebhookResponse, error)); ok {
+		return rf(ctx, tenantID, webhookID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *models.TestWebhookRequest) *models.TestWebhookResponse); ok {
+		r0 = rf(ctx, tenantID, webhookID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TestWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, *models.TestWebhookRequest) error); ok {
+		r1 = rf(ctx, tenantID, webhookID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_TestWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestWebhook'
+type MockWebhookService_TestWebhook_Call struct {
+	*mock.Call
+}
+
+// TestWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - webhookID uuid.UUID
+//   - req *models.TestWebhookRequest
+func (_e *MockWebhookService_Expecter) TestWebhook(ctx interface{}, tenantID interface{}, webhookID interface{}, req interface{}) *MockWebhookService_TestWebhook_Call {
+	return &MockWebhookService_TestWebhook_Call{Call: _e.mock.On("TestWebhook", ctx, tenantID, webhookID, req)}
+}
+
+func (_c *MockWebhookService_TestWebhook_Call) Run(run func(ctx context.Context, tenantID string, webhookID uuid.UUID, req *models.TestWebhookRequest)) *MockWebhookService_TestWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(*models.TestWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_TestWebhook_Call) Return(_a0 *models.TestWebhookResponse, _a1 error) *MockWebhookService_TestWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_TestWebhook_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, *models.TestWebhookRequest) (*models.TestWebhookResponse, error)) *MockWebhookService_TestWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transports provides a mock function with no fields
+func (_m *MockWebhookService) Transports() *service.TransportRegistry {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transports")
+	}
+
+	var r0 *service.TransportRegistry
+	if rf, ok := ret.Get(0).(func() *service.TransportRegistry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.TransportRegistry)
+		}
+	}
+
+	return r0
+}
+
+// MockWebhookService_Transports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transports'
+type MockWebhookService_Transports_Call struct {
+	*mock.Call
+}
+
+// Transports is a helper method to define mock.On call
+func (_e *MockWebhookService_Expecter) Transports() *MockWebhookService_Transports_Call {
+	return &MockWebhookService_Transports_Call{Call: _e.mock.On("Transports")}
+}
+
+func (_c *MockWebhookService_Transports_Call) Run(run func()) *MockWebhookService_Transports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Transports_Call) Return(_a0 *service.TransportRegistry) *MockWebhookService_Transports_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_Transports_Call) RunAndReturn(run func() *service.TransportRegistry) *MockWebhookService_Transports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEventType provides a mock function with given fields: ctx, id, req
+func (_m *MockWebhookService) UpdateEventType(ctx context.Context, id uuid.UUID, req *models.UpdateEventTypeRequest) (*models.EventType, error) {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEventType")
+	}
+
+	var r0 *models.EventType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.UpdateEventTypeRequest) (*models.EventType, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.UpdateEventTypeRequest) *models.EventType); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EventType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *models.UpdateEventTypeRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_UpdateEventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEventType'
+type MockWebhookService_UpdateEventType_Call struct {
+	*mock.Call
+}
+
+// UpdateEventType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - req *models.UpdateEventTypeRequest
+func (_e *MockWebhookService_Expecter) UpdateEventType(ctx interface{}, id interface{}, req interface{}) *MockWebhookService_UpdateEventType_Call {
+	return &MockWebhookService_UpdateEventType_Call{Call: _e.mock.On("UpdateEventType", ctx, id, req)}
+}
+
+func (_c *MockWebhookService_UpdateEventType_Call) Run(run func(ctx context.Context, id uuid.UUID, req *models.UpdateEventTypeRequest)) *MockWebhookService_UpdateEventType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*models.UpdateEventTypeRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateEventType_Call) Return(_a0 *models.EventType, _a1 error) *MockWebhookService_UpdateEventType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateEventType_Call) RunAndReturn(run func(context.Context, uuid.UUID, *models.UpdateEventTypeRequest) (*models.EventType, error)) *MockWebhookService_UpdateEventType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTenantPayloadValidation provides a mock function with given fields: ctx, tenantID, strict
+func (_m *MockWebhookService) UpdateTenantPayloadValidation(ctx context.Context, tenantID string, strict bool) (*models.TenantPayloadValidationResponse, error) {
+	ret := _m.Called(ctx, tenantID, strict)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTenantPayloadValidation")
+	}
+
+	var r0 *models.TenantPayloadValidationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*models.TenantPayloadValidationResponse, error)); ok {
+		return rf(ctx, tenantID, strict)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *models.TenantPayloadValidationResponse); ok {
+		r0 = rf(ctx, tenantID, strict)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantPayloadValidationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, tenantID, strict)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_UpdateTenantPayloadValidation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTenantPayloadValidation'
+type MockWebhookService_UpdateTenantPayloadValidation_Call struct {
+	*mock.Call
+}
+
+// UpdateTenantPayloadValidation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - strict bool
+func (_e *MockWebhookService_Expecter) UpdateTenantPayloadValidation(ctx interface{}, tenantID interface{}, strict interface{}) *MockWebhookService_UpdateTenantPayloadValidation_Call {
+	return &MockWebhookService_UpdateTenantPayloadValidation_Call{Call: _e.mock.On("UpdateTenantPayloadValidation", ctx, tenantID, strict)}
+}
+
+func (_c *MockWebhookService_UpdateTenantPayloadValidation_Call) Run(run func(ctx context.Context, tenantID string, strict bool)) *MockWebhookService_UpdateTenantPayloadValidation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateTenantPayloadValidation_Call) Return(_a0 *models.TenantPayloadValidationResponse, _a1 error) *MockWebhookService_UpdateTenantPayloadValidation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateTenantPayloadValidation_Call) RunAndReturn(run func(context.Context, string, bool) (*models.TenantPayloadValidationResponse, error)) *MockWebhookService_UpdateTenantPayloadValidation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTenantSigning provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockWebhookService) UpdateTenantSigning(ctx context.Context, tenantID string, req *models.TenantSigningRequest) (*models.TenantSigningResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTenantSigning")
+	}
+
+	var r0 *models.TenantSigningResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.TenantSigningRequest) (*models.TenantSigningResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.TenantSigningRequest) *models.TenantSigningResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantSigningResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.TenantSigningRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_UpdateTenantSigning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTenantSigning'
+type MockWebhookService_UpdateTenantSigning_Call struct {
+	*mock.Call
+}
+
+// UpdateTenantSigning is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - req *models.TenantSigningRequest
+func (_e *MockWebhookService_Expecter) UpdateTenantSigning(ctx interface{}, tenantID interface{}, req interface{}) *MockWebhookService_UpdateTenantSigning_Call {
+	return &MockWebhookService_UpdateTenantSigning_Call{Call: _e.mock.On("UpdateTenantSigning", ctx, tenantID, req)}
+}
+
+func (_c *MockWebhookService_UpdateTenantSigning_Call) Run(run func(ctx context.Context, tenantID string, req *models.TenantSigningRequest)) *MockWebhookService_UpdateTenantSigning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.TenantSigningRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateTenantSigning_Call) Return(_a0 *models.TenantSigningResponse, _a1 error) *MockWebhookService_UpdateTenantSigning_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateTenantSigning_Call) RunAndReturn(run func(context.Context, string, *models.TenantSigningRequest) (*models.TenantSigningResponse, error)) *MockWebhookService_UpdateTenantSigning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTenantSigningHeaders provides a mock function with given fields: ctx, tenantID, headers
+func (_m *MockWebhookService) UpdateTenantSigningHeaders(ctx context.Context, tenantID string, headers models.SigningHeaders) (*models.TenantSigningHeadersResponse, error) {
+	ret := _m.Called(ctx, tenantID, headers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTenantSigningHeaders")
+	}
+
+	var r0 *models.TenantSigningHeadersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SigningHeaders) (*models.TenantSigningHeadersResponse, error)); ok {
+		return rf(ctx, tenantID, headers)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SigningHeaders) *models.TenantSigningHeadersResponse); ok {
+		r0 = rf(ctx, tenantID, headers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TenantSigningHeadersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.SigningHeaders) error); ok {
+		r1 = rf(ctx, tenantID, headers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_UpdateTenantSigningHeaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTenantSigningHeaders'
+type MockWebhookService_UpdateTenantSigningHeaders_Call struct {
+	*mock.Call
+}
+
+// UpdateTenantSigningHeaders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - headers models.SigningHeaders
+func (_e *MockWebhookService_Expecter) UpdateTenantSigningHeaders(ctx interface{}, tenantID interface{}, headers interface{}) *MockWebhookService_UpdateTenantSigningHeaders_Call {
+	return &MockWebhookService_UpdateTenantSigningHeaders_Call{Call: _e.mock.On("UpdateTenantSigningHeaders", ctx, tenantID, headers)}
+}
+
+func (_c *MockWebhookService_UpdateTenantSigningHeaders_Call) Run(run func(ctx context.Context, tenantID string, headers models.SigningHeaders)) *MockWebhookService_UpdateTenantSigningHeaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.SigningHeaders))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateTenantSigningHeaders_Call) Return(_a0 *models.TenantSigningHeadersResponse, _a1 error) *MockWebhookService_UpdateTenantSigningHeaders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateTenantSigningHeaders_Call) RunAndReturn(run func(context.Context, string, models.SigningHeaders) (*models.TenantSigningHeadersResponse, error)) *MockWebhookService_UpdateTenantSigningHeaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifySignature provides a mock function with given fields: ctx, tenantID, req
+func (_m *MockWebhookService) VerifySignature(ctx context.Context, tenantID string, req *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignature")
+	}
+
+	var r0 *models.VerifySignatureResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.VerifySignatureRequest) *models.VerifySignatureResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VerifySignatureResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.VerifySignatureRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_VerifySignature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifySignature'
+type MockWebhookService_VerifySignature_Call struct {
+	*mock.Call
+}
+
+// VerifySignature is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - req *models.VerifySignatureRequest
+func (_e *MockWebhookService_Expecter) VerifySignature(ctx interface{}, tenantID interface{}, req interface{}) *MockWebhookService_VerifySignature_Call {
+	return &MockWebhookService_VerifySignature_Call{Call: _e.mock.On("VerifySignature", ctx, tenantID, req)}
+}
+
+func (_c *MockWebhookService_VerifySignature_Call) Run(run func(ctx context.Context, tenantID string, req *models.VerifySignatureRequest)) *MockWebhookService_VerifySignature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.VerifySignatureRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_VerifySignature_Call) Return(_a0 *models.VerifySignatureResponse, _a1 error) *MockWebhookService_VerifySignature_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockWebhookService_SubscribeWebhook_Call) RunAndReturn(run func(*models.SubscribeWebhookRequest) (*models.GenerateWebhookResponse, error)) *MockWebhookService_SubscribeWebhook_Call {
+func (_c *MockWebhookService_VerifySignature_Call) RunAndReturn(run func(context.Context, string, *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error)) *MockWebhookService_VerifySignature_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// VerifyWebhook provides a mock function with given fields: webhookID, payload, signature, timestamp, authHeader
-func (_m *MockWebhookService) VerifyWebhook(webhookID uuid.UUID, payload []byte, signature string, timestamp string, authHeader string) error {
-	ret := _m.Called(webhookID, payload, signature, timestamp, authHeader)
+// VerifyWebhook provides a mock function with given fields: ctx, webhookID, payload, signature, timestamp, authHeader, sourceIP
+func (_m *MockWebhookService) VerifyWebhook(ctx context.Context, webhookID uuid.UUID, payload []byte, signature string, timestamp string, authHeader string, sourceIP string) error {
+	ret := _m.Called(ctx, webhookID, payload, signature, timestamp, authHeader, sourceIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for VerifyWebhook")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, []byte, string, string, string) error); ok {
-		r0 = rf(webhookID, payload, signature, timestamp, authHeader)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte, string, string, string, string) error); ok {
+		r0 = rf(ctx, webhookID, payload, signature, timestamp, authHeader, sourceIP)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -314,18 +3137,20 @@ type MockWebhookService_VerifyWebhook_Call struct {
 }
 
 // VerifyWebhook is a helper method to define mock.On call
+//   - ctx context.Context
 //   - webhookID uuid.UUID
 //   - payload []byte
 //   - signature string
 //   - timestamp string
 //   - authHeader string
-func (_e *MockWebhookService_Expecter) VerifyWebhook(webhookID interface{}, payload interface{}, signature interface{}, timestamp interface{}, authHeader interface{}) *MockWebhookService_VerifyWebhook_Call {
-	return &MockWebhookService_VerifyWebhook_Call{Call: _e.mock.On("VerifyWebhook", webhookID, payload, signature, timestamp, authHeader)}
+//   - sourceIP string
+func (_e *MockWebhookService_Expecter) VerifyWebhook(ctx interface{}, webhookID interface{}, payload interface{}, signature interface{}, timestamp interface{}, authHeader interface{}, sourceIP interface{}) *MockWebhookService_VerifyWebhook_Call {
+	return &MockWebhookService_VerifyWebhook_Call{Call: _e.mock.On("VerifyWebhook", ctx, webhookID, payload, signature, timestamp, authHeader, sourceIP)}
 }
 
-func (_c *MockWebhookService_VerifyWebhook_Call) Run(run func(webhookID uuid.UUID, payload []byte, signature string, timestamp string, authHeader string)) *MockWebhookService_VerifyWebhook_Call {
+func (_c *MockWebhookService_VerifyWebhook_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, payload []byte, signature string, timestamp string, authHeader string, sourceIP string)) *MockWebhookService_VerifyWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID), args[1].([]byte), args[2].(string), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]byte), args[3].(string), args[4].(string), args[5].(string), args[6].(string))
 	})
 	return _c
 }
@@ -335,7 +3160,7 @@ func (_c *MockWebhookService_VerifyWebhook_Call) Return(_a0 error) *MockWebhookS
 	return _c
 }
 
-func (_c *MockWebhookService_VerifyWebhook_Call) RunAndReturn(run func(uuid.UUID, []byte, string, string, string) error) *MockWebhookService_VerifyWebhook_Call {
+func (_c *MockWebhookService_VerifyWebhook_Call) RunAndReturn(run func(context.Context, uuid.UUID, []byte, string, string, string, string) error) *MockWebhookService_VerifyWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
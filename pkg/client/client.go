@@ -0,0 +1,297 @@
+// Package client helps services receiving Loki Suite webhooks verify and parse deliveries
+//
+// Every delivery is a POST whose body is a JSON WebhookPayload, signed with the subscription's
+// secret token: the signature header carries "sha256=" followed by the hex HMAC-SHA256 of the raw
+// body, and the timestamp header the RFC3339 time the delivery was signed at. Tenants signing with
+// Ed25519 send "ed25519=" followed by the base64 signature of "<timestamp>.<body>" instead, verified
+// with the public keys of the server's key set.
+//
+// Subscriptions may receive deliveries gzip-compressed, still signed over the uncompressed body, and
+// deliveries too large to send inline carry a PayloadRef to download the full delivery with Resolve.
+//
+//	verifier := client.NewVerifier(os.Getenv("WEBHOOK_SECRET"))
+//	http.HandleFunc("/hooks", func(w http.ResponseWriter, r *http.Request) {
+//		payload, err := verifier.VerifyRequest(r)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusUnauthorized)
+//			return
+//		}
+//		handle(payload.Event, payload.Payload)
+//	})
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default header names of a delivery; tenants and subscriptions may configure others
+const (
+	SignatureHeader = "X-Shavix-Signature"
+	TimestampHeader = "X-Shavix-Timestamp"
+	AttemptHeader   = "X-Shavix-Attempt"
+
+	// TestHeader is set to "true" on test deliveries sent with POST /api/webhooks/:id/test
+	TestHeader = "X-Loki-Test"
+
+	// DeliveryIDHeader carries the ID of the delivered message, the same for every attempt
+	DeliveryIDHeader = "X-Shavix-Delivery-Id"
+
+	// RequestIDHeader carries the ID of the API request that caused the delivery, when there is one;
+	// log it to correlate a delivery with the sender's logs
+	RequestIDHeader = "X-Request-ID"
+)
+
+// DefaultTolerance is how far a delivery's timestamp may be from the receiver's clock
+const DefaultTolerance = 5 * time.Minute
+
+// signaturePrefix precedes the hex digest in the signature header
+const signaturePrefix = "sha256="
+
+// Errors returned by verification; wrap them with errors.Is to tell failures apart
+var (
+	ErrMissingSignature          = errors.New("signature header is missing")
+	ErrInvalidSignatureFormat    = errors.New("signature header must be sha256=<hex digest>")
+	ErrSignatureMismatch         = errors.New("signature does not match the body")
+	ErrMissingTimestamp          = errors.New("timestamp header is missing")
+	ErrInvalidTimestamp          = errors.New("timestamp header is not an RFC3339 time")
+	ErrTimestampOutsideTolerance = errors.New("timestamp is outside the allowed tolerance")
+	ErrPayloadMismatch           = errors.New("downloaded payload does not match its reference")
+)
+
+// WebhookPayload is the body of a delivery
+type WebhookPayload struct {
+	Event     string          `json:"event"`
+	Source    string          `json:"source"`
+	Timestamp string          `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+	EventID   uuid.UUID       `json:"event_id"`
+
+	// OrderingKey is set for events sent with one; events with the same key arrive in order
+	OrderingKey string `json:"ordering_key,omitempty"`
+
+	// PayloadRef replaces Payload in deliveries too large to be sent inline; see Resolve
+	PayloadRef *PayloadReference `json:"payload_ref,omitempty"`
+}
+
+// PayloadReference points to the full delivery of a payload too large to be sent inline
+type PayloadReference struct {
+	// URL is a presigned URL the delivery can be downloaded from until ExpiresAt
+	URL string `json:"url"`
+
+	// Size is the size of the delivery in bytes
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex SHA-256 digest of the delivery; the signature of the reference covers it
+	SHA256 string `json:"sha256"`
+
+	// ExpiresAt is when URL stops working
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Resolve downloads the full delivery of a payload sent by reference and replaces p with it, after checking it
+// against the reference's digest; payloads sent inline are left as they are. A nil client uses
+// http.DefaultClient
+func (p *WebhookPayload) Resolve(ctx context.Context, httpClient *http.Client) error {
+	if p.PayloadRef == nil {
+		return nil
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.PayloadRef.URL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid payload URL: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download payload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading payload returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, p.PayloadRef.Size+1))
+	if err != nil {
+		return fmt.Errorf("failed to download payload: %w", err)
+	}
+
+	digest := sha256.Sum256(body)
+	if !hmac.Equal([]byte(hex.EncodeToString(digest[:])), []byte(strings.ToLower(p.PayloadRef.SHA256))) {
+		return ErrPayloadMismatch
+	}
+	resolved, err := ParsePayload(body)
+	if err != nil {
+		return err
+	}
+	*p = *resolved
+	return nil
+}
+
+// Decode unmarshals the event data into v
+func (p *WebhookPayload) Decode(v interface{}) error {
+	return json.Unmarshal(p.Payload, v)
+}
+
+// Sign returns the signature header value of a body signed with secret
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a signature header value against the raw body
+// The body must be the exact bytes received; re-encoded JSON does not verify
+func VerifySignature(body []byte, signature, secret string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	digest, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return ErrInvalidSignatureFormat
+	}
+	received, err := hex.DecodeString(digest)
+	if err != nil {
+		return ErrInvalidSignatureFormat
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(received, mac.Sum(nil)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// VerifyTimestamp checks that a timestamp header value lies within tolerance of now
+// Rejecting old deliveries keeps captured requests from being replayed later
+func VerifyTimestamp(timestamp string, now time.Time, tolerance time.Duration) error {
+	if timestamp == "" {
+		return ErrMissingTimestamp
+	}
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	skew := now.Sub(signedAt)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > tolerance {
+		return fmt.Errorf("%w: signed %s from now", ErrTimestampOutsideTolerance, skew.Round(time.Second))
+	}
+	return nil
+}
+
+// ParsePayload decodes a delivery body
+func ParsePayload(body []byte) (*WebhookPayload, error) {
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	return &payload, nil
+}
+
+// Verifier verifies deliveries of one subscription
+type Verifier struct {
+	// Secret is the subscription's secret token
+	Secret string
+
+	// PublicKeys verifies deliveries of tenants signing with Ed25519, keyed by key ID; see ParseKeySet
+	PublicKeys map[string]ed25519.PublicKey
+
+	// Tolerance is how far the timestamp may be from Now; zero skips the timestamp check
+	Tolerance time.Duration
+
+	// SignatureHeader and TimestampHeader name the headers to read, for tenants overriding the defaults
+	SignatureHeader string
+	TimestampHeader string
+	KeyIDHeader     string
+
+	// Now returns the current time; replace it in tests
+	Now func() time.Time
+}
+
+// NewVerifier creates a verifier with the default header names and tolerance
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{
+		Secret:          secret,
+		Tolerance:       DefaultTolerance,
+		SignatureHeader: SignatureHeader,
+		TimestampHeader: TimestampHeader,
+		KeyIDHeader:     KeyIDHeader,
+		Now:             time.Now,
+	}
+}
+
+// NewKeySetVerifier creates a verifier for a tenant signing with Ed25519, from its published key set
+func NewKeySetVerifier(publicKeys map[string]ed25519.PublicKey) *Verifier {
+	verifier := NewVerifier("")
+	verifier.PublicKeys = publicKeys
+	return verifier
+}
+
+// Verify checks the signature and timestamp headers of a delivery against its raw body
+// Ed25519 signatures are checked with the public key named by the key ID header, others with Secret
+func (v *Verifier) Verify(header http.Header, body []byte) error {
+	signature := header.Get(v.SignatureHeader)
+	if IsEd25519Signature(signature) {
+		publicKey, ok := v.PublicKeys[header.Get(v.KeyIDHeader)]
+		if !ok {
+			return ErrUnknownKey
+		}
+		if err := VerifyEd25519(body, signature, header.Get(v.TimestampHeader), publicKey); err != nil {
+			return err
+		}
+	} else if err := VerifySignature(body, signature, v.Secret); err != nil {
+		return err
+	}
+	if v.Tolerance > 0 {
+		if err := VerifyTimestamp(header.Get(v.TimestampHeader), v.Now(), v.Tolerance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyRequest reads, verifies and parses a delivery
+// Gzip-compressed deliveries are decompressed, as their signature covers the uncompressed body; the
+// uncompressed body is restored so later handlers can read it again
+func (v *Verifier) VerifyRequest(r *http.Request) (*WebhookPayload, error) {
+	reader := r.Body
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress body: %w", err)
+		}
+		reader = gz
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.Header.Del("Content-Encoding")
+
+	if err := v.Verify(r.Header, body); err != nil {
+		return nil, err
+	}
+	return ParsePayload(body)
+}
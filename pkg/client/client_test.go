@@ -0,0 +1,190 @@
+package client_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sakibcoolz/loki-suite/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerifyRequest tests that a signed delivery verifies and parses
+func TestVerifyRequest(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	body := []byte(`{"event":"order.created","source":"checkout","timestamp":"2024-01-15T10:30:00Z","payload":{"order_id":"ORD-1"},"event_id":"7d1f5a8e-2b1c-4f0e-9a51-3c2d1e0f9b7a"}`)
+	req := httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewReader(body))
+	req.Header.Set(client.SignatureHeader, client.Sign(body, "test-secret"))
+	req.Header.Set(client.TimestampHeader, now.Add(-time.Minute).Format(time.RFC3339))
+
+	verifier := client.NewVerifier("test-secret")
+	verifier.Now = func() time.Time { return now }
+
+	// Act
+	payload, err := verifier.VerifyRequest(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "order.created", payload.Event)
+	var order struct {
+		OrderID string `json:"order_id"`
+	}
+	assert.NoError(t, payload.Decode(&order))
+	assert.Equal(t, "ORD-1", order.OrderID)
+}
+
+// TestVerify_Failures tests that each verification failure is reported with its error
+func TestVerify_Failures(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	body := []byte(`{"event":"order.created"}`)
+	signature := client.Sign(body, "test-secret")
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		want      error
+	}{
+		{"missing signature", "", now.Format(time.RFC3339), client.ErrMissingSignature},
+		{"unprefixed signature", signature[len("sha256="):], now.Format(time.RFC3339), client.ErrInvalidSignatureFormat},
+		{"wrong secret", client.Sign(body, "other-secret"), now.Format(time.RFC3339), client.ErrSignatureMismatch},
+		{"missing timestamp", signature, "", client.ErrMissingTimestamp},
+		{"unix timestamp", signature, "1705314600", client.ErrInvalidTimestamp},
+		{"replayed delivery", signature, now.Add(-time.Hour).Format(time.RFC3339), client.ErrTimestampOutsideTolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(client.SignatureHeader, tt.signature)
+			header.Set(client.TimestampHeader, tt.timestamp)
+
+			verifier := client.NewVerifier("test-secret")
+			verifier.Now = func() time.Time { return now }
+
+			err := verifier.Verify(header, body)
+			assert.True(t, errors.Is(err, tt.want), "got %v, want %v", err, tt.want)
+		})
+	}
+}
+
+// TestStandardWebhooks tests signing against the Standard Webhooks reference vector and verifying with a rotated secret
+func TestStandardWebhooks(t *testing.T) {
+	// Arrange
+	secret := "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+	timestamp := time.Unix(1614265330, 0)
+	body := []byte(`{"test": 2432232314}`)
+
+	// Act
+	signature, err := client.SignStandard("msg_p5jXN8AQM9LWM0D4loKWxJek", timestamp, body, secret)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=", signature)
+
+	header := http.Header{}
+	header.Set(client.StandardIDHeader, "msg_p5jXN8AQM9LWM0D4loKWxJek")
+	header.Set(client.StandardTimestampHeader, "1614265330")
+	header.Set(client.StandardSignatureHeader, "v1,b2xkLXNpZ25hdHVyZQ== "+signature)
+	assert.NoError(t, client.VerifyStandard(header, body, secret, timestamp.Add(time.Minute), client.DefaultTolerance))
+
+	other := client.StandardSecret("other-secret")
+	assert.ErrorIs(t, client.VerifyStandard(header, body, other, timestamp, client.DefaultTolerance), client.ErrSignatureMismatch)
+}
+
+// TestKeySetVerifier tests that Ed25519 signatures verify with the key named by the key ID header
+func TestKeySetVerifier(t *testing.T) {
+	// Arrange
+	publicKey, privateKey, _ := ed25519.GenerateKey(nil)
+	keySet := fmt.Sprintf(`{"keys":[{"kty":"OKP","crv":"Ed25519","x":%q,"kid":"lk_1","use":"sig","alg":"EdDSA"}]}`,
+		base64.RawURLEncoding.EncodeToString(publicKey))
+	keys, err := client.ParseKeySet([]byte(keySet))
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	body := []byte(`{"event":"order.created"}`)
+	timestamp := now.Format(time.RFC3339)
+
+	header := http.Header{}
+	header.Set(client.SignatureHeader, client.SignEd25519(body, timestamp, privateKey))
+	header.Set(client.TimestampHeader, timestamp)
+	header.Set(client.KeyIDHeader, "lk_1")
+
+	verifier := client.NewKeySetVerifier(keys)
+	verifier.Now = func() time.Time { return now }
+
+	// Act & Assert
+	assert.NoError(t, verifier.Verify(header, body))
+
+	header.Set(client.TimestampHeader, now.Add(time.Second).Format(time.RFC3339))
+	assert.ErrorIs(t, verifier.Verify(header, body), client.ErrSignatureMismatch)
+
+	header.Set(client.KeyIDHeader, "lk_2")
+	assert.ErrorIs(t, verifier.Verify(header, body), client.ErrUnknownKey)
+}
+
+// TestVerifyRequest_Gzip tests that a compressed delivery verifies against its uncompressed body
+func TestVerifyRequest_Gzip(t *testing.T) {
+	// Arrange
+	body := []byte(`{"event":"order.created","payload":{"order_id":"ORD-1"}}`)
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write(body)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks", &compressed)
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set(client.SignatureHeader, client.Sign(body, "test-secret"))
+	verifier := client.NewVerifier("test-secret")
+	verifier.Tolerance = 0
+
+	// Act
+	payload, err := verifier.VerifyRequest(req)
+
+	// Assert - later handlers read the uncompressed body
+	assert.NoError(t, err)
+	assert.Equal(t, "order.created", payload.Event)
+	restored, _ := io.ReadAll(req.Body)
+	assert.Equal(t, body, restored)
+}
+
+// TestResolve tests that a payload sent by reference is downloaded and checked against its digest
+func TestResolve(t *testing.T) {
+	// Arrange
+	full := []byte(`{"event":"report.generated","payload":{"rows":3},"event_id":"7d1f5a8e-2b1c-4f0e-9a51-3c2d1e0f9b7a"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(full)
+	}))
+	defer server.Close()
+
+	digest := sha256.Sum256(full)
+	reference := fmt.Sprintf(`{"event":"report.generated","payload_ref":{"url":%q,"size":%d,"sha256":%q}}`,
+		server.URL, len(full), hex.EncodeToString(digest[:]))
+	payload, err := client.ParsePayload([]byte(reference))
+	assert.NoError(t, err)
+
+	// Act
+	err = payload.Resolve(context.Background(), nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, payload.PayloadRef)
+	assert.JSONEq(t, `{"rows":3}`, string(payload.Payload))
+
+	tampered, _ := client.ParsePayload([]byte(strings.Replace(reference, hex.EncodeToString(digest[:]), strings.Repeat("0", 64), 1)))
+	assert.ErrorIs(t, tampered.Resolve(context.Background(), nil), client.ErrPayloadMismatch)
+}
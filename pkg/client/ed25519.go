@@ -0,0 +1,89 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeyIDHeader carries the ID of the key an Ed25519 signature was made with
+const KeyIDHeader = "X-Shavix-Key-Id"
+
+// KeySetPath is where a Loki Suite server publishes the public keys of Ed25519 signing tenants
+const KeySetPath = "/.well-known/loki-suite/keys.json"
+
+// ed25519Prefix precedes the base64 signature in the signature header of Ed25519 signed deliveries
+const ed25519Prefix = "ed25519="
+
+// ErrUnknownKey is returned when an Ed25519 signature names a key that is not in the verifier's key set
+var ErrUnknownKey = errors.New("signature key is not in the key set")
+
+// SignEd25519 returns the signature header value of a body signed with an Ed25519 private key
+// The signed content is "<timestamp>.<body>", so the timestamp cannot be changed without invalidating it
+func SignEd25519(body []byte, timestamp string, privateKey ed25519.PrivateKey) string {
+	signature := ed25519.Sign(privateKey, ed25519Content(body, timestamp))
+	return ed25519Prefix + base64.StdEncoding.EncodeToString(signature)
+}
+
+// VerifyEd25519 checks an Ed25519 signature header value against the raw body and timestamp header value
+func VerifyEd25519(body []byte, signature, timestamp string, publicKey ed25519.PublicKey) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	encoded, ok := strings.CutPrefix(signature, ed25519Prefix)
+	if !ok {
+		return ErrInvalidSignatureFormat
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidSignatureFormat
+	}
+	if !ed25519.Verify(publicKey, ed25519Content(body, timestamp), decoded) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// IsEd25519Signature reports whether a signature header value is an Ed25519 signature
+func IsEd25519Signature(signature string) bool {
+	return strings.HasPrefix(signature, ed25519Prefix)
+}
+
+// ParseKeySet decodes the public keys of a key set served at KeySetPath, keyed by key ID
+func ParseKeySet(data []byte) (map[string]ed25519.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid key set: %w", err)
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Kty != "OKP" || key.Crv != "Ed25519" {
+			continue
+		}
+		publicKey, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key %q", key.Kid)
+		}
+		keys[key.Kid] = publicKey
+	}
+	return keys, nil
+}
+
+// ed25519Content returns the content an Ed25519 signature covers
+func ed25519Content(body []byte, timestamp string) []byte {
+	content := make([]byte, 0, len(timestamp)+1+len(body))
+	content = append(content, timestamp...)
+	content = append(content, '.')
+	return append(content, body...)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of deliveries signed per the Standard Webhooks specification (https://www.standardwebhooks.com)
+const (
+	StandardIDHeader        = "webhook-id"
+	StandardTimestampHeader = "webhook-timestamp"
+	StandardSignatureHeader = "webhook-signature"
+)
+
+// standardSecretPrefix precedes the base64 key of a Standard Webhooks secret
+const standardSecretPrefix = "whsec_"
+
+// Signature versions in the signature header: symmetric HMAC-SHA256 and asymmetric Ed25519
+const (
+	standardSignatureVersion  = "v1"
+	standardAsymmetricVersion = "v1a"
+)
+
+// ErrInvalidSecret is returned when a Standard Webhooks secret is not whsec_ followed by base64
+var ErrInvalidSecret = errors.New("secret must be whsec_ followed by a base64 key")
+
+// StandardSecret returns the Standard Webhooks form of a subscription's secret token
+// Verification libraries decode the key after the whsec_ prefix, so they sign with the same bytes as the token
+func StandardSecret(secretToken string) string {
+	return standardSecretPrefix + base64.StdEncoding.EncodeToString([]byte(secretToken))
+}
+
+// SignStandard returns the signature header value of a delivery signed per the Standard Webhooks specification
+// The signed content is "<id>.<unix timestamp>.<body>"; secret is a whsec_ secret
+func SignStandard(id string, timestamp time.Time, body []byte, secret string) (string, error) {
+	key, err := standardKey(secret)
+	if err != nil {
+		return "", err
+	}
+	return standardSignatureVersion + "," + standardDigest(key, id, timestamp.Unix(), body), nil
+}
+
+// VerifyStandard checks the Standard Webhooks headers of a delivery against its raw body
+// The signature header may list several space separated signatures, e.g. while a secret is rotated;
+// the delivery verifies when any v1 signature matches
+func VerifyStandard(header http.Header, body []byte, secret string, now time.Time, tolerance time.Duration) error {
+	key, err := standardKey(secret)
+	if err != nil {
+		return err
+	}
+
+	id := header.Get(StandardIDHeader)
+	signatures := header.Get(StandardSignatureHeader)
+	if id == "" || signatures == "" {
+		return ErrMissingSignature
+	}
+	timestamp := header.Get(StandardTimestampHeader)
+	if timestamp == "" {
+		return ErrMissingTimestamp
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if tolerance > 0 {
+		skew := now.Sub(time.Unix(seconds, 0))
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > tolerance {
+			return fmt.Errorf("%w: signed %s from now", ErrTimestampOutsideTolerance, skew.Round(time.Second))
+		}
+	}
+
+	expected := standardDigest(key, id, seconds, body)
+	for _, signature := range strings.Fields(signatures) {
+		version, digest, ok := strings.Cut(signature, ",")
+		if !ok {
+			return ErrInvalidSignatureFormat
+		}
+		if version == standardSignatureVersion && hmac.Equal([]byte(digest), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+// standardKey decodes the HMAC key of a whsec_ secret
+func standardKey(secret string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(secret, standardSecretPrefix)
+	if !ok {
+		return nil, ErrInvalidSecret
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// standardDigest returns the base64 HMAC-SHA256 of a delivery's signed content
+func standardDigest(key []byte, id string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s.%d.", id, timestamp)
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignStandardEd25519 returns the signature header value of a delivery signed with an Ed25519 private key
+// per the Standard Webhooks specification ("v1a,<base64 signature>" over "<id>.<unix timestamp>.<body>")
+func SignStandardEd25519(id string, timestamp time.Time, body []byte, privateKey ed25519.PrivateKey) string {
+	content := fmt.Appendf(nil, "%s.%d.", id, timestamp.Unix())
+	content = append(content, body...)
+	return standardAsymmetricVersion + "," + base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content))
+}
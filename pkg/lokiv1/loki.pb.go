@@ -0,0 +1,1892 @@
+// gRPC API of Loki Suite, served next to the REST API on LOKI_GRPC_PORT
+//
+// Calls authenticate like REST requests: send an API key as "x-api-key" metadata or a JWT as
+// "authorization: Bearer <jwt>". Every RPC requires the role of its REST counterpart.
+//
+// Regenerate pkg/lokiv1 with `make proto` after changing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: loki/v1/loki.proto
+
+package lokiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PublishEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// request_id is chosen by the client and echoed in the response, to match responses on streams
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	TenantId  string `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Event     string `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Source    string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// payload is the event data delivered to subscriptions
+	Payload *structpb.Value `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// deliver_at or delay_seconds schedule the event for delivery later instead of now
+	DeliverAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	DelaySeconds  int32                  `protobuf:"varint,7,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishEventRequest) Reset() {
+	*x = PublishEventRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishEventRequest) ProtoMessage() {}
+
+func (x *PublishEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishEventRequest.ProtoReflect.Descriptor instead.
+func (*PublishEventRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PublishEventRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *PublishEventRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *PublishEventRequest) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *PublishEventRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PublishEventRequest) GetPayload() *structpb.Value {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PublishEventRequest) GetDeliverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return nil
+}
+
+func (x *PublishEventRequest) GetDelaySeconds() int32 {
+	if x != nil {
+		return x.DelaySeconds
+	}
+	return 0
+}
+
+type PublishEventResponse struct {
+	state       protoimpl.MessageState   `protogen:"open.v1"`
+	RequestId   string                   `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	EventId     string                   `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	TotalSent   int32                    `protobuf:"varint,3,opt,name=total_sent,json=totalSent,proto3" json:"total_sent,omitempty"`
+	TotalFailed int32                    `protobuf:"varint,4,opt,name=total_failed,json=totalFailed,proto3" json:"total_failed,omitempty"`
+	TotalQueued int32                    `protobuf:"varint,5,opt,name=total_queued,json=totalQueued,proto3" json:"total_queued,omitempty"`
+	Webhooks    []*WebhookDeliveryResult `protobuf:"bytes,6,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	// scheduled is set when the event was stored for delivery at deliver_at
+	Scheduled bool                   `protobuf:"varint,7,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	DeliverAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	// error is set on streams when the event was not published; the other fields are then empty
+	Error         *Error `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishEventResponse) Reset() {
+	*x = PublishEventResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishEventResponse) ProtoMessage() {}
+
+func (x *PublishEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishEventResponse.ProtoReflect.Descriptor instead.
+func (*PublishEventResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PublishEventResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *PublishEventResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PublishEventResponse) GetTotalSent() int32 {
+	if x != nil {
+		return x.TotalSent
+	}
+	return 0
+}
+
+func (x *PublishEventResponse) GetTotalFailed() int32 {
+	if x != nil {
+		return x.TotalFailed
+	}
+	return 0
+}
+
+func (x *PublishEventResponse) GetTotalQueued() int32 {
+	if x != nil {
+		return x.TotalQueued
+	}
+	return 0
+}
+
+func (x *PublishEventResponse) GetWebhooks() []*WebhookDeliveryResult {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+func (x *PublishEventResponse) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *PublishEventResponse) GetDeliverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return nil
+}
+
+func (x *PublishEventResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type WebhookDeliveryResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	TargetUrl     string                 `protobuf:"bytes,2,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ResponseCode  int32                  `protobuf:"varint,4,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	AttemptCount  int32                  `protobuf:"varint,6,opt,name=attempt_count,json=attemptCount,proto3" json:"attempt_count,omitempty"`
+	Queued        bool                   `protobuf:"varint,7,opt,name=queued,proto3" json:"queued,omitempty"`
+	PausedUntil   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=paused_until,json=pausedUntil,proto3" json:"paused_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryResult) Reset() {
+	*x = WebhookDeliveryResult{}
+	mi := &file_loki_v1_loki_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryResult) ProtoMessage() {}
+
+func (x *WebhookDeliveryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryResult.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryResult) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WebhookDeliveryResult) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryResult) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WebhookDeliveryResult) GetResponseCode() int32 {
+	if x != nil {
+		return x.ResponseCode
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryResult) GetAttemptCount() int32 {
+	if x != nil {
+		return x.AttemptCount
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryResult) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
+
+func (x *WebhookDeliveryResult) GetPausedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PausedUntil
+	}
+	return nil
+}
+
+// Error reports the failure of one message of a stream
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code is the google.rpc.Code a unary call would have failed with
+	Code          int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_loki_v1_loki_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateChainRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain is the body of POST /api/execution-chains: tenant_id, name, trigger_event, steps and so on
+	Chain         *structpb.Struct `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChainRequest) Reset() {
+	*x = CreateChainRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChainRequest) ProtoMessage() {}
+
+func (x *CreateChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChainRequest.ProtoReflect.Descriptor instead.
+func (*CreateChainRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateChainRequest) GetChain() *structpb.Struct {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+type CreateChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TriggerEvent  string                 `protobuf:"bytes,3,opt,name=trigger_event,json=triggerEvent,proto3" json:"trigger_event,omitempty"`
+	StepsCount    int32                  `protobuf:"varint,4,opt,name=steps_count,json=stepsCount,proto3" json:"steps_count,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChainResponse) Reset() {
+	*x = CreateChainResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChainResponse) ProtoMessage() {}
+
+func (x *CreateChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChainResponse.ProtoReflect.Descriptor instead.
+func (*CreateChainResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateChainResponse) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *CreateChainResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChainResponse) GetTriggerEvent() string {
+	if x != nil {
+		return x.TriggerEvent
+	}
+	return ""
+}
+
+func (x *CreateChainResponse) GetStepsCount() int32 {
+	if x != nil {
+		return x.StepsCount
+	}
+	return 0
+}
+
+func (x *CreateChainResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateChainResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChainRequest) Reset() {
+	*x = GetChainRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainRequest) ProtoMessage() {}
+
+func (x *GetChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChainRequest.ProtoReflect.Descriptor instead.
+func (*GetChainRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetChainRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+type Chain struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId     string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Status       string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	TriggerEvent string                 `protobuf:"bytes,6,opt,name=trigger_event,json=triggerEvent,proto3" json:"trigger_event,omitempty"`
+	IsActive     bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Version      int32                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// details is the chain as GET /api/execution-chains/{id} returns it, including its steps
+	Details       *structpb.Struct `protobuf:"bytes,11,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chain) Reset() {
+	*x = Chain{}
+	mi := &file_loki_v1_loki_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chain) ProtoMessage() {}
+
+func (x *Chain) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chain.ProtoReflect.Descriptor instead.
+func (*Chain) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Chain) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Chain) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Chain) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chain) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Chain) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Chain) GetTriggerEvent() string {
+	if x != nil {
+		return x.TriggerEvent
+	}
+	return ""
+}
+
+func (x *Chain) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Chain) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Chain) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Chain) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Chain) GetDetails() *structpb.Struct {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type ListChainsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// page starts at 1; limit defaults to 10 and is at most 100
+	Page          int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChainsRequest) Reset() {
+	*x = ListChainsRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChainsRequest) ProtoMessage() {}
+
+func (x *ListChainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChainsRequest.ProtoReflect.Descriptor instead.
+func (*ListChainsRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListChainsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListChainsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListChainsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListChainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chains        []*Chain               `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChainsResponse) Reset() {
+	*x = ListChainsResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChainsResponse) ProtoMessage() {}
+
+func (x *ListChainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChainsResponse.ProtoReflect.Descriptor instead.
+func (*ListChainsResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListChainsResponse) GetChains() []*Chain {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+func (x *ListChainsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListChainsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListChainsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UpdateChainRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Fields left unset keep their value
+	Name                *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description         *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	IsActive            *bool   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	CompletionWebhookId *string `protobuf:"bytes,5,opt,name=completion_webhook_id,json=completionWebhookId,proto3,oneof" json:"completion_webhook_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdateChainRequest) Reset() {
+	*x = UpdateChainRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChainRequest) ProtoMessage() {}
+
+func (x *UpdateChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChainRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChainRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateChainRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *UpdateChainRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateChainRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateChainRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateChainRequest) GetCompletionWebhookId() string {
+	if x != nil && x.CompletionWebhookId != nil {
+		return *x.CompletionWebhookId
+	}
+	return ""
+}
+
+type UpdateChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChainResponse) Reset() {
+	*x = UpdateChainResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChainResponse) ProtoMessage() {}
+
+func (x *UpdateChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChainResponse.ProtoReflect.Descriptor instead.
+func (*UpdateChainResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{11}
+}
+
+type DeleteChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChainRequest) Reset() {
+	*x = DeleteChainRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChainRequest) ProtoMessage() {}
+
+func (x *DeleteChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChainRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChainRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteChainRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+type DeleteChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChainResponse) Reset() {
+	*x = DeleteChainResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChainResponse) ProtoMessage() {}
+
+func (x *DeleteChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChainResponse.ProtoReflect.Descriptor instead.
+func (*DeleteChainResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{13}
+}
+
+type ExecuteChainRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChainId     string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TriggerData *structpb.Struct       `protobuf:"bytes,2,opt,name=trigger_data,json=triggerData,proto3" json:"trigger_data,omitempty"`
+	// callback_url receives the run's summary once it finishes
+	CallbackUrl string `protobuf:"bytes,3,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	// execution_options are the execution_options of POST /api/execution-chains/{id}/execute
+	ExecutionOptions *structpb.Struct `protobuf:"bytes,4,opt,name=execution_options,json=executionOptions,proto3" json:"execution_options,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExecuteChainRequest) Reset() {
+	*x = ExecuteChainRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteChainRequest) ProtoMessage() {}
+
+func (x *ExecuteChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteChainRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteChainRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExecuteChainRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ExecuteChainRequest) GetTriggerData() *structpb.Struct {
+	if x != nil {
+		return x.TriggerData
+	}
+	return nil
+}
+
+func (x *ExecuteChainRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *ExecuteChainRequest) GetExecutionOptions() *structpb.Struct {
+	if x != nil {
+		return x.ExecutionOptions
+	}
+	return nil
+}
+
+type ExecuteChainResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RunId          string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	ChainId        string                 `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TotalSteps     int32                  `protobuf:"varint,4,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	StartedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	QueuePosition  int32                  `protobuf:"varint,6,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	CallbackSecret string                 `protobuf:"bytes,7,opt,name=callback_secret,json=callbackSecret,proto3" json:"callback_secret,omitempty"`
+	RetryOfRunId   string                 `protobuf:"bytes,8,opt,name=retry_of_run_id,json=retryOfRunId,proto3" json:"retry_of_run_id,omitempty"`
+	// plan is set instead of the run fields for dry runs and validations
+	Plan          *structpb.Struct `protobuf:"bytes,9,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteChainResponse) Reset() {
+	*x = ExecuteChainResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteChainResponse) ProtoMessage() {}
+
+func (x *ExecuteChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteChainResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteChainResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExecuteChainResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ExecuteChainResponse) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ExecuteChainResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExecuteChainResponse) GetTotalSteps() int32 {
+	if x != nil {
+		return x.TotalSteps
+	}
+	return 0
+}
+
+func (x *ExecuteChainResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ExecuteChainResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *ExecuteChainResponse) GetCallbackSecret() string {
+	if x != nil {
+		return x.CallbackSecret
+	}
+	return ""
+}
+
+func (x *ExecuteChainResponse) GetRetryOfRunId() string {
+	if x != nil {
+		return x.RetryOfRunId
+	}
+	return ""
+}
+
+func (x *ExecuteChainResponse) GetPlan() *structpb.Struct {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+type GetChainRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChainRunRequest) Reset() {
+	*x = GetChainRunRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainRunRequest) ProtoMessage() {}
+
+func (x *GetChainRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChainRunRequest.ProtoReflect.Descriptor instead.
+func (*GetChainRunRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetChainRunRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type ChainRun struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChainId      string                 `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TenantId     string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Status       string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TriggerEvent string                 `protobuf:"bytes,5,opt,name=trigger_event,json=triggerEvent,proto3" json:"trigger_event,omitempty"`
+	ChainVersion int32                  `protobuf:"varint,6,opt,name=chain_version,json=chainVersion,proto3" json:"chain_version,omitempty"`
+	CurrentStep  int32                  `protobuf:"varint,7,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	TotalSteps   int32                  `protobuf:"varint,8,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	StartedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// details is the run as GET /api/execution-chains/runs/{runId} returns it, including its step results
+	Details       *structpb.Struct `protobuf:"bytes,11,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainRun) Reset() {
+	*x = ChainRun{}
+	mi := &file_loki_v1_loki_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainRun) ProtoMessage() {}
+
+func (x *ChainRun) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainRun.ProtoReflect.Descriptor instead.
+func (*ChainRun) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChainRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChainRun) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ChainRun) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ChainRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChainRun) GetTriggerEvent() string {
+	if x != nil {
+		return x.TriggerEvent
+	}
+	return ""
+}
+
+func (x *ChainRun) GetChainVersion() int32 {
+	if x != nil {
+		return x.ChainVersion
+	}
+	return 0
+}
+
+func (x *ChainRun) GetCurrentStep() int32 {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return 0
+}
+
+func (x *ChainRun) GetTotalSteps() int32 {
+	if x != nil {
+		return x.TotalSteps
+	}
+	return 0
+}
+
+func (x *ChainRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ChainRun) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *ChainRun) GetDetails() *structpb.Struct {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type ListChainRunsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// page starts at 1; limit defaults to 10 and is at most 100
+	Page          int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChainRunsRequest) Reset() {
+	*x = ListChainRunsRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChainRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChainRunsRequest) ProtoMessage() {}
+
+func (x *ListChainRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChainRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListChainRunsRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListChainRunsRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListChainRunsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListChainRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListChainRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*ChainRun            `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChainRunsResponse) Reset() {
+	*x = ListChainRunsResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChainRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChainRunsResponse) ProtoMessage() {}
+
+func (x *ListChainRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChainRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListChainRunsResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListChainRunsResponse) GetRuns() []*ChainRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *ListChainRunsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListChainRunsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListChainRunsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CancelChainRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelChainRunRequest) Reset() {
+	*x = CancelChainRunRequest{}
+	mi := &file_loki_v1_loki_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelChainRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelChainRunRequest) ProtoMessage() {}
+
+func (x *CancelChainRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelChainRunRequest.ProtoReflect.Descriptor instead.
+func (*CancelChainRunRequest) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelChainRunRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *CancelChainRunRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ChainRunControlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	ChainId       string                 `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CurrentStep   int32                  `protobuf:"varint,4,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	TotalSteps    int32                  `protobuf:"varint,5,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainRunControlResponse) Reset() {
+	*x = ChainRunControlResponse{}
+	mi := &file_loki_v1_loki_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainRunControlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainRunControlResponse) ProtoMessage() {}
+
+func (x *ChainRunControlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loki_v1_loki_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainRunControlResponse.ProtoReflect.Descriptor instead.
+func (*ChainRunControlResponse) Descriptor() ([]byte, []int) {
+	return file_loki_v1_loki_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChainRunControlResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ChainRunControlResponse) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ChainRunControlResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChainRunControlResponse) GetCurrentStep() int32 {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return 0
+}
+
+func (x *ChainRunControlResponse) GetTotalSteps() int32 {
+	if x != nil {
+		return x.TotalSteps
+	}
+	return 0
+}
+
+var File_loki_v1_loki_proto protoreflect.FileDescriptor
+
+const file_loki_v1_loki_proto_rawDesc = "" +
+	"\n" +
+	"\x12loki/v1/loki.proto\x12\aloki.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x02\n" +
+	"\x13PublishEventRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x120\n" +
+	"\apayload\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\apayload\x129\n" +
+	"\n" +
+	"deliver_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdeliverAt\x12#\n" +
+	"\rdelay_seconds\x18\a \x01(\x05R\fdelaySeconds\"\xf0\x02\n" +
+	"\x14PublishEventResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"total_sent\x18\x03 \x01(\x05R\ttotalSent\x12!\n" +
+	"\ftotal_failed\x18\x04 \x01(\x05R\vtotalFailed\x12!\n" +
+	"\ftotal_queued\x18\x05 \x01(\x05R\vtotalQueued\x12:\n" +
+	"\bwebhooks\x18\x06 \x03(\v2\x1e.loki.v1.WebhookDeliveryResultR\bwebhooks\x12\x1c\n" +
+	"\tscheduled\x18\a \x01(\bR\tscheduled\x129\n" +
+	"\n" +
+	"deliver_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeliverAt\x12$\n" +
+	"\x05error\x18\t \x01(\v2\x0e.loki.v1.ErrorR\x05error\"\xa6\x02\n" +
+	"\x15WebhookDeliveryResult\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x02 \x01(\tR\ttargetUrl\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12#\n" +
+	"\rresponse_code\x18\x04 \x01(\x05R\fresponseCode\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12#\n" +
+	"\rattempt_count\x18\x06 \x01(\x05R\fattemptCount\x12\x16\n" +
+	"\x06queued\x18\a \x01(\bR\x06queued\x12=\n" +
+	"\fpaused_until\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vpausedUntil\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"C\n" +
+	"\x12CreateChainRequest\x12-\n" +
+	"\x05chain\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x05chain\"\xdd\x01\n" +
+	"\x13CreateChainResponse\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rtrigger_event\x18\x03 \x01(\tR\ftriggerEvent\x12\x1f\n" +
+	"\vsteps_count\x18\x04 \x01(\x05R\n" +
+	"stepsCount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\",\n" +
+	"\x0fGetChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"\x87\x03\n" +
+	"\x05Chain\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12#\n" +
+	"\rtrigger_event\x18\x06 \x01(\tR\ftriggerEvent\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x18\n" +
+	"\aversion\x18\b \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x121\n" +
+	"\adetails\x18\v \x01(\v2\x17.google.protobuf.StructR\adetails\"Z\n" +
+	"\x11ListChainsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"|\n" +
+	"\x12ListChainsResponse\x12&\n" +
+	"\x06chains\x18\x01 \x03(\v2\x0e.loki.v1.ChainR\x06chains\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x8b\x02\n" +
+	"\x12UpdateChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x04 \x01(\bH\x02R\bisActive\x88\x01\x01\x127\n" +
+	"\x15completion_webhook_id\x18\x05 \x01(\tH\x03R\x13completionWebhookId\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_is_activeB\x18\n" +
+	"\x16_completion_webhook_id\"\x15\n" +
+	"\x13UpdateChainResponse\"/\n" +
+	"\x12DeleteChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"\x15\n" +
+	"\x13DeleteChainResponse\"\xd5\x01\n" +
+	"\x13ExecuteChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12:\n" +
+	"\ftrigger_data\x18\x02 \x01(\v2\x17.google.protobuf.StructR\vtriggerData\x12!\n" +
+	"\fcallback_url\x18\x03 \x01(\tR\vcallbackUrl\x12D\n" +
+	"\x11execution_options\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x10executionOptions\"\xe0\x02\n" +
+	"\x14ExecuteChainResponse\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\tR\achainId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtotal_steps\x18\x04 \x01(\x05R\n" +
+	"totalSteps\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12%\n" +
+	"\x0equeue_position\x18\x06 \x01(\x05R\rqueuePosition\x12'\n" +
+	"\x0fcallback_secret\x18\a \x01(\tR\x0ecallbackSecret\x12%\n" +
+	"\x0fretry_of_run_id\x18\b \x01(\tR\fretryOfRunId\x12+\n" +
+	"\x04plan\x18\t \x01(\v2\x17.google.protobuf.StructR\x04plan\"+\n" +
+	"\x12GetChainRunRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\"\xa5\x03\n" +
+	"\bChainRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\tR\achainId\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rtrigger_event\x18\x05 \x01(\tR\ftriggerEvent\x12#\n" +
+	"\rchain_version\x18\x06 \x01(\x05R\fchainVersion\x12!\n" +
+	"\fcurrent_step\x18\a \x01(\x05R\vcurrentStep\x12\x1f\n" +
+	"\vtotal_steps\x18\b \x01(\x05R\n" +
+	"totalSteps\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x121\n" +
+	"\adetails\x18\v \x01(\v2\x17.google.protobuf.StructR\adetails\"[\n" +
+	"\x14ListChainRunsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"~\n" +
+	"\x15ListChainRunsResponse\x12%\n" +
+	"\x04runs\x18\x01 \x03(\v2\x11.loki.v1.ChainRunR\x04runs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"F\n" +
+	"\x15CancelChainRunRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xa7\x01\n" +
+	"\x17ChainRunControlResponse\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\tR\achainId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fcurrent_step\x18\x04 \x01(\x05R\vcurrentStep\x12\x1f\n" +
+	"\vtotal_steps\x18\x05 \x01(\x05R\n" +
+	"totalSteps2\xb2\x01\n" +
+	"\fEventService\x12K\n" +
+	"\fPublishEvent\x12\x1c.loki.v1.PublishEventRequest\x1a\x1d.loki.v1.PublishEventResponse\x12U\n" +
+	"\x12PublishEventStream\x12\x1c.loki.v1.PublishEventRequest\x1a\x1d.loki.v1.PublishEventResponse(\x010\x012\x99\x05\n" +
+	"\fChainService\x12H\n" +
+	"\vCreateChain\x12\x1b.loki.v1.CreateChainRequest\x1a\x1c.loki.v1.CreateChainResponse\x124\n" +
+	"\bGetChain\x12\x18.loki.v1.GetChainRequest\x1a\x0e.loki.v1.Chain\x12E\n" +
+	"\n" +
+	"ListChains\x12\x1a.loki.v1.ListChainsRequest\x1a\x1b.loki.v1.ListChainsResponse\x12H\n" +
+	"\vUpdateChain\x12\x1b.loki.v1.UpdateChainRequest\x1a\x1c.loki.v1.UpdateChainResponse\x12H\n" +
+	"\vDeleteChain\x12\x1b.loki.v1.DeleteChainRequest\x1a\x1c.loki.v1.DeleteChainResponse\x12K\n" +
+	"\fExecuteChain\x12\x1c.loki.v1.ExecuteChainRequest\x1a\x1d.loki.v1.ExecuteChainResponse\x12=\n" +
+	"\vGetChainRun\x12\x1b.loki.v1.GetChainRunRequest\x1a\x11.loki.v1.ChainRun\x12N\n" +
+	"\rListChainRuns\x12\x1d.loki.v1.ListChainRunsRequest\x1a\x1e.loki.v1.ListChainRunsResponse\x12R\n" +
+	"\x0eCancelChainRun\x12\x1e.loki.v1.CancelChainRunRequest\x1a .loki.v1.ChainRunControlResponseB4Z2github.com/sakibcoolz/loki-suite/pkg/lokiv1;lokiv1b\x06proto3"
+
+var (
+	file_loki_v1_loki_proto_rawDescOnce sync.Once
+	file_loki_v1_loki_proto_rawDescData []byte
+)
+
+func file_loki_v1_loki_proto_rawDescGZIP() []byte {
+	file_loki_v1_loki_proto_rawDescOnce.Do(func() {
+		file_loki_v1_loki_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_loki_v1_loki_proto_rawDesc), len(file_loki_v1_loki_proto_rawDesc)))
+	})
+	return file_loki_v1_loki_proto_rawDescData
+}
+
+var file_loki_v1_loki_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_loki_v1_loki_proto_goTypes = []any{
+	(*PublishEventRequest)(nil),     // 0: loki.v1.PublishEventRequest
+	(*PublishEventResponse)(nil),    // 1: loki.v1.PublishEventResponse
+	(*WebhookDeliveryResult)(nil),   // 2: loki.v1.WebhookDeliveryResult
+	(*Error)(nil),                   // 3: loki.v1.Error
+	(*CreateChainRequest)(nil),      // 4: loki.v1.CreateChainRequest
+	(*CreateChainResponse)(nil),     // 5: loki.v1.CreateChainResponse
+	(*GetChainRequest)(nil),         // 6: loki.v1.GetChainRequest
+	(*Chain)(nil),                   // 7: loki.v1.Chain
+	(*ListChainsRequest)(nil),       // 8: loki.v1.ListChainsRequest
+	(*ListChainsResponse)(nil),      // 9: loki.v1.ListChainsResponse
+	(*UpdateChainRequest)(nil),      // 10: loki.v1.UpdateChainRequest
+	(*UpdateChainResponse)(nil),     // 11: loki.v1.UpdateChainResponse
+	(*DeleteChainRequest)(nil),      // 12: loki.v1.DeleteChainRequest
+	(*DeleteChainResponse)(nil),     // 13: loki.v1.DeleteChainResponse
+	(*ExecuteChainRequest)(nil),     // 14: loki.v1.ExecuteChainRequest
+	(*ExecuteChainResponse)(nil),    // 15: loki.v1.ExecuteChainResponse
+	(*GetChainRunRequest)(nil),      // 16: loki.v1.GetChainRunRequest
+	(*ChainRun)(nil),                // 17: loki.v1.ChainRun
+	(*ListChainRunsRequest)(nil),    // 18: loki.v1.ListChainRunsRequest
+	(*ListChainRunsResponse)(nil),   // 19: loki.v1.ListChainRunsResponse
+	(*CancelChainRunRequest)(nil),   // 20: loki.v1.CancelChainRunRequest
+	(*ChainRunControlResponse)(nil), // 21: loki.v1.ChainRunControlResponse
+	(*structpb.Value)(nil),          // 22: google.protobuf.Value
+	(*timestamppb.Timestamp)(nil),   // 23: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),         // 24: google.protobuf.Struct
+}
+var file_loki_v1_loki_proto_depIdxs = []int32{
+	22, // 0: loki.v1.PublishEventRequest.payload:type_name -> google.protobuf.Value
+	23, // 1: loki.v1.PublishEventRequest.deliver_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: loki.v1.PublishEventResponse.webhooks:type_name -> loki.v1.WebhookDeliveryResult
+	23, // 3: loki.v1.PublishEventResponse.deliver_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: loki.v1.PublishEventResponse.error:type_name -> loki.v1.Error
+	23, // 5: loki.v1.WebhookDeliveryResult.paused_until:type_name -> google.protobuf.Timestamp
+	24, // 6: loki.v1.CreateChainRequest.chain:type_name -> google.protobuf.Struct
+	23, // 7: loki.v1.CreateChainResponse.created_at:type_name -> google.protobuf.Timestamp
+	23, // 8: loki.v1.Chain.created_at:type_name -> google.protobuf.Timestamp
+	23, // 9: loki.v1.Chain.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 10: loki.v1.Chain.details:type_name -> google.protobuf.Struct
+	7,  // 11: loki.v1.ListChainsResponse.chains:type_name -> loki.v1.Chain
+	24, // 12: loki.v1.ExecuteChainRequest.trigger_data:type_name -> google.protobuf.Struct
+	24, // 13: loki.v1.ExecuteChainRequest.execution_options:type_name -> google.protobuf.Struct
+	23, // 14: loki.v1.ExecuteChainResponse.started_at:type_name -> google.protobuf.Timestamp
+	24, // 15: loki.v1.ExecuteChainResponse.plan:type_name -> google.protobuf.Struct
+	23, // 16: loki.v1.ChainRun.started_at:type_name -> google.protobuf.Timestamp
+	23, // 17: loki.v1.ChainRun.completed_at:type_name -> google.protobuf.Timestamp
+	24, // 18: loki.v1.ChainRun.details:type_name -> google.protobuf.Struct
+	17, // 19: loki.v1.ListChainRunsResponse.runs:type_name -> loki.v1.ChainRun
+	0,  // 20: loki.v1.EventService.PublishEvent:input_type -> loki.v1.PublishEventRequest
+	0,  // 21: loki.v1.EventService.PublishEventStream:input_type -> loki.v1.PublishEventRequest
+	4,  // 22: loki.v1.ChainService.CreateChain:input_type -> loki.v1.CreateChainRequest
+	6,  // 23: loki.v1.ChainService.GetChain:input_type -> loki.v1.GetChainRequest
+	8,  // 24: loki.v1.ChainService.ListChains:input_type -> loki.v1.ListChainsRequest
+	10, // 25: loki.v1.ChainService.UpdateChain:input_type -> loki.v1.UpdateChainRequest
+	12, // 26: loki.v1.ChainService.DeleteChain:input_type -> loki.v1.DeleteChainRequest
+	14, // 27: loki.v1.ChainService.ExecuteChain:input_type -> loki.v1.ExecuteChainRequest
+	16, // 28: loki.v1.ChainService.GetChainRun:input_type -> loki.v1.GetChainRunRequest
+	18, // 29: loki.v1.ChainService.ListChainRuns:input_type -> loki.v1.ListChainRunsRequest
+	20, // 30: loki.v1.ChainService.CancelChainRun:input_type -> loki.v1.CancelChainRunRequest
+	1,  // 31: loki.v1.EventService.PublishEvent:output_type -> loki.v1.PublishEventResponse
+	1,  // 32: loki.v1.EventService.PublishEventStream:output_type -> loki.v1.PublishEventResponse
+	5,  // 33: loki.v1.ChainService.CreateChain:output_type -> loki.v1.CreateChainResponse
+	7,  // 34: loki.v1.ChainService.GetChain:output_type -> loki.v1.Chain
+	9,  // 35: loki.v1.ChainService.ListChains:output_type -> loki.v1.ListChainsResponse
+	11, // 36: loki.v1.ChainService.UpdateChain:output_type -> loki.v1.UpdateChainResponse
+	13, // 37: loki.v1.ChainService.DeleteChain:output_type -> loki.v1.DeleteChainResponse
+	15, // 38: loki.v1.ChainService.ExecuteChain:output_type -> loki.v1.ExecuteChainResponse
+	17, // 39: loki.v1.ChainService.GetChainRun:output_type -> loki.v1.ChainRun
+	19, // 40: loki.v1.ChainService.ListChainRuns:output_type -> loki.v1.ListChainRunsResponse
+	21, // 41: loki.v1.ChainService.CancelChainRun:output_type -> loki.v1.ChainRunControlResponse
+	31, // [31:42] is the sub-list for method output_type
+	20, // [20:31] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_loki_v1_loki_proto_init() }
+func file_loki_v1_loki_proto_init() {
+	if File_loki_v1_loki_proto != nil {
+		return
+	}
+	file_loki_v1_loki_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_loki_v1_loki_proto_rawDesc), len(file_loki_v1_loki_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_loki_v1_loki_proto_goTypes,
+		DependencyIndexes: file_loki_v1_loki_proto_depIdxs,
+		MessageInfos:      file_loki_v1_loki_proto_msgTypes,
+	}.Build()
+	File_loki_v1_loki_proto = out.File
+	file_loki_v1_loki_proto_goTypes = nil
+	file_loki_v1_loki_proto_depIdxs = nil
+}
@@ -0,0 +1,585 @@
+// gRPC API of Loki Suite, served next to the REST API on LOKI_GRPC_PORT
+//
+// Calls authenticate like REST requests: send an API key as "x-api-key" metadata or a JWT as
+// "authorization: Bearer <jwt>". Every RPC requires the role of its REST counterpart.
+//
+// Regenerate pkg/lokiv1 with `make proto` after changing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: loki/v1/loki.proto
+
+package lokiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EventService_PublishEvent_FullMethodName       = "/loki.v1.EventService/PublishEvent"
+	EventService_PublishEventStream_FullMethodName = "/loki.v1.EventService/PublishEventStream"
+)
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EventService publishes events to the webhook subscriptions of a tenant, like POST /api/webhooks/event
+type EventServiceClient interface {
+	// PublishEvent publishes one event and returns once it was delivered, queued or scheduled
+	PublishEvent(ctx context.Context, in *PublishEventRequest, opts ...grpc.CallOption) (*PublishEventResponse, error)
+	// PublishEventStream publishes every event sent on the stream and answers each with a response
+	// carrying its request_id; events are processed concurrently, so responses may arrive out of order.
+	// Events that fail are answered with an error instead of ending the stream.
+	PublishEventStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PublishEventRequest, PublishEventResponse], error)
+}
+
+type eventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) PublishEvent(ctx context.Context, in *PublishEventRequest, opts ...grpc.CallOption) (*PublishEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishEventResponse)
+	err := c.cc.Invoke(ctx, EventService_PublishEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) PublishEventStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PublishEventRequest, PublishEventResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[0], EventService_PublishEventStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PublishEventRequest, PublishEventResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_PublishEventStreamClient = grpc.BidiStreamingClient[PublishEventRequest, PublishEventResponse]
+
+// EventServiceServer is the server API for EventService service.
+// All implementations must embed UnimplementedEventServiceServer
+// for forward compatibility.
+//
+// EventService publishes events to the webhook subscriptions of a tenant, like POST /api/webhooks/event
+type EventServiceServer interface {
+	// PublishEvent publishes one event and returns once it was delivered, queued or scheduled
+	PublishEvent(context.Context, *PublishEventRequest) (*PublishEventResponse, error)
+	// PublishEventStream publishes every event sent on the stream and answers each with a response
+	// carrying its request_id; events are processed concurrently, so responses may arrive out of order.
+	// Events that fail are answered with an error instead of ending the stream.
+	PublishEventStream(grpc.BidiStreamingServer[PublishEventRequest, PublishEventResponse]) error
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+// UnimplementedEventServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEventServiceServer struct{}
+
+func (UnimplementedEventServiceServer) PublishEvent(context.Context, *PublishEventRequest) (*PublishEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishEvent not implemented")
+}
+func (UnimplementedEventServiceServer) PublishEventStream(grpc.BidiStreamingServer[PublishEventRequest, PublishEventResponse]) error {
+	return status.Error(codes.Unimplemented, "method PublishEventStream not implemented")
+}
+func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
+func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServiceServer will
+// result in compilation errors.
+type UnsafeEventServiceServer interface {
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer) {
+	// If the following call panics, it indicates UnimplementedEventServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EventService_ServiceDesc, srv)
+}
+
+func _EventService_PublishEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).PublishEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_PublishEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).PublishEvent(ctx, req.(*PublishEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_PublishEventStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EventServiceServer).PublishEventStream(&grpc.GenericServerStream[PublishEventRequest, PublishEventResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_PublishEventStreamServer = grpc.BidiStreamingServer[PublishEventRequest, PublishEventResponse]
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "loki.v1.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublishEvent",
+			Handler:    _EventService_PublishEvent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PublishEventStream",
+			Handler:       _EventService_PublishEventStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "loki/v1/loki.proto",
+}
+
+const (
+	ChainService_CreateChain_FullMethodName    = "/loki.v1.ChainService/CreateChain"
+	ChainService_GetChain_FullMethodName       = "/loki.v1.ChainService/GetChain"
+	ChainService_ListChains_FullMethodName     = "/loki.v1.ChainService/ListChains"
+	ChainService_UpdateChain_FullMethodName    = "/loki.v1.ChainService/UpdateChain"
+	ChainService_DeleteChain_FullMethodName    = "/loki.v1.ChainService/DeleteChain"
+	ChainService_ExecuteChain_FullMethodName   = "/loki.v1.ChainService/ExecuteChain"
+	ChainService_GetChainRun_FullMethodName    = "/loki.v1.ChainService/GetChainRun"
+	ChainService_ListChainRuns_FullMethodName  = "/loki.v1.ChainService/ListChainRuns"
+	ChainService_CancelChainRun_FullMethodName = "/loki.v1.ChainService/CancelChainRun"
+)
+
+// ChainServiceClient is the client API for ChainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChainService manages execution chains and their runs, like the /api/execution-chains endpoints
+type ChainServiceClient interface {
+	CreateChain(ctx context.Context, in *CreateChainRequest, opts ...grpc.CallOption) (*CreateChainResponse, error)
+	GetChain(ctx context.Context, in *GetChainRequest, opts ...grpc.CallOption) (*Chain, error)
+	ListChains(ctx context.Context, in *ListChainsRequest, opts ...grpc.CallOption) (*ListChainsResponse, error)
+	UpdateChain(ctx context.Context, in *UpdateChainRequest, opts ...grpc.CallOption) (*UpdateChainResponse, error)
+	DeleteChain(ctx context.Context, in *DeleteChainRequest, opts ...grpc.CallOption) (*DeleteChainResponse, error)
+	// ExecuteChain starts a run; runs requesting a dry run or validation return the plan instead
+	ExecuteChain(ctx context.Context, in *ExecuteChainRequest, opts ...grpc.CallOption) (*ExecuteChainResponse, error)
+	GetChainRun(ctx context.Context, in *GetChainRunRequest, opts ...grpc.CallOption) (*ChainRun, error)
+	ListChainRuns(ctx context.Context, in *ListChainRunsRequest, opts ...grpc.CallOption) (*ListChainRunsResponse, error)
+	CancelChainRun(ctx context.Context, in *CancelChainRunRequest, opts ...grpc.CallOption) (*ChainRunControlResponse, error)
+}
+
+type chainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChainServiceClient(cc grpc.ClientConnInterface) ChainServiceClient {
+	return &chainServiceClient{cc}
+}
+
+func (c *chainServiceClient) CreateChain(ctx context.Context, in *CreateChainRequest, opts ...grpc.CallOption) (*CreateChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateChainResponse)
+	err := c.cc.Invoke(ctx, ChainService_CreateChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) GetChain(ctx context.Context, in *GetChainRequest, opts ...grpc.CallOption) (*Chain, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Chain)
+	err := c.cc.Invoke(ctx, ChainService_GetChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) ListChains(ctx context.Context, in *ListChainsRequest, opts ...grpc.CallOption) (*ListChainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChainsResponse)
+	err := c.cc.Invoke(ctx, ChainService_ListChains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) UpdateChain(ctx context.Context, in *UpdateChainRequest, opts ...grpc.CallOption) (*UpdateChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateChainResponse)
+	err := c.cc.Invoke(ctx, ChainService_UpdateChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) DeleteChain(ctx context.Context, in *DeleteChainRequest, opts ...grpc.CallOption) (*DeleteChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteChainResponse)
+	err := c.cc.Invoke(ctx, ChainService_DeleteChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) ExecuteChain(ctx context.Context, in *ExecuteChainRequest, opts ...grpc.CallOption) (*ExecuteChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteChainResponse)
+	err := c.cc.Invoke(ctx, ChainService_ExecuteChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) GetChainRun(ctx context.Context, in *GetChainRunRequest, opts ...grpc.CallOption) (*ChainRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChainRun)
+	err := c.cc.Invoke(ctx, ChainService_GetChainRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) ListChainRuns(ctx context.Context, in *ListChainRunsRequest, opts ...grpc.CallOption) (*ListChainRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChainRunsResponse)
+	err := c.cc.Invoke(ctx, ChainService_ListChainRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) CancelChainRun(ctx context.Context, in *CancelChainRunRequest, opts ...grpc.CallOption) (*ChainRunControlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChainRunControlResponse)
+	err := c.cc.Invoke(ctx, ChainService_CancelChainRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChainServiceServer is the server API for ChainService service.
+// All implementations must embed UnimplementedChainServiceServer
+// for forward compatibility.
+//
+// ChainService manages execution chains and their runs, like the /api/execution-chains endpoints
+type ChainServiceServer interface {
+	CreateChain(context.Context, *CreateChainRequest) (*CreateChainResponse, error)
+	GetChain(context.Context, *GetChainRequest) (*Chain, error)
+	ListChains(context.Context, *ListChainsRequest) (*ListChainsResponse, error)
+	UpdateChain(context.Context, *UpdateChainRequest) (*UpdateChainResponse, error)
+	DeleteChain(context.Context, *DeleteChainRequest) (*DeleteChainResponse, error)
+	// ExecuteChain starts a run; runs requesting a dry run or validation return the plan instead
+	ExecuteChain(context.Context, *ExecuteChainRequest) (*ExecuteChainResponse, error)
+	GetChainRun(context.Context, *GetChainRunRequest) (*ChainRun, error)
+	ListChainRuns(context.Context, *ListChainRunsRequest) (*ListChainRunsResponse, error)
+	CancelChainRun(context.Context, *CancelChainRunRequest) (*ChainRunControlResponse, error)
+	mustEmbedUnimplementedChainServiceServer()
+}
+
+// UnimplementedChainServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChainServiceServer struct{}
+
+func (UnimplementedChainServiceServer) CreateChain(context.Context, *CreateChainRequest) (*CreateChainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateChain not implemented")
+}
+func (UnimplementedChainServiceServer) GetChain(context.Context, *GetChainRequest) (*Chain, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChain not implemented")
+}
+func (UnimplementedChainServiceServer) ListChains(context.Context, *ListChainsRequest) (*ListChainsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListChains not implemented")
+}
+func (UnimplementedChainServiceServer) UpdateChain(context.Context, *UpdateChainRequest) (*UpdateChainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateChain not implemented")
+}
+func (UnimplementedChainServiceServer) DeleteChain(context.Context, *DeleteChainRequest) (*DeleteChainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteChain not implemented")
+}
+func (UnimplementedChainServiceServer) ExecuteChain(context.Context, *ExecuteChainRequest) (*ExecuteChainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExecuteChain not implemented")
+}
+func (UnimplementedChainServiceServer) GetChainRun(context.Context, *GetChainRunRequest) (*ChainRun, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChainRun not implemented")
+}
+func (UnimplementedChainServiceServer) ListChainRuns(context.Context, *ListChainRunsRequest) (*ListChainRunsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListChainRuns not implemented")
+}
+func (UnimplementedChainServiceServer) CancelChainRun(context.Context, *CancelChainRunRequest) (*ChainRunControlResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelChainRun not implemented")
+}
+func (UnimplementedChainServiceServer) mustEmbedUnimplementedChainServiceServer() {}
+func (UnimplementedChainServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeChainServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChainServiceServer will
+// result in compilation errors.
+type UnsafeChainServiceServer interface {
+	mustEmbedUnimplementedChainServiceServer()
+}
+
+func RegisterChainServiceServer(s grpc.ServiceRegistrar, srv ChainServiceServer) {
+	// If the following call panics, it indicates UnimplementedChainServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChainService_ServiceDesc, srv)
+}
+
+func _ChainService_CreateChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).CreateChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_CreateChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).CreateChain(ctx, req.(*CreateChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_GetChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).GetChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_GetChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).GetChain(ctx, req.(*GetChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_ListChains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).ListChains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_ListChains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).ListChains(ctx, req.(*ListChainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_UpdateChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).UpdateChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_UpdateChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).UpdateChain(ctx, req.(*UpdateChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_DeleteChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).DeleteChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_DeleteChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).DeleteChain(ctx, req.(*DeleteChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_ExecuteChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).ExecuteChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_ExecuteChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).ExecuteChain(ctx, req.(*ExecuteChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_GetChainRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChainRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).GetChainRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_GetChainRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).GetChainRun(ctx, req.(*GetChainRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_ListChainRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChainRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).ListChainRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_ListChainRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).ListChainRuns(ctx, req.(*ListChainRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_CancelChainRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelChainRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).CancelChainRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_CancelChainRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).CancelChainRun(ctx, req.(*CancelChainRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChainService_ServiceDesc is the grpc.ServiceDesc for ChainService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChainService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "loki.v1.ChainService",
+	HandlerType: (*ChainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateChain",
+			Handler:    _ChainService_CreateChain_Handler,
+		},
+		{
+			MethodName: "GetChain",
+			Handler:    _ChainService_GetChain_Handler,
+		},
+		{
+			MethodName: "ListChains",
+			Handler:    _ChainService_ListChains_Handler,
+		},
+		{
+			MethodName: "UpdateChain",
+			Handler:    _ChainService_UpdateChain_Handler,
+		},
+		{
+			MethodName: "DeleteChain",
+			Handler:    _ChainService_DeleteChain_Handler,
+		},
+		{
+			MethodName: "ExecuteChain",
+			Handler:    _ChainService_ExecuteChain_Handler,
+		},
+		{
+			MethodName: "GetChainRun",
+			Handler:    _ChainService_GetChainRun_Handler,
+		},
+		{
+			MethodName: "ListChainRuns",
+			Handler:    _ChainService_ListChainRuns_Handler,
+		},
+		{
+			MethodName: "CancelChainRun",
+			Handler:    _ChainService_CancelChainRun_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "loki/v1/loki.proto",
+}
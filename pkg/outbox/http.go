@@ -0,0 +1,81 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventPath is the Loki Suite API path events are posted to
+const EventPath = "/api/v1/webhooks/event"
+
+// HTTPPublisher posts events to the Loki Suite API
+// Responses meaning the API rejected the event itself (400 invalid, 404 unknown tenant, 413 too large,
+// 422 failing its schema) are permanent; others, including rejected credentials, suspended tenants (403)
+// and exceeded quotas or rate limits (429), are retried
+type HTTPPublisher struct {
+	// BaseURL is the URL of the Loki Suite API, e.g. https://loki.internal
+	BaseURL string
+
+	// APIKey is sent as X-API-Key; it needs a role allowed to send events
+	APIKey string
+
+	// Client sends the requests
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates a publisher posting to the API at baseURL with an API key
+func NewHTTPPublisher(baseURL, apiKey string) *HTTPPublisher {
+	return &HTTPPublisher{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		APIKey:  apiKey,
+		Client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Publish posts an event, sending its outbox ID as X-Request-ID so retries of it can be correlated
+func (p *HTTPPublisher) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"tenant_id": msg.TenantID,
+		"event":     msg.Event,
+		"source":    msg.Source,
+		"payload":   msg.Payload,
+	})
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal event: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+EventPath, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "outbox-"+strconv.FormatInt(msg.ID, 10))
+	if p.APIKey != "" {
+		req.Header.Set("X-API-Key", p.APIKey)
+	}
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(excerpt)))
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge,
+		http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return Permanent(err)
+	}
+	return err
+}
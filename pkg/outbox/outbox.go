@@ -0,0 +1,177 @@
+// Package outbox lets producer services publish events to Loki Suite without dual writes
+//
+// Services write events to an outbox table of their own database in the transaction that changes their
+// data, so an event is stored exactly when the change commits. A Relay ships stored events to Loki Suite
+// and marks them published, retrying failures with backoff; events are published at least once, so
+// subscribers must tolerate duplicates, e.g. by keying on the outbox ID sent as X-Request-ID.
+//
+//	box := outbox.New(db, outbox.Options{})
+//	tx, _ := db.BeginTx(ctx, nil)
+//	// ... change the service's own data in tx ...
+//	box.Write(ctx, tx, outbox.Event{TenantID: "acme", Event: "order.created", Source: "checkout", Payload: order})
+//	tx.Commit()
+//
+//	relay := outbox.NewRelay(box, outbox.NewHTTPPublisher("https://loki.internal", os.Getenv("LOKI_API_KEY")), outbox.RelayOptions{})
+//	go relay.Run(ctx)
+//
+// The table is created with the statements returned by Schema; several relays may share it, each claims
+// its batch with SELECT ... FOR UPDATE SKIP LOCKED.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultTable is the name of the outbox table when Options doesn't name one
+const DefaultTable = "loki_outbox"
+
+// Dialect selects the SQL flavour of the producer's database
+type Dialect int
+
+const (
+	// Postgres uses $n placeholders; it is the default
+	Postgres Dialect = iota
+
+	// MySQL uses ? placeholders and needs MySQL 8 for SKIP LOCKED
+	MySQL
+)
+
+// tableName restricts table names to identifiers, since they are formatted into statements
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// Options configures an outbox
+type Options struct {
+	// Table is the name of the outbox table, DefaultTable if empty
+	Table string
+
+	// Dialect is the SQL flavour of the database, Postgres by default
+	Dialect Dialect
+}
+
+// Event is an event to publish, as sent to POST /api/webhooks/event
+type Event struct {
+	// TenantID identifies the tenant the event belongs to
+	TenantID string
+
+	// Event is the name subscriptions match, e.g. "order.created"
+	Event string
+
+	// Source identifies the producing service
+	Source string
+
+	// Payload is the event data; it must marshal to JSON
+	Payload interface{}
+}
+
+// Execer executes statements; *sql.Tx and *sql.DB implement it
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Outbox stores events in a table of the producer's database
+type Outbox struct {
+	db      *sql.DB
+	table   string
+	dialect Dialect
+}
+
+// New creates an outbox over the producer's database; db is used by relays, writes use the caller's transaction
+func New(db *sql.DB, opts Options) *Outbox {
+	table := opts.Table
+	if table == "" {
+		table = DefaultTable
+	}
+	if !tableName.MatchString(table) {
+		panic(fmt.Sprintf("outbox: invalid table name %q", table))
+	}
+	return &Outbox{db: db, table: table, dialect: opts.Dialect}
+}
+
+// Schema returns the statements creating the outbox table and its index of pending events
+func (o *Outbox) Schema() string {
+	if o.dialect == MySQL {
+		return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    event VARCHAR(255) NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    payload JSON NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NOT NULL,
+    last_error TEXT,
+    published_at DATETIME(6),
+    failed_at DATETIME(6),
+    INDEX %[2]s_pending (published_at, failed_at, next_attempt_at)
+)`, o.table, strings.ReplaceAll(o.table, ".", "_"))
+	}
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+    id bigserial PRIMARY KEY,
+    tenant_id text NOT NULL,
+    event text NOT NULL,
+    source text NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamptz NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error text,
+    published_at timestamptz,
+    failed_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS %[2]s_pending ON %[1]s (next_attempt_at) WHERE published_at IS NULL AND failed_at IS NULL`,
+		o.table, strings.ReplaceAll(o.table, ".", "_"))
+}
+
+// Write stores events in the outbox; pass the transaction changing the data the events are about
+func (o *Outbox) Write(ctx context.Context, tx Execer, events ...Event) error {
+	now := time.Now().UTC()
+	query := o.query("INSERT INTO %s (tenant_id, event, source, payload, created_at, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?)")
+	for _, event := range events {
+		if event.TenantID == "" || event.Event == "" || event.Source == "" {
+			return fmt.Errorf("outbox: tenant ID, event and source are required")
+		}
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return fmt.Errorf("outbox: failed to marshal payload of %s: %w", event.Event, err)
+		}
+		if _, err := tx.ExecContext(ctx, query, event.TenantID, event.Event, event.Source, string(payload), now, now); err != nil {
+			return fmt.Errorf("outbox: failed to write %s: %w", event.Event, err)
+		}
+	}
+	return nil
+}
+
+// DeletePublished deletes events published before the given time and returns how many were deleted
+// Failed events are kept for inspection
+func (o *Outbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := o.db.ExecContext(ctx, o.query("DELETE FROM %s WHERE published_at < ?"), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("outbox: failed to delete published events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// query formats the table into a statement written with ? placeholders and numbers them for Postgres
+func (o *Outbox) query(format string) string {
+	query := fmt.Sprintf(format, o.table)
+	if o.dialect != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDB is a database/sql driver recording statements and answering queries with preset rows
+type fakeDB struct {
+	mu        sync.Mutex
+	execs     []fakeExec
+	rows      [][]driver.Value
+	committed bool
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+var (
+	fakeDBs      sync.Map
+	registerOnce sync.Once
+)
+
+func openFakeDB(t *testing.T, rows ...[]driver.Value) (*sql.DB, *fakeDB) {
+	registerOnce.Do(func() { sql.Register("outbox-fake", fakeDriver{}) })
+	fake := &fakeDB{rows: rows}
+	fakeDBs.Store(t.Name(), fake)
+	db, err := sql.Open("outbox-fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, _ := fakeDBs.Load(name)
+	return &fakeConn{db: fake.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeConn) Commit() error             { c.db.committed = true; return nil }
+func (c *fakeConn) Rollback() error           { return nil }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.execs = append(s.db.execs, fakeExec{query: s.query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.db.rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"id", "tenant_id", "event", "source", "payload", "created_at", "attempts"}
+}
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// publisherFunc adapts a function to Publisher
+type publisherFunc func(ctx context.Context, msg Message) error
+
+func (f publisherFunc) Publish(ctx context.Context, msg Message) error { return f(ctx, msg) }
+
+// TestWrite tests that events are inserted with numbered placeholders for Postgres and ? for MySQL
+func TestWrite(t *testing.T) {
+	db, fake := openFakeDB(t)
+
+	err := New(db, Options{}).Write(context.Background(), db, Event{TenantID: "acme", Event: "order.created", Source: "checkout", Payload: map[string]string{"id": "1"}})
+	assert.NoError(t, err)
+	err = New(db, Options{Table: "events_outbox", Dialect: MySQL}).Write(context.Background(), db, Event{TenantID: "acme", Event: "order.paid", Source: "checkout"})
+	assert.NoError(t, err)
+
+	assert.Len(t, fake.execs, 2)
+	assert.Contains(t, fake.execs[0].query, "INSERT INTO loki_outbox")
+	assert.Contains(t, fake.execs[0].query, "VALUES ($1, $2, $3, $4, $5, $6)")
+	assert.Equal(t, `{"id":"1"}`, fake.execs[0].args[3])
+	assert.Contains(t, fake.execs[1].query, "INSERT INTO events_outbox")
+	assert.Contains(t, fake.execs[1].query, "VALUES (?, ?, ?, ?, ?, ?)")
+
+	err = New(db, Options{}).Write(context.Background(), db, Event{TenantID: "acme", Event: "order.created"})
+	assert.ErrorContains(t, err, "source are required")
+}
+
+// TestRelayOnce tests that published events are marked published, failed ones rescheduled with backoff and
+// permanent failures marked failed
+func TestRelayOnce(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	db, fake := openFakeDB(t,
+		[]driver.Value{int64(1), "acme", "order.created", "checkout", []byte(`{}`), created, int64(0)},
+		[]driver.Value{int64(2), "acme", "order.paid", "checkout", []byte(`{}`), created, int64(2)},
+		[]driver.Value{int64(3), "acme", "order.bad", "checkout", []byte(`{}`), created, int64(0)},
+	)
+	var published []int64
+	publisher := publisherFunc(func(_ context.Context, msg Message) error {
+		published = append(published, msg.ID)
+		switch msg.Event {
+		case "order.paid":
+			return errors.New("connection refused")
+		case "order.bad":
+			return Permanent(errors.New("API returned status 400"))
+		}
+		return nil
+	})
+	now := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	relay := NewRelay(New(db, Options{}), publisher, RelayOptions{MinBackoff: time.Second})
+	relay.now = func() time.Time { return now }
+
+	handled, err := relay.RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, handled)
+	assert.Equal(t, []int64{1, 2, 3}, published)
+	assert.True(t, fake.committed)
+	assert.Len(t, fake.execs, 3)
+	assert.True(t, strings.HasPrefix(fake.execs[0].query, "UPDATE loki_outbox SET attempts = $1, published_at"))
+	assert.Equal(t, []driver.Value{int64(1), now, int64(1)}, fake.execs[0].args)
+	assert.Contains(t, fake.execs[1].query, "next_attempt_at")
+	assert.Equal(t, now.Add(4*time.Second), fake.execs[1].args[1])
+	assert.Contains(t, fake.execs[2].query, "failed_at")
+}
+
+// TestHTTPPublisher tests that events are posted with the API key and that rejected events are permanent failures
+func TestHTTPPublisher(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, EventPath, r.URL.Path)
+		assert.Equal(t, "key-1", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "outbox-7", r.Header.Get("X-Request-ID"))
+		assert.JSONEq(t, `{"tenant_id":"acme","event":"order.created","source":"checkout","payload":{"id":"1"}}`, string(body))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	publisher := NewHTTPPublisher(server.URL+"/", "key-1")
+	msg := Message{ID: 7, TenantID: "acme", Event: "order.created", Source: "checkout", Payload: []byte(`{"id":"1"}`)}
+
+	assert.NoError(t, publisher.Publish(context.Background(), msg))
+
+	status = http.StatusUnprocessableEntity
+	err := publisher.Publish(context.Background(), msg)
+	assert.True(t, IsPermanent(err))
+
+	status = http.StatusTooManyRequests
+	err = publisher.Publish(context.Background(), msg)
+	assert.Error(t, err)
+	assert.False(t, IsPermanent(err))
+}
+
+// TestBackoff tests that the retry delay doubles per attempt up to the maximum
+func TestBackoff(t *testing.T) {
+	relay := NewRelay(New(nil, Options{}), nil, RelayOptions{MinBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(10))
+}
@@ -0,0 +1,210 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Relay defaults
+const (
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Second
+	DefaultMaxAttempts  = 20
+	DefaultMinBackoff   = time.Second
+	DefaultMaxBackoff   = 10 * time.Minute
+)
+
+// maxErrorLength bounds the error stored with an event
+const maxErrorLength = 1024
+
+// Message is a stored event handed to a Publisher
+type Message struct {
+	// ID is the outbox ID of the event, the same for every attempt
+	ID int64
+
+	TenantID  string
+	Event     string
+	Source    string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+
+	// Attempts is how many earlier attempts to publish the event failed
+	Attempts int
+}
+
+// Publisher ships an event to Loki Suite
+// Errors wrapped with Permanent are not retried; the event is marked failed
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// permanentError marks a failure retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a publishing failure retrying cannot fix, e.g. an event the API rejects as invalid
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether a publishing failure was marked with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// RelayOptions configures a relay
+type RelayOptions struct {
+	// BatchSize is how many due events a pass claims, DefaultBatchSize if 0
+	BatchSize int
+
+	// PollInterval is the pause between passes that found no events, DefaultPollInterval if 0
+	PollInterval time.Duration
+
+	// MaxAttempts is how many attempts an event gets before it is marked failed, DefaultMaxAttempts if 0
+	MaxAttempts int
+
+	// MinBackoff and MaxBackoff bound the delay before retrying, which doubles with every failed attempt
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// OnError is called with errors of passes and failed attempts; errors are dropped if nil
+	OnError func(err error)
+}
+
+// Relay ships events from the outbox to Loki Suite
+type Relay struct {
+	outbox    *Outbox
+	publisher Publisher
+	opts      RelayOptions
+	now       func() time.Time
+}
+
+// NewRelay creates a relay publishing the events of an outbox
+func NewRelay(outbox *Outbox, publisher Publisher, opts RelayOptions) *Relay {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(DefaultMaxBackoff, opts.MinBackoff)
+	}
+	return &Relay{outbox: outbox, publisher: publisher, opts: opts, now: time.Now}
+}
+
+// Run publishes due events until ctx is cancelled, pausing for the poll interval whenever none are due
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		published, err := r.RelayOnce(ctx)
+		if err != nil {
+			r.report(err)
+		}
+		if published == r.opts.BatchSize && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.opts.PollInterval):
+		}
+	}
+}
+
+// RelayOnce claims a batch of due events, publishes them in order and records the outcomes in one
+// transaction, returning how many events it handled
+// Events claimed by another relay are skipped, so relays of several instances share the outbox
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	tx, err := r.outbox.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := r.now().UTC()
+	rows, err := tx.QueryContext(ctx, r.outbox.query(
+		"SELECT id, tenant_id, event, source, payload, created_at, attempts FROM %s "+
+			"WHERE published_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ? "+
+			"ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED"), now, r.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: failed to claim events: %w", err)
+	}
+	var messages []Message
+	for rows.Next() {
+		var msg Message
+		var payload []byte
+		if err := rows.Scan(&msg.ID, &msg.TenantID, &msg.Event, &msg.Source, &payload, &msg.CreatedAt, &msg.Attempts); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("outbox: failed to read event: %w", err)
+		}
+		msg.Payload = payload
+		messages = append(messages, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("outbox: failed to claim events: %w", err)
+	}
+
+	published := r.outbox.query("UPDATE %s SET attempts = ?, published_at = ?, last_error = NULL WHERE id = ?")
+	retried := r.outbox.query("UPDATE %s SET attempts = ?, next_attempt_at = ?, last_error = ? WHERE id = ?")
+	failed := r.outbox.query("UPDATE %s SET attempts = ?, failed_at = ?, last_error = ? WHERE id = ?")
+	for _, msg := range messages {
+		err := r.publisher.Publish(ctx, msg)
+		attempts := msg.Attempts + 1
+		done := r.now().UTC()
+		switch {
+		case err == nil:
+			_, err = tx.ExecContext(ctx, published, attempts, done, msg.ID)
+		case IsPermanent(err) || attempts >= r.opts.MaxAttempts:
+			r.report(fmt.Errorf("outbox: giving up on event %d (%s) after %d attempts: %w", msg.ID, msg.Event, attempts, err))
+			_, err = tx.ExecContext(ctx, failed, attempts, done, truncate(err.Error()), msg.ID)
+		default:
+			r.report(fmt.Errorf("outbox: failed to publish event %d (%s): %w", msg.ID, msg.Event, err))
+			_, err = tx.ExecContext(ctx, retried, attempts, done.Add(r.backoff(attempts)), truncate(err.Error()), msg.ID)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("outbox: failed to record outcome of event %d: %w", msg.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("outbox: failed to commit: %w", err)
+	}
+	return len(messages), nil
+}
+
+// backoff returns the delay before the attempt following the given number of failed attempts
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.opts.MinBackoff
+	for i := 1; i < attempts && delay < r.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.opts.MaxBackoff)
+}
+
+func (r *Relay) report(err error) {
+	if r.opts.OnError != nil {
+		r.opts.OnError(err)
+	}
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}